@@ -41,7 +41,9 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 		if dst.Spec.Topology == nil {
 			dst.Spec.Topology = &clusterv1.Topology{}
 		}
+		dst.Spec.Topology.ClassNamespace = restored.Spec.Topology.ClassNamespace
 		dst.Spec.Topology.Variables = restored.Spec.Topology.Variables
+		dst.Spec.Topology.NodeVolumeDetachTimeout = restored.Spec.Topology.NodeVolumeDetachTimeout
 
 		if restored.Spec.Topology.ControlPlane.MachineHealthCheck != nil {
 			dst.Spec.Topology.ControlPlane.MachineHealthCheck = restored.Spec.Topology.ControlPlane.MachineHealthCheck
@@ -69,15 +71,28 @@ func (src *Cluster) ConvertTo(dstRaw conversion.Hub) error {
 				dst.Spec.Topology.Workers.MachineDeployments[i].NodeDrainTimeout = restored.Spec.Topology.Workers.MachineDeployments[i].NodeDrainTimeout
 				dst.Spec.Topology.Workers.MachineDeployments[i].NodeVolumeDetachTimeout = restored.Spec.Topology.Workers.MachineDeployments[i].NodeVolumeDetachTimeout
 				dst.Spec.Topology.Workers.MachineDeployments[i].NodeDeletionTimeout = restored.Spec.Topology.Workers.MachineDeployments[i].NodeDeletionTimeout
+				dst.Spec.Topology.Workers.MachineDeployments[i].Taints = restored.Spec.Topology.Workers.MachineDeployments[i].Taints
 				dst.Spec.Topology.Workers.MachineDeployments[i].MinReadySeconds = restored.Spec.Topology.Workers.MachineDeployments[i].MinReadySeconds
 				dst.Spec.Topology.Workers.MachineDeployments[i].Strategy = restored.Spec.Topology.Workers.MachineDeployments[i].Strategy
 				dst.Spec.Topology.Workers.MachineDeployments[i].MachineHealthCheck = restored.Spec.Topology.Workers.MachineDeployments[i].MachineHealthCheck
+				dst.Spec.Topology.Workers.MachineDeployments[i].TemplateOverrides = restored.Spec.Topology.Workers.MachineDeployments[i].TemplateOverrides
 			}
 
 			dst.Spec.Topology.Workers.MachinePools = restored.Spec.Topology.Workers.MachinePools
 		}
 	}
 
+	dst.Status.FailureDomainMachines = restored.Status.FailureDomainMachines
+
+	for id, restoredFailureDomain := range restored.Status.FailureDomains {
+		if failureDomain, ok := dst.Status.FailureDomains[id]; ok {
+			failureDomain.Region = restoredFailureDomain.Region
+			failureDomain.Zone = restoredFailureDomain.Zone
+			failureDomain.Weight = restoredFailureDomain.Weight
+			dst.Status.FailureDomains[id] = failureDomain
+		}
+	}
+
 	return nil
 }
 
@@ -139,6 +154,8 @@ func (src *ClusterClass) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Workers.MachineDeployments[i].NodeDeletionTimeout = restored.Spec.Workers.MachineDeployments[i].NodeDeletionTimeout
 		dst.Spec.Workers.MachineDeployments[i].MinReadySeconds = restored.Spec.Workers.MachineDeployments[i].MinReadySeconds
 		dst.Spec.Workers.MachineDeployments[i].Strategy = restored.Spec.Workers.MachineDeployments[i].Strategy
+		dst.Spec.Workers.MachineDeployments[i].AutoscalerCapacity = restored.Spec.Workers.MachineDeployments[i].AutoscalerCapacity
+		dst.Spec.Workers.MachineDeployments[i].TemplateOverrides = restored.Spec.Workers.MachineDeployments[i].TemplateOverrides
 	}
 
 	dst.Status = restored.Status
@@ -188,7 +205,10 @@ func (src *Machine) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.NodeDeletionTimeout = restored.Spec.NodeDeletionTimeout
 	dst.Status.CertificatesExpiryDate = restored.Status.CertificatesExpiryDate
+	dst.Status.DeletionHooks = restored.Status.DeletionHooks
+	dst.Status.Timeline = restored.Status.Timeline
 	dst.Spec.NodeVolumeDetachTimeout = restored.Spec.NodeVolumeDetachTimeout
+	dst.Spec.Taints = restored.Spec.Taints
 	return nil
 }
 
@@ -234,6 +254,8 @@ func (src *MachineSet) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.Spec.NodeDeletionTimeout = restored.Spec.Template.Spec.NodeDeletionTimeout
 	dst.Spec.Template.Spec.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.NodeVolumeDetachTimeout
+	dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
+	dst.Spec.FailureDomainRebalance = restored.Spec.FailureDomainRebalance
 	return nil
 }
 
@@ -275,7 +297,9 @@ func (src *MachineDeployment) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.Spec.NodeDeletionTimeout = restored.Spec.Template.Spec.NodeDeletionTimeout
 	dst.Spec.Template.Spec.NodeVolumeDetachTimeout = restored.Spec.Template.Spec.NodeVolumeDetachTimeout
+	dst.Spec.Template.Spec.Taints = restored.Spec.Template.Spec.Taints
 	dst.Spec.RolloutAfter = restored.Spec.RolloutAfter
+	dst.Spec.FailureDomainRebalance = restored.Spec.FailureDomainRebalance
 	return nil
 }
 
@@ -305,13 +329,30 @@ func (dst *MachineDeploymentList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *MachineHealthCheck) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*clusterv1.MachineHealthCheck)
 
-	return Convert_v1alpha4_MachineHealthCheck_To_v1beta1_MachineHealthCheck(src, dst, nil)
+	if err := Convert_v1alpha4_MachineHealthCheck_To_v1beta1_MachineHealthCheck(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &clusterv1.MachineHealthCheck{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.InfrastructureStartupTimeout = restored.Spec.InfrastructureStartupTimeout
+	dst.Spec.RemediationSuppressionWindows = restored.Spec.RemediationSuppressionWindows
+	return nil
 }
 
 func (dst *MachineHealthCheck) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*clusterv1.MachineHealthCheck)
 
-	return Convert_v1beta1_MachineHealthCheck_To_v1alpha4_MachineHealthCheck(src, dst, nil)
+	if err := Convert_v1beta1_MachineHealthCheck_To_v1alpha4_MachineHealthCheck(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Preserve Hub data on down-conversion except for metadata
+	return utilconversion.MarshalData(src, dst)
 }
 
 func (src *MachineHealthCheckList) ConvertTo(dstRaw conversion.Hub) error {
@@ -338,15 +379,22 @@ func Convert_v1beta1_ClusterClassSpec_To_v1alpha4_ClusterClassSpec(in *clusterv1
 
 func Convert_v1beta1_MachineSpec_To_v1alpha4_MachineSpec(in *clusterv1.MachineSpec, out *MachineSpec, s apiconversion.Scope) error {
 	// spec.nodeDeletionTimeout has been added with v1beta1.
+	// spec.taints has been added with v1beta1.
 	return autoConvert_v1beta1_MachineSpec_To_v1alpha4_MachineSpec(in, out, s)
 }
 
+func Convert_v1beta1_MachineSetSpec_To_v1alpha4_MachineSetSpec(in *clusterv1.MachineSetSpec, out *MachineSetSpec, s apiconversion.Scope) error {
+	// MachineSetSpec.FailureDomainRebalance has been added in v1beta1.
+	return autoConvert_v1beta1_MachineSetSpec_To_v1alpha4_MachineSetSpec(in, out, s)
+}
+
 func Convert_v1beta1_MachineDeploymentSpec_To_v1alpha4_MachineDeploymentSpec(in *clusterv1.MachineDeploymentSpec, out *MachineDeploymentSpec, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_MachineDeploymentSpec_To_v1alpha4_MachineDeploymentSpec(in, out, s)
 }
 
 func Convert_v1beta1_Topology_To_v1alpha4_Topology(in *clusterv1.Topology, out *Topology, s apiconversion.Scope) error {
 	// spec.topology.variables has been added with v1beta1.
+	// spec.topology.nodeVolumeDetachTimeout has been added with v1beta1.
 	return autoConvert_v1beta1_Topology_To_v1alpha4_Topology(in, out, s)
 }
 
@@ -371,11 +419,21 @@ func Convert_v1beta1_ControlPlaneTopology_To_v1alpha4_ControlPlaneTopology(in *c
 	return autoConvert_v1beta1_ControlPlaneTopology_To_v1alpha4_ControlPlaneTopology(in, out, s)
 }
 
+func Convert_v1beta1_ClusterStatus_To_v1alpha4_ClusterStatus(in *clusterv1.ClusterStatus, out *ClusterStatus, s apiconversion.Scope) error {
+	// ClusterStatus.FailureDomainMachines has been added in v1beta1.
+	return autoConvert_v1beta1_ClusterStatus_To_v1alpha4_ClusterStatus(in, out, s)
+}
+
 func Convert_v1beta1_MachineStatus_To_v1alpha4_MachineStatus(in *clusterv1.MachineStatus, out *MachineStatus, s apiconversion.Scope) error {
-	// MachineStatus.CertificatesExpiryDate has been added in v1beta1.
+	// MachineStatus.CertificatesExpiryDate, MachineStatus.DeletionHooks and MachineStatus.Timeline have been added in v1beta1.
 	return autoConvert_v1beta1_MachineStatus_To_v1alpha4_MachineStatus(in, out, s)
 }
 
+func Convert_v1beta1_MachineHealthCheckSpec_To_v1alpha4_MachineHealthCheckSpec(in *clusterv1.MachineHealthCheckSpec, out *MachineHealthCheckSpec, s apiconversion.Scope) error {
+	// MachineHealthCheckSpec.InfrastructureStartupTimeout and MachineHealthCheckSpec.RemediationSuppressionWindows have been added in v1beta1.
+	return autoConvert_v1beta1_MachineHealthCheckSpec_To_v1alpha4_MachineHealthCheckSpec(in, out, s)
+}
+
 func Convert_v1beta1_ClusterClass_To_v1alpha4_ClusterClass(in *clusterv1.ClusterClass, out *ClusterClass, s apiconversion.Scope) error {
 	// ClusterClass.Status has been added in v1beta1.
 	return autoConvert_v1beta1_ClusterClass_To_v1alpha4_ClusterClass(in, out, s)
@@ -390,3 +448,8 @@ func Convert_v1beta1_WorkersTopology_To_v1alpha4_WorkersTopology(in *clusterv1.W
 	// WorkersTopology.MachinePools has been added in v1beta1.
 	return autoConvert_v1beta1_WorkersTopology_To_v1alpha4_WorkersTopology(in, out, s)
 }
+
+func Convert_v1beta1_FailureDomainSpec_To_v1alpha4_FailureDomainSpec(in *clusterv1.FailureDomainSpec, out *FailureDomainSpec, s apiconversion.Scope) error {
+	// FailureDomainSpec.Region, FailureDomainSpec.Zone and FailureDomainSpec.Weight have been added in v1beta1.
+	return autoConvert_v1beta1_FailureDomainSpec_To_v1alpha4_FailureDomainSpec(in, out, s)
+}
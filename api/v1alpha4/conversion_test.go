@@ -81,6 +81,7 @@ func MachineStatusFuzzer(in *MachineStatus, c fuzz.Continue) {
 func ClusterJSONFuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 	return []interface{}{
 		ClusterVariableFuzzer,
+		TemplateOverrideFuzzer,
 	}
 }
 
@@ -91,6 +92,13 @@ func ClusterVariableFuzzer(in *clusterv1.ClusterVariable, c fuzz.Continue) {
 	in.Value = apiextensionsv1.JSON{Raw: []byte("\"test-string\"")}
 }
 
+func TemplateOverrideFuzzer(in *clusterv1.TemplateOverride, c fuzz.Continue) {
+	c.FuzzNoCustom(in)
+
+	// Not every random byte array is valid JSON, e.g. a string without `""`,so we're setting a valid value.
+	in.Value = apiextensionsv1.JSON{Raw: []byte("\"test-string\"")}
+}
+
 func ClusterClassJSONFuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 	return []interface{}{
 		JSONPatchFuzzer,
@@ -25,6 +25,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/utils/pointer"
 
 	capierrors "sigs.k8s.io/cluster-api/errors"
@@ -78,6 +79,16 @@ type Topology struct {
 	// The name of the ClusterClass object to create the topology.
 	Class string `json:"class"`
 
+	// ClassNamespace is the namespace of the ClusterClass object to create the topology.
+	// If empty or not set, the Cluster namespace is used.
+	// A ClusterClass in another namespace can be used only if it is exported to the Cluster namespace
+	// with the topology.cluster.x-k8s.io/export-to-namespaces annotation.
+	// +optional
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern="^[a-z0-9](?:[-a-z0-9]*[a-z0-9])?$"
+	ClassNamespace string `json:"classNamespace,omitempty"`
+
 	// The Kubernetes version of the cluster.
 	Version string `json:"version"`
 
@@ -98,6 +109,13 @@ type Topology struct {
 	// +optional
 	Workers *WorkersTopology `json:"workers,omitempty"`
 
+	// NodeVolumeDetachTimeout is the total amount of time that the controller will spend on waiting for all volumes
+	// to be detached from the Nodes of the Cluster. It overrides the values defined in the ClusterClass for the control
+	// plane, the MachineDeployments and the MachinePools of the Cluster.
+	// NOTE: This value can be overridden for the control plane, a MachineDeployment or a MachinePool in their topology.
+	// +optional
+	NodeVolumeDetachTimeout *metav1.Duration `json:"nodeVolumeDetachTimeout,omitempty"`
+
 	// Variables can be used to customize the Cluster through
 	// patches. They must comply to the corresponding
 	// VariableClasses defined in the ClusterClass.
@@ -208,6 +226,10 @@ type MachineDeploymentTopology struct {
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
 
+	// Taints are the taints applied to the Nodes of the Machines of this MachineDeployment.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+
 	// Minimum number of seconds for which a newly created machine should
 	// be ready.
 	// Defaults to 0 (machine will be considered available as soon as it
@@ -223,6 +245,25 @@ type MachineDeploymentTopology struct {
 	// Variables can be used to customize the MachineDeployment through patches.
 	// +optional
 	Variables *MachineDeploymentVariables `json:"variables,omitempty"`
+
+	// TemplateOverrides are the values of the template fields defined in the templateOverrides of the
+	// MachineDeploymentClass, which are set on the templates of this MachineDeployment.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	TemplateOverrides []TemplateOverride `json:"templateOverrides,omitempty"`
+}
+
+// TemplateOverride defines the value of a template field defined in a TemplateOverrideClass.
+type TemplateOverride struct {
+	// Name of the TemplateOverrideClass.
+	Name string `json:"name"`
+
+	// Value of the field.
+	// Note: We have to use apiextensionsv1.JSON instead of a custom JSON type, because controller-tools has a
+	// hard-coded schema for apiextensionsv1.JSON which cannot be produced by another type via controller-tools,
+	// i.e. it is not possible to have no type field.
+	Value apiextensionsv1.JSON `json:"value"`
 }
 
 // MachineHealthCheckTopology defines a MachineHealthCheck for a group of machines.
@@ -420,6 +461,15 @@ type ClusterStatus struct {
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
 
+	// FailureDomainMachines reports, for each failure domain, the number of Machines of the Cluster
+	// placed in it, including both control plane and worker Machines. The list is sorted by failure domain name.
+	// Machines without a failure domain are not reported.
+	// This allows to compute the distribution of the Machines across failure domains without listing them.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FailureDomainMachines []FailureDomainMachinesStatus `json:"failureDomainMachines,omitempty"`
+
 	// ObservedGeneration is the latest generation observed by the controller.
 	// +optional
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
@@ -427,6 +477,31 @@ type ClusterStatus struct {
 
 // ANCHOR_END: ClusterStatus
 
+// FailureDomainMachinesStatus reports the number of Machines of a Cluster placed in a failure domain.
+type FailureDomainMachinesStatus struct {
+	// Name is the name of the failure domain.
+	Name string `json:"name"`
+
+	// DesiredMachines is the number of Machines in the failure domain which are not being deleted.
+	// +optional
+	DesiredMachines int32 `json:"desiredMachines"`
+
+	// ReadyMachines is the number of Machines in the failure domain with the Ready condition set to true.
+	// +optional
+	ReadyMachines int32 `json:"readyMachines"`
+
+	// AvailableMachines is the number of ready Machines in the failure domain with a healthy Node,
+	// i.e. with the NodeHealthy condition set to true.
+	// +optional
+	AvailableMachines int32 `json:"availableMachines"`
+
+	// UpToDateMachines is the number of Machines in the failure domain which are not being deleted and
+	// don't require a rollout, i.e. Machines belonging to the current revision of their MachineDeployment,
+	// or control plane Machines with the same version as the control plane.
+	// +optional
+	UpToDateMachines int32 `json:"upToDateMachines"`
+}
+
 // SetTypedPhase sets the Phase field to the string representation of ClusterPhase.
 func (c *ClusterStatus) SetTypedPhase(p ClusterPhase) {
 	c.Phase = string(p)
@@ -478,6 +553,7 @@ func (v APIEndpoint) String() string {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=clusters,shortName=cl,scope=Namespaced,categories=cluster-api
+// +kubebuilder:metadata:labels="clusterctl.cluster.x-k8s.io/paused-condition=true"
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="ClusterClass",type="string",JSONPath=".spec.topology.class",description="ClusterClass of this Cluster, empty if the Cluster is not using a ClusterClass"
@@ -494,6 +570,19 @@ type Cluster struct {
 	Status ClusterStatus `json:"status,omitempty"`
 }
 
+// GetClassKey returns the namespaced name of the ClusterClass used by the Cluster;
+// it returns an empty key if the Cluster does not use a managed topology.
+func (c *Cluster) GetClassKey() types.NamespacedName {
+	if c.Spec.Topology == nil {
+		return types.NamespacedName{}
+	}
+	namespace := c.Spec.Topology.ClassNamespace
+	if namespace == "" {
+		namespace = c.Namespace
+	}
+	return types.NamespacedName{Namespace: namespace, Name: c.Spec.Topology.Class}
+}
+
 // GetConditions returns the set of conditions for this object.
 func (c *Cluster) GetConditions() Conditions {
 	return c.Status.Conditions
@@ -634,6 +723,22 @@ type FailureDomainSpec struct {
 	// +optional
 	ControlPlane bool `json:"controlPlane,omitempty"`
 
+	// Region is the name of the region of this failure domain, if any.
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Zone is the name of the zone of this failure domain, if any.
+	// +optional
+	Zone string `json:"zone,omitempty"`
+
+	// Weight is the relative weight of this failure domain when spreading Machines across failure domains,
+	// e.g. to express that a failure domain has more capacity than the others; Machines are spread so that the
+	// number of Machines in each failure domain is proportional to its weight. Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	Weight *int32 `json:"weight,omitempty"`
+
 	// Attributes is a free form map of attributes an infrastructure provider might use or require.
 	// +optional
 	Attributes map[string]string `json:"attributes,omitempty"`
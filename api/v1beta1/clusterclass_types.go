@@ -21,6 +21,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 )
@@ -214,6 +215,90 @@ type MachineDeploymentClass struct {
 	// new ones.
 	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachineDeploymentClass.
 	Strategy *MachineDeploymentStrategy `json:"strategy,omitempty"`
+
+	// AutoscalerCapacity defines the capacity of the nodes of the MachineDeployments using this class;
+	// it is rendered as annotations on the MachineDeployments to allow the cluster-autoscaler to scale them from zero.
+	// +optional
+	AutoscalerCapacity *AutoscalerCapacity `json:"autoscalerCapacity,omitempty"`
+
+	// TemplateOverrides defines the fields of the bootstrap and infrastructure templates of this class
+	// which can be set for each MachineDeployment in the Cluster topology, without defining patches.
+	// NOTE: The values from the Cluster topology are set when the templates are cloned, and patches are applied afterwards.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	TemplateOverrides []TemplateOverrideClass `json:"templateOverrides,omitempty"`
+}
+
+// TemplateOverrideTarget is the template a TemplateOverrideClass applies to.
+// +kubebuilder:validation:Enum=Bootstrap;Infrastructure
+type TemplateOverrideTarget string
+
+const (
+	// BootstrapTemplateOverrideTarget targets the bootstrap template of a MachineDeploymentClass.
+	BootstrapTemplateOverrideTarget TemplateOverrideTarget = "Bootstrap"
+
+	// InfrastructureTemplateOverrideTarget targets the infrastructure template of a MachineDeploymentClass.
+	InfrastructureTemplateOverrideTarget TemplateOverrideTarget = "Infrastructure"
+)
+
+// TemplateOverrideClass defines a field of a template which can be set in the Cluster topology.
+type TemplateOverrideClass struct {
+	// Name of the override. It must be unique within the class and it is used to set
+	// the value of the field in the Cluster topology.
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Template is the template the field belongs to, either Bootstrap or Infrastructure.
+	Template TemplateOverrideTarget `json:"template"`
+
+	// Path is the path of the field in the template, in the JSON Pointer format (RFC 6901),
+	// e.g. "/spec/template/spec/instanceType". The path must start with "/spec/" and can't
+	// refer to items of lists.
+	Path string `json:"path"`
+}
+
+// AutoscalerCapacity defines the capacity of the nodes of a node group, which is used by the cluster-autoscaler
+// to scale the node group from zero, when there are no nodes to infer the capacity from.
+type AutoscalerCapacity struct {
+	// CPU is the number of CPUs of the nodes.
+	// +optional
+	CPU *resource.Quantity `json:"cpu,omitempty"`
+
+	// Memory is the amount of memory of the nodes.
+	// +optional
+	Memory *resource.Quantity `json:"memory,omitempty"`
+
+	// EphemeralDisk is the amount of ephemeral disk of the nodes.
+	// +optional
+	EphemeralDisk *resource.Quantity `json:"ephemeralDisk,omitempty"`
+
+	// MaxPods is the maximum number of pods that can be scheduled on the nodes.
+	// +optional
+	MaxPods *resource.Quantity `json:"maxPods,omitempty"`
+
+	// GPU defines the GPUs of the nodes.
+	// +optional
+	GPU *AutoscalerGPUCapacity `json:"gpu,omitempty"`
+
+	// Labels are the labels the nodes are expected to have, so the cluster-autoscaler can
+	// take node selectors and affinities into account when scaling from zero.
+	// +optional
+	Labels map[string]string `json:"labels,omitempty"`
+
+	// Taints are the taints the nodes are expected to have, so the cluster-autoscaler can
+	// take tolerations into account when scaling from zero.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
+}
+
+// AutoscalerGPUCapacity defines the GPUs of the nodes of a node group.
+type AutoscalerGPUCapacity struct {
+	// Type is the type of the GPUs, e.g. nvidia.com/gpu.
+	Type string `json:"type"`
+
+	// Count is the number of GPUs of the nodes.
+	Count resource.Quantity `json:"count"`
 }
 
 // MachineDeploymentClassTemplate defines how a MachineDeployment generated from a MachineDeploymentClass
@@ -270,10 +355,26 @@ type MachineHealthCheckClass struct {
 
 	// Machines older than this duration without a node will be considered to have
 	// failed and will be remediated.
+	// If InfrastructureStartupTimeout is set, this duration is counted from the time
+	// the infrastructure of the Machine became ready.
 	// If you wish to disable this feature, set the value explicitly to 0.
 	// +optional
 	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
 
+	// Machines older than this duration without their infrastructure ready will be considered
+	// to have failed and will be remediated, e.g. because of issues with the infrastructure provider
+	// or the cloud API. This allows to tune the remediation of infrastructure provisioning failures
+	// separately from the remediation of boot failures, which are covered by NodeStartupTimeout.
+	// If not set or set to 0, this feature is disabled.
+	// +optional
+	InfrastructureStartupTimeout *metav1.Duration `json:"infrastructureStartupTimeout,omitempty"`
+
+	// RemediationSuppressionWindows defines recurring windows during which the remediation of unhealthy Machines
+	// is suppressed, e.g. for planned infrastructure maintenance that would otherwise trigger the remediation of
+	// many Machines at once. Machines are still health checked during the windows.
+	// +optional
+	RemediationSuppressionWindows []RemediationSuppressionWindow `json:"remediationSuppressionWindows,omitempty"`
+
 	// RemediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -332,6 +433,11 @@ type MachinePoolClass struct {
 	// is ready)
 	// NOTE: This value can be overridden while defining a Cluster.Topology using this MachinePoolClass.
 	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
+
+	// AutoscalerCapacity defines the capacity of the nodes of the MachinePools using this class;
+	// it is rendered as annotations on the MachinePools to allow the cluster-autoscaler to scale them from zero.
+	// +optional
+	AutoscalerCapacity *AutoscalerCapacity `json:"autoscalerCapacity,omitempty"`
 }
 
 // MachinePoolClassTemplate defines how a MachinePool generated from a MachinePoolClass
@@ -698,6 +804,11 @@ type ClusterClassStatus struct {
 	// +optional
 	Variables []ClusterClassStatusVariable `json:"variables,omitempty"`
 
+	// DiscoveredVariablesChange reports the last change to the variables returned by the DiscoverVariables
+	// hooks of the ClusterClass patches, e.g. after a Runtime Extension has been upgraded.
+	// +optional
+	DiscoveredVariablesChange *ClusterClassDiscoveredVariablesChange `json:"discoveredVariablesChange,omitempty"`
+
 	// Conditions defines current observed state of the ClusterClass.
 	// +optional
 	Conditions Conditions `json:"conditions,omitempty"`
@@ -707,6 +818,16 @@ type ClusterClassStatus struct {
 	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
 }
 
+// ClusterClassDiscoveredVariablesChange describes a change to the variables returned by the DiscoverVariables hooks
+// of the ClusterClass patches.
+type ClusterClassDiscoveredVariablesChange struct {
+	// Time is the time the change has been detected.
+	Time metav1.Time `json:"time"`
+
+	// Message lists the variables added, removed and changed for each patch.
+	Message string `json:"message"`
+}
+
 // ClusterClassStatusVariable defines a variable which appears in the status of a ClusterClass.
 type ClusterClassStatusVariable struct {
 	// Name is the name of the variable.
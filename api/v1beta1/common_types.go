@@ -68,6 +68,16 @@ const (
 	// update that disallows a pre-existing Cluster to be populated with Topology information and Class.
 	ClusterTopologyUnsafeUpdateClassNameAnnotation = "unsafe.topology.cluster.x-k8s.io/disable-update-class-name-check"
 
+	// ClusterClassExportToNamespacesAnnotation can be set on a ClusterClass to allow Clusters in other namespaces to use it;
+	// the value is a comma-separated list of namespaces, or "*" for all the namespaces.
+	// NOTE: Users creating a Cluster using a ClusterClass in another namespace must also be allowed to "use" the ClusterClass.
+	ClusterClassExportToNamespacesAnnotation = "topology.cluster.x-k8s.io/export-to-namespaces"
+
+	// SkipVersionSkewValidationAnnotation can be used in emergencies to disable the webhook checks which reject
+	// Cluster topology and MachineDeployment version changes that would violate the Kubernetes version skew policy
+	// between the kubelets and the API server.
+	SkipVersionSkewValidationAnnotation = "unsafe.cluster.x-k8s.io/skip-version-skew-validation"
+
 	// ProviderNameLabel is the label set on components in the provider manifest.
 	// This label allows to easily identify all the components belonging to a provider; the clusterctl
 	// tool uses this label for implementing provider's lifecycle operations.
@@ -88,6 +98,9 @@ const (
 	// LabelsFromMachineAnnotation is the annotation set on nodes to track the labels originated from machines.
 	LabelsFromMachineAnnotation = "cluster.x-k8s.io/labels-from-machine"
 
+	// TaintsFromMachineAnnotation is the annotation set on nodes to track the taints originated from machines.
+	TaintsFromMachineAnnotation = "cluster.x-k8s.io/taints-from-machine"
+
 	// OwnerNameAnnotation is the annotation set on nodes identifying the owner name.
 	OwnerNameAnnotation = "cluster.x-k8s.io/owner-name"
 
@@ -98,6 +111,29 @@ const (
 	// on the reconciled object.
 	PausedAnnotation = "cluster.x-k8s.io/paused"
 
+	// ReconcileRateLimitAnnotation is an annotation that can be applied to a Namespace or to a Cluster to set the
+	// maximum number of reconciles per second of the objects in the Namespace or of the Cluster, for each of the
+	// core controllers with reconcile rate limiting enabled; the value must be a positive number, e.g. "0.5".
+	ReconcileRateLimitAnnotation = "cluster.x-k8s.io/reconcile-rate-limit"
+
+	// ClusterProxyURLAnnotation is an annotation that can be applied to a Cluster to set the URL of the HTTP(S)
+	// or SOCKS5 proxy the management cluster must use to reach the workload cluster API server, e.g. when
+	// the API server is only reachable through a tunnel like konnectivity; the value must be an absolute URL,
+	// e.g. "http://proxy.example.com:3128".
+	ClusterProxyURLAnnotation = "cluster.x-k8s.io/proxy-url"
+
+	// ClusterKubeconfigEndpointAnnotation is an annotation that can be applied to a Cluster to set the endpoint, in the
+	// host:port format, used as server in the generated kubeconfig Secret instead of the Cluster control plane endpoint,
+	// e.g. a private endpoint to be used by controllers.
+	// NOTE: The annotation is only used by the Cluster controller, which generates the kubeconfig Secret for Clusters
+	// without a control plane provider; KubeadmControlPlane uses spec.kubeconfigEndpoint instead.
+	ClusterKubeconfigEndpointAnnotation = "cluster.x-k8s.io/kubeconfig-endpoint"
+
+	// ClusterUserKubeconfigEndpointAnnotation is an annotation that can be applied to a Cluster to additionally generate
+	// a kubeconfig using the given endpoint, in the host:port format, e.g. a public endpoint to be used by users;
+	// the kubeconfig is stored in the user-value key of the kubeconfig Secret.
+	ClusterUserKubeconfigEndpointAnnotation = "cluster.x-k8s.io/user-kubeconfig-endpoint"
+
 	// DisableMachineCreateAnnotation is an annotation that can be used to signal a MachineSet to stop creating new machines.
 	// It is utilized in the OnDelete MachineDeploymentStrategy to allow the MachineDeployment controller to scale down
 	// older MachineSets when Machines are deleted and add the new replicas to the latest MachineSet.
@@ -124,6 +160,11 @@ const (
 	// MachineSkipRemediationAnnotation is the annotation used to mark the machines that should not be considered for remediation by MachineHealthCheck reconciler.
 	MachineSkipRemediationAnnotation = "cluster.x-k8s.io/skip-remediation"
 
+	// MachineRemediationSuppressedUntilAnnotation is the annotation used to temporarily suppress the remediation of a machine
+	// by the MachineHealthCheck reconciler; the value of the annotation is the time, in RFC3339 format, until which the
+	// remediation is suppressed. The machine is still health checked while the remediation is suppressed.
+	MachineRemediationSuppressedUntilAnnotation = "cluster.x-k8s.io/remediation-suppressed-until"
+
 	// MachineSetSkipPreflightChecksAnnotation is the annotation used to provide a comma-separated list of
 	// preflight checks that should be skipped during the MachineSet reconciliation.
 	// Supported items are:
@@ -136,12 +177,21 @@ const (
 	// the MachineSet.
 	MachineSetSkipPreflightChecksAnnotation = "machineset.cluster.x-k8s.io/skip-preflight-checks"
 
+	// MachineSetEnablePreflightChecksAnnotation is the annotation used to provide a comma-separated list of
+	// preflight checks that should be run during the MachineSet reconciliation even if the MachineSetPreflightChecks
+	// feature gate is disabled; it supports the same items of the MachineSetSkipPreflightChecksAnnotation.
+	// Example: "machineset.cluster.x-k8s.io/enable-preflight-checks": "KubeadmVersionSkew".
+	// Note: The preflight checks listed in the MachineSetSkipPreflightChecksAnnotation are skipped even if they are enabled.
+	// Note: The annotation can also be set on a MachineDeployment as MachineDeployment annotations are synced to
+	// the MachineSet.
+	MachineSetEnablePreflightChecksAnnotation = "machineset.cluster.x-k8s.io/enable-preflight-checks"
+
 	// ClusterSecretType defines the type of secret created by core components.
 	// Note: This is used by core CAPI, CAPBK, and KCP to determine whether a secret is created by the controllers
 	// themselves or supplied by the user (e.g. bring your own certificates).
 	ClusterSecretType corev1.SecretType = "cluster.x-k8s.io/secret" //nolint:gosec
 
-	// InterruptibleLabel is the label used to mark the nodes that run on interruptible instances.
+	// InterruptibleLabel is the label used to mark the Machines, and their nodes, that run on interruptible instances.
 	InterruptibleLabel = "cluster.x-k8s.io/interruptible"
 
 	// ManagedByAnnotation is an annotation that can be applied to InfraCluster resources to signify that
@@ -161,12 +211,52 @@ const (
 	// will receive the resulting object.
 	TopologyDryRunAnnotation = "topology.cluster.x-k8s.io/dry-run"
 
+	// TopologyDesiredStateHashAnnotation is an annotation that gets set on objects by the topology controller when
+	// applying them; it contains a hash of the desired state applied by the topology controller, and it is used to skip
+	// further server side apply operations until the desired state changes.
+	TopologyDesiredStateHashAnnotation = "topology.cluster.x-k8s.io/desired-state-hash"
+
 	// ReplicasManagedByAnnotation is an annotation that indicates external (non-Cluster API) management of infra scaling.
 	// The practical effect of this is that the capi "replica" count should be passively derived from the number of observed infra machines,
 	// instead of being a source of truth for eventual consistency.
 	// This annotation can be used to inform MachinePool status during in-progress scaling scenarios.
 	ReplicasManagedByAnnotation = "cluster.x-k8s.io/replicas-managed-by"
 
+	// BootstrapDataRotationAnnotation is an annotation that can be set by bootstrap providers on the bootstrap data
+	// Secret to signal that the bootstrap data is going to be rotated (e.g. because of a bootstrap token refresh).
+	// The value of the annotation is the time, in RFC3339 format, after which the bootstrap data will be rotated.
+	// Infrastructure providers can use this annotation to pre-stage the new bootstrap data for the instances that
+	// are going to be created after the rotation, without rolling out existing Machines.
+	BootstrapDataRotationAnnotation = "cluster.x-k8s.io/bootstrap-data-rotation"
+
+	// BootstrapDataRotationAcknowledgedAnnotation is an annotation that can be set by infrastructure providers
+	// on the InfraMachine or InfraMachinePool to acknowledge a bootstrap data rotation; the value of the annotation
+	// is the value of the BootstrapDataRotationAnnotation the infrastructure provider is prepared for.
+	BootstrapDataRotationAcknowledgedAnnotation = "cluster.x-k8s.io/bootstrap-data-rotation-acknowledged"
+
+	// RebootstrapAnnotation is an annotation that can be set on a Machine to request to re-bootstrap the Machine in place,
+	// e.g. after a rotation of the certificates or of the tokens used in the bootstrap data, instead of replacing it.
+	// The value of the annotation is an opaque identifier of the request, e.g. a timestamp; a new re-bootstrap can be
+	// requested by changing the value.
+	// The Machine controller propagates the annotation to the bootstrap config first; bootstrap providers supporting
+	// re-bootstrap regenerate the bootstrap data secret and acknowledge the request with the RebootstrapAcknowledgedAnnotation.
+	// Then the Machine controller propagates the annotation to the InfraMachine; infrastructure providers supporting
+	// re-bootstrap re-run the bootstrap data on the instance and acknowledge the request with the RebootstrapAcknowledgedAnnotation.
+	// Providers may not acknowledge requests for Machines they cannot re-bootstrap in place, e.g. the kubeadm bootstrap
+	// provider supports re-bootstrap for worker Machines only.
+	RebootstrapAnnotation = "cluster.x-k8s.io/rebootstrap"
+
+	// RebootstrapAcknowledgedAnnotation is an annotation that can be set by bootstrap providers on the bootstrap config,
+	// and by infrastructure providers on the InfraMachine, to acknowledge a re-bootstrap request; the value of the annotation
+	// is the value of the RebootstrapAnnotation the provider has completed the re-bootstrap for.
+	RebootstrapAcknowledgedAnnotation = "cluster.x-k8s.io/rebootstrap-acknowledged"
+
+	// BootstrapDataFormatsAnnotation is an annotation that can be set by infrastructure providers on the
+	// InfraMachine CustomResourceDefinition to advertise the comma-separated list of bootstrap data formats
+	// accepted by the infrastructure provider, e.g. "cloud-config,ignition"; if the annotation is not set, any format is accepted.
+	// The Machine webhook rejects Machines whose bootstrap data format is not accepted by the infrastructure provider.
+	BootstrapDataFormatsAnnotation = "cluster.x-k8s.io/bootstrap-data-formats"
+
 	// AutoscalerMinSizeAnnotation defines the minimum node group size.
 	// The annotation is used by autoscaler.
 	// The annotation is copied from kubernetes/autoscaler.
@@ -187,6 +277,53 @@ const (
 	// Note: It can be used by setting as top level annotation on MachineDeployment and MachineSets.
 	AutoscalerMaxSizeAnnotation = "cluster.x-k8s.io/cluster-api-autoscaler-node-group-max-size"
 
+	// TopologyReplicasExternallyManagedAnnotation is an annotation set by the topology controller on the MachineDeployments
+	// of a Cluster with a managed topology when their replicas are not managed by the topology controller because
+	// the autoscaler min size and max size annotations are set; its value is the name of the external manager, e.g. "autoscaler".
+	TopologyReplicasExternallyManagedAnnotation = "topology.cluster.x-k8s.io/replicas-externally-managed"
+
+	// AutoscalerCapacityCPUAnnotation defines the CPU capacity of the nodes of a node group, it is used by the
+	// autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	// Ref:https://github.com/kubernetes/autoscaler/blob/master/cluster-autoscaler/cloudprovider/clusterapi/README.md#scale-from-zero-support
+	// Note: It can be used by setting as top level annotation on MachineDeployment and MachinePools.
+	AutoscalerCapacityCPUAnnotation = "capacity.cluster-autoscaler.kubernetes.io/cpu"
+
+	// AutoscalerCapacityMemoryAnnotation defines the memory capacity of the nodes of a node group, it is used by the
+	// autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityMemoryAnnotation = "capacity.cluster-autoscaler.kubernetes.io/memory"
+
+	// AutoscalerCapacityEphemeralDiskAnnotation defines the ephemeral disk capacity of the nodes of a node group,
+	// it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityEphemeralDiskAnnotation = "capacity.cluster-autoscaler.kubernetes.io/ephemeral-disk"
+
+	// AutoscalerCapacityMaxPodsAnnotation defines the maximum number of pods of the nodes of a node group,
+	// it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityMaxPodsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/maxPods"
+
+	// AutoscalerCapacityGPUTypeAnnotation defines the type of the GPUs of the nodes of a node group,
+	// it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityGPUTypeAnnotation = "capacity.cluster-autoscaler.kubernetes.io/gpu-type"
+
+	// AutoscalerCapacityGPUCountAnnotation defines the number of GPUs of the nodes of a node group,
+	// it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityGPUCountAnnotation = "capacity.cluster-autoscaler.kubernetes.io/gpu-count"
+
+	// AutoscalerCapacityLabelsAnnotation defines the labels of the nodes of a node group as comma-separated
+	// key=value pairs, it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityLabelsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/labels"
+
+	// AutoscalerCapacityTaintsAnnotation defines the taints of the nodes of a node group as comma-separated
+	// key=value:effect triples, it is used by the autoscaler to scale a node group from zero.
+	// The annotation definition is copied from kubernetes/autoscaler.
+	AutoscalerCapacityTaintsAnnotation = "capacity.cluster-autoscaler.kubernetes.io/taints"
+
 	// VariableDefinitionFromInline indicates a patch or variable was defined in the `.spec` of a ClusterClass
 	// rather than from an external patch extension.
 	VariableDefinitionFromInline = "inline"
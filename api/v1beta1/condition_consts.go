@@ -22,6 +22,12 @@ package v1beta1
 const (
 	// ReadyCondition defines the Ready condition type that summarizes the operational state of a Cluster API object.
 	ReadyCondition ConditionType = "Ready"
+
+	// PausedCondition documents that reconciliation of a Cluster API object has been paused, either because
+	// the Cluster has spec.paused set or because the object has the cluster.x-k8s.io/paused annotation.
+	// The condition is set by the controller reconciling the object only after it has observed the pause,
+	// and it is removed when reconciliation resumes.
+	PausedCondition ConditionType = "Paused"
 )
 
 // Common ConditionReason used by Cluster API objects.
@@ -38,6 +44,12 @@ const (
 
 	// IncorrectExternalRefReason (Severity=Error) documents a CAPI object with an incorrect external object reference.
 	IncorrectExternalRefReason = "IncorrectExternalRef"
+
+	// ClusterPausedReason (Severity=Info) documents a CAPI object being paused because the Cluster it belongs to has spec.paused set.
+	ClusterPausedReason = "ClusterPaused"
+
+	// PausedAnnotationReason (Severity=Info) documents a CAPI object being paused because it has the cluster.x-k8s.io/paused annotation.
+	PausedAnnotationReason = "PausedAnnotation"
 )
 
 const (
@@ -102,6 +114,22 @@ const (
 	// NOTE: Having the control plane machine available is a pre-condition for joining additional control planes
 	// or workers nodes.
 	WaitingForControlPlaneAvailableReason = "WaitingForControlPlaneAvailable"
+
+	// WorkersDeletedCondition documents the deletion of the MachinePools, MachineDeployments, MachineSets and worker
+	// Machines of a Cluster, which is the first phase of the Cluster deletion.
+	WorkersDeletedCondition ConditionType = "WorkersDeleted"
+
+	// ControlPlaneDeletedCondition documents the deletion of the control plane of a Cluster, which is the second phase
+	// of the Cluster deletion and starts after all the workers have been deleted.
+	ControlPlaneDeletedCondition ConditionType = "ControlPlaneDeleted"
+
+	// InfrastructureDeletedCondition documents the deletion of the infrastructure of a Cluster, which is the last phase
+	// of the Cluster deletion and starts after the control plane has been deleted.
+	InfrastructureDeletedCondition ConditionType = "InfrastructureDeleted"
+
+	// DeletionTimeoutReason (Severity=Warning) documents a Cluster deletion phase taking longer than the deletion phase
+	// timeout; in this case the next deletion phase is started without waiting for the current one to complete.
+	DeletionTimeoutReason = "DeletionTimeout"
 )
 
 // Conditions and condition Reasons for the Machine object.
@@ -137,11 +165,68 @@ const (
 	// WaitingExternalHookReason (Severity=Info) provide evidence that we are waiting for an external hook to complete.
 	WaitingExternalHookReason = "WaitingExternalHook"
 
+	// ExternalHookTimedOutReason (Severity=Error) documents a machine waiting for an external hook to complete
+	// for longer than the timeout defined for the hook.
+	ExternalHookTimedOutReason = "ExternalHookTimedOut"
+
 	// VolumeDetachSucceededCondition reports a machine waiting for volumes to be detached.
 	VolumeDetachSucceededCondition ConditionType = "VolumeDetachSucceeded"
 
 	// WaitingForVolumeDetachReason (Severity=Info) provide evidence that a machine node waiting for volumes to be attached.
 	WaitingForVolumeDetachReason = "WaitingForVolumeDetach"
+
+	// MachinePhaseWithinThresholdCondition reports if a machine has been in its current phase for less than
+	// the threshold configured for that phase in the machine controller.
+	// NOTE: This condition is set only when at least one threshold is configured.
+	MachinePhaseWithinThresholdCondition ConditionType = "PhaseWithinThreshold"
+
+	// MachineStuckInPhaseReason (Severity=Warning) documents a machine that has been in its current phase
+	// for longer than the configured threshold, e.g. because provisioning or deletion is stuck.
+	MachineStuckInPhaseReason = "StuckInPhase"
+
+	// MachineCertificatesNotExpiringCondition reports if the certificates of a machine are not expired and do not
+	// expire within the warning threshold configured in the machine controller.
+	// NOTE: This condition is set only when the certificates expiry date of the machine is known, i.e. for control plane
+	// machines with the certificates expiry annotation set by the control plane or the bootstrap provider.
+	MachineCertificatesNotExpiringCondition ConditionType = "CertificatesNotExpiring"
+
+	// MachineCertificatesExpiringSoonReason (Severity=Warning) documents a machine with certificates expiring
+	// within the warning threshold configured in the machine controller.
+	MachineCertificatesExpiringSoonReason = "CertificatesExpiringSoon"
+
+	// MachineCertificatesExpiredReason (Severity=Error) documents a machine with expired certificates.
+	MachineCertificatesExpiredReason = "CertificatesExpired"
+
+	// MachineDeletionBlockedCondition is set to True on a machine that has been deleting for longer than the
+	// threshold configured in the machine controller; the message names the finalizers and the deletion
+	// lifecycle hooks blocking the deletion.
+	// NOTE: This condition has a negative polarity, and it is not part of the machine Ready condition summary.
+	MachineDeletionBlockedCondition ConditionType = "DeletionBlocked"
+
+	// MachineDeletionThresholdExceededReason documents a machine that has been deleting for longer than
+	// the threshold configured in the machine controller.
+	MachineDeletionThresholdExceededReason = "DeletionThresholdExceeded"
+
+	// MachineInterruptionNoticeCondition is set to True on a machine when the infrastructure provider reports, with a
+	// condition of the same type on the infrastructure machine, that the machine infrastructure is going to be interrupted,
+	// e.g. because a spot instance is going to be reclaimed. When this happens the machine controller proactively cordons
+	// and drains the node, and the owning MachineSet creates a replacement before deleting the interrupted machine.
+	// NOTE: This condition has a negative polarity, and it is not part of the machine Ready condition summary.
+	MachineInterruptionNoticeCondition ConditionType = "InterruptionNotice"
+
+	// MachineInterruptionNoticeReceivedReason documents a machine whose infrastructure is going to be interrupted;
+	// it is used when the infrastructure provider does not report a reason.
+	MachineInterruptionNoticeReceivedReason = "InterruptionNoticeReceived"
+
+	// MachineUpToDateCondition is set on a machine by its owner, e.g. the MachineSet or the KubeadmControlPlane controller,
+	// and documents whether the machine matches the current template/version of the owner or if it is going to be
+	// replaced by a rollout.
+	// NOTE: This condition is not part of the machine Ready condition summary.
+	MachineUpToDateCondition ConditionType = "UpToDate"
+
+	// MachineNotUpToDateReason (Severity=Info) documents a machine that does not match the current template/version
+	// of its owner and that is going to be replaced by a rollout.
+	MachineNotUpToDateReason = "NotUpToDate"
 )
 
 const (
@@ -155,6 +240,10 @@ const (
 	// NodeStartupTimeoutReason is the reason used when a machine's node does not appear within the specified timeout.
 	NodeStartupTimeoutReason = "NodeStartupTimeout"
 
+	// InfrastructureStartupTimeoutReason is the reason used when a machine's infrastructure does not become ready within
+	// the specified timeout.
+	InfrastructureStartupTimeoutReason = "InfrastructureStartupTimeout"
+
 	// UnhealthyNodeConditionReason is the reason used when a machine's node has one of the MachineHealthCheck's unhealthy conditions.
 	UnhealthyNodeConditionReason = "UnhealthyNode"
 )
@@ -219,6 +308,10 @@ const (
 	// TooManyUnhealthyReason is the reason used when too many Machines are unhealthy and the MachineHealthCheck is blocked
 	// from making any further remediations.
 	TooManyUnhealthyReason = "TooManyUnhealthy"
+
+	// RemediationSuppressedReason is the reason used when the MachineHealthCheck is blocked from making any
+	// further remediations because of a remediation suppression window.
+	RemediationSuppressedReason = "RemediationSuppressed"
 )
 
 // Conditions and condition Reasons for  MachineDeployments.
@@ -230,6 +323,15 @@ const (
 
 	// WaitingForAvailableMachinesReason (Severity=Warning) reflects the fact that the required minimum number of machines for a machinedeployment are not available.
 	WaitingForAvailableMachinesReason = "WaitingForAvailableMachines"
+
+	// MachineDeploymentRolloutProgressingCondition reports a rollout of a MachineDeployment which failed to make progress.
+	// Note: This condition is only set if the MachineDeploymentRollback feature gate is enabled, and it is removed as soon
+	// as the MachineDeployment has a new MachineSet whose rollout did not fail.
+	MachineDeploymentRolloutProgressingCondition ConditionType = "RolloutProgressing"
+
+	// RolloutFailedReason (Severity=Error) documents a MachineDeployment whose new MachineSet failed to become available
+	// within the progress deadline, and which has been rolled back to the old MachineSets.
+	RolloutFailedReason = "RolloutFailed"
 )
 
 // Conditions and condition Reasons for  MachineSets.
@@ -247,6 +349,14 @@ const (
 	// to create machine(s).
 	PreflightCheckFailedReason = "PreflightCheckFailed"
 
+	// InfrastructureProvisionHookBlockingReason (Severity=Info) documents a MachineSet waiting for the
+	// BeforeMachineInfrastructureProvision hook to allow the creation of machine(s).
+	InfrastructureProvisionHookBlockingReason = "InfrastructureProvisionHookBlocking"
+
+	// InfrastructureProvisionHookFailedReason (Severity=Error) documents a MachineSet failing to create machine(s)
+	// because the BeforeMachineInfrastructureProvision hook returned a failure.
+	InfrastructureProvisionHookFailedReason = "InfrastructureProvisionHookFailed"
+
 	// BootstrapTemplateCloningFailedReason (Severity=Error) documents a MachineSet failing to
 	// clone the bootstrap template.
 	BootstrapTemplateCloningFailedReason = "BootstrapTemplateCloningFailed"
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Event reasons are part of the API: they are stable and can be used to key off the events emitted by Cluster API,
+// e.g. in alerting pipelines.
+
+// Event reasons used by the Machine controller.
+const (
+	// DrainNodeSucceededEventReason is used when the Node of a Machine has been drained.
+	DrainNodeSucceededEventReason = "SuccessfulDrainNode"
+
+	// DrainNodeFailedEventReason is used when the Node of a Machine failed to drain.
+	DrainNodeFailedEventReason = "FailedDrainNode"
+
+	// NodeVolumesDetachedEventReason is used when all the volumes have been detached from the Node of a Machine.
+	NodeVolumesDetachedEventReason = "NodeVolumesDetached"
+
+	// WaitingForVolumeDetachEventReason is used when the deletion of a Machine waits for volumes to be detached from its Node.
+	WaitingForVolumeDetachEventReason = "WaitingForVolumeDetach"
+
+	// WaitForVolumeDetachFailedEventReason is used when waiting for the volumes to be detached from the Node of a Machine failed.
+	WaitForVolumeDetachFailedEventReason = "FailedWaitForVolumeDetach"
+
+	// DeleteNodeFailedEventReason is used when the Node of a Machine failed to be deleted.
+	DeleteNodeFailedEventReason = "FailedDeleteNode"
+
+	// GetNodeFailedEventReason is used when the Node of a Machine can't be retrieved from the workload cluster.
+	GetNodeFailedEventReason = "FailedGetNode"
+
+	// SetNodeRefSucceededEventReason is used when the Node of a Machine has been found and Machine.Status.NodeRef set.
+	SetNodeRefSucceededEventReason = "SuccessfulSetNodeRef"
+
+	// SetInterruptibleNodeLabelSucceededEventReason is used when the interruptible label has been set on the Node of a Machine.
+	SetInterruptibleNodeLabelSucceededEventReason = "SuccessfulSetInterruptibleNodeLabel"
+
+	// InterruptionNoticeReceivedEventReason is used when the infrastructure of a Machine reports that it is going to be interrupted.
+	InterruptionNoticeReceivedEventReason = "InterruptionNoticeReceived"
+
+	// InvalidAddressesEventReason is used when the infrastructure of a Machine reports invalid addresses; the addresses are
+	// reported in the Machine status as they are.
+	InvalidAddressesEventReason = "InvalidAddresses"
+
+	// ExternalHookTimedOutEventReason is used when a Machine waits for a deletion hook for longer than the timeout defined for the hook.
+	ExternalHookTimedOutEventReason = "ExternalHookTimedOut"
+)
+
+// Event reasons used by the MachineSet controller.
+const (
+	// ReconcileErrorEventReason is used when the reconciliation of a MachineSet failed.
+	ReconcileErrorEventReason = "ReconcileError"
+
+	// AdoptMachineSucceededEventReason is used when a Machine has been adopted by a MachineSet.
+	AdoptMachineSucceededEventReason = "SuccessfulAdopt"
+
+	// AdoptMachineFailedEventReason is used when a Machine failed to be adopted by a MachineSet.
+	AdoptMachineFailedEventReason = "FailedAdopt"
+
+	// CreateMachineSucceededEventReason is used when a Machine has been created by a MachineSet.
+	CreateMachineSucceededEventReason = "SuccessfulCreate"
+
+	// CreateMachineFailedEventReason is used when a Machine failed to be created by a MachineSet.
+	CreateMachineFailedEventReason = "FailedCreate"
+
+	// DeleteMachineSucceededEventReason is used when a Machine has been deleted by a MachineSet.
+	DeleteMachineSucceededEventReason = "SuccessfulDelete"
+
+	// DeleteMachineFailedEventReason is used when a Machine failed to be deleted by a MachineSet.
+	DeleteMachineFailedEventReason = "FailedDelete"
+)
@@ -63,6 +63,20 @@ const (
 	// an instance from an infrastructure provider until all are removed.
 	PreTerminateDeleteHookAnnotationPrefix = "pre-terminate.delete.hook.machine.cluster.x-k8s.io"
 
+	// PreDrainDeleteHookTimeoutAnnotationPrefix annotation specifies the prefix of the annotations
+	// defining a timeout for a pre-drain.delete lifecycle hook, e.g.
+	// pre-drain.delete.hook-timeout.machine.cluster.x-k8s.io/<hook-name>: 30m for the hook
+	// pre-drain.delete.hook.machine.cluster.x-k8s.io/<hook-name>. When the timeout expires
+	// the Machine is still blocked by the hook, but the PreDrainDeleteHookSucceeded condition reports a failure.
+	PreDrainDeleteHookTimeoutAnnotationPrefix = "pre-drain.delete.hook-timeout.machine.cluster.x-k8s.io"
+
+	// PreTerminateDeleteHookTimeoutAnnotationPrefix annotation specifies the prefix of the annotations
+	// defining a timeout for a pre-terminate.delete lifecycle hook, e.g.
+	// pre-terminate.delete.hook-timeout.machine.cluster.x-k8s.io/<hook-name>: 30m for the hook
+	// pre-terminate.delete.hook.machine.cluster.x-k8s.io/<hook-name>. When the timeout expires
+	// the Machine is still blocked by the hook, but the PreTerminateDeleteHookSucceeded condition reports a failure.
+	PreTerminateDeleteHookTimeoutAnnotationPrefix = "pre-terminate.delete.hook-timeout.machine.cluster.x-k8s.io"
+
 	// MachineCertificatesExpiryDateAnnotation annotation specifies the expiry date of the machine certificates in RFC3339 format.
 	// This annotation can be used on control plane machines to trigger rollout before certificates expire.
 	// This annotation can be set on BootstrapConfig or Machine objects. The value set on the Machine object takes precedence.
@@ -132,6 +146,13 @@ type MachineSpec struct {
 	// Defaults to 10 seconds.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// Taints are the taints the controller applies to the Node corresponding to this Machine and keeps reconciled.
+	// Taints removed from this list are removed from the Node; taints not set from the Machine are always preserved.
+	// NOTE: Changes to this field are propagated in-place to existing Machines by MachineDeployments and MachineSets,
+	// without triggering a rollout.
+	// +optional
+	Taints []corev1.Taint `json:"taints,omitempty"`
 }
 
 // ANCHOR_END: MachineSpec
@@ -144,7 +165,9 @@ type MachineStatus struct {
 	// +optional
 	NodeRef *corev1.ObjectReference `json:"nodeRef,omitempty"`
 
-	// NodeInfo is a set of ids/uuids to uniquely identify the node.
+	// NodeInfo is a set of ids/uuids to uniquely identify the node, and the versions of the OS image, kernel,
+	// container runtime, kubelet and kube-proxy running on the node; it is copied from the Node status,
+	// so it is available on the management cluster without access to the workload cluster.
 	// More info: https://kubernetes.io/docs/concepts/nodes/node/#info
 	// +optional
 	NodeInfo *corev1.NodeSystemInfo `json:"nodeInfo,omitempty"`
@@ -153,6 +176,13 @@ type MachineStatus struct {
 	// +optional
 	LastUpdated *metav1.Time `json:"lastUpdated,omitempty"`
 
+	// Timeline records when the Machine controller first observed the key transitions of the Machine
+	// during provisioning, so the provisioning latency of the Machine can be computed.
+	// NOTE: The timeline is recorded only for Machines which are in the Pending phase when the controller
+	// starts to record it; it is not recorded for Machines provisioned before.
+	// +optional
+	Timeline *MachineTimeline `json:"timeline,omitempty"`
+
 	// FailureReason will be set in the event that there is a terminal problem
 	// reconciling the Machine and will contain a succinct value suitable
 	// for machine interpretation.
@@ -206,6 +236,10 @@ type MachineStatus struct {
 	// +optional
 	CertificatesExpiryDate *metav1.Time `json:"certificatesExpiryDate,omitempty"`
 
+	// DeletionHooks is the list of lifecycle hooks currently blocking the deletion of the Machine.
+	// +optional
+	DeletionHooks []MachineDeletionHook `json:"deletionHooks,omitempty"`
+
 	// BootstrapReady is the state of the bootstrap provider.
 	// +optional
 	BootstrapReady bool `json:"bootstrapReady"`
@@ -225,6 +259,56 @@ type MachineStatus struct {
 
 // ANCHOR_END: MachineStatus
 
+// MachineTimeline records when the key transitions of a Machine during provisioning have been observed.
+type MachineTimeline struct {
+	// BootstrapReadyTime is the time when the bootstrap data secret was first reported ready.
+	// +optional
+	BootstrapReadyTime *metav1.Time `json:"bootstrapReadyTime,omitempty"`
+
+	// InfrastructureReadyTime is the time when the infrastructure was first reported ready.
+	// +optional
+	InfrastructureReadyTime *metav1.Time `json:"infrastructureReadyTime,omitempty"`
+
+	// NodeJoinedTime is the time when the Node was first found in the workload cluster.
+	// +optional
+	NodeJoinedTime *metav1.Time `json:"nodeJoinedTime,omitempty"`
+
+	// NodeReadyTime is the time when the Node first reported the Ready condition true.
+	// +optional
+	NodeReadyTime *metav1.Time `json:"nodeReadyTime,omitempty"`
+}
+
+// MachineDeletionHookPhase is the phase of the Machine deletion in which a lifecycle hook is blocking.
+type MachineDeletionHookPhase string
+
+const (
+	// MachineDeletionHookPhasePreDrain is the phase of pre-drain.delete lifecycle hooks.
+	MachineDeletionHookPhasePreDrain = MachineDeletionHookPhase("PreDrain")
+
+	// MachineDeletionHookPhasePreTerminate is the phase of pre-terminate.delete lifecycle hooks.
+	MachineDeletionHookPhasePreTerminate = MachineDeletionHookPhase("PreTerminate")
+)
+
+// MachineDeletionHook reports a lifecycle hook blocking the deletion of a Machine.
+type MachineDeletionHook struct {
+	// Phase is the phase of the Machine deletion the hook is blocking.
+	Phase MachineDeletionHookPhase `json:"phase"`
+
+	// Name is the name of the hook, i.e. the suffix of the hook annotation.
+	Name string `json:"name"`
+
+	// Owner is the owner of the hook, i.e. the value of the hook annotation.
+	// +optional
+	Owner string `json:"owner,omitempty"`
+
+	// WaitingSince is the time at which the Machine started waiting for the hook.
+	WaitingSince metav1.Time `json:"waitingSince"`
+
+	// Timeout is the timeout of the hook, if defined with the corresponding timeout annotation.
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+}
+
 // SetTypedPhase sets the Phase field to the string representation of MachinePhase.
 func (m *MachineStatus) SetTypedPhase(p MachinePhase) {
 	m.Phase = string(p)
@@ -269,6 +353,7 @@ type Bootstrap struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=machines,shortName=ma,scope=Namespaced,categories=cluster-api
+// +kubebuilder:metadata:labels="clusterctl.cluster.x-k8s.io/paused-condition=true"
 // +kubebuilder:subresource:status
 // +kubebuilder:storageversion
 // +kubebuilder:printcolumn:name="Cluster",type="string",JSONPath=".spec.clusterName",description="Cluster"
@@ -277,6 +362,9 @@ type Bootstrap struct {
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase",description="Machine status such as Terminating/Pending/Running/Failed etc"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of Machine"
 // +kubebuilder:printcolumn:name="Version",type="string",JSONPath=".spec.version",description="Kubernetes version associated with this Machine"
+// +kubebuilder:printcolumn:name="OSImage",type="string",JSONPath=".status.nodeInfo.osImage",description="OS image running on the Node of this Machine",priority=1
+// +kubebuilder:printcolumn:name="KernelVersion",type="string",JSONPath=".status.nodeInfo.kernelVersion",description="Kernel version running on the Node of this Machine",priority=1
+// +kubebuilder:printcolumn:name="ContainerRuntime",type="string",JSONPath=".status.nodeInfo.containerRuntimeVersion",description="Container runtime version running on the Node of this Machine",priority=1
 
 // Machine is the Schema for the machines API.
 type Machine struct {
@@ -44,6 +44,10 @@ const (
 	// RevisionHistoryAnnotation maintains the history of all old revisions that a machine set has served for a machine deployment.
 	RevisionHistoryAnnotation = "machinedeployment.clusters.x-k8s.io/revision-history"
 
+	// RevisionTimeAnnotation records, in RFC3339 format, when a machine set of a machine deployment got its current revision,
+	// i.e. when the rollout to that machine set began.
+	RevisionTimeAnnotation = "machinedeployment.clusters.x-k8s.io/revision-time"
+
 	// DesiredReplicasAnnotation is the desired replicas for a machine deployment recorded as an annotation
 	// in its machine sets. Helps in separating scaling events from the rollout process and for
 	// determining if the new machine set for a deployment is really saturated.
@@ -54,6 +58,12 @@ const (
 	// proportions in case the deployment has surge replicas.
 	MaxReplicasAnnotation = "machinedeployment.clusters.x-k8s.io/max-replicas"
 
+	// RolloutFailedAnnotation is set on the new MachineSet of a MachineDeployment when its Machines did not become
+	// available within the progress deadline of the MachineDeployment. The MachineDeployment is then rolled back to
+	// the old MachineSets, and the new MachineSet is not scaled up anymore until the annotation is removed.
+	// Note: This annotation is only used if the MachineDeploymentRollback feature gate is enabled.
+	RolloutFailedAnnotation = "machinedeployment.clusters.x-k8s.io/rollout-failed"
+
 	// MachineDeploymentUniqueLabel is used to uniquely identify the Machines of a MachineSet.
 	// The MachineDeployment controller will set this label on a MachineSet when it is created.
 	// The label is also applied to the Machines of the MachineSet and used in the MachineSet selector.
@@ -126,6 +136,12 @@ type MachineDeploymentSpec struct {
 	// +optional
 	MinReadySeconds *int32 `json:"minReadySeconds,omitempty"`
 
+	// FailureDomainRebalance, if set, enables spreading the Machines of the MachineDeployment across the
+	// failure domains of the Cluster, and gradually rebalancing them when the skew exceeds the allowed value.
+	// The value is propagated to the MachineSets of the MachineDeployment.
+	// +optional
+	FailureDomainRebalance *FailureDomainRebalance `json:"failureDomainRebalance,omitempty"`
+
 	// The number of old MachineSets to retain to allow rollback.
 	// This is a pointer to distinguish between explicit zero and not specified.
 	// Defaults to 1.
@@ -133,6 +149,12 @@ type MachineDeploymentSpec struct {
 	RevisionHistoryLimit *int32 `json:"revisionHistoryLimit,omitempty"`
 
 	// Indicates that the deployment is paused.
+	// While paused, changes to the MachineDeployment template are not rolled out, i.e. no new
+	// MachineSet is created and existing MachineSets are not scaled down in favour of a new one.
+	// Scaling is still active: changes to spec.replicas are applied to the existing MachineSets,
+	// so capacity can be added or removed while a rollout is held.
+	// Note: this is different from the cluster.x-k8s.io/paused annotation, which stops
+	// reconciliation of the MachineDeployment entirely.
 	// +optional
 	Paused bool `json:"paused,omitempty"`
 
@@ -141,6 +163,10 @@ type MachineDeploymentSpec struct {
 	// process failed deployments and a condition with a ProgressDeadlineExceeded
 	// reason will be surfaced in the deployment status. Note that progress will
 	// not be estimated during the time a deployment is paused. Defaults to 600s.
+	// If the MachineDeploymentRollback feature gate is enabled, a rolling update is considered
+	// failed when a Machine of the new MachineSet does not become available within this time
+	// from its creation; the new MachineSet is then scaled down, the old MachineSets are scaled
+	// back up and the RolloutProgressing condition is set to false with the RolloutFailed reason.
 	// +optional
 	ProgressDeadlineSeconds *int32 `json:"progressDeadlineSeconds,omitempty"`
 }
@@ -305,6 +331,7 @@ func (md *MachineDeploymentStatus) GetTypedPhase() MachineDeploymentPhase {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=machinedeployments,shortName=md,scope=Namespaced,categories=cluster-api
+// +kubebuilder:metadata:labels="clusterctl.cluster.x-k8s.io/paused-condition=true"
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
@@ -66,11 +66,27 @@ type MachineHealthCheckSpec struct {
 
 	// Machines older than this duration without a node will be considered to have
 	// failed and will be remediated.
+	// If InfrastructureStartupTimeout is set, this duration is counted from the time
+	// the infrastructure of the Machine became ready.
 	// If not set, this value is defaulted to 10 minutes.
 	// If you wish to disable this feature, set the value explicitly to 0.
 	// +optional
 	NodeStartupTimeout *metav1.Duration `json:"nodeStartupTimeout,omitempty"`
 
+	// Machines older than this duration without their infrastructure ready will be considered
+	// to have failed and will be remediated, e.g. because of issues with the infrastructure provider
+	// or the cloud API. This allows to tune the remediation of infrastructure provisioning failures
+	// separately from the remediation of boot failures, which are covered by NodeStartupTimeout.
+	// If not set or set to 0, this feature is disabled.
+	// +optional
+	InfrastructureStartupTimeout *metav1.Duration `json:"infrastructureStartupTimeout,omitempty"`
+
+	// RemediationSuppressionWindows defines recurring windows during which the remediation of unhealthy Machines
+	// is suppressed, e.g. for planned infrastructure maintenance that would otherwise trigger the remediation of
+	// many Machines at once. Machines are still health checked during the windows.
+	// +optional
+	RemediationSuppressionWindows []RemediationSuppressionWindow `json:"remediationSuppressionWindows,omitempty"`
+
 	// RemediationTemplate is a reference to a remediation template
 	// provided by an infrastructure provider.
 	//
@@ -102,6 +118,17 @@ type UnhealthyCondition struct {
 
 // ANCHOR_END: UnhealthyCondition
 
+// RemediationSuppressionWindow defines a recurring window during which the remediation of unhealthy Machines is suppressed.
+type RemediationSuppressionWindow struct {
+	// Schedule defines when the window starts, in the standard cron format evaluated in UTC,
+	// e.g. "0 2 * * sat" for every Saturday at 02:00 UTC.
+	// +kubebuilder:validation:MinLength=1
+	Schedule string `json:"schedule"`
+
+	// Duration is the duration of the window; it must be at least 1 minute and at most 7 days.
+	Duration metav1.Duration `json:"duration"`
+}
+
 // ANCHOR: MachineHealthCheckStatus
 
 // MachineHealthCheckStatus defines the observed state of MachineHealthCheck.
@@ -57,6 +57,14 @@ type MachineSetSpec struct {
 	// +optional
 	DeletePolicy string `json:"deletePolicy,omitempty"`
 
+	// FailureDomainRebalance, if set, enables spreading the Machines of the MachineSet across the
+	// failure domains of the Cluster, and gradually deleting and recreating Machines when the
+	// difference between the number of Machines in the most and the least populated failure domains
+	// exceeds the allowed skew, e.g. after recovering from a failure domain outage.
+	// NOTE: Rebalancing is supported only if the failure domain is not set in the Machine template.
+	// +optional
+	FailureDomainRebalance *FailureDomainRebalance `json:"failureDomainRebalance,omitempty"`
+
 	// Selector is a label query over machines that should match the replica count.
 	// Label keys and values that must match in order to be controlled by this MachineSet.
 	// It must match the machine template's labels.
@@ -72,6 +80,24 @@ type MachineSetSpec struct {
 
 // ANCHOR_END: MachineSetSpec
 
+// FailureDomainRebalance defines how Machines are rebalanced across failure domains.
+type FailureDomainRebalance struct {
+	// MaxSkew is the maximum allowed difference between the number of Machines
+	// in the most and the least populated failure domains.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxSkew *int32 `json:"maxSkew,omitempty"`
+
+	// MaxUnavailable is the maximum number of Machines that can be unavailable,
+	// including the ones deleted to rebalance failure domains; rebalancing is
+	// paused while the number of unavailable Machines is equal or greater than this value.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxUnavailable *int32 `json:"maxUnavailable,omitempty"`
+}
+
 // ANCHOR: MachineTemplateSpec
 
 // MachineTemplateSpec describes the data needed to create a Machine from a template.
@@ -200,6 +226,7 @@ func (m *MachineSet) Validate() field.ErrorList {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=machinesets,shortName=ms,scope=Namespaced,categories=cluster-api
+// +kubebuilder:metadata:labels="clusterctl.cluster.x-k8s.io/paused-condition=true"
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
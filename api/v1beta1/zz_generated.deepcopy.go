@@ -44,6 +44,76 @@ func (in *APIEndpoint) DeepCopy() *APIEndpoint {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerCapacity) DeepCopyInto(out *AutoscalerCapacity) {
+	*out = *in
+	if in.CPU != nil {
+		in, out := &in.CPU, &out.CPU
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.Memory != nil {
+		in, out := &in.Memory, &out.Memory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.EphemeralDisk != nil {
+		in, out := &in.EphemeralDisk, &out.EphemeralDisk
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.MaxPods != nil {
+		in, out := &in.MaxPods, &out.MaxPods
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.GPU != nil {
+		in, out := &in.GPU, &out.GPU
+		*out = new(AutoscalerGPUCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerCapacity.
+func (in *AutoscalerCapacity) DeepCopy() *AutoscalerCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AutoscalerGPUCapacity) DeepCopyInto(out *AutoscalerGPUCapacity) {
+	*out = *in
+	out.Count = in.Count.DeepCopy()
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AutoscalerGPUCapacity.
+func (in *AutoscalerGPUCapacity) DeepCopy() *AutoscalerGPUCapacity {
+	if in == nil {
+		return nil
+	}
+	out := new(AutoscalerGPUCapacity)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Bootstrap) DeepCopyInto(out *Bootstrap) {
 	*out = *in
@@ -123,6 +193,22 @@ func (in *ClusterClass) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ClusterClassDiscoveredVariablesChange) DeepCopyInto(out *ClusterClassDiscoveredVariablesChange) {
+	*out = *in
+	in.Time.DeepCopyInto(&out.Time)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterClassDiscoveredVariablesChange.
+func (in *ClusterClassDiscoveredVariablesChange) DeepCopy() *ClusterClassDiscoveredVariablesChange {
+	if in == nil {
+		return nil
+	}
+	out := new(ClusterClassDiscoveredVariablesChange)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *ClusterClassList) DeepCopyInto(out *ClusterClassList) {
 	*out = *in
@@ -229,6 +315,11 @@ func (in *ClusterClassStatus) DeepCopyInto(out *ClusterClassStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.DiscoveredVariablesChange != nil {
+		in, out := &in.DiscoveredVariablesChange, &out.DiscoveredVariablesChange
+		*out = new(ClusterClassDiscoveredVariablesChange)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(Conditions, len(*in))
@@ -427,6 +518,11 @@ func (in *ClusterStatus) DeepCopyInto(out *ClusterStatus) {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.FailureDomainMachines != nil {
+		in, out := &in.FailureDomainMachines, &out.FailureDomainMachines
+		*out = make([]FailureDomainMachinesStatus, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ClusterStatus.
@@ -637,9 +733,54 @@ func (in *ExternalPatchDefinition) DeepCopy() *ExternalPatchDefinition {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainMachinesStatus) DeepCopyInto(out *FailureDomainMachinesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainMachinesStatus.
+func (in *FailureDomainMachinesStatus) DeepCopy() *FailureDomainMachinesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainMachinesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureDomainRebalance) DeepCopyInto(out *FailureDomainRebalance) {
+	*out = *in
+	if in.MaxSkew != nil {
+		in, out := &in.MaxSkew, &out.MaxSkew
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureDomainRebalance.
+func (in *FailureDomainRebalance) DeepCopy() *FailureDomainRebalance {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureDomainRebalance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *FailureDomainSpec) DeepCopyInto(out *FailureDomainSpec) {
 	*out = *in
+	if in.Weight != nil {
+		in, out := &in.Weight, &out.Weight
+		*out = new(int32)
+		**out = **in
+	}
 	if in.Attributes != nil {
 		in, out := &in.Attributes, &out.Attributes
 		*out = make(map[string]string, len(*in))
@@ -895,6 +1036,27 @@ func (in MachineAddresses) DeepCopy() MachineAddresses {
 	return *out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineDeletionHook) DeepCopyInto(out *MachineDeletionHook) {
+	*out = *in
+	in.WaitingSince.DeepCopyInto(&out.WaitingSince)
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeletionHook.
+func (in *MachineDeletionHook) DeepCopy() *MachineDeletionHook {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineDeletionHook)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *MachineDeployment) DeepCopyInto(out *MachineDeployment) {
 	*out = *in
@@ -966,6 +1128,16 @@ func (in *MachineDeploymentClass) DeepCopyInto(out *MachineDeploymentClass) {
 		*out = new(MachineDeploymentStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.AutoscalerCapacity != nil {
+		in, out := &in.AutoscalerCapacity, &out.AutoscalerCapacity
+		*out = new(AutoscalerCapacity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TemplateOverrides != nil {
+		in, out := &in.TemplateOverrides, &out.TemplateOverrides
+		*out = make([]TemplateOverrideClass, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentClass.
@@ -1072,6 +1244,11 @@ func (in *MachineDeploymentSpec) DeepCopyInto(out *MachineDeploymentSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FailureDomainRebalance != nil {
+		in, out := &in.FailureDomainRebalance, &out.FailureDomainRebalance
+		*out = new(FailureDomainRebalance)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.RevisionHistoryLimit != nil {
 		in, out := &in.RevisionHistoryLimit, &out.RevisionHistoryLimit
 		*out = new(int32)
@@ -1170,6 +1347,13 @@ func (in *MachineDeploymentTopology) DeepCopyInto(out *MachineDeploymentTopology
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.MinReadySeconds != nil {
 		in, out := &in.MinReadySeconds, &out.MinReadySeconds
 		*out = new(int32)
@@ -1185,6 +1369,13 @@ func (in *MachineDeploymentTopology) DeepCopyInto(out *MachineDeploymentTopology
 		*out = new(MachineDeploymentVariables)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.TemplateOverrides != nil {
+		in, out := &in.TemplateOverrides, &out.TemplateOverrides
+		*out = make([]TemplateOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentTopology.
@@ -1269,6 +1460,16 @@ func (in *MachineHealthCheckClass) DeepCopyInto(out *MachineHealthCheckClass) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.InfrastructureStartupTimeout != nil {
+		in, out := &in.InfrastructureStartupTimeout, &out.InfrastructureStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemediationSuppressionWindows != nil {
+		in, out := &in.RemediationSuppressionWindows, &out.RemediationSuppressionWindows
+		*out = make([]RemediationSuppressionWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.RemediationTemplate != nil {
 		in, out := &in.RemediationTemplate, &out.RemediationTemplate
 		*out = new(v1.ObjectReference)
@@ -1342,6 +1543,16 @@ func (in *MachineHealthCheckSpec) DeepCopyInto(out *MachineHealthCheckSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.InfrastructureStartupTimeout != nil {
+		in, out := &in.InfrastructureStartupTimeout, &out.InfrastructureStartupTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
+	if in.RemediationSuppressionWindows != nil {
+		in, out := &in.RemediationSuppressionWindows, &out.RemediationSuppressionWindows
+		*out = make([]RemediationSuppressionWindow, len(*in))
+		copy(*out, *in)
+	}
 	if in.RemediationTemplate != nil {
 		in, out := &in.RemediationTemplate, &out.RemediationTemplate
 		*out = new(v1.ObjectReference)
@@ -1473,6 +1684,11 @@ func (in *MachinePoolClass) DeepCopyInto(out *MachinePoolClass) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.AutoscalerCapacity != nil {
+		in, out := &in.AutoscalerCapacity, &out.AutoscalerCapacity
+		*out = new(AutoscalerCapacity)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachinePoolClass.
@@ -1693,6 +1909,11 @@ func (in *MachineSetSpec) DeepCopyInto(out *MachineSetSpec) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.FailureDomainRebalance != nil {
+		in, out := &in.FailureDomainRebalance, &out.FailureDomainRebalance
+		*out = new(FailureDomainRebalance)
+		(*in).DeepCopyInto(*out)
+	}
 	in.Selector.DeepCopyInto(&out.Selector)
 	in.Template.DeepCopyInto(&out.Template)
 }
@@ -1774,6 +1995,13 @@ func (in *MachineSpec) DeepCopyInto(out *MachineSpec) {
 		*out = new(metav1.Duration)
 		**out = **in
 	}
+	if in.Taints != nil {
+		in, out := &in.Taints, &out.Taints
+		*out = make([]v1.Taint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineSpec.
@@ -1803,6 +2031,11 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		in, out := &in.LastUpdated, &out.LastUpdated
 		*out = (*in).DeepCopy()
 	}
+	if in.Timeline != nil {
+		in, out := &in.Timeline, &out.Timeline
+		*out = new(MachineTimeline)
+		(*in).DeepCopyInto(*out)
+	}
 	if in.FailureReason != nil {
 		in, out := &in.FailureReason, &out.FailureReason
 		*out = new(errors.MachineStatusError)
@@ -1822,6 +2055,13 @@ func (in *MachineStatus) DeepCopyInto(out *MachineStatus) {
 		in, out := &in.CertificatesExpiryDate, &out.CertificatesExpiryDate
 		*out = (*in).DeepCopy()
 	}
+	if in.DeletionHooks != nil {
+		in, out := &in.DeletionHooks, &out.DeletionHooks
+		*out = make([]MachineDeletionHook, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make(Conditions, len(*in))
@@ -1858,6 +2098,37 @@ func (in *MachineTemplateSpec) DeepCopy() *MachineTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MachineTimeline) DeepCopyInto(out *MachineTimeline) {
+	*out = *in
+	if in.BootstrapReadyTime != nil {
+		in, out := &in.BootstrapReadyTime, &out.BootstrapReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.InfrastructureReadyTime != nil {
+		in, out := &in.InfrastructureReadyTime, &out.InfrastructureReadyTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeJoinedTime != nil {
+		in, out := &in.NodeJoinedTime, &out.NodeJoinedTime
+		*out = (*in).DeepCopy()
+	}
+	if in.NodeReadyTime != nil {
+		in, out := &in.NodeReadyTime, &out.NodeReadyTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineTimeline.
+func (in *MachineTimeline) DeepCopy() *MachineTimeline {
+	if in == nil {
+		return nil
+	}
+	out := new(MachineTimeline)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *NetworkRanges) DeepCopyInto(out *NetworkRanges) {
 	*out = *in
@@ -2011,6 +2282,53 @@ func (in *PatchSelectorMatchMachinePoolClass) DeepCopy() *PatchSelectorMatchMach
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RemediationSuppressionWindow) DeepCopyInto(out *RemediationSuppressionWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RemediationSuppressionWindow.
+func (in *RemediationSuppressionWindow) DeepCopy() *RemediationSuppressionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(RemediationSuppressionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateOverride) DeepCopyInto(out *TemplateOverride) {
+	*out = *in
+	in.Value.DeepCopyInto(&out.Value)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateOverride.
+func (in *TemplateOverride) DeepCopy() *TemplateOverride {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateOverride)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TemplateOverrideClass) DeepCopyInto(out *TemplateOverrideClass) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TemplateOverrideClass.
+func (in *TemplateOverrideClass) DeepCopy() *TemplateOverrideClass {
+	if in == nil {
+		return nil
+	}
+	out := new(TemplateOverrideClass)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *Topology) DeepCopyInto(out *Topology) {
 	*out = *in
@@ -2024,6 +2342,11 @@ func (in *Topology) DeepCopyInto(out *Topology) {
 		*out = new(WorkersTopology)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.NodeVolumeDetachTimeout != nil {
+		in, out := &in.NodeVolumeDetachTimeout, &out.NodeVolumeDetachTimeout
+		*out = new(metav1.Duration)
+		**out = **in
+	}
 	if in.Variables != nil {
 		in, out := &in.Variables, &out.Variables
 		*out = make([]ClusterVariable, len(*in))
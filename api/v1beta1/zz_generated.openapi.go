@@ -31,9 +31,12 @@ import (
 func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenAPIDefinition {
 	return map[string]common.OpenAPIDefinition{
 		"sigs.k8s.io/cluster-api/api/v1beta1.APIEndpoint":                              schema_sigsk8sio_cluster_api_api_v1beta1_APIEndpoint(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerCapacity":                       schema_sigsk8sio_cluster_api_api_v1beta1_AutoscalerCapacity(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerGPUCapacity":                    schema_sigsk8sio_cluster_api_api_v1beta1_AutoscalerGPUCapacity(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.Bootstrap":                                schema_sigsk8sio_cluster_api_api_v1beta1_Bootstrap(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.Cluster":                                  schema_sigsk8sio_cluster_api_api_v1beta1_Cluster(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClass":                             schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClass(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassDiscoveredVariablesChange":    schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassDiscoveredVariablesChange(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassList":                         schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassList(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassPatch":                        schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassPatch(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassSpec":                         schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassSpec(ref),
@@ -51,6 +54,8 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/v1beta1.ControlPlaneClassNamingStrategy":          schema_sigsk8sio_cluster_api_api_v1beta1_ControlPlaneClassNamingStrategy(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ControlPlaneTopology":                     schema_sigsk8sio_cluster_api_api_v1beta1_ControlPlaneTopology(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ExternalPatchDefinition":                  schema_sigsk8sio_cluster_api_api_v1beta1_ExternalPatchDefinition(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainMachinesStatus":              schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainMachinesStatus(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainRebalance":                   schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainRebalance(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainSpec":                        schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainSpec(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.JSONPatch":                                schema_sigsk8sio_cluster_api_api_v1beta1_JSONPatch(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.JSONPatchValue":                           schema_sigsk8sio_cluster_api_api_v1beta1_JSONPatchValue(ref),
@@ -58,6 +63,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/v1beta1.LocalObjectTemplate":                      schema_sigsk8sio_cluster_api_api_v1beta1_LocalObjectTemplate(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.Machine":                                  schema_sigsk8sio_cluster_api_api_v1beta1_Machine(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineAddress":                           schema_sigsk8sio_cluster_api_api_v1beta1_MachineAddress(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.MachineDeletionHook":                      schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeletionHook(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineDeployment":                        schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeployment(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClass":                   schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentClass(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClassNamingStrategy":     schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentClassNamingStrategy(ref),
@@ -88,6 +94,7 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineSpec":                              schema_sigsk8sio_cluster_api_api_v1beta1_MachineSpec(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineStatus":                            schema_sigsk8sio_cluster_api_api_v1beta1_MachineStatus(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.MachineTemplateSpec":                      schema_sigsk8sio_cluster_api_api_v1beta1_MachineTemplateSpec(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.MachineTimeline":                          schema_sigsk8sio_cluster_api_api_v1beta1_MachineTimeline(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.NetworkRanges":                            schema_sigsk8sio_cluster_api_api_v1beta1_NetworkRanges(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.ObjectMeta":                               schema_sigsk8sio_cluster_api_api_v1beta1_ObjectMeta(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.PatchDefinition":                          schema_sigsk8sio_cluster_api_api_v1beta1_PatchDefinition(ref),
@@ -95,6 +102,9 @@ func GetOpenAPIDefinitions(ref common.ReferenceCallback) map[string]common.OpenA
 		"sigs.k8s.io/cluster-api/api/v1beta1.PatchSelectorMatch":                       schema_sigsk8sio_cluster_api_api_v1beta1_PatchSelectorMatch(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.PatchSelectorMatchMachineDeploymentClass": schema_sigsk8sio_cluster_api_api_v1beta1_PatchSelectorMatchMachineDeploymentClass(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.PatchSelectorMatchMachinePoolClass":       schema_sigsk8sio_cluster_api_api_v1beta1_PatchSelectorMatchMachinePoolClass(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow":             schema_sigsk8sio_cluster_api_api_v1beta1_RemediationSuppressionWindow(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverride":                         schema_sigsk8sio_cluster_api_api_v1beta1_TemplateOverride(ref),
+		"sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverrideClass":                    schema_sigsk8sio_cluster_api_api_v1beta1_TemplateOverrideClass(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.Topology":                                 schema_sigsk8sio_cluster_api_api_v1beta1_Topology(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition":                       schema_sigsk8sio_cluster_api_api_v1beta1_UnhealthyCondition(ref),
 		"sigs.k8s.io/cluster-api/api/v1beta1.VariableSchema":                           schema_sigsk8sio_cluster_api_api_v1beta1_VariableSchema(ref),
@@ -133,6 +143,112 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_APIEndpoint(ref common.ReferenceCa
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_AutoscalerCapacity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AutoscalerCapacity defines the capacity of the nodes of a node group, which is used by the cluster-autoscaler to scale the node group from zero, when there are no nodes to infer the capacity from.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"cpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "CPU is the number of CPUs of the nodes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"memory": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Memory is the amount of memory of the nodes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"ephemeralDisk": {
+						SchemaProps: spec.SchemaProps{
+							Description: "EphemeralDisk is the amount of ephemeral disk of the nodes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"maxPods": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxPods is the maximum number of pods that can be scheduled on the nodes.",
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+					"gpu": {
+						SchemaProps: spec.SchemaProps{
+							Description: "GPU defines the GPUs of the nodes.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerGPUCapacity"),
+						},
+					},
+					"labels": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Labels are the labels the nodes are expected to have, so the cluster-autoscaler can take node selectors and affinities into account when scaling from zero.",
+							Type:        []string{"object"},
+							AdditionalProperties: &spec.SchemaOrBool{
+								Allows: true,
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: "",
+										Type:    []string{"string"},
+										Format:  "",
+									},
+								},
+							},
+						},
+					},
+					"taints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Taints are the taints the nodes are expected to have, so the cluster-autoscaler can take tolerations into account when scaling from zero.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Taint"),
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/api/core/v1.Taint", "k8s.io/apimachinery/pkg/api/resource.Quantity", "sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerGPUCapacity"},
+	}
+}
+
+func schema_sigsk8sio_cluster_api_api_v1beta1_AutoscalerGPUCapacity(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "AutoscalerGPUCapacity defines the GPUs of the nodes of a node group.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"type": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Type is the type of the GPUs, e.g. nvidia.com/gpu.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"count": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Count is the number of GPUs of the nodes.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/api/resource.Quantity"),
+						},
+					},
+				},
+				Required: []string{"type", "count"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/api/resource.Quantity"},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_Bootstrap(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -255,6 +371,37 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClass(ref common.ReferenceC
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassDiscoveredVariablesChange(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "ClusterClassDiscoveredVariablesChange describes a change to the variables returned by the DiscoverVariables hooks of the ClusterClass patches.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"time": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Time is the time the change has been detected.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"message": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Message lists the variables added, removed and changed for each patch.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"time", "message"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassList(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -447,6 +594,12 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassStatus(ref common.Refe
 							},
 						},
 					},
+					"discoveredVariablesChange": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DiscoveredVariablesChange reports the last change to the variables returned by the DiscoverVariables hooks of the ClusterClass patches, e.g. after a Runtime Extension has been upgraded.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassDiscoveredVariablesChange"),
+						},
+					},
 					"conditions": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Conditions defines current observed state of the ClusterClass.",
@@ -472,7 +625,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterClassStatus(ref common.Refe
 			},
 		},
 		Dependencies: []string{
-			"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassStatusVariable", "sigs.k8s.io/cluster-api/api/v1beta1.Condition"},
+			"sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassDiscoveredVariablesChange", "sigs.k8s.io/cluster-api/api/v1beta1.ClusterClassStatusVariable", "sigs.k8s.io/cluster-api/api/v1beta1.Condition"},
 	}
 }
 
@@ -816,6 +969,28 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterStatus(ref common.Reference
 							},
 						},
 					},
+					"failureDomainMachines": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureDomainMachines reports, for each failure domain, the number of Machines of the Cluster placed in it, including both control plane and worker Machines. The list is sorted by failure domain name. Machines without a failure domain are not reported. This allows to compute the distribution of the Machines across failure domains without listing them.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainMachinesStatus"),
+									},
+								},
+							},
+						},
+					},
 					"observedGeneration": {
 						SchemaProps: spec.SchemaProps{
 							Description: "ObservedGeneration is the latest generation observed by the controller.",
@@ -827,7 +1002,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ClusterStatus(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"sigs.k8s.io/cluster-api/api/v1beta1.Condition", "sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainSpec"},
+			"sigs.k8s.io/cluster-api/api/v1beta1.Condition", "sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainMachinesStatus", "sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainSpec"},
 	}
 }
 
@@ -1117,6 +1292,87 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_ExternalPatchDefinition(ref common
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainMachinesStatus(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FailureDomainMachinesStatus reports the number of Machines of a Cluster placed in a failure domain.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the failure domain.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"desiredMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DesiredMachines is the number of Machines in the failure domain which are not being deleted.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"readyMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ReadyMachines is the number of Machines in the failure domain with the Ready condition set to true.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"availableMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AvailableMachines is the number of ready Machines in the failure domain with a healthy Node, i.e. with the NodeHealthy condition set to true.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"upToDateMachines": {
+						SchemaProps: spec.SchemaProps{
+							Description: "UpToDateMachines is the number of Machines in the failure domain which are not being deleted and don't require a rollout, i.e. Machines belonging to the current revision of their MachineDeployment, or control plane Machines with the same version as the control plane.",
+							Default:     0,
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+				Required: []string{"name"},
+			},
+		},
+	}
+}
+
+func schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainRebalance(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "FailureDomainRebalance defines how Machines are rebalanced across failure domains.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"maxSkew": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxSkew is the maximum allowed difference between the number of Machines in the most and the least populated failure domains. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+					"maxUnavailable": {
+						SchemaProps: spec.SchemaProps{
+							Description: "MaxUnavailable is the maximum number of Machines that can be unavailable, including the ones deleted to rebalance failure domains; rebalancing is paused while the number of unavailable Machines is equal or greater than this value. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainSpec(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1131,6 +1387,27 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_FailureDomainSpec(ref common.Refer
 							Format:      "",
 						},
 					},
+					"region": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Region is the name of the region of this failure domain, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"zone": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Zone is the name of the zone of this failure domain, if any.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"weight": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Weight is the relative weight of this failure domain when spreading Machines across failure domains, e.g. to express that a failure domain has more capacity than the others; Machines are spread so that the number of Machines in each failure domain is proportional to its weight. Defaults to 1.",
+							Type:        []string{"integer"},
+							Format:      "int32",
+						},
+					},
 					"attributes": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Attributes is a free form map of attributes an infrastructure provider might use or require.",
@@ -1506,6 +1783,58 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineAddress(ref common.Referenc
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeletionHook(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineDeletionHook reports a lifecycle hook blocking the deletion of a Machine.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"phase": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Phase is the phase of the Machine deletion the hook is blocking.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name is the name of the hook, i.e. the suffix of the hook annotation.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"owner": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Owner is the owner of the hook, i.e. the value of the hook annotation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"waitingSince": {
+						SchemaProps: spec.SchemaProps{
+							Description: "WaitingSince is the time at which the Machine started waiting for the hook.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"timeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeout is the timeout of the hook, if defined with the corresponding timeout annotation.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"phase", "name", "waitingSince"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeployment(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -1625,12 +1954,40 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentClass(ref common.
 							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy"),
 						},
 					},
+					"autoscalerCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoscalerCapacity defines the capacity of the nodes of the MachineDeployments using this class; it is rendered as annotations on the MachineDeployments to allow the cluster-autoscaler to scale them from zero.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerCapacity"),
+						},
+					},
+					"templateOverrides": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TemplateOverrides defines the fields of the bootstrap and infrastructure templates of this class which can be set for each MachineDeployment in the Cluster topology, without defining patches. NOTE: The values from the Cluster topology are set when the templates are cloned, and patches are applied afterwards.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverrideClass"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"class", "template"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClassNamingStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClassTemplate", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineHealthCheckClass"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerCapacity", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClassNamingStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentClassTemplate", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineHealthCheckClass", "sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverrideClass"},
 	}
 }
 
@@ -1795,6 +2152,12 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentSpec(ref common.R
 							Format:      "int32",
 						},
 					},
+					"failureDomainRebalance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureDomainRebalance, if set, enables spreading the Machines of the MachineDeployment across the failure domains of the Cluster, and gradually rebalancing them when the skew exceeds the allowed value. The value is propagated to the MachineSets of the MachineDeployment.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainRebalance"),
+						},
+					},
 					"revisionHistoryLimit": {
 						SchemaProps: spec.SchemaProps{
 							Description: "The number of old MachineSets to retain to allow rollback. This is a pointer to distinguish between explicit zero and not specified. Defaults to 1.",
@@ -1804,14 +2167,14 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentSpec(ref common.R
 					},
 					"paused": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Indicates that the deployment is paused.",
+							Description: "Indicates that the deployment is paused. While paused, changes to the MachineDeployment template are not rolled out, i.e. no new MachineSet is created and existing MachineSets are not scaled down in favour of a new one. Scaling is still active: changes to spec.replicas are applied to the existing MachineSets, so capacity can be added or removed while a rollout is held. Note: this is different from the cluster.x-k8s.io/paused annotation, which stops reconciliation of the MachineDeployment entirely.",
 							Type:        []string{"boolean"},
 							Format:      "",
 						},
 					},
 					"progressDeadlineSeconds": {
 						SchemaProps: spec.SchemaProps{
-							Description: "The maximum time in seconds for a deployment to make progress before it is considered to be failed. The deployment controller will continue to process failed deployments and a condition with a ProgressDeadlineExceeded reason will be surfaced in the deployment status. Note that progress will not be estimated during the time a deployment is paused. Defaults to 600s.",
+							Description: "The maximum time in seconds for a deployment to make progress before it is considered to be failed. The deployment controller will continue to process failed deployments and a condition with a ProgressDeadlineExceeded reason will be surfaced in the deployment status. Note that progress will not be estimated during the time a deployment is paused. Defaults to 600s. If the MachineDeploymentRollback feature gate is enabled, a rolling update is considered failed when a Machine of the new MachineSet does not become available within this time from its creation; the new MachineSet is then scaled down, the old MachineSets are scaled back up and the RolloutProgressing condition is set to false with the RolloutFailed reason.",
 							Type:        []string{"integer"},
 							Format:      "int32",
 						},
@@ -1821,7 +2184,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentSpec(ref common.R
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineTemplateSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainRebalance", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineTemplateSpec"},
 	}
 }
 
@@ -2011,6 +2374,20 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentTopology(ref comm
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"taints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Taints are the taints applied to the Nodes of the Machines of this MachineDeployment.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Taint"),
+									},
+								},
+							},
+						},
+					},
 					"minReadySeconds": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Minimum number of seconds for which a newly created machine should be ready. Defaults to 0 (machine will be considered available as soon as it is ready)",
@@ -2030,12 +2407,34 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineDeploymentTopology(ref comm
 							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentVariables"),
 						},
 					},
+					"templateOverrides": {
+						VendorExtensible: spec.VendorExtensible{
+							Extensions: spec.Extensions{
+								"x-kubernetes-list-map-keys": []interface{}{
+									"name",
+								},
+								"x-kubernetes-list-type": "map",
+							},
+						},
+						SchemaProps: spec.SchemaProps{
+							Description: "TemplateOverrides are the values of the template fields defined in the templateOverrides of the MachineDeploymentClass, which are set on the templates of this MachineDeployment.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverride"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"class", "name"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentVariables", "sigs.k8s.io/cluster-api/api/v1beta1.MachineHealthCheckTopology", "sigs.k8s.io/cluster-api/api/v1beta1.ObjectMeta"},
+			"k8s.io/api/core/v1.Taint", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeploymentVariables", "sigs.k8s.io/cluster-api/api/v1beta1.MachineHealthCheckTopology", "sigs.k8s.io/cluster-api/api/v1beta1.ObjectMeta", "sigs.k8s.io/cluster-api/api/v1beta1.TemplateOverride"},
 	}
 }
 
@@ -2153,10 +2552,30 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckClass(ref common
 					},
 					"nodeStartupTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If you wish to disable this feature, set the value explicitly to 0.",
+							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If InfrastructureStartupTimeout is set, this duration is counted from the time the infrastructure of the Machine became ready. If you wish to disable this feature, set the value explicitly to 0.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"infrastructureStartupTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Machines older than this duration without their infrastructure ready will be considered to have failed and will be remediated, e.g. because of issues with the infrastructure provider or the cloud API. This allows to tune the remediation of infrastructure provisioning failures separately from the remediation of boot failures, which are covered by NodeStartupTimeout. If not set or set to 0, this feature is disabled.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"remediationSuppressionWindows": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemediationSuppressionWindows defines recurring windows during which the remediation of unhealthy Machines is suppressed, e.g. for planned infrastructure maintenance that would otherwise trigger the remediation of many Machines at once. Machines are still health checked during the windows.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow"),
+									},
+								},
+							},
+						},
+					},
 					"remediationTemplate": {
 						SchemaProps: spec.SchemaProps{
 							Description: "RemediationTemplate is a reference to a remediation template provided by an infrastructure provider.\n\nThis field is completely optional, when filled, the MachineHealthCheck controller creates a new object from the template referenced and hands off remediation of the machine to a controller that lives outside of Cluster API.",
@@ -2167,7 +2586,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckClass(ref common
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
+			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
 	}
 }
 
@@ -2271,10 +2690,30 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckSpec(ref common.
 					},
 					"nodeStartupTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If not set, this value is defaulted to 10 minutes. If you wish to disable this feature, set the value explicitly to 0.",
+							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If InfrastructureStartupTimeout is set, this duration is counted from the time the infrastructure of the Machine became ready. If not set, this value is defaulted to 10 minutes. If you wish to disable this feature, set the value explicitly to 0.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"infrastructureStartupTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Machines older than this duration without their infrastructure ready will be considered to have failed and will be remediated, e.g. because of issues with the infrastructure provider or the cloud API. This allows to tune the remediation of infrastructure provisioning failures separately from the remediation of boot failures, which are covered by NodeStartupTimeout. If not set or set to 0, this feature is disabled.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"remediationSuppressionWindows": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemediationSuppressionWindows defines recurring windows during which the remediation of unhealthy Machines is suppressed, e.g. for planned infrastructure maintenance that would otherwise trigger the remediation of many Machines at once. Machines are still health checked during the windows.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow"),
+									},
+								},
+							},
+						},
+					},
 					"remediationTemplate": {
 						SchemaProps: spec.SchemaProps{
 							Description: "RemediationTemplate is a reference to a remediation template provided by an infrastructure provider.\n\nThis field is completely optional, when filled, the MachineHealthCheck controller creates a new object from the template referenced and hands off remediation of the machine to a controller that lives outside of Cluster API.",
@@ -2286,7 +2725,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckSpec(ref common.
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
+			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
 	}
 }
 
@@ -2408,10 +2847,30 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckTopology(ref com
 					},
 					"nodeStartupTimeout": {
 						SchemaProps: spec.SchemaProps{
-							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If you wish to disable this feature, set the value explicitly to 0.",
+							Description: "Machines older than this duration without a node will be considered to have failed and will be remediated. If InfrastructureStartupTimeout is set, this duration is counted from the time the infrastructure of the Machine became ready. If you wish to disable this feature, set the value explicitly to 0.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+					"infrastructureStartupTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Machines older than this duration without their infrastructure ready will be considered to have failed and will be remediated, e.g. because of issues with the infrastructure provider or the cloud API. This allows to tune the remediation of infrastructure provisioning failures separately from the remediation of boot failures, which are covered by NodeStartupTimeout. If not set or set to 0, this feature is disabled.",
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"remediationSuppressionWindows": {
+						SchemaProps: spec.SchemaProps{
+							Description: "RemediationSuppressionWindows defines recurring windows during which the remediation of unhealthy Machines is suppressed, e.g. for planned infrastructure maintenance that would otherwise trigger the remediation of many Machines at once. Machines are still health checked during the windows.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow"),
+									},
+								},
+							},
+						},
+					},
 					"remediationTemplate": {
 						SchemaProps: spec.SchemaProps{
 							Description: "RemediationTemplate is a reference to a remediation template provided by an infrastructure provider.\n\nThis field is completely optional, when filled, the MachineHealthCheck controller creates a new object from the template referenced and hands off remediation of the machine to a controller that lives outside of Cluster API.",
@@ -2422,7 +2881,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineHealthCheckTopology(ref com
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
+			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/util/intstr.IntOrString", "sigs.k8s.io/cluster-api/api/v1beta1.RemediationSuppressionWindow", "sigs.k8s.io/cluster-api/api/v1beta1.UnhealthyCondition"},
 	}
 }
 
@@ -2543,12 +3002,18 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachinePoolClass(ref common.Refere
 							Format:      "int32",
 						},
 					},
+					"autoscalerCapacity": {
+						SchemaProps: spec.SchemaProps{
+							Description: "AutoscalerCapacity defines the capacity of the nodes of the MachinePools using this class; it is rendered as annotations on the MachinePools to allow the cluster-autoscaler to scale them from zero.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerCapacity"),
+						},
+					},
 				},
 				Required: []string{"class", "template"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.MachinePoolClassNamingStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachinePoolClassTemplate"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.AutoscalerCapacity", "sigs.k8s.io/cluster-api/api/v1beta1.MachinePoolClassNamingStrategy", "sigs.k8s.io/cluster-api/api/v1beta1.MachinePoolClassTemplate"},
 	}
 }
 
@@ -2896,6 +3361,12 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineSetSpec(ref common.Referenc
 							Format:      "",
 						},
 					},
+					"failureDomainRebalance": {
+						SchemaProps: spec.SchemaProps{
+							Description: "FailureDomainRebalance, if set, enables spreading the Machines of the MachineSet across the failure domains of the Cluster, and gradually deleting and recreating Machines when the difference between the number of Machines in the most and the least populated failure domains exceeds the allowed skew, e.g. after recovering from a failure domain outage. NOTE: Rebalancing is supported only if the failure domain is not set in the Machine template.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainRebalance"),
+						},
+					},
 					"selector": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Selector is a label query over machines that should match the replica count. Label keys and values that must match in order to be controlled by this MachineSet. It must match the machine template's labels. More info: https://kubernetes.io/docs/concepts/overview/working-with-objects/labels/#label-selectors",
@@ -2915,7 +3386,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineSetSpec(ref common.Referenc
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "sigs.k8s.io/cluster-api/api/v1beta1.MachineTemplateSpec"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.LabelSelector", "sigs.k8s.io/cluster-api/api/v1beta1.FailureDomainRebalance", "sigs.k8s.io/cluster-api/api/v1beta1.MachineTemplateSpec"},
 	}
 }
 
@@ -3075,12 +3546,26 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineSpec(ref common.ReferenceCa
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
 						},
 					},
+					"taints": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Taints are the taints the controller applies to the Node corresponding to this Machine and keeps reconciled. Taints removed from this list are removed from the Node; taints not set from the Machine are always preserved. NOTE: Changes to this field are propagated in-place to existing Machines by MachineDeployments and MachineSets, without triggering a rollout.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("k8s.io/api/core/v1.Taint"),
+									},
+								},
+							},
+						},
+					},
 				},
 				Required: []string{"clusterName", "bootstrap", "infrastructureRef"},
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.Bootstrap"},
+			"k8s.io/api/core/v1.ObjectReference", "k8s.io/api/core/v1.Taint", "k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "sigs.k8s.io/cluster-api/api/v1beta1.Bootstrap"},
 	}
 }
 
@@ -3099,7 +3584,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineStatus(ref common.Reference
 					},
 					"nodeInfo": {
 						SchemaProps: spec.SchemaProps{
-							Description: "NodeInfo is a set of ids/uuids to uniquely identify the node. More info: https://kubernetes.io/docs/concepts/nodes/node/#info",
+							Description: "NodeInfo is a set of ids/uuids to uniquely identify the node, and the versions of the OS image, kernel, container runtime, kubelet and kube-proxy running on the node; it is copied from the Node status, so it is available on the management cluster without access to the workload cluster. More info: https://kubernetes.io/docs/concepts/nodes/node/#info",
 							Ref:         ref("k8s.io/api/core/v1.NodeSystemInfo"),
 						},
 					},
@@ -3109,6 +3594,12 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineStatus(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"timeline": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Timeline records when the Machine controller first observed the key transitions of the Machine during provisioning, so the provisioning latency of the Machine can be computed. NOTE: The timeline is recorded only for Machines which are in the Pending phase when the controller starts to record it; it is not recorded for Machines provisioned before.",
+							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.MachineTimeline"),
+						},
+					},
 					"failureReason": {
 						SchemaProps: spec.SchemaProps{
 							Description: "FailureReason will be set in the event that there is a terminal problem reconciling the Machine and will contain a succinct value suitable for machine interpretation.\n\nThis field should not be set for transitive errors that a controller faces that are expected to be fixed automatically over time (like service outages), but instead indicate that something is fundamentally wrong with the Machine's spec or the configuration of the controller, and that manual intervention is required. Examples of terminal errors would be invalid combinations of settings in the spec, values that are unsupported by the controller, or the responsible controller itself being critically misconfigured.\n\nAny transient errors that occur during the reconciliation of Machines can be added as events to the Machine object and/or logged in the controller's output.",
@@ -3150,6 +3641,20 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineStatus(ref common.Reference
 							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
 						},
 					},
+					"deletionHooks": {
+						SchemaProps: spec.SchemaProps{
+							Description: "DeletionHooks is the list of lifecycle hooks currently blocking the deletion of the Machine.",
+							Type:        []string{"array"},
+							Items: &spec.SchemaOrArray{
+								Schema: &spec.Schema{
+									SchemaProps: spec.SchemaProps{
+										Default: map[string]interface{}{},
+										Ref:     ref("sigs.k8s.io/cluster-api/api/v1beta1.MachineDeletionHook"),
+									},
+								},
+							},
+						},
+					},
 					"bootstrapReady": {
 						SchemaProps: spec.SchemaProps{
 							Description: "BootstrapReady is the state of the bootstrap provider.",
@@ -3191,7 +3696,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineStatus(ref common.Reference
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/api/core/v1.NodeSystemInfo", "k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.Condition", "sigs.k8s.io/cluster-api/api/v1beta1.MachineAddress"},
+			"k8s.io/api/core/v1.NodeSystemInfo", "k8s.io/api/core/v1.ObjectReference", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.Condition", "sigs.k8s.io/cluster-api/api/v1beta1.MachineAddress", "sigs.k8s.io/cluster-api/api/v1beta1.MachineDeletionHook", "sigs.k8s.io/cluster-api/api/v1beta1.MachineTimeline"},
 	}
 }
 
@@ -3224,6 +3729,45 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_MachineTemplateSpec(ref common.Ref
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_MachineTimeline(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "MachineTimeline records when the key transitions of a Machine during provisioning have been observed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"bootstrapReadyTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "BootstrapReadyTime is the time when the bootstrap data secret was first reported ready.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"infrastructureReadyTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "InfrastructureReadyTime is the time when the infrastructure was first reported ready.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nodeJoinedTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeJoinedTime is the time when the Node was first found in the workload cluster.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+					"nodeReadyTime": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeReadyTime is the time when the Node first reported the Ready condition true.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Time"),
+						},
+					},
+				},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Time"},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_NetworkRanges(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3470,6 +4014,106 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_PatchSelectorMatchMachinePoolClass
 	}
 }
 
+func schema_sigsk8sio_cluster_api_api_v1beta1_RemediationSuppressionWindow(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "RemediationSuppressionWindow defines a recurring window during which the remediation of unhealthy Machines is suppressed.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"schedule": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Schedule defines when the window starts, in the standard cron format evaluated in UTC, e.g. \"0 2 * * sat\" for every Saturday at 02:00 UTC.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"duration": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Duration is the duration of the window; it must be at least 1 minute and at most 7 days.",
+							Default:     0,
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
+				},
+				Required: []string{"schedule", "duration"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration"},
+	}
+}
+
+func schema_sigsk8sio_cluster_api_api_v1beta1_TemplateOverride(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplateOverride defines the value of a template field defined in a TemplateOverrideClass.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the TemplateOverrideClass.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"value": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Value of the field. Note: We have to use apiextensionsv1.JSON instead of a custom JSON type, because controller-tools has a hard-coded schema for apiextensionsv1.JSON which cannot be produced by another type via controller-tools, i.e. it is not possible to have no type field.",
+							Default:     map[string]interface{}{},
+							Ref:         ref("k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON"),
+						},
+					},
+				},
+				Required: []string{"name", "value"},
+			},
+		},
+		Dependencies: []string{
+			"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1.JSON"},
+	}
+}
+
+func schema_sigsk8sio_cluster_api_api_v1beta1_TemplateOverrideClass(ref common.ReferenceCallback) common.OpenAPIDefinition {
+	return common.OpenAPIDefinition{
+		Schema: spec.Schema{
+			SchemaProps: spec.SchemaProps{
+				Description: "TemplateOverrideClass defines a field of a template which can be set in the Cluster topology.",
+				Type:        []string{"object"},
+				Properties: map[string]spec.Schema{
+					"name": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Name of the override. It must be unique within the class and it is used to set the value of the field in the Cluster topology.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"template": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Template is the template the field belongs to, either Bootstrap or Infrastructure.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+					"path": {
+						SchemaProps: spec.SchemaProps{
+							Description: "Path is the path of the field in the template, in the JSON Pointer format (RFC 6901), e.g. \"/spec/template/spec/instanceType\". The path must start with \"/spec/\" and can't refer to items of lists.",
+							Default:     "",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
+				},
+				Required: []string{"name", "template", "path"},
+			},
+		},
+	}
+}
+
 func schema_sigsk8sio_cluster_api_api_v1beta1_Topology(ref common.ReferenceCallback) common.OpenAPIDefinition {
 	return common.OpenAPIDefinition{
 		Schema: spec.Schema{
@@ -3485,6 +4129,13 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_Topology(ref common.ReferenceCallb
 							Format:      "",
 						},
 					},
+					"classNamespace": {
+						SchemaProps: spec.SchemaProps{
+							Description: "ClassNamespace is the namespace of the ClusterClass object to create the topology. If empty or not set, the Cluster namespace is used. A ClusterClass in another namespace can be used only if it is exported to the Cluster namespace with the topology.cluster.x-k8s.io/export-to-namespaces annotation.",
+							Type:        []string{"string"},
+							Format:      "",
+						},
+					},
 					"version": {
 						SchemaProps: spec.SchemaProps{
 							Description: "The Kubernetes version of the cluster.",
@@ -3512,6 +4163,12 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_Topology(ref common.ReferenceCallb
 							Ref:         ref("sigs.k8s.io/cluster-api/api/v1beta1.WorkersTopology"),
 						},
 					},
+					"nodeVolumeDetachTimeout": {
+						SchemaProps: spec.SchemaProps{
+							Description: "NodeVolumeDetachTimeout is the total amount of time that the controller will spend on waiting for all volumes to be detached from the Nodes of the Cluster. It overrides the values defined in the ClusterClass for the control plane, the MachineDeployments and the MachinePools of the Cluster. NOTE: This value can be overridden for the control plane, a MachineDeployment or a MachinePool in their topology.",
+							Ref:         ref("k8s.io/apimachinery/pkg/apis/meta/v1.Duration"),
+						},
+					},
 					"variables": {
 						SchemaProps: spec.SchemaProps{
 							Description: "Variables can be used to customize the Cluster through patches. They must comply to the corresponding VariableClasses defined in the ClusterClass.",
@@ -3531,7 +4188,7 @@ func schema_sigsk8sio_cluster_api_api_v1beta1_Topology(ref common.ReferenceCallb
 			},
 		},
 		Dependencies: []string{
-			"k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.ClusterVariable", "sigs.k8s.io/cluster-api/api/v1beta1.ControlPlaneTopology", "sigs.k8s.io/cluster-api/api/v1beta1.WorkersTopology"},
+			"k8s.io/apimachinery/pkg/apis/meta/v1.Duration", "k8s.io/apimachinery/pkg/apis/meta/v1.Time", "sigs.k8s.io/cluster-api/api/v1beta1.ClusterVariable", "sigs.k8s.io/cluster-api/api/v1beta1.ControlPlaneTopology", "sigs.k8s.io/cluster-api/api/v1beta1.WorkersTopology"},
 	}
 }
 
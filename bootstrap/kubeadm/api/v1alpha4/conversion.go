@@ -49,12 +49,14 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Ignition = restored.Spec.Ignition
+	dst.Spec.KubeletConfiguration = restored.Spec.KubeletConfiguration
 	if restored.Spec.InitConfiguration != nil {
 		if dst.Spec.InitConfiguration == nil {
 			dst.Spec.InitConfiguration = &bootstrapv1.InitConfiguration{}
 		}
 		dst.Spec.InitConfiguration.Patches = restored.Spec.InitConfiguration.Patches
 		dst.Spec.InitConfiguration.SkipPhases = restored.Spec.InitConfiguration.SkipPhases
+		dst.Spec.InitConfiguration.Timeouts = restored.Spec.InitConfiguration.Timeouts
 	}
 	if restored.Spec.JoinConfiguration != nil {
 		if dst.Spec.JoinConfiguration == nil {
@@ -62,6 +64,15 @@ func (src *KubeadmConfig) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.JoinConfiguration.Patches = restored.Spec.JoinConfiguration.Patches
 		dst.Spec.JoinConfiguration.SkipPhases = restored.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.JoinConfiguration.Timeouts = restored.Spec.JoinConfiguration.Timeouts
+	}
+	if restored.Spec.ClusterConfiguration != nil && dst.Spec.ClusterConfiguration != nil {
+		dst.Spec.ClusterConfiguration.APIServer.ExtraEnvs = restored.Spec.ClusterConfiguration.APIServer.ExtraEnvs
+		dst.Spec.ClusterConfiguration.ControllerManager.ExtraEnvs = restored.Spec.ClusterConfiguration.ControllerManager.ExtraEnvs
+		dst.Spec.ClusterConfiguration.Scheduler.ExtraEnvs = restored.Spec.ClusterConfiguration.Scheduler.ExtraEnvs
+		if restored.Spec.ClusterConfiguration.Etcd.Local != nil && dst.Spec.ClusterConfiguration.Etcd.Local != nil {
+			dst.Spec.ClusterConfiguration.Etcd.Local.ExtraEnvs = restored.Spec.ClusterConfiguration.Etcd.Local.ExtraEnvs
+		}
 	}
 
 	if restored.Spec.JoinConfiguration != nil && restored.Spec.JoinConfiguration.NodeRegistration.ImagePullPolicy != "" {
@@ -130,12 +141,14 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
 
 	dst.Spec.Template.Spec.Ignition = restored.Spec.Template.Spec.Ignition
+	dst.Spec.Template.Spec.KubeletConfiguration = restored.Spec.Template.Spec.KubeletConfiguration
 	if restored.Spec.Template.Spec.InitConfiguration != nil {
 		if dst.Spec.Template.Spec.InitConfiguration == nil {
 			dst.Spec.Template.Spec.InitConfiguration = &bootstrapv1.InitConfiguration{}
 		}
 		dst.Spec.Template.Spec.InitConfiguration.Patches = restored.Spec.Template.Spec.InitConfiguration.Patches
 		dst.Spec.Template.Spec.InitConfiguration.SkipPhases = restored.Spec.Template.Spec.InitConfiguration.SkipPhases
+		dst.Spec.Template.Spec.InitConfiguration.Timeouts = restored.Spec.Template.Spec.InitConfiguration.Timeouts
 	}
 	if restored.Spec.Template.Spec.JoinConfiguration != nil {
 		if dst.Spec.Template.Spec.JoinConfiguration == nil {
@@ -143,6 +156,15 @@ func (src *KubeadmConfigTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.JoinConfiguration.Patches = restored.Spec.Template.Spec.JoinConfiguration.Patches
 		dst.Spec.Template.Spec.JoinConfiguration.SkipPhases = restored.Spec.Template.Spec.JoinConfiguration.SkipPhases
+		dst.Spec.Template.Spec.JoinConfiguration.Timeouts = restored.Spec.Template.Spec.JoinConfiguration.Timeouts
+	}
+	if restored.Spec.Template.Spec.ClusterConfiguration != nil && dst.Spec.Template.Spec.ClusterConfiguration != nil {
+		dst.Spec.Template.Spec.ClusterConfiguration.APIServer.ExtraEnvs = restored.Spec.Template.Spec.ClusterConfiguration.APIServer.ExtraEnvs
+		dst.Spec.Template.Spec.ClusterConfiguration.ControllerManager.ExtraEnvs = restored.Spec.Template.Spec.ClusterConfiguration.ControllerManager.ExtraEnvs
+		dst.Spec.Template.Spec.ClusterConfiguration.Scheduler.ExtraEnvs = restored.Spec.Template.Spec.ClusterConfiguration.Scheduler.ExtraEnvs
+		if restored.Spec.Template.Spec.ClusterConfiguration.Etcd.Local != nil && dst.Spec.Template.Spec.ClusterConfiguration.Etcd.Local != nil {
+			dst.Spec.Template.Spec.ClusterConfiguration.Etcd.Local.ExtraEnvs = restored.Spec.Template.Spec.ClusterConfiguration.Etcd.Local.ExtraEnvs
+		}
 	}
 
 	if restored.Spec.Template.Spec.JoinConfiguration != nil && restored.Spec.Template.Spec.JoinConfiguration.NodeRegistration.ImagePullPolicy != "" {
@@ -186,7 +208,7 @@ func (dst *KubeadmConfigTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 
 // Convert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec is an autogenerated conversion function.
 func Convert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec(in *bootstrapv1.KubeadmConfigSpec, out *KubeadmConfigSpec, s apiconversion.Scope) error {
-	// KubeadmConfigSpec.Ignition does not exist in kubeadm v1alpha4 API.
+	// KubeadmConfigSpec.Ignition and KubeadmConfigSpec.KubeletConfiguration do not exist in kubeadm v1alpha4 API.
 	return autoConvert_v1beta1_KubeadmConfigSpec_To_v1alpha4_KubeadmConfigSpec(in, out, s)
 }
 
@@ -216,6 +238,16 @@ func Convert_v1beta1_NodeRegistrationOptions_To_v1alpha4_NodeRegistrationOptions
 	return autoConvert_v1beta1_NodeRegistrationOptions_To_v1alpha4_NodeRegistrationOptions(in, out, s)
 }
 
+func Convert_v1beta1_ControlPlaneComponent_To_v1alpha4_ControlPlaneComponent(in *bootstrapv1.ControlPlaneComponent, out *ControlPlaneComponent, s apiconversion.Scope) error {
+	// ControlPlaneComponent.ExtraEnvs does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_ControlPlaneComponent_To_v1alpha4_ControlPlaneComponent(in, out, s)
+}
+
+func Convert_v1beta1_LocalEtcd_To_v1alpha4_LocalEtcd(in *bootstrapv1.LocalEtcd, out *LocalEtcd, s apiconversion.Scope) error {
+	// LocalEtcd.ExtraEnvs does not exist in kubeadm v1alpha4 API.
+	return autoConvert_v1beta1_LocalEtcd_To_v1alpha4_LocalEtcd(in, out, s)
+}
+
 func Convert_v1beta1_KubeadmConfigTemplateResource_To_v1alpha4_KubeadmConfigTemplateResource(in *bootstrapv1.KubeadmConfigTemplateResource, out *KubeadmConfigTemplateResource, s apiconversion.Scope) error {
 	// KubeadmConfigTemplateResource.metadata does not exist in kubeadm v1alpha4.
 	return autoConvert_v1beta1_KubeadmConfigTemplateResource_To_v1alpha4_KubeadmConfigTemplateResource(in, out, s)
@@ -59,3 +59,17 @@ const (
 	// an error while retrieving certificates for a joining node.
 	CertificatesCorruptedReason = "CertificatesCorrupted"
 )
+
+const (
+	// RebootstrapSucceededCondition documents the status of the last re-bootstrap request for the machine,
+	// as requested with the cluster.x-k8s.io/rebootstrap annotation.
+	//
+	// NOTE: Only worker machines can be re-bootstrapped; re-bootstrapping a control plane machine in place
+	// would require to reset the node first, so those requests are not acknowledged.
+	RebootstrapSucceededCondition clusterv1.ConditionType = "RebootstrapSucceeded"
+
+	// RebootstrapNotSupportedReason (Severity=Warning) documents a KubeadmConfig controller ignoring a re-bootstrap
+	// request for a machine that cannot be re-bootstrapped in place, e.g. a control plane machine; user intervention
+	// is required to remove the annotation, or to replace the machine instead.
+	RebootstrapNotSupportedReason = "RebootstrapNotSupported"
+)
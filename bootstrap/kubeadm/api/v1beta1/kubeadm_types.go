@@ -65,6 +65,11 @@ type InitConfiguration struct {
 	// "kubeadm init". The minimum kubernetes version needed to support Patches is v1.22
 	// +optional
 	Patches *Patches `json:"patches,omitempty"`
+
+	// Timeouts holds various timeouts that apply to kubeadm commands.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
 }
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -158,6 +163,17 @@ type ControlPlaneComponent struct {
 	// ExtraVolumes is an extra set of host volumes, mounted to the control plane component.
 	// +optional
 	ExtraVolumes []HostPathMount `json:"extraVolumes,omitempty"`
+
+	// ExtraEnvs is an extra set of environment variables to pass to the control plane component.
+	// Environment variables passed using ExtraEnvs will override any existing environment variables, or *_proxy environment variables that kubeadm adds by default.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	ExtraEnvs []EnvVar `json:"extraEnvs,omitempty"`
+}
+
+// EnvVar represents an environment variable present in a Container.
+type EnvVar struct {
+	corev1.EnvVar `json:",inline"`
 }
 
 // APIServer holds settings necessary for API server deployments in the cluster.
@@ -169,6 +185,8 @@ type APIServer struct {
 	CertSANs []string `json:"certSANs,omitempty"`
 
 	// TimeoutForControlPlane controls the timeout that we use for API server to appear
+	// NOTE: On Kubernetes >=1.31.0 this value is used as a default for initConfiguration.timeouts.controlPlaneComponentHealthCheck
+	// and joinConfiguration.timeouts.controlPlaneComponentHealthCheck.
 	// +optional
 	TimeoutForControlPlane *metav1.Duration `json:"timeoutForControlPlane,omitempty"`
 }
@@ -382,6 +400,12 @@ type LocalEtcd struct {
 	// +optional
 	ExtraArgs map[string]string `json:"extraArgs,omitempty"`
 
+	// ExtraEnvs is an extra set of environment variables to pass to the control plane component.
+	// Environment variables passed using ExtraEnvs will override any existing environment variables, or *_proxy environment variables that kubeadm adds by default.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	ExtraEnvs []EnvVar `json:"extraEnvs,omitempty"`
+
 	// ServerCertSANs sets extra Subject Alternative Names for the etcd server signing cert.
 	// +optional
 	ServerCertSANs []string `json:"serverCertSANs,omitempty"`
@@ -448,6 +472,11 @@ type JoinConfiguration struct {
 	// "kubeadm join". The minimum kubernetes version needed to support Patches is v1.22
 	// +optional
 	Patches *Patches `json:"patches,omitempty"`
+
+	// Timeouts holds various timeouts that apply to kubeadm commands.
+	// This option takes effect only on Kubernetes >=1.31.0.
+	// +optional
+	Timeouts *Timeouts `json:"timeouts,omitempty"`
 }
 
 // JoinControlPlane contains elements describing an additional control plane instance to be deployed on the joining node.
@@ -476,10 +505,44 @@ type Discovery struct {
 	TLSBootstrapToken string `json:"tlsBootstrapToken,omitempty"`
 
 	// Timeout modifies the discovery timeout
+	// NOTE: On Kubernetes >=1.31.0 this value is used as a default for joinConfiguration.timeouts.discovery.
 	// +optional
 	Timeout *metav1.Duration `json:"timeout,omitempty"`
 }
 
+// Timeouts holds various timeouts that apply to kubeadm commands.
+type Timeouts struct {
+	// ControlPlaneComponentHealthCheck is the amount of time to wait for a control plane
+	// component, such as the API server, to be healthy during "kubeadm init" and "kubeadm join".
+	// +optional
+	ControlPlaneComponentHealthCheck *metav1.Duration `json:"controlPlaneComponentHealthCheck,omitempty"`
+
+	// KubeletHealthCheck is the amount of time to wait for a healthy kubelet
+	// during "kubeadm init" and "kubeadm join".
+	// +optional
+	KubeletHealthCheck *metav1.Duration `json:"kubeletHealthCheck,omitempty"`
+
+	// KubernetesAPICall is the amount of time to wait for the kubeadm client to complete a request to
+	// the API server. This applies to all types of methods (GET, POST, etc).
+	// +optional
+	KubernetesAPICall *metav1.Duration `json:"kubernetesAPICall,omitempty"`
+
+	// EtcdAPICall is the amount of time to wait for the kubeadm etcd client to complete a request to
+	// the etcd cluster.
+	// +optional
+	EtcdAPICall *metav1.Duration `json:"etcdAPICall,omitempty"`
+
+	// TLSBootstrap is the amount of time to wait for the kubelet to complete TLS bootstrap
+	// for a joining node.
+	// +optional
+	TLSBootstrap *metav1.Duration `json:"tlsBootstrap,omitempty"`
+
+	// Discovery is the amount of time to wait for kubeadm to validate the API server identity
+	// for a joining node.
+	// +optional
+	Discovery *metav1.Duration `json:"discovery,omitempty"`
+}
+
 // BootstrapTokenDiscovery is used to set the options for bootstrap token based discovery.
 type BootstrapTokenDiscovery struct {
 	// Token is a token used to validate cluster information
@@ -604,3 +667,95 @@ type Patches struct {
 	// +optional
 	Directory string `json:"directory,omitempty"`
 }
+
+// KubeletConfiguration defines a subset of the kubelet configuration, see
+// https://kubernetes.io/docs/reference/config-api/kubelet-config.v1beta1/ for the documentation of each field.
+// The field names and types match the KubeletConfiguration type in the kubelet.config.k8s.io/v1beta1 API.
+type KubeletConfiguration struct {
+	// MaxPods is the maximum number of Pods that can run on the kubelet.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxPods *int32 `json:"maxPods,omitempty"`
+
+	// PodPidsLimit is the maximum number of PIDs in any pod.
+	// +optional
+	PodPidsLimit *int64 `json:"podPidsLimit,omitempty"`
+
+	// KubeReserved is a set of ResourceName=ResourceQuantity (e.g. cpu=200m,memory=150G,ephemeral-storage=1G,pid=100)
+	// pairs that describe resources reserved for Kubernetes system components.
+	// +optional
+	KubeReserved map[string]string `json:"kubeReserved,omitempty"`
+
+	// SystemReserved is a set of ResourceName=ResourceQuantity (e.g. cpu=200m,memory=150G,ephemeral-storage=1G,pid=100)
+	// pairs that describe resources reserved for non-Kubernetes components.
+	// +optional
+	SystemReserved map[string]string `json:"systemReserved,omitempty"`
+
+	// EvictionHard is a map of signal names to quantities that defines hard eviction thresholds,
+	// e.g. {"memory.available": "300Mi"}; quantities can be expressed as percentages, e.g. "10%".
+	// +optional
+	EvictionHard map[string]string `json:"evictionHard,omitempty"`
+
+	// EvictionSoft is a map of signal names to quantities that defines soft eviction thresholds,
+	// e.g. {"memory.available": "300Mi"}; quantities can be expressed as percentages, e.g. "10%".
+	// +optional
+	EvictionSoft map[string]string `json:"evictionSoft,omitempty"`
+
+	// EvictionSoftGracePeriod is a map of signal names to durations that defines grace periods for each
+	// soft eviction signal, e.g. {"memory.available": "30s"}.
+	// +optional
+	EvictionSoftGracePeriod map[string]string `json:"evictionSoftGracePeriod,omitempty"`
+
+	// ImageGCHighThresholdPercent is the percent of disk usage after which image garbage collection is always run.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ImageGCHighThresholdPercent *int32 `json:"imageGCHighThresholdPercent,omitempty"`
+
+	// ImageGCLowThresholdPercent is the percent of disk usage before which image garbage collection is never run.
+	// It must be lower than ImageGCHighThresholdPercent.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	ImageGCLowThresholdPercent *int32 `json:"imageGCLowThresholdPercent,omitempty"`
+
+	// CPUManagerPolicy is the name of the policy to use by the CPU manager.
+	// +optional
+	// +kubebuilder:validation:Enum=none;static
+	CPUManagerPolicy string `json:"cpuManagerPolicy,omitempty"`
+
+	// TopologyManagerPolicy is the name of the topology manager policy to use.
+	// +optional
+	// +kubebuilder:validation:Enum=none;best-effort;restricted;single-numa-node
+	TopologyManagerPolicy string `json:"topologyManagerPolicy,omitempty"`
+
+	// SerializeImagePulls when enabled, tells the kubelet to pull images one at a time.
+	// +optional
+	SerializeImagePulls *bool `json:"serializeImagePulls,omitempty"`
+
+	// MaxParallelImagePulls sets the maximum number of image pulls in parallel.
+	// It can be set only when SerializeImagePulls is false.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxParallelImagePulls *int32 `json:"maxParallelImagePulls,omitempty"`
+
+	// ContainerLogMaxSize is a quantity defining the maximum size of the container log file before it is rotated,
+	// e.g. "10Mi".
+	// +optional
+	ContainerLogMaxSize string `json:"containerLogMaxSize,omitempty"`
+
+	// ContainerLogMaxFiles specifies the maximum number of container log files that can be present for a container.
+	// +optional
+	// +kubebuilder:validation:Minimum=2
+	ContainerLogMaxFiles *int32 `json:"containerLogMaxFiles,omitempty"`
+
+	// ShutdownGracePeriod specifies the total duration that the node should delay the shutdown
+	// and total grace period for pod termination during a node shutdown.
+	// +optional
+	ShutdownGracePeriod *metav1.Duration `json:"shutdownGracePeriod,omitempty"`
+
+	// ShutdownGracePeriodCriticalPods specifies the duration used to terminate critical pods during a node shutdown.
+	// It must be less than or equal to ShutdownGracePeriod.
+	// +optional
+	ShutdownGracePeriodCriticalPods *metav1.Duration `json:"shutdownGracePeriodCriticalPods,omitempty"`
+}
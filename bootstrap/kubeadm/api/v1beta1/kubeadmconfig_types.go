@@ -18,7 +18,11 @@ package v1beta1
 
 import (
 	"fmt"
+	"strconv"
+	"strings"
+	"time"
 
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
@@ -63,6 +67,14 @@ type KubeadmConfigSpec struct {
 	// +optional
 	JoinConfiguration *JoinConfiguration `json:"joinConfiguration,omitempty"`
 
+	// KubeletConfiguration defines kubelet settings applied on top of the kubelet configuration generated by kubeadm.
+	// The settings are passed to kubeadm as a patch for the "kubeletconfiguration" target, written in the directory
+	// defined in the patches options of the InitConfiguration or JoinConfiguration, or in /etc/kubernetes/patches if
+	// not defined.
+	// NOTE: Patching the kubelet configuration is supported by kubeadm starting from Kubernetes v1.25.
+	// +optional
+	KubeletConfiguration *KubeletConfiguration `json:"kubeletConfiguration,omitempty"`
+
 	// Files specifies extra files to be passed to user_data upon creation.
 	// +optional
 	Files []File `json:"files,omitempty"`
@@ -141,6 +153,7 @@ func (c *KubeadmConfigSpec) Validate(pathPrefix *field.Path) field.ErrorList {
 	allErrs = append(allErrs, c.validateFiles(pathPrefix)...)
 	allErrs = append(allErrs, c.validateUsers(pathPrefix)...)
 	allErrs = append(allErrs, c.validateIgnition(pathPrefix)...)
+	allErrs = append(allErrs, c.validateKubeletConfiguration(pathPrefix)...)
 
 	return allErrs
 }
@@ -245,6 +258,94 @@ func (c *KubeadmConfigSpec) validateUsers(pathPrefix *field.Path) field.ErrorLis
 	return allErrs
 }
 
+func (c *KubeadmConfigSpec) validateKubeletConfiguration(pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	kubeletConfiguration := c.KubeletConfiguration
+	if kubeletConfiguration == nil {
+		return allErrs
+	}
+	path := pathPrefix.Child("kubeletConfiguration")
+
+	for _, reserved := range []struct {
+		name  string
+		value map[string]string
+	}{
+		{name: "kubeReserved", value: kubeletConfiguration.KubeReserved},
+		{name: "systemReserved", value: kubeletConfiguration.SystemReserved},
+	} {
+		for resourceName, quantity := range reserved.value {
+			if _, err := resource.ParseQuantity(quantity); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child(reserved.name).Key(resourceName), quantity, "must be a valid quantity"))
+			}
+		}
+	}
+
+	for _, eviction := range []struct {
+		name  string
+		value map[string]string
+	}{
+		{name: "evictionHard", value: kubeletConfiguration.EvictionHard},
+		{name: "evictionSoft", value: kubeletConfiguration.EvictionSoft},
+	} {
+		for signal, threshold := range eviction.value {
+			if strings.HasSuffix(threshold, "%") {
+				if percentage, err := strconv.ParseFloat(strings.TrimSuffix(threshold, "%"), 64); err != nil || percentage < 0 || percentage > 100 {
+					allErrs = append(allErrs, field.Invalid(path.Child(eviction.name).Key(signal), threshold, "must be a valid percentage between 0% and 100%"))
+				}
+				continue
+			}
+			if _, err := resource.ParseQuantity(threshold); err != nil {
+				allErrs = append(allErrs, field.Invalid(path.Child(eviction.name).Key(signal), threshold, "must be a valid quantity or percentage"))
+			}
+		}
+	}
+
+	for signal, gracePeriod := range kubeletConfiguration.EvictionSoftGracePeriod {
+		if _, ok := kubeletConfiguration.EvictionSoft[signal]; !ok {
+			allErrs = append(allErrs, field.Invalid(path.Child("evictionSoftGracePeriod").Key(signal), gracePeriod, "must be set only for signals defined in evictionSoft"))
+			continue
+		}
+		if d, err := time.ParseDuration(gracePeriod); err != nil || d < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("evictionSoftGracePeriod").Key(signal), gracePeriod, "must be a valid duration greater than or equal to 0"))
+		}
+	}
+	for signal := range kubeletConfiguration.EvictionSoft {
+		if _, ok := kubeletConfiguration.EvictionSoftGracePeriod[signal]; !ok {
+			allErrs = append(allErrs, field.Required(path.Child("evictionSoftGracePeriod").Key(signal), "must be set for each signal defined in evictionSoft"))
+		}
+	}
+
+	if kubeletConfiguration.ImageGCHighThresholdPercent != nil && kubeletConfiguration.ImageGCLowThresholdPercent != nil &&
+		*kubeletConfiguration.ImageGCLowThresholdPercent >= *kubeletConfiguration.ImageGCHighThresholdPercent {
+		allErrs = append(allErrs, field.Invalid(path.Child("imageGCLowThresholdPercent"), *kubeletConfiguration.ImageGCLowThresholdPercent, "must be lower than imageGCHighThresholdPercent"))
+	}
+
+	if kubeletConfiguration.MaxParallelImagePulls != nil && *kubeletConfiguration.MaxParallelImagePulls > 1 &&
+		(kubeletConfiguration.SerializeImagePulls == nil || *kubeletConfiguration.SerializeImagePulls) {
+		allErrs = append(allErrs, field.Invalid(path.Child("maxParallelImagePulls"), *kubeletConfiguration.MaxParallelImagePulls, "can be greater than 1 only if serializeImagePulls is false"))
+	}
+
+	if kubeletConfiguration.ContainerLogMaxSize != "" {
+		if _, err := resource.ParseQuantity(kubeletConfiguration.ContainerLogMaxSize); err != nil {
+			allErrs = append(allErrs, field.Invalid(path.Child("containerLogMaxSize"), kubeletConfiguration.ContainerLogMaxSize, "must be a valid quantity"))
+		}
+	}
+
+	if d := kubeletConfiguration.ShutdownGracePeriod; d != nil && d.Duration < 0 {
+		allErrs = append(allErrs, field.Invalid(path.Child("shutdownGracePeriod"), d.Duration.String(), "must be greater than or equal to 0"))
+	}
+	if d := kubeletConfiguration.ShutdownGracePeriodCriticalPods; d != nil {
+		if d.Duration < 0 {
+			allErrs = append(allErrs, field.Invalid(path.Child("shutdownGracePeriodCriticalPods"), d.Duration.String(), "must be greater than or equal to 0"))
+		} else if kubeletConfiguration.ShutdownGracePeriod == nil || d.Duration > kubeletConfiguration.ShutdownGracePeriod.Duration {
+			allErrs = append(allErrs, field.Invalid(path.Child("shutdownGracePeriodCriticalPods"), d.Duration.String(), "must be less than or equal to shutdownGracePeriod"))
+		}
+	}
+
+	return allErrs
+}
+
 func (c *KubeadmConfigSpec) validateIgnition(pathPrefix *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 
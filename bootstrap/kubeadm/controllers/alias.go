@@ -26,6 +26,7 @@ import (
 
 	kubeadmbootstrapcontrollers "sigs.k8s.io/cluster-api/bootstrap/kubeadm/internal/controllers"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/util/secret"
 )
 
 // Following types provides access to reconcilers implemented in internal/controllers, thus
@@ -48,6 +49,10 @@ type KubeadmConfigReconciler struct {
 
 	// TokenTTL is the amount of time a bootstrap token (and therefore a KubeadmConfig) will be valid.
 	TokenTTL time.Duration
+
+	// CertificatesStore is the store used to read and save cluster certificates.
+	// If nil, certificates are stored in Kubernetes Secrets.
+	CertificatesStore secret.Store
 }
 
 // SetupWithManager sets up the reconciler with the Manager.
@@ -58,5 +63,6 @@ func (r *KubeadmConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl
 		Tracker:             r.Tracker,
 		WatchFilterValue:    r.WatchFilterValue,
 		TokenTTL:            r.TokenTTL,
+		CertificatesStore:   r.CertificatesStore,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -18,7 +18,9 @@ package controllers
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"path"
 	"strconv"
 	"time"
 
@@ -62,8 +64,18 @@ import (
 const (
 	// DefaultTokenTTL is the default TTL used for tokens.
 	DefaultTokenTTL = 15 * time.Minute
+
+	// defaultPatchesDirectory is the directory where the kubeadm patch for the KubeletConfiguration is written
+	// when the patches directory is not defined in the InitConfiguration or JoinConfiguration.
+	defaultPatchesDirectory = "/etc/kubernetes/patches"
+
+	// kubeletConfigurationPatchFile is the name of the file containing the kubeadm patch for the KubeletConfiguration.
+	kubeletConfigurationPatchFile = "kubeletconfiguration0+strategic.json"
 )
 
+// minVerKubeletConfigurationPatch is the min version of kubeadm supporting patches for the "kubeletconfiguration" target.
+var minVerKubeletConfigurationPatch = semver.MustParse("1.25.0")
+
 // InitLocker is a lock that is used around kubeadm init.
 type InitLocker interface {
 	Lock(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine) bool
@@ -86,6 +98,10 @@ type KubeadmConfigReconciler struct {
 
 	// TokenTTL is the amount of time a bootstrap token (and therefore a KubeadmConfig) will be valid.
 	TokenTTL time.Duration
+
+	// CertificatesStore is the store used to read and save cluster certificates.
+	// If nil, certificates are stored in Kubernetes Secrets.
+	CertificatesStore secret.Store
 }
 
 // Scope is a scoped struct used during reconciliation.
@@ -266,6 +282,10 @@ func (r *KubeadmConfigReconciler) reconcile(ctx context.Context, scope *Scope, c
 		return ctrl.Result{}, nil
 	// Status is ready means a config has been generated.
 	case config.Status.Ready:
+		// Regenerate the bootstrap data if a re-bootstrap of the Machine has been requested and not yet acknowledged.
+		if value, ok := config.GetAnnotations()[clusterv1.RebootstrapAnnotation]; ok && config.GetAnnotations()[clusterv1.RebootstrapAcknowledgedAnnotation] != value {
+			return r.reconcileRebootstrap(ctx, scope, value)
+		}
 		if config.Spec.JoinConfiguration != nil && config.Spec.JoinConfiguration.Discovery.BootstrapToken != nil {
 			if !configOwner.HasNodeRefs() {
 				// If the BootstrapToken has been generated for a join but the config owner has no nodeRefs,
@@ -336,11 +356,11 @@ func (r *KubeadmConfigReconciler) rotateMachinePoolBootstrapToken(ctx context.Co
 	}
 
 	token := config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
-	shouldRotate, err := shouldRotate(ctx, remoteClient, token, r.TokenTTL)
+	rotationTime, err := tokenRotationTime(ctx, remoteClient, token, r.TokenTTL)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
-	if shouldRotate {
+	if rotationTime.Before(time.Now().UTC()) {
 		log.Info("Creating new bootstrap token, the existing one should be rotated")
 		token, err := createToken(ctx, remoteClient, r.TokenTTL)
 		if err != nil {
@@ -353,11 +373,82 @@ func (r *KubeadmConfigReconciler) rotateMachinePoolBootstrapToken(ctx context.Co
 		// update the bootstrap data
 		return r.joinWorker(ctx, scope)
 	}
+
+	// Signal the upcoming rotation of the bootstrap data, so infrastructure providers can get prepared for it.
+	if err := r.reconcileBootstrapDataRotation(ctx, scope, rotationTime); err != nil {
+		return ctrl.Result{}, err
+	}
 	return ctrl.Result{
 		RequeueAfter: r.TokenTTL / 3,
 	}, nil
 }
 
+// reconcileBootstrapDataRotation annotates the bootstrap data secret with the time the bootstrap data is going to be rotated.
+// NOTE: The annotation is dropped when the bootstrap data are rotated, given that the secret is re-generated.
+func (r *KubeadmConfigReconciler) reconcileBootstrapDataRotation(ctx context.Context, scope *Scope, rotationTime time.Time) error {
+	secret := &corev1.Secret{}
+	if err := r.SecretCachingClient.Get(ctx, client.ObjectKey{Namespace: scope.Config.Namespace, Name: scope.Config.Name}, secret); err != nil {
+		return errors.Wrapf(err, "failed to get bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	}
+
+	value := rotationTime.UTC().Format(time.RFC3339)
+	if secret.GetAnnotations()[clusterv1.BootstrapDataRotationAnnotation] == value {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(secret, r.Client)
+	if err != nil {
+		return errors.Wrapf(err, "failed to annotate bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	}
+	annotations.AddAnnotations(secret, map[string]string{clusterv1.BootstrapDataRotationAnnotation: value})
+	if err := patchHelper.Patch(ctx, secret); err != nil {
+		return errors.Wrapf(err, "failed to annotate bootstrap data secret for KubeadmConfig %s/%s", scope.Config.Namespace, scope.Config.Name)
+	}
+	return nil
+}
+
+// reconcileRebootstrap regenerates the bootstrap data for the re-bootstrap of a Machine in place, and acknowledges
+// the re-bootstrap request once the bootstrap data secret is updated.
+// NOTE: Only worker Machines can be re-bootstrapped; the bootstrap data is regenerated with a new bootstrap token,
+// given that the existing one is already consumed or expired. Re-running kubeadm init or kubeadm join --control-plane
+// on an existing control plane node requires the node to be reset first, so re-bootstrap requests for control
+// plane Machines are not acknowledged and are reported with the RebootstrapSucceeded condition instead.
+func (r *KubeadmConfigReconciler) reconcileRebootstrap(ctx context.Context, scope *Scope, value string) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	config := scope.Config
+
+	if scope.ConfigOwner.IsControlPlaneMachine() || config.Spec.JoinConfiguration == nil {
+		if !conditions.IsFalse(config, bootstrapv1.RebootstrapSucceededCondition) {
+			log.Info("Ignoring re-bootstrap request, only worker machines can be re-bootstrapped", "rebootstrap", value)
+		}
+		conditions.MarkFalse(config, bootstrapv1.RebootstrapSucceededCondition, bootstrapv1.RebootstrapNotSupportedReason, clusterv1.ConditionSeverityWarning,
+			"Re-bootstrap request %q ignored: control plane machines cannot be re-bootstrapped in place, the machine must be replaced instead", value)
+		return ctrl.Result{}, nil
+	}
+
+	if config.Spec.JoinConfiguration.Discovery.BootstrapToken != nil {
+		remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(scope.Cluster))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		token, err := createToken(ctx, remoteClient, r.TokenTTL)
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to create new bootstrap token")
+		}
+		config.Spec.JoinConfiguration.Discovery.BootstrapToken.Token = token
+	}
+
+	log.Info("Regenerating bootstrap data for the re-bootstrap of the machine", "rebootstrap", value)
+	res, err := r.joinWorker(ctx, scope)
+	if err != nil || !res.IsZero() {
+		return res, err
+	}
+
+	annotations.AddAnnotations(config, map[string]string{clusterv1.RebootstrapAcknowledgedAnnotation: value})
+	conditions.MarkTrue(config, bootstrapv1.RebootstrapSucceededCondition)
+	return ctrl.Result{}, nil
+}
+
 func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Context, scope *Scope) (_ ctrl.Result, reterr error) {
 	// initialize the DataSecretAvailableCondition if missing.
 	// this is required in order to avoid the condition's LastTransitionTime to flicker in case of errors surfacing
@@ -421,7 +512,18 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		}
 	}
 
-	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(scope.Config.Spec.InitConfiguration, parsedVersion)
+	// DeepCopy the InitConfiguration to prevent persisting the patches options required to apply the KubeletConfiguration
+	// and the defaulted timeouts.
+	initConfiguration := scope.Config.Spec.InitConfiguration.DeepCopy()
+	initConfiguration.Timeouts = resolveControlPlaneComponentHealthCheckTimeout(scope.Config, initConfiguration.Timeouts)
+	kubeletConfigurationFiles, patches, err := resolveKubeletConfiguration(scope.Config, initConfiguration.Patches, parsedVersion)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	initConfiguration.Patches = patches
+
+	initdata, err := kubeadmtypes.MarshalInitConfigurationForVersion(initConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal init configuration")
 		return ctrl.Result{}, err
@@ -451,16 +553,14 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 	// Otherwise rely on certificates generated by the ControlPlane controller.
 	// Note: A cluster does not have a ControlPlane reference when using standalone CP machines.
 	if scope.Cluster.Spec.ControlPlaneRef == nil {
-		err = certificates.LookupOrGenerateCached(
+		err = certificates.LookupOrGenerateFromStore(
 			ctx,
-			r.SecretCachingClient,
-			r.Client,
+			r.certificatesStore(),
 			util.ObjectKey(scope.Cluster),
 			*metav1.NewControllerRef(scope.Config, bootstrapv1.GroupVersion.WithKind("KubeadmConfig")))
 	} else {
-		err = certificates.LookupCached(ctx,
-			r.SecretCachingClient,
-			r.Client,
+		err = certificates.LookupFromStore(ctx,
+			r.certificatesStore(),
 			util.ObjectKey(scope.Cluster))
 	}
 	if err != nil {
@@ -480,6 +580,7 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubeletConfigurationFiles...)
 
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
@@ -527,14 +628,21 @@ func (r *KubeadmConfigReconciler) handleClusterNotInitialized(ctx context.Contex
 	return ctrl.Result{}, nil
 }
 
+// certificatesStore returns the store used to read and save cluster certificates.
+func (r *KubeadmConfigReconciler) certificatesStore() secret.Store {
+	if r.CertificatesStore != nil {
+		return r.CertificatesStore
+	}
+	return secret.NewSecretStore(r.SecretCachingClient, r.Client)
+}
+
 func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope) (ctrl.Result, error) {
 	scope.Info("Creating BootstrapData for the worker node")
 
 	certificates := secret.NewCertificatesForWorker(scope.Config.Spec.JoinConfiguration.CACertPath)
-	err := certificates.LookupCached(
+	err := certificates.LookupFromStore(
 		ctx,
-		r.SecretCachingClient,
-		r.Client,
+		r.certificatesStore(),
 		util.ObjectKey(scope.Cluster),
 	)
 	if err != nil {
@@ -569,6 +677,14 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		joinConfiguration.NodeRegistration.Taints = append(joinConfiguration.NodeRegistration.Taints, clusterv1.NodeUninitializedTaint)
 	}
 
+	// Add the patches options required to apply the KubeletConfiguration, if any.
+	kubeletConfigurationFiles, patches, err := resolveKubeletConfiguration(scope.Config, joinConfiguration.Patches, parsedVersion)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	joinConfiguration.Patches = patches
+
 	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(joinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
@@ -589,6 +705,7 @@ func (r *KubeadmConfigReconciler) joinWorker(ctx context.Context, scope *Scope)
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubeletConfigurationFiles...)
 
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
@@ -646,10 +763,9 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 	}
 
 	certificates := secret.NewControlPlaneJoinCerts(scope.Config.Spec.ClusterConfiguration)
-	err := certificates.LookupCached(
+	err := certificates.LookupFromStore(
 		ctx,
-		r.SecretCachingClient,
-		r.Client,
+		r.certificatesStore(),
 		util.ObjectKey(scope.Cluster),
 	)
 	if err != nil {
@@ -676,7 +792,18 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		return ctrl.Result{}, errors.Wrapf(err, "failed to parse kubernetes version %q", kubernetesVersion)
 	}
 
-	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(scope.Config.Spec.JoinConfiguration, parsedVersion)
+	// DeepCopy the JoinConfiguration to prevent persisting the patches options required to apply the KubeletConfiguration
+	// and the defaulted timeouts.
+	joinConfiguration := scope.Config.Spec.JoinConfiguration.DeepCopy()
+	joinConfiguration.Timeouts = resolveControlPlaneComponentHealthCheckTimeout(scope.Config, joinConfiguration.Timeouts)
+	kubeletConfigurationFiles, patches, err := resolveKubeletConfiguration(scope.Config, joinConfiguration.Patches, parsedVersion)
+	if err != nil {
+		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, err
+	}
+	joinConfiguration.Patches = patches
+
+	joinData, err := kubeadmtypes.MarshalJoinConfigurationForVersion(joinConfiguration, parsedVersion)
 	if err != nil {
 		scope.Error(err, "Failed to marshal join configuration")
 		return ctrl.Result{}, err
@@ -692,6 +819,7 @@ func (r *KubeadmConfigReconciler) joinControlplane(ctx context.Context, scope *S
 		conditions.MarkFalse(scope.Config, bootstrapv1.DataSecretAvailableCondition, bootstrapv1.DataSecretGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return ctrl.Result{}, err
 	}
+	files = append(files, kubeletConfigurationFiles...)
 
 	users, err := r.resolveUsers(ctx, scope.Config)
 	if err != nil {
@@ -760,6 +888,60 @@ func (r *KubeadmConfigReconciler) resolveFiles(ctx context.Context, cfg *bootstr
 	return collected, nil
 }
 
+// resolveKubeletConfiguration returns the file with the kubeadm patch applying .Spec.KubeletConfiguration and the
+// patches options required for kubeadm to apply it, i.e. the given patches options, defaulting the patches directory
+// if not set. If .Spec.KubeletConfiguration is not set, the given patches options are returned unchanged.
+func resolveKubeletConfiguration(cfg *bootstrapv1.KubeadmConfig, patches *bootstrapv1.Patches, version semver.Version) ([]bootstrapv1.File, *bootstrapv1.Patches, error) {
+	if cfg.Spec.KubeletConfiguration == nil {
+		return nil, patches, nil
+	}
+
+	if version.LT(minVerKubeletConfigurationPatch) {
+		return nil, nil, errors.Errorf("failed to resolve kubeletConfiguration: Kubernetes %s does not support patching the kubelet configuration, v%s or later is required", version, minVerKubeletConfigurationPatch)
+	}
+
+	content, err := json.Marshal(cfg.Spec.KubeletConfiguration)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to marshal kubeletConfiguration")
+	}
+
+	patches = patches.DeepCopy()
+	if patches == nil {
+		patches = &bootstrapv1.Patches{}
+	}
+	if patches.Directory == "" {
+		patches.Directory = defaultPatchesDirectory
+	}
+
+	return []bootstrapv1.File{
+		{
+			Path:        path.Join(patches.Directory, kubeletConfigurationPatchFile),
+			Owner:       "root:root",
+			Permissions: "0644",
+			Content:     string(content),
+		},
+	}, patches, nil
+}
+
+// resolveControlPlaneComponentHealthCheckTimeout returns the given timeouts, defaulting ControlPlaneComponentHealthCheck
+// to .Spec.ClusterConfiguration.APIServer.TimeoutForControlPlane if not set; this is required because kubeadm v1beta4
+// replaced the latter with the former.
+func resolveControlPlaneComponentHealthCheckTimeout(cfg *bootstrapv1.KubeadmConfig, timeouts *bootstrapv1.Timeouts) *bootstrapv1.Timeouts {
+	if cfg.Spec.ClusterConfiguration == nil || cfg.Spec.ClusterConfiguration.APIServer.TimeoutForControlPlane == nil {
+		return timeouts
+	}
+	if timeouts != nil && timeouts.ControlPlaneComponentHealthCheck != nil {
+		return timeouts
+	}
+
+	timeouts = timeouts.DeepCopy()
+	if timeouts == nil {
+		timeouts = &bootstrapv1.Timeouts{}
+	}
+	timeouts.ControlPlaneComponentHealthCheck = cfg.Spec.ClusterConfiguration.APIServer.TimeoutForControlPlane.DeepCopy()
+	return timeouts
+}
+
 // resolveSecretFileContent returns file content fetched from a referenced secret object.
 func (r *KubeadmConfigReconciler) resolveSecretFileContent(ctx context.Context, ns string, source bootstrapv1.File) ([]byte, error) {
 	secret := &corev1.Secret{}
@@ -24,6 +24,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/blang/semver/v4"
 	ignition "github.com/flatcar/ignition/config/v2_3"
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
@@ -1205,6 +1206,135 @@ func TestBootstrapTokenTTLExtension(t *testing.T) {
 	}
 }
 
+func TestKubeadmConfigReconciler_Rebootstrap(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Status.InfrastructureReady = true
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+	controlPlaneInitMachine := newControlPlaneMachine(cluster, "control-plane-init-machine")
+	initConfig := newControlPlaneInitKubeadmConfig(controlPlaneInitMachine.Namespace, "control-plane-init-config")
+	addKubeadmConfigToMachine(initConfig, controlPlaneInitMachine)
+
+	workerMachine := newWorkerMachineForCluster(cluster)
+	workerJoinConfig := newWorkerJoinKubeadmConfig(metav1.NamespaceDefault, "worker-join-cfg")
+	addKubeadmConfigToMachine(workerJoinConfig, workerMachine)
+
+	objects := []client.Object{
+		cluster,
+		workerMachine,
+		workerJoinConfig,
+	}
+	objects = append(objects, createSecrets(t, cluster, initConfig)...)
+	myclient := fake.NewClientBuilder().WithObjects(objects...).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}, &clusterv1.Machine{}).Build()
+	k := &KubeadmConfigReconciler{
+		Client:              myclient,
+		SecretCachingClient: myclient,
+		Tracker:             remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), myclient, myclient.Scheme(), client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+		KubeadmInitLock:     &myInitLocker{},
+		TokenTTL:            DefaultTokenTTL,
+	}
+	request := ctrl.Request{
+		NamespacedName: client.ObjectKey{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "worker-join-cfg",
+		},
+	}
+	_, err := k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	cfg, err := getKubeadmConfig(myclient, "worker-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Status.Ready).To(BeTrue())
+	token := cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
+	dataSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "worker-join-cfg"}, dataSecret)).To(Succeed())
+	data := dataSecret.Data["value"]
+
+	// Request a re-bootstrap of the machine.
+	cfg.Annotations = map[string]string{clusterv1.RebootstrapAnnotation: "1"}
+	g.Expect(myclient.Update(ctx, cfg)).To(Succeed())
+
+	_, err = k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// The bootstrap data is regenerated with a new token, and the re-bootstrap request is acknowledged.
+	cfg, err = getKubeadmConfig(myclient, "worker-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Annotations).To(HaveKeyWithValue(clusterv1.RebootstrapAcknowledgedAnnotation, "1"))
+	g.Expect(conditions.IsTrue(cfg, bootstrapv1.RebootstrapSucceededCondition)).To(BeTrue())
+	g.Expect(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token).ToNot(Equal(token))
+	token = cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token
+
+	dataSecret = &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "worker-join-cfg"}, dataSecret)).To(Succeed())
+	g.Expect(dataSecret.Data["value"]).ToNot(Equal(data))
+	g.Expect(string(dataSecret.Data["value"])).To(ContainSubstring(token))
+
+	// Acknowledged re-bootstrap requests are not processed again.
+	_, err = k.Reconcile(ctx, request)
+	g.Expect(err).ToNot(HaveOccurred())
+	cfg, err = getKubeadmConfig(myclient, "worker-join-cfg", metav1.NamespaceDefault)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(cfg.Spec.JoinConfiguration.Discovery.BootstrapToken.Token).To(Equal(token))
+}
+
+func TestKubeadmConfigReconciler_RebootstrapControlPlane(t *testing.T) {
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster").Build()
+	cluster.Status.InfrastructureReady = true
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	cluster.Spec.ControlPlaneEndpoint = clusterv1.APIEndpoint{Host: "100.105.150.1", Port: 6443}
+
+	tests := []struct {
+		name   string
+		config *bootstrapv1.KubeadmConfig
+	}{
+		{
+			name:   "control plane machine initializing the cluster",
+			config: newControlPlaneInitKubeadmConfig(metav1.NamespaceDefault, "control-plane-cfg"),
+		},
+		{
+			name:   "control plane machine joining the cluster",
+			config: newControlPlaneJoinKubeadmConfig(metav1.NamespaceDefault, "control-plane-cfg"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlaneMachine := newControlPlaneMachine(cluster, "control-plane-machine")
+			config := tt.config
+			addKubeadmConfigToMachine(config, controlPlaneMachine)
+			config.Status.Ready = true
+			config.Status.DataSecretName = pointer.String(config.Name)
+			config.Annotations = map[string]string{clusterv1.RebootstrapAnnotation: "1"}
+
+			myclient := fake.NewClientBuilder().WithObjects(cluster, controlPlaneMachine, config).WithStatusSubresource(&bootstrapv1.KubeadmConfig{}, &clusterv1.Machine{}).Build()
+			k := &KubeadmConfigReconciler{
+				Client:              myclient,
+				SecretCachingClient: myclient,
+				Tracker:             remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), myclient, myclient.Scheme(), client.ObjectKey{Name: cluster.Name, Namespace: cluster.Namespace}),
+				KubeadmInitLock:     &myInitLocker{},
+				TokenTTL:            DefaultTokenTTL,
+			}
+			_, err := k.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(config)})
+			g.Expect(err).ToNot(HaveOccurred())
+
+			// The re-bootstrap request is not acknowledged, and it is reported with a condition.
+			cfg, err := getKubeadmConfig(myclient, config.Name, metav1.NamespaceDefault)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cfg.Annotations).ToNot(HaveKey(clusterv1.RebootstrapAcknowledgedAnnotation))
+			g.Expect(conditions.IsFalse(cfg, bootstrapv1.RebootstrapSucceededCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(cfg, bootstrapv1.RebootstrapSucceededCondition)).To(Equal(bootstrapv1.RebootstrapNotSupportedReason))
+
+			// The bootstrap data secret is not regenerated.
+			g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: config.Name}, &corev1.Secret{})).ToNot(Succeed())
+		})
+	}
+}
+
 func TestBootstrapTokenRotationMachinePool(t *testing.T) {
 	_ = feature.MutableGates.Set("MachinePool=true")
 	g := NewWithT(t)
@@ -1350,6 +1480,13 @@ func TestBootstrapTokenRotationMachinePool(t *testing.T) {
 		g.Expect(bytes.Equal(tokenExpires[i], item.Data[bootstrapapi.BootstrapTokenExpirationKey])).To(BeTrue())
 	}
 
+	// ...the upcoming rotation of the bootstrap data is signaled on the bootstrap data secret...
+	expiration, err := time.Parse(time.RFC3339, string(tokenExpires[0]))
+	g.Expect(err).ToNot(HaveOccurred())
+	dataSecret := &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "workerpool-join-cfg"}, dataSecret)).To(Succeed())
+	g.Expect(dataSecret.Annotations).To(HaveKeyWithValue(clusterv1.BootstrapDataRotationAnnotation, expiration.Add(-k.TokenTTL/2).Format(time.RFC3339)))
+
 	// before token expires, it should rotate it
 	tokenExpires[0] = []byte(time.Now().UTC().Add(k.TokenTTL / 5).Format(time.RFC3339))
 	l.Items[0].Data[bootstrapapi.BootstrapTokenExpirationKey] = tokenExpires[0]
@@ -1384,6 +1521,11 @@ func TestBootstrapTokenRotationMachinePool(t *testing.T) {
 	}
 	g.Expect(foundOld).To(BeTrue())
 	g.Expect(foundNew).To(BeTrue())
+
+	// ...and the bootstrap data secret is re-generated without the bootstrap data rotation annotation.
+	dataSecret = &corev1.Secret{}
+	g.Expect(myclient.Get(ctx, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "workerpool-join-cfg"}, dataSecret)).To(Succeed())
+	g.Expect(dataSecret.Annotations).ToNot(HaveKey(clusterv1.BootstrapDataRotationAnnotation))
 }
 
 // Ensure the discovery portion of the JoinConfiguration gets generated correctly.
@@ -2095,6 +2237,141 @@ func TestKubeadmConfigReconciler_ResolveFiles(t *testing.T) {
 	}
 }
 
+func TestKubeadmConfigReconciler_ResolveKubeletConfiguration(t *testing.T) {
+	kubeletConfiguration := &bootstrapv1.KubeletConfiguration{
+		MaxPods:             pointer.Int32(200),
+		SystemReserved:      map[string]string{"memory": "500Mi"},
+		ShutdownGracePeriod: &metav1.Duration{Duration: 30 * time.Second},
+	}
+	expectedContent := `{"maxPods":200,"systemReserved":{"memory":"500Mi"},"shutdownGracePeriod":"30s"}`
+
+	tests := []struct {
+		name                 string
+		kubeletConfiguration *bootstrapv1.KubeletConfiguration
+		patches              *bootstrapv1.Patches
+		version              string
+		wantFiles            []bootstrapv1.File
+		wantPatches          *bootstrapv1.Patches
+		wantErr              bool
+	}{
+		{
+			name:        "returns the patches options unchanged if kubeletConfiguration is not set",
+			patches:     &bootstrapv1.Patches{Directory: "/tmp/patches"},
+			version:     "1.28.0",
+			wantPatches: &bootstrapv1.Patches{Directory: "/tmp/patches"},
+		},
+		{
+			name:                 "writes the patch to the default directory if patches options are not set",
+			kubeletConfiguration: kubeletConfiguration,
+			version:              "1.28.0",
+			wantFiles: []bootstrapv1.File{
+				{Path: "/etc/kubernetes/patches/kubeletconfiguration0+strategic.json", Owner: "root:root", Permissions: "0644", Content: expectedContent},
+			},
+			wantPatches: &bootstrapv1.Patches{Directory: "/etc/kubernetes/patches"},
+		},
+		{
+			name:                 "writes the patch to the directory defined in the patches options",
+			kubeletConfiguration: kubeletConfiguration,
+			patches:              &bootstrapv1.Patches{Directory: "/tmp/patches"},
+			version:              "1.25.0",
+			wantFiles: []bootstrapv1.File{
+				{Path: "/tmp/patches/kubeletconfiguration0+strategic.json", Owner: "root:root", Permissions: "0644", Content: expectedContent},
+			},
+			wantPatches: &bootstrapv1.Patches{Directory: "/tmp/patches"},
+		},
+		{
+			name:                 "returns an error for versions not supporting kubelet configuration patches",
+			kubeletConfiguration: kubeletConfiguration,
+			version:              "1.24.9",
+			wantErr:              true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cfg := &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: tt.kubeletConfiguration,
+				},
+			}
+			files, patches, err := resolveKubeletConfiguration(cfg, tt.patches, semver.MustParse(tt.version))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(files).To(Equal(tt.wantFiles))
+			g.Expect(patches).To(Equal(tt.wantPatches))
+		})
+	}
+}
+
+func TestKubeadmConfigReconciler_ResolveControlPlaneComponentHealthCheckTimeout(t *testing.T) {
+	timeoutForControlPlane := &metav1.Duration{Duration: 10 * time.Minute}
+	controlPlaneComponentHealthCheck := &metav1.Duration{Duration: 5 * time.Minute}
+
+	tests := []struct {
+		name                 string
+		clusterConfiguration *bootstrapv1.ClusterConfiguration
+		timeouts             *bootstrapv1.Timeouts
+		want                 *bootstrapv1.Timeouts
+	}{
+		{
+			name:                 "returns the given timeouts when the ClusterConfiguration is not set",
+			clusterConfiguration: nil,
+			timeouts:             &bootstrapv1.Timeouts{KubeletHealthCheck: controlPlaneComponentHealthCheck},
+			want:                 &bootstrapv1.Timeouts{KubeletHealthCheck: controlPlaneComponentHealthCheck},
+		},
+		{
+			name:                 "returns the given timeouts when timeoutForControlPlane is not set",
+			clusterConfiguration: &bootstrapv1.ClusterConfiguration{},
+			timeouts:             nil,
+			want:                 nil,
+		},
+		{
+			name: "defaults controlPlaneComponentHealthCheck to timeoutForControlPlane",
+			clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{TimeoutForControlPlane: timeoutForControlPlane},
+			},
+			timeouts: &bootstrapv1.Timeouts{KubeletHealthCheck: controlPlaneComponentHealthCheck},
+			want: &bootstrapv1.Timeouts{
+				ControlPlaneComponentHealthCheck: timeoutForControlPlane,
+				KubeletHealthCheck:               controlPlaneComponentHealthCheck,
+			},
+		},
+		{
+			name: "defaults controlPlaneComponentHealthCheck to timeoutForControlPlane when timeouts are not set",
+			clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{TimeoutForControlPlane: timeoutForControlPlane},
+			},
+			timeouts: nil,
+			want:     &bootstrapv1.Timeouts{ControlPlaneComponentHealthCheck: timeoutForControlPlane},
+		},
+		{
+			name: "does not override controlPlaneComponentHealthCheck",
+			clusterConfiguration: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{TimeoutForControlPlane: timeoutForControlPlane},
+			},
+			timeouts: &bootstrapv1.Timeouts{ControlPlaneComponentHealthCheck: controlPlaneComponentHealthCheck},
+			want:     &bootstrapv1.Timeouts{ControlPlaneComponentHealthCheck: controlPlaneComponentHealthCheck},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cfg := &bootstrapv1.KubeadmConfig{
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: tt.clusterConfiguration,
+				},
+			}
+			g.Expect(resolveControlPlaneComponentHealthCheckTimeout(cfg, tt.timeouts)).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestKubeadmConfigReconciler_ResolveUsers(t *testing.T) {
 	fakePasswd := "bar"
 	testSecret := &corev1.Secret{
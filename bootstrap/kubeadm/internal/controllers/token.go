@@ -98,22 +98,23 @@ func refreshToken(ctx context.Context, c client.Client, token string, ttl time.D
 	return c.Update(ctx, secret)
 }
 
-// shouldRotate returns true if an existing token is past half of its TTL and should to be rotated.
-func shouldRotate(ctx context.Context, c client.Client, token string, ttl time.Duration) (bool, error) {
+// tokenRotationTime returns the time after which an existing token is past half of its TTL and should to be rotated.
+// If the token does not exist anymore, a zero time is returned, so the token is rotated immediately.
+func tokenRotationTime(ctx context.Context, c client.Client, token string, ttl time.Duration) (time.Time, error) {
 	secret, err := getToken(ctx, c, token)
 	if err != nil {
 		// If the secret is deleted before due to unknown reasons, machine pools cannot be scaled up.
 		// Since that, secret should be rotated if missing.
 		// Normally, it is not expected to reach this line.
 		if apierrors.IsNotFound(err) {
-			return true, nil
+			return time.Time{}, nil
 		}
-		return false, err
+		return time.Time{}, err
 	}
 
 	expiration, err := time.Parse(time.RFC3339, string(secret.Data[bootstrapapi.BootstrapTokenExpirationKey]))
 	if err != nil {
-		return false, err
+		return time.Time{}, err
 	}
-	return expiration.Before(time.Now().UTC().Add(ttl / 2)), nil
+	return expiration.Add(-ttl / 2), nil
 }
@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -457,6 +458,106 @@ func TestKubeadmConfigValidate(t *testing.T) {
 			},
 			expectErr: true,
 		},
+		"valid kubeletConfiguration": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						MaxPods:                         pointer.Int32(200),
+						KubeReserved:                    map[string]string{"cpu": "200m", "memory": "1Gi"},
+						SystemReserved:                  map[string]string{"memory": "500Mi"},
+						EvictionHard:                    map[string]string{"memory.available": "100Mi", "nodefs.available": "10%"},
+						EvictionSoft:                    map[string]string{"memory.available": "300Mi"},
+						EvictionSoftGracePeriod:         map[string]string{"memory.available": "30s"},
+						ImageGCHighThresholdPercent:     pointer.Int32(85),
+						ImageGCLowThresholdPercent:      pointer.Int32(80),
+						SerializeImagePulls:             pointer.Bool(false),
+						MaxParallelImagePulls:           pointer.Int32(5),
+						ContainerLogMaxSize:             "10Mi",
+						ShutdownGracePeriod:             &metav1.Duration{Duration: 30 * time.Second},
+						ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: 10 * time.Second},
+					},
+				},
+			},
+			expectErr: false,
+		},
+		"kubeletConfiguration with invalid quantities": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						KubeReserved:        map[string]string{"cpu": "two"},
+						EvictionHard:        map[string]string{"memory.available": "110%"},
+						ContainerLogMaxSize: "10 megabytes",
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"kubeletConfiguration with evictionSoft without grace period": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						EvictionSoft: map[string]string{"memory.available": "300Mi"},
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"kubeletConfiguration with imageGCLowThresholdPercent greater than imageGCHighThresholdPercent": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						ImageGCHighThresholdPercent: pointer.Int32(80),
+						ImageGCLowThresholdPercent:  pointer.Int32(85),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"kubeletConfiguration with maxParallelImagePulls and serialized image pulls": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						MaxParallelImagePulls: pointer.Int32(5),
+					},
+				},
+			},
+			expectErr: true,
+		},
+		"kubeletConfiguration with shutdownGracePeriodCriticalPods greater than shutdownGracePeriod": {
+			in: &bootstrapv1.KubeadmConfig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "baz",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: bootstrapv1.KubeadmConfigSpec{
+					KubeletConfiguration: &bootstrapv1.KubeletConfiguration{
+						ShutdownGracePeriod:             &metav1.Duration{Duration: 10 * time.Second},
+						ShutdownGracePeriodCriticalPods: &metav1.Duration{Duration: 30 * time.Second},
+					},
+				},
+			},
+			expectErr: true,
+		},
 	}
 
 	for name, tt := range cases {
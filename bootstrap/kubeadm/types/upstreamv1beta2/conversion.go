@@ -84,12 +84,12 @@ func Convert_upstreamv1beta2_ClusterConfiguration_To_v1beta1_ClusterConfiguratio
 }
 
 func Convert_v1beta1_InitConfiguration_To_upstreamv1beta2_InitConfiguration(in *bootstrapv1.InitConfiguration, out *InitConfiguration, s apimachineryconversion.Scope) error {
-	// InitConfiguration.Patches does not exist in kubeadm v1beta2 API
+	// InitConfiguration.Patches and InitConfiguration.Timeouts do not exist in kubeadm v1beta2 API
 	return autoConvert_v1beta1_InitConfiguration_To_upstreamv1beta2_InitConfiguration(in, out, s)
 }
 
 func Convert_v1beta1_JoinConfiguration_To_upstreamv1beta2_JoinConfiguration(in *bootstrapv1.JoinConfiguration, out *JoinConfiguration, s apimachineryconversion.Scope) error {
-	// JoinConfiguration.Patches does not exist in kubeadm v1beta2 API
+	// JoinConfiguration.Patches and JoinConfiguration.Timeouts do not exist in kubeadm v1beta2 API
 	return autoConvert_v1beta1_JoinConfiguration_To_upstreamv1beta2_JoinConfiguration(in, out, s)
 }
 
@@ -98,3 +98,13 @@ func Convert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta2_NodeRegistration
 	// kubeadm v1beta2 API.
 	return autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta2_NodeRegistrationOptions(in, out, s)
 }
+
+func Convert_v1beta1_ControlPlaneComponent_To_upstreamv1beta2_ControlPlaneComponent(in *bootstrapv1.ControlPlaneComponent, out *ControlPlaneComponent, s apimachineryconversion.Scope) error {
+	// ControlPlaneComponent.ExtraEnvs does not exist in kubeadm v1beta2 API, dropping this info.
+	return autoConvert_v1beta1_ControlPlaneComponent_To_upstreamv1beta2_ControlPlaneComponent(in, out, s)
+}
+
+func Convert_v1beta1_LocalEtcd_To_upstreamv1beta2_LocalEtcd(in *bootstrapv1.LocalEtcd, out *LocalEtcd, s apimachineryconversion.Scope) error {
+	// LocalEtcd.ExtraEnvs does not exist in kubeadm v1beta2 API, dropping this info.
+	return autoConvert_v1beta1_LocalEtcd_To_upstreamv1beta2_LocalEtcd(in, out, s)
+}
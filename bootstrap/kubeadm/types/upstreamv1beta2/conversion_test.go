@@ -67,6 +67,8 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 		kubeadmInitConfigurationFuzzer,
 		kubeadmJoinConfigurationFuzzer,
 		kubeadmNodeRegistrationOptionsFuzzer,
+		kubeadmControlPlaneComponentFuzzer,
+		kubeadmLocalEtcdFuzzer,
 	}
 }
 
@@ -108,6 +110,10 @@ func kubeadmInitConfigurationFuzzer(obj *bootstrapv1.InitConfiguration, c fuzz.C
 	// InitConfiguration.SkipPhases does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
 	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
 	obj.SkipPhases = nil
+
+	// InitConfiguration.Timeouts does not exist in kubeadm v1beta2 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
 }
 
 func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.Continue) {
@@ -120,6 +126,10 @@ func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.C
 	// JoinConfiguration.SkipPhases does not exist in kubeadm v1beta1 API, so setting it to nil in order to avoid
 	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
 	obj.SkipPhases = nil
+
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1beta2 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
 }
 
 func kubeadmNodeRegistrationOptionsFuzzer(obj *bootstrapv1.NodeRegistrationOptions, c fuzz.Continue) {
@@ -130,3 +140,19 @@ func kubeadmNodeRegistrationOptionsFuzzer(obj *bootstrapv1.NodeRegistrationOptio
 	// avoid round trip errors.
 	obj.ImagePullPolicy = ""
 }
+
+func kubeadmControlPlaneComponentFuzzer(obj *bootstrapv1.ControlPlaneComponent, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ControlPlaneComponent.ExtraEnvs does not exist in kubeadm v1beta2 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
+	obj.ExtraEnvs = nil
+}
+
+func kubeadmLocalEtcdFuzzer(obj *bootstrapv1.LocalEtcd, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// LocalEtcd.ExtraEnvs does not exist in kubeadm v1beta2 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta2 -> v1beta1 round trip errors.
+	obj.ExtraEnvs = nil
+}
@@ -67,6 +67,10 @@ func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
 		initConfigurationFuzzer,
 		joinConfigurationFuzzer,
 		joinControlPlanesFuzzer,
+		kubeadmInitConfigurationFuzzer,
+		kubeadmJoinConfigurationFuzzer,
+		kubeadmControlPlaneComponentFuzzer,
+		kubeadmLocalEtcdFuzzer,
 	}
 }
 
@@ -100,3 +104,35 @@ func joinConfigurationFuzzer(obj *JoinConfiguration, c fuzz.Continue) {
 	// JoinConfiguration.SkipPhases does not exists in v1alpha4, so setting it to empty string in order to avoid v1beta3 --> v1alpha4 --> v1beta3 round trip errors.
 	obj.SkipPhases = nil
 }
+
+func kubeadmInitConfigurationFuzzer(obj *bootstrapv1.InitConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// InitConfiguration.Timeouts does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
+}
+
+func kubeadmJoinConfigurationFuzzer(obj *bootstrapv1.JoinConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// JoinConfiguration.Timeouts does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.Timeouts = nil
+}
+
+func kubeadmControlPlaneComponentFuzzer(obj *bootstrapv1.ControlPlaneComponent, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ControlPlaneComponent.ExtraEnvs does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.ExtraEnvs = nil
+}
+
+func kubeadmLocalEtcdFuzzer(obj *bootstrapv1.LocalEtcd, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// LocalEtcd.ExtraEnvs does not exist in kubeadm v1beta3 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta3 -> v1beta1 round trip errors.
+	obj.ExtraEnvs = nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstreamv1beta4
+
+import (
+	"sort"
+
+	apimachineryconversion "k8s.io/apimachinery/pkg/conversion"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+)
+
+// ConvertTo converts this ClusterConfiguration to the Hub version (v1beta1).
+func (src *ClusterConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.ClusterConfiguration)
+	return Convert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the ClusterConfiguration Hub version (v1beta1) to this version.
+func (dst *ClusterConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.ClusterConfiguration)
+	return Convert_v1beta1_ClusterConfiguration_To_upstreamv1beta4_ClusterConfiguration(src, dst, nil)
+}
+
+// ConvertTo converts this InitConfiguration to the Hub version (v1beta1).
+func (src *InitConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.InitConfiguration)
+	return Convert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the InitConfiguration Hub version (v1beta1) to this version.
+func (dst *InitConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.InitConfiguration)
+	return Convert_v1beta1_InitConfiguration_To_upstreamv1beta4_InitConfiguration(src, dst, nil)
+}
+
+// ConvertTo converts this JoinConfiguration to the Hub version (v1beta1).
+func (src *JoinConfiguration) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*bootstrapv1.JoinConfiguration)
+	return Convert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(src, dst, nil)
+}
+
+// ConvertFrom converts from the JoinConfiguration Hub version (v1beta1) to this version.
+func (dst *JoinConfiguration) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*bootstrapv1.JoinConfiguration)
+	return Convert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(src, dst, nil)
+}
+
+// Custom conversion from this API, kubeadm v1beta4, to the hub version, CABPK v1beta1.
+
+func Convert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in *ClusterConfiguration, out *bootstrapv1.ClusterConfiguration, s apimachineryconversion.Scope) error {
+	// ClusterConfiguration.Proxy, EncryptionAlgorithm, CertificateValidityPeriod and CACertificateValidityPeriod exist in v1beta4 types but not in bootstrapv1.ClusterConfiguration (Cluster API does not support them for now). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_ClusterConfiguration_To_v1beta1_ClusterConfiguration(in, out, s)
+}
+
+func Convert_upstreamv1beta4_ControlPlaneComponent_To_v1beta1_ControlPlaneComponent(in *ControlPlaneComponent, out *bootstrapv1.ControlPlaneComponent, s apimachineryconversion.Scope) error {
+	// ControlPlaneComponent.ExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	out.ExtraArgs = convertFromArgs(in.ExtraArgs)
+	return autoConvert_upstreamv1beta4_ControlPlaneComponent_To_v1beta1_ControlPlaneComponent(in, out, s)
+}
+
+func Convert_upstreamv1beta4_DNS_To_v1beta1_DNS(in *DNS, out *bootstrapv1.DNS, s apimachineryconversion.Scope) error {
+	// DNS.Disabled exists in v1beta4 types but not in bootstrapv1.DNS (Cluster API does not support it for now). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_DNS_To_v1beta1_DNS(in, out, s)
+}
+
+func Convert_upstreamv1beta4_LocalEtcd_To_v1beta1_LocalEtcd(in *LocalEtcd, out *bootstrapv1.LocalEtcd, s apimachineryconversion.Scope) error {
+	// LocalEtcd.ExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	out.ExtraArgs = convertFromArgs(in.ExtraArgs)
+	return autoConvert_upstreamv1beta4_LocalEtcd_To_v1beta1_LocalEtcd(in, out, s)
+}
+
+func Convert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(in *InitConfiguration, out *bootstrapv1.InitConfiguration, s apimachineryconversion.Scope) error {
+	// InitConfiguration.DryRun and CertificateKey exist in v1beta4 types but not in bootstrapv1.InitConfiguration (Cluster API does not support dry run and does not use automatic copy certs). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_InitConfiguration_To_v1beta1_InitConfiguration(in, out, s)
+}
+
+func Convert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(in *JoinConfiguration, out *bootstrapv1.JoinConfiguration, s apimachineryconversion.Scope) error {
+	// JoinConfiguration.DryRun exists in v1beta4 types but not in bootstrapv1.JoinConfiguration (Cluster API does not support dry run). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_JoinConfiguration_To_v1beta1_JoinConfiguration(in, out, s)
+}
+
+func Convert_upstreamv1beta4_JoinControlPlane_To_v1beta1_JoinControlPlane(in *JoinControlPlane, out *bootstrapv1.JoinControlPlane, s apimachineryconversion.Scope) error {
+	// JoinControlPlane.CertificateKey exists in v1beta4 types but not in bootstrapv1.JoinControlPlane (Cluster API does not use automatic copy certs). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_JoinControlPlane_To_v1beta1_JoinControlPlane(in, out, s)
+}
+
+func Convert_upstreamv1beta4_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in *NodeRegistrationOptions, out *bootstrapv1.NodeRegistrationOptions, s apimachineryconversion.Scope) error {
+	// NodeRegistrationOptions.KubeletExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	// NodeRegistrationOptions.ImagePullSerial exists in v1beta4 types but not in bootstrapv1.NodeRegistrationOptions (Cluster API does not support it for now). Ignoring when converting.
+	out.KubeletExtraArgs = convertFromArgs(in.KubeletExtraArgs)
+	return autoConvert_upstreamv1beta4_NodeRegistrationOptions_To_v1beta1_NodeRegistrationOptions(in, out, s)
+}
+
+func Convert_upstreamv1beta4_Timeouts_To_v1beta1_Timeouts(in *Timeouts, out *bootstrapv1.Timeouts, s apimachineryconversion.Scope) error {
+	// Timeouts.UpgradeManifests exists in v1beta4 types but not in bootstrapv1.Timeouts (Cluster API does not use kubeadm upgrade). Ignoring when converting.
+	return autoConvert_upstreamv1beta4_Timeouts_To_v1beta1_Timeouts(in, out, s)
+}
+
+// Custom conversion from the hub version, CABPK v1beta1, to this API, kubeadm v1beta4.
+
+func Convert_v1beta1_APIServer_To_upstreamv1beta4_APIServer(in *bootstrapv1.APIServer, out *APIServer, s apimachineryconversion.Scope) error {
+	// APIServer.TimeoutForControlPlane has been replaced by Timeouts.ControlPlaneComponentHealthCheck in the v1beta4 InitConfiguration
+	// and JoinConfiguration; it is the responsibility of the caller to carry over this value if required. Dropping this info.
+	return autoConvert_v1beta1_APIServer_To_upstreamv1beta4_APIServer(in, out, s)
+}
+
+func Convert_v1beta1_ControlPlaneComponent_To_upstreamv1beta4_ControlPlaneComponent(in *bootstrapv1.ControlPlaneComponent, out *ControlPlaneComponent, s apimachineryconversion.Scope) error {
+	// ControlPlaneComponent.ExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	out.ExtraArgs = convertToArgs(in.ExtraArgs)
+	return autoConvert_v1beta1_ControlPlaneComponent_To_upstreamv1beta4_ControlPlaneComponent(in, out, s)
+}
+
+func Convert_v1beta1_LocalEtcd_To_upstreamv1beta4_LocalEtcd(in *bootstrapv1.LocalEtcd, out *LocalEtcd, s apimachineryconversion.Scope) error {
+	// LocalEtcd.ExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	out.ExtraArgs = convertToArgs(in.ExtraArgs)
+	return autoConvert_v1beta1_LocalEtcd_To_upstreamv1beta4_LocalEtcd(in, out, s)
+}
+
+func Convert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(in *bootstrapv1.JoinConfiguration, out *JoinConfiguration, s apimachineryconversion.Scope) error {
+	if err := autoConvert_v1beta1_JoinConfiguration_To_upstreamv1beta4_JoinConfiguration(in, out, s); err != nil {
+		return err
+	}
+
+	// Discovery.Timeout has been replaced by Timeouts.Discovery in v1beta4; carry over the value unless Timeouts.Discovery is already set.
+	if in.Discovery.Timeout != nil && (out.Timeouts == nil || out.Timeouts.Discovery == nil) {
+		if out.Timeouts == nil {
+			out.Timeouts = &Timeouts{}
+		}
+		out.Timeouts.Discovery = in.Discovery.Timeout.DeepCopy()
+	}
+	return nil
+}
+
+func Convert_v1beta1_Discovery_To_upstreamv1beta4_Discovery(in *bootstrapv1.Discovery, out *Discovery, s apimachineryconversion.Scope) error {
+	// Discovery.Timeout has been replaced by Timeouts.Discovery in v1beta4, the value is carried over when converting the JoinConfiguration.
+	return autoConvert_v1beta1_Discovery_To_upstreamv1beta4_Discovery(in, out, s)
+}
+
+func Convert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta4_NodeRegistrationOptions(in *bootstrapv1.NodeRegistrationOptions, out *NodeRegistrationOptions, s apimachineryconversion.Scope) error {
+	// NodeRegistrationOptions.KubeletExtraArgs has been converted from a map to a list of arguments in v1beta4.
+	out.KubeletExtraArgs = convertToArgs(in.KubeletExtraArgs)
+	return autoConvert_v1beta1_NodeRegistrationOptions_To_upstreamv1beta4_NodeRegistrationOptions(in, out, s)
+}
+
+// convertToArgs takes a argument map and converts it to a slice of arguments.
+// The resulting argument slice is sorted alpha-numerically.
+func convertToArgs(in map[string]string) []Arg {
+	if in == nil {
+		return nil
+	}
+	args := make([]Arg, 0, len(in))
+	for k, v := range in {
+		args = append(args, Arg{Name: k, Value: v})
+	}
+	sort.Slice(args, func(i, j int) bool {
+		if args[i].Name == args[j].Name {
+			return args[i].Value < args[j].Value
+		}
+		return args[i].Name < args[j].Name
+	})
+	return args
+}
+
+// convertFromArgs takes a slice of arguments and returns an argument map.
+// Duplicate argument keys will be de-duped, where later keys will take precedence.
+func convertFromArgs(in []Arg) map[string]string {
+	if in == nil {
+		return nil
+	}
+	args := make(map[string]string, len(in))
+	for _, arg := range in {
+		args[arg.Name] = arg.Value
+	}
+	return args
+}
@@ -0,0 +1,176 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package upstreamv1beta4
+
+import (
+	"testing"
+
+	fuzz "github.com/google/gofuzz"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/apitesting/fuzzer"
+	"k8s.io/apimachinery/pkg/runtime"
+	runtimeserializer "k8s.io/apimachinery/pkg/runtime/serializer"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
+)
+
+func TestFuzzyConversion(t *testing.T) {
+	g := NewWithT(t)
+	scheme := runtime.NewScheme()
+	g.Expect(AddToScheme(scheme)).To(Succeed())
+	g.Expect(bootstrapv1.AddToScheme(scheme)).To(Succeed())
+
+	t.Run("for ClusterConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.ClusterConfiguration{},
+		Spoke:  &ClusterConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for InitConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.InitConfiguration{},
+		Spoke:  &InitConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+	t.Run("for JoinConfiguration", utilconversion.FuzzTestFunc(utilconversion.FuzzTestFuncInput{
+		Scheme: scheme,
+		Hub:    &bootstrapv1.JoinConfiguration{},
+		Spoke:  &JoinConfiguration{},
+		// NOTE: Kubeadm types does not have ObjectMeta, so we are required to skip data annotation cleanup in the spoke-hub-spoke round trip test.
+		SkipSpokeAnnotationCleanup: true,
+		FuzzerFuncs:                []fuzzer.FuzzerFuncs{fuzzFuncs},
+	}))
+}
+
+func fuzzFuncs(_ runtimeserializer.CodecFactory) []interface{} {
+	return []interface{}{
+		clusterConfigurationFuzzer,
+		controlPlaneComponentFuzzer,
+		dnsFuzzer,
+		localEtcdFuzzer,
+		nodeRegistrationOptionsFuzzer,
+		initConfigurationFuzzer,
+		joinConfigurationFuzzer,
+		joinControlPlanesFuzzer,
+		timeoutsFuzzer,
+		kubeadmAPIServerFuzzer,
+		kubeadmDiscoveryFuzzer,
+	}
+}
+
+func clusterConfigurationFuzzer(obj *ClusterConfiguration, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ClusterConfiguration.Proxy, EncryptionAlgorithm, CertificateValidityPeriod and CACertificateValidityPeriod do not exist in v1beta1,
+	// so setting them to empty values in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.Proxy = Proxy{}
+	obj.EncryptionAlgorithm = ""
+	obj.CertificateValidityPeriod = nil
+	obj.CACertificateValidityPeriod = nil
+}
+
+func controlPlaneComponentFuzzer(obj *ControlPlaneComponent, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// ControlPlaneComponent.ExtraArgs is a map in v1beta1, so setting it to a list sorted by name and without duplicates
+	// in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraArgs = fuzzArgs(c)
+}
+
+func dnsFuzzer(obj *DNS, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// DNS.Disabled does not exist in v1beta1, so setting it to false in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.Disabled = false
+}
+
+func localEtcdFuzzer(obj *LocalEtcd, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// LocalEtcd.ExtraArgs is a map in v1beta1, so setting it to a list sorted by name and without duplicates
+	// in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ExtraArgs = fuzzArgs(c)
+}
+
+func nodeRegistrationOptionsFuzzer(obj *NodeRegistrationOptions, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// NodeRegistrationOptions.KubeletExtraArgs is a map in v1beta1, so setting it to a list sorted by name and without duplicates
+	// in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.KubeletExtraArgs = fuzzArgs(c)
+
+	// NodeRegistrationOptions.ImagePullSerial does not exist in v1beta1, so setting it to nil in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.ImagePullSerial = nil
+}
+
+func joinControlPlanesFuzzer(obj *JoinControlPlane, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// JoinControlPlane.CertificateKey does not exist in v1beta1, so setting it to empty string in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.CertificateKey = ""
+}
+
+func initConfigurationFuzzer(obj *InitConfiguration, c fuzz.Continue) {
+	c.Fuzz(obj)
+
+	// InitConfiguration.CertificateKey and DryRun do not exist in v1beta1, so setting them to empty values in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.CertificateKey = ""
+	obj.DryRun = false
+}
+
+func joinConfigurationFuzzer(obj *JoinConfiguration, c fuzz.Continue) {
+	c.Fuzz(obj)
+
+	// JoinConfiguration.DryRun does not exist in v1beta1, so setting it to false in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.DryRun = false
+}
+
+func timeoutsFuzzer(obj *Timeouts, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// Timeouts.UpgradeManifests does not exist in v1beta1, so setting it to nil in order to avoid v1beta4 --> v1beta1 --> v1beta4 round trip errors.
+	obj.UpgradeManifests = nil
+}
+
+func kubeadmAPIServerFuzzer(obj *bootstrapv1.APIServer, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// APIServer.TimeoutForControlPlane does not exist in kubeadm v1beta4 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta4 -> v1beta1 round trip errors.
+	obj.TimeoutForControlPlane = nil
+}
+
+func kubeadmDiscoveryFuzzer(obj *bootstrapv1.Discovery, c fuzz.Continue) {
+	c.FuzzNoCustom(obj)
+
+	// Discovery.Timeout does not exist in kubeadm v1beta4 API, so setting it to nil in order to avoid
+	// v1beta1 --> upstream v1beta4 -> v1beta1 round trip errors.
+	obj.Timeout = nil
+}
+
+// fuzzArgs returns a list of arguments sorted by name and without duplicates, which is what
+// the conversion from a map of arguments generates.
+func fuzzArgs(c fuzz.Continue) []Arg {
+	var args map[string]string
+	c.Fuzz(&args)
+	return convertToArgs(args)
+}
@@ -29,29 +29,34 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
 var (
 	v1beta2KubeadmVersion = semver.MustParse("1.15.0")
 	v1beta3KubeadmVersion = semver.MustParse("1.22.0")
+	v1beta4KubeadmVersion = semver.MustParse("1.31.0")
 
 	clusterConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.ClusterConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.ClusterConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterConfiguration{},
 	}
 
 	clusterStatusVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
-		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3
+		// ClusterStatus has been removed in v1beta3, so we don't need an entry for v1beta3 and v1beta4
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.ClusterStatus{},
 	}
 
 	initConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.InitConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.InitConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.InitConfiguration{},
 	}
 
 	joinConfigurationVersionTypeMap = map[schema.GroupVersion]conversion.Convertible{
+		upstreamv1beta4.GroupVersion: &upstreamv1beta4.JoinConfiguration{},
 		upstreamv1beta3.GroupVersion: &upstreamv1beta3.JoinConfiguration{},
 		upstreamv1beta2.GroupVersion: &upstreamv1beta2.JoinConfiguration{},
 	}
@@ -65,14 +70,17 @@ func KubeVersionToKubeadmAPIGroupVersion(v semver.Version) (schema.GroupVersion,
 	case version.Compare(v, v1beta3KubeadmVersion, version.WithoutPreReleases()) < 0:
 		// NOTE: All the Kubernetes version >= v1.15 and < v1.22 should use the kubeadm API version v1beta2
 		return upstreamv1beta2.GroupVersion, nil
+	case version.Compare(v, v1beta4KubeadmVersion, version.WithoutPreReleases()) < 0:
+		// NOTE: All the Kubernetes version >= v1.22 and < v1.31 should use the kubeadm API version v1beta3
+		return upstreamv1beta3.GroupVersion, nil
 	default:
-		// NOTE: All the Kubernetes version greater or equal to v1.22 should use the kubeadm API version v1beta3.
-		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta3,
-		// no matter if kubeadm API versions newer than v1beta3 could be introduced by those release.
-		// This is acceptable because v1beta3 will be supported by kubeadm until the deprecation cycle completes
+		// NOTE: All the Kubernetes version greater or equal to v1.31 should use the kubeadm API version v1beta4.
+		// Also future Kubernetes versions (not yet released at the time of writing this code) are going to use v1beta4,
+		// no matter if kubeadm API versions newer than v1beta4 could be introduced by those release.
+		// This is acceptable because v1beta4 will be supported by kubeadm until the deprecation cycle completes
 		// (9 months minimum after the deprecation date, not yet announced now); this gives Cluster API project time to
 		// introduce support for newer releases without blocking users to deploy newer version of Kubernetes.
-		return upstreamv1beta3.GroupVersion, nil
+		return upstreamv1beta4.GroupVersion, nil
 	}
 }
 
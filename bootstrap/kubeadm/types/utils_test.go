@@ -18,15 +18,19 @@ package utils
 
 import (
 	"testing"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta2"
 	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta3"
+	"sigs.k8s.io/cluster-api/bootstrap/kubeadm/types/upstreamv1beta4"
 )
 
 func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
@@ -102,12 +106,28 @@ func TestKubeVersionToKubeadmAPIGroupVersion(t *testing.T) {
 			want:    upstreamv1beta3.GroupVersion,
 			wantErr: false,
 		},
+		{
+			name: "pass with minimum kubernetes alpha version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0-alpha.0.734+ba502ee555924a"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
+		{
+			name: "pass with minimum kubernetes version for kubeadm API v1beta4",
+			args: args{
+				version: semver.MustParse("1.31.0"),
+			},
+			want:    upstreamv1beta4.GroupVersion,
+			wantErr: false,
+		},
 		{
 			name: "pass with future kubernetes version",
 			args: args{
 				version: semver.MustParse("99.99.99"),
 			},
-			want:    upstreamv1beta3.GroupVersion,
+			want:    upstreamv1beta4.GroupVersion,
 			wantErr: false,
 		},
 	}
@@ -169,6 +189,46 @@ func TestMarshalClusterConfigurationForVersion(t *testing.T) {
 				"scheduler: {}\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{
+						ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+							ExtraArgs: map[string]string{
+								"foo": "bar",
+								"bar": "baz",
+							},
+							ExtraEnvs: []bootstrapv1.EnvVar{
+								{
+									EnvVar: corev1.EnvVar{Name: "HTTP_PROXY", Value: "http://proxy"},
+								},
+							},
+						},
+						TimeoutForControlPlane: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiServer:\n" +
+				"  extraArgs:\n" +
+				"  - name: bar\n" +
+				"    value: baz\n" +
+				"  - name: foo\n" +
+				"    value: bar\n" +
+				"  extraEnvs:\n" +
+				"  - name: HTTP_PROXY\n" +
+				"    value: http://proxy\n" +
+				"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"controllerManager: {}\n" +
+				"dns: {}\n" +
+				"etcd: {}\n" +
+				"kind: ClusterConfiguration\n" +
+				"networking: {}\n" +
+				"proxy: {}\n" +
+				"scheduler: {}\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -279,6 +339,36 @@ func TestMarshalInitConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.InitConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+						KubeletExtraArgs: map[string]string{
+							"node-labels": "foo=bar",
+						},
+					},
+					Timeouts: &bootstrapv1.Timeouts{
+						ControlPlaneComponentHealthCheck: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" +
+				"kind: InitConfiguration\n" +
+				"localAPIEndpoint: {}\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  kubeletExtraArgs:\n" +
+				"  - name: node-labels\n" +
+				"    value: foo=bar\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  controlPlaneComponentHealthCheck: 10m0s\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -343,6 +433,30 @@ func TestMarshalJoinConfigurationForVersion(t *testing.T) {
 				"  taints: null\n",
 			wantErr: false,
 		},
+		{
+			name: "Generates a v1beta4 kubeadm configuration",
+			args: args{
+				capiObj: &bootstrapv1.JoinConfiguration{
+					NodeRegistration: bootstrapv1.NodeRegistrationOptions{
+						IgnorePreflightErrors: []string{"some-preflight-check"},
+					},
+					Discovery: bootstrapv1.Discovery{
+						Timeout: &metav1.Duration{Duration: 10 * time.Minute},
+					},
+				},
+				version: semver.MustParse("1.31.0"),
+			},
+			want: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+				"discovery: {}\n" +
+				"kind: JoinConfiguration\n" +
+				"nodeRegistration:\n" +
+				"  ignorePreflightErrors:\n" +
+				"  - some-preflight-check\n" +
+				"  taints: null\n" +
+				"timeouts:\n" +
+				"  discovery: 10m0s\n",
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -399,6 +513,31 @@ func TestUnmarshalClusterConfiguration(t *testing.T) {
 			want:    &bootstrapv1.ClusterConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiServer:\n" +
+					"  extraArgs:\n" +
+					"  - name: foo\n" +
+					"    value: bar\n" +
+					"apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"controllerManager: {}\n" +
+					"dns: {}\n" +
+					"etcd: {}\n" +
+					"kind: ClusterConfiguration\n" +
+					"networking: {}\n" +
+					"proxy: {}\n" +
+					"scheduler: {}\n",
+			},
+			want: &bootstrapv1.ClusterConfiguration{
+				APIServer: bootstrapv1.APIServer{
+					ControlPlaneComponent: bootstrapv1.ControlPlaneComponent{
+						ExtraArgs: map[string]string{"foo": "bar"},
+					},
+				},
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -492,6 +631,17 @@ func TestUnmarshalInitConfiguration(t *testing.T) {
 			want:    &bootstrapv1.InitConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"kind: InitConfiguration\n" +
+					"localAPIEndpoint: {}\n" +
+					"nodeRegistration: {}\n",
+			},
+			want:    &bootstrapv1.InitConfiguration{},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -540,6 +690,17 @@ func TestUnmarshalJoinConfiguration(t *testing.T) {
 			want:    &bootstrapv1.JoinConfiguration{},
 			wantErr: false,
 		},
+		{
+			name: "Parses a v1beta4 kubeadm configuration",
+			args: args{
+				yaml: "apiVersion: kubeadm.k8s.io/v1beta4\n" + "" +
+					"caCertPath: \"\"\n" +
+					"discovery: {}\n" +
+					"kind: JoinConfiguration\n",
+			},
+			want:    &bootstrapv1.JoinConfiguration{},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
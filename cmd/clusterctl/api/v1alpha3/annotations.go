@@ -41,4 +41,13 @@ const (
 	// `clusterctl move` is invoked, then NO resources for ANY workload cluster will be created on the
 	// destination management cluster until the annotation is removed.
 	BlockMoveAnnotation = "clusterctl.cluster.x-k8s.io/block-move"
+
+	// ComponentsPatchesAnnotation is set by clusterctl on the Provider inventory object to store the patches used to
+	// customize the provider components with `clusterctl init --patches`, so they can be applied again on upgrade.
+	ComponentsPatchesAnnotation = "clusterctl.cluster.x-k8s.io/components-patches"
+
+	// WebhookCertificatesAnnotation is set by clusterctl on the Provider inventory object to store how the certificates
+	// of the provider webhooks are managed, i.e. the `clusterctl init --webhook-certificates` mode, so the same mode
+	// is used on upgrade.
+	WebhookCertificatesAnnotation = "clusterctl.cluster.x-k8s.io/webhook-certificates"
 )
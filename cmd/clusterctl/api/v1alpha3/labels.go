@@ -36,6 +36,10 @@ const (
 
 	// ClusterctlMoveHierarchyLabel can be set on CRDs that providers wish to move with their entire hierarchy, but that are not part of a Cluster.
 	ClusterctlMoveHierarchyLabel = "clusterctl.cluster.x-k8s.io/move-hierarchy"
+
+	// ClusterctlPausedConditionLabel can be set to "true" on CRDs whose controllers acknowledge that reconciliation
+	// has been paused or resumed with the Paused condition; clusterctl waits for the acknowledgment only for these CRDs.
+	ClusterctlPausedConditionLabel = "clusterctl.cluster.x-k8s.io/paused-condition"
 )
 
 // ManifestLabel returns the cluster.x-k8s.io/provider label value for a provider/type.
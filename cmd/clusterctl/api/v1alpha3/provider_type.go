@@ -53,6 +53,11 @@ type Provider struct {
 	// Deprecated: providers complying with the Cluster API v1alpha4 contract or above must watch all namespaces; this field will be removed in a future version of this API
 	// +optional
 	WatchedNamespace string `json:"watchedNamespace,omitempty"`
+
+	// ComponentsChecksum is the checksum of the provider components as applied by clusterctl during
+	// install or upgrade; it is used to detect changes to the provider components made afterwards.
+	// +optional
+	ComponentsChecksum string `json:"componentsChecksum,omitempty"`
 }
 
 // ManifestLabel returns the cluster.x-k8s.io/provider label value for an entry in the provider inventory.
@@ -17,6 +17,7 @@ limitations under the License.
 package client
 
 import (
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/repository"
@@ -35,6 +36,9 @@ type Components repository.Components
 // ComponentsOptions wraps inputs to get provider's components.
 type ComponentsOptions repository.ComponentsOptions
 
+// ComponentsPatch defines a patch to customize provider's components.
+type ComponentsPatch = repository.ComponentsPatch
+
 // Template wraps a YAML file that defines the cluster objects (Cluster, Machines etc.).
 type Template repository.Template
 
@@ -45,6 +49,12 @@ type UpgradePlan cluster.UpgradePlan
 // upgraded to a different version.
 type CertManagerUpgradePlan cluster.CertManagerUpgradePlan
 
+// ProviderDrift reports if the Deployments of a provider have been changed after clusterctl applied them.
+type ProviderDrift cluster.ProviderDrift
+
+// RolloutRevision describes a revision in the rollout history of a cluster-api resource.
+type RolloutRevision alpha.RolloutRevision
+
 // Kubeconfig is a type that specifies inputs related to the actual kubeconfig.
 type Kubeconfig cluster.Kubeconfig
 
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// RolloutRevision describes a revision in the rollout history of a cluster-api resource.
+type RolloutRevision struct {
+	// Revision is the revision number.
+	Revision int64
+
+	// MachineSet is the name of the MachineSet retained for the revision.
+	MachineSet string
+
+	// CreationTimestamp is the time the revision has been created.
+	CreationTimestamp metav1.Time
+
+	// Template is the Machine template of the revision.
+	Template clusterv1.MachineTemplateSpec
+}
+
+// ObjectHistoryViewer will return the rollout history of the specified cluster-api resource, sorted by revision.
+func (r *rollout) ObjectHistoryViewer(ctx context.Context, proxy cluster.Proxy, ref corev1.ObjectReference) ([]RolloutRevision, error) {
+	switch ref.Kind {
+	case MachineDeployment:
+		deployment, err := getMachineDeployment(ctx, proxy, ref.Name, ref.Namespace)
+		if err != nil || deployment == nil {
+			return nil, errors.Wrapf(err, "failed to get %v/%v", ref.Kind, ref.Name)
+		}
+		return machineDeploymentHistory(ctx, proxy, deployment)
+	default:
+		return nil, errors.Errorf("invalid resource type %q, valid values are %v", ref.Kind, validRollbackResourceTypes)
+	}
+}
+
+// machineDeploymentHistory returns the revisions of the MachineSets retained for this MachineDeployment.
+func machineDeploymentHistory(ctx context.Context, proxy cluster.Proxy, md *clusterv1.MachineDeployment) ([]RolloutRevision, error) {
+	msList, err := getMachineSetsForDeployment(ctx, proxy, md)
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]RolloutRevision, 0, len(msList))
+	for _, ms := range msList {
+		v, err := revision(ms)
+		if err != nil || v == 0 {
+			// Skip MachineSets without a valid revision.
+			continue
+		}
+		// Drop the hash, so the template is the same used when rolling back to the revision.
+		template := *ms.Spec.Template.DeepCopy()
+		delete(template.Labels, clusterv1.MachineDeploymentUniqueLabel)
+
+		revisions = append(revisions, RolloutRevision{
+			Revision:          v,
+			MachineSet:        ms.Name,
+			CreationTimestamp: ms.CreationTimestamp,
+			Template:          template,
+		})
+	}
+	sort.Slice(revisions, func(i, j int) bool { return revisions[i].Revision < revisions[j].Revision })
+	return revisions, nil
+}
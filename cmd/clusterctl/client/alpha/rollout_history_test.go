@@ -0,0 +1,158 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package alpha
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_ObjectHistoryViewer(t *testing.T) {
+	deployment := &clusterv1.MachineDeployment{
+		TypeMeta: metav1.TypeMeta{
+			Kind: "MachineDeployment",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-md-0",
+			Namespace: "default",
+			Annotations: map[string]string{
+				clusterv1.RevisionAnnotation: "3",
+			},
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			ClusterName: "test",
+			Selector: metav1.LabelSelector{
+				MatchLabels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+		},
+	}
+	machineSet := func(name, revision, version string) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			TypeMeta: metav1.TypeMeta{
+				Kind: "MachineSet",
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+				OwnerReferences: []metav1.OwnerReference{
+					*metav1.NewControllerRef(deployment, clusterv1.GroupVersion.WithKind("MachineDeployment")),
+				},
+				Labels: map[string]string{
+					clusterv1.ClusterNameLabel: "test",
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				ClusterName: "test",
+				Template: clusterv1.MachineTemplateSpec{
+					ObjectMeta: clusterv1.ObjectMeta{
+						Labels: map[string]string{
+							clusterv1.MachineDeploymentUniqueLabel: name,
+						},
+					},
+					Spec: clusterv1.MachineSpec{
+						ClusterName: "test",
+						Version:     &version,
+					},
+				},
+			},
+		}
+		if revision != "" {
+			ms.Annotations = map[string]string{clusterv1.RevisionAnnotation: revision}
+		}
+		return ms
+	}
+
+	tests := []struct {
+		name          string
+		objs          []client.Object
+		ref           corev1.ObjectReference
+		wantErr       bool
+		wantRevisions []int64
+		wantVersions  []string
+	}{
+		{
+			name: "machinedeployment history should be sorted by revision",
+			objs: []client.Object{
+				deployment,
+				machineSet("ms-rev-3", "3", "v1.27.3"),
+				machineSet("ms-rev-1", "1", "v1.27.1"),
+				machineSet("ms-rev-2", "2", "v1.27.2"),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "test-md-0",
+				Namespace: "default",
+			},
+			wantRevisions: []int64{1, 2, 3},
+			wantVersions:  []string{"v1.27.1", "v1.27.2", "v1.27.3"},
+		},
+		{
+			name: "machinedeployment history should skip MachineSets without revision",
+			objs: []client.Object{
+				deployment,
+				machineSet("ms-rev-3", "3", "v1.27.3"),
+				machineSet("ms-no-rev", "", "v1.27.0"),
+			},
+			ref: corev1.ObjectReference{
+				Kind:      MachineDeployment,
+				Name:      "test-md-0",
+				Namespace: "default",
+			},
+			wantRevisions: []int64{3},
+			wantVersions:  []string{"v1.27.3"},
+		},
+		{
+			name: "history is not supported for kubeadmcontrolplane",
+			objs: []client.Object{},
+			ref: corev1.ObjectReference{
+				Kind:      KubeadmControlPlane,
+				Name:      "test-kcp",
+				Namespace: "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			r := newRolloutClient()
+			proxy := test.NewFakeProxy().WithObjs(tt.objs...)
+			revisions, err := r.ObjectHistoryViewer(context.Background(), proxy, tt.ref)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(revisions).To(HaveLen(len(tt.wantRevisions)))
+			for i, rev := range revisions {
+				g.Expect(rev.Revision).To(Equal(tt.wantRevisions[i]))
+				g.Expect(*rev.Template.Spec.Version).To(Equal(tt.wantVersions[i]))
+				g.Expect(rev.Template.Labels).ToNot(HaveKey(clusterv1.MachineDeploymentUniqueLabel))
+			}
+		})
+	}
+}
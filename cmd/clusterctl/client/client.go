@@ -19,6 +19,8 @@ package client
 import (
 	"context"
 
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/alpha"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
@@ -56,6 +58,12 @@ type Client interface {
 	// Move moves all the Cluster API objects existing in a namespace (or from all the namespaces if empty) to a target management cluster.
 	Move(ctx context.Context, options MoveOptions) error
 
+	// Pause pauses the reconciliation of all the Clusters matching a label selector, and returns the paused Clusters.
+	Pause(ctx context.Context, options PauseOptions) ([]client.ObjectKey, error)
+
+	// Resume resumes the reconciliation of all the Clusters matching a label selector, and returns the resumed Clusters.
+	Resume(ctx context.Context, options ResumeOptions) ([]client.ObjectKey, error)
+
 	// PlanUpgrade returns a set of suggested Upgrade plans for the cluster.
 	PlanUpgrade(ctx context.Context, options PlanUpgradeOptions) ([]UpgradePlan, error)
 
@@ -65,6 +73,9 @@ type Client interface {
 	// ApplyUpgrade executes an upgrade plan.
 	ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error
 
+	// CheckDrift checks if the components of the providers have been changed after clusterctl applied them.
+	CheckDrift(ctx context.Context, options CheckDriftOptions) ([]ProviderDrift, error)
+
 	// ProcessYAML provides a direct way to process a yaml and inspect its
 	// variables.
 	ProcessYAML(ctx context.Context, options ProcessYAMLOptions) (YamlPrinter, error)
@@ -86,8 +97,16 @@ type AlphaClient interface {
 	RolloutResume(ctx context.Context, options RolloutResumeOptions) error
 	// RolloutUndo provides rollout rollback of cluster-api resources
 	RolloutUndo(ctx context.Context, options RolloutUndoOptions) error
+	// RolloutHistory provides the rollout history of cluster-api resources
+	RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error)
 	// TopologyPlan dry runs the topology reconciler
 	TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*TopologyPlanOutput, error)
+	// TopologyRebase checks if a Cluster can be rebased to another ClusterClass and reports the impact on its Machines
+	TopologyRebase(ctx context.Context, options TopologyRebaseOptions) (*TopologyRebaseOutput, error)
+	// Graph returns the graph of the Cluster API objects considered by clusterctl move
+	Graph(ctx context.Context, options GraphOptions) (*MoveGraph, error)
+	// ClusterStatus returns a snapshot of the status of a Cluster API cluster, e.g. to watch its provisioning or upgrade
+	ClusterStatus(ctx context.Context, options ClusterStatusOptions) (*ClusterStatus, error)
 }
 
 // YamlPrinter exposes methods that prints the processed template and
@@ -111,6 +111,14 @@ func (f fakeClient) Move(ctx context.Context, options MoveOptions) error {
 	return f.internalClient.Move(ctx, options)
 }
 
+func (f fakeClient) Pause(ctx context.Context, options PauseOptions) ([]client.ObjectKey, error) {
+	return f.internalClient.Pause(ctx, options)
+}
+
+func (f fakeClient) Resume(ctx context.Context, options ResumeOptions) ([]client.ObjectKey, error) {
+	return f.internalClient.Resume(ctx, options)
+}
+
 func (f fakeClient) PlanUpgrade(ctx context.Context, options PlanUpgradeOptions) ([]UpgradePlan, error) {
 	return f.internalClient.PlanUpgrade(ctx, options)
 }
@@ -123,6 +131,10 @@ func (f fakeClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOption
 	return f.internalClient.ApplyUpgrade(ctx, options)
 }
 
+func (f fakeClient) CheckDrift(ctx context.Context, options CheckDriftOptions) ([]ProviderDrift, error) {
+	return f.internalClient.CheckDrift(ctx, options)
+}
+
 func (f fakeClient) ProcessYAML(ctx context.Context, options ProcessYAMLOptions) (YamlPrinter, error) {
 	return f.internalClient.ProcessYAML(ctx, options)
 }
@@ -147,10 +159,26 @@ func (f fakeClient) RolloutUndo(ctx context.Context, options RolloutUndoOptions)
 	return f.internalClient.RolloutUndo(ctx, options)
 }
 
+func (f fakeClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error) {
+	return f.internalClient.RolloutHistory(ctx, options)
+}
+
 func (f fakeClient) TopologyPlan(ctx context.Context, options TopologyPlanOptions) (*cluster.TopologyPlanOutput, error) {
 	return f.internalClient.TopologyPlan(ctx, options)
 }
 
+func (f fakeClient) TopologyRebase(ctx context.Context, options TopologyRebaseOptions) (*cluster.TopologyRebaseOutput, error) {
+	return f.internalClient.TopologyRebase(ctx, options)
+}
+
+func (f fakeClient) Graph(ctx context.Context, options GraphOptions) (*MoveGraph, error) {
+	return f.internalClient.Graph(ctx, options)
+}
+
+func (f fakeClient) ClusterStatus(ctx context.Context, options ClusterStatusOptions) (*ClusterStatus, error) {
+	return f.internalClient.ClusterStatus(ctx, options)
+}
+
 // newFakeClient returns a clusterctl client that allows to execute tests on a set of fake config, fake repositories and fake clusters.
 // you can use WithCluster and WithRepository to prepare for the test case.
 func newFakeClient(ctx context.Context, configClient config.Client) *fakeClient {
@@ -309,6 +337,10 @@ func (f *fakeClusterClient) ObjectMover() cluster.ObjectMover {
 	return f.fakeObjectMover
 }
 
+func (f *fakeClusterClient) ClusterPauser() cluster.ClusterPauser {
+	return f.internalclient.ClusterPauser()
+}
+
 func (f *fakeClusterClient) ProviderUpgrader() cluster.ProviderUpgrader {
 	return f.internalclient.ProviderUpgrader()
 }
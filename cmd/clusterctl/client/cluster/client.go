@@ -71,6 +71,10 @@ type Client interface {
 	// from one management cluster to another management cluster.
 	ObjectMover() ObjectMover
 
+	// ClusterPauser returns a ClusterPauser that implements support for pausing and resuming the reconciliation
+	// of many Clusters at once.
+	ClusterPauser() ClusterPauser
+
 	// ProviderUpgrader returns a ProviderUpgrader that supports upgrading Cluster API providers.
 	ProviderUpgrader() ProviderUpgrader
 
@@ -131,6 +135,10 @@ func (c *clusterClient) ObjectMover() ObjectMover {
 	return newObjectMover(c.proxy, c.ProviderInventory())
 }
 
+func (c *clusterClient) ClusterPauser() ClusterPauser {
+	return newClusterPauser(c.proxy, c.ProviderInventory())
+}
+
 func (c *clusterClient) ProviderUpgrader() ProviderUpgrader {
 	return newProviderUpgrader(c.configClient, c.proxy, c.repositoryClientFactory, c.ProviderInventory(), c.ProviderComponents())
 }
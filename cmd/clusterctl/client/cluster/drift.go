@@ -0,0 +1,171 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+// restartedAtAnnotation is set on the Pod template of a Deployment by kubectl rollout restart.
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
+// ProviderDrift reports if the components of a provider have been changed after clusterctl applied them.
+type ProviderDrift struct {
+	// Provider is the entry of the provider in the inventory.
+	Provider clusterctlv1.Provider
+
+	// Drifted is true if the checksum of the provider components does not match
+	// the checksum recorded in the inventory.
+	Drifted bool
+
+	// Unknown is true if the inventory does not record a checksum for the provider, e.g. because
+	// the provider has been installed with a version of clusterctl not recording it.
+	Unknown bool
+}
+
+// CheckDrift checks if the components of the providers in the inventory have been changed after clusterctl applied them.
+func (p *inventoryClient) CheckDrift(ctx context.Context) ([]ProviderDrift, error) {
+	providerList, err := p.List(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ret := make([]ProviderDrift, 0, len(providerList.Items))
+	for _, provider := range providerList.Items {
+		drift, err := checkProviderDrift(ctx, p.proxy, provider)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, drift)
+	}
+	return ret, nil
+}
+
+// checkProviderDrift compares the checksum of the components of a provider with the checksum recorded in the inventory.
+func checkProviderDrift(ctx context.Context, proxy Proxy, provider clusterctlv1.Provider) (ProviderDrift, error) {
+	drift := ProviderDrift{Provider: provider}
+	if provider.ComponentsChecksum == "" {
+		drift.Unknown = true
+		return drift, nil
+	}
+
+	checksum, err := componentsChecksum(ctx, proxy, provider)
+	if err != nil {
+		return drift, err
+	}
+	drift.Drifted = checksum != provider.ComponentsChecksum
+	return drift, nil
+}
+
+// componentsChecksum returns the checksum of the components of a provider, as stored in the API server.
+// NOTE: The checksum is computed on the objects read back from the API server, so it includes the defaulted fields;
+// the fields set at runtime by the API server, by controllers, or by expected operations like scaling or restarting
+// the provider Deployments are ignored, see normalizeForChecksum.
+func componentsChecksum(ctx context.Context, proxy Proxy, provider clusterctlv1.Provider) (string, error) {
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabel: "",
+		clusterv1.ProviderNameLabel:  provider.ManifestLabel(),
+	}
+	resources, err := proxy.ListResources(ctx, labels, provider.Namespace)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to list the components of provider %q", provider.InstanceName())
+	}
+
+	// Drops the inventory entry, which is not part of the components and which stores the checksum itself.
+	objs := make([]unstructured.Unstructured, 0, len(resources))
+	for _, obj := range resources {
+		if obj.GroupVersionKind().GroupKind() == clusterctlv1.GroupVersion.WithKind("Provider").GroupKind() {
+			continue
+		}
+		objs = append(objs, obj)
+	}
+
+	sort.Slice(objs, func(i, j int) bool {
+		return checksumKey(objs[i]) < checksumKey(objs[j])
+	})
+
+	hash := sha256.New()
+	for i := range objs {
+		content, err := json.Marshal(normalizeForChecksum(objs[i]))
+		if err != nil {
+			return "", errors.Wrapf(err, "failed to marshal %s %s/%s", objs[i].GetKind(), objs[i].GetNamespace(), objs[i].GetName())
+		}
+		hash.Write([]byte(checksumKey(objs[i])))
+		hash.Write([]byte{0})
+		hash.Write(content)
+		hash.Write([]byte{0})
+	}
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// checksumKey returns the key identifying an object in the components checksum.
+func checksumKey(obj unstructured.Unstructured) string {
+	return obj.GroupVersionKind().GroupKind().String() + "/" + obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// normalizeForChecksum returns the content of a provider component relevant to detect drift: metadata, except for
+// labels, and status are dropped, as well as the fields expected to change while the provider is running.
+func normalizeForChecksum(obj unstructured.Unstructured) map[string]interface{} {
+	content := obj.DeepCopy().UnstructuredContent()
+	delete(content, "apiVersion")
+	delete(content, "status")
+	content["metadata"] = map[string]interface{}{
+		"name":      obj.GetName(),
+		"namespace": obj.GetNamespace(),
+		"labels":    obj.GetLabels(),
+	}
+
+	switch obj.GetKind() {
+	case "Deployment":
+		// Replicas are changed when scaling the provider, and the restartedAt annotation is set by kubectl rollout restart.
+		unstructured.RemoveNestedField(content, "spec", "replicas")
+		unstructured.RemoveNestedField(content, "spec", "template", "metadata", "annotations", restartedAtAnnotation)
+		if annotations, ok, _ := unstructured.NestedMap(content, "spec", "template", "metadata", "annotations"); ok && len(annotations) == 0 {
+			unstructured.RemoveNestedField(content, "spec", "template", "metadata", "annotations")
+		}
+	case validatingWebhookConfigurationKind, mutatingWebhookConfigurationKind:
+		// The CA bundle is injected by cert-manager, and it changes when the certificates are renewed.
+		if webhooks, ok, _ := unstructured.NestedSlice(content, "webhooks"); ok {
+			for i := range webhooks {
+				if webhook, ok := webhooks[i].(map[string]interface{}); ok {
+					unstructured.RemoveNestedField(webhook, "clientConfig", "caBundle")
+				}
+			}
+			_ = unstructured.SetNestedSlice(content, webhooks, "webhooks")
+		}
+	case customResourceDefinitionKind:
+		unstructured.RemoveNestedField(content, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	case "Secret":
+		// The content of the Secrets, e.g. the webhook certificates, changes when it is renewed.
+		delete(content, "data")
+		delete(content, "stringData")
+	case "ServiceAccount":
+		// The token Secrets are added by the API server.
+		delete(content, "secrets")
+	}
+	return content
+}
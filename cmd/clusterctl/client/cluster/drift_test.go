@@ -0,0 +1,168 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_inventoryClient_CheckDrift(t *testing.T) {
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabel: "",
+		clusterv1.ProviderNameLabel:  "cluster-api",
+	}
+	deployment := func(mutate func(*appsv1.Deployment)) client.Object {
+		d := &appsv1.Deployment{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+			ObjectMeta: metav1.ObjectMeta{Name: "capi-controller-manager", Namespace: "capi-system", Labels: labels},
+			Spec: appsv1.DeploymentSpec{
+				Replicas: pointer.Int32(1),
+				Template: corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "manager", Image: "capi:v1.0.0"}}},
+				},
+			},
+		}
+		if mutate != nil {
+			mutate(d)
+		}
+		return d
+	}
+	service := func(port int32) client.Object {
+		return &corev1.Service{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+			ObjectMeta: metav1.ObjectMeta{Name: "capi-webhook-service", Namespace: "capi-system", Labels: labels},
+			Spec:       corev1.ServiceSpec{Ports: []corev1.ServicePort{{Port: port}}},
+		}
+	}
+	webhookConfiguration := func(caBundle string) client.Object {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			TypeMeta:   metav1.TypeMeta{APIVersion: "admissionregistration.k8s.io/v1", Kind: "ValidatingWebhookConfiguration"},
+			ObjectMeta: metav1.ObjectMeta{Name: "capi-validating-webhook-configuration", Labels: labels},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name:         "validation.cluster.cluster.x-k8s.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{CABundle: []byte(caBundle)},
+			}},
+		}
+	}
+	provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+
+	// Gets the checksum of the components as applied.
+	appliedChecksum, err := componentsChecksum(context.Background(), test.NewFakeProxy().WithObjs(deployment(nil), service(443), webhookConfiguration("ca1")), provider)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name       string
+		objs       []client.Object
+		checksum   string
+		wantDrifts []ProviderDrift
+	}{
+		{
+			name:     "no drift if the components did not change",
+			objs:     []client.Object{deployment(nil), service(443), webhookConfiguration("ca1")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: false, Unknown: false},
+			},
+		},
+		{
+			name: "no drift if the Deployments have been scaled or restarted",
+			objs: []client.Object{deployment(func(d *appsv1.Deployment) {
+				d.Spec.Replicas = pointer.Int32(0)
+				d.Spec.Template.Annotations = map[string]string{restartedAtAnnotation: "2023-01-01T00:00:00Z"}
+			}), service(443), webhookConfiguration("ca1")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: false, Unknown: false},
+			},
+		},
+		{
+			name:     "no drift if the CA bundle changed",
+			objs:     []client.Object{deployment(nil), service(443), webhookConfiguration("ca2")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: false, Unknown: false},
+			},
+		},
+		{
+			name: "drift if the Deployments changed",
+			objs: []client.Object{deployment(func(d *appsv1.Deployment) {
+				d.Spec.Template.Spec.Containers[0].Image = "capi:v1.0.1"
+			}), service(443), webhookConfiguration("ca1")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: true, Unknown: false},
+			},
+		},
+		{
+			name:     "drift if other components changed",
+			objs:     []client.Object{deployment(nil), service(9443), webhookConfiguration("ca1")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: true, Unknown: false},
+			},
+		},
+		{
+			name:     "drift if a component has been deleted",
+			objs:     []client.Object{deployment(nil), webhookConfiguration("ca1")},
+			checksum: appliedChecksum,
+			wantDrifts: []ProviderDrift{
+				{Drifted: true, Unknown: false},
+			},
+		},
+		{
+			name:     "unknown if the inventory does not record a checksum",
+			objs:     []client.Object{deployment(nil), service(9443), webhookConfiguration("ca1")},
+			checksum: "",
+			wantDrifts: []ProviderDrift{
+				{Drifted: false, Unknown: true},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			inventoryObject := provider.DeepCopy()
+			inventoryObject.ComponentsChecksum = tt.checksum
+			proxy := test.NewFakeProxy().WithObjs(append(tt.objs, inventoryObject)...)
+
+			got, err := newInventoryClient(proxy, nil).CheckDrift(context.Background())
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(HaveLen(len(tt.wantDrifts)))
+			for i := range got {
+				g.Expect(got[i].Provider.Name).To(Equal(inventoryObject.Name))
+				g.Expect(got[i].Drifted).To(Equal(tt.wantDrifts[i].Drifted))
+				g.Expect(got[i].Unknown).To(Equal(tt.wantDrifts[i].Unknown))
+			}
+		})
+	}
+}
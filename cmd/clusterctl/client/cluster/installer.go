@@ -29,6 +29,7 @@ import (
 	apiextensionsv1beta1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/version"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -70,6 +71,16 @@ type ProviderInstaller interface {
 type InstallOptions struct {
 	WaitProviders       bool
 	WaitProviderTimeout time.Duration
+
+	// RollbackOnFailure instructs the installer to delete the components and the inventory entries of the providers
+	// installed so far if the installation of a provider fails or, when waiting for providers, if a provider does not
+	// become available within WaitProviderTimeout.
+	// NOTE: CRDs are deleted too, while the provider namespaces are preserved because they could host objects not
+	// created by clusterctl, e.g. credentials.
+	RollbackOnFailure bool
+
+	// WebhookCertificates defines how the certificates of the provider webhooks are managed; if empty, cert-manager is used.
+	WebhookCertificates WebhookCertificatesMode
 }
 
 // providerInstaller implements ProviderInstaller.
@@ -96,26 +107,75 @@ func (i *providerInstaller) Add(components repository.Components) {
 func (i *providerInstaller) Install(ctx context.Context, opts InstallOptions) ([]repository.Components, error) {
 	ret := make([]repository.Components, 0, len(i.installQueue))
 	for _, components := range i.installQueue {
-		if err := installComponentsAndUpdateInventory(ctx, components, i.providerComponents, i.providerInventory); err != nil {
-			return nil, err
+		objs, err := replaceCertManager(ctx, i.proxy, opts.WebhookCertificates, components.Objs())
+		if err != nil {
+			return nil, i.rollback(ctx, opts, ret, err)
+		}
+
+		if err := installComponentsAndUpdateInventory(ctx, components, objs, opts.WebhookCertificates, i.providerComponents, i.providerInventory, i.proxy); err != nil {
+			// NOTE: The components of the provider failing installation could have been partially created, so they are rolled back too.
+			return nil, i.rollback(ctx, opts, append(ret, components), err)
 		}
 
 		ret = append(ret, components)
 	}
 
-	return ret, waitForProvidersReady(ctx, opts, i.installQueue, i.proxy)
+	if err := waitForProvidersReady(ctx, opts, i.installQueue, i.proxy); err != nil {
+		if opts.RollbackOnFailure {
+			return nil, i.rollback(ctx, opts, ret, err)
+		}
+		return ret, err
+	}
+	return ret, nil
+}
+
+// rollback deletes the components and the inventory entries of the given providers, if RollbackOnFailure is set,
+// and returns the error which caused the rollback, eventually enriched with the rollback outcome.
+func (i *providerInstaller) rollback(ctx context.Context, opts InstallOptions, installed []repository.Components, installErr error) error {
+	if !opts.RollbackOnFailure {
+		return installErr
+	}
+
+	log := logf.Log
+	log.Error(installErr, "Failed to install providers, rolling back")
+
+	// Delete the providers in reverse order, so e.g. the core provider is deleted last.
+	errList := []error{}
+	for j := len(installed) - 1; j >= 0; j-- {
+		deleteOptions := DeleteOptions{
+			Provider:         installed[j].InventoryObject(),
+			IncludeNamespace: false,
+			IncludeCRDs:      true,
+		}
+		if err := i.providerComponents.Delete(ctx, deleteOptions); err != nil {
+			errList = append(errList, errors.Wrapf(err, "failed to roll back provider %q", installed[j].ManifestLabel()))
+		}
+	}
+	if len(errList) > 0 {
+		return errors.Wrapf(kerrors.NewAggregate(errList), "failed to roll back the installation of providers after: %v", installErr)
+	}
+	return errors.Wrap(installErr, "installation of providers rolled back")
 }
 
-func installComponentsAndUpdateInventory(ctx context.Context, components repository.Components, providerComponents ComponentsClient, providerInventory InventoryClient) error {
+func installComponentsAndUpdateInventory(ctx context.Context, components repository.Components, objs []unstructured.Unstructured, webhookCertificates WebhookCertificatesMode, providerComponents ComponentsClient, providerInventory InventoryClient, proxy Proxy) error {
 	log := logf.Log
 	log.Info("Installing", "Provider", components.ManifestLabel(), "Version", components.Version(), "TargetNamespace", components.TargetNamespace())
 
 	inventoryObject := components.InventoryObject()
+	// Store how the webhook certificates are managed, so the same mode is used on upgrade.
+	setWebhookCertificatesMode(&inventoryObject, webhookCertificates)
 
 	log.V(1).Info("Creating objects", "Provider", components.ManifestLabel(), "Version", components.Version(), "TargetNamespace", components.TargetNamespace())
-	if err := providerComponents.Create(ctx, components.Objs()); err != nil {
+	if err := providerComponents.Create(ctx, objs); err != nil {
+		return err
+	}
+
+	// Record the checksum of the provider Deployments as applied, so it is possible to detect changes made afterwards.
+	checksum, err := componentsChecksum(ctx, proxy, inventoryObject)
+	if err != nil {
 		return err
 	}
+	inventoryObject.ComponentsChecksum = checksum
 
 	log.V(1).Info("Creating inventory entry", "Provider", components.ManifestLabel(), "Version", components.Version(), "TargetNamespace", components.TargetNamespace())
 	return providerInventory.Create(ctx, inventoryObject)
@@ -19,8 +19,13 @@ package cluster
 import (
 	"context"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 
@@ -261,6 +266,104 @@ func Test_providerInstaller_Validate(t *testing.T) {
 	}
 }
 
+func Test_providerInstaller_Install(t *testing.T) {
+	// A manager Deployment which never becomes available.
+	deployment := &appsv1.Deployment{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "infra1-controller-manager",
+			Namespace: "infra1-system",
+		},
+		Spec: appsv1.DeploymentSpec{
+			Template: corev1.PodTemplateSpec{
+				Spec: corev1.PodSpec{
+					Containers: []corev1.Container{{Name: "manager"}},
+				},
+			},
+		},
+	}
+	deploymentObj := unstructured.Unstructured{}
+	if err := test.FakeScheme.Convert(deployment, &deploymentObj, nil); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name        string
+		opts        InstallOptions
+		failCreate  int
+		wantErr     bool
+		wantDeleted []string
+	}{
+		{
+			name:        "does not roll back if the installation succeeds",
+			opts:        InstallOptions{RollbackOnFailure: true},
+			wantErr:     false,
+			wantDeleted: nil,
+		},
+		{
+			name:        "rolls back the providers installed so far if a provider fails to be installed",
+			opts:        InstallOptions{RollbackOnFailure: true},
+			failCreate:  2, // bootstrap1
+			wantErr:     true,
+			wantDeleted: []string{"bootstrap-bootstrap1", "cluster-api"},
+		},
+		{
+			name:        "does not roll back if a provider fails to be installed and RollbackOnFailure is not set",
+			failCreate:  2, // bootstrap1
+			wantErr:     true,
+			wantDeleted: nil,
+		},
+		{
+			name:        "rolls back all the providers if a provider does not become available",
+			opts:        InstallOptions{WaitProviders: true, WaitProviderTimeout: 200 * time.Millisecond, RollbackOnFailure: true},
+			wantErr:     true,
+			wantDeleted: []string{"infrastructure-infra1", "bootstrap-bootstrap1", "cluster-api"},
+		},
+		{
+			name:        "does not roll back if a provider does not become available and RollbackOnFailure is not set",
+			opts:        InstallOptions{WaitProviders: true, WaitProviderTimeout: 200 * time.Millisecond},
+			wantErr:     true,
+			wantDeleted: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			proxy := test.NewFakeProxy().WithObjs(deployment.DeepCopy())
+			components := &fakeComponentsClient{failCreate: tt.failCreate}
+			i := &providerInstaller{
+				proxy:              proxy,
+				providerComponents: components,
+				providerInventory:  newInventoryClient(proxy, nil),
+			}
+
+			newComponents := func(name string, providerType clusterctlv1.ProviderType, targetNamespace string) *fakeComponents {
+				c := newFakeComponents(name, providerType, "v1.0.0", targetNamespace).(*fakeComponents)
+				// Inventory objects to be created must not have a resourceVersion.
+				c.inventoryObject.ResourceVersion = ""
+				return c
+			}
+			infra := newComponents("infra1", clusterctlv1.InfrastructureProviderType, "infra1-system")
+			infra.objs = []unstructured.Unstructured{deploymentObj}
+			i.Add(infra)
+			i.Add(newComponents("bootstrap1", clusterctlv1.BootstrapProviderType, "bootstrap1-system"))
+			i.Add(newComponents("cluster-api", clusterctlv1.CoreProviderType, "cluster-api-system"))
+
+			_, err := i.Install(ctx, tt.opts)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(components.deleted).To(Equal(tt.wantDeleted))
+		})
+	}
+}
+
 func Test_providerInstaller_ValidateCRDName(t *testing.T) {
 	tests := []struct {
 		name    string
@@ -307,10 +410,11 @@ func Test_providerInstaller_ValidateCRDName(t *testing.T) {
 type fakeComponents struct {
 	config.Provider
 	inventoryObject clusterctlv1.Provider
+	objs            []unstructured.Unstructured
 }
 
 func (c *fakeComponents) Version() string {
-	panic("not implemented")
+	return c.inventoryObject.Version
 }
 
 func (c *fakeComponents) Variables() []string {
@@ -322,7 +426,7 @@ func (c *fakeComponents) Images() []string {
 }
 
 func (c *fakeComponents) TargetNamespace() string {
-	panic("not implemented")
+	return c.inventoryObject.Namespace
 }
 
 func (c *fakeComponents) InventoryObject() clusterctlv1.Provider {
@@ -330,7 +434,7 @@ func (c *fakeComponents) InventoryObject() clusterctlv1.Provider {
 }
 
 func (c *fakeComponents) Objs() []unstructured.Unstructured {
-	return []unstructured.Unstructured{}
+	return c.objs
 }
 
 func (c *fakeComponents) Yaml() ([]byte, error) {
@@ -345,6 +449,31 @@ func newFakeComponents(name string, providerType clusterctlv1.ProviderType, vers
 	}
 }
 
+// fakeComponentsClient is a ComponentsClient failing the n-th call to Create, if failCreate is set,
+// and keeping track of the providers being deleted.
+type fakeComponentsClient struct {
+	failCreate int
+	created    int
+	deleted    []string
+}
+
+func (c *fakeComponentsClient) Create(_ context.Context, _ []unstructured.Unstructured) error {
+	c.created++
+	if c.created == c.failCreate {
+		return errors.New("failed to create components")
+	}
+	return nil
+}
+
+func (c *fakeComponentsClient) Delete(_ context.Context, options DeleteOptions) error {
+	c.deleted = append(c.deleted, options.Provider.ManifestLabel())
+	return nil
+}
+
+func (c *fakeComponentsClient) DeleteWebhookNamespace(_ context.Context) error {
+	return nil
+}
+
 func newFakeCRD(name string, annotations map[string]string) unstructured.Unstructured {
 	u := unstructured.Unstructured{}
 	u.SetName(name)
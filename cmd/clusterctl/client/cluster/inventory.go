@@ -122,6 +122,10 @@ type InventoryClient interface {
 
 	// CheckSingleProviderInstance ensures that only one instance of a provider is running, returns error otherwise.
 	CheckSingleProviderInstance(ctx context.Context) error
+
+	// CheckDrift checks if the components of the providers in the inventory have been changed after clusterctl applied them,
+	// by comparing their checksum with the checksum recorded in the inventory.
+	CheckDrift(ctx context.Context) ([]ProviderDrift, error)
 }
 
 // inventoryClient implements InventoryClient.
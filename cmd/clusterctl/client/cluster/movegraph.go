@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// MoveGraph is a report of the object graph discovered by clusterctl move, with the Kubernetes objects as nodes
+// and the ownership relations between those objects as edges.
+type MoveGraph struct {
+	// Nodes is the list of objects in the graph, sorted by Kind, Namespace and Name.
+	Nodes []MoveGraphNode `json:"nodes"`
+}
+
+// MoveGraphNode is a single node of the MoveGraph.
+type MoveGraphNode struct {
+	// Object identifies the Kubernetes object represented by this node.
+	Object corev1.ObjectReference `json:"object"`
+
+	// Owners is the list of UIDs of the objects owning this node via OwnerReferences.
+	Owners []types.UID `json:"owners,omitempty"`
+
+	// SoftOwners is the list of UIDs of the objects owning this node without an explicit OwnerReference,
+	// e.g. secrets linked to a Cluster by a naming convention.
+	SoftOwners []types.UID `json:"softOwners,omitempty"`
+
+	// Tenants is the list of UIDs of the objects with a move hierarchy (e.g. Clusters or ClusterResourceSets)
+	// this node belongs to.
+	Tenants []types.UID `json:"tenants,omitempty"`
+
+	// Move is true if the object is going to be moved by clusterctl move.
+	Move bool `json:"move"`
+
+	// Virtual is true if the object is referenced by an OwnerReference but has not been observed
+	// as a concrete object, e.g. because its type is not considered by clusterctl move.
+	Virtual bool `json:"virtual,omitempty"`
+
+	// GlobalHierarchy is true if the object is part of the hierarchy of a global resource; such objects are
+	// not deleted from the source cluster.
+	GlobalHierarchy bool `json:"globalHierarchy,omitempty"`
+
+	// BlockingMove is true if the object has the block-move annotation.
+	BlockingMove bool `json:"blockingMove,omitempty"`
+}
+
+// toMoveGraph converts the objectGraph into a MoveGraph.
+// If clusterName is not empty, the graph is limited to the given Cluster and the objects belonging to its hierarchy.
+func (o *objectGraph) toMoveGraph(clusterName string) *MoveGraph {
+	var tenant *node
+	if clusterName != "" {
+		for _, c := range o.getClusters() {
+			if c.identity.Name == clusterName {
+				tenant = c
+				break
+			}
+		}
+		if tenant == nil {
+			return &MoveGraph{Nodes: []MoveGraphNode{}}
+		}
+	}
+
+	moveNodes := map[*node]empty{}
+	for _, n := range o.getMoveNodes() {
+		moveNodes[n] = empty{}
+	}
+
+	nodes := []MoveGraphNode{}
+	for _, n := range o.getNodes() {
+		if tenant != nil {
+			if _, ok := n.tenant[tenant]; !ok {
+				continue
+			}
+		}
+
+		graphNode := MoveGraphNode{
+			Object:          n.identity,
+			Virtual:         n.virtual,
+			GlobalHierarchy: n.isGlobalHierarchy,
+			BlockingMove:    n.blockingMove,
+		}
+		_, graphNode.Move = moveNodes[n]
+		for owner := range n.owners {
+			graphNode.Owners = append(graphNode.Owners, owner.identity.UID)
+		}
+		for owner := range n.softOwners {
+			graphNode.SoftOwners = append(graphNode.SoftOwners, owner.identity.UID)
+		}
+		for t := range n.tenant {
+			graphNode.Tenants = append(graphNode.Tenants, t.identity.UID)
+		}
+		sortUIDs(graphNode.Owners)
+		sortUIDs(graphNode.SoftOwners)
+		sortUIDs(graphNode.Tenants)
+
+		nodes = append(nodes, graphNode)
+	}
+
+	sort.Slice(nodes, func(i, j int) bool {
+		a, b := nodes[i].Object, nodes[j].Object
+		if a.Kind != b.Kind {
+			// Always list Clusters first, so the root of the hierarchy is easy to spot.
+			clusterKind := clusterv1.GroupVersion.WithKind("Cluster").Kind
+			if a.Kind == clusterKind || b.Kind == clusterKind {
+				return a.Kind == clusterKind
+			}
+			return a.Kind < b.Kind
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+
+	return &MoveGraph{Nodes: nodes}
+}
+
+func sortUIDs(uids []types.UID) {
+	sort.Slice(uids, func(i, j int) bool { return uids[i] < uids[j] })
+}
@@ -0,0 +1,88 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_objectGraph_toMoveGraph(t *testing.T) {
+	objs := []client.Object{}
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster1").Objs()...)
+	objs = append(objs, test.NewFakeCluster("ns1", "cluster2").Objs()...)
+
+	tests := []struct {
+		name        string
+		clusterName string
+		wantNodes   int
+	}{
+		{
+			name:        "all the objects in the graph",
+			clusterName: "",
+			wantNodes:   len(objs),
+		},
+		{
+			name:        "objects belonging to a single cluster",
+			clusterName: "cluster1",
+			wantNodes:   len(test.NewFakeCluster("ns1", "cluster1").Objs()),
+		},
+		{
+			name:        "cluster does not exist",
+			clusterName: "does-not-exist",
+			wantNodes:   0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			graph := getObjectGraphWithObjs(objs)
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			got := graph.toMoveGraph(tt.clusterName)
+			g.Expect(got.Nodes).To(HaveLen(tt.wantNodes))
+			if tt.wantNodes == 0 {
+				return
+			}
+
+			// Clusters are listed first.
+			g.Expect(got.Nodes[0].Object.Kind).To(Equal("Cluster"))
+
+			uids := map[string]bool{}
+			for _, n := range got.Nodes {
+				uids[string(n.Object.UID)] = true
+			}
+			for _, n := range got.Nodes {
+				g.Expect(n.Move).To(BeTrue(), "expected %s/%s to be moved", n.Object.Kind, n.Object.Name)
+				// All the objects in the graph belong to a cluster hierarchy, so each tenant must be a node of the graph.
+				g.Expect(n.Tenants).ToNot(BeEmpty())
+				for _, tenant := range n.Tenants {
+					g.Expect(uids).To(HaveKey(string(tenant)))
+				}
+			}
+		})
+	}
+}
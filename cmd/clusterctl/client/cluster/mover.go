@@ -25,10 +25,12 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -58,6 +60,10 @@ type ObjectMover interface {
 
 	// FromDirectory reads all the Cluster API objects existing in a configured directory to a target management cluster.
 	FromDirectory(ctx context.Context, toCluster Client, directory string) error
+
+	// Graph returns the graph of the Cluster API objects existing in a namespace (or from all the namespaces if empty)
+	// as discovered by move; if clusterName is not empty, the graph is limited to the objects belonging to the Cluster hierarchy.
+	Graph(ctx context.Context, namespace, clusterName string) (*MoveGraph, error)
 }
 
 // objectMover implements the ObjectMover interface.
@@ -113,6 +119,23 @@ func (o *objectMover) ToDirectory(ctx context.Context, namespace string, directo
 	return o.toDirectory(ctx, objectGraph, directory)
 }
 
+func (o *objectMover) Graph(ctx context.Context, namespace, clusterName string) (*MoveGraph, error) {
+	objectGraph := newObjectGraph(o.fromProxy, o.fromProviderInventory)
+
+	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	if err := objectGraph.getDiscoveryTypes(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve discovery types")
+	}
+
+	// NOTE: differently from move, the graph is returned also if provisioning is not completed, given that
+	// it is intended to be used for inspecting the object hierarchy and debugging ownership problems.
+	if err := objectGraph.Discovery(ctx, namespace); err != nil {
+		return nil, errors.Wrap(err, "failed to discover the object graph")
+	}
+
+	return objectGraph.toMoveGraph(clusterName), nil
+}
+
 func (o *objectMover) FromDirectory(ctx context.Context, toCluster Client, directory string) error {
 	log := logf.Log
 	log.Info("Moving from directory...")
@@ -335,6 +358,12 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 		return errors.Wrap(err, "error pausing ClusterClasses")
 	}
 
+	// Wait for the controllers in the source management cluster to acknowledge the pause, so no object is mutated during the move.
+	log.Info("Waiting for the controllers to acknowledge the pause")
+	if err := waitForPausedAcknowledged(ctx, o.fromProxy, graph.getMoveNodes(), true, o.dryRun, newWaitForPausedBackoff()); err != nil {
+		return errors.Wrap(err, "error waiting for the controllers to acknowledge the pause")
+	}
+
 	log.Info("Waiting for all resources to be ready to move")
 	// exponential backoff configuration which returns durations for a total time of ~2m.
 	// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
@@ -387,7 +416,15 @@ func (o *objectMover) move(ctx context.Context, graph *objectGraph, toProxy Prox
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun, mutators...); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Waiting for the target controllers to acknowledge the resume")
+	if err := waitForPausedAcknowledged(ctx, toProxy, graph.getMoveNodes(), false, o.dryRun, newWaitForPausedBackoff(), mutators...); err != nil {
+		return errors.Wrap(err, "error waiting for the target controllers to acknowledge the resume")
+	}
+	return nil
 }
 
 func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, directory string) error {
@@ -410,6 +447,12 @@ func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, direc
 		return errors.Wrap(err, "error pausing ClusterClasses")
 	}
 
+	// Wait for the controllers to acknowledge the pause, so no object is mutated while it is saved.
+	log.Info("Waiting for the controllers to acknowledge the pause")
+	if err := waitForPausedAcknowledged(ctx, o.fromProxy, graph.getMoveNodes(), true, o.dryRun, newWaitForPausedBackoff()); err != nil {
+		return errors.Wrap(err, "error waiting for the controllers to acknowledge the pause")
+	}
+
 	// Define the move sequence by processing the ownerReference chain, so we ensure that a Kubernetes object is moved only after its owners.
 	// The sequence is bases on object graph nodes, each one representing a Kubernetes object; nodes are grouped, so bulk of nodes can be moved in parallel. e.g.
 	// - All the Clusters should be moved first (group 1, processed in parallel)
@@ -433,7 +476,15 @@ func (o *objectMover) toDirectory(ctx context.Context, graph *objectGraph, direc
 
 	// Reset the pause field on the Cluster object in the target management cluster, so the controllers start reconciling it.
 	log.V(1).Info("Resuming the source cluster")
-	return setClusterPause(ctx, o.fromProxy, clusters, false, o.dryRun)
+	if err := setClusterPause(ctx, o.fromProxy, clusters, false, o.dryRun); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Waiting for the controllers to acknowledge the resume")
+	if err := waitForPausedAcknowledged(ctx, o.fromProxy, graph.getMoveNodes(), false, o.dryRun, newWaitForPausedBackoff()); err != nil {
+		return errors.Wrap(err, "error waiting for the controllers to acknowledge the resume")
+	}
+	return nil
 }
 
 func (o *objectMover) fromDirectory(ctx context.Context, graph *objectGraph, toProxy Proxy) error {
@@ -475,7 +526,15 @@ func (o *objectMover) fromDirectory(ctx context.Context, graph *objectGraph, toP
 	// Resume reconciling the Clusters after being restored from a directory.
 	// By default, when moved to a directory, Clusters are paused, so they must be unpaused to be used again.
 	log.V(1).Info("Resuming the target cluster")
-	return setClusterPause(ctx, toProxy, clusters, false, o.dryRun)
+	if err := setClusterPause(ctx, toProxy, clusters, false, o.dryRun); err != nil {
+		return err
+	}
+
+	log.V(1).Info("Waiting for the target controllers to acknowledge the resume")
+	if err := waitForPausedAcknowledged(ctx, toProxy, graph.getMoveNodes(), false, o.dryRun, newWaitForPausedBackoff()); err != nil {
+		return errors.Wrap(err, "error waiting for the target controllers to acknowledge the resume")
+	}
+	return nil
 }
 
 // moveSequence defines a list of group of moveGroups.
@@ -670,6 +729,133 @@ func waitReadyForMove(ctx context.Context, proxy Proxy, nodes []*node, dryRun bo
 	return nil
 }
 
+// pausedConditionKinds are the kinds of the objects whose controllers are expected to acknowledge the pause with the
+// Paused condition; a warning is logged if the CRD of one of those kinds does not declare support for the condition,
+// e.g. because an older version of the provider is installed.
+var pausedConditionKinds = sets.New(
+	schema.GroupKind{Group: clusterv1.GroupVersion.Group, Kind: clusterv1.ClusterKind},
+	schema.GroupKind{Group: clusterv1.GroupVersion.Group, Kind: "MachineDeployment"},
+	schema.GroupKind{Group: clusterv1.GroupVersion.Group, Kind: "MachineSet"},
+	schema.GroupKind{Group: clusterv1.GroupVersion.Group, Kind: "Machine"},
+	schema.GroupKind{Group: "controlplane.cluster.x-k8s.io", Kind: "KubeadmControlPlane"},
+)
+
+// newWaitForPausedBackoff creates a new API Machinery backoff parameter set suitable for use when waiting for
+// controllers to acknowledge that reconciliation has been paused or resumed.
+func newWaitForPausedBackoff() wait.Backoff {
+	// exponential backoff configuration which returns durations for a total time of ~2m.
+	// Example: 0, 5s, 8s, 11s, 17s, 26s, 38s, 57s, 86s, 128s
+	return wait.Backoff{
+		Duration: 5 * time.Second,
+		Factor:   1.5,
+		Steps:    10,
+		Jitter:   0.1,
+	}
+}
+
+// waitForPausedAcknowledged waits for the controllers to acknowledge that reconciliation of the objects has been paused,
+// i.e. for the Paused condition to be true, or resumed, i.e. for the Paused condition to be removed.
+// The wait is enforced only for the objects whose CRD is labeled with the clusterctl.cluster.x-k8s.io/paused-condition
+// label, i.e. whose provider declares support for the Paused condition; all the other objects are skipped.
+// Objects not reporting any condition are skipped as well, given that they have not been reconciled yet and their controller
+// is going to observe the paused state the first time it reconciles them.
+func waitForPausedAcknowledged(ctx context.Context, proxy Proxy, nodes []*node, paused bool, dryRun bool, backoff wait.Backoff, mutators ...ResourceMutatorFunc) error {
+	if dryRun {
+		return nil
+	}
+
+	log := logf.Log
+
+	c, err := proxy.NewClient()
+	if err != nil {
+		return errors.Wrap(err, "error creating client")
+	}
+
+	supportedKinds, err := getPausedConditionSupportedKinds(ctx, proxy)
+	if err != nil {
+		return err
+	}
+
+	unsupportedKinds := sets.New[schema.GroupKind]()
+	for _, n := range nodes {
+		groupKind := n.identity.GroupVersionKind().GroupKind()
+		if !supportedKinds.Has(groupKind) {
+			if pausedConditionKinds.Has(groupKind) && !unsupportedKinds.Has(groupKind) {
+				log.Info(fmt.Sprintf("Warning: the provider of %s does not declare support for the Paused condition, skipping the wait for the paused state to be acknowledged", groupKind))
+				unsupportedKinds.Insert(groupKind)
+			}
+			continue
+		}
+
+		log := log.WithValues(
+			"apiVersion", n.identity.GroupVersionKind(),
+			"resource", klog.ObjectRef{
+				Name:      n.identity.Name,
+				Namespace: n.identity.Namespace,
+			},
+		)
+
+		// Mutators can only affect the namespace of the resource here.
+		obj, err := applyMutators(&metav1.PartialObjectMetadata{
+			TypeMeta: metav1.TypeMeta{
+				APIVersion: n.identity.APIVersion,
+				Kind:       n.identity.Kind,
+			},
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      n.identity.Name,
+				Namespace: n.identity.Namespace,
+			},
+		}, mutators...)
+		if err != nil {
+			return err
+		}
+		key := client.ObjectKeyFromObject(obj)
+
+		waitLogged := false
+		if err := retryWithExponentialBackoff(ctx, backoff, func(ctx context.Context) error {
+			if err := c.Get(ctx, key, obj); err != nil {
+				return errors.Wrapf(err, "error getting %s/%s", obj.GroupVersionKind(), key)
+			}
+
+			getter := conditions.UnstructuredGetter(obj)
+			if len(getter.GetConditions()) == 0 {
+				log.V(5).Info("Resource not reconciled yet, skipping the Paused condition check")
+				return nil
+			}
+
+			if paused == conditions.IsTrue(getter, clusterv1.PausedCondition) {
+				log.V(5).Info("Paused state acknowledged", "paused", paused)
+				return nil
+			}
+			if !waitLogged {
+				log.Info("Waiting for the controller to acknowledge the paused state", "paused", paused)
+				waitLogged = true
+			}
+			return errors.Errorf("paused state not acknowledged yet: %s/%s", obj.GroupVersionKind(), key)
+		}); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// getPausedConditionSupportedKinds returns the kinds whose CRD is labeled with the clusterctl.cluster.x-k8s.io/paused-condition label.
+func getPausedConditionSupportedKinds(ctx context.Context, proxy Proxy) (sets.Set[schema.GroupKind], error) {
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	if err := getCRDList(ctx, proxy, crdList); err != nil {
+		return nil, err
+	}
+
+	kinds := sets.New[schema.GroupKind]()
+	for _, crd := range crdList.Items {
+		if crd.Labels[clusterctlv1.ClusterctlPausedConditionLabel] == "true" {
+			kinds.Insert(schema.GroupKind{Group: crd.Spec.Group, Kind: crd.Spec.Names.Kind})
+		}
+	}
+	return kinds, nil
+}
+
 // patchCluster applies a patch to a node referring to a Cluster object.
 func patchCluster(ctx context.Context, proxy Proxy, n *node, patch client.Patch, mutators ...ResourceMutatorFunc) error {
 	cFrom, err := proxy.NewClient()
@@ -27,6 +27,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -2408,3 +2409,133 @@ func TestWaitReadyForMove(t *testing.T) {
 		})
 	}
 }
+
+func TestWaitForPausedAcknowledged(t *testing.T) {
+	tests := []struct {
+		name                  string
+		conditions            clusterv1.Conditions
+		paused                bool
+		eventuallyUpdate      clusterv1.Conditions
+		pausedConditionNotSet bool
+		wantErr               bool
+	}{
+		{
+			name:                  "resources whose provider does not support the Paused condition are skipped",
+			conditions:            clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ReadyCondition)},
+			paused:                true,
+			pausedConditionNotSet: true,
+			wantErr:               false,
+		},
+		{
+			name:       "not reconciled resources are skipped",
+			conditions: nil,
+			paused:     true,
+			wantErr:    false,
+		},
+		{
+			name:       "pause not acknowledged should fail",
+			conditions: clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ReadyCondition)},
+			paused:     true,
+			wantErr:    true,
+		},
+		{
+			name: "pause acknowledged should succeed",
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.ReadyCondition),
+				*conditions.TrueCondition(clusterv1.PausedCondition),
+			},
+			paused:  true,
+			wantErr: false,
+		},
+		{
+			name:       "pause eventually acknowledged should succeed",
+			conditions: clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ReadyCondition)},
+			paused:     true,
+			eventuallyUpdate: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.ReadyCondition),
+				*conditions.TrueCondition(clusterv1.PausedCondition),
+			},
+			wantErr: false,
+		},
+		{
+			name: "resume not acknowledged should fail",
+			conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.ReadyCondition),
+				*conditions.TrueCondition(clusterv1.PausedCondition),
+			},
+			paused:  false,
+			wantErr: true,
+		},
+		{
+			name:       "resume acknowledged should succeed",
+			conditions: clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ReadyCondition)},
+			paused:     false,
+			wantErr:    false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterName := "foo"
+			clusterNamespace := "ns1"
+			objs := test.NewFakeCluster(clusterNamespace, clusterName).Objs()
+			for _, o := range objs {
+				if cluster, ok := o.(*clusterv1.Cluster); ok {
+					cluster.Status.Conditions = tt.conditions
+				}
+			}
+
+			ctx := context.Background()
+
+			// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+			graph := getObjectGraphWithObjs(objs)
+
+			// Get all the types to be considered for discovery
+			g.Expect(graph.getDiscoveryTypes(ctx)).To(Succeed())
+
+			// trigger discovery the content of the source cluster
+			g.Expect(graph.Discovery(ctx, "")).To(Succeed())
+
+			if tt.pausedConditionNotSet {
+				// Simulate a provider which does not declare support for the Paused condition.
+				c, err := graph.proxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				crd := &apiextensionsv1.CustomResourceDefinition{}
+				g.Expect(c.Get(ctx, client.ObjectKey{Name: "cluster.cluster.x-k8s.io"}, crd)).To(Succeed())
+				delete(crd.Labels, clusterctlv1.ClusterctlPausedConditionLabel)
+				g.Expect(c.Update(ctx, crd)).To(Succeed())
+			}
+
+			backoff := wait.Backoff{
+				Steps: 1,
+			}
+			if tt.eventuallyUpdate != nil {
+				c, err := graph.proxy.NewClient()
+				g.Expect(err).NotTo(HaveOccurred())
+
+				cluster := &clusterv1.Cluster{}
+				g.Expect(c.Get(ctx, types.NamespacedName{Namespace: clusterNamespace, Name: clusterName}, cluster)).To(Succeed())
+				go func() {
+					time.Sleep(50 * time.Millisecond)
+					cluster.Status.Conditions = tt.eventuallyUpdate
+					g.Expect(c.Update(ctx, cluster)).To(Succeed())
+				}()
+
+				backoff = wait.Backoff{
+					Duration: 20 * time.Millisecond,
+					Steps:    10,
+				}
+			}
+
+			err := waitForPausedAcknowledged(ctx, graph.proxy, graph.getMoveNodes(), tt.paused, false, backoff)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).NotTo(HaveOccurred())
+			}
+		})
+	}
+}
@@ -27,6 +27,7 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -34,10 +35,12 @@ import (
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/move"
 	secretutil "sigs.k8s.io/cluster-api/util/secret"
 )
 
 const clusterTopologyNameKey = "cluster.spec.topology.class"
+const clusterTopologyNamespaceKey = "cluster.spec.topology.classNamespace"
 const clusterResourceSetBindingClusterNameKey = "clusterresourcesetbinding.spec.clustername"
 
 type empty struct{}
@@ -96,6 +99,40 @@ type node struct {
 	// blockingMove is true when the object should prevent a move operation from proceeding as indicated by
 	// the presence of the block-move annotation.
 	blockingMove bool
+
+	// moveWith contains the list of objects the node should be moved with, as indicated by the move-with annotation.
+	moveWith []moveWithReference
+}
+
+// moveWithReference is a reference to an object in the move-with annotation.
+type moveWithReference struct {
+	groupKind schema.GroupKind
+	name      string
+}
+
+// parseMoveWithAnnotation parses the references in the move-with annotation of an object.
+func parseMoveWithAnnotation(obj *unstructured.Unstructured) ([]moveWithReference, error) {
+	value, ok := obj.GetAnnotations()[move.Annotation]
+	if !ok {
+		return nil, nil
+	}
+
+	refs := []moveWithReference{}
+	for _, s := range strings.Split(value, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		groupKind, name, ok := strings.Cut(s, "/")
+		if !ok || groupKind == "" || name == "" || strings.Contains(name, "/") {
+			return nil, errors.Errorf("invalid %s annotation: %q must be in the <Kind>.<group>/<name> format", move.Annotation, s)
+		}
+		refs = append(refs, moveWithReference{
+			groupKind: schema.ParseGroupKind(groupKind),
+			name:      name,
+		})
+	}
+	return refs, nil
 }
 
 type discoveryTypeInfo struct {
@@ -149,6 +186,7 @@ func (n *node) captureAdditionalInformation(obj *unstructured.Unstructured) erro
 				n.additionalInfo = map[string]interface{}{}
 			}
 			n.additionalInfo[clusterTopologyNameKey] = cluster.Spec.Topology.Class
+			n.additionalInfo[clusterTopologyNamespaceKey] = cluster.GetClassKey().Namespace
 		}
 	}
 
@@ -295,6 +333,11 @@ func (o *objectGraph) objToNode(obj *unstructured.Unstructured) (*node, error) {
 
 func (o *objectGraph) objInfoToNode(obj *unstructured.Unstructured, n *node) error {
 	o.objMetaToNode(obj, n)
+	moveWith, err := parseMoveWithAnnotation(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to parse annotations of object %s", n.identityStr())
+	}
+	n.moveWith = moveWith
 	if err := n.captureAdditionalInformation(obj); err != nil {
 		return errors.Wrapf(err, "failed to capture additional information of object %s", n.identityStr())
 	}
@@ -621,7 +664,7 @@ func (o *objectGraph) setSoftOwnership() {
 			// if the cluster uses a managed topology and uses the clusterclass
 			// set the clusterclass as a soft owner of the cluster.
 			if className, ok := cluster.additionalInfo[clusterTopologyNameKey]; ok {
-				if className == clusterClass.identity.Name && clusterClass.identity.Namespace == cluster.identity.Namespace {
+				if className == clusterClass.identity.Name && clusterClass.identity.Namespace == cluster.additionalInfo[clusterTopologyNamespaceKey] {
 					cluster.addSoftOwner(clusterClass)
 				}
 			}
@@ -642,6 +685,36 @@ func (o *objectGraph) setSoftOwnership() {
 			}
 		}
 	}
+
+	// Objects with the move-with annotation are soft owned by the objects referenced in the annotation.
+	// NOTE: Only objects of the discovered types are part of the graph, so references to other types are never found.
+	discoveredGroupKinds := map[schema.GroupKind]bool{}
+	for _, t := range o.types {
+		discoveredGroupKinds[t.typeMeta.GroupVersionKind().GroupKind()] = true
+	}
+	for _, node := range o.getNodes() {
+		for _, ref := range node.moveWith {
+			found := false
+			for _, other := range o.getNodes() {
+				if other.virtual || other == node {
+					continue
+				}
+				if other.identity.GroupVersionKind().GroupKind() == ref.groupKind && other.identity.Name == ref.name &&
+					(other.identity.Namespace == node.identity.Namespace || other.isGlobal) {
+					node.addSoftOwner(other)
+					found = true
+				}
+			}
+			if found {
+				continue
+			}
+			if !discoveredGroupKinds[ref.groupKind] {
+				log.Info("Warning: object referenced in the move-with annotation is of a type not supported by clusterctl move", "kind", node.identity.Kind, "name", node.identity.Name, "ref", ref.groupKind.String()+"/"+ref.name)
+				continue
+			}
+			log.V(5).Info("Object referenced in the move-with annotation not found", "kind", node.identity.Kind, "name", node.identity.Name, "ref", ref.groupKind.String()+"/"+ref.name)
+		}
+	}
 }
 
 // setTenants identifies all the nodes linked to a parent with forceMoveHierarchy = true (e.g. Clusters or ClusterResourceSet)
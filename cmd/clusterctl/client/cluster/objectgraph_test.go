@@ -24,15 +24,19 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util/move"
 )
 
 func TestObjectGraph_getDiscoveryTypeMetaList(t *testing.T) {
@@ -2109,6 +2113,58 @@ func Test_objectGraph_setSoftOwnership(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "A Cluster with a soft owned companion secret",
+			fields: fields{
+				objs: func() []client.Object {
+					objs := test.NewFakeCluster("ns1", "cluster1").Objs()
+					objs = append(objs, &corev1.Secret{
+						TypeMeta: metav1.TypeMeta{
+							Kind:       "Secret",
+							APIVersion: "v1",
+						},
+						ObjectMeta: metav1.ObjectMeta{
+							Name:      "credentials",
+							Namespace: "ns1",
+							UID:       "/v1, Kind=Secret, ns1/credentials",
+							Annotations: map[string]string{
+								move.Annotation: "Cluster.cluster.x-k8s.io/cluster1, Cluster.cluster.x-k8s.io/cluster2",
+							},
+						},
+					})
+
+					return objs
+				}(),
+			},
+			want: wantGraph{
+				nodes: map[string]wantGraphItem{
+					"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1": {
+						forceMove:          true,
+						forceMoveHierarchy: true,
+					},
+					"infrastructure.cluster.x-k8s.io/v1beta1, Kind=GenericInfrastructureCluster, ns1/cluster1": {
+						owners: []string{
+							"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1",
+						},
+					},
+					"/v1, Kind=Secret, ns1/cluster1-ca": {
+						softOwners: []string{
+							"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1",
+						},
+					},
+					"/v1, Kind=Secret, ns1/cluster1-kubeconfig": {
+						owners: []string{
+							"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1",
+						},
+					},
+					"/v1, Kind=Secret, ns1/credentials": {
+						softOwners: []string{
+							"cluster.x-k8s.io/v1beta1, Kind=Cluster, ns1/cluster1", // NB. this secret is not linked to the cluster through owner ref or naming convention, but via the move-with annotation
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2527,3 +2583,53 @@ func deduplicateObjects(objs []client.Object) []client.Object {
 	}
 	return res
 }
+
+func Test_parseMoveWithAnnotation(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   *string
+		want    []moveWithReference
+		wantErr bool
+	}{
+		{
+			name: "no annotation",
+			want: nil,
+		},
+		{
+			name:  "references in the core and in other groups",
+			value: pointer.String("Cluster.cluster.x-k8s.io/cluster1, ConfigMap/pool1,"),
+			want: []moveWithReference{
+				{groupKind: schema.GroupKind{Group: "cluster.x-k8s.io", Kind: "Cluster"}, name: "cluster1"},
+				{groupKind: schema.GroupKind{Kind: "ConfigMap"}, name: "pool1"},
+			},
+		},
+		{
+			name:    "fails for a reference without name",
+			value:   pointer.String("Cluster.cluster.x-k8s.io"),
+			wantErr: true,
+		},
+		{
+			name:    "fails for a reference with a namespace",
+			value:   pointer.String("Cluster.cluster.x-k8s.io/ns1/cluster1"),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := &unstructured.Unstructured{}
+			if tt.value != nil {
+				obj.SetAnnotations(map[string]string{move.Annotation: *tt.value})
+			}
+
+			got, err := parseMoveWithAnnotation(obj)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+)
+
+// ClusterPauser defines methods for pausing and resuming the reconciliation of many Clusters at once.
+type ClusterPauser interface {
+	// Pause sets spec.paused on the Clusters matching the selector in a namespace (or in all the namespaces if empty),
+	// and waits for the controllers to acknowledge the pause on the Clusters and their descendants.
+	// It returns the Clusters matching the selector.
+	Pause(ctx context.Context, namespace string, selector labels.Selector, dryRun bool) ([]client.ObjectKey, error)
+
+	// Resume unsets spec.paused on the Clusters matching the selector in a namespace (or in all the namespaces if empty),
+	// and waits for the controllers to acknowledge the resume on the Clusters and their descendants.
+	// It returns the Clusters matching the selector.
+	Resume(ctx context.Context, namespace string, selector labels.Selector, dryRun bool) ([]client.ObjectKey, error)
+}
+
+// clusterPauser implements the ClusterPauser interface.
+type clusterPauser struct {
+	proxy             Proxy
+	providerInventory InventoryClient
+	backoff           wait.Backoff
+}
+
+// ensure clusterPauser implements the ClusterPauser interface.
+var _ ClusterPauser = &clusterPauser{}
+
+func newClusterPauser(proxy Proxy, providerInventory InventoryClient) *clusterPauser {
+	return &clusterPauser{
+		proxy:             proxy,
+		providerInventory: providerInventory,
+		backoff:           newWaitForPausedBackoff(),
+	}
+}
+
+func (p *clusterPauser) Pause(ctx context.Context, namespace string, selector labels.Selector, dryRun bool) ([]client.ObjectKey, error) {
+	return p.setPaused(ctx, namespace, selector, true, dryRun)
+}
+
+func (p *clusterPauser) Resume(ctx context.Context, namespace string, selector labels.Selector, dryRun bool) ([]client.ObjectKey, error) {
+	return p.setPaused(ctx, namespace, selector, false, dryRun)
+}
+
+func (p *clusterPauser) setPaused(ctx context.Context, namespace string, selector labels.Selector, paused bool, dryRun bool) ([]client.ObjectKey, error) {
+	log := logf.Log
+
+	keys, err := p.getSelectedClusters(ctx, namespace, selector)
+	if err != nil {
+		return nil, err
+	}
+	if len(keys) == 0 {
+		log.Info("No Clusters matching the selector")
+		return nil, nil
+	}
+
+	graph := newObjectGraph(p.proxy, p.providerInventory)
+
+	// Gets all the types defined by the CRDs installed by clusterctl plus the ConfigMap/Secret core types.
+	if err := graph.getDiscoveryTypes(ctx); err != nil {
+		return nil, errors.Wrap(err, "failed to retrieve discovery types")
+	}
+
+	// Discovery the object graph, so it is possible to wait for the descendants of the Clusters to acknowledge the paused state.
+	if err := graph.Discovery(ctx, namespace); err != nil {
+		return nil, errors.Wrap(err, "failed to discover the object graph")
+	}
+
+	selected := sets.New(keys...)
+	clusters := []*node{}
+	for _, cluster := range graph.getClusters() {
+		if selected.Has(client.ObjectKey{Namespace: cluster.identity.Namespace, Name: cluster.identity.Name}) {
+			clusters = append(clusters, cluster)
+		}
+	}
+
+	// Only the objects belonging to the selected Clusters are considered when waiting for the acknowledgment.
+	nodes := []*node{}
+	for _, n := range graph.getMoveNodes() {
+		for _, cluster := range clusters {
+			if _, ok := n.tenant[cluster]; ok {
+				nodes = append(nodes, n)
+				break
+			}
+		}
+	}
+
+	log.Info("Setting Cluster.Spec.Paused", "paused", paused, "Clusters", len(clusters))
+	if err := setClusterPause(ctx, p.proxy, clusters, paused, dryRun); err != nil {
+		return nil, err
+	}
+
+	log.Info("Waiting for the controllers to acknowledge the paused state", "paused", paused)
+	if err := waitForPausedAcknowledged(ctx, p.proxy, nodes, paused, dryRun, p.backoff); err != nil {
+		return nil, errors.Wrap(err, "error waiting for the controllers to acknowledge the paused state")
+	}
+
+	return keys, nil
+}
+
+// getSelectedClusters returns the Clusters matching the selector in a namespace (or in all the namespaces if empty).
+func (p *clusterPauser) getSelectedClusters(ctx context.Context, namespace string, selector labels.Selector) ([]client.ObjectKey, error) {
+	c, err := p.proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := retryWithExponentialBackoff(ctx, newReadBackoff(), func(ctx context.Context) error {
+		return c.List(ctx, clusterList, client.InNamespace(namespace), client.MatchingLabelsSelector{Selector: selector})
+	}); err != nil {
+		return nil, errors.Wrap(err, "failed to list Clusters")
+	}
+
+	keys := make([]client.ObjectKey, 0, len(clusterList.Items))
+	for i := range clusterList.Items {
+		keys = append(keys, client.ObjectKeyFromObject(&clusterList.Items[i]))
+	}
+	return keys, nil
+}
@@ -0,0 +1,163 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestClusterPauser(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "foo").Objs()
+	objs = append(objs, test.NewFakeCluster("ns1", "bar").Objs()...)
+	for _, o := range objs {
+		if cluster, ok := o.(*clusterv1.Cluster); ok && cluster.Name == "foo" {
+			cluster.Labels = map[string]string{"env": "production"}
+		}
+	}
+
+	// Create an objectGraph bound a source cluster with all the CRDs for the types involved in the test.
+	graph := getObjectGraphWithObjs(objs)
+	p := &clusterPauser{
+		proxy:             graph.proxy,
+		providerInventory: graph.providerInventory,
+		backoff:           wait.Backoff{Steps: 1},
+	}
+
+	selector, err := labels.Parse("env=production")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	c, err := graph.proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	foo := client.ObjectKey{Namespace: "ns1", Name: "foo"}
+	bar := client.ObjectKey{Namespace: "ns1", Name: "bar"}
+	isPaused := func(key client.ObjectKey) bool {
+		cluster := &clusterv1.Cluster{}
+		g.Expect(c.Get(ctx, key, cluster)).To(Succeed())
+		return cluster.Spec.Paused
+	}
+
+	// Dry run doesn't pause the Clusters.
+	clusters, err := p.Pause(ctx, "ns1", selector, true)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusters).To(ConsistOf(foo))
+	g.Expect(isPaused(foo)).To(BeFalse())
+
+	// Only the selected Clusters are paused.
+	clusters, err = p.Pause(ctx, "ns1", selector, false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusters).To(ConsistOf(foo))
+	g.Expect(isPaused(foo)).To(BeTrue())
+	g.Expect(isPaused(bar)).To(BeFalse())
+
+	// Only the selected Clusters are resumed.
+	barCluster := &clusterv1.Cluster{}
+	g.Expect(c.Get(ctx, bar, barCluster)).To(Succeed())
+	barCluster.Spec.Paused = true
+	g.Expect(c.Update(ctx, barCluster)).To(Succeed())
+
+	clusters, err = p.Resume(ctx, "ns1", selector, false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusters).To(ConsistOf(foo))
+	g.Expect(isPaused(foo)).To(BeFalse())
+	g.Expect(isPaused(bar)).To(BeTrue())
+
+	// No Clusters are returned if no Cluster matches the selector.
+	selector, err = labels.Parse("env=staging")
+	g.Expect(err).ToNot(HaveOccurred())
+	clusters, err = p.Pause(ctx, "ns1", selector, false)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusters).To(BeEmpty())
+}
+
+func TestClusterPauser_PausedConditionSupport(t *testing.T) {
+	tests := []struct {
+		name                  string
+		pausedConditionNotSet bool
+		wantErr               bool
+	}{
+		{
+			name:                  "pause succeeds without waiting if the provider does not declare support for the Paused condition",
+			pausedConditionNotSet: true,
+			wantErr:               false,
+		},
+		{
+			name:                  "pause fails if the provider declares support for the Paused condition but does not acknowledge it",
+			pausedConditionNotSet: false,
+			wantErr:               true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			objs := test.NewFakeCluster("ns1", "foo").Objs()
+			for _, o := range objs {
+				if cluster, ok := o.(*clusterv1.Cluster); ok {
+					cluster.Labels = map[string]string{"env": "production"}
+					// Mark the Cluster as reconciled, so the Paused condition is waited for.
+					cluster.Status.Conditions = clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ReadyCondition)}
+				}
+			}
+
+			graph := getObjectGraphWithObjs(objs)
+			p := &clusterPauser{
+				proxy:             graph.proxy,
+				providerInventory: graph.providerInventory,
+				backoff:           wait.Backoff{Steps: 1},
+			}
+
+			if tt.pausedConditionNotSet {
+				// Simulate a provider which does not declare support for the Paused condition.
+				c, err := graph.proxy.NewClient()
+				g.Expect(err).ToNot(HaveOccurred())
+
+				crd := &apiextensionsv1.CustomResourceDefinition{}
+				g.Expect(c.Get(ctx, client.ObjectKey{Name: "cluster.cluster.x-k8s.io"}, crd)).To(Succeed())
+				delete(crd.Labels, clusterctlv1.ClusterctlPausedConditionLabel)
+				g.Expect(c.Update(ctx, crd)).To(Succeed())
+			}
+
+			selector, err := labels.Parse("env=production")
+			g.Expect(err).ToNot(HaveOccurred())
+
+			_, err = p.Pause(ctx, "ns1", selector, false)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -56,6 +56,7 @@ const (
 // TopologyClient has methods to work with ClusterClass and ManagedTopologies.
 type TopologyClient interface {
 	Plan(ctx context.Context, in *TopologyPlanInput) (*TopologyPlanOutput, error)
+	Rebase(ctx context.Context, in *TopologyRebaseInput) (*TopologyRebaseOutput, error)
 }
 
 // topologyClient implements TopologyClient.
@@ -468,12 +469,12 @@ func (t *topologyClient) reconcileClusterClasses(ctx context.Context, inputObjec
 
 	// Add ClusterClasses from the input
 	inClusterClasses := getClusterClasses(inputObjects)
-	cc := clusterv1.ClusterClass{}
 	for _, class := range inClusterClasses {
-		if err := scheme.Scheme.Convert(class, &cc, ctx); err != nil {
+		cc := &clusterv1.ClusterClass{}
+		if err := scheme.Scheme.Convert(class, cc, ctx); err != nil {
 			return nil, errors.Wrapf(err, "failed to convert object %s/%s to ClusterClass", class.GetNamespace(), class.GetName())
 		}
-		allClusterClasses = append(allClusterClasses, &cc)
+		allClusterClasses = append(allClusterClasses, cc)
 	}
 
 	// Each ClusterClass should be reconciled in order to ensure variables are correctly added to `status.variables`.
@@ -487,19 +488,21 @@ func (t *topologyClient) reconcileClusterClasses(ctx context.Context, inputObjec
 		reconciledClusterClasses = append(reconciledClusterClasses, reconciledClusterClass)
 	}
 
-	// Remove the ClusterClasses from the input objects and replace them with the reconciled version.
+	// Replace the ClusterClasses in the input objects with the reconciled version.
+	// NOTE: The ClusterClasses from the management cluster are not added to the input objects.
 	for i, obj := range inputObjects {
-		if obj.GroupVersionKind() == clusterv1.GroupVersion.WithKind("ClusterClass") {
-			// remove the clusterclass from the list of reconciled clusterclasses if it was not in the input.
-			inputObjects = append(inputObjects[:i], inputObjects[i+1:]...)
+		if obj.GroupVersionKind() != clusterv1.GroupVersion.WithKind("ClusterClass") {
+			continue
 		}
-	}
-	for _, class := range reconciledClusterClasses {
-		obj := &unstructured.Unstructured{}
-		if err := localScheme.Convert(class, obj, nil); err != nil {
-			return nil, errors.Wrapf(err, "failed to convert %s to object", obj.GetKind())
+		for _, class := range reconciledClusterClasses {
+			if class.GetNamespace() == obj.GetNamespace() && class.GetName() == obj.GetName() {
+				reconciledObj := &unstructured.Unstructured{}
+				if err := localScheme.Convert(class, reconciledObj, nil); err != nil {
+					return nil, errors.Wrapf(err, "failed to convert %s to object", obj.GetKind())
+				}
+				inputObjects[i] = reconciledObj
+			}
 		}
-		inputObjects = append(inputObjects, obj)
 	}
 
 	// Return a list of successfully reconciled ClusterClasses.
@@ -698,7 +701,7 @@ func (t *topologyClient) affectedClusters(ctx context.Context, in *TopologyPlanI
 	// Each of the Cluster that uses the ClusterClass in the input is an affected cluster.
 	for _, cc := range affectedClusterClasses {
 		for i := range clusterList.Items {
-			if clusterList.Items[i].Spec.Topology != nil && clusterList.Items[i].Spec.Topology.Class == cc.Name {
+			if clusterList.Items[i].Spec.Topology != nil && clusterList.Items[i].GetClassKey() == cc {
 				affectedClusters[client.ObjectKeyFromObject(&clusterList.Items[i])] = true
 			}
 		}
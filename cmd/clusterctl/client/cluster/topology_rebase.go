@@ -0,0 +1,258 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/topology/check"
+)
+
+// TopologyRebaseInput defines the input for the Rebase function.
+type TopologyRebaseInput struct {
+	// Objs is an optional list of objects, e.g. a new revision of a ClusterClass and its templates, to be
+	// considered together with the objects in the management cluster.
+	Objs []*unstructured.Unstructured
+	// ClusterName is the name of the Cluster to rebase.
+	ClusterName string
+	// ClusterClassName is the name of the ClusterClass to rebase the Cluster to.
+	ClusterClassName string
+	// TargetNamespace is the namespace of the Cluster; if empty, the current namespace is used.
+	TargetNamespace string
+}
+
+// TopologyRebaseOutput defines the output of the Rebase function.
+type TopologyRebaseOutput struct {
+	// Cluster is the Cluster to rebase.
+	Cluster client.ObjectKey
+	// CurrentClusterClass is the name of the ClusterClass currently used by the Cluster.
+	CurrentClusterClass string
+	// TargetClusterClass is the name of the ClusterClass the Cluster is rebased to.
+	TargetClusterClass string
+	// CompatibilityErrors is the list of the reasons why the Cluster cannot be rebased to the target ClusterClass.
+	// If not empty, the topology reconciler is not executed and ChangeSummary and Impacts are empty.
+	CompatibilityErrors field.ErrorList
+	// Impacts is the list of the MachineDeployments and of the control plane modified by the rebase,
+	// with the changes triggering a rollout of their Machines, if any.
+	Impacts []TopologyRebaseImpact
+	// ChangeSummary is the full list of changes (objects created, modified and deleted) observed
+	// during the dry run of the topology reconciler on the rebased Cluster.
+	*ChangeSummary
+}
+
+// TopologyRebaseImpact defines the impact of a rebase on the Machines of a MachineDeployment or of a control plane.
+type TopologyRebaseImpact struct {
+	// Object is the modified MachineDeployment or control plane.
+	Object *unstructured.Unstructured
+	// Replicas is the number of Machines of the object, if defined.
+	Replicas *int64
+	// RolloutChanges is the list of changed fields triggering a rollout of the Machines of the object;
+	// if empty, the object is modified without replacing its Machines.
+	RolloutChanges []string
+}
+
+// Rollout returns true if the Machines of the object are going to be replaced.
+func (i TopologyRebaseImpact) Rollout() bool {
+	return len(i.RolloutChanges) > 0
+}
+
+// machineDeploymentRolloutFields are the fields of MachineDeployment.spec.template.spec triggering a rollout
+// of the Machines when changed; all the other fields, e.g. nodeDrainTimeout, are propagated in place.
+var machineDeploymentRolloutFields = []string{"version", "bootstrap", "infrastructureRef", "failureDomain"}
+
+// controlPlaneRolloutFields are the fields of the control plane spec triggering a rollout of the Machines when changed.
+// NOTE: kubeadmConfigSpec is specific to KubeadmControlPlane; changes to other provider specific fields of
+// the control plane are not considered.
+var controlPlaneRolloutFields = [][]string{
+	{"version"},
+	{"machineTemplate", "infrastructureRef"},
+	{"kubeadmConfigSpec"},
+}
+
+// Rebase checks if a Cluster can be rebased to another ClusterClass, e.g. to a new revision of the current
+// ClusterClass, and performs a dry run execution of the topology reconciler on the rebased Cluster.
+// It returns the result of the compatibility check and, if the Cluster can be rebased, the changes observed
+// during the execution and their impact on the Machines of the Cluster.
+func (t *topologyClient) Rebase(ctx context.Context, in *TopologyRebaseInput) (*TopologyRebaseOutput, error) {
+	if in.ClusterName == "" {
+		return nil, errors.New("the name of the Cluster to rebase is required")
+	}
+	if in.ClusterClassName == "" {
+		return nil, errors.New("the name of the ClusterClass to rebase the Cluster to is required")
+	}
+
+	// The Cluster to rebase and its current ClusterClass are read from the management cluster.
+	if err := t.proxy.CheckClusterAvailable(); err != nil {
+		return nil, errors.Wrap(err, "a management cluster is required to rebase a Cluster")
+	}
+	c, err := t.proxy.NewClient()
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to create a client to the cluster")
+	}
+
+	namespace := in.TargetNamespace
+	if namespace == "" {
+		if namespace, err = t.proxy.CurrentNamespace(); err != nil {
+			return nil, errors.Wrap(err, "failed to get current namespace")
+		}
+	}
+	if err := t.setMissingNamespaces(namespace, in.Objs); err != nil {
+		return nil, errors.Wrap(err, "failed to set missing namespaces")
+	}
+
+	cluster := &clusterv1.Cluster{}
+	clusterKey := client.ObjectKey{Namespace: namespace, Name: in.ClusterName}
+	if err := c.Get(ctx, clusterKey, cluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", clusterKey)
+	}
+	if cluster.Spec.Topology == nil {
+		return nil, errors.Errorf("Cluster %s does not use a managed topology", clusterKey)
+	}
+
+	// NOTE: The Cluster is rebased to a ClusterClass in the same namespace of its current ClusterClass.
+	currentClusterClassKey := cluster.GetClassKey()
+	currentClusterClass := &clusterv1.ClusterClass{}
+	if err := c.Get(ctx, currentClusterClassKey, currentClusterClass); err != nil {
+		return nil, errors.Wrapf(err, "failed to get the ClusterClass %s used by Cluster %s", currentClusterClassKey, clusterKey)
+	}
+	targetClusterClass, err := getClusterClass(ctx, c, in.Objs, client.ObjectKey{Namespace: currentClusterClassKey.Namespace, Name: in.ClusterClassName})
+	if err != nil {
+		return nil, err
+	}
+
+	res := &TopologyRebaseOutput{
+		Cluster:             clusterKey,
+		CurrentClusterClass: currentClusterClass.Name,
+		TargetClusterClass:  targetClusterClass.Name,
+		CompatibilityErrors: check.ClusterCanBeRebased(cluster, currentClusterClass, targetClusterClass),
+		ChangeSummary:       &ChangeSummary{},
+	}
+	if len(res.CompatibilityErrors) > 0 {
+		return res, nil
+	}
+
+	// Dry run the topology reconciler on the rebased Cluster.
+	rebasedCluster := cluster.DeepCopy()
+	rebasedCluster.Spec.Topology.Class = in.ClusterClassName
+	rebasedCluster.SetManagedFields(nil)
+	rebasedCluster.SetResourceVersion("")
+	u := &unstructured.Unstructured{}
+	if err := localScheme.Convert(rebasedCluster, u, nil); err != nil {
+		return nil, errors.Wrapf(err, "failed to convert Cluster %s to unstructured", clusterKey)
+	}
+	u.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("Cluster"))
+
+	objs := append([]*unstructured.Unstructured{}, filterObjects(in.Objs, clusterv1.GroupVersion.WithKind("Cluster"))...)
+	objs = append(objs, u)
+	plan, err := t.Plan(ctx, &TopologyPlanInput{
+		Objs:              objs,
+		TargetClusterName: cluster.Name,
+		TargetNamespace:   namespace,
+	})
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to plan the rebase of Cluster %s", clusterKey)
+	}
+	if plan.ChangeSummary != nil {
+		res.ChangeSummary = plan.ChangeSummary
+	}
+	res.Impacts = rebaseImpacts(cluster, res.ChangeSummary)
+
+	return res, nil
+}
+
+// getClusterClass returns the ClusterClass with the given key from the list of objects, if present,
+// or from the management cluster.
+func getClusterClass(ctx context.Context, c client.Reader, objs []*unstructured.Unstructured, key client.ObjectKey) (*clusterv1.ClusterClass, error) {
+	clusterClass := &clusterv1.ClusterClass{}
+	for _, o := range getClusterClasses(objs) {
+		if o.GetNamespace() == key.Namespace && o.GetName() == key.Name {
+			if err := localScheme.Convert(o, clusterClass, nil); err != nil {
+				return nil, errors.Wrapf(err, "failed to convert ClusterClass %s", key)
+			}
+			return clusterClass, nil
+		}
+	}
+	if err := c.Get(ctx, key, clusterClass); err != nil {
+		return nil, errors.Wrapf(err, "failed to get ClusterClass %s", key)
+	}
+	return clusterClass, nil
+}
+
+// rebaseImpacts returns the impact of the given changes on the MachineDeployments and on the control plane of the Cluster.
+func rebaseImpacts(cluster *clusterv1.Cluster, changes *ChangeSummary) []TopologyRebaseImpact {
+	impacts := []TopologyRebaseImpact{}
+	for _, m := range changes.Modified {
+		switch {
+		case m.After.GroupVersionKind().GroupKind() == clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind():
+			rolloutChanges := []string{}
+			for _, f := range machineDeploymentRolloutFields {
+				if fieldChanged(m.Before, m.After, "spec", "template", "spec", f) {
+					rolloutChanges = append(rolloutChanges, joinFieldPath("spec", "template", "spec", f))
+				}
+			}
+			impacts = append(impacts, newTopologyRebaseImpact(m.After, rolloutChanges))
+		case cluster.Spec.ControlPlaneRef != nil &&
+			m.After.GetKind() == cluster.Spec.ControlPlaneRef.Kind && m.After.GetName() == cluster.Spec.ControlPlaneRef.Name:
+			rolloutChanges := []string{}
+			for _, f := range controlPlaneRolloutFields {
+				fields := append([]string{"spec"}, f...)
+				if fieldChanged(m.Before, m.After, fields...) {
+					rolloutChanges = append(rolloutChanges, joinFieldPath(fields...))
+				}
+			}
+			impacts = append(impacts, newTopologyRebaseImpact(m.After, rolloutChanges))
+		}
+	}
+
+	sort.Slice(impacts, func(i, j int) bool {
+		if impacts[i].Object.GetKind() == impacts[j].Object.GetKind() {
+			return impacts[i].Object.GetName() < impacts[j].Object.GetName()
+		}
+		return impacts[i].Object.GetKind() < impacts[j].Object.GetKind()
+	})
+	return impacts
+}
+
+func newTopologyRebaseImpact(obj *unstructured.Unstructured, rolloutChanges []string) TopologyRebaseImpact {
+	impact := TopologyRebaseImpact{
+		Object:         obj,
+		RolloutChanges: rolloutChanges,
+	}
+	if replicas, ok, err := unstructured.NestedInt64(obj.Object, "spec", "replicas"); err == nil && ok {
+		impact.Replicas = &replicas
+	}
+	return impact
+}
+
+// fieldChanged returns true if the value of the field at the given path is different in the two objects.
+func fieldChanged(before, after *unstructured.Unstructured, fields ...string) bool {
+	beforeValue, _, _ := unstructured.NestedFieldNoCopy(before.Object, fields...)
+	afterValue, _, _ := unstructured.NestedFieldNoCopy(after.Object, fields...)
+	return !reflect.DeepEqual(beforeValue, afterValue)
+}
+
+func joinFieldPath(fields ...string) string {
+	return field.NewPath(fields[0], fields[1:]...).String()
+}
@@ -25,9 +25,11 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/onsi/gomega/types"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
 	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
 )
@@ -58,6 +60,13 @@ var (
 
 	//go:embed assets/topology-test/objects-in-different-namespaces.yaml
 	objsInDifferentNamespacesYAML []byte
+
+	// rebaseMyClusterClassV2YAML is a new revision of my-cluster-class using a different DockerMachineTemplate for the control plane.
+	//go:embed assets/topology-test/rebase-my-cluster-class-v2.yaml
+	rebaseMyClusterClassV2YAML []byte
+
+	//go:embed assets/topology-test/rebase-incompatible-cluster-class.yaml
+	rebaseIncompatibleClusterClassYAML []byte
 )
 
 func Test_topologyClient_Plan(t *testing.T) {
@@ -328,6 +337,197 @@ func Test_topologyClient_Plan(t *testing.T) {
 	}
 }
 
+func Test_topologyClient_Rebase(t *testing.T) {
+	tests := []struct {
+		name                    string
+		existingObjects         []*unstructured.Unstructured
+		in                      *TopologyRebaseInput
+		wantCompatibilityErrors bool
+		wantImpacts             map[string][]string
+		wantErr                 bool
+	}{
+		{
+			name: "Rebase to a new revision of the ClusterClass rolls out the control plane",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+			),
+			in: &TopologyRebaseInput{
+				Objs:             mustToUnstructured(rebaseMyClusterClassV2YAML),
+				ClusterName:      "my-cluster",
+				ClusterClassName: "my-cluster-class-v2",
+				TargetNamespace:  "default",
+			},
+			wantImpacts: map[string][]string{
+				"KubeadmControlPlane": {"spec.machineTemplate.infrastructureRef"},
+			},
+		},
+		{
+			name: "Rebase to an incompatible ClusterClass reports compatibility errors",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+			),
+			in: &TopologyRebaseInput{
+				Objs:             mustToUnstructured(rebaseIncompatibleClusterClassYAML),
+				ClusterName:      "my-cluster",
+				ClusterClassName: "my-incompatible-cluster-class",
+				TargetNamespace:  "default",
+			},
+			wantCompatibilityErrors: true,
+		},
+		{
+			name: "Rebase to a ClusterClass that does not exist returns error",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+				existingMyClusterYAML,
+			),
+			in: &TopologyRebaseInput{
+				ClusterName:      "my-cluster",
+				ClusterClassName: "does-not-exist",
+				TargetNamespace:  "default",
+			},
+			wantErr: true,
+		},
+		{
+			name: "Rebase of a Cluster that does not exist returns error",
+			existingObjects: mustToUnstructured(
+				mockCRDsYAML,
+				existingMyClusterClassYAML,
+			),
+			in: &TopologyRebaseInput{
+				Objs:             mustToUnstructured(rebaseMyClusterClassV2YAML),
+				ClusterName:      "my-cluster",
+				ClusterClassName: "my-cluster-class-v2",
+				TargetNamespace:  "default",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			existingObjects := []client.Object{}
+			for _, o := range tt.existingObjects {
+				existingObjects = append(existingObjects, o)
+			}
+			proxy := test.NewFakeProxy().WithClusterAvailable(true).WithFakeCAPISetup().WithObjs(existingObjects...)
+			inventoryClient := newInventoryClient(proxy, nil)
+			tc := newTopologyClient(
+				proxy,
+				inventoryClient,
+			)
+
+			res, err := tc.Rebase(ctx, tt.in)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(res.Cluster).To(Equal(client.ObjectKey{Namespace: "default", Name: "my-cluster"}))
+			g.Expect(res.CurrentClusterClass).To(Equal("my-cluster-class"))
+			g.Expect(res.TargetClusterClass).To(Equal(tt.in.ClusterClassName))
+			if tt.wantCompatibilityErrors {
+				g.Expect(res.CompatibilityErrors).ToNot(BeEmpty())
+				g.Expect(res.Impacts).To(BeEmpty())
+				return
+			}
+			g.Expect(res.CompatibilityErrors).To(BeEmpty())
+
+			impacts := map[string][]string{}
+			for _, impact := range res.Impacts {
+				impacts[impact.Object.GetKind()] = impact.RolloutChanges
+			}
+			g.Expect(impacts).To(Equal(tt.wantImpacts))
+		})
+	}
+}
+
+func Test_rebaseImpacts(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneRef: &corev1.ObjectReference{Kind: "KubeadmControlPlane", Name: "cp"},
+		},
+	}
+	newObject := func(kind, name string, spec map[string]interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{"spec": spec}}
+		u.SetAPIVersion(clusterv1.GroupVersion.String())
+		if kind == "KubeadmControlPlane" {
+			u.SetAPIVersion("controlplane.cluster.x-k8s.io/v1beta1")
+		}
+		u.SetKind(kind)
+		u.SetName(name)
+		return u
+	}
+
+	changes := &ChangeSummary{
+		Modified: []*PatchSummary{
+			{
+				// MachineDeployment with a new bootstrap template is rolled out.
+				Before: newObject("MachineDeployment", "md-1", map[string]interface{}{
+					"replicas": int64(3),
+					"template": map[string]interface{}{"spec": map[string]interface{}{"bootstrap": map[string]interface{}{"configRef": map[string]interface{}{"name": "bootstrap-1"}}}},
+				}),
+				After: newObject("MachineDeployment", "md-1", map[string]interface{}{
+					"replicas": int64(3),
+					"template": map[string]interface{}{"spec": map[string]interface{}{"bootstrap": map[string]interface{}{"configRef": map[string]interface{}{"name": "bootstrap-2"}}}},
+				}),
+			},
+			{
+				// MachineDeployment with a new nodeDrainTimeout is modified in place.
+				Before: newObject("MachineDeployment", "md-0", map[string]interface{}{
+					"template": map[string]interface{}{"spec": map[string]interface{}{"nodeDrainTimeout": "10s"}},
+				}),
+				After: newObject("MachineDeployment", "md-0", map[string]interface{}{
+					"template": map[string]interface{}{"spec": map[string]interface{}{"nodeDrainTimeout": "20s"}},
+				}),
+			},
+			{
+				// Control plane with a new kubeadmConfigSpec is rolled out.
+				Before: newObject("KubeadmControlPlane", "cp", map[string]interface{}{
+					"replicas":          int64(1),
+					"kubeadmConfigSpec": map[string]interface{}{"format": "cloud-config"},
+				}),
+				After: newObject("KubeadmControlPlane", "cp", map[string]interface{}{
+					"replicas":          int64(1),
+					"kubeadmConfigSpec": map[string]interface{}{"format": "ignition"},
+				}),
+			},
+			{
+				// Other objects are ignored.
+				Before: newObject("DockerCluster", "infra", map[string]interface{}{}),
+				After:  newObject("DockerCluster", "infra", map[string]interface{}{"foo": "bar"}),
+			},
+		},
+	}
+
+	impacts := rebaseImpacts(cluster, changes)
+	g.Expect(impacts).To(HaveLen(3))
+
+	g.Expect(impacts[0].Object.GetName()).To(Equal("cp"))
+	g.Expect(impacts[0].Rollout()).To(BeTrue())
+	g.Expect(impacts[0].RolloutChanges).To(Equal([]string{"spec.kubeadmConfigSpec"}))
+	g.Expect(*impacts[0].Replicas).To(Equal(int64(1)))
+
+	g.Expect(impacts[1].Object.GetName()).To(Equal("md-0"))
+	g.Expect(impacts[1].Rollout()).To(BeFalse())
+	g.Expect(impacts[1].Replicas).To(BeNil())
+
+	g.Expect(impacts[2].Object.GetName()).To(Equal("md-1"))
+	g.Expect(impacts[2].Rollout()).To(BeTrue())
+	g.Expect(impacts[2].RolloutChanges).To(Equal([]string{"spec.template.spec.bootstrap"}))
+	g.Expect(*impacts[2].Replicas).To(Equal(int64(3)))
+}
+
 func MatchTopologyPlanOutputItem(kind, namespace, namePrefix string) types.GomegaMatcher {
 	return &topologyPlanOutputItemMatcher{kind, namespace, namePrefix}
 }
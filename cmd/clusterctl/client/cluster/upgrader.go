@@ -58,6 +58,11 @@ type UpgradePlan struct {
 type UpgradeOptions struct {
 	WaitProviders       bool
 	WaitProviderTimeout time.Duration
+
+	// WebhookCertificates defines how the certificates of the provider webhooks are managed; if empty, the mode used
+	// when installing each provider is used, as stored in the Provider inventory object.
+	// NOTE: The self-signed certificates of all the providers are renewed if they are about to expire.
+	WebhookCertificates WebhookCertificatesMode
 }
 
 // isPartialUpgrade returns true if at least one upgradeItem in the plan does not have a target version.
@@ -336,9 +341,16 @@ func (u *providerUpgrader) getUpgradeComponents(ctx context.Context, provider Up
 		return nil, err
 	}
 
+	// Apply again the patches used to customize the provider components when the provider has been installed.
+	patches, err := repository.ComponentsPatchesFromProvider(provider.Provider)
+	if err != nil {
+		return nil, err
+	}
+
 	options := repository.ComponentsOptions{
 		Version:         provider.NextVersion,
 		TargetNamespace: provider.Namespace,
+		Patches:         patches,
 	}
 	components, err := providerRepository.Components().Get(ctx, options)
 	if err != nil {
@@ -362,6 +374,23 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		return providers[a].GetProviderType().Order() < providers[b].GetProviderType().Order()
 	})
 
+	// Warn if the Deployments of the providers being upgraded have been changed after clusterctl applied them,
+	// given that those changes are going to be lost with the upgrade.
+	log := logf.Log
+	for _, upgradeItem := range providers {
+		if upgradeItem.NextVersion == "" {
+			continue
+		}
+		drift, err := checkProviderDrift(ctx, u.proxy, upgradeItem.Provider)
+		if err != nil {
+			return err
+		}
+		if drift.Drifted {
+			log.Info("Warning: the provider components have been changed after clusterctl applied them, the changes will be lost with the upgrade",
+				"Provider", upgradeItem.Provider.Name, "Version", upgradeItem.Provider.Version, "Namespace", upgradeItem.Provider.Namespace)
+		}
+	}
+
 	// Migrate CRs to latest CRD storage version, if necessary.
 	// Note: We have to do this before the providers are scaled down or deleted
 	// so conversion webhooks still work.
@@ -424,6 +453,16 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 
 		installQueue = append(installQueue, components)
 
+		// Gets the webhook certificates for the new version of the provider components, before deleting the provider.
+		webhookCertificates := opts.WebhookCertificates
+		if webhookCertificates == "" {
+			webhookCertificates = WebhookCertificatesModeFromProvider(upgradeItem.Provider)
+		}
+		objs, err := replaceCertManager(ctx, u.proxy, webhookCertificates, components.Objs())
+		if err != nil {
+			return err
+		}
+
 		// Delete the provider, preserving CRD, namespace and the inventory.
 		if err := u.providerComponents.Delete(ctx, DeleteOptions{
 			Provider:         upgradeItem.Provider,
@@ -435,7 +474,7 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		}
 
 		// Install the new version of the provider components.
-		if err := installComponentsAndUpdateInventory(ctx, components, u.providerComponents, u.providerInventory); err != nil {
+		if err := installComponentsAndUpdateInventory(ctx, components, objs, webhookCertificates, u.providerComponents, u.providerInventory, u.proxy); err != nil {
 			return err
 		}
 	}
@@ -447,7 +486,21 @@ func (u *providerUpgrader) doUpgrade(ctx context.Context, upgradePlan *UpgradePl
 		}
 	}
 
-	return waitForProvidersReady(ctx, InstallOptions(opts), installQueue, u.proxy)
+	// Renew the self-signed webhook certificates which are about to expire, also for the providers not being upgraded.
+	providerList, err := u.providerInventory.List(ctx)
+	if err != nil {
+		return err
+	}
+	for _, provider := range providerList.Items {
+		if opts.WebhookCertificates != WebhookCertificatesSelfSigned && WebhookCertificatesModeFromProvider(provider) != WebhookCertificatesSelfSigned {
+			continue
+		}
+		if err := rotateSelfSignedWebhookCertificates(ctx, u.proxy, provider); err != nil {
+			return err
+		}
+	}
+
+	return waitForProvidersReady(ctx, InstallOptions{WaitProviders: opts.WaitProviders, WaitProviderTimeout: opts.WaitProviderTimeout}, installQueue, u.proxy)
 }
 
 func (u *providerUpgrader) scaleDownProvider(ctx context.Context, provider clusterctlv1.Provider) error {
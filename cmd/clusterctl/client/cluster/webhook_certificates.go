@@ -0,0 +1,463 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math"
+	"math/big"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	logf "sigs.k8s.io/cluster-api/cmd/clusterctl/log"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// WebhookCertificatesMode defines how the certificates of the provider webhooks are managed.
+type WebhookCertificatesMode string
+
+const (
+	// WebhookCertificatesCertManager uses cert-manager to issue the certificates of the provider webhooks
+	// and to inject the CA bundle into the webhook configurations and the CRDs; this is the default.
+	WebhookCertificatesCertManager = WebhookCertificatesMode("cert-manager")
+
+	// WebhookCertificatesSelfSigned uses certificates generated by clusterctl and signed by a self-signed
+	// CA generated for each provider; cert-manager is not required. The certificates are valid for 90 days and
+	// they are renewed by clusterctl upgrade apply when they expire within 30 days; they are not renewed in-cluster,
+	// so clusterctl upgrade apply must be run periodically.
+	WebhookCertificatesSelfSigned = WebhookCertificatesMode("self-signed")
+
+	// WebhookCertificatesSecret uses certificates stored in existing Secrets, named after the secretName of
+	// the cert-manager Certificates of the providers; cert-manager is not required.
+	WebhookCertificatesSecret = WebhookCertificatesMode("secret")
+)
+
+const (
+	certManagerGroup            = "cert-manager.io"
+	certManagerCertificateKind  = "Certificate"
+	certManagerInjectAnnotation = "cert-manager.io/inject-ca-from"
+
+	// webhookCACrtDataName is the key used to store the CA certificate in the webhook certificate Secrets,
+	// as in the Secrets created by cert-manager.
+	webhookCACrtDataName = "ca.crt"
+
+	// webhookCASecretAnnotation is set on the self-signed webhook certificate Secrets to the name of the Secret
+	// storing the CA, which is used to rotate the certificates.
+	webhookCASecretAnnotation = "clusterctl.cluster.x-k8s.io/webhook-ca-secret"
+
+	// webhookCertificateDuration is the validity of the self-signed webhook certificates.
+	webhookCertificateDuration = 90 * 24 * time.Hour
+
+	// webhookCertificateRenewBefore is how long before they expire the self-signed webhook certificates, and the CA, are renewed.
+	webhookCertificateRenewBefore = 30 * 24 * time.Hour
+)
+
+// WebhookCertificatesModeFromProvider returns how the certificates of the webhooks of a provider are managed, as stored
+// in the Provider inventory object when the provider has been installed; it defaults to WebhookCertificatesCertManager
+// for the providers installed before the mode has been stored.
+func WebhookCertificatesModeFromProvider(provider clusterctlv1.Provider) WebhookCertificatesMode {
+	if mode, ok := provider.GetAnnotations()[clusterctlv1.WebhookCertificatesAnnotation]; ok && mode != "" {
+		return WebhookCertificatesMode(mode)
+	}
+	return WebhookCertificatesCertManager
+}
+
+// setWebhookCertificatesMode stores how the certificates of the webhooks of a provider are managed in the Provider inventory object.
+func setWebhookCertificatesMode(provider *clusterctlv1.Provider, mode WebhookCertificatesMode) {
+	if mode == "" {
+		mode = WebhookCertificatesCertManager
+	}
+	annotations := provider.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterctlv1.WebhookCertificatesAnnotation] = string(mode)
+	provider.SetAnnotations(annotations)
+}
+
+// certManagerCertificate is the subset of a cert-manager Certificate used by clusterctl.
+type certManagerCertificate struct {
+	Spec struct {
+		DNSNames   []string `json:"dnsNames,omitempty"`
+		SecretName string   `json:"secretName"`
+	} `json:"spec"`
+}
+
+// replaceCertManager replaces the cert-manager objects in the provider components with the webhook certificates Secrets,
+// according to the WebhookCertificatesMode, and sets the CA bundle of the objects with the cert-manager CA injection annotation.
+func replaceCertManager(ctx context.Context, proxy Proxy, mode WebhookCertificatesMode, objs []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	if mode == "" || mode == WebhookCertificatesCertManager {
+		return objs, nil
+	}
+
+	// Gets the CA bundle for all the cert-manager Certificates, dropping all the cert-manager objects,
+	// e.g. Certificates and Issuers.
+	caBundles := map[string][]byte{}
+	ret := make([]unstructured.Unstructured, 0, len(objs))
+	for _, obj := range objs {
+		if obj.GroupVersionKind().Group != certManagerGroup {
+			ret = append(ret, obj)
+			continue
+		}
+		if obj.GetKind() != certManagerCertificateKind {
+			continue
+		}
+
+		certificate := &certManagerCertificate{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.UnstructuredContent(), certificate); err != nil {
+			return nil, errors.Wrapf(err, "failed to parse Certificate %s/%s", obj.GetNamespace(), obj.GetName())
+		}
+		if certificate.Spec.SecretName == "" {
+			return nil, errors.Errorf("Certificate %s/%s does not define a secretName", obj.GetNamespace(), obj.GetName())
+		}
+
+		var caBundle []byte
+		switch mode {
+		case WebhookCertificatesSelfSigned:
+			caSecret, tlsSecret, err := getSelfSignedWebhookSecrets(ctx, proxy, obj, certificate)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to generate the certificate for Certificate %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+			caBundle = tlsSecret.Data[webhookCACrtDataName]
+
+			for _, s := range []*corev1.Secret{caSecret, tlsSecret} {
+				u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(s)
+				if err != nil {
+					return nil, err
+				}
+				ret = append(ret, unstructured.Unstructured{Object: u})
+			}
+		case WebhookCertificatesSecret:
+			var err error
+			caBundle, err = getWebhookSecretCABundle(ctx, proxy, obj.GetNamespace(), certificate.Spec.SecretName)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to get the certificate for Certificate %s/%s", obj.GetNamespace(), obj.GetName())
+			}
+		default:
+			return nil, errors.Errorf("invalid webhook certificates mode %q", mode)
+		}
+		caBundles[obj.GetNamespace()+"/"+obj.GetName()] = caBundle
+	}
+
+	// Sets the CA bundle in the objects which are using the cert-manager CA injection.
+	for i := range ret {
+		certificateName, ok := ret[i].GetAnnotations()[certManagerInjectAnnotation]
+		if !ok {
+			continue
+		}
+		caBundle, ok := caBundles[certificateName]
+		if !ok {
+			return nil, errors.Errorf("%s %s refers to Certificate %s which is not part of the provider components", ret[i].GetKind(), ret[i].GetName(), certificateName)
+		}
+		if err := setCABundle(&ret[i], caBundle); err != nil {
+			return nil, errors.Wrapf(err, "failed to set the CA bundle of %s %s", ret[i].GetKind(), ret[i].GetName())
+		}
+
+		annotations := ret[i].GetAnnotations()
+		delete(annotations, certManagerInjectAnnotation)
+		ret[i].SetAnnotations(annotations)
+	}
+	return ret, nil
+}
+
+// getSelfSignedWebhookSecrets returns the Secret with the self-signed CA and the Secret with the certificate for the DNS names
+// of a cert-manager Certificate, signed by the CA. The CA and the certificate already existing in the management cluster
+// are preserved unless they are about to expire, so the CA bundle does not change on upgrade; otherwise new ones are generated.
+func getSelfSignedWebhookSecrets(ctx context.Context, proxy Proxy, obj unstructured.Unstructured, certificate *certManagerCertificate) (*corev1.Secret, *corev1.Secret, error) {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	caSecretName := webhookCASecretName(certificate.Spec.SecretName)
+	existingCA, err := getSecretIfExists(ctx, c, obj.GetNamespace(), caSecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var caCrt, caKey []byte
+	if existingCA != nil && isWebhookCertificateValid(existingCA.Data[secret.TLSCrtDataName], nil, nil) {
+		caCrt, caKey = existingCA.Data[secret.TLSCrtDataName], existingCA.Data[secret.TLSKeyDataName]
+	} else {
+		ca := &secret.Certificate{Purpose: secret.ClusterCA}
+		if err := ca.Generate(); err != nil {
+			return nil, nil, err
+		}
+		caCrt, caKey = ca.KeyPair.Cert, ca.KeyPair.Key
+	}
+
+	existing, err := getSecretIfExists(ctx, c, obj.GetNamespace(), certificate.Spec.SecretName)
+	if err != nil {
+		return nil, nil, err
+	}
+	var tlsCrt, tlsKey []byte
+	if existing != nil && isWebhookCertificateValid(existing.Data[secret.TLSCrtDataName], caCrt, certificate.Spec.DNSNames) {
+		tlsCrt, tlsKey = existing.Data[secret.TLSCrtDataName], existing.Data[secret.TLSKeyDataName]
+	} else {
+		commonName := obj.GetName()
+		if len(certificate.Spec.DNSNames) > 0 {
+			commonName = certificate.Spec.DNSNames[0]
+		}
+		tlsCrt, tlsKey, err = newWebhookCertificate(caCrt, caKey, commonName, certificate.Spec.DNSNames)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	caSecret := newWebhookSecret(obj, caSecretName, map[string][]byte{
+		secret.TLSCrtDataName: caCrt,
+		secret.TLSKeyDataName: caKey,
+	})
+	tlsSecret := newWebhookSecret(obj, certificate.Spec.SecretName, map[string][]byte{
+		secret.TLSCrtDataName: tlsCrt,
+		secret.TLSKeyDataName: tlsKey,
+		webhookCACrtDataName:  caCrt,
+	})
+	tlsSecret.Annotations = map[string]string{webhookCASecretAnnotation: caSecretName}
+	return caSecret, tlsSecret, nil
+}
+
+// rotateSelfSignedWebhookCertificates renews the self-signed webhook certificates of a provider which are about to expire.
+// The certificates are signed by the CA stored in the management cluster, so the CA bundle of the webhook configurations
+// and of the CRDs does not change, and the provider picks up the new certificates without being restarted.
+func rotateSelfSignedWebhookCertificates(ctx context.Context, proxy Proxy, provider clusterctlv1.Provider) error {
+	log := logf.Log
+
+	c, err := proxy.NewClient()
+	if err != nil {
+		return err
+	}
+
+	secretList := &corev1.SecretList{}
+	if err := c.List(ctx, secretList,
+		client.InNamespace(provider.Namespace),
+		client.MatchingLabels{
+			clusterctlv1.ClusterctlLabel: "",
+			clusterv1.ProviderNameLabel:  provider.ManifestLabel(),
+		},
+	); err != nil {
+		return errors.Wrapf(err, "failed to list Secrets for provider %s", provider.InstanceName())
+	}
+
+	for i := range secretList.Items {
+		s := &secretList.Items[i]
+		caSecretName, ok := s.Annotations[webhookCASecretAnnotation]
+		if !ok {
+			continue
+		}
+
+		caSecret := &corev1.Secret{}
+		if err := c.Get(ctx, client.ObjectKey{Namespace: s.Namespace, Name: caSecretName}, caSecret); err != nil {
+			return errors.Wrapf(err, "failed to get the CA Secret %s/%s", s.Namespace, caSecretName)
+		}
+		caCrt, caKey := caSecret.Data[secret.TLSCrtDataName], caSecret.Data[secret.TLSKeyDataName]
+
+		cert, err := certs.DecodeCertPEM(s.Data[secret.TLSCrtDataName])
+		if err != nil {
+			return errors.Wrapf(err, "failed to decode the certificate in Secret %s/%s", s.Namespace, s.Name)
+		}
+		if isWebhookCertificateValid(s.Data[secret.TLSCrtDataName], caCrt, cert.DNSNames) {
+			continue
+		}
+
+		log.Info("Rotating the webhook certificate", "Provider", provider.InstanceName(), "Secret", s.Name, "NotAfter", cert.NotAfter)
+		tlsCrt, tlsKey, err := newWebhookCertificate(caCrt, caKey, cert.Subject.CommonName, cert.DNSNames)
+		if err != nil {
+			return errors.Wrapf(err, "failed to generate the certificate for Secret %s/%s", s.Namespace, s.Name)
+		}
+		s.Data[secret.TLSCrtDataName] = tlsCrt
+		s.Data[secret.TLSKeyDataName] = tlsKey
+		s.Data[webhookCACrtDataName] = caCrt
+		if err := c.Update(ctx, s); err != nil {
+			return errors.Wrapf(err, "failed to update Secret %s/%s", s.Namespace, s.Name)
+		}
+	}
+	return nil
+}
+
+// newWebhookCertificate returns a certificate and its private key for the DNS names, signed by the CA;
+// the certificate is valid for webhookCertificateDuration.
+func newWebhookCertificate(caCrt, caKey []byte, commonName string, dnsNames []string) ([]byte, []byte, error) {
+	caCert, err := certs.DecodeCertPEM(caCrt)
+	if err != nil {
+		return nil, nil, err
+	}
+	caSigner, err := certs.DecodePrivateKeyPEM(caKey)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	key, err := certs.NewPrivateKey()
+	if err != nil {
+		return nil, nil, err
+	}
+	serial, err := rand.Int(rand.Reader, new(big.Int).SetInt64(math.MaxInt64))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to generate random integer for the certificate")
+	}
+	now := time.Now().UTC()
+	tmpl := x509.Certificate{
+		Subject:      pkix.Name{CommonName: commonName},
+		DNSNames:     dnsNames,
+		SerialNumber: serial,
+		NotBefore:    now.Add(-5 * time.Minute),
+		NotAfter:     now.Add(webhookCertificateDuration),
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	b, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, key.Public(), caSigner)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to create the certificate")
+	}
+	tlsCert, err := x509.ParseCertificate(b)
+	if err != nil {
+		return nil, nil, err
+	}
+	return certs.EncodeCertPEM(tlsCert), certs.EncodePrivateKeyPEM(key), nil
+}
+
+// isWebhookCertificateValid returns true if the certificate does not expire within webhookCertificateRenewBefore and,
+// if a CA is provided, if it is signed by the CA and valid for all the DNS names.
+func isWebhookCertificateValid(crt, caCrt []byte, dnsNames []string) bool {
+	cert, err := certs.DecodeCertPEM(crt)
+	if err != nil {
+		return false
+	}
+	if time.Until(cert.NotAfter) < webhookCertificateRenewBefore {
+		return false
+	}
+	if caCrt == nil {
+		return true
+	}
+
+	caCert, err := certs.DecodeCertPEM(caCrt)
+	if err != nil {
+		return false
+	}
+	roots := x509.NewCertPool()
+	roots.AddCert(caCert)
+	opts := x509.VerifyOptions{
+		Roots:     roots,
+		KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	if _, err := cert.Verify(opts); err != nil {
+		return false
+	}
+	for _, name := range dnsNames {
+		if err := cert.VerifyHostname(name); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// newWebhookSecret returns a TLS Secret in the namespace of a cert-manager Certificate.
+func newWebhookSecret(obj unstructured.Unstructured, name string, data map[string][]byte) *corev1.Secret {
+	return &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{
+			APIVersion: corev1.SchemeGroupVersion.String(),
+			Kind:       "Secret",
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: obj.GetNamespace(),
+			Name:      name,
+			// NOTE: The Secret gets the labels of the Certificate, so it is deleted together with the provider.
+			Labels: obj.GetLabels(),
+		},
+		Type: corev1.SecretTypeTLS,
+		Data: data,
+	}
+}
+
+// getSecretIfExists returns a Secret, or nil if it does not exist.
+func getSecretIfExists(ctx context.Context, c client.Client, namespace, name string) (*corev1.Secret, error) {
+	s := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, s); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get Secret %s/%s", namespace, name)
+	}
+	return s, nil
+}
+
+// webhookCASecretName returns the name of the Secret storing the self-signed CA for a webhook certificate Secret.
+func webhookCASecretName(secretName string) string {
+	return secretName + "-ca"
+}
+
+// getWebhookSecretCABundle returns the CA bundle from an existing webhook certificate Secret.
+func getWebhookSecretCABundle(ctx context.Context, proxy Proxy, namespace, name string) ([]byte, error) {
+	c, err := proxy.NewClient()
+	if err != nil {
+		return nil, err
+	}
+	s := &corev1.Secret{}
+	if err := c.Get(ctx, client.ObjectKey{Namespace: namespace, Name: name}, s); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Secret %s/%s", namespace, name)
+	}
+	if len(s.Data[secret.TLSCrtDataName]) == 0 || len(s.Data[secret.TLSKeyDataName]) == 0 {
+		return nil, errors.Errorf("Secret %s/%s must contain %s and %s", namespace, name, secret.TLSCrtDataName, secret.TLSKeyDataName)
+	}
+	if caBundle := s.Data[webhookCACrtDataName]; len(caBundle) > 0 {
+		return caBundle, nil
+	}
+	return s.Data[secret.TLSCrtDataName], nil
+}
+
+// setCABundle sets the CA bundle of webhook configurations and of CRDs with a conversion webhook.
+func setCABundle(obj *unstructured.Unstructured, caBundle []byte) error {
+	encoded := base64.StdEncoding.EncodeToString(caBundle)
+	switch obj.GetKind() {
+	case validatingWebhookConfigurationKind, mutatingWebhookConfigurationKind:
+		webhooks, _, err := unstructured.NestedSlice(obj.Object, "webhooks")
+		if err != nil {
+			return err
+		}
+		for i := range webhooks {
+			webhook, ok := webhooks[i].(map[string]interface{})
+			if !ok {
+				return errors.New("invalid webhooks")
+			}
+			if err := unstructured.SetNestedField(webhook, encoded, "clientConfig", "caBundle"); err != nil {
+				return err
+			}
+		}
+		return unstructured.SetNestedSlice(obj.Object, webhooks, "webhooks")
+	case customResourceDefinitionKind:
+		if strategy, _, _ := unstructured.NestedString(obj.Object, "spec", "conversion", "strategy"); !strings.EqualFold(strategy, "Webhook") {
+			return nil
+		}
+		return unstructured.SetNestedField(obj.Object, encoded, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+	default:
+		return errors.Errorf("CA injection is not supported for %s", obj.GetKind())
+	}
+}
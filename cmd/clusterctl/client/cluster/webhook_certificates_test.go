@@ -0,0 +1,380 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"math/big"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+func Test_replaceCertManager(t *testing.T) {
+	objs := func() []unstructured.Unstructured {
+		return []unstructured.Unstructured{
+			{Object: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Issuer",
+				"metadata":   map[string]interface{}{"name": "capi-selfsigned-issuer", "namespace": "capi-system"},
+				"spec":       map[string]interface{}{"selfSigned": map[string]interface{}{}},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "cert-manager.io/v1",
+				"kind":       "Certificate",
+				"metadata": map[string]interface{}{
+					"name":      "capi-serving-cert",
+					"namespace": "capi-system",
+					"labels":    map[string]interface{}{"cluster.x-k8s.io/provider": "cluster-api"},
+				},
+				"spec": map[string]interface{}{
+					"dnsNames":   []interface{}{"capi-webhook-service.capi-system.svc"},
+					"secretName": "capi-webhook-service-cert",
+				},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "admissionregistration.k8s.io/v1",
+				"kind":       "ValidatingWebhookConfiguration",
+				"metadata": map[string]interface{}{
+					"name":        "capi-validating-webhook-configuration",
+					"annotations": map[string]interface{}{certManagerInjectAnnotation: "capi-system/capi-serving-cert"},
+				},
+				"webhooks": []interface{}{
+					map[string]interface{}{"name": "a", "clientConfig": map[string]interface{}{}},
+					map[string]interface{}{"name": "b", "clientConfig": map[string]interface{}{}},
+				},
+			}},
+			{Object: map[string]interface{}{
+				"apiVersion": "apiextensions.k8s.io/v1",
+				"kind":       "CustomResourceDefinition",
+				"metadata": map[string]interface{}{
+					"name":        "clusters.cluster.x-k8s.io",
+					"annotations": map[string]interface{}{certManagerInjectAnnotation: "capi-system/capi-serving-cert"},
+				},
+				"spec": map[string]interface{}{
+					"conversion": map[string]interface{}{
+						"strategy": "Webhook",
+						"webhook":  map[string]interface{}{"clientConfig": map[string]interface{}{}},
+					},
+				},
+			}},
+		}
+	}
+	caBundles := func(g *WithT, objs []unstructured.Unstructured) []string {
+		ret := []string{}
+		for _, obj := range objs {
+			g.Expect(obj.GetAnnotations()).ToNot(HaveKey(certManagerInjectAnnotation))
+			switch obj.GetKind() {
+			case validatingWebhookConfigurationKind:
+				webhooks, _, err := unstructured.NestedSlice(obj.Object, "webhooks")
+				g.Expect(err).ToNot(HaveOccurred())
+				for _, w := range webhooks {
+					caBundle, _, err := unstructured.NestedString(w.(map[string]interface{}), "clientConfig", "caBundle")
+					g.Expect(err).ToNot(HaveOccurred())
+					ret = append(ret, caBundle)
+				}
+			case customResourceDefinitionKind:
+				caBundle, _, err := unstructured.NestedString(obj.Object, "spec", "conversion", "webhook", "clientConfig", "caBundle")
+				g.Expect(err).ToNot(HaveOccurred())
+				ret = append(ret, caBundle)
+			}
+		}
+		return ret
+	}
+
+	t.Run("does not change the components with cert-manager", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got, err := replaceCertManager(context.Background(), test.NewFakeProxy(), WebhookCertificatesCertManager, objs())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(objs()))
+	})
+
+	t.Run("generates self-signed certificates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got, err := replaceCertManager(context.Background(), test.NewFakeProxy(), WebhookCertificatesSelfSigned, objs())
+		g.Expect(err).ToNot(HaveOccurred())
+
+		var s, caSecret *unstructured.Unstructured
+		for i := range got {
+			g.Expect(got[i].GroupVersionKind().Group).ToNot(Equal(certManagerGroup))
+			if got[i].GetKind() != "Secret" {
+				continue
+			}
+			switch got[i].GetName() {
+			case "capi-webhook-service-cert":
+				s = &got[i]
+			case "capi-webhook-service-cert-ca":
+				caSecret = &got[i]
+			}
+		}
+		g.Expect(s).ToNot(BeNil())
+		g.Expect(s.GetNamespace()).To(Equal("capi-system"))
+		g.Expect(s.GetLabels()).To(HaveKeyWithValue("cluster.x-k8s.io/provider", "cluster-api"))
+		g.Expect(s.GetAnnotations()).To(HaveKeyWithValue(webhookCASecretAnnotation, "capi-webhook-service-cert-ca"))
+
+		// The CA key must be stored, so the certificate can be renewed.
+		g.Expect(caSecret).ToNot(BeNil())
+		g.Expect(caSecret.GetNamespace()).To(Equal("capi-system"))
+		g.Expect(caSecret.GetLabels()).To(HaveKeyWithValue("cluster.x-k8s.io/provider", "cluster-api"))
+		caData, _, err := unstructured.NestedStringMap(caSecret.Object, "data")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(caData).To(HaveKey("tls.key"))
+
+		data, _, err := unstructured.NestedStringMap(s.Object, "data")
+		g.Expect(err).ToNot(HaveOccurred())
+		caCrt, err := base64.StdEncoding.DecodeString(data["ca.crt"])
+		g.Expect(err).ToNot(HaveOccurred())
+		tlsCrt, err := base64.StdEncoding.DecodeString(data["tls.crt"])
+		g.Expect(err).ToNot(HaveOccurred())
+
+		// The certificate must be valid for the webhook service.
+		caCert, err := certs.DecodeCertPEM(caCrt)
+		g.Expect(err).ToNot(HaveOccurred())
+		tlsCert, err := certs.DecodeCertPEM(tlsCrt)
+		g.Expect(err).ToNot(HaveOccurred())
+		pool := x509.NewCertPool()
+		pool.AddCert(caCert)
+		_, err = tlsCert.Verify(x509.VerifyOptions{
+			DNSName:   "capi-webhook-service.capi-system.svc",
+			Roots:     pool,
+			KeyUsages: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(tlsCert.NotAfter).To(BeTemporally("<=", time.Now().Add(webhookCertificateDuration)))
+		g.Expect(caData["tls.crt"]).To(Equal(data["ca.crt"]))
+
+		g.Expect(caBundles(g, got)).To(Equal([]string{data["ca.crt"], data["ca.crt"], data["ca.crt"]}))
+	})
+
+	t.Run("preserves the existing self-signed CA and certificates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		caCrt, caKey := newTestWebhookCA(g)
+		tlsCrt, tlsKey := newTestWebhookCertificate(g, caCrt, caKey, time.Now().Add(webhookCertificateDuration))
+		proxy := test.NewFakeProxy().WithObjs(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert-ca"},
+				Data:       map[string][]byte{"tls.crt": caCrt, "tls.key": caKey},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert"},
+				Data:       map[string][]byte{"tls.crt": tlsCrt, "tls.key": tlsKey, "ca.crt": caCrt},
+			},
+		)
+		got, err := replaceCertManager(context.Background(), proxy, WebhookCertificatesSelfSigned, objs())
+		g.Expect(err).ToNot(HaveOccurred())
+
+		data := secretData(g, got, "capi-webhook-service-cert")
+		g.Expect(data["tls.crt"]).To(Equal(base64.StdEncoding.EncodeToString(tlsCrt)))
+		g.Expect(data["tls.key"]).To(Equal(base64.StdEncoding.EncodeToString(tlsKey)))
+		g.Expect(data["ca.crt"]).To(Equal(base64.StdEncoding.EncodeToString(caCrt)))
+		g.Expect(caBundles(g, got)).To(Equal([]string{data["ca.crt"], data["ca.crt"], data["ca.crt"]}))
+	})
+
+	t.Run("renews the self-signed certificates about to expire with the existing CA", func(t *testing.T) {
+		g := NewWithT(t)
+
+		caCrt, caKey := newTestWebhookCA(g)
+		tlsCrt, tlsKey := newTestWebhookCertificate(g, caCrt, caKey, time.Now().Add(webhookCertificateRenewBefore/2))
+		proxy := test.NewFakeProxy().WithObjs(
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert-ca"},
+				Data:       map[string][]byte{"tls.crt": caCrt, "tls.key": caKey},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert"},
+				Data:       map[string][]byte{"tls.crt": tlsCrt, "tls.key": tlsKey, "ca.crt": caCrt},
+			},
+		)
+		got, err := replaceCertManager(context.Background(), proxy, WebhookCertificatesSelfSigned, objs())
+		g.Expect(err).ToNot(HaveOccurred())
+
+		data := secretData(g, got, "capi-webhook-service-cert")
+		g.Expect(data["tls.crt"]).ToNot(Equal(base64.StdEncoding.EncodeToString(tlsCrt)))
+		g.Expect(data["ca.crt"]).To(Equal(base64.StdEncoding.EncodeToString(caCrt)))
+	})
+
+	t.Run("uses existing Secrets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		proxy := test.NewFakeProxy().WithObjs(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert"},
+			Data: map[string][]byte{
+				"tls.crt": []byte("tls.crt"),
+				"tls.key": []byte("tls.key"),
+				"ca.crt":  []byte("ca.crt"),
+			},
+		})
+		got, err := replaceCertManager(context.Background(), proxy, WebhookCertificatesSecret, objs())
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(HaveLen(2))
+
+		caBundle := base64.StdEncoding.EncodeToString([]byte("ca.crt"))
+		g.Expect(caBundles(g, got)).To(Equal([]string{caBundle, caBundle, caBundle}))
+	})
+
+	t.Run("fails if the existing Secret does not exist", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := replaceCertManager(context.Background(), test.NewFakeProxy(), WebhookCertificatesSecret, objs())
+		g.Expect(err).To(HaveOccurred())
+	})
+
+	t.Run("fails if an object refers to a Certificate not in the components", func(t *testing.T) {
+		g := NewWithT(t)
+
+		components := objs()
+		components[2].SetAnnotations(map[string]string{certManagerInjectAnnotation: "capi-system/another-cert"})
+		_, err := replaceCertManager(context.Background(), test.NewFakeProxy(), WebhookCertificatesSelfSigned, components)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestWebhookCertificatesModeFromProvider(t *testing.T) {
+	t.Run("defaults to cert-manager for providers without the annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+		g.Expect(WebhookCertificatesModeFromProvider(provider)).To(Equal(WebhookCertificatesCertManager))
+	})
+	t.Run("returns the mode stored in the inventory object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+		setWebhookCertificatesMode(&provider, WebhookCertificatesSelfSigned)
+		g.Expect(provider.GetAnnotations()).To(HaveKeyWithValue(clusterctlv1.WebhookCertificatesAnnotation, "self-signed"))
+		g.Expect(WebhookCertificatesModeFromProvider(provider)).To(Equal(WebhookCertificatesSelfSigned))
+	})
+	t.Run("stores cert-manager if the mode is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+		setWebhookCertificatesMode(&provider, "")
+		g.Expect(provider.GetAnnotations()).To(HaveKeyWithValue(clusterctlv1.WebhookCertificatesAnnotation, "cert-manager"))
+	})
+}
+
+func Test_rotateSelfSignedWebhookCertificates(t *testing.T) {
+	g := NewWithT(t)
+
+	caCrt, caKey := newTestWebhookCA(g)
+	validCrt, validKey := newTestWebhookCertificate(g, caCrt, caKey, time.Now().Add(webhookCertificateDuration))
+	expiringCrt, expiringKey := newTestWebhookCertificate(g, caCrt, caKey, time.Now().Add(webhookCertificateRenewBefore/2))
+
+	labels := map[string]string{
+		clusterctlv1.ClusterctlLabel: "",
+		"cluster.x-k8s.io/provider":  "cluster-api",
+	}
+	proxy := test.NewFakeProxy().WithObjs(
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-webhook-service-cert-ca", Labels: labels},
+			Data:       map[string][]byte{"tls.crt": caCrt, "tls.key": caKey},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "capi-system",
+				Name:        "valid-cert",
+				Labels:      labels,
+				Annotations: map[string]string{webhookCASecretAnnotation: "capi-webhook-service-cert-ca"},
+			},
+			Data: map[string][]byte{"tls.crt": validCrt, "tls.key": validKey, "ca.crt": caCrt},
+		},
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace:   "capi-system",
+				Name:        "expiring-cert",
+				Labels:      labels,
+				Annotations: map[string]string{webhookCASecretAnnotation: "capi-webhook-service-cert-ca"},
+			},
+			Data: map[string][]byte{"tls.crt": expiringCrt, "tls.key": expiringKey, "ca.crt": caCrt},
+		},
+	)
+	provider := fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "capi-system")
+	g.Expect(rotateSelfSignedWebhookCertificates(context.Background(), proxy, provider)).To(Succeed())
+
+	c, err := proxy.NewClient()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	valid := &corev1.Secret{}
+	g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "capi-system", Name: "valid-cert"}, valid)).To(Succeed())
+	g.Expect(valid.Data["tls.crt"]).To(Equal(validCrt))
+
+	renewed := &corev1.Secret{}
+	g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: "capi-system", Name: "expiring-cert"}, renewed)).To(Succeed())
+	g.Expect(renewed.Data["tls.crt"]).ToNot(Equal(expiringCrt))
+	g.Expect(renewed.Data["ca.crt"]).To(Equal(caCrt))
+	g.Expect(isWebhookCertificateValid(renewed.Data["tls.crt"], caCrt, []string{"capi-webhook-service.capi-system.svc"})).To(BeTrue())
+}
+
+func newTestWebhookCA(g *WithT) ([]byte, []byte) {
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	g.Expect(ca.Generate()).To(Succeed())
+	return ca.KeyPair.Cert, ca.KeyPair.Key
+}
+
+func newTestWebhookCertificate(g *WithT, caCrt, caKey []byte, notAfter time.Time) ([]byte, []byte) {
+	caCert, err := certs.DecodeCertPEM(caCrt)
+	g.Expect(err).ToNot(HaveOccurred())
+	caSigner, err := certs.DecodePrivateKeyPEM(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+	key, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	tmpl := x509.Certificate{
+		Subject:      pkix.Name{CommonName: "capi-webhook-service.capi-system.svc"},
+		DNSNames:     []string{"capi-webhook-service.capi-system.svc"},
+		SerialNumber: big.NewInt(1),
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     notAfter,
+		KeyUsage:     x509.KeyUsageKeyEncipherment | x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	b, err := x509.CreateCertificate(rand.Reader, &tmpl, caCert, key.Public(), caSigner)
+	g.Expect(err).ToNot(HaveOccurred())
+	cert, err := x509.ParseCertificate(b)
+	g.Expect(err).ToNot(HaveOccurred())
+	return certs.EncodeCertPEM(cert), certs.EncodePrivateKeyPEM(key)
+}
+
+func secretData(g *WithT, objs []unstructured.Unstructured, name string) map[string]string {
+	var data map[string]string
+	for _, obj := range objs {
+		if obj.GetKind() == "Secret" && obj.GetName() == name {
+			var err error
+			data, _, err = unstructured.NestedStringMap(obj.Object, "data")
+			g.Expect(err).ToNot(HaveOccurred())
+		}
+	}
+	g.Expect(data).ToNot(BeNil(), "Secret %s not found", name)
+	return data
+}
@@ -0,0 +1,281 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+	"sigs.k8s.io/cluster-api/controllers/external"
+)
+
+// ClusterStatusOptions carries the options supported by ClusterStatus.
+type ClusterStatusOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the workload cluster is located. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// ClusterName is the name of the workload cluster.
+	ClusterName string
+
+	// ShowOtherConditions is a list of comma separated kind or kind/name for which we should add the ShowObjectConditionsAnnotation
+	// to signal to the presentation layer to show all the conditions for the objects.
+	ShowOtherConditions string
+
+	// Grouping groups machines objects in case the ready conditions
+	// have the same Status, Severity and Reason.
+	Grouping bool
+
+	// MaxEvents is the maximum number of recent events to be returned; if zero, no events are returned.
+	MaxEvents int
+}
+
+// ClusterStatus defines a snapshot of the status of a Cluster API cluster.
+type ClusterStatus struct {
+	// Cluster is the Cluster.
+	Cluster *clusterv1.Cluster
+
+	// Tree is the object tree representing the status of the Cluster, the same used by describe cluster.
+	Tree *tree.ObjectTree
+
+	// Rollouts is the rollout progress of the control plane and of the MachineDeployments of the Cluster.
+	Rollouts []RolloutStatus
+
+	// Events is the list of the most recent events for the objects of the Cluster, sorted from the most recent.
+	Events []corev1.Event
+}
+
+// RolloutStatus defines the rollout progress of the Machines of a control plane or of a MachineDeployment.
+type RolloutStatus struct {
+	// Kind and Name of the control plane or of the MachineDeployment.
+	Kind string
+	Name string
+
+	// Version is the Kubernetes version of the Machines, if defined.
+	Version string
+
+	// DesiredReplicas is desired number of Machines, if defined.
+	DesiredReplicas *int32
+
+	// Replicas is the number of Machines.
+	Replicas int32
+
+	// UpdatedReplicas is the number of Machines which are up-to-date with the desired state.
+	UpdatedReplicas int32
+
+	// ReadyReplicas is the number of Machines which are ready.
+	ReadyReplicas int32
+
+	// UnavailableReplicas is the number of Machines which are not available.
+	UnavailableReplicas int32
+}
+
+// ClusterStatus returns a snapshot of the status of a Cluster API cluster, including the object tree
+// used by describe cluster, the rollout progress of its Machines and the most recent events.
+func (c *clusterctlClient) ClusterStatus(ctx context.Context, options ClusterStatusOptions) (*ClusterStatus, error) {
+	if options.ClusterName == "" {
+		return nil, errors.New("the name of the Cluster is required")
+	}
+
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	objectTree, err := c.DescribeCluster(ctx, DescribeClusterOptions{
+		Kubeconfig:             options.Kubeconfig,
+		Namespace:              options.Namespace,
+		ClusterName:            options.ClusterName,
+		ShowOtherConditions:    options.ShowOtherConditions,
+		AddTemplateVirtualNode: true,
+		Grouping:               options.Grouping,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	cl, err := clusterClient.Proxy().NewClient()
+	if err != nil {
+		return nil, err
+	}
+
+	cluster := &clusterv1.Cluster{}
+	clusterKey := client.ObjectKey{Namespace: options.Namespace, Name: options.ClusterName}
+	if err := cl.Get(ctx, clusterKey, cluster); err != nil {
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", clusterKey)
+	}
+
+	status := &ClusterStatus{
+		Cluster: cluster,
+		Tree:    objectTree,
+	}
+
+	// Collect the UIDs of the objects of the Cluster, so it is possible to select their events.
+	uids := map[types.UID]bool{cluster.UID: true}
+
+	if cluster.Spec.InfrastructureRef != nil {
+		if infraCluster, err := external.Get(ctx, cl, cluster.Spec.InfrastructureRef, cluster.Namespace); err == nil {
+			uids[infraCluster.GetUID()] = true
+		}
+	}
+
+	if cluster.Spec.ControlPlaneRef != nil {
+		controlPlane, err := external.Get(ctx, cl, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get the control plane of Cluster %s", clusterKey)
+		}
+		uids[controlPlane.GetUID()] = true
+		if rollout, ok := controlPlaneRolloutStatus(controlPlane); ok {
+			status.Rollouts = append(status.Rollouts, rollout)
+		}
+	}
+
+	selectors := []client.ListOption{
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := cl.List(ctx, machineDeployments, selectors...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineDeployments of Cluster %s", clusterKey)
+	}
+	sort.Slice(machineDeployments.Items, func(i, j int) bool {
+		return machineDeployments.Items[i].Name < machineDeployments.Items[j].Name
+	})
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		uids[md.UID] = true
+		status.Rollouts = append(status.Rollouts, machineDeploymentRolloutStatus(md))
+	}
+
+	if options.MaxEvents <= 0 {
+		return status, nil
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := cl.List(ctx, machineSets, selectors...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list MachineSets of Cluster %s", clusterKey)
+	}
+	for i := range machineSets.Items {
+		uids[machineSets.Items[i].UID] = true
+	}
+
+	machines := &clusterv1.MachineList{}
+	if err := cl.List(ctx, machines, selectors...); err != nil {
+		return nil, errors.Wrapf(err, "failed to list Machines of Cluster %s", clusterKey)
+	}
+	for i := range machines.Items {
+		uids[machines.Items[i].UID] = true
+	}
+
+	events := &corev1.EventList{}
+	if err := cl.List(ctx, events, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil, errors.Wrapf(err, "failed to list events in namespace %s", cluster.Namespace)
+	}
+	for _, e := range events.Items {
+		if uids[e.InvolvedObject.UID] {
+			status.Events = append(status.Events, e)
+		}
+	}
+	sort.SliceStable(status.Events, func(i, j int) bool {
+		return EventTime(status.Events[i]).After(EventTime(status.Events[j]))
+	})
+	if len(status.Events) > options.MaxEvents {
+		status.Events = status.Events[:options.MaxEvents]
+	}
+
+	return status, nil
+}
+
+// EventTime returns the time an event was last observed.
+func EventTime(e corev1.Event) time.Time {
+	if !e.LastTimestamp.IsZero() {
+		return e.LastTimestamp.Time
+	}
+	if !e.EventTime.IsZero() {
+		return e.EventTime.Time
+	}
+	return e.CreationTimestamp.Time
+}
+
+// controlPlaneRolloutStatus returns the rollout progress of a machine based control plane; it returns false
+// for control planes not reporting replicas, e.g. control planes not based on Machines.
+func controlPlaneRolloutStatus(controlPlane *unstructured.Unstructured) (RolloutStatus, bool) {
+	replicas, ok, err := unstructured.NestedInt64(controlPlane.Object, "status", "replicas")
+	if err != nil || !ok {
+		return RolloutStatus{}, false
+	}
+
+	rollout := RolloutStatus{
+		Kind:     controlPlane.GetKind(),
+		Name:     controlPlane.GetName(),
+		Replicas: int32(replicas),
+	}
+	rollout.Version, _, _ = unstructured.NestedString(controlPlane.Object, "spec", "version")
+	if desiredReplicas, ok, err := unstructured.NestedInt64(controlPlane.Object, "spec", "replicas"); err == nil && ok {
+		d := int32(desiredReplicas)
+		rollout.DesiredReplicas = &d
+	}
+	if updatedReplicas, ok, err := unstructured.NestedInt64(controlPlane.Object, "status", "updatedReplicas"); err == nil && ok {
+		rollout.UpdatedReplicas = int32(updatedReplicas)
+	}
+	if readyReplicas, ok, err := unstructured.NestedInt64(controlPlane.Object, "status", "readyReplicas"); err == nil && ok {
+		rollout.ReadyReplicas = int32(readyReplicas)
+	}
+	if unavailableReplicas, ok, err := unstructured.NestedInt64(controlPlane.Object, "status", "unavailableReplicas"); err == nil && ok {
+		rollout.UnavailableReplicas = int32(unavailableReplicas)
+	}
+	return rollout, true
+}
+
+// machineDeploymentRolloutStatus returns the rollout progress of a MachineDeployment.
+func machineDeploymentRolloutStatus(md *clusterv1.MachineDeployment) RolloutStatus {
+	rollout := RolloutStatus{
+		Kind:                "MachineDeployment",
+		Name:                md.Name,
+		DesiredReplicas:     md.Spec.Replicas,
+		Replicas:            md.Status.Replicas,
+		UpdatedReplicas:     md.Status.UpdatedReplicas,
+		ReadyReplicas:       md.Status.ReadyReplicas,
+		UnavailableReplicas: md.Status.UnavailableReplicas,
+	}
+	if md.Spec.Template.Spec.Version != nil {
+		rollout.Version = *md.Spec.Template.Spec.Version
+	}
+	return rollout
+}
@@ -0,0 +1,162 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
+)
+
+func Test_clusterctlClient_ClusterStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	ctx := context.Background()
+
+	objs := test.NewFakeCluster("ns1", "cluster1").
+		WithMachineDeployments(
+			test.NewFakeMachineDeployment("md1").
+				WithMachineSets(
+					test.NewFakeMachineSet("ms1").
+						WithMachines(test.NewFakeMachine("m1")),
+				),
+		).Objs()
+
+	var clusterObj *clusterv1.Cluster
+	var machine *clusterv1.Machine
+	for _, o := range objs {
+		switch o := o.(type) {
+		case *clusterv1.Cluster:
+			clusterObj = o
+		case *clusterv1.Machine:
+			machine = o
+		}
+	}
+	g.Expect(clusterObj).ToNot(BeNil())
+	g.Expect(machine).ToNot(BeNil())
+
+	now := time.Now()
+	objs = append(objs,
+		fakeEvent("e1", clusterObj, now.Add(-2*time.Minute)),
+		fakeEvent("e2", machine, now.Add(-1*time.Minute)),
+		fakeEvent("e3", clusterObj, now.Add(-3*time.Minute)),
+		fakeEvent("other", &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "other", UID: "other"}}, now),
+	)
+
+	core := config.NewProvider("cluster-api", "https://somewhere.com", clusterctlv1.CoreProviderType)
+	config1 := newFakeConfig(ctx).WithProvider(core)
+	cluster1 := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config1).
+		WithProviderInventory(core.Name(), core.Type(), "v1.0.0", "cluster-api-system").
+		WithObjs(test.FakeCAPISetupObjects()...).
+		WithObjs(objs...)
+	c := newFakeClient(ctx, config1).WithCluster(cluster1)
+
+	tests := []struct {
+		name       string
+		options    ClusterStatusOptions
+		wantEvents []string
+		wantErr    bool
+	}{
+		{
+			name: "returns the most recent events of the objects of the cluster",
+			options: ClusterStatusOptions{
+				Kubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:   "ns1",
+				ClusterName: "cluster1",
+				MaxEvents:   2,
+			},
+			wantEvents: []string{"e2", "e1"},
+		},
+		{
+			name: "does not return events if MaxEvents is not set",
+			options: ClusterStatusOptions{
+				Kubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:   "ns1",
+				ClusterName: "cluster1",
+			},
+			wantEvents: []string{},
+		},
+		{
+			name: "returns an error if the cluster name is not set",
+			options: ClusterStatusOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:  "ns1",
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the cluster does not exist",
+			options: ClusterStatusOptions{
+				Kubeconfig:  Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:   "ns1",
+				ClusterName: "does-not-exist",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			status, err := c.ClusterStatus(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			g.Expect(status.Cluster.Name).To(Equal("cluster1"))
+			g.Expect(status.Tree).ToNot(BeNil())
+			g.Expect(status.Rollouts).To(HaveLen(1))
+			g.Expect(status.Rollouts[0].Kind).To(Equal("MachineDeployment"))
+			g.Expect(status.Rollouts[0].Name).To(Equal("md1"))
+
+			events := []string{}
+			for _, e := range status.Events {
+				events = append(events, e.Name)
+			}
+			g.Expect(events).To(HaveExactElements(tt.wantEvents))
+		})
+	}
+}
+
+func fakeEvent(name string, obj client.Object, lastTimestamp time.Time) *corev1.Event {
+	return &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: obj.GetNamespace(),
+			Name:      name,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Namespace: obj.GetNamespace(),
+			Name:      obj.GetName(),
+			UID:       obj.GetUID(),
+		},
+		Reason:        "Reason",
+		LastTimestamp: metav1.NewTime(lastTimestamp),
+	}
+}
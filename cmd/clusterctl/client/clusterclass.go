@@ -35,7 +35,7 @@ import (
 // are references in the template. If the cluster class referenced already exists in the cluster it is not added to the
 // template.
 func addClusterClassIfMissing(ctx context.Context, template Template, clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, targetNamespace string, listVariablesOnly bool) (Template, error) {
-	classes, err := clusterClassNamesFromTemplate(template)
+	classes, err := clusterClassNamesFromTemplate(template, targetNamespace)
 	if err != nil {
 		return nil, err
 	}
@@ -62,8 +62,11 @@ func addClusterClassIfMissing(ctx context.Context, template Template, clusterCla
 // clusterClassNamesFromTemplate returns the list of ClusterClasses referenced
 // by clusters defined in the template. If not clusters are defined in the template
 // or if no cluster uses a cluster class it returns an empty list.
-func clusterClassNamesFromTemplate(template Template) ([]string, error) {
-	classes := []string{}
+// ClusterClasses are identified by namespace and name; if a cluster does not set
+// cluster.spec.topology.classNamespace, the ClusterClass is in the target namespace.
+func clusterClassNamesFromTemplate(template Template, targetNamespace string) ([]client.ObjectKey, error) {
+	classes := []client.ObjectKey{}
+	seen := map[client.ObjectKey]bool{}
 
 	// loop through all the objects and if the object is a cluster
 	// check and see if cluster.spec.topology.class is defined.
@@ -80,14 +83,22 @@ func clusterClassNamesFromTemplate(template Template) ([]string, error) {
 		if cluster.Spec.Topology == nil {
 			continue
 		}
-		classes = append(classes, cluster.Spec.Topology.Class)
+		class := client.ObjectKey{Namespace: cluster.Spec.Topology.ClassNamespace, Name: cluster.Spec.Topology.Class}
+		if class.Namespace == "" {
+			class.Namespace = targetNamespace
+		}
+		if seen[class] {
+			continue
+		}
+		seen[class] = true
+		classes = append(classes, class)
 	}
 	return classes, nil
 }
 
 // fetchMissingClusterClassTemplates returns a list of templates for ClusterClasses that do not yet exist
 // in the cluster. If the cluster is not initialized, all the ClusterClasses are added.
-func fetchMissingClusterClassTemplates(ctx context.Context, clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, classes []string, targetNamespace string, listVariablesOnly bool) (Template, error) {
+func fetchMissingClusterClassTemplates(ctx context.Context, clusterClassClient repository.ClusterClassClient, clusterClient cluster.Client, classes []client.ObjectKey, targetNamespace string, listVariablesOnly bool) (Template, error) {
 	// first check if the cluster is initialized.
 	// If it is initialized:
 	//    For every ClusterClass check if it already exists in the cluster.
@@ -118,7 +129,7 @@ func fetchMissingClusterClassTemplates(ctx context.Context, clusterClassClient r
 	templates := []repository.Template{}
 	for _, class := range classes {
 		if clusterInitialized {
-			exists, err := clusterClassExists(ctx, c, class, targetNamespace)
+			exists, err := clusterClassExists(ctx, c, class)
 			if err != nil {
 				return nil, err
 			}
@@ -126,9 +137,15 @@ func fetchMissingClusterClassTemplates(ctx context.Context, clusterClassClient r
 				continue
 			}
 		}
+		// ClusterClasses from other namespaces cannot be added to the template, which is for the target namespace only,
+		// so they must be installed in the cluster before.
+		if class.Namespace != targetNamespace {
+			return nil, errors.Errorf("ClusterClass %q does not exist in the cluster; ClusterClasses outside of the target namespace %q must be installed before", class, targetNamespace)
+		}
+
 		// The cluster is either not initialized or the ClusterClass does not yet exist in the cluster.
 		// Fetch the cluster class to install.
-		clusterClassTemplate, err := clusterClassClient.Get(ctx, class, targetNamespace, listVariablesOnly)
+		clusterClassTemplate, err := clusterClassClient.Get(ctx, class.Name, targetNamespace, listVariablesOnly)
 		if err != nil {
 			return nil, errors.Wrapf(err, "failed to get the cluster class template for %q", class)
 		}
@@ -157,9 +174,9 @@ func fetchMissingClusterClassTemplates(ctx context.Context, clusterClassClient r
 	return merged, nil
 }
 
-func clusterClassExists(ctx context.Context, c client.Client, class, targetNamespace string) (bool, error) {
+func clusterClassExists(ctx context.Context, c client.Client, class client.ObjectKey) (bool, error) {
 	clusterClass := &clusterv1.ClusterClass{}
-	if err := c.Get(ctx, client.ObjectKey{Name: class, Namespace: targetNamespace}, clusterClass); err != nil {
+	if err := c.Get(ctx, class, clusterClass); err != nil {
 		if apierrors.IsNotFound(err) {
 			return false, nil
 		}
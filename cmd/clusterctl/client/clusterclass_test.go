@@ -71,10 +71,10 @@ func TestClusterClassExists(t *testing.T) {
 			ctx := context.Background()
 
 			config := newFakeConfig(ctx)
-			client := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config).WithObjs(tt.objs...)
-			c, _ := client.Proxy().NewClient()
+			fakeCluster := newFakeCluster(cluster.Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"}, config).WithObjs(tt.objs...)
+			c, _ := fakeCluster.Proxy().NewClient()
 
-			actual, err := clusterClassExists(ctx, c, tt.clusterClass, metav1.NamespaceDefault)
+			actual, err := clusterClassExists(ctx, c, client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: tt.clusterClass})
 			g.Expect(err).ToNot(HaveOccurred())
 			g.Expect(actual).To(Equal(tt.want))
 		})
@@ -100,6 +100,7 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 		objs                        []client.Object
 		clusterClassTemplateContent []byte
 		targetNamespace             string
+		classNamespace              string
 		listVariablesOnly           bool
 		wantClusterClassInTemplate  bool
 		wantError                   bool
@@ -139,6 +140,38 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 			wantClusterClassInTemplate:  false,
 			wantError:                   false,
 		},
+		{
+			name:               "should throw error if the cluster class is not installed in the class namespace, even if installed in the target namespace",
+			clusterInitialized: true,
+			objs: []client.Object{&clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dev",
+					Namespace: "ns5",
+				},
+			}},
+			targetNamespace:             "ns5",
+			classNamespace:              "shared",
+			clusterClassTemplateContent: clusterClassYAML("ns5", "dev"),
+			listVariablesOnly:           false,
+			wantClusterClassInTemplate:  false,
+			wantError:                   true,
+		},
+		{
+			name:               "should NOT add the cluster class to the template if cluster is initialized and cluster class is installed in the class namespace",
+			clusterInitialized: true,
+			objs: []client.Object{&clusterv1.ClusterClass{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "dev",
+					Namespace: "shared",
+				},
+			}},
+			targetNamespace:             "ns6",
+			classNamespace:              "shared",
+			clusterClassTemplateContent: clusterClassYAML("shared", "dev"),
+			listVariablesOnly:           false,
+			wantClusterClassInTemplate:  false,
+			wantError:                   false,
+		},
 		{
 			name:               "should throw error if the cluster is initialized and templates from the cluster class template already exist in the cluster",
 			clusterInitialized: true,
@@ -196,7 +229,8 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 				fmt.Sprintf("  namespace: %s\n", tt.targetNamespace) +
 				"spec:\n" +
 				"  topology:\n" +
-				"    class: dev")
+				"    class: dev\n" +
+				fmt.Sprintf("    classNamespace: %s", tt.classNamespace))
 
 			baseTemplate, err := repository.NewTemplate(repository.TemplateInput{
 				RawArtifact:           clusterWithTopology,
@@ -211,13 +245,18 @@ func TestAddClusterClassIfMissing(t *testing.T) {
 
 			g := NewWithT(t)
 			template, err := addClusterClassIfMissing(ctx, baseTemplate, clusterClassClient, cluster, tt.targetNamespace, tt.listVariablesOnly)
+			classNamespace := tt.targetNamespace
+			if tt.classNamespace != "" {
+				classNamespace = tt.classNamespace
+			}
 			if tt.wantError {
 				g.Expect(err).To(HaveOccurred())
 			} else {
+				g.Expect(err).ToNot(HaveOccurred())
 				if tt.wantClusterClassInTemplate {
-					g.Expect(template.Objs()).To(ContainElement(MatchClusterClass("dev", tt.targetNamespace)))
+					g.Expect(template.Objs()).To(ContainElement(MatchClusterClass("dev", classNamespace)))
 				} else {
-					g.Expect(template.Objs()).NotTo(ContainElement(MatchClusterClass("dev", tt.targetNamespace)))
+					g.Expect(template.Objs()).NotTo(ContainElement(MatchClusterClass("dev", classNamespace)))
 				}
 			}
 		})
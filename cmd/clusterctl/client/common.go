@@ -60,6 +60,11 @@ func (c *clusterctlClient) getComponentsByName(ctx context.Context, provider str
 	return components, nil
 }
 
+// ReadComponentsPatches reads the patches to customize provider's components from a file.
+func ReadComponentsPatches(path string) ([]ComponentsPatch, error) {
+	return repository.ReadComponentsPatches(path)
+}
+
 // parseProviderName defines a utility function that parses the abbreviated syntax for name[:version].
 func parseProviderName(provider string) (name string, version string, err error) {
 	t := strings.Split(strings.ToLower(provider), ":")
@@ -0,0 +1,54 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+)
+
+// CheckDriftOptions carries the options supported by CheckDrift.
+type CheckDriftOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty, default discovery rules apply.
+	Kubeconfig Kubeconfig
+}
+
+// CheckDrift checks if the components of the providers installed in the management cluster have been changed
+// after clusterctl applied them, e.g. by editing them by hand.
+func (c *clusterctlClient) CheckDrift(ctx context.Context, options CheckDriftOptions) ([]ProviderDrift, error) {
+	// Get the client for interacting with the management cluster.
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	// Ensures the custom resource definitions required by clusterctl are in place.
+	if err := clusterClient.ProviderInventory().EnsureCustomResourceDefinitions(ctx); err != nil {
+		return nil, err
+	}
+
+	drifts, err := clusterClient.ProviderInventory().CheckDrift(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// ProviderDrift is an alias for cluster.ProviderDrift; this makes the conversion
+	aliasDrifts := make([]ProviderDrift, len(drifts))
+	for i, drift := range drifts {
+		aliasDrifts[i] = ProviderDrift(drift)
+	}
+	return aliasDrifts, nil
+}
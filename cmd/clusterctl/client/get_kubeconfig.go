@@ -18,8 +18,20 @@ package client
 
 import (
 	"context"
+	"sort"
 
 	"github.com/pkg/errors"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
+)
+
+const (
+	// defaultExecAPIVersion is the default API version used by exec credential plugins.
+	defaultExecAPIVersion = "client.authentication.k8s.io/v1"
+
+	// oidcLoginCommand is the command used to get OIDC tokens, i.e. the kubelogin kubectl plugin
+	// (https://github.com/int128/kubelogin); the oidc auth provider has been removed in kubectl v1.26.
+	oidcLoginCommand = "kubectl"
 )
 
 // GetKubeconfigOptions carries all the options supported by GetKubeconfig.
@@ -33,9 +45,51 @@ type GetKubeconfigOptions struct {
 
 	// WorkloadClusterName is the name of the workload cluster.
 	WorkloadClusterName string
+
+	// Exec, if set, rewrites the users in the kubeconfig to use an exec credential plugin
+	// instead of the embedded client certificate.
+	Exec *KubeconfigExecOptions
+
+	// OIDC, if set, rewrites the users in the kubeconfig to get OIDC tokens with the kubelogin
+	// exec credential plugin instead of using the embedded client certificate.
+	OIDC *KubeconfigOIDCOptions
+}
+
+// KubeconfigExecOptions carries the options for configuring an exec credential plugin in a kubeconfig.
+type KubeconfigExecOptions struct {
+	// APIVersion is the preferred input version of the ExecCredential; if empty, client.authentication.k8s.io/v1 is used.
+	APIVersion string
+
+	// Command is the command to execute.
+	Command string
+
+	// Args are the arguments to pass to the command.
+	Args []string
+
+	// Env defines additional environment variables to expose to the command.
+	Env map[string]string
+}
+
+// KubeconfigOIDCOptions carries the options for configuring the kubelogin exec credential plugin in a kubeconfig.
+type KubeconfigOIDCOptions struct {
+	// IssuerURL is the URL of the OIDC issuer.
+	IssuerURL string
+
+	// ClientID is the OIDC client ID.
+	ClientID string
+
+	// ClientSecret is the OIDC client secret; it is optional.
+	ClientSecret string
+
+	// ExtraScopes are additional scopes to request.
+	ExtraScopes []string
 }
 
 func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubeconfigOptions) (string, error) {
+	if err := options.validate(); err != nil {
+		return "", err
+	}
+
 	// gets access to the management cluster
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -58,5 +112,94 @@ func (c *clusterctlClient) GetKubeconfig(ctx context.Context, options GetKubecon
 		options.Namespace = currentNamespace
 	}
 
-	return clusterClient.WorkloadCluster().GetKubeconfig(ctx, options.WorkloadClusterName, options.Namespace)
+	kubeconfig, err := clusterClient.WorkloadCluster().GetKubeconfig(ctx, options.WorkloadClusterName, options.Namespace)
+	if err != nil {
+		return "", err
+	}
+
+	if options.Exec == nil && options.OIDC == nil {
+		return kubeconfig, nil
+	}
+	return rewriteKubeconfigAuthInfos(kubeconfig, options)
+}
+
+func (o *GetKubeconfigOptions) validate() error {
+	if o.Exec != nil && o.OIDC != nil {
+		return errors.New("exec credential plugin and OIDC options are mutually exclusive")
+	}
+	if o.Exec != nil && o.Exec.Command == "" {
+		return errors.New("the exec credential plugin command must be specified")
+	}
+	if o.OIDC != nil && (o.OIDC.IssuerURL == "" || o.OIDC.ClientID == "") {
+		return errors.New("the OIDC issuer URL and client ID must be specified")
+	}
+	return nil
+}
+
+// rewriteKubeconfigAuthInfos replaces the credentials of all the users in the kubeconfig, e.g. the embedded
+// client certificate, with an exec credential plugin.
+func rewriteKubeconfigAuthInfos(kubeconfig string, options GetKubeconfigOptions) (string, error) {
+	config, err := clientcmd.Load([]byte(kubeconfig))
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse the workload cluster kubeconfig")
+	}
+
+	for name := range config.AuthInfos {
+		authInfo := clientcmdapi.NewAuthInfo()
+		if options.Exec != nil {
+			authInfo.Exec = execConfig(options.Exec)
+		}
+		if options.OIDC != nil {
+			authInfo.Exec = execConfig(oidcExecOptions(options.OIDC))
+		}
+		config.AuthInfos[name] = authInfo
+	}
+
+	out, err := clientcmd.Write(*config)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to serialize the workload cluster kubeconfig")
+	}
+	return string(out), nil
+}
+
+func execConfig(options *KubeconfigExecOptions) *clientcmdapi.ExecConfig {
+	exec := &clientcmdapi.ExecConfig{
+		APIVersion:      options.APIVersion,
+		Command:         options.Command,
+		Args:            options.Args,
+		InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+	}
+	if exec.APIVersion == "" {
+		exec.APIVersion = defaultExecAPIVersion
+	}
+
+	names := make([]string, 0, len(options.Env))
+	for name := range options.Env {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		exec.Env = append(exec.Env, clientcmdapi.ExecEnvVar{Name: name, Value: options.Env[name]})
+	}
+	return exec
+}
+
+// oidcExecOptions returns the options for getting OIDC tokens with kubelogin, i.e. `kubectl oidc-login get-token`.
+func oidcExecOptions(options *KubeconfigOIDCOptions) *KubeconfigExecOptions {
+	args := []string{
+		"oidc-login",
+		"get-token",
+		"--oidc-issuer-url=" + options.IssuerURL,
+		"--oidc-client-id=" + options.ClientID,
+	}
+	if options.ClientSecret != "" {
+		args = append(args, "--oidc-client-secret="+options.ClientSecret)
+	}
+	for _, scope := range options.ExtraScopes {
+		args = append(args, "--oidc-extra-scope="+scope)
+	}
+	return &KubeconfigExecOptions{
+		Command: oidcLoginCommand,
+		Args:    args,
+	}
 }
@@ -21,6 +21,8 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	"k8s.io/client-go/tools/clientcmd"
+	clientcmdapi "k8s.io/client-go/tools/clientcmd/api"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/internal/test"
@@ -70,3 +72,147 @@ func Test_clusterctlClient_GetKubeconfig(t *testing.T) {
 		})
 	}
 }
+
+func Test_GetKubeconfigOptions_validate(t *testing.T) {
+	tests := []struct {
+		name      string
+		options   GetKubeconfigOptions
+		expectErr bool
+	}{
+		{
+			name:    "no auth options",
+			options: GetKubeconfigOptions{},
+		},
+		{
+			name:    "exec options",
+			options: GetKubeconfigOptions{Exec: &KubeconfigExecOptions{Command: "kubectl"}},
+		},
+		{
+			name:      "exec options without a command",
+			options:   GetKubeconfigOptions{Exec: &KubeconfigExecOptions{}},
+			expectErr: true,
+		},
+		{
+			name:    "OIDC options",
+			options: GetKubeconfigOptions{OIDC: &KubeconfigOIDCOptions{IssuerURL: "https://issuer.example.com", ClientID: "foo"}},
+		},
+		{
+			name:      "OIDC options without a client ID",
+			options:   GetKubeconfigOptions{OIDC: &KubeconfigOIDCOptions{IssuerURL: "https://issuer.example.com"}},
+			expectErr: true,
+		},
+		{
+			name: "exec and OIDC options",
+			options: GetKubeconfigOptions{
+				Exec: &KubeconfigExecOptions{Command: "kubectl"},
+				OIDC: &KubeconfigOIDCOptions{IssuerURL: "https://issuer.example.com", ClientID: "foo"},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := tt.options.validate()
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func Test_rewriteKubeconfigAuthInfos(t *testing.T) {
+	kubeconfig, err := clientcmd.Write(clientcmdapi.Config{
+		Clusters: map[string]*clientcmdapi.Cluster{
+			"foo": {Server: "https://foo:6443", CertificateAuthorityData: []byte("ca")},
+		},
+		AuthInfos: map[string]*clientcmdapi.AuthInfo{
+			"foo-admin": {ClientCertificateData: []byte("cert"), ClientKeyData: []byte("key")},
+		},
+		Contexts: map[string]*clientcmdapi.Context{
+			"foo-admin@foo": {Cluster: "foo", AuthInfo: "foo-admin"},
+		},
+		CurrentContext: "foo-admin@foo",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		name         string
+		options      GetKubeconfigOptions
+		wantAuthInfo *clientcmdapi.AuthInfo
+	}{
+		{
+			name: "exec credential plugin",
+			options: GetKubeconfigOptions{
+				Exec: &KubeconfigExecOptions{
+					Command: "kubectl",
+					Args:    []string{"oidc-login", "get-token"},
+					Env:     map[string]string{"B": "b", "A": "a"},
+				},
+			},
+			wantAuthInfo: &clientcmdapi.AuthInfo{
+				Exec: &clientcmdapi.ExecConfig{
+					APIVersion:      "client.authentication.k8s.io/v1",
+					Command:         "kubectl",
+					Args:            []string{"oidc-login", "get-token"},
+					Env:             []clientcmdapi.ExecEnvVar{{Name: "A", Value: "a"}, {Name: "B", Value: "b"}},
+					InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+				},
+			},
+		},
+		{
+			name: "OIDC",
+			options: GetKubeconfigOptions{
+				OIDC: &KubeconfigOIDCOptions{
+					IssuerURL:    "https://issuer.example.com",
+					ClientID:     "foo",
+					ClientSecret: "bar",
+					ExtraScopes:  []string{"groups", "email"},
+				},
+			},
+			wantAuthInfo: &clientcmdapi.AuthInfo{
+				Exec: &clientcmdapi.ExecConfig{
+					APIVersion: "client.authentication.k8s.io/v1",
+					Command:    "kubectl",
+					Args: []string{
+						"oidc-login",
+						"get-token",
+						"--oidc-issuer-url=https://issuer.example.com",
+						"--oidc-client-id=foo",
+						"--oidc-client-secret=bar",
+						"--oidc-extra-scope=groups",
+						"--oidc-extra-scope=email",
+					},
+					InteractiveMode: clientcmdapi.IfAvailableExecInteractiveMode,
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := rewriteKubeconfigAuthInfos(string(kubeconfig), tt.options)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			config, err := clientcmd.Load([]byte(got))
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(config.CurrentContext).To(Equal("foo-admin@foo"))
+			g.Expect(config.Clusters["foo"].CertificateAuthorityData).To(Equal([]byte("ca")))
+			g.Expect(config.AuthInfos).To(HaveLen(1))
+
+			authInfo := config.AuthInfos["foo-admin"]
+			g.Expect(authInfo.ClientCertificateData).To(BeEmpty())
+			g.Expect(authInfo.ClientKeyData).To(BeEmpty())
+			g.Expect(authInfo.Exec).To(BeComparableTo(tt.wantAuthInfo.Exec))
+			g.Expect(authInfo.AuthProvider).To(BeComparableTo(tt.wantAuthInfo.AuthProvider))
+		})
+	}
+}
@@ -0,0 +1,64 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// GraphOptions carries the options supported by Graph.
+type GraphOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the objects describing the workload cluster exists. If unspecified, the current
+	// namespace will be used.
+	Namespace string
+
+	// ClusterName is the name of the workload cluster to limit the graph to. If empty, the graph
+	// contains all the objects in the namespace.
+	ClusterName string
+}
+
+// MoveGraph defines the graph of the objects considered by clusterctl move.
+type MoveGraph = cluster.MoveGraph
+
+// MoveGraphNode defines a single node of the MoveGraph.
+type MoveGraphNode = cluster.MoveGraphNode
+
+// Graph returns the graph of the Cluster API objects considered by clusterctl move, with the ownership
+// relations between them.
+func (c *clusterctlClient) Graph(ctx context.Context, options GraphOptions) (*MoveGraph, error) {
+	clusterClient, err := c.getClusterClient(ctx, options.Kubeconfig)
+	if err != nil {
+		return nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if options.Namespace == "" {
+		currentNamespace, err := clusterClient.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, err
+		}
+		options.Namespace = currentNamespace
+	}
+
+	return clusterClient.ObjectMover().Graph(ctx, options.Namespace, options.ClusterName)
+}
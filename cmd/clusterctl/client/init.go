@@ -67,6 +67,10 @@ type InitOptions struct {
 	// will be installed in a provider's default namespace.
 	TargetNamespace string
 
+	// Patches are applied to the components of all the providers, e.g. to customize the provider Deployments;
+	// patches not selecting any object of a provider are ignored.
+	Patches []ComponentsPatch
+
 	// LogUsageInstructions instructs the init command to print the usage instructions in case of first run.
 	LogUsageInstructions bool
 
@@ -76,6 +80,17 @@ type InitOptions struct {
 	// WaitProviderTimeout sets the timeout per provider wait installation
 	WaitProviderTimeout time.Duration
 
+	// RollbackOnFailure instructs the init command to delete the providers installed by this run, including their CRDs
+	// and inventory entries, if a provider fails to be installed or, when WaitProviders is set, if it does not
+	// become available within WaitProviderTimeout; the provider namespaces are preserved.
+	RollbackOnFailure bool
+
+	// WebhookCertificates defines how the certificates of the provider webhooks are managed:
+	// - "cert-manager" (default) installs cert-manager, if not already installed, and uses it to issue the certificates.
+	// - "self-signed" uses certificates generated by clusterctl and signed by a self-signed CA, without cert-manager.
+	// - "secret" uses the certificates in existing Secrets, named after the cert-manager Certificates secretName, without cert-manager.
+	WebhookCertificates string
+
 	// SkipTemplateProcess allows for skipping the call to the template processor, including also variable replacement in the component YAML.
 	// NOTE this works only if the rawYaml is a valid yaml by itself, like e.g when using envsubst/the simple processor.
 	skipTemplateProcess bool
@@ -99,6 +114,10 @@ func (c *clusterctlClient) Init(ctx context.Context, options InitOptions) ([]Com
 		options.WaitProviderTimeout = time.Duration(5*60) * time.Second
 	}
 
+	if err := validateWebhookCertificates(options.WebhookCertificates); err != nil {
+		return nil, err
+	}
+
 	// gets access to the management cluster
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -140,15 +159,19 @@ func (c *clusterctlClient) Init(ctx context.Context, options InitOptions) ([]Com
 		log.Error(err, "Ignoring validation errors")
 	}
 
-	// Before installing the providers, ensure the cert-manager Webhook is in place.
-	certManager := clusterClient.CertManager()
-	if err := certManager.EnsureInstalled(ctx); err != nil {
-		return nil, err
+	// Before installing the providers, ensure the cert-manager Webhook is in place, if the providers are using it.
+	if usesCertManager(options.WebhookCertificates) {
+		certManager := clusterClient.CertManager()
+		if err := certManager.EnsureInstalled(ctx); err != nil {
+			return nil, err
+		}
 	}
 
 	installOpts := cluster.InstallOptions{
 		WaitProviders:       options.WaitProviders,
 		WaitProviderTimeout: options.WaitProviderTimeout,
+		RollbackOnFailure:   options.RollbackOnFailure,
+		WebhookCertificates: cluster.WebhookCertificatesMode(options.WebhookCertificates),
 	}
 	components, err := installer.Install(ctx, installOpts)
 	if err != nil {
@@ -176,6 +199,10 @@ func (c *clusterctlClient) Init(ctx context.Context, options InitOptions) ([]Com
 
 // InitImages returns the list of images required for init.
 func (c *clusterctlClient) InitImages(ctx context.Context, options InitOptions) ([]string, error) {
+	if err := validateWebhookCertificates(options.WebhookCertificates); err != nil {
+		return nil, err
+	}
+
 	// gets access to the management cluster
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -204,11 +231,14 @@ func (c *clusterctlClient) InitImages(ctx context.Context, options InitOptions)
 		return nil, err
 	}
 
-	// Gets the list of container images required for the cert-manager (if not already installed).
-	certManager := clusterClient.CertManager()
-	images, err := certManager.Images(ctx)
-	if err != nil {
-		return nil, err
+	// Gets the list of container images required for the cert-manager (if used and not already installed).
+	images := []string{}
+	if usesCertManager(options.WebhookCertificates) {
+		certManager := clusterClient.CertManager()
+		images, err = certManager.Images(ctx)
+		if err != nil {
+			return nil, err
+		}
 	}
 
 	// Appends the list of container images required for the selected providers.
@@ -218,6 +248,22 @@ func (c *clusterctlClient) InitImages(ctx context.Context, options InitOptions)
 	return images, nil
 }
 
+// validateWebhookCertificates checks the webhook certificates mode is supported.
+func validateWebhookCertificates(mode string) error {
+	switch cluster.WebhookCertificatesMode(mode) {
+	case "", cluster.WebhookCertificatesCertManager, cluster.WebhookCertificatesSelfSigned, cluster.WebhookCertificatesSecret:
+		return nil
+	default:
+		return errors.Errorf("invalid webhook certificates mode %q, it must be one of %q, %q or %q", mode,
+			cluster.WebhookCertificatesCertManager, cluster.WebhookCertificatesSelfSigned, cluster.WebhookCertificatesSecret)
+	}
+}
+
+// usesCertManager returns true if the webhook certificates mode requires cert-manager.
+func usesCertManager(mode string) bool {
+	return mode == "" || cluster.WebhookCertificatesMode(mode) == cluster.WebhookCertificatesCertManager
+}
+
 func (c *clusterctlClient) setupInstaller(ctx context.Context, cluster cluster.Client, options InitOptions) (cluster.ProviderInstaller, error) {
 	installer := cluster.ProviderInstaller()
 
@@ -227,6 +273,7 @@ func (c *clusterctlClient) setupInstaller(ctx context.Context, cluster cluster.C
 		installer:           installer,
 		targetNamespace:     options.TargetNamespace,
 		skipTemplateProcess: options.skipTemplateProcess,
+		patches:             options.Patches,
 		providerList:        providerList,
 	}
 
@@ -300,6 +347,7 @@ type addToInstallerOptions struct {
 	installer           cluster.ProviderInstaller
 	targetNamespace     string
 	skipTemplateProcess bool
+	patches             []ComponentsPatch
 	providerList        *clusterctlv1.ProviderList
 }
 
@@ -316,6 +364,7 @@ func (c *clusterctlClient) addToInstaller(ctx context.Context, options addToInst
 		componentsOptions := repository.ComponentsOptions{
 			TargetNamespace:     options.targetNamespace,
 			SkipTemplateProcess: options.skipTemplateProcess,
+			Patches:             options.patches,
 		}
 		components, err := c.getComponentsByName(ctx, provider, providerType, componentsOptions)
 		if err != nil {
@@ -326,3 +326,7 @@ func (f *fakeObjectMover) FromDirectory(_ context.Context, _ cluster.Client, _ s
 func (f *fakeObjectMover) Restore(_ context.Context, _ cluster.Client, _ string) error {
 	return f.fromDirectoryErr
 }
+
+func (f *fakeObjectMover) Graph(_ context.Context, _, _ string) (*cluster.MoveGraph, error) {
+	return &cluster.MoveGraph{}, nil
+}
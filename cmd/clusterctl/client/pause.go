@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+)
+
+// PauseOptions carries the options supported by Pause.
+type PauseOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the Clusters to be paused exist. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// AllNamespaces selects the Clusters in all the namespaces; it can't be used together with Namespace.
+	AllNamespaces bool
+
+	// Selector is the label selector for the Clusters to be paused; it must not be empty.
+	Selector string
+
+	// DryRun means the pause action is a dry run, no real action will be performed.
+	DryRun bool
+}
+
+// ResumeOptions carries the options supported by Resume.
+type ResumeOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Namespace where the Clusters to be resumed exist. If unspecified, the current namespace will be used.
+	Namespace string
+
+	// AllNamespaces selects the Clusters in all the namespaces; it can't be used together with Namespace.
+	AllNamespaces bool
+
+	// Selector is the label selector for the Clusters to be resumed; it must not be empty.
+	Selector string
+
+	// DryRun means the resume action is a dry run, no real action will be performed.
+	DryRun bool
+}
+
+// Pause sets spec.paused on all the Clusters matching a label selector and waits for the controllers
+// to acknowledge the pause on the Clusters and their descendants.
+func (c *clusterctlClient) Pause(ctx context.Context, options PauseOptions) ([]client.ObjectKey, error) {
+	clusterClient, namespace, selector, err := c.getPauseTarget(ctx, options.Kubeconfig, options.Namespace, options.AllNamespaces, options.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterClient.ClusterPauser().Pause(ctx, namespace, selector, options.DryRun)
+}
+
+// Resume unsets spec.paused on all the Clusters matching a label selector and waits for the controllers
+// to acknowledge the resume on the Clusters and their descendants.
+func (c *clusterctlClient) Resume(ctx context.Context, options ResumeOptions) ([]client.ObjectKey, error) {
+	clusterClient, namespace, selector, err := c.getPauseTarget(ctx, options.Kubeconfig, options.Namespace, options.AllNamespaces, options.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterClient.ClusterPauser().Resume(ctx, namespace, selector, options.DryRun)
+}
+
+// getPauseTarget validates the options for Pause and Resume, and returns the cluster client, the namespace
+// and the label selector to be used.
+func (c *clusterctlClient) getPauseTarget(ctx context.Context, kubeconfig Kubeconfig, namespace string, allNamespaces bool, selectorString string) (cluster.Client, string, labels.Selector, error) {
+	if allNamespaces && namespace != "" {
+		return nil, "", nil, errors.New("can't set both Namespace and AllNamespaces")
+	}
+
+	selector, err := labels.Parse(selectorString)
+	if err != nil {
+		return nil, "", nil, errors.Wrapf(err, "failed to parse selector %q", selectorString)
+	}
+	// Selecting all the Clusters must be explicit, given that pausing the wrong Clusters can be disruptive.
+	if selector.Empty() {
+		return nil, "", nil, errors.New("a non-empty selector is required")
+	}
+
+	clusterClient, err := c.getClusterClient(ctx, kubeconfig)
+	if err != nil {
+		return nil, "", nil, err
+	}
+
+	// If the option specifying the Namespace is empty, try to detect it.
+	if namespace == "" && !allNamespaces {
+		namespace, err = clusterClient.Proxy().CurrentNamespace()
+		if err != nil {
+			return nil, "", nil, err
+		}
+	}
+
+	return clusterClient, namespace, selector, nil
+}
@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package client
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func Test_clusterctlClient_Pause(t *testing.T) {
+	tests := []struct {
+		name    string
+		options PauseOptions
+		wantErr bool
+	}{
+		{
+			name: "does not return error if cluster client is found",
+			options: PauseOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Selector:   "env=production",
+			},
+			wantErr: false,
+		},
+		{
+			name: "returns an error if cluster client is not found",
+			options: PauseOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "does-not-exist"},
+				Selector:   "env=production",
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the selector is empty",
+			options: PauseOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if the selector is invalid",
+			options: PauseOptions{
+				Kubeconfig: Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Selector:   "env in (production",
+			},
+			wantErr: true,
+		},
+		{
+			name: "returns an error if both Namespace and AllNamespaces are set",
+			options: PauseOptions{
+				Kubeconfig:    Kubeconfig{Path: "kubeconfig", Context: "mgmt-context"},
+				Namespace:     "default",
+				AllNamespaces: true,
+				Selector:      "env=production",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			ctx := context.Background()
+
+			_, err := fakeClientForMove().Pause(ctx, tt.options)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			_, err = fakeClientForMove().Resume(ctx, ResumeOptions(tt.options))
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
@@ -95,6 +95,7 @@ type components struct {
 	variables       []string
 	images          []string
 	targetNamespace string
+	patches         string
 	objs            []unstructured.Unstructured
 }
 
@@ -121,7 +122,7 @@ func (c *components) InventoryObject() clusterctlv1.Provider {
 	labels := getCommonLabels(c.Provider)
 	labels[clusterctlv1.ClusterctlCoreLabel] = clusterctlv1.ClusterctlCoreLabelInventoryValue
 
-	return clusterctlv1.Provider{
+	provider := clusterctlv1.Provider{
 		TypeMeta: metav1.TypeMeta{
 			APIVersion: clusterctlv1.GroupVersion.String(),
 			Kind:       "Provider",
@@ -135,6 +136,11 @@ func (c *components) InventoryObject() clusterctlv1.Provider {
 		Type:         string(c.Type()),
 		Version:      c.version,
 	}
+	// Store the patches applied to the components, so they can be applied again on upgrade.
+	if c.patches != "" {
+		provider.SetAnnotations(map[string]string{clusterctlv1.ComponentsPatchesAnnotation: c.patches})
+	}
+	return provider
 }
 
 func (c *components) Objs() []unstructured.Unstructured {
@@ -172,6 +178,8 @@ type ComponentsOptions struct {
 	// SkipTemplateProcess allows for skipping the call to the template processor, including also variable replacement in the component YAML.
 	// NOTE this works only if the rawYaml is a valid yaml by itself, like e.g when using envsubst/the simple processor.
 	SkipTemplateProcess bool
+	// Patches are applied to the provider components, in order, after all the other processing steps.
+	Patches []ComponentsPatch
 }
 
 // ComponentsInput represents all the inputs required by NewComponents.
@@ -192,6 +200,7 @@ type ComponentsInput struct {
 // 3. Ensure all the provider components are deployed in the target namespace (apply only to namespaced objects)
 // 4. Ensure all the ClusterRoleBinding which are referencing namespaced objects have the name prefixed with the namespace name
 // 5. Adds labels to all the components in order to allow easy identification of the provider objects.
+// 6. Applies the user provided patches, if any.
 func NewComponents(input ComponentsInput) (Components, error) {
 	variables, err := input.Processor.GetVariables(input.RawYaml)
 	if err != nil {
@@ -222,12 +231,6 @@ func NewComponents(input ComponentsInput) (Components, error) {
 		return nil, errors.Wrap(err, "failed to apply image overrides")
 	}
 
-	// Inspect the list of objects for the images required by the provider component.
-	images, err := util.InspectImages(objs)
-	if err != nil {
-		return nil, errors.Wrap(err, "failed to detect required images")
-	}
-
 	// inspect the list of objects for the default target namespace
 	// the default target namespace is the namespace object defined in the component yaml read from the repository, if any
 	defaultTargetNamespace, err := inspectTargetNamespace(objs)
@@ -259,12 +262,33 @@ func NewComponents(input ComponentsInput) (Components, error) {
 	// Add common labels.
 	objs = addCommonLabels(objs, input.Provider)
 
+	// Apply the user provided patches; they are applied last, so they can also target objects using the common labels.
+	objs, err = applyComponentsPatches(objs, input.Options.Patches)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to apply patches")
+	}
+
+	var patches string
+	if len(input.Options.Patches) > 0 {
+		patches, err = marshalComponentsPatches(input.Options.Patches)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// Inspect the list of objects for the images required by the provider component.
+	images, err := util.InspectImages(objs)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to detect required images")
+	}
+
 	return &components{
 		Provider:        input.Provider,
 		version:         input.Options.Version,
 		variables:       variables,
 		images:          images,
 		targetNamespace: input.Options.TargetNamespace,
+		patches:         patches,
 		objs:            objs,
 	}, nil
 }
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"bytes"
+	"os"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/yaml"
+
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+)
+
+// ComponentsPatch defines a patch to be applied to the provider components, e.g. to customize
+// the resources, the node selector or the environment variables of the provider Deployments.
+type ComponentsPatch struct {
+	// Target selects the objects to be patched. If not set, the patch must be a strategic merge patch
+	// and the objects are selected using the apiVersion, kind, metadata.name and metadata.namespace of the patch.
+	Target *ComponentsPatchTarget `json:"target,omitempty"`
+
+	// Patch is the patch, in YAML or JSON format. A list is applied as a JSON patch (RFC 6902), otherwise
+	// the patch is applied as a strategic merge patch for the Kubernetes built-in types and as a JSON merge patch
+	// (RFC 7386) for all the other types, e.g. custom resources.
+	Patch string `json:"patch"`
+}
+
+// ComponentsPatchTarget selects the objects to be patched; all the fields which are set must match.
+type ComponentsPatchTarget struct {
+	Group         string `json:"group,omitempty"`
+	Version       string `json:"version,omitempty"`
+	Kind          string `json:"kind,omitempty"`
+	Name          string `json:"name,omitempty"`
+	Namespace     string `json:"namespace,omitempty"`
+	LabelSelector string `json:"labelSelector,omitempty"`
+}
+
+// componentsPatchesFile is the format of the file containing the components patches.
+type componentsPatchesFile struct {
+	Patches []ComponentsPatch `json:"patches"`
+}
+
+// ReadComponentsPatches reads the components patches from a file.
+func ReadComponentsPatches(path string) ([]ComponentsPatch, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The path is provided by the user running clusterctl.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read patches file %q", path)
+	}
+	patches, err := parseComponentsPatches(data)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid patches file %q", path)
+	}
+	return patches, nil
+}
+
+// ComponentsPatchesFromProvider returns the components patches stored in the Provider inventory object,
+// i.e. the patches applied when the provider has been installed.
+func ComponentsPatchesFromProvider(provider clusterctlv1.Provider) ([]ComponentsPatch, error) {
+	data, ok := provider.GetAnnotations()[clusterctlv1.ComponentsPatchesAnnotation]
+	if !ok {
+		return nil, nil
+	}
+	patches, err := parseComponentsPatches([]byte(data))
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation on provider %s", clusterctlv1.ComponentsPatchesAnnotation, provider.InstanceName())
+	}
+	return patches, nil
+}
+
+// parseComponentsPatches parses components patches in the format of the patches file.
+func parseComponentsPatches(data []byte) ([]ComponentsPatch, error) {
+	file := &componentsPatchesFile{}
+	if err := yaml.UnmarshalStrict(data, file); err != nil {
+		return nil, errors.Wrap(err, "failed to parse patches")
+	}
+	for i, p := range file.Patches {
+		if _, _, err := p.parse(); err != nil {
+			return nil, errors.Wrapf(err, "invalid patches[%d]", i)
+		}
+	}
+	return file.Patches, nil
+}
+
+// marshalComponentsPatches returns the components patches in the format of the patches file.
+func marshalComponentsPatches(patches []ComponentsPatch) (string, error) {
+	data, err := yaml.Marshal(&componentsPatchesFile{Patches: patches})
+	if err != nil {
+		return "", errors.Wrap(err, "failed to marshal patches")
+	}
+	return string(data), nil
+}
+
+// parse returns the patch in JSON format and the target of the patch.
+func (p ComponentsPatch) parse() ([]byte, *ComponentsPatchTarget, error) {
+	patch, err := yaml.YAMLToJSON([]byte(p.Patch))
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "failed to parse patch")
+	}
+	patch = bytes.TrimSpace(patch)
+	if len(patch) == 0 || bytes.Equal(patch, []byte("null")) {
+		return nil, nil, errors.New("patch must be set")
+	}
+	if p.Target != nil {
+		return patch, p.Target, nil
+	}
+
+	// Select the objects using the object the strategic merge patch is for.
+	obj := &unstructured.Unstructured{}
+	if isJSONPatch(patch) || obj.UnmarshalJSON(patch) != nil || obj.GetKind() == "" || obj.GetName() == "" {
+		return nil, nil, errors.New("target must be set, unless the patch is a strategic merge patch with apiVersion, kind and metadata.name")
+	}
+	gvk := obj.GroupVersionKind()
+	return patch, &ComponentsPatchTarget{
+		Group:     gvk.Group,
+		Version:   gvk.Version,
+		Kind:      gvk.Kind,
+		Name:      obj.GetName(),
+		Namespace: obj.GetNamespace(),
+	}, nil
+}
+
+// matches returns true if obj is selected by the target.
+func (t *ComponentsPatchTarget) matches(obj unstructured.Unstructured) (bool, error) {
+	gvk := obj.GroupVersionKind()
+	if (t.Group != "" && t.Group != gvk.Group) ||
+		(t.Version != "" && t.Version != gvk.Version) ||
+		(t.Kind != "" && t.Kind != gvk.Kind) ||
+		(t.Name != "" && t.Name != obj.GetName()) ||
+		(t.Namespace != "" && t.Namespace != obj.GetNamespace()) {
+		return false, nil
+	}
+	if t.LabelSelector == "" {
+		return true, nil
+	}
+	selector, err := labels.Parse(t.LabelSelector)
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to parse label selector %q", t.LabelSelector)
+	}
+	return selector.Matches(labels.Set(obj.GetLabels())), nil
+}
+
+// applyComponentsPatches applies the patches to the objects selected by their targets, in order.
+// NOTE: Patches not selecting any object are ignored, so the same patches can be used for many providers.
+func applyComponentsPatches(objs []unstructured.Unstructured, patches []ComponentsPatch) ([]unstructured.Unstructured, error) {
+	for i, p := range patches {
+		patch, target, err := p.parse()
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid patches[%d]", i)
+		}
+		for j := range objs {
+			ok, err := target.matches(objs[j])
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid patches[%d]", i)
+			}
+			if !ok {
+				continue
+			}
+			patched, err := applyComponentsPatch(objs[j], patch)
+			if err != nil {
+				return nil, errors.Wrapf(err, "failed to apply patches[%d] to %s %s", i, objs[j].GetKind(), objs[j].GetName())
+			}
+			objs[j] = patched
+		}
+	}
+	return objs, nil
+}
+
+func applyComponentsPatch(obj unstructured.Unstructured, patch []byte) (unstructured.Unstructured, error) {
+	original, err := obj.MarshalJSON()
+	if err != nil {
+		return obj, err
+	}
+
+	var patched []byte
+	if isJSONPatch(patch) {
+		jsonPatch, err := jsonpatch.DecodePatch(patch)
+		if err != nil {
+			return obj, errors.Wrap(err, "failed to decode JSON patch")
+		}
+		patched, err = jsonPatch.Apply(original)
+		if err != nil {
+			return obj, err
+		}
+	} else if dataStruct, err := scheme.Scheme.New(obj.GroupVersionKind()); err == nil {
+		patched, err = strategicpatch.StrategicMergePatch(original, patch, dataStruct)
+		if err != nil {
+			return obj, err
+		}
+	} else {
+		patched, err = jsonpatch.MergePatch(original, patch)
+		if err != nil {
+			return obj, err
+		}
+	}
+
+	result := unstructured.Unstructured{}
+	if err := result.UnmarshalJSON(patched); err != nil {
+		return obj, errors.Wrap(err, "failed to parse the patched object")
+	}
+	if result.GroupVersionKind() != obj.GroupVersionKind() || result.GetName() != obj.GetName() || result.GetNamespace() != obj.GetNamespace() {
+		return obj, errors.New("patch must not change apiVersion, kind, metadata.name or metadata.namespace")
+	}
+	return result, nil
+}
+
+func isJSONPatch(patch []byte) bool {
+	return bytes.HasPrefix(patch, []byte("["))
+}
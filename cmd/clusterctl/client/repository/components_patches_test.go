@@ -0,0 +1,295 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package repository
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	clusterctlv1 "sigs.k8s.io/cluster-api/cmd/clusterctl/api/v1alpha3"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/config"
+)
+
+func Test_applyComponentsPatches(t *testing.T) {
+	deployment := func() unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "apps/v1",
+			"kind":       "Deployment",
+			"metadata": map[string]interface{}{
+				"name":      "controller-manager",
+				"namespace": "ns1",
+				"labels": map[string]interface{}{
+					clusterv1.ProviderNameLabel: "infrastructure-foo",
+				},
+			},
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"containers": []interface{}{
+							map[string]interface{}{"name": "manager", "image": "manager:v1"},
+							map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+						},
+					},
+				},
+			},
+		}}
+	}
+	customResource := func() unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]interface{}{
+			"apiVersion": "foo.example.com/v1",
+			"kind":       "Foo",
+			"metadata": map[string]interface{}{
+				"name":      "foo",
+				"namespace": "ns1",
+			},
+			"spec": map[string]interface{}{
+				"a": "a",
+				"b": "b",
+			},
+		}}
+	}
+	containers := func(obj unstructured.Unstructured) []interface{} {
+		containers, _, _ := unstructured.NestedSlice(obj.Object, "spec", "template", "spec", "containers")
+		return containers
+	}
+
+	t.Run("applies a strategic merge patch to built-in types", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := applyComponentsPatches([]unstructured.Unstructured{deployment()}, []ComponentsPatch{{
+			Target: &ComponentsPatchTarget{Kind: "Deployment"},
+			Patch: `
+spec:
+  template:
+    spec:
+      nodeSelector:
+        node-role.kubernetes.io/control-plane: ""
+      containers:
+      - name: manager
+        env:
+        - name: FOO
+          value: bar`,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(containers(objs[0])).To(ConsistOf(
+			map[string]interface{}{"name": "manager", "image": "manager:v1", "env": []interface{}{map[string]interface{}{"name": "FOO", "value": "bar"}}},
+			map[string]interface{}{"name": "sidecar", "image": "sidecar:v1"},
+		))
+		nodeSelector, _, err := unstructured.NestedStringMap(objs[0].Object, "spec", "template", "spec", "nodeSelector")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(nodeSelector).To(HaveKey("node-role.kubernetes.io/control-plane"))
+	})
+
+	t.Run("applies a JSON merge patch to other types", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := applyComponentsPatches([]unstructured.Unstructured{customResource()}, []ComponentsPatch{{
+			Target: &ComponentsPatchTarget{Group: "foo.example.com", Kind: "Foo"},
+			Patch:  `{"spec": {"a": null, "c": "c"}}`,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs[0].Object["spec"]).To(Equal(map[string]interface{}{"b": "b", "c": "c"}))
+	})
+
+	t.Run("applies a JSON patch to the objects selected by labels", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := applyComponentsPatches([]unstructured.Unstructured{deployment(), customResource()}, []ComponentsPatch{{
+			Target: &ComponentsPatchTarget{LabelSelector: clusterv1.ProviderNameLabel + "=infrastructure-foo"},
+			Patch: `
+- op: add
+  path: /metadata/annotations
+  value:
+    foo: bar`,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs[0].GetAnnotations()).To(Equal(map[string]string{"foo": "bar"}))
+		g.Expect(objs[1].GetAnnotations()).To(BeEmpty())
+	})
+
+	t.Run("selects the objects using the strategic merge patch if the target is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := applyComponentsPatches([]unstructured.Unstructured{deployment(), customResource()}, []ComponentsPatch{{
+			Patch: `
+apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: controller-manager
+spec:
+  replicas: 2`,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs[0].Object["spec"]).To(HaveKeyWithValue("replicas", int64(2)))
+		g.Expect(objs[1]).To(Equal(customResource()))
+	})
+
+	t.Run("ignores patches not selecting any object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		objs, err := applyComponentsPatches([]unstructured.Unstructured{deployment()}, []ComponentsPatch{{
+			Target: &ComponentsPatchTarget{Kind: "Deployment", Namespace: "ns2"},
+			Patch:  `{"spec": {"replicas": 2}}`,
+		}})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(objs[0]).To(Equal(deployment()))
+	})
+
+	t.Run("fails if a patch changes the name of an object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		_, err := applyComponentsPatches([]unstructured.Unstructured{customResource()}, []ComponentsPatch{{
+			Target: &ComponentsPatchTarget{Kind: "Foo"},
+			Patch:  `{"metadata": {"name": "bar"}}`,
+		}})
+		g.Expect(err).To(HaveOccurred())
+	})
+}
+
+func TestReadComponentsPatches(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    []ComponentsPatch
+		wantErr bool
+	}{
+		{
+			name: "valid patches",
+			content: `
+patches:
+- target:
+    kind: Deployment
+    labelSelector: cluster.x-k8s.io/provider=infrastructure-foo
+  patch: |
+    - op: add
+      path: /spec/replicas
+      value: 2
+- patch: |
+    apiVersion: apps/v1
+    kind: Deployment
+    metadata:
+      name: controller-manager
+    spec:
+      replicas: 2
+`,
+			want: []ComponentsPatch{
+				{
+					Target: &ComponentsPatchTarget{Kind: "Deployment", LabelSelector: "cluster.x-k8s.io/provider=infrastructure-foo"},
+					Patch:  "- op: add\n  path: /spec/replicas\n  value: 2\n",
+				},
+				{
+					Patch: "apiVersion: apps/v1\nkind: Deployment\nmetadata:\n  name: controller-manager\nspec:\n  replicas: 2\n",
+				},
+			},
+		},
+		{
+			name: "fails for a JSON patch without target",
+			content: `
+patches:
+- patch: |
+    - op: add
+      path: /spec/replicas
+      value: 2
+`,
+			wantErr: true,
+		},
+		{
+			name: "fails for an empty patch",
+			content: `
+patches:
+- target:
+    kind: Deployment
+`,
+			wantErr: true,
+		},
+		{
+			name: "fails for unknown fields",
+			content: `
+patches:
+- target:
+    kind: Deployment
+  patches: '{}'
+`,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			path := filepath.Join(t.TempDir(), "patches.yaml")
+			g.Expect(os.WriteFile(path, []byte(tt.content), 0600)).To(Succeed())
+
+			got, err := ReadComponentsPatches(path)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestComponentsPatchesFromProvider(t *testing.T) {
+	patches := []ComponentsPatch{
+		{
+			Target: &ComponentsPatchTarget{Kind: "Deployment", LabelSelector: "cluster.x-k8s.io/provider=infrastructure-foo"},
+			Patch:  "- op: add\n  path: /spec/replicas\n  value: 2\n",
+		},
+	}
+
+	t.Run("returns the patches stored in the inventory object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := &components{
+			Provider:        config.NewProvider("foo", "", clusterctlv1.InfrastructureProviderType),
+			version:         "v1.0.0",
+			targetNamespace: "ns1",
+		}
+		var err error
+		c.patches, err = marshalComponentsPatches(patches)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		provider := c.InventoryObject()
+		g.Expect(provider.GetAnnotations()).To(HaveKey(clusterctlv1.ComponentsPatchesAnnotation))
+
+		got, err := ComponentsPatchesFromProvider(provider)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(Equal(patches))
+	})
+	t.Run("returns no patches if the annotation is not set", func(t *testing.T) {
+		g := NewWithT(t)
+
+		got, err := ComponentsPatchesFromProvider(clusterctlv1.Provider{})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).To(BeEmpty())
+	})
+	t.Run("fails for an invalid annotation", func(t *testing.T) {
+		g := NewWithT(t)
+
+		provider := clusterctlv1.Provider{}
+		provider.SetAnnotations(map[string]string{clusterctlv1.ComponentsPatchesAnnotation: "patches:\n- target:\n    kind: Deployment\n"})
+		_, err := ComponentsPatchesFromProvider(provider)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -21,6 +21,7 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
@@ -86,6 +87,20 @@ type RolloutUndoOptions struct {
 	ToRevision int64
 }
 
+// RolloutHistoryOptions carries the options supported by RolloutHistory.
+type RolloutHistoryOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Resource for the rollout command
+	Resource string
+
+	// Namespace where the resource lives. If unspecified, the namespace name will be inferred
+	// from the current configuration.
+	Namespace string
+}
+
 func (c *clusterctlClient) RolloutRestart(ctx context.Context, options RolloutRestartOptions) error {
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -154,6 +169,30 @@ func (c *clusterctlClient) RolloutUndo(ctx context.Context, options RolloutUndoO
 	return nil
 }
 
+func (c *clusterctlClient) RolloutHistory(ctx context.Context, options RolloutHistoryOptions) ([]RolloutRevision, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+	if options.Resource == "" {
+		return nil, errors.New("required resource not specified")
+	}
+	objRefs, err := getObjectRefs(clusterClient, options.Namespace, []string{options.Resource})
+	if err != nil {
+		return nil, err
+	}
+	history, err := c.alphaClient.Rollout().ObjectHistoryViewer(ctx, clusterClient.Proxy(), objRefs[0])
+	if err != nil {
+		return nil, err
+	}
+
+	revisions := make([]RolloutRevision, 0, len(history))
+	for _, revision := range history {
+		revisions = append(revisions, RolloutRevision(revision))
+	}
+	return revisions, nil
+}
+
 func getObjectRefs(clusterClient cluster.Client, namespace string, resources []string) ([]corev1.ObjectReference, error) {
 	// If the option specifying the Namespace is empty, try to detect it.
 	if namespace == "" {
@@ -62,3 +62,43 @@ func (c *clusterctlClient) TopologyPlan(ctx context.Context, options TopologyPla
 
 	return out, err
 }
+
+// TopologyRebaseOptions define options for TopologyRebase.
+type TopologyRebaseOptions struct {
+	// Kubeconfig defines the kubeconfig to use for accessing the management cluster. If empty,
+	// default rules for kubeconfig discovery will be used.
+	Kubeconfig Kubeconfig
+
+	// Objs is an optional list of objects to be considered together with the objects in the management cluster,
+	// e.g. a new revision of a ClusterClass and its templates that are not yet applied.
+	Objs []*unstructured.Unstructured
+
+	// Cluster is the name of the Cluster to rebase.
+	Cluster string
+
+	// ClusterClass is the name of the ClusterClass to rebase the Cluster to.
+	ClusterClass string
+
+	// Namespace is the namespace of the Cluster. If unspecified, the current namespace will be used.
+	Namespace string
+}
+
+// TopologyRebaseOutput defines the output of the topology rebase operation.
+type TopologyRebaseOutput = cluster.TopologyRebaseOutput
+
+// TopologyRebase checks if a Cluster can be rebased to another ClusterClass and performs a dry run execution
+// of the topology reconciler on the rebased Cluster.
+// It returns the result of the compatibility check and the impact of the rebase on the Machines of the Cluster.
+func (c *clusterctlClient) TopologyRebase(ctx context.Context, options TopologyRebaseOptions) (*TopologyRebaseOutput, error) {
+	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
+	if err != nil {
+		return nil, err
+	}
+
+	return clusterClient.Topology().Rebase(ctx, &cluster.TopologyRebaseInput{
+		Objs:             options.Objs,
+		ClusterName:      options.Cluster,
+		ClusterClassName: options.ClusterClass,
+		TargetNamespace:  options.Namespace,
+	})
+}
@@ -30,6 +30,7 @@ import (
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/contract"
 )
 
 // DiscoverOptions define options for the discovery process.
@@ -125,7 +126,12 @@ func Discovery(ctx context.Context, c client.Client, namespace, name string, opt
 		}
 	}
 
-	controlPlaneMachines := selectControlPlaneMachines(machinesList)
+	// NOTE: Externally managed control planes (e.g. AKS, EKS, GKE) do not have control plane Machines, so
+	// all the Machines in the Cluster are considered as workers or orphans.
+	var controlPlaneMachines []*clusterv1.Machine
+	if controlPlane == nil || !isExternalManagedControlPlane(controlPlane) {
+		controlPlaneMachines = selectControlPlaneMachines(machinesList)
+	}
 	if controlPlane != nil {
 		for i := range controlPlaneMachines {
 			cp := controlPlaneMachines[i]
@@ -375,6 +381,11 @@ func getMachinePoolsInCluster(ctx context.Context, c client.Client, namespace, n
 	return machinePoolList, nil
 }
 
+func isExternalManagedControlPlane(controlPlane *unstructured.Unstructured) bool {
+	externalManaged, err := contract.IsExternalManagedControlPlane(controlPlane)
+	return err == nil && externalManaged
+}
+
 func selectControlPlaneMachines(machineList *clusterv1.MachineList) []*clusterv1.Machine {
 	machines := []*clusterv1.Machine{}
 	for i := range machineList.Items {
@@ -127,6 +127,10 @@ type ApplyUpgradeOptions struct {
 
 	// WaitProviderTimeout sets the timeout per provider upgrade.
 	WaitProviderTimeout time.Duration
+
+	// WebhookCertificates defines how the certificates of the provider webhooks are managed, see InitOptions.WebhookCertificates;
+	// if empty, the value used when installing each provider is used.
+	WebhookCertificates string
 }
 
 func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgradeOptions) error {
@@ -140,6 +144,10 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 		options.WaitProviderTimeout = time.Duration(5*60) * time.Second
 	}
 
+	if err := validateWebhookCertificates(options.WebhookCertificates); err != nil {
+		return err
+	}
+
 	// Get the client for interacting with the management cluster.
 	clusterClient, err := c.clusterClientFactory(ClusterClientFactoryInput{Kubeconfig: options.Kubeconfig})
 	if err != nil {
@@ -163,10 +171,16 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 	// NOTE: it is safe to upgrade to latest version of cert-manager given that it provides
 	// conversion web-hooks around Issuer/Certificate kinds, so installing an older versions of providers
 	// should continue to work with the latest cert-manager.
-	certManager := clusterClient.CertManager()
-	if err := certManager.EnsureLatestVersion(ctx); err != nil {
+	useCertManager, err := upgradeUsesCertManager(ctx, clusterClient, options.WebhookCertificates)
+	if err != nil {
 		return err
 	}
+	if useCertManager {
+		certManager := clusterClient.CertManager()
+		if err := certManager.EnsureLatestVersion(ctx); err != nil {
+			return err
+		}
+	}
 
 	// Check if the user want a custom upgrade
 	isCustomUpgrade := options.CoreProvider != "" ||
@@ -180,6 +194,7 @@ func (c *clusterctlClient) ApplyUpgrade(ctx context.Context, options ApplyUpgrad
 	opts := cluster.UpgradeOptions{
 		WaitProviders:       options.WaitProviders,
 		WaitProviderTimeout: options.WaitProviderTimeout,
+		WebhookCertificates: cluster.WebhookCertificatesMode(options.WebhookCertificates),
 	}
 
 	// If we are upgrading a specific set of providers only, process the providers and call ApplyCustomPlan.
@@ -314,3 +329,22 @@ func parseUpgradeItemWithoutNamespace(ctx context.Context, clusterClient cluster
 		NextVersion: version,
 	}, nil
 }
+
+// upgradeUsesCertManager returns true if the webhook certificates mode requires cert-manager; if the mode is not set,
+// it returns true if at least one of the installed providers uses cert-manager.
+func upgradeUsesCertManager(ctx context.Context, clusterClient cluster.Client, mode string) (bool, error) {
+	if mode != "" {
+		return usesCertManager(mode), nil
+	}
+	providerList, err := clusterClient.ProviderInventory().List(ctx)
+	if err != nil {
+		return false, err
+	}
+	for _, provider := range providerList.Items {
+		if cluster.WebhookCertificatesModeFromProvider(provider) == cluster.WebhookCertificatesCertManager {
+			return true, nil
+		}
+	}
+	// NOTE: A management cluster without providers is upgraded as usual, installing cert-manager.
+	return len(providerList.Items) == 0, nil
+}
@@ -187,8 +187,8 @@ func Test_clusterctlClient_ApplyUpgrade(t *testing.T) {
 				},
 				ListMeta: metav1.ListMeta{},
 				Items: []clusterctlv1.Provider{ // both providers should be upgraded
-					fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
-					fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
+					upgradedFakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
+					upgradedFakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
 				},
 			},
 			wantErr: false,
@@ -215,7 +215,7 @@ func Test_clusterctlClient_ApplyUpgrade(t *testing.T) {
 				},
 				ListMeta: metav1.ListMeta{},
 				Items: []clusterctlv1.Provider{ // only one provider should be upgraded
-					fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
+					upgradedFakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
 					fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.0", "infra-system"),
 				},
 			},
@@ -244,7 +244,7 @@ func Test_clusterctlClient_ApplyUpgrade(t *testing.T) {
 				ListMeta: metav1.ListMeta{},
 				Items: []clusterctlv1.Provider{ // only one provider should be upgraded
 					fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.0", "cluster-api-system"),
-					fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
+					upgradedFakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
 				},
 			},
 			wantErr: false,
@@ -271,8 +271,8 @@ func Test_clusterctlClient_ApplyUpgrade(t *testing.T) {
 				},
 				ListMeta: metav1.ListMeta{},
 				Items: []clusterctlv1.Provider{
-					fakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
-					fakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
+					upgradedFakeProvider("cluster-api", clusterctlv1.CoreProviderType, "v1.0.1", "cluster-api-system"),
+					upgradedFakeProvider("infra", clusterctlv1.InfrastructureProviderType, "v2.0.1", "infra-system"),
 				},
 			},
 			wantErr: false,
@@ -361,6 +361,16 @@ func fakeClientForUpgrade() *fakeClient {
 	return client
 }
 
+// upgradedFakeProvider returns the inventory entry of a provider upgraded by clusterctl, which records
+// the checksum of the provider Deployments; the fake providers used in tests do not have Deployments.
+func upgradedFakeProvider(name string, providerType clusterctlv1.ProviderType, version, targetNamespace string) clusterctlv1.Provider {
+	p := fakeProvider(name, providerType, version, targetNamespace)
+	p.ComponentsChecksum = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855" // sha256 of no Deployments.
+	// The webhook certificates mode is stored on upgrade, defaulting to cert-manager for providers installed without storing it.
+	p.SetAnnotations(map[string]string{clusterctlv1.WebhookCertificatesAnnotation: "cert-manager"})
+	return p
+}
+
 func fakeProvider(name string, providerType clusterctlv1.ProviderType, version, targetNamespace string) clusterctlv1.Provider {
 	return clusterctlv1.Provider{
 		TypeMeta: metav1.TypeMeta{
@@ -29,8 +29,10 @@ var alphaCmd = &cobra.Command{
 
 func init() {
 	// Alpha commands should be added here.
+	alphaCmd.AddCommand(graphCmd)
 	alphaCmd.AddCommand(rolloutCmd)
 	alphaCmd.AddCommand(topologyCmd)
+	alphaCmd.AddCommand(watchCmd)
 
 	RootCmd.AddCommand(alphaCmd)
 }
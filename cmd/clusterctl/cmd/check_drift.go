@@ -0,0 +1,127 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type checkDriftOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+}
+
+var cd = &checkDriftOptions{}
+
+var checkDriftCmd = &cobra.Command{
+	Use:   "drift",
+	Short: "Report the providers whose components have been changed after clusterctl applied them",
+	Long: LongDesc(`
+		The check drift command reports the providers whose components, e.g. Deployments or RBAC rules,
+		have been changed after clusterctl applied them during init or upgrade, e.g. because they have
+		been edited by hand.
+
+		Such changes are lost when the providers are upgraded with clusterctl upgrade apply, so
+		it is recommended to run this command before upgrading.`),
+
+	Example: Examples(`
+		# Reports the providers whose components have been changed after clusterctl applied them.
+		clusterctl check drift`),
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCheckDrift()
+	},
+}
+
+func init() {
+	checkDriftCmd.Flags().StringVar(&cd.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If empty, default discovery rules apply.")
+	checkDriftCmd.Flags().StringVar(&cd.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+}
+
+func runCheckDrift() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	drifts, err := c.CheckDrift(ctx, client.CheckDriftOptions{
+		Kubeconfig: client.Kubeconfig{Path: cd.kubeconfig, Context: cd.kubeconfigContext},
+	})
+	if err != nil {
+		return err
+	}
+
+	if len(drifts) == 0 {
+		fmt.Println("There are no providers in the cluster. Please use clusterctl init to initialize a Cluster API management cluster.")
+		return nil
+	}
+
+	// ensure providers are sorted consistently (by Type, Name, Namespace).
+	sort.Slice(drifts, func(i, j int) bool {
+		a, b := drifts[i].Provider, drifts[j].Provider
+		if a.Type != b.Type {
+			return a.Type < b.Type
+		}
+		if a.Name != b.Name {
+			return a.Name < b.Name
+		}
+		return a.Namespace < b.Namespace
+	})
+
+	drifted := false
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "NAME\tNAMESPACE\tTYPE\tVERSION\tDRIFT")
+	for _, drift := range drifts {
+		fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", drift.Provider.Name, drift.Provider.Namespace, drift.Provider.Type, drift.Provider.Version, prettifyDrift(drift))
+		if drift.Drifted {
+			drifted = true
+		}
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	if drifted {
+		fmt.Println("")
+		fmt.Println("The components of some providers have been changed after clusterctl applied them; those changes will be lost with clusterctl upgrade apply.")
+	}
+	return nil
+}
+
+func prettifyDrift(drift client.ProviderDrift) string {
+	switch {
+	case drift.Unknown:
+		return "Unknown (no checksum recorded)"
+	case drift.Drifted:
+		return "Drifted"
+	default:
+		return "None"
+	}
+}
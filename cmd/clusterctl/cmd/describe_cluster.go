@@ -18,7 +18,9 @@ package cmd
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"io"
 	"os"
 	"sort"
 	"strings"
@@ -30,8 +32,10 @@ import (
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/duration"
 	ctrlclient "sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
@@ -66,6 +70,7 @@ type describeClusterOptions struct {
 	grouping                bool
 	disableGrouping         bool
 	color                   bool
+	output                  string
 }
 
 var dc = &describeClusterOptions{}
@@ -94,7 +99,10 @@ var describeClusterClusterCmd = &cobra.Command{
 
 		# Describe the cluster named test-1 showing the MachineInfrastructure and BootstrapConfig objects
 		# also when their status is the same as the status of the corresponding machine object.
-		clusterctl describe cluster test-1 --echo`),
+		clusterctl describe cluster test-1 --echo
+
+		# Describe the cluster named test-1 in json format, e.g. to be consumed by CI systems or UIs.
+		clusterctl describe cluster test-1 -o json`),
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -133,6 +141,8 @@ func init() {
 	_ = describeClusterClusterCmd.Flags().MarkDeprecated("disable-grouping",
 		"use --grouping instead.")
 	describeClusterClusterCmd.Flags().BoolVarP(&dc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+	describeClusterClusterCmd.Flags().StringVarP(&dc.output, "output", "o", "",
+		"Output format; available options are 'yaml' and 'json'. If unspecified, the cluster is described as a tree view.")
 
 	// completions
 	describeClusterClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
@@ -149,6 +159,12 @@ func init() {
 func runDescribeCluster(cmd *cobra.Command, name string) error {
 	ctx := context.Background()
 
+	switch dc.output {
+	case "", "yaml", "json":
+	default:
+		return errors.Errorf("invalid output format: %s", dc.output)
+	}
+
 	c, err := client.New(ctx, cfgFile)
 	if err != nil {
 		return err
@@ -170,18 +186,85 @@ func runDescribeCluster(cmd *cobra.Command, name string) error {
 		return err
 	}
 
-	if cmd.Flags().Changed("color") {
-		color.NoColor = !dc.color
+	switch dc.output {
+	case "yaml":
+		y, err := yaml.Marshal(newObjectTreeNode(tree, tree.GetRoot()))
+		if err != nil {
+			return err
+		}
+		fmt.Print(string(y))
+	case "json":
+		y, err := json.MarshalIndent(newObjectTreeNode(tree, tree.GetRoot()), "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(y))
+	default:
+		if cmd.Flags().Changed("color") {
+			color.NoColor = !dc.color
+		}
+		printObjectTree(os.Stdout, tree)
+	}
+	return nil
+}
+
+// objectTreeNode is the machine-readable representation of a node of the object tree; it includes
+// the same objects, conditions and groupings shown by the tree view.
+type objectTreeNode struct {
+	// Kind, Namespace and Name identify the object; for virtual objects, e.g. Workers, they do not
+	// identify any real object.
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	// MetaName is the name used for the object in the tree view, e.g. ControlPlane for the KubeadmControlPlane.
+	MetaName string `json:"metaName,omitempty"`
+	// Virtual is true if the object does not correspond to any real object.
+	Virtual bool `json:"virtual,omitempty"`
+	// GroupItems is the list of names of the objects represented by a group object, e.g. a group of machines.
+	GroupItems []string `json:"groupItems,omitempty"`
+	// DeletionTimestamp is set if the object is being deleted.
+	DeletionTimestamp *metav1.Time `json:"deletionTimestamp,omitempty"`
+	// Ready is the ready condition of the object, if any.
+	Ready *clusterv1.Condition `json:"ready,omitempty"`
+	// Conditions are the other conditions of the object; they are included only for the objects
+	// for which all the conditions are requested with --show-conditions.
+	Conditions []clusterv1.Condition `json:"conditions,omitempty"`
+	// Children are the object's children, in the same order of the tree view.
+	Children []objectTreeNode `json:"children,omitempty"`
+}
+
+// newObjectTreeNode returns the objectTreeNode for the given object, and recursively for all the object's children.
+func newObjectTreeNode(objectTree *tree.ObjectTree, obj ctrlclient.Object) objectTreeNode {
+	node := objectTreeNode{
+		Kind:              obj.GetObjectKind().GroupVersionKind().Kind,
+		Namespace:         obj.GetNamespace(),
+		Name:              obj.GetName(),
+		MetaName:          tree.GetMetaName(obj),
+		Virtual:           tree.IsVirtualObject(obj),
+		DeletionTimestamp: obj.GetDeletionTimestamp(),
+		Ready:             tree.GetReadyCondition(obj),
 	}
 
-	printObjectTree(tree)
-	return nil
+	if tree.IsGroupObject(obj) {
+		node.GroupItems = strings.Split(tree.GetGroupItems(obj), tree.GroupItemsSeparator)
+	}
+
+	if tree.IsShowConditionsObject(obj) {
+		for _, c := range tree.GetOtherConditions(obj) {
+			node.Conditions = append(node.Conditions, *c)
+		}
+	}
+
+	for _, child := range getSortedChildren(objectTree, obj) {
+		node.Children = append(node.Children, newObjectTreeNode(objectTree, child))
+	}
+	return node
 }
 
-// printObjectTree prints the cluster status to stdout.
-func printObjectTree(tree *tree.ObjectTree) {
+// printObjectTree prints the cluster status to the given writer.
+func printObjectTree(w io.Writer, tree *tree.ObjectTree) {
 	// Creates the output table
-	tbl := tablewriter.NewWriter(os.Stdout)
+	tbl := tablewriter.NewWriter(w)
 	tbl.SetHeader([]string{"NAME", "READY", "SEVERITY", "REASON", "SINCE", "MESSAGE"})
 
 	formatTableTree(tbl)
@@ -248,6 +331,14 @@ func addObjectRow(prefix string, tbl *tablewriter.Table, objectTree *tree.Object
 	}
 
 	// Add a row for each object's children, taking care of updating the tree view prefix.
+	childrenObj := getSortedChildren(objectTree, obj)
+	for i, child := range childrenObj {
+		addObjectRow(getChildPrefix(prefix, i, len(childrenObj)), tbl, objectTree, child)
+	}
+}
+
+// getSortedChildren returns the object's children in the order they should be printed.
+func getSortedChildren(objectTree *tree.ObjectTree, obj ctrlclient.Object) []ctrlclient.Object {
 	childrenObj := objectTree.GetObjectsByParent(obj.GetUID())
 
 	// printBefore returns true if children[i] should be printed before children[j]. Objects are sorted by z-order and
@@ -261,10 +352,7 @@ func addObjectRow(prefix string, tbl *tablewriter.Table, objectTree *tree.Object
 		return tree.GetZOrder(childrenObj[i]) > tree.GetZOrder(childrenObj[j])
 	}
 	sort.Slice(childrenObj, printBefore)
-
-	for i, child := range childrenObj {
-		addObjectRow(getChildPrefix(prefix, i, len(childrenObj)), tbl, objectTree, child)
-	}
+	return childrenObj
 }
 
 // addOtherConditions adds a row for each object condition except the ready condition,
@@ -294,6 +294,61 @@ func Test_TreePrefix(t *testing.T) {
 	}
 }
 
+func Test_newObjectTreeNode(t *testing.T) {
+	g := NewWithT(t)
+
+	root := fakeObject("root", withCondition(conditions.TrueCondition(clusterv1.ReadyCondition)))
+	objectTree := tree.NewObjectTree(root, tree.ObjectTreeOptions{})
+
+	o1 := fakeObject("child1",
+		withAnnotation(tree.ShowObjectConditionsAnnotation, "True"),
+		withCondition(conditions.FalseCondition(clusterv1.ReadyCondition, "Reason", clusterv1.ConditionSeverityWarning, "message")),
+		withCondition(conditions.TrueCondition("C1.1")),
+	)
+	o1_1 := fakeObject("child1.1", withDeletionTimestamp)
+	o2 := fakeObject("child2", withAnnotation(tree.ObjectMetaNameAnnotation, "MetaName"))
+	o3 := fakeObject("child3",
+		withAnnotation(tree.VirtualObjectAnnotation, "True"),
+		withAnnotation(tree.GroupObjectAnnotation, "True"),
+		withAnnotation(tree.GroupItemsAnnotation, "m1, m2"),
+		withCondition(conditions.TrueCondition("C3.1")),
+	)
+	objectTree.Add(root, o1)
+	objectTree.Add(o1, o1_1)
+	objectTree.Add(root, o2, tree.ZOrder(1))
+	objectTree.Add(root, o3)
+
+	node := newObjectTreeNode(objectTree, objectTree.GetRoot())
+
+	g.Expect(node.Kind).To(Equal("Object"))
+	g.Expect(node.Namespace).To(Equal("ns"))
+	g.Expect(node.Name).To(Equal("root"))
+	g.Expect(node.Ready).ToNot(BeNil())
+	g.Expect(node.Ready.Status).To(BeEquivalentTo("True"))
+	g.Expect(node.Conditions).To(BeEmpty())
+
+	// Children are in the same order of the tree view, with higher z-order first and then by row name.
+	g.Expect(node.Children).To(HaveLen(3))
+	g.Expect(node.Children[0].Name).To(Equal("child2"))
+	g.Expect(node.Children[0].MetaName).To(Equal("MetaName"))
+	g.Expect(node.Children[0].Ready).To(BeNil())
+
+	// Other conditions are included only for the objects with the show conditions annotation.
+	g.Expect(node.Children[1].Name).To(Equal("child3"))
+	g.Expect(node.Children[1].Virtual).To(BeTrue())
+	g.Expect(node.Children[1].GroupItems).To(Equal([]string{"m1", "m2"}))
+	g.Expect(node.Children[1].Conditions).To(BeEmpty())
+
+	g.Expect(node.Children[2].Name).To(Equal("child1"))
+	g.Expect(node.Children[2].Ready.Reason).To(Equal("Reason"))
+	g.Expect(node.Children[2].Ready.Message).To(Equal("message"))
+	g.Expect(node.Children[2].Conditions).To(HaveLen(1))
+	g.Expect(node.Children[2].Conditions[0].Type).To(BeEquivalentTo("C1.1"))
+	g.Expect(node.Children[2].Children).To(HaveLen(1))
+	g.Expect(node.Children[2].Children[0].Name).To(Equal("child1.1"))
+	g.Expect(node.Children[2].Children[0].DeletionTimestamp).ToNot(BeNil())
+}
+
 type objectOption func(object ctrlclient.Object)
 
 func fakeObject(name string, options ...objectOption) ctrlclient.Object {
@@ -35,6 +35,7 @@ type generateProvidersOptions struct {
 	runtimeExtensionProvider string
 	addonProvider            string
 	targetNamespace          string
+	patches                  string
 	textOutput               bool
 	raw                      bool
 	outputFile               string
@@ -72,7 +73,10 @@ var generateProviderCmd = &cobra.Command{
 
 		# Generates a yaml file for creating provider for a specific version.
 		# No variables will be processed and substituted using this flag
-		clusterctl generate provider --infrastructure aws:v0.4.1 --raw`),
+		clusterctl generate provider --infrastructure aws:v0.4.1 --raw
+
+		# Generates a yaml file for creating provider customizing the components with the patches in a file.
+		clusterctl generate provider --infrastructure aws --patches patches.yaml`),
 
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runGenerateProviderComponents()
@@ -96,6 +100,8 @@ func init() {
 		"Add-on provider and version (e.g. helm:v0.1.0)")
 	generateProviderCmd.Flags().StringVarP(&gpo.targetNamespace, "target-namespace", "n", "",
 		"The target namespace where the provider should be deployed. If unspecified, the components default namespace is used.")
+	generateProviderCmd.Flags().StringVar(&gpo.patches, "patches", "",
+		"Path to a file with patches to customize the provider components, e.g. the resources, node selectors or environment variables of the provider Deployments.")
 	generateProviderCmd.Flags().BoolVar(&gpo.textOutput, "describe", false,
 		"Generate configuration without variable substitution.")
 	generateProviderCmd.Flags().BoolVar(&gpo.raw, "raw", false,
@@ -122,6 +128,12 @@ func runGenerateProviderComponents() error {
 		TargetNamespace:     gpo.targetNamespace,
 		SkipTemplateProcess: gpo.raw || gpo.textOutput,
 	}
+	if gpo.patches != "" {
+		options.Patches, err = client.ReadComponentsPatches(gpo.patches)
+		if err != nil {
+			return err
+		}
+	}
 
 	components, err := c.GenerateProvider(ctx, providerName, providerType, options)
 	if err != nil {
@@ -31,6 +31,16 @@ type getKubeconfigOptions struct {
 	kubeconfig        string
 	kubeconfigContext string
 	namespace         string
+
+	execCommand    string
+	execArgs       []string
+	execEnv        map[string]string
+	execAPIVersion string
+
+	oidcIssuerURL    string
+	oidcClientID     string
+	oidcClientSecret string
+	oidcExtraScopes  []string
 }
 
 var gk = &getKubeconfigOptions{}
@@ -46,7 +56,14 @@ var getKubeconfigCmd = &cobra.Command{
 		clusterctl get kubeconfig <name of workload cluster>
 
 		# Get the workload cluster's kubeconfig in a particular namespace.
-		clusterctl get kubeconfig <name of workload cluster> --namespace foo`),
+		clusterctl get kubeconfig <name of workload cluster> --namespace foo
+
+		# Get the workload cluster's kubeconfig using an exec credential plugin instead of the embedded client certificate.
+		clusterctl get kubeconfig <name of workload cluster> --exec-command kubectl --exec-arg oidc-login --exec-arg get-token
+
+		# Get the workload cluster's kubeconfig using OIDC tokens instead of the embedded client certificate;
+		# the tokens are retrieved with the kubelogin kubectl plugin, i.e. kubectl oidc-login get-token.
+		clusterctl get kubeconfig <name of workload cluster> --oidc-issuer-url https://issuer.example.com --oidc-client-id foo`),
 
 	Args: func(cmd *cobra.Command, args []string) error {
 		if len(args) != 1 {
@@ -67,6 +84,27 @@ func init() {
 	getKubeconfigCmd.Flags().StringVar(&gk.kubeconfigContext, "kubeconfig-context", "",
 		"Context to be used within the kubeconfig file. If empty, current context will be used.")
 
+	getKubeconfigCmd.Flags().StringVar(&gk.execCommand, "exec-command", "",
+		"Command of the exec credential plugin to be used in the workload cluster kubeconfig instead of the embedded client certificate.")
+	getKubeconfigCmd.Flags().StringArrayVar(&gk.execArgs, "exec-arg", nil,
+		"Argument to pass to the exec credential plugin. Can be repeated.")
+	getKubeconfigCmd.Flags().StringToStringVar(&gk.execEnv, "exec-env", nil,
+		"Environment variables to expose to the exec credential plugin, e.g. FOO=bar.")
+	getKubeconfigCmd.Flags().StringVar(&gk.execAPIVersion, "exec-api-version", "client.authentication.k8s.io/v1",
+		"Preferred input version of the ExecCredential of the exec credential plugin.")
+
+	getKubeconfigCmd.Flags().StringVar(&gk.oidcIssuerURL, "oidc-issuer-url", "",
+		"URL of the OIDC issuer to get tokens from with the kubelogin kubectl plugin, to be used in the workload cluster kubeconfig instead of the embedded client certificate.")
+	getKubeconfigCmd.Flags().StringVar(&gk.oidcClientID, "oidc-client-id", "",
+		"OIDC client ID. Required if --oidc-issuer-url is set.")
+	getKubeconfigCmd.Flags().StringVar(&gk.oidcClientSecret, "oidc-client-secret", "",
+		"OIDC client secret.")
+	getKubeconfigCmd.Flags().StringSliceVar(&gk.oidcExtraScopes, "oidc-extra-scope", nil,
+		"Additional scopes to request to the OIDC issuer. Can be repeated.")
+
+	getKubeconfigCmd.MarkFlagsMutuallyExclusive("exec-command", "oidc-issuer-url")
+	getKubeconfigCmd.MarkFlagsRequiredTogether("oidc-issuer-url", "oidc-client-id")
+
 	// completions
 	getKubeconfigCmd.ValidArgsFunction = resourceNameCompletionFunc(
 		getKubeconfigCmd.Flags().Lookup("kubeconfig"),
@@ -93,6 +131,24 @@ func runGetKubeconfig(workloadClusterName string) error {
 		Namespace:           gk.namespace,
 	}
 
+	if gk.execCommand != "" {
+		options.Exec = &client.KubeconfigExecOptions{
+			APIVersion: gk.execAPIVersion,
+			Command:    gk.execCommand,
+			Args:       gk.execArgs,
+			Env:        gk.execEnv,
+		}
+	}
+
+	if gk.oidcIssuerURL != "" {
+		options.OIDC = &client.KubeconfigOIDCOptions{
+			IssuerURL:    gk.oidcIssuerURL,
+			ClientID:     gk.oidcClientID,
+			ClientSecret: gk.oidcClientSecret,
+			ExtraScopes:  gk.oidcExtraScopes,
+		}
+	}
+
 	out, err := c.GetKubeconfig(ctx, options)
 	if err != nil {
 		return err
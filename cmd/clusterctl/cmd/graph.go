@@ -0,0 +1,164 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+type graphOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	clusterName       string
+	output            string
+}
+
+var gro = &graphOptions{}
+
+var graphCmd = &cobra.Command{
+	Use:   "graph",
+	Short: "Print the graph of the Cluster API objects considered by move",
+	Long: LongDesc(`
+		Print the graph of the Cluster API objects and their ownership relations, as discovered by clusterctl move.
+
+		The graph can be used to understand which objects clusterctl move would touch and to debug ownership problems.
+		Solid edges represent OwnerReferences, dashed edges represent soft ownership relations (e.g. Secrets linked
+		to a Cluster by a naming convention); objects that are not going to be moved are greyed out.`),
+
+	Example: Examples(`
+		# Print the graph of all the objects in the current namespace in DOT format.
+		clusterctl alpha graph
+
+		# Render the graph of the objects belonging to a Cluster as an image using Graphviz.
+		clusterctl alpha graph --cluster my-cluster | dot -Tsvg > my-cluster.svg
+
+		# Print the graph of the objects in a namespace in JSON format.
+		clusterctl alpha graph -n my-namespace -o json`),
+
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runGraph()
+	},
+}
+
+func init() {
+	graphCmd.Flags().StringVar(&gro.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the management cluster. If unspecified, default discovery rules apply.")
+	graphCmd.Flags().StringVar(&gro.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	graphCmd.Flags().StringVarP(&gro.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are hosted. If unspecified, the current context's namespace is used.")
+	graphCmd.Flags().StringVar(&gro.clusterName, "cluster", "",
+		"The name of the workload cluster to limit the graph to. If unspecified, all the objects in the namespace are included.")
+	graphCmd.Flags().StringVarP(&gro.output, "output", "o", "dot",
+		"Output format; available options are 'dot' and 'json'")
+}
+
+func runGraph() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	graph, err := c.Graph(ctx, client.GraphOptions{
+		Kubeconfig:  client.Kubeconfig{Path: gro.kubeconfig, Context: gro.kubeconfigContext},
+		Namespace:   gro.namespace,
+		ClusterName: gro.clusterName,
+	})
+	if err != nil {
+		return err
+	}
+
+	switch gro.output {
+	case "dot":
+		return printGraphDOT(os.Stdout, graph)
+	case "json":
+		out, err := json.MarshalIndent(graph, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "failed to marshal graph to JSON")
+		}
+		fmt.Println(string(out))
+		return nil
+	default:
+		return errors.Errorf("invalid output format: %s", gro.output)
+	}
+}
+
+// printGraphDOT prints the graph in the Graphviz DOT language.
+func printGraphDOT(w io.Writer, graph *client.MoveGraph) error {
+	inGraph := sets.Set[types.UID]{}
+	for _, n := range graph.Nodes {
+		inGraph.Insert(n.Object.UID)
+	}
+
+	b := &strings.Builder{}
+	b.WriteString("digraph clusterctl {\n")
+	b.WriteString("  rankdir=LR;\n")
+	b.WriteString("  node [shape=box];\n")
+
+	for _, n := range graph.Nodes {
+		label := fmt.Sprintf("%s\n%s", n.Object.Kind, n.Object.Name)
+		if n.Object.Namespace != "" {
+			label = fmt.Sprintf("%s\n%s/%s", n.Object.Kind, n.Object.Namespace, n.Object.Name)
+		}
+
+		attributes := []string{fmt.Sprintf("label=%q", label)}
+		switch {
+		case n.Virtual:
+			attributes = append(attributes, "style=dotted")
+		case !n.Move:
+			attributes = append(attributes, "color=gray", "fontcolor=gray")
+		}
+		if n.BlockingMove {
+			attributes = append(attributes, "color=red")
+		}
+		fmt.Fprintf(b, "  %q [%s];\n", n.Object.UID, strings.Join(attributes, ", "))
+	}
+
+	// NOTE: edges from owners not included in the graph, e.g. when the graph is limited to a single Cluster, are skipped.
+	for _, n := range graph.Nodes {
+		for _, owner := range n.Owners {
+			if inGraph.Has(owner) {
+				fmt.Fprintf(b, "  %q -> %q;\n", owner, n.Object.UID)
+			}
+		}
+		for _, owner := range n.SoftOwners {
+			if inGraph.Has(owner) {
+				fmt.Fprintf(b, "  %q -> %q [style=dashed];\n", owner, n.Object.UID)
+			}
+		}
+	}
+	b.WriteString("}\n")
+
+	_, err := io.WriteString(w, b.String())
+	return err
+}
@@ -0,0 +1,63 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+func Test_printGraphDOT(t *testing.T) {
+	g := NewWithT(t)
+
+	graph := &client.MoveGraph{
+		Nodes: []client.MoveGraphNode{
+			{
+				Object: corev1.ObjectReference{Kind: "Cluster", Namespace: "ns1", Name: "cluster1", UID: "cluster1"},
+				Move:   true,
+			},
+			{
+				Object: corev1.ObjectReference{Kind: "Machine", Namespace: "ns1", Name: "m1", UID: "m1"},
+				Owners: []types.UID{"cluster1", "not-in-graph"},
+				Move:   true,
+			},
+			{
+				Object:     corev1.ObjectReference{Kind: "Secret", Namespace: "ns1", Name: "cluster1-kubeconfig", UID: "secret1"},
+				SoftOwners: []types.UID{"cluster1"},
+			},
+		},
+	}
+
+	var b bytes.Buffer
+	g.Expect(printGraphDOT(&b, graph)).To(Succeed())
+	g.Expect(b.String()).To(Equal(`digraph clusterctl {
+  rankdir=LR;
+  node [shape=box];
+  "cluster1" [label="Cluster\nns1/cluster1"];
+  "m1" [label="Machine\nns1/m1"];
+  "secret1" [label="Secret\nns1/cluster1-kubeconfig", color=gray, fontcolor=gray];
+  "cluster1" -> "m1";
+  "cluster1" -> "secret1" [style=dashed];
+}
+`))
+}
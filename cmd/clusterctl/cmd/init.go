@@ -36,9 +36,12 @@ type initOptions struct {
 	runtimeExtensionProviders []string
 	addonProviders            []string
 	targetNamespace           string
+	patches                   string
 	validate                  bool
 	waitProviders             bool
 	waitProviderTimeout       int
+	rollbackOnFailure         bool
+	webhookCertificates       string
 }
 
 var initOpts = &initOptions{}
@@ -81,7 +84,16 @@ var initCmd = &cobra.Command{
 		clusterctl init --infrastructure=aws,vsphere
 
 		# Initialize a management cluster with a custom target namespace for the provider resources.
-		clusterctl init --infrastructure aws --target-namespace foo`),
+		clusterctl init --infrastructure aws --target-namespace foo
+
+		# Initialize a management cluster customizing the provider components with the patches in a file.
+		clusterctl init --infrastructure aws --patches patches.yaml
+
+		# Initialize a management cluster, waiting for the providers to be available and removing them if they are not.
+		clusterctl init --infrastructure aws --wait-providers --rollback-on-failure
+
+		# Initialize a management cluster without cert-manager, using webhook certificates generated by clusterctl.
+		clusterctl init --infrastructure aws --webhook-certificates self-signed`),
 	Args: cobra.NoArgs,
 	RunE: func(cmd *cobra.Command, args []string) error {
 		return runInit()
@@ -107,12 +119,19 @@ func init() {
 		"Runtime extension providers and versions (e.g. test:v0.0.1) to add to the management cluster.")
 	initCmd.PersistentFlags().StringSliceVar(&initOpts.addonProviders, "addon", nil,
 		"Add-on providers and versions (e.g. helm:v0.1.0) to add to the management cluster.")
+	initCmd.PersistentFlags().StringVar(&initOpts.webhookCertificates, "webhook-certificates", "cert-manager",
+		"How the certificates of the provider webhooks are managed: cert-manager, self-signed (certificates generated by clusterctl, without cert-manager) "+
+			"or secret (certificates in existing Secrets, without cert-manager).")
 	initCmd.Flags().StringVarP(&initOpts.targetNamespace, "target-namespace", "n", "",
 		"The target namespace where the providers should be deployed. If unspecified, the provider components' default namespace is used.")
 	initCmd.Flags().BoolVar(&initOpts.waitProviders, "wait-providers", false,
 		"Wait for providers to be installed.")
 	initCmd.Flags().IntVar(&initOpts.waitProviderTimeout, "wait-provider-timeout", 5*60,
 		"Wait timeout per provider installation in seconds. This value is ignored if --wait-providers is false")
+	initCmd.Flags().BoolVar(&initOpts.rollbackOnFailure, "rollback-on-failure", false,
+		"Delete the providers installed by this command, including their CRDs, if a provider fails to be installed or, when --wait-providers is set, if it does not become available within --wait-provider-timeout.")
+	initCmd.Flags().StringVar(&initOpts.patches, "patches", "",
+		"Path to a file with patches to customize the provider components, e.g. the resources, node selectors or environment variables of the provider Deployments.")
 	initCmd.Flags().BoolVar(&initOpts.validate, "validate", true,
 		"If true, clusterctl will validate that the deployments will succeed on the management cluster.")
 
@@ -128,6 +147,14 @@ func runInit() error {
 		return err
 	}
 
+	var patches []client.ComponentsPatch
+	if initOpts.patches != "" {
+		patches, err = client.ReadComponentsPatches(initOpts.patches)
+		if err != nil {
+			return err
+		}
+	}
+
 	options := client.InitOptions{
 		Kubeconfig:                client.Kubeconfig{Path: initOpts.kubeconfig, Context: initOpts.kubeconfigContext},
 		CoreProvider:              initOpts.coreProvider,
@@ -138,9 +165,12 @@ func runInit() error {
 		RuntimeExtensionProviders: initOpts.runtimeExtensionProviders,
 		AddonProviders:            initOpts.addonProviders,
 		TargetNamespace:           initOpts.targetNamespace,
+		Patches:                   patches,
 		LogUsageInstructions:      true,
 		WaitProviders:             initOpts.waitProviders,
 		WaitProviderTimeout:       time.Duration(initOpts.waitProviderTimeout) * time.Second,
+		RollbackOnFailure:         initOpts.rollbackOnFailure,
+		WebhookCertificates:       initOpts.webhookCertificates,
 		IgnoreValidationErrors:    !initOpts.validate,
 	}
 
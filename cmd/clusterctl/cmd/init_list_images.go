@@ -65,6 +65,7 @@ func runInitListImages() error {
 		IPAMProviders:             initOpts.ipamProviders,
 		RuntimeExtensionProviders: initOpts.runtimeExtensionProviders,
 		AddonProviders:            initOpts.addonProviders,
+		WebhookCertificates:       initOpts.webhookCertificates,
 		LogUsageInstructions:      false,
 	}
 
@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+// pauseOptions carries the options supported by both the pause and the resume commands.
+type pauseOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	namespace         string
+	allNamespaces     bool
+	selector          string
+	dryRun            bool
+}
+
+var po = &pauseOptions{}
+
+var ro = &pauseOptions{}
+
+var pauseCmd = &cobra.Command{
+	Use:     "pause",
+	GroupID: groupManagement,
+	Short:   "Pause the reconciliation of the Clusters matching a label selector",
+	Long: LongDesc(`
+		Pause the reconciliation of the Clusters matching a label selector.
+
+		The command sets spec.paused on the selected Clusters and waits for the controllers to acknowledge the pause
+		on the Clusters and their descendants, e.g. before starting a maintenance window across a fleet of Clusters.`),
+
+	Example: Examples(`
+		Pause the reconciliation of the Clusters with the env=production label in the current namespace.
+		clusterctl pause --selector env=production
+
+		Pause the reconciliation of the Clusters with the env=production label in all the namespaces.
+		clusterctl pause --selector env=production --all-namespaces
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPause()
+	},
+}
+
+var resumeCmd = &cobra.Command{
+	Use:     "resume",
+	GroupID: groupManagement,
+	Short:   "Resume the reconciliation of the Clusters matching a label selector",
+	Long: LongDesc(`
+		Resume the reconciliation of the Clusters matching a label selector.
+
+		The command unsets spec.paused on the selected Clusters and waits for the controllers to acknowledge the resume
+		on the Clusters and their descendants.`),
+
+	Example: Examples(`
+		Resume the reconciliation of the Clusters with the env=production label in the current namespace.
+		clusterctl resume --selector env=production
+
+		Resume the reconciliation of the Clusters with the env=production label in all the namespaces.
+		clusterctl resume --selector env=production --all-namespaces
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runResume()
+	},
+}
+
+func init() {
+	pauseCmd.Flags().StringVar(&po.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the management cluster. If unspecified, default discovery rules apply.")
+	pauseCmd.Flags().StringVar(&po.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	pauseCmd.Flags().StringVarP(&po.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are hosted. If unspecified, the current context's namespace is used.")
+	pauseCmd.Flags().BoolVarP(&po.allNamespaces, "all-namespaces", "A", false,
+		"If present, pause the Clusters matching the selector in all the namespaces.")
+	pauseCmd.Flags().StringVarP(&po.selector, "selector", "l", "",
+		"Label selector for the Clusters to pause, e.g. env=production.")
+	pauseCmd.Flags().BoolVar(&po.dryRun, "dry-run", false,
+		"Enable dry run, don't really pause the Clusters")
+
+	pauseCmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
+	if err := pauseCmd.MarkFlagRequired("selector"); err != nil {
+		panic(err)
+	}
+
+	resumeCmd.Flags().StringVar(&ro.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file for the management cluster. If unspecified, default discovery rules apply.")
+	resumeCmd.Flags().StringVar(&ro.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	resumeCmd.Flags().StringVarP(&ro.namespace, "namespace", "n", "",
+		"The namespace where the workload clusters are hosted. If unspecified, the current context's namespace is used.")
+	resumeCmd.Flags().BoolVarP(&ro.allNamespaces, "all-namespaces", "A", false,
+		"If present, resume the Clusters matching the selector in all the namespaces.")
+	resumeCmd.Flags().StringVarP(&ro.selector, "selector", "l", "",
+		"Label selector for the Clusters to resume, e.g. env=production.")
+	resumeCmd.Flags().BoolVar(&ro.dryRun, "dry-run", false,
+		"Enable dry run, don't really resume the Clusters")
+
+	resumeCmd.MarkFlagsMutuallyExclusive("namespace", "all-namespaces")
+	if err := resumeCmd.MarkFlagRequired("selector"); err != nil {
+		panic(err)
+	}
+
+	RootCmd.AddCommand(pauseCmd)
+	RootCmd.AddCommand(resumeCmd)
+}
+
+func runPause() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := c.Pause(ctx, client.PauseOptions{
+		Kubeconfig:    client.Kubeconfig{Path: po.kubeconfig, Context: po.kubeconfigContext},
+		Namespace:     po.namespace,
+		AllNamespaces: po.allNamespaces,
+		Selector:      po.selector,
+		DryRun:        po.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		fmt.Printf("Cluster %s paused\n", cluster)
+	}
+	return nil
+}
+
+func runResume() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	clusters, err := c.Resume(ctx, client.ResumeOptions{
+		Kubeconfig:    client.Kubeconfig{Path: ro.kubeconfig, Context: ro.kubeconfigContext},
+		Namespace:     ro.namespace,
+		AllNamespaces: ro.allNamespaces,
+		Selector:      ro.selector,
+		DryRun:        ro.dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, cluster := range clusters {
+		fmt.Printf("Cluster %s resumed\n", cluster)
+	}
+	return nil
+}
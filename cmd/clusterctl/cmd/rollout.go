@@ -44,6 +44,9 @@ var (
 		clusterctl alpha rollout resume machinedeployment/my-md-0
 		clusterctl alpha rollout resume kubeadmcontrolplane/my-kcp
 
+		# View the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0
+
 		# Rollback a machinedeployment
 		clusterctl alpha rollout undo machinedeployment/my-md-0 --to-revision=3`)
 
@@ -61,4 +64,5 @@ func init() {
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutPause(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutResume(cfgFile))
 	rolloutCmd.AddCommand(rollout.NewCmdRolloutUndo(cfgFile))
+	rolloutCmd.AddCommand(rollout.NewCmdRolloutHistory(cfgFile))
 }
@@ -0,0 +1,122 @@
+/*
+Copyright 2020 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/util/duration"
+	"k8s.io/kubectl/pkg/util/templates"
+	"sigs.k8s.io/yaml"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+// historyOptions is the start of the data required to perform the operation.
+type historyOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	resource          string
+	namespace         string
+	revision          int64
+}
+
+var historyOpt = &historyOptions{}
+
+var (
+	historyLong = templates.LongDesc(`
+		View previous rollout revisions and their Machine templates.`)
+
+	historyExample = templates.Examples(`
+		# View the rollout history of a machinedeployment
+		clusterctl alpha rollout history machinedeployment/my-md-0
+
+		# View the Machine template of the machinedeployment revision 3
+		clusterctl alpha rollout history machinedeployment/my-md-0 --revision=3`)
+)
+
+// NewCmdRolloutHistory returns a Command instance for 'rollout history' sub command.
+func NewCmdRolloutHistory(cfgFile string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:                   "history RESOURCE",
+		DisableFlagsInUseLine: true,
+		Short:                 "View rollout history of a cluster-api resource",
+		Long:                  historyLong,
+		Example:               historyExample,
+		Args:                  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runHistory(cfgFile, args)
+		},
+	}
+	cmd.Flags().StringVar(&historyOpt.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig file to use for accessing the management cluster. If unspecified, default discovery rules apply.")
+	cmd.Flags().StringVar(&historyOpt.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	cmd.Flags().StringVarP(&historyOpt.namespace, "namespace", "n", "", "Namespace where the resource(s) reside. If unspecified, the defult namespace will be used.")
+	cmd.Flags().Int64Var(&historyOpt.revision, "revision", historyOpt.revision, "See the details, including the Machine template, of the revision specified.")
+
+	return cmd
+}
+
+func runHistory(cfgFile string, args []string) error {
+	historyOpt.resource = args[0]
+
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	revisions, err := c.RolloutHistory(ctx, client.RolloutHistoryOptions{
+		Kubeconfig: client.Kubeconfig{Path: historyOpt.kubeconfig, Context: historyOpt.kubeconfigContext},
+		Namespace:  historyOpt.namespace,
+		Resource:   historyOpt.resource,
+	})
+	if err != nil {
+		return err
+	}
+
+	if historyOpt.revision > 0 {
+		for _, r := range revisions {
+			if r.Revision != historyOpt.revision {
+				continue
+			}
+			y, err := yaml.Marshal(r.Template)
+			if err != nil {
+				return errors.Wrapf(err, "failed to marshal the Machine template of revision %d", r.Revision)
+			}
+			fmt.Printf("%s with revision #%d\n", historyOpt.resource, r.Revision)
+			fmt.Print(string(y))
+			return nil
+		}
+		return errors.Errorf("unable to find the specified revision: %d", historyOpt.revision)
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 10, 4, 3, ' ', 0)
+	fmt.Fprintln(w, "REVISION\tMACHINESET\tAGE")
+	for _, r := range revisions {
+		fmt.Fprintf(w, "%d\t%s\t%s\n", r.Revision, r.MachineSet, duration.HumanDuration(time.Since(r.CreationTimestamp.Time)))
+	}
+	return w.Flush()
+}
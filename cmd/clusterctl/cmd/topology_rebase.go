@@ -0,0 +1,199 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/olekukonko/tablewriter"
+	pkgerrors "github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/cluster"
+	utilyaml "sigs.k8s.io/cluster-api/util/yaml"
+)
+
+type topologyRebaseOptions struct {
+	kubeconfig        string
+	kubeconfigContext string
+	files             []string
+	cluster           string
+	namespace         string
+	toClass           string
+	outDir            string
+}
+
+var tr = &topologyRebaseOptions{}
+
+var topologyRebaseCmd = &cobra.Command{
+	Use:   "rebase",
+	Short: "Check if a cluster can be rebased to another ClusterClass and report the impact on its machines",
+	Long: LongDesc(`
+		Check if a Cluster can be rebased to another ClusterClass, e.g. to a new revision of the ClusterClass
+		it is currently using, and report which MachineDeployments and control plane would roll out their
+		Machines as a consequence of the rebase.
+
+		The target ClusterClass and its templates can be read from the management cluster or provided in the
+		input files, in order to check a new revision of a ClusterClass before applying it.
+		Details about the objects that will be created and modified can be stored in a path passed using --output-directory.
+
+		Note: This command does not rebase the Cluster; in order to do so, change spec.topology.class of the Cluster.
+	`),
+	Example: Examples(`
+		# Check if "cluster1" can be rebased to the "my-class-v2" ClusterClass, and report the impact on its machines.
+		clusterctl alpha topology rebase --cluster cluster1 --to-class my-class-v2
+
+		# Check if "cluster1" can be rebased to a new revision of a ClusterClass that is not yet applied.
+		clusterctl alpha topology rebase --cluster cluster1 --to-class my-class-v2 -f my-class-v2.yaml
+
+		# Check if "cluster1" can be rebased and write details about the created/modified objects to a directory.
+		clusterctl alpha topology rebase --cluster cluster1 --to-class my-class-v2 -o output/
+	`),
+	Args: cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runTopologyRebase()
+	},
+}
+
+func init() {
+	topologyRebaseCmd.Flags().StringVar(&tr.kubeconfig, "kubeconfig", "",
+		"Path to the kubeconfig for the management cluster. If unspecified, default discovery rules apply.")
+	topologyRebaseCmd.Flags().StringVar(&tr.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+
+	topologyRebaseCmd.Flags().StringArrayVarP(&tr.files, "file", "f", nil, "path to the file with the target ClusterClass and/or its templates, if they are not yet applied to the management cluster; the file should not contain Clusters or more than one ClusterClass")
+	topologyRebaseCmd.Flags().StringVarP(&tr.cluster, "cluster", "c", "", "name of the cluster to rebase")
+	topologyRebaseCmd.Flags().StringVarP(&tr.namespace, "namespace", "n", "", "namespace of the cluster. If unspecified, the current namespace will be used")
+	topologyRebaseCmd.Flags().StringVar(&tr.toClass, "to-class", "", "name of the ClusterClass to rebase the cluster to")
+	topologyRebaseCmd.Flags().StringVarP(&tr.outDir, "output-directory", "o", "", "output directory to write details about created/modified objects")
+
+	if err := topologyRebaseCmd.MarkFlagRequired("cluster"); err != nil {
+		panic(err)
+	}
+	if err := topologyRebaseCmd.MarkFlagRequired("to-class"); err != nil {
+		panic(err)
+	}
+
+	topologyCmd.AddCommand(topologyRebaseCmd)
+}
+
+func runTopologyRebase() error {
+	ctx := context.Background()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	objs := []unstructured.Unstructured{}
+	for _, f := range tr.files {
+		raw, err := os.ReadFile(f) //nolint:gosec
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to read input file %q", f)
+		}
+		objects, err := utilyaml.ToUnstructured(raw)
+		if err != nil {
+			return pkgerrors.Wrapf(err, "failed to convert file %q to list of objects", f)
+		}
+		objs = append(objs, objects...)
+	}
+
+	out, err := c.TopologyRebase(ctx, client.TopologyRebaseOptions{
+		Kubeconfig:   client.Kubeconfig{Path: tr.kubeconfig, Context: tr.kubeconfigContext},
+		Objs:         convertToPtrSlice(objs),
+		Cluster:      tr.cluster,
+		ClusterClass: tr.toClass,
+		Namespace:    tr.namespace,
+	})
+	if err != nil {
+		return err
+	}
+	return printTopologyRebaseOutput(out, tr.outDir)
+}
+
+func printTopologyRebaseOutput(out *cluster.TopologyRebaseOutput, outDir string) error {
+	if len(out.CompatibilityErrors) > 0 {
+		fmt.Printf("Cluster %q cannot be rebased from ClusterClass %q to ClusterClass %q:\n", out.Cluster.String(), out.CurrentClusterClass, out.TargetClusterClass)
+		for _, e := range out.CompatibilityErrors {
+			fmt.Printf(" ＊ %s\n", e.Error())
+		}
+		fmt.Printf("\n")
+		return fmt.Errorf("ClusterClass %q is not compatible with Cluster %q", out.TargetClusterClass, out.Cluster.String())
+	}
+
+	fmt.Printf("Cluster %q can be rebased from ClusterClass %q to ClusterClass %q.\n\n", out.Cluster.String(), out.CurrentClusterClass, out.TargetClusterClass)
+	printRebaseImpacts(out)
+
+	if outDir != "" {
+		if err := writeOutputFiles(&cluster.TopologyPlanOutput{ChangeSummary: out.ChangeSummary}, outDir); err != nil {
+			return pkgerrors.Wrap(err, "failed to write output files of the rebased cluster changes")
+		}
+	}
+	fmt.Printf("\n")
+	return nil
+}
+
+func printRebaseImpacts(out *cluster.TopologyRebaseOutput) {
+	if len(out.Impacts) == 0 {
+		fmt.Printf("No MachineDeployments or control plane of Cluster %q will be affected by the rebase.\n", out.Cluster.String())
+		return
+	}
+
+	fmt.Printf("Impact of the rebase on the machines of Cluster %q: \n", out.Cluster.String())
+	table := tablewriter.NewWriter(os.Stdout)
+	table.SetHeader([]string{"Namespace", "Kind", "Name", "Replicas", "Rollout", "Changes"})
+	table.SetHeaderAlignment(tablewriter.ALIGN_LEFT)
+	table.SetAlignment(tablewriter.ALIGN_LEFT)
+	table.SetCenterSeparator("")
+	table.SetColumnSeparator("")
+	table.SetRowSeparator("")
+	table.SetHeaderLine(false)
+	table.SetBorder(false)
+
+	for _, impact := range out.Impacts {
+		replicas := ""
+		if impact.Replicas != nil {
+			replicas = strconv.FormatInt(*impact.Replicas, 10)
+		}
+		rollout, rolloutColor := "no", tablewriter.FgGreenColor
+		if impact.Rollout() {
+			rollout, rolloutColor = "yes", tablewriter.FgYellowColor
+		}
+		table.Rich(
+			[]string{
+				impact.Object.GetNamespace(),
+				impact.Object.GetKind(),
+				impact.Object.GetName(),
+				replicas,
+				rollout,
+				strings.Join(impact.RolloutChanges, ", "),
+			},
+			[]tablewriter.Colors{
+				{}, {}, {}, {}, {rolloutColor}, {},
+			},
+		)
+	}
+	fmt.Printf("\n")
+	table.Render()
+	fmt.Printf("\n")
+}
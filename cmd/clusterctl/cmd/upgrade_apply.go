@@ -39,6 +39,7 @@ type upgradeApplyOptions struct {
 	addonProviders            []string
 	waitProviders             bool
 	waitProviderTimeout       int
+	webhookCertificates       string
 }
 
 var ua = &upgradeApplyOptions{}
@@ -93,6 +94,8 @@ func init() {
 		"Wait for providers to be upgraded.")
 	upgradeApplyCmd.Flags().IntVar(&ua.waitProviderTimeout, "wait-provider-timeout", 5*60,
 		"Wait timeout per provider upgrade in seconds. This value is ignored if --wait-providers is false")
+	upgradeApplyCmd.Flags().StringVar(&ua.webhookCertificates, "webhook-certificates", "",
+		"How the certificates of the provider webhooks are managed: cert-manager, self-signed or secret. Defaults to the value used with clusterctl init for each provider.")
 }
 
 func runUpgradeApply() error {
@@ -130,5 +133,6 @@ func runUpgradeApply() error {
 		AddonProviders:            ua.addonProviders,
 		WaitProviders:             ua.waitProviders,
 		WaitProviderTimeout:       time.Duration(ua.waitProviderTimeout) * time.Second,
+		WebhookCertificates:       ua.webhookCertificates,
 	})
 }
@@ -0,0 +1,253 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strconv"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/olekukonko/tablewriter"
+	"github.com/pkg/errors"
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/duration"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+)
+
+// clearScreen moves the cursor to the top left corner and clears the terminal.
+const clearScreen = "\033[H\033[2J"
+
+type watchClusterOptions struct {
+	kubeconfig          string
+	kubeconfigContext   string
+	namespace           string
+	showOtherConditions string
+	grouping            bool
+	interval            time.Duration
+	events              int
+	color               bool
+}
+
+var wc = &watchClusterOptions{}
+
+var watchClusterCmd = &cobra.Command{
+	Use:   "cluster NAME",
+	Short: "Watch the status of a workload cluster",
+	Long: LongDesc(`
+		Live-update a condensed view of the status of a Cluster API cluster, including the object tree
+		shown by describe cluster, the rollout progress of the control plane and of the MachineDeployments,
+		and the most recent events, e.g. to follow the provisioning or the upgrade of a cluster.
+
+		The view is refreshed at every interval until the command is interrupted.`),
+
+	Example: Examples(`
+		# Watch the cluster named test-1.
+		clusterctl alpha watch cluster test-1
+
+		# Watch the cluster named test-1, refreshing the view every 10 seconds and showing the last 20 events.
+		clusterctl alpha watch cluster test-1 --interval 10s --events 20
+
+		# Watch the cluster named test-1 showing all the conditions for the KubeadmControlPlane object kind.
+		clusterctl alpha watch cluster test-1 --show-conditions KubeadmControlPlane`),
+
+	Args: func(cmd *cobra.Command, args []string) error {
+		if len(args) != 1 {
+			return errors.New("please specify a cluster name")
+		}
+		return nil
+	},
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runWatchCluster(cmd, args[0])
+	},
+}
+
+func init() {
+	watchClusterCmd.Flags().StringVar(&wc.kubeconfig, "kubeconfig", "",
+		"Path to a kubeconfig file to use for the management cluster. If empty, default discovery rules apply.")
+	watchClusterCmd.Flags().StringVar(&wc.kubeconfigContext, "kubeconfig-context", "",
+		"Context to be used within the kubeconfig file. If empty, current context will be used.")
+	watchClusterCmd.Flags().StringVarP(&wc.namespace, "namespace", "n", "",
+		"The namespace where the workload cluster is located. If unspecified, the current namespace will be used.")
+
+	watchClusterCmd.Flags().StringVar(&wc.showOtherConditions, "show-conditions", "",
+		"list of comma separated kind or kind/name for which the command should show all the object's conditions (use 'all' to show conditions for everything).")
+	watchClusterCmd.Flags().BoolVar(&wc.grouping, "grouping", true,
+		"Groups machines when ready condition has the same Status, Severity and Reason.")
+	watchClusterCmd.Flags().DurationVar(&wc.interval, "interval", 5*time.Second,
+		"The interval between two refreshes of the view.")
+	watchClusterCmd.Flags().IntVar(&wc.events, "events", 10,
+		"The number of recent events to show; use 0 to hide events.")
+	watchClusterCmd.Flags().BoolVarP(&wc.color, "color", "c", false, "Enable or disable color output; if not set color is enabled by default only if using tty. The flag is overridden by the NO_COLOR env variable if set.")
+
+	// completions
+	watchClusterCmd.ValidArgsFunction = resourceNameCompletionFunc(
+		watchClusterCmd.Flags().Lookup("kubeconfig"),
+		watchClusterCmd.Flags().Lookup("kubeconfig-context"),
+		watchClusterCmd.Flags().Lookup("namespace"),
+		clusterv1.GroupVersion.String(),
+		"cluster",
+	)
+
+	watchCmd.AddCommand(watchClusterCmd)
+}
+
+func runWatchCluster(cmd *cobra.Command, name string) error {
+	if wc.interval <= 0 {
+		return errors.Errorf("invalid interval %s, it must be greater than zero", wc.interval)
+	}
+
+	ctx, cancel := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer cancel()
+
+	c, err := client.New(ctx, cfgFile)
+	if err != nil {
+		return err
+	}
+
+	if cmd.Flags().Changed("color") {
+		color.NoColor = !wc.color
+	}
+
+	options := client.ClusterStatusOptions{
+		Kubeconfig:          client.Kubeconfig{Path: wc.kubeconfig, Context: wc.kubeconfigContext},
+		Namespace:           wc.namespace,
+		ClusterName:         name,
+		ShowOtherConditions: wc.showOtherConditions,
+		Grouping:            wc.grouping,
+		MaxEvents:           wc.events,
+	}
+
+	var lastStatus *client.ClusterStatus
+	for {
+		status, err := c.ClusterStatus(ctx, options)
+		if ctx.Err() != nil {
+			return nil
+		}
+		// Fail if the status can't be read at the first attempt, e.g. because the cluster does not exist;
+		// afterwards, keep showing the last known status together with the error, given that errors
+		// can be transient, e.g. during an upgrade of the management cluster.
+		if err != nil && lastStatus == nil {
+			return err
+		}
+		if err == nil {
+			lastStatus = status
+		}
+
+		fmt.Print(clearScreen)
+		printClusterStatus(os.Stdout, lastStatus, err, wc.interval, time.Now())
+
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-time.After(wc.interval):
+		}
+	}
+}
+
+// printClusterStatus prints the condensed view of the status of a cluster.
+func printClusterStatus(w io.Writer, status *client.ClusterStatus, statusErr error, interval time.Duration, now time.Time) {
+	cluster := status.Cluster
+	fmt.Fprintf(w, "Cluster %s/%s, phase %s. Every %s, last update %s; press Ctrl+C to stop.\n",
+		cluster.Namespace, cluster.Name, cluster.Status.GetTypedPhase(), interval, now.Format(time.TimeOnly))
+	if statusErr != nil {
+		fmt.Fprintf(w, "%s\n", red.Sprintf("Failed to update the status: %v", statusErr))
+	}
+	fmt.Fprintf(w, "\n")
+
+	printObjectTree(w, status.Tree)
+
+	if len(status.Rollouts) > 0 {
+		fmt.Fprintf(w, "\n")
+		printRolloutStatus(w, status.Rollouts)
+	}
+
+	if len(status.Events) > 0 {
+		fmt.Fprintf(w, "\n")
+		printEvents(w, status.Events, now)
+	}
+}
+
+// printRolloutStatus prints the rollout progress of the control plane and of the MachineDeployments.
+func printRolloutStatus(w io.Writer, rollouts []client.RolloutStatus) {
+	tbl := tablewriter.NewWriter(w)
+	tbl.SetHeader([]string{"ROLLOUT", "VERSION", "REPLICAS", "UPDATED", "READY", "UNAVAILABLE"})
+	formatTableTree(tbl)
+
+	for _, r := range rollouts {
+		replicas := strconv.Itoa(int(r.Replicas))
+		if r.DesiredReplicas != nil {
+			replicas = fmt.Sprintf("%d/%d", r.Replicas, *r.DesiredReplicas)
+		}
+		updatedColor := green
+		if r.DesiredReplicas != nil && r.UpdatedReplicas < *r.DesiredReplicas {
+			updatedColor = yellow
+		}
+		unavailableColor := green
+		if r.UnavailableReplicas > 0 {
+			unavailableColor = yellow
+		}
+		tbl.Append([]string{
+			fmt.Sprintf("%s/%s", r.Kind, color.New(color.Bold).Sprint(r.Name)),
+			r.Version,
+			replicas,
+			updatedColor.Sprint(r.UpdatedReplicas),
+			strconv.Itoa(int(r.ReadyReplicas)),
+			unavailableColor.Sprint(r.UnavailableReplicas),
+		})
+	}
+
+	tbl.Render()
+}
+
+// printEvents prints the most recent events for the objects of the cluster.
+func printEvents(w io.Writer, events []corev1.Event, now time.Time) {
+	tbl := tablewriter.NewWriter(w)
+	tbl.SetHeader([]string{"LAST SEEN", "TYPE", "REASON", "OBJECT", "MESSAGE"})
+	formatTableTree(tbl)
+
+	for _, e := range events {
+		eventColor := white
+		if e.Type == corev1.EventTypeWarning {
+			eventColor = yellow
+		}
+
+		// Eventually cut the message to keep the table dimension under control.
+		message := e.Message
+		if len(message) > 100 {
+			message = fmt.Sprintf("%s ...", message[:100])
+		}
+
+		tbl.Append([]string{
+			duration.HumanDuration(now.Sub(client.EventTime(e))),
+			eventColor.Sprint(e.Type),
+			eventColor.Sprint(e.Reason),
+			fmt.Sprintf("%s/%s", e.InvolvedObject.Kind, e.InvolvedObject.Name),
+			message,
+		})
+	}
+
+	tbl.Render()
+}
@@ -0,0 +1,113 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cmd
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client"
+	"sigs.k8s.io/cluster-api/cmd/clusterctl/client/tree"
+)
+
+func Test_printClusterStatus(t *testing.T) {
+	now := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+
+	root := fakeObject("root")
+	status := &client.ClusterStatus{
+		Cluster: &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "root"},
+			Status:     clusterv1.ClusterStatus{Phase: string(clusterv1.ClusterPhaseProvisioned)},
+		},
+		Tree: tree.NewObjectTree(root, tree.ObjectTreeOptions{}),
+		Rollouts: []client.RolloutStatus{
+			{
+				Kind:                "KubeadmControlPlane",
+				Name:                "cp",
+				Version:             "v1.28.0",
+				DesiredReplicas:     pointer.Int32(3),
+				Replicas:            4,
+				UpdatedReplicas:     2,
+				ReadyReplicas:       3,
+				UnavailableReplicas: 1,
+			},
+			{
+				Kind:     "MachineDeployment",
+				Name:     "md",
+				Replicas: 2,
+			},
+		},
+		Events: []corev1.Event{
+			{
+				InvolvedObject: corev1.ObjectReference{Kind: "Machine", Name: "m1"},
+				Type:           corev1.EventTypeNormal,
+				Reason:         "SuccessfulCreate",
+				Message:        "Created machine m1",
+				LastTimestamp:  metav1.NewTime(now.Add(-2 * time.Minute)),
+			},
+		},
+	}
+
+	tests := []struct {
+		name      string
+		statusErr error
+		expect    []string
+	}{
+		{
+			name: "Prints the status of the cluster",
+			expect: []string{
+				"Cluster ns/root, phase Provisioned. Every 5s, last update 02:00:00; press Ctrl+C to stop.",
+				"",
+				"NAME         READY  SEVERITY  REASON  SINCE  MESSAGE",
+				"Object/root",
+				"",
+				"ROLLOUT                 VERSION  REPLICAS  UPDATED  READY  UNAVAILABLE",
+				"KubeadmControlPlane/cp  v1.28.0  4/3       2        3      1",
+				"MachineDeployment/md             2         0        0      0",
+				"",
+				"LAST SEEN  TYPE    REASON            OBJECT      MESSAGE",
+				"2m         Normal  SuccessfulCreate  Machine/m1  Created machine m1",
+			},
+		},
+		{
+			name:      "Prints the error if the status can't be updated",
+			statusErr: errors.New("connection refused"),
+			expect: []string{
+				"Cluster ns/root, phase Provisioned. Every 5s, last update 02:00:00; press Ctrl+C to stop.",
+				"Failed to update the status: connection refused",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			var output bytes.Buffer
+
+			printClusterStatus(&output, status, tt.statusErr, 5*time.Second, now)
+
+			g.Expect(output.String()).Should(MatchTable(tt.expect))
+		})
+	}
+}
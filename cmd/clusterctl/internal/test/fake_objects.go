@@ -1445,8 +1445,12 @@ func FakeCRDList() []*apiextensionsv1.CustomResourceDefinition {
 	clusterInfrastructureIdentityCRD := FakeClusterCustomResourceDefinition(fakeinfrastructure.GroupVersion.Group, "GenericClusterInfrastructureIdentity", version)
 	clusterInfrastructureIdentityCRD.Labels[clusterctlv1.ClusterctlMoveHierarchyLabel] = ""
 
+	// Ensure CRD for Cluster declares support for the Paused condition, like the actual Cluster CRD.
+	clusterCRD := FakeNamespacedCustomResourceDefinition(clusterv1.GroupVersion.Group, "Cluster", version)
+	clusterCRD.Labels[clusterctlv1.ClusterctlPausedConditionLabel] = "true"
+
 	return []*apiextensionsv1.CustomResourceDefinition{
-		FakeNamespacedCustomResourceDefinition(clusterv1.GroupVersion.Group, "Cluster", version),
+		clusterCRD,
 		FakeNamespacedCustomResourceDefinition(clusterv1.GroupVersion.Group, "ClusterClass", version),
 		FakeNamespacedCustomResourceDefinition(clusterv1.GroupVersion.Group, "Machine", version),
 		FakeNamespacedCustomResourceDefinition(clusterv1.GroupVersion.Group, "MachineDeployment", version),
@@ -24,6 +24,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	clustercontroller "sigs.k8s.io/cluster-api/internal/controllers/cluster"
 	clusterclasscontroller "sigs.k8s.io/cluster-api/internal/controllers/clusterclass"
@@ -35,6 +36,8 @@ import (
 	machinedeploymenttopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machinedeployment"
 	machinesettopologycontroller "sigs.k8s.io/cluster-api/internal/controllers/topology/machineset"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/util/priority"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // Following types provides access to reconcilers implemented in internal/controllers, thus
@@ -48,6 +51,12 @@ type ClusterReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// DeletionPhaseTimeout is the time after which a Cluster deletion phase is considered timed out and the next phase is started.
+	DeletionPhaseTimeout time.Duration
 }
 
 func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -56,6 +65,8 @@ func (r *ClusterReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		APIReader:                 r.APIReader,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
+		DeletionPhaseTimeout:      r.DeletionPhaseTimeout,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -69,8 +80,26 @@ type MachineReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// NodeDrainClientTimeout timeout of the client used for draining nodes.
 	NodeDrainClientTimeout time.Duration
+
+	// StuckInPhaseThresholds defines for each phase how long a Machine can stay in that phase
+	// before being reported as stuck.
+	StuckInPhaseThresholds map[clusterv1.MachinePhase]time.Duration
+
+	// CertificatesExpiryWarningThreshold defines how long before the expiry of its certificates a Machine
+	// is reported by the CertificatesNotExpiring condition.
+	CertificatesExpiryWarningThreshold time.Duration
+
+	// DeletionBlockedThreshold defines how long a Machine can be deleting before being reported
+	// by the DeletionBlocked condition.
+	DeletionBlockedThreshold time.Duration
+
+	// ReconcilePriority configures the fast lane in the reconcile queue for Machines being deleted or recently created.
+	ReconcilePriority priority.Options
 }
 
 func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -80,7 +109,13 @@ func (r *MachineReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manag
 		APIReader:                 r.APIReader,
 		Tracker:                   r.Tracker,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
 		NodeDrainClientTimeout:    r.NodeDrainClientTimeout,
+		StuckInPhaseThresholds:    r.StuckInPhaseThresholds,
+		ReconcilePriority:         r.ReconcilePriority,
+
+		CertificatesExpiryWarningThreshold: r.CertificatesExpiryWarningThreshold,
+		DeletionBlockedThreshold:           r.DeletionBlockedThreshold,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -90,9 +125,16 @@ type MachineSetReconciler struct {
 	UnstructuredCachingClient client.Client
 	APIReader                 client.Reader
 	Tracker                   *remote.ClusterCacheTracker
+	RuntimeClient             runtimeclient.Client
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// ReconcilePriority configures the fast lane in the reconcile queue for MachineSets being deleted or recently created.
+	ReconcilePriority priority.Options
 }
 
 func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -101,7 +143,10 @@ func (r *MachineSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Ma
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		APIReader:                 r.APIReader,
 		Tracker:                   r.Tracker,
+		RuntimeClient:             r.RuntimeClient,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
+		ReconcilePriority:         r.ReconcilePriority,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -113,6 +158,9 @@ type MachineDeploymentReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -121,6 +169,7 @@ func (r *MachineDeploymentReconciler) SetupWithManager(ctx context.Context, mgr
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		APIReader:                 r.APIReader,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -131,6 +180,9 @@ type MachineHealthCheckReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *MachineHealthCheckReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -138,6 +190,7 @@ func (r *MachineHealthCheckReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		Tracker:          r.Tracker,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -154,6 +207,9 @@ type ClusterTopologyReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects in a managed topology.
 	UnstructuredCachingClient client.Client
@@ -167,6 +223,7 @@ func (r *ClusterTopologyReconciler) SetupWithManager(ctx context.Context, mgr ct
 		RuntimeClient:             r.RuntimeClient,
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -180,6 +237,8 @@ type MachineDeploymentTopologyReconciler struct {
 	// race conditions caused by an outdated cache.
 	APIReader        client.Reader
 	WatchFilterValue string
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *MachineDeploymentTopologyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -187,6 +246,7 @@ func (r *MachineDeploymentTopologyReconciler) SetupWithManager(ctx context.Conte
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -200,6 +260,8 @@ type MachineSetTopologyReconciler struct {
 	// race conditions caused by an outdated cache.
 	APIReader        client.Reader
 	WatchFilterValue string
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *MachineSetTopologyReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -207,6 +269,7 @@ func (r *MachineSetTopologyReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		APIReader:        r.APIReader,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -221,6 +284,9 @@ type ClusterClassReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects.
 	UnstructuredCachingClient client.Client
@@ -233,5 +299,6 @@ func (r *ClusterClassReconciler) SetupWithManager(ctx context.Context, mgr ctrl.
 		RuntimeClient:             r.RuntimeClient,
 		UnstructuredCachingClient: r.UnstructuredCachingClient,
 		WatchFilterValue:          r.WatchFilterValue,
+		Shard:                     r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -20,7 +20,9 @@ import (
 	"context"
 	"crypto/rsa"
 	"fmt"
+	"net"
 	"net/http"
+	"net/url"
 	"os"
 	"sync"
 	"time"
@@ -68,6 +70,7 @@ var ErrClusterLocked = errors.New("cluster is locked already")
 type ClusterCacheTracker struct {
 	log                   logr.Logger
 	clientUncachedObjects []client.Object
+	cacheByObject         map[client.Object]cache.ByObject
 
 	client client.Client
 
@@ -97,6 +100,9 @@ type ClusterCacheTracker struct {
 	// This information will be used to detected if the controller is running on a workload cluster, so
 	// that we can then access the apiserver directly.
 	controllerPodMetadata *metav1.ObjectMeta
+
+	// dialContext is the dialer used to connect to the workload clusters.
+	dialContext func(ctx context.Context, network, address string) (net.Conn, error)
 }
 
 // ClusterCacheTrackerOptions defines options to configure
@@ -117,10 +123,21 @@ type ClusterCacheTrackerOptions struct {
 	ClientUncachedObjects []client.Object
 	Indexes               []Index
 
+	// CacheByObject restricts the objects cached for the workload clusters per object type,
+	// e.g. with a label selector to watch only the Nodes that are relevant for the controller.
+	// NOTE: Objects not matching the selectors are not returned by the Client.
+	CacheByObject map[client.Object]cache.ByObject
+
 	// ControllerName is the name of the controller.
 	// This is used to calculate the user agent string.
 	// If not set, it defaults to "cluster-cache-tracker".
 	ControllerName string
+
+	// DialContext is the dialer used to connect to the workload clusters.
+	// If not set, connections are established by the default transport of client-go.
+	// NOTE: This can be used e.g. to connect to workload clusters served in-process by fake API servers,
+	// without requiring a host port for each workload cluster.
+	DialContext func(ctx context.Context, network, address string) (net.Conn, error)
 }
 
 func setDefaultOptions(opts *ClusterCacheTrackerOptions) {
@@ -166,12 +183,14 @@ func NewClusterCacheTracker(manager ctrl.Manager, options ClusterCacheTrackerOpt
 		controllerPodMetadata: controllerPodMetadata,
 		log:                   *options.Log,
 		clientUncachedObjects: options.ClientUncachedObjects,
+		cacheByObject:         options.CacheByObject,
 		client:                manager.GetClient(),
 		secretCachingClient:   options.SecretCachingClient,
 		scheme:                manager.GetScheme(),
 		clusterAccessors:      make(map[client.ObjectKey]*clusterAccessor),
 		clusterLock:           newKeyedMutex(),
 		indexes:               options.Indexes,
+		dialContext:           options.DialContext,
 	}, nil
 }
 
@@ -212,6 +231,9 @@ type clusterAccessor struct {
 	watches                  sets.Set[string]
 	config                   *rest.Config
 	etcdClientCertificateKey *rsa.PrivateKey
+
+	// proxyURL is the value of the proxy-url annotation of the Cluster when the clusterAccessor was created.
+	proxyURL string
 }
 
 // clusterAccessorExists returns true if a clusterAccessor exists for cluster.
@@ -248,8 +270,8 @@ func (t *ClusterCacheTracker) storeAccessor(cluster client.ObjectKey, accessor *
 func (t *ClusterCacheTracker) getClusterAccessor(ctx context.Context, cluster client.ObjectKey, indexes ...Index) (*clusterAccessor, error) {
 	log := ctrl.LoggerFrom(ctx, "cluster", klog.KRef(cluster.Namespace, cluster.Name))
 
-	// If the clusterAccessor already exists, return early.
-	if accessor, ok := t.loadAccessor(cluster); ok {
+	// If the clusterAccessor already exists and the proxy of the Cluster did not change, return early.
+	if accessor, ok := t.loadAccessor(cluster); ok && !t.proxyChanged(ctx, cluster, accessor) {
 		return accessor, nil
 	}
 
@@ -264,8 +286,13 @@ func (t *ClusterCacheTracker) getClusterAccessor(ctx context.Context, cluster cl
 
 	// Until we got the cluster lock a different goroutine might have initialized the clusterAccessor
 	// for this cluster successfully already. If this is the case we return it.
+	// If the proxy of the Cluster changed, the clusterAccessor is recreated to connect through the new proxy.
 	if accessor, ok := t.loadAccessor(cluster); ok {
-		return accessor, nil
+		if !t.proxyChanged(ctx, cluster, accessor) {
+			return accessor, nil
+		}
+		log.Info("Recreating cluster accessor because the proxy of the Cluster changed")
+		t.deleteAccessor(ctx, cluster)
 	}
 
 	// We are the go routine who has to initialize the clusterAccessor.
@@ -280,6 +307,17 @@ func (t *ClusterCacheTracker) getClusterAccessor(ctx context.Context, cluster cl
 	return accessor, nil
 }
 
+// proxyChanged returns true if the proxy-url annotation of the Cluster changed since the clusterAccessor was created.
+// NOTE: If the Cluster can't be read, the existing clusterAccessor is kept.
+func (t *ClusterCacheTracker) proxyChanged(ctx context.Context, cluster client.ObjectKey, accessor *clusterAccessor) bool {
+	proxyURL, err := t.clusterProxyURL(ctx, cluster)
+	if err != nil {
+		ctrl.LoggerFrom(ctx).Error(err, "Failed to check if the proxy of the Cluster changed")
+		return false
+	}
+	return accessor.proxyURL != proxyURL
+}
+
 // newClusterAccessor creates a new clusterAccessor.
 func (t *ClusterCacheTracker) newClusterAccessor(ctx context.Context, cluster client.ObjectKey, indexes ...Index) (*clusterAccessor, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -294,6 +332,20 @@ func (t *ClusterCacheTracker) newClusterAccessor(ctx context.Context, cluster cl
 	if err != nil {
 		return nil, errors.Wrapf(err, "error fetching REST client config for remote cluster %q", cluster.String())
 	}
+	if t.dialContext != nil {
+		config.Dial = t.dialContext
+	}
+
+	// Route connections through the proxy configured on the Cluster, if any.
+	// NOTE: A proxy-url set in the kubeconfig secret is already honored by RESTConfig;
+	// the annotation on the Cluster takes precedence.
+	proxy, proxyURL, err := t.clusterProxy(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+	if proxy != nil {
+		config.Proxy = proxy
+	}
 
 	// Create a client and a cache for the cluster.
 	c, uncachedClient, cache, err := t.createClient(ctx, config, cluster, indexes)
@@ -320,6 +372,8 @@ func (t *ClusterCacheTracker) newClusterAccessor(ctx context.Context, cluster cl
 		config.CAData = nil
 		config.CAFile = inClusterConfig.CAFile
 		config.Host = inClusterConfig.Host
+		// The in-cluster service is reachable directly, so the proxy must not be used.
+		config.Proxy = nil
 
 		// Create a new client and overwrite the previously created client.
 		c, _, cache, err = t.createClient(ctx, config, cluster, indexes)
@@ -345,6 +399,7 @@ func (t *ClusterCacheTracker) newClusterAccessor(ctx context.Context, cluster cl
 		client:                   c,
 		watches:                  sets.Set[string]{},
 		etcdClientCertificateKey: etcdKey,
+		proxyURL:                 proxyURL,
 	}, nil
 }
 
@@ -374,6 +429,59 @@ func (t *ClusterCacheTracker) runningOnWorkloadCluster(ctx context.Context, c cl
 	return t.controllerPodMetadata.UID == pod.UID, nil
 }
 
+// clusterProxy returns the proxy function to be used to connect to the workload cluster, if the Cluster
+// has the proxy-url annotation; nil is returned otherwise. The value of the annotation is returned as well.
+func (t *ClusterCacheTracker) clusterProxy(ctx context.Context, cluster client.ObjectKey) (func(*http.Request) (*url.URL, error), string, error) {
+	value, err := t.clusterProxyURL(ctx, cluster)
+	if err != nil {
+		return nil, "", err
+	}
+
+	proxy, err := proxyFromURL(value)
+	if err != nil {
+		return nil, "", errors.Wrapf(err, "error getting proxy configuration for remote cluster %q", cluster.String())
+	}
+	return proxy, value, nil
+}
+
+// clusterProxyURL returns the value of the proxy-url annotation of the Cluster, or an empty string
+// if the annotation is not set.
+func (t *ClusterCacheTracker) clusterProxyURL(ctx context.Context, cluster client.ObjectKey) (string, error) {
+	c := &clusterv1.Cluster{}
+	if err := t.client.Get(ctx, cluster, c); err != nil {
+		// If the Cluster is not found, there is no proxy to use; errors on the workload cluster
+		// will be surfaced when creating the client.
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "error getting proxy configuration for remote cluster %q", cluster.String())
+	}
+	return c.GetAnnotations()[clusterv1.ClusterProxyURLAnnotation], nil
+}
+
+// proxyFromURL returns the proxy function for the URL of the proxy-url annotation,
+// or nil if the value is empty.
+func proxyFromURL(value string) (func(*http.Request) (*url.URL, error), error) {
+	if value == "" {
+		return nil, nil
+	}
+
+	proxyURL, err := url.Parse(value)
+	if err != nil {
+		return nil, errors.Wrapf(err, "invalid %s annotation %q", clusterv1.ClusterProxyURLAnnotation, value)
+	}
+	switch proxyURL.Scheme {
+	case "http", "https", "socks5":
+	default:
+		return nil, errors.Errorf("invalid %s annotation %q: scheme must be one of http, https or socks5", clusterv1.ClusterProxyURLAnnotation, value)
+	}
+	if proxyURL.Host == "" {
+		return nil, errors.Errorf("invalid %s annotation %q: host must be set", clusterv1.ClusterProxyURLAnnotation, value)
+	}
+
+	return http.ProxyURL(proxyURL), nil
+}
+
 // createClient creates a cached client, and uncached client and a mapper based on a rest.Config.
 func (t *ClusterCacheTracker) createClient(ctx context.Context, config *rest.Config, cluster client.ObjectKey, indexes []Index) (client.Client, client.Client, *stoppableCache, error) {
 	// Create a http client for the cluster.
@@ -401,6 +509,7 @@ func (t *ClusterCacheTracker) createClient(ctx context.Context, config *rest.Con
 		HTTPClient: httpClient,
 		Scheme:     t.scheme,
 		Mapper:     mapper,
+		ByObject:   t.cacheByObject,
 	}
 	remoteCache, err := cache.New(config, cacheOptions)
 	if err != nil {
@@ -19,6 +19,8 @@ package remote
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"net/url"
 	"testing"
 
 	"github.com/davecgh/go-spew/spew"
@@ -317,6 +319,159 @@ func TestClusterCacheTracker(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("clusterProxy", func(t *testing.T) {
+		tests := []struct {
+			name        string
+			annotations map[string]string
+			noCluster   bool
+			wantProxy   string
+			wantErr     bool
+		}{
+			{
+				name:      "should return no proxy if the Cluster does not exist",
+				noCluster: true,
+			},
+			{
+				name: "should return no proxy if the annotation is not set",
+			},
+			{
+				name:        "should return no proxy if the annotation is empty",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: ""},
+			},
+			{
+				name:        "should return the proxy from the annotation",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://proxy.example.com:3128"},
+				wantProxy:   "http://proxy.example.com:3128",
+			},
+			{
+				name:        "should return a socks5 proxy from the annotation",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "socks5://127.0.0.1:1080"},
+				wantProxy:   "socks5://127.0.0.1:1080",
+			},
+			{
+				name:        "should fail if the annotation has an unsupported scheme",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "ftp://proxy.example.com"},
+				wantErr:     true,
+			},
+			{
+				name:        "should fail if the annotation has no host",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://"},
+				wantErr:     true,
+			},
+			{
+				name:        "should fail if the annotation is not a valid URL",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://proxy example.com"},
+				wantErr:     true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				cluster := &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test-cluster",
+						Namespace:   "test-namespace",
+						Annotations: tt.annotations,
+					},
+				}
+				objs := []client.Object{}
+				if !tt.noCluster {
+					objs = append(objs, cluster)
+				}
+
+				cct := &ClusterCacheTracker{
+					client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+				}
+
+				proxy, value, err := cct.clusterProxy(ctx, client.ObjectKeyFromObject(cluster))
+				if tt.wantErr {
+					g.Expect(err).To(HaveOccurred())
+					return
+				}
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(value).To(Equal(tt.wantProxy))
+				if tt.wantProxy == "" {
+					g.Expect(proxy).To(BeNil())
+					return
+				}
+				g.Expect(proxy).ToNot(BeNil())
+				proxyURL, err := proxy(&http.Request{URL: &url.URL{Scheme: "https", Host: "apiserver.example.com:6443"}})
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(proxyURL.String()).To(Equal(tt.wantProxy))
+			})
+		}
+	})
+
+	t.Run("proxyChanged", func(t *testing.T) {
+		tests := []struct {
+			name          string
+			accessorProxy string
+			annotations   map[string]string
+			noCluster     bool
+			expected      bool
+		}{
+			{
+				name:     "should return false if the Cluster has no proxy",
+				expected: false,
+			},
+			{
+				name:          "should return false if the proxy of the Cluster did not change",
+				accessorProxy: "http://proxy.example.com:3128",
+				annotations:   map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://proxy.example.com:3128"},
+				expected:      false,
+			},
+			{
+				name:          "should return true if the proxy of the Cluster changed",
+				accessorProxy: "http://proxy.example.com:3128",
+				annotations:   map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://new-proxy.example.com:3128"},
+				expected:      true,
+			},
+			{
+				name:        "should return true if the proxy has been added to the Cluster",
+				annotations: map[string]string{clusterv1.ClusterProxyURLAnnotation: "http://proxy.example.com:3128"},
+				expected:    true,
+			},
+			{
+				name:          "should return true if the proxy has been removed from the Cluster",
+				accessorProxy: "http://proxy.example.com:3128",
+				expected:      true,
+			},
+			{
+				name:          "should return true if the Cluster does not exist anymore",
+				accessorProxy: "http://proxy.example.com:3128",
+				noCluster:     true,
+				expected:      true,
+			},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				cluster := &clusterv1.Cluster{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "test-cluster",
+						Namespace:   "test-namespace",
+						Annotations: tt.annotations,
+					},
+				}
+				objs := []client.Object{}
+				if !tt.noCluster {
+					objs = append(objs, cluster)
+				}
+
+				cct := &ClusterCacheTracker{
+					client: fake.NewClientBuilder().WithObjects(objs...).Build(),
+				}
+
+				changed := cct.proxyChanged(ctx, client.ObjectKeyFromObject(cluster), &clusterAccessor{proxyURL: tt.accessorProxy})
+				g.Expect(changed).To(Equal(tt.expected))
+			})
+		}
+	})
 }
 
 type testController struct {
@@ -0,0 +1,71 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"context"
+	"net"
+	"strings"
+
+	"github.com/pkg/errors"
+)
+
+// maxDialedAddressLength is the maximum length of the address written by the unix socket dialer.
+const maxDialedAddressLength = 1024
+
+// NewUnixSocketDialer returns a dialer connecting to the workload clusters through the unix socket at socketPath,
+// e.g. when the workload clusters are served by a process running on the same host, like the in-memory
+// infrastructure provider used in scale tests, which then doesn't have to bind a host port for each workload cluster.
+// The dialer writes the address of the workload cluster on the connection as a single line before returning it,
+// so the server can route the connection to the target workload cluster; see ReadDialedAddress.
+func NewUnixSocketDialer(socketPath string) func(ctx context.Context, network, address string) (net.Conn, error) {
+	return func(ctx context.Context, _, address string) (net.Conn, error) {
+		if strings.Contains(address, "\n") {
+			return nil, errors.Errorf("failed to connect to %s through the unix socket %s: invalid address", address, socketPath)
+		}
+
+		d := &net.Dialer{}
+		conn, err := d.DialContext(ctx, "unix", socketPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to connect to %s through the unix socket %s", address, socketPath)
+		}
+		if _, err := conn.Write([]byte(address + "\n")); err != nil {
+			_ = conn.Close()
+			return nil, errors.Wrapf(err, "failed to connect to %s through the unix socket %s", address, socketPath)
+		}
+		return conn, nil
+	}
+}
+
+// ReadDialedAddress reads the address written by the dialer returned by NewUnixSocketDialer on a connection.
+// NOTE: The address is read one byte at a time, so no data following the address is consumed.
+func ReadDialedAddress(conn net.Conn) (string, error) {
+	var address []byte
+	b := make([]byte, 1)
+	for {
+		if _, err := conn.Read(b); err != nil {
+			return "", errors.Wrap(err, "failed to read the dialed address")
+		}
+		if b[0] == '\n' {
+			return string(address), nil
+		}
+		if len(address) >= maxDialedAddressLength {
+			return "", errors.New("failed to read the dialed address: address too long")
+		}
+		address = append(address, b[0])
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package remote
+
+import (
+	"io"
+	"net"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestUnixSocketDialer(t *testing.T) {
+	g := NewWithT(t)
+
+	socketPath := filepath.Join(t.TempDir(), "workload-clusters.sock")
+	l, err := net.Listen("unix", socketPath)
+	g.Expect(err).ToNot(HaveOccurred())
+	defer l.Close()
+
+	type result struct {
+		address string
+		data    string
+		err     error
+	}
+	results := make(chan result, 1)
+	go func() {
+		conn, err := l.Accept()
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		defer conn.Close()
+
+		address, err := ReadDialedAddress(conn)
+		if err != nil {
+			results <- result{err: err}
+			return
+		}
+		// The data written after the address is not consumed when reading the address.
+		data, err := io.ReadAll(conn)
+		results <- result{address: address, data: string(data), err: err}
+	}()
+
+	conn, err := NewUnixSocketDialer(socketPath)(ctx, "tcp", "10.0.0.1:20000")
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = conn.Write([]byte("hello"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(conn.Close()).To(Succeed())
+
+	r := <-results
+	g.Expect(r.err).ToNot(HaveOccurred())
+	g.Expect(r.address).To(Equal("10.0.0.1:20000"))
+	g.Expect(r.data).To(Equal("hello"))
+
+	// Invalid addresses are rejected.
+	_, err = NewUnixSocketDialer(socketPath)(ctx, "tcp", "10.0.0.1:20000\nfoo")
+	g.Expect(err).To(HaveOccurred())
+}
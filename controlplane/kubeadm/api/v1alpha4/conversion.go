@@ -51,12 +51,14 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.KubeadmConfigSpec.Ignition = restored.Spec.KubeadmConfigSpec.Ignition
+	dst.Spec.KubeadmConfigSpec.KubeletConfiguration = restored.Spec.KubeadmConfigSpec.KubeletConfiguration
 	if restored.Spec.KubeadmConfigSpec.InitConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.InitConfiguration == nil {
 			dst.Spec.KubeadmConfigSpec.InitConfiguration = &bootstrapv1.InitConfiguration{}
 		}
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.KubeadmConfigSpec.InitConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts
 	}
 	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil {
 		if dst.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -64,11 +66,27 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
 		dst.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
+		dst.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = restored.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts
+	}
+	if restored.Spec.KubeadmConfigSpec.ClusterConfiguration != nil && dst.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraEnvs = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraEnvs
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.ControllerManager.ExtraEnvs = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.ControllerManager.ExtraEnvs
+		dst.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler.ExtraEnvs = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler.ExtraEnvs
+		if restored.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local != nil && dst.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local != nil {
+			dst.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.ExtraEnvs = restored.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.ExtraEnvs
+		}
 	}
 
 	dst.Spec.MachineTemplate.NodeDeletionTimeout = restored.Spec.MachineTemplate.NodeDeletionTimeout
 	dst.Spec.RolloutBefore = restored.Spec.RolloutBefore
 	dst.Spec.MachineTemplate.NodeVolumeDetachTimeout = restored.Spec.MachineTemplate.NodeVolumeDetachTimeout
+	dst.Spec.MachineTemplate.FailureDomains = restored.Spec.MachineTemplate.FailureDomains
+
+	if restored.Spec.RolloutStrategy != nil && restored.Spec.RolloutStrategy.RollingUpdate != nil &&
+		dst.Spec.RolloutStrategy != nil && dst.Spec.RolloutStrategy.RollingUpdate != nil {
+		dst.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = restored.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable
+		dst.Spec.RolloutStrategy.RollingUpdate.SoakDuration = restored.Spec.RolloutStrategy.RollingUpdate.SoakDuration
+	}
 
 	if restored.Spec.KubeadmConfigSpec.JoinConfiguration != nil && restored.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullPolicy != "" {
 		if dst.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -87,6 +105,12 @@ func (src *KubeadmControlPlane) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.RemediationStrategy != nil {
 		dst.Spec.RemediationStrategy = restored.Spec.RemediationStrategy
 	}
+	if restored.Spec.EtcdMaintenance != nil {
+		dst.Spec.EtcdMaintenance = restored.Spec.EtcdMaintenance
+	}
+	if restored.Spec.KubeconfigEndpoint != nil {
+		dst.Spec.KubeconfigEndpoint = restored.Spec.KubeconfigEndpoint
+	}
 	if restored.Status.LastRemediation != nil {
 		dst.Status.LastRemediation = restored.Status.LastRemediation
 	}
@@ -133,6 +157,7 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Files = restored.Spec.Template.Spec.KubeadmConfigSpec.Files
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Users = restored.Spec.Template.Spec.KubeadmConfigSpec.Users
 	dst.Spec.Template.Spec.KubeadmConfigSpec.Ignition = restored.Spec.Template.Spec.KubeadmConfigSpec.Ignition
+	dst.Spec.Template.Spec.KubeadmConfigSpec.KubeletConfiguration = restored.Spec.Template.Spec.KubeadmConfigSpec.KubeletConfiguration
 	dst.Spec.Template.Spec.MachineTemplate = restored.Spec.Template.Spec.MachineTemplate
 
 	if restored.Spec.Template.Spec.KubeadmConfigSpec.Users != nil {
@@ -154,6 +179,7 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Patches = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Patches
 		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.SkipPhases
+		dst.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = restored.Spec.Template.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts
 	}
 	if restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration != nil {
 		if dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
@@ -161,6 +187,15 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		}
 		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Patches = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Patches
 		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.SkipPhases
+		dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts
+	}
+	if restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration != nil && dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration != nil {
+		dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraEnvs = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.ExtraEnvs
+		dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.ControllerManager.ExtraEnvs = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.ControllerManager.ExtraEnvs
+		dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler.ExtraEnvs = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Scheduler.ExtraEnvs
+		if restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local != nil && dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local != nil {
+			dst.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.ExtraEnvs = restored.Spec.Template.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local.ExtraEnvs
+		}
 	}
 	if dst.Spec.Template.Spec.MachineTemplate == nil {
 		dst.Spec.Template.Spec.MachineTemplate = restored.Spec.Template.Spec.MachineTemplate
@@ -171,6 +206,12 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 
 	dst.Spec.Template.Spec.RolloutBefore = restored.Spec.Template.Spec.RolloutBefore
 
+	if restored.Spec.Template.Spec.RolloutStrategy != nil && restored.Spec.Template.Spec.RolloutStrategy.RollingUpdate != nil &&
+		dst.Spec.Template.Spec.RolloutStrategy != nil && dst.Spec.Template.Spec.RolloutStrategy.RollingUpdate != nil {
+		dst.Spec.Template.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = restored.Spec.Template.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable
+		dst.Spec.Template.Spec.RolloutStrategy.RollingUpdate.SoakDuration = restored.Spec.Template.Spec.RolloutStrategy.RollingUpdate.SoakDuration
+	}
+
 	if restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration != nil && restored.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration.NodeRegistration.ImagePullPolicy != "" {
 		if dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration == nil {
 			dst.Spec.Template.Spec.KubeadmConfigSpec.JoinConfiguration = &bootstrapv1.JoinConfiguration{}
@@ -188,6 +229,9 @@ func (src *KubeadmControlPlaneTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	if restored.Spec.Template.Spec.RemediationStrategy != nil {
 		dst.Spec.Template.Spec.RemediationStrategy = restored.Spec.Template.Spec.RemediationStrategy
 	}
+	if restored.Spec.Template.Spec.EtcdMaintenance != nil {
+		dst.Spec.Template.Spec.EtcdMaintenance = restored.Spec.Template.Spec.EtcdMaintenance
+	}
 
 	return nil
 }
@@ -273,12 +317,15 @@ func Convert_v1beta1_KubeadmControlPlaneTemplateResourceSpec_To_v1alpha4_Kubeadm
 
 func Convert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in *controlplanev1.KubeadmControlPlaneMachineTemplate, out *KubeadmControlPlaneMachineTemplate, s apiconversion.Scope) error {
 	// .NodeDrainTimeout was added in v1beta1.
+	// .FailureDomains was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneMachineTemplate_To_v1alpha4_KubeadmControlPlaneMachineTemplate(in, out, s)
 }
 
 func Convert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in *controlplanev1.KubeadmControlPlaneSpec, out *KubeadmControlPlaneSpec, scope apiconversion.Scope) error {
 	// .RolloutBefore was added in v1beta1.
 	// .RemediationStrategy was added in v1beta1.
+	// .EtcdMaintenance was added in v1beta1.
+	// .KubeconfigEndpoint was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneSpec_To_v1alpha4_KubeadmControlPlaneSpec(in, out, scope)
 }
 
@@ -291,3 +338,8 @@ func Convert_v1beta1_KubeadmControlPlaneTemplateResource_To_v1alpha4_KubeadmCont
 	// .metadata and .spec.machineTemplate.metadata was added in v1beta1.
 	return autoConvert_v1beta1_KubeadmControlPlaneTemplateResource_To_v1alpha4_KubeadmControlPlaneTemplateResource(in, out, scope)
 }
+
+func Convert_v1beta1_RollingUpdate_To_v1alpha4_RollingUpdate(in *controlplanev1.RollingUpdate, out *RollingUpdate, scope apiconversion.Scope) error {
+	// .MaxUnavailable and .SoakDuration were added in v1beta1.
+	return autoConvert_v1beta1_RollingUpdate_To_v1alpha4_RollingUpdate(in, out, scope)
+}
@@ -132,6 +132,25 @@ const (
 	// EtcdMemberUnhealthyReason (Severity=Error) documents a Machine's etcd member is unhealthy.
 	EtcdMemberUnhealthyReason = "EtcdMemberUnhealthy"
 
+	// EtcdMemberNoSpaceAlarmReason documents a Machine's etcd member reports only NOSPACE alarms;
+	// the severity is Warning if KCP is configured to disarm NOSPACE alarms, Error otherwise.
+	EtcdMemberNoSpaceAlarmReason = "EtcdMemberNoSpaceAlarm"
+
+	// EtcdMaintenanceSucceededCondition documents the result of the last maintenance operations performed by KCP
+	// on the etcd cluster, e.g. defragmentation of the etcd members and disarm of the etcd alarms.
+	// NOTE: This condition exists only if EtcdMaintenance is configured.
+	EtcdMaintenanceSucceededCondition clusterv1.ConditionType = "EtcdMaintenanceSucceeded"
+
+	// EtcdDefragmentationFailedReason (Severity=Warning) documents a failure in defragmenting an etcd member.
+	EtcdDefragmentationFailedReason = "EtcdDefragmentationFailed"
+
+	// EtcdAlarmDisarmFailedReason (Severity=Warning) documents a failure in disarming an etcd alarm.
+	EtcdAlarmDisarmFailedReason = "EtcdAlarmDisarmFailed"
+
+	// EtcdMaintenanceInspectionFailedReason (Severity=Warning) documents a failure in inspecting the etcd
+	// members for maintenance.
+	EtcdMaintenanceInspectionFailedReason = "EtcdMaintenanceInspectionFailed"
+
 	// MachinesCreatedCondition documents that the machines controlled by the KubeadmControlPlane are created.
 	// When this condition is false, it indicates that there was an error when cloning the infrastructure/bootstrap template or
 	// when generating the machine object.
@@ -0,0 +1,53 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+// Event reasons used by the KubeadmControlPlane controller.
+// NOTE: Event reasons are part of the API: they are stable and can be used to key off the events emitted by KCP.
+const (
+	// InitializationFailedEventReason is used when the first control plane Machine failed to be created.
+	InitializationFailedEventReason = "FailedInitialization"
+
+	// ScaleUpFailedEventReason is used when an additional control plane Machine failed to be created.
+	ScaleUpFailedEventReason = "FailedScaleUp"
+
+	// ScalingDownEventReason is used when a control plane Machine is deleted to scale down the control plane.
+	ScalingDownEventReason = "ScalingDown"
+
+	// ScaleDownFailedEventReason is used when a control plane Machine failed to be deleted to scale down the control plane.
+	ScaleDownFailedEventReason = "FailedScaleDown"
+
+	// DeleteFailedEventReason is used when the control plane Machines failed to be deleted during KCP deletion.
+	DeleteFailedEventReason = "FailedDelete"
+
+	// ControlPlaneUnhealthyEventReason is used when an operation is blocked because the control plane is not healthy.
+	ControlPlaneUnhealthyEventReason = "ControlPlaneUnhealthy"
+
+	// AdoptionFailedEventReason is used when a Machine can't be adopted by KCP.
+	AdoptionFailedEventReason = "AdoptionFailed"
+
+	// RemediatingMachineEventReason is used when an unhealthy control plane Machine is deleted to be remediated.
+	RemediatingMachineEventReason = "RemediatingMachine"
+
+	// KubeconfigRotatedEventReason is used when the kubeconfig Secret of the Cluster is regenerated because
+	// its client certificate is about to expire.
+	KubeconfigRotatedEventReason = "KubeconfigRotated"
+
+	// KubeconfigEndpointUpdatedEventReason is used when the kubeconfig Secret of the Cluster is regenerated because
+	// its server does not match the kubeconfig endpoint of the KubeadmControlPlane or the Cluster control plane endpoint.
+	KubeconfigEndpointUpdatedEventReason = "KubeconfigEndpointUpdated"
+)
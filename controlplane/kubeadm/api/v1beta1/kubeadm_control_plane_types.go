@@ -20,6 +20,7 @@ import (
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/intstr"
 
@@ -65,9 +66,22 @@ const (
 	// failures in updating remediation retry (the counter restarts from zero).
 	RemediationForAnnotation = "controlplane.cluster.x-k8s.io/remediation-for"
 
+	// EtcdLastDefragmentationAnnotation is a machine annotation that stores the time, in RFC3339 format, of the
+	// last defragmentation of the etcd member hosted on the machine performed by KCP.
+	// This annotation is used to enforce the minimum interval between two defragmentations of the same etcd member.
+	EtcdLastDefragmentationAnnotation = "controlplane.cluster.x-k8s.io/etcd-last-defragmentation"
+
 	// DefaultMinHealthyPeriod defines the default minimum period before we consider a remediation on a
 	// machine unrelated from the previous remediation.
 	DefaultMinHealthyPeriod = 1 * time.Hour
+
+	// DefaultEtcdDefragmentationFragmentationPercentageThreshold defines the default minimum percentage of the
+	// etcd DB size not in use for an etcd member to be defragmented.
+	DefaultEtcdDefragmentationFragmentationPercentageThreshold = 50
+
+	// DefaultEtcdDefragmentationMinInterval defines the default minimum interval between two defragmentations
+	// of the same etcd member.
+	DefaultEtcdDefragmentationMinInterval = 24 * time.Hour
 )
 
 // KubeadmControlPlaneSpec defines the desired state of KubeadmControlPlane.
@@ -117,6 +131,22 @@ type KubeadmControlPlaneSpec struct {
 	// The RemediationStrategy that controls how control plane machine remediation happens.
 	// +optional
 	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// EtcdMaintenance defines the maintenance operations KCP performs on the etcd cluster,
+	// e.g. defragmentation of the etcd members and management of the etcd alarms.
+	// If not set, KCP does not perform any maintenance operation on the etcd cluster.
+	// NOTE: This applies only when using an etcd cluster managed by KCP (stacked etcd).
+	// +optional
+	EtcdMaintenance *EtcdMaintenance `json:"etcdMaintenance,omitempty"`
+
+	// KubeconfigEndpoint is the endpoint used as server in the kubeconfig Secret that KCP generates for the Cluster,
+	// e.g. a new load balancer hostname when moving the Cluster to a new load balancer; when the endpoint changes
+	// the kubeconfig Secret is regenerated.
+	// If not set, the Cluster control plane endpoint is used.
+	// NOTE: The host must be included in kubeadmConfigSpec.clusterConfiguration.apiServer.certSANs, so it is
+	// part of the API server serving certificate.
+	// +optional
+	KubeconfigEndpoint *clusterv1.APIEndpoint `json:"kubeconfigEndpoint,omitempty"`
 }
 
 // KubeadmControlPlaneMachineTemplate defines the template for Machines
@@ -147,6 +177,25 @@ type KubeadmControlPlaneMachineTemplate struct {
 	// If no value is provided, the default value for this property of the Machine resource will be used.
 	// +optional
 	NodeDeletionTimeout *metav1.Duration `json:"nodeDeletionTimeout,omitempty"`
+
+	// FailureDomains defines overrides of the machine template for the control plane machines created in
+	// specific failure domains, e.g. to use different machine images or subnets in each zone.
+	// Control plane machines created in failure domains without an override use InfrastructureRef.
+	// +optional
+	// +listType=map
+	// +listMapKey=name
+	FailureDomains []KubeadmControlPlaneMachineTemplateFailureDomain `json:"failureDomains,omitempty"`
+}
+
+// KubeadmControlPlaneMachineTemplateFailureDomain defines the overrides of the machine template for the
+// control plane machines created in a failure domain.
+type KubeadmControlPlaneMachineTemplateFailureDomain struct {
+	// Name is the name of the failure domain, as reported by the Cluster in status.failureDomains.
+	Name string `json:"name"`
+
+	// InfrastructureRef is a reference to a custom resource offered by an infrastructure provider, which is used
+	// instead of the InfrastructureRef of the machine template for the control plane machines in the failure domain.
+	InfrastructureRef corev1.ObjectReference `json:"infrastructureRef"`
 }
 
 // RolloutBefore describes when a rollout should be performed on the KCP machines.
@@ -180,8 +229,36 @@ type RollingUpdate struct {
 	// Defaults to 1.
 	// Example: when this is set to 1, the control plane can be scaled
 	// up immediately when the rolling update starts.
+	// When this is set to 0, each outdated machine is deleted before its replacement is created,
+	// e.g. for environments that cannot run an additional control plane machine; this requires
+	// at least 3 replicas, and an outdated machine is deleted only if etcd keeps quorum without it.
 	// +optional
 	MaxSurge *intstr.IntOrString `json:"maxSurge,omitempty"`
+
+	// The maximum number of control plane machines that can be deleted concurrently
+	// during the rolling update.
+	// Value can be an absolute number greater than or equal to 1.
+	// Defaults to 1.
+	// Values greater than 1 are only allowed for control planes with 5 or more replicas;
+	// in any case KCP never deletes more machines concurrently than the number of etcd
+	// members the control plane can lose while preserving quorum, i.e. (replicas-1)/2.
+	// Example: when this is set to 2 on a control plane with 5 replicas, two outdated
+	// machines are deleted concurrently, thus halving the duration of the rolling update.
+	// +optional
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable,omitempty"`
+
+	// SoakDuration is the minimum amount of time the up-to-date control plane machines must have been
+	// continuously healthy before KCP proceeds with the replacement of the next outdated machine.
+	// A machine is healthy when it has a Node and all its health conditions are true, i.e. the API server
+	// pod is ready (which implies the /readyz endpoint of the new API server reports ready), the controller
+	// manager and scheduler pods are healthy and, in case of managed etcd, the etcd pod is healthy and the
+	// etcd member is healthy and has no alarms.
+	// Defaults to 0, i.e. the next machine is replaced as soon as the health conditions are true.
+	// Example: when this is set to 10m, KCP waits for a new control plane machine to be healthy for 10 minutes
+	// before deleting the next outdated machine, thus reducing the risk of rolling out a bad version
+	// to the entire control plane.
+	// +optional
+	SoakDuration *metav1.Duration `json:"soakDuration,omitempty"`
 }
 
 // RemediationStrategy allows to define how control plane machine remediation happens.
@@ -228,6 +305,50 @@ type RemediationStrategy struct {
 	MinHealthyPeriod *metav1.Duration `json:"minHealthyPeriod,omitempty"`
 }
 
+// EtcdMaintenance defines the maintenance operations KCP performs on the etcd cluster.
+type EtcdMaintenance struct {
+	// Defragmentation configures the defragmentation of the etcd members.
+	// Etcd members are defragmented one at a time, only when the control plane is stable, i.e. no
+	// rollout, scaling or remediation is in progress and all the etcd members are reachable.
+	// Followers are defragmented first; when the leader needs defragmentation, the leadership is moved
+	// to a follower before defragmenting it. Etcd clusters with a single member are not defragmented.
+	// If not set, etcd members are not defragmented by KCP.
+	// +optional
+	Defragmentation *EtcdDefragmentation `json:"defragmentation,omitempty"`
+
+	// DisarmNoSpaceAlarms instructs KCP to disarm the NOSPACE alarms raised by the etcd members once
+	// no etcd member needs defragmentation anymore; if the etcd DB size still exceeds the quota after the
+	// defragmentation, etcd raises the alarm again.
+	// This requires Defragmentation to be set, given that defragmentation is the way to reclaim
+	// space in the etcd DB.
+	// +optional
+	DisarmNoSpaceAlarms bool `json:"disarmNoSpaceAlarms,omitempty"`
+}
+
+// EtcdDefragmentation defines when etcd members are defragmented.
+// An etcd member is defragmented when its DB size is greater than or equal to DBSizeThreshold and the
+// percentage of the DB size not in use is greater than or equal to FragmentationPercentageThreshold, or
+// when the member has a NOSPACE alarm; in any case a member is not defragmented more than once in MinInterval.
+type EtcdDefragmentation struct {
+	// DBSizeThreshold is the minimum size of the DB of an etcd member for the member to be defragmented.
+	// Defaults to 0, i.e. members are defragmented only depending on the FragmentationPercentageThreshold.
+	// +optional
+	DBSizeThreshold *resource.Quantity `json:"dbSizeThreshold,omitempty"`
+
+	// FragmentationPercentageThreshold is the minimum percentage of the DB size not in use, i.e. the
+	// space that can be reclaimed by defragmentation, for an etcd member to be defragmented.
+	// Defaults to 50.
+	// +optional
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=100
+	FragmentationPercentageThreshold *int32 `json:"fragmentationPercentageThreshold,omitempty"`
+
+	// MinInterval is the minimum interval between two defragmentations of the same etcd member.
+	// Defaults to 24h.
+	// +optional
+	MinInterval *metav1.Duration `json:"minInterval,omitempty"`
+}
+
 // KubeadmControlPlaneStatus defines the observed state of KubeadmControlPlane.
 type KubeadmControlPlaneStatus struct {
 	// Selector is the label selector in string format to avoid introspection
@@ -316,6 +437,7 @@ type LastRemediationStatus struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:resource:path=kubeadmcontrolplanes,shortName=kcp,scope=Namespaced,categories=cluster-api
+// +kubebuilder:metadata:labels="clusterctl.cluster.x-k8s.io/paused-condition=true"
 // +kubebuilder:storageversion
 // +kubebuilder:subresource:status
 // +kubebuilder:subresource:scale:specpath=.spec.replicas,statuspath=.status.replicas,selectorpath=.status.selector
@@ -101,6 +101,13 @@ type KubeadmControlPlaneTemplateResourceSpec struct {
 	// The RemediationStrategy that controls how control plane machine remediation happens.
 	// +optional
 	RemediationStrategy *RemediationStrategy `json:"remediationStrategy,omitempty"`
+
+	// EtcdMaintenance defines the maintenance operations KCP performs on the etcd cluster,
+	// e.g. defragmentation of the etcd members and management of the etcd alarms.
+	// If not set, KCP does not perform any maintenance operation on the etcd cluster.
+	// NOTE: This applies only when using an etcd cluster managed by KCP (stacked etcd).
+	// +optional
+	EtcdMaintenance *EtcdMaintenance `json:"etcdMaintenance,omitempty"`
 }
 
 // KubeadmControlPlaneTemplateMachineTemplate defines the template for Machines
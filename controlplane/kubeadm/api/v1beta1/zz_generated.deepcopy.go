@@ -27,6 +27,56 @@ import (
 	apiv1beta1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdDefragmentation) DeepCopyInto(out *EtcdDefragmentation) {
+	*out = *in
+	if in.DBSizeThreshold != nil {
+		in, out := &in.DBSizeThreshold, &out.DBSizeThreshold
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.FragmentationPercentageThreshold != nil {
+		in, out := &in.FragmentationPercentageThreshold, &out.FragmentationPercentageThreshold
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MinInterval != nil {
+		in, out := &in.MinInterval, &out.MinInterval
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdDefragmentation.
+func (in *EtcdDefragmentation) DeepCopy() *EtcdDefragmentation {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdDefragmentation)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EtcdMaintenance) DeepCopyInto(out *EtcdMaintenance) {
+	*out = *in
+	if in.Defragmentation != nil {
+		in, out := &in.Defragmentation, &out.Defragmentation
+		*out = new(EtcdDefragmentation)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EtcdMaintenance.
+func (in *EtcdMaintenance) DeepCopy() *EtcdMaintenance {
+	if in == nil {
+		return nil
+	}
+	out := new(EtcdMaintenance)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlane) DeepCopyInto(out *KubeadmControlPlane) {
 	*out = *in
@@ -106,6 +156,11 @@ func (in *KubeadmControlPlaneMachineTemplate) DeepCopyInto(out *KubeadmControlPl
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.FailureDomains != nil {
+		in, out := &in.FailureDomains, &out.FailureDomains
+		*out = make([]KubeadmControlPlaneMachineTemplateFailureDomain, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneMachineTemplate.
@@ -118,6 +173,22 @@ func (in *KubeadmControlPlaneMachineTemplate) DeepCopy() *KubeadmControlPlaneMac
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubeadmControlPlaneMachineTemplateFailureDomain) DeepCopyInto(out *KubeadmControlPlaneMachineTemplateFailureDomain) {
+	*out = *in
+	out.InfrastructureRef = in.InfrastructureRef
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneMachineTemplateFailureDomain.
+func (in *KubeadmControlPlaneMachineTemplateFailureDomain) DeepCopy() *KubeadmControlPlaneMachineTemplateFailureDomain {
+	if in == nil {
+		return nil
+	}
+	out := new(KubeadmControlPlaneMachineTemplateFailureDomain)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 	*out = *in
@@ -147,6 +218,16 @@ func (in *KubeadmControlPlaneSpec) DeepCopyInto(out *KubeadmControlPlaneSpec) {
 		*out = new(RemediationStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EtcdMaintenance != nil {
+		in, out := &in.EtcdMaintenance, &out.EtcdMaintenance
+		*out = new(EtcdMaintenance)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.KubeconfigEndpoint != nil {
+		in, out := &in.KubeconfigEndpoint, &out.KubeconfigEndpoint
+		*out = new(apiv1beta1.APIEndpoint)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneSpec.
@@ -330,6 +411,11 @@ func (in *KubeadmControlPlaneTemplateResourceSpec) DeepCopyInto(out *KubeadmCont
 		*out = new(RemediationStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.EtcdMaintenance != nil {
+		in, out := &in.EtcdMaintenance, &out.EtcdMaintenance
+		*out = new(EtcdMaintenance)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubeadmControlPlaneTemplateResourceSpec.
@@ -408,6 +494,16 @@ func (in *RollingUpdate) DeepCopyInto(out *RollingUpdate) {
 		*out = new(intstr.IntOrString)
 		**out = **in
 	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.SoakDuration != nil {
+		in, out := &in.SoakDuration, &out.SoakDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RollingUpdate.
@@ -26,6 +26,7 @@ import (
 
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/controllers"
+	"sigs.k8s.io/cluster-api/util/secret"
 )
 
 // KubeadmControlPlaneReconciler reconciles a KubeadmControlPlane object.
@@ -37,6 +38,14 @@ type KubeadmControlPlaneReconciler struct {
 	EtcdDialTimeout time.Duration
 	EtcdCallTimeout time.Duration
 
+	// KubeconfigClientCertRenewalWindow defines how long before its expiry the client certificate in the kubeconfig
+	// Secret of the Cluster is rotated.
+	KubeconfigClientCertRenewalWindow time.Duration
+
+	// CertificatesStore is the store used to read and save cluster certificates.
+	// If nil, certificates are stored in Kubernetes Secrets.
+	CertificatesStore secret.Store
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 }
@@ -50,5 +59,27 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 		EtcdDialTimeout:     r.EtcdDialTimeout,
 		EtcdCallTimeout:     r.EtcdCallTimeout,
 		WatchFilterValue:    r.WatchFilterValue,
+
+		KubeconfigClientCertRenewalWindow: r.KubeconfigClientCertRenewalWindow,
+		CertificatesStore:                 r.CertificatesStore,
+	}).SetupWithManager(ctx, mgr, options)
+}
+
+// KubeletServingCSRReconciler approves kubelet serving CertificateSigningRequests created by Nodes of Machines
+// in clusters with a KubeadmControlPlane.
+type KubeletServingCSRReconciler struct {
+	Client  client.Client
+	Tracker *remote.ClusterCacheTracker
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+// SetupWithManager sets up the reconciler with the Manager.
+func (r *KubeletServingCSRReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&kubeadmcontrolplanecontrollers.KubeletServingCSRReconciler{
+		Client:           r.Client,
+		Tracker:          r.Tracker,
+		WatchFilterValue: r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
 }
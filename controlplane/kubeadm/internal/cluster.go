@@ -20,12 +20,9 @@ import (
 	"context"
 	"crypto/tls"
 	"crypto/x509"
-	"fmt"
 	"time"
 
 	"github.com/pkg/errors"
-	corev1 "k8s.io/api/core/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/client-go/rest"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -52,11 +49,11 @@ type ManagementCluster interface {
 
 // Management holds operations on the management cluster.
 type Management struct {
-	Client              client.Reader
-	SecretCachingClient client.Reader
-	Tracker             *remote.ClusterCacheTracker
-	EtcdDialTimeout     time.Duration
-	EtcdCallTimeout     time.Duration
+	Client            client.Reader
+	CertificatesStore secret.Store
+	Tracker           *remote.ClusterCacheTracker
+	EtcdDialTimeout   time.Duration
+	EtcdCallTimeout   time.Duration
 }
 
 // RemoteClusterConnectionError represents a failure to connect to a remote cluster.
@@ -129,7 +126,7 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.O
 	// the apiserver-etcd-client certificate.
 	// TODO: consider if we can detect if we are using external etcd in a more explicit way (e.g. looking at the config instead of deriving from the existing certificates)
 	var clientCert tls.Certificate
-	if keyData != nil {
+	if len(keyData) > 0 {
 		clientKey, err := m.Tracker.GetEtcdClientCertificateKey(ctx, clusterKey)
 		if err != nil {
 			return nil, err
@@ -163,50 +160,20 @@ func (m *Management) GetWorkloadCluster(ctx context.Context, clusterKey client.O
 }
 
 func (m *Management) getEtcdCAKeyPair(ctx context.Context, clusterKey client.ObjectKey) ([]byte, []byte, error) {
-	etcdCASecret := &corev1.Secret{}
-	etcdCAObjectKey := client.ObjectKey{
-		Namespace: clusterKey.Namespace,
-		Name:      fmt.Sprintf("%s-etcd", clusterKey.Name),
+	etcdCA := &secret.Certificate{Purpose: secret.EtcdCA}
+	if err := m.CertificatesStore.Get(ctx, clusterKey, etcdCA); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get etcd CA bundle for cluster %s/%s", clusterKey.Namespace, clusterKey.Name)
 	}
-
-	// Try to get the certificate via the cached client.
-	err := m.SecretCachingClient.Get(ctx, etcdCAObjectKey, etcdCASecret)
-	if err != nil {
-		if !apierrors.IsNotFound(err) {
-			// Return error if we got an errors which is not a NotFound error.
-			return nil, nil, errors.Wrapf(err, "failed to get secret; etcd CA bundle %s/%s", etcdCAObjectKey.Namespace, etcdCAObjectKey.Name)
-		}
-
-		// Try to get the certificate via the uncached client.
-		if err := m.Client.Get(ctx, etcdCAObjectKey, etcdCASecret); err != nil {
-			return nil, nil, errors.Wrapf(err, "failed to get secret; etcd CA bundle %s/%s", etcdCAObjectKey.Namespace, etcdCAObjectKey.Name)
-		}
-	}
-
-	crtData, ok := etcdCASecret.Data[secret.TLSCrtDataName]
-	if !ok {
-		return nil, nil, errors.Errorf("etcd tls crt does not exist for cluster %s/%s", clusterKey.Namespace, clusterKey.Name)
-	}
-	keyData := etcdCASecret.Data[secret.TLSKeyDataName]
-	return crtData, keyData, nil
+	return etcdCA.KeyPair.Cert, etcdCA.KeyPair.Key, nil
 }
 
 func (m *Management) getAPIServerEtcdClientCert(ctx context.Context, clusterKey client.ObjectKey) (tls.Certificate, error) {
-	apiServerEtcdClientCertificateSecret := &corev1.Secret{}
-	apiServerEtcdClientCertificateObjectKey := client.ObjectKey{
-		Namespace: clusterKey.Namespace,
-		Name:      fmt.Sprintf("%s-apiserver-etcd-client", clusterKey.Name),
-	}
-	if err := m.Client.Get(ctx, apiServerEtcdClientCertificateObjectKey, apiServerEtcdClientCertificateSecret); err != nil {
-		return tls.Certificate{}, errors.Wrapf(err, "failed to get secret; etcd apiserver-etcd-client %s/%s", apiServerEtcdClientCertificateObjectKey.Namespace, apiServerEtcdClientCertificateObjectKey.Name)
-	}
-	crtData, ok := apiServerEtcdClientCertificateSecret.Data[secret.TLSCrtDataName]
-	if !ok {
-		return tls.Certificate{}, errors.Errorf("etcd tls crt does not exist for cluster %s/%s", clusterKey.Namespace, clusterKey.Name)
+	apiServerEtcdClient := &secret.Certificate{Purpose: secret.APIServerEtcdClient}
+	if err := m.CertificatesStore.Get(ctx, clusterKey, apiServerEtcdClient); err != nil {
+		return tls.Certificate{}, errors.Wrapf(err, "failed to get etcd apiserver-etcd-client certificate for cluster %s/%s", clusterKey.Namespace, clusterKey.Name)
 	}
-	keyData, ok := apiServerEtcdClientCertificateSecret.Data[secret.TLSKeyDataName]
-	if !ok {
+	if len(apiServerEtcdClient.KeyPair.Key) == 0 {
 		return tls.Certificate{}, errors.Errorf("etcd tls key does not exist for cluster %s/%s", clusterKey.Namespace, clusterKey.Name)
 	}
-	return tls.X509KeyPair(crtData, keyData)
+	return tls.X509KeyPair(apiServerEtcdClient.KeyPair.Cert, apiServerEtcdClient.KeyPair.Key)
 }
@@ -202,9 +202,9 @@ func TestGetWorkloadCluster(t *testing.T) {
 			g.Expect(err).ToNot(HaveOccurred())
 
 			m := Management{
-				Client:              env.GetClient(),
-				SecretCachingClient: secretCachingClient,
-				Tracker:             tracker,
+				Client:            env.GetClient(),
+				CertificatesStore: secret.NewSecretStore(secretCachingClient, env.GetClient()),
+				Tracker:           tracker,
 			}
 
 			workloadCluster, err := m.GetWorkloadCluster(ctx, tt.clusterKey)
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/failuredomains"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
@@ -160,6 +161,36 @@ func (c *ControlPlane) HasDeletingMachine() bool {
 	return len(c.Machines.Filter(collections.HasDeletionTimestamp)) > 0
 }
 
+// MaxConcurrentDeletions returns the number of outdated machines that can be deleted concurrently during a rollout.
+// The value is defined by rolloutStrategy.rollingUpdate.maxUnavailable, but it is only honored for control planes
+// with 5 or more replicas and it is capped to the number of etcd members the control plane can lose while
+// preserving quorum, i.e. (replicas-1)/2.
+func (c *ControlPlane) MaxConcurrentDeletions() int {
+	if c.KCP.Spec.Replicas == nil || c.KCP.Spec.RolloutStrategy == nil || c.KCP.Spec.RolloutStrategy.RollingUpdate == nil ||
+		c.KCP.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable == nil {
+		return 1
+	}
+
+	replicas := int(*c.KCP.Spec.Replicas)
+	maxUnavailable := c.KCP.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable.IntValue()
+	if replicas < 5 || maxUnavailable <= 1 {
+		return 1
+	}
+	if limit := (replicas - 1) / 2; maxUnavailable > limit {
+		return limit
+	}
+	return maxUnavailable
+}
+
+// Quorum returns the minimum number of control plane machines that must be available to preserve
+// etcd quorum for the desired number of replicas.
+func (c *ControlPlane) Quorum() int {
+	if c.KCP.Spec.Replicas == nil {
+		return 1
+	}
+	return int(*c.KCP.Spec.Replicas)/2 + 1
+}
+
 // GetKubeadmConfig returns the KubeadmConfig of a given machine.
 func (c *ControlPlane) GetKubeadmConfig(machineName string) (*bootstrapv1.KubeadmConfig, bool) {
 	kubeadmConfig, ok := c.KubeadmConfigs[machineName]
@@ -197,6 +228,22 @@ func (c *ControlPlane) UpToDateMachines() collections.Machines {
 	return upToDateMachines
 }
 
+// SetMachinesUpToDateCondition sets the UpToDate condition on the control plane machines not being deleted,
+// reporting whether they match the current configuration of the control plane or need to be rolled out.
+func (c *ControlPlane) SetMachinesUpToDateCondition() {
+	for _, m := range c.Machines {
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		reason, needsRollout := NeedsRollout(&c.reconciliationTime, c.KCP.Spec.RolloutAfter, c.KCP.Spec.RolloutBefore, c.InfraResources, c.KubeadmConfigs, c.KCP, m)
+		if needsRollout {
+			conditions.MarkFalse(m, clusterv1.MachineUpToDateCondition, clusterv1.MachineNotUpToDateReason, clusterv1.ConditionSeverityInfo, reason)
+			continue
+		}
+		conditions.MarkTrue(m, clusterv1.MachineUpToDateCondition)
+	}
+}
+
 // getInfraResources fetches the external infrastructure resource for each machine in the collection and returns a map of machine.Name -> infraResource.
 func getInfraResources(ctx context.Context, cl client.Client, machines collections.Machines) (map[string]*unstructured.Unstructured, error) {
 	result := map[string]*unstructured.Unstructured{}
@@ -265,6 +312,7 @@ func (c *ControlPlane) PatchMachines(ctx context.Context) error {
 				controlplanev1.MachineSchedulerPodHealthyCondition,
 				controlplanev1.MachineEtcdPodHealthyCondition,
 				controlplanev1.MachineEtcdMemberHealthyCondition,
+				clusterv1.MachineUpToDateCondition,
 			}}); err != nil {
 				errList = append(errList, errors.Wrapf(err, "failed to patch machine %s", machine.Name))
 			}
@@ -18,9 +18,12 @@ package internal
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
@@ -62,6 +65,65 @@ func TestControlPlane(t *testing.T) {
 	})
 }
 
+func TestMaxConcurrentDeletions(t *testing.T) {
+	tests := []struct {
+		name           string
+		replicas       *int32
+		maxUnavailable *intstr.IntOrString
+		want           int
+	}{
+		{
+			name:     "defaults to 1 when maxUnavailable is not set",
+			replicas: pointer.Int32(5),
+			want:     1,
+		},
+		{
+			name:           "returns 1 for control planes with less than 5 replicas",
+			replicas:       pointer.Int32(3),
+			maxUnavailable: &intstr.IntOrString{IntVal: 2},
+			want:           1,
+		},
+		{
+			name:           "returns maxUnavailable for control planes with 5 or more replicas",
+			replicas:       pointer.Int32(5),
+			maxUnavailable: &intstr.IntOrString{IntVal: 2},
+			want:           2,
+		},
+		{
+			name:           "caps maxUnavailable to preserve etcd quorum",
+			replicas:       pointer.Int32(5),
+			maxUnavailable: &intstr.IntOrString{IntVal: 3},
+			want:           2,
+		},
+		{
+			name:           "allows more concurrent deletions for bigger control planes",
+			replicas:       pointer.Int32(7),
+			maxUnavailable: &intstr.IntOrString{IntVal: 3},
+			want:           3,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := ControlPlane{
+				KCP: &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						Replicas: tt.replicas,
+						RolloutStrategy: &controlplanev1.RolloutStrategy{
+							Type: controlplanev1.RollingUpdateStrategyType,
+							RollingUpdate: &controlplanev1.RollingUpdate{
+								MaxUnavailable: tt.maxUnavailable,
+							},
+						},
+					},
+				},
+			}
+			g.Expect(c.MaxConcurrentDeletions()).To(Equal(tt.want))
+		})
+	}
+}
+
 func TestHasUnhealthyMachine(t *testing.T) {
 	// healthy machine (without MachineHealthCheckSucceded condition)
 	healthyMachine1 := &clusterv1.Machine{}
@@ -89,6 +151,33 @@ func TestHasUnhealthyMachine(t *testing.T) {
 	g.Expect(c.HasUnhealthyMachine()).To(BeTrue())
 }
 
+func TestSetMachinesUpToDateCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	upToDate := machine("up-to-date", withVersion("v1.27.0"))
+	outdated := machine("outdated", withVersion("v1.26.0"))
+	deleting := machine("deleting", withVersion("v1.26.0"))
+	deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+
+	c := ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				Version: "v1.27.0",
+			},
+		},
+		Machines: collections.FromMachines(upToDate, outdated, deleting),
+	}
+	c.SetMachinesUpToDateCondition()
+
+	g.Expect(conditions.IsTrue(upToDate, clusterv1.MachineUpToDateCondition)).To(BeTrue())
+
+	g.Expect(conditions.IsFalse(outdated, clusterv1.MachineUpToDateCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(outdated, clusterv1.MachineUpToDateCondition)).To(Equal(clusterv1.MachineNotUpToDateReason))
+	g.Expect(conditions.GetMessage(outdated, clusterv1.MachineUpToDateCondition)).To(ContainSubstring(`Machine version "v1.26.0" is not equal to KCP version "v1.27.0"`))
+
+	g.Expect(conditions.Has(deleting, clusterv1.MachineUpToDateCondition)).To(BeFalse())
+}
+
 type machineOpt func(*clusterv1.Machine)
 
 func failureDomain(controlPlane bool) clusterv1.FailureDomainSpec {
@@ -103,6 +192,12 @@ func withFailureDomain(fd string) machineOpt {
 	}
 }
 
+func withVersion(version string) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.Spec.Version = &version
+	}
+}
+
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	m := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
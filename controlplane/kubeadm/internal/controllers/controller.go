@@ -20,6 +20,7 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/blang/semver/v4"
@@ -48,11 +49,12 @@ import (
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	capirecord "sigs.k8s.io/cluster-api/util/record"
 	"sigs.k8s.io/cluster-api/util/secret"
 	"sigs.k8s.io/cluster-api/util/version"
 )
@@ -80,12 +82,24 @@ type KubeadmControlPlaneReconciler struct {
 	EtcdDialTimeout time.Duration
 	EtcdCallTimeout time.Duration
 
+	// KubeconfigClientCertRenewalWindow defines how long before its expiry the client certificate in the kubeconfig
+	// Secret of the Cluster is rotated; if zero, it is rotated after half of its validity.
+	KubeconfigClientCertRenewalWindow time.Duration
+
+	// CertificatesStore is the store used to read and save cluster certificates.
+	// If nil, certificates are stored in Kubernetes Secrets.
+	CertificatesStore secret.Store
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
 	managementCluster         internal.ManagementCluster
 	managementClusterUncached internal.ManagementCluster
 	ssaCache                  ssa.Cache
+
+	// etcdMaintenanceNextInspection stores, for each KubeadmControlPlane UID, the time when the etcd members
+	// should be inspected again to determine if maintenance operations are required.
+	etcdMaintenanceNextInspection sync.Map
 }
 
 func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -93,14 +107,17 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 		For(&controlplanev1.KubeadmControlPlane{}).
 		Owns(&clusterv1.Machine{}).
 		WithOptions(options).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.ClusterToKubeadmControlPlane),
 			builder.WithPredicates(
 				predicates.All(ctrl.LoggerFrom(ctx),
 					predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
-					predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+					predicates.Any(ctrl.LoggerFrom(ctx),
+						predicates.ClusterUnpausedAndInfrastructureReady(ctrl.LoggerFrom(ctx)),
+						predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+					),
 				),
 			),
 		).Build(r)
@@ -109,7 +126,7 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 	}
 
 	r.controller = c
-	r.recorder = mgr.GetEventRecorderFor("kubeadm-control-plane-controller")
+	r.recorder = capirecord.NewDeduplicatingRecorder(mgr.GetEventRecorderFor("kubeadm-control-plane-controller"), capirecord.DefaultDeduplicationWindow)
 	r.ssaCache = ssa.NewCache()
 
 	if r.managementCluster == nil {
@@ -117,11 +134,11 @@ func (r *KubeadmControlPlaneReconciler) SetupWithManager(ctx context.Context, mg
 			return errors.New("cluster cache tracker is nil, cannot create the internal management cluster resource")
 		}
 		r.managementCluster = &internal.Management{
-			Client:              r.Client,
-			SecretCachingClient: r.SecretCachingClient,
-			Tracker:             r.Tracker,
-			EtcdDialTimeout:     r.EtcdDialTimeout,
-			EtcdCallTimeout:     r.EtcdCallTimeout,
+			Client:            r.Client,
+			CertificatesStore: r.certificatesStore(),
+			Tracker:           r.Tracker,
+			EtcdDialTimeout:   r.EtcdDialTimeout,
+			EtcdCallTimeout:   r.EtcdCallTimeout,
 		}
 	}
 
@@ -139,6 +156,7 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 	kcp := &controlplanev1.KubeadmControlPlane{}
 	if err := r.Client.Get(ctx, req.NamespacedName, kcp); err != nil {
 		if apierrors.IsNotFound(err) {
+			deleteKubeadmControlPlaneMetrics(req.NamespacedName)
 			return ctrl.Result{}, nil
 		}
 		return ctrl.Result{Requeue: true}, nil
@@ -157,7 +175,12 @@ func (r *KubeadmControlPlaneReconciler) Reconcile(ctx context.Context, req ctrl.
 	log = log.WithValues("Cluster", klog.KObj(cluster))
 	ctx = ctrl.LoggerInto(ctx, log)
 
-	if annotations.IsPaused(cluster, kcp) {
+	// Set or remove the Paused condition, then return early if the object or Cluster is paused.
+	isPaused, _, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, kcp)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -329,6 +352,7 @@ func patchKubeadmControlPlane(ctx context.Context, patchHelper *patch.Helper, kc
 			controlplanev1.MachinesReadyCondition,
 			controlplanev1.AvailableCondition,
 			controlplanev1.CertificatesAvailableCondition,
+			controlplanev1.EtcdMaintenanceSucceededCondition,
 		}},
 		patch.WithStatusObservedGeneration{},
 	)
@@ -339,10 +363,15 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Reconcile KubeadmControlPlane")
 
-	// Make sure to reconcile the external infrastructure reference.
+	// Make sure to reconcile the external infrastructure references, including the failure domain overrides.
 	if err := r.reconcileExternalReference(ctx, controlPlane.Cluster, &controlPlane.KCP.Spec.MachineTemplate.InfrastructureRef); err != nil {
 		return ctrl.Result{}, err
 	}
+	for i := range controlPlane.KCP.Spec.MachineTemplate.FailureDomains {
+		if err := r.reconcileExternalReference(ctx, controlPlane.Cluster, &controlPlane.KCP.Spec.MachineTemplate.FailureDomains[i].InfrastructureRef); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
 
 	// Wait for the cluster infrastructure to be ready before creating machines
 	if !controlPlane.Cluster.Status.InfrastructureReady {
@@ -377,7 +406,8 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	// source ref (reason@machine/name) so the problem can be easily tracked down to its source machine.
 	conditions.SetAggregate(controlPlane.KCP, controlplanev1.MachinesReadyCondition, controlPlane.Machines.ConditionGetters(), conditions.AddSourceRef(), conditions.WithStepCounterIf(false))
 
-	// Updates conditions reporting the status of static pods and the status of the etcd cluster.
+	// Updates conditions reporting the status of static pods and the status of the etcd cluster, and
+	// the UpToDate condition on the machines.
 	// NOTE: Conditions reporting KCP operation progress like e.g. Resized or SpecUpToDate are inlined with the rest of the execution.
 	if err := r.reconcileControlPlaneConditions(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
@@ -397,6 +427,7 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 
 	// Control plane machines rollout due to configuration changes (e.g. upgrades) takes precedence over other operations.
 	machinesNeedingRollout, rolloutReasons := controlPlane.MachinesNeedingRollout()
+	updateRolloutMetrics(client.ObjectKeyFromObject(controlPlane.KCP), controlPlane.Cluster.Name, len(machinesNeedingRollout) > 0, time.Now())
 	switch {
 	case len(machinesNeedingRollout) > 0:
 		var reasons []string
@@ -475,7 +506,11 @@ func (r *KubeadmControlPlaneReconciler) reconcile(ctx context.Context, controlPl
 	if err := r.reconcileCertificateExpiries(ctx, controlPlane); err != nil {
 		return ctrl.Result{}, err
 	}
-	return ctrl.Result{}, nil
+
+	// Perform the etcd maintenance operations, if configured.
+	// Note: This is done at the end of the reconcile, when no rollout, scaling or remediation is in progress,
+	// given that maintenance operations like defragmentation temporarily impact the etcd members.
+	return r.reconcileEtcdMaintenance(ctx, controlPlane)
 }
 
 // reconcileClusterCertificates ensures that all the cluster certificates exists and
@@ -491,7 +526,7 @@ func (r *KubeadmControlPlaneReconciler) reconcileClusterCertificates(ctx context
 	}
 	certificates := secret.NewCertificatesForInitialControlPlane(config.ClusterConfiguration)
 	controllerRef := metav1.NewControllerRef(controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
-	if err := certificates.LookupOrGenerateCached(ctx, r.SecretCachingClient, r.Client, util.ObjectKey(controlPlane.Cluster), *controllerRef); err != nil {
+	if err := certificates.LookupOrGenerateFromStore(ctx, r.certificatesStore(), util.ObjectKey(controlPlane.Cluster), *controllerRef); err != nil {
 		log.Error(err, "unable to lookup or create cluster certificates")
 		conditions.MarkFalse(controlPlane.KCP, controlplanev1.CertificatesAvailableCondition, controlplanev1.CertificatesGenerationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 		return err
@@ -505,6 +540,14 @@ func (r *KubeadmControlPlaneReconciler) reconcileClusterCertificates(ctx context
 	return nil
 }
 
+// certificatesStore returns the store used to read and save cluster certificates.
+func (r *KubeadmControlPlaneReconciler) certificatesStore() secret.Store {
+	if r.CertificatesStore != nil {
+		return r.CertificatesStore
+	}
+	return secret.NewSecretStore(r.SecretCachingClient, r.Client)
+}
+
 // reconcileDelete handles KubeadmControlPlane deletion.
 // The implementation does not take non-control plane workloads into consideration. This may or may not change in the future.
 // Please see https://github.com/kubernetes-sigs/cluster-api/issues/2064.
@@ -512,6 +555,9 @@ func (r *KubeadmControlPlaneReconciler) reconcileDelete(ctx context.Context, con
 	log := ctrl.LoggerFrom(ctx)
 	log.Info("Reconcile KubeadmControlPlane deletion")
 
+	// Forget about the etcd maintenance of the KubeadmControlPlane being deleted.
+	r.etcdMaintenanceNextInspection.Delete(controlPlane.KCP.UID)
+
 	// If no control plane machines remain, remove the finalizer
 	if len(controlPlane.Machines) == 0 {
 		controllerutil.RemoveFinalizer(controlPlane.KCP, controlplanev1.KubeadmControlPlaneFinalizer)
@@ -564,7 +610,7 @@ func (r *KubeadmControlPlaneReconciler) reconcileDelete(ctx context.Context, con
 	}
 	if len(errs) > 0 {
 		err := kerrors.NewAggregate(errs)
-		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedDelete",
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.DeleteFailedEventReason,
 			"Failed to delete control plane Machines for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
 		return ctrl.Result{}, err
 	}
@@ -611,6 +657,11 @@ func (r *KubeadmControlPlaneReconciler) syncMachines(ctx context.Context, contro
 		if err := ssa.CleanUpManagedFieldsForSSAAdoption(ctx, r.Client, m, kcpManagerName); err != nil {
 			return errors.Wrapf(err, "failed to update Machine: failed to adjust the managedFields of the Machine %s", klog.KObj(m))
 		}
+		// Drop the ownership of fields still co-owned by "before-first-apply", the manager assigned by the API server
+		// at the first apply; otherwise, those fields would not be dropped when they are removed from KCP.
+		if err := ssa.DropCoOwnedManagedFields(ctx, r.Client, m, kcpManagerName); err != nil {
+			return errors.Wrapf(err, "failed to update Machine: failed to drop co-owned managedFields of the Machine %s", klog.KObj(m))
+		}
 		// Update Machine to propagate in-place mutable fields from KCP.
 		updatedMachine, err := r.updateMachine(ctx, m, controlPlane.KCP, controlPlane.Cluster)
 		if err != nil {
@@ -677,12 +728,16 @@ func (r *KubeadmControlPlaneReconciler) syncMachines(ctx context.Context, contro
 }
 
 // reconcileControlPlaneConditions is responsible of reconciling conditions reporting the status of static pods and
-// the status of the etcd cluster.
+// the status of the etcd cluster, as well as the UpToDate condition on the machines.
 func (r *KubeadmControlPlaneReconciler) reconcileControlPlaneConditions(ctx context.Context, controlPlane *internal.ControlPlane) error {
+	// Updates the UpToDate condition on the machines, reporting whether they match the current KCP configuration.
+	// NOTE: This does not require a connection to the workload cluster, so it is done also if the cluster is not yet initialized.
+	controlPlane.SetMachinesUpToDateCondition()
+
 	// If the cluster is not yet initialized, there is no way to connect to the workload cluster and fetch information
-	// for updating conditions. Return early.
+	// for updating conditions. Patch machines with the UpToDate condition and return early.
 	if !controlPlane.KCP.Status.Initialized {
-		return nil
+		return controlPlane.PatchMachines(ctx)
 	}
 
 	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
@@ -870,7 +925,7 @@ func (r *KubeadmControlPlaneReconciler) adoptMachines(ctx context.Context, kcp *
 		}
 
 		if !util.IsSupportedVersionSkew(kcpVersion, machineVersion) {
-			r.recorder.Eventf(kcp, corev1.EventTypeWarning, "AdoptionFailed", "Could not adopt Machine %s/%s: its version (%q) is outside supported +/- one minor version skew from KCP's (%q)", m.Namespace, m.Name, *m.Spec.Version, kcp.Spec.Version)
+			r.recorder.Eventf(kcp, corev1.EventTypeWarning, controlplanev1.AdoptionFailedEventReason, "Could not adopt Machine %s/%s: its version (%q) is outside supported +/- one minor version skew from KCP's (%q)", m.Namespace, m.Name, *m.Spec.Version, kcp.Spec.Version)
 			// avoid returning an error here so we don't cause the KCP controller to spin until the operator clarifies their intent
 			return nil
 		}
@@ -942,6 +997,7 @@ func (r *KubeadmControlPlaneReconciler) adoptOwnedSecrets(ctx context.Context, k
 }
 
 // ensureCertificatesOwnerRef ensures an ownerReference to the owner is added on the Secrets holding certificates.
+// Certificates not stored in Secrets, e.g. when using an external CertificatesStore, are ignored.
 func (r *KubeadmControlPlaneReconciler) ensureCertificatesOwnerRef(ctx context.Context, certificates secret.Certificates, owner metav1.OwnerReference) error {
 	for _, c := range certificates {
 		if c.Secret == nil {
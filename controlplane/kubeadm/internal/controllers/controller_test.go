@@ -402,6 +402,12 @@ func TestReconcilePaused(t *testing.T) {
 	g.Expect(fakeClient.List(ctx, machineList, client.InNamespace(metav1.NamespaceDefault))).To(Succeed())
 	g.Expect(machineList.Items).To(BeEmpty())
 
+	// The pause is acknowledged with the Paused condition.
+	updatedKCP := &controlplanev1.KubeadmControlPlane{}
+	g.Expect(fakeClient.Get(ctx, util.ObjectKey(kcp), updatedKCP)).To(Succeed())
+	g.Expect(conditions.IsTrue(updatedKCP, clusterv1.PausedCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(updatedKCP, clusterv1.PausedCondition)).To(Equal(clusterv1.ClusterPausedReason))
+
 	// Test: kcp is paused and cluster is not
 	cluster.Spec.Paused = false
 	kcp.ObjectMeta.Annotations = map[string]string{}
@@ -2273,6 +2279,8 @@ func TestKubeadmControlPlaneReconciler_reconcileDelete(t *testing.T) {
 			recorder: record.NewFakeRecorder(32),
 		}
 
+		r.etcdMaintenanceNextInspection.Store(kcp.UID, time.Now().Add(time.Minute))
+
 		controlPlane := &internal.ControlPlane{
 			KCP:     kcp,
 			Cluster: cluster,
@@ -2282,6 +2290,9 @@ func TestKubeadmControlPlaneReconciler_reconcileDelete(t *testing.T) {
 		g.Expect(result).To(BeComparableTo(ctrl.Result{}))
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(kcp.Finalizers).To(BeEmpty())
+
+		_, ok := r.etcdMaintenanceNextInspection.Load(kcp.UID)
+		g.Expect(ok).To(BeFalse())
 	})
 }
 
@@ -0,0 +1,270 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+const (
+	// etcdMaintenanceInspectionInterval is the minimum interval between two inspections of the etcd members
+	// when no maintenance operation is required; it avoids connecting to all the etcd members at every reconcile.
+	etcdMaintenanceInspectionInterval = 5 * time.Minute
+
+	// etcdDefragmentationSettleInterval is the minimum interval between the defragmentation of two etcd members,
+	// which gives the defragmented member time to catch up with the cluster and its health to be reported.
+	etcdDefragmentationSettleInterval = 2 * time.Minute
+)
+
+// reconcileEtcdMaintenance performs the maintenance operations defined in spec.etcdMaintenance, i.e. the
+// defragmentation of the etcd members and the disarm of the NOSPACE alarms.
+// NOTE: Maintenance operations are performed only when the control plane is stable and etcd is healthy, and only on
+// one etcd member at a time, given that an etcd member cannot serve requests while it is defragmented.
+func (r *KubeadmControlPlaneReconciler) reconcileEtcdMaintenance(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	maintenance := controlPlane.KCP.Spec.EtcdMaintenance
+	if maintenance == nil || maintenance.Defragmentation == nil || !controlPlane.IsEtcdManaged() {
+		conditions.Delete(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Skip maintenance if the control plane is not stable, e.g. if KCP is not yet initialized,
+	// or if there are machines being provisioned or deleted.
+	if !controlPlane.KCP.Status.Initialized ||
+		controlPlane.HasDeletingMachine() ||
+		controlPlane.Machines.Len() != int(*controlPlane.KCP.Spec.Replicas) {
+		return ctrl.Result{}, nil
+	}
+
+	// Skip maintenance if the etcd cluster or any of its members is not healthy, given that defragmenting
+	// a member temporarily reduces the number of members serving requests.
+	// NOTE: NOSPACE alarms to be disarmed are reported as warnings and they do not block maintenance,
+	// given that defragmenting the members and disarming the alarms is the way to resolve them.
+	if !etcdHealthyForMaintenance(controlPlane.KCP, controlplanev1.EtcdClusterHealthyCondition, maintenance) {
+		log.V(2).Info("Skipping etcd maintenance because the etcd cluster is not healthy")
+		return ctrl.Result{}, nil
+	}
+
+	now := time.Now()
+	machinesByNodeName := map[string]*clusterv1.Machine{}
+	nodeNames := []string{}
+	var latestDefragmentation time.Time
+	for _, machine := range controlPlane.Machines {
+		if machine.Status.NodeRef == nil {
+			return ctrl.Result{}, nil
+		}
+		if !etcdHealthyForMaintenance(machine, controlplanev1.MachineEtcdMemberHealthyCondition, maintenance) {
+			log.V(2).Info("Skipping etcd maintenance because an etcd member is not healthy", "Machine", klog.KObj(machine))
+			return ctrl.Result{}, nil
+		}
+		if t, ok := etcdLastDefragmentation(machine); ok && t.After(latestDefragmentation) {
+			latestDefragmentation = t
+		}
+		machinesByNodeName[machine.Status.NodeRef.Name] = machine
+		nodeNames = append(nodeNames, machine.Status.NodeRef.Name)
+	}
+
+	// Wait for the last defragmented member to settle before inspecting the members again.
+	if next := latestDefragmentation.Add(etcdDefragmentationSettleInterval).Sub(now); next > 0 {
+		return ctrl.Result{RequeueAfter: next}, nil
+	}
+
+	// Skip the inspection if no maintenance operation was required when the members were inspected recently.
+	if nextInspection, ok := r.etcdMaintenanceNextInspection.Load(controlPlane.KCP.UID); ok {
+		if next := nextInspection.(time.Time).Sub(now); next > 0 {
+			return ctrl.Result{RequeueAfter: next}, nil
+		}
+	}
+
+	workloadCluster, err := controlPlane.GetWorkloadCluster(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile etcd maintenance: cannot get remote client to workload cluster")
+	}
+
+	statuses, err := workloadCluster.EtcdMembersMaintenanceStatus(ctx, nodeNames)
+	if err != nil {
+		conditions.MarkFalse(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdMaintenanceInspectionFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile etcd maintenance")
+	}
+
+	// Select the members to be defragmented; members defragmented less than minInterval ago are skipped,
+	// and a requeue is scheduled when the first of them can be defragmented again.
+	minInterval := etcdDefragmentationMinInterval(maintenance.Defragmentation)
+	var candidates []internal.EtcdMemberMaintenanceStatus
+	var noSpaceAlarms []etcd.MemberAlarm
+	var requeueAfter time.Duration
+	for _, status := range statuses {
+		hasNoSpaceAlarm := false
+		for _, alarm := range status.Alarms {
+			if alarm == etcd.AlarmNoSpace {
+				hasNoSpaceAlarm = true
+				noSpaceAlarms = append(noSpaceAlarms, etcd.MemberAlarm{MemberID: status.MemberID, Type: alarm})
+			}
+		}
+
+		if !hasNoSpaceAlarm && !etcdMemberNeedsDefragmentation(maintenance.Defragmentation, status) {
+			continue
+		}
+
+		if lastDefragmentation, ok := etcdLastDefragmentation(machinesByNodeName[status.NodeName]); ok {
+			if next := lastDefragmentation.Add(minInterval).Sub(now); next > 0 {
+				if requeueAfter == 0 || next < requeueAfter {
+					requeueAfter = next
+				}
+				continue
+			}
+		}
+		candidates = append(candidates, status)
+	}
+
+	if len(candidates) == 0 {
+		if maintenance.DisarmNoSpaceAlarms && len(noSpaceAlarms) > 0 {
+			log.Info("Disarming etcd NOSPACE alarms", "alarms", len(noSpaceAlarms))
+			if err := workloadCluster.DisarmEtcdAlarms(ctx, nodeNames, noSpaceAlarms); err != nil {
+				conditions.MarkFalse(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdAlarmDisarmFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+				return ctrl.Result{}, errors.Wrap(err, "failed to reconcile etcd maintenance")
+			}
+		}
+		conditions.MarkTrue(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition)
+		r.etcdMaintenanceNextInspection.Store(controlPlane.KCP.UID, now.Add(etcdMaintenanceInspectionInterval))
+		return ctrl.Result{RequeueAfter: requeueAfter}, nil
+	}
+
+	// Defragment a follower; if only the leader needs defragmentation, move the leadership to another member first.
+	// NOTE: Etcd clusters with a single member are not defragmented, given that the etcd cluster would be unavailable
+	// while defragmenting its only member.
+	if len(statuses) < 2 {
+		log.V(2).Info("Skipping defragmentation of etcd clusters with a single member")
+		conditions.MarkTrue(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition)
+		return ctrl.Result{}, nil
+	}
+	candidate := candidates[0]
+	for _, c := range candidates {
+		if !c.IsLeader {
+			candidate = c
+			break
+		}
+	}
+	machine := machinesByNodeName[candidate.NodeName]
+	log = log.WithValues("Machine", klog.KObj(machine), "Node", klog.KRef("", candidate.NodeName))
+
+	if candidate.IsLeader {
+		var leaderCandidate *clusterv1.Machine
+		for _, status := range statuses {
+			if !status.IsLeader {
+				leaderCandidate = machinesByNodeName[status.NodeName]
+				break
+			}
+		}
+		log.Info("Moving etcd leadership before defragmentation", "leaderCandidate", klog.KObj(leaderCandidate))
+		if err := workloadCluster.ForwardEtcdLeadership(ctx, machine, leaderCandidate); err != nil {
+			conditions.MarkFalse(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdDefragmentationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			return ctrl.Result{}, errors.Wrapf(err, "failed to move etcd leadership before defragmenting the etcd member for Machine/%s", machine.Name)
+		}
+	}
+
+	log.Info("Defragmenting etcd member", "dbSize", candidate.DBSize, "dbSizeInUse", candidate.DBSizeInUse)
+	if err := workloadCluster.DefragmentEtcdMember(ctx, candidate.NodeName); err != nil {
+		conditions.MarkFalse(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdDefragmentationFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		return ctrl.Result{}, errors.Wrapf(err, "failed to defragment the etcd member for Machine/%s", machine.Name)
+	}
+
+	patchHelper, err := patch.NewHelper(machine, r.Client)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to create PatchHelper for Machine/%s", machine.Name)
+	}
+	annotations := machine.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[controlplanev1.EtcdLastDefragmentationAnnotation] = now.UTC().Format(time.RFC3339)
+	machine.SetAnnotations(annotations)
+	if err := patchHelper.Patch(ctx, machine); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to patch Machine/%s", machine.Name)
+	}
+
+	conditions.MarkTrue(controlPlane.KCP, controlplanev1.EtcdMaintenanceSucceededCondition)
+
+	// Requeue to process the remaining members, if any, once the defragmented member settled.
+	if len(candidates) > 1 {
+		return ctrl.Result{RequeueAfter: etcdDefragmentationSettleInterval}, nil
+	}
+	return ctrl.Result{RequeueAfter: requeueAfter}, nil
+}
+
+// etcdMemberNeedsDefragmentation returns true if the DB size of an etcd member and the percentage of the DB size
+// not in use are greater than or equal to the thresholds defined in EtcdDefragmentation.
+func etcdMemberNeedsDefragmentation(defragmentation *controlplanev1.EtcdDefragmentation, status internal.EtcdMemberMaintenanceStatus) bool {
+	if status.DBSize <= 0 {
+		return false
+	}
+
+	if defragmentation.DBSizeThreshold != nil && status.DBSize < defragmentation.DBSizeThreshold.Value() {
+		return false
+	}
+
+	fragmentationPercentageThreshold := int64(controlplanev1.DefaultEtcdDefragmentationFragmentationPercentageThreshold)
+	if defragmentation.FragmentationPercentageThreshold != nil {
+		fragmentationPercentageThreshold = int64(*defragmentation.FragmentationPercentageThreshold)
+	}
+	return (status.DBSize-status.DBSizeInUse)*100 >= fragmentationPercentageThreshold*status.DBSize
+}
+
+// etcdDefragmentationMinInterval returns the minimum interval between two defragmentations of the same etcd member.
+func etcdDefragmentationMinInterval(defragmentation *controlplanev1.EtcdDefragmentation) time.Duration {
+	if defragmentation.MinInterval != nil {
+		return defragmentation.MinInterval.Duration
+	}
+	return controlplanev1.DefaultEtcdDefragmentationMinInterval
+}
+
+// etcdHealthyForMaintenance returns true if an etcd health condition is true, or if it is false only because of
+// NOSPACE alarms KCP is going to disarm; those are the only issues reported with Warning severity.
+func etcdHealthyForMaintenance(from conditions.Getter, t clusterv1.ConditionType, maintenance *controlplanev1.EtcdMaintenance) bool {
+	if conditions.IsTrue(from, t) {
+		return true
+	}
+	severity := conditions.GetSeverity(from, t)
+	return maintenance.DisarmNoSpaceAlarms && conditions.IsFalse(from, t) && severity != nil && *severity == clusterv1.ConditionSeverityWarning
+}
+
+// etcdLastDefragmentation returns the time of the last defragmentation of the etcd member hosted on a Machine,
+// if any; an invalid value of the EtcdLastDefragmentationAnnotation is ignored.
+func etcdLastDefragmentation(machine *clusterv1.Machine) (time.Time, bool) {
+	value, ok := machine.GetAnnotations()[controlplanev1.EtcdLastDefragmentationAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	lastDefragmentation, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lastDefragmentation, true
+}
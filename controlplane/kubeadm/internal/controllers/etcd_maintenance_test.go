@@ -0,0 +1,332 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileEtcdMaintenance(t *testing.T) {
+	recentDefragmentation := time.Now().Add(-1 * time.Hour).UTC().Format(time.RFC3339)
+	justDefragmented := time.Now().Add(-10 * time.Second).UTC().Format(time.RFC3339)
+
+	fragmented := func(nodeName string, memberID uint64, isLeader bool) internal.EtcdMemberMaintenanceStatus {
+		return internal.EtcdMemberMaintenanceStatus{NodeName: nodeName, MemberID: memberID, IsLeader: isLeader, DBSize: 100 * 1024 * 1024, DBSizeInUse: 20 * 1024 * 1024}
+	}
+	compacted := func(nodeName string, memberID uint64, isLeader bool) internal.EtcdMemberMaintenanceStatus {
+		return internal.EtcdMemberMaintenanceStatus{NodeName: nodeName, MemberID: memberID, IsLeader: isLeader, DBSize: 100 * 1024 * 1024, DBSizeInUse: 90 * 1024 * 1024}
+	}
+
+	tests := []struct {
+		name                 string
+		etcdMaintenance      *controlplanev1.EtcdMaintenance
+		machineAnnotations   map[string]map[string]string
+		etcdClusterUnhealthy bool
+		unhealthyMachines    []string
+		noSpaceAlarmMachines []string
+		recentlyInspected    bool
+		etcdMaintenanceFake  *fakeEtcdMaintenance
+		wantErr              bool
+		wantInspected        bool
+		wantResult           ctrl.Result
+		wantDefragmented     []string
+		wantMovedLeaders     []string
+		wantDisarmedAlarms   []etcd.MemberAlarm
+		wantCondition        *clusterv1.Condition
+		wantDefragmentedNode string
+	}{
+		{
+			name:            "Does nothing if etcd maintenance is not configured",
+			etcdMaintenance: nil,
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), fragmented("n3", 3, false)},
+			},
+		},
+		{
+			name:            "Defragments a follower first",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), compacted("n2", 2, false), fragmented("n3", 3, false)},
+			},
+			wantResult:           ctrl.Result{RequeueAfter: etcdDefragmentationSettleInterval},
+			wantInspected:        true,
+			wantDefragmented:     []string{"n3"},
+			wantCondition:        conditions.TrueCondition(controlplanev1.EtcdMaintenanceSucceededCondition),
+			wantDefragmentedNode: "n3",
+		},
+		{
+			name:            "Moves the leadership before defragmenting the leader",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), compacted("n2", 2, false), compacted("n3", 3, false)},
+			},
+			wantInspected:        true,
+			wantDefragmented:     []string{"n1"},
+			wantMovedLeaders:     []string{"n2"},
+			wantCondition:        conditions.TrueCondition(controlplanev1.EtcdMaintenanceSucceededCondition),
+			wantDefragmentedNode: "n1",
+		},
+		{
+			name: "Does not defragment members below the thresholds",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{
+				DBSizeThreshold:                  resource.NewQuantity(1024*1024*1024, resource.BinarySI),
+				FragmentationPercentageThreshold: pointer.Int32(50),
+			}},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), compacted("n3", 3, false)},
+			},
+			wantInspected: true,
+			wantCondition: conditions.TrueCondition(controlplanev1.EtcdMaintenanceSucceededCondition),
+		},
+		{
+			name:                 "Does not inspect the etcd members if the etcd cluster is not healthy",
+			etcdMaintenance:      &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			etcdClusterUnhealthy: true,
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), fragmented("n3", 3, false)},
+			},
+		},
+		{
+			name:              "Does not inspect the etcd members if an etcd member is not healthy",
+			etcdMaintenance:   &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			unhealthyMachines: []string{"m2"},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), fragmented("n3", 3, false)},
+			},
+		},
+		{
+			name:            "Does not inspect the etcd members while the last defragmented member settles",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			machineAnnotations: map[string]map[string]string{
+				"m3": {controlplanev1.EtcdLastDefragmentationAnnotation: justDefragmented},
+			},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), compacted("n3", 3, false)},
+			},
+		},
+		{
+			name:              "Does not inspect the etcd members if they have been inspected recently",
+			etcdMaintenance:   &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			recentlyInspected: true,
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), fragmented("n3", 3, false)},
+			},
+		},
+		{
+			name: "Disarms NOSPACE alarms of members defragmented recently",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{
+				Defragmentation:     &controlplanev1.EtcdDefragmentation{MinInterval: &metav1.Duration{Duration: 2 * time.Hour}},
+				DisarmNoSpaceAlarms: true,
+			},
+			machineAnnotations: map[string]map[string]string{
+				"m2": {controlplanev1.EtcdLastDefragmentationAnnotation: recentDefragmentation},
+			},
+			noSpaceAlarmMachines: []string{"m2"},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{
+					compacted("n1", 1, true),
+					func() internal.EtcdMemberMaintenanceStatus {
+						s := compacted("n2", 2, false)
+						s.Alarms = []etcd.AlarmType{etcd.AlarmNoSpace}
+						return s
+					}(),
+					compacted("n3", 3, false),
+				},
+			},
+			wantInspected:      true,
+			wantDisarmedAlarms: []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}},
+			wantCondition:      conditions.TrueCondition(controlplanev1.EtcdMaintenanceSucceededCondition),
+		},
+		{
+			name:                 "Does not inspect the etcd members if an etcd member reports NOSPACE alarms which are not to be disarmed",
+			etcdMaintenance:      &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			noSpaceAlarmMachines: []string{"m2"},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses: []internal.EtcdMemberMaintenanceStatus{fragmented("n1", 1, true), fragmented("n2", 2, false), fragmented("n3", 3, false)},
+			},
+		},
+		{
+			name:            "Reports a failure in inspecting the etcd members",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				StatusErr: errors.New("failed to connect to etcd"),
+			},
+			wantErr:       true,
+			wantInspected: true,
+			wantCondition: conditions.FalseCondition(controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdMaintenanceInspectionFailedReason, clusterv1.ConditionSeverityWarning, "failed to connect to etcd"),
+		},
+		{
+			name:            "Reports a failure in defragmenting an etcd member",
+			etcdMaintenance: &controlplanev1.EtcdMaintenance{Defragmentation: &controlplanev1.EtcdDefragmentation{}},
+			etcdMaintenanceFake: &fakeEtcdMaintenance{
+				Statuses:      []internal.EtcdMemberMaintenanceStatus{compacted("n1", 1, true), fragmented("n2", 2, false), compacted("n3", 3, false)},
+				DefragmentErr: errors.New("context deadline exceeded"),
+			},
+			wantErr:       true,
+			wantInspected: true,
+			wantCondition: conditions.FalseCondition(controlplanev1.EtcdMaintenanceSucceededCondition, controlplanev1.EtcdDefragmentationFailedReason, clusterv1.ConditionSeverityWarning, "context deadline exceeded"),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			cluster := newCluster(&types.NamespacedName{Name: "foo", Namespace: metav1.NamespaceDefault})
+			kcp := &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas:        pointer.Int32(3),
+					EtcdMaintenance: tt.etcdMaintenance,
+				},
+				Status: controlplanev1.KubeadmControlPlaneStatus{Initialized: true},
+			}
+			// NOSPACE alarms are reported with Warning severity only if KCP is going to disarm them.
+			noSpaceAlarmSeverity := clusterv1.ConditionSeverityError
+			if tt.etcdMaintenance != nil && tt.etcdMaintenance.DisarmNoSpaceAlarms {
+				noSpaceAlarmSeverity = clusterv1.ConditionSeverityWarning
+			}
+			switch {
+			case tt.etcdClusterUnhealthy:
+				conditions.MarkFalse(kcp, controlplanev1.EtcdClusterHealthyCondition, controlplanev1.EtcdClusterUnhealthyReason, clusterv1.ConditionSeverityError, "")
+			case len(tt.noSpaceAlarmMachines) > 0:
+				conditions.MarkFalse(kcp, controlplanev1.EtcdClusterHealthyCondition, controlplanev1.EtcdClusterUnhealthyReason, noSpaceAlarmSeverity, "")
+			default:
+				conditions.MarkTrue(kcp, controlplanev1.EtcdClusterHealthyCondition)
+			}
+
+			machines := collections.New()
+			objs := []client.Object{}
+			for i := 1; i <= 3; i++ {
+				machine := &clusterv1.Machine{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        fmt.Sprintf("m%d", i),
+						Namespace:   metav1.NamespaceDefault,
+						Annotations: tt.machineAnnotations[fmt.Sprintf("m%d", i)],
+					},
+					Spec: clusterv1.MachineSpec{
+						InfrastructureRef: corev1.ObjectReference{
+							Kind:       "GenericMachine",
+							APIVersion: "generic.io/v1",
+							Namespace:  metav1.NamespaceDefault,
+							Name:       fmt.Sprintf("m%d-infra", i),
+						},
+					},
+					Status: clusterv1.MachineStatus{
+						NodeRef: &corev1.ObjectReference{Name: fmt.Sprintf("n%d", i)},
+					},
+				}
+				switch {
+				case sets.New(tt.unhealthyMachines...).Has(machine.Name):
+					conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityError, "")
+				case sets.New(tt.noSpaceAlarmMachines...).Has(machine.Name):
+					conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberNoSpaceAlarmReason, noSpaceAlarmSeverity, "")
+				default:
+					conditions.MarkTrue(machine, controlplanev1.MachineEtcdMemberHealthyCondition)
+				}
+				machines.Insert(machine)
+				objs = append(objs, machine.DeepCopy())
+			}
+			fakeClient := newFakeClient(objs...)
+
+			managementCluster := &fakeManagementCluster{
+				Workload: fakeWorkloadCluster{
+					EtcdMaintenance: tt.etcdMaintenanceFake,
+				},
+			}
+			r := &KubeadmControlPlaneReconciler{
+				Client:              fakeClient,
+				SecretCachingClient: fakeClient,
+				managementCluster:   managementCluster,
+			}
+			if tt.recentlyInspected {
+				r.etcdMaintenanceNextInspection.Store(kcp.UID, time.Now().Add(time.Minute))
+			}
+
+			controlPlane, err := internal.NewControlPlane(ctx, managementCluster, fakeClient, cluster, kcp, machines)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			result, err := r.reconcileEtcdMaintenance(ctx, controlPlane)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				if tt.wantDisarmedAlarms != nil || tt.recentlyInspected || tt.machineAnnotations != nil {
+					// The members are inspected again, or defragmented again, after some time.
+					g.Expect(result.RequeueAfter).To(BeNumerically(">", 0))
+				} else {
+					g.Expect(result).To(Equal(tt.wantResult))
+				}
+			}
+
+			g.Expect(tt.etcdMaintenanceFake.Inspected).To(Equal(tt.wantInspected))
+			g.Expect(tt.etcdMaintenanceFake.Defragmented).To(Equal(tt.wantDefragmented))
+			g.Expect(tt.etcdMaintenanceFake.MovedLeaders).To(Equal(tt.wantMovedLeaders))
+			g.Expect(tt.etcdMaintenanceFake.DisarmedAlarms).To(Equal(tt.wantDisarmedAlarms))
+
+			if tt.wantCondition == nil {
+				g.Expect(conditions.Has(kcp, controlplanev1.EtcdMaintenanceSucceededCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.Get(kcp, controlplanev1.EtcdMaintenanceSucceededCondition)).To(conditions.HaveSameStateOf(tt.wantCondition))
+			}
+
+			for _, machine := range machines {
+				actualMachine := &clusterv1.Machine{}
+				g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(machine), actualMachine)).To(Succeed())
+				lastDefragmentation, ok := actualMachine.Annotations[controlplanev1.EtcdLastDefragmentationAnnotation]
+				if machine.Status.NodeRef.Name == tt.wantDefragmentedNode {
+					g.Expect(ok).To(BeTrue())
+					g.Expect(time.Parse(time.RFC3339, lastDefragmentation)).To(BeTemporally("~", time.Now(), time.Minute))
+				} else {
+					g.Expect(lastDefragmentation).To(Equal(tt.machineAnnotations[machine.Name][controlplanev1.EtcdLastDefragmentationAnnotation]))
+				}
+			}
+		})
+	}
+}
+
+func TestEtcdMemberNeedsDefragmentation(t *testing.T) {
+	g := NewWithT(t)
+
+	status := internal.EtcdMemberMaintenanceStatus{DBSize: 1000, DBSizeInUse: 400}
+
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{}, status)).To(BeTrue())
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{FragmentationPercentageThreshold: pointer.Int32(60)}, status)).To(BeTrue())
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{FragmentationPercentageThreshold: pointer.Int32(61)}, status)).To(BeFalse())
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{DBSizeThreshold: resource.NewQuantity(1000, resource.DecimalSI)}, status)).To(BeTrue())
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{DBSizeThreshold: resource.NewQuantity(1001, resource.DecimalSI)}, status)).To(BeFalse())
+	g.Expect(etcdMemberNeedsDefragmentation(&controlplanev1.EtcdDefragmentation{}, internal.EtcdMemberMaintenanceStatus{})).To(BeFalse())
+}
@@ -27,6 +27,7 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/util/collections"
 )
@@ -71,12 +72,45 @@ type fakeWorkloadCluster struct {
 	Status                     internal.ClusterStatus
 	EtcdMembersResult          []string
 	APIServerCertificateExpiry *time.Time
+	EtcdMaintenance            *fakeEtcdMaintenance
+}
+
+// fakeEtcdMaintenance stores the status of the etcd members and records the etcd maintenance operations.
+type fakeEtcdMaintenance struct {
+	Statuses       []internal.EtcdMemberMaintenanceStatus
+	StatusErr      error
+	Inspected      bool
+	DefragmentErr  error
+	Defragmented   []string
+	DisarmedAlarms []etcd.MemberAlarm
+	MovedLeaders   []string
 }
 
 func (f fakeWorkloadCluster) ForwardEtcdLeadership(_ context.Context, _ *clusterv1.Machine, leaderCandidate *clusterv1.Machine) error {
 	if leaderCandidate == nil {
 		return errors.New("leaderCandidate is nil")
 	}
+	if f.EtcdMaintenance != nil && leaderCandidate.Status.NodeRef != nil {
+		f.EtcdMaintenance.MovedLeaders = append(f.EtcdMaintenance.MovedLeaders, leaderCandidate.Status.NodeRef.Name)
+	}
+	return nil
+}
+
+func (f fakeWorkloadCluster) EtcdMembersMaintenanceStatus(_ context.Context, _ []string) ([]internal.EtcdMemberMaintenanceStatus, error) {
+	f.EtcdMaintenance.Inspected = true
+	return f.EtcdMaintenance.Statuses, f.EtcdMaintenance.StatusErr
+}
+
+func (f fakeWorkloadCluster) DefragmentEtcdMember(_ context.Context, nodeName string) error {
+	if f.EtcdMaintenance.DefragmentErr != nil {
+		return f.EtcdMaintenance.DefragmentErr
+	}
+	f.EtcdMaintenance.Defragmented = append(f.EtcdMaintenance.Defragmented, nodeName)
+	return nil
+}
+
+func (f fakeWorkloadCluster) DisarmEtcdAlarms(_ context.Context, _ []string, alarms []etcd.MemberAlarm) error {
+	f.EtcdMaintenance.DisarmedAlarms = append(f.EtcdMaintenance.DisarmedAlarms, alarms...)
 	return nil
 }
 
@@ -29,6 +29,7 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apiserver/pkg/storage/names"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -50,21 +51,28 @@ import (
 func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context, controlPlane *internal.ControlPlane) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 
-	endpoint := controlPlane.Cluster.Spec.ControlPlaneEndpoint
-	if endpoint.IsZero() {
+	if controlPlane.Cluster.Spec.ControlPlaneEndpoint.IsZero() {
 		return ctrl.Result{}, nil
 	}
+	// NOTE: The cluster.x-k8s.io/kubeconfig-endpoint annotation of the Cluster is not used, KCP supports a custom
+	// endpoint only with spec.kubeconfigEndpoint, which is validated against the API server certificate SANs.
+	endpoint := controlPlane.Cluster.Spec.ControlPlaneEndpoint.String()
+	if controlPlane.KCP.Spec.KubeconfigEndpoint != nil {
+		endpoint = controlPlane.KCP.Spec.KubeconfigEndpoint.String()
+	}
+	userEndpoint := kubeconfig.UserEndpoint(controlPlane.Cluster)
 
 	controllerOwnerRef := *metav1.NewControllerRef(controlPlane.KCP, controlplanev1.GroupVersion.WithKind(kubeadmControlPlaneKind))
 	clusterName := util.ObjectKey(controlPlane.Cluster)
 	configSecret, err := secret.GetFromNamespacedName(ctx, r.SecretCachingClient, clusterName, secret.Kubeconfig)
 	switch {
 	case apierrors.IsNotFound(err):
-		createErr := kubeconfig.CreateSecretWithOwner(
+		createErr := kubeconfig.CreateSecretWithOwnerFromStore(
 			ctx,
-			r.SecretCachingClient,
+			r.Client,
+			r.certificatesStore(),
 			clusterName,
-			endpoint.String(),
+			endpoint,
 			controllerOwnerRef,
 		)
 		if errors.Is(createErr, kubeconfig.ErrDependentCertificateNotFound) {
@@ -85,16 +93,57 @@ func (r *KubeadmControlPlaneReconciler) reconcileKubeconfig(ctx context.Context,
 		return ctrl.Result{}, nil
 	}
 
-	needsRotation, err := kubeconfig.NeedsClientCertRotation(configSecret, certs.ClientCertificateRenewalDuration)
+	needsEndpointUpdate, err := kubeconfig.NeedsEndpointUpdate(configSecret, endpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if needsEndpointUpdate {
+		log.Info("regenerating kubeconfig secret with the new endpoint", "endpoint", endpoint)
+		if err := kubeconfig.RegenerateSecretWithEndpointFromStore(ctx, r.Client, r.certificatesStore(), configSecret, endpoint); err != nil {
+			if errors.Is(err, kubeconfig.ErrDependentCertificateNotFound) {
+				return ctrl.Result{RequeueAfter: dependentCertRequeueAfter}, nil
+			}
+			return ctrl.Result{}, errors.Wrap(err, "failed to regenerate kubeconfig")
+		}
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, controlplanev1.KubeconfigEndpointUpdatedEventReason,
+			"Regenerated kubeconfig Secret %s with endpoint %s", klog.KObj(configSecret), endpoint)
+		// Return after regenerating the kubeconfig, the new client certificate does not need to be rotated.
+		return ctrl.Result{}, nil
+	}
+
+	needsUserEndpointUpdate, err := kubeconfig.NeedsUserEndpointUpdate(configSecret, userEndpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if needsUserEndpointUpdate {
+		log.Info("updating user kubeconfig in the kubeconfig secret", "endpoint", userEndpoint)
+		if err := kubeconfig.SetUserKubeconfig(configSecret, userEndpoint); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to generate user kubeconfig")
+		}
+		if err := r.Client.Update(ctx, configSecret); err != nil {
+			return ctrl.Result{}, errors.Wrap(err, "failed to update kubeconfig Secret")
+		}
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, controlplanev1.KubeconfigEndpointUpdatedEventReason,
+			"Updated user kubeconfig of Secret %s with endpoint %q", klog.KObj(configSecret), userEndpoint)
+		return ctrl.Result{}, nil
+	}
+
+	renewalWindow := r.KubeconfigClientCertRenewalWindow
+	if renewalWindow <= 0 {
+		renewalWindow = certs.ClientCertificateRenewalDuration
+	}
+	needsRotation, err := kubeconfig.NeedsClientCertRotation(configSecret, renewalWindow)
 	if err != nil {
 		return ctrl.Result{}, err
 	}
 
 	if needsRotation {
 		log.Info("rotating kubeconfig secret")
-		if err := kubeconfig.RegenerateSecret(ctx, r.Client, configSecret); err != nil {
+		if err := kubeconfig.RegenerateSecretFromStore(ctx, r.Client, r.certificatesStore(), configSecret); err != nil {
 			return ctrl.Result{}, errors.Wrap(err, "failed to regenerate kubeconfig")
 		}
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, controlplanev1.KubeconfigRotatedEventReason,
+			"Rotated kubeconfig Secret %s because its client certificate expires within %s", klog.KObj(configSecret), renewalWindow)
 	}
 
 	return ctrl.Result{}, nil
@@ -177,7 +226,7 @@ func (r *KubeadmControlPlaneReconciler) cloneConfigsAndGenerateMachine(ctx conte
 	// Clone the infrastructure template
 	infraRef, err := external.CreateFromTemplate(ctx, &external.CreateFromTemplateInput{
 		Client:      r.Client,
-		TemplateRef: &kcp.Spec.MachineTemplate.InfrastructureRef,
+		TemplateRef: internal.InfrastructureRefForFailureDomain(kcp, failureDomain),
 		Namespace:   kcp.Namespace,
 		OwnerRef:    infraCloneOwner,
 		ClusterName: cluster.Name,
@@ -25,6 +25,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -292,6 +293,204 @@ func TestKubeadmControlPlaneReconciler_reconcileKubeconfig(t *testing.T) {
 	g.Expect(kubeconfigSecret.Labels).To(HaveKeyWithValue(clusterv1.ClusterNameLabel, cluster.Name))
 }
 
+func TestKubeadmControlPlaneReconciler_reconcileKubeconfigRotation(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "test.local", Port: 8443},
+		},
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.16.6",
+		},
+	}
+
+	clusterCerts := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(clusterCerts.Generate()).To(Succeed())
+	caCert := clusterCerts.GetByPurpose(secret.ClusterCA)
+	existingCACertSecret := caCert.AsSecret(
+		client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"},
+		*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane")),
+	)
+
+	fakeClient := newFakeClient(kcp.DeepCopy(), existingCACertSecret.DeepCopy())
+	recorder := record.NewFakeRecorder(32)
+	r := &KubeadmControlPlaneReconciler{
+		Client:              fakeClient,
+		SecretCachingClient: fakeClient,
+		recorder:            recorder,
+	}
+
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: cluster,
+	}
+
+	secretName := client.ObjectKey{
+		Namespace: metav1.NamespaceDefault,
+		Name:      secret.Name(cluster.Name, secret.Kubeconfig),
+	}
+
+	// Create the kubeconfig Secret.
+	_, err := r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	kubeconfigSecret := &corev1.Secret{}
+	g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+	originalKubeconfig := kubeconfigSecret.Data[secret.KubeconfigDataName]
+
+	// The kubeconfig Secret is not rotated with the default renewal window.
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+	g.Expect(kubeconfigSecret.Data[secret.KubeconfigDataName]).To(Equal(originalKubeconfig))
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	// The kubeconfig Secret is rotated when the client certificate expires within the renewal window.
+	r.KubeconfigClientCertRenewalWindow = 2 * 365 * 24 * time.Hour
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+	g.Expect(kubeconfigSecret.Data[secret.KubeconfigDataName]).ToNot(Equal(originalKubeconfig))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(controlplanev1.KubeconfigRotatedEventReason)))
+}
+
+func TestKubeadmControlPlaneReconciler_reconcileKubeconfigEndpoint(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Cluster",
+			APIVersion: clusterv1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "test.local", Port: 8443},
+		},
+	}
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "KubeadmControlPlane",
+			APIVersion: controlplanev1.GroupVersion.String(),
+		},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			Version: "v1.16.6",
+		},
+	}
+
+	clusterCerts := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(clusterCerts.Generate()).To(Succeed())
+	caCert := clusterCerts.GetByPurpose(secret.ClusterCA)
+	existingCACertSecret := caCert.AsSecret(
+		client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"},
+		*metav1.NewControllerRef(kcp, controlplanev1.GroupVersion.WithKind("KubeadmControlPlane")),
+	)
+
+	fakeClient := newFakeClient(kcp.DeepCopy(), existingCACertSecret.DeepCopy())
+	recorder := record.NewFakeRecorder(32)
+	r := &KubeadmControlPlaneReconciler{
+		Client:              fakeClient,
+		SecretCachingClient: fakeClient,
+		recorder:            recorder,
+	}
+
+	controlPlane := &internal.ControlPlane{
+		KCP:     kcp,
+		Cluster: cluster,
+	}
+
+	secretName := client.ObjectKey{
+		Namespace: metav1.NamespaceDefault,
+		Name:      secret.Name(cluster.Name, secret.Kubeconfig),
+	}
+	server := func() string {
+		kubeconfigSecret := &corev1.Secret{}
+		g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+		config, err := clientcmd.Load(kubeconfigSecret.Data[secret.KubeconfigDataName])
+		g.Expect(err).ToNot(HaveOccurred())
+		return config.Clusters[cluster.Name].Server
+	}
+
+	// Create the kubeconfig Secret using the Cluster control plane endpoint.
+	_, err := r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server()).To(Equal("https://test.local:8443"))
+
+	// The kubeconfig Secret is regenerated when the kubeconfig endpoint is set.
+	kcp.Spec.KubeconfigEndpoint = &clusterv1.APIEndpoint{Host: "new-lb.local", Port: 6443}
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server()).To(Equal("https://new-lb.local:6443"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(controlplanev1.KubeconfigEndpointUpdatedEventReason)))
+
+	// The kubeconfig endpoint annotation of the Cluster is ignored by KCP.
+	cluster.Annotations = map[string]string{clusterv1.ClusterKubeconfigEndpointAnnotation: "10.0.0.1:6443"}
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server()).To(Equal("https://new-lb.local:6443"))
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	// The kubeconfig Secret is regenerated using the Cluster control plane endpoint when the kubeconfig endpoint is removed.
+	kcp.Spec.KubeconfigEndpoint = nil
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server()).To(Equal("https://test.local:8443"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(controlplanev1.KubeconfigEndpointUpdatedEventReason)))
+	cluster.Annotations = nil
+
+	// The user kubeconfig is added to the kubeconfig Secret when the user kubeconfig endpoint annotation is set.
+	userServer := func() string {
+		kubeconfigSecret := &corev1.Secret{}
+		g.Expect(r.Client.Get(ctx, secretName, kubeconfigSecret)).To(Succeed())
+		data, ok := kubeconfigSecret.Data[secret.UserKubeconfigDataName]
+		if !ok {
+			return ""
+		}
+		config, err := clientcmd.Load(data)
+		g.Expect(err).ToNot(HaveOccurred())
+		return config.Clusters[cluster.Name].Server
+	}
+	cluster.Annotations = map[string]string{clusterv1.ClusterUserKubeconfigEndpointAnnotation: "api.example.com:443"}
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(server()).To(Equal("https://test.local:8443"))
+	g.Expect(userServer()).To(Equal("https://api.example.com:443"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(controlplanev1.KubeconfigEndpointUpdatedEventReason)))
+
+	// The user kubeconfig is removed from the kubeconfig Secret when the user kubeconfig endpoint annotation is removed.
+	cluster.Annotations = nil
+	_, err = r.reconcileKubeconfig(ctx, controlPlane)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(userServer()).To(BeEmpty())
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(controlplanev1.KubeconfigEndpointUpdatedEventReason)))
+}
+
 func TestCloneConfigsAndGenerateMachine(t *testing.T) {
 	setup := func(t *testing.T, g *WithT) *corev1.Namespace {
 		t.Helper()
@@ -0,0 +1,307 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/addresses"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+const (
+	// kubeletServingCSRApprovedReason is the reason set on the Approved condition of the kubelet serving
+	// CertificateSigningRequests approved by the KubeletServingCSRReconciler.
+	kubeletServingCSRApprovedReason = "ClusterAPIApproved"
+
+	nodeUserPrefix = "system:node:"
+	nodesGroup     = "system:nodes"
+)
+
+var (
+	// kubeletServingCSRRequeueAfter is the interval after which CertificateSigningRequests for
+	// Nodes not yet linked to a Machine are checked again.
+	kubeletServingCSRRequeueAfter = 30 * time.Second
+
+	allowedKubeletServingUsages = sets.New[certificatesv1.KeyUsage](
+		certificatesv1.UsageDigitalSignature,
+		certificatesv1.UsageKeyEncipherment,
+		certificatesv1.UsageServerAuth,
+	)
+)
+
+// KubeletServingCSRReconciler approves the kubelet serving CertificateSigningRequests created in the workload clusters
+// of KubeadmControlPlanes by the Nodes of Cluster API Machines, after verifying that the requested subject
+// alternative names match the addresses of the corresponding Machine.
+// NOTE: This removes the need to deploy a third-party CertificateSigningRequest approver in the workload cluster
+// when the kubelets are configured with serverTLSBootstrap: true.
+type KubeletServingCSRReconciler struct {
+	Client     client.Client
+	Tracker    *remote.ClusterCacheTracker
+	controller controller.Controller
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+func (r *KubeletServingCSRReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Tracker == nil {
+		return errors.New("cluster cache tracker is nil, cannot watch CertificateSigningRequests in the workload clusters")
+	}
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		Named("kubeletservingcsr").
+		For(&controlplanev1.KubeadmControlPlane{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.controller = c
+	return nil
+}
+
+func (r *KubeletServingCSRReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the KubeadmControlPlane instance.
+	kcp := &controlplanev1.KubeadmControlPlane{}
+	if err := r.Client.Get(ctx, req.NamespacedName, kcp); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	if !kcp.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	// Fetch the Cluster.
+	cluster, err := util.GetOwnerCluster(ctx, r.Client, kcp.ObjectMeta)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to retrieve owner Cluster from the API Server")
+	}
+	if cluster == nil {
+		return ctrl.Result{}, nil
+	}
+	log = log.WithValues("Cluster", klog.KObj(cluster))
+	ctx = ctrl.LoggerInto(ctx, log)
+
+	if annotations.IsPaused(cluster, kcp) {
+		log.V(4).Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	// Nodes can't request certificates before the control plane is initialized.
+	if !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+		return ctrl.Result{}, nil
+	}
+
+	res, err := r.reconcile(ctx, cluster, kcp)
+	// Requeue if the reconcile failed because the ClusterCacheTracker was locked for
+	// the current cluster because of concurrent access.
+	if errors.Is(err, remote.ErrClusterLocked) {
+		log.V(5).Info("Requeuing because another worker has the lock on the ClusterCacheTracker")
+		return ctrl.Result{Requeue: true}, nil
+	}
+	return res, err
+}
+
+func (r *KubeletServingCSRReconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if err := r.watchKubeletServingCSRs(ctx, cluster, kcp); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	csrList := &certificatesv1.CertificateSigningRequestList{}
+	if err := remoteClient.List(ctx, csrList); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list CertificateSigningRequests")
+	}
+
+	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list Machines")
+	}
+	machinesByNodeName := map[string]*clusterv1.Machine{}
+	for _, m := range machines {
+		if m.Status.NodeRef != nil {
+			machinesByNodeName[m.Status.NodeRef.Name] = m
+		}
+	}
+
+	res := ctrl.Result{}
+	errs := []error{}
+	for i := range csrList.Items {
+		csr := &csrList.Items[i]
+		if csr.Spec.SignerName != certificatesv1.KubeletServingSignerName || !isPendingCSR(csr) {
+			continue
+		}
+		log := log.WithValues("CertificateSigningRequest", klog.KObj(csr))
+
+		if !strings.HasPrefix(csr.Spec.Username, nodeUserPrefix) {
+			continue
+		}
+		nodeName := strings.TrimPrefix(csr.Spec.Username, nodeUserPrefix)
+		machine, ok := machinesByNodeName[nodeName]
+		if !ok {
+			// The Node might not be linked to its Machine yet; check again later.
+			// NOTE: CertificateSigningRequests not created by Nodes of Cluster API Machines are left to other approvers.
+			log.V(4).Info("Not approving kubelet serving CertificateSigningRequest: no Machine found for the requesting Node", "Node", klog.KRef("", nodeName))
+			res = ctrl.Result{RequeueAfter: kubeletServingCSRRequeueAfter}
+			continue
+		}
+
+		if err := validateKubeletServingCSR(csr, nodeName, machine); err != nil {
+			log.Info(fmt.Sprintf("Not approving kubelet serving CertificateSigningRequest: %v", err), "Machine", klog.KObj(machine))
+			continue
+		}
+
+		csr.Status.Conditions = append(csr.Status.Conditions, certificatesv1.CertificateSigningRequestCondition{
+			Type:           certificatesv1.CertificateApproved,
+			Status:         corev1.ConditionTrue,
+			Reason:         kubeletServingCSRApprovedReason,
+			Message:        fmt.Sprintf("Subject alternative names verified against the addresses of Machine %s", klog.KObj(machine)),
+			LastUpdateTime: metav1.Now(),
+		})
+		if err := remoteClient.SubResource("approval").Update(ctx, csr); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to approve CertificateSigningRequest %s", csr.Name))
+			continue
+		}
+		log.Info("Approved kubelet serving CertificateSigningRequest", "Machine", klog.KObj(machine))
+	}
+	if len(errs) > 0 {
+		return ctrl.Result{}, kerrors.NewAggregate(errs)
+	}
+	return res, nil
+}
+
+func (r *KubeletServingCSRReconciler) watchKubeletServingCSRs(ctx context.Context, cluster *clusterv1.Cluster, kcp *controlplanev1.KubeadmControlPlane) error {
+	kcpKey := client.ObjectKeyFromObject(kcp)
+	return r.Tracker.Watch(ctx, remote.WatchInput{
+		Name:    "kubeletservingcsr-watchCertificateSigningRequests",
+		Cluster: util.ObjectKey(cluster),
+		Watcher: r.controller,
+		Kind:    &certificatesv1.CertificateSigningRequest{},
+		EventHandler: handler.EnqueueRequestsFromMapFunc(func(_ context.Context, o client.Object) []reconcile.Request {
+			csr, ok := o.(*certificatesv1.CertificateSigningRequest)
+			if !ok || csr.Spec.SignerName != certificatesv1.KubeletServingSignerName {
+				return nil
+			}
+			return []reconcile.Request{{NamespacedName: kcpKey}}
+		}),
+	})
+}
+
+// isPendingCSR returns true if a CertificateSigningRequest has not been approved, denied or failed yet.
+func isPendingCSR(csr *certificatesv1.CertificateSigningRequest) bool {
+	for _, c := range csr.Status.Conditions {
+		if c.Type == certificatesv1.CertificateApproved || c.Type == certificatesv1.CertificateDenied || c.Type == certificatesv1.CertificateFailed {
+			return false
+		}
+	}
+	return true
+}
+
+// validateKubeletServingCSR validates that a CertificateSigningRequest is a valid kubelet serving certificate request
+// for the given Node and that all the requested subject alternative names are addresses of the Machine of the Node.
+func validateKubeletServingCSR(csr *certificatesv1.CertificateSigningRequest, nodeName string, machine *clusterv1.Machine) error {
+	if !sets.New[string](csr.Spec.Groups...).Has(nodesGroup) {
+		return errors.Errorf("requesting user is not in the %q group", nodesGroup)
+	}
+
+	usages := sets.New[certificatesv1.KeyUsage](csr.Spec.Usages...)
+	if !usages.Has(certificatesv1.UsageServerAuth) {
+		return errors.Errorf("usages do not include %q", certificatesv1.UsageServerAuth)
+	}
+	if !allowedKubeletServingUsages.IsSuperset(usages) {
+		return errors.Errorf("usages %v are not allowed", sets.List(usages.Difference(allowedKubeletServingUsages)))
+	}
+
+	block, _ := pem.Decode(csr.Spec.Request)
+	if block == nil || block.Type != "CERTIFICATE REQUEST" {
+		return errors.New("request is not a PEM encoded certificate request")
+	}
+	request, err := x509.ParseCertificateRequest(block.Bytes)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse certificate request")
+	}
+	if err := request.CheckSignature(); err != nil {
+		return errors.Wrap(err, "failed to verify certificate request signature")
+	}
+
+	if request.Subject.CommonName != nodeUserPrefix+nodeName {
+		return errors.Errorf("subject common name %q does not match the requesting Node", request.Subject.CommonName)
+	}
+	if len(request.Subject.Organization) != 1 || request.Subject.Organization[0] != nodesGroup {
+		return errors.Errorf("subject organization %v is not [%s]", request.Subject.Organization, nodesGroup)
+	}
+	if len(request.EmailAddresses) > 0 || len(request.URIs) > 0 {
+		return errors.New("email and URI subject alternative names are not allowed")
+	}
+	if len(request.DNSNames) == 0 && len(request.IPAddresses) == 0 {
+		return errors.New("at least one DNS or IP subject alternative name is required")
+	}
+
+	// NOTE: All the addresses reported for the Machine are considered, including all the IPs of dual-stack Machines.
+	dnsNames := addresses.DNSNames(machine.Status.Addresses)
+	ipAddresses := addresses.IPs(machine.Status.Addresses)
+	for _, dnsName := range request.DNSNames {
+		if !dnsNames.Has(dnsName) {
+			return errors.Errorf("DNS subject alternative name %q is not an address of the Machine", dnsName)
+		}
+	}
+	for _, ip := range request.IPAddresses {
+		if !ipAddresses.Has(ip.String()) {
+			return errors.Errorf("IP subject alternative name %q is not an address of the Machine", ip.String())
+		}
+	}
+	return nil
+}
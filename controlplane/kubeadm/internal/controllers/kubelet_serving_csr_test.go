@@ -0,0 +1,247 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"net"
+	"testing"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	certificatesv1 "k8s.io/api/certificates/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestValidateKubeletServingCSR(t *testing.T) {
+	machine := &clusterv1.Machine{
+		Status: clusterv1.MachineStatus{
+			Addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineHostName, Address: "node-1"},
+				{Type: clusterv1.MachineInternalDNS, Address: "node-1.internal"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		csr     *certificatesv1.CertificateSigningRequest
+		wantErr bool
+	}{
+		{
+			name: "valid request",
+			csr:  kubeletServingCSR(t, "csr", "node-1", []string{"node-1", "node-1.internal"}, []string{"10.0.0.1"}),
+		},
+		{
+			name:    "DNS name not in the Machine addresses",
+			csr:     kubeletServingCSR(t, "csr", "node-1", []string{"node-1", "evil.example.com"}, []string{"10.0.0.1"}),
+			wantErr: true,
+		},
+		{
+			name:    "IP address not in the Machine addresses",
+			csr:     kubeletServingCSR(t, "csr", "node-1", []string{"node-1"}, []string{"10.0.0.2"}),
+			wantErr: true,
+		},
+		{
+			name:    "no subject alternative names",
+			csr:     kubeletServingCSR(t, "csr", "node-1", nil, nil),
+			wantErr: true,
+		},
+		{
+			name: "common name not matching the requesting Node",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "csr", "node-2", []string{"node-1"}, nil)
+				csr.Spec.Username = nodeUserPrefix + "node-1"
+				return csr
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "requesting user not in the nodes group",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "csr", "node-1", []string{"node-1"}, nil)
+				csr.Spec.Groups = []string{"system:authenticated"}
+				return csr
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "client auth usage",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "csr", "node-1", []string{"node-1"}, nil)
+				csr.Spec.Usages = append(csr.Spec.Usages, certificatesv1.UsageClientAuth)
+				return csr
+			}(),
+			wantErr: true,
+		},
+		{
+			name: "invalid request",
+			csr: func() *certificatesv1.CertificateSigningRequest {
+				csr := kubeletServingCSR(t, "csr", "node-1", []string{"node-1"}, nil)
+				csr.Spec.Request = []byte("invalid")
+				return csr
+			}(),
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateKubeletServingCSR(tt.csr, "node-1", machine)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestKubeletServingCSRReconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: metav1.NamespaceDefault,
+		},
+	}
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "foo",
+			Namespace: cluster.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				{
+					Kind:       "Cluster",
+					APIVersion: clusterv1.GroupVersion.String(),
+					Name:       cluster.Name,
+				},
+			},
+		},
+	}
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "machine-1",
+			Namespace: cluster.Namespace,
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: cluster.Name,
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "node-1"},
+			Addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineHostName, Address: "node-1"},
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+			},
+		},
+	}
+
+	validCSR := kubeletServingCSR(t, "valid", "node-1", []string{"node-1"}, []string{"10.0.0.1"})
+	invalidCSR := kubeletServingCSR(t, "invalid", "node-1", []string{"node-1"}, []string{"10.0.0.2"})
+	unknownNodeCSR := kubeletServingCSR(t, "unknown-node", "node-2", []string{"node-2"}, nil)
+
+	mgmtClient := fake.NewClientBuilder().WithObjects(cluster, kcp, machine).Build()
+	remoteClient := fake.NewClientBuilder().
+		WithObjects(validCSR, invalidCSR, unknownNodeCSR).
+		WithStatusSubresource(&certificatesv1.CertificateSigningRequest{}).
+		Build()
+
+	r := &KubeletServingCSRReconciler{
+		Client:  mgmtClient,
+		Tracker: remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), remoteClient, scheme.Scheme, client.ObjectKeyFromObject(cluster), "kubeletservingcsr-watchCertificateSigningRequests"),
+	}
+
+	res, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(kcp)})
+	g.Expect(err).ToNot(HaveOccurred())
+	// Requeue to check again the CertificateSigningRequest of the Node without a Machine.
+	g.Expect(res.RequeueAfter).To(Equal(kubeletServingCSRRequeueAfter))
+
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(validCSR), validCSR)).To(Succeed())
+	g.Expect(isPendingCSR(validCSR)).To(BeFalse())
+	g.Expect(validCSR.Status.Conditions).To(HaveLen(1))
+	g.Expect(validCSR.Status.Conditions[0].Type).To(Equal(certificatesv1.CertificateApproved))
+	g.Expect(validCSR.Status.Conditions[0].Reason).To(Equal(kubeletServingCSRApprovedReason))
+
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(invalidCSR), invalidCSR)).To(Succeed())
+	g.Expect(isPendingCSR(invalidCSR)).To(BeTrue())
+
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(unknownNodeCSR), unknownNodeCSR)).To(Succeed())
+	g.Expect(isPendingCSR(unknownNodeCSR)).To(BeTrue())
+}
+
+func kubeletServingCSR(t *testing.T, name, nodeName string, dnsNames, ipAddresses []string) *certificatesv1.CertificateSigningRequest {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ips := []net.IP{}
+	for _, ip := range ipAddresses {
+		ips = append(ips, net.ParseIP(ip))
+	}
+	request, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject: pkix.Name{
+			CommonName:   nodeUserPrefix + nodeName,
+			Organization: []string{nodesGroup},
+		},
+		DNSNames:    dnsNames,
+		IPAddresses: ips,
+	}, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	return &certificatesv1.CertificateSigningRequest{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: name,
+		},
+		Spec: certificatesv1.CertificateSigningRequestSpec{
+			Request:    pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE REQUEST", Bytes: request}),
+			SignerName: certificatesv1.KubeletServingSignerName,
+			Usages: []certificatesv1.KeyUsage{
+				certificatesv1.UsageDigitalSignature,
+				certificatesv1.UsageKeyEncipherment,
+				certificatesv1.UsageServerAuth,
+			},
+			Username: nodeUserPrefix + nodeName,
+			Groups:   []string{nodesGroup, "system:authenticated"},
+		},
+	}
+}
@@ -0,0 +1,84 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/types"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(kcpRolloutDuration, kcpRolloutInProgress)
+}
+
+// kcpRolloutDuration reports the duration of the completed rollouts of the KubeadmControlPlanes, from the first
+// reconcile detecting Machines needing rollout to the first reconcile without Machines needing rollout.
+var kcpRolloutDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "capi_kubeadmcontrolplane_rollout_duration_seconds",
+	Help:    "Duration in seconds of the completed KubeadmControlPlane rollouts",
+	Buckets: prometheus.ExponentialBuckets(60, 2, 10),
+}, []string{"namespace", "cluster_name"})
+
+// kcpRolloutInProgress reports how long the rollout in progress of each KubeadmControlPlane has been running.
+// NOTE: the value is refreshed every time the KubeadmControlPlane is reconciled.
+var kcpRolloutInProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capi_kubeadmcontrolplane_rollout_in_progress_seconds",
+	Help: "Time in seconds since the start of the KubeadmControlPlane rollout in progress",
+}, []string{"namespace", "name", "cluster_name"})
+
+// rolloutStarts tracks the start of the rollouts in progress, by KubeadmControlPlane.
+// NOTE: the start of a rollout is not persisted, so rollouts in progress when the controller restarts
+// are measured from the first reconcile after the restart.
+var (
+	rolloutStartsLock sync.Mutex
+	rolloutStarts     = map[types.NamespacedName]time.Time{}
+)
+
+// updateRolloutMetrics updates the rollout metrics of a KubeadmControlPlane, given whether it has Machines needing rollout.
+func updateRolloutMetrics(kcp types.NamespacedName, clusterName string, rolloutInProgress bool, now time.Time) {
+	rolloutStartsLock.Lock()
+	defer rolloutStartsLock.Unlock()
+
+	start, ok := rolloutStarts[kcp]
+	if rolloutInProgress {
+		if !ok {
+			start = now
+			rolloutStarts[kcp] = start
+		}
+		kcpRolloutInProgress.WithLabelValues(kcp.Namespace, kcp.Name, clusterName).Set(now.Sub(start).Seconds())
+		return
+	}
+
+	if ok {
+		delete(rolloutStarts, kcp)
+		kcpRolloutDuration.WithLabelValues(kcp.Namespace, clusterName).Observe(now.Sub(start).Seconds())
+	}
+	kcpRolloutInProgress.DeleteLabelValues(kcp.Namespace, kcp.Name, clusterName)
+}
+
+func deleteKubeadmControlPlaneMetrics(kcp types.NamespacedName) {
+	rolloutStartsLock.Lock()
+	defer rolloutStartsLock.Unlock()
+
+	delete(rolloutStarts, kcp)
+	kcpRolloutInProgress.DeletePartialMatch(prometheus.Labels{"namespace": kcp.Namespace, "name": kcp.Name})
+}
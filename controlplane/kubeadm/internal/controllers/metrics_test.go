@@ -0,0 +1,56 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"k8s.io/apimachinery/pkg/types"
+)
+
+func TestUpdateRolloutMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	kcp := types.NamespacedName{Namespace: "rollout-metrics", Name: "kcp"}
+	durations := testutil.CollectAndCount(kcpRolloutDuration)
+
+	// Nothing is reported without a rollout in progress.
+	updateRolloutMetrics(kcp, "cluster", false, now)
+	g.Expect(testutil.CollectAndCount(kcpRolloutDuration)).To(Equal(durations))
+	g.Expect(kcpRolloutInProgress.DeleteLabelValues(kcp.Namespace, kcp.Name, "cluster")).To(BeFalse())
+
+	// The gauge reports the time since the first reconcile with Machines needing rollout.
+	updateRolloutMetrics(kcp, "cluster", true, now)
+	updateRolloutMetrics(kcp, "cluster", true, now.Add(time.Minute))
+	g.Expect(testutil.ToFloat64(kcpRolloutInProgress.WithLabelValues(kcp.Namespace, kcp.Name, "cluster"))).To(Equal(60.0))
+	g.Expect(testutil.CollectAndCount(kcpRolloutDuration)).To(Equal(durations))
+
+	// The duration is observed when the rollout completes, and the gauge is deleted.
+	updateRolloutMetrics(kcp, "cluster", false, now.Add(2*time.Minute))
+	g.Expect(testutil.CollectAndCount(kcpRolloutDuration)).To(Equal(durations + 1))
+	g.Expect(kcpRolloutInProgress.DeleteLabelValues(kcp.Namespace, kcp.Name, "cluster")).To(BeFalse())
+
+	// Deleting the metrics drops the rollout in progress.
+	updateRolloutMetrics(kcp, "cluster", true, now.Add(3*time.Minute))
+	deleteKubeadmControlPlaneMetrics(kcp)
+	g.Expect(kcpRolloutInProgress.DeleteLabelValues(kcp.Namespace, kcp.Name, "cluster")).To(BeFalse())
+	g.Expect(rolloutStarts).ToNot(HaveKey(kcp))
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/klog/v2"
@@ -34,6 +35,7 @@ import (
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
@@ -80,12 +82,13 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 		return ctrl.Result{}, nil
 	}
 
-	// Select the machine to be remediated, which is the oldest machine marked as unhealthy.
+	// Select the machine to be remediated, which is the oldest machine marked as unhealthy in the failure domain
+	// with most control plane machines.
 	//
 	// NOTE: The current solution is considered acceptable for the most frequent use case (only one unhealthy machine),
 	// however, in the future this could potentially be improved for the scenario where more than one unhealthy machine exists
 	// by considering which machine has lower impact on etcd quorum.
-	machineToBeRemediated := unhealthyMachines.Oldest()
+	machineToBeRemediated := getMachineToBeRemediated(controlPlane, unhealthyMachines)
 
 	// Returns if the machine is in the process of being deleted.
 	if !machineToBeRemediated.ObjectMeta.DeletionTimestamp.IsZero() {
@@ -221,6 +224,8 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 
 	// Surface the operation is in progress.
 	log.Info("Remediating unhealthy machine")
+	r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, controlplanev1.RemediatingMachineEventReason,
+		"Deleting unhealthy control plane Machine %s: %s", machineToBeRemediated.Name, failureDomainSelectionMessage(controlPlane, machineToBeRemediated))
 	conditions.MarkFalse(machineToBeRemediated, clusterv1.MachineOwnerRemediatedCondition, clusterv1.RemediationInProgressReason, clusterv1.ConditionSeverityWarning, "")
 
 	// Prepare the info for tracking the remediation progress into the RemediationInProgressAnnotation.
@@ -238,6 +243,22 @@ func (r *KubeadmControlPlaneReconciler) reconcileUnhealthyMachines(ctx context.C
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// getMachineToBeRemediated returns the unhealthy Machine to be remediated.
+// Machines already being deleted are picked first, so remediation waits for the deletion to complete;
+// otherwise the oldest Machine in the failure domain with most control plane Machines is picked, so the remediation
+// preserves the spread of the control plane across failure domains.
+func getMachineToBeRemediated(controlPlane *internal.ControlPlane, unhealthyMachines collections.Machines) *clusterv1.Machine {
+	if deletingMachines := unhealthyMachines.Filter(collections.HasDeletionTimestamp); deletingMachines.Len() > 0 {
+		return deletingMachines.Oldest()
+	}
+
+	machine, err := controlPlane.MachineInFailureDomainWithMostMachines(unhealthyMachines)
+	if err != nil {
+		return unhealthyMachines.Oldest()
+	}
+	return machine
+}
+
 // checkRetryLimits checks if KCP is allowed to remediate considering retry limits:
 // - Remediation cannot happen because retryPeriod is not yet expired.
 // - KCP already reached the maximum number of retries for a machine.
@@ -1642,6 +1642,53 @@ func TestCanSafelyRemoveEtcdMember(t *testing.T) {
 	})
 }
 
+func TestGetMachineToBeRemediated(t *testing.T) {
+	startDate := time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC)
+	m1 := machine("machine-1", withFailureDomain("one"), withTimestamp(startDate.Add(-3*time.Hour)))
+	m2 := machine("machine-2", withFailureDomain("two"), withTimestamp(startDate.Add(-2*time.Hour)))
+	m3 := machine("machine-3", withFailureDomain("two"), withTimestamp(startDate.Add(-time.Hour)))
+	m4 := machine("machine-4", withFailureDomain("one"), withTimestamp(startDate))
+	m4.DeletionTimestamp = &metav1.Time{Time: startDate}
+
+	controlPlane := &internal.ControlPlane{
+		KCP: &controlplanev1.KubeadmControlPlane{},
+		Cluster: &clusterv1.Cluster{Status: clusterv1.ClusterStatus{FailureDomains: clusterv1.FailureDomains{
+			"one": failureDomain(true),
+			"two": failureDomain(true),
+		}}},
+		Machines: collections.FromMachines(m1, m2, m3),
+	}
+
+	tests := []struct {
+		name              string
+		unhealthyMachines collections.Machines
+		expectedMachine   string
+	}{
+		{
+			name:              "it returns the oldest unhealthy machine in the failure domain with most machines",
+			unhealthyMachines: collections.FromMachines(m1, m3),
+			expectedMachine:   "machine-3",
+		},
+		{
+			name:              "it returns the only unhealthy machine",
+			unhealthyMachines: collections.FromMachines(m1),
+			expectedMachine:   "machine-1",
+		},
+		{
+			name:              "it returns the unhealthy machine being deleted first",
+			unhealthyMachines: collections.FromMachines(m3, m4),
+			expectedMachine:   "machine-4",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(getMachineToBeRemediated(controlPlane, tt.unhealthyMachines).Name).To(Equal(tt.expectedMachine))
+		})
+	}
+}
+
 func nodes(machines collections.Machines) []string {
 	nodes := make([]string, 0, machines.Len())
 	for _, m := range machines {
@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"fmt"
 	"strings"
 
 	"github.com/blang/semver/v4"
@@ -25,6 +26,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 
@@ -42,7 +44,7 @@ func (r *KubeadmControlPlaneReconciler) initializeControlPlane(ctx context.Conte
 	fd := controlPlane.NextFailureDomainForScaleUp()
 	if err := r.cloneConfigsAndGenerateMachine(ctx, controlPlane.Cluster, controlPlane.KCP, bootstrapSpec, fd); err != nil {
 		logger.Error(err, "Failed to create initial control plane Machine")
-		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedInitialization", "Failed to create initial control plane Machine for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.InitializationFailedEventReason, "Failed to create initial control plane Machine for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
 		return ctrl.Result{}, err
 	}
 
@@ -63,7 +65,7 @@ func (r *KubeadmControlPlaneReconciler) scaleUpControlPlane(ctx context.Context,
 	fd := controlPlane.NextFailureDomainForScaleUp()
 	if err := r.cloneConfigsAndGenerateMachine(ctx, controlPlane.Cluster, controlPlane.KCP, bootstrapSpec, fd); err != nil {
 		logger.Error(err, "Failed to create additional control plane Machine")
-		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedScaleUp", "Failed to create additional control plane Machine for cluster % control plane: %v", klog.KObj(controlPlane.Cluster), err)
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.ScaleUpFailedEventReason, "Failed to create additional control plane Machine for cluster %s control plane: %v", klog.KObj(controlPlane.Cluster), err)
 		return ctrl.Result{}, err
 	}
 
@@ -84,9 +86,25 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 		return ctrl.Result{}, errors.Wrap(err, "failed to select machine for scale down")
 	}
 
+	// During a rollout, outdated machines can be deleted concurrently if allowed by rolloutStrategy.rollingUpdate.maxUnavailable.
+	// NOTE: When scaling down due to a replicas change outdatedMachines is always empty and machines are always deleted one by one.
+	deletingMachines := collections.Machines{}
+	if outdatedMachines.Len() > 0 && controlPlane.MaxConcurrentDeletions() > 1 && machineToDelete != nil && machineToDelete.DeletionTimestamp.IsZero() {
+		deletingMachines = controlPlane.Machines.Filter(collections.HasDeletionTimestamp)
+		if deletingMachines.Len() >= controlPlane.MaxConcurrentDeletions() {
+			logger.Info("Waiting for machines to be deleted", "Machines", strings.Join(deletingMachines.Names(), ", "))
+			return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+		}
+		// Never go below the number of machines required to preserve etcd quorum.
+		if available := controlPlane.Machines.Len() - deletingMachines.Len() - 1; available < controlPlane.Quorum() {
+			logger.Info("Waiting for machines to be deleted, deleting another machine would break quorum", "Machines", strings.Join(deletingMachines.Names(), ", "))
+			return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+		}
+	}
+
 	// Run preflight checks ensuring the control plane is stable before proceeding with a scale up/scale down operation; if not, wait.
 	// Given that we're scaling down, we can exclude the machineToDelete from the preflight checks.
-	if result, err := r.preflightChecks(ctx, controlPlane, machineToDelete); err != nil || !result.IsZero() {
+	if result, err := r.preflightChecksAllowingDeletions(ctx, controlPlane, deletingMachines, machineToDelete); err != nil || !result.IsZero() {
 		return result, err
 	}
 
@@ -96,6 +114,14 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create client to workload cluster")
 	}
 
+	// During a rollout with maxSurge=0 the outdated machine is deleted before its replacement is created, so the control plane
+	// temporarily runs with fewer machines than the desired replicas; ensure etcd can safely lose a member first.
+	if outdatedMachines.Len() > 0 && controlPlane.KCP.Spec.Replicas != nil && controlPlane.Machines.Len() <= int(*controlPlane.KCP.Spec.Replicas) {
+		if result, err := r.scaleInPreflightChecks(ctx, controlPlane, workloadCluster); err != nil || !result.IsZero() {
+			return result, err
+		}
+	}
+
 	if machineToDelete == nil {
 		logger.Info("Failed to pick control plane Machine to delete")
 		return ctrl.Result{}, errors.New("failed to pick control plane Machine to delete")
@@ -127,15 +153,34 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 	logger = logger.WithValues("Machine", klog.KObj(machineToDelete))
 	if err := r.Client.Delete(ctx, machineToDelete); err != nil && !apierrors.IsNotFound(err) {
 		logger.Error(err, "Failed to delete control plane machine")
-		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "FailedScaleDown",
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.ScaleDownFailedEventReason,
 			"Failed to delete control plane Machine %s for cluster %s control plane: %v", machineToDelete.Name, klog.KObj(controlPlane.Cluster), err)
 		return ctrl.Result{}, err
 	}
+	r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeNormal, controlplanev1.ScalingDownEventReason,
+		"Deleting control plane Machine %s: %s", machineToDelete.Name, failureDomainSelectionMessage(controlPlane, machineToDelete))
 
 	// Requeue the control plane, in case there are additional operations to perform
 	return ctrl.Result{Requeue: true}, nil
 }
 
+// failureDomainSelectionMessage explains why a control plane Machine has been selected for deletion
+// with regard to its failure domain.
+func failureDomainSelectionMessage(controlPlane *internal.ControlPlane, machine *clusterv1.Machine) string {
+	failureDomains := controlPlane.FailureDomains().FilterControlPlane()
+	if len(failureDomains) == 0 {
+		return "no failure domains defined for the control plane"
+	}
+	if machine.Spec.FailureDomain == nil {
+		return "Machine is not in a failure domain"
+	}
+	if _, ok := failureDomains[*machine.Spec.FailureDomain]; !ok {
+		return fmt.Sprintf("failure domain %q is not defined for the control plane", *machine.Spec.FailureDomain)
+	}
+	machinesInFailureDomain := controlPlane.Machines.CountByFailureDomain()[*machine.Spec.FailureDomain]
+	return fmt.Sprintf("failure domain %q has %d of %d control plane Machines", *machine.Spec.FailureDomain, machinesInFailureDomain, controlPlane.Machines.Len())
+}
+
 // preflightChecks checks if the control plane is stable before proceeding with a scale up/scale down operation,
 // where stable means that:
 // - There are no machine deletion in progress
@@ -145,6 +190,13 @@ func (r *KubeadmControlPlaneReconciler) scaleDownControlPlane(
 //
 // NOTE: this func uses KCP conditions, it is required to call reconcileControlPlaneConditions before this.
 func (r *KubeadmControlPlaneReconciler) preflightChecks(ctx context.Context, controlPlane *internal.ControlPlane, excludeFor ...*clusterv1.Machine) (ctrl.Result, error) { //nolint:unparam
+	return r.preflightChecksAllowingDeletions(ctx, controlPlane, nil, excludeFor...)
+}
+
+// preflightChecksAllowingDeletions is like preflightChecks, but it does not wait for the given deleting machines
+// to be deleted and it excludes them from the health checks; this allows to delete multiple machines concurrently
+// during a rollout.
+func (r *KubeadmControlPlaneReconciler) preflightChecksAllowingDeletions(ctx context.Context, controlPlane *internal.ControlPlane, allowedDeletions collections.Machines, excludeFor ...*clusterv1.Machine) (ctrl.Result, error) {
 	logger := ctrl.LoggerFrom(ctx)
 
 	// If there is no KCP-owned control-plane machines, then control-plane has not been initialized yet,
@@ -154,23 +206,16 @@ func (r *KubeadmControlPlaneReconciler) preflightChecks(ctx context.Context, con
 	}
 
 	// If there are deleting machines, wait for the operation to complete.
-	if controlPlane.HasDeletingMachine() {
-		logger.Info("Waiting for machines to be deleted", "Machines", strings.Join(controlPlane.Machines.Filter(collections.HasDeletionTimestamp).Names(), ", "))
+	if deletingMachines := controlPlane.Machines.Filter(collections.HasDeletionTimestamp).Difference(allowedDeletions); deletingMachines.Len() > 0 {
+		logger.Info("Waiting for machines to be deleted", "Machines", strings.Join(deletingMachines.Names(), ", "))
 		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
 	}
+	for _, machine := range allowedDeletions {
+		excludeFor = append(excludeFor, machine)
+	}
 
 	// Check machine health conditions; if there are conditions with False or Unknown, then wait.
-	allMachineHealthConditions := []clusterv1.ConditionType{
-		controlplanev1.MachineAPIServerPodHealthyCondition,
-		controlplanev1.MachineControllerManagerPodHealthyCondition,
-		controlplanev1.MachineSchedulerPodHealthyCondition,
-	}
-	if controlPlane.IsEtcdManaged() {
-		allMachineHealthConditions = append(allMachineHealthConditions,
-			controlplanev1.MachineEtcdPodHealthyCondition,
-			controlplanev1.MachineEtcdMemberHealthyCondition,
-		)
-	}
+	allMachineHealthConditions := machineHealthConditions(controlPlane)
 	machineErrors := []error{}
 
 loopmachines:
@@ -199,7 +244,7 @@ loopmachines:
 	}
 	if len(machineErrors) > 0 {
 		aggregatedError := kerrors.NewAggregate(machineErrors)
-		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, "ControlPlaneUnhealthy",
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.ControlPlaneUnhealthyEventReason,
 			"Waiting for control plane to pass preflight checks to continue reconciliation: %v", aggregatedError)
 		logger.Info("Waiting for control plane to pass preflight checks", "failures", aggregatedError.Error())
 
@@ -209,6 +254,73 @@ loopmachines:
 	return ctrl.Result{}, nil
 }
 
+// scaleInPreflightChecks checks if etcd can safely lose a member before scaling in the control plane below the desired
+// number of replicas, e.g. during a rollout with maxSurge=0, where safe means that:
+// - Each etcd member has a corresponding control plane machine, and vice versa.
+// - The etcd cluster still has quorum for the desired number of replicas without the member being removed.
+// If the control plane is not passing the checks, it requeue.
+//
+// NOTE: this func does not check the health of the etcd members, it is required to call preflightChecks as well.
+func (r *KubeadmControlPlaneReconciler) scaleInPreflightChecks(ctx context.Context, controlPlane *internal.ControlPlane, workloadCluster internal.WorkloadCluster) (ctrl.Result, error) {
+	logger := ctrl.LoggerFrom(ctx)
+
+	if !controlPlane.IsEtcdManaged() {
+		return ctrl.Result{}, nil
+	}
+
+	etcdMembers, err := workloadCluster.EtcdMembers(ctx)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get etcd members for workload cluster %s", controlPlane.Cluster.Name)
+	}
+
+	nodeNames := sets.Set[string]{}
+	for _, machine := range controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp)) {
+		if machine.Status.NodeRef == nil {
+			continue
+		}
+		nodeNames.Insert(machine.Status.NodeRef.Name)
+	}
+
+	var failures []string
+	members := sets.New[string](etcdMembers...)
+	if membersWithoutMachine := members.Difference(nodeNames); membersWithoutMachine.Len() > 0 {
+		failures = append(failures, fmt.Sprintf("etcd members %s do not have a corresponding control plane Machine", strings.Join(sets.List(membersWithoutMachine), ", ")))
+	}
+	if machinesWithoutMember := nodeNames.Difference(members); machinesWithoutMember.Len() > 0 {
+		failures = append(failures, fmt.Sprintf("control plane Nodes %s do not have a corresponding etcd member", strings.Join(sets.List(machinesWithoutMember), ", ")))
+	}
+	if target := members.Len() - 1; target < controlPlane.Quorum() {
+		failures = append(failures, fmt.Sprintf("removing an etcd member would leave %d members, while %d are required for quorum", target, controlPlane.Quorum()))
+	}
+
+	if len(failures) > 0 {
+		message := strings.Join(failures, "; ")
+		r.recorder.Eventf(controlPlane.KCP, corev1.EventTypeWarning, controlplanev1.ControlPlaneUnhealthyEventReason,
+			"Waiting for etcd to be safe to scale in to continue reconciliation: %s", message)
+		logger.Info("Waiting for etcd to be safe to scale in", "failures", message)
+
+		return ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}, nil
+	}
+
+	return ctrl.Result{}, nil
+}
+
+// machineHealthConditions returns the conditions reporting the health of a control plane machine.
+func machineHealthConditions(controlPlane *internal.ControlPlane) []clusterv1.ConditionType {
+	allMachineHealthConditions := []clusterv1.ConditionType{
+		controlplanev1.MachineAPIServerPodHealthyCondition,
+		controlplanev1.MachineControllerManagerPodHealthyCondition,
+		controlplanev1.MachineSchedulerPodHealthyCondition,
+	}
+	if controlPlane.IsEtcdManaged() {
+		allMachineHealthConditions = append(allMachineHealthConditions,
+			controlplanev1.MachineEtcdPodHealthyCondition,
+			controlplanev1.MachineEtcdMemberHealthyCondition,
+		)
+	}
+	return allMachineHealthConditions
+}
+
 func preflightCheckCondition(kind string, obj conditions.Getter, condition clusterv1.ConditionType) error {
 	c := conditions.Get(obj, condition)
 	if c == nil {
@@ -26,7 +26,9 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -361,6 +363,95 @@ func TestKubeadmControlPlaneReconciler_scaleDownControlPlane_NoError(t *testing.
 		g.Expect(fakeClient.List(context.Background(), &controlPlaneMachines)).To(Succeed())
 		g.Expect(controlPlaneMachines.Items).To(HaveLen(3))
 	})
+
+	t.Run("deletes outdated control plane Machines concurrently during a rollout", func(t *testing.T) {
+		tests := []struct {
+			name             string
+			machines         int
+			maxUnavailable   int
+			wantResult       ctrl.Result
+			wantMachinesLeft int
+		}{
+			{
+				name:             "deletes another Machine while one is being deleted if allowed by maxUnavailable",
+				machines:         6,
+				maxUnavailable:   2,
+				wantResult:       ctrl.Result{Requeue: true},
+				wantMachinesLeft: 4,
+			},
+			{
+				name:             "waits for the deleting Machine if maxUnavailable is 1",
+				machines:         6,
+				maxUnavailable:   1,
+				wantResult:       ctrl.Result{RequeueAfter: deleteRequeueAfter},
+				wantMachinesLeft: 5,
+			},
+			{
+				name:             "waits for the deleting Machine if deleting another Machine would break quorum",
+				machines:         4,
+				maxUnavailable:   2,
+				wantResult:       ctrl.Result{RequeueAfter: deleteRequeueAfter},
+				wantMachinesLeft: 3,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+
+				// The oldest Machine is already being deleted; given that the fake client does not allow
+				// to create objects with a deletionTimestamp, it is only part of the control plane.
+				deleting := machine("deleting", withTimestamp(time.Now().Add(-2*time.Hour)))
+				deleting.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+				machines := collections.FromMachines(deleting)
+				objs := []client.Object{}
+				for i := 1; i < tt.machines; i++ {
+					m := machine(fmt.Sprintf("machine-%d", i), withTimestamp(time.Now().Add(-time.Duration(tt.machines-i)*time.Minute)))
+					setMachineHealthy(m)
+					machines.Insert(m)
+					objs = append(objs, m)
+				}
+				fakeClient := newFakeClient(objs...)
+
+				r := &KubeadmControlPlaneReconciler{
+					recorder:            record.NewFakeRecorder(32),
+					Client:              fakeClient,
+					SecretCachingClient: fakeClient,
+					managementCluster: &fakeManagementCluster{
+						Workload: fakeWorkloadCluster{},
+					},
+				}
+
+				kcp := &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						Replicas: pointer.Int32(5),
+						Version:  "v1.19.1",
+						RolloutStrategy: &controlplanev1.RolloutStrategy{
+							Type: controlplanev1.RollingUpdateStrategyType,
+							RollingUpdate: &controlplanev1.RollingUpdate{
+								MaxUnavailable: &intstr.IntOrString{IntVal: int32(tt.maxUnavailable)},
+							},
+						},
+					},
+				}
+				setKCPHealthy(kcp)
+				controlPlane := &internal.ControlPlane{
+					KCP:      kcp,
+					Cluster:  &clusterv1.Cluster{},
+					Machines: machines,
+				}
+				controlPlane.InjectTestManagementCluster(r.managementCluster)
+
+				outdatedMachines := controlPlane.Machines.Filter(collections.Not(collections.HasDeletionTimestamp))
+				result, err := r.scaleDownControlPlane(context.Background(), controlPlane, outdatedMachines)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(result).To(BeComparableTo(tt.wantResult))
+
+				controlPlaneMachines := clusterv1.MachineList{}
+				g.Expect(fakeClient.List(context.Background(), &controlPlaneMachines)).To(Succeed())
+				g.Expect(controlPlaneMachines.Items).To(HaveLen(tt.wantMachinesLeft))
+			})
+		}
+	})
 }
 
 func TestSelectMachineForScaleDown(t *testing.T) {
@@ -477,6 +568,61 @@ func TestSelectMachineForScaleDown(t *testing.T) {
 	}
 }
 
+func TestFailureDomainSelectionMessage(t *testing.T) {
+	m1 := machine("machine-1", withFailureDomain("one"))
+	m2 := machine("machine-2", withFailureDomain("one"))
+	m3 := machine("machine-3", withFailureDomain("two"))
+	m4 := machine("machine-4", withFailureDomain("three"))
+	m5 := machine("machine-5")
+	fd := clusterv1.FailureDomains{
+		"one": failureDomain(true),
+		"two": failureDomain(true),
+	}
+
+	tests := []struct {
+		name            string
+		failureDomains  clusterv1.FailureDomains
+		machine         *clusterv1.Machine
+		expectedMessage string
+	}{
+		{
+			name:            "machine in a failure domain",
+			failureDomains:  fd,
+			machine:         m1,
+			expectedMessage: `failure domain "one" has 2 of 5 control plane Machines`,
+		},
+		{
+			name:            "machine in a failure domain not defined for the control plane",
+			failureDomains:  fd,
+			machine:         m4,
+			expectedMessage: `failure domain "three" is not defined for the control plane`,
+		},
+		{
+			name:            "machine without a failure domain",
+			failureDomains:  fd,
+			machine:         m5,
+			expectedMessage: "Machine is not in a failure domain",
+		},
+		{
+			name:            "no failure domains",
+			machine:         m1,
+			expectedMessage: "no failure domains defined for the control plane",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &internal.ControlPlane{
+				KCP:      &controlplanev1.KubeadmControlPlane{},
+				Cluster:  &clusterv1.Cluster{Status: clusterv1.ClusterStatus{FailureDomains: tt.failureDomains}},
+				Machines: collections.FromMachines(m1, m2, m3, m4, m5),
+			}
+			g.Expect(failureDomainSelectionMessage(controlPlane, tt.machine)).To(Equal(tt.expectedMessage))
+		})
+	}
+}
+
 func TestPreflightChecks(t *testing.T) {
 	testCases := []struct {
 		name         string
@@ -585,6 +731,113 @@ func TestPreflightChecks(t *testing.T) {
 	}
 }
 
+func TestScaleInPreflightChecks(t *testing.T) {
+	machineWithNode := func(name, nodeName string, opts ...machineOpt) *clusterv1.Machine {
+		m := machine(name, opts...)
+		m.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: nodeName}
+		return m
+	}
+
+	testCases := []struct {
+		name         string
+		kcp          *controlplanev1.KubeadmControlPlane
+		machines     []*clusterv1.Machine
+		etcdMembers  []string
+		expectResult ctrl.Result
+	}{
+		{
+			name: "control plane with etcd members matching the machines should pass",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{Replicas: pointer.Int32(3)},
+			},
+			machines: []*clusterv1.Machine{
+				machineWithNode("m1", "node-1"),
+				machineWithNode("m2", "node-2"),
+				machineWithNode("m3", "node-3"),
+			},
+			etcdMembers:  []string{"node-1", "node-2", "node-3"},
+			expectResult: ctrl.Result{},
+		},
+		{
+			name: "control plane with an etcd member without a machine should requeue",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{Replicas: pointer.Int32(3)},
+			},
+			machines: []*clusterv1.Machine{
+				machineWithNode("m1", "node-1"),
+				machineWithNode("m2", "node-2"),
+				machineWithNode("m3", "node-3"),
+			},
+			etcdMembers:  []string{"node-1", "node-2", "node-3", "node-4"},
+			expectResult: ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+		{
+			name: "control plane with a machine without an etcd member should requeue",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{Replicas: pointer.Int32(3)},
+			},
+			machines: []*clusterv1.Machine{
+				machineWithNode("m1", "node-1"),
+				machineWithNode("m2", "node-2"),
+				machineWithNode("m3", "node-3"),
+			},
+			etcdMembers:  []string{"node-1", "node-2"},
+			expectResult: ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+		{
+			name: "control plane where removing an etcd member would break quorum should requeue",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{Replicas: pointer.Int32(3)},
+			},
+			machines: []*clusterv1.Machine{
+				machineWithNode("m1", "node-1"),
+				machineWithNode("m2", "node-2"),
+				machineWithNode("m3", "node-3", withDeletionTimestamp(time.Now())),
+			},
+			etcdMembers:  []string{"node-1", "node-2"},
+			expectResult: ctrl.Result{RequeueAfter: preflightFailedRequeueAfter},
+		},
+		{
+			name: "control plane with external etcd should pass",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					Replicas: pointer.Int32(3),
+					KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+						ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+							Etcd: bootstrapv1.Etcd{External: &bootstrapv1.ExternalEtcd{}},
+						},
+					},
+				},
+			},
+			machines: []*clusterv1.Machine{
+				machineWithNode("m1", "node-1"),
+				machineWithNode("m2", "node-2"),
+				machineWithNode("m3", "node-3"),
+			},
+			expectResult: ctrl.Result{},
+		},
+	}
+
+	for _, tt := range testCases {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &KubeadmControlPlaneReconciler{
+				recorder: record.NewFakeRecorder(32),
+			}
+			controlPlane := &internal.ControlPlane{
+				Cluster:  &clusterv1.Cluster{},
+				KCP:      tt.kcp,
+				Machines: collections.FromMachines(tt.machines...),
+			}
+			workloadCluster := fakeWorkloadCluster{EtcdMembersResult: tt.etcdMembers}
+			result, err := r.scaleInPreflightChecks(context.TODO(), controlPlane, workloadCluster)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(result).To(BeComparableTo(tt.expectResult))
+		})
+	}
+}
+
 func TestPreflightCheckCondition(t *testing.T) {
 	condition := clusterv1.ConditionType("fooCondition")
 	testCases := []struct {
@@ -670,3 +923,9 @@ func withTimestamp(t time.Time) machineOpt {
 		m.CreationTimestamp = metav1.NewTime(t)
 	}
 }
+
+func withDeletionTimestamp(t time.Time) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.DeletionTimestamp = &metav1.Time{Time: t}
+	}
+}
@@ -18,15 +18,19 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	ctrl "sigs.k8s.io/controller-runtime"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
@@ -125,15 +129,66 @@ func (r *KubeadmControlPlaneReconciler) upgradeControlPlane(
 	switch controlPlane.KCP.Spec.RolloutStrategy.Type {
 	case controlplanev1.RollingUpdateStrategyType:
 		// RolloutStrategy is currently defaulted and validated to be RollingUpdate
-		// We can ignore MaxUnavailable because we are enforcing health checks before we get here.
+		// MaxUnavailable is taken into account when scaling down, by allowing multiple outdated machines to be
+		// deleted concurrently on control planes with 5 or more replicas; health checks are still enforced before
+		// each scale up/scale down operation.
 		maxNodes := *controlPlane.KCP.Spec.Replicas + int32(controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate.MaxSurge.IntValue())
 		if int32(controlPlane.Machines.Len()) < maxNodes {
 			// scaleUp ensures that we don't continue scaling up while waiting for Machines to have NodeRefs
 			return r.scaleUpControlPlane(ctx, controlPlane)
 		}
+		// Ensure the up-to-date machines have been healthy for at least SoakDuration before replacing the next outdated machine.
+		if remaining := machinesSoakRemaining(controlPlane, machinesRequireUpgrade, time.Now()); remaining > 0 {
+			logger.Info("Waiting for the up-to-date control plane machines to soak before replacing the next outdated machine",
+				"soakDuration", controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate.SoakDuration.Duration, "remaining", remaining)
+			return ctrl.Result{RequeueAfter: remaining}, nil
+		}
 		return r.scaleDownControlPlane(ctx, controlPlane, machinesRequireUpgrade)
 	default:
 		logger.Info("RolloutStrategy type is not set to RollingUpdateStrategyType, unable to determine the strategy for rolling out machines")
 		return ctrl.Result{}, nil
 	}
 }
+
+// machinesSoakRemaining returns how long the up-to-date machines still have to be continuously healthy before
+// the next outdated machine can be replaced, according to the SoakDuration of the rollout strategy.
+// A machine is considered healthy since the last transition of its health conditions, if all of them are true.
+// NOTE: Machines which are not healthy are ignored here, because the preflight checks already wait for them to
+// become healthy before scaling down; then they will have to soak for the entire SoakDuration.
+func machinesSoakRemaining(controlPlane *internal.ControlPlane, machinesRequireUpgrade collections.Machines, now time.Time) time.Duration {
+	rollingUpdate := controlPlane.KCP.Spec.RolloutStrategy.RollingUpdate
+	if rollingUpdate.SoakDuration == nil || rollingUpdate.SoakDuration.Duration <= 0 {
+		return 0
+	}
+
+	var remaining time.Duration
+	upToDateMachines := controlPlane.Machines.Difference(machinesRequireUpgrade).Filter(collections.Not(collections.HasDeletionTimestamp))
+	for _, machine := range upToDateMachines {
+		healthySince, healthy := machineHealthySince(controlPlane, machine)
+		if !healthy {
+			continue
+		}
+		if machineRemaining := healthySince.Add(rollingUpdate.SoakDuration.Duration).Sub(now); machineRemaining > remaining {
+			remaining = machineRemaining
+		}
+	}
+	return remaining
+}
+
+// machineHealthySince returns the time since when a machine has all its health conditions true, if it is healthy.
+func machineHealthySince(controlPlane *internal.ControlPlane, machine *clusterv1.Machine) (time.Time, bool) {
+	var healthySince time.Time
+	if machine.Status.NodeRef == nil {
+		return healthySince, false
+	}
+	for _, condition := range machineHealthConditions(controlPlane) {
+		c := conditions.Get(machine, condition)
+		if c == nil || c.Status != corev1.ConditionTrue {
+			return healthySince, false
+		}
+		if c.LastTransitionTime.Time.After(healthySince) {
+			healthySince = c.LastTransitionTime.Time
+		}
+	}
+	return healthySince, true
+}
@@ -33,11 +33,13 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 const UpdatedVersion string = "v1.17.4"
@@ -193,7 +195,8 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	fmc := &fakeManagementCluster{
 		Machines: collections.Machines{},
 		Workload: fakeWorkloadCluster{
-			Status: internal.ClusterStatus{Nodes: 3},
+			Status:            internal.ClusterStatus{Nodes: 3},
+			EtcdMembersResult: []string{"node-0", "node-1", "node-2"},
 		},
 	}
 	objs := []client.Object{builder.GenericInfrastructureMachineTemplateCRD, cluster.DeepCopy(), kcp.DeepCopy(), tmpl.DeepCopy()}
@@ -230,6 +233,7 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	r := &KubeadmControlPlaneReconciler{
 		Client:                    fakeClient,
 		SecretCachingClient:       fakeClient,
+		recorder:                  record.NewFakeRecorder(32),
 		managementCluster:         fmc,
 		managementClusterUncached: fmc,
 	}
@@ -250,14 +254,29 @@ func TestKubeadmControlPlaneReconciler_RolloutStrategy_ScaleDown(t *testing.T) {
 	g.Expect(machineList.Items).To(HaveLen(3))
 	for i := range machineList.Items {
 		setMachineHealthy(&machineList.Items[i])
+		machineList.Items[i].Status.NodeRef.Name = fmt.Sprintf("node-%d", i)
 	}
 
 	// change the KCP spec so the machine becomes outdated
 	kcp.Spec.Version = UpdatedVersion
 
-	// run upgrade, expect we scale down
+	// run upgrade with an etcd member without a corresponding machine, expect we don't scale down
+	// because removing another etcd member would put the etcd quorum at risk
 	needingUpgrade := collections.FromMachineList(machineList)
 	controlPlane.Machines = needingUpgrade
+	fmc.Workload.EtcdMembersResult = []string{"node-0", "node-1", "node-2", "node-3"}
+	controlPlane.InjectTestManagementCluster(fmc)
+
+	result, err = r.upgradeControlPlane(ctx, controlPlane, needingUpgrade)
+	g.Expect(result).To(BeComparableTo(ctrl.Result{RequeueAfter: preflightFailedRequeueAfter}))
+	g.Expect(err).ToNot(HaveOccurred())
+	currentMachines := &clusterv1.MachineList{}
+	g.Expect(fakeClient.List(ctx, currentMachines, client.InNamespace(cluster.Namespace))).To(Succeed())
+	g.Expect(currentMachines.Items).To(HaveLen(3))
+
+	// run upgrade, expect we scale down
+	fmc.Workload.EtcdMembersResult = []string{"node-0", "node-1", "node-2"}
+	controlPlane.InjectTestManagementCluster(fmc)
 
 	result, err = r.upgradeControlPlane(ctx, controlPlane, needingUpgrade)
 	g.Expect(result).To(BeComparableTo(ctrl.Result{Requeue: true}))
@@ -281,3 +300,105 @@ func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	}
 	return m
 }
+
+func TestMachinesSoakRemaining(t *testing.T) {
+	now := time.Now()
+
+	healthySince := func(since time.Time) machineOpt {
+		return func(m *clusterv1.Machine) {
+			m.Status.NodeRef = &corev1.ObjectReference{Kind: "Node", Name: m.Name}
+			for _, condition := range []clusterv1.ConditionType{
+				controlplanev1.MachineAPIServerPodHealthyCondition,
+				controlplanev1.MachineControllerManagerPodHealthyCondition,
+				controlplanev1.MachineSchedulerPodHealthyCondition,
+				controlplanev1.MachineEtcdPodHealthyCondition,
+				controlplanev1.MachineEtcdMemberHealthyCondition,
+			} {
+				m.Status.Conditions = append(m.Status.Conditions, clusterv1.Condition{
+					Type:               condition,
+					Status:             corev1.ConditionTrue,
+					LastTransitionTime: metav1.NewTime(since),
+				})
+			}
+		}
+	}
+	unhealthy := func(m *clusterv1.Machine) {
+		conditions.MarkFalse(m, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityError, "etcd member has alarms")
+	}
+
+	tests := []struct {
+		name         string
+		soakDuration *metav1.Duration
+		outdated     collections.Machines
+		upToDate     collections.Machines
+		want         time.Duration
+	}{
+		{
+			name:         "no soak duration",
+			soakDuration: nil,
+			outdated:     collections.FromMachines(machine("m1")),
+			upToDate:     collections.FromMachines(machine("m2", healthySince(now))),
+			want:         0,
+		},
+		{
+			name:         "no up-to-date machines",
+			soakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			outdated:     collections.FromMachines(machine("m1"), machine("m2")),
+			want:         0,
+		},
+		{
+			name:         "up-to-date machine healthy for less than the soak duration",
+			soakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			outdated:     collections.FromMachines(machine("m1")),
+			upToDate:     collections.FromMachines(machine("m2", healthySince(now.Add(-4*time.Minute)))),
+			want:         6 * time.Minute,
+		},
+		{
+			name:         "up-to-date machines healthy for longer than the soak duration",
+			soakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			outdated:     collections.FromMachines(machine("m1")),
+			upToDate: collections.FromMachines(
+				machine("m2", healthySince(now.Add(-20*time.Minute))),
+				machine("m3", healthySince(now.Add(-10*time.Minute))),
+			),
+			want: 0,
+		},
+		{
+			name:         "the most recently healthy machine determines the remaining soak",
+			soakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			outdated:     collections.FromMachines(machine("m1")),
+			upToDate: collections.FromMachines(
+				machine("m2", healthySince(now.Add(-20*time.Minute))),
+				machine("m3", healthySince(now.Add(-2*time.Minute))),
+			),
+			want: 8 * time.Minute,
+		},
+		{
+			name:         "unhealthy up-to-date machines are left to the preflight checks",
+			soakDuration: &metav1.Duration{Duration: 10 * time.Minute},
+			outdated:     collections.FromMachines(machine("m1")),
+			upToDate:     collections.FromMachines(machine("m2", healthySince(now), unhealthy)),
+			want:         0,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			controlPlane := &internal.ControlPlane{
+				KCP: &controlplanev1.KubeadmControlPlane{
+					Spec: controlplanev1.KubeadmControlPlaneSpec{
+						RolloutStrategy: &controlplanev1.RolloutStrategy{
+							RollingUpdate: &controlplanev1.RollingUpdate{
+								SoakDuration: tt.soakDuration,
+							},
+						},
+					},
+				},
+				Machines: collections.FromMachines(append(tt.outdated.UnsortedList(), tt.upToDate.UnsortedList()...)...),
+			}
+
+			g.Expect(machinesSoakRemaining(controlPlane, tt.outdated, now)).To(Equal(tt.want))
+		})
+	}
+}
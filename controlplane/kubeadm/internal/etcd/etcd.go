@@ -37,8 +37,10 @@ type GRPCDial func(ctx context.Context, addr string) (net.Conn, error)
 // etcd wraps the etcd client from etcd's clientv3 package.
 // This interface is implemented by both the clientv3 package and the backoff adapter that adds retries to the client.
 type etcd interface {
+	AlarmDisarm(ctx context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error)
 	AlarmList(ctx context.Context) (*clientv3.AlarmResponse, error)
 	Close() error
+	Defragment(ctx context.Context, endpoint string) (*clientv3.DefragmentResponse, error)
 	Endpoints() []string
 	MemberList(ctx context.Context) (*clientv3.MemberListResponse, error)
 	MemberRemove(ctx context.Context, id uint64) (*clientv3.MemberRemoveResponse, error)
@@ -83,6 +85,11 @@ const (
 // for read and write operations to etcd.
 const DefaultCallTimeout = 15 * time.Second
 
+// DefaultDefragmentTimeout represents the duration that the etcd client waits at most
+// for the defragmentation of an etcd member; defragmentation is a blocking operation
+// that can take longer than other operations, depending on the DB size.
+const DefaultDefragmentTimeout = 5 * time.Minute
+
 // AlarmTypeName provides a text translation for AlarmType codes.
 var AlarmTypeName = map[AlarmType]string{
 	AlarmOK:      "NONE",
@@ -129,6 +136,22 @@ func pbMemberToMember(m *etcdserverpb.Member) *Member {
 	}
 }
 
+// MemberStatus represents the status of the etcd member a Client is connected to.
+type MemberStatus struct {
+	// ID is the ID of the member.
+	ID uint64
+
+	// IsLeader is true if the member is the leader of the etcd cluster.
+	IsLeader bool
+
+	// DBSize is the size of the DB of the member, in bytes.
+	DBSize int64
+
+	// DBSizeInUse is the size of the DB of the member actually in use, in bytes;
+	// the difference with DBSize is the space that can be reclaimed by defragmentation.
+	DBSizeInUse int64
+}
+
 // ClientConfiguration describes the configuration for an etcd client.
 type ClientConfiguration struct {
 	Endpoint    string
@@ -286,3 +309,45 @@ func (c *Client) Alarms(ctx context.Context) ([]MemberAlarm, error) {
 
 	return memberAlarms, nil
 }
+
+// MemberStatus retrieves the status of the etcd member the client is connected to.
+func (c *Client) MemberStatus(ctx context.Context) (*MemberStatus, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.CallTimeout)
+	defer cancel()
+
+	status, err := c.EtcdClient.Status(ctx, c.Endpoint)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to get status of etcd member %s", c.Endpoint)
+	}
+
+	memberID := status.Header.GetMemberId()
+	return &MemberStatus{
+		ID:          memberID,
+		IsLeader:    memberID == status.Leader,
+		DBSize:      status.DbSize,
+		DBSizeInUse: status.DbSizeInUse,
+	}, nil
+}
+
+// Defragment defragments the DB of the etcd member the client is connected to.
+// NOTE: Defragmentation is a blocking operation for the etcd member, which cannot serve requests while
+// the defragmentation is in progress.
+func (c *Client) Defragment(ctx context.Context) error {
+	ctx, cancel := context.WithTimeout(ctx, DefaultDefragmentTimeout)
+	defer cancel()
+
+	_, err := c.EtcdClient.Defragment(ctx, c.Endpoint)
+	return errors.Wrapf(err, "failed to defragment etcd member %s", c.Endpoint)
+}
+
+// DisarmAlarm disarms an alarm raised by an etcd member.
+func (c *Client) DisarmAlarm(ctx context.Context, alarm MemberAlarm) error {
+	ctx, cancel := context.WithTimeout(ctx, c.CallTimeout)
+	defer cancel()
+
+	_, err := c.EtcdClient.AlarmDisarm(ctx, &clientv3.AlarmMember{
+		MemberID: alarm.MemberID,
+		Alarm:    etcdserverpb.AlarmType(alarm.Type),
+	})
+	return errors.Wrapf(err, "failed to disarm alarm %s for etcd member %v", AlarmTypeName[alarm.Type], alarm.MemberID)
+}
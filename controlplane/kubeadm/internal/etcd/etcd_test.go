@@ -104,3 +104,36 @@ func TestEtcdMembers_WithSuccess(t *testing.T) {
 	g.Expect(updatedMembers[0].PeerURLs).To(HaveLen(2))
 	g.Expect(updatedMembers[0].PeerURLs).To(Equal([]string{"https://1.2.3.4:2000", "https://4.5.6.7:2000"}))
 }
+
+func TestEtcdMaintenance(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClient := &etcdfake.FakeEtcdClient{
+		EtcdEndpoints: []string{"https://etcd-instance:2379"},
+		StatusResponse: &clientv3.StatusResponse{
+			Header:      &etcdserverpb.ResponseHeader{MemberId: 1234},
+			Leader:      1234,
+			DbSize:      100,
+			DbSizeInUse: 40,
+		},
+		AlarmResponse:      &clientv3.AlarmResponse{},
+		DefragmentResponse: &clientv3.DefragmentResponse{},
+	}
+
+	client, err := newEtcdClient(ctx, fakeEtcdClient, DefaultCallTimeout)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	status, err := client.MemberStatus(ctx)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(*status).To(Equal(MemberStatus{ID: 1234, IsLeader: true, DBSize: 100, DBSizeInUse: 40}))
+
+	g.Expect(client.Defragment(ctx)).To(Succeed())
+	g.Expect(fakeEtcdClient.Defragmented).To(Equal([]string{"https://etcd-instance:2379"}))
+
+	g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).To(Succeed())
+	g.Expect(fakeEtcdClient.DisarmedAlarms).To(Equal([]*clientv3.AlarmMember{{MemberID: 1234, Alarm: etcdserverpb.AlarmType_NOSPACE}}))
+
+	fakeEtcdClient.ErrorResponse = errors.New("something went wrong")
+	g.Expect(client.Defragment(ctx)).ToNot(Succeed())
+	g.Expect(client.DisarmAlarm(ctx, MemberAlarm{MemberID: 1234, Type: AlarmNoSpace})).ToNot(Succeed())
+}
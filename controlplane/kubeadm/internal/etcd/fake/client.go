@@ -25,6 +25,7 @@ import (
 
 type FakeEtcdClient struct { //nolint:revive
 	AlarmResponse        *clientv3.AlarmResponse
+	DefragmentResponse   *clientv3.DefragmentResponse
 	EtcdEndpoints        []string
 	MemberListResponse   *clientv3.MemberListResponse
 	MemberRemoveResponse *clientv3.MemberRemoveResponse
@@ -34,6 +35,8 @@ type FakeEtcdClient struct { //nolint:revive
 	ErrorResponse        error
 	MovedLeader          uint64
 	RemovedMember        uint64
+	Defragmented         []string
+	DisarmedAlarms       []*clientv3.AlarmMember
 }
 
 func (c *FakeEtcdClient) Endpoints() []string {
@@ -53,6 +56,16 @@ func (c *FakeEtcdClient) AlarmList(_ context.Context) (*clientv3.AlarmResponse,
 	return c.AlarmResponse, c.ErrorResponse
 }
 
+func (c *FakeEtcdClient) AlarmDisarm(_ context.Context, m *clientv3.AlarmMember) (*clientv3.AlarmResponse, error) {
+	c.DisarmedAlarms = append(c.DisarmedAlarms, m)
+	return c.AlarmResponse, c.ErrorResponse
+}
+
+func (c *FakeEtcdClient) Defragment(_ context.Context, endpoint string) (*clientv3.DefragmentResponse, error) {
+	c.Defragmented = append(c.Defragmented, endpoint)
+	return c.DefragmentResponse, c.ErrorResponse
+}
+
 func (c *FakeEtcdClient) MemberList(_ context.Context) (*clientv3.MemberListResponse, error) {
 	return c.MemberListResponse, c.ErrorResponse
 }
@@ -22,6 +22,7 @@ import (
 	"reflect"
 	"strings"
 
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
@@ -116,17 +117,33 @@ func matchesTemplateClonedFrom(infraConfigs map[string]*unstructured.Unstructure
 		return "", true
 	}
 
-	// Check if the machine's infrastructure reference has been created from the current KCP infrastructure template.
-	if clonedFromName != kcp.Spec.MachineTemplate.InfrastructureRef.Name ||
-		clonedFromGroupKind != kcp.Spec.MachineTemplate.InfrastructureRef.GroupVersionKind().GroupKind().String() {
+	// Check if the machine's infrastructure reference has been created from the current KCP infrastructure template
+	// for the failure domain of the machine.
+	infraRef := InfrastructureRefForFailureDomain(kcp, machine.Spec.FailureDomain)
+	if clonedFromName != infraRef.Name ||
+		clonedFromGroupKind != infraRef.GroupVersionKind().GroupKind().String() {
 		return fmt.Sprintf("Infrastructure template on KCP rotated from %s %s to %s %s",
 			clonedFromGroupKind, clonedFromName,
-			kcp.Spec.MachineTemplate.InfrastructureRef.GroupVersionKind().GroupKind().String(), kcp.Spec.MachineTemplate.InfrastructureRef.Name), false
+			infraRef.GroupVersionKind().GroupKind().String(), infraRef.Name), false
 	}
 
 	return "", true
 }
 
+// InfrastructureRefForFailureDomain returns the infrastructure template to be used for the control plane machines
+// in the given failure domain, i.e. the one from the failure domain overrides in the KCP machine template, if any,
+// or the infrastructure template of the KCP machine template.
+func InfrastructureRefForFailureDomain(kcp *controlplanev1.KubeadmControlPlane, failureDomain *string) *corev1.ObjectReference {
+	if failureDomain != nil {
+		for i := range kcp.Spec.MachineTemplate.FailureDomains {
+			if kcp.Spec.MachineTemplate.FailureDomains[i].Name == *failureDomain {
+				return &kcp.Spec.MachineTemplate.FailureDomains[i].InfrastructureRef
+			}
+		}
+	}
+	return &kcp.Spec.MachineTemplate.InfrastructureRef
+}
+
 // matchesKubeadmBootstrapConfig checks if machine's KubeadmConfigSpec is equivalent with KCP's KubeadmConfigSpec.
 // Note: Differences to the labels and annotations on the KubeadmConfig are not considered for matching
 // criteria, because changes to labels and annotations are propagated in-place to KubeadmConfig.
@@ -23,6 +23,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
@@ -88,6 +89,28 @@ func TestMatchClusterConfiguration(t *testing.T) {
 		}
 		g.Expect(matchClusterConfiguration(kcp, m)).To(BeFalse())
 	})
+	t.Run("Return false if the API server certSANs do not match", func(t *testing.T) {
+		g := NewWithT(t)
+		kcp := &controlplanev1.KubeadmControlPlane{
+			Spec: controlplanev1.KubeadmControlPlaneSpec{
+				KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+					ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+						APIServer: bootstrapv1.APIServer{
+							CertSANs: []string{"old-lb.example.com", "new-lb.example.com"},
+						},
+					},
+				},
+			},
+		}
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Annotations: map[string]string{
+					controlplanev1.KubeadmClusterConfigurationAnnotation: "{\n  \"apiServer\": {\n    \"certSANs\": [\"old-lb.example.com\"]\n  }\n}",
+				},
+			},
+		}
+		g.Expect(matchClusterConfiguration(kcp, m)).To(BeFalse())
+	})
 	t.Run("Return true if cluster configuration is nil (special case)", func(t *testing.T) {
 		g := NewWithT(t)
 		kcp := &controlplanev1.KubeadmControlPlane{
@@ -1195,3 +1218,97 @@ func TestMatchesTemplateClonedFrom_WithClonedFromAnnotations(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchesTemplateClonedFrom_WithFailureDomains(t *testing.T) {
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			MachineTemplate: controlplanev1.KubeadmControlPlaneMachineTemplate{
+				InfrastructureRef: corev1.ObjectReference{
+					Kind:       "GenericMachineTemplate",
+					Namespace:  "default",
+					Name:       "infra-foo",
+					APIVersion: "generic.io/v1",
+				},
+				FailureDomains: []controlplanev1.KubeadmControlPlaneMachineTemplateFailureDomain{
+					{
+						Name: "zone-a",
+						InfrastructureRef: corev1.ObjectReference{
+							Kind:       "GenericMachineTemplate",
+							Namespace:  "default",
+							Name:       "infra-foo-zone-a",
+							APIVersion: "generic.io/v1",
+						},
+					},
+				},
+			},
+		},
+	}
+	tests := []struct {
+		name          string
+		failureDomain *string
+		clonedFrom    string
+		expectMatch   bool
+		expectReason  string
+	}{
+		{
+			name:          "returns true if the machine in a failure domain with an override is cloned from the override",
+			failureDomain: pointer.String("zone-a"),
+			clonedFrom:    "infra-foo-zone-a",
+			expectMatch:   true,
+		},
+		{
+			name:          "returns false if the machine in a failure domain with an override is cloned from the machine template",
+			failureDomain: pointer.String("zone-a"),
+			clonedFrom:    "infra-foo",
+			expectMatch:   false,
+			expectReason:  "Infrastructure template on KCP rotated from GenericMachineTemplate.generic.io infra-foo to GenericMachineTemplate.generic.io infra-foo-zone-a",
+		},
+		{
+			name:          "returns true if the machine in a failure domain without an override is cloned from the machine template",
+			failureDomain: pointer.String("zone-b"),
+			clonedFrom:    "infra-foo",
+			expectMatch:   true,
+		},
+		{
+			name:        "returns true if the machine without a failure domain is cloned from the machine template",
+			clonedFrom:  "infra-foo",
+			expectMatch: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "machine1",
+				},
+				Spec: clusterv1.MachineSpec{
+					FailureDomain: tt.failureDomain,
+				},
+			}
+			infraConfigs := map[string]*unstructured.Unstructured{
+				machine.Name: {
+					Object: map[string]interface{}{
+						"kind":       "InfrastructureMachine",
+						"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+						"metadata": map[string]interface{}{
+							"name":      "infra-config1",
+							"namespace": "default",
+							"annotations": map[string]interface{}{
+								clusterv1.TemplateClonedFromNameAnnotation:      tt.clonedFrom,
+								clusterv1.TemplateClonedFromGroupKindAnnotation: "GenericMachineTemplate.generic.io",
+							},
+						},
+					},
+				},
+			}
+			reason, match := matchesTemplateClonedFrom(infraConfigs, kcp, machine)
+			g.Expect(match).To(Equal(tt.expectMatch))
+			g.Expect(reason).To(Equal(tt.expectReason))
+		})
+	}
+}
@@ -28,9 +28,13 @@ import (
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -54,7 +58,11 @@ func (webhook *KubeadmControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) er
 // +kubebuilder:webhook:verbs=create;update,path=/validate-controlplane-cluster-x-k8s-io-v1beta1-kubeadmcontrolplane,mutating=false,failurePolicy=fail,matchPolicy=Equivalent,groups=controlplane.cluster.x-k8s.io,resources=kubeadmcontrolplanes,versions=v1beta1,name=validation.kubeadmcontrolplane.controlplane.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // KubeadmControlPlane implements a validation and defaulting webhook for KubeadmControlPlane.
-type KubeadmControlPlane struct{}
+type KubeadmControlPlane struct {
+	// Client is used to read the Cluster of a KubeadmControlPlane to validate the kubeconfig endpoint against the
+	// control plane endpoint of the Cluster; the Cluster is not considered if Client is nil.
+	Client client.Reader
+}
 
 var _ webhook.CustomValidator = &KubeadmControlPlane{}
 var _ webhook.CustomDefaulter = &KubeadmControlPlane{}
@@ -80,6 +88,11 @@ func defaultKubeadmControlPlaneSpec(s *controlplanev1.KubeadmControlPlaneSpec, n
 	if s.MachineTemplate.InfrastructureRef.Namespace == "" {
 		s.MachineTemplate.InfrastructureRef.Namespace = namespace
 	}
+	for i := range s.MachineTemplate.FailureDomains {
+		if s.MachineTemplate.FailureDomains[i].InfrastructureRef.Namespace == "" {
+			s.MachineTemplate.FailureDomains[i].InfrastructureRef.Namespace = namespace
+		}
+	}
 
 	if !strings.HasPrefix(s.Version, "v") {
 		s.Version = "v" + s.Version
@@ -114,7 +127,7 @@ func defaultRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy) *co
 }
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *KubeadmControlPlane) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (webhook *KubeadmControlPlane) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	k, ok := obj.(*controlplanev1.KubeadmControlPlane)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a KubeadmControlPlane but got a %T", obj))
@@ -122,6 +135,11 @@ func (webhook *KubeadmControlPlane) ValidateCreate(_ context.Context, obj runtim
 
 	spec := k.Spec
 	allErrs := validateKubeadmControlPlaneSpec(spec, k.Namespace, field.NewPath("spec"))
+	kubeconfigEndpointErrs, err := webhook.validateKubeconfigEndpoint(ctx, k)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	allErrs = append(allErrs, kubeconfigEndpointErrs...)
 	allErrs = append(allErrs, validateClusterConfiguration(nil, spec.KubeadmConfigSpec.ClusterConfiguration, field.NewPath("spec", "kubeadmConfigSpec", "clusterConfiguration"))...)
 	allErrs = append(allErrs, spec.KubeadmConfigSpec.Validate(field.NewPath("spec", "kubeadmConfigSpec"))...)
 	if len(allErrs) > 0 {
@@ -154,8 +172,11 @@ const (
 
 const minimumCertificatesExpiryDays = 7
 
+// minVerKubeletConfiguration is the min version of Kubernetes supporting spec.kubeadmConfigSpec.kubeletConfiguration.
+var minVerKubeletConfiguration = semver.MustParse("1.25.0")
+
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (webhook *KubeadmControlPlane) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	// add a * to indicate everything beneath is ok.
 	// For example, {"spec", "*"} will allow any path under "spec" to change.
 	allowedPaths := [][]string{
@@ -165,6 +186,8 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, kubeadmConfigSpec, clusterConfiguration, "etcd", "local", "imageTag"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "etcd", "local", "extraArgs"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "etcd", "local", "extraArgs", "*"},
+		{spec, kubeadmConfigSpec, clusterConfiguration, "etcd", "local", "extraEnvs"},
+		{spec, kubeadmConfigSpec, clusterConfiguration, "etcd", "local", "extraEnvs", "*"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "dns", "imageRepository"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "dns", "imageTag"},
 		{spec, kubeadmConfigSpec, clusterConfiguration, "imageRepository"},
@@ -179,11 +202,15 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, kubeadmConfigSpec, initConfiguration, patches, directory},
 		{spec, kubeadmConfigSpec, initConfiguration, patches},
 		{spec, kubeadmConfigSpec, initConfiguration, skipPhases},
+		{spec, kubeadmConfigSpec, initConfiguration, "timeouts"},
+		{spec, kubeadmConfigSpec, initConfiguration, "timeouts", "*"},
 		{spec, kubeadmConfigSpec, joinConfiguration, nodeRegistration},
 		{spec, kubeadmConfigSpec, joinConfiguration, nodeRegistration, "*"},
 		{spec, kubeadmConfigSpec, joinConfiguration, patches, directory},
 		{spec, kubeadmConfigSpec, joinConfiguration, patches},
 		{spec, kubeadmConfigSpec, joinConfiguration, skipPhases},
+		{spec, kubeadmConfigSpec, joinConfiguration, "timeouts"},
+		{spec, kubeadmConfigSpec, joinConfiguration, "timeouts", "*"},
 		{spec, kubeadmConfigSpec, preKubeadmCommands},
 		{spec, kubeadmConfigSpec, postKubeadmCommands},
 		{spec, kubeadmConfigSpec, files},
@@ -193,6 +220,8 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, kubeadmConfigSpec, ntp, "*"},
 		{spec, kubeadmConfigSpec, ignition},
 		{spec, kubeadmConfigSpec, ignition, "*"},
+		{spec, kubeadmConfigSpec, "kubeletConfiguration"},
+		{spec, kubeadmConfigSpec, "kubeletConfiguration", "*"},
 		{spec, kubeadmConfigSpec, diskSetup},
 		{spec, kubeadmConfigSpec, diskSetup, "*"},
 		{spec, kubeadmConfigSpec, "format"},
@@ -205,10 +234,15 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 		{spec, "machineTemplate", "nodeDrainTimeout"},
 		{spec, "machineTemplate", "nodeVolumeDetachTimeout"},
 		{spec, "machineTemplate", "nodeDeletionTimeout"},
+		{spec, "machineTemplate", "failureDomains"},
 		{spec, "replicas"},
 		{spec, "version"},
 		{spec, "remediationStrategy"},
 		{spec, "remediationStrategy", "*"},
+		{spec, "etcdMaintenance"},
+		{spec, "etcdMaintenance", "*"},
+		{spec, "kubeconfigEndpoint"},
+		{spec, "kubeconfigEndpoint", "*"},
 		{spec, "rolloutAfter"},
 		{spec, "rolloutBefore"},
 		{spec, "rolloutBefore", "*"},
@@ -227,6 +261,11 @@ func (webhook *KubeadmControlPlane) ValidateUpdate(_ context.Context, oldObj, ne
 	}
 
 	allErrs := validateKubeadmControlPlaneSpec(newK.Spec, newK.Namespace, field.NewPath("spec"))
+	kubeconfigEndpointErrs, err := webhook.validateKubeconfigEndpoint(ctx, newK)
+	if err != nil {
+		return nil, apierrors.NewInternalError(err)
+	}
+	allErrs = append(allErrs, kubeconfigEndpointErrs...)
 
 	originalJSON, err := json.Marshal(oldK)
 	if err != nil {
@@ -358,15 +397,46 @@ func validateKubeadmControlPlaneSpec(s controlplanev1.KubeadmControlPlaneSpec, n
 		)
 	}
 
+	// Validate the failure domain overrides of the MachineTemplate
+	failureDomainNames := sets.Set[string]{}
+	for i, fd := range s.MachineTemplate.FailureDomains {
+		fdPath := pathPrefix.Child("machineTemplate", "failureDomains").Index(i)
+		if fd.Name == "" {
+			allErrs = append(allErrs, field.Required(fdPath.Child("name"), "cannot be empty"))
+		} else if failureDomainNames.Has(fd.Name) {
+			allErrs = append(allErrs, field.Duplicate(fdPath.Child("name"), fd.Name))
+		}
+		failureDomainNames.Insert(fd.Name)
+
+		if fd.InfrastructureRef.APIVersion == "" {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("infrastructureRef", "apiVersion"), fd.InfrastructureRef.APIVersion, "cannot be empty"))
+		}
+		if fd.InfrastructureRef.Kind == "" {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("infrastructureRef", "kind"), fd.InfrastructureRef.Kind, "cannot be empty"))
+		}
+		if fd.InfrastructureRef.Name == "" {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("infrastructureRef", "name"), fd.InfrastructureRef.Name, "cannot be empty"))
+		}
+		if fd.InfrastructureRef.Namespace != namespace {
+			allErrs = append(allErrs, field.Invalid(fdPath.Child("infrastructureRef", "namespace"), fd.InfrastructureRef.Namespace, "must match metadata.namespace"))
+		}
+	}
+
 	// Validate the metadata of the MachineTemplate
 	allErrs = append(allErrs, s.MachineTemplate.ObjectMeta.Validate(pathPrefix.Child("machineTemplate", "metadata"))...)
 
 	if !version.KubeSemver.MatchString(s.Version) {
 		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("version"), s.Version, "must be a valid semantic version"))
+	} else if s.KubeadmConfigSpec.KubeletConfiguration != nil {
+		// NOTE: Patching the kubelet configuration is supported by kubeadm starting from Kubernetes v1.25.
+		if parsedVersion, err := version.ParseMajorMinorPatchTolerant(s.Version); err == nil && parsedVersion.LT(minVerKubeletConfiguration) {
+			allErrs = append(allErrs, field.Forbidden(pathPrefix.Child("kubeadmConfigSpec", "kubeletConfiguration"), fmt.Sprintf("can be set only for Kubernetes v%s or later", minVerKubeletConfiguration)))
+		}
 	}
 
 	allErrs = append(allErrs, validateRolloutBefore(s.RolloutBefore, pathPrefix.Child("rolloutBefore"))...)
 	allErrs = append(allErrs, validateRolloutStrategy(s.RolloutStrategy, s.Replicas, pathPrefix.Child("rolloutStrategy"))...)
+	allErrs = append(allErrs, validateEtcdMaintenance(s.EtcdMaintenance, pathPrefix.Child("etcdMaintenance"))...)
 
 	return allErrs
 }
@@ -427,6 +497,189 @@ func validateRolloutStrategy(rolloutStrategy *controlplanev1.RolloutStrategy, re
 		)
 	}
 
+	if maxUnavailable := rolloutStrategy.RollingUpdate.MaxUnavailable; maxUnavailable != nil {
+		switch {
+		case maxUnavailable.IntValue() < ios1.IntValue():
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("rollingUpdate", "maxUnavailable"),
+					maxUnavailable.String(),
+					"value must be an integer greater than or equal to 1",
+				),
+			)
+		case maxUnavailable.IntValue() > ios1.IntValue() && replicas != nil && *replicas < int32(5):
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("rollingUpdate", "maxUnavailable"),
+					maxUnavailable.String(),
+					"values greater than 1 are only allowed when replica count is at least 5",
+				),
+			)
+		case maxUnavailable.IntValue() > ios1.IntValue() && replicas != nil && maxUnavailable.IntValue() > int(*replicas-1)/2:
+			allErrs = append(
+				allErrs,
+				field.Invalid(
+					pathPrefix.Child("rollingUpdate", "maxUnavailable"),
+					maxUnavailable.String(),
+					fmt.Sprintf("value must be less than or equal to %d in order to preserve etcd quorum", (*replicas-1)/2),
+				),
+			)
+		}
+	}
+
+	if soakDuration := rolloutStrategy.RollingUpdate.SoakDuration; soakDuration != nil && soakDuration.Duration < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("rollingUpdate", "soakDuration"),
+				soakDuration.Duration.String(),
+				"value must be greater than or equal to 0",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+// validateKubeconfigEndpoint validates the kubeconfig endpoint of a KubeadmControlPlane, which must be served by the
+// API server certificate, i.e. its host must be one of the certSANs or the host of the control plane endpoint of the Cluster.
+func (webhook *KubeadmControlPlane) validateKubeconfigEndpoint(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (field.ErrorList, error) {
+	endpoint := kcp.Spec.KubeconfigEndpoint
+	if endpoint == nil {
+		return nil, nil
+	}
+
+	cluster, err := webhook.getCluster(ctx, kcp)
+	if err != nil {
+		return nil, err
+	}
+	clusterEndpointHost := ""
+	if cluster != nil {
+		clusterEndpointHost = cluster.Spec.ControlPlaneEndpoint.Host
+	}
+
+	return validateKubeconfigEndpoint(endpoint, kcp.Spec.KubeadmConfigSpec.ClusterConfiguration, clusterEndpointHost, field.NewPath("spec", "kubeconfigEndpoint")), nil
+}
+
+// getCluster returns the Cluster of a KubeadmControlPlane, looking it up by the cluster name label or by the owner
+// reference; it returns nil if the Cluster is not known yet, e.g. when the KubeadmControlPlane is created.
+func (webhook *KubeadmControlPlane) getCluster(ctx context.Context, kcp *controlplanev1.KubeadmControlPlane) (*clusterv1.Cluster, error) {
+	if webhook.Client == nil {
+		return nil, nil
+	}
+
+	clusterName := kcp.Labels[clusterv1.ClusterNameLabel]
+	if clusterName == "" {
+		for _, ref := range kcp.GetOwnerReferences() {
+			gv, err := schema.ParseGroupVersion(ref.APIVersion)
+			if err != nil {
+				continue
+			}
+			if ref.Kind == "Cluster" && gv.Group == clusterv1.GroupVersion.Group {
+				clusterName = ref.Name
+				break
+			}
+		}
+	}
+	if clusterName == "" {
+		return nil, nil
+	}
+
+	cluster := &clusterv1.Cluster{}
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: kcp.Namespace, Name: clusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get Cluster %s", klog.KRef(kcp.Namespace, clusterName))
+	}
+	return cluster, nil
+}
+
+func validateKubeconfigEndpoint(endpoint *clusterv1.APIEndpoint, clusterConfiguration *bootstrapv1.ClusterConfiguration, clusterEndpointHost string, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if endpoint == nil {
+		return allErrs
+	}
+
+	if endpoint.Host == "" {
+		allErrs = append(allErrs, field.Required(pathPrefix.Child("host"), "must be set"))
+	}
+	if endpoint.Port <= 0 || endpoint.Port > 65535 {
+		allErrs = append(allErrs, field.Invalid(pathPrefix.Child("port"), endpoint.Port, "must be between 1 and 65535"))
+	}
+	if endpoint.Host == "" {
+		return allErrs
+	}
+
+	// The kubeconfig can't be used if the API server serving certificate is not valid for the host.
+	// NOTE: The host of the control plane endpoint of the Cluster is always included in the certificate.
+	hosts := sets.New[string]()
+	if clusterConfiguration != nil {
+		hosts.Insert(clusterConfiguration.APIServer.CertSANs...)
+	}
+	if clusterEndpointHost != "" {
+		hosts.Insert(clusterEndpointHost)
+	}
+	if !hosts.Has(endpoint.Host) {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("host"),
+				endpoint.Host,
+				"must be included in spec.kubeadmConfigSpec.clusterConfiguration.apiServer.certSANs or be the host of the Cluster spec.controlPlaneEndpoint",
+			),
+		)
+	}
+
+	return allErrs
+}
+
+func validateEtcdMaintenance(etcdMaintenance *controlplanev1.EtcdMaintenance, pathPrefix *field.Path) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	if etcdMaintenance == nil {
+		return allErrs
+	}
+
+	if etcdMaintenance.DisarmNoSpaceAlarms && etcdMaintenance.Defragmentation == nil {
+		allErrs = append(
+			allErrs,
+			field.Required(
+				pathPrefix.Child("defragmentation"),
+				"must be set when disarmNoSpaceAlarms is true",
+			),
+		)
+	}
+
+	if etcdMaintenance.Defragmentation == nil {
+		return allErrs
+	}
+
+	if dbSizeThreshold := etcdMaintenance.Defragmentation.DBSizeThreshold; dbSizeThreshold != nil && dbSizeThreshold.Sign() < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("defragmentation", "dbSizeThreshold"),
+				dbSizeThreshold.String(),
+				"value must be greater than or equal to 0",
+			),
+		)
+	}
+
+	if minInterval := etcdMaintenance.Defragmentation.MinInterval; minInterval != nil && minInterval.Duration < 0 {
+		allErrs = append(
+			allErrs,
+			field.Invalid(
+				pathPrefix.Child("defragmentation", "minInterval"),
+				minInterval.Duration.String(),
+				"value must be greater than or equal to 0",
+			),
+		)
+	}
+
 	return allErrs
 }
 
@@ -23,12 +23,17 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
@@ -116,6 +121,87 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	val := intstr.FromString("1")
 	stringMaxSurge.Spec.RolloutStrategy.RollingUpdate.MaxSurge = &val
 
+	zeroMaxUnavailable := valid.DeepCopy()
+	zeroMaxUnavailable.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 0}
+
+	maxUnavailableWithLessThanFiveReplicas := valid.DeepCopy()
+	maxUnavailableWithLessThanFiveReplicas.Spec.Replicas = pointer.Int32(3)
+	maxUnavailableWithLessThanFiveReplicas.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 2}
+
+	maxUnavailableBreakingQuorum := valid.DeepCopy()
+	maxUnavailableBreakingQuorum.Spec.Replicas = pointer.Int32(5)
+	maxUnavailableBreakingQuorum.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 3}
+
+	validMaxUnavailable := valid.DeepCopy()
+	validMaxUnavailable.Spec.Replicas = pointer.Int32(5)
+	validMaxUnavailable.Spec.RolloutStrategy.RollingUpdate.MaxUnavailable = &intstr.IntOrString{IntVal: 2}
+
+	negativeSoakDuration := valid.DeepCopy()
+	negativeSoakDuration.Spec.RolloutStrategy.RollingUpdate.SoakDuration = &metav1.Duration{Duration: -time.Minute}
+
+	validSoakDuration := valid.DeepCopy()
+	validSoakDuration.Spec.RolloutStrategy.RollingUpdate.SoakDuration = &metav1.Duration{Duration: 10 * time.Minute}
+
+	validEtcdMaintenance := valid.DeepCopy()
+	validEtcdMaintenance.Spec.EtcdMaintenance = &controlplanev1.EtcdMaintenance{
+		Defragmentation: &controlplanev1.EtcdDefragmentation{
+			DBSizeThreshold:                  resource.NewQuantity(100*1024*1024, resource.BinarySI),
+			FragmentationPercentageThreshold: pointer.Int32(30),
+			MinInterval:                      &metav1.Duration{Duration: time.Hour},
+		},
+		DisarmNoSpaceAlarms: true,
+	}
+
+	disarmNoSpaceAlarmsWithoutDefragmentation := valid.DeepCopy()
+	disarmNoSpaceAlarmsWithoutDefragmentation.Spec.EtcdMaintenance = &controlplanev1.EtcdMaintenance{
+		DisarmNoSpaceAlarms: true,
+	}
+
+	negativeEtcdDefragmentationMinInterval := validEtcdMaintenance.DeepCopy()
+	negativeEtcdDefragmentationMinInterval.Spec.EtcdMaintenance.Defragmentation.MinInterval = &metav1.Duration{Duration: -time.Hour}
+
+	validKubeletConfiguration := valid.DeepCopy()
+	validKubeletConfiguration.Spec.Version = "v1.25.0"
+	validKubeletConfiguration.Spec.KubeadmConfigSpec.KubeletConfiguration = &bootstrapv1.KubeletConfiguration{
+		MaxPods: pointer.Int32(200),
+	}
+
+	kubeletConfigurationWithUnsupportedVersion := validKubeletConfiguration.DeepCopy()
+	kubeletConfigurationWithUnsupportedVersion.Spec.Version = "v1.24.9"
+
+	validFailureDomains := valid.DeepCopy()
+	validFailureDomains.Spec.MachineTemplate.FailureDomains = []controlplanev1.KubeadmControlPlaneMachineTemplateFailureDomain{
+		{
+			Name: "zone-a",
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: "test/v1alpha1",
+				Kind:       "UnknownInfraMachine",
+				Namespace:  "foo",
+				Name:       "infraTemplate-zone-a",
+			},
+		},
+	}
+
+	duplicatedFailureDomains := validFailureDomains.DeepCopy()
+	duplicatedFailureDomains.Spec.MachineTemplate.FailureDomains = append(duplicatedFailureDomains.Spec.MachineTemplate.FailureDomains,
+		duplicatedFailureDomains.Spec.MachineTemplate.FailureDomains[0])
+
+	invalidFailureDomainNamespace := validFailureDomains.DeepCopy()
+	invalidFailureDomainNamespace.Spec.MachineTemplate.FailureDomains[0].InfrastructureRef.Namespace = invalidNamespaceName
+
+	missingFailureDomainInfrastructureRefName := validFailureDomains.DeepCopy()
+	missingFailureDomainInfrastructureRefName.Spec.MachineTemplate.FailureDomains[0].InfrastructureRef.Name = ""
+
+	validKubeconfigEndpoint := valid.DeepCopy()
+	validKubeconfigEndpoint.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"new-lb.example.com"}
+	validKubeconfigEndpoint.Spec.KubeconfigEndpoint = &clusterv1.APIEndpoint{Host: "new-lb.example.com", Port: 6443}
+
+	kubeconfigEndpointNotInCertSANs := validKubeconfigEndpoint.DeepCopy()
+	kubeconfigEndpointNotInCertSANs.Spec.KubeconfigEndpoint.Host = "other-lb.example.com"
+
+	kubeconfigEndpointWithoutPort := validKubeconfigEndpoint.DeepCopy()
+	kubeconfigEndpointWithoutPort.Spec.KubeconfigEndpoint.Port = 0
+
 	invalidNamespace := valid.DeepCopy()
 	invalidNamespace.Spec.MachineTemplate.InfrastructureRef.Namespace = invalidNamespaceName
 
@@ -237,11 +323,101 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 			expectErr: false,
 			kcp:       stringMaxSurge,
 		},
+		{
+			name:      "should return error when maxUnavailable is 0",
+			expectErr: true,
+			kcp:       zeroMaxUnavailable,
+		},
+		{
+			name:      "should return error when maxUnavailable is greater than 1 and replica count is < 5",
+			expectErr: true,
+			kcp:       maxUnavailableWithLessThanFiveReplicas,
+		},
+		{
+			name:      "should return error when maxUnavailable would break etcd quorum",
+			expectErr: true,
+			kcp:       maxUnavailableBreakingQuorum,
+		},
+		{
+			name:      "should succeed when maxUnavailable is 2 and replica count is 5",
+			expectErr: false,
+			kcp:       validMaxUnavailable,
+		},
+		{
+			name:      "should return error when soakDuration is negative",
+			expectErr: true,
+			kcp:       negativeSoakDuration,
+		},
+		{
+			name:      "should succeed when soakDuration is positive",
+			expectErr: false,
+			kcp:       validSoakDuration,
+		},
 		{
 			name:      "should return error when given an invalid rolloutBefore.certificatesExpiryDays value",
 			expectErr: true,
 			kcp:       invalidRolloutBeforeCertificateExpiryDays,
 		},
+		{
+			name:      "should succeed when kubeletConfiguration is set with a supported version",
+			expectErr: false,
+			kcp:       validKubeletConfiguration,
+		},
+		{
+			name:      "should return error when kubeletConfiguration is set with an unsupported version",
+			expectErr: true,
+			kcp:       kubeletConfigurationWithUnsupportedVersion,
+		},
+		{
+			name:      "should succeed when etcdMaintenance is valid",
+			expectErr: false,
+			kcp:       validEtcdMaintenance,
+		},
+		{
+			name:      "should return error when disarmNoSpaceAlarms is set without defragmentation",
+			expectErr: true,
+			kcp:       disarmNoSpaceAlarmsWithoutDefragmentation,
+		},
+		{
+			name:      "should return error when etcd defragmentation minInterval is negative",
+			expectErr: true,
+			kcp:       negativeEtcdDefragmentationMinInterval,
+		},
+		{
+			name:      "should succeed when kubeconfigEndpoint is included in the certSANs",
+			expectErr: false,
+			kcp:       validKubeconfigEndpoint,
+		},
+		{
+			name:      "should return error when kubeconfigEndpoint is not included in the certSANs",
+			expectErr: true,
+			kcp:       kubeconfigEndpointNotInCertSANs,
+		},
+		{
+			name:      "should return error when kubeconfigEndpoint does not have a port",
+			expectErr: true,
+			kcp:       kubeconfigEndpointWithoutPort,
+		},
+		{
+			name:      "should succeed when failure domain overrides are valid",
+			expectErr: false,
+			kcp:       validFailureDomains,
+		},
+		{
+			name:      "should return error when failure domain overrides are duplicated",
+			expectErr: true,
+			kcp:       duplicatedFailureDomains,
+		},
+		{
+			name:      "should return error when the infrastructureRef of a failure domain override is in another namespace",
+			expectErr: true,
+			kcp:       invalidFailureDomainNamespace,
+		},
+		{
+			name:      "should return error when the infrastructureRef of a failure domain override has no name",
+			expectErr: true,
+			kcp:       missingFailureDomainInfrastructureRefName,
+		},
 
 		{
 			name:                  "should return error when Ignition configuration is invalid",
@@ -286,6 +462,110 @@ func TestKubeadmControlPlaneValidateCreate(t *testing.T) {
 	}
 }
 
+func TestKubeadmControlPlaneValidateKubeconfigEndpoint(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: "foo",
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "cluster-lb.example.com", Port: 6443},
+		},
+	}
+	kcp := &controlplanev1.KubeadmControlPlane{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test",
+			Namespace: "foo",
+		},
+		Spec: controlplanev1.KubeadmControlPlaneSpec{
+			KubeadmConfigSpec: bootstrapv1.KubeadmConfigSpec{
+				ClusterConfiguration: &bootstrapv1.ClusterConfiguration{
+					APIServer: bootstrapv1.APIServer{CertSANs: []string{"new-lb.example.com"}},
+				},
+			},
+			KubeconfigEndpoint: &clusterv1.APIEndpoint{Host: "cluster-lb.example.com", Port: 6443},
+		},
+	}
+
+	kcpWithClusterNameLabel := kcp.DeepCopy()
+	kcpWithClusterNameLabel.Labels = map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+
+	kcpWithOwnerCluster := kcp.DeepCopy()
+	kcpWithOwnerCluster.OwnerReferences = []metav1.OwnerReference{
+		*metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind("Cluster")),
+	}
+
+	kcpWithCertSANsEndpoint := kcpWithOwnerCluster.DeepCopy()
+	kcpWithCertSANsEndpoint.Spec.KubeconfigEndpoint.Host = "new-lb.example.com"
+
+	kcpWithUnknownEndpoint := kcpWithOwnerCluster.DeepCopy()
+	kcpWithUnknownEndpoint.Spec.KubeconfigEndpoint.Host = "other-lb.example.com"
+
+	fakeScheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(fakeScheme)
+	fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cluster).Build()
+
+	tests := []struct {
+		name      string
+		client    client.Reader
+		kcp       *controlplanev1.KubeadmControlPlane
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when kubeconfigEndpoint is the control plane endpoint of the Cluster from the cluster name label",
+			client:    fakeClient,
+			kcp:       kcpWithClusterNameLabel,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when kubeconfigEndpoint is the control plane endpoint of the owner Cluster",
+			client:    fakeClient,
+			kcp:       kcpWithOwnerCluster,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when kubeconfigEndpoint is included in the certSANs",
+			client:    fakeClient,
+			kcp:       kcpWithCertSANsEndpoint,
+			expectErr: false,
+		},
+		{
+			name:      "should return error when kubeconfigEndpoint is neither in the certSANs nor the control plane endpoint of the Cluster",
+			client:    fakeClient,
+			kcp:       kcpWithUnknownEndpoint,
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the Cluster is not known",
+			client:    fakeClient,
+			kcp:       kcp,
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the Cluster can't be read",
+			client:    nil,
+			kcp:       kcpWithOwnerCluster,
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &KubeadmControlPlane{Client: tt.client}
+
+			errs, err := webhook.validateKubeconfigEndpoint(ctx, tt.kcp)
+			g.Expect(err).ToNot(HaveOccurred())
+			if tt.expectErr {
+				g.Expect(errs).ToNot(BeEmpty())
+			} else {
+				g.Expect(errs).To(BeEmpty())
+			}
+		})
+	}
+}
+
 func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 	before := &controlplanev1.KubeadmControlPlane{
 		ObjectMeta: metav1.ObjectMeta{
@@ -444,6 +724,14 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		MinHealthyPeriod: &metav1.Duration{Duration: 10 * time.Hour},
 		RetryPeriod:      metav1.Duration{Duration: 10 * time.Minute},
 	}
+	validUpdate.Spec.EtcdMaintenance = &controlplanev1.EtcdMaintenance{
+		Defragmentation: &controlplanev1.EtcdDefragmentation{
+			MinInterval: &metav1.Duration{Duration: 12 * time.Hour},
+		},
+		DisarmNoSpaceAlarms: true,
+	}
+	validUpdate.Spec.KubeadmConfigSpec.ClusterConfiguration.APIServer.CertSANs = []string{"new-lb.example.com"}
+	validUpdate.Spec.KubeconfigEndpoint = &clusterv1.APIEndpoint{Host: "new-lb.example.com", Port: 6443}
 	validUpdate.Spec.KubeadmConfigSpec.Format = bootstrapv1.CloudConfig
 
 	scaleToZero := before.DeepCopy()
@@ -479,6 +767,23 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 		},
 	}
 
+	etcdLocalExtraEnvs := before.DeepCopy()
+	etcdLocalExtraEnvs.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local = &bootstrapv1.LocalEtcd{
+		ExtraEnvs: []bootstrapv1.EnvVar{
+			{
+				EnvVar: corev1.EnvVar{Name: "GOMAXPROCS", Value: "4"},
+			},
+		},
+	}
+
+	initAndJoinTimeouts := before.DeepCopy()
+	initAndJoinTimeouts.Spec.KubeadmConfigSpec.InitConfiguration.Timeouts = &bootstrapv1.Timeouts{
+		ControlPlaneComponentHealthCheck: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+	initAndJoinTimeouts.Spec.KubeadmConfigSpec.JoinConfiguration.Timeouts = &bootstrapv1.Timeouts{
+		Discovery: &metav1.Duration{Duration: 10 * time.Minute},
+	}
+
 	unsetEtcd := etcdLocalImageTag.DeepCopy()
 	unsetEtcd.Spec.KubeadmConfigSpec.ClusterConfiguration.Etcd.Local = nil
 
@@ -798,6 +1103,18 @@ func TestKubeadmControlPlaneValidateUpdate(t *testing.T) {
 			before:    before,
 			kcp:       etcdLocalImageTag,
 		},
+		{
+			name:      "should succeed when making a change to the local etcd extraEnvs",
+			expectErr: false,
+			before:    before,
+			kcp:       etcdLocalExtraEnvs,
+		},
+		{
+			name:      "should succeed when making a change to the init and join configuration timeouts",
+			expectErr: false,
+			before:    before,
+			kcp:       initAndJoinTimeouts,
+		},
 		{
 			name:      "should succeed when making a change to the local etcd image tag",
 			expectErr: false,
@@ -36,6 +36,8 @@ import (
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
 	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/util/retry"
@@ -46,6 +48,7 @@ import (
 	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
 	kubeadmtypes "sigs.k8s.io/cluster-api/bootstrap/kubeadm/types"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/proxy"
 	"sigs.k8s.io/cluster-api/internal/util/kubeadm"
 	"sigs.k8s.io/cluster-api/util"
@@ -124,6 +127,11 @@ type WorkloadCluster interface {
 
 	// State recovery tasks.
 	ReconcileEtcdMembers(ctx context.Context, nodeNames []string, version semver.Version) ([]string, error)
+
+	// Etcd maintenance tasks.
+	EtcdMembersMaintenanceStatus(ctx context.Context, nodeNames []string) ([]EtcdMemberMaintenanceStatus, error)
+	DefragmentEtcdMember(ctx context.Context, nodeName string) error
+	DisarmEtcdAlarms(ctx context.Context, nodeNames []string, alarms []etcd.MemberAlarm) error
 }
 
 // Workload defines operations on workload clusters.
@@ -136,11 +144,22 @@ type Workload struct {
 
 var _ WorkloadCluster = &Workload{}
 
+// ControlPlaneNodesSelector returns a selector for the control plane Nodes of a workload cluster, i.e. the Nodes with
+// the node-role.kubernetes.io/control-plane label; it can be used to cache only the Nodes read by KCP.
+// NOTE: kubeadm sets the node-role.kubernetes.io/control-plane label on control plane Nodes since Kubernetes v1.20, so
+// only the Nodes of unsupported Kubernetes versions, with just the deprecated node-role.kubernetes.io/master label,
+// are not selected.
+func ControlPlaneNodesSelector() labels.Selector {
+	req, _ := labels.NewRequirement(labelNodeRoleControlPlane, selection.Exists, nil)
+	return labels.NewSelector().Add(*req)
+}
+
 func (w *Workload) getControlPlaneNodes(ctx context.Context) (*corev1.NodeList, error) {
 	controlPlaneNodes := &corev1.NodeList{}
 	controlPlaneNodeNames := sets.Set[string]{}
 
 	for _, label := range []string{labelNodeRoleOldControlPlane, labelNodeRoleControlPlane} {
+		// NOTE: The Nodes are read from the cache of the Client, which only stores the Nodes matching ControlPlaneNodesSelector.
 		nodes := &corev1.NodeList{}
 		if err := w.Client.List(ctx, nodes, ctrlclient.MatchingLabels(map[string]string{
 			label: "",
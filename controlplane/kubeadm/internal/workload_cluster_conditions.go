@@ -127,13 +127,27 @@ func (w *Workload) updateManagedEtcdConditions(ctx context.Context, controlPlane
 		}
 		if len(member.Alarms) > 0 {
 			alarmList := []string{}
+			noSpaceOnly := true
 			for _, alarm := range member.Alarms {
 				switch alarm {
 				case etcd.AlarmOK:
 					continue
 				default:
 					alarmList = append(alarmList, etcd.AlarmTypeName[alarm])
+					if alarm != etcd.AlarmNoSpace {
+						noSpaceOnly = false
+					}
+				}
+			}
+			// NOSPACE alarms are reported as warnings when KCP is going to disarm them, so that
+			// the etcd maintenance is not blocked by the alarms it is meant to resolve.
+			if len(alarmList) > 0 && noSpaceOnly {
+				severity := clusterv1.ConditionSeverityError
+				if controlPlane.KCP.Spec.EtcdMaintenance != nil && controlPlane.KCP.Spec.EtcdMaintenance.DisarmNoSpaceAlarms {
+					severity = clusterv1.ConditionSeverityWarning
 				}
+				conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberNoSpaceAlarmReason, severity, "Etcd member reports alarms: %s", strings.Join(alarmList, ", "))
+				continue
 			}
 			if len(alarmList) > 0 {
 				conditions.MarkFalse(machine, controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityError, "Etcd member reports alarms: %s", strings.Join(alarmList, ", "))
@@ -189,7 +189,49 @@ func TestUpdateEtcdConditions(t *testing.T) {
 			expectedKCPCondition: conditions.FalseCondition(controlplanev1.EtcdClusterHealthyCondition, controlplanev1.EtcdClusterUnhealthyReason, clusterv1.ConditionSeverityError, "Following machines are reporting etcd member errors: %s", "m1"),
 			expectedMachineConditions: map[string]clusterv1.Conditions{
 				"m1": {
-					*conditions.FalseCondition(controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberUnhealthyReason, clusterv1.ConditionSeverityError, "Etcd member reports alarms: %s", "NOSPACE"),
+					*conditions.FalseCondition(controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberNoSpaceAlarmReason, clusterv1.ConditionSeverityError, "Etcd member reports alarms: %s", "NOSPACE"),
+				},
+			},
+		},
+		{
+			name: "an etcd member with NOSPACE alarms to be disarmed should report false condition with warning severity",
+			kcp: &controlplanev1.KubeadmControlPlane{
+				Spec: controlplanev1.KubeadmControlPlaneSpec{
+					EtcdMaintenance: &controlplanev1.EtcdMaintenance{
+						Defragmentation:     &controlplanev1.EtcdDefragmentation{},
+						DisarmNoSpaceAlarms: true,
+					},
+				},
+			},
+			machines: []*clusterv1.Machine{
+				fakeMachine("m1", withNodeRef("n1")),
+			},
+			injectClient: &fakeClient{
+				list: &corev1.NodeList{
+					Items: []corev1.Node{*fakeNode("n1")},
+				},
+			},
+			injectEtcdClientGenerator: &fakeEtcdClientGenerator{
+				forNodesClient: &etcd.Client{
+					EtcdClient: &fake2.FakeEtcdClient{
+						EtcdEndpoints: []string{},
+						MemberListResponse: &clientv3.MemberListResponse{
+							Members: []*pb.Member{
+								{Name: "n1", ID: uint64(1)},
+							},
+						},
+						AlarmResponse: &clientv3.AlarmResponse{
+							Alarms: []*pb.AlarmMember{
+								{MemberID: uint64(1), Alarm: 1}, // NOSPACE
+							},
+						},
+					},
+				},
+			},
+			expectedKCPCondition: conditions.FalseCondition(controlplanev1.EtcdClusterHealthyCondition, controlplanev1.EtcdClusterUnhealthyReason, clusterv1.ConditionSeverityWarning, "Following machines are reporting etcd member warnings: %s", "m1"),
+			expectedMachineConditions: map[string]clusterv1.Conditions{
+				"m1": {
+					*conditions.FalseCondition(controlplanev1.MachineEtcdMemberHealthyCondition, controlplanev1.EtcdMemberNoSpaceAlarmReason, clusterv1.ConditionSeverityWarning, "Etcd member reports alarms: %s", "NOSPACE"),
 				},
 			},
 		},
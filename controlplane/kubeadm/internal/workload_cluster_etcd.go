@@ -247,3 +247,101 @@ func (w *Workload) EtcdMembers(ctx context.Context) ([]string, error) {
 	}
 	return names, nil
 }
+
+// EtcdMemberMaintenanceStatus contains the information required to decide on maintenance operations
+// for a single etcd member, e.g. defragmentation.
+type EtcdMemberMaintenanceStatus struct {
+	NodeName    string
+	MemberID    uint64
+	IsLeader    bool
+	DBSize      int64
+	DBSizeInUse int64
+	Alarms      []etcd.AlarmType
+}
+
+// EtcdMembersMaintenanceStatus returns the maintenance status of the etcd members hosted on the given nodes.
+// NOTE: Each member is reached using the etcd pod running on its node, given that the DB size
+// is reported by each member for itself.
+func (w *Workload) EtcdMembersMaintenanceStatus(ctx context.Context, nodeNames []string) ([]EtcdMemberMaintenanceStatus, error) {
+	statuses := make([]EtcdMemberMaintenanceStatus, 0, len(nodeNames))
+	var alarms []etcd.MemberAlarm
+	for i, nodeName := range nodeNames {
+		// Alarms are the same for all the members, so they are retrieved only once.
+		status, memberAlarms, err := w.etcdMemberMaintenanceStatus(ctx, nodeName, i == 0)
+		if err != nil {
+			return nil, err
+		}
+		if i == 0 {
+			alarms = memberAlarms
+		}
+		statuses = append(statuses, *status)
+	}
+
+	// Alarms are reported for the whole cluster, so they are assigned to the corresponding members.
+	for i := range statuses {
+		for _, alarm := range alarms {
+			if alarm.MemberID == statuses[i].MemberID {
+				statuses[i].Alarms = append(statuses[i].Alarms, alarm.Type)
+			}
+		}
+	}
+	return statuses, nil
+}
+
+func (w *Workload) etcdMemberMaintenanceStatus(ctx context.Context, nodeName string, withAlarms bool) (*EtcdMemberMaintenanceStatus, []etcd.MemberAlarm, error) {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to create etcd client for Node %s", nodeName)
+	}
+	defer etcdClient.Close()
+
+	status, err := etcdClient.MemberStatus(ctx)
+	if err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get status of the etcd member on Node %s", nodeName)
+	}
+
+	var alarms []etcd.MemberAlarm
+	if withAlarms {
+		if alarms, err = etcdClient.Alarms(ctx); err != nil {
+			return nil, nil, errors.Wrap(err, "failed to get etcd alarms")
+		}
+	}
+
+	return &EtcdMemberMaintenanceStatus{
+		NodeName:    nodeName,
+		MemberID:    status.ID,
+		IsLeader:    status.IsLeader,
+		DBSize:      status.DBSize,
+		DBSizeInUse: status.DBSizeInUse,
+	}, alarms, nil
+}
+
+// DefragmentEtcdMember defragments the DB of the etcd member hosted on the given node.
+// NOTE: The etcd member cannot serve requests while the defragmentation is in progress, so
+// this operation should be performed on one member at a time, preferably on followers.
+func (w *Workload) DefragmentEtcdMember(ctx context.Context, nodeName string) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, []string{nodeName})
+	if err != nil {
+		return errors.Wrapf(err, "failed to create etcd client for Node %s", nodeName)
+	}
+	defer etcdClient.Close()
+
+	return etcdClient.Defragment(ctx)
+}
+
+// DisarmEtcdAlarms disarms the given etcd alarms using any of the etcd members hosted on the given nodes.
+func (w *Workload) DisarmEtcdAlarms(ctx context.Context, nodeNames []string, alarms []etcd.MemberAlarm) error {
+	etcdClient, err := w.etcdClientGenerator.forFirstAvailableNode(ctx, nodeNames)
+	if err != nil {
+		return errors.Wrap(err, "failed to create etcd client")
+	}
+	defer etcdClient.Close()
+
+	var errs []error
+	for _, alarm := range alarms {
+		if err := etcdClient.DisarmAlarm(ctx, alarm); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
@@ -692,6 +692,58 @@ func TestReconcileEtcdMembers(t *testing.T) {
 	}
 }
 
+func TestEtcdMaintenance(t *testing.T) {
+	g := NewWithT(t)
+
+	fakeEtcdClients := map[string]*fake2.FakeEtcdClient{}
+	for i, nodeName := range []string{"n1", "n2"} {
+		memberID := uint64(i + 1)
+		fakeEtcdClients[nodeName] = &fake2.FakeEtcdClient{
+			EtcdEndpoints: []string{nodeName},
+			StatusResponse: &clientv3.StatusResponse{
+				Header:      &pb.ResponseHeader{MemberId: memberID},
+				Leader:      1,
+				DbSize:      int64(100 * memberID),
+				DbSizeInUse: 50,
+			},
+			AlarmResponse: &clientv3.AlarmResponse{
+				Alarms: []*pb.AlarmMember{{MemberID: 2, Alarm: pb.AlarmType_NOSPACE}},
+			},
+		}
+	}
+	w := &Workload{
+		etcdClientGenerator: &fakeEtcdClientGenerator{
+			forNodesClientFunc: func(nodeNames []string) (*etcd.Client, error) {
+				fakeEtcdClient := fakeEtcdClients[nodeNames[0]]
+				return &etcd.Client{
+					EtcdClient:  fakeEtcdClient,
+					Endpoint:    nodeNames[0],
+					CallTimeout: etcd.DefaultCallTimeout,
+				}, nil
+			},
+		},
+	}
+
+	statuses, err := w.EtcdMembersMaintenanceStatus(ctx, []string{"n1", "n2"})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(statuses).To(Equal([]EtcdMemberMaintenanceStatus{
+		{NodeName: "n1", MemberID: 1, IsLeader: true, DBSize: 100, DBSizeInUse: 50},
+		{NodeName: "n2", MemberID: 2, IsLeader: false, DBSize: 200, DBSizeInUse: 50, Alarms: []etcd.AlarmType{etcd.AlarmNoSpace}},
+	}))
+
+	g.Expect(w.DefragmentEtcdMember(ctx, "n2")).To(Succeed())
+	g.Expect(fakeEtcdClients["n1"].Defragmented).To(BeEmpty())
+	g.Expect(fakeEtcdClients["n2"].Defragmented).To(Equal([]string{"n2"}))
+
+	g.Expect(w.DisarmEtcdAlarms(ctx, []string{"n1", "n2"}, []etcd.MemberAlarm{{MemberID: 2, Type: etcd.AlarmNoSpace}})).To(Succeed())
+	g.Expect(fakeEtcdClients["n1"].DisarmedAlarms).To(Equal([]*clientv3.AlarmMember{{MemberID: 2, Alarm: pb.AlarmType_NOSPACE}}))
+
+	fakeEtcdClients["n2"].ErrorResponse = errors.New("something went wrong")
+	_, err = w.EtcdMembersMaintenanceStatus(ctx, []string{"n2"})
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(w.DefragmentEtcdMember(ctx, "n2")).ToNot(Succeed())
+}
+
 func TestRemoveNodeFromKubeadmConfigMap(t *testing.T) {
 	tests := []struct {
 		name              string
@@ -28,6 +28,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
@@ -79,9 +80,9 @@ func TestGetControlPlaneNodes(t *testing.T) {
 					},
 				},
 			},
+			// NOTE: Nodes with only the deprecated label are not cached.
 			expectedNodes: []string{
 				"control-plane-node-with-both-labels",
-				"control-plane-node-with-old-label",
 				"control-plane-node-with-new-label",
 			},
 		},
@@ -90,14 +91,16 @@ func TestGetControlPlaneNodes(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
-			objs := []client.Object{}
+			cachedObjs := []client.Object{}
 			for i := range tt.nodes {
-				objs = append(objs, &tt.nodes[i])
+				// The cache of the client only stores the Nodes matching ControlPlaneNodesSelector.
+				if ControlPlaneNodesSelector().Matches(labels.Set(tt.nodes[i].Labels)) {
+					cachedObjs = append(cachedObjs, &tt.nodes[i])
+				}
 			}
-			fakeClient := fake.NewClientBuilder().WithObjects(objs...).Build()
 
 			w := &Workload{
-				Client: fakeClient,
+				Client: fake.NewClientBuilder().WithObjects(cachedObjs...).Build(),
 			}
 			nodes, err := w.getControlPlaneNodes(ctx)
 			g.Expect(err).ToNot(HaveOccurred())
@@ -110,6 +113,16 @@ func TestGetControlPlaneNodes(t *testing.T) {
 	}
 }
 
+func TestControlPlaneNodesSelector(t *testing.T) {
+	g := NewWithT(t)
+
+	selector := ControlPlaneNodesSelector()
+	g.Expect(selector.Matches(labels.Set{labelNodeRoleControlPlane: ""})).To(BeTrue())
+	g.Expect(selector.Matches(labels.Set{labelNodeRoleOldControlPlane: "", labelNodeRoleControlPlane: ""})).To(BeTrue())
+	g.Expect(selector.Matches(labels.Set{labelNodeRoleOldControlPlane: ""})).To(BeFalse())
+	g.Expect(selector.Matches(labels.Set{})).To(BeFalse())
+}
+
 func TestUpdateKubeProxyImageInfo(t *testing.T) {
 	tests := []struct {
 		name        string
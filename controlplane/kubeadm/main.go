@@ -25,6 +25,7 @@ import (
 	goruntime "runtime"
 	"time"
 
+	"github.com/pkg/errors"
 	"github.com/spf13/pflag"
 	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
@@ -51,9 +52,11 @@ import (
 	controlplanev1alpha4 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1alpha4"
 	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
 	kubeadmcontrolplanecontrollers "sigs.k8s.io/cluster-api/controlplane/kubeadm/controllers"
+	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal"
 	"sigs.k8s.io/cluster-api/controlplane/kubeadm/internal/etcd"
 	kcpwebhooks "sigs.k8s.io/cluster-api/controlplane/kubeadm/webhooks"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/util/certs"
 	"sigs.k8s.io/cluster-api/util/flags"
 	"sigs.k8s.io/cluster-api/version"
 )
@@ -86,6 +89,8 @@ var (
 	clusterCacheTrackerConcurrency int
 	etcdDialTimeout                time.Duration
 	etcdCallTimeout                time.Duration
+	kubeletServingCSRApproval      bool
+	kubeconfigCertRenewalWindow    time.Duration
 )
 
 func init() {
@@ -155,6 +160,12 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&etcdCallTimeout, "etcd-call-timeout-duration", etcd.DefaultCallTimeout,
 		"Duration that the etcd client waits at most for read and write operations to etcd.")
 
+	fs.DurationVar(&kubeconfigCertRenewalWindow, "kubeconfig-client-cert-renewal-window", certs.ClientCertificateRenewalDuration,
+		"Duration before its expiry after which the client certificate in the kubeconfig Secret of a Cluster is rotated (e.g. 720h). Must be lower than the certificate validity of one year")
+
+	fs.BoolVar(&kubeletServingCSRApproval, "kubelet-serving-csr-approval", false,
+		"Approve kubelet serving certificate signing requests from Nodes of Machines in clusters with a KubeadmControlPlane, after verifying the requested subject alternative names against the Machine addresses")
+
 	flags.AddDiagnosticsOptions(fs, &diagnosticsOptions)
 	flags.AddTLSOptions(fs, &tlsOptions)
 
@@ -184,6 +195,11 @@ func main() {
 	// klog.Background will automatically use the right logger.
 	ctrl.SetLogger(klog.Background())
 
+	if kubeconfigCertRenewalWindow <= 0 || kubeconfigCertRenewalWindow >= certs.DefaultCertDuration {
+		setupLog.Error(errors.Errorf("must be greater than 0 and lower than %s", certs.DefaultCertDuration), "invalid --kubeconfig-client-cert-renewal-window")
+		os.Exit(1)
+	}
+
 	restConfig := ctrl.GetConfigOrDie()
 	restConfig.QPS = restConfigQPS
 	restConfig.Burst = restConfigBurst
@@ -314,6 +330,11 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			&appsv1.Deployment{},
 			&appsv1.DaemonSet{},
 		},
+		CacheByObject: map[client.Object]cache.ByObject{
+			// Note: Only control plane Nodes are cached, given that they are the only Nodes read by KCP; this avoids
+			// watching all the Nodes of large workload clusters.
+			&corev1.Node{}: {Label: internal.ControlPlaneNodesSelector()},
+		},
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to create cluster cache tracker")
@@ -335,14 +356,29 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		WatchFilterValue:    watchFilterValue,
 		EtcdDialTimeout:     etcdDialTimeout,
 		EtcdCallTimeout:     etcdCallTimeout,
+
+		KubeconfigClientCertRenewalWindow: kubeconfigCertRenewalWindow,
 	}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "KubeadmControlPlane")
 		os.Exit(1)
 	}
+
+	if kubeletServingCSRApproval {
+		if err := (&kubeadmcontrolplanecontrollers.KubeletServingCSRReconciler{
+			Client:           mgr.GetClient(),
+			Tracker:          tracker,
+			WatchFilterValue: watchFilterValue,
+		}).SetupWithManager(ctx, mgr, concurrency(kubeadmControlPlaneConcurrency)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "KubeletServingCSR")
+			os.Exit(1)
+		}
+	}
 }
 
 func setupWebhooks(mgr ctrl.Manager) {
-	if err := (&kcpwebhooks.KubeadmControlPlane{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&kcpwebhooks.KubeadmControlPlane{
+		Client: mgr.GetClient(),
+	}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "KubeadmControlPlane")
 		os.Exit(1)
 	}
@@ -36,11 +36,15 @@ func (v *ScaleValidator) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // KubeadmControlPlane implements a validating and defaulting webhook for KubeadmControlPlane.
-type KubeadmControlPlane struct{}
+type KubeadmControlPlane struct {
+	Client client.Reader
+}
 
 // SetupWebhookWithManager sets up KubeadmControlPlane webhooks.
 func (webhook *KubeadmControlPlane) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return (&webhooks.KubeadmControlPlane{}).SetupWebhookWithManager(mgr)
+	return (&webhooks.KubeadmControlPlane{
+		Client: webhook.Client,
+	}).SetupWebhookWithManager(mgr)
 }
 
 // KubeadmControlPlaneTemplate implements a validating and defaulting webhook for KubeadmControlPlaneTemplate.
@@ -89,11 +89,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ClusterResourceSetSpec)(nil), (*ClusterResourceSetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSpec(a.(*v1beta1.ClusterResourceSetSpec), b.(*ClusterResourceSetSpec), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ClusterResourceSetStatus)(nil), (*v1beta1.ClusterResourceSetStatus)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_ClusterResourceSetStatus_To_v1beta1_ClusterResourceSetStatus(a.(*ClusterResourceSetStatus), b.(*v1beta1.ClusterResourceSetStatus), scope)
 	}); err != nil {
@@ -109,11 +104,6 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
-	if err := s.AddGeneratedConversionFunc((*v1beta1.ResourceBinding)(nil), (*ResourceBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
-		return Convert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(a.(*v1beta1.ResourceBinding), b.(*ResourceBinding), scope)
-	}); err != nil {
-		return err
-	}
 	if err := s.AddGeneratedConversionFunc((*ResourceRef)(nil), (*v1beta1.ResourceRef)(nil), func(a, b interface{}, scope conversion.Scope) error {
 		return Convert_v1alpha4_ResourceRef_To_v1beta1_ResourceRef(a.(*ResourceRef), b.(*v1beta1.ResourceRef), scope)
 	}); err != nil {
@@ -139,6 +129,16 @@ func RegisterConversions(s *runtime.Scheme) error {
 	}); err != nil {
 		return err
 	}
+	if err := s.AddConversionFunc((*v1beta1.ClusterResourceSetSpec)(nil), (*ClusterResourceSetSpec)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSpec(a.(*v1beta1.ClusterResourceSetSpec), b.(*ClusterResourceSetSpec), scope)
+	}); err != nil {
+		return err
+	}
+	if err := s.AddConversionFunc((*v1beta1.ResourceBinding)(nil), (*ResourceBinding)(nil), func(a, b interface{}, scope conversion.Scope) error {
+		return Convert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(a.(*v1beta1.ResourceBinding), b.(*ResourceBinding), scope)
+	}); err != nil {
+		return err
+	}
 	return nil
 }
 
@@ -243,7 +243,21 @@ func Convert_v1beta1_ClusterResourceSetBindingList_To_v1alpha4_ClusterResourceSe
 }
 
 func autoConvert_v1alpha4_ClusterResourceSetBindingSpec_To_v1beta1_ClusterResourceSetBindingSpec(in *ClusterResourceSetBindingSpec, out *v1beta1.ClusterResourceSetBindingSpec, s conversion.Scope) error {
-	out.Bindings = *(*[]*v1beta1.ResourceSetBinding)(unsafe.Pointer(&in.Bindings))
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]*v1beta1.ResourceSetBinding, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(v1beta1.ResourceSetBinding)
+				if err := Convert_v1alpha4_ResourceSetBinding_To_v1beta1_ResourceSetBinding(*in, *out, s); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		out.Bindings = nil
+	}
 	return nil
 }
 
@@ -253,7 +267,21 @@ func Convert_v1alpha4_ClusterResourceSetBindingSpec_To_v1beta1_ClusterResourceSe
 }
 
 func autoConvert_v1beta1_ClusterResourceSetBindingSpec_To_v1alpha4_ClusterResourceSetBindingSpec(in *v1beta1.ClusterResourceSetBindingSpec, out *ClusterResourceSetBindingSpec, s conversion.Scope) error {
-	out.Bindings = *(*[]*ResourceSetBinding)(unsafe.Pointer(&in.Bindings))
+	if in.Bindings != nil {
+		in, out := &in.Bindings, &out.Bindings
+		*out = make([]*ResourceSetBinding, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ResourceSetBinding)
+				if err := Convert_v1beta1_ResourceSetBinding_To_v1alpha4_ResourceSetBinding(*in, *out, s); err != nil {
+					return err
+				}
+			}
+		}
+	} else {
+		out.Bindings = nil
+	}
 	// WARNING: in.ClusterName requires manual conversion: does not exist in peer-type
 	return nil
 }
@@ -316,14 +344,10 @@ func autoConvert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSp
 	out.ClusterSelector = in.ClusterSelector
 	out.Resources = *(*[]ResourceRef)(unsafe.Pointer(&in.Resources))
 	out.Strategy = in.Strategy
+	// WARNING: in.SubstituteVariables requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSpec is an autogenerated conversion function.
-func Convert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSpec(in *v1beta1.ClusterResourceSetSpec, out *ClusterResourceSetSpec, s conversion.Scope) error {
-	return autoConvert_v1beta1_ClusterResourceSetSpec_To_v1alpha4_ClusterResourceSetSpec(in, out, s)
-}
-
 func autoConvert_v1alpha4_ClusterResourceSetStatus_To_v1beta1_ClusterResourceSetStatus(in *ClusterResourceSetStatus, out *v1beta1.ClusterResourceSetStatus, s conversion.Scope) error {
 	out.ObservedGeneration = in.ObservedGeneration
 	if in.Conditions != nil {
@@ -388,14 +412,10 @@ func autoConvert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(in *v1beta1
 	out.Hash = in.Hash
 	out.LastAppliedTime = (*v1.Time)(unsafe.Pointer(in.LastAppliedTime))
 	out.Applied = in.Applied
+	// WARNING: in.Error requires manual conversion: does not exist in peer-type
 	return nil
 }
 
-// Convert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding is an autogenerated conversion function.
-func Convert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(in *v1beta1.ResourceBinding, out *ResourceBinding, s conversion.Scope) error {
-	return autoConvert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(in, out, s)
-}
-
 func autoConvert_v1alpha4_ResourceRef_To_v1beta1_ResourceRef(in *ResourceRef, out *v1beta1.ResourceRef, s conversion.Scope) error {
 	out.Name = in.Name
 	out.Kind = in.Kind
@@ -420,7 +440,17 @@ func Convert_v1beta1_ResourceRef_To_v1alpha4_ResourceRef(in *v1beta1.ResourceRef
 
 func autoConvert_v1alpha4_ResourceSetBinding_To_v1beta1_ResourceSetBinding(in *ResourceSetBinding, out *v1beta1.ResourceSetBinding, s conversion.Scope) error {
 	out.ClusterResourceSetName = in.ClusterResourceSetName
-	out.Resources = *(*[]v1beta1.ResourceBinding)(unsafe.Pointer(&in.Resources))
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]v1beta1.ResourceBinding, len(*in))
+		for i := range *in {
+			if err := Convert_v1alpha4_ResourceBinding_To_v1beta1_ResourceBinding(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
 	return nil
 }
 
@@ -431,7 +461,17 @@ func Convert_v1alpha4_ResourceSetBinding_To_v1beta1_ResourceSetBinding(in *Resou
 
 func autoConvert_v1beta1_ResourceSetBinding_To_v1alpha4_ResourceSetBinding(in *v1beta1.ResourceSetBinding, out *ResourceSetBinding, s conversion.Scope) error {
 	out.ClusterResourceSetName = in.ClusterResourceSetName
-	out.Resources = *(*[]ResourceBinding)(unsafe.Pointer(&in.Resources))
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make([]ResourceBinding, len(*in))
+		for i := range *in {
+			if err := Convert_v1beta1_ResourceBinding_To_v1alpha4_ResourceBinding(&(*in)[i], &(*out)[i], s); err != nil {
+				return err
+			}
+		}
+	} else {
+		out.Resources = nil
+	}
 	return nil
 }
 
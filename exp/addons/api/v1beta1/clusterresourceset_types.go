@@ -49,6 +49,13 @@ type ClusterResourceSetSpec struct {
 	// +kubebuilder:validation:Enum=ApplyOnce;Reconcile
 	// +optional
 	Strategy string `json:"strategy,omitempty"`
+
+	// SubstituteVariables enables the substitution of variables in the format ${VAR} in the resources
+	// before they are applied to each Cluster; default values can be specified in the format ${VAR:=default}.
+	// Supported variables are CLUSTER_NAME, CLUSTER_NAMESPACE, CONTROL_PLANE_ENDPOINT_HOST,
+	// CONTROL_PLANE_ENDPOINT_PORT and the variables defined in the Cluster's spec.topology.variables.
+	// +optional
+	SubstituteVariables bool `json:"substituteVariables,omitempty"`
 }
 
 // ANCHOR_END: ClusterResourceSetSpec
@@ -41,6 +41,11 @@ type ResourceBinding struct {
 
 	// Applied is to track if a resource is applied to the cluster or not.
 	Applied bool `json:"applied"`
+
+	// Error is the error message of the last failed attempt to apply this resource to the cluster.
+	// It is cleared once the resource is applied successfully.
+	// +optional
+	Error string `json:"error,omitempty"`
 }
 
 // ANCHOR_END: ResourceBinding
@@ -25,6 +25,7 @@ import (
 
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	clusterresourcesets "sigs.k8s.io/cluster-api/exp/addons/internal/controllers"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // ClusterResourceSetReconciler reconciles a ClusterResourceSet object.
@@ -34,6 +35,9 @@ type ClusterResourceSetReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -41,6 +45,7 @@ func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr
 		Client:           r.Client,
 		Tracker:          r.Tracker,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
 
@@ -50,11 +55,15 @@ type ClusterResourceSetBindingReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *ClusterResourceSetBindingReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&clusterresourcesets.ClusterResourceSetBindingReconciler{
 		Client:           r.Client,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -42,10 +42,12 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 	resourcepredicates "sigs.k8s.io/cluster-api/exp/addons/internal/controllers/predicates"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // ErrSecretTypeNotSupported signals that a Secret is not supported.
@@ -63,11 +65,14 @@ type ClusterResourceSetReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
 }
 
 func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&addonsv1.ClusterResourceSet{}).
+		For(&addonsv1.ClusterResourceSet{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterResourceSet),
@@ -88,7 +93,7 @@ func (r *ClusterResourceSetReconciler) SetupWithManager(ctx context.Context, mgr
 		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Complete(r)
+		Complete(concurrency.Reconciler(r, options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
@@ -111,6 +116,11 @@ func (r *ClusterResourceSetReconciler) Reconcile(ctx context.Context, req ctrl.R
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the ClusterResourceSet does not belong to the shard of this manager.
+	if !r.Shard.Contains(clusterResourceSet) {
+		return ctrl.Result{}, nil
+	}
+
 	// Initialize the patch helper.
 	patchHelper, err := patch.NewHelper(clusterResourceSet, r.Client)
 	if err != nil {
@@ -306,6 +316,12 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 	for _, resource := range clusterResourceSet.Spec.Resources {
 		unstructuredObj, err := r.getResource(ctx, resource, cluster.GetNamespace())
 		if err != nil {
+			// Surface the error on resources already tracked in the ClusterResourceSetBinding, preserving their last apply status.
+			if resourceBinding := resourceSetBinding.GetResource(resource); resourceBinding != nil {
+				resourceBinding.Error = err.Error()
+				resourceSetBinding.SetBinding(*resourceBinding)
+			}
+
 			if err == ErrSecretTypeNotSupported {
 				conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.WrongSecretTypeReason, clusterv1.ConditionSeverityWarning, err.Error())
 			} else {
@@ -327,13 +343,14 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 			errList = append(errList, err)
 		}
 
-		resourceScope, err := reconcileScopeForResource(clusterResourceSet, resource, resourceSetBinding, unstructuredObj)
+		resourceScope, err := reconcileScopeForResource(cluster, clusterResourceSet, resource, resourceSetBinding, unstructuredObj)
 		if err != nil {
 			resourceSetBinding.SetBinding(addonsv1.ResourceBinding{
 				ResourceRef:     resource,
 				Hash:            "",
 				Applied:         false,
 				LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
+				Error:           err.Error(),
 			})
 
 			errList = append(errList, err)
@@ -356,8 +373,10 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 		// Apply all values in the key-value pair of the resource to the cluster.
 		// As there can be multiple key-value pairs in a resource, each value may have multiple objects in it.
 		isSuccessful := true
+		applyError := ""
 		if err := resourceScope.apply(ctx, remoteClient); err != nil {
 			isSuccessful = false
+			applyError = err.Error()
 			log.Error(err, "failed to apply ClusterResourceSet resource", "Resource kind", resource.Kind, "Resource name", resource.Name)
 			conditions.MarkFalse(clusterResourceSet, addonsv1.ResourcesAppliedCondition, addonsv1.ApplyFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
 			errList = append(errList, err)
@@ -368,6 +387,7 @@ func (r *ClusterResourceSetReconciler) ApplyClusterResourceSet(ctx context.Conte
 			Hash:            resourceScope.hash(),
 			Applied:         isSuccessful,
 			LastAppliedTime: &metav1.Time{Time: time.Now().UTC()},
+			Error:           applyError,
 		})
 	}
 	if len(errList) > 0 {
@@ -25,8 +25,11 @@ import (
 	"encoding/json"
 	"fmt"
 	"sort"
+	"strings"
 	"unicode"
 
+	"github.com/drone/envsubst/v2"
+	"github.com/drone/envsubst/v2/parse"
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -34,6 +37,7 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -222,6 +226,95 @@ func normalizeData(resource *unstructured.Unstructured) ([][]byte, error) {
 	return dataList, nil
 }
 
+// substituteVariables replaces the variables in the format ${VAR} in the normalized data of a resource
+// with the values computed for the given Cluster.
+// An error is returned if the data references variables without a value and without a default.
+func substituteVariables(cluster *clusterv1.Cluster, dataList [][]byte) ([][]byte, error) {
+	variables := clusterVariables(cluster)
+
+	ret := make([][]byte, 0, len(dataList))
+	for _, data := range dataList {
+		// Only variables in the format ${VAR} are substituted, so e.g. $VAR in shell scripts is preserved.
+		escaped := escapeUnbracedVariables(string(data))
+
+		tree, err := parse.Parse(escaped)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to parse variables")
+		}
+		missing := sets.Set[string]{}
+		collectMissingVariables(tree.Root, variables, missing)
+		if missing.Len() > 0 {
+			return nil, errors.Errorf("value for variables [%s] is not set for Cluster %s", strings.Join(sets.List(missing), ", "), klog.KObj(cluster))
+		}
+
+		substituted, err := envsubst.Eval(escaped, func(name string) string {
+			return variables[name]
+		})
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to substitute variables")
+		}
+		ret = append(ret, []byte(substituted))
+	}
+	return ret, nil
+}
+
+// escapeUnbracedVariables escapes all the $ not followed by {, so envsubst
+// leaves them untouched, e.g. $VAR or $(command).
+func escapeUnbracedVariables(data string) string {
+	var b strings.Builder
+	b.Grow(len(data))
+	for i := 0; i < len(data); i++ {
+		if data[i] == '$' && (i+1 == len(data) || data[i+1] != '{') {
+			b.WriteString("$$")
+			continue
+		}
+		b.WriteByte(data[i])
+	}
+	return b.String()
+}
+
+// collectMissingVariables walks down the parse tree of a resource and collects the variables
+// without a value and without a default.
+func collectMissingVariables(root parse.Node, variables map[string]string, missing sets.Set[string]) {
+	switch v := root.(type) {
+	case *parse.ListNode:
+		for _, n := range v.Nodes {
+			collectMissingVariables(n, variables, missing)
+		}
+	case *parse.FuncNode:
+		if _, ok := variables[v.Param]; !ok && len(v.Args) == 0 {
+			missing.Insert(v.Param)
+		}
+	}
+}
+
+// clusterVariables returns the variables that can be used in the resources of a ClusterResourceSet
+// for the given Cluster.
+func clusterVariables(cluster *clusterv1.Cluster) map[string]string {
+	variables := map[string]string{
+		"CLUSTER_NAME":      cluster.Name,
+		"CLUSTER_NAMESPACE": cluster.Namespace,
+	}
+	if cluster.Spec.ControlPlaneEndpoint.IsValid() {
+		variables["CONTROL_PLANE_ENDPOINT_HOST"] = cluster.Spec.ControlPlaneEndpoint.Host
+		variables["CONTROL_PLANE_ENDPOINT_PORT"] = fmt.Sprintf("%d", cluster.Spec.ControlPlaneEndpoint.Port)
+	}
+	if cluster.Spec.Topology != nil {
+		for _, variable := range cluster.Spec.Topology.Variables {
+			if _, ok := variables[variable.Name]; ok {
+				continue
+			}
+			// String values are substituted without quotes, all the other values as JSON.
+			var value string
+			if err := json.Unmarshal(variable.Value.Raw, &value); err != nil {
+				value = string(variable.Value.Raw)
+			}
+			variables[variable.Name] = value
+		}
+	}
+	return variables
+}
+
 func getClusterNameFromOwnerRef(obj metav1.ObjectMeta) (string, error) {
 	for _, ref := range obj.GetOwnerReferences() {
 		if ref.Kind != "Cluster" {
@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
@@ -271,3 +272,68 @@ func TestEnsureKubernetesServiceCreated(t *testing.T) {
 		})
 	}
 }
+
+func TestSubstituteVariables(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-cluster",
+			Namespace: metav1.NamespaceDefault,
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1", Port: 6443},
+			Topology: &clusterv1.Topology{
+				Variables: []clusterv1.ClusterVariable{
+					{Name: "imageRepository", Value: apiextensionsv1.JSON{Raw: []byte(`"registry.example.com"`)}},
+					{Name: "replicas", Value: apiextensionsv1.JSON{Raw: []byte(`3`)}},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		data    string
+		want    string
+		wantErr bool
+	}{
+		{
+			name: "built-in variables",
+			data: "cluster: ${CLUSTER_NAME}\nnamespace: ${CLUSTER_NAMESPACE}\nendpoint: ${CONTROL_PLANE_ENDPOINT_HOST}:${CONTROL_PLANE_ENDPOINT_PORT}",
+			want: "cluster: test-cluster\nnamespace: default\nendpoint: 10.0.0.1:6443",
+		},
+		{
+			name: "topology variables",
+			data: "image: ${imageRepository}/addon\nreplicas: ${replicas}",
+			want: "image: registry.example.com/addon\nreplicas: 3",
+		},
+		{
+			name: "default values",
+			data: "cidr: ${POD_CIDR:=192.168.0.0/16}",
+			want: "cidr: 192.168.0.0/16",
+		},
+		{
+			name:    "missing variables",
+			data:    "cidr: ${POD_CIDR}",
+			wantErr: true,
+		},
+		{
+			name: "unbraced variables are not substituted",
+			data: "script: echo $CLUSTER_NAME $POD_CIDR $(hostname) $$ ${CLUSTER_NAME} $",
+			want: "script: echo $CLUSTER_NAME $POD_CIDR $(hostname) $$ test-cluster $",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := substituteVariables(cluster, [][]byte{[]byte(tt.data)})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(HaveLen(1))
+			g.Expect(string(got[0])).To(Equal(tt.want))
+		})
+	}
+}
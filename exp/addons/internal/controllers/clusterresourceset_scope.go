@@ -26,6 +26,7 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	addonsv1 "sigs.k8s.io/cluster-api/exp/addons/api/v1beta1"
 )
 
@@ -43,6 +44,7 @@ type resourceReconcileScope interface {
 }
 
 func reconcileScopeForResource(
+	cluster *clusterv1.Cluster,
 	crs *addonsv1.ClusterResourceSet,
 	resourceRef addonsv1.ResourceRef,
 	resourceSetBinding *addonsv1.ResourceSetBinding,
@@ -53,6 +55,15 @@ func reconcileScopeForResource(
 		return nil, err
 	}
 
+	// NOTE: variables are substituted before computing the hash, so changes to the values
+	// computed for a Cluster are re-applied when using the Reconcile strategy.
+	if crs.Spec.SubstituteVariables {
+		normalizedData, err = substituteVariables(cluster, normalizedData)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to substitute variables in %s %s", resource.GetKind(), klog.KObj(resource))
+		}
+	}
+
 	objs, err := objsFromYamlData(normalizedData)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,193 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+// InstalledVersionAnnotationSuffix is the suffix of the annotation set on a Cluster to record the Kubernetes version
+// the addons of an addon provider have been installed or upgraded for. The annotation key is prefixed by the
+// Name of the Reconciler, e.g. `helm.addons.cluster.x-k8s.io/installed-version`.
+const InstalledVersionAnnotationSuffix = "addons.cluster.x-k8s.io/installed-version"
+
+// Handler is implemented by addon providers to install and upgrade their addons on Clusters.
+type Handler interface {
+	// Install installs the addons on the Cluster. It is called once, after the control plane of the Cluster
+	// has been initialized, with the Kubernetes version of the control plane.
+	Install(ctx context.Context, cluster *clusterv1.Cluster, version string) error
+
+	// Upgrade upgrades the addons on the Cluster. It is called after the control plane of the Cluster has been
+	// upgraded from the Kubernetes version the addons have been installed or last upgraded for.
+	Upgrade(ctx context.Context, cluster *clusterv1.Cluster, fromVersion, toVersion string) error
+}
+
+// Reconciler calls a Handler following the lifecycle of Clusters, so that addon providers don't have to
+// track the state of the Clusters and of their control planes.
+//
+// The Handler is called only for Clusters which are not paused nor being deleted and which have a control plane
+// object, when the control plane is not upgrading. If the Handler returns an error it is called again with
+// exponential backoff; once it succeeds the Kubernetes version of the control plane is recorded on the Cluster
+// with the installed version annotation.
+type Reconciler struct {
+	Client client.Client
+
+	// Name identifies the addon provider. It must be a valid DNS label, as it is used to prefix the
+	// installed version annotation on the Clusters.
+	Name string
+
+	// Handler installs and upgrades the addons.
+	Handler Handler
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	externalTracker external.ObjectTracker
+}
+
+// SetupWithManager sets up the Reconciler with the Manager.
+func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	if r.Name == "" {
+		return errors.New("failed setting up with a controller manager: Name must be set")
+	}
+	if r.Handler == nil {
+		return errors.New("failed setting up with a controller manager: Handler must be set")
+	}
+
+	c, err := ctrl.NewControllerManagedBy(mgr).
+		Named(fmt.Sprintf("%s-addons-lifecycle", r.Name)).
+		For(&clusterv1.Cluster{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Build(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.externalTracker = external.ObjectTracker{
+		Controller: c,
+		Cache:      mgr.GetCache(),
+	}
+	return nil
+}
+
+// Reconcile calls the Handler to install or upgrade the addons on a Cluster.
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	cluster := &clusterv1.Cluster{}
+	if err := r.Client.Get(ctx, req.NamespacedName, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	if !cluster.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+	if annotations.IsPaused(cluster, cluster) {
+		log.Info("Reconciliation is paused for this object")
+		return ctrl.Result{}, nil
+	}
+
+	// Addons are installed only after the control plane is initialized.
+	if !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
+		log.V(4).Info("Waiting for the control plane to be initialized")
+		return ctrl.Result{}, nil
+	}
+	if cluster.Spec.ControlPlaneRef == nil {
+		log.V(4).Info("Skipping Cluster without a control plane object")
+		return ctrl.Result{}, nil
+	}
+
+	controlPlane, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Watch the control plane, so the Cluster is reconciled when the control plane version changes.
+	if err := r.externalTracker.Watch(log, controlPlane, handler.EnqueueRequestForOwner(r.Client.Scheme(), r.Client.RESTMapper(), &clusterv1.Cluster{})); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	isUpgrading, err := contract.ControlPlane().IsUpgrading(controlPlane)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to check if %s is upgrading", cluster.Spec.ControlPlaneRef.Kind)
+	}
+	if isUpgrading {
+		log.V(4).Info("Waiting for the control plane to complete the upgrade")
+		return ctrl.Result{}, nil
+	}
+	version, err := contract.ControlPlane().StatusVersion().Get(controlPlane)
+	if err != nil {
+		if errors.Is(err, contract.ErrFieldNotFound) {
+			log.V(4).Info("Waiting for the control plane to report its version")
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, errors.Wrapf(err, "failed to get the version of %s", cluster.Spec.ControlPlaneRef.Kind)
+	}
+
+	annotation := r.installedVersionAnnotation()
+	installedVersion := cluster.Annotations[annotation]
+	if installedVersion == *version {
+		return ctrl.Result{}, nil
+	}
+
+	patchHelper, err := patch.NewHelper(cluster, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if installedVersion == "" {
+		log.Info("Installing addons", "version", *version)
+		if err := r.Handler.Install(ctx, cluster, *version); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to install addons for version %s", *version)
+		}
+	} else {
+		log.Info("Upgrading addons", "fromVersion", installedVersion, "toVersion", *version)
+		if err := r.Handler.Upgrade(ctx, cluster, installedVersion, *version); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to upgrade addons from version %s to version %s", installedVersion, *version)
+		}
+	}
+
+	annotations.AddAnnotations(cluster, map[string]string{annotation: *version})
+	if err := patchHelper.Patch(ctx, cluster); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to record the installed addons version")
+	}
+	return ctrl.Result{}, nil
+}
+
+func (r *Reconciler) installedVersionAnnotation() string {
+	return fmt.Sprintf("%s.%s", r.Name, InstalledVersionAnnotationSuffix)
+}
@@ -0,0 +1,189 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package lifecycle
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+var ctx = ctrl.SetupSignalHandler()
+
+type fakeHandler struct {
+	installed []string
+	upgraded  []string
+	err       error
+}
+
+func (h *fakeHandler) Install(_ context.Context, _ *clusterv1.Cluster, version string) error {
+	h.installed = append(h.installed, version)
+	return h.err
+}
+
+func (h *fakeHandler) Upgrade(_ context.Context, _ *clusterv1.Cluster, fromVersion, toVersion string) error {
+	h.upgraded = append(h.upgraded, fromVersion+"->"+toVersion)
+	return h.err
+}
+
+func TestReconcile(t *testing.T) {
+	const annotation = "test." + InstalledVersionAnnotationSuffix
+
+	scheme := runtime.NewScheme()
+	NewWithT(t).Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	stableControlPlane := builder.ControlPlane(metav1.NamespaceDefault, "cp").
+		WithVersion("v1.28.0").
+		WithStatusFields(map[string]interface{}{
+			"status.version": "v1.28.0",
+		}).
+		Build()
+	upgradingControlPlane := builder.ControlPlane(metav1.NamespaceDefault, "cp").
+		WithVersion("v1.28.0").
+		WithStatusFields(map[string]interface{}{
+			"status.version": "v1.27.3",
+		}).
+		Build()
+	provisioningControlPlane := builder.ControlPlane(metav1.NamespaceDefault, "cp").
+		WithVersion("v1.28.0").
+		Build()
+
+	newCluster := func(initialized bool, installedVersion string) *clusterv1.Cluster {
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef: &corev1.ObjectReference{
+					APIVersion: builder.ControlPlaneGroupVersion.String(),
+					Kind:       builder.GenericControlPlaneKind,
+					Name:       "cp",
+					Namespace:  metav1.NamespaceDefault,
+				},
+			},
+		}
+		if initialized {
+			conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+		}
+		if installedVersion != "" {
+			cluster.Annotations = map[string]string{annotation: installedVersion}
+		}
+		return cluster
+	}
+
+	pausedCluster := newCluster(true, "")
+	pausedCluster.Spec.Paused = true
+
+	tests := []struct {
+		name                 string
+		cluster              *clusterv1.Cluster
+		controlPlane         *unstructured.Unstructured
+		handlerErr           error
+		wantErr              bool
+		wantInstalled        []string
+		wantUpgraded         []string
+		wantInstalledVersion string
+	}{
+		{
+			name:         "does not install the addons before the control plane is initialized",
+			cluster:      newCluster(false, ""),
+			controlPlane: stableControlPlane,
+		},
+		{
+			name:         "does not install the addons on a paused Cluster",
+			cluster:      pausedCluster,
+			controlPlane: stableControlPlane,
+		},
+		{
+			name:         "does not install the addons before the control plane reports its version",
+			cluster:      newCluster(true, ""),
+			controlPlane: provisioningControlPlane,
+		},
+		{
+			name:                 "installs the addons once the control plane is initialized",
+			cluster:              newCluster(true, ""),
+			controlPlane:         stableControlPlane,
+			wantInstalled:        []string{"v1.28.0"},
+			wantInstalledVersion: "v1.28.0",
+		},
+		{
+			name:                 "does not install again the addons",
+			cluster:              newCluster(true, "v1.28.0"),
+			controlPlane:         stableControlPlane,
+			wantInstalledVersion: "v1.28.0",
+		},
+		{
+			name:                 "does not upgrade the addons while the control plane is upgrading",
+			cluster:              newCluster(true, "v1.27.3"),
+			controlPlane:         upgradingControlPlane,
+			wantInstalledVersion: "v1.27.3",
+		},
+		{
+			name:                 "upgrades the addons once the control plane is upgraded",
+			cluster:              newCluster(true, "v1.27.3"),
+			controlPlane:         stableControlPlane,
+			wantUpgraded:         []string{"v1.27.3->v1.28.0"},
+			wantInstalledVersion: "v1.28.0",
+		},
+		{
+			name:                 "does not record the version when the upgrade fails",
+			cluster:              newCluster(true, "v1.27.3"),
+			controlPlane:         stableControlPlane,
+			handlerErr:           errors.New("upgrade failed"),
+			wantErr:              true,
+			wantUpgraded:         []string{"v1.27.3->v1.28.0"},
+			wantInstalledVersion: "v1.27.3",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.cluster.DeepCopy(), tt.controlPlane.DeepCopy()).Build()
+			h := &fakeHandler{err: tt.handlerErr}
+			r := &Reconciler{
+				Client:  c,
+				Name:    "test",
+				Handler: h,
+			}
+
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(tt.cluster)})
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(h.installed).To(Equal(tt.wantInstalled))
+			g.Expect(h.upgraded).To(Equal(tt.wantUpgraded))
+
+			cluster := &clusterv1.Cluster{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(tt.cluster), cluster)).To(Succeed())
+			g.Expect(cluster.Annotations[annotation]).To(Equal(tt.wantInstalledVersion))
+		})
+	}
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// ClusterGroupFinalizer is used to unpause the Clusters paused by a ClusterGroup before removing it.
+	ClusterGroupFinalizer = "clustergroup.cluster.x-k8s.io"
+
+	// ClusterGroupPausedAnnotation is set by the ClusterGroup controller on the Clusters it has paused;
+	// the value of the annotation is the name of the ClusterGroup.
+	// Only the Clusters with this annotation are unpaused when the ClusterGroup is unpaused, so Clusters
+	// paused by other means are left untouched.
+	ClusterGroupPausedAnnotation = "clustergroup.cluster.x-k8s.io/paused"
+)
+
+// ANCHOR: ClusterGroupSpec
+
+// ClusterGroupSpec defines the desired state of ClusterGroup.
+type ClusterGroupSpec struct {
+	// ClusterSelector is the label selector for Clusters in the same namespace that are part of the group.
+	// If ClusterSelector is empty, no Clusters are selected.
+	ClusterSelector metav1.LabelSelector `json:"clusterSelector"`
+
+	// Paused can be used to pause all the Clusters of the group at once; when set to false, only the Clusters
+	// paused by the ClusterGroup are unpaused.
+	// +optional
+	Paused bool `json:"paused,omitempty"`
+
+	// ClassRollout can be used to move the Clusters of the group with a managed topology to another ClusterClass,
+	// a few Clusters at a time. The class rollout does not progress while the ClusterGroup is paused.
+	// +optional
+	ClassRollout *ClusterGroupClassRollout `json:"classRollout,omitempty"`
+}
+
+// ClusterGroupClassRollout defines the ClusterClass the Clusters of a group are moved to.
+type ClusterGroupClassRollout struct {
+	// Class is the name of the ClusterClass the Clusters of the group are moved to.
+	// +kubebuilder:validation:MinLength=1
+	Class string `json:"class"`
+
+	// MaxInFlight is the maximum number of Clusters moved to the ClusterClass which are not yet ready
+	// with their topology reconciled; the next Clusters are moved only when these are done.
+	// Defaults to 1.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	MaxInFlight *int32 `json:"maxInFlight,omitempty"`
+}
+
+// ANCHOR_END: ClusterGroupSpec
+
+// ANCHOR: ClusterGroupStatus
+
+// ClusterGroupStatus defines the observed state of ClusterGroup.
+type ClusterGroupStatus struct {
+	// Clusters is the total number of Clusters selected by the group.
+	// +optional
+	Clusters int32 `json:"clusters"`
+
+	// AvailableClusters is the number of selected Clusters with the Ready condition set to true.
+	// +optional
+	AvailableClusters int32 `json:"availableClusters"`
+
+	// UpgradingClusters is the number of selected Clusters with a control plane that is not yet running
+	// the desired Kubernetes version.
+	// +optional
+	UpgradingClusters int32 `json:"upgradingClusters"`
+
+	// FailedClusters is the number of selected Clusters in the Failed phase.
+	// +optional
+	FailedClusters int32 `json:"failedClusters"`
+
+	// PausedClusters is the number of selected Clusters with spec.paused set to true.
+	// +optional
+	PausedClusters int32 `json:"pausedClusters"`
+
+	// ClassRolloutClusters is the number of selected Clusters with a managed topology that have been moved
+	// to the ClusterClass of the class rollout, and are ready with their topology reconciled.
+	// +optional
+	ClassRolloutClusters int32 `json:"classRolloutClusters,omitempty"`
+
+	// Versions reports the number of selected Clusters for each Kubernetes version their control plane is running.
+	// +optional
+	Versions []ClusterGroupVersion `json:"versions,omitempty"`
+
+	// ObservedGeneration is the latest generation observed by the controller.
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// Conditions defines current service state of the ClusterGroup.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// ClusterGroupVersion reports the number of Clusters running a Kubernetes version.
+type ClusterGroupVersion struct {
+	// Version is the Kubernetes version.
+	Version string `json:"version"`
+
+	// Clusters is the number of Clusters with a control plane running the version.
+	Clusters int32 `json:"clusters"`
+}
+
+// ANCHOR_END: ClusterGroupStatus
+
+// +kubebuilder:object:root=true
+// +kubebuilder:resource:path=clustergroups,shortName=cg,scope=Namespaced,categories=cluster-api
+// +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:printcolumn:name="Clusters",type="integer",JSONPath=".status.clusters",description="Total number of Clusters selected by this ClusterGroup"
+// +kubebuilder:printcolumn:name="Available",type="integer",JSONPath=".status.availableClusters",description="Number of available Clusters"
+// +kubebuilder:printcolumn:name="Upgrading",type="integer",JSONPath=".status.upgradingClusters",description="Number of upgrading Clusters"
+// +kubebuilder:printcolumn:name="Failed",type="integer",JSONPath=".status.failedClusters",description="Number of failed Clusters"
+// +kubebuilder:printcolumn:name="Paused",type="boolean",JSONPath=".spec.paused",description="Whether the Clusters of the ClusterGroup are paused"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of ClusterGroup"
+// +k8s:conversion-gen=false
+
+// ClusterGroup is the Schema for the clustergroups API.
+// A ClusterGroup selects Clusters by label and aggregates their status, providing a single object
+// to observe and operate on a fleet of Clusters.
+type ClusterGroup struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   ClusterGroupSpec   `json:"spec,omitempty"`
+	Status ClusterGroupStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (g *ClusterGroup) GetConditions() clusterv1.Conditions {
+	return g.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (g *ClusterGroup) SetConditions(conditions clusterv1.Conditions) {
+	g.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// ClusterGroupList contains a list of ClusterGroup.
+type ClusterGroupList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []ClusterGroup `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &ClusterGroup{}, &ClusterGroupList{})
+}
@@ -27,4 +27,22 @@ const (
 	// WaitingForReplicasReadyReason (Severity=Info) documents a machinepool waiting for the required replicas
 	// to be ready.
 	WaitingForReplicasReadyReason = "WaitingForReplicasReady"
+
+	// ScaleDownNodesDrainedCondition reports whether the Nodes of the instances selected for deletion on scale down,
+	// using the ScaleDownProviderIDsAnnotation, have been cordoned and drained.
+	ScaleDownNodesDrainedCondition clusterv1.ConditionType = "ScaleDownNodesDrained"
+)
+
+// Conditions and condition Reasons for the ClusterGroup object.
+
+const (
+	// ClustersAvailableCondition reports whether all the Clusters selected by the ClusterGroup are available.
+	ClustersAvailableCondition clusterv1.ConditionType = "ClustersAvailable"
+
+	// ClustersNotAvailableReason (Severity=Info) documents a ClusterGroup with selected Clusters that
+	// are not available yet.
+	ClustersNotAvailableReason = "ClustersNotAvailable"
+
+	// ClustersFailedReason (Severity=Error) documents a ClusterGroup with selected Clusters in the Failed phase.
+	ClustersFailedReason = "ClustersFailed"
 )
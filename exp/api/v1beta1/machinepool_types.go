@@ -27,6 +27,16 @@ import (
 const (
 	// MachinePoolFinalizer is used to ensure deletion of dependencies (nodes, infra).
 	MachinePoolFinalizer = "machinepool.cluster.x-k8s.io"
+
+	// ScaleDownProviderIDsAnnotation is the annotation the infrastructure provider, or a user, sets on a MachinePool to
+	// opt in to drain-before-scale-down; the value is a comma-separated list of the providerIDs of the instances
+	// selected for deletion on scale down. The MachinePool controller cordons and drains the corresponding Nodes.
+	ScaleDownProviderIDsAnnotation = "machinepool.cluster.x-k8s.io/scale-down-provider-ids"
+
+	// ScaleDownDrainedProviderIDsAnnotation is the annotation set by the MachinePool controller on a MachinePool,
+	// listing as comma-separated values the providerIDs from ScaleDownProviderIDsAnnotation whose Nodes have been
+	// cordoned and drained. Infrastructure providers opting in to drain-before-scale-down must only delete those instances.
+	ScaleDownDrainedProviderIDsAnnotation = "machinepool.cluster.x-k8s.io/scale-down-drained-provider-ids"
 )
 
 // ANCHOR: MachinePoolSpec
@@ -93,6 +103,12 @@ type MachinePoolStatus struct {
 	// +optional
 	UnavailableReplicas int32 `json:"unavailableReplicas,omitempty"`
 
+	// ProviderIDsMarkedForDeletion lists the providerIDs of the instances whose MachinePool Machine or Node has the
+	// cluster.x-k8s.io/delete-machine annotation. Infrastructure providers should delete these instances first
+	// when the MachinePool is scaled down.
+	// +optional
+	ProviderIDsMarkedForDeletion []string `json:"providerIDsMarkedForDeletion,omitempty"`
+
 	// FailureReason indicates that there is a problem reconciling the state, and
 	// will be set to a token value suitable for programmatic interpretation.
 	// +optional
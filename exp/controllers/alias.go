@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -25,6 +26,7 @@ import (
 
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	machinepool "sigs.k8s.io/cluster-api/exp/internal/controllers"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // MachinePoolReconciler reconciles a MachinePool object.
@@ -35,13 +37,40 @@ type MachinePoolReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// NodeDrainClientTimeout timeout of the client used for draining nodes.
+	NodeDrainClientTimeout time.Duration
 }
 
 func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	return (&machinepool.MachinePoolReconciler{
+		Client:                 r.Client,
+		APIReader:              r.APIReader,
+		Tracker:                r.Tracker,
+		WatchFilterValue:       r.WatchFilterValue,
+		Shard:                  r.Shard,
+		NodeDrainClientTimeout: r.NodeDrainClientTimeout,
+	}).SetupWithManager(ctx, mgr, options)
+}
+
+// ClusterGroupReconciler reconciles a ClusterGroup object.
+type ClusterGroupReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+}
+
+func (r *ClusterGroupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&machinepool.ClusterGroupReconciler{
 		Client:           r.Client,
-		APIReader:        r.APIReader,
-		Tracker:          r.Tracker,
 		WatchFilterValue: r.WatchFilterValue,
+		Shard:            r.Shard,
 	}).SetupWithManager(ctx, mgr, options)
 }
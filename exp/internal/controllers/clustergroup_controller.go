@@ -0,0 +1,468 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
+)
+
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clusters,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=clustergroups;clustergroups/status;clustergroups/finalizers,verbs=get;list;watch;update;patch
+
+// upgradingRequeueAfter is the interval used to refresh the status of a ClusterGroup while
+// some of its Clusters are upgrading, given that control plane objects are not watched.
+const upgradingRequeueAfter = 1 * time.Minute
+
+// ClusterGroupReconciler reconciles a ClusterGroup object.
+type ClusterGroupReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+}
+
+func (r *ClusterGroupReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&expv1.ClusterGroup{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
+		Watches(
+			&clusterv1.Cluster{},
+			handler.EnqueueRequestsFromMapFunc(r.clusterToClusterGroups),
+		).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Complete(concurrency.Reconciler(r, options))
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	return nil
+}
+
+func (r *ClusterGroupReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+	// Fetch the ClusterGroup instance.
+	clusterGroup := &expv1.ClusterGroup{}
+	if err := r.Client.Get(ctx, req.NamespacedName, clusterGroup); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Object not found, return. Created objects are automatically garbage collected.
+			// For additional cleanup logic use finalizers.
+			return ctrl.Result{}, nil
+		}
+		// Error reading the object - requeue the request.
+		return ctrl.Result{}, err
+	}
+
+	// Return early if the ClusterGroup does not belong to the shard of this manager.
+	if !r.Shard.Contains(clusterGroup) {
+		return ctrl.Result{}, nil
+	}
+
+	// Initialize the patch helper.
+	patchHelper, err := patch.NewHelper(clusterGroup, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	defer func() {
+		// Always attempt to Patch the ClusterGroup object and status after each reconciliation.
+		if err := patchHelper.Patch(ctx, clusterGroup,
+			patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{expv1.ClustersAvailableCondition}},
+			patch.WithStatusObservedGeneration{},
+		); err != nil {
+			reterr = kerrors.NewAggregate([]error{reterr, err})
+		}
+	}()
+
+	// Handle deletion reconciliation loop.
+	if !clusterGroup.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, r.reconcileDelete(ctx, clusterGroup)
+	}
+
+	// Add finalizer first if not set to avoid the race condition between init and delete.
+	// Note: Finalizers in general can only be added when the deletionTimestamp is not set.
+	if !controllerutil.ContainsFinalizer(clusterGroup, expv1.ClusterGroupFinalizer) {
+		controllerutil.AddFinalizer(clusterGroup, expv1.ClusterGroupFinalizer)
+		return ctrl.Result{}, nil
+	}
+
+	clusters, err := r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcilePause(ctx, clusterGroup, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileStatus(ctx, clusterGroup, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if err := r.reconcileClassRollout(ctx, clusterGroup, clusters); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	if clusterGroup.Status.UpgradingClusters > 0 {
+		return ctrl.Result{RequeueAfter: upgradingRequeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// reconcileDelete unpauses the Clusters paused by the ClusterGroup before removing the finalizer.
+func (r *ClusterGroupReconciler) reconcileDelete(ctx context.Context, clusterGroup *expv1.ClusterGroup) error {
+	clusters, err := r.getClustersPausedByClusterGroup(ctx, clusterGroup)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range clusters {
+		if err := r.setClusterPaused(ctx, clusterGroup, cluster, false); err != nil {
+			return err
+		}
+	}
+
+	controllerutil.RemoveFinalizer(clusterGroup, expv1.ClusterGroupFinalizer)
+	return nil
+}
+
+// reconcilePause pauses the selected Clusters if the ClusterGroup is paused, otherwise it unpauses
+// the Clusters previously paused by the ClusterGroup.
+func (r *ClusterGroupReconciler) reconcilePause(ctx context.Context, clusterGroup *expv1.ClusterGroup, clusters []*clusterv1.Cluster) error {
+	if clusterGroup.Spec.Paused {
+		for _, cluster := range clusters {
+			if cluster.Spec.Paused {
+				continue
+			}
+			if err := r.setClusterPaused(ctx, clusterGroup, cluster, true); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	// NOTE: Clusters are unpaused also if they have been removed from the group while paused,
+	// so they are not left paused without a ClusterGroup taking care of them.
+	pausedClusters, err := r.getClustersPausedByClusterGroup(ctx, clusterGroup)
+	if err != nil {
+		return err
+	}
+	for _, cluster := range pausedClusters {
+		if err := r.setClusterPaused(ctx, clusterGroup, cluster, false); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// setClusterPaused pauses or unpauses a Cluster, keeping track of the Clusters paused by the ClusterGroup
+// with the ClusterGroupPausedAnnotation.
+func (r *ClusterGroupReconciler) setClusterPaused(ctx context.Context, clusterGroup *expv1.ClusterGroup, cluster *clusterv1.Cluster, paused bool) error {
+	log := ctrl.LoggerFrom(ctx, "Cluster", klog.KObj(cluster))
+
+	patchHelper, err := patch.NewHelper(cluster, r.Client)
+	if err != nil {
+		return err
+	}
+
+	cluster.Spec.Paused = paused
+	if paused {
+		if cluster.Annotations == nil {
+			cluster.Annotations = map[string]string{}
+		}
+		cluster.Annotations[expv1.ClusterGroupPausedAnnotation] = clusterGroup.Name
+		log.Info("Pausing Cluster")
+	} else {
+		delete(cluster.Annotations, expv1.ClusterGroupPausedAnnotation)
+		log.Info("Unpausing Cluster")
+	}
+
+	if err := patchHelper.Patch(ctx, cluster); err != nil {
+		return errors.Wrapf(err, "failed to patch Cluster %s", klog.KObj(cluster))
+	}
+	return nil
+}
+
+// reconcileStatus aggregates the status of the selected Clusters into the ClusterGroup status.
+func (r *ClusterGroupReconciler) reconcileStatus(ctx context.Context, clusterGroup *expv1.ClusterGroup, clusters []*clusterv1.Cluster) error {
+	status := expv1.ClusterGroupStatus{
+		ObservedGeneration: clusterGroup.Status.ObservedGeneration,
+		Conditions:         clusterGroup.Status.Conditions,
+		Clusters:           int32(len(clusters)),
+	}
+
+	versions := map[string]int32{}
+	for _, cluster := range clusters {
+		if conditions.IsTrue(cluster, clusterv1.ReadyCondition) {
+			status.AvailableClusters++
+		}
+		if cluster.Status.GetTypedPhase() == clusterv1.ClusterPhaseFailed {
+			status.FailedClusters++
+		}
+		if cluster.Spec.Paused {
+			status.PausedClusters++
+		}
+
+		version, upgrading, err := r.getClusterVersion(ctx, cluster)
+		if err != nil {
+			return err
+		}
+		if version != "" {
+			versions[version]++
+		}
+		if upgrading {
+			status.UpgradingClusters++
+		}
+	}
+
+	for version, count := range versions {
+		status.Versions = append(status.Versions, expv1.ClusterGroupVersion{Version: version, Clusters: count})
+	}
+	sort.Slice(status.Versions, func(i, j int) bool {
+		return status.Versions[i].Version < status.Versions[j].Version
+	})
+
+	clusterGroup.Status = status
+
+	switch {
+	case status.FailedClusters > 0:
+		conditions.MarkFalse(clusterGroup, expv1.ClustersAvailableCondition, expv1.ClustersFailedReason, clusterv1.ConditionSeverityError,
+			"%d of %d Clusters failed", status.FailedClusters, status.Clusters)
+	case status.AvailableClusters < status.Clusters:
+		conditions.MarkFalse(clusterGroup, expv1.ClustersAvailableCondition, expv1.ClustersNotAvailableReason, clusterv1.ConditionSeverityInfo,
+			"%d of %d Clusters available", status.AvailableClusters, status.Clusters)
+	default:
+		conditions.MarkTrue(clusterGroup, expv1.ClustersAvailableCondition)
+	}
+	return nil
+}
+
+// reconcileClassRollout moves the selected Clusters with a managed topology to the ClusterClass of the class rollout,
+// with no more than MaxInFlight Clusters moved to the ClusterClass and not yet done at the same time.
+func (r *ClusterGroupReconciler) reconcileClassRollout(ctx context.Context, clusterGroup *expv1.ClusterGroup, clusters []*clusterv1.Cluster) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	rollout := clusterGroup.Spec.ClassRollout
+	if rollout == nil {
+		return nil
+	}
+
+	maxInFlight := int32(1)
+	if rollout.MaxInFlight != nil {
+		maxInFlight = *rollout.MaxInFlight
+	}
+
+	var inFlight int32
+	pending := []*clusterv1.Cluster{}
+	for _, cluster := range clusters {
+		if cluster.Spec.Topology == nil {
+			continue
+		}
+		if cluster.Spec.Topology.Class != rollout.Class {
+			pending = append(pending, cluster)
+			continue
+		}
+		if isClassRolloutDone(cluster) {
+			clusterGroup.Status.ClassRolloutClusters++
+			continue
+		}
+		inFlight++
+	}
+
+	if clusterGroup.Spec.Paused {
+		return nil
+	}
+
+	// Move the Clusters in order of name, so the order of the rollout is predictable.
+	sort.Slice(pending, func(i, j int) bool {
+		return pending[i].Name < pending[j].Name
+	})
+	for _, cluster := range pending {
+		if inFlight >= maxInFlight {
+			break
+		}
+
+		patchHelper, err := patch.NewHelper(cluster, r.Client)
+		if err != nil {
+			return err
+		}
+		cluster.Spec.Topology.Class = rollout.Class
+		log.Info(fmt.Sprintf("Moving Cluster to ClusterClass %s", rollout.Class), "Cluster", klog.KObj(cluster))
+		if err := patchHelper.Patch(ctx, cluster); err != nil {
+			return errors.Wrapf(err, "failed to patch Cluster %s", klog.KObj(cluster))
+		}
+		inFlight++
+	}
+	return nil
+}
+
+// isClassRolloutDone returns true if a Cluster moved to the ClusterClass of the class rollout is ready
+// with its topology reconciled.
+func isClassRolloutDone(cluster *clusterv1.Cluster) bool {
+	return cluster.Status.ObservedGeneration >= cluster.Generation &&
+		conditions.IsTrue(cluster, clusterv1.TopologyReconciledCondition) &&
+		conditions.IsTrue(cluster, clusterv1.ReadyCondition)
+}
+
+// getClusterVersion returns the Kubernetes version the control plane of a Cluster is running, if known,
+// and whether the control plane is not yet running the desired Kubernetes version.
+func (r *ClusterGroupReconciler) getClusterVersion(ctx context.Context, cluster *clusterv1.Cluster) (string, bool, error) {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return "", false, nil
+	}
+
+	controlPlane, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return "", false, nil
+		}
+		return "", false, errors.Wrapf(err, "failed to get control plane for Cluster %s", klog.KObj(cluster))
+	}
+
+	desiredVersion, err := contract.ControlPlane().Version().Get(controlPlane)
+	if err != nil {
+		if errors.Is(err, contract.ErrFieldNotFound) {
+			// The control plane doesn't surface a version, e.g. it is a managed control plane.
+			return "", false, nil
+		}
+		return "", false, errors.Wrapf(err, "failed to get the version of the control plane for Cluster %s", klog.KObj(cluster))
+	}
+	if cluster.Spec.Topology != nil {
+		desiredVersion = &cluster.Spec.Topology.Version
+	}
+
+	version, err := contract.ControlPlane().StatusVersion().Get(controlPlane)
+	if err != nil {
+		if errors.Is(err, contract.ErrFieldNotFound) {
+			// The first control plane Machine is still provisioning.
+			return "", false, nil
+		}
+		return "", false, errors.Wrapf(err, "failed to get the status version of the control plane for Cluster %s", klog.KObj(cluster))
+	}
+	return *version, *version != *desiredVersion, nil
+}
+
+// getClustersByClusterGroupSelector returns the Clusters selected by the ClusterGroup.
+func (r *ClusterGroupReconciler) getClustersByClusterGroupSelector(ctx context.Context, clusterGroup *expv1.ClusterGroup) ([]*clusterv1.Cluster, error) {
+	selector, err := metav1.LabelSelectorAsSelector(&clusterGroup.Spec.ClusterSelector)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to build selector")
+	}
+
+	// If a ClusterGroup has a nil or empty selector, it should match nothing, not everything.
+	if selector.Empty() {
+		return nil, nil
+	}
+
+	return r.listClusters(ctx, clusterGroup.Namespace, client.MatchingLabelsSelector{Selector: selector})
+}
+
+// getClustersPausedByClusterGroup returns the Clusters paused by the ClusterGroup.
+func (r *ClusterGroupReconciler) getClustersPausedByClusterGroup(ctx context.Context, clusterGroup *expv1.ClusterGroup) ([]*clusterv1.Cluster, error) {
+	clusters, err := r.listClusters(ctx, clusterGroup.Namespace)
+	if err != nil {
+		return nil, err
+	}
+
+	pausedClusters := []*clusterv1.Cluster{}
+	for _, cluster := range clusters {
+		if cluster.Annotations[expv1.ClusterGroupPausedAnnotation] == clusterGroup.Name {
+			pausedClusters = append(pausedClusters, cluster)
+		}
+	}
+	return pausedClusters, nil
+}
+
+func (r *ClusterGroupReconciler) listClusters(ctx context.Context, namespace string, opts ...client.ListOption) ([]*clusterv1.Cluster, error) {
+	clusterList := &clusterv1.ClusterList{}
+	if err := r.Client.List(ctx, clusterList, append(opts, client.InNamespace(namespace))...); err != nil {
+		return nil, errors.Wrap(err, "failed to list clusters")
+	}
+
+	clusters := []*clusterv1.Cluster{}
+	for i := range clusterList.Items {
+		c := &clusterList.Items[i]
+		if c.DeletionTimestamp.IsZero() {
+			clusters = append(clusters, c)
+		}
+	}
+	return clusters, nil
+}
+
+// clusterToClusterGroups is a mapper function that maps a Cluster to the ClusterGroups selecting it,
+// or that paused it.
+func (r *ClusterGroupReconciler) clusterToClusterGroups(ctx context.Context, o client.Object) []ctrl.Request {
+	result := []ctrl.Request{}
+
+	cluster, ok := o.(*clusterv1.Cluster)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Cluster but got a %T", o))
+	}
+
+	clusterGroupList := &expv1.ClusterGroupList{}
+	if err := r.Client.List(ctx, clusterGroupList, client.InNamespace(cluster.Namespace)); err != nil {
+		return nil
+	}
+
+	clusterLabels := labels.Set(cluster.GetLabels())
+	for i := range clusterGroupList.Items {
+		cg := &clusterGroupList.Items[i]
+
+		if cluster.Annotations[expv1.ClusterGroupPausedAnnotation] != cg.Name {
+			selector, err := metav1.LabelSelectorAsSelector(&cg.Spec.ClusterSelector)
+			if err != nil {
+				continue
+			}
+
+			// If a ClusterGroup has a nil or empty selector, it should match nothing, not everything.
+			if selector.Empty() || !selector.Matches(clusterLabels) {
+				continue
+			}
+		}
+
+		name := client.ObjectKey{Namespace: cg.Namespace, Name: cg.Name}
+		result = append(result, ctrl.Request{NamespacedName: name})
+	}
+	return result
+}
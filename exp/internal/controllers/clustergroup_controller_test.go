@@ -0,0 +1,274 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func newClusterGroupTestCluster(name string, labels map[string]string, controlPlane client.Object) *clusterv1.Cluster {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: metav1.NamespaceDefault,
+			Labels:    labels,
+		},
+	}
+	if controlPlane != nil {
+		cluster.Spec.ControlPlaneRef = &corev1.ObjectReference{
+			APIVersion: controlPlane.GetObjectKind().GroupVersionKind().GroupVersion().String(),
+			Kind:       controlPlane.GetObjectKind().GroupVersionKind().Kind,
+			Name:       controlPlane.GetName(),
+			Namespace:  controlPlane.GetNamespace(),
+		}
+	}
+	return cluster
+}
+
+func TestClusterGroupReconcileStatus(t *testing.T) {
+	g := NewWithT(t)
+
+	selected := map[string]string{"environment": "production"}
+
+	availableControlPlane := builder.ControlPlane(metav1.NamespaceDefault, "available").
+		WithVersion("v1.28.0").
+		WithStatusFields(map[string]interface{}{"status.version": "v1.28.0"}).
+		Build()
+	available := newClusterGroupTestCluster("available", selected, availableControlPlane)
+	conditions.MarkTrue(available, clusterv1.ReadyCondition)
+
+	upgradingControlPlane := builder.ControlPlane(metav1.NamespaceDefault, "upgrading").
+		WithVersion("v1.28.0").
+		WithStatusFields(map[string]interface{}{"status.version": "v1.27.3"}).
+		Build()
+	upgrading := newClusterGroupTestCluster("upgrading", selected, upgradingControlPlane)
+	conditions.MarkTrue(upgrading, clusterv1.ReadyCondition)
+
+	failed := newClusterGroupTestCluster("failed", selected, nil)
+	failed.Status.SetTypedPhase(clusterv1.ClusterPhaseFailed)
+	failed.Spec.Paused = true
+
+	notSelected := newClusterGroupTestCluster("not-selected", map[string]string{"environment": "staging"}, nil)
+	conditions.MarkTrue(notSelected, clusterv1.ReadyCondition)
+
+	r := &ClusterGroupReconciler{
+		Client: fake.NewClientBuilder().WithObjects(
+			available, availableControlPlane,
+			upgrading, upgradingControlPlane,
+			failed, notSelected,
+		).Build(),
+	}
+
+	clusterGroup := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.ClusterGroupSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: selected},
+		},
+	}
+
+	clusters, err := r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(clusters).To(HaveLen(3))
+
+	g.Expect(r.reconcileStatus(ctx, clusterGroup, clusters)).To(Succeed())
+	g.Expect(clusterGroup.Status.Clusters).To(Equal(int32(3)))
+	g.Expect(clusterGroup.Status.AvailableClusters).To(Equal(int32(2)))
+	g.Expect(clusterGroup.Status.UpgradingClusters).To(Equal(int32(1)))
+	g.Expect(clusterGroup.Status.FailedClusters).To(Equal(int32(1)))
+	g.Expect(clusterGroup.Status.PausedClusters).To(Equal(int32(1)))
+	g.Expect(clusterGroup.Status.Versions).To(Equal([]expv1.ClusterGroupVersion{
+		{Version: "v1.27.3", Clusters: 1},
+		{Version: "v1.28.0", Clusters: 1},
+	}))
+	g.Expect(conditions.IsFalse(clusterGroup, expv1.ClustersAvailableCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(clusterGroup, expv1.ClustersAvailableCondition)).To(Equal(expv1.ClustersFailedReason))
+}
+
+func TestClusterGroupReconcilePause(t *testing.T) {
+	g := NewWithT(t)
+
+	selected := map[string]string{"environment": "production"}
+
+	running := newClusterGroupTestCluster("running", selected, nil)
+	pausedByUser := newClusterGroupTestCluster("paused-by-user", selected, nil)
+	pausedByUser.Spec.Paused = true
+
+	c := fake.NewClientBuilder().WithObjects(running, pausedByUser).Build()
+	r := &ClusterGroupReconciler{Client: c}
+
+	clusterGroup := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.ClusterGroupSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: selected},
+			Paused:          true,
+		},
+	}
+
+	// Pausing the ClusterGroup pauses the selected Clusters.
+	clusters, err := r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.reconcilePause(ctx, clusterGroup, clusters)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(running), running)).To(Succeed())
+	g.Expect(running.Spec.Paused).To(BeTrue())
+	g.Expect(running.Annotations).To(HaveKeyWithValue(expv1.ClusterGroupPausedAnnotation, clusterGroup.Name))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pausedByUser), pausedByUser)).To(Succeed())
+	g.Expect(pausedByUser.Annotations).ToNot(HaveKey(expv1.ClusterGroupPausedAnnotation))
+
+	// Unpausing the ClusterGroup only unpauses the Clusters paused by the ClusterGroup.
+	clusterGroup.Spec.Paused = false
+	clusters, err = r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.reconcilePause(ctx, clusterGroup, clusters)).To(Succeed())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(running), running)).To(Succeed())
+	g.Expect(running.Spec.Paused).To(BeFalse())
+	g.Expect(running.Annotations).ToNot(HaveKey(expv1.ClusterGroupPausedAnnotation))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pausedByUser), pausedByUser)).To(Succeed())
+	g.Expect(pausedByUser.Spec.Paused).To(BeTrue())
+}
+
+func TestClusterGroupReconcileClassRollout(t *testing.T) {
+	g := NewWithT(t)
+
+	selected := map[string]string{"environment": "production"}
+	newTopologyCluster := func(name, class string) *clusterv1.Cluster {
+		cluster := newClusterGroupTestCluster(name, selected, nil)
+		cluster.Spec.Topology = &clusterv1.Topology{Class: class, Version: "v1.28.0"}
+		return cluster
+	}
+
+	pending1 := newTopologyCluster("pending-1", "old")
+	pending2 := newTopologyCluster("pending-2", "old")
+	inFlight := newTopologyCluster("in-flight", "new")
+	conditions.MarkTrue(inFlight, clusterv1.ReadyCondition)
+	conditions.MarkFalse(inFlight, clusterv1.TopologyReconciledCondition, clusterv1.TopologyReconciledControlPlaneUpgradePendingReason, clusterv1.ConditionSeverityInfo, "")
+	done := newTopologyCluster("done", "new")
+	conditions.MarkTrue(done, clusterv1.ReadyCondition)
+	conditions.MarkTrue(done, clusterv1.TopologyReconciledCondition)
+	withoutTopology := newClusterGroupTestCluster("without-topology", selected, nil)
+
+	c := fake.NewClientBuilder().WithObjects(pending1, pending2, inFlight, done, withoutTopology).Build()
+	r := &ClusterGroupReconciler{Client: c}
+
+	clusterGroup := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "production", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.ClusterGroupSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: selected},
+			Paused:          true,
+			ClassRollout: &expv1.ClusterGroupClassRollout{
+				Class:       "new",
+				MaxInFlight: pointer.Int32(2),
+			},
+		},
+	}
+
+	// The class rollout does not progress while the ClusterGroup is paused.
+	clusters, err := r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.reconcileClassRollout(ctx, clusterGroup, clusters)).To(Succeed())
+	g.Expect(clusterGroup.Status.ClassRolloutClusters).To(Equal(int32(1)))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pending1), pending1)).To(Succeed())
+	g.Expect(pending1.Spec.Topology.Class).To(Equal("old"))
+
+	// Only the Clusters within MaxInFlight are moved to the ClusterClass.
+	clusterGroup.Spec.Paused = false
+	clusterGroup.Status.ClassRolloutClusters = 0
+	clusters, err = r.getClustersByClusterGroupSelector(ctx, clusterGroup)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.reconcileClassRollout(ctx, clusterGroup, clusters)).To(Succeed())
+	g.Expect(clusterGroup.Status.ClassRolloutClusters).To(Equal(int32(1)))
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pending1), pending1)).To(Succeed())
+	g.Expect(pending1.Spec.Topology.Class).To(Equal("new"))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pending2), pending2)).To(Succeed())
+	g.Expect(pending2.Spec.Topology.Class).To(Equal("old"))
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(withoutTopology), withoutTopology)).To(Succeed())
+	g.Expect(withoutTopology.Spec.Topology).To(BeNil())
+}
+
+func TestClusterGroupReconcileDelete(t *testing.T) {
+	g := NewWithT(t)
+
+	pausedByGroup := newClusterGroupTestCluster("paused-by-group", nil, nil)
+	pausedByGroup.Spec.Paused = true
+	pausedByGroup.Annotations = map[string]string{expv1.ClusterGroupPausedAnnotation: "production"}
+
+	c := fake.NewClientBuilder().WithObjects(pausedByGroup).Build()
+	r := &ClusterGroupReconciler{Client: c}
+
+	clusterGroup := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:       "production",
+			Namespace:  metav1.NamespaceDefault,
+			Finalizers: []string{expv1.ClusterGroupFinalizer},
+		},
+	}
+
+	g.Expect(r.reconcileDelete(ctx, clusterGroup)).To(Succeed())
+	g.Expect(controllerutil.ContainsFinalizer(clusterGroup, expv1.ClusterGroupFinalizer)).To(BeFalse())
+
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(pausedByGroup), pausedByGroup)).To(Succeed())
+	g.Expect(pausedByGroup.Spec.Paused).To(BeFalse())
+	g.Expect(pausedByGroup.Annotations).ToNot(HaveKey(expv1.ClusterGroupPausedAnnotation))
+}
+
+func TestClusterToClusterGroups(t *testing.T) {
+	g := NewWithT(t)
+
+	selecting := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "selecting", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.ClusterGroupSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"environment": "production"}},
+		},
+	}
+	pausing := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "pausing", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.ClusterGroupSpec{
+			ClusterSelector: metav1.LabelSelector{MatchLabels: map[string]string{"environment": "staging"}},
+		},
+	}
+	empty := &expv1.ClusterGroup{
+		ObjectMeta: metav1.ObjectMeta{Name: "empty", Namespace: metav1.NamespaceDefault},
+	}
+
+	r := &ClusterGroupReconciler{
+		Client: fake.NewClientBuilder().WithObjects(selecting, pausing, empty).Build(),
+	}
+
+	cluster := newClusterGroupTestCluster("cluster", map[string]string{"environment": "production"}, nil)
+	cluster.Annotations = map[string]string{expv1.ClusterGroupPausedAnnotation: "pausing"}
+
+	g.Expect(r.clusterToClusterGroups(ctx, cluster)).To(ConsistOf(
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(selecting)},
+		ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pausing)},
+	))
+}
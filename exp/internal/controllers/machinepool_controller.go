@@ -19,6 +19,7 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -41,12 +42,16 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	capirecord "sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // +kubebuilder:rbac:groups=core,resources=events,verbs=get;list;watch;create;patch
@@ -69,6 +74,12 @@ type MachinePoolReconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// NodeDrainClientTimeout timeout of the client used for draining nodes.
+	NodeDrainClientTimeout time.Duration
+
 	controller      controller.Controller
 	ssaCache        ssa.Cache
 	recorder        record.EventRecorder
@@ -82,7 +93,9 @@ func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 	}
 
 	c, err := ctrl.NewControllerManagedBy(mgr).
-		For(&expv1.MachinePool{}).
+		For(&expv1.MachinePool{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
+		// Watch the MachinePool Machines to observe the delete-machine annotation.
+		Owns(&clusterv1.Machine{}).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Watches(
@@ -96,13 +109,13 @@ func (r *MachinePoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.M
 				),
 			),
 		).
-		Build(r)
+		Build(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &expv1.MachinePool{}), options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
 	r.controller = c
-	r.recorder = mgr.GetEventRecorderFor("machinepool-controller")
+	r.recorder = capirecord.NewDeduplicatingRecorder(mgr.GetEventRecorderFor("machinepool-controller"), capirecord.DefaultDeduplicationWindow)
 	r.externalTracker = external.ObjectTracker{
 		Controller: c,
 		Cache:      mgr.GetCache(),
@@ -126,6 +139,11 @@ func (r *MachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the MachinePool does not belong to the shard of this manager.
+	if !r.Shard.Contains(mp) {
+		return ctrl.Result{}, nil
+	}
+
 	log = log.WithValues("Cluster", klog.KRef(mp.ObjectMeta.Namespace, mp.Spec.ClusterName))
 	ctx = ctrl.LoggerInto(ctx, log)
 
@@ -169,6 +187,7 @@ func (r *MachinePoolReconciler) Reconcile(ctx context.Context, req ctrl.Request)
 				clusterv1.BootstrapReadyCondition,
 				clusterv1.InfrastructureReadyCondition,
 				expv1.ReplicasReadyCondition,
+				expv1.ScaleDownNodesDrainedCondition,
 			}},
 		}
 		if reterr == nil {
@@ -228,6 +247,8 @@ func (r *MachinePoolReconciler) reconcile(ctx context.Context, cluster *clusterv
 		r.reconcileBootstrap,
 		r.reconcileInfrastructure,
 		r.reconcileNodeRefs,
+		r.reconcileProviderIDsMarkedForDeletion,
+		r.reconcileScaleDownDrain,
 	}
 
 	res := ctrl.Result{}
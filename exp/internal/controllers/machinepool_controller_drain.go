@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/drain"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileScaleDownDrain cordons and drains the Nodes of the instances selected for deletion on scale down
+// with the ScaleDownProviderIDsAnnotation, and reports the drained instances with the ScaleDownDrainedProviderIDsAnnotation,
+// so the infrastructure provider can delete them without killing workloads abruptly.
+func (r *MachinePoolReconciler) reconcileScaleDownDrain(ctx context.Context, cluster *clusterv1.Cluster, mp *expv1.MachinePool) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Check that the MachinePool hasn't been deleted or in the process.
+	if !mp.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	selected := providerIDsFromAnnotation(mp, expv1.ScaleDownProviderIDsAnnotation)
+	if len(selected) == 0 {
+		// The MachinePool didn't opt in to drain-before-scale-down, or the scale down is completed.
+		delete(mp.Annotations, expv1.ScaleDownDrainedProviderIDsAnnotation)
+		conditions.Delete(mp, expv1.ScaleDownNodesDrainedCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Only instances still in the MachinePool are considered; the other ones have been deleted already.
+	inPool := sets.New(mp.Spec.ProviderIDList...)
+	selectedInPool := sets.New(selected...).Intersection(inPool)
+	drained := sets.New(providerIDsFromAnnotation(mp, expv1.ScaleDownDrainedProviderIDsAnnotation)...).Intersection(selectedInPool)
+	pending := sets.List(selectedInPool.Difference(drained))
+
+	defer func() {
+		setProviderIDsAnnotation(mp, expv1.ScaleDownDrainedProviderIDsAnnotation, sets.List(drained))
+	}()
+
+	if len(pending) == 0 {
+		conditions.MarkTrue(mp, expv1.ScaleDownNodesDrainedCondition)
+		return ctrl.Result{}, nil
+	}
+
+	// Mark the start of the drain, which is used to compute the NodeDrainTimeout.
+	if !conditions.IsFalse(mp, expv1.ScaleDownNodesDrainedCondition) {
+		conditions.MarkFalse(mp, expv1.ScaleDownNodesDrainedCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "Draining the nodes selected for deletion")
+	}
+
+	if !isNodeDrainAllowed(mp) {
+		// The Nodes are not drained, and the instances can be deleted.
+		log.Info("Skipping drain of the Nodes selected for deletion", "providerIDs", pending)
+		drained.Insert(pending...)
+		conditions.MarkTrue(mp, expv1.ScaleDownNodesDrainedCondition)
+		return ctrl.Result{}, nil
+	}
+
+	restConfig, err := r.Tracker.GetRESTConfig(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		if errors.Is(err, remote.ErrClusterLocked) {
+			log.V(5).Info("Requeuing drain Nodes because another worker has the lock on the ClusterCacheTracker")
+			return ctrl.Result{Requeue: true}, nil
+		}
+		return ctrl.Result{}, err
+	}
+	restConfig = rest.CopyConfig(restConfig)
+	restConfig.Timeout = r.NodeDrainClientTimeout
+	kubeClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to create a client to drain Nodes")
+	}
+
+	nodeList, err := kubeClient.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list Nodes")
+	}
+	nodesByProviderID := make(map[string]*corev1.Node, len(nodeList.Items))
+	for i := range nodeList.Items {
+		nodesByProviderID[nodeList.Items[i].Spec.ProviderID] = &nodeList.Items[i]
+	}
+
+	for _, providerID := range pending {
+		node, ok := nodesByProviderID[providerID]
+		if !ok {
+			// There is nothing to drain if the instance doesn't have a Node.
+			log.V(2).Info("No Node found for the instance selected for deletion, skipping drain", "providerID", providerID)
+			drained.Insert(providerID)
+			continue
+		}
+
+		if err := drain.CordonAndDrainNode(ctx, kubeClient, node); err != nil {
+			conditions.MarkFalse(mp, expv1.ScaleDownNodesDrainedCondition, clusterv1.DrainingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+			r.recorder.Eventf(mp, corev1.EventTypeWarning, clusterv1.DrainNodeFailedEventReason, "error draining MachinePool's node %q: %v", node.Name, err)
+			// The MachinePool will be re-reconciled after a drain failure, to allow other MachinePools to be reconciled.
+			log.Error(err, "Drain failed, retry in 20s", "Node", klog.KObj(node))
+			return ctrl.Result{RequeueAfter: 20 * time.Second}, nil
+		}
+
+		r.recorder.Eventf(mp, corev1.EventTypeNormal, clusterv1.DrainNodeSucceededEventReason, "success draining MachinePool's node %q", node.Name)
+		drained.Insert(providerID)
+	}
+
+	conditions.MarkTrue(mp, expv1.ScaleDownNodesDrainedCondition)
+	return ctrl.Result{}, nil
+}
+
+// isNodeDrainAllowed returns false if the ExcludeNodeDrainingAnnotation is set on the MachinePool, or if the
+// NodeDrainTimeout of the MachinePool template is exceeded since the start of the drain of the Nodes selected for deletion.
+func isNodeDrainAllowed(mp *expv1.MachinePool) bool {
+	if _, exists := mp.Annotations[clusterv1.ExcludeNodeDrainingAnnotation]; exists {
+		return false
+	}
+
+	nodeDrainTimeout := mp.Spec.Template.Spec.NodeDrainTimeout
+	if nodeDrainTimeout == nil || nodeDrainTimeout.Seconds() <= 0 {
+		return true
+	}
+	firstTimeDrain := conditions.GetLastTransitionTime(mp, expv1.ScaleDownNodesDrainedCondition)
+	if firstTimeDrain == nil {
+		return true
+	}
+	return time.Since(firstTimeDrain.Time).Seconds() < nodeDrainTimeout.Seconds()
+}
+
+// providerIDsFromAnnotation returns the providerIDs listed as comma-separated values in the given annotation.
+func providerIDsFromAnnotation(mp *expv1.MachinePool, annotation string) []string {
+	providerIDs := []string{}
+	for _, providerID := range strings.Split(mp.Annotations[annotation], ",") {
+		if providerID = strings.TrimSpace(providerID); providerID != "" {
+			providerIDs = append(providerIDs, providerID)
+		}
+	}
+	return providerIDs
+}
+
+// setProviderIDsAnnotation sets the given providerIDs as comma-separated values in the given annotation,
+// or removes the annotation if there are no providerIDs.
+func setProviderIDsAnnotation(mp *expv1.MachinePool, annotation string, providerIDs []string) {
+	if len(providerIDs) == 0 {
+		delete(mp.Annotations, annotation)
+		return
+	}
+	if mp.Annotations == nil {
+		mp.Annotations = map[string]string{}
+	}
+	mp.Annotations[annotation] = strings.Join(providerIDs, ",")
+}
@@ -0,0 +1,191 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestMachinePoolReconcileScaleDownDrain(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		providerIDList  []string
+		wantAnnotations map[string]string
+		wantCondition   bool
+	}{
+		{
+			name:            "MachinePool not opted in to drain-before-scale-down",
+			annotations:     nil,
+			providerIDList:  []string{"aws://us-east-1/id-node-1"},
+			wantAnnotations: nil,
+			wantCondition:   false,
+		},
+		{
+			name: "drained annotation is removed when the scale down is completed",
+			annotations: map[string]string{
+				expv1.ScaleDownDrainedProviderIDsAnnotation: "aws://us-east-1/id-node-1",
+			},
+			providerIDList:  []string{"aws://us-east-1/id-node-2"},
+			wantAnnotations: map[string]string{},
+			wantCondition:   false,
+		},
+		{
+			name: "instances already drained are kept in the drained annotation",
+			annotations: map[string]string{
+				expv1.ScaleDownProviderIDsAnnotation:        "aws://us-east-1/id-node-1, aws://us-east-1/id-node-2",
+				expv1.ScaleDownDrainedProviderIDsAnnotation: "aws://us-east-1/id-node-2,aws://us-east-1/id-node-1",
+			},
+			providerIDList: []string{"aws://us-east-1/id-node-1", "aws://us-east-1/id-node-2", "aws://us-east-1/id-node-3"},
+			wantAnnotations: map[string]string{
+				expv1.ScaleDownProviderIDsAnnotation:        "aws://us-east-1/id-node-1, aws://us-east-1/id-node-2",
+				expv1.ScaleDownDrainedProviderIDsAnnotation: "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2",
+			},
+			wantCondition: true,
+		},
+		{
+			name: "instances already deleted are removed from the drained annotation",
+			annotations: map[string]string{
+				expv1.ScaleDownProviderIDsAnnotation:        "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2",
+				expv1.ScaleDownDrainedProviderIDsAnnotation: "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2",
+			},
+			providerIDList: []string{"aws://us-east-1/id-node-2", "aws://us-east-1/id-node-3"},
+			wantAnnotations: map[string]string{
+				expv1.ScaleDownProviderIDsAnnotation:        "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2",
+				expv1.ScaleDownDrainedProviderIDsAnnotation: "aws://us-east-1/id-node-2",
+			},
+			wantCondition: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &MachinePoolReconciler{
+				recorder: record.NewFakeRecorder(32),
+			}
+			mp := &expv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-mp",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: expv1.MachinePoolSpec{
+					ProviderIDList: tt.providerIDList,
+				},
+			}
+
+			res, err := r.reconcileScaleDownDrain(ctx, cluster, mp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(ctrl.Result{}))
+			g.Expect(mp.Annotations).To(Equal(tt.wantAnnotations))
+			if tt.wantCondition {
+				g.Expect(conditions.IsTrue(mp, expv1.ScaleDownNodesDrainedCondition)).To(BeTrue())
+			} else {
+				g.Expect(conditions.Has(mp, expv1.ScaleDownNodesDrainedCondition)).To(BeFalse())
+			}
+		})
+	}
+}
+
+func TestMachinePoolReconcileScaleDownDrainNotAllowed(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+
+	tests := []struct {
+		name             string
+		annotations      map[string]string
+		nodeDrainTimeout *metav1.Duration
+		conditions       clusterv1.Conditions
+	}{
+		{
+			name: "drain is skipped when the MachinePool has the exclude node draining annotation",
+			annotations: map[string]string{
+				clusterv1.ExcludeNodeDrainingAnnotation: "",
+			},
+		},
+		{
+			name:             "drain is skipped when the NodeDrainTimeout is exceeded",
+			nodeDrainTimeout: &metav1.Duration{Duration: time.Minute},
+			conditions: clusterv1.Conditions{
+				{
+					Type:               expv1.ScaleDownNodesDrainedCondition,
+					Status:             corev1.ConditionFalse,
+					Reason:             clusterv1.DrainingFailedReason,
+					LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Minute)),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &MachinePoolReconciler{
+				recorder: record.NewFakeRecorder(32),
+			}
+			annotations := map[string]string{
+				expv1.ScaleDownProviderIDsAnnotation: "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2",
+			}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			mp := &expv1.MachinePool{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-mp",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: annotations,
+				},
+				Spec: expv1.MachinePoolSpec{
+					ProviderIDList: []string{"aws://us-east-1/id-node-1", "aws://us-east-1/id-node-2"},
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							NodeDrainTimeout: tt.nodeDrainTimeout,
+						},
+					},
+				},
+				Status: expv1.MachinePoolStatus{
+					Conditions: tt.conditions,
+				},
+			}
+
+			res, err := r.reconcileScaleDownDrain(ctx, cluster, mp)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res).To(Equal(ctrl.Result{}))
+			g.Expect(mp.Annotations).To(HaveKeyWithValue(expv1.ScaleDownDrainedProviderIDsAnnotation, "aws://us-east-1/id-node-1,aws://us-east-1/id-node-2"))
+			g.Expect(conditions.IsTrue(mp, expv1.ScaleDownNodesDrainedCondition)).To(BeTrue())
+		})
+	}
+}
@@ -23,6 +23,8 @@ import (
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -32,6 +34,7 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
@@ -236,6 +239,59 @@ func (r *MachinePoolReconciler) patchNodes(ctx context.Context, c client.Client,
 	return nil
 }
 
+// reconcileProviderIDsMarkedForDeletion reports in Status.ProviderIDsMarkedForDeletion the providerIDs of the instances
+// whose MachinePool Machine or Node has the cluster.x-k8s.io/delete-machine annotation, so infrastructure providers
+// can remove exactly those instances when the MachinePool is scaled down.
+func (r *MachinePoolReconciler) reconcileProviderIDsMarkedForDeletion(ctx context.Context, cluster *clusterv1.Cluster, mp *expv1.MachinePool) (ctrl.Result, error) {
+	// Check that the MachinePool hasn't been deleted or in the process.
+	if !mp.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	marked := sets.Set[string]{}
+
+	machineList := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machineList, client.InNamespace(mp.Namespace), client.MatchingLabels{
+		clusterv1.MachinePoolNameLabel: format.MustFormatValue(mp.Name),
+		clusterv1.ClusterNameLabel:     mp.Spec.ClusterName,
+	}); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to list MachinePool Machines")
+	}
+	for _, machine := range machineList.Items {
+		if _, ok := machine.Annotations[clusterv1.DeleteMachineAnnotation]; ok && machine.Spec.ProviderID != nil {
+			marked.Insert(*machine.Spec.ProviderID)
+		}
+	}
+
+	// Nodes can only be read once the control plane is initialized; if there is no tracker, don't read remote Nodes.
+	if len(mp.Status.NodeRefs) > 0 && conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) && r.Tracker != nil {
+		clusterClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		for _, nodeRef := range mp.Status.NodeRefs {
+			node := &corev1.Node{}
+			if err := clusterClient.Get(ctx, client.ObjectKey{Name: nodeRef.Name}, node); err != nil {
+				if apierrors.IsNotFound(err) {
+					continue
+				}
+				return ctrl.Result{}, errors.Wrapf(err, "failed to get Node %s", nodeRef.Name)
+			}
+			if _, ok := node.Annotations[clusterv1.DeleteMachineAnnotation]; ok && node.Spec.ProviderID != "" {
+				marked.Insert(node.Spec.ProviderID)
+			}
+		}
+	}
+
+	// Only instances still in the MachinePool are reported; the other ones have been deleted already.
+	marked = marked.Intersection(sets.New(mp.Spec.ProviderIDList...))
+	mp.Status.ProviderIDsMarkedForDeletion = nil
+	if marked.Len() > 0 {
+		mp.Status.ProviderIDsMarkedForDeletion = sets.List(marked)
+	}
+	return ctrl.Result{}, nil
+}
+
 func nodeIsReady(node *corev1.Node) bool {
 	for _, n := range node.Status.Conditions {
 		if n.Type == corev1.NodeReady {
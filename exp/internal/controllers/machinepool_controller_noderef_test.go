@@ -19,15 +19,22 @@ package controllers
 import (
 	"testing"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/labels/format"
 )
 
 func TestMachinePoolGetNodeReference(t *testing.T) {
@@ -430,3 +437,89 @@ func TestMachinePoolPatchNodes(t *testing.T) {
 		})
 	}
 }
+
+func TestMachinePoolReconcileProviderIDsMarkedForDeletion(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+	conditions.MarkTrue(cluster, clusterv1.ControlPlaneInitializedCondition)
+	mp := &expv1.MachinePool{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-mp", Namespace: metav1.NamespaceDefault},
+		Spec: expv1.MachinePoolSpec{
+			ClusterName: cluster.Name,
+			ProviderIDList: []string{
+				"aws://us-east-1/id-node-1",
+				"aws://us-east-1/id-node-2",
+				"aws://us-east-1/id-node-3",
+				"aws://us-east-1/id-node-4",
+			},
+		},
+		Status: expv1.MachinePoolStatus{
+			NodeRefs: []corev1.ObjectReference{
+				{Name: "node-3"},
+				{Name: "node-4"},
+				{Name: "node-deleted"},
+			},
+		},
+	}
+
+	machinePoolMachine := func(name, providerID string, annotations map[string]string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Namespace:   metav1.NamespaceDefault,
+				Annotations: annotations,
+				Labels: map[string]string{
+					clusterv1.MachinePoolNameLabel: format.MustFormatValue(mp.Name),
+					clusterv1.ClusterNameLabel:     cluster.Name,
+				},
+			},
+			Spec: clusterv1.MachineSpec{
+				ClusterName: cluster.Name,
+				ProviderID:  pointer.String(providerID),
+			},
+		}
+	}
+	node := func(name, providerID string, annotations map[string]string) *corev1.Node {
+		return &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        name,
+				Annotations: annotations,
+			},
+			Spec: corev1.NodeSpec{
+				ProviderID: providerID,
+			},
+		}
+	}
+	deleteMachine := map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+
+	fakeClient := fake.NewClientBuilder().WithObjects(
+		machinePoolMachine("machine-1", "aws://us-east-1/id-node-1", deleteMachine),
+		machinePoolMachine("machine-2", "aws://us-east-1/id-node-2", nil),
+		// Instances already removed from the MachinePool are not reported.
+		machinePoolMachine("machine-gone", "aws://us-east-1/id-node-gone", deleteMachine),
+		node("node-3", "aws://us-east-1/id-node-3", deleteMachine),
+		node("node-4", "aws://us-east-1/id-node-4", nil),
+	).Build()
+
+	r := &MachinePoolReconciler{
+		Client:   fakeClient,
+		Tracker:  remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), fakeClient, fakeClient.Scheme(), client.ObjectKeyFromObject(cluster)),
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	res, err := r.reconcileProviderIDsMarkedForDeletion(ctx, cluster, mp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(mp.Status.ProviderIDsMarkedForDeletion).To(Equal([]string{"aws://us-east-1/id-node-1", "aws://us-east-1/id-node-3"}))
+
+	// Removing the annotations clears the providerIDs marked for deletion.
+	mp.Spec.ProviderIDList = []string{"aws://us-east-1/id-node-2", "aws://us-east-1/id-node-4"}
+	mp.Status.NodeRefs = []corev1.ObjectReference{{Name: "node-4"}}
+	res, err = r.reconcileProviderIDsMarkedForDeletion(ctx, cluster, mp)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res).To(Equal(ctrl.Result{}))
+	g.Expect(mp.Status.ProviderIDsMarkedForDeletion).To(BeNil())
+}
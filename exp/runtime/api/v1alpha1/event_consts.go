@@ -0,0 +1,26 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+// Event reasons are part of the API: they are stable and can be used to key off the events emitted by Cluster API,
+// e.g. in alerting pipelines.
+
+const (
+	// DiscoveredVariablesChangedEventReason is used when the variables returned by the DiscoverVariables hook
+	// of an ExtensionConfig change, e.g. after an upgrade of the Runtime Extension.
+	DiscoveredVariablesChangedEventReason = "DiscoveredVariablesChanged"
+)
@@ -75,6 +75,13 @@ type ClientConfig struct {
 	// CABundle is a PEM encoded CA bundle which will be used to validate the Extension server's server certificate.
 	// +optional
 	CABundle []byte `json:"caBundle,omitempty"`
+
+	// ClientCertificate is a reference to a Secret containing the client certificate and key which will be used
+	// to authenticate to the Extension server, for Extension servers requiring client authentication (mTLS).
+	// The Secret must contain the PEM encoded certificate in the `tls.crt` key and the PEM encoded private key in the
+	// `tls.key` key, e.g. a Secret of type `kubernetes.io/tls`.
+	// +optional
+	ClientCertificate *SecretReference `json:"clientCertificate,omitempty"`
 }
 
 // ServiceReference holds a reference to a Kubernetes Service of an Extension server.
@@ -97,6 +104,15 @@ type ServiceReference struct {
 	Port *int32 `json:"port,omitempty"`
 }
 
+// SecretReference holds a reference to a Kubernetes Secret.
+type SecretReference struct {
+	// Namespace is the namespace of the Secret.
+	Namespace string `json:"namespace"`
+
+	// Name is the name of the Secret.
+	Name string `json:"name"`
+}
+
 // ANCHOR_END: ExtensionConfigSpec
 
 // ANCHOR: ExtensionConfigStatus
@@ -207,6 +223,13 @@ const (
 	// DiscoveryFailedReason documents failure of a Discovery call.
 	DiscoveryFailedReason string = "DiscoveryFailed"
 
+	// RuntimeExtensionHandlersAvailableCondition is a condition set on an ExtensionConfig object reporting if the
+	// extension is reachable and if the last call to each of its ExtensionHandlers succeeded.
+	RuntimeExtensionHandlersAvailableCondition clusterv1.ConditionType = "HandlersAvailable"
+
+	// HandlersUnavailableReason documents ExtensionHandlers which could not be called the last time they were called.
+	HandlersUnavailableReason string = "HandlersUnavailable"
+
 	// InjectCAFromSecretAnnotation is the annotation that specifies that an ExtensionConfig
 	// object wants injection of CAs. The value is a reference to a Secret
 	// as <namespace>/<name>.
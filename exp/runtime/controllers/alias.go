@@ -18,6 +18,7 @@ package controllers
 
 import (
 	"context"
+	"time"
 
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -35,6 +36,9 @@ type ExtensionConfigReconciler struct {
 
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ProbeInterval is the interval at which ExtensionConfigs are discovered again.
+	ProbeInterval time.Duration
 }
 
 func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -43,5 +47,6 @@ func (r *ExtensionConfigReconciler) SetupWithManager(ctx context.Context, mgr ct
 		APIReader:        r.APIReader,
 		RuntimeClient:    r.RuntimeClient,
 		WatchFilterValue: r.WatchFilterValue,
+		ProbeInterval:    r.ProbeInterval,
 	}).SetupWithManager(ctx, mgr, options)
 }
@@ -99,6 +99,7 @@ func (r *CommonResponse) GetStatus() ResponseStatus {
 
 // ResponseStatus represents the status of the hook response.
 // +enum
+// +kubebuilder:validation:Enum=Success;Failure
 type ResponseStatus string
 
 const (
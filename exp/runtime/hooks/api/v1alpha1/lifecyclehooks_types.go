@@ -196,6 +196,40 @@ type BeforeClusterDeleteResponse struct {
 // and before the cluster and its underlying objects are deleted.
 func BeforeClusterDelete(*BeforeClusterDeleteRequest, *BeforeClusterDeleteResponse) {}
 
+// BeforeMachineInfrastructureProvisionRequest is the request of the BeforeMachineInfrastructureProvision hook.
+// +kubebuilder:object:root=true
+type BeforeMachineInfrastructureProvisionRequest struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRequest contains fields common to all request types.
+	CommonRequest `json:",inline"`
+
+	// Cluster is the cluster object the lifecycle hook corresponds to.
+	Cluster clusterv1.Cluster `json:"cluster"`
+
+	// MachineSet is the MachineSet that is going to create the Machines.
+	MachineSet clusterv1.MachineSet `json:"machineSet"`
+
+	// MachinesToCreate is the number of Machines the MachineSet is going to create.
+	MachinesToCreate int32 `json:"machinesToCreate"`
+}
+
+var _ RetryResponseObject = &BeforeMachineInfrastructureProvisionResponse{}
+
+// BeforeMachineInfrastructureProvisionResponse is the response of the BeforeMachineInfrastructureProvision hook.
+// +kubebuilder:object:root=true
+type BeforeMachineInfrastructureProvisionResponse struct {
+	metav1.TypeMeta `json:",inline"`
+
+	// CommonRetryResponse contains Status, Message and RetryAfterSeconds fields.
+	CommonRetryResponse `json:",inline"`
+}
+
+// BeforeMachineInfrastructureProvision is the hook that will be called before a MachineSet creates
+// the infrastructure and bootstrap objects of new Machines.
+func BeforeMachineInfrastructureProvision(*BeforeMachineInfrastructureProvisionRequest, *BeforeMachineInfrastructureProvisionResponse) {
+}
+
 func init() {
 	catalogBuilder.RegisterHook(BeforeClusterCreate, &runtimecatalog.HookMeta{
 		Tags:    []string{"Lifecycle Hooks"},
@@ -271,4 +305,18 @@ func init() {
 			"- This is a blocking hook; Runtime Extension implementers can use this hook  to execute " +
 			"tasks before objects of the Cluster are deleted",
 	})
+
+	catalogBuilder.RegisterHook(BeforeMachineInfrastructureProvision, &runtimecatalog.HookMeta{
+		Tags:    []string{"Lifecycle Hooks"},
+		Summary: "Cluster API Runtime will call this hook before a MachineSet provisions the infrastructure of new Machines",
+		Description: "Cluster API Runtime will call this hook when a MachineSet is scaling up, and immediately before " +
+			"the infrastructure machines and bootstrap configs of the new Machines are going to be created.\n" +
+			"\n" +
+			"Notes:\n" +
+			"- This hook will be called for all the MachineSets, including the ones not part of a Cluster with a managed topology\n" +
+			"- The call's request contains the Cluster object, the MachineSet object and the number of Machines to create\n" +
+			"- This is a blocking hook; Runtime Extension implementers can use this hook to validate quota or capacity " +
+			"before the infrastructure is provisioned; returning a failure surfaces the message on the MachinesCreated " +
+			"condition of the MachineSet",
+	})
 }
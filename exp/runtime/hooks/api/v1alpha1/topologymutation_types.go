@@ -93,6 +93,7 @@ type GeneratePatchesResponseItem struct {
 
 // PatchType defines the supported patch types.
 // +enum
+// +kubebuilder:validation:Enum=JSONPatch;JSONMergePatch
 type PatchType string
 
 const (
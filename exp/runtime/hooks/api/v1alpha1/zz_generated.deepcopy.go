@@ -331,6 +331,58 @@ func (in *BeforeClusterUpgradeResponse) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineInfrastructureProvisionRequest) DeepCopyInto(out *BeforeMachineInfrastructureProvisionRequest) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.CommonRequest.DeepCopyInto(&out.CommonRequest)
+	in.Cluster.DeepCopyInto(&out.Cluster)
+	in.MachineSet.DeepCopyInto(&out.MachineSet)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineInfrastructureProvisionRequest.
+func (in *BeforeMachineInfrastructureProvisionRequest) DeepCopy() *BeforeMachineInfrastructureProvisionRequest {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineInfrastructureProvisionRequest)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineInfrastructureProvisionRequest) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeforeMachineInfrastructureProvisionResponse) DeepCopyInto(out *BeforeMachineInfrastructureProvisionResponse) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.CommonRetryResponse = in.CommonRetryResponse
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeforeMachineInfrastructureProvisionResponse.
+func (in *BeforeMachineInfrastructureProvisionResponse) DeepCopy() *BeforeMachineInfrastructureProvisionResponse {
+	if in == nil {
+		return nil
+	}
+	out := new(BeforeMachineInfrastructureProvisionResponse)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *BeforeMachineInfrastructureProvisionResponse) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CommonRequest) DeepCopyInto(out *CommonRequest) {
 	*out = *in
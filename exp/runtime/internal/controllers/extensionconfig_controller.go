@@ -19,7 +19,9 @@ package controllers
 import (
 	"context"
 	"fmt"
+	"sort"
 	"strings"
+	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
@@ -36,6 +38,7 @@ import (
 	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -57,6 +60,10 @@ type Reconciler struct {
 	RuntimeClient runtimeclient.Client
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
+
+	// ProbeInterval is the interval at which ExtensionConfigs are discovered again, to detect unavailable
+	// extensions before they are called by lifecycle hooks. If zero, ExtensionConfigs are discovered only on changes.
+	ProbeInterval time.Duration
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
@@ -68,7 +75,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Complete(r)
+		Complete(concurrency.Reconciler(r, options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
@@ -153,7 +160,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Resu
 	if err = r.RuntimeClient.Register(discoveredExtensionConfig); err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to register ExtensionConfig %s/%s", extensionConfig.Namespace, extensionConfig.Name)
 	}
-	return ctrl.Result{}, nil
+	return ctrl.Result{RequeueAfter: r.ProbeInterval}, nil
 }
 
 func patchExtensionConfig(ctx context.Context, client client.Client, original, modified *runtimev1.ExtensionConfig, options ...patch.Option) error {
@@ -164,6 +171,7 @@ func patchExtensionConfig(ctx context.Context, client client.Client, original, m
 
 	options = append(options, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
 		runtimev1.RuntimeExtensionDiscoveredCondition,
+		runtimev1.RuntimeExtensionHandlersAvailableCondition,
 	}})
 	err = patchHelper.Patch(ctx, modified, options...)
 	if err != nil {
@@ -214,13 +222,38 @@ func discoverExtensionConfig(ctx context.Context, runtimeClient runtimeclient.Cl
 	if err != nil {
 		modifiedExtensionConfig := extensionConfig.DeepCopy()
 		conditions.MarkFalse(modifiedExtensionConfig, runtimev1.RuntimeExtensionDiscoveredCondition, runtimev1.DiscoveryFailedReason, clusterv1.ConditionSeverityError, "error in discovery: %v", err)
+		conditions.MarkFalse(modifiedExtensionConfig, runtimev1.RuntimeExtensionHandlersAvailableCondition, runtimev1.DiscoveryFailedReason, clusterv1.ConditionSeverityError, "extension is not reachable")
 		return modifiedExtensionConfig, errors.Wrapf(err, "failed to discover %s", tlog.KObj{Obj: extensionConfig})
 	}
 
 	conditions.MarkTrue(discoveredExtension, runtimev1.RuntimeExtensionDiscoveredCondition)
+	setHandlersAvailableCondition(runtimeClient, discoveredExtension)
 	return discoveredExtension, nil
 }
 
+// setHandlersAvailableCondition sets the HandlersAvailable condition on a discovered ExtensionConfig, reporting
+// the ExtensionHandlers which could not be called the last time they were called.
+// NOTE: Failures expire after a while, and failures of ExtensionHandlers which are not in the discovered handlers
+// anymore are dropped, so the condition recovers also if a failing handler is not called again.
+func setHandlersAvailableCondition(runtimeClient runtimeclient.Client, extensionConfig *runtimev1.ExtensionConfig) {
+	unavailable := runtimeClient.UnavailableHandlers(extensionConfig)
+	if len(unavailable) == 0 {
+		conditions.MarkTrue(extensionConfig, runtimev1.RuntimeExtensionHandlersAvailableCondition)
+		return
+	}
+
+	names := make([]string, 0, len(unavailable))
+	for name := range unavailable {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	messages := make([]string, 0, len(names))
+	for _, name := range names {
+		messages = append(messages, fmt.Sprintf("handler %s: %s", name, unavailable[name]))
+	}
+	conditions.MarkFalse(extensionConfig, runtimev1.RuntimeExtensionHandlersAvailableCondition, runtimev1.HandlersUnavailableReason, clusterv1.ConditionSeverityWarning, "%s", strings.Join(messages, "; "))
+}
+
 // reconcileCABundle reconciles the CA bundle for the ExtensionConfig.
 // Note: This was implemented to behave similar to the cert-manager cainjector.
 // We couldn't use the cert-manager cainjector because it doesn't work with CustomResources.
@@ -42,9 +42,11 @@ import (
 	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
 	fakev1alpha1 "sigs.k8s.io/cluster-api/internal/runtime/test/v1alpha1"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestExtensionReconciler_Reconcile(t *testing.T) {
@@ -121,9 +123,11 @@ func TestExtensionReconciler_Reconcile(t *testing.T) {
 		g.Expect(handlers[2].Name).To(Equal("third.ext1"))
 
 		conditions := config.GetConditions()
-		g.Expect(conditions).To(HaveLen(1))
+		g.Expect(conditions).To(HaveLen(2))
 		g.Expect(conditions[0].Status).To(Equal(corev1.ConditionTrue))
 		g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+		g.Expect(conditions[1].Status).To(Equal(corev1.ConditionTrue))
+		g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 		_, err = registry.Get("first.ext1")
 		g.Expect(err).ToNot(HaveOccurred())
 		_, err = registry.Get("second.ext1")
@@ -172,9 +176,11 @@ func TestExtensionReconciler_Reconcile(t *testing.T) {
 		g.Expect(handlers[0].Name).To(Equal("first.ext1"))
 		g.Expect(handlers[1].Name).To(Equal("third.ext1"))
 		conditions := config.GetConditions()
-		g.Expect(conditions).To(HaveLen(1))
+		g.Expect(conditions).To(HaveLen(2))
 		g.Expect(conditions[0].Status).To(Equal(corev1.ConditionTrue))
 		g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+		g.Expect(conditions[1].Status).To(Equal(corev1.ConditionTrue))
+		g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 
 		_, err = registry.Get("first.ext1")
 		g.Expect(err).ToNot(HaveOccurred())
@@ -230,12 +236,13 @@ func TestExtensionReconciler_discoverExtensionConfig(t *testing.T) {
 		g.Expect(handlers).To(HaveLen(1))
 		g.Expect(handlers[0].Name).To(Equal("first.ext1"))
 
-		// Expect exactly one condition and expect the condition to have type RuntimeExtensionDiscoveredCondition and
-		// Status true.
+		// Expect the RuntimeExtensionDiscoveredCondition and the RuntimeExtensionHandlersAvailableCondition with Status true.
 		conditions := discoveredExtensionConfig.GetConditions()
-		g.Expect(conditions).To(HaveLen(1))
+		g.Expect(conditions).To(HaveLen(2))
 		g.Expect(conditions[0].Status).To(Equal(corev1.ConditionTrue))
 		g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+		g.Expect(conditions[1].Status).To(Equal(corev1.ConditionTrue))
+		g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 	})
 	t.Run("fail discovery for non-running extension", func(t *testing.T) {
 		cat := runtimecatalog.New()
@@ -263,12 +270,54 @@ func TestExtensionReconciler_discoverExtensionConfig(t *testing.T) {
 		handlers := discoveredExtensionConfig.Status.Handlers
 		g.Expect(handlers).To(BeEmpty())
 
-		// Expect exactly one condition and expect the condition to have type RuntimeExtensionDiscoveredCondition and
-		// Status false.
+		// Expect the RuntimeExtensionDiscoveredCondition and the RuntimeExtensionHandlersAvailableCondition with Status false.
 		conditions := discoveredExtensionConfig.GetConditions()
-		g.Expect(conditions).To(HaveLen(1))
+		g.Expect(conditions).To(HaveLen(2))
 		g.Expect(conditions[0].Status).To(Equal(corev1.ConditionFalse))
 		g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+		g.Expect(conditions[1].Status).To(Equal(corev1.ConditionFalse))
+		g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
+	})
+}
+
+func Test_setHandlersAvailableCondition(t *testing.T) {
+	extensionConfig := &runtimev1.ExtensionConfig{
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{
+				{Name: "first.ext1"},
+				{Name: "second.ext1"},
+				{Name: "third.ext1"},
+			},
+		},
+	}
+
+	t.Run("marks the condition true if all the handlers are available", func(t *testing.T) {
+		g := NewWithT(t)
+
+		config := extensionConfig.DeepCopy()
+		setHandlersAvailableCondition(fakeruntimeclient.NewRuntimeClientBuilder().Build(), config)
+
+		g.Expect(conditions.IsTrue(config, runtimev1.RuntimeExtensionHandlersAvailableCondition)).To(BeTrue())
+	})
+
+	t.Run("marks the condition false listing the unavailable handlers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+			WithUnavailableHandlers(map[string]string{
+				"third.ext1": "connection refused",
+				"first.ext1": "timeout",
+				"other.ext2": "connection refused",
+			}).
+			Build()
+		config := extensionConfig.DeepCopy()
+		setHandlersAvailableCondition(runtimeClient, config)
+
+		condition := conditions.Get(config, runtimev1.RuntimeExtensionHandlersAvailableCondition)
+		g.Expect(condition).ToNot(BeNil())
+		g.Expect(condition.Status).To(Equal(corev1.ConditionFalse))
+		g.Expect(condition.Reason).To(Equal(runtimev1.HandlersUnavailableReason))
+		g.Expect(condition.Message).To(Equal("handler first.ext1: timeout; handler third.ext1: connection refused"))
 	})
 }
 
@@ -96,9 +96,11 @@ func Test_warmupRunnable_Start(t *testing.T) {
 			g.Expect(handlers[2].Name).To(Equal(fmt.Sprintf("third.ext%d", i+1)))
 
 			conditions := config.GetConditions()
-			g.Expect(conditions).To(HaveLen(1))
+			g.Expect(conditions).To(HaveLen(2))
 			g.Expect(conditions[0].Status).To(Equal(corev1.ConditionTrue))
 			g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+			g.Expect(conditions[1].Status).To(Equal(corev1.ConditionTrue))
+			g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 		}
 	})
 
@@ -161,9 +163,11 @@ func Test_warmupRunnable_Start(t *testing.T) {
 
 			// Expect no handlers and a failed condition for the broken extension.
 			if config.Name == brokenExtension {
-				g.Expect(conditions).To(HaveLen(1))
+				g.Expect(conditions).To(HaveLen(2))
 				g.Expect(conditions[0].Status).To(Equal(corev1.ConditionFalse))
 				g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+				g.Expect(conditions[1].Status).To(Equal(corev1.ConditionFalse))
+				g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 				g.Expect(handlers).To(BeEmpty())
 
 				continue
@@ -175,9 +179,11 @@ func Test_warmupRunnable_Start(t *testing.T) {
 			g.Expect(handlers[1].Name).To(Equal(fmt.Sprintf("second.ext%d", i+1)))
 			g.Expect(handlers[2].Name).To(Equal(fmt.Sprintf("third.ext%d", i+1)))
 
-			g.Expect(conditions).To(HaveLen(1))
+			g.Expect(conditions).To(HaveLen(2))
 			g.Expect(conditions[0].Status).To(Equal(corev1.ConditionTrue))
 			g.Expect(conditions[0].Type).To(Equal(runtimev1.RuntimeExtensionDiscoveredCondition))
+			g.Expect(conditions[1].Status).To(Equal(corev1.ConditionTrue))
+			g.Expect(conditions[1].Type).To(Equal(runtimev1.RuntimeExtensionHandlersAvailableCondition))
 		}
 	})
 }
@@ -60,6 +60,17 @@ const (
 	//
 	// alpha: v1.5
 	MachineSetPreflightChecks featuregate.Feature = "MachineSetPreflightChecks"
+
+	// ClusterGroup is a feature gate for the ClusterGroup functionality.
+	//
+	// alpha: v1.6
+	ClusterGroup featuregate.Feature = "ClusterGroup"
+
+	// MachineDeploymentRollback is a feature gate for rolling back MachineDeployment rollouts
+	// which did not make progress within the progress deadline.
+	//
+	// alpha: v1.6
+	MachineDeploymentRollback featuregate.Feature = "MachineDeploymentRollback"
 )
 
 func init() {
@@ -76,4 +87,6 @@ var defaultClusterAPIFeatureGates = map[featuregate.Feature]featuregate.FeatureS
 	KubeadmBootstrapFormatIgnition: {Default: false, PreRelease: featuregate.Alpha},
 	RuntimeSDK:                     {Default: false, PreRelease: featuregate.Alpha},
 	MachineSetPreflightChecks:      {Default: false, PreRelease: featuregate.Alpha},
+	ClusterGroup:                   {Default: false, PreRelease: featuregate.Alpha},
+	MachineDeploymentRollback:      {Default: false, PreRelease: featuregate.Alpha},
 }
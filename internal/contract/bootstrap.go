@@ -59,3 +59,11 @@ func (b *BootstrapContract) FailureMessage() *String {
 		path: []string{"status", "failureMessage"},
 	}
 }
+
+// Format provides access to the spec.format field in a bootstrap object, i.e. the format of the bootstrap data
+// generated by the bootstrap provider. Note that this field is optional.
+func (b *BootstrapContract) Format() *String {
+	return &String{
+		path: []string{"spec", "format"},
+	}
+}
@@ -78,4 +78,17 @@ func TestBootstrap(t *testing.T) {
 		g.Expect(got).ToNot(BeNil())
 		g.Expect(*got).To(Equal("fake-message"))
 	})
+	t.Run("Manages optional spec.format", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(Bootstrap().Format().Path()).To(Equal(Path{"spec", "format"}))
+
+		err := Bootstrap().Format().Set(obj, "fake-format")
+		g.Expect(err).ToNot(HaveOccurred())
+
+		got, err := Bootstrap().Format().Get(obj)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(got).ToNot(BeNil())
+		g.Expect(*got).To(Equal("fake-format"))
+	})
 }
@@ -31,6 +31,7 @@ import (
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -42,12 +43,15 @@ import (
 	expv1 "sigs.k8s.io/cluster-api/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/hooks"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 const (
@@ -72,20 +76,27 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// DeletionPhaseTimeout is the time after which a Cluster deletion phase, e.g. the deletion of the workers,
+	// is considered timed out and the next phase is started. If zero, every phase waits indefinitely for the previous ones.
+	DeletionPhaseTimeout time.Duration
+
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	c, err := ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.Cluster{}).
+		For(&clusterv1.Cluster{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
 		Watches(
 			&clusterv1.Machine{},
-			handler.EnqueueRequestsFromMapFunc(r.controlPlaneMachineToCluster),
+			handler.EnqueueRequestsFromMapFunc(r.machineToCluster),
 		).
 		WithOptions(options).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Build(r)
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Build(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.Cluster{}), options))
 
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
@@ -115,8 +126,17 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, cluster) {
+	// Return early if the Cluster does not belong to the shard of this manager.
+	if !r.Shard.Contains(cluster) {
+		return ctrl.Result{}, nil
+	}
+
+	// Set or remove the Paused condition, then return early if the object or Cluster is paused.
+	isPaused, _, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -175,6 +195,9 @@ func patchCluster(ctx context.Context, patchHelper *patch.Helper, cluster *clust
 			clusterv1.ReadyCondition,
 			clusterv1.ControlPlaneReadyCondition,
 			clusterv1.InfrastructureReadyCondition,
+			clusterv1.WorkersDeletedCondition,
+			clusterv1.ControlPlaneDeletedCondition,
+			clusterv1.InfrastructureDeletedCondition,
 		}},
 	)
 	return patchHelper.Patch(ctx, cluster, options...)
@@ -197,6 +220,7 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster)
 		r.reconcileControlPlane,
 		r.reconcileKubeconfig,
 		r.reconcileControlPlaneInitialized,
+		r.reconcileFailureDomainMachines,
 	}
 
 	res := ctrl.Result{}
@@ -233,109 +257,225 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 		return reconcile.Result{}, err
 	}
 
-	children, err := descendants.filterOwnedDescendants(cluster)
-	if err != nil {
-		log.Error(err, "Failed to extract direct descendants")
-		return reconcile.Result{}, err
+	// The Cluster is deleted in phases, so workers are deleted before the control plane, and the control plane
+	// before the infrastructure, instead of relying on the garbage collector deleting everything at the same time.
+	// NOTE: If a phase takes longer than the DeletionPhaseTimeout, the next phase is started without waiting for
+	// the current one to complete, but the Cluster finalizer is removed only after all the phases are completed.
+	phases := []deletionPhase{
+		{condition: clusterv1.WorkersDeletedCondition, reconcile: r.reconcileDeleteWorkers},
+		{condition: clusterv1.ControlPlaneDeletedCondition, reconcile: r.reconcileDeleteControlPlane},
+		{condition: clusterv1.InfrastructureDeletedCondition, reconcile: r.reconcileDeleteInfrastructure},
 	}
 
-	if len(children) > 0 {
-		log.Info("Cluster still has children - deleting them first", "count", len(children))
-
-		var errs []error
+	res := ctrl.Result{}
+	completed := true
+	// Every phase starts when the previous one is completed or times out; the first phase starts with the Cluster deletion.
+	var phaseStart time.Time
+	if cluster.DeletionTimestamp != nil {
+		phaseStart = cluster.DeletionTimestamp.Time
+	}
+	for _, phase := range phases {
+		phaseResult, deleted, err := phase.reconcile(ctx, cluster, descendants)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if deleted {
+			conditions.MarkTrue(cluster, phase.condition)
+			phaseStart = r.deletionPhaseEnd(cluster, phase.condition, phaseStart)
+			continue
+		}
 
-		for _, child := range children {
-			if !child.GetDeletionTimestamp().IsZero() {
-				// Don't handle deleted child
-				continue
+		completed = false
+		res = util.LowestNonZeroResult(res, phaseResult)
+		remaining := r.DeletionPhaseTimeout - time.Since(phaseStart)
+		if r.DeletionPhaseTimeout <= 0 || remaining > 0 {
+			// Requeue at the latest when the phase times out, so the next phase can be started.
+			if r.DeletionPhaseTimeout > 0 {
+				res = util.LowestNonZeroResult(res, ctrl.Result{RequeueAfter: remaining})
 			}
-			gvk := child.GetObjectKind().GroupVersionKind().String()
+			return res, nil
+		}
+		log.Info("Cluster deletion phase timed out, proceeding with the next phase", "phase", phase.condition, "timeout", r.DeletionPhaseTimeout)
+		conditions.MarkFalse(cluster, phase.condition, clusterv1.DeletionTimeoutReason, clusterv1.ConditionSeverityWarning,
+			"Deletion is taking longer than %s", r.DeletionPhaseTimeout)
+		phaseStart = phaseStart.Add(r.DeletionPhaseTimeout)
+	}
+	if !completed {
+		return util.LowestNonZeroResult(res, ctrl.Result{RequeueAfter: deleteRequeueAfter}), nil
+	}
 
-			log.Info("Deleting child object", "gvk", gvk, "name", child.GetName())
-			if err := r.Client.Delete(ctx, child); err != nil {
-				err = errors.Wrapf(err, "error deleting cluster %s/%s: failed to delete %s %s", cluster.Namespace, cluster.Name, gvk, child.GetName())
-				log.Error(err, "Error deleting resource", "gvk", gvk, "name", child.GetName())
-				errs = append(errs, err)
-			}
+	controllerutil.RemoveFinalizer(cluster, clusterv1.ClusterFinalizer)
+	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "Deleted", "Cluster %s has been deleted", cluster.Name)
+	return ctrl.Result{}, nil
+}
+
+// deletionPhase is a phase of the Cluster deletion.
+type deletionPhase struct {
+	// condition documents the progress of the phase.
+	condition clusterv1.ConditionType
+
+	// reconcile issues the deletion requests for the objects deleted in the phase, and returns true
+	// when all of them are gone.
+	reconcile func(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) (ctrl.Result, bool, error)
+}
+
+// deletionPhaseEnd returns when a completed deletion phase ended, i.e. when the corresponding condition transitioned to true.
+// NOTE: If the phase timed out before completing, the next phase already started when the phase timed out.
+func (r *Reconciler) deletionPhaseEnd(cluster *clusterv1.Cluster, conditionType clusterv1.ConditionType, phaseStart time.Time) time.Time {
+	phaseEnd := phaseStart
+	if c := conditions.Get(cluster, conditionType); c != nil && c.LastTransitionTime.Time.After(phaseEnd) {
+		phaseEnd = c.LastTransitionTime.Time
+	}
+	if r.DeletionPhaseTimeout > 0 && phaseEnd.After(phaseStart.Add(r.DeletionPhaseTimeout)) {
+		phaseEnd = phaseStart.Add(r.DeletionPhaseTimeout)
+	}
+	return phaseEnd
+}
+
+// reconcileDeleteWorkers deletes the MachinePools, MachineDeployments, MachineSets and worker Machines owned by the
+// Cluster, and waits for all the workers of the Cluster to be gone.
+func (r *Reconciler) reconcileDeleteWorkers(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) (ctrl.Result, bool, error) {
+	workers := descendants.workers()
+	if err := r.deleteOwnedDescendants(ctx, cluster, workers); err != nil {
+		return ctrl.Result{}, false, err
+	}
+
+	if workers.length() > 0 {
+		conditions.MarkFalse(cluster, clusterv1.WorkersDeletedCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for workers to be deleted: %s", workers.descendantNames())
+		// Requeue so we can check the next time to see if there are still any workers left.
+		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, false, nil
+	}
+	return ctrl.Result{}, true, nil
+}
+
+// reconcileDeleteControlPlane deletes the control plane object of the Cluster or, if the Cluster does not
+// have a control plane provider, the control plane Machines owned by the Cluster.
+func (r *Reconciler) reconcileDeleteControlPlane(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) (ctrl.Result, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if cluster.Spec.ControlPlaneRef == nil {
+		controlPlane := descendants.controlPlane()
+		if err := r.deleteOwnedDescendants(ctx, cluster, controlPlane); err != nil {
+			return ctrl.Result{}, false, err
 		}
 
-		if len(errs) > 0 {
-			return ctrl.Result{}, kerrors.NewAggregate(errs)
+		if controlPlane.length() > 0 {
+			conditions.MarkFalse(cluster, clusterv1.ControlPlaneDeletedCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo,
+				"Waiting for control plane Machines to be deleted: %s", controlPlane.descendantNames())
+			// Requeue so we can check the next time to see if there are still any control plane machines left.
+			return ctrl.Result{RequeueAfter: deleteRequeueAfter}, false, nil
 		}
+		return ctrl.Result{}, true, nil
 	}
 
-	if descendantCount := descendants.length(); descendantCount > 0 {
-		indirect := descendantCount - len(children)
-		log.Info("Cluster still has descendants - need to requeue", "descendants", descendants.descendantNames(), "indirect descendants count", indirect)
-		// Requeue so we can check the next time to see if there are still any descendants left.
-		return ctrl.Result{RequeueAfter: deleteRequeueAfter}, nil
+	obj, err := external.Get(ctx, r.UnstructuredCachingClient, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	switch {
+	case apierrors.IsNotFound(errors.Cause(err)):
+		// All good - the control plane resource has been deleted
+		conditions.MarkFalse(cluster, clusterv1.ControlPlaneReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, true, nil
+	case err != nil:
+		return ctrl.Result{}, false, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
+			path.Join(cluster.Spec.ControlPlaneRef.APIVersion, cluster.Spec.ControlPlaneRef.Kind),
+			cluster.Spec.ControlPlaneRef.Name, cluster.Namespace, cluster.Name)
 	}
 
-	if cluster.Spec.ControlPlaneRef != nil {
-		obj, err := external.Get(ctx, r.UnstructuredCachingClient, cluster.Spec.ControlPlaneRef, cluster.Namespace)
-		switch {
-		case apierrors.IsNotFound(errors.Cause(err)):
-			// All good - the control plane resource has been deleted
-			conditions.MarkFalse(cluster, clusterv1.ControlPlaneReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-		case err != nil:
-			return reconcile.Result{}, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
-				path.Join(cluster.Spec.ControlPlaneRef.APIVersion, cluster.Spec.ControlPlaneRef.Kind),
-				cluster.Spec.ControlPlaneRef.Name, cluster.Namespace, cluster.Name)
-		default:
-			// Report a summary of current status of the control plane object defined for this cluster.
-			conditions.SetMirror(cluster, clusterv1.ControlPlaneReadyCondition,
-				conditions.UnstructuredGetter(obj),
-				conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
-			)
-
-			// Issue a deletion request for the control plane object.
-			// Once it's been deleted, the cluster will get processed again.
-			if err := r.Client.Delete(ctx, obj); err != nil {
-				return ctrl.Result{}, errors.Wrapf(err,
-					"failed to delete %v %q for Cluster %q in namespace %q",
-					obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
-			}
+	// Report a summary of current status of the control plane object defined for this cluster.
+	conditions.SetMirror(cluster, clusterv1.ControlPlaneReadyCondition,
+		conditions.UnstructuredGetter(obj),
+		conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
+	)
 
-			// Return here so we don't remove the finalizer yet.
-			log.Info("Cluster still has descendants - need to requeue", "controlPlaneRef", cluster.Spec.ControlPlaneRef.Name)
-			return ctrl.Result{}, nil
+	// Issue a deletion request for the control plane object.
+	// Once it's been deleted, the cluster will get processed again.
+	if obj.GetDeletionTimestamp().IsZero() {
+		if err := r.Client.Delete(ctx, obj); err != nil {
+			return ctrl.Result{}, false, errors.Wrapf(err,
+				"failed to delete %v %q for Cluster %q in namespace %q",
+				obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
 		}
 	}
 
-	if cluster.Spec.InfrastructureRef != nil {
-		obj, err := external.Get(ctx, r.UnstructuredCachingClient, cluster.Spec.InfrastructureRef, cluster.Namespace)
-		switch {
-		case apierrors.IsNotFound(errors.Cause(err)):
-			// All good - the infra resource has been deleted
-			conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
-		case err != nil:
-			return ctrl.Result{}, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
-				path.Join(cluster.Spec.InfrastructureRef.APIVersion, cluster.Spec.InfrastructureRef.Kind),
-				cluster.Spec.InfrastructureRef.Name, cluster.Namespace, cluster.Name)
-		default:
-			// Report a summary of current status of the infrastructure object defined for this cluster.
-			conditions.SetMirror(cluster, clusterv1.InfrastructureReadyCondition,
-				conditions.UnstructuredGetter(obj),
-				conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
-			)
-
-			// Issue a deletion request for the infrastructure object.
-			// Once it's been deleted, the cluster will get processed again.
-			if err := r.Client.Delete(ctx, obj); err != nil {
-				return ctrl.Result{}, errors.Wrapf(err,
-					"failed to delete %v %q for Cluster %q in namespace %q",
-					obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
-			}
+	log.Info("Cluster still has descendants - need to requeue", "controlPlaneRef", cluster.Spec.ControlPlaneRef.Name)
+	conditions.MarkFalse(cluster, clusterv1.ControlPlaneDeletedCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo,
+		"Waiting for %s %s to be deleted", cluster.Spec.ControlPlaneRef.Kind, cluster.Spec.ControlPlaneRef.Name)
+	return ctrl.Result{}, false, nil
+}
 
-			// Return here so we don't remove the finalizer yet.
-			log.Info("Cluster still has descendants - need to requeue", "infrastructureRef", cluster.Spec.InfrastructureRef.Name)
-			return ctrl.Result{}, nil
+// reconcileDeleteInfrastructure deletes the infrastructure object of the Cluster.
+func (r *Reconciler) reconcileDeleteInfrastructure(ctx context.Context, cluster *clusterv1.Cluster, _ clusterDescendants) (ctrl.Result, bool, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if cluster.Spec.InfrastructureRef == nil {
+		return ctrl.Result{}, true, nil
+	}
+
+	obj, err := external.Get(ctx, r.UnstructuredCachingClient, cluster.Spec.InfrastructureRef, cluster.Namespace)
+	switch {
+	case apierrors.IsNotFound(errors.Cause(err)):
+		// All good - the infra resource has been deleted
+		conditions.MarkFalse(cluster, clusterv1.InfrastructureReadyCondition, clusterv1.DeletedReason, clusterv1.ConditionSeverityInfo, "")
+		return ctrl.Result{}, true, nil
+	case err != nil:
+		return ctrl.Result{}, false, errors.Wrapf(err, "failed to get %s %q for Cluster %s/%s",
+			path.Join(cluster.Spec.InfrastructureRef.APIVersion, cluster.Spec.InfrastructureRef.Kind),
+			cluster.Spec.InfrastructureRef.Name, cluster.Namespace, cluster.Name)
+	}
+
+	// Report a summary of current status of the infrastructure object defined for this cluster.
+	conditions.SetMirror(cluster, clusterv1.InfrastructureReadyCondition,
+		conditions.UnstructuredGetter(obj),
+		conditions.WithFallbackValue(false, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo, ""),
+	)
+
+	// Issue a deletion request for the infrastructure object.
+	// Once it's been deleted, the cluster will get processed again.
+	if obj.GetDeletionTimestamp().IsZero() {
+		if err := r.Client.Delete(ctx, obj); err != nil {
+			return ctrl.Result{}, false, errors.Wrapf(err,
+				"failed to delete %v %q for Cluster %q in namespace %q",
+				obj.GroupVersionKind(), obj.GetName(), cluster.Name, cluster.Namespace)
 		}
 	}
 
-	controllerutil.RemoveFinalizer(cluster, clusterv1.ClusterFinalizer)
-	r.recorder.Eventf(cluster, corev1.EventTypeNormal, "Deleted", "Cluster %s has been deleted", cluster.Name)
-	return ctrl.Result{}, nil
+	log.Info("Cluster still has descendants - need to requeue", "infrastructureRef", cluster.Spec.InfrastructureRef.Name)
+	conditions.MarkFalse(cluster, clusterv1.InfrastructureDeletedCondition, clusterv1.DeletingReason, clusterv1.ConditionSeverityInfo,
+		"Waiting for %s %s to be deleted", cluster.Spec.InfrastructureRef.Kind, cluster.Spec.InfrastructureRef.Name)
+	return ctrl.Result{}, false, nil
+}
+
+// deleteOwnedDescendants issues a deletion request for the given descendants owned by the Cluster.
+func (r *Reconciler) deleteOwnedDescendants(ctx context.Context, cluster *clusterv1.Cluster, descendants clusterDescendants) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	children, err := descendants.filterOwnedDescendants(cluster)
+	if err != nil {
+		log.Error(err, "Failed to extract direct descendants")
+		return err
+	}
+
+	if len(children) == 0 {
+		return nil
+	}
+	log.Info("Cluster still has children - deleting them first", "count", len(children))
+
+	var errs []error
+	for _, child := range children {
+		if !child.GetDeletionTimestamp().IsZero() {
+			// Don't handle deleted child
+			continue
+		}
+		gvk := child.GetObjectKind().GroupVersionKind().String()
+
+		log.Info("Deleting child object", "gvk", gvk, "name", child.GetName())
+		if err := r.Client.Delete(ctx, child); err != nil {
+			err = errors.Wrapf(err, "error deleting cluster %s/%s: failed to delete %s %s", cluster.Namespace, cluster.Name, gvk, child.GetName())
+			log.Error(err, "Error deleting resource", "gvk", gvk, "name", child.GetName())
+			errs = append(errs, err)
+		}
+	}
+	return kerrors.NewAggregate(errs)
 }
 
 type clusterDescendants struct {
@@ -355,6 +495,25 @@ func (c *clusterDescendants) length() int {
 		len(c.machinePools.Items)
 }
 
+// workers returns the descendants which are deleted in the first phase of the Cluster deletion, i.e. all
+// the descendants except control plane Machines.
+func (c *clusterDescendants) workers() clusterDescendants {
+	return clusterDescendants{
+		machineDeployments: c.machineDeployments,
+		machineSets:        c.machineSets,
+		workerMachines:     c.workerMachines,
+		machinePools:       c.machinePools,
+	}
+}
+
+// controlPlane returns the control plane Machines, which are deleted after the workers.
+// NOTE: control plane Machines are descendants only if the Cluster does not have a control plane provider.
+func (c *clusterDescendants) controlPlane() clusterDescendants {
+	return clusterDescendants{
+		controlPlaneMachines: c.controlPlaneMachines,
+	}
+}
+
 func (c *clusterDescendants) descendantNames() string {
 	descendants := make([]string, 0)
 	controlPlaneMachineNames := make([]string, len(c.controlPlaneMachines.Items))
@@ -509,6 +668,23 @@ func (r *Reconciler) reconcileControlPlaneInitialized(ctx context.Context, clust
 	return ctrl.Result{}, nil
 }
 
+// machineToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for Cluster to update its status.failureDomainMachines when a Machine in a failure domain changes,
+// or to update its status.controlPlaneInitialized field when control plane machines are changed.
+func (r *Reconciler) machineToCluster(ctx context.Context, o client.Object) []ctrl.Request {
+	m, ok := o.(*clusterv1.Machine)
+	if !ok {
+		panic(fmt.Sprintf("Expected a Machine but got a %T", o))
+	}
+	if m.Spec.FailureDomain == nil || *m.Spec.FailureDomain == "" {
+		return r.controlPlaneMachineToCluster(ctx, o)
+	}
+
+	return []ctrl.Request{{
+		NamespacedName: client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.ClusterName},
+	}}
+}
+
 // controlPlaneMachineToCluster is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
 // for Cluster to update its status.controlPlaneInitialized field.
 func (r *Reconciler) controlPlaneMachineToCluster(ctx context.Context, o client.Object) []ctrl.Request {
@@ -0,0 +1,180 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// reconcileFailureDomainMachines reports the number of Machines of the Cluster in each failure domain.
+func (r *Reconciler) reconcileFailureDomainMachines(ctx context.Context, cluster *clusterv1.Cluster) (ctrl.Result, error) {
+	machines, err := collections.GetFilteredMachinesForCluster(ctx, r.Client, cluster)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list Machines")
+	}
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, machineSets, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list MachineSets")
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := r.Client.List(ctx, machineDeployments, client.InNamespace(cluster.Namespace), client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name}); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list MachineDeployments")
+	}
+
+	controlPlaneVersion, err := r.getControlPlaneVersion(ctx, cluster)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	cluster.Status.FailureDomainMachines = computeFailureDomainMachines(machines, machineSets.Items, machineDeployments.Items, controlPlaneVersion)
+	return ctrl.Result{}, nil
+}
+
+// getControlPlaneVersion returns the version of the control plane, if defined.
+func (r *Reconciler) getControlPlaneVersion(ctx context.Context, cluster *clusterv1.Cluster) (string, error) {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return "", nil
+	}
+
+	controlPlane, err := external.Get(ctx, r.UnstructuredCachingClient, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(errors.Cause(err)) {
+			return "", nil
+		}
+		return "", err
+	}
+
+	version, err := contract.ControlPlane().Version().Get(controlPlane)
+	if err != nil {
+		if errors.Is(err, contract.ErrFieldNotFound) {
+			return "", nil
+		}
+		return "", errors.Wrapf(err, "failed to get version from %s %s", controlPlane.GetKind(), controlPlane.GetName())
+	}
+	return *version, nil
+}
+
+// computeFailureDomainMachines computes the number of Machines in each failure domain, sorted by failure domain name.
+func computeFailureDomainMachines(machines collections.Machines, machineSets []clusterv1.MachineSet, machineDeployments []clusterv1.MachineDeployment, controlPlaneVersion string) []clusterv1.FailureDomainMachinesStatus {
+	machineSetsByName := make(map[string]*clusterv1.MachineSet, len(machineSets))
+	for i := range machineSets {
+		machineSetsByName[machineSets[i].Name] = &machineSets[i]
+	}
+	machineDeploymentsByName := make(map[string]*clusterv1.MachineDeployment, len(machineDeployments))
+	for i := range machineDeployments {
+		machineDeploymentsByName[machineDeployments[i].Name] = &machineDeployments[i]
+	}
+
+	statusByFailureDomain := map[string]*clusterv1.FailureDomainMachinesStatus{}
+	for _, machine := range machines {
+		if machine.Spec.FailureDomain == nil || *machine.Spec.FailureDomain == "" {
+			continue
+		}
+
+		status, ok := statusByFailureDomain[*machine.Spec.FailureDomain]
+		if !ok {
+			status = &clusterv1.FailureDomainMachinesStatus{Name: *machine.Spec.FailureDomain}
+			statusByFailureDomain[status.Name] = status
+		}
+
+		if conditions.IsTrue(machine, clusterv1.ReadyCondition) {
+			status.ReadyMachines++
+			if conditions.IsTrue(machine, clusterv1.MachineNodeHealthyCondition) {
+				status.AvailableMachines++
+			}
+		}
+
+		if !machine.DeletionTimestamp.IsZero() {
+			continue
+		}
+		status.DesiredMachines++
+		if isMachineUpToDate(machine, machineSetsByName, machineDeploymentsByName, controlPlaneVersion) {
+			status.UpToDateMachines++
+		}
+	}
+
+	if len(statusByFailureDomain) == 0 {
+		return nil
+	}
+
+	failureDomainMachines := make([]clusterv1.FailureDomainMachinesStatus, 0, len(statusByFailureDomain))
+	for _, status := range statusByFailureDomain {
+		failureDomainMachines = append(failureDomainMachines, *status)
+	}
+	sort.Slice(failureDomainMachines, func(i, j int) bool {
+		return failureDomainMachines[i].Name < failureDomainMachines[j].Name
+	})
+	return failureDomainMachines
+}
+
+// isMachineUpToDate returns true if the Machine doesn't require a rollout.
+// NOTE: Machines owned by a MachineDeployment are up-to-date if they belong to the current revision of the MachineDeployment;
+// control plane Machines are up-to-date if they have the same version of the control plane; all the other Machines
+// are considered up-to-date.
+func isMachineUpToDate(machine *clusterv1.Machine, machineSets map[string]*clusterv1.MachineSet, machineDeployments map[string]*clusterv1.MachineDeployment, controlPlaneVersion string) bool {
+	if util.IsControlPlaneMachine(machine) {
+		if controlPlaneVersion == "" || machine.Spec.Version == nil {
+			return true
+		}
+		return *machine.Spec.Version == controlPlaneVersion
+	}
+
+	machineSet := machineSets[controllerName(machine.ObjectMeta, "MachineSet")]
+	if machineSet == nil {
+		return true
+	}
+	machineDeployment := machineDeployments[controllerName(machineSet.ObjectMeta, "MachineDeployment")]
+	if machineDeployment == nil {
+		return true
+	}
+
+	machineSetRevision, err := mdutil.Revision(machineSet)
+	if err != nil {
+		return true
+	}
+	machineDeploymentRevision, err := mdutil.Revision(machineDeployment)
+	if err != nil {
+		return true
+	}
+	return machineSetRevision == machineDeploymentRevision
+}
+
+// controllerName returns the name of the controller of an object if it has the given kind.
+func controllerName(obj metav1.ObjectMeta, kind string) string {
+	ref := metav1.GetControllerOfNoCopy(&obj)
+	if ref == nil || ref.Kind != kind {
+		return ""
+	}
+	return ref.Name
+}
@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cluster
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestComputeFailureDomainMachines(t *testing.T) {
+	md := clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "md",
+			Annotations: map[string]string{clusterv1.RevisionAnnotation: "2"},
+		},
+	}
+	oldMS := fdMachineSet("ms-old", "1", md.Name)
+	newMS := fdMachineSet("ms-new", "2", md.Name)
+
+	machines := collections.FromMachines(
+		// Up-to-date, available control plane Machine.
+		fdMachine("cp-1", "fd1", "", "v1.28.0", true, true, true),
+		// Outdated, ready control plane Machine.
+		fdMachine("cp-2", "fd2", "", "v1.27.0", true, false, true),
+		// Up-to-date, not ready worker Machine.
+		fdMachine("worker-1", "fd1", newMS.Name, "", false, false, false),
+		// Outdated, available worker Machine.
+		fdMachine("worker-2", "fd2", oldMS.Name, "", false, true, true),
+		// Deleting, available worker Machine.
+		deletingMachine(fdMachine("worker-3", "fd2", newMS.Name, "", false, true, true)),
+		// Worker Machine without a failure domain.
+		fdMachine("worker-4", "", newMS.Name, "", false, true, true),
+	)
+
+	g := NewWithT(t)
+	got := computeFailureDomainMachines(machines, []clusterv1.MachineSet{oldMS, newMS}, []clusterv1.MachineDeployment{md}, "v1.28.0")
+	g.Expect(got).To(Equal([]clusterv1.FailureDomainMachinesStatus{
+		{
+			Name:              "fd1",
+			DesiredMachines:   2,
+			ReadyMachines:     1,
+			AvailableMachines: 1,
+			UpToDateMachines:  2,
+		},
+		{
+			Name:              "fd2",
+			DesiredMachines:   2,
+			ReadyMachines:     3,
+			AvailableMachines: 2,
+			UpToDateMachines:  0,
+		},
+	}))
+
+	g.Expect(computeFailureDomainMachines(collections.New(), nil, nil, "")).To(BeNil())
+}
+
+func TestClusterReconciler_machineToCluster(t *testing.T) {
+	g := NewWithT(t)
+
+	r := &Reconciler{}
+	m := fdMachine("worker-1", "fd1", "", "", false, false, false)
+	g.Expect(r.machineToCluster(ctx, m)).To(Equal([]ctrl.Request{{
+		NamespacedName: client.ObjectKey{Namespace: m.Namespace, Name: m.Spec.ClusterName},
+	}}))
+}
+
+func fdMachineSet(name, revision, machineDeploymentName string) clusterv1.MachineSet {
+	return clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Annotations: map[string]string{clusterv1.RevisionAnnotation: revision},
+			OwnerReferences: []metav1.OwnerReference{{
+				APIVersion: clusterv1.GroupVersion.String(),
+				Kind:       "MachineDeployment",
+				Name:       machineDeploymentName,
+				Controller: pointer.Bool(true),
+			}},
+		},
+	}
+}
+
+func fdMachine(name, failureDomain, machineSetName, version string, controlPlane, nodeHealthy, ready bool) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "test",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "test-cluster"},
+		},
+		Spec: clusterv1.MachineSpec{
+			ClusterName: "test-cluster",
+		},
+	}
+	if failureDomain != "" {
+		m.Spec.FailureDomain = pointer.String(failureDomain)
+	}
+	if version != "" {
+		m.Spec.Version = pointer.String(version)
+	}
+	if controlPlane {
+		m.Labels[clusterv1.MachineControlPlaneLabel] = ""
+	}
+	if machineSetName != "" {
+		m.OwnerReferences = []metav1.OwnerReference{{
+			APIVersion: clusterv1.GroupVersion.String(),
+			Kind:       "MachineSet",
+			Name:       machineSetName,
+			Controller: pointer.Bool(true),
+		}}
+	}
+	if ready {
+		conditions.MarkTrue(m, clusterv1.ReadyCondition)
+	}
+	if nodeHealthy {
+		conditions.MarkTrue(m, clusterv1.MachineNodeHealthyCondition)
+	}
+	return m
+}
+
+func deletingMachine(m *clusterv1.Machine) *clusterv1.Machine {
+	m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	return m
+}
@@ -35,6 +35,7 @@ import (
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/contract"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/kubeconfig"
 	"sigs.k8s.io/cluster-api/util/patch"
@@ -263,7 +264,7 @@ func (r *Reconciler) reconcileControlPlane(ctx context.Context, cluster *cluster
 	// Update cluster.Status.ControlPlaneInitialized if it hasn't already been set
 	// Determine if the control plane provider is initialized.
 	if !conditions.IsTrue(cluster, clusterv1.ControlPlaneInitializedCondition) {
-		initialized, err := external.IsInitialized(controlPlaneConfig)
+		initialized, err := contract.IsControlPlaneInitialized(controlPlaneConfig)
 		if err != nil {
 			return ctrl.Result{}, err
 		}
@@ -291,7 +292,7 @@ func (r *Reconciler) reconcileKubeconfig(ctx context.Context, cluster *clusterv1
 		return ctrl.Result{}, nil
 	}
 
-	_, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
+	configSecret, err := secret.Get(ctx, r.Client, util.ObjectKey(cluster), secret.Kubeconfig)
 	switch {
 	case apierrors.IsNotFound(err):
 		if err := kubeconfig.CreateSecret(ctx, r.Client, cluster); err != nil {
@@ -301,9 +302,44 @@ func (r *Reconciler) reconcileKubeconfig(ctx context.Context, cluster *clusterv1
 			}
 			return ctrl.Result{}, err
 		}
+		return ctrl.Result{}, nil
 	case err != nil:
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
 	}
 
+	// Regenerate the Kubeconfig if the kubeconfig endpoint annotations of the Cluster changed,
+	// the same way the KubeadmControlPlane controller does for Clusters with a control plane.
+	endpoint := kubeconfig.Endpoint(cluster)
+	needsEndpointUpdate, err := kubeconfig.NeedsEndpointUpdate(configSecret, endpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if needsEndpointUpdate {
+		log.Info("Regenerating Kubeconfig Secret with the new endpoint", "endpoint", endpoint)
+		if err := kubeconfig.RegenerateSecretWithEndpoint(ctx, r.Client, configSecret, endpoint); err != nil {
+			if err == kubeconfig.ErrDependentCertificateNotFound {
+				log.Info("Could not find secret for cluster, requeuing", "Secret", secret.ClusterCA)
+				return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
+			}
+			return ctrl.Result{}, errors.Wrapf(err, "failed to regenerate Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+		}
+		return ctrl.Result{}, nil
+	}
+
+	userEndpoint := kubeconfig.UserEndpoint(cluster)
+	needsUserEndpointUpdate, err := kubeconfig.NeedsUserEndpointUpdate(configSecret, userEndpoint)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if needsUserEndpointUpdate {
+		log.Info("Updating user Kubeconfig in the Kubeconfig Secret", "endpoint", userEndpoint)
+		if err := kubeconfig.SetUserKubeconfig(configSecret, userEndpoint); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to generate user Kubeconfig for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+		}
+		if err := r.Client.Update(ctx, configSecret); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to update Kubeconfig Secret for Cluster %q in namespace %q", cluster.Name, cluster.Namespace)
+		}
+	}
+
 	return ctrl.Result{}, nil
 }
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/clientcmd"
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -32,6 +33,8 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
+	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/secret"
 )
 
 func TestClusterReconcilePhases(t *testing.T) {
@@ -185,6 +188,9 @@ func TestClusterReconcilePhases(t *testing.T) {
 					ObjectMeta: metav1.ObjectMeta{
 						Name: "test-cluster-kubeconfig",
 					},
+					Data: map[string][]byte{
+						secret.KubeconfigDataName: []byte("clusters:\n- cluster:\n    server: https://1.2.3.4:8443\n  name: test-cluster\n"),
+					},
 				},
 				wantErr: false,
 			},
@@ -235,6 +241,81 @@ func TestClusterReconcilePhases(t *testing.T) {
 			})
 		}
 	})
+
+	t.Run("reconcile kubeconfig endpoints", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: metav1.NamespaceDefault,
+			},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneEndpoint: clusterv1.APIEndpoint{
+					Host: "1.2.3.4",
+					Port: 8443,
+				},
+			},
+		}
+
+		certificates := secret.NewCertificatesForInitialControlPlane(nil)
+		g.Expect(certificates.Generate()).To(Succeed())
+		caSecret := certificates.GetByPurpose(secret.ClusterCA).AsSecret(util.ObjectKey(cluster), *metav1.NewControllerRef(cluster, clusterv1.GroupVersion.WithKind("Cluster")))
+
+		c := fake.NewClientBuilder().WithObjects(cluster, caSecret).Build()
+		r := &Reconciler{
+			Client:                    c,
+			UnstructuredCachingClient: c,
+			recorder:                  record.NewFakeRecorder(32),
+		}
+
+		getServers := func() (string, string) {
+			configSecret, err := secret.Get(ctx, c, util.ObjectKey(cluster), secret.Kubeconfig)
+			g.Expect(err).ToNot(HaveOccurred())
+			config, err := clientcmd.Load(configSecret.Data[secret.KubeconfigDataName])
+			g.Expect(err).ToNot(HaveOccurred())
+			userServer := ""
+			if data, ok := configSecret.Data[secret.UserKubeconfigDataName]; ok {
+				userConfig, err := clientcmd.Load(data)
+				g.Expect(err).ToNot(HaveOccurred())
+				userServer = userConfig.Clusters[cluster.Name].Server
+			}
+			return config.Clusters[cluster.Name].Server, userServer
+		}
+
+		// The Kubeconfig Secret is created with the control plane endpoint.
+		_, err := r.reconcileKubeconfig(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		server, userServer := getServers()
+		g.Expect(server).To(Equal("https://1.2.3.4:8443"))
+		g.Expect(userServer).To(BeEmpty())
+
+		// The Kubeconfig is regenerated when the kubeconfig endpoint annotation is set.
+		cluster.Annotations = map[string]string{clusterv1.ClusterKubeconfigEndpointAnnotation: "internal.example.com:6443"}
+		_, err = r.reconcileKubeconfig(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		server, userServer = getServers()
+		g.Expect(server).To(Equal("https://internal.example.com:6443"))
+		g.Expect(userServer).To(BeEmpty())
+
+		// The user Kubeconfig is added when the user kubeconfig endpoint annotation is set.
+		cluster.Annotations[clusterv1.ClusterUserKubeconfigEndpointAnnotation] = "api.example.com:443"
+		_, err = r.reconcileKubeconfig(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		server, userServer = getServers()
+		g.Expect(server).To(Equal("https://internal.example.com:6443"))
+		g.Expect(userServer).To(Equal("https://api.example.com:443"))
+
+		// Both Kubeconfigs go back to the defaults when the annotations are removed.
+		cluster.Annotations = nil
+		_, err = r.reconcileKubeconfig(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = r.reconcileKubeconfig(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		server, userServer = getServers()
+		g.Expect(server).To(Equal("https://1.2.3.4:8443"))
+		g.Expect(userServer).To(BeEmpty())
+	})
 }
 
 func TestClusterReconciler_reconcilePhase(t *testing.T) {
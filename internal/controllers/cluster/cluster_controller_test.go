@@ -18,11 +18,13 @@ package cluster
 
 import (
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
@@ -402,6 +404,143 @@ func TestClusterReconciler_reconcileDelete(t *testing.T) {
 	}
 }
 
+func TestClusterReconciler_reconcileDeletePhases(t *testing.T) {
+	newCluster := func() *clusterv1.Cluster {
+		cluster := builder.Cluster("test-ns", "test-cluster").
+			WithControlPlane(builder.ControlPlane("test-ns", "test-cp").Build()).
+			WithInfrastructureCluster(builder.InfrastructureCluster("test-ns", "test-infra").Build()).
+			Build()
+		cluster.Finalizers = []string{clusterv1.ClusterFinalizer}
+		return cluster
+	}
+	newMachineDeployment := func(cluster *clusterv1.Cluster) *clusterv1.MachineDeployment {
+		md := newMachineDeploymentBuilder().named("test-md").ownedBy(cluster).build()
+		md.Namespace = cluster.Namespace
+		md.Labels = map[string]string{clusterv1.ClusterNameLabel: cluster.Name}
+		// Use a finalizer so the MachineDeployment is not immediately removed when deleted.
+		md.Finalizers = []string{"test.cluster.x-k8s.io/finalizer"}
+		return &md
+	}
+
+	t.Run("deletes workers before the control plane", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		controlPlane := builder.ControlPlane("test-ns", "test-cp").Build()
+		infrastructureCluster := builder.InfrastructureCluster("test-ns", "test-infra").Build()
+		md := newMachineDeployment(cluster)
+		fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cluster, controlPlane, infrastructureCluster, md).Build()
+		r := &Reconciler{
+			Client:                    fakeClient,
+			UnstructuredCachingClient: fakeClient,
+			APIReader:                 fakeClient,
+		}
+
+		res, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.RequeueAfter).To(Equal(deleteRequeueAfter))
+
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(md), md)).To(Succeed())
+		g.Expect(md.DeletionTimestamp.IsZero()).To(BeFalse())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane)).To(Succeed())
+		g.Expect(controlPlane.GetDeletionTimestamp().IsZero()).To(BeTrue())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infrastructureCluster), infrastructureCluster)).To(Succeed())
+		g.Expect(infrastructureCluster.GetDeletionTimestamp().IsZero()).To(BeTrue())
+
+		g.Expect(conditions.IsFalse(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.GetReason(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.DeletingReason))
+		g.Expect(conditions.Has(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeFalse())
+		g.Expect(cluster.Finalizers).To(ContainElement(clusterv1.ClusterFinalizer))
+	})
+
+	t.Run("deletes the control plane before the infrastructure", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		controlPlane := builder.ControlPlane("test-ns", "test-cp").Build()
+		infrastructureCluster := builder.InfrastructureCluster("test-ns", "test-infra").Build()
+		fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cluster, controlPlane, infrastructureCluster).Build()
+		r := &Reconciler{
+			Client:                    fakeClient,
+			UnstructuredCachingClient: fakeClient,
+			APIReader:                 fakeClient,
+		}
+
+		_, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane))).To(BeTrue())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infrastructureCluster), infrastructureCluster)).To(Succeed())
+
+		g.Expect(conditions.IsTrue(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.IsFalse(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.Has(cluster, clusterv1.InfrastructureDeletedCondition)).To(BeFalse())
+
+		// Once the control plane is gone, the infrastructure is deleted.
+		_, err = r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(infrastructureCluster), infrastructureCluster))).To(BeTrue())
+		g.Expect(conditions.IsTrue(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.IsFalse(cluster, clusterv1.InfrastructureDeletedCondition)).To(BeTrue())
+		g.Expect(cluster.Finalizers).To(ContainElement(clusterv1.ClusterFinalizer))
+	})
+
+	t.Run("removes the finalizer when all the phases are completed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cluster).Build()
+		r := &Reconciler{
+			Client:                    fakeClient,
+			UnstructuredCachingClient: fakeClient,
+			APIReader:                 fakeClient,
+			recorder:                  record.NewFakeRecorder(32),
+		}
+
+		_, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		g.Expect(conditions.IsTrue(cluster, clusterv1.WorkersDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeTrue())
+		g.Expect(conditions.IsTrue(cluster, clusterv1.InfrastructureDeletedCondition)).To(BeTrue())
+		g.Expect(cluster.Finalizers).ToNot(ContainElement(clusterv1.ClusterFinalizer))
+	})
+
+	t.Run("starts the next phase when a phase times out", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := newCluster()
+		controlPlane := builder.ControlPlane("test-ns", "test-cp").Build()
+		infrastructureCluster := builder.InfrastructureCluster("test-ns", "test-infra").Build()
+		md := newMachineDeployment(cluster)
+		fakeClient := fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(cluster, controlPlane, infrastructureCluster, md).Build()
+		r := &Reconciler{
+			Client:                    fakeClient,
+			UnstructuredCachingClient: fakeClient,
+			APIReader:                 fakeClient,
+			DeletionPhaseTimeout:      time.Minute,
+		}
+
+		// The deletion of the workers started more than a minute ago.
+		cluster.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-90 * time.Second)}
+
+		res, err := r.reconcileDelete(ctx, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		// Requeue at the latest when the control plane deletion phase times out, a minute after the workers one.
+		g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+		g.Expect(res.RequeueAfter).To(BeNumerically("<=", 30*time.Second))
+
+		g.Expect(apierrors.IsNotFound(fakeClient.Get(ctx, client.ObjectKeyFromObject(controlPlane), controlPlane))).To(BeTrue())
+		g.Expect(fakeClient.Get(ctx, client.ObjectKeyFromObject(infrastructureCluster), infrastructureCluster)).To(Succeed())
+
+		g.Expect(conditions.GetReason(cluster, clusterv1.WorkersDeletedCondition)).To(Equal(clusterv1.DeletionTimeoutReason))
+		g.Expect(conditions.GetSeverity(cluster, clusterv1.WorkersDeletedCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityWarning)))
+		g.Expect(conditions.IsFalse(cluster, clusterv1.ControlPlaneDeletedCondition)).To(BeTrue())
+		g.Expect(cluster.Finalizers).To(ContainElement(clusterv1.ClusterFinalizer))
+	})
+}
+
 func TestClusterReconcilerNodeRef(t *testing.T) {
 	t.Run("machine to cluster", func(t *testing.T) {
 		cluster := &clusterv1.Cluster{
@@ -31,7 +31,9 @@ import (
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
@@ -45,11 +47,14 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	capirecord "sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;update;patch
@@ -64,17 +69,25 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// RuntimeClient is a client for calling runtime extensions.
 	RuntimeClient runtimeclient.Client
 
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects.
 	UnstructuredCachingClient client.Client
+
+	recorder record.EventRecorder
+
+	// discoveredVariables caches the last responses of the DiscoverVariables hook, to report when they change.
+	discoveredVariables *discoveredVariablesCache
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	err := ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.ClusterClass{}).
+		For(&clusterv1.ClusterClass{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
 		Named("clusterclass").
 		WithOptions(options).
 		Watches(
@@ -82,11 +95,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 			handler.EnqueueRequestsFromMapFunc(r.extensionConfigToClusterClass),
 		).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Complete(r)
+		Complete(concurrency.Reconciler(r, options))
 
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
+
+	r.recorder = capirecord.NewDeduplicatingRecorder(mgr.GetEventRecorderFor("clusterclass-controller"), capirecord.DefaultDeduplicationWindow)
+	r.discoveredVariables = newDiscoveredVariablesCache(discoveredVariablesCacheTTL)
 	return nil
 }
 
@@ -102,6 +118,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the ClusterClass does not belong to the shard of this manager.
+	if !r.Shard.Contains(clusterClass) {
+		return ctrl.Result{}, nil
+	}
+
 	// Return early if the ClusterClass is paused.
 	if annotations.HasPaused(clusterClass) {
 		log.Info("Reconciliation is paused for this object")
@@ -223,6 +244,7 @@ func (r *Reconciler) reconcileExternalReferences(ctx context.Context, clusterCla
 
 func (r *Reconciler) reconcileVariables(ctx context.Context, clusterClass *clusterv1.ClusterClass) error {
 	errs := []error{}
+	variablesChanges := []string{}
 	allVariableDefinitions := map[string]*clusterv1.ClusterClassStatusVariable{}
 	// Add inline variable definitions to the ClusterClass status.
 	for _, variable := range clusterClass.Spec.Variables {
@@ -236,22 +258,17 @@ func (r *Reconciler) reconcileVariables(ctx context.Context, clusterClass *clust
 			if patch.External == nil || patch.External.DiscoverVariablesExtension == nil {
 				continue
 			}
-			req := &runtimehooksv1.DiscoverVariablesRequest{}
-			req.Settings = patch.External.Settings
-
-			resp := &runtimehooksv1.DiscoverVariablesResponse{}
-			err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.DiscoverVariables, clusterClass, *patch.External.DiscoverVariablesExtension, req, resp)
+			variables, err := r.discoverVariables(ctx, clusterClass, patch)
 			if err != nil {
-				errs = append(errs, errors.Wrapf(err, "failed to call DiscoverVariables for patch %s", patch.Name))
+				errs = append(errs, err)
 				continue
 			}
-			if resp.Status != runtimehooksv1.ResponseStatusSuccess {
-				errs = append(errs, errors.Errorf("patch %s returned status %q with message %q", patch.Name, resp.Status, resp.Message))
-				continue
+			if diff, ok := diffDiscoveredVariables(clusterClass, patch.Name, variables); ok && !diff.IsEmpty() {
+				variablesChanges = append(variablesChanges, fmt.Sprintf("patch %s (%s)", patch.Name, diff))
 			}
-			if resp.Variables != nil {
+			if variables != nil {
 				uniqueNamesForPatch := sets.Set[string]{}
-				for _, variable := range resp.Variables {
+				for _, variable := range variables {
 					// Ensure a patch doesn't define multiple variables with the same name.
 					if uniqueNamesForPatch.Has(variable.Name) {
 						errs = append(errs, errors.Errorf("variable %q is defined multiple times in variable discovery response from patch %q", variable.Name, patch.Name))
@@ -289,10 +306,41 @@ func (r *Reconciler) reconcileVariables(ctx context.Context, clusterClass *clust
 		return statusVarList[i].Name < statusVarList[j].Name
 	})
 	clusterClass.Status.Variables = statusVarList
+	if len(variablesChanges) > 0 {
+		clusterClass.Status.DiscoveredVariablesChange = &clusterv1.ClusterClassDiscoveredVariablesChange{
+			Time:    metav1.Now(),
+			Message: fmt.Sprintf("Variables discovered for %s changed", strings.Join(variablesChanges, ", ")),
+		}
+	}
 	conditions.MarkTrue(clusterClass, clusterv1.ClusterClassVariablesReconciledCondition)
 	return nil
 }
 
+// discoverVariables returns the variables returned by the DiscoverVariables hook of a patch.
+// The hook is called only if there is no response cached for the same ExtensionHandler and settings,
+// or if the cached response is stale.
+func (r *Reconciler) discoverVariables(ctx context.Context, clusterClass *clusterv1.ClusterClass, patch clusterv1.ClusterClassPatch) ([]clusterv1.ClusterClassVariable, error) {
+	handlerName := *patch.External.DiscoverVariablesExtension
+	if r.discoveredVariables != nil {
+		if variables, ok := r.discoveredVariables.get(handlerName, patch.External.Settings); ok {
+			return variables, nil
+		}
+	}
+
+	req := &runtimehooksv1.DiscoverVariablesRequest{}
+	req.Settings = patch.External.Settings
+
+	resp := &runtimehooksv1.DiscoverVariablesResponse{}
+	if err := r.RuntimeClient.CallExtension(ctx, runtimehooksv1.DiscoverVariables, clusterClass, handlerName, req, resp); err != nil {
+		return nil, errors.Wrapf(err, "failed to call DiscoverVariables for patch %s", patch.Name)
+	}
+	if resp.Status != runtimehooksv1.ResponseStatusSuccess {
+		return nil, errors.Errorf("patch %s returned status %q with message %q", patch.Name, resp.Status, resp.Message)
+	}
+	r.reportDiscoveredVariablesChanges(ctx, handlerName, patch.External.Settings, resp.Variables)
+	return resp.Variables, nil
+}
+
 func reconcileConditions(clusterClass *clusterv1.ClusterClass, outdatedRefs map[*corev1.ObjectReference]*corev1.ObjectReference) {
 	if len(outdatedRefs) > 0 {
 		var msg []string
@@ -0,0 +1,227 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// discoveredVariablesCacheTTL is the time after which the cached response of a DiscoverVariables hook is
+// considered stale, and the hook is called again.
+const discoveredVariablesCacheTTL = 5 * time.Minute
+
+// discoveredVariablesCache caches the last response of the DiscoverVariables hook for each ExtensionHandler and settings,
+// so the hook is called at most once per TTL no matter how many ClusterClasses use it, and changes to the variables
+// returned by a Runtime Extension, e.g. after an upgrade, can be surfaced to the users.
+// NOTE: The cache is shared by all the ClusterClasses using the same ExtensionHandler with the same settings, so
+// each change is reported only once on the ExtensionConfig. The cache is in memory, so changes happening while the
+// controller is not running are not reported on the ExtensionConfig; they are still reported in the status of the
+// ClusterClasses, see diffDiscoveredVariables.
+type discoveredVariablesCache struct {
+	lock      sync.Mutex
+	ttl       time.Duration
+	responses map[string]discoveredVariablesResponse
+}
+
+// discoveredVariablesResponse is a cached response of the DiscoverVariables hook.
+type discoveredVariablesResponse struct {
+	variables []clusterv1.ClusterClassVariable
+	time      time.Time
+}
+
+func newDiscoveredVariablesCache(ttl time.Duration) *discoveredVariablesCache {
+	return &discoveredVariablesCache{
+		ttl:       ttl,
+		responses: map[string]discoveredVariablesResponse{},
+	}
+}
+
+// get returns the variables returned by an ExtensionHandler, if the cached response is not older than the TTL.
+func (c *discoveredVariablesCache) get(handlerName string, settings map[string]string) ([]clusterv1.ClusterClassVariable, bool) {
+	key := discoveredVariablesCacheKey(handlerName, settings)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	response, ok := c.responses[key]
+	if !ok || time.Since(response.time) >= c.ttl {
+		return nil, false
+	}
+	return response.variables, true
+}
+
+// update stores the variables returned by an ExtensionHandler and returns the difference with the previous response.
+// The returned bool is false if there is no previous response for the ExtensionHandler and settings.
+func (c *discoveredVariablesCache) update(handlerName string, settings map[string]string, variables []clusterv1.ClusterClassVariable) (variablesDiff, bool) {
+	key := discoveredVariablesCacheKey(handlerName, settings)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	previous, ok := c.responses[key]
+	c.responses[key] = discoveredVariablesResponse{variables: variables, time: time.Now()}
+	if !ok {
+		return variablesDiff{}, false
+	}
+	return diffVariables(previous.variables, variables), true
+}
+
+// discoveredVariablesCacheKey returns the key of the response of an ExtensionHandler called with the given settings.
+func discoveredVariablesCacheKey(handlerName string, settings map[string]string) string {
+	keys := make([]string, 0, len(settings))
+	for k := range settings {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var sb strings.Builder
+	sb.WriteString(handlerName)
+	for _, k := range keys {
+		sb.WriteString(fmt.Sprintf(",%s=%s", k, settings[k]))
+	}
+	return sb.String()
+}
+
+// variablesDiff is the difference between two responses of the DiscoverVariables hook.
+type variablesDiff struct {
+	Added   []string
+	Removed []string
+	Changed []string
+}
+
+// IsEmpty returns true if there are no differences.
+func (d variablesDiff) IsEmpty() bool {
+	return len(d.Added) == 0 && len(d.Removed) == 0 && len(d.Changed) == 0
+}
+
+// String returns a human readable representation of the difference.
+func (d variablesDiff) String() string {
+	var parts []string
+	if len(d.Added) > 0 {
+		parts = append(parts, fmt.Sprintf("added: %s", strings.Join(d.Added, ",")))
+	}
+	if len(d.Removed) > 0 {
+		parts = append(parts, fmt.Sprintf("removed: %s", strings.Join(d.Removed, ",")))
+	}
+	if len(d.Changed) > 0 {
+		parts = append(parts, fmt.Sprintf("changed: %s", strings.Join(d.Changed, ",")))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// diffVariables returns the variables added, removed and changed, i.e. with a different schema or required field,
+// between two responses of the DiscoverVariables hook.
+func diffVariables(previous, current []clusterv1.ClusterClassVariable) variablesDiff {
+	previousByName := map[string]clusterv1.ClusterClassVariable{}
+	for _, v := range previous {
+		previousByName[v.Name] = v
+	}
+
+	diff := variablesDiff{}
+	currentNames := map[string]bool{}
+	for _, v := range current {
+		currentNames[v.Name] = true
+		p, ok := previousByName[v.Name]
+		if !ok {
+			diff.Added = append(diff.Added, v.Name)
+			continue
+		}
+		if !reflect.DeepEqual(p, v) {
+			diff.Changed = append(diff.Changed, v.Name)
+		}
+	}
+	for _, v := range previous {
+		if !currentNames[v.Name] {
+			diff.Removed = append(diff.Removed, v.Name)
+		}
+	}
+
+	sort.Strings(diff.Added)
+	sort.Strings(diff.Removed)
+	sort.Strings(diff.Changed)
+	return diff
+}
+
+// reportDiscoveredVariablesChanges caches the variables returned by the DiscoverVariables hook of an ExtensionHandler,
+// and emits an event on its ExtensionConfig if they changed since the previous call.
+func (r *Reconciler) reportDiscoveredVariablesChanges(ctx context.Context, handlerName string, settings map[string]string, variables []clusterv1.ClusterClassVariable) {
+	if r.discoveredVariables == nil {
+		return
+	}
+	log := ctrl.LoggerFrom(ctx)
+
+	diff, ok := r.discoveredVariables.update(handlerName, settings, variables)
+	if !ok || diff.IsEmpty() || r.recorder == nil {
+		return
+	}
+	log.Info(fmt.Sprintf("Variables returned by DiscoverVariables extension handler %q changed: %s", handlerName, diff))
+
+	extensionConfigName, err := runtimeclient.ExtensionNameFromHandlerName(handlerName)
+	if err != nil {
+		log.Error(err, "Failed to report changes to discovered variables")
+		return
+	}
+	extensionConfig := &runtimev1.ExtensionConfig{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Name: extensionConfigName}, extensionConfig); err != nil {
+		log.Error(err, "Failed to report changes to discovered variables: failed to get ExtensionConfig", "ExtensionConfig", extensionConfigName)
+		return
+	}
+	r.recorder.Eventf(extensionConfig, corev1.EventTypeNormal, runtimev1.DiscoveredVariablesChangedEventReason,
+		"Variables returned by DiscoverVariables extension handler %q changed: %s", handlerName, diff)
+}
+
+// diffDiscoveredVariables returns the difference between the variables returned by the DiscoverVariables hook of
+// a patch and the variables discovered for the same patch in the previous reconcile, as recorded in the ClusterClass status.
+// The returned bool is false if the variables of the patch have not been discovered before with the current
+// ClusterClass spec, e.g. because the patch has just been added.
+func diffDiscoveredVariables(clusterClass *clusterv1.ClusterClass, patchName string, variables []clusterv1.ClusterClassVariable) (variablesDiff, bool) {
+	// Changes of the ClusterClass spec, e.g. to the settings of the patch, are expected to change the variables.
+	if clusterClass.Status.ObservedGeneration != clusterClass.Generation ||
+		!conditions.IsTrue(clusterClass, clusterv1.ClusterClassVariablesReconciledCondition) {
+		return variablesDiff{}, false
+	}
+
+	previous := []clusterv1.ClusterClassVariable{}
+	for _, statusVariable := range clusterClass.Status.Variables {
+		for _, definition := range statusVariable.Definitions {
+			if definition.From != patchName {
+				continue
+			}
+			previous = append(previous, clusterv1.ClusterClassVariable{
+				Name:     statusVariable.Name,
+				Required: definition.Required,
+				Schema:   definition.Schema,
+			})
+		}
+	}
+	return diffVariables(previous, variables), true
+}
@@ -0,0 +1,250 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package clusterclass
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	runtimev1 "sigs.k8s.io/cluster-api/exp/runtime/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestDiffVariables(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous []clusterv1.ClusterClassVariable
+		current  []clusterv1.ClusterClassVariable
+		want     variablesDiff
+		wantMsg  string
+	}{
+		{
+			name:     "no changes",
+			previous: []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", false)},
+			current:  []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", false)},
+			want:     variablesDiff{},
+			wantMsg:  "",
+		},
+		{
+			name: "added, removed and changed variables",
+			previous: []clusterv1.ClusterClassVariable{
+				discoveredVariable("cpu", "integer", false),
+				discoveredVariable("memory", "string", false),
+				discoveredVariable("region", "string", false),
+				discoveredVariable("zone", "string", false),
+			},
+			current: []clusterv1.ClusterClassVariable{
+				discoveredVariable("zone", "string", false),
+				discoveredVariable("cpu", "string", false),
+				discoveredVariable("region", "string", true),
+				discoveredVariable("image", "string", false),
+			},
+			want: variablesDiff{
+				Added:   []string{"image"},
+				Removed: []string{"memory"},
+				Changed: []string{"cpu", "region"},
+			},
+			wantMsg: "added: image; removed: memory; changed: cpu,region",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got := diffVariables(tt.previous, tt.current)
+			g.Expect(got).To(BeComparableTo(tt.want))
+			g.Expect(got.IsEmpty()).To(Equal(tt.wantMsg == ""))
+			g.Expect(got.String()).To(Equal(tt.wantMsg))
+		})
+	}
+}
+
+func TestDiscoveredVariablesCache(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newDiscoveredVariablesCache(time.Hour)
+	variables := []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", false)}
+	changedVariables := []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "string", false)}
+
+	// There is no cached response before the first call.
+	_, ok := c.get("discover-variables.ext", nil)
+	g.Expect(ok).To(BeFalse())
+
+	// There is no diff for the first response.
+	_, ok = c.update("discover-variables.ext", nil, variables)
+	g.Expect(ok).To(BeFalse())
+
+	// The response is cached.
+	cached, ok := c.get("discover-variables.ext", nil)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(cached).To(BeComparableTo(variables))
+
+	// Responses are diffed with the previous response.
+	diff, ok := c.update("discover-variables.ext", nil, variables)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(diff.IsEmpty()).To(BeTrue())
+
+	diff, ok = c.update("discover-variables.ext", nil, changedVariables)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(diff.Changed).To(ConsistOf("cpu"))
+
+	// Responses for different settings are cached separately.
+	_, ok = c.get("discover-variables.ext", map[string]string{"foo": "bar"})
+	g.Expect(ok).To(BeFalse())
+	_, ok = c.update("discover-variables.ext", map[string]string{"foo": "bar"}, variables)
+	g.Expect(ok).To(BeFalse())
+
+	// Stale responses are not returned, but they are still used to diff the next response.
+	c = newDiscoveredVariablesCache(0)
+	_, ok = c.update("discover-variables.ext", nil, variables)
+	g.Expect(ok).To(BeFalse())
+	_, ok = c.get("discover-variables.ext", nil)
+	g.Expect(ok).To(BeFalse())
+	diff, ok = c.update("discover-variables.ext", nil, changedVariables)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(diff.Changed).To(ConsistOf("cpu"))
+}
+
+func TestDiffDiscoveredVariables(t *testing.T) {
+	clusterClass := &clusterv1.ClusterClass{
+		ObjectMeta: metav1.ObjectMeta{Generation: 2},
+		Status: clusterv1.ClusterClassStatus{
+			ObservedGeneration: 2,
+			Conditions:         clusterv1.Conditions{*conditions.TrueCondition(clusterv1.ClusterClassVariablesReconciledCondition)},
+			Variables: []clusterv1.ClusterClassStatusVariable{
+				{
+					Name: "cpu",
+					Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+						{From: clusterv1.VariableDefinitionFromInline, Schema: discoveredVariable("cpu", "string", false).Schema},
+						{From: "patch1", Schema: discoveredVariable("cpu", "integer", false).Schema},
+					},
+				},
+				{
+					Name: "memory",
+					Definitions: []clusterv1.ClusterClassStatusVariableDefinition{
+						{From: "patch1", Schema: discoveredVariable("memory", "string", false).Schema},
+					},
+				},
+			},
+		},
+	}
+
+	tests := []struct {
+		name         string
+		clusterClass func() *clusterv1.ClusterClass
+		patchName    string
+		variables    []clusterv1.ClusterClassVariable
+		wantOK       bool
+		wantMsg      string
+	}{
+		{
+			name:         "no changes",
+			clusterClass: clusterClass.DeepCopy,
+			patchName:    "patch1",
+			variables:    []clusterv1.ClusterClassVariable{discoveredVariable("memory", "string", false), discoveredVariable("cpu", "integer", false)},
+			wantOK:       true,
+		},
+		{
+			name:         "changes are diffed with the definitions from the same patch",
+			clusterClass: clusterClass.DeepCopy,
+			patchName:    "patch1",
+			variables:    []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", true), discoveredVariable("image", "string", false)},
+			wantOK:       true,
+			wantMsg:      "added: image; removed: memory; changed: cpu",
+		},
+		{
+			name:         "variables added to a patch which did not return any variable",
+			clusterClass: clusterClass.DeepCopy,
+			patchName:    "patch2",
+			variables:    []clusterv1.ClusterClassVariable{discoveredVariable("image", "string", false)},
+			wantOK:       true,
+			wantMsg:      "added: image",
+		},
+		{
+			name: "variables are not reported after a change of the ClusterClass spec",
+			clusterClass: func() *clusterv1.ClusterClass {
+				c := clusterClass.DeepCopy()
+				c.Generation = 3
+				return c
+			},
+			patchName: "patch1",
+			variables: []clusterv1.ClusterClassVariable{discoveredVariable("image", "string", false)},
+			wantOK:    false,
+		},
+		{
+			name: "variables are not reported if the previous discovery failed",
+			clusterClass: func() *clusterv1.ClusterClass {
+				c := clusterClass.DeepCopy()
+				conditions.MarkFalse(c, clusterv1.ClusterClassVariablesReconciledCondition, clusterv1.VariableDiscoveryFailedReason, clusterv1.ConditionSeverityError, "")
+				return c
+			},
+			patchName: "patch1",
+			variables: []clusterv1.ClusterClassVariable{discoveredVariable("image", "string", false)},
+			wantOK:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			diff, ok := diffDiscoveredVariables(tt.clusterClass(), tt.patchName, tt.variables)
+			g.Expect(ok).To(Equal(tt.wantOK))
+			g.Expect(diff.String()).To(Equal(tt.wantMsg))
+		})
+	}
+}
+
+func TestReportDiscoveredVariablesChanges(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(runtimev1.AddToScheme(scheme)).To(Succeed())
+	extensionConfig := &runtimev1.ExtensionConfig{ObjectMeta: metav1.ObjectMeta{Name: "ext"}}
+
+	recorder := record.NewFakeRecorder(10)
+	r := &Reconciler{
+		Client:              fake.NewClientBuilder().WithScheme(scheme).WithObjects(extensionConfig).Build(),
+		recorder:            recorder,
+		discoveredVariables: newDiscoveredVariablesCache(discoveredVariablesCacheTTL),
+	}
+
+	r.reportDiscoveredVariablesChanges(ctx, "discover-variables.ext", nil, []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", false)})
+	r.reportDiscoveredVariablesChanges(ctx, "discover-variables.ext", nil, []clusterv1.ClusterClassVariable{discoveredVariable("cpu", "integer", false)})
+	g.Expect(recorder.Events).To(BeEmpty())
+
+	r.reportDiscoveredVariablesChanges(ctx, "discover-variables.ext", nil, []clusterv1.ClusterClassVariable{discoveredVariable("memory", "string", false)})
+	g.Expect(recorder.Events).To(Receive(Equal(`Normal DiscoveredVariablesChanged Variables returned by DiscoverVariables extension handler "discover-variables.ext" changed: added: memory; removed: cpu`)))
+}
+
+func discoveredVariable(name, schemaType string, required bool) clusterv1.ClusterClassVariable {
+	return clusterv1.ClusterClassVariable{
+		Name:     name,
+		Required: required,
+		Schema: clusterv1.VariableSchema{
+			OpenAPIV3Schema: clusterv1.JSONSchemaProps{
+				Type: schemaType,
+			},
+		},
+	}
+}
@@ -19,10 +19,13 @@ package machine
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -45,18 +48,28 @@ import (
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/api/v1beta1/index"
 	"sigs.k8s.io/cluster-api/controllers/external"
-	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/drain"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/contract"
 	clog "sigs.k8s.io/cluster-api/util/log"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/priority"
+	capirecord "sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
+// maxAttachedVolumesInMessage is the maximum number of attached volumes listed in the VolumeDetachSucceeded condition
+// message and in the WaitingForVolumeDetach event.
+const maxAttachedVolumesInMessage = 10
+
 var (
 	errNilNodeRef                 = errors.New("noderef is nil")
 	errLastControlPlaneNode       = errors.New("last control plane member")
@@ -82,9 +95,27 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// NodeDrainClientTimeout timeout of the client used for draining nodes.
 	NodeDrainClientTimeout time.Duration
 
+	// StuckInPhaseThresholds defines for each phase how long a Machine can stay in that phase
+	// before being reported as stuck; phases without a threshold are never reported as stuck.
+	StuckInPhaseThresholds map[clusterv1.MachinePhase]time.Duration
+
+	// CertificatesExpiryWarningThreshold defines how long before the expiry of its certificates a Machine
+	// is reported by the CertificatesNotExpiring condition; if zero, only expired certificates are reported.
+	CertificatesExpiryWarningThreshold time.Duration
+
+	// DeletionBlockedThreshold defines how long a Machine can be deleting before being reported
+	// by the DeletionBlocked condition; if zero, deleting Machines are never reported.
+	DeletionBlockedThreshold time.Duration
+
+	// ReconcilePriority configures the fast lane in the reconcile queue for Machines being deleted or recently created.
+	ReconcilePriority priority.Options
+
 	controller      controller.Controller
 	recorder        record.EventRecorder
 	externalTracker external.ObjectTracker
@@ -106,9 +137,10 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	c, err := ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.Machine{}).
+		For(&clusterv1.Machine{}, builder.WithPredicates(r.ReconcilePriority.HighPriority(ctrl.LoggerFrom(ctx)), predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
+		Watches(&clusterv1.Machine{}, r.ReconcilePriority.LowPriorityHandler()).
 		WithOptions(options).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(clusterToMachines),
@@ -117,18 +149,19 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 				predicates.All(ctrl.LoggerFrom(ctx),
 					predicates.Any(ctrl.LoggerFrom(ctx),
 						predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+						predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
 						predicates.ClusterControlPlaneInitialized(ctrl.LoggerFrom(ctx)),
 					),
 					predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 				),
 			)).
-		Build(r)
+		Build(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.Machine{}), options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
 	r.controller = c
-	r.recorder = mgr.GetEventRecorderFor("machine-controller")
+	r.recorder = capirecord.NewDeduplicatingRecorder(mgr.GetEventRecorderFor("machine-controller"), capirecord.DefaultDeduplicationWindow)
 	r.externalTracker = external.ObjectTracker{
 		Controller: c,
 		Cache:      mgr.GetCache(),
@@ -137,13 +170,14 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	return nil
 }
 
-func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, reterr error) {
+func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (retres ctrl.Result, reterr error) {
 	// Fetch the Machine instance
 	m := &clusterv1.Machine{}
 	if err := r.Client.Get(ctx, req.NamespacedName, m); err != nil {
 		if apierrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			deleteMachineMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 
@@ -151,6 +185,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the Machine does not belong to the shard of this manager.
+	if !r.Shard.Contains(m) {
+		return ctrl.Result{}, nil
+	}
+
 	// AddOwners adds the owners of Machine as k/v pairs to the logger.
 	// Specifically, it will add KubeadmControlPlane, MachineSet and MachineDeployment.
 	ctx, log, err := clog.AddOwners(ctx, r.Client, m)
@@ -167,8 +206,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			m.Spec.ClusterName, m.Name, m.Namespace)
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, m) {
+	// Set or remove the Paused condition, then return early if the object or Cluster is paused.
+	isPaused, _, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, m)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -180,7 +223,10 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 	}
 
 	defer func() {
+		r.reconcileTimeline(ctx, m)
 		r.reconcilePhase(ctx, m)
+		retres = util.LowestNonZeroResult(retres, r.reconcileStuckInPhase(ctx, m))
+		retres = util.LowestNonZeroResult(retres, r.reconcileDeletionBlocked(ctx, m))
 
 		// Always attempt to patch the object and status after each reconciliation.
 		// Patch ObservedGeneration only if the reconciliation completed successfully
@@ -261,6 +307,10 @@ func patchMachine(ctx context.Context, patchHelper *patch.Helper, machine *clust
 			clusterv1.DrainingSucceededCondition,
 			clusterv1.MachineHealthCheckSucceededCondition,
 			clusterv1.MachineOwnerRemediatedCondition,
+			clusterv1.MachinePhaseWithinThresholdCondition,
+			clusterv1.MachineCertificatesNotExpiringCondition,
+			clusterv1.MachineDeletionBlockedCondition,
+			clusterv1.MachineInterruptionNoticeCondition,
 		}},
 	)
 
@@ -282,7 +332,9 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 	phases := []func(context.Context, *scope) (ctrl.Result, error){
 		r.reconcileBootstrap,
 		r.reconcileInfrastructure,
+		r.reconcileRebootstrap,
 		r.reconcileNode,
+		r.reconcileInterruption,
 		r.reconcileCertificateExpiry,
 	}
 
@@ -346,9 +398,8 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 	if isDeleteNodeAllowed {
 		// pre-drain.delete lifecycle hook
 		// Return early without error, will requeue if/when the hook owner removes the annotation.
-		if annotations.HasWithPrefix(clusterv1.PreDrainDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations) {
-			conditions.MarkFalse(m, clusterv1.PreDrainDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
-			return ctrl.Result{}, nil
+		if result, blocked := r.reconcileDeletionHooks(ctx, m, clusterv1.MachineDeletionHookPhasePreDrain); blocked {
+			return result, nil
 		}
 		conditions.MarkTrue(m, clusterv1.PreDrainDeleteHookSucceededCondition)
 
@@ -374,13 +425,13 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 			if result, err := r.drainNode(ctx, cluster, m.Status.NodeRef.Name); !result.IsZero() || err != nil {
 				if err != nil {
 					conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
-					r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedDrainNode", "error draining Machine's node %q: %v", m.Status.NodeRef.Name, err)
+					r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.DrainNodeFailedEventReason, "error draining Machine's node %q: %v", m.Status.NodeRef.Name, err)
 				}
 				return result, err
 			}
 
 			conditions.MarkTrue(m, clusterv1.DrainingSucceededCondition)
-			r.recorder.Eventf(m, corev1.EventTypeNormal, "SuccessfulDrainNode", "success draining Machine's node %q", m.Status.NodeRef.Name)
+			r.recorder.Eventf(m, corev1.EventTypeNormal, clusterv1.DrainNodeSucceededEventReason, "success draining Machine's node %q", m.Status.NodeRef.Name)
 		}
 
 		// After node draining is completed, and if isNodeVolumeDetachingAllowed returns True, make sure all
@@ -393,24 +444,27 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 				conditions.MarkFalse(m, clusterv1.VolumeDetachSucceededCondition, clusterv1.WaitingForVolumeDetachReason, clusterv1.ConditionSeverityInfo, "Waiting for node volumes to be detached")
 			}
 
-			if ok, err := r.shouldWaitForNodeVolumes(ctx, cluster, m.Status.NodeRef.Name); ok || err != nil {
-				if err != nil {
-					r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedWaitForVolumeDetach", "error waiting for node volumes detaching, Machine's node %q: %v", m.Status.NodeRef.Name, err)
-					return ctrl.Result{}, err
-				}
-				log.Info("Waiting for node volumes to be detached", "Node", klog.KRef("", m.Status.NodeRef.Name))
+			attachedVolumes, err := r.getNodeAttachedVolumes(ctx, cluster, m.Status.NodeRef.Name)
+			if err != nil {
+				r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.WaitForVolumeDetachFailedEventReason, "error waiting for node volumes detaching, Machine's node %q: %v", m.Status.NodeRef.Name, err)
+				return ctrl.Result{}, err
+			}
+			if len(attachedVolumes) > 0 {
+				message := fmt.Sprintf("Waiting for node volumes to be detached: %s", summarizeAttachedVolumes(attachedVolumes))
+				conditions.MarkFalse(m, clusterv1.VolumeDetachSucceededCondition, clusterv1.WaitingForVolumeDetachReason, clusterv1.ConditionSeverityInfo, message)
+				r.recorder.Eventf(m, corev1.EventTypeNormal, clusterv1.WaitingForVolumeDetachEventReason, "%s from Machine's node %q", message, m.Status.NodeRef.Name)
+				log.Info("Waiting for node volumes to be detached", "Node", klog.KRef("", m.Status.NodeRef.Name), "attachedVolumes", attachedVolumes)
 				return ctrl.Result{}, nil
 			}
 			conditions.MarkTrue(m, clusterv1.VolumeDetachSucceededCondition)
-			r.recorder.Eventf(m, corev1.EventTypeNormal, "NodeVolumesDetached", "success waiting for node volumes detaching Machine's node %q", m.Status.NodeRef.Name)
+			r.recorder.Eventf(m, corev1.EventTypeNormal, clusterv1.NodeVolumesDetachedEventReason, "success waiting for node volumes detaching Machine's node %q", m.Status.NodeRef.Name)
 		}
 	}
 
 	// pre-term.delete lifecycle hook
 	// Return early without error, will requeue if/when the hook owner removes the annotation.
-	if annotations.HasWithPrefix(clusterv1.PreTerminateDeleteHookAnnotationPrefix, m.ObjectMeta.Annotations) {
-		conditions.MarkFalse(m, clusterv1.PreTerminateDeleteHookSucceededCondition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo, "")
-		return ctrl.Result{}, nil
+	if result, blocked := r.reconcileDeletionHooks(ctx, m, clusterv1.MachineDeletionHookPhasePreTerminate); blocked {
+		return result, nil
 	}
 	conditions.MarkTrue(m, clusterv1.PreTerminateDeleteHookSucceededCondition)
 
@@ -460,7 +514,7 @@ func (r *Reconciler) reconcileDelete(ctx context.Context, cluster *clusterv1.Clu
 		if waitErr != nil {
 			log.Error(deleteNodeErr, "Timed out deleting node", "Node", klog.KRef("", m.Status.NodeRef.Name))
 			conditions.MarkFalse(m, clusterv1.MachineNodeHealthyCondition, clusterv1.DeletionFailedReason, clusterv1.ConditionSeverityWarning, "")
-			r.recorder.Eventf(m, corev1.EventTypeWarning, "FailedDeleteNode", "error deleting Machine's node: %v", deleteNodeErr)
+			r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.DeleteNodeFailedEventReason, "error deleting Machine's node: %v", deleteNodeErr)
 
 			// If the node deletion timeout is not expired yet, requeue the Machine for reconciliation.
 			if m.Spec.NodeDeletionTimeout == nil || m.Spec.NodeDeletionTimeout.Nanoseconds() == 0 || m.DeletionTimestamp.Add(m.Spec.NodeDeletionTimeout.Duration).After(time.Now()) {
@@ -574,7 +628,11 @@ func (r *Reconciler) isDeleteNodeAllowed(ctx context.Context, cluster *clusterv1
 			// Check if the ControlPlane is externally managed (AKS, EKS, GKE, etc)
 			// and skip the following section if control plane is externally managed
 			// because there will be no control plane nodes registered
-			if util.IsExternalManagedControlPlane(controlPlane) {
+			externalManaged, err := contract.IsExternalManagedControlPlane(controlPlane)
+			if err != nil {
+				return err
+			}
+			if externalManaged {
 				return nil
 			}
 		}
@@ -629,34 +687,7 @@ func (r *Reconciler) drainNode(ctx context.Context, cluster *clusterv1.Cluster,
 		return ctrl.Result{}, errors.Wrapf(err, "unable to get node %v", nodeName)
 	}
 
-	drainer := &kubedrain.Helper{
-		Client:              kubeClient,
-		Ctx:                 ctx,
-		Force:               true,
-		IgnoreAllDaemonSets: true,
-		DeleteEmptyDirData:  true,
-		GracePeriodSeconds:  -1,
-		// If a pod is not evicted in 20 seconds, retry the eviction next time the
-		// machine gets reconciled again (to allow other machines to be reconciled).
-		Timeout: 20 * time.Second,
-		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
-			verbStr := "Deleted"
-			if usingEviction {
-				verbStr = "Evicted"
-			}
-			log.Info(fmt.Sprintf("%s pod from Node", verbStr),
-				"Pod", klog.KObj(pod))
-		},
-		Out: writer{log.Info},
-		ErrOut: writer{func(msg string, keysAndValues ...interface{}) {
-			log.Error(nil, msg, keysAndValues...)
-		}},
-	}
-
-	if noderefutil.IsNodeUnreachable(node) {
-		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
-		drainer.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
-	}
+	drainer := drain.NewHelper(ctx, kubeClient, node)
 
 	if err := kubedrain.RunCordonOrUncordon(drainer, node, true); err != nil {
 		// Machine will be re-reconciled after a cordon failure.
@@ -674,29 +705,69 @@ func (r *Reconciler) drainNode(ctx context.Context, cluster *clusterv1.Cluster,
 	return ctrl.Result{}, nil
 }
 
-// shouldWaitForNodeVolumes returns true if node status still have volumes attached
+// getNodeAttachedVolumes returns the volumes still attached to the node; it returns no volumes if the node does not exist anymore.
 // pod deletion and volume detach happen asynchronously, so pod could be deleted before volume detached from the node
 // this could cause issue for some storage provisioner, for example, vsphere-volume this is problematic
 // because if the node is deleted before detach success, then the underline VMDK will be deleted together with the Machine
 // so after node draining we need to check if all volumes are detached before deleting the node.
-func (r *Reconciler) shouldWaitForNodeVolumes(ctx context.Context, cluster *clusterv1.Cluster, nodeName string) (bool, error) {
+// The volumes are identified by the VolumeAttachments on the node and the PersistentVolumes they refer to, falling back
+// to the names in the node status for volumes not attached through a VolumeAttachment.
+func (r *Reconciler) getNodeAttachedVolumes(ctx context.Context, cluster *clusterv1.Cluster, nodeName string) ([]string, error) {
 	log := ctrl.LoggerFrom(ctx, "Node", klog.KRef("", nodeName))
 
 	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
 	if err != nil {
-		return true, err
+		return nil, err
 	}
 
 	node := &corev1.Node{}
 	if err := remoteClient.Get(ctx, types.NamespacedName{Name: nodeName}, node); err != nil {
 		if apierrors.IsNotFound(err) {
 			log.Error(err, "Could not find node from noderef, it may have already been deleted")
-			return false, nil
+			return nil, nil
 		}
-		return true, err
+		return nil, err
+	}
+
+	if len(node.Status.VolumesAttached) == 0 {
+		return nil, nil
+	}
+
+	// NOTE: VolumeAttachments are not cached by the ClusterCacheTracker (see main.go), so this lists them from the
+	// API server of the workload cluster; they have to be filtered by node here, because the API server does not
+	// support selecting VolumeAttachments by spec.nodeName.
+	volumeAttachments := &storagev1.VolumeAttachmentList{}
+	if err := remoteClient.List(ctx, volumeAttachments); err != nil {
+		return nil, errors.Wrapf(err, "failed to list VolumeAttachments")
 	}
 
-	return len(node.Status.VolumesAttached) != 0, nil
+	attachedVolumes := []string{}
+	for _, volumeAttachment := range volumeAttachments.Items {
+		if volumeAttachment.Spec.NodeName != nodeName || !volumeAttachment.Status.Attached {
+			continue
+		}
+		if pvName := volumeAttachment.Spec.Source.PersistentVolumeName; pvName != nil {
+			attachedVolumes = append(attachedVolumes, fmt.Sprintf("VolumeAttachment %s (PersistentVolume %s)", volumeAttachment.Name, *pvName))
+			continue
+		}
+		attachedVolumes = append(attachedVolumes, fmt.Sprintf("VolumeAttachment %s", volumeAttachment.Name))
+	}
+	if len(attachedVolumes) == 0 {
+		for _, volume := range node.Status.VolumesAttached {
+			attachedVolumes = append(attachedVolumes, string(volume.Name))
+		}
+	}
+	sort.Strings(attachedVolumes)
+	return attachedVolumes, nil
+}
+
+// summarizeAttachedVolumes returns a comma separated list of the attached volumes, truncated to
+// maxAttachedVolumesInMessage volumes to keep condition messages and events short.
+func summarizeAttachedVolumes(attachedVolumes []string) string {
+	if len(attachedVolumes) <= maxAttachedVolumesInMessage {
+		return strings.Join(attachedVolumes, ", ")
+	}
+	return fmt.Sprintf("%s and %d more", strings.Join(attachedVolumes[:maxAttachedVolumesInMessage], ", "), len(attachedVolumes)-maxAttachedVolumesInMessage)
 }
 
 func (r *Reconciler) deleteNode(ctx context.Context, cluster *clusterv1.Cluster, name string) error {
@@ -891,14 +962,3 @@ func (r *Reconciler) nodeToMachine(ctx context.Context, o client.Object) []recon
 
 	return nil
 }
-
-// writer implements io.Writer interface as a pass-through for klog.
-type writer struct {
-	logFunc func(msg string, keysAndValues ...interface{})
-}
-
-// Write passes string(p) into writer's logFunc and always returns len(p).
-func (w writer) Write(p []byte) (n int, err error) {
-	w.logFunc(string(p))
-	return len(p), nil
-}
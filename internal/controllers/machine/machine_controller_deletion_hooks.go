@@ -0,0 +1,146 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// deletionHookPhases defines, for each phase of the Machine deletion, the annotation prefixes used for
+// lifecycle hooks and their timeouts, and the condition reporting the hooks status.
+var deletionHookPhases = map[clusterv1.MachineDeletionHookPhase]struct {
+	hookPrefix    string
+	timeoutPrefix string
+	condition     clusterv1.ConditionType
+}{
+	clusterv1.MachineDeletionHookPhasePreDrain: {
+		hookPrefix:    clusterv1.PreDrainDeleteHookAnnotationPrefix,
+		timeoutPrefix: clusterv1.PreDrainDeleteHookTimeoutAnnotationPrefix,
+		condition:     clusterv1.PreDrainDeleteHookSucceededCondition,
+	},
+	clusterv1.MachineDeletionHookPhasePreTerminate: {
+		hookPrefix:    clusterv1.PreTerminateDeleteHookAnnotationPrefix,
+		timeoutPrefix: clusterv1.PreTerminateDeleteHookTimeoutAnnotationPrefix,
+		condition:     clusterv1.PreTerminateDeleteHookSucceededCondition,
+	},
+}
+
+// reconcileDeletionHooks reports the lifecycle hooks of the given phase in the Machine status and in the
+// corresponding condition, and returns true if the hooks are blocking the Machine deletion.
+// NOTE: Hooks exceeding their timeout are still blocking the Machine deletion, but they are surfaced
+// as a failure in the condition; if there are hooks with a timeout not yet expired, the returned result
+// requeues the Machine when the first timeout expires.
+func (r *Reconciler) reconcileDeletionHooks(ctx context.Context, m *clusterv1.Machine, phase clusterv1.MachineDeletionHookPhase) (ctrl.Result, bool) {
+	log := ctrl.LoggerFrom(ctx)
+	hookPhase := deletionHookPhases[phase]
+
+	// Preserve the time the Machine started to wait for hooks already reported in status.
+	waitingSince := map[string]metav1.Time{}
+	deletionHooks := []clusterv1.MachineDeletionHook{}
+	for _, hook := range m.Status.DeletionHooks {
+		if hook.Phase == phase {
+			waitingSince[hook.Name] = hook.WaitingSince
+			continue
+		}
+		deletionHooks = append(deletionHooks, hook)
+	}
+
+	now := metav1.Now()
+	hooks := []clusterv1.MachineDeletionHook{}
+	for key, value := range m.GetAnnotations() {
+		if !strings.HasPrefix(key, hookPhase.hookPrefix) {
+			continue
+		}
+		name := strings.TrimPrefix(strings.TrimPrefix(key, hookPhase.hookPrefix), "/")
+		hook := clusterv1.MachineDeletionHook{
+			Phase:        phase,
+			Name:         name,
+			Owner:        value,
+			WaitingSince: now,
+		}
+		if since, ok := waitingSince[name]; ok {
+			hook.WaitingSince = since
+		}
+		if timeout, ok := m.GetAnnotations()[fmt.Sprintf("%s/%s", hookPhase.timeoutPrefix, name)]; ok {
+			d, err := time.ParseDuration(timeout)
+			if err != nil || d <= 0 {
+				log.Info("Ignoring invalid timeout for deletion hook", "hook", key, "timeout", timeout)
+			} else {
+				hook.Timeout = &metav1.Duration{Duration: d}
+			}
+		}
+		hooks = append(hooks, hook)
+	}
+	sort.Slice(hooks, func(i, j int) bool { return hooks[i].Name < hooks[j].Name })
+
+	deletionHooks = append(deletionHooks, hooks...)
+	if len(deletionHooks) == 0 {
+		deletionHooks = nil
+	}
+	m.Status.DeletionHooks = deletionHooks
+
+	if len(hooks) == 0 {
+		return ctrl.Result{}, false
+	}
+
+	var requeueAfter time.Duration
+	timedOut := []string{}
+	waiting := []string{}
+	for _, hook := range hooks {
+		description := hook.Name
+		if hook.Owner != "" {
+			description = fmt.Sprintf("%s (owner: %s)", hook.Name, hook.Owner)
+		}
+		if hook.Timeout == nil {
+			waiting = append(waiting, description)
+			continue
+		}
+		remaining := hook.Timeout.Duration - now.Sub(hook.WaitingSince.Time)
+		if remaining <= 0 {
+			timedOut = append(timedOut, description)
+			continue
+		}
+		waiting = append(waiting, description)
+		if requeueAfter == 0 || remaining < requeueAfter {
+			requeueAfter = remaining
+		}
+	}
+
+	if len(timedOut) > 0 {
+		message := fmt.Sprintf("Timed out waiting for hooks: %s", strings.Join(timedOut, ", "))
+		if conditions.GetReason(m, hookPhase.condition) != clusterv1.ExternalHookTimedOutReason {
+			r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.ExternalHookTimedOutEventReason, "%s deletion hooks: %s", phase, message)
+		}
+		conditions.MarkFalse(m, hookPhase.condition, clusterv1.ExternalHookTimedOutReason, clusterv1.ConditionSeverityError, message)
+	} else {
+		conditions.MarkFalse(m, hookPhase.condition, clusterv1.WaitingExternalHookReason, clusterv1.ConditionSeverityInfo,
+			"Waiting for hooks: %s", strings.Join(waiting, ", "))
+	}
+
+	return ctrl.Result{RequeueAfter: requeueAfter}, true
+}
@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestReconcileDeletionHooks(t *testing.T) {
+	hoursAgo := metav1.NewTime(time.Now().Add(-2 * time.Hour))
+
+	testCases := []struct {
+		name              string
+		annotations       map[string]string
+		deletionHooks     []clusterv1.MachineDeletionHook
+		phase             clusterv1.MachineDeletionHookPhase
+		wantBlocked       bool
+		wantRequeue       bool
+		wantReason        string
+		wantDeletionHooks int
+	}{
+		{
+			name:  "no hooks",
+			phase: clusterv1.MachineDeletionHookPhasePreDrain,
+		},
+		{
+			name: "hooks of another phase are ignored but preserved in status",
+			annotations: map[string]string{
+				clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/hook": "owner",
+			},
+			deletionHooks: []clusterv1.MachineDeletionHook{
+				{Phase: clusterv1.MachineDeletionHookPhasePreTerminate, Name: "hook", Owner: "owner", WaitingSince: hoursAgo},
+			},
+			phase:             clusterv1.MachineDeletionHookPhasePreDrain,
+			wantDeletionHooks: 1,
+		},
+		{
+			name: "hook without timeout",
+			annotations: map[string]string{
+				clusterv1.PreDrainDeleteHookAnnotationPrefix + "/hook": "owner",
+			},
+			phase:             clusterv1.MachineDeletionHookPhasePreDrain,
+			wantBlocked:       true,
+			wantReason:        clusterv1.WaitingExternalHookReason,
+			wantDeletionHooks: 1,
+		},
+		{
+			name: "hook with timeout not expired",
+			annotations: map[string]string{
+				clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/hook":        "owner",
+				clusterv1.PreTerminateDeleteHookTimeoutAnnotationPrefix + "/hook": "30m",
+			},
+			phase:             clusterv1.MachineDeletionHookPhasePreTerminate,
+			wantBlocked:       true,
+			wantRequeue:       true,
+			wantReason:        clusterv1.WaitingExternalHookReason,
+			wantDeletionHooks: 1,
+		},
+		{
+			name: "hook with timeout expired",
+			annotations: map[string]string{
+				clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/hook":        "owner",
+				clusterv1.PreTerminateDeleteHookTimeoutAnnotationPrefix + "/hook": "1h",
+			},
+			deletionHooks: []clusterv1.MachineDeletionHook{
+				{Phase: clusterv1.MachineDeletionHookPhasePreTerminate, Name: "hook", Owner: "owner", WaitingSince: hoursAgo},
+			},
+			phase:             clusterv1.MachineDeletionHookPhasePreTerminate,
+			wantBlocked:       true,
+			wantReason:        clusterv1.ExternalHookTimedOutReason,
+			wantDeletionHooks: 1,
+		},
+		{
+			name: "removed hooks are dropped from status",
+			deletionHooks: []clusterv1.MachineDeletionHook{
+				{Phase: clusterv1.MachineDeletionHookPhasePreDrain, Name: "hook", Owner: "owner", WaitingSince: hoursAgo},
+			},
+			phase: clusterv1.MachineDeletionHookPhasePreDrain,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{Annotations: tc.annotations},
+				Status:     clusterv1.MachineStatus{DeletionHooks: tc.deletionHooks},
+			}
+			r := &Reconciler{recorder: record.NewFakeRecorder(10)}
+
+			res, blocked := r.reconcileDeletionHooks(ctx, m, tc.phase)
+			g.Expect(blocked).To(Equal(tc.wantBlocked))
+			g.Expect(res.RequeueAfter > 0).To(Equal(tc.wantRequeue))
+			g.Expect(m.Status.DeletionHooks).To(HaveLen(tc.wantDeletionHooks))
+
+			condition := deletionHookPhases[tc.phase].condition
+			if !tc.wantBlocked {
+				g.Expect(conditions.Has(m, condition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.IsFalse(m, condition)).To(BeTrue())
+			g.Expect(conditions.GetReason(m, condition)).To(Equal(tc.wantReason))
+			g.Expect(conditions.GetMessage(m, condition)).To(ContainSubstring("hook (owner: owner)"))
+			for _, hook := range m.Status.DeletionHooks {
+				if hook.Phase == tc.phase && len(tc.deletionHooks) > 0 {
+					// The time the Machine started waiting for the hook is preserved.
+					g.Expect(hook.WaitingSince).To(Equal(hoursAgo))
+				}
+			}
+		})
+	}
+}
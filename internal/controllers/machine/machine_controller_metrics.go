@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machine
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(machinePhaseAge, machineCertificatesExpiry, machineDeletionBlocked)
+}
+
+// machinePhaseAge reports how long each Machine has been in its current phase.
+// NOTE: the value is refreshed every time the Machine is reconciled.
+var machinePhaseAge = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capi_machine_phase_age_seconds",
+	Help: "Time in seconds since the Machine transitioned to its current phase",
+}, []string{"namespace", "name", "cluster_name", "phase"})
+
+// machineCertificatesExpiry reports the expiry date of the certificates of each Machine, if known.
+// NOTE: the value is refreshed every time the Machine is reconciled.
+var machineCertificatesExpiry = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capi_machine_certificates_expiry_timestamp_seconds",
+	Help: "Expiry date of the Machine certificates, in seconds since the Unix epoch",
+}, []string{"namespace", "name", "cluster_name"})
+
+// machineDeletionBlocked reports how long each Machine deleting for longer than the deletion blocked threshold
+// has been deleting.
+// NOTE: the value is refreshed every time the Machine is reconciled.
+var machineDeletionBlocked = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capi_machine_deletion_blocked_seconds",
+	Help: "Time in seconds since the deletion of a Machine deleting for longer than the deletion blocked threshold",
+}, []string{"namespace", "name", "cluster_name"})
+
+func deleteMachineMetrics(namespace, name string) {
+	machinePhaseAge.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	machineCertificatesExpiry.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+	machineDeletionBlocked.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+}
@@ -80,7 +80,7 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 			return ctrl.Result{}, nil
 		}
 		log.Error(err, "Failed to retrieve Node by ProviderID")
-		r.recorder.Event(machine, corev1.EventTypeWarning, "Failed to retrieve Node by ProviderID", err.Error())
+		r.recorder.Event(machine, corev1.EventTypeWarning, clusterv1.GetNodeFailedEventReason, fmt.Sprintf("Failed to retrieve Node by ProviderID: %v", err))
 		return ctrl.Result{}, err
 	}
 
@@ -93,7 +93,13 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 			UID:        node.UID,
 		}
 		log.Info("Infrastructure provider reporting spec.providerID, Kubernetes node is now available", machine.Spec.InfrastructureRef.Kind, klog.KRef(machine.Spec.InfrastructureRef.Namespace, machine.Spec.InfrastructureRef.Name), "providerID", *machine.Spec.ProviderID, "node", klog.KRef("", machine.Status.NodeRef.Name))
-		r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulSetNodeRef", machine.Status.NodeRef.Name)
+		r.recorder.Event(machine, corev1.EventTypeNormal, clusterv1.SetNodeRefSucceededEventReason, machine.Status.NodeRef.Name)
+	}
+
+	// Record the time when the Node reported Ready for the first time.
+	if machine.Status.Timeline != nil && machine.Status.Timeline.NodeReadyTime == nil && util.IsNodeReady(node) {
+		now := metav1.Now()
+		machine.Status.Timeline.NodeReadyTime = &now
 	}
 
 	// Set the NodeSystemInfo.
@@ -132,15 +138,15 @@ func (r *Reconciler) reconcileNode(ctx context.Context, s *scope) (ctrl.Result,
 
 	_, nodeHadInterruptibleLabel := node.Labels[clusterv1.InterruptibleLabel]
 
-	// Reconcile node taints
-	if err := r.patchNode(ctx, remoteClient, node, nodeLabels, nodeAnnotations); err != nil {
+	// Reconcile node labels, annotations and taints.
+	if err := r.patchNode(ctx, remoteClient, node, nodeLabels, nodeAnnotations, machine.Spec.Taints); err != nil {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to reconcile Node %s", klog.KObj(node))
 	}
 	if !nodeHadInterruptibleLabel && interruptible {
 		// If the interruptible label is added to the node then record the event.
 		// Nb. Only record the event if the node previously did not have the label to avoid recording
 		// the event during every reconcile.
-		r.recorder.Event(machine, corev1.EventTypeNormal, "SuccessfulSetInterruptibleNodeLabel", node.Name)
+		r.recorder.Event(machine, corev1.EventTypeNormal, clusterv1.SetInterruptibleNodeLabelSucceededEventReason, node.Name)
 	}
 
 	// Do the remaining node health checks, then set the node health to true if all checks pass.
@@ -220,29 +226,13 @@ func summarizeNodeConditions(node *corev1.Node) (corev1.ConditionStatus, string)
 }
 
 func (r *Reconciler) getNode(ctx context.Context, c client.Reader, providerID string) (*corev1.Node, error) {
+	// NOTE: Nodes are read from the cache of the ClusterCacheTracker using the NodeProviderIDIndex, so finding
+	// the Node of a Machine does not require listing the Nodes of the workload cluster.
 	nodeList := corev1.NodeList{}
 	if err := c.List(ctx, &nodeList, client.MatchingFields{index.NodeProviderIDField: providerID}); err != nil {
 		return nil, err
 	}
 	if len(nodeList.Items) == 0 {
-		// If for whatever reason the index isn't registered or available, we fallback to loop over the whole list.
-		nl := corev1.NodeList{}
-		for {
-			if err := c.List(ctx, &nl, client.Continue(nl.Continue)); err != nil {
-				return nil, err
-			}
-
-			for _, node := range nl.Items {
-				if providerID == node.Spec.ProviderID {
-					return &node, nil
-				}
-			}
-
-			if nl.Continue == "" {
-				break
-			}
-		}
-
 		return nil, ErrNodeNotFound
 	}
 
@@ -255,7 +245,7 @@ func (r *Reconciler) getNode(ctx context.Context, c client.Reader, providerID st
 
 // PatchNode is required to workaround an issue on Node.Status.Address which is incorrectly annotated as patchStrategy=merge
 // and this causes SSA patch to fail in case there are two addresses with the same key https://github.com/kubernetes-sigs/cluster-api/issues/8417
-func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client, node *corev1.Node, newLabels, newAnnotations map[string]string) error {
+func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client, node *corev1.Node, newLabels, newAnnotations map[string]string, newTaints []corev1.Taint) error {
 	newNode := node.DeepCopy()
 
 	// Adds the annotations CAPI sets on the node.
@@ -289,8 +279,39 @@ func (r *Reconciler) patchNode(ctx context.Context, remoteClient client.Client,
 	}
 	annotations.AddAnnotations(newNode, map[string]string{clusterv1.LabelsFromMachineAnnotation: strings.Join(labelsFromCurrentReconcile, ",")})
 
+	// Adds the taints from the Machine.
+	// NOTE: in order to handle deletion we are tracking the taints set from the Machine in an annotation,
+	// using the key:effect format. At the next reconcile we are going to use this for deleting taints previously
+	// set by the Machine, but not present anymore. Taints not set from machines should be always preserved.
+	hasTaintChanges := false
+	taintsFromPreviousReconcile := strings.Split(newNode.Annotations[clusterv1.TaintsFromMachineAnnotation], ",")
+	if len(taintsFromPreviousReconcile) == 1 && taintsFromPreviousReconcile[0] == "" {
+		taintsFromPreviousReconcile = []string{}
+	}
+	taintsFromCurrentReconcile := []string{}
+	for _, taint := range newTaints {
+		if taints.EnsureNodeTaint(newNode, taint) {
+			hasTaintChanges = true
+		}
+		taintsFromCurrentReconcile = append(taintsFromCurrentReconcile, fmt.Sprintf("%s:%s", taint.Key, taint.Effect))
+	}
+	for _, t := range taintsFromPreviousReconcile {
+		key, effect, _ := strings.Cut(t, ":")
+		drop := corev1.Taint{Key: key, Effect: corev1.TaintEffect(effect)}
+		if !taints.HasTaint(newTaints, drop) && taints.RemoveNodeTaint(newNode, drop) {
+			hasTaintChanges = true
+		}
+	}
+	if len(taintsFromCurrentReconcile) > 0 || newNode.Annotations[clusterv1.TaintsFromMachineAnnotation] != "" {
+		if annotations.AddAnnotations(newNode, map[string]string{clusterv1.TaintsFromMachineAnnotation: strings.Join(taintsFromCurrentReconcile, ",")}) {
+			hasAnnotationChanges = true
+		}
+	}
+
 	// Drop the NodeUninitializedTaint taint on the node given that we are reconciling labels.
-	hasTaintChanges := taints.RemoveNodeTaint(newNode, clusterv1.NodeUninitializedTaint)
+	if taints.RemoveNodeTaint(newNode, clusterv1.NodeUninitializedTaint) {
+		hasTaintChanges = true
+	}
 
 	if !hasAnnotationChanges && !hasLabelChanges && !hasTaintChanges {
 		return nil
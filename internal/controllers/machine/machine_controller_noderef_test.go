@@ -520,6 +520,7 @@ func TestPatchNode(t *testing.T) {
 		oldNode             *corev1.Node
 		newLabels           map[string]string
 		newAnnotations      map[string]string
+		newTaints           []corev1.Taint
 		expectedLabels      map[string]string
 		expectedAnnotations map[string]string
 		expectedTaints      []corev1.Taint
@@ -739,6 +740,59 @@ func TestPatchNode(t *testing.T) {
 				{Key: "node.kubernetes.io/not-ready", Effect: "NoSchedule"}, // Added by the API server
 			},
 		},
+		{
+			name: "Add taints from machines must preserve existing taints",
+			oldNode: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("node-%s", util.RandomString(6)),
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "not-managed-by-capi", Effect: corev1.TaintEffectNoSchedule},
+					},
+				},
+			},
+			newTaints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectedAnnotations: map[string]string{
+				clusterv1.LabelsFromMachineAnnotation: "",
+				clusterv1.TaintsFromMachineAnnotation: "dedicated:NoSchedule",
+			},
+			expectedTaints: []corev1.Taint{
+				{Key: "not-managed-by-capi", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.kubernetes.io/not-ready", Effect: "NoSchedule"}, // Added by the API server
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			},
+		},
+		{
+			name: "Change and delete taints previously set from machines",
+			oldNode: &corev1.Node{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: fmt.Sprintf("node-%s", util.RandomString(6)),
+					Annotations: map[string]string{
+						clusterv1.TaintsFromMachineAnnotation: "dedicated:NoSchedule,to-be-deleted:NoExecute",
+					},
+				},
+				Spec: corev1.NodeSpec{
+					Taints: []corev1.Taint{
+						{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+						{Key: "to-be-deleted", Effect: corev1.TaintEffectNoExecute},
+					},
+				},
+			},
+			newTaints: []corev1.Taint{
+				{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectedAnnotations: map[string]string{
+				clusterv1.LabelsFromMachineAnnotation: "",
+				clusterv1.TaintsFromMachineAnnotation: "dedicated:NoSchedule",
+			},
+			expectedTaints: []corev1.Taint{
+				{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "node.kubernetes.io/not-ready", Effect: "NoSchedule"}, // Added by the API server
+			},
+		},
 	}
 
 	r := Reconciler{
@@ -755,7 +809,7 @@ func TestPatchNode(t *testing.T) {
 				_ = env.Cleanup(ctx, oldNode)
 			})
 
-			err := r.patchNode(ctx, env, oldNode, tc.newLabels, tc.newAnnotations)
+			err := r.patchNode(ctx, env, oldNode, tc.newLabels, tc.newAnnotations, tc.newTaints)
 			g.Expect(err).ToNot(HaveOccurred())
 
 			g.Eventually(func(g Gomega) {
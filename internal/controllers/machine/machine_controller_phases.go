@@ -19,6 +19,8 @@ package machine
 import (
 	"context"
 	"fmt"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -27,9 +29,11 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/klog/v2"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 
@@ -37,6 +41,7 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	capierrors "sigs.k8s.io/cluster-api/errors"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/addresses"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
@@ -47,6 +52,10 @@ var (
 	externalReadyWait = 30 * time.Second
 )
 
+// deletionBlockedRequeueAfter is the interval used to refresh the deletion blocked metric of a Machine
+// while its deletion is blocked.
+const deletionBlockedRequeueAfter = 1 * time.Minute
+
 func (r *Reconciler) reconcilePhase(_ context.Context, m *clusterv1.Machine) {
 	originalPhase := m.Status.Phase
 
@@ -84,9 +93,120 @@ func (r *Reconciler) reconcilePhase(_ context.Context, m *clusterv1.Machine) {
 	if m.Status.Phase != originalPhase {
 		now := metav1.Now()
 		m.Status.LastUpdated = &now
+		machinePhaseAge.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName, originalPhase)
+	}
+
+	if m.Status.LastUpdated != nil {
+		machinePhaseAge.WithLabelValues(m.Namespace, m.Name, m.Spec.ClusterName, m.Status.Phase).Set(time.Since(m.Status.LastUpdated.Time).Seconds())
+	}
+}
+
+// reconcileTimeline records the time of the key transitions of the Machine during provisioning.
+// NOTE: The time when the Node reports Ready is recorded in reconcileNode.
+func (r *Reconciler) reconcileTimeline(_ context.Context, m *clusterv1.Machine) {
+	if !m.DeletionTimestamp.IsZero() {
+		return
+	}
+
+	// Start recording the timeline only for Machines not yet provisioned, so Machines provisioned before
+	// the timeline has been introduced do not report misleading timestamps.
+	if m.Status.Timeline == nil {
+		if m.Status.Phase != "" && m.Status.GetTypedPhase() != clusterv1.MachinePhasePending {
+			return
+		}
+		m.Status.Timeline = &clusterv1.MachineTimeline{}
+	}
+
+	now := metav1.Now()
+	if m.Status.BootstrapReady && m.Status.Timeline.BootstrapReadyTime == nil {
+		m.Status.Timeline.BootstrapReadyTime = &now
+	}
+	if m.Status.InfrastructureReady && m.Status.Timeline.InfrastructureReadyTime == nil {
+		m.Status.Timeline.InfrastructureReadyTime = &now
+	}
+	if m.Status.NodeRef != nil && m.Status.Timeline.NodeJoinedTime == nil {
+		m.Status.Timeline.NodeJoinedTime = &now
 	}
 }
 
+// reconcileStuckInPhase sets the PhaseWithinThreshold condition according to the time spent by the Machine
+// in its current phase and the threshold configured for that phase.
+// If the Machine is not stuck yet, it returns a result requeueing the Machine when the threshold will be exceeded.
+func (r *Reconciler) reconcileStuckInPhase(_ context.Context, m *clusterv1.Machine) ctrl.Result {
+	if len(r.StuckInPhaseThresholds) == 0 {
+		conditions.Delete(m, clusterv1.MachinePhaseWithinThresholdCondition)
+		return ctrl.Result{}
+	}
+
+	phase := m.Status.GetTypedPhase()
+	threshold := r.StuckInPhaseThresholds[phase]
+	if threshold <= 0 || m.Status.LastUpdated == nil {
+		conditions.MarkTrue(m, clusterv1.MachinePhaseWithinThresholdCondition)
+		return ctrl.Result{}
+	}
+
+	age := time.Since(m.Status.LastUpdated.Time)
+	if age >= threshold {
+		conditions.MarkFalse(m, clusterv1.MachinePhaseWithinThresholdCondition, clusterv1.MachineStuckInPhaseReason, clusterv1.ConditionSeverityWarning,
+			"Machine has been in phase %s for more than %s", phase, threshold)
+		return ctrl.Result{}
+	}
+
+	conditions.MarkTrue(m, clusterv1.MachinePhaseWithinThresholdCondition)
+	return ctrl.Result{RequeueAfter: threshold - age}
+}
+
+// reconcileDeletionBlocked sets the DeletionBlocked condition and the deletion blocked metric if the Machine
+// has been deleting for longer than the deletion blocked threshold, naming the finalizers and the deletion
+// lifecycle hooks blocking the deletion.
+// If the threshold is not exceeded yet, it returns a result requeueing the Machine when it will be exceeded,
+// otherwise it returns a result requeueing the Machine periodically, so the metric is refreshed.
+func (r *Reconciler) reconcileDeletionBlocked(_ context.Context, m *clusterv1.Machine) ctrl.Result {
+	if r.DeletionBlockedThreshold <= 0 || m.DeletionTimestamp.IsZero() {
+		conditions.Delete(m, clusterv1.MachineDeletionBlockedCondition)
+		machineDeletionBlocked.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName)
+		return ctrl.Result{}
+	}
+
+	age := time.Since(m.DeletionTimestamp.Time)
+	if age < r.DeletionBlockedThreshold {
+		conditions.Delete(m, clusterv1.MachineDeletionBlockedCondition)
+		machineDeletionBlocked.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName)
+		return ctrl.Result{RequeueAfter: r.DeletionBlockedThreshold - age}
+	}
+
+	machineDeletionBlocked.WithLabelValues(m.Namespace, m.Name, m.Spec.ClusterName).Set(age.Seconds())
+
+	hooks := []string{}
+	for key := range m.GetAnnotations() {
+		if strings.HasPrefix(key, clusterv1.PreDrainDeleteHookAnnotationPrefix) || strings.HasPrefix(key, clusterv1.PreTerminateDeleteHookAnnotationPrefix) {
+			hooks = append(hooks, key)
+		}
+	}
+	sort.Strings(hooks)
+	finalizers := append([]string{}, m.GetFinalizers()...)
+	sort.Strings(finalizers)
+
+	blockedBy := []string{}
+	if len(finalizers) > 0 {
+		blockedBy = append(blockedBy, fmt.Sprintf("finalizers %s", strings.Join(finalizers, ", ")))
+	}
+	if len(hooks) > 0 {
+		blockedBy = append(blockedBy, fmt.Sprintf("deletion hooks %s", strings.Join(hooks, ", ")))
+	}
+	message := fmt.Sprintf("Machine has been deleting for more than %s", r.DeletionBlockedThreshold)
+	if len(blockedBy) > 0 {
+		message = fmt.Sprintf("%s, blocked by %s", message, strings.Join(blockedBy, "; "))
+	}
+	conditions.Set(m, &clusterv1.Condition{
+		Type:    clusterv1.MachineDeletionBlockedCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  clusterv1.MachineDeletionThresholdExceededReason,
+		Message: message,
+	})
+	return ctrl.Result{RequeueAfter: deletionBlockedRequeueAfter}
+}
+
 // reconcileExternal handles generic unstructured objects referenced by a Machine.
 func (r *Reconciler) reconcileExternal(ctx context.Context, cluster *clusterv1.Cluster, m *clusterv1.Machine, ref *corev1.ObjectReference) (external.ReconcileOutput, error) {
 	log := ctrl.LoggerFrom(ctx)
@@ -306,6 +426,14 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 	if err != nil && err != util.ErrUnstructuredFieldNotFound {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve addresses from infrastructure provider for Machine %q in namespace %q", m.Name, m.Namespace)
 	}
+	// Drop the duplicated addresses reported by the infrastructure provider, and report the invalid ones, if any.
+	// NOTE: Addresses are validated here and not in the Machine webhook, given that they are set by this controller
+	// on the status subresource.
+	m.Status.Addresses = addresses.Normalize(m.Status.Addresses)
+	if errs := addresses.Validate(m.Status.Addresses, field.NewPath("status", "addresses")); len(errs) > 0 {
+		log.Info("Invalid addresses reported by the infrastructure provider", infraConfig.GetKind(), klog.KObj(infraConfig), "err", errs.ToAggregate().Error())
+		r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.InvalidAddressesEventReason, "Invalid addresses reported by %s %s: %v", infraConfig.GetKind(), klog.KObj(infraConfig), errs.ToAggregate())
+	}
 
 	// Get and set the failure domain from the infrastructure provider.
 	var failureDomain string
@@ -322,6 +450,59 @@ func (r *Reconciler) reconcileInfrastructure(ctx context.Context, s *scope) (ctr
 	return ctrl.Result{}, nil
 }
 
+// reconcileRebootstrap propagates the RebootstrapAnnotation of a provisioned Machine to the bootstrap config and,
+// once the bootstrap provider has acknowledged the re-bootstrap request, to the InfraMachine.
+// NOTE: If the bootstrap data secret is provided by the user, the annotation is propagated to the InfraMachine immediately.
+func (r *Reconciler) reconcileRebootstrap(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	value, ok := m.GetAnnotations()[clusterv1.RebootstrapAnnotation]
+	if !ok || !m.Status.BootstrapReady || !m.Status.InfrastructureReady || s.infraMachine == nil {
+		return ctrl.Result{}, nil
+	}
+
+	if m.Spec.Bootstrap.ConfigRef != nil {
+		// If the bootstrap config is paused or it does not exist, wait.
+		if s.bootstrapConfig == nil {
+			return ctrl.Result{}, nil
+		}
+		if err := r.setRebootstrapAnnotation(ctx, s.bootstrapConfig, value); err != nil {
+			return ctrl.Result{}, err
+		}
+		if s.bootstrapConfig.GetAnnotations()[clusterv1.RebootstrapAcknowledgedAnnotation] != value {
+			log.V(4).Info("Waiting for bootstrap provider to regenerate the bootstrap data for the re-bootstrap", s.bootstrapConfig.GetKind(), klog.KObj(s.bootstrapConfig), "rebootstrap", value)
+			return ctrl.Result{}, nil
+		}
+	}
+
+	if err := r.setRebootstrapAnnotation(ctx, s.infraMachine, value); err != nil {
+		return ctrl.Result{}, err
+	}
+	if s.infraMachine.GetAnnotations()[clusterv1.RebootstrapAcknowledgedAnnotation] != value {
+		log.V(4).Info("Waiting for infrastructure provider to re-bootstrap the machine", s.infraMachine.GetKind(), klog.KObj(s.infraMachine), "rebootstrap", value)
+	}
+	return ctrl.Result{}, nil
+}
+
+// setRebootstrapAnnotation sets the RebootstrapAnnotation with the given value on the bootstrap config or on the InfraMachine.
+func (r *Reconciler) setRebootstrapAnnotation(ctx context.Context, obj *unstructured.Unstructured, value string) error {
+	if obj.GetAnnotations()[clusterv1.RebootstrapAnnotation] == value {
+		return nil
+	}
+
+	patchHelper, err := patch.NewHelper(obj, r.Client)
+	if err != nil {
+		return err
+	}
+	annotations.AddAnnotations(obj, map[string]string{clusterv1.RebootstrapAnnotation: value})
+	if err := patchHelper.Patch(ctx, obj); err != nil {
+		return errors.Wrapf(err, "failed to set %s annotation on %s %s", clusterv1.RebootstrapAnnotation, obj.GetKind(), klog.KObj(obj))
+	}
+	ctrl.LoggerFrom(ctx).Info("Requested re-bootstrap of the machine", obj.GetKind(), klog.KObj(obj), "rebootstrap", value)
+	return nil
+}
+
 func (r *Reconciler) reconcileCertificateExpiry(_ context.Context, s *scope) (ctrl.Result, error) {
 	m := s.machine
 	var annotations map[string]string
@@ -365,9 +546,150 @@ func (r *Reconciler) reconcileCertificateExpiry(_ context.Context, s *scope) (ct
 		m.Status.CertificatesExpiryDate = nil
 	}
 
+	return r.reconcileCertificatesNotExpiring(m), nil
+}
+
+// reconcileCertificatesNotExpiring sets the CertificatesNotExpiring condition and the certificates expiry metric
+// according to the certificates expiry date of the Machine.
+// If the certificates are not expired yet, it returns a result requeueing the Machine when the condition will change.
+func (r *Reconciler) reconcileCertificatesNotExpiring(m *clusterv1.Machine) ctrl.Result {
+	if m.Status.CertificatesExpiryDate == nil {
+		conditions.Delete(m, clusterv1.MachineCertificatesNotExpiringCondition)
+		machineCertificatesExpiry.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName)
+		return ctrl.Result{}
+	}
+
+	expiry := m.Status.CertificatesExpiryDate.Time
+	machineCertificatesExpiry.WithLabelValues(m.Namespace, m.Name, m.Spec.ClusterName).Set(float64(expiry.Unix()))
+
+	untilExpiry := time.Until(expiry)
+	if untilExpiry <= 0 {
+		conditions.MarkFalse(m, clusterv1.MachineCertificatesNotExpiringCondition, clusterv1.MachineCertificatesExpiredReason, clusterv1.ConditionSeverityError,
+			"Machine certificates expired on %s", expiry.Format(time.RFC3339))
+		return ctrl.Result{}
+	}
+	if untilExpiry <= r.CertificatesExpiryWarningThreshold {
+		conditions.MarkFalse(m, clusterv1.MachineCertificatesNotExpiringCondition, clusterv1.MachineCertificatesExpiringSoonReason, clusterv1.ConditionSeverityWarning,
+			"Machine certificates expire on %s", expiry.Format(time.RFC3339))
+		return ctrl.Result{RequeueAfter: untilExpiry}
+	}
+
+	conditions.MarkTrue(m, clusterv1.MachineCertificatesNotExpiringCondition)
+	return ctrl.Result{RequeueAfter: untilExpiry - r.CertificatesExpiryWarningThreshold}
+}
+
+// reconcileInterruption marks Machines running on interruptible instances with the InterruptibleLabel, and mirrors the
+// InterruptionNotice condition of the InfraMachine to the Machine. When the infrastructure provider reports that the
+// Machine is going to be interrupted, the Node gets cordoned and drained, so workloads are moved before the instance
+// goes away; the owning MachineSet takes care of replacing the Machine. If the notice is withdrawn before the Machine
+// gets deleted, the Node is uncordoned.
+func (r *Reconciler) reconcileInterruption(ctx context.Context, s *scope) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+	m := s.machine
+
+	var notice *clusterv1.Condition
+	interruptible := false
+	if s.infraMachine != nil {
+		notice = conditions.Get(conditions.UnstructuredGetter(s.infraMachine), clusterv1.MachineInterruptionNoticeCondition)
+
+		var err error
+		interruptible, _, err = unstructured.NestedBool(s.infraMachine.Object, "status", "interruptible")
+		if err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to get status interruptible from infra machine %s", klog.KObj(s.infraMachine))
+		}
+	}
+	// NOTE: The label is only added and never removed, consistently with the interruptible label on the Node.
+	if interruptible {
+		if m.Labels == nil {
+			m.Labels = map[string]string{}
+		}
+		m.Labels[clusterv1.InterruptibleLabel] = ""
+	}
+
+	if notice == nil || notice.Status != corev1.ConditionTrue {
+		// If the Node has been drained because of an interruption notice which is now withdrawn, uncordon the Node
+		// and reset the DrainingSucceededCondition, so the deletion workflow drains the Node again if required.
+		if conditions.IsTrue(m, clusterv1.MachineInterruptionNoticeCondition) && conditions.Has(m, clusterv1.DrainingSucceededCondition) {
+			if m.Status.NodeRef != nil {
+				log.Info("Uncordoning node after the interruption notice has been withdrawn", "Node", klog.KRef("", m.Status.NodeRef.Name))
+				if err := r.uncordonNode(ctx, s.cluster, m.Status.NodeRef.Name); err != nil {
+					return ctrl.Result{}, err
+				}
+			}
+			conditions.Delete(m, clusterv1.DrainingSucceededCondition)
+		}
+		conditions.Delete(m, clusterv1.MachineInterruptionNoticeCondition)
+		return ctrl.Result{}, nil
+	}
+
+	if !conditions.IsTrue(m, clusterv1.MachineInterruptionNoticeCondition) {
+		log.Info("Infrastructure provider reported that the Machine is going to be interrupted", s.infraMachine.GetKind(), klog.KObj(s.infraMachine))
+		r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.InterruptionNoticeReceivedEventReason, "Machine's infrastructure is going to be interrupted: %s", notice.Message)
+	}
+	reason := notice.Reason
+	if reason == "" {
+		reason = clusterv1.MachineInterruptionNoticeReceivedReason
+	}
+	conditions.Set(m, &clusterv1.Condition{
+		Type:    clusterv1.MachineInterruptionNoticeCondition,
+		Status:  corev1.ConditionTrue,
+		Reason:  reason,
+		Message: notice.Message,
+	})
+
+	// Drain the Node only once; the deletion workflow drains it again, if required.
+	if m.Status.NodeRef == nil || conditions.IsTrue(m, clusterv1.DrainingSucceededCondition) || !r.isNodeDrainAllowed(m) {
+		return ctrl.Result{}, nil
+	}
+
+	// NOTE: The DrainingSucceededCondition is shared with the deletion workflow, so the NodeDrainTimeout
+	// applies from the first time the Node gets drained because of the interruption.
+	if conditions.Get(m, clusterv1.DrainingSucceededCondition) == nil {
+		conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingReason, clusterv1.ConditionSeverityInfo, "Draining the node before the interruption")
+	}
+
+	log.Info("Draining node before the interruption", "Node", klog.KRef("", m.Status.NodeRef.Name))
+	result, err := r.drainNode(ctx, s.cluster, m.Status.NodeRef.Name)
+	if err != nil {
+		conditions.MarkFalse(m, clusterv1.DrainingSucceededCondition, clusterv1.DrainingFailedReason, clusterv1.ConditionSeverityWarning, err.Error())
+		r.recorder.Eventf(m, corev1.EventTypeWarning, clusterv1.DrainNodeFailedEventReason, "error draining Machine's node %q: %v", m.Status.NodeRef.Name, err)
+		return ctrl.Result{}, err
+	}
+	if !result.IsZero() {
+		return result, nil
+	}
+
+	conditions.MarkTrue(m, clusterv1.DrainingSucceededCondition)
+	r.recorder.Eventf(m, corev1.EventTypeNormal, clusterv1.DrainNodeSucceededEventReason, "success draining Machine's node %q", m.Status.NodeRef.Name)
 	return ctrl.Result{}, nil
 }
 
+// uncordonNode marks the Node as schedulable again.
+func (r *Reconciler) uncordonNode(ctx context.Context, cluster *clusterv1.Cluster, nodeName string) error {
+	remoteClient, err := r.Tracker.GetClient(ctx, util.ObjectKey(cluster))
+	if err != nil {
+		return err
+	}
+
+	node := &corev1.Node{}
+	if err := remoteClient.Get(ctx, client.ObjectKey{Name: nodeName}, node); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return errors.Wrapf(err, "failed to get Node %s", nodeName)
+	}
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+
+	patchBase := client.MergeFrom(node.DeepCopy())
+	node.Spec.Unschedulable = false
+	if err := remoteClient.Patch(ctx, node, patchBase); err != nil {
+		return errors.Wrapf(err, "failed to uncordon Node %s", nodeName)
+	}
+	return nil
+}
+
 // removeOnCreateOwnerRefs will remove any MachineSet or control plane owner references from passed objects.
 func removeOnCreateOwnerRefs(cluster *clusterv1.Cluster, m *clusterv1.Machine, obj *unstructured.Unstructured) error {
 	cpGVK := getControlPlaneGVKForMachine(cluster, m)
@@ -21,16 +21,21 @@ import (
 	"testing"
 	"time"
 
+	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/log"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/conditions"
@@ -1012,6 +1017,61 @@ func TestReconcileInfrastructure(t *testing.T) {
 				g.Expect(m.GetOwnerReferences()).NotTo(ContainRefOfGroupKind("cluster.x-k8s.io", "MachineSet"))
 			},
 		},
+		{
+			name: "new machine, infrastructure reports invalid and duplicated addresses",
+			infraConfig: map[string]interface{}{
+				"kind":       "GenericInfrastructureMachine",
+				"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+				"metadata": map[string]interface{}{
+					"name":      "infra-config1",
+					"namespace": metav1.NamespaceDefault,
+				},
+				"spec": map[string]interface{}{
+					"providerID": "test://id-1",
+				},
+				"status": map[string]interface{}{
+					"ready": true,
+					"addresses": []interface{}{
+						map[string]interface{}{
+							"type":    "Hostname",
+							"address": "machine-1",
+						},
+						map[string]interface{}{
+							"type":    "InternalIP",
+							"address": "not-an-ip",
+						},
+						map[string]interface{}{
+							"type":    "InternalIP",
+							"address": "10.0.0.1",
+						},
+						map[string]interface{}{
+							"type":    "InternalIP",
+							"address": "fd00:0::1",
+						},
+						map[string]interface{}{
+							"type":    "InternalIP",
+							"address": "10.0.0.1",
+						},
+						map[string]interface{}{
+							"type":    "Unknown",
+							"address": "10.0.0.2",
+						},
+					},
+				},
+			},
+			expectResult:  ctrl.Result{},
+			expectError:   false,
+			expectChanged: true,
+			expected: func(g *WithT, m *clusterv1.Machine) {
+				g.Expect(m.Status.Addresses).To(Equal(clusterv1.MachineAddresses{
+					{Type: clusterv1.MachineHostName, Address: "machine-1"},
+					{Type: clusterv1.MachineInternalIP, Address: "not-an-ip"},
+					{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+					{Type: clusterv1.MachineInternalIP, Address: "fd00:0::1"},
+					{Type: "Unknown", Address: "10.0.0.2"},
+				}))
+			},
+		},
 		{
 			name: "ready bootstrap, infra, and nodeRef, machine is running, infra object is deleted, expect failed",
 			machine: &clusterv1.Machine{
@@ -1122,6 +1182,7 @@ func TestReconcileInfrastructure(t *testing.T) {
 			r := &Reconciler{
 				Client:                    c,
 				UnstructuredCachingClient: c,
+				recorder:                  record.NewFakeRecorder(32),
 			}
 			s := &scope{cluster: defaultCluster, machine: tc.machine}
 			result, err := r.reconcileInfrastructure(ctx, s)
@@ -1375,3 +1436,630 @@ func TestReconcileCertificateExpiry(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileStuckInPhase(t *testing.T) {
+	thresholds := map[clusterv1.MachinePhase]time.Duration{
+		clusterv1.MachinePhaseProvisioning: 10 * time.Minute,
+	}
+
+	testCases := []struct {
+		name          string
+		thresholds    map[clusterv1.MachinePhase]time.Duration
+		phase         clusterv1.MachinePhase
+		inPhaseFor    time.Duration
+		expectStuck   bool
+		expectRequeue bool
+		expectNoCond  bool
+	}{
+		{
+			name:         "no thresholds configured",
+			phase:        clusterv1.MachinePhaseProvisioning,
+			inPhaseFor:   time.Hour,
+			expectNoCond: true,
+		},
+		{
+			name:       "phase without a threshold",
+			thresholds: thresholds,
+			phase:      clusterv1.MachinePhaseRunning,
+			inPhaseFor: time.Hour,
+		},
+		{
+			name:          "phase within the threshold",
+			thresholds:    thresholds,
+			phase:         clusterv1.MachinePhaseProvisioning,
+			inPhaseFor:    time.Minute,
+			expectRequeue: true,
+		},
+		{
+			name:        "phase exceeding the threshold",
+			thresholds:  thresholds,
+			phase:       clusterv1.MachinePhaseProvisioning,
+			inPhaseFor:  time.Hour,
+			expectStuck: true,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Phase:       string(tc.phase),
+					LastUpdated: &metav1.Time{Time: time.Now().Add(-tc.inPhaseFor)},
+				},
+			}
+			r := &Reconciler{StuckInPhaseThresholds: tc.thresholds}
+
+			res := r.reconcileStuckInPhase(ctx, m)
+			if tc.expectRequeue {
+				g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+				g.Expect(res.RequeueAfter).To(BeNumerically("<=", thresholds[tc.phase]-tc.inPhaseFor))
+			} else {
+				g.Expect(res.IsZero()).To(BeTrue())
+			}
+
+			switch {
+			case tc.expectNoCond:
+				g.Expect(conditions.Has(m, clusterv1.MachinePhaseWithinThresholdCondition)).To(BeFalse())
+			case tc.expectStuck:
+				g.Expect(conditions.IsFalse(m, clusterv1.MachinePhaseWithinThresholdCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(m, clusterv1.MachinePhaseWithinThresholdCondition)).To(Equal(clusterv1.MachineStuckInPhaseReason))
+				g.Expect(conditions.GetSeverity(m, clusterv1.MachinePhaseWithinThresholdCondition)).To(HaveValue(Equal(clusterv1.ConditionSeverityWarning)))
+			default:
+				g.Expect(conditions.IsTrue(m, clusterv1.MachinePhaseWithinThresholdCondition)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestReconcileTimeline(t *testing.T) {
+	recorded := metav1.NewTime(time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC))
+
+	testCases := []struct {
+		name                     string
+		machine                  *clusterv1.Machine
+		expectTimeline           bool
+		expectBootstrapReady     bool
+		expectInfraReady         bool
+		expectNodeJoined         bool
+		expectPreservedBootstrap bool
+	}{
+		{
+			name:           "new machine starts recording the timeline",
+			machine:        &clusterv1.Machine{},
+			expectTimeline: true,
+		},
+		{
+			name: "pending machine records bootstrap ready",
+			machine: &clusterv1.Machine{Status: clusterv1.MachineStatus{
+				Phase:          string(clusterv1.MachinePhasePending),
+				BootstrapReady: true,
+			}},
+			expectTimeline:       true,
+			expectBootstrapReady: true,
+		},
+		{
+			name: "machine provisioned before the timeline was introduced does not record the timeline",
+			machine: &clusterv1.Machine{Status: clusterv1.MachineStatus{
+				Phase:               string(clusterv1.MachinePhaseRunning),
+				BootstrapReady:      true,
+				InfrastructureReady: true,
+				NodeRef:             &corev1.ObjectReference{Name: "node"},
+			}},
+			expectTimeline: false,
+		},
+		{
+			name: "running machine records the remaining transitions and preserves the recorded ones",
+			machine: &clusterv1.Machine{Status: clusterv1.MachineStatus{
+				Phase:               string(clusterv1.MachinePhaseRunning),
+				BootstrapReady:      true,
+				InfrastructureReady: true,
+				NodeRef:             &corev1.ObjectReference{Name: "node"},
+				Timeline:            &clusterv1.MachineTimeline{BootstrapReadyTime: recorded.DeepCopy()},
+			}},
+			expectTimeline:           true,
+			expectBootstrapReady:     true,
+			expectInfraReady:         true,
+			expectNodeJoined:         true,
+			expectPreservedBootstrap: true,
+		},
+		{
+			name: "deleting machine does not record the timeline",
+			machine: &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{DeletionTimestamp: &metav1.Time{Time: time.Now()}},
+			},
+			expectTimeline: false,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			r := &Reconciler{}
+			r.reconcileTimeline(ctx, tc.machine)
+
+			timeline := tc.machine.Status.Timeline
+			if !tc.expectTimeline {
+				g.Expect(timeline).To(BeNil())
+				return
+			}
+			g.Expect(timeline).ToNot(BeNil())
+			g.Expect(timeline.BootstrapReadyTime != nil).To(Equal(tc.expectBootstrapReady))
+			g.Expect(timeline.InfrastructureReadyTime != nil).To(Equal(tc.expectInfraReady))
+			g.Expect(timeline.NodeJoinedTime != nil).To(Equal(tc.expectNodeJoined))
+			g.Expect(timeline.NodeReadyTime).To(BeNil())
+			if tc.expectPreservedBootstrap {
+				g.Expect(timeline.BootstrapReadyTime.Equal(&recorded)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestReconcileCertificatesNotExpiring(t *testing.T) {
+	testCases := []struct {
+		name             string
+		threshold        time.Duration
+		expiresIn        *time.Duration
+		expectReason     string
+		expectSeverity   clusterv1.ConditionSeverity
+		expectRequeueMax time.Duration
+		expectNoCond     bool
+	}{
+		{
+			name:         "certificates expiry unknown",
+			expectNoCond: true,
+		},
+		{
+			name:             "certificates not expiring",
+			threshold:        24 * time.Hour,
+			expiresIn:        pointer.Duration(48 * time.Hour),
+			expectRequeueMax: 24 * time.Hour,
+		},
+		{
+			name:             "certificates expiring within the threshold",
+			threshold:        24 * time.Hour,
+			expiresIn:        pointer.Duration(time.Hour),
+			expectReason:     clusterv1.MachineCertificatesExpiringSoonReason,
+			expectSeverity:   clusterv1.ConditionSeverityWarning,
+			expectRequeueMax: time.Hour,
+		},
+		{
+			name:             "certificates not expired without threshold",
+			expiresIn:        pointer.Duration(time.Hour),
+			expectRequeueMax: time.Hour,
+		},
+		{
+			name:           "certificates expired",
+			threshold:      24 * time.Hour,
+			expiresIn:      pointer.Duration(-time.Hour),
+			expectReason:   clusterv1.MachineCertificatesExpiredReason,
+			expectSeverity: clusterv1.ConditionSeverityError,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "certificates-expiry",
+					Namespace: metav1.NamespaceDefault,
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "test-cluster",
+				},
+			}
+			var expiry time.Time
+			if tc.expiresIn != nil {
+				expiry = time.Now().Add(*tc.expiresIn)
+				m.Status.CertificatesExpiryDate = &metav1.Time{Time: expiry}
+			}
+			r := &Reconciler{CertificatesExpiryWarningThreshold: tc.threshold}
+
+			res := r.reconcileCertificatesNotExpiring(m)
+			if tc.expectRequeueMax > 0 {
+				g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+				g.Expect(res.RequeueAfter).To(BeNumerically("<=", tc.expectRequeueMax))
+			} else {
+				g.Expect(res.IsZero()).To(BeTrue())
+			}
+
+			switch {
+			case tc.expectNoCond:
+				g.Expect(conditions.Has(m, clusterv1.MachineCertificatesNotExpiringCondition)).To(BeFalse())
+				g.Expect(machineCertificatesExpiry.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName)).To(BeFalse())
+				return
+			case tc.expectReason != "":
+				g.Expect(conditions.IsFalse(m, clusterv1.MachineCertificatesNotExpiringCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(m, clusterv1.MachineCertificatesNotExpiringCondition)).To(Equal(tc.expectReason))
+				g.Expect(conditions.GetSeverity(m, clusterv1.MachineCertificatesNotExpiringCondition)).To(HaveValue(Equal(tc.expectSeverity)))
+			default:
+				g.Expect(conditions.IsTrue(m, clusterv1.MachineCertificatesNotExpiringCondition)).To(BeTrue())
+			}
+			g.Expect(testutil.ToFloat64(machineCertificatesExpiry.WithLabelValues(m.Namespace, m.Name, m.Spec.ClusterName))).To(Equal(float64(expiry.Unix())))
+		})
+	}
+}
+
+func TestReconcileDeletionBlocked(t *testing.T) {
+	testCases := []struct {
+		name             string
+		threshold        time.Duration
+		deletingFor      *time.Duration
+		expectMessage    string
+		expectRequeueMax time.Duration
+	}{
+		{
+			name:        "no threshold configured",
+			deletingFor: pointer.Duration(time.Hour),
+		},
+		{
+			name:      "machine not deleting",
+			threshold: 10 * time.Minute,
+		},
+		{
+			name:             "machine deleting within the threshold",
+			threshold:        10 * time.Minute,
+			deletingFor:      pointer.Duration(time.Minute),
+			expectRequeueMax: 9 * time.Minute,
+		},
+		{
+			name:             "machine deleting for longer than the threshold",
+			threshold:        10 * time.Minute,
+			deletingFor:      pointer.Duration(time.Hour),
+			expectMessage:    "Machine has been deleting for more than 10m0s, blocked by finalizers example.com/finalizer, machine.cluster.x-k8s.io; deletion hooks pre-terminate.delete.hook.machine.cluster.x-k8s.io/hook",
+			expectRequeueMax: deletionBlockedRequeueAfter,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:       "deletion-blocked",
+					Namespace:  metav1.NamespaceDefault,
+					Finalizers: []string{clusterv1.MachineFinalizer, "example.com/finalizer"},
+					Annotations: map[string]string{
+						clusterv1.PreTerminateDeleteHookAnnotationPrefix + "/hook": "owner",
+					},
+				},
+				Spec: clusterv1.MachineSpec{
+					ClusterName: "test-cluster",
+				},
+			}
+			if tc.deletingFor != nil {
+				m.DeletionTimestamp = &metav1.Time{Time: time.Now().Add(-*tc.deletingFor)}
+			}
+			r := &Reconciler{DeletionBlockedThreshold: tc.threshold}
+
+			res := r.reconcileDeletionBlocked(ctx, m)
+			if tc.expectRequeueMax > 0 {
+				g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+				g.Expect(res.RequeueAfter).To(BeNumerically("<=", tc.expectRequeueMax))
+			} else {
+				g.Expect(res.IsZero()).To(BeTrue())
+			}
+
+			if tc.expectMessage == "" {
+				g.Expect(conditions.Has(m, clusterv1.MachineDeletionBlockedCondition)).To(BeFalse())
+				g.Expect(machineDeletionBlocked.DeleteLabelValues(m.Namespace, m.Name, m.Spec.ClusterName)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.IsTrue(m, clusterv1.MachineDeletionBlockedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(m, clusterv1.MachineDeletionBlockedCondition)).To(Equal(clusterv1.MachineDeletionThresholdExceededReason))
+			g.Expect(conditions.GetMessage(m, clusterv1.MachineDeletionBlockedCondition)).To(Equal(tc.expectMessage))
+			g.Expect(testutil.ToFloat64(machineDeletionBlocked.WithLabelValues(m.Namespace, m.Name, m.Spec.ClusterName))).To(BeNumerically(">=", tc.deletingFor.Seconds()))
+		})
+	}
+}
+
+func TestReconcileInterruption(t *testing.T) {
+	infraMachine := func(infraConditions ...interface{}) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]interface{}{
+			"kind":       "GenericInfrastructureMachine",
+			"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+			"metadata": map[string]interface{}{
+				"name":      "infra-config1",
+				"namespace": metav1.NamespaceDefault,
+			},
+		}}
+		if len(infraConditions) > 0 {
+			u.Object["status"] = map[string]interface{}{"conditions": infraConditions}
+		}
+		return u
+	}
+
+	testCases := []struct {
+		name          string
+		infraMachine  *unstructured.Unstructured
+		machine       *clusterv1.Machine
+		expectNotice  bool
+		expectReason  string
+		expectMessage string
+		expectEvent   bool
+	}{
+		{
+			name:         "infra machine without an interruption notice",
+			infraMachine: infraMachine(),
+			machine:      &clusterv1.Machine{},
+		},
+		{
+			name: "interruption notice removed from the infra machine",
+			infraMachine: infraMachine(map[string]interface{}{
+				"type":   string(clusterv1.MachineInterruptionNoticeCondition),
+				"status": string(corev1.ConditionFalse),
+			}),
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					Conditions: clusterv1.Conditions{*conditions.TrueCondition(clusterv1.MachineInterruptionNoticeCondition)},
+				},
+			},
+		},
+		{
+			name: "interruption notice reported by the infra machine",
+			infraMachine: infraMachine(map[string]interface{}{
+				"type":    string(clusterv1.MachineInterruptionNoticeCondition),
+				"status":  string(corev1.ConditionTrue),
+				"reason":  "SpotInstanceReclaimed",
+				"message": "instance is going to be reclaimed in 2 minutes",
+			}),
+			machine:       &clusterv1.Machine{},
+			expectNotice:  true,
+			expectReason:  "SpotInstanceReclaimed",
+			expectMessage: "instance is going to be reclaimed in 2 minutes",
+			expectEvent:   true,
+		},
+		{
+			name: "interruption notice reported by the infra machine without a reason",
+			infraMachine: infraMachine(map[string]interface{}{
+				"type":   string(clusterv1.MachineInterruptionNoticeCondition),
+				"status": string(corev1.ConditionTrue),
+			}),
+			machine:      &clusterv1.Machine{},
+			expectNotice: true,
+			expectReason: clusterv1.MachineInterruptionNoticeReceivedReason,
+			expectEvent:  true,
+		},
+		{
+			name: "node already drained",
+			infraMachine: infraMachine(map[string]interface{}{
+				"type":   string(clusterv1.MachineInterruptionNoticeCondition),
+				"status": string(corev1.ConditionTrue),
+				"reason": clusterv1.MachineInterruptionNoticeReceivedReason,
+			}),
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					NodeRef: &corev1.ObjectReference{Name: "node-1"},
+					Conditions: clusterv1.Conditions{
+						*conditions.TrueCondition(clusterv1.MachineInterruptionNoticeCondition),
+						*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+					},
+				},
+			},
+			expectNotice: true,
+			expectReason: clusterv1.MachineInterruptionNoticeReceivedReason,
+		},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			recorder := record.NewFakeRecorder(32)
+			// NOTE: The Tracker is not set, so the test fails if the Node gets drained unexpectedly.
+			r := &Reconciler{recorder: recorder}
+			s := &scope{cluster: &clusterv1.Cluster{}, machine: tc.machine, infraMachine: tc.infraMachine}
+
+			res, err := r.reconcileInterruption(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.IsZero()).To(BeTrue())
+
+			if !tc.expectNotice {
+				g.Expect(conditions.Has(tc.machine, clusterv1.MachineInterruptionNoticeCondition)).To(BeFalse())
+			} else {
+				g.Expect(conditions.IsTrue(tc.machine, clusterv1.MachineInterruptionNoticeCondition)).To(BeTrue())
+				g.Expect(conditions.GetReason(tc.machine, clusterv1.MachineInterruptionNoticeCondition)).To(Equal(tc.expectReason))
+				g.Expect(conditions.GetMessage(tc.machine, clusterv1.MachineInterruptionNoticeCondition)).To(Equal(tc.expectMessage))
+			}
+			if tc.expectEvent {
+				g.Expect(recorder.Events).To(Receive(ContainSubstring(clusterv1.InterruptionNoticeReceivedEventReason)))
+			} else {
+				g.Expect(recorder.Events).ToNot(Receive())
+			}
+		})
+	}
+}
+
+func TestReconcileInterruptionWithdrawn(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault}}
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Spec:       corev1.NodeSpec{Unschedulable: true},
+	}
+	remoteClient := fake.NewClientBuilder().WithObjects(node).Build()
+	r := &Reconciler{
+		recorder: record.NewFakeRecorder(32),
+		Tracker:  remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), remoteClient, remoteClient.Scheme(), client.ObjectKeyFromObject(cluster)),
+	}
+
+	// The infrastructure provider withdrew the interruption notice after the Node has been drained.
+	infraMachine := &unstructured.Unstructured{Object: map[string]interface{}{
+		"kind":       "GenericInfrastructureMachine",
+		"apiVersion": "infrastructure.cluster.x-k8s.io/v1beta1",
+		"metadata": map[string]interface{}{
+			"name":      "infra-config1",
+			"namespace": metav1.NamespaceDefault,
+		},
+		"status": map[string]interface{}{
+			"interruptible": true,
+		},
+	}}
+	machine := &clusterv1.Machine{
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: "node-1"},
+			Conditions: clusterv1.Conditions{
+				*conditions.TrueCondition(clusterv1.MachineInterruptionNoticeCondition),
+				*conditions.TrueCondition(clusterv1.DrainingSucceededCondition),
+			},
+		},
+	}
+	s := &scope{cluster: cluster, machine: machine, infraMachine: infraMachine}
+
+	res, err := r.reconcileInterruption(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.IsZero()).To(BeTrue())
+
+	// The Machine is marked as interruptible, the Node is uncordoned and the DrainingSucceededCondition is reset.
+	g.Expect(machine.Labels).To(HaveKey(clusterv1.InterruptibleLabel))
+	g.Expect(conditions.Has(machine, clusterv1.MachineInterruptionNoticeCondition)).To(BeFalse())
+	g.Expect(conditions.Has(machine, clusterv1.DrainingSucceededCondition)).To(BeFalse())
+	g.Expect(remoteClient.Get(ctx, client.ObjectKeyFromObject(node), node)).To(Succeed())
+	g.Expect(node.Spec.Unschedulable).To(BeFalse())
+
+	// The interruptible label is never removed, e.g. when the InfraMachine does not report the interruptible status.
+	infraMachine.Object["status"] = map[string]interface{}{}
+	_, err = r.reconcileInterruption(ctx, s)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(machine.Labels).To(HaveKey(clusterv1.InterruptibleLabel))
+}
+
+func TestReconcileRebootstrap(t *testing.T) {
+	newExternal := func(kind, apiVersion, name string, annotations map[string]interface{}) *unstructured.Unstructured {
+		return &unstructured.Unstructured{
+			Object: map[string]interface{}{
+				"kind":       kind,
+				"apiVersion": apiVersion,
+				"metadata": map[string]interface{}{
+					"name":        name,
+					"namespace":   metav1.NamespaceDefault,
+					"annotations": annotations,
+				},
+			},
+		}
+	}
+	newBootstrapConfig := func(annotations map[string]interface{}) *unstructured.Unstructured {
+		return newExternal("GenericBootstrapConfig", "bootstrap.cluster.x-k8s.io/v1beta1", "bootstrap-config", annotations)
+	}
+	newInfraMachine := func(annotations map[string]interface{}) *unstructured.Unstructured {
+		return newExternal("GenericInfrastructureMachine", "infrastructure.cluster.x-k8s.io/v1beta1", "infra-machine", annotations)
+	}
+
+	tests := []struct {
+		name                  string
+		machineAnnotations    map[string]string
+		provisioned           bool
+		userDataSecret        bool
+		bootstrapConfig       *unstructured.Unstructured
+		infraMachine          *unstructured.Unstructured
+		expectBootstrapConfig map[string]string
+		expectInfraMachine    map[string]string
+	}{
+		{
+			name:            "no re-bootstrap requested",
+			provisioned:     true,
+			bootstrapConfig: newBootstrapConfig(nil),
+			infraMachine:    newInfraMachine(nil),
+		},
+		{
+			name:               "machine not yet provisioned",
+			machineAnnotations: map[string]string{clusterv1.RebootstrapAnnotation: "1"},
+			bootstrapConfig:    newBootstrapConfig(nil),
+			infraMachine:       newInfraMachine(nil),
+		},
+		{
+			name:                  "re-bootstrap requested, propagated to the bootstrap config only",
+			machineAnnotations:    map[string]string{clusterv1.RebootstrapAnnotation: "1"},
+			provisioned:           true,
+			bootstrapConfig:       newBootstrapConfig(nil),
+			infraMachine:          newInfraMachine(nil),
+			expectBootstrapConfig: map[string]string{clusterv1.RebootstrapAnnotation: "1"},
+		},
+		{
+			name:               "re-bootstrap acknowledged by the bootstrap provider, propagated to the InfraMachine",
+			machineAnnotations: map[string]string{clusterv1.RebootstrapAnnotation: "2"},
+			provisioned:        true,
+			bootstrapConfig: newBootstrapConfig(map[string]interface{}{
+				clusterv1.RebootstrapAnnotation:             "2",
+				clusterv1.RebootstrapAcknowledgedAnnotation: "2",
+			}),
+			infraMachine: newInfraMachine(map[string]interface{}{
+				clusterv1.RebootstrapAnnotation:             "1",
+				clusterv1.RebootstrapAcknowledgedAnnotation: "1",
+			}),
+			expectBootstrapConfig: map[string]string{
+				clusterv1.RebootstrapAnnotation:             "2",
+				clusterv1.RebootstrapAcknowledgedAnnotation: "2",
+			},
+			expectInfraMachine: map[string]string{
+				clusterv1.RebootstrapAnnotation:             "2",
+				clusterv1.RebootstrapAcknowledgedAnnotation: "1",
+			},
+		},
+		{
+			name:               "re-bootstrap requested with a user provided bootstrap data secret, propagated to the InfraMachine",
+			machineAnnotations: map[string]string{clusterv1.RebootstrapAnnotation: "1"},
+			provisioned:        true,
+			userDataSecret:     true,
+			infraMachine:       newInfraMachine(nil),
+			expectInfraMachine: map[string]string{clusterv1.RebootstrapAnnotation: "1"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "rebootstrap",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.machineAnnotations,
+				},
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{
+						DataSecretName: pointer.String("secret-data"),
+					},
+				},
+				Status: clusterv1.MachineStatus{
+					BootstrapReady:      tt.provisioned,
+					InfrastructureReady: tt.provisioned,
+				},
+			}
+			if !tt.userDataSecret {
+				m.Spec.Bootstrap.ConfigRef = &corev1.ObjectReference{
+					APIVersion: "bootstrap.cluster.x-k8s.io/v1beta1",
+					Kind:       "GenericBootstrapConfig",
+					Name:       "bootstrap-config",
+				}
+			}
+
+			objs := []client.Object{tt.infraMachine.DeepCopy()}
+			if tt.bootstrapConfig != nil {
+				objs = append(objs, tt.bootstrapConfig.DeepCopy())
+			}
+			c := fake.NewClientBuilder().WithObjects(objs...).Build()
+			r := &Reconciler{Client: c}
+
+			s := &scope{machine: m, infraMachine: tt.infraMachine, bootstrapConfig: tt.bootstrapConfig}
+			res, err := r.reconcileRebootstrap(ctx, s)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(res.IsZero()).To(BeTrue())
+
+			if tt.bootstrapConfig != nil {
+				bootstrapConfig := newBootstrapConfig(nil)
+				g.Expect(c.Get(ctx, client.ObjectKeyFromObject(bootstrapConfig), bootstrapConfig)).To(Succeed())
+				g.Expect(bootstrapConfig.GetAnnotations()).To(Equal(tt.expectBootstrapConfig))
+			}
+			infraMachine := newInfraMachine(nil)
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(infraMachine), infraMachine)).To(Succeed())
+			g.Expect(infraMachine.GetAnnotations()).To(Equal(tt.expectInfraMachine))
+		})
+	}
+}
@@ -25,6 +25,7 @@ import (
 	"github.com/go-logr/logr"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
@@ -1381,6 +1382,102 @@ func TestIsNodeVolumeDetachingAllowed(t *testing.T) {
 	}
 }
 
+func TestGetNodeAttachedVolumes(t *testing.T) {
+	testCluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "test-cluster"},
+	}
+
+	nodeWithVolumes := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+		Status: corev1.NodeStatus{
+			VolumesAttached: []corev1.AttachedVolume{
+				{Name: "kubernetes.io/csi/csi.example.com^vol-2"},
+				{Name: "kubernetes.io/csi/csi.example.com^vol-1"},
+			},
+		},
+	}
+	nodeWithoutVolumes := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "node-1"},
+	}
+
+	newVolumeAttachment := func(name, nodeName, pvName string, attached bool) *storagev1.VolumeAttachment {
+		return &storagev1.VolumeAttachment{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Spec: storagev1.VolumeAttachmentSpec{
+				Attacher: "csi.example.com",
+				NodeName: nodeName,
+				Source:   storagev1.VolumeAttachmentSource{PersistentVolumeName: pointer.String(pvName)},
+			},
+			Status: storagev1.VolumeAttachmentStatus{Attached: attached},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		objs     []client.Object
+		expected []string
+	}{
+		{
+			name:     "Node does not exist",
+			expected: nil,
+		},
+		{
+			name:     "Node without attached volumes",
+			objs:     []client.Object{nodeWithoutVolumes},
+			expected: nil,
+		},
+		{
+			name: "Node with attached volumes reported by VolumeAttachments",
+			objs: []client.Object{
+				nodeWithVolumes,
+				newVolumeAttachment("csi-b", "node-1", "pvc-b", true),
+				newVolumeAttachment("csi-a", "node-1", "pvc-a", true),
+				newVolumeAttachment("csi-detached", "node-1", "pvc-detached", false),
+				newVolumeAttachment("csi-other-node", "node-2", "pvc-other-node", true),
+			},
+			expected: []string{
+				"VolumeAttachment csi-a (PersistentVolume pvc-a)",
+				"VolumeAttachment csi-b (PersistentVolume pvc-b)",
+			},
+		},
+		{
+			name: "Node with attached volumes without VolumeAttachments",
+			objs: []client.Object{nodeWithVolumes},
+			expected: []string{
+				"kubernetes.io/csi/csi.example.com^vol-1",
+				"kubernetes.io/csi/csi.example.com^vol-2",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			remoteClient := fake.NewClientBuilder().WithObjects(tt.objs...).Build()
+			r := &Reconciler{
+				Tracker: remote.NewTestClusterCacheTracker(logr.New(log.NullLogSink{}), remoteClient, fakeScheme, client.ObjectKeyFromObject(testCluster)),
+			}
+
+			got, err := r.getNodeAttachedVolumes(ctx, testCluster, "node-1")
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.expected))
+		})
+	}
+}
+
+func TestSummarizeAttachedVolumes(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(summarizeAttachedVolumes([]string{"a", "b"})).To(Equal("a, b"))
+
+	attachedVolumes := []string{}
+	for i := 0; i < maxAttachedVolumesInMessage+2; i++ {
+		attachedVolumes = append(attachedVolumes, fmt.Sprintf("v%d", i))
+	}
+	g.Expect(summarizeAttachedVolumes(attachedVolumes)).To(Equal("v0, v1, v2, v3, v4, v5, v6, v7, v8, v9 and 2 more"))
+}
+
 func TestIsDeleteNodeAllowed(t *testing.T) {
 	deletionts := metav1.Now()
 
@@ -37,13 +37,16 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 var (
@@ -70,6 +73,9 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	recorder record.EventRecorder
 	ssaCache ssa.Cache
 }
@@ -81,25 +87,28 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	err = ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.MachineDeployment{}).
+		For(&clusterv1.MachineDeployment{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
 		Owns(&clusterv1.MachineSet{}).
 		Watches(
 			&clusterv1.MachineSet{},
 			handler.EnqueueRequestsFromMapFunc(r.MachineSetToDeployments),
 		).
 		WithOptions(options).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(clusterToMachineDeployments),
 			builder.WithPredicates(
 				// TODO: should this wait for Cluster.Status.InfrastructureReady similar to Infra Machine resources?
 				predicates.All(ctrl.LoggerFrom(ctx),
-					predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+					predicates.Any(ctrl.LoggerFrom(ctx),
+						predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+						predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+					),
 					predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 				),
 			),
-		).Complete(r)
+		).Complete(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.MachineDeployment{}), options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
@@ -118,12 +127,18 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		if apierrors.IsNotFound(err) {
 			// Object not found, return.  Created objects are automatically garbage collected.
 			// For additional cleanup logic use finalizers.
+			deleteMachineDeploymentMetrics(req.Namespace, req.Name)
 			return ctrl.Result{}, nil
 		}
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the MachineDeployment does not belong to the shard of this manager.
+	if !r.Shard.Contains(deployment) {
+		return ctrl.Result{}, nil
+	}
+
 	log = log.WithValues("Cluster", klog.KRef(deployment.Namespace, deployment.Spec.ClusterName))
 	ctx = ctrl.LoggerInto(ctx, log)
 
@@ -132,8 +147,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, deployment) {
+	// Set or remove the Paused condition, then return early if the object or Cluster is paused.
+	isPaused, _, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, deployment)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -162,12 +181,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, nil
 	}
 
-	err = r.reconcile(ctx, cluster, deployment)
+	result, err := r.reconcile(ctx, cluster, deployment)
 	if err != nil {
 		log.Error(err, "Failed to reconcile MachineDeployment")
 		r.recorder.Eventf(deployment, corev1.EventTypeWarning, "ReconcileError", "%v", err)
 	}
-	return ctrl.Result{}, err
+	return result, err
 }
 
 func patchMachineDeployment(ctx context.Context, patchHelper *patch.Helper, md *clusterv1.MachineDeployment, options ...patch.Option) error {
@@ -188,7 +207,7 @@ func patchMachineDeployment(ctx context.Context, patchHelper *patch.Helper, md *
 	return patchHelper.Patch(ctx, md, options...)
 }
 
-func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment) error {
+func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster, md *clusterv1.MachineDeployment) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	log.V(4).Info("Reconcile MachineDeployment")
 
@@ -215,18 +234,18 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 
 	// Make sure to reconcile the external infrastructure reference.
 	if err := reconcileExternalTemplateReference(ctx, r.UnstructuredCachingClient, cluster, &md.Spec.Template.Spec.InfrastructureRef); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 	// Make sure to reconcile the external bootstrap reference, if any.
 	if md.Spec.Template.Spec.Bootstrap.ConfigRef != nil {
 		if err := reconcileExternalTemplateReference(ctx, r.UnstructuredCachingClient, cluster, md.Spec.Template.Spec.Bootstrap.ConfigRef); err != nil {
-			return err
+			return ctrl.Result{}, err
 		}
 	}
 
 	msList, err := r.getMachineSetsForDeployment(ctx, md)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// If not already present, add a label specifying the MachineDeployment name to MachineSets.
@@ -242,11 +261,11 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 
 		helper, err := patch.NewHelper(machineSet, r.Client)
 		if err != nil {
-			return errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
 		}
 		machineSet.Labels[clusterv1.MachineDeploymentNameLabel] = md.Name
 		if err := helper.Patch(ctx, machineSet); err != nil {
-			return errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
+			return ctrl.Result{}, errors.Wrapf(err, "failed to apply %s label to MachineSet %q", clusterv1.MachineDeploymentNameLabel, machineSet.Name)
 		}
 	}
 
@@ -259,30 +278,38 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 	for idx := range msList {
 		machineSet := msList[idx]
 		if err := ssa.CleanUpManagedFieldsForSSAAdoption(ctx, r.Client, machineSet, machineDeploymentManagerName); err != nil {
-			return errors.Wrapf(err, "failed to clean up managedFields of MachineSet %s", klog.KObj(machineSet))
+			return ctrl.Result{}, errors.Wrapf(err, "failed to clean up managedFields of MachineSet %s", klog.KObj(machineSet))
+		}
+		// Drop the ownership of fields still co-owned by "before-first-apply", the manager assigned by the API server
+		// at the first apply; otherwise, those fields would not be dropped when they are removed from the MachineDeployment.
+		if err := ssa.DropCoOwnedManagedFields(ctx, r.Client, machineSet, machineDeploymentManagerName); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to drop co-owned managedFields of MachineSet %s", klog.KObj(machineSet))
 		}
 	}
 
+	// When the rollout is paused, only scaling is applied to the existing MachineSets;
+	// template changes are held until the MachineDeployment is resumed.
 	if md.Spec.Paused {
-		return r.sync(ctx, md, msList)
+		log.V(4).Info("Rollout is paused, skipping rollout and only reconciling replicas")
+		return ctrl.Result{}, r.sync(ctx, md, msList)
 	}
 
 	if md.Spec.Strategy == nil {
-		return errors.Errorf("missing MachineDeployment strategy")
+		return ctrl.Result{}, errors.Errorf("missing MachineDeployment strategy")
 	}
 
 	if md.Spec.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType {
 		if md.Spec.Strategy.RollingUpdate == nil {
-			return errors.Errorf("missing MachineDeployment settings for strategy type: %s", md.Spec.Strategy.Type)
+			return ctrl.Result{}, errors.Errorf("missing MachineDeployment settings for strategy type: %s", md.Spec.Strategy.Type)
 		}
 		return r.rolloutRolling(ctx, md, msList)
 	}
 
 	if md.Spec.Strategy.Type == clusterv1.OnDeleteMachineDeploymentStrategyType {
-		return r.rolloutOnDelete(ctx, md, msList)
+		return ctrl.Result{}, r.rolloutOnDelete(ctx, md, msList)
 	}
 
-	return errors.Errorf("unexpected deployment strategy type: %s", md.Spec.Strategy.Type)
+	return ctrl.Result{}, errors.Errorf("unexpected deployment strategy type: %s", md.Spec.Strategy.Type)
 }
 
 // getMachineSetsForDeployment returns a list of MachineSets associated with a MachineDeployment.
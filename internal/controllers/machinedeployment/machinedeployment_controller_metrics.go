@@ -0,0 +1,81 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machinedeployment
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(machineDeploymentRolloutDuration, machineDeploymentRolloutInProgress)
+}
+
+// machineDeploymentRolloutDuration reports the duration of the completed rollouts of the MachineDeployments, from the
+// time the new MachineSet got its revision to the deletion of the last Machine of the old MachineSets.
+var machineDeploymentRolloutDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+	Name:    "capi_machinedeployment_rollout_duration_seconds",
+	Help:    "Duration in seconds of the completed MachineDeployment rollouts",
+	Buckets: prometheus.ExponentialBuckets(60, 2, 10),
+}, []string{"namespace", "cluster_name"})
+
+// machineDeploymentRolloutInProgress reports how long the rollout in progress of each MachineDeployment has been running.
+// NOTE: the value is refreshed every time the MachineDeployment is reconciled.
+var machineDeploymentRolloutInProgress = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+	Name: "capi_machinedeployment_rollout_in_progress_seconds",
+	Help: "Time in seconds since the start of the MachineDeployment rollout in progress",
+}, []string{"namespace", "name", "cluster_name"})
+
+// updateRolloutMetrics updates the rollout metrics of a MachineDeployment, given its status before and after
+// the reconcile; a rollout is in progress as long as there are Machines of the old MachineSets.
+func updateRolloutMetrics(md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldStatus, status clusterv1.MachineDeploymentStatus, now time.Time) {
+	if newMS == nil {
+		return
+	}
+	duration := now.Sub(rolloutStartTime(newMS)).Seconds()
+
+	if status.Replicas > status.UpdatedReplicas {
+		machineDeploymentRolloutInProgress.WithLabelValues(md.Namespace, md.Name, md.Spec.ClusterName).Set(duration)
+		return
+	}
+	machineDeploymentRolloutInProgress.DeleteLabelValues(md.Namespace, md.Name, md.Spec.ClusterName)
+
+	// Observe the duration only once, when the last Machine of the old MachineSets goes away.
+	if oldStatus.Replicas > oldStatus.UpdatedReplicas {
+		machineDeploymentRolloutDuration.WithLabelValues(md.Namespace, md.Spec.ClusterName).Observe(duration)
+	}
+}
+
+func deleteMachineDeploymentMetrics(namespace, name string) {
+	machineDeploymentRolloutInProgress.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+}
+
+// rolloutStartTime returns when the rollout to the given MachineSet began, i.e. when the MachineSet got its
+// current revision; it falls back to the creation of the MachineSet if the revision time is unknown.
+func rolloutStartTime(ms *clusterv1.MachineSet) time.Time {
+	if value, ok := ms.Annotations[clusterv1.RevisionTimeAnnotation]; ok {
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			return t
+		}
+	}
+	return ms.CreationTimestamp.Time
+}
@@ -18,58 +18,161 @@ package machinedeployment
 
 import (
 	"context"
+	"fmt"
 	"sort"
+	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
 	"k8s.io/utils/integer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
 )
 
 // rolloutRolling implements the logic for rolling a new MachineSet.
-func (r *Reconciler) rolloutRolling(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) error {
+func (r *Reconciler) rolloutRolling(ctx context.Context, md *clusterv1.MachineDeployment, msList []*clusterv1.MachineSet) (ctrl.Result, error) {
 	newMS, oldMSs, err := r.getAllMachineSetsAndSyncRevision(ctx, md, msList, true)
 	if err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// newMS can be nil in case there is already a MachineSet associated with this deployment,
 	// but there are only either changes in annotations or MinReadySeconds. Or in other words,
 	// this can be nil if there are changes, but no replacement of existing machines is needed.
 	if newMS == nil {
-		return nil
+		return ctrl.Result{}, nil
 	}
 
 	allMSs := append(oldMSs, newMS)
 
+	// If the new MachineSet failed to make progress within the progress deadline, roll back to the old MachineSets
+	// instead of retrying forever.
+	result := ctrl.Result{}
+	if feature.Gates.Enabled(feature.MachineDeploymentRollback) {
+		result, err = r.reconcileProgressDeadline(ctx, md, newMS, oldMSs)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if mdutil.IsRolloutFailed(newMS) {
+			if err := r.rollbackRolling(ctx, md, newMS, oldMSs); err != nil {
+				return ctrl.Result{}, err
+			}
+			return ctrl.Result{}, r.syncDeploymentStatus(allMSs, newMS, md)
+		}
+	}
+
 	// Scale up, if we can.
 	if err := r.reconcileNewMachineSet(ctx, allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	// Scale down, if we can.
 	if err := r.reconcileOldMachineSets(ctx, allMSs, oldMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if err := r.syncDeploymentStatus(allMSs, newMS, md); err != nil {
-		return err
+		return ctrl.Result{}, err
 	}
 
 	if mdutil.DeploymentComplete(md, &md.Status) {
 		if err := r.cleanupDeployment(ctx, oldMSs, md); err != nil {
+			return ctrl.Result{}, err
+		}
+	}
+
+	return result, nil
+}
+
+// reconcileProgressDeadline marks the rollout of the new MachineSet as failed if one of its Machines did not become
+// available within the progress deadline of the MachineDeployment.
+// Note: A rollout can fail only if there are old MachineSets to roll back to.
+func (r *Reconciler) reconcileProgressDeadline(ctx context.Context, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if md.Spec.ProgressDeadlineSeconds == nil || len(oldMSs) == 0 || mdutil.IsRolloutFailed(newMS) {
+		return ctrl.Result{}, nil
+	}
+
+	selectorMap, err := metav1.LabelSelectorAsMap(&newMS.Spec.Selector)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to convert MachineSet %s label selector to a map", klog.KObj(newMS))
+	}
+	machines := &clusterv1.MachineList{}
+	if err := r.Client.List(ctx, machines, client.InNamespace(newMS.Namespace), client.MatchingLabels(selectorMap)); err != nil {
+		return ctrl.Result{}, errors.Wrapf(err, "failed to list Machines of MachineSet %s", klog.KObj(newMS))
+	}
+
+	progressDeadline := time.Duration(*md.Spec.ProgressDeadlineSeconds) * time.Second
+	result := ctrl.Result{}
+	for i := range machines.Items {
+		machine := &machines.Items[i]
+		if !machine.DeletionTimestamp.IsZero() || isMachineAvailable(machine) {
+			continue
+		}
+		// Requeue when the progress deadline of the Machine is reached, if it does not become available before.
+		if remaining := progressDeadline - time.Since(machine.CreationTimestamp.Time); remaining > 0 {
+			if result.RequeueAfter == 0 || remaining < result.RequeueAfter {
+				result.RequeueAfter = remaining
+			}
+			continue
+		}
+
+		log.Info(fmt.Sprintf("Machine did not become available within %s, marking the rollout of MachineSet as failed", progressDeadline),
+			"MachineSet", klog.KObj(newMS), "Machine", klog.KObj(machine))
+		patchHelper, err := patch.NewHelper(newMS, r.Client)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		annotations.AddAnnotations(newMS, map[string]string{clusterv1.RolloutFailedAnnotation: time.Now().UTC().Format(time.RFC3339)})
+		if err := patchHelper.Patch(ctx, newMS); err != nil {
+			return ctrl.Result{}, errors.Wrapf(err, "failed to set %s annotation on MachineSet %s", clusterv1.RolloutFailedAnnotation, klog.KObj(newMS))
+		}
+		r.recorder.Eventf(md, corev1.EventTypeWarning, clusterv1.RolloutFailedReason, "Machine %s of MachineSet %s did not become available within %s, rolling back",
+			machine.Name, newMS.Name, progressDeadline)
+		return ctrl.Result{}, nil
+	}
+	return result, nil
+}
+
+// isMachineAvailable returns true if the Machine has a Node which is healthy.
+func isMachineAvailable(machine *clusterv1.Machine) bool {
+	return machine.Status.NodeRef != nil && conditions.IsTrue(machine, clusterv1.MachineNodeHealthyCondition)
+}
+
+// rollbackRolling rolls back a failed rollout by scaling the latest old MachineSet up to the desired replicas
+// of the MachineDeployment, and by scaling the new MachineSet down to zero.
+func (r *Reconciler) rollbackRolling(ctx context.Context, md *clusterv1.MachineDeployment, newMS *clusterv1.MachineSet, oldMSs []*clusterv1.MachineSet) error {
+	if md.Spec.Replicas == nil {
+		return errors.Errorf("spec.replicas for MachineDeployment %v is nil, this is unexpected", client.ObjectKeyFromObject(md))
+	}
+
+	if missingReplicas := *md.Spec.Replicas - mdutil.GetReplicaCountForMachineSets(oldMSs); missingReplicas > 0 {
+		sortedOldMSs := append([]*clusterv1.MachineSet{}, oldMSs...)
+		sort.Sort(mdutil.MachineSetsByCreationTimestamp(sortedOldMSs))
+		latestOldMS := sortedOldMSs[len(sortedOldMSs)-1]
+		if latestOldMS.Spec.Replicas == nil {
+			return errors.Errorf("spec.replicas for MachineSet %v is nil, this is unexpected", client.ObjectKeyFromObject(latestOldMS))
+		}
+		if err := r.scaleMachineSet(ctx, latestOldMS, *latestOldMS.Spec.Replicas+missingReplicas, md); err != nil {
 			return err
 		}
 	}
 
-	return nil
+	return r.scaleMachineSet(ctx, newMS, 0, md)
 }
 
 func (r *Reconciler) reconcileNewMachineSet(ctx context.Context, allMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet, deployment *clusterv1.MachineDeployment) error {
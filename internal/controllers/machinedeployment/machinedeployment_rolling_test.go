@@ -19,9 +19,11 @@ package machinedeployment
 import (
 	"strconv"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
 	"k8s.io/utils/pointer"
@@ -30,6 +32,7 @@ import (
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	"sigs.k8s.io/cluster-api/util/conditions"
 )
 
 func TestReconcileNewMachineSet(t *testing.T) {
@@ -446,3 +449,171 @@ func TestReconcileOldMachineSets(t *testing.T) {
 		})
 	}
 }
+
+func TestReconcileProgressDeadline(t *testing.T) {
+	newMachine := func(creationTimestamp time.Time, available bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:              "machine",
+				Namespace:         metav1.NamespaceDefault,
+				Labels:            map[string]string{"machineset": "new"},
+				CreationTimestamp: metav1.NewTime(creationTimestamp),
+			},
+		}
+		if available {
+			m.Status.NodeRef = &corev1.ObjectReference{Name: "node"}
+			conditions.MarkTrue(m, clusterv1.MachineNodeHealthyCondition)
+		}
+		return m
+	}
+
+	testCases := []struct {
+		name                    string
+		progressDeadlineSeconds *int32
+		machine                 *clusterv1.Machine
+		oldMachineSets          bool
+		expectRolloutFailed     bool
+		expectRequeue           bool
+	}{
+		{
+			name:                    "rollout fails when a Machine is not available after the progress deadline",
+			progressDeadlineSeconds: pointer.Int32(600),
+			machine:                 newMachine(time.Now().Add(-time.Hour), false),
+			oldMachineSets:          true,
+			expectRolloutFailed:     true,
+		},
+		{
+			name:                    "rollout does not fail when a Machine is not available before the progress deadline",
+			progressDeadlineSeconds: pointer.Int32(600),
+			machine:                 newMachine(time.Now(), false),
+			oldMachineSets:          true,
+			expectRolloutFailed:     false,
+			expectRequeue:           true,
+		},
+		{
+			name:                    "rollout does not fail when a Machine is available after the progress deadline",
+			progressDeadlineSeconds: pointer.Int32(600),
+			machine:                 newMachine(time.Now().Add(-time.Hour), true),
+			oldMachineSets:          true,
+			expectRolloutFailed:     false,
+		},
+		{
+			name:                    "rollout does not fail without a progress deadline",
+			progressDeadlineSeconds: nil,
+			machine:                 newMachine(time.Now().Add(-time.Hour), false),
+			oldMachineSets:          true,
+			expectRolloutFailed:     false,
+		},
+		{
+			name:                    "rollout does not fail without old MachineSets to roll back to",
+			progressDeadlineSeconds: pointer.Int32(600),
+			machine:                 newMachine(time.Now().Add(-time.Hour), false),
+			oldMachineSets:          false,
+			expectRolloutFailed:     false,
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: metav1.NamespaceDefault},
+				Spec: clusterv1.MachineDeploymentSpec{
+					Replicas:                pointer.Int32(1),
+					ProgressDeadlineSeconds: tc.progressDeadlineSeconds,
+				},
+			}
+			newMS := &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{Name: "new", Namespace: metav1.NamespaceDefault},
+				Spec: clusterv1.MachineSetSpec{
+					Replicas: pointer.Int32(1),
+					Selector: metav1.LabelSelector{MatchLabels: map[string]string{"machineset": "new"}},
+				},
+			}
+			var oldMSs []*clusterv1.MachineSet
+			if tc.oldMachineSets {
+				oldMSs = append(oldMSs, &clusterv1.MachineSet{
+					ObjectMeta: metav1.ObjectMeta{Name: "old", Namespace: metav1.NamespaceDefault},
+					Spec:       clusterv1.MachineSetSpec{Replicas: pointer.Int32(0)},
+				})
+			}
+
+			r := &Reconciler{
+				Client:   fake.NewClientBuilder().WithObjects(md, newMS, tc.machine).Build(),
+				recorder: record.NewFakeRecorder(32),
+			}
+
+			res, err := r.reconcileProgressDeadline(ctx, md, newMS, oldMSs)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(mdutil.IsRolloutFailed(newMS)).To(Equal(tc.expectRolloutFailed))
+			if tc.expectRequeue {
+				// The MachineDeployment is requeued when the progress deadline of the Machine is reached.
+				g.Expect(res.RequeueAfter).To(BeNumerically(">", 590*time.Second))
+				g.Expect(res.RequeueAfter).To(BeNumerically("<=", 600*time.Second))
+			} else {
+				g.Expect(res.IsZero()).To(BeTrue())
+			}
+
+			freshNewMS := &clusterv1.MachineSet{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(newMS), freshNewMS)).To(Succeed())
+			g.Expect(mdutil.IsRolloutFailed(freshNewMS)).To(Equal(tc.expectRolloutFailed))
+		})
+	}
+}
+
+func TestRollbackRolling(t *testing.T) {
+	g := NewWithT(t)
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Name: "md", Namespace: metav1.NamespaceDefault},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Replicas: pointer.Int32(3),
+			Strategy: &clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: intOrStrPtr(0),
+					MaxSurge:       intOrStrPtr(1),
+				},
+			},
+		},
+	}
+	newMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "new",
+			Namespace:         metav1.NamespaceDefault,
+			CreationTimestamp: metav1.NewTime(time.Now()),
+			Annotations:       map[string]string{clusterv1.RolloutFailedAnnotation: time.Now().UTC().Format(time.RFC3339)},
+		},
+		Spec: clusterv1.MachineSetSpec{Replicas: pointer.Int32(2)},
+	}
+	olderMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "older",
+			Namespace:         metav1.NamespaceDefault,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+		},
+		Spec: clusterv1.MachineSetSpec{Replicas: pointer.Int32(0)},
+	}
+	latestOldMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "latest-old",
+			Namespace:         metav1.NamespaceDefault,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Hour)),
+		},
+		Spec: clusterv1.MachineSetSpec{Replicas: pointer.Int32(1)},
+	}
+
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithObjects(md, newMS, olderMS, latestOldMS).Build(),
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	g.Expect(r.rollbackRolling(ctx, md, newMS, []*clusterv1.MachineSet{latestOldMS, olderMS})).To(Succeed())
+
+	expectedReplicas := map[*clusterv1.MachineSet]int32{newMS: 0, olderMS: 0, latestOldMS: 3}
+	for ms, replicas := range expectedReplicas {
+		freshMS := &clusterv1.MachineSet{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(ms), freshMS)).To(Succeed())
+		g.Expect(*freshMS.Spec.Replicas).To(Equal(replicas), "unexpected replicas for MachineSet %s", ms.Name)
+	}
+}
@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
 	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
@@ -335,6 +336,7 @@ func (r *Reconciler) computeDesiredMachineSet(deployment *clusterv1.MachineDeplo
 
 	// Set all other in-place mutable fields.
 	desiredMS.Spec.MinReadySeconds = pointer.Int32Deref(deployment.Spec.MinReadySeconds, 0)
+	desiredMS.Spec.FailureDomainRebalance = deployment.Spec.FailureDomainRebalance.DeepCopy()
 	if deployment.Spec.Strategy != nil && deployment.Spec.Strategy.RollingUpdate != nil {
 		desiredMS.Spec.DeletePolicy = pointer.StringDeref(deployment.Spec.Strategy.RollingUpdate.DeletePolicy, "")
 	} else {
@@ -343,6 +345,7 @@ func (r *Reconciler) computeDesiredMachineSet(deployment *clusterv1.MachineDeplo
 	desiredMS.Spec.Template.Spec.NodeDrainTimeout = deployment.Spec.Template.Spec.NodeDrainTimeout
 	desiredMS.Spec.Template.Spec.NodeDeletionTimeout = deployment.Spec.Template.Spec.NodeDeletionTimeout
 	desiredMS.Spec.Template.Spec.NodeVolumeDetachTimeout = deployment.Spec.Template.Spec.NodeVolumeDetachTimeout
+	desiredMS.Spec.Template.Spec.Taints = deployment.Spec.Template.Spec.Taints
 
 	return desiredMS, nil
 }
@@ -489,7 +492,9 @@ func (r *Reconciler) scale(ctx context.Context, deployment *clusterv1.MachineDep
 
 // syncDeploymentStatus checks if the status is up-to-date and sync it if necessary.
 func (r *Reconciler) syncDeploymentStatus(allMSs []*clusterv1.MachineSet, newMS *clusterv1.MachineSet, md *clusterv1.MachineDeployment) error {
+	oldStatus := md.Status
 	md.Status = calculateStatus(allMSs, newMS, md)
+	updateRolloutMetrics(md, newMS, oldStatus, md.Status, time.Now())
 
 	// minReplicasNeeded will be equal to md.Spec.Replicas when the strategy is not RollingUpdateMachineDeploymentStrategyType.
 	minReplicasNeeded := *(md.Spec.Replicas) - mdutil.MaxUnavailable(*md)
@@ -500,6 +505,12 @@ func (r *Reconciler) syncDeploymentStatus(allMSs []*clusterv1.MachineSet, newMS
 	} else {
 		conditions.MarkFalse(md, clusterv1.MachineDeploymentAvailableCondition, clusterv1.WaitingForAvailableMachinesReason, clusterv1.ConditionSeverityWarning, "Minimum availability requires %d replicas, current %d available", minReplicasNeeded, md.Status.AvailableReplicas)
 	}
+
+	if feature.Gates.Enabled(feature.MachineDeploymentRollback) && mdutil.IsRolloutFailed(newMS) {
+		conditions.MarkFalse(md, clusterv1.MachineDeploymentRolloutProgressingCondition, clusterv1.RolloutFailedReason, clusterv1.ConditionSeverityError, "Machines of MachineSet %s did not become available within %ds, rolled back to the old MachineSets", newMS.Name, pointer.Int32Deref(md.Spec.ProgressDeadlineSeconds, 0))
+	} else {
+		conditions.Delete(md, clusterv1.MachineDeploymentRolloutProgressingCondition)
+	}
 	return nil
 }
 
@@ -25,6 +25,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -550,6 +551,9 @@ func TestComputeDesiredMachineSet(t *testing.T) {
 					NodeDrainTimeout:        duration10s,
 					NodeVolumeDetachTimeout: duration10s,
 					NodeDeletionTimeout:     duration10s,
+					Taints: []corev1.Taint{
+						{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+					},
 				},
 			},
 		},
@@ -617,6 +621,7 @@ func TestComputeDesiredMachineSet(t *testing.T) {
 		existingMS.Spec.Template.Spec.NodeDrainTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeDeletionTimeout = duration5s
 		existingMS.Spec.Template.Spec.NodeVolumeDetachTimeout = duration5s
+		existingMS.Spec.Template.Spec.Taints = nil
 		existingMS.Spec.DeletePolicy = string(clusterv1.NewestMachineSetDeletePolicy)
 		existingMS.Spec.MinReadySeconds = 0
 
@@ -836,3 +841,105 @@ func Test_computeNewMachineSetName(t *testing.T) {
 		})
 	}
 }
+
+func TestUpdateRolloutMetrics(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now().Truncate(time.Second)
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "rollout-metrics", Name: "md"},
+		Spec:       clusterv1.MachineDeploymentSpec{ClusterName: "cluster"},
+	}
+	// The new MachineSet got its revision long after its creation, e.g. when rolling back to it.
+	newMS := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			CreationTimestamp: metav1.NewTime(now.Add(-time.Hour)),
+			Annotations: map[string]string{
+				clusterv1.RevisionTimeAnnotation: now.Add(-10 * time.Minute).UTC().Format(time.RFC3339),
+			},
+		},
+	}
+	inProgress := clusterv1.MachineDeploymentStatus{Replicas: 4, UpdatedReplicas: 1}
+	completed := clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 3}
+	durations := testutil.CollectAndCount(machineDeploymentRolloutDuration)
+
+	// The gauge reports the time since the new MachineSet got its revision while the rollout is in progress.
+	updateRolloutMetrics(md, newMS, completed, inProgress, now)
+	g.Expect(testutil.ToFloat64(machineDeploymentRolloutInProgress.WithLabelValues(md.Namespace, md.Name, md.Spec.ClusterName))).To(Equal(600.0))
+	updateRolloutMetrics(md, newMS, inProgress, inProgress, now.Add(time.Minute))
+	g.Expect(testutil.ToFloat64(machineDeploymentRolloutInProgress.WithLabelValues(md.Namespace, md.Name, md.Spec.ClusterName))).To(Equal(660.0))
+	g.Expect(testutil.CollectAndCount(machineDeploymentRolloutDuration)).To(Equal(durations))
+
+	// The duration is observed when the rollout completes, and the gauge is deleted.
+	updateRolloutMetrics(md, newMS, inProgress, completed, now.Add(2*time.Minute))
+	g.Expect(testutil.CollectAndCount(machineDeploymentRolloutDuration)).To(Equal(durations + 1))
+	g.Expect(machineDeploymentRolloutInProgress.DeleteLabelValues(md.Namespace, md.Name, md.Spec.ClusterName)).To(BeFalse())
+
+	// The duration is not observed again in the following reconciles.
+	updateRolloutMetrics(md, newMS, completed, completed, now.Add(3*time.Minute))
+	g.Expect(testutil.CollectAndCount(machineDeploymentRolloutDuration)).To(Equal(durations + 1))
+
+	// The gauge falls back to the creation of the new MachineSet if its revision time is unknown.
+	delete(newMS.Annotations, clusterv1.RevisionTimeAnnotation)
+	updateRolloutMetrics(md, newMS, completed, inProgress, now)
+	g.Expect(testutil.ToFloat64(machineDeploymentRolloutInProgress.WithLabelValues(md.Namespace, md.Name, md.Spec.ClusterName))).To(Equal(3600.0))
+	deleteMachineDeploymentMetrics(md.Namespace, md.Name)
+}
+
+func TestSyncPausedMachineDeployment(t *testing.T) {
+	g := NewWithT(t)
+
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "md",
+		},
+		Spec: clusterv1.MachineDeploymentSpec{
+			Paused:   true,
+			Replicas: pointer.Int32(5),
+			Strategy: &clusterv1.MachineDeploymentStrategy{
+				Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
+				RollingUpdate: &clusterv1.MachineRollingUpdateDeployment{
+					MaxUnavailable: intOrStrPtr(0),
+					MaxSurge:       intOrStrPtr(1),
+				},
+			},
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: pointer.String("v1.26.0"),
+				},
+			},
+		},
+	}
+
+	// The existing MachineSet does not match the (changed) template of the MachineDeployment.
+	ms := &clusterv1.MachineSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceDefault,
+			Name:      "ms",
+		},
+		Spec: clusterv1.MachineSetSpec{
+			Replicas: pointer.Int32(3),
+			Template: clusterv1.MachineTemplateSpec{
+				Spec: clusterv1.MachineSpec{
+					Version: pointer.String("v1.25.0"),
+				},
+			},
+		},
+	}
+
+	r := &Reconciler{
+		Client:   fake.NewClientBuilder().WithObjects(md, ms).Build(),
+		recorder: record.NewFakeRecorder(32),
+	}
+
+	g.Expect(r.sync(ctx, md, []*clusterv1.MachineSet{ms})).To(Succeed())
+
+	// The rollout is held: no new MachineSet is created, but the existing one is scaled to the desired replicas.
+	machineSets := &clusterv1.MachineSetList{}
+	g.Expect(r.Client.List(ctx, machineSets)).To(Succeed())
+	g.Expect(machineSets.Items).To(HaveLen(1))
+	g.Expect(machineSets.Items[0].Name).To(Equal("ms"))
+	g.Expect(*machineSets.Items[0].Spec.Replicas).To(BeEquivalentTo(5))
+	g.Expect(*machineSets.Items[0].Spec.Template.Spec.Version).To(Equal("v1.25.0"))
+}
@@ -22,6 +22,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -145,8 +146,10 @@ var annotationsToSkip = map[string]bool{
 	corev1.LastAppliedConfigAnnotation:  true,
 	clusterv1.RevisionAnnotation:        true,
 	clusterv1.RevisionHistoryAnnotation: true,
+	clusterv1.RevisionTimeAnnotation:    true,
 	clusterv1.DesiredReplicasAnnotation: true,
 	clusterv1.MaxReplicasAnnotation:     true,
+	clusterv1.RolloutFailedAnnotation:   true,
 
 	// Exclude the conversion annotation, to avoid infinite loops between the conversion webhook
 	// and the MachineDeployment controller syncing the annotations between a MachineDeployment
@@ -154,6 +157,9 @@ var annotationsToSkip = map[string]bool{
 	//
 	// See https://github.com/kubernetes-sigs/cluster-api/pull/3010#issue-413767831 for more details.
 	conversion.DataAnnotation: true,
+
+	// Exclude the desired state hash annotation, which is specific to the MachineDeployment applied by the topology controller.
+	clusterv1.TopologyDesiredStateHashAnnotation: true,
 }
 
 // skipCopyAnnotation returns true if we should skip copying the annotation with the given annotation key
@@ -221,6 +227,16 @@ func ComputeMachineSetAnnotations(log logr.Logger, deployment *clusterv1.Machine
 			annotations[clusterv1.RevisionHistoryAnnotation] = revisionHistory
 		}
 
+		// Ensure we preserve the rollout failed annotation if it already exists.
+		if rolloutFailed, rolloutFailedExists := newMS.Annotations[clusterv1.RolloutFailedAnnotation]; rolloutFailedExists {
+			annotations[clusterv1.RolloutFailedAnnotation] = rolloutFailed
+		}
+
+		// Ensure we preserve the revision time annotation as long as the revision does not change.
+		if revisionTime, revisionTimeExists := newMS.Annotations[clusterv1.RevisionTimeAnnotation]; revisionTimeExists && currentRevision == newRevision {
+			annotations[clusterv1.RevisionTimeAnnotation] = revisionTime
+		}
+
 		// If the revision changes then add the old revision to the revision history annotation
 		if currentRevisionExists && currentRevision != newRevision {
 			oldRevisions := strings.Split(revisionHistory, ",")
@@ -233,6 +249,9 @@ func ComputeMachineSetAnnotations(log logr.Logger, deployment *clusterv1.Machine
 	}
 
 	annotations[clusterv1.RevisionAnnotation] = newRevision
+	if _, ok := annotations[clusterv1.RevisionTimeAnnotation]; !ok {
+		annotations[clusterv1.RevisionTimeAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	}
 	annotations[clusterv1.DesiredReplicasAnnotation] = fmt.Sprintf("%d", *deployment.Spec.Replicas)
 	annotations[clusterv1.MaxReplicasAnnotation] = fmt.Sprintf("%d", *(deployment.Spec.Replicas)+MaxSurge(*deployment))
 	return annotations, nil
@@ -390,6 +409,9 @@ func MachineTemplateDeepCopyRolloutFields(template *clusterv1.MachineTemplateSpe
 	templateCopy.Spec.NodeDeletionTimeout = nil
 	templateCopy.Spec.NodeVolumeDetachTimeout = nil
 
+	// Drop taints, they are propagated in-place to existing Machines.
+	templateCopy.Spec.Taints = nil
+
 	// Remove the version part from the references APIVersion field,
 	// for more details see issue #2183 and #2140.
 	templateCopy.Spec.InfrastructureRef.APIVersion = templateCopy.Spec.InfrastructureRef.GroupVersionKind().Group
@@ -513,6 +535,15 @@ func GetAvailableReplicaCountForMachineSets(machineSets []*clusterv1.MachineSet)
 	return totalAvailableReplicas
 }
 
+// IsRolloutFailed returns true if the rollout of the given MachineSet failed, i.e. it has the rollout failed annotation.
+func IsRolloutFailed(ms *clusterv1.MachineSet) bool {
+	if ms == nil {
+		return false
+	}
+	_, ok := ms.Annotations[clusterv1.RolloutFailedAnnotation]
+	return ok
+}
+
 // IsRollingUpdate returns true if the strategy type is a rolling update.
 func IsRollingUpdate(deployment *clusterv1.MachineDeployment) bool {
 	return deployment.Spec.Strategy.Type == clusterv1.RollingUpdateMachineDeploymentStrategyType
@@ -210,6 +210,7 @@ func TestEqualMachineTemplate(t *testing.T) {
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeDrainTimeout = &metav1.Duration{Duration: 20 * time.Second}
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeDeletionTimeout = &metav1.Duration{Duration: 20 * time.Second}
 	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.NodeVolumeDetachTimeout = &metav1.Duration{Duration: 20 * time.Second}
+	machineTemplateWithDifferentInPlaceMutableSpecFields.Spec.Taints = []corev1.Taint{{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule}}
 
 	machineTemplateWithDifferentInfraRef := machineTemplate.DeepCopy()
 	machineTemplateWithDifferentInfraRef.Spec.InfrastructureRef.Name = "infra2"
@@ -887,6 +888,36 @@ func TestComputeMachineSetAnnotations(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:       "Calculating annotations for a existing MachineSet - revision time is preserved",
+			deployment: &deployment,
+			oldMSs:     nil,
+			ms:         machineSetWithRevisionTime(machineSetWithRevisionAndHistory("1", ""), "2023-01-01T00:00:00Z"),
+			want: map[string]string{
+				"key1":                              "value1",
+				clusterv1.RevisionAnnotation:        "1",
+				clusterv1.RevisionTimeAnnotation:    "2023-01-01T00:00:00Z",
+				clusterv1.DesiredReplicasAnnotation: "3",
+				clusterv1.MaxReplicasAnnotation:     "4",
+			},
+			wantErr: false,
+		},
+		{
+			name:       "Calculating annotations for a existing MachineSet - old MSs exist - revision time is reset",
+			deployment: &deployment,
+			oldMSs: []*clusterv1.MachineSet{
+				machineSetWithRevisionAndHistory("2", ""),
+			},
+			ms: machineSetWithRevisionTime(machineSetWithRevisionAndHistory("1", ""), "2023-01-01T00:00:00Z"),
+			want: map[string]string{
+				"key1":                              "value1",
+				clusterv1.RevisionAnnotation:        "3",
+				clusterv1.RevisionHistoryAnnotation: "1",
+				clusterv1.DesiredReplicasAnnotation: "3",
+				clusterv1.MaxReplicasAnnotation:     "4",
+			},
+			wantErr: false,
+		},
 		{
 			name:       "Calculating annotations for a existing MachineSet - old MSs exist",
 			deployment: &deployment,
@@ -948,6 +979,13 @@ func TestComputeMachineSetAnnotations(t *testing.T) {
 				g.Expect(err).ShouldNot(HaveOccurred())
 			} else {
 				g.Expect(err).ToNot(HaveOccurred())
+				// A new revision time is set to the current time, so only check that it is valid.
+				if _, ok := tt.want[clusterv1.RevisionTimeAnnotation]; !ok {
+					g.Expect(got).To(HaveKey(clusterv1.RevisionTimeAnnotation))
+					_, err := time.Parse(time.RFC3339, got[clusterv1.RevisionTimeAnnotation])
+					g.Expect(err).ToNot(HaveOccurred())
+					delete(got, clusterv1.RevisionTimeAnnotation)
+				}
 				g.Expect(got).Should(Equal(tt.want))
 			}
 		})
@@ -1039,3 +1077,8 @@ func TestReplicasAnnotationsNeedUpdate(t *testing.T) {
 		})
 	}
 }
+
+func machineSetWithRevisionTime(ms *clusterv1.MachineSet, revisionTime string) *clusterv1.MachineSet {
+	ms.Annotations[clusterv1.RevisionTimeAnnotation] = revisionTime
+	return ms
+}
@@ -47,11 +47,14 @@ import (
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/remote"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 const (
@@ -80,13 +83,16 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	controller controller.Controller
 	recorder   record.EventRecorder
 }
 
 func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
 	c, err := ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.MachineHealthCheck{}).
+		For(&clusterv1.MachineHealthCheck{}, builder.WithPredicates(predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(r.machineToMachineHealthCheck),
@@ -103,7 +109,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 					predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 				),
 			),
-		).Build(r)
+		).Build(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.MachineHealthCheck{}), options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
@@ -130,6 +136,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the MachineHealthCheck does not belong to the shard of this manager.
+	if !r.Shard.Contains(m) {
+		return ctrl.Result{}, nil
+	}
+
 	log = log.WithValues("Cluster", klog.KRef(m.Namespace, m.Spec.ClusterName))
 	ctx = ctrl.LoggerInto(ctx, log)
 
@@ -232,11 +243,44 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	if nodeStartupTimeout == nil {
 		nodeStartupTimeout = &clusterv1.DefaultNodeStartupTimeout
 	}
+	infrastructureStartupTimeout := m.Spec.InfrastructureStartupTimeout
+	if infrastructureStartupTimeout == nil {
+		infrastructureStartupTimeout = &disabledInfrastructureStartupTimeout
+	}
 
 	// health check all targets and reconcile mhc status
-	healthy, unhealthy, nextCheckTimes := r.healthCheckTargets(targets, logger, *nodeStartupTimeout)
+	healthy, unhealthy, nextCheckTimes := r.healthCheckTargets(targets, logger, *nodeStartupTimeout, *infrastructureStartupTimeout)
 	m.Status.CurrentHealthy = int32(len(healthy))
 
+	// Skip remediation during remediation suppression windows; the targets are still health checked,
+	// so the MachineHealthCheckSucceededCondition on the Machines reports the result of the health check.
+	now := time.Now()
+	if suppressedUntil, ok := remediationSuppressedUntil(m, now); ok {
+		message := fmt.Sprintf("Remediation is suppressed by a remediation suppression window until %s", suppressedUntil.UTC().Format(time.RFC3339))
+		logger.V(3).Info("Suppressing remediation", "until", suppressedUntil.UTC().Format(time.RFC3339), unhealthyTargetsKeyLog, len(unhealthy))
+
+		m.Status.RemediationsAllowed = 0
+		conditions.Set(m, &clusterv1.Condition{
+			Type:     clusterv1.RemediationAllowedCondition,
+			Status:   corev1.ConditionFalse,
+			Severity: clusterv1.ConditionSeverityInfo,
+			Reason:   clusterv1.RemediationSuppressedReason,
+			Message:  message,
+		})
+
+		errList := []error{}
+		for _, t := range append(healthy, unhealthy...) {
+			if err := t.patchHelper.Patch(ctx, t.Machine); err != nil {
+				errList = append(errList, errors.Wrapf(err, "failed to patch machine status for machine: %s/%s", t.Machine.Namespace, t.Machine.Name))
+				continue
+			}
+		}
+		if len(errList) > 0 {
+			return ctrl.Result{}, kerrors.NewAggregate(errList)
+		}
+		return ctrl.Result{RequeueAfter: suppressedUntil.Sub(now)}, nil
+	}
+
 	// check MHC current health against MaxUnhealthy
 	remediationAllowed, remediationCount, err := isAllowedRemediation(m)
 	if err != nil {
@@ -322,6 +366,13 @@ func (r *Reconciler) reconcile(ctx context.Context, logger logr.Logger, cluster
 	errList := r.patchUnhealthyTargets(ctx, logger, unhealthy, cluster, m)
 	errList = append(errList, r.patchHealthyTargets(ctx, logger, healthy, m)...)
 
+	// Ensure a requeue happens when the remediation of unhealthy Machines is not suppressed anymore.
+	for _, t := range unhealthy {
+		if suppressedUntil, ok := machineRemediationSuppressedUntil(t.Machine, now); ok {
+			nextCheckTimes = append(nextCheckTimes, suppressedUntil.Sub(now))
+		}
+	}
+
 	// handle update errors
 	if len(errList) > 0 {
 		logger.V(3).Info("Error(s) marking machine, requeuing")
@@ -379,6 +430,8 @@ func (r *Reconciler) patchUnhealthyTargets(ctx context.Context, logger logr.Logg
 
 		if annotations.IsPaused(cluster, t.Machine) {
 			logger.Info("Machine has failed health check, but machine is paused so skipping remediation", "target", t.string(), "reason", condition.Reason, "message", condition.Message)
+		} else if suppressedUntil, ok := machineRemediationSuppressedUntil(t.Machine, time.Now()); ok {
+			logger.Info("Machine has failed health check, but remediation is suppressed so skipping remediation", "target", t.string(), "until", suppressedUntil.UTC().Format(time.RFC3339), "reason", condition.Reason, "message", condition.Message)
 		} else {
 			if m.Spec.RemediationTemplate != nil {
 				// If external remediation request already exists,
@@ -31,9 +31,12 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/util/cron"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/contract"
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
@@ -50,6 +53,8 @@ const (
 var (
 	// We allow users to disable the nodeStartupTimeout by setting the duration to 0.
 	disabledNodeStartupTimeout = clusterv1.ZeroDuration
+	// The infrastructureStartupTimeout is disabled if it is not set or if it is set to 0.
+	disabledInfrastructureStartupTimeout = clusterv1.ZeroDuration
 )
 
 // healthCheckTarget contains the information required to perform a health check
@@ -61,6 +66,9 @@ type healthCheckTarget struct {
 	MHC         *clusterv1.MachineHealthCheck
 	patchHelper *patch.Helper
 	nodeMissing bool
+	// externalManagedControlPlane is true if the control plane of the Cluster is externally managed (e.g. AKS, EKS, GKE),
+	// and thus there are no control plane Machines and Nodes.
+	externalManagedControlPlane bool
 }
 
 func (t *healthCheckTarget) string() string {
@@ -83,13 +91,14 @@ func (t *healthCheckTarget) nodeName() string {
 // Determine whether or not a given target needs remediation.
 // The node will need remediation if any of the following are true:
 // - The Machine has failed for some reason
+// - The Machine infrastructure did not become ready before `timeoutForInfrastructureToBeReady` elapses
 // - The Machine did not get a node before `timeoutForMachineToHaveNode` elapses
 // - The Node has gone away
 // - Any condition on the node is matched for the given timeout
 // If the target doesn't currently need rememdiation, provide a duration after
 // which the target should next be checked.
 // The target should be requeued after this duration.
-func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachineToHaveNode metav1.Duration) (bool, time.Duration) {
+func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachineToHaveNode, timeoutForInfrastructureToBeReady metav1.Duration) (bool, time.Duration) {
 	var nextCheckTimes []time.Duration
 	now := time.Now()
 
@@ -114,7 +123,8 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 
 	// Don't penalize any Machine/Node if the control plane has not been initialized
 	// Exception of this rule are control plane machine itself, so the first control plane machine can be remediated.
-	if !conditions.IsTrue(t.Cluster, clusterv1.ControlPlaneInitializedCondition) && !util.IsControlPlaneMachine(t.Machine) {
+	// NOTE: The exception does not apply to externally managed control planes, which are not composed by Machines.
+	if !conditions.IsTrue(t.Cluster, clusterv1.ControlPlaneInitializedCondition) && (t.externalManagedControlPlane || !util.IsControlPlaneMachine(t.Machine)) {
 		logger.V(3).Info("Not evaluating target health because the control plane has not yet been initialized")
 		// Return a nextCheck time of 0 because we'll get requeued when the Cluster is updated.
 		return false, 0
@@ -129,8 +139,8 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 
 	// the node has not been set yet
 	if t.Node == nil {
-		if timeoutForMachineToHaveNode == disabledNodeStartupTimeout {
-			// Startup timeout is disabled so no need to go any further.
+		if timeoutForMachineToHaveNode == disabledNodeStartupTimeout && timeoutForInfrastructureToBeReady == disabledInfrastructureStartupTimeout {
+			// Startup timeouts are disabled so no need to go any further.
 			// No node yet to check conditions, can return early here.
 			return false, 0
 		}
@@ -148,8 +158,40 @@ func (t *healthCheckTarget) needsRemediation(logger logr.Logger, timeoutForMachi
 		if conditions.IsTrue(t.Cluster, clusterv1.InfrastructureReadyCondition) && clusterInfraReady != nil && clusterInfraReady.Time.After(comparisonTime) {
 			comparisonTime = clusterInfraReady.Time
 		}
+
+		// If the infrastructure startup timeout is enabled, the infrastructure of the Machine must become ready
+		// within the infrastructure startup timeout, and the node startup timeout applies only after the
+		// infrastructure of the Machine is ready.
+		if timeoutForInfrastructureToBeReady != disabledInfrastructureStartupTimeout {
+			if !t.Machine.Status.InfrastructureReady {
+				logger.V(3).Info("Using comparison time", "time", comparisonTime)
+
+				timeoutDuration := timeoutForInfrastructureToBeReady.Duration
+				if comparisonTime.Add(timeoutDuration).Before(now) {
+					conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.InfrastructureStartupTimeoutReason, clusterv1.ConditionSeverityWarning, "Infrastructure failed to report ready in %s", timeoutDuration)
+					logger.V(3).Info("Target is unhealthy: machine infrastructure is not ready", "duration", timeoutDuration)
+					return true, time.Duration(0)
+				}
+
+				durationUnhealthy := now.Sub(comparisonTime)
+				nextCheck := timeoutDuration - durationUnhealthy + time.Second
+
+				return false, nextCheck
+			}
+
+			machineInfraReady := conditions.GetLastTransitionTime(t.Machine, clusterv1.InfrastructureReadyCondition)
+			logger.V(3).Info("Determining comparison time", "machineInfraReadyTime", machineInfraReady)
+			if conditions.IsTrue(t.Machine, clusterv1.InfrastructureReadyCondition) && machineInfraReady != nil && machineInfraReady.Time.After(comparisonTime) {
+				comparisonTime = machineInfraReady.Time
+			}
+		}
 		logger.V(3).Info("Using comparison time", "time", comparisonTime)
 
+		if timeoutForMachineToHaveNode == disabledNodeStartupTimeout {
+			// Node startup timeout is disabled so no need to go any further.
+			return false, 0
+		}
+
 		timeoutDuration := timeoutForMachineToHaveNode.Duration
 		if comparisonTime.Add(timeoutForMachineToHaveNode.Duration).Before(now) {
 			conditions.MarkFalse(t.Machine, clusterv1.MachineHealthCheckSucceededCondition, clusterv1.NodeStartupTimeoutReason, clusterv1.ConditionSeverityWarning, "Node failed to report startup in %s", timeoutDuration)
@@ -201,6 +243,11 @@ func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger,
 		return nil, nil
 	}
 
+	externalManagedControlPlane, err := r.isExternalManagedControlPlane(ctx, cluster)
+	if err != nil {
+		return nil, err
+	}
+
 	targets := []healthCheckTarget{}
 	for k := range machines {
 		logger := logger.WithValues("Machine", klog.KObj(&machines[k]))
@@ -219,6 +266,8 @@ func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger,
 			MHC:         mhc,
 			Machine:     &machines[k],
 			patchHelper: patchHelper,
+
+			externalManagedControlPlane: externalManagedControlPlane,
 		}
 		if clusterClient != nil {
 			node, err := r.getNodeFromMachine(ctx, clusterClient, target.Machine)
@@ -237,6 +286,21 @@ func (r *Reconciler) getTargetsFromMHC(ctx context.Context, logger logr.Logger,
 	return targets, nil
 }
 
+// isExternalManagedControlPlane returns true if the control plane referenced by the Cluster is externally managed.
+func (r *Reconciler) isExternalManagedControlPlane(ctx context.Context, cluster *clusterv1.Cluster) (bool, error) {
+	if cluster.Spec.ControlPlaneRef == nil {
+		return false, nil
+	}
+	controlPlane, err := external.Get(ctx, r.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, errors.Wrap(err, "error getting control plane")
+	}
+	return contract.IsExternalManagedControlPlane(controlPlane)
+}
+
 // getMachinesFromMHC fetches Machines matched by the MachineHealthCheck's
 // label selector.
 func (r *Reconciler) getMachinesFromMHC(ctx context.Context, mhc *clusterv1.MachineHealthCheck) ([]clusterv1.Machine, error) {
@@ -280,7 +344,7 @@ func (r *Reconciler) getNodeFromMachine(ctx context.Context, clusterClient clien
 
 // healthCheckTargets health checks a slice of targets
 // and gives a data to measure the average health.
-func (r *Reconciler) healthCheckTargets(targets []healthCheckTarget, logger logr.Logger, timeoutForMachineToHaveNode metav1.Duration) ([]healthCheckTarget, []healthCheckTarget, []time.Duration) {
+func (r *Reconciler) healthCheckTargets(targets []healthCheckTarget, logger logr.Logger, timeoutForMachineToHaveNode, timeoutForInfrastructureToBeReady metav1.Duration) ([]healthCheckTarget, []healthCheckTarget, []time.Duration) {
 	var nextCheckTimes []time.Duration
 	var unhealthy []healthCheckTarget
 	var healthy []healthCheckTarget
@@ -288,7 +352,7 @@ func (r *Reconciler) healthCheckTargets(targets []healthCheckTarget, logger logr
 	for _, t := range targets {
 		logger := logger.WithValues("Target", t.string())
 		logger.V(3).Info("Health checking target")
-		needsRemediation, nextCheck := t.needsRemediation(logger, timeoutForMachineToHaveNode)
+		needsRemediation, nextCheck := t.needsRemediation(logger, timeoutForMachineToHaveNode, timeoutForInfrastructureToBeReady)
 
 		if needsRemediation {
 			unhealthy = append(unhealthy, t)
@@ -355,3 +419,39 @@ func shouldSkipRemediation(m *clusterv1.Machine) (bool, string) {
 
 	return false, ""
 }
+
+// remediationSuppressedUntil returns the end of the remediation suppression window of a MachineHealthCheck
+// active at the given time, if any; if more windows are active, the latest end is returned.
+func remediationSuppressedUntil(m *clusterv1.MachineHealthCheck, now time.Time) (time.Time, bool) {
+	var suppressedUntil time.Time
+	for _, window := range m.Spec.RemediationSuppressionWindows {
+		schedule, err := cron.Parse(window.Schedule)
+		if err != nil {
+			// NOTE: this should never happen as the schedule has already been validated.
+			continue
+		}
+		start, ok := schedule.Last(now.UTC(), window.Duration.Duration)
+		if !ok {
+			continue
+		}
+		if end := start.Add(window.Duration.Duration); end.After(now) && end.After(suppressedUntil) {
+			suppressedUntil = end
+		}
+	}
+	return suppressedUntil, !suppressedUntil.IsZero()
+}
+
+// machineRemediationSuppressedUntil returns the time until which the remediation of a Machine is suppressed
+// with the MachineRemediationSuppressedUntilAnnotation, if it is after the given time; an invalid value
+// of the annotation is ignored.
+func machineRemediationSuppressedUntil(m *clusterv1.Machine, now time.Time) (time.Time, bool) {
+	value, ok := m.GetAnnotations()[clusterv1.MachineRemediationSuppressedUntilAnnotation]
+	if !ok {
+		return time.Time{}, false
+	}
+	suppressedUntil, err := time.Parse(time.RFC3339, value)
+	if err != nil || !suppressedUntil.After(now) {
+		return time.Time{}, false
+	}
+	return suppressedUntil, true
+}
@@ -24,6 +24,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -264,6 +265,36 @@ func TestHealthCheckTargets(t *testing.T) {
 		Node:    nil,
 	}
 
+	// Targets for when the infrastructure of the Machine is not yet ready
+	testMachineInfraNotReady400s := testMachineCreated400s.DeepCopy()
+	testMachineInfraNotReady400s.Status.InfrastructureReady = false
+	conditions.MarkFalse(testMachineInfraNotReady400s, clusterv1.InfrastructureReadyCondition, clusterv1.WaitingForInfrastructureFallbackReason, clusterv1.ConditionSeverityInfo, "")
+
+	infraNotYetReadyTarget400s := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHC,
+		Machine: testMachineInfraNotReady400s,
+		Node:    nil,
+	}
+	infraNotYetReadyTarget400sCondition := newFailedHealthCheckCondition(clusterv1.InfrastructureStartupTimeoutReason, "Infrastructure failed to report ready in %s", 300*time.Second)
+
+	// Target for when the infrastructure of the Machine became ready after the Machine creation
+	testMachineInfraReady400s := testMachineCreated1200s.DeepCopy()
+	testMachineInfraReady400s.Status.Conditions = clusterv1.Conditions{
+		{
+			Type:               clusterv1.InfrastructureReadyCondition,
+			Status:             corev1.ConditionTrue,
+			LastTransitionTime: nowMinus400s,
+		},
+	}
+
+	infraReadyNodeNotYetStartedTarget400s := healthCheckTarget{
+		Cluster: cluster,
+		MHC:     testMHC,
+		Machine: testMachineInfraReady400s,
+		Node:    nil,
+	}
+
 	// Target for when the Node has been seen, but has now gone
 	nodeGoneAway := healthCheckTarget{
 		Cluster:     cluster,
@@ -344,6 +375,7 @@ func TestHealthCheckTargets(t *testing.T) {
 		desc                              string
 		targets                           []healthCheckTarget
 		timeoutForMachineToHaveNode       *time.Duration
+		timeoutForInfrastructureToBeReady *time.Duration
 		expectedHealthy                   []healthCheckTarget
 		expectedNeedsRemediation          []healthCheckTarget
 		expectedNeedsRemediationCondition []clusterv1.Condition
@@ -410,6 +442,46 @@ func TestHealthCheckTargets(t *testing.T) {
 			expectedNeedsRemediation:    []healthCheckTarget{},
 			expectedNextCheckTimes:      []time.Duration{}, // We don't have a timeout so no way to know when to re-check
 		},
+		{
+			desc:                              "when the machine infrastructure is not ready for longer than the infrastructure startup timeout",
+			targets:                           []healthCheckTarget{infraNotYetReadyTarget400s},
+			timeoutForInfrastructureToBeReady: pointer.Duration(300 * time.Second),
+			expectedHealthy:                   []healthCheckTarget{},
+			expectedNeedsRemediation:          []healthCheckTarget{infraNotYetReadyTarget400s},
+			expectedNeedsRemediationCondition: []clusterv1.Condition{infraNotYetReadyTarget400sCondition},
+			expectedNextCheckTimes:            []time.Duration{},
+		},
+		{
+			desc:                              "when the machine infrastructure is not ready for shorter than the infrastructure startup timeout",
+			targets:                           []healthCheckTarget{infraNotYetReadyTarget400s},
+			timeoutForInfrastructureToBeReady: pointer.Duration(600 * time.Second),
+			expectedHealthy:                   []healthCheckTarget{},
+			expectedNeedsRemediation:          []healthCheckTarget{},
+			expectedNextCheckTimes:            []time.Duration{200 * time.Second},
+		},
+		{
+			desc:                     "when the machine infrastructure is not ready and the infrastructure startup timeout is disabled",
+			targets:                  []healthCheckTarget{infraNotYetReadyTarget400s},
+			expectedHealthy:          []healthCheckTarget{},
+			expectedNeedsRemediation: []healthCheckTarget{},
+			expectedNextCheckTimes:   []time.Duration{timeoutForMachineToHaveNode - 400*time.Second},
+		},
+		{
+			desc:                              "when the node has not yet started for shorter than the timeout since the machine infrastructure is ready",
+			targets:                           []healthCheckTarget{infraReadyNodeNotYetStartedTarget400s},
+			timeoutForInfrastructureToBeReady: pointer.Duration(300 * time.Second),
+			expectedHealthy:                   []healthCheckTarget{},
+			expectedNeedsRemediation:          []healthCheckTarget{},
+			expectedNextCheckTimes:            []time.Duration{timeoutForMachineToHaveNode - 400*time.Second},
+		},
+		{
+			desc:                              "when the node has not yet started for longer than the timeout since the machine creation and the infrastructure startup timeout is disabled",
+			targets:                           []healthCheckTarget{infraReadyNodeNotYetStartedTarget400s},
+			expectedHealthy:                   []healthCheckTarget{},
+			expectedNeedsRemediation:          []healthCheckTarget{infraReadyNodeNotYetStartedTarget400s},
+			expectedNeedsRemediationCondition: []clusterv1.Condition{nodeNotYetStartedTarget1200sCondition},
+			expectedNextCheckTimes:            []time.Duration{},
+		},
 		{
 			desc:                              "when the machine has a failure reason",
 			targets:                           []healthCheckTarget{machineFailureReason},
@@ -443,7 +515,12 @@ func TestHealthCheckTargets(t *testing.T) {
 				timeout.Duration = *tc.timeoutForMachineToHaveNode
 			}
 
-			healthy, unhealthy, nextCheckTimes := reconciler.healthCheckTargets(tc.targets, ctrl.LoggerFrom(ctx), timeout)
+			infrastructureTimeout := metav1.Duration{}
+			if tc.timeoutForInfrastructureToBeReady != nil {
+				infrastructureTimeout.Duration = *tc.timeoutForInfrastructureToBeReady
+			}
+
+			healthy, unhealthy, nextCheckTimes := reconciler.healthCheckTargets(tc.targets, ctrl.LoggerFrom(ctx), timeout, infrastructureTimeout)
 
 			// Round durations down to nearest second account for minute differences
 			// in timing when running tests
@@ -478,6 +555,208 @@ func TestHealthCheckTargets(t *testing.T) {
 	}
 }
 
+func TestNeedsRemediationBeforeControlPlaneInitialized(t *testing.T) {
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "test-mhc",
+			Name:      "test-cluster",
+		},
+	}
+	conditions.MarkTrue(cluster, clusterv1.InfrastructureReadyCondition)
+	conditions.MarkFalse(cluster, clusterv1.ControlPlaneInitializedCondition, clusterv1.WaitingForControlPlaneProviderInitializedReason, clusterv1.ConditionSeverityInfo, "")
+
+	// Ensure the cluster infrastructure was ready earlier to prevent it interfering with
+	// NodeStartupTimeout testing.
+	conds := clusterv1.Conditions{}
+	for _, condition := range cluster.GetConditions() {
+		condition.LastTransitionTime = metav1.NewTime(condition.LastTransitionTime.Add(-1 * time.Hour))
+		conds = append(conds, condition)
+	}
+	cluster.SetConditions(conds)
+
+	mhc := &clusterv1.MachineHealthCheck{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-mhc",
+			Namespace: cluster.Namespace,
+		},
+	}
+
+	controlPlaneMachine := newTestMachine("machine1", cluster.Namespace, cluster.Name, "node1", map[string]string{clusterv1.MachineControlPlaneLabel: ""})
+	controlPlaneMachine.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(-1200 * time.Second))
+	controlPlaneMachine.Status.NodeRef = nil
+
+	workerMachine := newTestMachine("machine2", cluster.Namespace, cluster.Name, "node2", map[string]string{})
+	workerMachine.ObjectMeta.CreationTimestamp = metav1.NewTime(time.Now().Add(-1200 * time.Second))
+	workerMachine.Status.NodeRef = nil
+
+	tests := []struct {
+		name                        string
+		machine                     *clusterv1.Machine
+		externalManagedControlPlane bool
+		wantNeedsRemediation        bool
+	}{
+		{
+			name:                 "control plane machines are evaluated before the control plane is initialized",
+			machine:              controlPlaneMachine,
+			wantNeedsRemediation: true,
+		},
+		{
+			name:                        "control plane machines are not evaluated before an externally managed control plane is initialized",
+			machine:                     controlPlaneMachine,
+			externalManagedControlPlane: true,
+			wantNeedsRemediation:        false,
+		},
+		{
+			name:                 "worker machines are not evaluated before the control plane is initialized",
+			machine:              workerMachine,
+			wantNeedsRemediation: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			target := healthCheckTarget{
+				Cluster:                     cluster,
+				MHC:                         mhc,
+				Machine:                     tt.machine.DeepCopy(),
+				externalManagedControlPlane: tt.externalManagedControlPlane,
+			}
+
+			needsRemediation, _ := target.needsRemediation(ctrl.LoggerFrom(ctx), metav1.Duration{Duration: 10 * time.Minute}, metav1.Duration{})
+			g.Expect(needsRemediation).To(Equal(tt.wantNeedsRemediation))
+		})
+	}
+}
+
+func TestRemediationSuppressedUntil(t *testing.T) {
+	// 2023-10-14 is a Saturday.
+	saturday := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name                string
+		windows             []clusterv1.RemediationSuppressionWindow
+		now                 time.Time
+		wantSuppressed      bool
+		wantSuppressedUntil time.Time
+	}{
+		{
+			name: "no windows",
+			now:  saturday,
+		},
+		{
+			name: "inside a window",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			now:                 saturday.Add(time.Hour),
+			wantSuppressed:      true,
+			wantSuppressedUntil: saturday.Add(2 * time.Hour),
+		},
+		{
+			name: "before a window",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			now: saturday.Add(-time.Minute),
+		},
+		{
+			name: "at the end of a window",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			now: saturday.Add(2 * time.Hour),
+		},
+		{
+			name: "schedules are evaluated in UTC",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			now:                 saturday.Add(time.Hour).In(time.FixedZone("UTC+5", 5*60*60)),
+			wantSuppressed:      true,
+			wantSuppressedUntil: saturday.Add(2 * time.Hour),
+		},
+		{
+			name: "overlapping windows return the latest end",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+				{Schedule: "30 2 * * *", Duration: metav1.Duration{Duration: 3 * time.Hour}},
+			},
+			now:                 saturday.Add(time.Hour),
+			wantSuppressed:      true,
+			wantSuppressedUntil: saturday.Add(30*time.Minute + 3*time.Hour),
+		},
+		{
+			name: "invalid schedules are ignored",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "invalid", Duration: metav1.Duration{Duration: 2 * time.Hour}},
+			},
+			now: saturday.Add(time.Hour),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mhc := &clusterv1.MachineHealthCheck{
+				Spec: clusterv1.MachineHealthCheckSpec{
+					RemediationSuppressionWindows: tt.windows,
+				},
+			}
+
+			suppressedUntil, suppressed := remediationSuppressedUntil(mhc, tt.now)
+			g.Expect(suppressed).To(Equal(tt.wantSuppressed))
+			if tt.wantSuppressed {
+				g.Expect(suppressedUntil.Equal(tt.wantSuppressedUntil)).To(BeTrue())
+			}
+		})
+	}
+}
+
+func TestMachineRemediationSuppressedUntil(t *testing.T) {
+	now := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		wantSuppressed bool
+	}{
+		{
+			name: "no annotation",
+		},
+		{
+			name:           "annotation in the future",
+			annotations:    map[string]string{clusterv1.MachineRemediationSuppressedUntilAnnotation: "2023-10-14T03:00:00Z"},
+			wantSuppressed: true,
+		},
+		{
+			name:        "annotation in the past",
+			annotations: map[string]string{clusterv1.MachineRemediationSuppressedUntilAnnotation: "2023-10-14T01:00:00Z"},
+		},
+		{
+			name:        "invalid annotation",
+			annotations: map[string]string{clusterv1.MachineRemediationSuppressedUntilAnnotation: "tomorrow"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			machine := &clusterv1.Machine{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: tt.annotations,
+				},
+			}
+
+			suppressedUntil, suppressed := machineRemediationSuppressedUntil(machine, now)
+			g.Expect(suppressed).To(Equal(tt.wantSuppressed))
+			if tt.wantSuppressed {
+				g.Expect(suppressedUntil.Equal(now.Add(time.Hour))).To(BeTrue())
+			}
+		})
+	}
+}
+
 func newTestMachine(name, namespace, clusterName, nodeName string, labels map[string]string) *clusterv1.Machine {
 	// Copy the labels so that the map is unique to each test Machine
 	l := make(map[string]string)
@@ -28,6 +28,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/apiserver/pkg/storage/names"
@@ -38,23 +39,34 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/controllers/noderefutil"
 	"sigs.k8s.io/cluster-api/controllers/remote"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
+	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/internal/controllers/machine"
+	"sigs.k8s.io/cluster-api/internal/controllers/machinedeployment/mdutil"
+	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
-	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/collections"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 	"sigs.k8s.io/cluster-api/util/labels/format"
 	clog "sigs.k8s.io/cluster-api/util/log"
 	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/paused"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/priority"
+	capirecord "sigs.k8s.io/cluster-api/util/record"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 var (
@@ -76,6 +88,7 @@ const machineSetManagerName = "capi-machineset"
 // +kubebuilder:rbac:groups=core,resources=nodes,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinesets;machinesets/status;machinesets/finalizers,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=cluster.x-k8s.io,resources=machinedeployments,verbs=get;list;watch
 
 // Reconciler reconciles a MachineSet object.
 type Reconciler struct {
@@ -84,9 +97,19 @@ type Reconciler struct {
 	APIReader                 client.Reader
 	Tracker                   *remote.ClusterCacheTracker
 
+	// RuntimeClient is used to call the BeforeMachineInfrastructureProvision hook before creating Machines.
+	// It is only used if the RuntimeSDK feature gate is enabled.
+	RuntimeClient runtimeclient.Client
+
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
+	// ReconcilePriority configures the fast lane in the reconcile queue for MachineSets being deleted or recently created.
+	ReconcilePriority priority.Options
+
 	ssaCache ssa.Cache
 	recorder record.EventRecorder
 }
@@ -98,30 +121,40 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 	}
 
 	err = ctrl.NewControllerManagedBy(mgr).
-		For(&clusterv1.MachineSet{}).
+		For(&clusterv1.MachineSet{}, builder.WithPredicates(r.ReconcilePriority.HighPriority(ctrl.LoggerFrom(ctx)), predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard))).
+		Watches(&clusterv1.MachineSet{}, r.ReconcilePriority.LowPriorityHandler()).
 		Owns(&clusterv1.Machine{}).
 		Watches(
 			&clusterv1.Machine{},
 			handler.EnqueueRequestsFromMapFunc(r.MachineToMachineSets),
 		).
+		Watches(
+			&clusterv1.MachineDeployment{},
+			handler.EnqueueRequestsFromMapFunc(r.MachineDeploymentToMachineSets),
+			// Only changes to the MachineDeployment spec (e.g. to the template) can change the UpToDate condition of the Machines.
+			builder.WithPredicates(predicate.GenerationChangedPredicate{}),
+		).
 		WithOptions(options).
-		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		WithEventFilter(predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
 		Watches(
 			&clusterv1.Cluster{},
 			handler.EnqueueRequestsFromMapFunc(clusterToMachineSets),
 			builder.WithPredicates(
 				// TODO: should this wait for Cluster.Status.InfrastructureReady similar to Infra Machine resources?
 				predicates.All(ctrl.LoggerFrom(ctx),
-					predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+					predicates.Any(ctrl.LoggerFrom(ctx),
+						predicates.ClusterUnpaused(ctrl.LoggerFrom(ctx)),
+						predicates.ClusterPausedTransitions(ctrl.LoggerFrom(ctx)),
+					),
 					predicates.ResourceHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue),
 				),
 			),
-		).Complete(r)
+		).Complete(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.MachineSet{}), options))
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
 	}
 
-	r.recorder = mgr.GetEventRecorderFor("machineset-controller")
+	r.recorder = capirecord.NewDeduplicatingRecorder(mgr.GetEventRecorderFor("machineset-controller"), capirecord.DefaultDeduplicationWindow)
 	r.ssaCache = ssa.NewCache()
 	return nil
 }
@@ -138,6 +171,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
+	// Return early if the MachineSet does not belong to the shard of this manager.
+	if !r.Shard.Contains(machineSet) {
+		return ctrl.Result{}, nil
+	}
+
 	// AddOwners adds the owners of MachineSet as k/v pairs to the logger.
 	// Specifically, it will add MachineDeployment.
 	ctx, log, err := clog.AddOwners(ctx, r.Client, machineSet)
@@ -153,8 +191,12 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		return ctrl.Result{}, err
 	}
 
-	// Return early if the object or Cluster is paused.
-	if annotations.IsPaused(cluster, machineSet) {
+	// Set or remove the Paused condition, then return early if the object or Cluster is paused.
+	isPaused, _, err := paused.EnsurePausedCondition(ctx, r.Client, cluster, machineSet)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if isPaused {
 		log.Info("Reconciliation is paused for this object")
 		return ctrl.Result{}, nil
 	}
@@ -186,7 +228,7 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 			log.V(5).Info("Requeuing because another worker has the lock on the ClusterCacheTracker")
 			return ctrl.Result{Requeue: true}, nil
 		}
-		r.recorder.Eventf(machineSet, corev1.EventTypeWarning, "ReconcileError", "%v", err)
+		r.recorder.Eventf(machineSet, corev1.EventTypeWarning, clusterv1.ReconcileErrorEventReason, "%v", err)
 	}
 	return result, err
 }
@@ -286,11 +328,11 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 		if metav1.GetControllerOf(machine) == nil {
 			if err := r.adoptOrphan(ctx, machineSet, machine); err != nil {
 				log.Error(err, "Failed to adopt Machine")
-				r.recorder.Eventf(machineSet, corev1.EventTypeWarning, "FailedAdopt", "Failed to adopt Machine %q: %v", machine.Name, err)
+				r.recorder.Eventf(machineSet, corev1.EventTypeWarning, clusterv1.AdoptMachineFailedEventReason, "Failed to adopt Machine %q: %v", machine.Name, err)
 				continue
 			}
 			log.Info("Adopted Machine")
-			r.recorder.Eventf(machineSet, corev1.EventTypeNormal, "SuccessfulAdopt", "Adopted Machine %q", machine.Name)
+			r.recorder.Eventf(machineSet, corev1.EventTypeNormal, clusterv1.AdoptMachineSucceededEventReason, "Adopted Machine %q", machine.Name)
 		}
 
 		filteredMachines = append(filteredMachines, machine)
@@ -304,10 +346,18 @@ func (r *Reconciler) reconcile(ctx context.Context, cluster *clusterv1.Cluster,
 	}
 	result = util.LowestNonZeroResult(result, reconcileUnhealthyMachinesResult)
 
+	if err := r.reconcileInterruptedMachines(ctx, machineSet, filteredMachines); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to reconcile interrupted machines")
+	}
+
 	if err := r.syncMachines(ctx, machineSet, filteredMachines); err != nil {
 		return ctrl.Result{}, errors.Wrap(err, "failed to update Machines")
 	}
 
+	if err := r.reconcileUpToDateCondition(ctx, machineSet, filteredMachines); err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to set UpToDate condition on Machines")
+	}
+
 	syncReplicasResult, syncErr := r.syncReplicas(ctx, cluster, machineSet, filteredMachines)
 	result = util.LowestNonZeroResult(result, syncReplicasResult)
 
@@ -373,6 +423,11 @@ func (r *Reconciler) syncMachines(ctx context.Context, machineSet *clusterv1.Mac
 		if err := ssa.CleanUpManagedFieldsForSSAAdoption(ctx, r.Client, m, machineSetManagerName); err != nil {
 			return errors.Wrapf(err, "failed to update machine: failed to adjust the managedFields of the Machine %q", m.Name)
 		}
+		// Drop the ownership of fields still co-owned by "before-first-apply", the manager assigned by the API server
+		// at the first apply; otherwise, those fields would not be dropped when they are removed from the MachineSet.
+		if err := ssa.DropCoOwnedManagedFields(ctx, r.Client, m, machineSetManagerName); err != nil {
+			return errors.Wrapf(err, "failed to update machine: failed to drop co-owned managedFields of the Machine %q", m.Name)
+		}
 
 		// Update Machine to propagate in-place mutable fields from the MachineSet.
 		updatedMachine := r.computeDesiredMachine(machineSet, m)
@@ -426,13 +481,81 @@ func (r *Reconciler) syncMachines(ctx context.Context, machineSet *clusterv1.Mac
 	return nil
 }
 
+// reconcileUpToDateCondition sets the UpToDate condition on the Machines of the MachineSet, reporting whether
+// they match the current template of the MachineDeployment owning the MachineSet.
+// Note: The Machines of a stand-alone MachineSet are always up to date, because changes to the MachineSet template
+// are propagated in-place to the existing Machines.
+func (r *Reconciler) reconcileUpToDateCondition(ctx context.Context, machineSet *clusterv1.MachineSet, machines []*clusterv1.Machine) error {
+	md, err := r.getOwnerMachineDeployment(ctx, machineSet)
+	if err != nil {
+		return err
+	}
+
+	var notUpToDateMessage string
+	if md != nil {
+		reconciliationTime := metav1.Now()
+		if mdutil.FindNewMachineSet(md, []*clusterv1.MachineSet{machineSet}, &reconciliationTime) == nil {
+			notUpToDateMessage = fmt.Sprintf("MachineSet %s does not match the current template of MachineDeployment %s", machineSet.Name, md.Name)
+		}
+	}
+
+	var errs []error
+	for _, m := range machines {
+		// If the machine is already being deleted, we don't need to update it.
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+
+		patchHelper, err := patch.NewHelper(m, r.Client)
+		if err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to create patch helper for Machine %s", klog.KObj(m)))
+			continue
+		}
+		if notUpToDateMessage == "" {
+			conditions.MarkTrue(m, clusterv1.MachineUpToDateCondition)
+		} else {
+			conditions.MarkFalse(m, clusterv1.MachineUpToDateCondition, clusterv1.MachineNotUpToDateReason, clusterv1.ConditionSeverityInfo, notUpToDateMessage)
+		}
+		if err := patchHelper.Patch(ctx, m, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.MachineUpToDateCondition}}); err != nil {
+			errs = append(errs, errors.Wrapf(err, "failed to patch Machine %s", klog.KObj(m)))
+		}
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// getOwnerMachineDeployment returns the MachineDeployment controlling the MachineSet, if any.
+func (r *Reconciler) getOwnerMachineDeployment(ctx context.Context, machineSet *clusterv1.MachineSet) (*clusterv1.MachineDeployment, error) {
+	ref := metav1.GetControllerOf(machineSet)
+	if ref == nil {
+		return nil, nil
+	}
+	gv, err := schema.ParseGroupVersion(ref.APIVersion)
+	if err != nil {
+		return nil, err
+	}
+	if ref.Kind != "MachineDeployment" || gv.Group != clusterv1.GroupVersion.Group {
+		return nil, nil
+	}
+
+	md := &clusterv1.MachineDeployment{}
+	if err := r.Client.Get(ctx, client.ObjectKey{Namespace: machineSet.Namespace, Name: ref.Name}, md); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrapf(err, "failed to get MachineDeployment %s", klog.KRef(machineSet.Namespace, ref.Name))
+	}
+	return md, nil
+}
+
 // syncReplicas scales Machine resources up or down.
 func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	if ms.Spec.Replicas == nil {
 		return ctrl.Result{}, errors.Errorf("the Replicas field in Spec for machineset %v is nil, this should not be allowed", ms.Name)
 	}
-	diff := len(machines) - int(*(ms.Spec.Replicas))
+	// Interrupted Machines are not counted, so replacements are created before the infrastructure goes away;
+	// the interrupted Machines are deleted by reconcileInterruptedMachines once the replacements exist.
+	diff := len(machines) - countInterruptedMachines(machines) - int(*(ms.Spec.Replicas))
 	switch {
 	case diff < 0:
 		diff *= -1
@@ -454,6 +577,10 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 			return result, err
 		}
 
+		if result, err := r.callBeforeMachineInfrastructureProvisionHook(ctx, cluster, ms, diff); err != nil || !result.IsZero() {
+			return result, err
+		}
+
 		var (
 			machineList []*clusterv1.Machine
 			errs        []error
@@ -463,6 +590,9 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 			// Create a new logger so the global logger is not modified.
 			log := log
 			machine := r.computeDesiredMachine(ms, nil)
+			if isFailureDomainRebalanceEnabled(ms) {
+				machine.Spec.FailureDomain = pickFailureDomain(cluster, append(machines, machineList...))
+			}
 			// Clone and set the infrastructure and bootstrap references.
 			var (
 				infraRef, bootstrapRef *corev1.ObjectReference
@@ -522,7 +652,7 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 			// Create the Machine.
 			if err := ssa.Patch(ctx, r.Client, machineSetManagerName, machine); err != nil {
 				log.Error(err, "Error while creating a machine")
-				r.recorder.Eventf(ms, corev1.EventTypeWarning, "FailedCreate", "Failed to create machine: %v", err)
+				r.recorder.Eventf(ms, corev1.EventTypeWarning, clusterv1.CreateMachineFailedEventReason, "Failed to create machine: %v", err)
 				errs = append(errs, err)
 				conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.MachineCreationFailedReason,
 					clusterv1.ConditionSeverityError, err.Error())
@@ -540,7 +670,7 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 			}
 
 			log.Info(fmt.Sprintf("Created machine %d of %d", i+1, diff), "Machine", klog.KObj(machine))
-			r.recorder.Eventf(ms, corev1.EventTypeNormal, "SuccessfulCreate", "Created machine %q", machine.Name)
+			r.recorder.Eventf(ms, corev1.EventTypeNormal, clusterv1.CreateMachineSucceededEventReason, "Created machine %q", machine.Name)
 			machineList = append(machineList, machine)
 		}
 
@@ -564,11 +694,11 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 				log.Info(fmt.Sprintf("Deleting machine %d of %d", i+1, diff))
 				if err := r.Client.Delete(ctx, machine); err != nil {
 					log.Error(err, "Unable to delete Machine")
-					r.recorder.Eventf(ms, corev1.EventTypeWarning, "FailedDelete", "Failed to delete machine %q: %v", machine.Name, err)
+					r.recorder.Eventf(ms, corev1.EventTypeWarning, clusterv1.DeleteMachineFailedEventReason, "Failed to delete machine %q: %v", machine.Name, err)
 					errs = append(errs, err)
 					continue
 				}
-				r.recorder.Eventf(ms, corev1.EventTypeNormal, "SuccessfulDelete", "Deleted machine %q", machine.Name)
+				r.recorder.Eventf(ms, corev1.EventTypeNormal, clusterv1.DeleteMachineSucceededEventReason, "Deleted machine %q", machine.Name)
 			} else {
 				log.Info(fmt.Sprintf("Waiting for machine %d of %d to be deleted", i+1, diff))
 			}
@@ -580,6 +710,35 @@ func (r *Reconciler) syncReplicas(ctx context.Context, cluster *clusterv1.Cluste
 		return ctrl.Result{}, r.waitForMachineDeletion(ctx, machinesToDelete)
 	}
 
+	return r.rebalanceFailureDomains(ctx, cluster, ms, machines)
+}
+
+// callBeforeMachineInfrastructureProvisionHook calls the BeforeMachineInfrastructureProvision hook before the
+// infrastructure and bootstrap objects of new Machines are created, so Runtime Extensions can e.g. validate quota
+// or capacity and hold the creation instead of leaving half-created Machines behind.
+func (r *Reconciler) callBeforeMachineInfrastructureProvisionHook(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, machinesToCreate int) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !feature.Gates.Enabled(feature.RuntimeSDK) || r.RuntimeClient == nil {
+		return ctrl.Result{}, nil
+	}
+
+	hookRequest := &runtimehooksv1.BeforeMachineInfrastructureProvisionRequest{
+		Cluster:          *cluster,
+		MachineSet:       *ms,
+		MachinesToCreate: int32(machinesToCreate),
+	}
+	hookResponse := &runtimehooksv1.BeforeMachineInfrastructureProvisionResponse{}
+	if err := r.RuntimeClient.CallAllExtensions(ctx, runtimehooksv1.BeforeMachineInfrastructureProvision, ms, hookRequest, hookResponse); err != nil {
+		conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.InfrastructureProvisionHookFailedReason, clusterv1.ConditionSeverityError, err.Error())
+		return ctrl.Result{}, err
+	}
+	if hookResponse.RetryAfterSeconds != 0 {
+		log.Info(fmt.Sprintf("Creation of Machines is blocked by %s hook", runtimecatalog.HookName(runtimehooksv1.BeforeMachineInfrastructureProvision)), "message", hookResponse.Message)
+		conditions.MarkFalse(ms, clusterv1.MachinesCreatedCondition, clusterv1.InfrastructureProvisionHookBlockingReason, clusterv1.ConditionSeverityInfo,
+			"Creation of %d Machine(s) is blocked by %s hook: %s", machinesToCreate, runtimecatalog.HookName(runtimehooksv1.BeforeMachineInfrastructureProvision), hookResponse.Message)
+		return ctrl.Result{RequeueAfter: time.Duration(hookResponse.RetryAfterSeconds) * time.Second}, nil
+	}
 	return ctrl.Result{}, nil
 }
 
@@ -630,6 +789,11 @@ func (r *Reconciler) computeDesiredMachine(machineSet *clusterv1.MachineSet, exi
 		desiredMachine.SetUID(existingMachine.UID)
 		desiredMachine.Spec.Bootstrap.ConfigRef = existingMachine.Spec.Bootstrap.ConfigRef
 		desiredMachine.Spec.InfrastructureRef = existingMachine.Spec.InfrastructureRef
+		// If failure domain rebalancing is enabled, the failure domain of the existing Machine has been picked
+		// by the MachineSet controller when creating the Machine.
+		if isFailureDomainRebalanceEnabled(machineSet) {
+			desiredMachine.Spec.FailureDomain = existingMachine.Spec.FailureDomain
+		}
 	}
 
 	// Set the in-place mutable fields.
@@ -646,6 +810,7 @@ func (r *Reconciler) computeDesiredMachine(machineSet *clusterv1.MachineSet, exi
 	desiredMachine.Spec.NodeDrainTimeout = machineSet.Spec.Template.Spec.NodeDrainTimeout
 	desiredMachine.Spec.NodeDeletionTimeout = machineSet.Spec.Template.Spec.NodeDeletionTimeout
 	desiredMachine.Spec.NodeVolumeDetachTimeout = machineSet.Spec.Template.Spec.NodeVolumeDetachTimeout
+	desiredMachine.Spec.Taints = machineSet.Spec.Template.Spec.Taints
 
 	return desiredMachine
 }
@@ -804,6 +969,29 @@ func (r *Reconciler) MachineToMachineSets(ctx context.Context, o client.Object)
 	return result
 }
 
+// MachineDeploymentToMachineSets is a handler.ToRequestsFunc to be used to enqueue requests for reconciliation
+// for MachineSets belonging to a MachineDeployment, e.g. to update the UpToDate condition of their Machines.
+func (r *Reconciler) MachineDeploymentToMachineSets(ctx context.Context, o client.Object) []ctrl.Request {
+	md, ok := o.(*clusterv1.MachineDeployment)
+	if !ok {
+		panic(fmt.Sprintf("Expected a MachineDeployment but got a %T", o))
+	}
+
+	log := ctrl.LoggerFrom(ctx, "MachineDeployment", klog.KObj(md))
+
+	machineSets := &clusterv1.MachineSetList{}
+	if err := r.Client.List(ctx, machineSets, client.InNamespace(md.Namespace), client.MatchingLabels{clusterv1.MachineDeploymentNameLabel: md.Name}); err != nil {
+		log.Error(err, "Failed getting MachineSets for MachineDeployment")
+		return nil
+	}
+
+	result := []ctrl.Request{}
+	for _, ms := range machineSets.Items {
+		result = append(result, ctrl.Request{NamespacedName: client.ObjectKey{Namespace: ms.Namespace, Name: ms.Name}})
+	}
+	return result
+}
+
 func (r *Reconciler) getMachineSetsForMachine(ctx context.Context, m *clusterv1.Machine) ([]*clusterv1.MachineSet, error) {
 	if len(m.Labels) == 0 {
 		return nil, fmt.Errorf("machine %v has no labels, this is unexpected", client.ObjectKeyFromObject(m))
@@ -959,6 +1147,53 @@ func (r *Reconciler) getMachineNode(ctx context.Context, cluster *clusterv1.Clus
 	return node, nil
 }
 
+// reconcileInterruptedMachines deletes the Machines whose infrastructure is going to be interrupted, e.g.
+// Machines backed by spot instances which are going to be reclaimed, once replacements for them have been created.
+func (r *Reconciler) reconcileInterruptedMachines(ctx context.Context, ms *clusterv1.MachineSet, filteredMachines []*clusterv1.Machine) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	interrupted := countInterruptedMachines(filteredMachines)
+	if interrupted == 0 || ms.Spec.Replicas == nil {
+		return nil
+	}
+
+	// Wait for syncReplicas to create the replacements before deleting the interrupted Machines.
+	if len(filteredMachines)-interrupted < int(*(ms.Spec.Replicas)) {
+		log.V(4).Info(fmt.Sprintf("Waiting for replacements of %d interrupted machines to be created", interrupted))
+		return nil
+	}
+
+	var errs []error
+	for _, m := range filteredMachines {
+		if !isMachineInterrupted(m) {
+			continue
+		}
+		log.Info(fmt.Sprintf("Deleting Machine %s because its infrastructure is going to be interrupted", klog.KObj(m)))
+		if err := r.Client.Delete(ctx, m); err != nil && !apierrors.IsNotFound(err) {
+			r.recorder.Eventf(ms, corev1.EventTypeWarning, clusterv1.DeleteMachineFailedEventReason, "Failed to delete machine %q: %v", m.Name, err)
+			errs = append(errs, errors.Wrapf(err, "failed to delete Machine %s", klog.KObj(m)))
+			continue
+		}
+		r.recorder.Eventf(ms, corev1.EventTypeNormal, clusterv1.DeleteMachineSucceededEventReason, "Deleted machine %q", m.Name)
+	}
+	return kerrors.NewAggregate(errs)
+}
+
+// isMachineInterrupted returns true if the infrastructure of a Machine which is not deleting yet is going to be interrupted.
+func isMachineInterrupted(m *clusterv1.Machine) bool {
+	return m.DeletionTimestamp.IsZero() && conditions.IsTrue(m, clusterv1.MachineInterruptionNoticeCondition)
+}
+
+func countInterruptedMachines(machines []*clusterv1.Machine) int {
+	count := 0
+	for _, m := range machines {
+		if isMachineInterrupted(m) {
+			count++
+		}
+	}
+	return count
+}
+
 func (r *Reconciler) reconcileUnhealthyMachines(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, filteredMachines []*clusterv1.Machine) (ctrl.Result, error) {
 	log := ctrl.LoggerFrom(ctx)
 	// List all unhealthy machines.
@@ -28,14 +28,18 @@ import (
 	"k8s.io/client-go/tools/record"
 	utilfeature "k8s.io/component-base/featuregate/testing"
 	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/controllers/external"
+	runtimecatalog "sigs.k8s.io/cluster-api/exp/runtime/catalog"
+	runtimehooksv1 "sigs.k8s.io/cluster-api/exp/runtime/hooks/api/v1alpha1"
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/contract"
+	fakeruntimeclient "sigs.k8s.io/cluster-api/internal/runtime/client/fake"
 	"sigs.k8s.io/cluster-api/internal/test/builder"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
@@ -1484,6 +1488,164 @@ func TestMachineSetReconciler_reconcileUnhealthyMachines(t *testing.T) {
 	})
 }
 
+func TestMachineSetReconciler_reconcileInterruptedMachines(t *testing.T) {
+	machine := func(name string, interrupted bool) *clusterv1.Machine {
+		m := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		}
+		if interrupted {
+			conditions.MarkTrue(m, clusterv1.MachineInterruptionNoticeCondition)
+		}
+		return m
+	}
+	machineSet := func(replicas int32) *clusterv1.MachineSet {
+		return &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machineset",
+				Namespace: "default",
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: pointer.Int32(replicas),
+			},
+		}
+	}
+
+	t.Run("should not delete interrupted machines before replacements are created", func(t *testing.T) {
+		g := NewWithT(t)
+
+		interruptedMachine := machine("interrupted-machine", true)
+		healthyMachine := machine("healthy-machine", false)
+		machines := []*clusterv1.Machine{interruptedMachine, healthyMachine}
+
+		fakeClient := fake.NewClientBuilder().WithObjects(interruptedMachine, healthyMachine).Build()
+		r := &Reconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+		g.Expect(r.reconcileInterruptedMachines(ctx, machineSet(2), machines)).To(Succeed())
+
+		// Verify the interrupted machine is not deleted.
+		m := &clusterv1.Machine{}
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(interruptedMachine), m)).To(Succeed())
+	})
+
+	t.Run("should delete interrupted machines once replacements are created", func(t *testing.T) {
+		g := NewWithT(t)
+
+		interruptedMachine := machine("interrupted-machine", true)
+		healthyMachine := machine("healthy-machine", false)
+		replacementMachine := machine("replacement-machine", false)
+		machines := []*clusterv1.Machine{interruptedMachine, healthyMachine, replacementMachine}
+
+		fakeClient := fake.NewClientBuilder().WithObjects(interruptedMachine, healthyMachine, replacementMachine).Build()
+		r := &Reconciler{
+			Client:   fakeClient,
+			recorder: record.NewFakeRecorder(32),
+		}
+		g.Expect(r.reconcileInterruptedMachines(ctx, machineSet(2), machines)).To(Succeed())
+
+		// Verify the interrupted machine is deleted.
+		m := &clusterv1.Machine{}
+		err := r.Client.Get(ctx, client.ObjectKeyFromObject(interruptedMachine), m)
+		g.Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		// Verify the other machines are not deleted.
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(healthyMachine), m)).To(Succeed())
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(replacementMachine), m)).To(Succeed())
+	})
+}
+
+func TestMachineSetReconciler_reconcileUpToDateCondition(t *testing.T) {
+	machineDeployment := func(version string) *clusterv1.MachineDeployment {
+		return &clusterv1.MachineDeployment{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-md",
+				Namespace: "default",
+			},
+			Spec: clusterv1.MachineDeploymentSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String(version),
+					},
+				},
+			},
+		}
+	}
+	machineSet := func(md *clusterv1.MachineDeployment) *clusterv1.MachineSet {
+		ms := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machineset",
+				Namespace: "default",
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{
+						Version: pointer.String("v1.26.0"),
+					},
+				},
+			},
+		}
+		if md != nil {
+			ms.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(md, clusterv1.GroupVersion.WithKind("MachineDeployment"))}
+		}
+		return ms
+	}
+	machine := func(name string) *clusterv1.Machine {
+		return &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: "default",
+			},
+		}
+	}
+
+	tests := []struct {
+		name              string
+		machineDeployment *clusterv1.MachineDeployment
+		wantUpToDate      bool
+	}{
+		{
+			name:         "Machines of a stand-alone MachineSet are up to date",
+			wantUpToDate: true,
+		},
+		{
+			name:              "Machines are up to date if the MachineSet matches the MachineDeployment template",
+			machineDeployment: machineDeployment("v1.26.0"),
+			wantUpToDate:      true,
+		},
+		{
+			name:              "Machines are not up to date if the MachineSet does not match the MachineDeployment template",
+			machineDeployment: machineDeployment("v1.27.0"),
+			wantUpToDate:      false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := machine("machine")
+			objs := []client.Object{m}
+			if tt.machineDeployment != nil {
+				objs = append(objs, tt.machineDeployment)
+			}
+			r := &Reconciler{
+				Client: fake.NewClientBuilder().WithObjects(objs...).WithStatusSubresource(&clusterv1.Machine{}).Build(),
+			}
+			g.Expect(r.reconcileUpToDateCondition(ctx, machineSet(tt.machineDeployment), []*clusterv1.Machine{m})).To(Succeed())
+
+			got := &clusterv1.Machine{}
+			g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(m), got)).To(Succeed())
+			g.Expect(conditions.Has(got, clusterv1.MachineUpToDateCondition)).To(BeTrue())
+			g.Expect(conditions.IsTrue(got, clusterv1.MachineUpToDateCondition)).To(Equal(tt.wantUpToDate))
+			if !tt.wantUpToDate {
+				g.Expect(conditions.GetReason(got, clusterv1.MachineUpToDateCondition)).To(Equal(clusterv1.MachineNotUpToDateReason))
+			}
+		})
+	}
+}
+
 func TestMachineSetReconciler_syncReplicas(t *testing.T) {
 	t.Run("should hold off on creating new machines when preflight checks do not pass", func(t *testing.T) {
 		defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachineSetPreflightChecks, true)()
@@ -1540,6 +1702,50 @@ func TestMachineSetReconciler_syncReplicas(t *testing.T) {
 		g.Expect(r.Client.List(ctx, machineList)).To(Succeed())
 		g.Expect(machineList.Items).To(BeEmpty(), "There should not be any machines")
 	})
+
+	t.Run("should not count interrupted machines", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-cluster",
+				Namespace: "default",
+			},
+		}
+		machineSet := &clusterv1.MachineSet{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-machineset",
+				Namespace: "default",
+				Annotations: map[string]string{
+					clusterv1.DisableMachineCreateAnnotation: "",
+				},
+			},
+			Spec: clusterv1.MachineSetSpec{
+				Replicas: pointer.Int32(1),
+			},
+		}
+		interruptedMachine := &clusterv1.Machine{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "interrupted-machine",
+				Namespace: "default",
+			},
+		}
+		conditions.MarkTrue(interruptedMachine, clusterv1.MachineInterruptionNoticeCondition)
+
+		fakeClient := fake.NewClientBuilder().WithObjects(machineSet, interruptedMachine).Build()
+		r := &Reconciler{
+			Client:                    fakeClient,
+			UnstructuredCachingClient: fakeClient,
+			recorder:                  record.NewFakeRecorder(32),
+		}
+		// NOTE: Machine creation is disabled, so syncReplicas returns as soon as it decides to scale up.
+		result, err := r.syncReplicas(ctx, cluster, machineSet, []*clusterv1.Machine{interruptedMachine})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(result.IsZero()).To(BeTrue())
+
+		// Verify the interrupted machine is not deleted to scale down.
+		g.Expect(r.Client.Get(ctx, client.ObjectKeyFromObject(interruptedMachine), &clusterv1.Machine{})).To(Succeed())
+	})
 }
 
 func TestComputeDesiredMachine(t *testing.T) {
@@ -1586,6 +1792,9 @@ func TestComputeDesiredMachine(t *testing.T) {
 					NodeDrainTimeout:        duration10s,
 					NodeVolumeDetachTimeout: duration10s,
 					NodeDeletionTimeout:     duration10s,
+					Taints: []corev1.Taint{
+						{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+					},
 				},
 			},
 		},
@@ -1608,6 +1817,9 @@ func TestComputeDesiredMachine(t *testing.T) {
 			NodeDrainTimeout:        duration10s,
 			NodeVolumeDetachTimeout: duration10s,
 			NodeDeletionTimeout:     duration10s,
+			Taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			},
 		},
 	}
 
@@ -1633,6 +1845,7 @@ func TestComputeDesiredMachine(t *testing.T) {
 	existingMachine.Spec.NodeDrainTimeout = duration5s
 	existingMachine.Spec.NodeDeletionTimeout = duration5s
 	existingMachine.Spec.NodeVolumeDetachTimeout = duration5s
+	existingMachine.Spec.Taints = nil
 
 	expectedUpdatedMachine := skeletonMachine.DeepCopy()
 	expectedUpdatedMachine.Name = existingMachine.Name
@@ -1692,3 +1905,96 @@ func assertMachine(g *WithT, actualMachine *clusterv1.Machine, expectedMachine *
 		g.Expect(actualMachine.Finalizers).Should(Equal(expectedMachine.Finalizers))
 	}
 }
+
+func TestMachineSetReconciler_callBeforeMachineInfrastructureProvisionHook(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.RuntimeSDK, true)()
+
+	catalog := runtimecatalog.New()
+	_ = runtimehooksv1.AddToCatalog(catalog)
+	gvh, err := catalog.GroupVersionHook(runtimehooksv1.BeforeMachineInfrastructureProvision)
+	if err != nil {
+		panic(err)
+	}
+
+	tests := []struct {
+		name          string
+		hookResponse  *runtimehooksv1.BeforeMachineInfrastructureProvisionResponse
+		wantResult    ctrl.Result
+		wantErr       bool
+		wantCondition *clusterv1.Condition
+	}{
+		{
+			name: "should return a requeue response when the hook is blocking",
+			hookResponse: &runtimehooksv1.BeforeMachineInfrastructureProvisionResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{
+						Status:  runtimehooksv1.ResponseStatusSuccess,
+						Message: "quota exhausted",
+					},
+					RetryAfterSeconds: int32(10),
+				},
+			},
+			wantResult:    ctrl.Result{RequeueAfter: 10 * time.Second},
+			wantCondition: conditions.FalseCondition(clusterv1.MachinesCreatedCondition, clusterv1.InfrastructureProvisionHookBlockingReason, clusterv1.ConditionSeverityInfo, ""),
+		},
+		{
+			name: "should return an empty response when the hook is not blocking",
+			hookResponse: &runtimehooksv1.BeforeMachineInfrastructureProvisionResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{
+						Status: runtimehooksv1.ResponseStatusSuccess,
+					},
+				},
+			},
+			wantResult: ctrl.Result{},
+		},
+		{
+			name: "should error when the hook returns a failure response",
+			hookResponse: &runtimehooksv1.BeforeMachineInfrastructureProvisionResponse{
+				CommonRetryResponse: runtimehooksv1.CommonRetryResponse{
+					CommonResponse: runtimehooksv1.CommonResponse{
+						Status: runtimehooksv1.ResponseStatusFailure,
+					},
+				},
+			},
+			wantErr:       true,
+			wantCondition: conditions.FalseCondition(clusterv1.MachinesCreatedCondition, clusterv1.InfrastructureProvisionHookFailedReason, clusterv1.ConditionSeverityError, ""),
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			runtimeClient := fakeruntimeclient.NewRuntimeClientBuilder().
+				WithCatalog(catalog).
+				WithCallAllExtensionResponses(map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject{
+					gvh: tt.hookResponse,
+				}).
+				Build()
+
+			r := &Reconciler{
+				RuntimeClient: runtimeClient,
+			}
+			cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault}}
+			ms := &clusterv1.MachineSet{ObjectMeta: metav1.ObjectMeta{Name: "test-ms", Namespace: metav1.NamespaceDefault}}
+
+			res, err := r.callBeforeMachineInfrastructureProvisionHook(ctx, cluster, ms, 3)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(res).To(BeComparableTo(tt.wantResult))
+			}
+			g.Expect(runtimeClient.CallAllCount(runtimehooksv1.BeforeMachineInfrastructureProvision)).To(Equal(1))
+
+			if tt.wantCondition == nil {
+				g.Expect(conditions.Has(ms, clusterv1.MachinesCreatedCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.IsFalse(ms, clusterv1.MachinesCreatedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(ms, clusterv1.MachinesCreatedCondition)).To(Equal(tt.wantCondition.Reason))
+			g.Expect(conditions.GetSeverity(ms, clusterv1.MachinesCreatedCondition)).To(HaveValue(Equal(tt.wantCondition.Severity)))
+		})
+	}
+}
@@ -149,5 +149,8 @@ func isMachineHealthy(machine *clusterv1.Machine) bool {
 	if nodeHealthyCondition != nil && nodeHealthyCondition.Status != corev1.ConditionTrue {
 		return false
 	}
+	if conditions.IsTrue(machine, clusterv1.MachineInterruptionNoticeCondition) {
+		return false
+	}
 	return true
 }
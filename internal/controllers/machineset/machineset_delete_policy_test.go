@@ -676,6 +676,21 @@ func TestIsMachineHealthy(t *testing.T) {
 			},
 			expect: false,
 		},
+		{
+			desc: "when it has an interruption notice",
+			machine: &clusterv1.Machine{
+				Status: clusterv1.MachineStatus{
+					NodeRef: nodeRef,
+					Conditions: clusterv1.Conditions{
+						{
+							Type:   clusterv1.MachineInterruptionNoticeCondition,
+							Status: corev1.ConditionTrue,
+						},
+					},
+				},
+			},
+			expect: false,
+		},
 		{
 			desc: "when all requirements are met for node to be healthy",
 			machine: &clusterv1.Machine{
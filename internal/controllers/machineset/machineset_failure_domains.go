@@ -0,0 +1,161 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/failuredomains"
+)
+
+// isFailureDomainRebalanceEnabled returns true if the MachineSet controller is responsible for
+// picking the failure domain of the Machines and for rebalancing them across failure domains.
+func isFailureDomainRebalanceEnabled(ms *clusterv1.MachineSet) bool {
+	if ms.Spec.FailureDomainRebalance == nil {
+		return false
+	}
+	return ms.Spec.Template.Spec.FailureDomain == nil || *ms.Spec.Template.Spec.FailureDomain == ""
+}
+
+// pickFailureDomain returns the failure domain of the Cluster with the fewest Machines.
+func pickFailureDomain(cluster *clusterv1.Cluster, machines []*clusterv1.Machine) *string {
+	return failuredomains.PickFewest(cluster.Status.FailureDomains, collections.FromMachines(machines...).Filter(collections.Not(collections.HasDeletionTimestamp)))
+}
+
+// rebalanceFailureDomains deletes a Machine from the most populated failure domain when the skew between
+// failure domains exceeds the MaxSkew; the Machine is then recreated in the least populated failure domain
+// when the MachineSet scales up to the desired number of replicas.
+// NOTE: Machines without a failure domain or in a failure domain not defined in the Cluster are deleted first.
+// NOTE: Only one Machine is deleted at time and only if the number of unavailable Machines is lower than MaxUnavailable.
+func (r *Reconciler) rebalanceFailureDomains(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, machines []*clusterv1.Machine) (ctrl.Result, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	if !isFailureDomainRebalanceEnabled(ms) || len(cluster.Status.FailureDomains) == 0 {
+		return ctrl.Result{}, nil
+	}
+
+	maxUnavailable := int(pointer.Int32Deref(ms.Spec.FailureDomainRebalance.MaxUnavailable, 1))
+	if unavailable := countUnavailableMachines(machines); unavailable >= maxUnavailable {
+		log.V(4).Info("Waiting for Machines to become available before rebalancing failure domains", "unavailable", unavailable, "maxUnavailable", maxUnavailable)
+		return ctrl.Result{}, nil
+	}
+
+	deletePriorityFunc, err := getDeletePriorityFunc(ms)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	maxSkew := int(pointer.Int32Deref(ms.Spec.FailureDomainRebalance.MaxSkew, 1))
+	machine := machineToRebalance(cluster.Status.FailureDomains, machines, maxSkew, deletePriorityFunc)
+	if machine == nil {
+		return ctrl.Result{}, nil
+	}
+
+	log = log.WithValues("Machine", klog.KObj(machine))
+	log.Info(fmt.Sprintf("Deleting Machine to rebalance failure domains (failure domain %q)", pointer.StringDeref(machine.Spec.FailureDomain, "")))
+	if err := r.Client.Delete(ctx, machine); err != nil {
+		r.recorder.Eventf(ms, corev1.EventTypeWarning, clusterv1.DeleteMachineFailedEventReason, "Failed to delete machine %q to rebalance failure domains: %v", machine.Name, err)
+		return ctrl.Result{}, err
+	}
+	r.recorder.Eventf(ms, corev1.EventTypeNormal, clusterv1.DeleteMachineSucceededEventReason, "Deleted machine %q to rebalance failure domains", machine.Name)
+	return ctrl.Result{}, r.waitForMachineDeletion(ctx, []*clusterv1.Machine{machine})
+}
+
+// countUnavailableMachines returns the number of Machines being deleted or not healthy.
+func countUnavailableMachines(machines []*clusterv1.Machine) int {
+	unavailable := 0
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() || !isMachineHealthy(m) {
+			unavailable++
+		}
+	}
+	return unavailable
+}
+
+// machineToRebalance returns the Machine to be deleted in order to rebalance failure domains, if any.
+func machineToRebalance(failureDomains clusterv1.FailureDomains, machines []*clusterv1.Machine, maxSkew int, deletePriorityFunc deletePriorityFunc) *clusterv1.Machine {
+	machinesByFailureDomain := map[string][]*clusterv1.Machine{}
+	for id := range failureDomains {
+		machinesByFailureDomain[id] = nil
+	}
+
+	var misplaced []*clusterv1.Machine
+	for _, m := range machines {
+		if !m.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if m.Spec.FailureDomain == nil {
+			misplaced = append(misplaced, m)
+			continue
+		}
+		if _, ok := machinesByFailureDomain[*m.Spec.FailureDomain]; !ok {
+			misplaced = append(misplaced, m)
+			continue
+		}
+		machinesByFailureDomain[*m.Spec.FailureDomain] = append(machinesByFailureDomain[*m.Spec.FailureDomain], m)
+	}
+	if len(misplaced) > 0 {
+		return getMachinesToDeletePrioritized(misplaced, 1, deletePriorityFunc)[0]
+	}
+
+	// Compare the number of Machines in each failure domain with the number of Machines expected
+	// according to the failure domain weights.
+	total, totalWeight := 0, 0
+	for id, fdMachines := range machinesByFailureDomain {
+		total += len(fdMachines)
+		totalWeight += failuredomains.Weight(failureDomains[id])
+	}
+	var mostID string
+	var mostExcess, fewestExcess float64
+	first := true
+	for id, fdMachines := range machinesByFailureDomain {
+		excess := float64(len(fdMachines)) - float64(total*failuredomains.Weight(failureDomains[id]))/float64(totalWeight)
+		if first || excess > mostExcess {
+			mostID, mostExcess = id, excess
+		}
+		if first || excess < fewestExcess {
+			fewestExcess = excess
+		}
+		first = false
+	}
+	most := machinesByFailureDomain[mostID]
+	if len(most) == 0 || mostExcess-fewestExcess <= float64(maxSkew) {
+		return nil
+	}
+
+	// Do not rebalance if the Machine would be recreated in the same failure domain, which can happen
+	// with weighted failure domains.
+	remaining := collections.New()
+	for id, fdMachines := range machinesByFailureDomain {
+		if id == mostID {
+			fdMachines = fdMachines[1:]
+		}
+		remaining.Insert(fdMachines...)
+	}
+	if pointer.StringDeref(failuredomains.PickFewest(failureDomains, remaining), "") == mostID {
+		return nil
+	}
+	return getMachinesToDeletePrioritized(most, 1, deletePriorityFunc)[0]
+}
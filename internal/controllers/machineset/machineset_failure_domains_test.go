@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package machineset
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestIsFailureDomainRebalanceEnabled(t *testing.T) {
+	tests := []struct {
+		name string
+		ms   *clusterv1.MachineSet
+		want bool
+	}{
+		{
+			name: "rebalance not set",
+			ms:   &clusterv1.MachineSet{},
+			want: false,
+		},
+		{
+			name: "rebalance set",
+			ms: &clusterv1.MachineSet{Spec: clusterv1.MachineSetSpec{
+				FailureDomainRebalance: &clusterv1.FailureDomainRebalance{},
+			}},
+			want: true,
+		},
+		{
+			name: "rebalance set, but failure domain defined in the template",
+			ms: &clusterv1.MachineSet{Spec: clusterv1.MachineSetSpec{
+				FailureDomainRebalance: &clusterv1.FailureDomainRebalance{},
+				Template: clusterv1.MachineTemplateSpec{
+					Spec: clusterv1.MachineSpec{FailureDomain: pointer.String("fd1")},
+				},
+			}},
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(isFailureDomainRebalanceEnabled(tt.ms)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestPickFailureDomain(t *testing.T) {
+	g := NewWithT(t)
+
+	cluster := &clusterv1.Cluster{Status: clusterv1.ClusterStatus{FailureDomains: clusterv1.FailureDomains{
+		"fd1": clusterv1.FailureDomainSpec{},
+		"fd2": clusterv1.FailureDomainSpec{},
+	}}}
+	machines := []*clusterv1.Machine{
+		rebalanceMachine("m1", "fd1", false),
+		// Machines being deleted are not counted.
+		rebalanceMachine("m2", "fd2", true),
+		rebalanceMachine("m3", "fd2", true),
+	}
+	g.Expect(pickFailureDomain(cluster, machines)).To(Equal(pointer.String("fd2")))
+}
+
+func TestMachineToRebalance(t *testing.T) {
+	failureDomains := clusterv1.FailureDomains{
+		"fd1": clusterv1.FailureDomainSpec{},
+		"fd2": clusterv1.FailureDomainSpec{},
+		"fd3": clusterv1.FailureDomainSpec{},
+	}
+	tests := []struct {
+		name           string
+		failureDomains clusterv1.FailureDomains
+		machines       []*clusterv1.Machine
+		maxSkew        int
+		want           string
+	}{
+		{
+			name: "balanced failure domains",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd2", false),
+				rebalanceMachine("m3", "fd3", false),
+				rebalanceMachine("m4", "fd1", false),
+			},
+			maxSkew: 1,
+			want:    "",
+		},
+		{
+			name: "skew exceeding max skew",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd1", false),
+				rebalanceMachine("m3", "fd2", false),
+			},
+			maxSkew: 1,
+			want:    "m1",
+		},
+		{
+			name: "skew within max skew",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd1", false),
+				rebalanceMachine("m3", "fd2", false),
+			},
+			maxSkew: 2,
+			want:    "",
+		},
+		{
+			name: "machines being deleted are ignored",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", true),
+				rebalanceMachine("m2", "fd1", false),
+				rebalanceMachine("m3", "fd2", false),
+				rebalanceMachine("m4", "fd3", false),
+			},
+			maxSkew: 1,
+			want:    "",
+		},
+		{
+			name: "machines without a failure domain are rebalanced first",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "", false),
+			},
+			maxSkew: 1,
+			want:    "m2",
+		},
+		{
+			name: "machines in an unknown failure domain are rebalanced first",
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd4", false),
+			},
+			maxSkew: 1,
+			want:    "m2",
+		},
+		{
+			name: "weighted failure domains within max skew",
+			failureDomains: clusterv1.FailureDomains{
+				"fd1": clusterv1.FailureDomainSpec{Weight: pointer.Int32(3)},
+				"fd2": clusterv1.FailureDomainSpec{},
+			},
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd1", false),
+				rebalanceMachine("m3", "fd1", false),
+				rebalanceMachine("m4", "fd2", false),
+			},
+			maxSkew: 1,
+			want:    "",
+		},
+		{
+			name: "weighted failure domains exceeding max skew",
+			failureDomains: clusterv1.FailureDomains{
+				"fd1": clusterv1.FailureDomainSpec{},
+				"fd2": clusterv1.FailureDomainSpec{Weight: pointer.Int32(3)},
+			},
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd1", false),
+				rebalanceMachine("m3", "fd1", false),
+				rebalanceMachine("m4", "fd2", false),
+			},
+			maxSkew: 1,
+			want:    "m1",
+		},
+		{
+			name: "weighted failure domains are not rebalanced if the machine would be recreated in the same failure domain",
+			failureDomains: clusterv1.FailureDomains{
+				"fd1": clusterv1.FailureDomainSpec{Weight: pointer.Int32(2)},
+				"fd2": clusterv1.FailureDomainSpec{},
+			},
+			machines: []*clusterv1.Machine{
+				rebalanceMachine("m1", "fd1", false),
+				rebalanceMachine("m2", "fd1", false),
+			},
+			maxSkew: 1,
+			want:    "",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			fds := failureDomains
+			if tt.failureDomains != nil {
+				fds = tt.failureDomains
+			}
+			got := machineToRebalance(fds, tt.machines, tt.maxSkew, oldestDeletePriority)
+			if tt.want == "" {
+				g.Expect(got).To(BeNil())
+				return
+			}
+			g.Expect(got).ToNot(BeNil())
+			g.Expect(got.Name).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestCountUnavailableMachines(t *testing.T) {
+	g := NewWithT(t)
+
+	notHealthy := rebalanceMachine("m3", "fd1", false)
+	notHealthy.Status.NodeRef = nil
+	machines := []*clusterv1.Machine{
+		rebalanceMachine("m1", "fd1", false),
+		rebalanceMachine("m2", "fd1", true),
+		notHealthy,
+	}
+	g.Expect(countUnavailableMachines(machines)).To(Equal(2))
+}
+
+func rebalanceMachine(name, failureDomain string, deleting bool) *clusterv1.Machine {
+	// Machines with a lower index in the name are older.
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-time.Duration(10-int(name[1]-'0')) * time.Hour)),
+		},
+		Status: clusterv1.MachineStatus{
+			NodeRef: &corev1.ObjectReference{Name: name},
+		},
+	}
+	if failureDomain != "" {
+		m.Spec.FailureDomain = pointer.String(failureDomain)
+	}
+	if deleting {
+		m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	}
+	return m
+}
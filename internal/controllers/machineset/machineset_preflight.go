@@ -49,14 +49,11 @@ var minVerKubernetesKubeletVersionSkewThree = semver.MustParse("1.28.0")
 
 func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.Cluster, ms *clusterv1.MachineSet, action string) (_ ctrl.Result, message string, retErr error) {
 	log := ctrl.LoggerFrom(ctx)
-	// If the MachineSetPreflightChecks feature gate is disabled return early.
-	if !feature.Gates.Enabled(feature.MachineSetPreflightChecks) {
-		return ctrl.Result{}, "", nil
-	}
 
-	skipped := skippedPreflightChecks(ms)
-	// If all the preflight checks are skipped then return early.
-	if skipped.Has(clusterv1.MachineSetPreflightCheckAll) {
+	checks := preflightChecksToRun(ms)
+	// If there are no preflight checks to run, e.g. because the MachineSetPreflightChecks feature gate is disabled
+	// and no preflight checks are enabled for the MachineSet, then return early.
+	if checks.Len() == 0 {
 		return ctrl.Result{}, "", nil
 	}
 
@@ -90,7 +87,7 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 	errList := []error{}
 	preflightCheckErrs := []preflightCheckErrorMessage{}
 	// Run the control-plane-stable preflight check.
-	if !skipped.Has(clusterv1.MachineSetPreflightCheckControlPlaneIsStable) {
+	if checks.Has(clusterv1.MachineSetPreflightCheckControlPlaneIsStable) {
 		preflightCheckErr, err := r.controlPlaneStablePreflightCheck(controlPlane)
 		if err != nil {
 			errList = append(errList, err)
@@ -109,7 +106,7 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 		}
 
 		// Run the kubernetes-version skew preflight check.
-		if !skipped.Has(clusterv1.MachineSetPreflightCheckKubernetesVersionSkew) {
+		if checks.Has(clusterv1.MachineSetPreflightCheckKubernetesVersionSkew) {
 			preflightCheckErr := r.kubernetesVersionPreflightCheck(cpSemver, msSemver)
 			if preflightCheckErr != nil {
 				preflightCheckErrs = append(preflightCheckErrs, preflightCheckErr)
@@ -117,7 +114,7 @@ func (r *Reconciler) runPreflightChecks(ctx context.Context, cluster *clusterv1.
 		}
 
 		// Run the kubeadm-version skew preflight check.
-		if !skipped.Has(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) {
+		if checks.Has(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) {
 			preflightCheckErr, err := r.kubeadmVersionPreflightCheck(cpSemver, msSemver, ms)
 			if err != nil {
 				errList = append(errList, err)
@@ -211,18 +208,36 @@ func (r *Reconciler) kubeadmVersionPreflightCheck(cpSemver, msSemver semver.Vers
 	return nil, nil
 }
 
-func skippedPreflightChecks(ms *clusterv1.MachineSet) sets.Set[clusterv1.MachineSetPreflightCheck] {
-	skipped := sets.Set[clusterv1.MachineSetPreflightCheck]{}
-	if ms == nil {
-		return skipped
-	}
-	skip := ms.Annotations[clusterv1.MachineSetSkipPreflightChecksAnnotation]
-	if skip == "" {
-		return skipped
+// preflightChecksToRun returns the preflight checks to run for a MachineSet: all the preflight checks if the
+// MachineSetPreflightChecks feature gate is enabled, otherwise the ones enabled with the
+// MachineSetEnablePreflightChecksAnnotation; in both cases, the ones skipped with the
+// MachineSetSkipPreflightChecksAnnotation are not run.
+func preflightChecksToRun(ms *clusterv1.MachineSet) sets.Set[clusterv1.MachineSetPreflightCheck] {
+	checks := sets.New[clusterv1.MachineSetPreflightCheck](
+		clusterv1.MachineSetPreflightCheckKubeadmVersionSkew,
+		clusterv1.MachineSetPreflightCheckKubernetesVersionSkew,
+		clusterv1.MachineSetPreflightCheckControlPlaneIsStable,
+	)
+	if !feature.Gates.Enabled(feature.MachineSetPreflightChecks) {
+		checks = checks.Intersection(preflightChecksFromAnnotation(ms, clusterv1.MachineSetEnablePreflightChecksAnnotation, checks))
 	}
-	skippedList := strings.Split(skip, ",")
-	for i := range skippedList {
-		skipped.Insert(clusterv1.MachineSetPreflightCheck(strings.TrimSpace(skippedList[i])))
+	return checks.Difference(preflightChecksFromAnnotation(ms, clusterv1.MachineSetSkipPreflightChecksAnnotation, checks))
+}
+
+// preflightChecksFromAnnotation returns the preflight checks listed in an annotation of the MachineSet,
+// expanding All to all the preflight checks.
+func preflightChecksFromAnnotation(ms *clusterv1.MachineSet, annotation string, all sets.Set[clusterv1.MachineSetPreflightCheck]) sets.Set[clusterv1.MachineSetPreflightCheck] {
+	checks := sets.Set[clusterv1.MachineSetPreflightCheck]{}
+	if ms == nil || ms.Annotations[annotation] == "" {
+		return checks
+	}
+	value := ms.Annotations[annotation]
+	for _, item := range strings.Split(value, ",") {
+		check := clusterv1.MachineSetPreflightCheck(strings.TrimSpace(item))
+		if check == clusterv1.MachineSetPreflightCheckAll {
+			return all.Clone()
+		}
+		checks.Insert(check)
 	}
-	return skipped
+	return checks
 }
@@ -605,4 +605,68 @@ func TestMachineSetReconciler_runPreflightChecks(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 		g.Expect(result.IsZero()).To(BeTrue())
 	})
+
+	t.Run("should run the preflight checks enabled on the MachineSet if the feature gate is disabled", func(t *testing.T) {
+		defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.MachineSetPreflightChecks, false)()
+
+		cluster := &clusterv1.Cluster{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: ns,
+			},
+			Spec: clusterv1.ClusterSpec{
+				ControlPlaneRef: contract.ObjToRef(controlPlaneUpgrading),
+			},
+		}
+		machineSet := func(enable, skip string) *clusterv1.MachineSet {
+			return &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Namespace: ns,
+					Annotations: map[string]string{
+						clusterv1.MachineSetEnablePreflightChecksAnnotation: enable,
+						clusterv1.MachineSetSkipPreflightChecksAnnotation:   skip,
+					},
+				},
+			}
+		}
+
+		tests := []struct {
+			name       string
+			machineSet *clusterv1.MachineSet
+			wantPass   bool
+		}{
+			{
+				name:       "should fail if the preflight check is enabled",
+				machineSet: machineSet(string(clusterv1.MachineSetPreflightCheckControlPlaneIsStable), ""),
+				wantPass:   false,
+			},
+			{
+				name:       "should fail if all the preflight checks are enabled",
+				machineSet: machineSet(string(clusterv1.MachineSetPreflightCheckAll), ""),
+				wantPass:   false,
+			},
+			{
+				name:       "should pass if only other preflight checks are enabled",
+				machineSet: machineSet(string(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew), ""),
+				wantPass:   true,
+			},
+			{
+				name:       "should pass if the preflight check is enabled but also skipped",
+				machineSet: machineSet(string(clusterv1.MachineSetPreflightCheckAll), string(clusterv1.MachineSetPreflightCheckControlPlaneIsStable)),
+				wantPass:   true,
+			},
+		}
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				g := NewWithT(t)
+				fakeClient := fake.NewClientBuilder().WithObjects(controlPlaneUpgrading).Build()
+				r := &Reconciler{
+					Client:                    fakeClient,
+					UnstructuredCachingClient: fakeClient,
+				}
+				result, _, err := r.runPreflightChecks(ctx, cluster, tt.machineSet, "")
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(result.IsZero()).To(Equal(tt.wantPass))
+			})
+		}
+	})
 }
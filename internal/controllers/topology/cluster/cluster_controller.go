@@ -47,12 +47,16 @@ import (
 	"sigs.k8s.io/cluster-api/internal/hooks"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
+	"sigs.k8s.io/cluster-api/internal/topology/check"
+	"sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util"
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io;bootstrap.cluster.x-k8s.io;controlplane.cluster.x-k8s.io,resources=*,verbs=get;list;watch;create;update;patch;delete
@@ -77,6 +81,9 @@ type Reconciler struct {
 	// WatchFilterValue is the label value used to filter events prior to reconciliation.
 	WatchFilterValue string
 
+	// Shard is the shard of the objects reconciled by this manager.
+	Shard sharding.Shard
+
 	// UnstructuredCachingClient provides a client that forces caching of unstructured objects,
 	// thus allowing to optimize reads for templates or provider specific objects in a managed topology.
 	UnstructuredCachingClient client.Client
@@ -95,6 +102,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		For(&clusterv1.Cluster{}, builder.WithPredicates(
 			// Only reconcile Cluster with topology.
 			predicates.ClusterHasTopology(ctrl.LoggerFrom(ctx)),
+			predicates.ResourceIsInShard(ctrl.LoggerFrom(ctx), r.Shard),
 		)).
 		Named("topology/cluster").
 		Watches(
@@ -115,7 +123,7 @@ func (r *Reconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, opt
 		).
 		WithOptions(options).
 		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
-		Build(r)
+		Build(concurrency.Reconciler(ratelimit.Reconciler(r, r.Client, &clusterv1.Cluster{}), options))
 
 	if err != nil {
 		return errors.Wrap(err, "failed setting up with a controller manager")
@@ -152,6 +160,11 @@ func (r *Reconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Re
 		// Error reading the object - requeue the request.
 		return ctrl.Result{}, err
 	}
+
+	// Return early if the Cluster does not belong to the shard of this manager.
+	if !r.Shard.Contains(cluster) {
+		return ctrl.Result{}, nil
+	}
 	cluster.APIVersion = clusterv1.GroupVersion.String()
 	cluster.Kind = "Cluster"
 
@@ -221,9 +234,14 @@ func (r *Reconciler) reconcile(ctx context.Context, s *scope.Scope) (ctrl.Result
 
 	// Get ClusterClass.
 	clusterClass := &clusterv1.ClusterClass{}
-	key := client.ObjectKey{Name: s.Current.Cluster.Spec.Topology.Class, Namespace: s.Current.Cluster.Namespace}
+	key := s.Current.Cluster.GetClassKey()
 	if err := r.Client.Get(ctx, key, clusterClass); err != nil {
-		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve ClusterClass %s", s.Current.Cluster.Spec.Topology.Class)
+		return ctrl.Result{}, errors.Wrapf(err, "failed to retrieve ClusterClass %s", key)
+	}
+
+	// A ClusterClass in another namespace can be used only if it is exported to the Cluster namespace.
+	if !check.ClusterClassIsExportedToNamespace(clusterClass, s.Current.Cluster.Namespace) {
+		return ctrl.Result{}, errors.Errorf("ClusterClass %s is not exported to namespace %s", key, s.Current.Cluster.Namespace)
 	}
 
 	s.Blueprint.ClusterClass = clusterClass
@@ -341,12 +359,12 @@ func (r *Reconciler) clusterClassToCluster(ctx context.Context, o client.Object)
 		panic(fmt.Sprintf("Expected a ClusterClass but got a %T", o))
 	}
 
+	// NOTE: Clusters are listed in all the namespaces, because a ClusterClass can be used by Clusters in other namespaces.
 	clusterList := &clusterv1.ClusterList{}
 	if err := r.Client.List(
 		ctx,
 		clusterList,
 		client.MatchingFields{index.ClusterClassNameField: clusterClass.Name},
-		client.InNamespace(clusterClass.Namespace),
 	); err != nil {
 		return nil
 	}
@@ -355,6 +373,9 @@ func (r *Reconciler) clusterClassToCluster(ctx context.Context, o client.Object)
 	// create a request for each of the clusters.
 	requests := []ctrl.Request{}
 	for i := range clusterList.Items {
+		if clusterList.Items[i].GetClassKey() != client.ObjectKeyFromObject(clusterClass) {
+			continue
+		}
 		requests = append(requests, ctrl.Request{NamespacedName: util.ObjectKey(&clusterList.Items[i])})
 	}
 	return requests
@@ -19,12 +19,15 @@ package cluster
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
@@ -39,8 +42,10 @@ import (
 	"sigs.k8s.io/cluster-api/internal/hooks"
 	tlog "sigs.k8s.io/cluster-api/internal/log"
 	"sigs.k8s.io/cluster-api/internal/topology/names"
+	"sigs.k8s.io/cluster-api/internal/topology/overrides"
 	"sigs.k8s.io/cluster-api/internal/webhooks"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 )
 
 // computeDesiredState computes the desired state of the cluster topology.
@@ -328,6 +333,9 @@ func (r *Reconciler) computeControlPlane(ctx context.Context, s *scope.Scope, in
 
 	// If it is required to manage the NodeVolumeDetachTimeout for the control plane, set the corresponding field.
 	nodeVolumeDetachTimeout := s.Blueprint.ClusterClass.Spec.ControlPlane.NodeVolumeDetachTimeout
+	if s.Blueprint.Topology.NodeVolumeDetachTimeout != nil {
+		nodeVolumeDetachTimeout = s.Blueprint.Topology.NodeVolumeDetachTimeout
+	}
 	if s.Blueprint.Topology.ControlPlane.NodeVolumeDetachTimeout != nil {
 		nodeVolumeDetachTimeout = s.Blueprint.Topology.ControlPlane.NodeVolumeDetachTimeout
 	}
@@ -456,7 +464,9 @@ func (r *Reconciler) computeControlPlaneVersion(ctx context.Context, s *scope.Sc
 
 	// If the control plane supports replicas, check if the control plane is in the middle of a scale operation.
 	// If yes, then do not pick up the desiredVersion yet. We will pick up the new version after the control plane is stable.
-	if s.Blueprint.Topology.ControlPlane.Replicas != nil {
+	// NOTE: Externally managed control planes, e.g. hosted control planes, do not have Machines, so the replicas are not
+	// used to determine if the control plane is stable; for those control planes only status.version is used.
+	if s.Blueprint.Topology.ControlPlane.Replicas != nil && !util.IsExternalManagedControlPlane(s.Current.ControlPlane.Object) {
 		cpScaling, err := contract.ControlPlane().IsScaling(s.Current.ControlPlane.Object)
 		if err != nil {
 			return "", errors.Wrap(err, "failed to check if the control plane is scaling")
@@ -629,6 +639,12 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 		return nil, err
 	}
 
+	// Set the template overrides from the MachineDeploymentTopology on the bootstrap template.
+	if err := overrides.Apply(desiredMachineDeployment.BootstrapTemplate, clusterv1.BootstrapTemplateOverrideTarget,
+		machineDeploymentClass.TemplateOverrides, machineDeploymentTopology.TemplateOverrides); err != nil {
+		return nil, errors.Wrapf(err, "failed to compute bootstrap template for MachineDeployment topology %s", machineDeploymentTopology.Name)
+	}
+
 	bootstrapTemplateLabels := desiredMachineDeployment.BootstrapTemplate.GetLabels()
 	if bootstrapTemplateLabels == nil {
 		bootstrapTemplateLabels = map[string]string{}
@@ -657,6 +673,12 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 		return nil, err
 	}
 
+	// Set the template overrides from the MachineDeploymentTopology on the infrastructure machine template.
+	if err := overrides.Apply(desiredMachineDeployment.InfrastructureMachineTemplate, clusterv1.InfrastructureTemplateOverrideTarget,
+		machineDeploymentClass.TemplateOverrides, machineDeploymentTopology.TemplateOverrides); err != nil {
+		return nil, errors.Wrapf(err, "failed to compute infrastructure machine template for MachineDeployment topology %s", machineDeploymentTopology.Name)
+	}
+
 	infraMachineTemplateLabels := desiredMachineDeployment.InfrastructureMachineTemplate.GetLabels()
 	if infraMachineTemplateLabels == nil {
 		infraMachineTemplateLabels = map[string]string{}
@@ -688,6 +710,9 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 	}
 
 	nodeVolumeDetachTimeout := machineDeploymentClass.NodeVolumeDetachTimeout
+	if s.Blueprint.Topology.NodeVolumeDetachTimeout != nil {
+		nodeVolumeDetachTimeout = s.Blueprint.Topology.NodeVolumeDetachTimeout
+	}
 	if machineDeploymentTopology.NodeVolumeDetachTimeout != nil {
 		nodeVolumeDetachTimeout = machineDeploymentTopology.NodeVolumeDetachTimeout
 	}
@@ -740,6 +765,7 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 					NodeDrainTimeout:        nodeDrainTimeout,
 					NodeVolumeDetachTimeout: nodeVolumeDetachTimeout,
 					NodeDeletionTimeout:     nodeDeletionTimeout,
+					Taints:                  machineDeploymentTopology.Taints,
 				},
 			},
 		},
@@ -756,9 +782,19 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 	// Ensure the annotations used to control the upgrade sequence are never propagated.
 	delete(machineDeploymentAnnotations, clusterv1.ClusterTopologyHoldUpgradeSequenceAnnotation)
 	delete(machineDeploymentAnnotations, clusterv1.ClusterTopologyDeferUpgradeAnnotation)
-	desiredMachineDeploymentObj.SetAnnotations(machineDeploymentAnnotations)
+	// NOTE: The autoscaler capacity annotations are only applied to the MachineDeployment, where they are read by the cluster-autoscaler.
+	desiredMachineDeploymentObj.SetAnnotations(util.MergeMap(machineDeploymentAnnotations, autoscalerCapacityAnnotations(machineDeploymentClass.AutoscalerCapacity)))
 	desiredMachineDeploymentObj.Spec.Template.Annotations = machineDeploymentAnnotations
 
+	// If the autoscaler min size and max size annotations are set, the replicas are managed by the autoscaler;
+	// surface it with the TopologyReplicasExternallyManagedAnnotation on the MachineDeployment.
+	replicasManagedByAutoscaler := annotations.HasAutoscalerMinMaxSize(machineDeploymentAnnotations)
+	if replicasManagedByAutoscaler {
+		desiredMachineDeploymentAnnotations := desiredMachineDeploymentObj.GetAnnotations()
+		desiredMachineDeploymentAnnotations[clusterv1.TopologyReplicasExternallyManagedAnnotation] = "autoscaler"
+		desiredMachineDeploymentObj.SetAnnotations(desiredMachineDeploymentAnnotations)
+	}
+
 	// Apply Labels
 	// NOTE: On top of all the labels applied to managed objects we are applying the ClusterTopologyMachineDeploymentLabel
 	// keeping track of the MachineDeployment name from the Topology; this will be used to identify the object in next reconcile loops.
@@ -784,8 +820,12 @@ func computeMachineDeployment(ctx context.Context, s *scope.Scope, machineDeploy
 	desiredMachineDeploymentObj.Spec.Selector.MatchLabels[clusterv1.ClusterTopologyOwnedLabel] = ""
 	desiredMachineDeploymentObj.Spec.Selector.MatchLabels[clusterv1.ClusterTopologyMachineDeploymentNameLabel] = machineDeploymentTopology.Name
 
-	// Set the desired replicas.
-	desiredMachineDeploymentObj.Spec.Replicas = machineDeploymentTopology.Replicas
+	// Set the desired replicas, unless they are managed by the autoscaler.
+	// NOTE: If replicas are not set in the desired state the topology controller drops the ownership of the field,
+	// and the MachineDeployment webhook preserves the current value.
+	if !replicasManagedByAutoscaler {
+		desiredMachineDeploymentObj.Spec.Replicas = machineDeploymentTopology.Replicas
+	}
 
 	desiredMachineDeployment.Object = desiredMachineDeploymentObj
 
@@ -1037,6 +1077,9 @@ func computeMachinePool(_ context.Context, s *scope.Scope, machinePoolTopology c
 	}
 
 	nodeVolumeDetachTimeout := machinePoolClass.NodeVolumeDetachTimeout
+	if s.Blueprint.Topology.NodeVolumeDetachTimeout != nil {
+		nodeVolumeDetachTimeout = s.Blueprint.Topology.NodeVolumeDetachTimeout
+	}
 	if machinePoolTopology.NodeVolumeDetachTimeout != nil {
 		nodeVolumeDetachTimeout = machinePoolTopology.NodeVolumeDetachTimeout
 	}
@@ -1104,7 +1147,8 @@ func computeMachinePool(_ context.Context, s *scope.Scope, machinePoolTopology c
 	// Ensure the annotations used to control the upgrade sequence are never propagated.
 	delete(machinePoolAnnotations, clusterv1.ClusterTopologyHoldUpgradeSequenceAnnotation)
 	delete(machinePoolAnnotations, clusterv1.ClusterTopologyDeferUpgradeAnnotation)
-	desiredMachinePoolObj.SetAnnotations(machinePoolAnnotations)
+	// NOTE: The autoscaler capacity annotations are only applied to the MachinePool, where they are read by the cluster-autoscaler.
+	desiredMachinePoolObj.SetAnnotations(util.MergeMap(machinePoolAnnotations, autoscalerCapacityAnnotations(machinePoolClass.AutoscalerCapacity)))
 	desiredMachinePoolObj.Spec.Template.Annotations = machinePoolAnnotations
 
 	// Apply Labels
@@ -1369,13 +1413,15 @@ func computeMachineHealthCheck(ctx context.Context, healthCheckTarget client.Obj
 			},
 		},
 		Spec: clusterv1.MachineHealthCheckSpec{
-			ClusterName:         clusterName,
-			Selector:            *selector,
-			UnhealthyConditions: check.UnhealthyConditions,
-			MaxUnhealthy:        check.MaxUnhealthy,
-			UnhealthyRange:      check.UnhealthyRange,
-			NodeStartupTimeout:  check.NodeStartupTimeout,
-			RemediationTemplate: check.RemediationTemplate,
+			ClusterName:                   clusterName,
+			Selector:                      *selector,
+			UnhealthyConditions:           check.UnhealthyConditions,
+			MaxUnhealthy:                  check.MaxUnhealthy,
+			UnhealthyRange:                check.UnhealthyRange,
+			NodeStartupTimeout:            check.NodeStartupTimeout,
+			InfrastructureStartupTimeout:  check.InfrastructureStartupTimeout,
+			RemediationSuppressionWindows: check.RemediationSuppressionWindows,
+			RemediationTemplate:           check.RemediationTemplate,
 		},
 	}
 
@@ -1408,3 +1454,43 @@ func selectorForMachineDeploymentMHC(md *clusterv1.MachineDeployment) *metav1.La
 	},
 	}
 }
+
+// autoscalerCapacityAnnotations returns the annotations used by the cluster-autoscaler to scale a node group from zero
+// for the given capacity.
+func autoscalerCapacityAnnotations(capacity *clusterv1.AutoscalerCapacity) map[string]string {
+	if capacity == nil {
+		return nil
+	}
+
+	annotations := map[string]string{}
+	quantities := map[string]*resource.Quantity{
+		clusterv1.AutoscalerCapacityCPUAnnotation:           capacity.CPU,
+		clusterv1.AutoscalerCapacityMemoryAnnotation:        capacity.Memory,
+		clusterv1.AutoscalerCapacityEphemeralDiskAnnotation: capacity.EphemeralDisk,
+		clusterv1.AutoscalerCapacityMaxPodsAnnotation:       capacity.MaxPods,
+	}
+	for annotation, quantity := range quantities {
+		if quantity != nil {
+			annotations[annotation] = quantity.String()
+		}
+	}
+	if capacity.GPU != nil {
+		annotations[clusterv1.AutoscalerCapacityGPUTypeAnnotation] = capacity.GPU.Type
+		annotations[clusterv1.AutoscalerCapacityGPUCountAnnotation] = capacity.GPU.Count.String()
+	}
+	if len(capacity.Labels) > 0 {
+		labels := make([]string, 0, len(capacity.Labels))
+		for _, key := range sets.List(sets.KeySet(capacity.Labels)) {
+			labels = append(labels, fmt.Sprintf("%s=%s", key, capacity.Labels[key]))
+		}
+		annotations[clusterv1.AutoscalerCapacityLabelsAnnotation] = strings.Join(labels, ",")
+	}
+	if len(capacity.Taints) > 0 {
+		taints := make([]string, 0, len(capacity.Taints))
+		for _, taint := range capacity.Taints {
+			taints = append(taints, fmt.Sprintf("%s=%s:%s", taint.Key, taint.Value, taint.Effect))
+		}
+		annotations[clusterv1.AutoscalerCapacityTaintsAnnotation] = strings.Join(taints, ",")
+	}
+	return annotations
+}
@@ -24,6 +24,8 @@ import (
 	"github.com/google/go-cmp/cmp"
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/util/intstr"
@@ -744,6 +746,24 @@ func TestComputeControlPlaneVersion(t *testing.T) {
 					Build(),
 				expectedVersion: "v1.2.2",
 			},
+			{
+				// Externally managed control planes do not have Machines, so the replicas are not used to determine if
+				// the control plane is stable.
+				name:            "should return cluster.spec.topology.version if an externally managed control plane is not upgrading, even if replicas are not up to date",
+				hookResponse:    nonBlockingBeforeClusterUpgradeResponse,
+				topologyVersion: "v1.2.3",
+				controlPlaneObj: builder.ControlPlane("test1", "cp1").
+					WithSpecFields(map[string]interface{}{
+						"spec.version":  "v1.2.2",
+						"spec.replicas": int64(2),
+					}).
+					WithStatusFields(map[string]interface{}{
+						"status.version":                     "v1.2.2",
+						"status.externalManagedControlPlane": true,
+					}).
+					Build(),
+				expectedVersion: "v1.2.3",
+			},
 			{
 				name:            "should return controlplane.spec.version if control plane is not upgrading and not scaling and one of the MachineDeployments and one of the MachinePools is upgrading",
 				topologyVersion: "v1.2.3",
@@ -1389,6 +1409,9 @@ func TestComputeMachineDeployment(t *testing.T) {
 	topologyStrategy := clusterv1.MachineDeploymentStrategy{
 		Type: clusterv1.RollingUpdateMachineDeploymentStrategyType,
 	}
+	topologyTaints := []corev1.Taint{
+		{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+	}
 	mdTopology := clusterv1.MachineDeploymentTopology{
 		Metadata: clusterv1.ObjectMeta{
 			Labels: map[string]string{
@@ -1410,6 +1433,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 		NodeDrainTimeout:        &topologyDuration,
 		NodeVolumeDetachTimeout: &topologyDuration,
 		NodeDeletionTimeout:     &topologyDuration,
+		Taints:                  topologyTaints,
 		MinReadySeconds:         &topologyMinReadySeconds,
 		Strategy:                &topologyStrategy,
 	}
@@ -1444,6 +1468,7 @@ func TestComputeMachineDeployment(t *testing.T) {
 		g.Expect(*actualMd.Spec.Template.Spec.NodeDrainTimeout).To(Equal(topologyDuration))
 		g.Expect(*actualMd.Spec.Template.Spec.NodeVolumeDetachTimeout).To(Equal(topologyDuration))
 		g.Expect(*actualMd.Spec.Template.Spec.NodeDeletionTimeout).To(Equal(topologyDuration))
+		g.Expect(actualMd.Spec.Template.Spec.Taints).To(BeComparableTo(topologyTaints))
 		g.Expect(actualMd.Spec.ClusterName).To(Equal("cluster1"))
 		g.Expect(actualMd.Name).To(ContainSubstring("cluster1"))
 		g.Expect(actualMd.Name).To(ContainSubstring("big-pool-of-machines"))
@@ -1501,6 +1526,58 @@ func TestComputeMachineDeployment(t *testing.T) {
 		g.Expect(*actualMd.Spec.Template.Spec.NodeDeletionTimeout).To(Equal(clusterClassDuration))
 	})
 
+	t.Run("Generates the machine deployment using the Cluster topology nodeVolumeDetachTimeout over ClusterClass defaults", func(t *testing.T) {
+		g := NewWithT(t)
+		clusterTopologyDuration := metav1.Duration{Duration: 30 * time.Second}
+		clusterBlueprint := *blueprint
+		clusterBlueprint.Topology = blueprint.Topology.DeepCopy()
+		clusterBlueprint.Topology.NodeVolumeDetachTimeout = &clusterTopologyDuration
+		scope := scope.New(cluster)
+		scope.Blueprint = &clusterBlueprint
+
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class:    "linux-worker",
+			Name:     "big-pool-of-machines",
+			Replicas: &replicas,
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, mdTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(*actual.Object.Spec.Template.Spec.NodeVolumeDetachTimeout).To(Equal(clusterTopologyDuration))
+
+		// The value in the MachineDeployment topology takes precedence over the Cluster topology.
+		mdTopology.NodeVolumeDetachTimeout = &topologyDuration
+		actual, err = computeMachineDeployment(ctx, scope, mdTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(*actual.Object.Spec.Template.Spec.NodeVolumeDetachTimeout).To(Equal(topologyDuration))
+	})
+
+	t.Run("Does not set replicas if the autoscaler annotations are set", func(t *testing.T) {
+		g := NewWithT(t)
+		scope := scope.New(cluster)
+		scope.Blueprint = blueprint
+
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Metadata: clusterv1.ObjectMeta{
+				Annotations: map[string]string{
+					clusterv1.AutoscalerMinSizeAnnotation: "1",
+					clusterv1.AutoscalerMaxSizeAnnotation: "3",
+				},
+			},
+			Class:    "linux-worker",
+			Name:     "big-pool-of-machines",
+			Replicas: &replicas,
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, mdTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		actualMd := actual.Object
+		g.Expect(actualMd.Spec.Replicas).To(BeNil())
+		g.Expect(actualMd.Annotations).To(HaveKeyWithValue(clusterv1.TopologyReplicasExternallyManagedAnnotation, "autoscaler"))
+		g.Expect(actualMd.Spec.Template.Annotations).ToNot(HaveKey(clusterv1.TopologyReplicasExternallyManagedAnnotation))
+	})
+
 	t.Run("If there is already a machine deployment, it preserves the object name and the reference names", func(t *testing.T) {
 		g := NewWithT(t)
 		s := scope.New(cluster)
@@ -1723,6 +1800,47 @@ func TestComputeMachineDeployment(t *testing.T) {
 		// Check that UnhealthyConditions are set as expected.
 		g.Expect(actual.MachineHealthCheck.Spec.UnhealthyConditions).To(BeComparableTo(unhealthyConditions))
 	})
+
+	t.Run("Should set the template overrides on the referenced templates", func(t *testing.T) {
+		g := NewWithT(t)
+
+		clusterClassWithOverrides := fakeClass.DeepCopy()
+		clusterClassWithOverrides.Spec.Workers.MachineDeployments[0].TemplateOverrides = []clusterv1.TemplateOverrideClass{
+			{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+			{Name: "verbosity", Template: clusterv1.BootstrapTemplateOverrideTarget, Path: "/spec/template/spec/verbosity"},
+		}
+		blueprintWithOverrides := *blueprint
+		blueprintWithOverrides.ClusterClass = clusterClassWithOverrides
+		scope := scope.New(cluster)
+		scope.Blueprint = &blueprintWithOverrides
+		mdTopology := clusterv1.MachineDeploymentTopology{
+			Class: "linux-worker",
+			Name:  "big-pool-of-machines",
+			TemplateOverrides: []clusterv1.TemplateOverride{
+				{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}},
+				{Name: "verbosity", Value: apiextensionsv1.JSON{Raw: []byte(`5`)}},
+			},
+		}
+
+		actual, err := computeMachineDeployment(ctx, scope, mdTopology)
+		g.Expect(err).ToNot(HaveOccurred())
+
+		instanceType, _, err := unstructured.NestedString(actual.InfrastructureMachineTemplate.Object, "spec", "template", "spec", "instanceType")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(instanceType).To(Equal("large"))
+		verbosity, _, err := unstructured.NestedInt64(actual.BootstrapTemplate.Object, "spec", "template", "spec", "verbosity")
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(verbosity).To(Equal(int64(5)))
+
+		// Ensure the templates of the ClusterClass are not modified.
+		_, found, _ := unstructured.NestedFieldNoCopy(workerInfrastructureMachineTemplate.Object, "spec", "template", "spec", "instanceType")
+		g.Expect(found).To(BeFalse())
+
+		// Fails if a template override is not defined in the MachineDeploymentClass.
+		mdTopology.TemplateOverrides = append(mdTopology.TemplateOverrides, clusterv1.TemplateOverride{Name: "unknown", Value: apiextensionsv1.JSON{Raw: []byte(`1`)}})
+		_, err = computeMachineDeployment(ctx, scope, mdTopology)
+		g.Expect(err).To(HaveOccurred())
+	})
 }
 
 func TestComputeMachinePool(t *testing.T) {
@@ -2852,6 +2970,45 @@ func TestMergeMap(t *testing.T) {
 	})
 }
 
+func TestAutoscalerCapacityAnnotations(t *testing.T) {
+	t.Run("Returns nil without capacity", func(t *testing.T) {
+		g := NewWithT(t)
+
+		g.Expect(autoscalerCapacityAnnotations(nil)).To(BeNil())
+	})
+	t.Run("Renders the capacity as annotations", func(t *testing.T) {
+		g := NewWithT(t)
+
+		capacity := &clusterv1.AutoscalerCapacity{
+			CPU:     resource.NewQuantity(4, resource.DecimalSI),
+			Memory:  resource.NewQuantity(16*1024*1024*1024, resource.BinarySI),
+			MaxPods: resource.NewQuantity(110, resource.DecimalSI),
+			GPU: &clusterv1.AutoscalerGPUCapacity{
+				Type:  "nvidia.com/gpu",
+				Count: *resource.NewQuantity(1, resource.DecimalSI),
+			},
+			Labels: map[string]string{
+				"zone": "a",
+				"disk": "ssd",
+			},
+			Taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "spot", Value: "true", Effect: corev1.TaintEffectPreferNoSchedule},
+			},
+		}
+
+		g.Expect(autoscalerCapacityAnnotations(capacity)).To(Equal(map[string]string{
+			clusterv1.AutoscalerCapacityCPUAnnotation:      "4",
+			clusterv1.AutoscalerCapacityMemoryAnnotation:   "16Gi",
+			clusterv1.AutoscalerCapacityMaxPodsAnnotation:  "110",
+			clusterv1.AutoscalerCapacityGPUTypeAnnotation:  "nvidia.com/gpu",
+			clusterv1.AutoscalerCapacityGPUCountAnnotation: "1",
+			clusterv1.AutoscalerCapacityLabelsAnnotation:   "disk=ssd,zone=a",
+			clusterv1.AutoscalerCapacityTaintsAnnotation:   "dedicated=gpu:NoSchedule,spot=true:PreferNoSchedule",
+		}))
+	})
+}
+
 func Test_computeMachineHealthCheck(t *testing.T) {
 	maxUnhealthyValue := intstr.FromString("100%")
 	mhcSpec := &clusterv1.MachineHealthCheckClass{
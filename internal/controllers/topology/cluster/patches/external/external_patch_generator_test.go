@@ -125,6 +125,10 @@ func (f *fakeRuntimeClient) Unregister(_ *runtimev1.ExtensionConfig) error {
 	panic("implement me")
 }
 
+func (f *fakeRuntimeClient) UnavailableHandlers(_ *runtimev1.ExtensionConfig) map[string]string {
+	panic("implement me")
+}
+
 func (f *fakeRuntimeClient) CallAllExtensions(_ context.Context, _ runtimecatalog.Hook, _ metav1.Object, _ runtimehooksv1.RequestObject, _ runtimehooksv1.ResponseObject) error {
 	panic("implement me")
 }
@@ -174,8 +174,8 @@ func dryRunSSAPatch(ctx context.Context, dryRunCtx *dryRunSSAPatchInput) (bool,
 	return hasChanges, hasSpecChanges, nil
 }
 
-// cleanupManagedFieldsAndAnnotation adjusts the obj to remove the topology.cluster.x-k8s.io/dry-run
-// and cluster.x-k8s.io/conversion-data annotations as well as the field ownership reference in managedFields. It does
+// cleanupManagedFieldsAndAnnotation adjusts the obj to remove the topology.cluster.x-k8s.io/dry-run,
+// topology.cluster.x-k8s.io/desired-state-hash and cluster.x-k8s.io/conversion-data annotations as well as the field ownership reference in managedFields. It does
 // also remove the timestamp of the managedField for `manager=capi-topology` because
 // it is expected to change due to the additional annotation.
 func cleanupManagedFieldsAndAnnotation(obj *unstructured.Unstructured) error {
@@ -189,6 +189,9 @@ func cleanupManagedFieldsAndAnnotation(obj *unstructured.Unstructured) error {
 			// annotation might be added to objects. As we don't care about differences in conversion as we
 			// are working on the old apiVersion we want to ignore the annotation when diffing.
 			{"metadata", "annotations", conversion.DataAnnotation},
+			// The desired state hash annotation changes with the intent, but differences in the annotation only
+			// are not a reason to apply the intent.
+			{"metadata", "annotations", clusterv1.TopologyDesiredStateHashAnnotation},
 		}),
 	})
 
@@ -223,6 +226,7 @@ func cleanupManagedFieldsAndAnnotation(obj *unstructured.Unstructured) error {
 			ShouldFilter: ssa.IsPathIgnored([]contract.Path{
 				{"f:metadata", "f:annotations", "f:" + clusterv1.TopologyDryRunAnnotation},
 				{"f:metadata", "f:annotations", "f:" + conversion.DataAnnotation},
+				{"f:metadata", "f:annotations", "f:" + clusterv1.TopologyDesiredStateHashAnnotation},
 			}),
 		})
 
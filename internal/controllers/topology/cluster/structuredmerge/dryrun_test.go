@@ -29,7 +29,7 @@ import (
 )
 
 func Test_cleanupManagedFieldsAndAnnotation(t *testing.T) {
-	rawManagedFieldWithAnnotation := `{"f:metadata":{"f:annotations":{"f:topology.cluster.x-k8s.io/dry-run":{},"f:cluster.x-k8s.io/conversion-data":{}}}}`
+	rawManagedFieldWithAnnotation := `{"f:metadata":{"f:annotations":{"f:topology.cluster.x-k8s.io/dry-run":{},"f:topology.cluster.x-k8s.io/desired-state-hash":{},"f:cluster.x-k8s.io/conversion-data":{}}}}`
 	rawManagedFieldWithAnnotationSpecLabels := `{"f:metadata":{"f:annotations":{"f:topology.cluster.x-k8s.io/dry-run":{},"f:cluster.x-k8s.io/conversion-data":{}},"f:labels":{}},"f:spec":{"f:foo":{}}}`
 	rawManagedFieldWithSpecLabels := `{"f:metadata":{"f:labels":{}},"f:spec":{"f:foo":{}}}`
 
@@ -62,6 +62,15 @@ func Test_cleanupManagedFieldsAndAnnotation(t *testing.T) {
 			want: newObjectBuilder().
 				Build(),
 		},
+		{
+			name: "filter out desired state hash annotation",
+			obj: newObjectBuilder().
+				WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, "12345").
+				Build(),
+			wantErr: false,
+			want: newObjectBuilder().
+				Build(),
+		},
 		{
 			name: "managedFields: should drop managed fields of other manager",
 			obj: newObjectBuilder().
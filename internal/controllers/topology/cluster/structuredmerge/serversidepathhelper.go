@@ -18,12 +18,18 @@ package structuredmerge
 
 import (
 	"context"
+	"fmt"
 
 	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/klog/v2"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	"sigs.k8s.io/cluster-api/internal/util/hash"
 	"sigs.k8s.io/cluster-api/internal/util/ssa"
 	"sigs.k8s.io/cluster-api/util"
 )
@@ -73,6 +79,13 @@ func NewServerSidePatchHelper(ctx context.Context, original, modified client.Obj
 	// The originalUnstructured object will be filtered in dryRunSSAPatch using other options.
 	ssa.FilterObject(modifiedUnstructured, &helperOptions.FilterObjectInput)
 
+	// Add the hash of the intent to the intent, if the topology controller has an opinion on annotations, so
+	// following reconciles can skip server side apply until the intent changes.
+	desiredStateHash, err := setDesiredStateHash(modifiedUnstructured, helperOptions)
+	if err != nil {
+		return nil, err
+	}
+
 	// Carry over uid to match the intent to:
 	// * create (uid==""):
 	//   * if object doesn't exist => create
@@ -92,8 +105,10 @@ func NewServerSidePatchHelper(ctx context.Context, original, modified client.Obj
 	switch {
 	case util.IsNil(original):
 		hasChanges, hasSpecChanges = true, true
+	case desiredStateHash != "" && isDesiredStateApplied(originalUnstructured, desiredStateHash) &&
+		ssaCache.Has(desiredStateCheckIdentifier(originalUnstructured, desiredStateHash)):
+		hasChanges, hasSpecChanges = false, false
 	default:
-		var err error
 		hasChanges, hasSpecChanges, err = dryRunSSAPatch(ctx, &dryRunSSAPatchInput{
 			client:               c,
 			ssaCache:             ssaCache,
@@ -104,6 +119,15 @@ func NewServerSidePatchHelper(ctx context.Context, original, modified client.Obj
 		if err != nil {
 			return nil, err
 		}
+
+		// If the dry-run confirmed that the desired state is applied, record it, so the dry-run can be skipped
+		// until the desired state changes or the record expires.
+		// NOTE: The desired state hash alone can't detect all the changes to the object, e.g. fields removed
+		// by other managers without taking ownership of other fields, so the record expiring after the
+		// cache ttl forces a periodic full check with the dry-run.
+		if desiredStateHash != "" && !hasChanges && isDesiredStateApplied(originalUnstructured, desiredStateHash) {
+			ssaCache.Add(desiredStateCheckIdentifier(originalUnstructured, desiredStateHash))
+		}
 	}
 
 	return &serverSidePatchHelper{
@@ -114,6 +138,75 @@ func NewServerSidePatchHelper(ctx context.Context, original, modified client.Obj
 	}, nil
 }
 
+// setDesiredStateHash sets the TopologyDesiredStateHashAnnotation with the hash of the intent on the intent,
+// if the annotation is allowed by the helper options; it returns the hash, or an empty string if the annotation is not allowed.
+func setDesiredStateHash(modified *unstructured.Unstructured, helperOptions *HelperOptions) (string, error) {
+	annotationPath := contract.Path{"metadata", "annotations", clusterv1.TopologyDesiredStateHashAnnotation}
+	if !ssa.IsPathAllowed(helperOptions.AllowedPaths)(annotationPath) || ssa.IsPathIgnored(helperOptions.IgnorePaths)(annotationPath) {
+		return "", nil
+	}
+
+	// NOTE: The hash is computed on the intent without the annotation.
+	annotations := modified.GetAnnotations()
+	delete(annotations, clusterv1.TopologyDesiredStateHashAnnotation)
+	modified.SetAnnotations(annotations)
+	intentHash, err := hash.Compute(modified.Object)
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to compute hash for the desired state of %s", klog.KObj(modified))
+	}
+	desiredStateHash := fmt.Sprintf("%d", intentHash)
+
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[clusterv1.TopologyDesiredStateHashAnnotation] = desiredStateHash
+	modified.SetAnnotations(annotations)
+	return desiredStateHash, nil
+}
+
+// isDesiredStateApplied returns true if the desired state with the given hash has already been applied to the
+// original object, and the object has not been changed by other managers after it has been applied.
+// NOTE: The object is considered changed if another manager changed it in the same second of the last apply,
+// given that managed fields timestamps have a one second granularity; changes to subresources, e.g. status, are ignored.
+func isDesiredStateApplied(original client.Object, desiredStateHash string) bool {
+	if original.GetAnnotations()[clusterv1.TopologyDesiredStateHashAnnotation] != desiredStateHash {
+		return false
+	}
+
+	var lastApplied *metav1.Time
+	for _, managedField := range original.GetManagedFields() {
+		if isTopologyApply(managedField) {
+			lastApplied = managedField.Time
+		}
+	}
+	if lastApplied == nil {
+		return false
+	}
+
+	for _, managedField := range original.GetManagedFields() {
+		if isTopologyApply(managedField) || managedField.Subresource != "" {
+			continue
+		}
+		if managedField.Time == nil || !managedField.Time.Before(lastApplied) {
+			return false
+		}
+	}
+	return true
+}
+
+// desiredStateCheckIdentifier returns the identifier used to record in the cache that a dry-run confirmed
+// the desired state with the given hash is applied to the original object.
+func desiredStateCheckIdentifier(original client.Object, desiredStateHash string) string {
+	return fmt.Sprintf("desired-state.%s.%s", original.GetUID(), desiredStateHash)
+}
+
+// isTopologyApply returns true if the managed field is the one of the server side apply operations of the topology controller.
+func isTopologyApply(managedField metav1.ManagedFieldsEntry) bool {
+	return managedField.Manager == TopologyManagerName &&
+		managedField.Operation == metav1.ManagedFieldsOperationApply &&
+		managedField.Subresource == ""
+}
+
 // HasSpecChanges return true if the patch has changes to the spec field.
 func (h *serverSidePatchHelper) HasSpecChanges() bool {
 	return h.hasSpecChanges
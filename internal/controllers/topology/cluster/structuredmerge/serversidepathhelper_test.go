@@ -28,6 +28,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	admissionv1 "k8s.io/api/admissionregistration/v1"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -36,6 +37,8 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
@@ -46,6 +49,149 @@ import (
 	"sigs.k8s.io/cluster-api/util/patch"
 )
 
+func Test_setDesiredStateHash(t *testing.T) {
+	t.Run("sets the hash of the intent", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := newObjectBuilder().WithAnnotation("foo", "bar").Build()
+		desiredStateHash, err := setDesiredStateHash(obj, newHelperOptions(obj))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(desiredStateHash).ToNot(BeEmpty())
+		g.Expect(obj.GetAnnotations()).To(Equal(map[string]string{
+			"foo": "bar",
+			clusterv1.TopologyDesiredStateHashAnnotation: desiredStateHash,
+		}))
+
+		// The hash does not depend on the previous value of the annotation.
+		again := obj.DeepCopy()
+		g.Expect(setDesiredStateHash(again, newHelperOptions(again))).To(Equal(desiredStateHash))
+
+		// The hash changes with the intent.
+		changed := newObjectBuilder().WithAnnotation("foo", "changed").Build()
+		g.Expect(setDesiredStateHash(changed, newHelperOptions(changed))).ToNot(Equal(desiredStateHash))
+	})
+
+	t.Run("does not set the hash if annotations are not allowed", func(t *testing.T) {
+		g := NewWithT(t)
+
+		cluster := &clusterv1.Cluster{}
+		obj := newObjectBuilder().Build()
+		desiredStateHash, err := setDesiredStateHash(obj, newHelperOptions(cluster))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(desiredStateHash).To(BeEmpty())
+		g.Expect(obj.GetAnnotations()).To(BeEmpty())
+	})
+}
+
+func Test_isDesiredStateApplied(t *testing.T) {
+	lastApplied := metav1.NewTime(time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC))
+	before := metav1.NewTime(lastApplied.Add(-time.Second))
+
+	tests := []struct {
+		name string
+		obj  *unstructured.Unstructured
+		want bool
+	}{
+		{
+			name: "applied if the hash matches and no other manager changed the object after the last apply",
+			obj: newObjectBuilder().
+				WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, "12345").
+				WithManagedFieldsEntry("other", "", metav1.ManagedFieldsOperationUpdate, []byte(`{}`), &before).
+				WithManagedFieldsEntry(TopologyManagerName, "", metav1.ManagedFieldsOperationApply, []byte(`{}`), &lastApplied).
+				WithManagedFieldsEntry("other", "status", metav1.ManagedFieldsOperationUpdate, []byte(`{}`), &lastApplied).
+				Build(),
+			want: true,
+		},
+		{
+			name: "not applied if the hash does not match",
+			obj: newObjectBuilder().
+				WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, "67890").
+				WithManagedFieldsEntry(TopologyManagerName, "", metav1.ManagedFieldsOperationApply, []byte(`{}`), &lastApplied).
+				Build(),
+			want: false,
+		},
+		{
+			name: "not applied if the object has not been applied by the topology controller",
+			obj: newObjectBuilder().
+				WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, "12345").
+				WithManagedFieldsEntry(TopologyManagerName, "", metav1.ManagedFieldsOperationUpdate, []byte(`{}`), &lastApplied).
+				Build(),
+			want: false,
+		},
+		{
+			name: "not applied if another manager changed the object in the same second of the last apply",
+			obj: newObjectBuilder().
+				WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, "12345").
+				WithManagedFieldsEntry(TopologyManagerName, "", metav1.ManagedFieldsOperationApply, []byte(`{}`), &lastApplied).
+				WithManagedFieldsEntry("other", "", metav1.ManagedFieldsOperationUpdate, []byte(`{}`), &lastApplied).
+				Build(),
+			want: false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(isDesiredStateApplied(tt.obj, "12345")).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestNewServerSidePatchHelper_DesiredStateHash(t *testing.T) {
+	g := NewWithT(t)
+
+	lastApplied := metav1.NewTime(time.Now().Add(-time.Minute))
+
+	modified := newObjectBuilder().WithAnnotation("foo", "bar").Build()
+	modified.SetAPIVersion("test.cluster.x-k8s.io/v1beta1")
+	modified.SetKind("TestObject")
+	intent := modified.DeepCopy()
+	helperOptions := newHelperOptions(intent)
+	ssa.FilterObject(intent, &helperOptions.FilterObjectInput)
+	desiredStateHash, err := setDesiredStateHash(intent, helperOptions)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	original := newObjectBuilder().
+		WithAnnotation("foo", "bar").
+		WithAnnotation(clusterv1.TopologyDesiredStateHashAnnotation, desiredStateHash).
+		WithManagedFieldsEntry(TopologyManagerName, "", metav1.ManagedFieldsOperationApply, []byte(`{}`), &lastApplied).
+		Build()
+	original.SetAPIVersion("test.cluster.x-k8s.io/v1beta1")
+	original.SetKind("TestObject")
+	original.SetUID("uid")
+
+	// Count the dry-run requests; they fail, given that the test object is not known by the fake client.
+	dryRuns := 0
+	c := fake.NewClientBuilder().WithInterceptorFuncs(interceptor.Funcs{
+		Patch: func(ctx context.Context, c client.WithWatch, obj client.Object, patch client.Patch, opts ...client.PatchOption) error {
+			dryRuns++
+			return errors.New("dry-run failed")
+		},
+	}).Build()
+	ssaCache := ssa.NewCache()
+
+	// The dry-run is not skipped if it has not confirmed the desired state yet, e.g. after a restart or
+	// after the confirmation expired, so changes not detected by the desired state hash are eventually detected.
+	_, err = NewServerSidePatchHelper(ctx, original, modified, c, ssaCache)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(dryRuns).To(Equal(1))
+
+	// The dry-run is skipped if it already confirmed the desired state.
+	ssaCache.Add(desiredStateCheckIdentifier(original, desiredStateHash))
+	p, err := NewServerSidePatchHelper(ctx, original, modified, c, ssaCache)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(p.HasChanges()).To(BeFalse())
+	g.Expect(p.HasSpecChanges()).To(BeFalse())
+	g.Expect(dryRuns).To(Equal(1))
+
+	// The dry-run is not skipped if the desired state changes.
+	changed := modified.DeepCopy()
+	changed.SetAnnotations(map[string]string{"foo": "changed"})
+	_, err = NewServerSidePatchHelper(ctx, original, changed, c, ssaCache)
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(dryRuns).To(Equal(2))
+}
+
 // NOTE: This test ensures the ServerSideApply works as expected when the object is co-authored by other controllers.
 func TestServerSideApply(t *testing.T) {
 	g := NewWithT(t)
@@ -748,6 +894,8 @@ func TestServerSideApplyWithDefaulting(t *testing.T) {
 				g.Expect(p0.Patch(ctx)).To(Succeed())
 
 				// Expect webhook to be called.
+				// Note: The dry-run is not skipped even if the desired state has just been applied, because
+				// it has not been confirmed by a dry-run yet.
 				g.Expect(defaulter.Counter).To(Equal(countBefore+2),
 					"request should not have been cached and thus we expect the webhook to be called twice (once for original and once for modified)")
 
@@ -29,15 +29,18 @@ import (
 	"path"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	utilnet "k8s.io/apimachinery/pkg/util/net"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/client-go/transport"
 	"k8s.io/utils/pointer"
@@ -97,6 +100,10 @@ type Client interface {
 
 	// CallExtension calls the ExtensionHandler with the given name.
 	CallExtension(ctx context.Context, hook runtimecatalog.Hook, forObject metav1.Object, name string, request runtimehooksv1.RequestObject, response runtimehooksv1.ResponseObject) error
+
+	// UnavailableHandlers returns the ExtensionHandlers of the ExtensionConfig which could not be called the last
+	// time they were called, within the last 15 minutes, with a message describing the failure.
+	UnavailableHandlers(extensionConfig *runtimev1.ExtensionConfig) map[string]string
 }
 
 var _ Client = &client{}
@@ -105,6 +112,21 @@ type client struct {
 	catalog  *runtimecatalog.Catalog
 	registry runtimeregistry.ExtensionRegistry
 	client   ctrlclient.Client
+
+	failuresLock sync.RWMutex
+	// failures are the failures of the last calls to the ExtensionHandlers, by ExtensionConfig name and by ExtensionHandler name.
+	failures map[string]map[string]handlerFailure
+}
+
+// handlerFailureTTL is the time after which the failure of the last call to an ExtensionHandler is not reported anymore,
+// so ExtensionHandlers which are not called frequently, or which are not exposed anymore by the extension, are not
+// reported as unavailable indefinitely.
+const handlerFailureTTL = 15 * time.Minute
+
+// handlerFailure is the failure of the last call to an ExtensionHandler.
+type handlerFailure struct {
+	time time.Time
+	err  error
 }
 
 func (c *client) WarmUp(extensionConfigList *runtimev1.ExtensionConfigList) error {
@@ -124,11 +146,18 @@ func (c *client) Discover(ctx context.Context, extensionConfig *runtimev1.Extens
 		return nil, errors.Wrapf(err, "failed to discover extension %q: failed to compute GVH of hook", extensionConfig.Name)
 	}
 
+	clientCert, clientKey, err := c.getClientCertificate(ctx, extensionConfig.Spec.ClientConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to discover extension %q", extensionConfig.Name)
+	}
+
 	request := &runtimehooksv1.DiscoveryRequest{}
 	response := &runtimehooksv1.DiscoveryResponse{}
 	opts := &httpCallOptions{
 		catalog:         c.catalog,
 		config:          extensionConfig.Spec.ClientConfig,
+		clientCert:      clientCert,
+		clientKey:       clientKey,
 		registrationGVH: hookGVH,
 		hookGVH:         hookGVH,
 		timeout:         defaultDiscoveryTimeout,
@@ -186,9 +215,59 @@ func (c *client) Unregister(extensionConfig *runtimev1.ExtensionConfig) error {
 	if err := c.registry.Remove(extensionConfig); err != nil {
 		return errors.Wrapf(err, "failed to unregister ExtensionConfig %q", extensionConfig.Name)
 	}
+
+	c.failuresLock.Lock()
+	defer c.failuresLock.Unlock()
+	delete(c.failures, extensionConfig.Name)
 	return nil
 }
 
+func (c *client) UnavailableHandlers(extensionConfig *runtimev1.ExtensionConfig) map[string]string {
+	c.failuresLock.Lock()
+	defer c.failuresLock.Unlock()
+
+	handlers := sets.Set[string]{}
+	for _, handler := range extensionConfig.Status.Handlers {
+		handlers.Insert(handler.Name)
+	}
+
+	unavailable := map[string]string{}
+	for name, failure := range c.failures[extensionConfig.Name] {
+		// Drop the failures which are expired or of ExtensionHandlers not exposed anymore by the extension.
+		if !handlers.Has(name) || time.Since(failure.time) > handlerFailureTTL {
+			delete(c.failures[extensionConfig.Name], name)
+			continue
+		}
+		unavailable[name] = fmt.Sprintf("last call at %s failed: %v", failure.time.UTC().Format(time.RFC3339), failure.err)
+	}
+	if len(c.failures[extensionConfig.Name]) == 0 {
+		delete(c.failures, extensionConfig.Name)
+	}
+	return unavailable
+}
+
+// recordCall records the result of a call to the ExtensionHandler with the given name of the given ExtensionConfig,
+// so that ExtensionHandlers which can't be called are reported by UnavailableHandlers.
+func (c *client) recordCall(extensionConfigName, name string, err error) {
+	c.failuresLock.Lock()
+	defer c.failuresLock.Unlock()
+
+	if err == nil {
+		delete(c.failures[extensionConfigName], name)
+		if len(c.failures[extensionConfigName]) == 0 {
+			delete(c.failures, extensionConfigName)
+		}
+		return
+	}
+	if c.failures == nil {
+		c.failures = map[string]map[string]handlerFailure{}
+	}
+	if c.failures[extensionConfigName] == nil {
+		c.failures[extensionConfigName] = map[string]handlerFailure{}
+	}
+	c.failures[extensionConfigName][name] = handlerFailure{time: time.Now(), err: err}
+}
+
 // CallAllExtensions calls all the ExtensionHandlers registered for the hook.
 // The ExtensionHandlers are called sequentially. The function exits immediately after any of the ExtensionHandlers return an error.
 // This ensures we don't end up waiting for timeout from multiple unreachable Extensions.
@@ -331,15 +410,27 @@ func (c *client) CallExtension(ctx context.Context, hook runtimecatalog.Hook, fo
 	// Prepare the request by merging the settings in the registration with the settings in the request.
 	request = cloneAndAddSettings(request, registration.Settings)
 
+	// Get the client certificate, if the extension requires client authentication.
+	// NOTE: Errors getting the client certificate are misconfigurations, so they are not subject to the failure policy.
+	clientCert, clientKey, err := c.getClientCertificate(ctx, registration.ClientConfig)
+	if err != nil {
+		return errors.Wrapf(err, "failed to call extension handler %q", name)
+	}
+
 	opts := &httpCallOptions{
 		catalog:         c.catalog,
 		config:          registration.ClientConfig,
+		clientCert:      clientCert,
+		clientKey:       clientKey,
 		registrationGVH: registration.GroupVersionHook,
 		hookGVH:         hookGVH,
 		name:            strings.TrimSuffix(registration.Name, "."+registration.ExtensionConfigName),
 		timeout:         timeoutDuration,
 	}
 	err = httpCall(ctx, request, response, opts)
+	// NOTE: The result of the call is recorded before applying the failure policy, so ExtensionHandler
+	// which can't be called are reported also when failures are ignored.
+	c.recordCall(registration.ExtensionConfigName, registration.Name, err)
 	if err != nil {
 		// If the error is errCallingExtensionHandler then apply failure policy to calculate
 		// the effective result of the operation.
@@ -393,6 +484,8 @@ func cloneAndAddSettings(request runtimehooksv1.RequestObject, registrationSetti
 type httpCallOptions struct {
 	catalog         *runtimecatalog.Catalog
 	config          runtimev1.ClientConfig
+	clientCert      []byte
+	clientKey       []byte
 	registrationGVH runtimecatalog.GroupVersionHook
 	hookGVH         runtimecatalog.GroupVersionHook
 	name            string
@@ -480,6 +573,8 @@ func httpCall(ctx context.Context, request, response runtime.Object, opts *httpC
 	tlsConfig, err := transport.TLSConfigFor(&transport.Config{
 		TLS: transport.TLSConfig{
 			CAData:     opts.config.CABundle,
+			CertData:   opts.clientCert,
+			KeyData:    opts.clientKey,
 			ServerName: extensionURL.Hostname(),
 		},
 	})
@@ -660,6 +755,31 @@ func (c *client) matchNamespace(ctx context.Context, selector labels.Selector, n
 	return selector.Matches(labels.Set(ns.GetLabels())), nil
 }
 
+// getClientCertificate returns the PEM encoded client certificate and key from the Secret referenced in the ClientConfig,
+// if any.
+// NOTE: The Secret is read at every call, so a rotated client certificate is picked up without re-registering the extension.
+func (c *client) getClientCertificate(ctx context.Context, config runtimev1.ClientConfig) ([]byte, []byte, error) {
+	if config.ClientCertificate == nil {
+		return nil, nil, nil
+	}
+
+	secret := &corev1.Secret{}
+	key := ctrlclient.ObjectKey{Namespace: config.ClientCertificate.Namespace, Name: config.ClientCertificate.Name}
+	if err := c.client.Get(ctx, key, secret); err != nil {
+		return nil, nil, errors.Wrapf(err, "failed to get client certificate: failed to get secret %s", key)
+	}
+
+	cert, ok := secret.Data[corev1.TLSCertKey]
+	if !ok || len(cert) == 0 {
+		return nil, nil, errors.Errorf("failed to get client certificate: secret %s does not contain a %q key", key, corev1.TLSCertKey)
+	}
+	privateKey, ok := secret.Data[corev1.TLSPrivateKeyKey]
+	if !ok || len(privateKey) == 0 {
+		return nil, nil, errors.Errorf("failed to get client certificate: secret %s does not contain a %q key", key, corev1.TLSPrivateKeyKey)
+	}
+	return cert, privateKey, nil
+}
+
 // NameForHandler constructs a canonical name for a registered runtime extension handler.
 func NameForHandler(handler runtimehooksv1.ExtensionHandler, extensionConfig *runtimev1.ExtensionConfig) (string, error) {
 	if extensionConfig == nil {
@@ -19,6 +19,7 @@ package client
 import (
 	"context"
 	"crypto/tls"
+	"crypto/x509"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,8 +27,10 @@ import (
 	"reflect"
 	"regexp"
 	"testing"
+	"time"
 
 	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
@@ -225,6 +228,142 @@ func fakeHookHandler(w http.ResponseWriter, _ *http.Request) {
 	_, _ = w.Write(respBody)
 }
 
+func TestClient_httpCallWithClientCertificate(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientCert []byte
+		clientKey  []byte
+		wantErr    bool
+	}{
+		{
+			name:    "fail if the extension requires client authentication and the client certificate is not set",
+			wantErr: true,
+		},
+		{
+			name:       "succeed if the extension requires client authentication and the client certificate is set",
+			clientCert: testcerts.ClientCert,
+			clientKey:  testcerts.ClientKey,
+			wantErr:    false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := runtimecatalog.New()
+			g.Expect(fakev1alpha1.AddToCatalog(c)).To(Succeed())
+			gvh, err := c.GroupVersionHook(fakev1alpha1.FakeHook)
+			g.Expect(err).ToNot(HaveOccurred())
+
+			// create http server with fakeHookHandler requiring client authentication.
+			mux := http.NewServeMux()
+			mux.HandleFunc("/", fakeHookHandler)
+
+			srv := newUnstartedTLSServer(mux)
+			clientCAs := x509.NewCertPool()
+			g.Expect(clientCAs.AppendCertsFromPEM(testcerts.CACert)).To(BeTrue())
+			srv.TLS.ClientAuth = tls.RequireAndVerifyClientCert
+			srv.TLS.ClientCAs = clientCAs
+			srv.StartTLS()
+			defer srv.Close()
+
+			opts := &httpCallOptions{
+				catalog: c,
+				config: runtimev1.ClientConfig{
+					URL:      pointer.String(srv.URL),
+					CABundle: testcerts.CACert,
+				},
+				clientCert:      tt.clientCert,
+				clientKey:       tt.clientKey,
+				registrationGVH: gvh,
+				hookGVH:         gvh,
+			}
+
+			err = httpCall(context.TODO(), &fakev1alpha1.FakeRequest{}, &fakev1alpha1.FakeResponse{}, opts)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestClient_getClientCertificate(t *testing.T) {
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "client-cert",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey:       testcerts.ClientCert,
+			corev1.TLSPrivateKeyKey: testcerts.ClientKey,
+		},
+	}
+	secretWithoutKey := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "foo",
+			Name:      "client-cert-without-key",
+		},
+		Data: map[string][]byte{
+			corev1.TLSCertKey: testcerts.ClientCert,
+		},
+	}
+
+	tests := []struct {
+		name     string
+		config   runtimev1.ClientConfig
+		wantCert []byte
+		wantKey  []byte
+		wantErr  bool
+	}{
+		{
+			name:   "no client certificate if not set",
+			config: runtimev1.ClientConfig{},
+		},
+		{
+			name: "client certificate from secret",
+			config: runtimev1.ClientConfig{
+				ClientCertificate: &runtimev1.SecretReference{Namespace: "foo", Name: "client-cert"},
+			},
+			wantCert: testcerts.ClientCert,
+			wantKey:  testcerts.ClientKey,
+		},
+		{
+			name: "fail if the secret does not exist",
+			config: runtimev1.ClientConfig{
+				ClientCertificate: &runtimev1.SecretReference{Namespace: "foo", Name: "does-not-exist"},
+			},
+			wantErr: true,
+		},
+		{
+			name: "fail if the secret does not contain the private key",
+			config: runtimev1.ClientConfig{
+				ClientCertificate: &runtimev1.SecretReference{Namespace: "foo", Name: "client-cert-without-key"},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			c := &client{
+				client: fake.NewClientBuilder().WithObjects(secret, secretWithoutKey).Build(),
+			}
+
+			cert, key, err := c.getClientCertificate(context.TODO(), tt.config)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(cert).To(Equal(tt.wantCert))
+			g.Expect(key).To(Equal(tt.wantKey))
+		})
+	}
+}
+
 func TestURLForExtension(t *testing.T) {
 	type args struct {
 		config               runtimev1.ClientConfig
@@ -599,6 +738,7 @@ func TestClient_CallExtension(t *testing.T) {
 		args                       args
 		testServer                 testServerConfig
 		wantErr                    bool
+		wantUnavailable            bool
 	}{
 		{
 			name:                       "should fail when hook and request/response are not compatible",
@@ -726,7 +866,8 @@ func TestClient_CallExtension(t *testing.T) {
 				request:  &fakev1alpha1.FakeRequest{},
 				response: &fakev1alpha1.FakeResponse{},
 			},
-			wantErr: false,
+			wantErr:         false,
+			wantUnavailable: true,
 		},
 		{
 			name:                       "should fail with unreachable extension and FailurePolicyFail",
@@ -740,7 +881,8 @@ func TestClient_CallExtension(t *testing.T) {
 				request:  &fakev1alpha1.FakeRequest{},
 				response: &fakev1alpha1.FakeResponse{},
 			},
-			wantErr: true,
+			wantErr:         true,
+			wantUnavailable: true,
 		},
 	}
 
@@ -785,10 +927,78 @@ func TestClient_CallExtension(t *testing.T) {
 			} else {
 				g.Expect(err).ToNot(HaveOccurred())
 			}
+
+			// Unreachable ExtensionHandlers are reported as unavailable, also if the failure is ignored.
+			for i := range tt.registeredExtensionConfigs {
+				unavailable := c.UnavailableHandlers(&tt.registeredExtensionConfigs[i])
+				if tt.wantUnavailable {
+					g.Expect(unavailable).To(HaveKey(tt.args.name))
+				} else {
+					g.Expect(unavailable).To(BeEmpty())
+				}
+			}
 		})
 	}
 }
 
+func TestClient_Unregister(t *testing.T) {
+	g := NewWithT(t)
+
+	extensionConfig := func(name string) runtimev1.ExtensionConfig {
+		return runtimev1.ExtensionConfig{
+			ObjectMeta: metav1.ObjectMeta{Name: name},
+			Status: runtimev1.ExtensionConfigStatus{
+				Handlers: []runtimev1.ExtensionHandler{{Name: "handler." + name}},
+			},
+		}
+	}
+	// NOTE: The name of the handlers of "bar" is a suffix of the name of the handlers of "foo.bar".
+	bar := extensionConfig("bar")
+	fooBar := extensionConfig("foo.bar")
+
+	c := New(Options{
+		Catalog:  runtimecatalog.New(),
+		Registry: registry([]runtimev1.ExtensionConfig{bar, fooBar}),
+	}).(*client)
+	c.recordCall("bar", "handler.bar", errors.New("failed"))
+	c.recordCall("foo.bar", "handler.foo.bar", errors.New("failed"))
+
+	g.Expect(c.Unregister(&bar)).To(Succeed())
+	g.Expect(c.UnavailableHandlers(&bar)).To(BeEmpty())
+	g.Expect(c.UnavailableHandlers(&fooBar)).To(HaveKey("handler.foo.bar"))
+}
+
+func TestClient_UnavailableHandlers(t *testing.T) {
+	g := NewWithT(t)
+
+	extensionConfig := runtimev1.ExtensionConfig{
+		ObjectMeta: metav1.ObjectMeta{Name: "ext"},
+		Status: runtimev1.ExtensionConfigStatus{
+			Handlers: []runtimev1.ExtensionHandler{{Name: "recent.ext"}, {Name: "expired.ext"}},
+		},
+	}
+
+	c := New(Options{
+		Catalog:  runtimecatalog.New(),
+		Registry: registry([]runtimev1.ExtensionConfig{extensionConfig}),
+	}).(*client)
+	c.recordCall("ext", "recent.ext", errors.New("failed"))
+	c.recordCall("ext", "expired.ext", errors.New("failed"))
+	c.recordCall("ext", "removed.ext", errors.New("failed"))
+	c.failures["ext"]["expired.ext"] = handlerFailure{time: time.Now().Add(-handlerFailureTTL - time.Minute), err: errors.New("failed")}
+
+	// Expired failures and failures of handlers not exposed anymore are not reported, and they are dropped.
+	unavailable := c.UnavailableHandlers(&extensionConfig)
+	g.Expect(unavailable).To(HaveLen(1))
+	g.Expect(unavailable).To(HaveKey("recent.ext"))
+	g.Expect(c.failures["ext"]).To(HaveLen(1))
+
+	// Failures are cleared by a successful call.
+	c.recordCall("ext", "recent.ext", nil)
+	g.Expect(c.UnavailableHandlers(&extensionConfig)).To(BeEmpty())
+	g.Expect(c.failures).ToNot(HaveKey("ext"))
+}
+
 func TestPrepareRequest(t *testing.T) {
 	t.Run("request should have the correct settings", func(t *testing.T) {
 		tests := []struct {
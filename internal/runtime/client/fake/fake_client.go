@@ -36,6 +36,7 @@ type RuntimeClientBuilder struct {
 	catalog          *runtimecatalog.Catalog
 	callAllResponses map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject
 	callResponses    map[string]runtimehooksv1.ResponseObject
+	unavailable      map[string]string
 }
 
 // NewRuntimeClientBuilder returns a new builder for the fake runtime client.
@@ -61,6 +62,12 @@ func (f *RuntimeClientBuilder) WithCallExtensionResponses(responses map[string]r
 	return f
 }
 
+// WithUnavailableHandlers can be used to dictate the ExtensionHandlers returned by UnavailableHandlers.
+func (f *RuntimeClientBuilder) WithUnavailableHandlers(unavailable map[string]string) *RuntimeClientBuilder {
+	f.unavailable = unavailable
+	return f
+}
+
 // MarkReady can be used to mark the fake runtime client as either ready or not ready.
 func (f *RuntimeClientBuilder) MarkReady(ready bool) *RuntimeClientBuilder {
 	f.ready = ready
@@ -74,6 +81,7 @@ func (f *RuntimeClientBuilder) Build() *RuntimeClient {
 		callAllResponses: f.callAllResponses,
 		callResponses:    f.callResponses,
 		catalog:          f.catalog,
+		unavailable:      f.unavailable,
 		callAllTracker:   map[string]int{},
 	}
 }
@@ -86,6 +94,7 @@ type RuntimeClient struct {
 	catalog          *runtimecatalog.Catalog
 	callAllResponses map[runtimecatalog.GroupVersionHook]runtimehooksv1.ResponseObject
 	callResponses    map[string]runtimehooksv1.ResponseObject
+	unavailable      map[string]string
 
 	callAllTracker map[string]int
 }
@@ -158,6 +167,17 @@ func (fc *RuntimeClient) Unregister(_ *runtimev1.ExtensionConfig) error {
 	panic("unimplemented")
 }
 
+// UnavailableHandlers implements Client.
+func (fc *RuntimeClient) UnavailableHandlers(extensionConfig *runtimev1.ExtensionConfig) map[string]string {
+	unavailable := map[string]string{}
+	for _, handler := range extensionConfig.Status.Handlers {
+		if message, ok := fc.unavailable[handler.Name]; ok {
+			unavailable[handler.Name] = message
+		}
+	}
+	return unavailable
+}
+
 // WarmUp implements Client.
 func (fc *RuntimeClient) WarmUp(_ *runtimev1.ExtensionConfigList) error {
 	panic("unimplemented")
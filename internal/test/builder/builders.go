@@ -193,6 +193,7 @@ type MachineDeploymentTopologyBuilder struct {
 	replicas  *int32
 	mhc       *clusterv1.MachineHealthCheckTopology
 	variables []clusterv1.ClusterVariable
+	overrides []clusterv1.TemplateOverride
 }
 
 // MachineDeploymentTopology returns a builder used to create a testable MachineDeploymentTopology.
@@ -220,6 +221,12 @@ func (m *MachineDeploymentTopologyBuilder) WithVariables(variables ...clusterv1.
 	return m
 }
 
+// WithTemplateOverrides adds template overrides used as the MachineDeploymentTopology templateOverrides value.
+func (m *MachineDeploymentTopologyBuilder) WithTemplateOverrides(overrides ...clusterv1.TemplateOverride) *MachineDeploymentTopologyBuilder {
+	m.overrides = overrides
+	return m
+}
+
 // WithMachineHealthCheck adds MachineHealthCheckTopology used as the MachineHealthCheck value.
 func (m *MachineDeploymentTopologyBuilder) WithMachineHealthCheck(mhc *clusterv1.MachineHealthCheckTopology) *MachineDeploymentTopologyBuilder {
 	m.mhc = mhc
@@ -233,6 +240,7 @@ func (m *MachineDeploymentTopologyBuilder) Build() clusterv1.MachineDeploymentTo
 		Name:               m.name,
 		Replicas:           m.replicas,
 		MachineHealthCheck: m.mhc,
+		TemplateOverrides:  m.overrides,
 	}
 
 	if len(m.variables) > 0 {
@@ -496,6 +504,7 @@ type MachineDeploymentClassBuilder struct {
 	minReadySeconds               *int32
 	strategy                      *clusterv1.MachineDeploymentStrategy
 	namingStrategy                *clusterv1.MachineDeploymentClassNamingStrategy
+	templateOverrides             []clusterv1.TemplateOverrideClass
 }
 
 // MachineDeploymentClass returns a MachineDeploymentClassBuilder with the given name and namespace.
@@ -577,6 +586,12 @@ func (m *MachineDeploymentClassBuilder) WithNamingStrategy(n *clusterv1.MachineD
 	return m
 }
 
+// WithTemplateOverrides sets the TemplateOverrides for the MachineDeploymentClassBuilder.
+func (m *MachineDeploymentClassBuilder) WithTemplateOverrides(o ...clusterv1.TemplateOverrideClass) *MachineDeploymentClassBuilder {
+	m.templateOverrides = o
+	return m
+}
+
 // Build creates a full MachineDeploymentClass object with the variables passed to the MachineDeploymentClassBuilder.
 func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClass {
 	obj := &clusterv1.MachineDeploymentClass{
@@ -618,6 +633,9 @@ func (m *MachineDeploymentClassBuilder) Build() *clusterv1.MachineDeploymentClas
 	if m.namingStrategy != nil {
 		obj.NamingStrategy = m.namingStrategy
 	}
+	if m.templateOverrides != nil {
+		obj.TemplateOverrides = m.templateOverrides
+	}
 	return obj
 }
 
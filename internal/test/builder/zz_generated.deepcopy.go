@@ -525,6 +525,11 @@ func (in *MachineDeploymentClassBuilder) DeepCopyInto(out *MachineDeploymentClas
 		*out = new(v1beta1.MachineDeploymentClassNamingStrategy)
 		(*in).DeepCopyInto(*out)
 	}
+	if in.templateOverrides != nil {
+		in, out := &in.templateOverrides, &out.templateOverrides
+		*out = make([]v1beta1.TemplateOverrideClass, len(*in))
+		copy(*out, *in)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentClassBuilder.
@@ -557,6 +562,13 @@ func (in *MachineDeploymentTopologyBuilder) DeepCopyInto(out *MachineDeploymentT
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
+	if in.overrides != nil {
+		in, out := &in.overrides, &out.overrides
+		*out = make([]v1beta1.TemplateOverride, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MachineDeploymentTopologyBuilder.
@@ -228,6 +228,49 @@ func ClusterClassesAreCompatible(current, desired *clusterv1.ClusterClass) field
 	return allErrs
 }
 
+// ClusterClassIsExportedToNamespace returns true if the ClusterClass can be used by Clusters in the given namespace,
+// i.e. if the ClusterClass is in the same namespace or if it is exported to the namespace with the
+// ClusterClassExportToNamespacesAnnotation.
+func ClusterClassIsExportedToNamespace(clusterClass *clusterv1.ClusterClass, namespace string) bool {
+	if clusterClass.Namespace == namespace {
+		return true
+	}
+	value, ok := clusterClass.GetAnnotations()[clusterv1.ClusterClassExportToNamespacesAnnotation]
+	if !ok {
+		return false
+	}
+	for _, ns := range strings.Split(value, ",") {
+		if ns = strings.TrimSpace(ns); ns == "*" || ns == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+// ClusterCanBeRebased checks if a Cluster using the current ClusterClass can be rebased to the desired ClusterClass,
+// e.g. to a new revision of the current ClusterClass.
+// It checks that:
+// 1) The current and the desired ClusterClasses are compatible.
+// 2) The MachineDeploymentTopologies of the Cluster are defined in the desired ClusterClass.
+// 3) The MachinePoolTopologies of the Cluster are defined in the desired ClusterClass.
+func ClusterCanBeRebased(cluster *clusterv1.Cluster, current, desired *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+	if cluster == nil || cluster.Spec.Topology == nil {
+		return append(allErrs, field.Invalid(field.NewPath(""), "", "could not check if the Cluster can be rebased: Cluster must not be nil and must have a topology"))
+	}
+
+	allErrs = append(allErrs, ClusterClassesAreCompatible(current, desired)...)
+	if len(allErrs) > 0 {
+		return allErrs
+	}
+
+	// Validate the topologies in the Cluster are defined in the desired ClusterClass.
+	allErrs = append(allErrs, MachineDeploymentTopologiesAreValidAndDefinedInClusterClass(cluster, desired)...)
+	allErrs = append(allErrs, MachinePoolTopologiesAreValidAndDefinedInClusterClass(cluster, desired)...)
+
+	return allErrs
+}
+
 // MachineDeploymentClassesAreCompatible checks if each MachineDeploymentClass in the new ClusterClass is a compatible change from the previous ClusterClass.
 // It checks if the MachineDeploymentClass.Template.Infrastructure reference has changed its Group or Kind.
 func MachineDeploymentClassesAreCompatible(current, desired *clusterv1.ClusterClass) field.ErrorList {
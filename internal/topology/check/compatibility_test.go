@@ -602,6 +602,186 @@ func TestClusterClassesAreCompatible(t *testing.T) {
 	}
 }
 
+func TestClusterClassIsExportedToNamespace(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		namespace   string
+		want        bool
+	}{
+		{
+			name:      "ClusterClass in the same namespace",
+			namespace: "shared",
+			want:      true,
+		},
+		{
+			name:      "ClusterClass not exported",
+			namespace: "tenant-1",
+			want:      false,
+		},
+		{
+			name:        "ClusterClass exported to the namespace",
+			annotations: map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: "tenant-0, tenant-1"},
+			namespace:   "tenant-1",
+			want:        true,
+		},
+		{
+			name:        "ClusterClass exported to other namespaces",
+			annotations: map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: "tenant-0,tenant-2"},
+			namespace:   "tenant-1",
+			want:        false,
+		},
+		{
+			name:        "ClusterClass exported to all the namespaces",
+			annotations: map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: "*"},
+			namespace:   "tenant-1",
+			want:        true,
+		},
+		{
+			name:        "ClusterClass with an empty annotation",
+			annotations: map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: ""},
+			namespace:   "tenant-1",
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			clusterClass := builder.ClusterClass("shared", "class1").Build()
+			clusterClass.SetAnnotations(tt.annotations)
+			g.Expect(ClusterClassIsExportedToNamespace(clusterClass, tt.namespace)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestClusterCanBeRebased(t *testing.T) {
+	ref := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "barTemplate",
+		Name:       "baz",
+		Namespace:  "default",
+	}
+	incompatibleRef := &corev1.ObjectReference{
+		APIVersion: "group.test.io/foo",
+		Kind:       "another-barTemplate",
+		Name:       "baz",
+		Namespace:  "default",
+	}
+
+	current := builder.ClusterClass(metav1.NamespaceDefault, "class1").
+		WithInfrastructureClusterTemplate(
+			builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+		WithControlPlaneTemplate(
+			refToUnstructured(ref)).
+		WithControlPlaneInfrastructureMachineTemplate(
+			refToUnstructured(ref)).
+		WithWorkerMachineDeploymentClasses(
+			*builder.MachineDeploymentClass("aa").
+				WithInfrastructureTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithBootstrapTemplate(
+					builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+				Build()).
+		Build()
+
+	cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+		WithTopology(builder.ClusterTopology().
+			WithClass("class1").
+			WithVersion("v1.19.1").
+			WithMachineDeployment(
+				builder.MachineDeploymentTopology("md1").
+					WithClass("aa").
+					Build()).
+			Build()).
+		Build()
+
+	tests := []struct {
+		name    string
+		cluster *clusterv1.Cluster
+		desired *clusterv1.ClusterClass
+		wantErr bool
+	}{
+		{
+			name:    "pass for a compatible ClusterClass defining the classes used by the Cluster",
+			cluster: cluster,
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1-v2").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra2").Build()).
+				WithControlPlaneTemplate(
+					refToUnstructured(ref)).
+				WithControlPlaneInfrastructureMachineTemplate(
+					refToUnstructured(ref)).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra2").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap2").Build()).
+						Build()).
+				Build(),
+			wantErr: false,
+		},
+		{
+			name:    "error for an incompatible ClusterClass",
+			cluster: cluster,
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1-v2").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					refToUnstructured(incompatibleRef)).
+				WithControlPlaneInfrastructureMachineTemplate(
+					refToUnstructured(ref)).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build()).
+				Build(),
+			wantErr: true,
+		},
+		{
+			name:    "error for a ClusterClass not defining a MachineDeploymentClass used by the Cluster",
+			cluster: cluster,
+			desired: builder.ClusterClass(metav1.NamespaceDefault, "class1-v2").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					refToUnstructured(ref)).
+				WithControlPlaneInfrastructureMachineTemplate(
+					refToUnstructured(ref)).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("bb").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						Build()).
+				Build(),
+			wantErr: true,
+		},
+		{
+			name:    "error for a Cluster without a topology",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").Build(),
+			desired: current,
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			allErrs := ClusterCanBeRebased(tt.cluster, current, tt.desired)
+			if tt.wantErr {
+				g.Expect(allErrs).ToNot(BeEmpty())
+				return
+			}
+			g.Expect(allErrs).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachineDeploymentClassesAreCompatible(t *testing.T) {
 	ref := &corev1.ObjectReference{
 		APIVersion: "group.test.io/foo",
@@ -0,0 +1,83 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package overrides implements the template overrides, which allow to set fields of the templates
+// of a ClusterClass from the Cluster topology without defining patches.
+package overrides
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/util/json"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// ParsePath parses the path of a template override, in the JSON Pointer format, into the list of fields to traverse.
+// The path must start with "/spec/" and can't refer to items of lists.
+func ParsePath(path string) ([]string, error) {
+	if !strings.HasPrefix(path, "/spec/") {
+		return nil, errors.Errorf("path %q must start with \"/spec/\"", path)
+	}
+
+	fields := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	for i, f := range fields {
+		if f == "" {
+			return nil, errors.Errorf("path %q must not contain empty fields", path)
+		}
+		if _, err := strconv.Atoi(f); err == nil || f == "-" {
+			return nil, errors.Errorf("path %q must not refer to items of lists", path)
+		}
+		// Unescape the field as defined in RFC 6901.
+		fields[i] = strings.ReplaceAll(strings.ReplaceAll(f, "~1", "/"), "~0", "~")
+	}
+	return fields, nil
+}
+
+// Apply sets on the template the values of the template overrides targeting it.
+// Every template override must be defined in the given list of template override classes.
+func Apply(template *unstructured.Unstructured, target clusterv1.TemplateOverrideTarget, overrideClasses []clusterv1.TemplateOverrideClass, overrides []clusterv1.TemplateOverride) error {
+	classes := make(map[string]clusterv1.TemplateOverrideClass, len(overrideClasses))
+	for _, c := range overrideClasses {
+		classes[c.Name] = c
+	}
+
+	for _, o := range overrides {
+		c, ok := classes[o.Name]
+		if !ok {
+			return errors.Errorf("template override %q is not defined in the class", o.Name)
+		}
+		if c.Template != target {
+			continue
+		}
+
+		fields, err := ParsePath(c.Path)
+		if err != nil {
+			return errors.Wrapf(err, "invalid path for template override %q", o.Name)
+		}
+		var value interface{}
+		if err := json.Unmarshal(o.Value.Raw, &value); err != nil {
+			return errors.Wrapf(err, "invalid value for template override %q", o.Name)
+		}
+		if err := unstructured.SetNestedField(template.Object, value, fields...); err != nil {
+			return errors.Wrapf(err, "failed to set template override %q on %s %s", o.Name, template.GetKind(), template.GetName())
+		}
+	}
+	return nil
+}
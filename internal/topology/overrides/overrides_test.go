@@ -0,0 +1,148 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package overrides
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestParsePath(t *testing.T) {
+	tests := []struct {
+		name    string
+		path    string
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "valid path",
+			path: "/spec/template/spec/instanceType",
+			want: []string{"spec", "template", "spec", "instanceType"},
+		},
+		{
+			name: "escaped fields",
+			path: "/spec/template/spec/tags/a~1b~0c",
+			want: []string{"spec", "template", "spec", "tags", "a/b~c"},
+		},
+		{
+			name:    "path not starting with /spec/",
+			path:    "/metadata/labels/foo",
+			wantErr: true,
+		},
+		{
+			name:    "path with empty fields",
+			path:    "/spec//instanceType",
+			wantErr: true,
+		},
+		{
+			name:    "path referring to list items",
+			path:    "/spec/template/spec/disks/0/size",
+			wantErr: true,
+		},
+		{
+			name:    "path appending to lists",
+			path:    "/spec/template/spec/disks/-",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := ParsePath(tt.path)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestApply(t *testing.T) {
+	overrideClasses := []clusterv1.TemplateOverrideClass{
+		{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+		{Name: "rootVolumeSize", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/rootVolume/size"},
+		{Name: "verbosity", Template: clusterv1.BootstrapTemplateOverrideTarget, Path: "/spec/template/spec/verbosity"},
+	}
+	newTemplate := func() *unstructured.Unstructured {
+		return &unstructured.Unstructured{Object: map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"instanceType": "small",
+					},
+				},
+			},
+		}}
+	}
+
+	t.Run("sets the overrides targeting the template", func(t *testing.T) {
+		g := NewWithT(t)
+
+		template := newTemplate()
+		err := Apply(template, clusterv1.InfrastructureTemplateOverrideTarget, overrideClasses, []clusterv1.TemplateOverride{
+			{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}},
+			{Name: "rootVolumeSize", Value: apiextensionsv1.JSON{Raw: []byte(`100`)}},
+			{Name: "verbosity", Value: apiextensionsv1.JSON{Raw: []byte(`5`)}},
+		})
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(template.Object).To(Equal(map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"spec": map[string]interface{}{
+						"instanceType": "large",
+						"rootVolume": map[string]interface{}{
+							"size": int64(100),
+						},
+					},
+				},
+			},
+		}))
+	})
+	t.Run("fails if an override is not defined in the class", func(t *testing.T) {
+		g := NewWithT(t)
+
+		err := Apply(newTemplate(), clusterv1.InfrastructureTemplateOverrideTarget, overrideClasses, []clusterv1.TemplateOverride{
+			{Name: "unknown", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+	t.Run("fails if the value is not valid JSON", func(t *testing.T) {
+		g := NewWithT(t)
+
+		err := Apply(newTemplate(), clusterv1.InfrastructureTemplateOverrideTarget, overrideClasses, []clusterv1.TemplateOverride{
+			{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`large`)}},
+		})
+		g.Expect(err).To(HaveOccurred())
+	})
+	t.Run("fails if the path traverses a field which is not an object", func(t *testing.T) {
+		g := NewWithT(t)
+
+		err := Apply(newTemplate(), clusterv1.InfrastructureTemplateOverrideTarget,
+			[]clusterv1.TemplateOverrideClass{{Name: "nested", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType/name"}},
+			[]clusterv1.TemplateOverride{{Name: "nested", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}}},
+		)
+		g.Expect(err).To(HaveOccurred())
+	})
+}
@@ -21,29 +21,28 @@ import (
 	"fmt"
 	"strings"
 
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
 	structuraldefaulting "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/defaulting"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
-// DefaultClusterVariables defaults ClusterVariables.
-func DefaultClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
-	return defaultClusterVariables(values, definitions, true, fldPath)
+// DefaultClusterVariables defaults ClusterVariables based on the definitions in ClusterClass `.status.variables`.
+func DefaultClusterVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
+	return defaultClusterVariables(values, clusterClass, true, fldPath)
 }
 
 // DefaultMachineVariables defaults MachineDeploymentVariables and MachinePoolVariables.
-func DefaultMachineVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
-	return defaultClusterVariables(values, definitions, false, fldPath)
+func DefaultMachineVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
+	return defaultClusterVariables(values, clusterClass, false, fldPath)
 }
 
 // defaultClusterVariables defaults variables.
 // If they do not exist yet, they are created if createVariables is set.
-func defaultClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, createVariables bool, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
+func defaultClusterVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, createVariables bool, fldPath *field.Path) ([]clusterv1.ClusterVariable, field.ErrorList) {
 	var allErrs field.ErrorList
+	definitions := clusterClass.Status.Variables
 
 	// Get a map of ClusterVariable values. This function validates that:
 	// - variables are not defined more than once in Cluster spec.
@@ -78,7 +77,7 @@ func defaultClusterVariables(values []clusterv1.ClusterVariable, definitions []c
 		currentValue := getCurrentValue(variable, valuesIndex)
 
 		// Default the variable.
-		defaultedValue, errs := defaultValue(currentValue, definition, fldPath, createVariables)
+		defaultedValue, errs := defaultValue(currentValue, definition, clusterClass, fldPath, createVariables)
 		if len(errs) > 0 {
 			allErrs = append(allErrs, errs...)
 			continue
@@ -111,7 +110,7 @@ func getCurrentValue(variable clusterv1.ClusterVariable, valuesMap map[string]ma
 }
 
 // defaultValue defaults a clusterVariable based on the default value in the clusterClassVariable.
-func defaultValue(currentValue *clusterv1.ClusterVariable, definition *statusVariableDefinition, fldPath *field.Path, createVariable bool) (*clusterv1.ClusterVariable, field.ErrorList) {
+func defaultValue(currentValue *clusterv1.ClusterVariable, definition *statusVariableDefinition, clusterClass *clusterv1.ClusterClass, fldPath *field.Path, createVariable bool) (*clusterv1.ClusterVariable, field.ErrorList) {
 	if currentValue == nil {
 		// Return if the variable does not exist yet and createVariable is false.
 		if !createVariable {
@@ -123,11 +122,11 @@ func defaultValue(currentValue *clusterv1.ClusterVariable, definition *statusVar
 		}
 	}
 
-	// Convert schema to Kubernetes APIExtensions schema.
-	apiExtensionsSchema, errs := convertToAPIExtensionsJSONSchemaProps(&definition.Schema.OpenAPIV3Schema, field.NewPath("schema"))
-	if len(errs) > 0 {
+	// Get the compiled schema, i.e. the schema converted to Kubernetes APIExtensions schema and its structural schema.
+	compiled := compiledSchemas.get(clusterClass, definition.From, definition.Name, &definition.Schema.OpenAPIV3Schema)
+	if len(compiled.convertErrs) > 0 {
 		return nil, field.ErrorList{field.Invalid(fldPath, "",
-			fmt.Sprintf("invalid schema in ClusterClass for variable %q: error to convert schema %v", definition.Name, errs))}
+			fmt.Sprintf("invalid schema in ClusterClass for variable %q: error to convert schema %v", definition.Name, compiled.convertErrs))}
 	}
 
 	var value interface{}
@@ -140,28 +139,18 @@ func defaultValue(currentValue *clusterv1.ClusterVariable, definition *statusVar
 	}
 
 	// Structural schema defaulting does not work with scalar values,
-	// so we wrap the schema and the variable in objects.
+	// so we wrap the variable in an object; the schema is wrapped accordingly by compileSchema.
 	// <variable-name>: <variable-value>
 	wrappedVariable := map[string]interface{}{
 		definition.Name: value,
 	}
-	// type: object
-	// properties:
-	//   <variable-name>: <variable-schema>
-	wrappedSchema := &apiextensions.JSONSchemaProps{
-		Type: "object",
-		Properties: map[string]apiextensions.JSONSchemaProps{
-			definition.Name: *apiExtensionsSchema,
-		},
-	}
 
 	// Default the variable via the structural schema library.
-	ss, err := structuralschema.NewStructural(wrappedSchema)
-	if err != nil {
+	if compiled.structuralErr != nil {
 		return nil, field.ErrorList{field.Invalid(fldPath, "",
-			fmt.Sprintf("failed defaulting variable %q: %v", currentValue.Name, err))}
+			fmt.Sprintf("failed defaulting variable %q: %v", definition.Name, compiled.structuralErr))}
 	}
-	structuraldefaulting.Default(wrappedVariable, ss)
+	structuraldefaulting.Default(wrappedVariable, compiled.structural)
 
 	// Marshal the defaulted value.
 	defaultedVariableValue, err := json.Marshal(wrappedVariable[definition.Name])
@@ -648,7 +648,8 @@ func Test_DefaultClusterVariables(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			vars, errList := defaultClusterVariables(tt.values, tt.definitions, tt.createVariables,
+			clusterClass := &clusterv1.ClusterClass{Status: clusterv1.ClusterClassStatus{Variables: tt.definitions}}
+			vars, errList := defaultClusterVariables(tt.values, clusterClass, tt.createVariables,
 				field.NewPath("spec", "topology", "variables"))
 
 			if tt.wantErr {
@@ -1255,7 +1256,7 @@ func Test_DefaultClusterVariable(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			defaultedVariable, errList := defaultValue(tt.clusterVariable, tt.clusterClassVariable,
+			defaultedVariable, errList := defaultValue(tt.clusterVariable, tt.clusterClassVariable, nil,
 				field.NewPath("spec", "topology", "variables").Index(0), tt.createVariable)
 
 			if tt.wantErr {
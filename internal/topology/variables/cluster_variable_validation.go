@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"strings"
 
-	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
 	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
 	structuralpruning "k8s.io/apiextensions-apiserver/pkg/apiserver/schema/pruning"
 	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
@@ -31,18 +30,19 @@ import (
 )
 
 // ValidateClusterVariables validates ClusterVariables based on the definitions in ClusterClass `.status.variables`.
-func ValidateClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
-	return validateClusterVariables(values, definitions, true, fldPath)
+func ValidateClusterVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariables(values, clusterClass, true, fldPath)
 }
 
 // ValidateMachineVariables validates MachineDeployment and MachinePool variables.
-func ValidateMachineVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, fldPath *field.Path) field.ErrorList {
-	return validateClusterVariables(values, definitions, false, fldPath)
+func ValidateMachineVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariables(values, clusterClass, false, fldPath)
 }
 
 // validateClusterVariables validates variable values according to the corresponding definition.
-func validateClusterVariables(values []clusterv1.ClusterVariable, definitions []clusterv1.ClusterClassStatusVariable, validateRequired bool, fldPath *field.Path) field.ErrorList {
+func validateClusterVariables(values []clusterv1.ClusterVariable, clusterClass *clusterv1.ClusterClass, validateRequired bool, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
+	definitions := clusterClass.Status.Variables
 
 	// Get a map of ClusterVariable values. This function validates that:
 	// - variables are not defined more than once in Cluster spec.
@@ -73,11 +73,11 @@ func validateClusterVariables(values []clusterv1.ClusterVariable, definitions []
 		}
 
 		// Values must be valid according to the schema in their definition.
-		allErrs = append(allErrs, ValidateClusterVariable(value.DeepCopy(), &clusterv1.ClusterClassVariable{
+		allErrs = append(allErrs, validateClusterVariable(value.DeepCopy(), &clusterv1.ClusterClassVariable{
 			Name:     value.Name,
 			Required: definition.Required,
 			Schema:   definition.Schema,
-		}, fldPath)...)
+		}, clusterClass, definition.From, fldPath)...)
 	}
 
 	return allErrs
@@ -120,6 +120,11 @@ func validateRequiredVariables(values map[string]map[string]clusterv1.ClusterVar
 
 // ValidateClusterVariable validates a clusterVariable.
 func ValidateClusterVariable(value *clusterv1.ClusterVariable, definition *clusterv1.ClusterClassVariable, fldPath *field.Path) field.ErrorList {
+	return validateClusterVariable(value, definition, nil, "", fldPath)
+}
+
+// validateClusterVariable validates a clusterVariable against a definition from the given ClusterClass.
+func validateClusterVariable(value *clusterv1.ClusterVariable, definition *clusterv1.ClusterClassVariable, clusterClass *clusterv1.ClusterClass, definitionFrom string, fldPath *field.Path) field.ErrorList {
 	// Parse JSON value.
 	var variableValue interface{}
 	// Only try to unmarshal the clusterVariable if it is not nil, otherwise the variableValue is nil.
@@ -131,52 +136,39 @@ func ValidateClusterVariable(value *clusterv1.ClusterVariable, definition *clust
 		}
 	}
 
-	// Convert schema to Kubernetes APIExtensions Schema.
-	apiExtensionsSchema, allErrs := convertToAPIExtensionsJSONSchemaProps(&definition.Schema.OpenAPIV3Schema, field.NewPath("schema"))
-	if len(allErrs) > 0 {
+	// Get the compiled schema, i.e. the schema converted to Kubernetes APIExtensions Schema and its validator.
+	compiled := compiledSchemas.get(clusterClass, definitionFrom, definition.Name, &definition.Schema.OpenAPIV3Schema)
+	if len(compiled.convertErrs) > 0 {
 		return field.ErrorList{field.InternalError(fldPath,
-			fmt.Errorf("failed to convert schema definition for variable %q; ClusterClass should be checked: %v", definition.Name, allErrs))} // TODO: consider if to add ClusterClass name
+			fmt.Errorf("failed to convert schema definition for variable %q; ClusterClass should be checked: %v", definition.Name, compiled.convertErrs))} // TODO: consider if to add ClusterClass name
 	}
-
-	// Create validator for schema.
-	validator, _, err := validation.NewSchemaValidator(apiExtensionsSchema)
-	if err != nil {
+	if compiled.validatorErr != nil {
 		return field.ErrorList{field.InternalError(fldPath,
-			fmt.Errorf("failed to create schema validator for variable %q; ClusterClass should be checked: %v", value.Name, err))} // TODO: consider if to add ClusterClass name
+			fmt.Errorf("failed to create schema validator for variable %q; ClusterClass should be checked: %v", value.Name, compiled.validatorErr))} // TODO: consider if to add ClusterClass name
 	}
 
 	// Validate variable against the schema.
 	// NOTE: We're reusing a library func used in CRD validation.
-	if err := validation.ValidateCustomResource(fldPath, variableValue, validator); err != nil {
+	if err := validation.ValidateCustomResource(fldPath, variableValue, compiled.validator); err != nil {
 		return err
 	}
 
-	return validateUnknownFields(fldPath, value, variableValue, apiExtensionsSchema)
+	return validateUnknownFields(fldPath, value, variableValue, compiled)
 }
 
 // validateUnknownFields validates the given variableValue for unknown fields.
 // This func returns an error if there are variable fields in variableValue that are not defined in
-// variableSchema and if x-kubernetes-preserve-unknown-fields is not set.
-func validateUnknownFields(fldPath *field.Path, clusterVariable *clusterv1.ClusterVariable, variableValue interface{}, variableSchema *apiextensions.JSONSchemaProps) field.ErrorList {
+// the variable schema and if x-kubernetes-preserve-unknown-fields is not set.
+func validateUnknownFields(fldPath *field.Path, clusterVariable *clusterv1.ClusterVariable, variableValue interface{}, compiled *compiledSchema) field.ErrorList {
 	// Structural schema pruning does not work with scalar values,
-	// so we wrap the schema and the variable in objects.
+	// so we wrap the variable in an object; the schema is wrapped accordingly by compileSchema.
 	// <variable-name>: <variable-value>
 	wrappedVariable := map[string]interface{}{
 		clusterVariable.Name: variableValue,
 	}
-	// type: object
-	// properties:
-	//   <variable-name>: <variable-schema>
-	wrappedSchema := &apiextensions.JSONSchemaProps{
-		Type: "object",
-		Properties: map[string]apiextensions.JSONSchemaProps{
-			clusterVariable.Name: *variableSchema,
-		},
-	}
-	ss, err := structuralschema.NewStructural(wrappedSchema)
-	if err != nil {
+	if compiled.structuralErr != nil {
 		return field.ErrorList{field.Invalid(fldPath, "",
-			fmt.Sprintf("failed defaulting variable %q: %v", clusterVariable.Name, err))}
+			fmt.Sprintf("failed defaulting variable %q: %v", clusterVariable.Name, compiled.structuralErr))}
 	}
 
 	// Run Prune to check if it would drop any unknown fields.
@@ -184,7 +176,7 @@ func validateUnknownFields(fldPath *field.Path, clusterVariable *clusterv1.Clust
 		// TrackUnknownFieldPaths has to be true so PruneWithOptions returns the unknown fields.
 		TrackUnknownFieldPaths: true,
 	}
-	prunedUnknownFields := structuralpruning.PruneWithOptions(wrappedVariable, ss, false, opts)
+	prunedUnknownFields := structuralpruning.PruneWithOptions(wrappedVariable, compiled.structural, false, opts)
 	if len(prunedUnknownFields) > 0 {
 		// If prune dropped any unknown fields, return an error.
 		// This means that not all variable fields have been defined in the variable schema and
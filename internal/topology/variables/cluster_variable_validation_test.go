@@ -629,7 +629,8 @@ func Test_ValidateClusterVariables(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			g := NewWithT(t)
 
-			errList := validateClusterVariables(tt.values, tt.definitions,
+			clusterClass := &clusterv1.ClusterClass{Status: clusterv1.ClusterClassStatus{Variables: tt.definitions}}
+			errList := validateClusterVariables(tt.values, clusterClass,
 				tt.validateRequired, field.NewPath("spec", "topology", "variables"))
 
 			if tt.wantErr {
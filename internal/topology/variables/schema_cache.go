@@ -0,0 +1,169 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apiextensions-apiserver/pkg/apis/apiextensions"
+	structuralschema "k8s.io/apiextensions-apiserver/pkg/apiserver/schema"
+	"k8s.io/apiextensions-apiserver/pkg/apiserver/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// schemaCacheTTL is the duration for which compiled schemas which are not used are kept in the cache.
+const schemaCacheTTL = 10 * time.Minute
+
+// compiledSchemas caches the compiled schemas of the variables defined in ClusterClasses, so defaulting and
+// validating the variables of a Cluster does not require to convert and parse the full schemas every time.
+// NOTE: Schemas are cached by ClusterClass UID and generation, by the source of the variable definition, e.g. a patch,
+// by variable name and by the content of the schema, so all the Clusters using the same ClusterClass share the same
+// compiled schemas, and a change to a variable discovered from a patch, which is not reflected in the ClusterClass
+// generation, is picked up immediately.
+var compiledSchemas = newSchemaCache(schemaCacheTTL)
+
+// compiledSchema is the schema of a variable compiled for defaulting and validation.
+// NOTE: Errors are cached as well, so each caller can surface them with its own message.
+type compiledSchema struct {
+	// apiExtensionsSchema is the schema converted to a Kubernetes APIExtensions schema.
+	apiExtensionsSchema *apiextensions.JSONSchemaProps
+	convertErrs         field.ErrorList
+
+	// validator is the validator for the schema.
+	validator    validation.SchemaValidator
+	validatorErr error
+
+	// structural is the structural schema of an object wrapping the variable, given that
+	// structural schema defaulting and pruning do not work with scalar values.
+	structural    *structuralschema.Structural
+	structuralErr error
+}
+
+type schemaCacheEntry struct {
+	schema   *compiledSchema
+	lastUsed time.Time
+}
+
+// schemaCache is a cache of compiled schemas; entries which have not been used for the ttl are dropped.
+type schemaCache struct {
+	ttl time.Duration
+
+	lock      sync.Mutex
+	entries   map[string]*schemaCacheEntry
+	lastSweep time.Time
+	now       func() time.Time
+}
+
+func newSchemaCache(ttl time.Duration) *schemaCache {
+	return &schemaCache{
+		ttl:       ttl,
+		entries:   map[string]*schemaCacheEntry{},
+		lastSweep: time.Now(),
+		now:       time.Now,
+	}
+}
+
+// get returns the compiled schema for a variable defined in the given ClusterClass, compiling it if it is not in the cache yet.
+// The ClusterClass can be nil if the variable is not defined in a ClusterClass.
+func (c *schemaCache) get(clusterClass *clusterv1.ClusterClass, definitionFrom, name string, schema *clusterv1.JSONSchemaProps) *compiledSchema {
+	key, err := schemaCacheKey(clusterClass, definitionFrom, name, schema)
+	if err != nil {
+		// NOTE: This should never happen given that schemas are read from the API server; in this case
+		// the schema is compiled without caching it.
+		return compileSchema(name, schema)
+	}
+
+	c.lock.Lock()
+	now := c.now()
+	if entry, ok := c.entries[key]; ok {
+		entry.lastUsed = now
+		c.lock.Unlock()
+		return entry.schema
+	}
+	c.lock.Unlock()
+
+	// Compile the schema without holding the lock, so a large schema does not block
+	// defaulting and validation of the variables with schemas already in the cache.
+	compiled := compileSchema(name, schema)
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+
+	// Drop expired entries at most once per ttl, so the memory used by the cache does not grow indefinitely
+	// when ClusterClasses are changed or deleted, without going through all the entries on every miss.
+	if now.Sub(c.lastSweep) >= c.ttl {
+		for k, entry := range c.entries {
+			if now.Sub(entry.lastUsed) >= c.ttl {
+				delete(c.entries, k)
+			}
+		}
+		c.lastSweep = now
+	}
+	c.entries[key] = &schemaCacheEntry{schema: compiled, lastUsed: now}
+	return compiled
+}
+
+// schemaCacheKey returns the key of the compiled schema of a variable.
+func schemaCacheKey(clusterClass *clusterv1.ClusterClass, definitionFrom, name string, schema *clusterv1.JSONSchemaProps) (string, error) {
+	raw, err := json.Marshal(schema)
+	if err != nil {
+		return "", err
+	}
+	sum := sha256.Sum256(raw)
+
+	var uid string
+	var generation int64
+	if clusterClass != nil {
+		uid = string(clusterClass.UID)
+		generation = clusterClass.Generation
+	}
+	return fmt.Sprintf("%s/%d/%s/%s/%s", uid, generation, definitionFrom, name, hex.EncodeToString(sum[:])), nil
+}
+
+// compileSchema compiles the schema of a variable.
+func compileSchema(name string, schema *clusterv1.JSONSchemaProps) *compiledSchema {
+	compiled := &compiledSchema{}
+
+	// Convert schema to Kubernetes APIExtensions schema.
+	compiled.apiExtensionsSchema, compiled.convertErrs = convertToAPIExtensionsJSONSchemaProps(schema, field.NewPath("schema"))
+	if len(compiled.convertErrs) > 0 {
+		return compiled
+	}
+
+	// Create validator for schema.
+	compiled.validator, _, compiled.validatorErr = validation.NewSchemaValidator(compiled.apiExtensionsSchema)
+
+	// type: object
+	// properties:
+	//   <variable-name>: <variable-schema>
+	wrappedSchema := &apiextensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiextensions.JSONSchemaProps{
+			name: *compiled.apiExtensionsSchema,
+		},
+	}
+	compiled.structural, compiled.structuralErr = structuralschema.NewStructural(wrappedSchema)
+
+	return compiled
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package variables
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/utils/pointer"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestSchemaCache(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Now()
+	c := newSchemaCache(time.Minute)
+	c.now = func() time.Time { return now }
+	c.lastSweep = now
+
+	clusterClass := &clusterv1.ClusterClass{ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1}}
+	schema := &clusterv1.JSONSchemaProps{Type: "integer", Minimum: pointer.Int64(1)}
+
+	// Compiling a schema adds it to the cache.
+	compiled := c.get(clusterClass, "", "replicas", schema)
+	g.Expect(compiled.convertErrs).To(BeEmpty())
+	g.Expect(compiled.validator).ToNot(BeNil())
+	g.Expect(compiled.structural).ToNot(BeNil())
+	g.Expect(c.entries).To(HaveLen(1))
+
+	// The same schema for the same variable is not compiled again.
+	g.Expect(c.get(clusterClass, "", "replicas", schema.DeepCopy())).To(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(1))
+
+	// The same schema for another variable is compiled again, given that the structural schema wraps the variable name.
+	g.Expect(c.get(clusterClass, "", "count", schema)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(2))
+
+	// A changed schema for the same variable is compiled again.
+	changed := schema.DeepCopy()
+	changed.Minimum = pointer.Int64(2)
+	g.Expect(c.get(clusterClass, "", "replicas", changed)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(3))
+
+	// Using a schema refreshes its entry, while the entries not used for the ttl are dropped
+	// when compiling a new schema after the ttl since the last sweep.
+	now = now.Add(30 * time.Second)
+	g.Expect(c.get(clusterClass, "", "replicas", schema)).To(BeIdenticalTo(compiled))
+	now = now.Add(45 * time.Second)
+	c.get(clusterClass, "", "other", schema)
+	g.Expect(c.entries).To(HaveLen(2))
+	g.Expect(c.get(clusterClass, "", "replicas", schema)).To(BeIdenticalTo(compiled))
+
+	// Expired entries are not dropped before the ttl since the last sweep.
+	now = now.Add(59 * time.Second)
+	c.get(clusterClass, "", "another", schema)
+	g.Expect(c.entries).To(HaveLen(3))
+}
+
+func TestSchemaCacheWithClusterClass(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newSchemaCache(time.Minute)
+
+	clusterClass := &clusterv1.ClusterClass{ObjectMeta: metav1.ObjectMeta{UID: "uid", Generation: 1}}
+	schema := &clusterv1.JSONSchemaProps{Type: "integer", Minimum: pointer.Int64(1)}
+
+	compiled := c.get(clusterClass, "", "replicas", schema)
+
+	// The same schema is compiled again for a variable defined by a patch.
+	g.Expect(c.get(clusterClass, "patch", "replicas", schema)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(2))
+
+	// The same schema is compiled again for a new generation of the ClusterClass.
+	newGeneration := clusterClass.DeepCopy()
+	newGeneration.Generation = 2
+	g.Expect(c.get(newGeneration, "", "replicas", schema)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(3))
+
+	// The same schema is compiled again for a ClusterClass re-created with the same name.
+	recreated := clusterClass.DeepCopy()
+	recreated.UID = "another-uid"
+	g.Expect(c.get(recreated, "", "replicas", schema)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(4))
+
+	// The same schema is compiled again when there is no ClusterClass.
+	g.Expect(c.get(nil, "", "replicas", schema)).ToNot(BeIdenticalTo(compiled))
+	g.Expect(c.entries).To(HaveLen(5))
+}
+
+func TestSchemaCacheWithInvalidSchema(t *testing.T) {
+	g := NewWithT(t)
+
+	c := newSchemaCache(time.Minute)
+
+	// Schemas which cannot be marshalled are compiled without caching them, and
+	// conversion errors are returned so each caller can surface them.
+	schema := &clusterv1.JSONSchemaProps{Type: "integer", Default: &apiextensionsv1.JSON{Raw: []byte(`{"invalid":`)}}
+	compiled := c.get(nil, "", "invalid", schema)
+	g.Expect(compiled.convertErrs).ToNot(BeEmpty())
+	g.Expect(c.entries).To(BeEmpty())
+}
@@ -0,0 +1,289 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package concurrency implements adaptive concurrency for controllers, i.e. it adjusts the number of
+// concurrent reconciles of a controller based on the number of pending reconciles and on the API server latency.
+package concurrency
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultTuneInterval is the default interval between two adjustments of the number of concurrent reconciles.
+	DefaultTuneInterval = 10 * time.Second
+
+	// latencyWeight is the weight of a new observation in the exponentially weighted moving average of the latency.
+	latencyWeight = 0.1
+)
+
+// Options are the options for adaptive concurrency.
+type Options struct {
+	// MinConcurrentReconciles is the minimum number of concurrent reconciles of a controller.
+	MinConcurrentReconciles int
+
+	// LatencyThreshold is the API server latency above which the number of concurrent reconciles is decreased.
+	LatencyThreshold time.Duration
+
+	// TuneInterval is the interval between two adjustments of the number of concurrent reconciles.
+	// Defaults to DefaultTuneInterval.
+	TuneInterval time.Duration
+
+	// Latency returns the current API server latency, e.g. LatencyTracker.Latency.
+	Latency func() time.Duration
+}
+
+var (
+	adaptiveOptionsLock sync.RWMutex
+	adaptiveOptions     *Options
+)
+
+// Configure enables adaptive concurrency for the controllers using Reconciler; it must be called
+// before setting up the controllers.
+func Configure(options Options) {
+	adaptiveOptionsLock.Lock()
+	defer adaptiveOptionsLock.Unlock()
+
+	if options.MinConcurrentReconciles < 1 {
+		options.MinConcurrentReconciles = 1
+	}
+	if options.TuneInterval <= 0 {
+		options.TuneInterval = DefaultTuneInterval
+	}
+	adaptiveOptions = &options
+}
+
+// Reconciler returns a reconciler limiting the number of concurrent reconciles of r between
+// the configured minimum and options.MaxConcurrentReconciles, if adaptive concurrency is enabled;
+// otherwise r is returned unchanged, and the number of concurrent reconciles is options.MaxConcurrentReconciles.
+func Reconciler(r reconcile.Reconciler, options controller.Options) reconcile.Reconciler {
+	adaptiveOptionsLock.RLock()
+	defer adaptiveOptionsLock.RUnlock()
+
+	if adaptiveOptions == nil || options.MaxConcurrentReconciles <= adaptiveOptions.MinConcurrentReconciles {
+		return r
+	}
+	return &limitedReconciler{
+		Reconciler: r,
+		limiter:    NewLimiter(*adaptiveOptions, options.MaxConcurrentReconciles),
+	}
+}
+
+type limitedReconciler struct {
+	reconcile.Reconciler
+	limiter *Limiter
+}
+
+func (r *limitedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	if err := r.limiter.Acquire(ctx); err != nil {
+		return reconcile.Result{}, err
+	}
+	defer r.limiter.Release()
+
+	return r.Reconciler.Reconcile(ctx, req)
+}
+
+// Limiter limits the number of concurrent reconciles, adjusting the limit at every tune interval:
+//   - if the API server latency is above the threshold, the limit is decreased by a quarter, to reduce the load on the API server.
+//   - if reconciles had to wait for the limit, the limit is increased by half of the waiting reconciles.
+//   - if less than half of the limit has been used, the limit is decreased by one.
+//
+// NOTE: The controller must run with a number of workers equal to the max limit; workers exceeding the
+// current limit wait for the running reconciles to complete.
+type Limiter struct {
+	options Options
+	max     int
+
+	lock        sync.Mutex
+	wakeup      chan struct{}
+	limit       int
+	inFlight    int
+	waiting     int
+	maxInFlight int
+	maxWaiting  int
+	lastTune    time.Time
+	now         func() time.Time
+}
+
+// NewLimiter returns a Limiter between options.MinConcurrentReconciles and max, starting from the minimum.
+func NewLimiter(options Options, max int) *Limiter {
+	l := &Limiter{
+		options: options,
+		max:     max,
+		limit:   options.MinConcurrentReconciles,
+		now:     time.Now,
+		wakeup:  make(chan struct{}),
+	}
+	l.lastTune = l.now()
+	return l
+}
+
+// Limit returns the current limit.
+func (l *Limiter) Limit() int {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	return l.limit
+}
+
+// Acquire waits until a reconcile can run within the current limit or until ctx is done.
+func (l *Limiter) Acquire(ctx context.Context) error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.tune()
+	for l.inFlight >= l.limit {
+		l.waiting++
+		if l.waiting > l.maxWaiting {
+			l.maxWaiting = l.waiting
+		}
+		wakeup := l.wakeup
+		l.lock.Unlock()
+
+		var err error
+		select {
+		case <-wakeup:
+		case <-ctx.Done():
+			err = ctx.Err()
+		}
+
+		l.lock.Lock()
+		l.waiting--
+		if err != nil {
+			return err
+		}
+	}
+
+	l.inFlight++
+	if l.inFlight > l.maxInFlight {
+		l.maxInFlight = l.inFlight
+	}
+	return nil
+}
+
+// Release signals that a reconcile is completed.
+func (l *Limiter) Release() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	l.inFlight--
+	l.tune()
+	l.broadcast()
+}
+
+// broadcast wakes up the waiting reconciles; it must be called with the lock held.
+func (l *Limiter) broadcast() {
+	close(l.wakeup)
+	l.wakeup = make(chan struct{})
+}
+
+// tune adjusts the limit if the tune interval is elapsed; it must be called with the lock held.
+func (l *Limiter) tune() {
+	if l.now().Sub(l.lastTune) < l.options.TuneInterval {
+		return
+	}
+
+	limit := l.limit
+	switch {
+	case l.options.Latency != nil && l.options.LatencyThreshold > 0 && l.options.Latency() > l.options.LatencyThreshold:
+		limit -= maxInt(1, limit/4)
+	case l.maxWaiting > 0:
+		limit += maxInt(1, l.maxWaiting/2)
+	case l.maxInFlight < limit/2:
+		limit--
+	}
+	if limit < l.options.MinConcurrentReconciles {
+		limit = l.options.MinConcurrentReconciles
+	}
+	if limit > l.max {
+		limit = l.max
+	}
+
+	// Wake up the waiting reconciles if the limit has been increased.
+	if limit > l.limit {
+		l.broadcast()
+	}
+	l.limit = limit
+	l.maxInFlight = l.inFlight
+	l.maxWaiting = l.waiting
+	l.lastTune = l.now()
+}
+
+// LatencyTracker tracks the latency of the requests to the API server as an exponentially weighted moving average.
+type LatencyTracker struct {
+	lock    sync.Mutex
+	latency time.Duration
+}
+
+// NewLatencyTracker returns a new LatencyTracker.
+func NewLatencyTracker() *LatencyTracker {
+	return &LatencyTracker{}
+}
+
+// Latency returns the current latency.
+func (t *LatencyTracker) Latency() time.Duration {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	return t.latency
+}
+
+// Observe adds the latency of a request to the moving average.
+func (t *LatencyTracker) Observe(latency time.Duration) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	if t.latency == 0 {
+		t.latency = latency
+		return
+	}
+	t.latency = time.Duration(latencyWeight*float64(latency) + (1-latencyWeight)*float64(t.latency))
+}
+
+// WrapTransport returns a RoundTripper observing the latency of the requests executed by rt;
+// it can be used as a rest.Config WrapTransport func.
+// NOTE: watch requests are not observed, given that they are long-running.
+func (t *LatencyTracker) WrapTransport(rt http.RoundTripper) http.RoundTripper {
+	return roundTripperFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL != nil && strings.EqualFold(req.URL.Query().Get("watch"), "true") {
+			return rt.RoundTrip(req)
+		}
+		start := time.Now()
+		resp, err := rt.RoundTrip(req)
+		t.Observe(time.Since(start))
+		return resp, err
+	})
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func maxInt(a, b int) int {
+	if a > b {
+		return a
+	}
+	return b
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package concurrency
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+func TestLimiter(t *testing.T) {
+	ctx := context.Background()
+
+	newLimiter := func(latency time.Duration) (*Limiter, *time.Time) {
+		now := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+		l := NewLimiter(Options{
+			MinConcurrentReconciles: 2,
+			LatencyThreshold:        time.Second,
+			TuneInterval:            time.Minute,
+			Latency:                 func() time.Duration { return latency },
+		}, 10)
+		l.now = func() time.Time { return now }
+		l.lastTune = now
+		return l, &now
+	}
+
+	t.Run("starts from the min and increases the limit if reconciles are waiting", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter(0)
+		g.Expect(l.Limit()).To(Equal(2))
+
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		// Simulate 6 reconciles waiting for the limit.
+		l.lock.Lock()
+		l.maxWaiting = 6
+		l.lock.Unlock()
+
+		*now = now.Add(time.Minute)
+		l.Release()
+		g.Expect(l.Limit()).To(Equal(5))
+
+		// The limit is not adjusted before the tune interval is elapsed.
+		l.lock.Lock()
+		l.maxWaiting = 6
+		l.lock.Unlock()
+		l.Release()
+		g.Expect(l.Limit()).To(Equal(5))
+	})
+
+	t.Run("does not increase the limit above the max", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter(0)
+		l.lock.Lock()
+		l.limit = 9
+		l.maxInFlight = 9
+		l.maxWaiting = 10
+		l.lock.Unlock()
+
+		*now = now.Add(time.Minute)
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Limit()).To(Equal(10))
+	})
+
+	t.Run("decreases the limit if the latency is above the threshold", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter(2 * time.Second)
+		l.lock.Lock()
+		l.limit = 8
+		l.maxInFlight = 8
+		l.maxWaiting = 10
+		l.lock.Unlock()
+
+		*now = now.Add(time.Minute)
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Limit()).To(Equal(6))
+	})
+
+	t.Run("decreases the limit if less than half of the limit is used, but not below the min", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter(0)
+		l.lock.Lock()
+		l.limit = 3
+		l.maxInFlight = 0
+		l.lock.Unlock()
+
+		*now = now.Add(time.Minute)
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Limit()).To(Equal(2))
+
+		*now = now.Add(time.Minute)
+		l.Release()
+		g.Expect(l.Limit()).To(Equal(2))
+	})
+
+	t.Run("reconciles exceeding the limit wait for running reconciles", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, _ := newLimiter(0)
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+
+		acquired := make(chan struct{})
+		go func() {
+			defer close(acquired)
+			g.Expect(l.Acquire(ctx)).To(Succeed())
+		}()
+		g.Consistently(acquired, 100*time.Millisecond).ShouldNot(BeClosed())
+
+		l.Release()
+		g.Eventually(acquired).Should(BeClosed())
+	})
+
+	t.Run("reconciles waiting for the limit return when the context is done", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, _ := newLimiter(0)
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+		g.Expect(l.Acquire(ctx)).To(Succeed())
+
+		cancelCtx, cancel := context.WithCancel(ctx)
+		acquired := make(chan error)
+		go func() {
+			acquired <- l.Acquire(cancelCtx)
+		}()
+		g.Consistently(acquired, 100*time.Millisecond).ShouldNot(Receive())
+
+		cancel()
+		g.Eventually(acquired).Should(Receive(MatchError(context.Canceled)))
+
+		l.lock.Lock()
+		defer l.lock.Unlock()
+		g.Expect(l.inFlight).To(Equal(2))
+		g.Expect(l.waiting).To(Equal(0))
+	})
+}
+
+func TestReconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	defer func() {
+		adaptiveOptionsLock.Lock()
+		adaptiveOptions = nil
+		adaptiveOptionsLock.Unlock()
+	}()
+
+	r := &fakeReconciler{}
+	g.Expect(Reconciler(r, controller.Options{MaxConcurrentReconciles: 10})).To(BeIdenticalTo(r))
+
+	Configure(Options{MinConcurrentReconciles: 2})
+	g.Expect(Reconciler(r, controller.Options{MaxConcurrentReconciles: 2})).To(BeIdenticalTo(r))
+
+	limited := Reconciler(r, controller.Options{MaxConcurrentReconciles: 10})
+	g.Expect(limited).To(BeAssignableToTypeOf(&limitedReconciler{}))
+	_, err := limited.Reconcile(context.Background(), reconcile.Request{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(r.calls).To(Equal(1))
+	g.Expect(limited.(*limitedReconciler).limiter.inFlight).To(Equal(0))
+}
+
+func TestLatencyTracker(t *testing.T) {
+	g := NewWithT(t)
+
+	tracker := NewLatencyTracker()
+	g.Expect(tracker.Latency()).To(Equal(time.Duration(0)))
+
+	tracker.Observe(time.Second)
+	g.Expect(tracker.Latency()).To(Equal(time.Second))
+
+	tracker.Observe(2 * time.Second)
+	g.Expect(tracker.Latency()).To(Equal(1100 * time.Millisecond))
+
+	// Watch requests are not observed.
+	rt := tracker.WrapTransport(roundTripperFunc(func(*http.Request) (*http.Response, error) {
+		time.Sleep(10 * time.Millisecond)
+		return &http.Response{}, nil
+	}))
+	_, err := rt.RoundTrip(&http.Request{URL: &url.URL{RawQuery: "watch=true"}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tracker.Latency()).To(Equal(1100 * time.Millisecond))
+
+	_, err = rt.RoundTrip(&http.Request{URL: &url.URL{}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(tracker.Latency()).To(BeNumerically("<", 1100*time.Millisecond))
+}
+
+type fakeReconciler struct {
+	calls int
+}
+
+func (r *fakeReconciler) Reconcile(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	r.calls++
+	return reconcile.Result{}, nil
+}
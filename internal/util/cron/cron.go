@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cron implements parsing and matching of schedules in the standard cron format.
+package cron
+
+import (
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Schedule is a schedule in the standard cron format, i.e. "minute hour day-of-month month day-of-week".
+type Schedule struct {
+	minute     uint64
+	hour       uint64
+	dayOfMonth uint64
+	month      uint64
+	dayOfWeek  uint64
+
+	// dayOfMonthRestricted and dayOfWeekRestricted are used to implement the cron semantic
+	// where, if both the day of month and the day of week are restricted, a day matches
+	// if either of them matches.
+	dayOfMonthRestricted bool
+	dayOfWeekRestricted  bool
+}
+
+type bounds struct {
+	min   int
+	max   int
+	names map[string]int
+}
+
+var (
+	minuteBounds     = bounds{min: 0, max: 59}
+	hourBounds       = bounds{min: 0, max: 23}
+	dayOfMonthBounds = bounds{min: 1, max: 31}
+	monthBounds      = bounds{min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// NOTE: 7 is accepted as an alias of Sunday.
+	dayOfWeekBounds = bounds{min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+
+	macros = map[string]string{
+		"@yearly":   "0 0 1 1 *",
+		"@annually": "0 0 1 1 *",
+		"@monthly":  "0 0 1 * *",
+		"@weekly":   "0 0 * * 0",
+		"@daily":    "0 0 * * *",
+		"@midnight": "0 0 * * *",
+		"@hourly":   "0 * * * *",
+	}
+)
+
+// Parse parses a schedule in the standard cron format, e.g. "0 2 * * sat" for every Saturday at 02:00.
+// Each field supports "*", values, ranges ("1-5"), steps ("*/15", "0-30/10") and lists ("1,3,5");
+// months and days of the week can also be specified by their three letters name, and the
+// "@yearly", "@annually", "@monthly", "@weekly", "@daily", "@midnight" and "@hourly" macros are supported.
+func Parse(spec string) (*Schedule, error) {
+	spec = strings.TrimSpace(spec)
+	if macro, ok := macros[strings.ToLower(spec)]; ok {
+		spec = macro
+	}
+
+	fields := strings.Fields(spec)
+	if len(fields) != 5 {
+		return nil, errors.Errorf("invalid schedule %q: expected 5 fields, got %d", spec, len(fields))
+	}
+
+	s := &Schedule{}
+	var err error
+	if s.minute, err = parseField(fields[0], minuteBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q: invalid minute", spec)
+	}
+	if s.hour, err = parseField(fields[1], hourBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q: invalid hour", spec)
+	}
+	if s.dayOfMonth, err = parseField(fields[2], dayOfMonthBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q: invalid day of month", spec)
+	}
+	if s.month, err = parseField(fields[3], monthBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q: invalid month", spec)
+	}
+	if s.dayOfWeek, err = parseField(fields[4], dayOfWeekBounds); err != nil {
+		return nil, errors.Wrapf(err, "invalid schedule %q: invalid day of week", spec)
+	}
+	// Sunday can be specified both as 0 and 7.
+	if s.dayOfWeek&(1<<7) != 0 {
+		s.dayOfWeek |= 1 << 0
+	}
+	s.dayOfMonthRestricted = !strings.HasPrefix(fields[2], "*")
+	s.dayOfWeekRestricted = !strings.HasPrefix(fields[4], "*")
+	return s, nil
+}
+
+// Matches returns true if the minute of t matches the schedule; t is evaluated in its own location.
+func (s *Schedule) Matches(t time.Time) bool {
+	if s.minute&(1<<uint(t.Minute())) == 0 ||
+		s.hour&(1<<uint(t.Hour())) == 0 ||
+		s.month&(1<<uint(t.Month())) == 0 {
+		return false
+	}
+
+	dayOfMonthMatches := s.dayOfMonth&(1<<uint(t.Day())) != 0
+	dayOfWeekMatches := s.dayOfWeek&(1<<uint(t.Weekday())) != 0
+	if s.dayOfMonthRestricted && s.dayOfWeekRestricted {
+		return dayOfMonthMatches || dayOfWeekMatches
+	}
+	return dayOfMonthMatches && dayOfWeekMatches
+}
+
+// Last returns the latest time at or before t, truncated to the minute, matching the schedule and not
+// before t - lookback; it returns false if there is no such time.
+func (s *Schedule) Last(t time.Time, lookback time.Duration) (time.Time, bool) {
+	earliest := t.Add(-lookback)
+	for c := t.Truncate(time.Minute); !c.Before(earliest); c = c.Add(-time.Minute) {
+		if s.Matches(c) {
+			return c, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// parseField parses a comma-separated list of values, ranges and steps into a bitset.
+func parseField(field string, b bounds) (uint64, error) {
+	var bits uint64
+	for _, item := range strings.Split(field, ",") {
+		itemBits, err := parseItem(item, b)
+		if err != nil {
+			return 0, err
+		}
+		bits |= itemBits
+	}
+	return bits, nil
+}
+
+// parseItem parses a single value, range or step, e.g. "5", "1-5", "*/15" or "0-30/10", into a bitset.
+func parseItem(item string, b bounds) (uint64, error) {
+	rangeAndStep := strings.Split(item, "/")
+	if len(rangeAndStep) > 2 {
+		return 0, errors.Errorf("%q is not valid", item)
+	}
+
+	start, end := b.min, b.max
+	switch r := rangeAndStep[0]; {
+	case r == "*":
+	case strings.Contains(r, "-"):
+		startAndEnd := strings.Split(r, "-")
+		if len(startAndEnd) != 2 {
+			return 0, errors.Errorf("%q is not a valid range", r)
+		}
+		var err error
+		if start, err = parseValue(startAndEnd[0], b); err != nil {
+			return 0, err
+		}
+		if end, err = parseValue(startAndEnd[1], b); err != nil {
+			return 0, err
+		}
+		if start > end {
+			return 0, errors.Errorf("%q is not a valid range: start is greater than end", r)
+		}
+	default:
+		value, err := parseValue(r, b)
+		if err != nil {
+			return 0, err
+		}
+		start = value
+		// A value followed by a step, e.g. "5/15", is a range from the value to the max.
+		if len(rangeAndStep) == 1 {
+			end = value
+		}
+	}
+
+	step := 1
+	if len(rangeAndStep) == 2 {
+		var err error
+		if step, err = strconv.Atoi(rangeAndStep[1]); err != nil || step <= 0 {
+			return 0, errors.Errorf("%q is not a valid step", rangeAndStep[1])
+		}
+	}
+
+	var bits uint64
+	for i := start; i <= end; i += step {
+		bits |= 1 << uint(i)
+	}
+	return bits, nil
+}
+
+// parseValue parses a number or a name within the given bounds.
+func parseValue(value string, b bounds) (int, error) {
+	if v, ok := b.names[strings.ToLower(value)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, errors.Errorf("%q is not a valid value", value)
+	}
+	if v < b.min || v > b.max {
+		return 0, errors.Errorf("%d is out of range [%d-%d]", v, b.min, b.max)
+	}
+	return v, nil
+}
@@ -0,0 +1,121 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cron
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		wantErr bool
+	}{
+		{name: "every minute", spec: "* * * * *"},
+		{name: "values, ranges, steps and lists", spec: "0,30 1-5 */2 1-12/3 1-5"},
+		{name: "value with step", spec: "5/15 * * * *"},
+		{name: "names", spec: "0 2 * jan-mar sat,SUN"},
+		{name: "sunday as 7", spec: "0 2 * * 7"},
+		{name: "macro", spec: "@weekly"},
+		{name: "too few fields", spec: "* * * *", wantErr: true},
+		{name: "too many fields", spec: "* * * * * *", wantErr: true},
+		{name: "empty", spec: "", wantErr: true},
+		{name: "minute out of range", spec: "60 * * * *", wantErr: true},
+		{name: "day of month out of range", spec: "* * 0 * *", wantErr: true},
+		{name: "invalid value", spec: "a * * * *", wantErr: true},
+		{name: "invalid range", spec: "5-1 * * * *", wantErr: true},
+		{name: "invalid step", spec: "*/0 * * * *", wantErr: true},
+		{name: "invalid name", spec: "* * * foo *", wantErr: true},
+		{name: "unknown macro", spec: "@every", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			_, err := Parse(tt.spec)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestScheduleMatches(t *testing.T) {
+	// 2023-10-14 is a Saturday.
+	saturday := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name string
+		spec string
+		t    time.Time
+		want bool
+	}{
+		{name: "every minute", spec: "* * * * *", t: saturday, want: true},
+		{name: "minute and hour match", spec: "0 2 * * *", t: saturday, want: true},
+		{name: "seconds are ignored", spec: "0 2 * * *", t: saturday.Add(59 * time.Second), want: true},
+		{name: "minute does not match", spec: "0 2 * * *", t: saturday.Add(time.Minute), want: false},
+		{name: "step matches", spec: "*/15 * * * *", t: saturday.Add(45 * time.Minute), want: true},
+		{name: "step does not match", spec: "*/15 * * * *", t: saturday.Add(50 * time.Minute), want: false},
+		{name: "value with step matches", spec: "5/15 * * * *", t: saturday.Add(20 * time.Minute), want: true},
+		{name: "day of week name matches", spec: "0 2 * * sat", t: saturday, want: true},
+		{name: "day of week does not match", spec: "0 2 * * mon-fri", t: saturday, want: false},
+		{name: "sunday as 7 matches", spec: "0 2 * * 7", t: saturday.Add(24 * time.Hour), want: true},
+		{name: "month name matches", spec: "0 2 * oct *", t: saturday, want: true},
+		{name: "month does not match", spec: "0 2 * nov *", t: saturday, want: false},
+		{name: "day of month or day of week match if both are restricted", spec: "0 2 1 * sat", t: saturday, want: true},
+		{name: "day of month and day of week must match if one is not restricted", spec: "0 2 1 * *", t: saturday, want: false},
+		{name: "macro matches", spec: "@daily", t: saturday.Add(-2 * time.Hour), want: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			s, err := Parse(tt.spec)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(s.Matches(tt.t)).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestScheduleLast(t *testing.T) {
+	g := NewWithT(t)
+
+	s, err := Parse("0 2 * * sat")
+	g.Expect(err).ToNot(HaveOccurred())
+
+	start := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+
+	last, ok := s.Last(start.Add(90*time.Minute+30*time.Second), 2*time.Hour)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(last).To(Equal(start))
+
+	last, ok = s.Last(start, 0)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(last).To(Equal(start))
+
+	_, ok = s.Last(start.Add(3*time.Hour), 2*time.Hour)
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = s.Last(start.Add(-time.Minute), 24*time.Hour)
+	g.Expect(ok).To(BeFalse())
+}
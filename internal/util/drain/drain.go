@@ -0,0 +1,94 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package drain implements the helpers used to cordon and drain the Nodes of Machines and MachinePools.
+package drain
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/klog/v2"
+	kubedrain "k8s.io/kubectl/pkg/drain"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api/controllers/noderefutil"
+)
+
+// NewHelper returns the helper used to cordon and drain a Node.
+func NewHelper(ctx context.Context, kubeClient kubernetes.Interface, node *corev1.Node) *kubedrain.Helper {
+	log := ctrl.LoggerFrom(ctx, "Node", klog.KObj(node))
+
+	drainer := &kubedrain.Helper{
+		Client:              kubeClient,
+		Ctx:                 ctx,
+		Force:               true,
+		IgnoreAllDaemonSets: true,
+		DeleteEmptyDirData:  true,
+		GracePeriodSeconds:  -1,
+		// If a pod is not evicted in 20 seconds, retry the eviction next time the
+		// object gets reconciled again (to allow other objects to be reconciled).
+		Timeout: 20 * time.Second,
+		OnPodDeletedOrEvicted: func(pod *corev1.Pod, usingEviction bool) {
+			verbStr := "Deleted"
+			if usingEviction {
+				verbStr = "Evicted"
+			}
+			log.Info(fmt.Sprintf("%s pod from Node", verbStr),
+				"Pod", klog.KObj(pod))
+		},
+		Out: writer{log.Info},
+		ErrOut: writer{func(msg string, keysAndValues ...interface{}) {
+			log.Error(nil, msg, keysAndValues...)
+		}},
+	}
+
+	if noderefutil.IsNodeUnreachable(node) {
+		// When the node is unreachable and some pods are not evicted for as long as this timeout, we ignore them.
+		drainer.SkipWaitForDeleteTimeoutSeconds = 60 * 5 // 5 minutes
+	}
+
+	return drainer
+}
+
+// CordonAndDrainNode cordons and drains a Node.
+func CordonAndDrainNode(ctx context.Context, kubeClient kubernetes.Interface, node *corev1.Node) error {
+	drainer := NewHelper(ctx, kubeClient, node)
+
+	if err := kubedrain.RunCordonOrUncordon(drainer, node, true); err != nil {
+		return errors.Wrapf(err, "unable to cordon node %v", node.Name)
+	}
+
+	if err := kubedrain.RunNodeDrain(drainer, node.Name); err != nil {
+		return errors.Wrapf(err, "unable to drain node %v", node.Name)
+	}
+	return nil
+}
+
+// writer implements io.Writer interface as a pass-through for klog.
+type writer struct {
+	logFunc func(msg string, keysAndValues ...interface{})
+}
+
+// Write passes string(p) into writer's logFunc and always returns len(p).
+func (w writer) Write(p []byte) (n int, err error) {
+	w.logFunc(string(p))
+	return len(p), nil
+}
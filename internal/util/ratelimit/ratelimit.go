@@ -0,0 +1,251 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package ratelimit implements per-namespace and per-cluster reconcile rate limiting for controllers, so objects
+// of a namespace or of a Cluster generating a lot of churn cannot consume all the reconcile throughput of a controller.
+package ratelimit
+
+import (
+	"context"
+	"math"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// Options are the options for reconcile rate limiting.
+type Options struct {
+	// NamespaceQPS is the default maximum number of reconciles per second of the objects in a namespace;
+	// it can be overridden for a namespace with the ReconcileRateLimitAnnotation. If zero, there is no default limit.
+	NamespaceQPS float64
+
+	// ClusterQPS is the default maximum number of reconciles per second of the objects of a Cluster;
+	// it can be overridden for a Cluster with the ReconcileRateLimitAnnotation. If zero, there is no default limit.
+	ClusterQPS float64
+}
+
+var (
+	rateLimitOptionsLock sync.RWMutex
+	rateLimitOptions     *Options
+)
+
+// Configure enables reconcile rate limiting for the controllers using Reconciler; it must be called
+// before setting up the controllers.
+func Configure(options Options) {
+	rateLimitOptionsLock.Lock()
+	defer rateLimitOptionsLock.Unlock()
+
+	rateLimitOptions = &options
+}
+
+// Reconciler returns a reconciler limiting the reconciles of r per namespace and per Cluster, if reconcile rate
+// limiting is enabled; otherwise r is returned unchanged.
+// The Cluster of a reconciled object is the object itself for Clusters, and the Cluster from the ClusterNameLabel
+// for all the other objects; obj must be an empty object of the type reconciled by r, and it is read using c.
+// NOTE: Reconciles exceeding the limit are requeued without calling r, after the time required to respect the limit.
+func Reconciler(r reconcile.Reconciler, c client.Reader, obj client.Object) reconcile.Reconciler {
+	rateLimitOptionsLock.RLock()
+	defer rateLimitOptionsLock.RUnlock()
+
+	if rateLimitOptions == nil {
+		return r
+	}
+	return &limitedReconciler{
+		Reconciler: r,
+		client:     c,
+		obj:        obj,
+		limiter:    NewLimiter(*rateLimitOptions),
+	}
+}
+
+type limitedReconciler struct {
+	reconcile.Reconciler
+	client  client.Reader
+	obj     client.Object
+	limiter *Limiter
+}
+
+func (r *limitedReconciler) Reconcile(ctx context.Context, req reconcile.Request) (reconcile.Result, error) {
+	namespaceQPS, clusterName, clusterQPS, err := r.getLimits(ctx, req)
+	if err != nil {
+		return reconcile.Result{}, err
+	}
+
+	if delay := r.limiter.Take(req.Namespace, namespaceQPS, clusterName, clusterQPS); delay > 0 {
+		ctrl.LoggerFrom(ctx).V(4).Info("Reconcile rate limited, requeuing", "requeueAfter", delay)
+		return reconcile.Result{RequeueAfter: delay}, nil
+	}
+	return r.Reconciler.Reconcile(ctx, req)
+}
+
+// getLimits returns the limits for the namespace of the request, the Cluster of the reconciled object and
+// the limits for the Cluster.
+func (r *limitedReconciler) getLimits(ctx context.Context, req reconcile.Request) (float64, string, float64, error) {
+	namespace := &corev1.Namespace{}
+	if err := r.client.Get(ctx, client.ObjectKey{Name: req.Namespace}, namespace); err != nil && !apierrors.IsNotFound(err) {
+		return 0, "", 0, errors.Wrapf(err, "failed to get Namespace %s", req.Namespace)
+	}
+	namespaceQPS := qpsFrom(ctx, namespace, r.limiter.options.NamespaceQPS)
+
+	obj := r.obj.DeepCopyObject().(client.Object)
+	if err := r.client.Get(ctx, req.NamespacedName, obj); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Reconcile deleted objects without limits for the Cluster.
+			return namespaceQPS, "", 0, nil
+		}
+		return 0, "", 0, errors.Wrapf(err, "failed to get %s", klog.KRef(req.Namespace, req.Name))
+	}
+
+	cluster, ok := obj.(*clusterv1.Cluster)
+	if !ok {
+		clusterName := obj.GetLabels()[clusterv1.ClusterNameLabel]
+		if clusterName == "" {
+			return namespaceQPS, "", 0, nil
+		}
+		cluster = &clusterv1.Cluster{}
+		if err := r.client.Get(ctx, client.ObjectKey{Namespace: req.Namespace, Name: clusterName}, cluster); err != nil {
+			if !apierrors.IsNotFound(err) {
+				return 0, "", 0, errors.Wrapf(err, "failed to get Cluster %s", klog.KRef(req.Namespace, clusterName))
+			}
+			// Use the default limits for the Cluster if it does not exist, e.g. because it has not been created yet.
+			cluster.Name = clusterName
+		}
+	}
+	return namespaceQPS, cluster.Name, qpsFrom(ctx, cluster, r.limiter.options.ClusterQPS), nil
+}
+
+// qpsFrom returns the limit from the ReconcileRateLimitAnnotation of obj, if set to a valid value, or defaultQPS.
+func qpsFrom(ctx context.Context, obj client.Object, defaultQPS float64) float64 {
+	value, ok := obj.GetAnnotations()[clusterv1.ReconcileRateLimitAnnotation]
+	if !ok {
+		return defaultQPS
+	}
+	qps, err := strconv.ParseFloat(value, 64)
+	if err != nil || qps <= 0 || math.IsInf(qps, 0) {
+		ctrl.LoggerFrom(ctx).V(4).Info("Ignoring invalid reconcile rate limit annotation", "object", klog.KObj(obj), "value", value)
+		return defaultQPS
+	}
+	return qps
+}
+
+// evictInterval is the minimum interval between two evictions of the idle buckets of a Limiter.
+const evictInterval = time.Minute
+
+// Limiter limits the reconciles per namespace and per Cluster using a token bucket for each namespace
+// and for each Cluster; each bucket allows a burst of reconciles equal to its limit, with a minimum of one.
+// Buckets are evicted when idle, so deleted namespaces and Clusters do not leak memory.
+type Limiter struct {
+	options Options
+
+	lock      sync.Mutex
+	buckets   map[string]*bucket
+	lastEvict time.Time
+	now       func() time.Time
+}
+
+// NewLimiter returns a new Limiter.
+func NewLimiter(options Options) *Limiter {
+	return &Limiter{
+		options: options,
+		buckets: map[string]*bucket{},
+		now:     time.Now,
+	}
+}
+
+// Take takes a token from the bucket of the namespace and from the bucket of the Cluster, if the corresponding
+// limit is greater than zero; if a token is not available, no token is taken and Take returns the time after which
+// tokens will be available.
+func (l *Limiter) Take(namespace string, namespaceQPS float64, clusterName string, clusterQPS float64) time.Duration {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+
+	now := l.now()
+	if now.Sub(l.lastEvict) >= evictInterval {
+		l.evictIdleBuckets(now)
+		l.lastEvict = now
+	}
+
+	var buckets []*bucket
+	if namespaceQPS > 0 {
+		buckets = append(buckets, l.bucket(namespace, namespaceQPS, now))
+	}
+	if clusterName != "" && clusterQPS > 0 {
+		buckets = append(buckets, l.bucket(namespace+"/"+clusterName, clusterQPS, now))
+	}
+
+	var delay time.Duration
+	for _, b := range buckets {
+		if d := b.delay(); d > delay {
+			delay = d
+		}
+	}
+	if delay > 0 {
+		return delay
+	}
+	for _, b := range buckets {
+		b.tokens--
+	}
+	return 0
+}
+
+// bucket returns the bucket for key, refilled up to now; it must be called with the lock held.
+func (l *Limiter) bucket(key string, qps float64, now time.Time) *bucket {
+	burst := math.Max(1, qps)
+	b, ok := l.buckets[key]
+	if !ok {
+		b = &bucket{tokens: burst, last: now}
+		l.buckets[key] = b
+	}
+	b.qps = qps
+	b.tokens = math.Min(burst, b.tokens+now.Sub(b.last).Seconds()*qps)
+	b.last = now
+	return b
+}
+
+// evictIdleBuckets deletes the buckets refilled up to their burst while idle; those buckets are equivalent to
+// new buckets, so evicting them does not change the limits. It must be called with the lock held.
+func (l *Limiter) evictIdleBuckets(now time.Time) {
+	for key, b := range l.buckets {
+		if b.tokens+now.Sub(b.last).Seconds()*b.qps >= math.Max(1, b.qps) {
+			delete(l.buckets, key)
+		}
+	}
+}
+
+type bucket struct {
+	qps    float64
+	tokens float64
+	last   time.Time
+}
+
+// delay returns the time after which a token will be available.
+func (b *bucket) delay() time.Duration {
+	if b.tokens >= 1 {
+		return 0
+	}
+	// NOTE: return at least one millisecond, so the delay is never rounded down to zero.
+	return time.Duration(math.Max((1-b.tokens)/b.qps*float64(time.Second), float64(time.Millisecond)))
+}
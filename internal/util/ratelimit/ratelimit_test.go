@@ -0,0 +1,177 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package ratelimit
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestLimiter(t *testing.T) {
+	newLimiter := func() (*Limiter, *time.Time) {
+		now := time.Date(2023, time.October, 14, 2, 0, 0, 0, time.UTC)
+		l := NewLimiter(Options{})
+		l.now = func() time.Time { return now }
+		return l, &now
+	}
+
+	t.Run("allows a burst equal to the limit, then requeues until tokens are available", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter()
+		g.Expect(l.Take("ns", 2, "", 0)).To(BeZero())
+		g.Expect(l.Take("ns", 2, "", 0)).To(BeZero())
+		g.Expect(l.Take("ns", 2, "", 0)).To(Equal(500 * time.Millisecond))
+
+		*now = now.Add(500 * time.Millisecond)
+		g.Expect(l.Take("ns", 2, "", 0)).To(BeZero())
+		g.Expect(l.Take("ns", 2, "", 0)).To(Equal(500 * time.Millisecond))
+
+		// Other namespaces are not affected.
+		g.Expect(l.Take("other", 2, "", 0)).To(BeZero())
+	})
+
+	t.Run("limits per Cluster, without taking tokens if one of the limits is exceeded", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter()
+		g.Expect(l.Take("ns", 2, "cluster1", 0.5)).To(BeZero())
+		g.Expect(l.Take("ns", 2, "cluster1", 0.5)).To(Equal(2 * time.Second))
+		// The token of the namespace is not taken by the rate limited reconcile.
+		g.Expect(l.Take("ns", 2, "cluster2", 0.5)).To(BeZero())
+		g.Expect(l.Take("ns", 2, "cluster3", 0.5)).To(Equal(500 * time.Millisecond))
+
+		*now = now.Add(2 * time.Second)
+		g.Expect(l.Take("ns", 2, "cluster1", 0.5)).To(BeZero())
+	})
+
+	t.Run("evicts the idle buckets", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, now := newLimiter()
+		g.Expect(l.Take("ns1", 1, "cluster1", 1)).To(BeZero())
+		g.Expect(l.Take("ns2", 0.01, "", 0)).To(BeZero())
+		g.Expect(l.buckets).To(HaveLen(3))
+
+		// The buckets of ns1 are refilled and evicted, while the bucket of ns2 is still refilling.
+		*now = now.Add(evictInterval)
+		g.Expect(l.Take("ns3", 1, "", 0)).To(BeZero())
+		g.Expect(l.buckets).To(HaveKey("ns2"))
+		g.Expect(l.buckets).To(HaveKey("ns3"))
+		g.Expect(l.buckets).To(HaveLen(2))
+
+		// Evicted buckets are re-created with a full burst.
+		g.Expect(l.Take("ns1", 1, "cluster1", 1)).To(BeZero())
+		g.Expect(l.Take("ns1", 1, "cluster1", 1)).To(Equal(time.Second))
+	})
+
+	t.Run("does not limit without limits", func(t *testing.T) {
+		g := NewWithT(t)
+
+		l, _ := newLimiter()
+		for i := 0; i < 10; i++ {
+			g.Expect(l.Take("ns", 0, "cluster1", 0)).To(BeZero())
+		}
+	})
+}
+
+func TestReconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	defer func() {
+		rateLimitOptionsLock.Lock()
+		rateLimitOptions = nil
+		rateLimitOptionsLock.Unlock()
+	}()
+
+	scheme := runtime.NewScheme()
+	g.Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		&corev1.Namespace{ObjectMeta: metav1.ObjectMeta{
+			Name: "ns",
+		}},
+		&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{
+			Namespace:   "ns",
+			Name:        "cluster1",
+			Annotations: map[string]string{clusterv1.ReconcileRateLimitAnnotation: "1"},
+		}},
+		&clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "machine1",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: "cluster1"},
+		}},
+		&clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: "ns",
+			Name:      "machine2",
+		}},
+	).Build()
+
+	r := &fakeReconciler{}
+	g.Expect(Reconciler(r, c, &clusterv1.Machine{})).To(BeIdenticalTo(r))
+
+	Configure(Options{})
+	limited := Reconciler(r, c, &clusterv1.Machine{})
+	g.Expect(limited).To(BeAssignableToTypeOf(&limitedReconciler{}))
+
+	request := func(name string) reconcile.Request {
+		return reconcile.Request{NamespacedName: types.NamespacedName{Namespace: "ns", Name: name}}
+	}
+
+	// The limit from the annotation on the Cluster applies to the Machines of the Cluster.
+	res, err := limited.Reconcile(ctx, request("machine1"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.IsZero()).To(BeTrue())
+	res, err = limited.Reconcile(ctx, request("machine1"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(res.RequeueAfter).To(BeNumerically(">", 0))
+	g.Expect(r.calls).To(Equal(1))
+
+	// Machines without a Cluster and deleted Machines are not limited.
+	for i := 0; i < 3; i++ {
+		res, err = limited.Reconcile(ctx, request("machine2"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+		res, err = limited.Reconcile(ctx, request("deleted"))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(res.IsZero()).To(BeTrue())
+	}
+	g.Expect(r.calls).To(Equal(7))
+}
+
+var ctx = context.Background()
+
+type fakeReconciler struct {
+	calls int
+}
+
+func (r *fakeReconciler) Reconcile(_ context.Context, _ reconcile.Request) (reconcile.Result, error) {
+	r.calls++
+	return reconcile.Result{}, nil
+}
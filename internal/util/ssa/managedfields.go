@@ -30,7 +30,13 @@ import (
 	"sigs.k8s.io/cluster-api/internal/contract"
 )
 
-const classicManager = "manager"
+const (
+	classicManager = "manager"
+
+	// beforeFirstApplyManager is the manager the API server assigns to the fields of an object without managedFields
+	// when SSA is applied for the first time on it.
+	beforeFirstApplyManager = "before-first-apply"
+)
 
 // DropManagedFields modifies the managedFields entries on the object that belong to "manager" (Operation=Update)
 // to drop ownership of the given paths if there is no field yet that is managed by `ssaManager`.
@@ -168,6 +174,131 @@ func CleanUpManagedFieldsForSSAAdoption(ctx context.Context, c client.Client, ob
 	return c.Patch(ctx, obj, client.MergeFrom(base))
 }
 
+// DropCoOwnedManagedFields modifies the managedFields entries on the object that belong to "before-first-apply"
+// (Operation=Update) to drop ownership of the fields which are also owned by `ssaManager`.
+//
+// When SSA is applied for the first time on an object without managedFields, the API server assigns all the existing
+// fields to "before-first-apply"; those fields end up co-owned by "before-first-apply" and `ssaManager`, and they are
+// not dropped when `ssaManager` stops applying them. This leads to spurious rollouts after controller upgrades,
+// e.g. when a label dropped from a MachineDeployment is never dropped from its MachineSets.
+// NOTE: Entries of "manager" are not modified, because "manager" is also the field manager used by the Cluster API
+// controllers for regular patches (e.g. the patch helper), and those fields would be written back at every reconcile.
+// This func can be called at every reconcile, given that "before-first-apply" is never written again, and thus
+// the object is patched only once.
+func DropCoOwnedManagedFields(ctx context.Context, c client.Client, obj client.Object, ssaManager string) error {
+	managedFields, changed, err := dropCoOwnedManagedFields(obj.GetManagedFields(), ssaManager)
+	if err != nil {
+		return errors.Wrapf(err, "failed to drop co-owned managed fields of object %s", klog.KObj(obj))
+	}
+	if !changed {
+		return nil
+	}
+
+	base := obj.DeepCopyObject().(client.Object)
+	obj.SetManagedFields(managedFields)
+
+	return c.Patch(ctx, obj, client.MergeFrom(base))
+}
+
+// dropCoOwnedManagedFields returns the managedFields entries without the ownership of the fields co-owned by
+// "before-first-apply" and `ssaManager`, and true if any entry has been changed; entries left without fields are dropped.
+func dropCoOwnedManagedFields(managedFields []metav1.ManagedFieldsEntry, ssaManager string) ([]metav1.ManagedFieldsEntry, bool, error) {
+	// Collect the fields owned by `ssaManager`, by subresource.
+	ssaFields := map[string]map[string]interface{}{}
+	for _, managedField := range managedFields {
+		if managedField.Manager != ssaManager || managedField.Operation != metav1.ManagedFieldsOperationApply || managedField.FieldsV1 == nil {
+			continue
+		}
+		fieldsV1 := map[string]interface{}{}
+		if err := json.Unmarshal(managedField.FieldsV1.Raw, &fieldsV1); err != nil {
+			return nil, false, errors.Wrap(err, "failed to unmarshal managed fields")
+		}
+		if _, ok := ssaFields[managedField.Subresource]; !ok {
+			ssaFields[managedField.Subresource] = map[string]interface{}{}
+		}
+		mergeFieldsV1(ssaFields[managedField.Subresource], fieldsV1)
+	}
+	if len(ssaFields) == 0 {
+		return managedFields, false, nil
+	}
+
+	changed := false
+	newManagedFields := make([]metav1.ManagedFieldsEntry, 0, len(managedFields))
+	for _, managedField := range managedFields {
+		owned, ok := ssaFields[managedField.Subresource]
+		if !ok || managedField.FieldsV1 == nil || managedField.Operation != metav1.ManagedFieldsOperationUpdate ||
+			managedField.Manager != beforeFirstApplyManager {
+			// Do not modify the entry. Use as is.
+			newManagedFields = append(newManagedFields, managedField)
+			continue
+		}
+
+		fieldsV1 := map[string]interface{}{}
+		if err := json.Unmarshal(managedField.FieldsV1.Raw, &fieldsV1); err != nil {
+			return nil, false, errors.Wrap(err, "failed to unmarshal managed fields")
+		}
+		if !dropFieldsV1(fieldsV1, owned) {
+			newManagedFields = append(newManagedFields, managedField)
+			continue
+		}
+		changed = true
+
+		// Drop the entry if there are no fields left.
+		if len(fieldsV1) == 0 {
+			continue
+		}
+		fieldsV1Raw, err := json.Marshal(fieldsV1)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "failed to marshal managed fields")
+		}
+		managedField.FieldsV1 = &metav1.FieldsV1{Raw: fieldsV1Raw}
+		newManagedFields = append(newManagedFields, managedField)
+	}
+	return newManagedFields, changed, nil
+}
+
+// mergeFieldsV1 adds the fields in src to dst.
+// NOTE: fieldsV1 are tries, where leaves are empty maps.
+func mergeFieldsV1(dst, src map[string]interface{}) {
+	for k, v := range src {
+		srcValue, _ := v.(map[string]interface{})
+		dstValue, ok := dst[k].(map[string]interface{})
+		if !ok {
+			dst[k] = srcValue
+			continue
+		}
+		mergeFieldsV1(dstValue, srcValue)
+	}
+}
+
+// dropFieldsV1 drops from fields the fields which are also in owned; it returns true if any field has been dropped.
+// NOTE: if a field is a leaf in one of the two tries, the field is entirely owned by the corresponding manager,
+// so the field is entirely dropped, including its sub fields.
+func dropFieldsV1(fields, owned map[string]interface{}) bool {
+	dropped := false
+	for k, v := range fields {
+		ownedValue, ok := owned[k]
+		if !ok {
+			continue
+		}
+		value, _ := v.(map[string]interface{})
+		ownedMap, _ := ownedValue.(map[string]interface{})
+		if len(value) == 0 || len(ownedMap) == 0 {
+			delete(fields, k)
+			dropped = true
+			continue
+		}
+		if dropFieldsV1(value, ownedMap) {
+			dropped = true
+			// Drop the field if there are no sub fields left, otherwise it would become a leaf.
+			if len(value) == 0 {
+				delete(fields, k)
+			}
+		}
+	}
+	return dropped
+}
+
 // hasFieldsManagedBy returns true if any of the fields in obj are managed by manager.
 func hasFieldsManagedBy(obj client.Object, manager string) bool {
 	managedFields := obj.GetManagedFields()
@@ -264,3 +264,174 @@ func TestCleanUpManagedFieldsForSSAAdoption(t *testing.T) {
 		})
 	}
 }
+
+func TestDropCoOwnedManagedFields(t *testing.T) {
+	ctx := context.Background()
+
+	ssaManager := "ssa-manager"
+
+	fieldsV1 := func(fields map[string]interface{}) *metav1.FieldsV1 {
+		fieldsV1Raw, err := json.Marshal(fields)
+		if err != nil {
+			panic(err)
+		}
+		return &metav1.FieldsV1{Raw: fieldsV1Raw}
+	}
+	ssaManagerEntry := metav1.ManagedFieldsEntry{
+		Manager:    ssaManager,
+		Operation:  metav1.ManagedFieldsOperationApply,
+		FieldsType: "FieldsV1",
+		FieldsV1: fieldsV1(map[string]interface{}{
+			"f:metadata": map[string]interface{}{
+				"f:labels": map[string]interface{}{
+					"f:foo": map[string]interface{}{},
+				},
+				"f:annotations": map[string]interface{}{},
+			},
+		}),
+	}
+
+	tests := []struct {
+		name              string
+		managedFields     []metav1.ManagedFieldsEntry
+		wantManagedFields []metav1.ManagedFieldsEntry
+	}{
+		{
+			name: "should drop co-owned fields from the before-first-apply manager entry",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    beforeFirstApplyManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+								"f:bar": map[string]interface{}{},
+							},
+							"f:annotations": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+				ssaManagerEntry,
+			},
+			wantManagedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    beforeFirstApplyManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:bar": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+				ssaManagerEntry,
+			},
+		},
+		{
+			name: "should drop the before-first-apply manager entry if all its fields are co-owned",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    beforeFirstApplyManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+				ssaManagerEntry,
+			},
+			wantManagedFields: []metav1.ManagedFieldsEntry{
+				ssaManagerEntry,
+			},
+		},
+		{
+			name: "should not change entries of the classic manager (no-op)",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    classicManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+				ssaManagerEntry,
+			},
+		},
+		{
+			name: "should not change entries of other managers (no-op)",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    "other-manager",
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+				ssaManagerEntry,
+			},
+		},
+		{
+			name: "should not change entries if ssaManager does not own any field (no-op)",
+			managedFields: []metav1.ManagedFieldsEntry{
+				{
+					Manager:    beforeFirstApplyManager,
+					Operation:  metav1.ManagedFieldsOperationUpdate,
+					FieldsType: "FieldsV1",
+					FieldsV1: fieldsV1(map[string]interface{}{
+						"f:metadata": map[string]interface{}{
+							"f:labels": map[string]interface{}{
+								"f:foo": map[string]interface{}{},
+							},
+						},
+					}),
+				},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			obj := &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:          "cm-1",
+					Namespace:     "default",
+					ManagedFields: tt.managedFields,
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithObjects(obj).Build()
+			g.Expect(DropCoOwnedManagedFields(ctx, fakeClient, obj, ssaManager)).Should(Succeed())
+
+			wantManagedFields := tt.wantManagedFields
+			if wantManagedFields == nil {
+				wantManagedFields = tt.managedFields
+			}
+			g.Expect(obj.GetManagedFields()).Should(HaveLen(len(wantManagedFields)))
+			for i := range wantManagedFields {
+				g.Expect(obj.GetManagedFields()[i].Manager).Should(Equal(wantManagedFields[i].Manager))
+				g.Expect(obj.GetManagedFields()[i].Operation).Should(Equal(wantManagedFields[i].Operation))
+				g.Expect(obj.GetManagedFields()[i].FieldsV1.Raw).Should(MatchJSON(wantManagedFields[i].FieldsV1.Raw))
+			}
+		})
+	}
+}
@@ -37,6 +37,22 @@ func RemoveNodeTaint(node *corev1.Node, drop corev1.Taint) bool {
 	return droppedTaint
 }
 
+// EnsureNodeTaint makes sure the node has the taint, with the same value.
+// It returns true if the taints are modified, false otherwise.
+func EnsureNodeTaint(node *corev1.Node, taint corev1.Taint) bool {
+	for i := range node.Spec.Taints {
+		if node.Spec.Taints[i].MatchTaint(&taint) {
+			if node.Spec.Taints[i].Value == taint.Value {
+				return false
+			}
+			node.Spec.Taints[i].Value = taint.Value
+			return true
+		}
+	}
+	node.Spec.Taints = append(node.Spec.Taints, taint)
+	return true
+}
+
 // HasTaint returns true if the targetTaint is in the list of taints.
 func HasTaint(taints []corev1.Taint, targetTaint corev1.Taint) bool {
 	for _, taint := range taints {
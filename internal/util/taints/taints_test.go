@@ -76,3 +76,59 @@ func TestRemoveNodeTaint(t *testing.T) {
 		})
 	}
 }
+
+func TestEnsureNodeTaint(t *testing.T) {
+	taint1 := corev1.Taint{Key: "taint1", Effect: corev1.TaintEffectNoSchedule}
+	taint2 := corev1.Taint{Key: "taint2", Effect: corev1.TaintEffectNoSchedule}
+	taint2WithValue := corev1.Taint{Key: "taint2", Value: "value", Effect: corev1.TaintEffectNoSchedule}
+
+	tests := []struct {
+		name         string
+		node         *corev1.Node
+		addTaint     corev1.Taint
+		wantTaints   []corev1.Taint
+		wantModified bool
+	}{
+		{
+			name: "adding missing taint to node should return true",
+			node: &corev1.Node{Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{
+					taint1,
+				}}},
+			addTaint:     taint2,
+			wantTaints:   []corev1.Taint{taint1, taint2},
+			wantModified: true,
+		},
+		{
+			name: "adding existing taint should return false",
+			node: &corev1.Node{Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{
+					taint1,
+					taint2,
+				}}},
+			addTaint:     taint2,
+			wantTaints:   []corev1.Taint{taint1, taint2},
+			wantModified: false,
+		},
+		{
+			name: "adding existing taint with a different value should update the value and return true",
+			node: &corev1.Node{Spec: corev1.NodeSpec{
+				Taints: []corev1.Taint{
+					taint1,
+					taint2,
+				}}},
+			addTaint:     taint2WithValue,
+			wantTaints:   []corev1.Taint{taint1, taint2WithValue},
+			wantModified: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			got := EnsureNodeTaint(tt.node, tt.addTaint)
+			g.Expect(got).To(Equal(tt.wantModified))
+			g.Expect(tt.node.Spec.Taints).To(BeComparableTo(tt.wantTaints))
+		})
+	}
+}
@@ -18,6 +18,7 @@ package webhooks
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
 	"net"
 	"strconv"
@@ -26,8 +27,10 @@ import (
 
 	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/apimachinery/pkg/util/wait"
@@ -40,6 +43,7 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/version"
 )
@@ -59,6 +63,10 @@ func (webhook *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // Cluster implements a validating and defaulting webhook for Cluster.
 type Cluster struct {
 	Client client.Reader
+
+	// SubjectAccessReviewClient is used to check that the user creating or updating a Cluster is allowed to use
+	// a ClusterClass in another namespace. If nil, the check is skipped.
+	SubjectAccessReviewClient client.Writer
 }
 
 var _ webhook.CustomDefaulter = &Cluster{}
@@ -96,7 +104,7 @@ func (webhook *Cluster) Default(ctx context.Context, obj runtime.Object) error {
 			if apierrors.IsNotFound(err) || errors.Is(err, errClusterClassNotReconciled) {
 				return nil
 			}
-			return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be defaulted. ClusterClass %s can not be retrieved", cluster.Name, cluster.Spec.Topology.Class))
+			return apierrors.NewInternalError(errors.Wrapf(err, "Cluster %s can't be defaulted. ClusterClass %s can not be retrieved", cluster.Name, cluster.GetClassKey()))
 		}
 
 		// Doing both defaulting and validating here prevents a race condition where the ClusterClass could be
@@ -188,6 +196,13 @@ func (webhook *Cluster) validate(ctx context.Context, oldCluster, newCluster *cl
 		}
 	}
 
+	// Ensure that the kubeconfig endpoints set in annotations are valid.
+	allErrs = append(allErrs, validateKubeconfigEndpointAnnotations(newCluster.Annotations)...)
+	if _, ok := newCluster.Annotations[clusterv1.ClusterKubeconfigEndpointAnnotation]; ok && newCluster.Spec.ControlPlaneRef != nil {
+		allWarnings = append(allWarnings, fmt.Sprintf("the %s annotation is ignored for Clusters with a control plane provider, which generates the kubeconfig Secret; "+
+			"use the control plane provider API instead, e.g. spec.kubeconfigEndpoint for KubeadmControlPlane", clusterv1.ClusterKubeconfigEndpointAnnotation))
+	}
+
 	topologyPath := specPath.Child("topology")
 
 	// Validate the managed topology, if defined.
@@ -258,6 +273,9 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 	// metadata in topology should be valid
 	allErrs = append(allErrs, validateTopologyMetadata(newCluster.Spec.Topology, fldPath)...)
 
+	// replicas must not be set for MachineDeployments with replicas managed by the autoscaler.
+	allErrs = append(allErrs, validateMachineDeploymentsAutoscalerReplicas(oldCluster, newCluster, fldPath)...)
+
 	// upgrade concurrency should be a numeric value.
 	if concurrency, ok := newCluster.Annotations[clusterv1.ClusterTopologyUpgradeConcurrencyAnnotation]; ok {
 		concurrencyAnnotationField := field.NewPath("metadata", "annotations", clusterv1.ClusterTopologyUpgradeConcurrencyAnnotation)
@@ -277,6 +295,15 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 		}
 	}
 
+	// A ClusterClass in another namespace can be used only if the user is allowed to use it.
+	if newCluster.GetClassKey().Namespace != newCluster.Namespace &&
+		(oldCluster == nil || oldCluster.GetClassKey() != newCluster.GetClassKey()) {
+		if err := webhook.validateClusterClassCanBeUsed(ctx, newCluster); err != nil {
+			allErrs = append(allErrs, field.Forbidden(fldPath.Child("classNamespace"), err.Error()))
+			return allWarnings, allErrs
+		}
+	}
+
 	// Get the ClusterClass referenced in the Cluster.
 	clusterClass, warnings, clusterClassPollErr := webhook.validateClusterClassExistsAndIsReconciled(ctx, newCluster)
 	// If the error is anything other than "NotFound" or "NotReconciled" return all errors.
@@ -293,6 +320,14 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 
 	// If there's no error validate the Cluster based on the ClusterClass.
 	if clusterClassPollErr == nil {
+		// A ClusterClass in another namespace must be exported to the namespace of the Cluster.
+		if !check.ClusterClassIsExportedToNamespace(clusterClass, newCluster.Namespace) {
+			allErrs = append(allErrs, field.Forbidden(
+				fldPath.Child("classNamespace"),
+				fmt.Sprintf("ClusterClass %s is not exported to namespace %s", newCluster.GetClassKey(), newCluster.Namespace),
+			))
+			return allWarnings, allErrs
+		}
 		allErrs = append(allErrs, ValidateClusterForClusterClass(newCluster, clusterClass)...)
 	}
 	if oldCluster != nil { // On update
@@ -373,8 +408,15 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 			)
 		}
 
+		// A version upgrade must not leave the kubelets of the MachineDeployments outside of the version skew
+		// supported by the new version of the API server.
+		if inVersion.NE(semver.Version{}) && oldVersion.NE(semver.Version{}) && version.Compare(inVersion, oldVersion, version.WithBuildTags()) == 1 &&
+			!skipVersionSkewValidation(newCluster.Annotations) {
+			allErrs = append(allErrs, webhook.validateMachineDeploymentsVersionSkew(ctx, newCluster, inVersion, fldPath.Child("version"))...)
+		}
+
 		// If the ClusterClass referenced in the Topology has changed compatibility checks are needed.
-		if oldCluster.Spec.Topology.Class != newCluster.Spec.Topology.Class {
+		if oldCluster.GetClassKey() != newCluster.GetClassKey() {
 			// Check to see if the ClusterClass referenced in the old version of the Cluster exists.
 			oldClusterClass, err := webhook.pollClusterClassForCluster(ctx, oldCluster)
 			if err != nil {
@@ -382,7 +424,7 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 					allErrs, field.Forbidden(
 						fldPath.Child("class"),
 						fmt.Sprintf("valid ClusterClass with name %q could not be retrieved, change from class %[1]q to class %q cannot be validated. Error: %s",
-							oldCluster.Spec.Topology.Class, newCluster.Spec.Topology.Class, err.Error())))
+							oldCluster.GetClassKey(), newCluster.GetClassKey(), err.Error())))
 
 				// Return early with errors if the ClusterClass can't be retrieved.
 				return allWarnings, allErrs
@@ -395,6 +437,52 @@ func (webhook *Cluster) validateTopology(ctx context.Context, oldCluster, newClu
 	return allWarnings, allErrs
 }
 
+// validateMachineDeploymentsVersionSkew validates that the kubelets of the MachineDeployments of the Cluster are within
+// the version skew supported by the given control plane version.
+func (webhook *Cluster) validateMachineDeploymentsVersionSkew(ctx context.Context, cluster *clusterv1.Cluster, controlPlaneVersion semver.Version, fldPath *field.Path) field.ErrorList {
+	if webhook.Client == nil {
+		return nil
+	}
+
+	machineDeployments := &clusterv1.MachineDeploymentList{}
+	if err := webhook.Client.List(ctx, machineDeployments,
+		client.InNamespace(cluster.Namespace),
+		client.MatchingLabels{clusterv1.ClusterNameLabel: cluster.Name},
+	); err != nil {
+		return field.ErrorList{
+			field.InternalError(
+				fldPath,
+				errors.Wrap(err, "failed to list MachineDeployments to validate the version skew"),
+			),
+		}
+	}
+
+	var allErrs field.ErrorList
+	for i := range machineDeployments.Items {
+		md := &machineDeployments.Items[i]
+		if md.Spec.Template.Spec.Version == nil {
+			continue
+		}
+		mdVersion, err := semver.ParseTolerant(*md.Spec.Template.Spec.Version)
+		if err != nil {
+			// NOTE: this should never happen as the MachineDeployment webhook validates the version.
+			continue
+		}
+		if exceedsKubeletVersionSkew(controlPlaneVersion, mdVersion) {
+			allErrs = append(
+				allErrs,
+				field.Forbidden(
+					fldPath,
+					fmt.Sprintf("version cannot be increased to %q because MachineDeployment %s is at version %q and kubelets can be at most %d minor versions older than the API server; "+
+						"upgrade the MachineDeployment to the current version first or set the %q annotation to skip this check",
+						controlPlaneVersion, md.Name, mdVersion, maxKubeletVersionSkew(controlPlaneVersion), clusterv1.SkipVersionSkewValidationAnnotation),
+				),
+			)
+		}
+	}
+	return allErrs
+}
+
 func validateMachineHealthChecks(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
 	var allErrs field.ErrorList
 
@@ -491,6 +579,28 @@ func validateCIDRBlocks(fldPath *field.Path, cidrs []string) field.ErrorList {
 	return allErrs
 }
 
+// validateKubeconfigEndpointAnnotations validates that the kubeconfig endpoint annotations, if set, are in the host:port format.
+func validateKubeconfigEndpointAnnotations(annotations map[string]string) field.ErrorList {
+	var allErrs field.ErrorList
+	for _, annotation := range []string{clusterv1.ClusterKubeconfigEndpointAnnotation, clusterv1.ClusterUserKubeconfigEndpointAnnotation} {
+		endpoint, ok := annotations[annotation]
+		if !ok {
+			continue
+		}
+		host, port, err := net.SplitHostPort(endpoint)
+		if err == nil && (host == "" || port == "") {
+			err = errors.New("host and port must not be empty")
+		}
+		if err != nil {
+			allErrs = append(allErrs, field.Invalid(
+				field.NewPath("metadata", "annotations", annotation),
+				endpoint,
+				fmt.Sprintf("must be an endpoint in the host:port format: %v", err)))
+		}
+	}
+	return allErrs
+}
+
 // DefaultAndValidateVariables defaults and validates variables in the Cluster and MachineDeployment/MachinePool topologies based
 // on the definitions in the ClusterClass.
 func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
@@ -499,7 +609,7 @@ func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clust
 
 	// Variables must be validated in the defaulting webhook. Variable definitions are stored in the ClusterClass status
 	// and are patched in the ClusterClass reconcile.
-	allErrs = append(allErrs, variables.ValidateClusterVariables(cluster.Spec.Topology.Variables, clusterClass.Status.Variables,
+	allErrs = append(allErrs, variables.ValidateClusterVariables(cluster.Spec.Topology.Variables, clusterClass,
 		field.NewPath("spec", "topology", "variables"))...)
 	if cluster.Spec.Topology.Workers != nil {
 		for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
@@ -507,7 +617,7 @@ func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clust
 			if md.Variables == nil || len(md.Variables.Overrides) == 0 {
 				continue
 			}
-			allErrs = append(allErrs, variables.ValidateMachineVariables(md.Variables.Overrides, clusterClass.Status.Variables,
+			allErrs = append(allErrs, variables.ValidateMachineVariables(md.Variables.Overrides, clusterClass,
 				field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("variables", "overrides"))...)
 		}
 		for i, mp := range cluster.Spec.Topology.Workers.MachinePools {
@@ -515,7 +625,7 @@ func DefaultAndValidateVariables(cluster *clusterv1.Cluster, clusterClass *clust
 			if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
 				continue
 			}
-			allErrs = append(allErrs, variables.ValidateMachineVariables(mp.Variables.Overrides, clusterClass.Status.Variables,
+			allErrs = append(allErrs, variables.ValidateMachineVariables(mp.Variables.Overrides, clusterClass,
 				field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("variables", "overrides"))...)
 		}
 	}
@@ -531,7 +641,7 @@ func DefaultVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.Cluste
 	if clusterClass == nil {
 		return field.ErrorList{field.InternalError(field.NewPath(""), errors.New("ClusterClass can not be nil"))}
 	}
-	defaultedVariables, errs := variables.DefaultClusterVariables(cluster.Spec.Topology.Variables, clusterClass.Status.Variables,
+	defaultedVariables, errs := variables.DefaultClusterVariables(cluster.Spec.Topology.Variables, clusterClass,
 		field.NewPath("spec", "topology", "variables"))
 	if len(errs) > 0 {
 		allErrs = append(allErrs, errs...)
@@ -545,7 +655,7 @@ func DefaultVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.Cluste
 			if md.Variables == nil || len(md.Variables.Overrides) == 0 {
 				continue
 			}
-			defaultedVariables, errs := variables.DefaultMachineVariables(md.Variables.Overrides, clusterClass.Status.Variables,
+			defaultedVariables, errs := variables.DefaultMachineVariables(md.Variables.Overrides, clusterClass,
 				field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("variables", "overrides"))
 			if len(errs) > 0 {
 				allErrs = append(allErrs, errs...)
@@ -558,7 +668,7 @@ func DefaultVariables(cluster *clusterv1.Cluster, clusterClass *clusterv1.Cluste
 			if mp.Variables == nil || len(mp.Variables.Overrides) == 0 {
 				continue
 			}
-			defaultedVariables, errs := variables.DefaultMachineVariables(mp.Variables.Overrides, clusterClass.Status.Variables,
+			defaultedVariables, errs := variables.DefaultMachineVariables(mp.Variables.Overrides, clusterClass,
 				field.NewPath("spec", "topology", "workers", "machinePools").Index(i).Child("variables", "overrides"))
 			if len(errs) > 0 {
 				allErrs = append(allErrs, errs...)
@@ -585,9 +695,106 @@ func ValidateClusterForClusterClass(cluster *clusterv1.Cluster, clusterClass *cl
 
 	// Validate the MachineHealthChecks defined in the cluster topology.
 	allErrs = append(allErrs, validateMachineHealthChecks(cluster, clusterClass)...)
+
+	// Validate the TemplateOverrides defined in the cluster topology.
+	allErrs = append(allErrs, validateTemplateOverrides(cluster, clusterClass)...)
 	return allErrs
 }
 
+// validateTemplateOverrides validates that the TemplateOverrides of the MachineDeployment topologies are defined in the
+// corresponding MachineDeploymentClasses and have valid values.
+func validateTemplateOverrides(cluster *clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	if cluster.Spec.Topology.Workers == nil {
+		return nil
+	}
+
+	for i, md := range cluster.Spec.Topology.Workers.MachineDeployments {
+		if len(md.TemplateOverrides) == 0 {
+			continue
+		}
+		fldPath := field.NewPath("spec", "topology", "workers", "machineDeployments").Index(i).Child("templateOverrides")
+
+		// NOTE: MachineDeployment topologies referring to a MachineDeploymentClass not defined in the ClusterClass
+		// are validated in MachineDeploymentTopologiesAreValidAndDefinedInClusterClass.
+		var mdClass *clusterv1.MachineDeploymentClass
+		for j := range clusterClass.Spec.Workers.MachineDeployments {
+			if clusterClass.Spec.Workers.MachineDeployments[j].Class == md.Class {
+				mdClass = &clusterClass.Spec.Workers.MachineDeployments[j]
+				break
+			}
+		}
+		if mdClass == nil {
+			continue
+		}
+
+		overrideNames := sets.Set[string]{}
+		for _, o := range mdClass.TemplateOverrides {
+			overrideNames.Insert(o.Name)
+		}
+		names := sets.Set[string]{}
+		for j, o := range md.TemplateOverrides {
+			if !overrideNames.Has(o.Name) {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(j).Child("name"), o.Name,
+					fmt.Sprintf("TemplateOverride is not defined in MachineDeploymentClass %q of ClusterClass %q", md.Class, clusterClass.Name)))
+			}
+			if names.Has(o.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Index(j).Child("name"), o.Name))
+			}
+			names.Insert(o.Name)
+
+			var v interface{}
+			if err := json.Unmarshal(o.Value.Raw, &v); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Index(j).Child("value"), string(o.Value.Raw), "value is invalid JSON"))
+			}
+		}
+	}
+	return allErrs
+}
+
+// validateClusterClassCanBeUsed checks with a SubjectAccessReview that the user creating or updating the Cluster is allowed
+// to use the ClusterClass referenced in the Cluster.
+func (webhook *Cluster) validateClusterClassCanBeUsed(ctx context.Context, cluster *clusterv1.Cluster) error {
+	// NOTE: The check is skipped if the webhook is not configured with a client to create SubjectAccessReviews, or when
+	// the webhook is not invoked by the API server, e.g. when validating a Cluster in clusterctl alpha topology plan.
+	if webhook.SubjectAccessReviewClient == nil {
+		return nil
+	}
+	req, err := admission.RequestFromContext(ctx)
+	if err != nil {
+		return nil //nolint:nilerr
+	}
+
+	key := cluster.GetClassKey()
+	extra := map[string]authorizationv1.ExtraValue{}
+	for k, v := range req.UserInfo.Extra {
+		extra[k] = authorizationv1.ExtraValue(v)
+	}
+	sar := &authorizationv1.SubjectAccessReview{
+		Spec: authorizationv1.SubjectAccessReviewSpec{
+			ResourceAttributes: &authorizationv1.ResourceAttributes{
+				Namespace: key.Namespace,
+				Verb:      "use",
+				Group:     clusterv1.GroupVersion.Group,
+				Resource:  "clusterclasses",
+				Name:      key.Name,
+			},
+			User:   req.UserInfo.Username,
+			UID:    req.UserInfo.UID,
+			Groups: req.UserInfo.Groups,
+			Extra:  extra,
+		},
+	}
+	if err := webhook.SubjectAccessReviewClient.Create(ctx, sar); err != nil {
+		return errors.Wrapf(err, "failed to check if user %q is allowed to use ClusterClass %s", req.UserInfo.Username, key)
+	}
+	if !sar.Status.Allowed {
+		return errors.Errorf("user %q is not allowed to use ClusterClass %s", req.UserInfo.Username, key)
+	}
+	return nil
+}
+
 // validateClusterClassExistsAndIsReconciled will try to get the ClusterClass referenced in the Cluster. If it does not exist or is not reconciled it will add a warning.
 // In any other case it will return an error.
 func (webhook *Cluster) validateClusterClassExistsAndIsReconciled(ctx context.Context, newCluster *clusterv1.Cluster) (*clusterv1.ClusterClass, admission.Warnings, error) {
@@ -601,20 +808,20 @@ func (webhook *Cluster) validateClusterClassExistsAndIsReconciled(ctx context.Co
 				fmt.Sprintf(
 					"Cluster refers to ClusterClass %s in the topology but it does not exist. "+
 						"Cluster topology has not been fully validated. "+
-						"The ClusterClass must be created to reconcile the Cluster", newCluster.Spec.Topology.Class),
+						"The ClusterClass must be created to reconcile the Cluster", newCluster.GetClassKey()),
 			)
 		case errors.Is(clusterClassPollErr, errClusterClassNotReconciled):
 			allWarnings = append(allWarnings,
 				fmt.Sprintf(
 					"Cluster refers to ClusterClass %s but this object which hasn't yet been reconciled. "+
-						"Cluster topology has not been fully validated. ", newCluster.Spec.Topology.Class),
+						"Cluster topology has not been fully validated. ", newCluster.GetClassKey()),
 			)
 		// If there's any other error return a generic warning with the error message.
 		default:
 			allWarnings = append(allWarnings,
 				fmt.Sprintf(
 					"Cluster refers to ClusterClass %s in the topology but it could not be retrieved. "+
-						"Cluster topology has not been fully validated: %s", newCluster.Spec.Topology.Class, clusterClassPollErr.Error()),
+						"Cluster topology has not been fully validated: %s", newCluster.GetClassKey(), clusterClassPollErr.Error()),
 			)
 		}
 	}
@@ -626,7 +833,7 @@ func (webhook *Cluster) pollClusterClassForCluster(ctx context.Context, cluster
 	clusterClass := &clusterv1.ClusterClass{}
 	var clusterClassPollErr error
 	_ = wait.PollUntilContextTimeout(ctx, 200*time.Millisecond, 2*time.Second, true, func(ctx context.Context) (bool, error) {
-		if clusterClassPollErr = webhook.Client.Get(ctx, client.ObjectKey{Namespace: cluster.Namespace, Name: cluster.Spec.Topology.Class}, clusterClass); clusterClassPollErr != nil {
+		if clusterClassPollErr = webhook.Client.Get(ctx, cluster.GetClassKey(), clusterClass); clusterClassPollErr != nil {
 			return false, nil //nolint:nilerr
 		}
 
@@ -657,6 +864,41 @@ func clusterClassIsReconciled(clusterClass *clusterv1.ClusterClass) error {
 	return nil
 }
 
+// validateMachineDeploymentsAutoscalerReplicas validates that replicas are not set for the MachineDeployments with
+// the autoscaler min size and max size annotations, given that replicas of those MachineDeployments are managed by the autoscaler.
+// NOTE: MachineDeployments already setting both replicas and the autoscaler annotations are not rejected, so existing Clusters
+// can still be updated.
+func validateMachineDeploymentsAutoscalerReplicas(oldCluster, newCluster *clusterv1.Cluster, fldPath *field.Path) field.ErrorList {
+	if newCluster.Spec.Topology.Workers == nil {
+		return nil
+	}
+
+	isInvalid := func(md clusterv1.MachineDeploymentTopology) bool {
+		return md.Replicas != nil && annotations.HasAutoscalerMinMaxSize(md.Metadata.Annotations)
+	}
+	oldInvalid := sets.Set[string]{}
+	if oldCluster != nil && oldCluster.Spec.Topology != nil && oldCluster.Spec.Topology.Workers != nil {
+		for _, md := range oldCluster.Spec.Topology.Workers.MachineDeployments {
+			if isInvalid(md) {
+				oldInvalid.Insert(md.Name)
+			}
+		}
+	}
+
+	var allErrs field.ErrorList
+	for i, md := range newCluster.Spec.Topology.Workers.MachineDeployments {
+		if !isInvalid(md) || oldInvalid.Has(md.Name) {
+			continue
+		}
+		allErrs = append(allErrs, field.Forbidden(
+			fldPath.Child("workers", "machineDeployments").Index(i).Child("replicas"),
+			fmt.Sprintf("must not be set when the %s and %s annotations are set, replicas are managed by the autoscaler",
+				clusterv1.AutoscalerMinSizeAnnotation, clusterv1.AutoscalerMaxSizeAnnotation),
+		))
+	}
+	return allErrs
+}
+
 func validateTopologyMetadata(topology *clusterv1.Topology, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
 	allErrs = append(allErrs, topology.ControlPlane.Metadata.Validate(fldPath.Child("controlPlane", "metadata"))...)
@@ -22,6 +22,9 @@ import (
 
 	. "github.com/onsi/gomega"
 	"github.com/pkg/errors"
+	admissionv1 "k8s.io/api/admission/v1"
+	authenticationv1 "k8s.io/api/authentication/v1"
+	authorizationv1 "k8s.io/api/authorization/v1"
 	corev1 "k8s.io/api/core/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -31,6 +34,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	"sigs.k8s.io/cluster-api/feature"
@@ -998,6 +1002,34 @@ func TestClusterValidation(t *testing.T) {
 				in:        builder.Cluster("fooNamespace", "thisNameContainsInvalid!@NonAlphanumerics").Build(),
 				expectErr: true,
 			},
+			{
+				name:      "pass with valid kubeconfig endpoint annotations",
+				expectErr: false,
+				in: builder.Cluster("fooNamespace", "cluster1").
+					WithAnnotations(map[string]string{
+						clusterv1.ClusterKubeconfigEndpointAnnotation:     "10.0.0.1:6443",
+						clusterv1.ClusterUserKubeconfigEndpointAnnotation: "api.example.com:443",
+					}).
+					Build(),
+			},
+			{
+				name:      "fails if the kubeconfig endpoint annotation has no port",
+				expectErr: true,
+				in: builder.Cluster("fooNamespace", "cluster1").
+					WithAnnotations(map[string]string{
+						clusterv1.ClusterKubeconfigEndpointAnnotation: "10.0.0.1",
+					}).
+					Build(),
+			},
+			{
+				name:      "fails if the user kubeconfig endpoint annotation has no host",
+				expectErr: true,
+				in: builder.Cluster("fooNamespace", "cluster1").
+					WithAnnotations(map[string]string{
+						clusterv1.ClusterUserKubeconfigEndpointAnnotation: ":443",
+					}).
+					Build(),
+			},
 		}
 	)
 	for _, tt := range tests {
@@ -1018,11 +1050,42 @@ func TestClusterValidation(t *testing.T) {
 	}
 }
 
+func TestClusterKubeconfigEndpointAnnotationWarning(t *testing.T) {
+	g := NewWithT(t)
+
+	webhook := &Cluster{}
+	annotations := map[string]string{clusterv1.ClusterKubeconfigEndpointAnnotation: "10.0.0.1:6443"}
+
+	// No warning for Clusters without a control plane provider, where the Cluster controller generates the kubeconfig Secret.
+	warnings, err := webhook.validate(ctx, nil, builder.Cluster("fooNamespace", "cluster1").WithAnnotations(annotations).Build())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(BeEmpty())
+
+	// Warning for Clusters with a control plane provider, which ignores the annotation.
+	warnings, err = webhook.validate(ctx, nil, builder.Cluster("fooNamespace", "cluster1").
+		WithAnnotations(annotations).
+		WithControlPlane(builder.ControlPlane("fooNamespace", "cp1").Build()).
+		Build())
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(warnings).To(HaveLen(1))
+	g.Expect(warnings[0]).To(ContainSubstring(clusterv1.ClusterKubeconfigEndpointAnnotation))
+}
+
 func TestClusterTopologyValidation(t *testing.T) {
 	// NOTE: ClusterTopology feature flag is disabled by default, thus preventing to set Cluster.Topologies.
 	// Enabling the feature flag temporarily for this test.
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
 
+	autoscaledMachineDeployment := func(replicas *int32) clusterv1.MachineDeploymentTopology {
+		md := builder.MachineDeploymentTopology("workers1").WithClass("aa").Build()
+		md.Replicas = replicas
+		md.Metadata.Annotations = map[string]string{
+			clusterv1.AutoscalerMinSizeAnnotation: "1",
+			clusterv1.AutoscalerMaxSizeAnnotation: "3",
+		}
+		return md
+	}
+
 	tests := []struct {
 		name                        string
 		clusterClassStatusVariables []clusterv1.ClusterClassStatusVariable
@@ -1236,6 +1299,46 @@ func TestClusterTopologyValidation(t *testing.T) {
 					Build()).
 				Build(),
 		},
+		{
+			name:      "should return error when replicas are set for a MachineDeployment with the autoscaler annotations",
+			expectErr: true,
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(autoscaledMachineDeployment(pointer.Int32(2))).
+					Build()).
+				Build(),
+		},
+		{
+			name:      "should pass when replicas are not set for a MachineDeployment with the autoscaler annotations",
+			expectErr: false,
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(autoscaledMachineDeployment(nil)).
+					Build()).
+				Build(),
+		},
+		{
+			name:      "should pass when replicas were already set for a MachineDeployment with the autoscaler annotations",
+			expectErr: false,
+			old: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(autoscaledMachineDeployment(pointer.Int32(2))).
+					Build()).
+				Build(),
+			in: builder.Cluster("fooboo", "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("foo").
+					WithVersion("v1.19.1").
+					WithMachineDeployment(autoscaledMachineDeployment(pointer.Int32(3))).
+					Build()).
+				Build(),
+		},
 		{
 			name:      "should pass upgrade concurrency annotation value is >= 1",
 			expectErr: false,
@@ -1284,6 +1387,94 @@ func TestClusterTopologyValidation(t *testing.T) {
 	}
 }
 
+func TestClusterTopologyValidationVersionSkew(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	tests := []struct {
+		name        string
+		oldVersion  string
+		version     string
+		annotations map[string]string
+		mdVersion   string
+		expectErr   bool
+	}{
+		{
+			name:       "should pass when MachineDeployments stay within the version skew",
+			oldVersion: "v1.26.0",
+			version:    "v1.27.0",
+			mdVersion:  "v1.25.0",
+			expectErr:  false,
+		},
+		{
+			name:       "should pass when MachineDeployments stay within the version skew of three minor versions since v1.28",
+			oldVersion: "v1.27.0",
+			version:    "v1.28.0",
+			mdVersion:  "v1.25.0",
+			expectErr:  false,
+		},
+		{
+			name:       "should return error when MachineDeployments would exceed the version skew",
+			oldVersion: "v1.26.0",
+			version:    "v1.27.0",
+			mdVersion:  "v1.24.0",
+			expectErr:  true,
+		},
+		{
+			name:        "should pass when the version skew validation is skipped via annotation",
+			oldVersion:  "v1.26.0",
+			version:     "v1.27.0",
+			annotations: map[string]string{clusterv1.SkipVersionSkewValidationAnnotation: ""},
+			mdVersion:   "v1.24.0",
+			expectErr:   false,
+		},
+		{
+			name:       "should pass when the version does not change",
+			oldVersion: "v1.26.0",
+			version:    "v1.26.0",
+			mdVersion:  "v1.23.0",
+			expectErr:  false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			class := builder.ClusterClass(metav1.NamespaceDefault, "class").Build()
+			// Mark this condition to true so the webhook sees the ClusterClass as up to date.
+			conditions.MarkTrue(class, clusterv1.ClusterClassVariablesReconciledCondition)
+
+			md := builder.MachineDeployment(metav1.NamespaceDefault, "md").
+				WithClusterName("cluster1").
+				WithLabels(map[string]string{clusterv1.ClusterNameLabel: "cluster1"}).
+				WithVersion(tt.mdVersion).
+				Build()
+
+			oldCluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(builder.ClusterTopology().
+					WithClass("class").
+					WithVersion(tt.oldVersion).
+					Build()).
+				Build()
+			newCluster := oldCluster.DeepCopy()
+			newCluster.Annotations = tt.annotations
+			newCluster.Spec.Topology.Version = tt.version
+
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(class, md).
+				WithScheme(fakeScheme).
+				Build()
+			webhook := &Cluster{Client: fakeClient}
+
+			_, err := webhook.ValidateUpdate(ctx, oldCluster, newCluster)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
 // TestClusterTopologyValidationWithClient tests the additional cases introduced in new validation in the webhook package.
 func TestClusterTopologyValidationWithClient(t *testing.T) {
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
@@ -1571,6 +1762,84 @@ func TestClusterTopologyValidationWithClient(t *testing.T) {
 			classReconciled: true,
 			wantErr:         false,
 		},
+		{
+			name: "Accept a cluster with a TemplateOverride defined in the MachineDeploymentClass",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						WithMachineDeployment(
+							builder.MachineDeploymentTopology("md1").
+								WithClass("worker-class").
+								WithTemplateOverrides(clusterv1.TemplateOverride{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}}).
+								Build(),
+						).
+						Build()).
+				Build(),
+			class: builder.ClusterClass(metav1.NamespaceDefault, "clusterclass").
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("worker-class").
+						WithTemplateOverrides(clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"}).
+						Build(),
+				).
+				Build(),
+			classReconciled: true,
+			wantErr:         false,
+		},
+		{
+			name: "Reject a cluster with a TemplateOverride not defined in the MachineDeploymentClass",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						WithMachineDeployment(
+							builder.MachineDeploymentTopology("md1").
+								WithClass("worker-class").
+								WithTemplateOverrides(clusterv1.TemplateOverride{Name: "flavor", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}}).
+								Build(),
+						).
+						Build()).
+				Build(),
+			class: builder.ClusterClass(metav1.NamespaceDefault, "clusterclass").
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("worker-class").
+						WithTemplateOverrides(clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"}).
+						Build(),
+				).
+				Build(),
+			classReconciled: true,
+			wantErr:         true,
+		},
+		{
+			name: "Reject a cluster with a TemplateOverride with an invalid value",
+			cluster: builder.Cluster(metav1.NamespaceDefault, "cluster1").
+				WithTopology(
+					builder.ClusterTopology().
+						WithClass("clusterclass").
+						WithVersion("v1.22.2").
+						WithControlPlaneReplicas(3).
+						WithMachineDeployment(
+							builder.MachineDeploymentTopology("md1").
+								WithClass("worker-class").
+								WithTemplateOverrides(clusterv1.TemplateOverride{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`large`)}}).
+								Build(),
+						).
+						Build()).
+				Build(),
+			class: builder.ClusterClass(metav1.NamespaceDefault, "clusterclass").
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("worker-class").
+						WithTemplateOverrides(clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"}).
+						Build(),
+				).
+				Build(),
+			classReconciled: true,
+			wantErr:         true,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -2330,3 +2599,113 @@ func refToUnstructured(ref *corev1.ObjectReference) *unstructured.Unstructured {
 	output.SetNamespace(ref.Namespace)
 	return output
 }
+
+func TestClusterTopologyValidationForClassNamespace(t *testing.T) {
+	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
+
+	allowedUser := "allowed-user"
+	exportedClass := builder.ClusterClass("shared", "class1").Build()
+	exportedClass.Annotations = map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: "*"}
+	notExportedClass := builder.ClusterClass("shared", "class2").Build()
+
+	clusterUsing := func(class string) *clusterv1.Cluster {
+		cluster := builder.Cluster(metav1.NamespaceDefault, "cluster1").
+			WithTopology(
+				builder.ClusterTopology().
+					WithClass(class).
+					WithVersion("v1.22.2").
+					WithControlPlaneReplicas(3).
+					Build()).
+			Build()
+		cluster.Spec.Topology.ClassNamespace = "shared"
+		return cluster
+	}
+
+	tests := []struct {
+		name       string
+		oldCluster *clusterv1.Cluster
+		cluster    *clusterv1.Cluster
+		user       string
+		noRequest  bool
+		wantErr    bool
+	}{
+		{
+			name:    "Accept a cluster using an exported ClusterClass if the user is allowed to use it",
+			cluster: clusterUsing("class1"),
+			user:    allowedUser,
+		},
+		{
+			name:    "Reject a cluster using an exported ClusterClass if the user is not allowed to use it",
+			cluster: clusterUsing("class1"),
+			user:    "other-user",
+			wantErr: true,
+		},
+		{
+			name:      "Accept a cluster using an exported ClusterClass if there is no admission request",
+			cluster:   clusterUsing("class1"),
+			noRequest: true,
+		},
+		{
+			name:    "Reject a cluster using a ClusterClass not exported to its namespace",
+			cluster: clusterUsing("class2"),
+			user:    allowedUser,
+			wantErr: true,
+		},
+		{
+			name:       "Accept an update of a cluster using an exported ClusterClass if the ClusterClass is not changed",
+			oldCluster: clusterUsing("class1"),
+			cluster:    clusterUsing("class1"),
+			user:       "other-user",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			classes := []client.Object{exportedClass.DeepCopy(), notExportedClass.DeepCopy()}
+			for _, class := range classes {
+				conditions.MarkTrue(class.(*clusterv1.ClusterClass), clusterv1.ClusterClassVariablesReconciledCondition)
+			}
+			fakeClient := fake.NewClientBuilder().
+				WithObjects(classes...).
+				WithScheme(fakeScheme).
+				Build()
+			// The SubjectAccessReview allows only allowedUser to use ClusterClasses.
+			sarClient := interceptor.NewClient(fake.NewClientBuilder().WithScheme(fakeScheme).Build(), interceptor.Funcs{
+				Create: func(_ context.Context, _ client.WithWatch, obj client.Object, _ ...client.CreateOption) error {
+					sar, ok := obj.(*authorizationv1.SubjectAccessReview)
+					if !ok {
+						return errors.Errorf("unexpected object %T", obj)
+					}
+					sar.Status.Allowed = sar.Spec.User == allowedUser &&
+						sar.Spec.ResourceAttributes.Verb == "use" &&
+						sar.Spec.ResourceAttributes.Namespace == "shared"
+					return nil
+				},
+			})
+
+			c := &Cluster{Client: fakeClient, SubjectAccessReviewClient: sarClient}
+
+			reqCtx := ctx
+			if !tt.noRequest {
+				reqCtx = admission.NewContextWithRequest(ctx, admission.Request{
+					AdmissionRequest: admissionv1.AdmissionRequest{
+						UserInfo: authenticationv1.UserInfo{Username: tt.user},
+					},
+				})
+			}
+
+			var err error
+			if tt.oldCluster == nil {
+				_, err = c.ValidateCreate(reqCtx, tt.cluster)
+			} else {
+				_, err = c.ValidateUpdate(reqCtx, tt.oldCluster, tt.cluster)
+			}
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
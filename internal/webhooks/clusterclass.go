@@ -39,6 +39,7 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	"sigs.k8s.io/cluster-api/internal/topology/check"
 	"sigs.k8s.io/cluster-api/internal/topology/names"
+	"sigs.k8s.io/cluster-api/internal/topology/overrides"
 	"sigs.k8s.io/cluster-api/internal/topology/variables"
 )
 
@@ -162,6 +163,9 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	// Ensure NamingStrategies are valid.
 	allErrs = append(allErrs, validateNamingStrategies(newClusterClass)...)
 
+	// Ensure TemplateOverrides are valid.
+	allErrs = append(allErrs, validateTemplateOverrideClasses(newClusterClass)...)
+
 	// Validate variables.
 	allErrs = append(allErrs,
 		variables.ValidateClusterClassVariables(ctx, newClusterClass.Spec.Variables, field.NewPath("spec", "variables"))...,
@@ -194,9 +198,17 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 		allErrs = append(allErrs,
 			webhook.validateRemovedMachinePoolClassesAreNotUsed(clusters, oldClusterClass, newClusterClass)...)
 
+		// Ensure no TemplateOverride currently in use has been removed from the ClusterClass.
+		allErrs = append(allErrs,
+			validateRemovedTemplateOverridesAreNotUsed(clusters, newClusterClass)...)
+
 		// Ensure no MachineHealthCheck currently in use has been removed from the ClusterClass.
 		allErrs = append(allErrs,
 			validateUpdatesToMachineHealthCheckClasses(clusters, oldClusterClass, newClusterClass)...)
+
+		// Ensure the ClusterClass is still exported to the namespaces of the Clusters using it.
+		allErrs = append(allErrs,
+			validateClusterClassIsExportedToClusters(clusters, newClusterClass)...)
 	}
 
 	if len(allErrs) > 0 {
@@ -205,6 +217,29 @@ func (webhook *ClusterClass) validate(ctx context.Context, oldClusterClass, newC
 	return nil
 }
 
+// validateClusterClassIsExportedToClusters checks that the ClusterClass is exported to the namespaces
+// of all the Clusters using it.
+func validateClusterClassIsExportedToClusters(clusters []clusterv1.Cluster, clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	namespaces := sets.Set[string]{}
+	for _, cluster := range clusters {
+		if !check.ClusterClassIsExportedToNamespace(clusterClass, cluster.Namespace) {
+			namespaces.Insert(cluster.Namespace)
+		}
+	}
+	if namespaces.Len() > 0 {
+		allErrs = append(allErrs,
+			field.Forbidden(
+				field.NewPath("metadata", "annotations", clusterv1.ClusterClassExportToNamespacesAnnotation),
+				fmt.Sprintf("ClusterClass must be exported to the namespaces of the Clusters using it: %s",
+					strings.Join(sets.List(namespaces), ", ")),
+			),
+		)
+	}
+	return allErrs
+}
+
 // validateUpdatesToMachineHealthCheckClasses checks if the updates made to MachineHealthChecks are valid.
 // It makes sure that if a MachineHealthCheck definition is dropped from the ClusterClass then none of the
 // clusters using the ClusterClass rely on it to create a MachineHealthCheck.
@@ -362,15 +397,21 @@ func (webhook *ClusterClass) classNamesFromMPWorkerClass(w clusterv1.WorkersClas
 }
 
 func (webhook *ClusterClass) getClustersUsingClusterClass(ctx context.Context, clusterClass *clusterv1.ClusterClass) ([]clusterv1.Cluster, error) {
+	// NOTE: Clusters are listed in all the namespaces, because a ClusterClass can be used by Clusters in other namespaces.
 	clusters := &clusterv1.ClusterList{}
 	err := webhook.Client.List(ctx, clusters,
 		client.MatchingFields{index.ClusterClassNameField: clusterClass.Name},
-		client.InNamespace(clusterClass.Namespace),
 	)
 	if err != nil {
 		return nil, err
 	}
-	return clusters.Items, nil
+	clustersUsingClusterClass := []clusterv1.Cluster{}
+	for i := range clusters.Items {
+		if clusters.Items[i].GetClassKey() == client.ObjectKeyFromObject(clusterClass) {
+			clustersUsingClusterClass = append(clustersUsingClusterClass, clusters.Items[i])
+		}
+	}
+	return clustersUsingClusterClass, nil
 }
 
 func getClusterClassVariablesMapWithReverseIndex(clusterClassVariables []clusterv1.ClusterClassVariable) (map[string]*clusterv1.ClusterClassVariable, map[string]int) {
@@ -478,6 +519,71 @@ func validateNamingStrategies(clusterClass *clusterv1.ClusterClass) field.ErrorL
 	return allErrs
 }
 
+// validateTemplateOverrideClasses validates the TemplateOverrides defined in the MachineDeploymentClasses.
+func validateTemplateOverrideClasses(clusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	for i, md := range clusterClass.Spec.Workers.MachineDeployments {
+		overrideNames := sets.Set[string]{}
+		for j, o := range md.TemplateOverrides {
+			fldPath := field.NewPath("spec", "workers", "machineDeployments").Index(i).Child("templateOverrides").Index(j)
+			if o.Name == "" {
+				allErrs = append(allErrs, field.Required(fldPath.Child("name"), "name must not be empty"))
+			} else if overrideNames.Has(o.Name) {
+				allErrs = append(allErrs, field.Duplicate(fldPath.Child("name"), o.Name))
+			}
+			overrideNames.Insert(o.Name)
+
+			if o.Template != clusterv1.BootstrapTemplateOverrideTarget && o.Template != clusterv1.InfrastructureTemplateOverrideTarget {
+				allErrs = append(allErrs, field.NotSupported(fldPath.Child("template"), o.Template,
+					[]string{string(clusterv1.BootstrapTemplateOverrideTarget), string(clusterv1.InfrastructureTemplateOverrideTarget)}))
+			}
+
+			if _, err := overrides.ParsePath(o.Path); err != nil {
+				allErrs = append(allErrs, field.Invalid(fldPath.Child("path"), o.Path, err.Error()))
+			}
+		}
+	}
+
+	return allErrs
+}
+
+// validateRemovedTemplateOverridesAreNotUsed checks that the TemplateOverrides set by the Clusters using the ClusterClass
+// are still defined in the corresponding MachineDeploymentClasses.
+func validateRemovedTemplateOverridesAreNotUsed(clusters []clusterv1.Cluster, newClusterClass *clusterv1.ClusterClass) field.ErrorList {
+	var allErrs field.ErrorList
+
+	overrideNames := map[string]sets.Set[string]{}
+	for _, md := range newClusterClass.Spec.Workers.MachineDeployments {
+		overrideNames[md.Class] = sets.Set[string]{}
+		for _, o := range md.TemplateOverrides {
+			overrideNames[md.Class].Insert(o.Name)
+		}
+	}
+
+	for _, c := range clusters {
+		if c.Spec.Topology.Workers == nil {
+			continue
+		}
+		for _, mdTopology := range c.Spec.Topology.Workers.MachineDeployments {
+			// NOTE: Removed MachineDeploymentClasses are validated in validateRemovedMachineDeploymentClassesAreNotUsed.
+			names, ok := overrideNames[mdTopology.Class]
+			if !ok {
+				continue
+			}
+			for _, o := range mdTopology.TemplateOverrides {
+				if !names.Has(o.Name) {
+					allErrs = append(allErrs, field.Forbidden(field.NewPath("spec", "workers", "machineDeployments"),
+						fmt.Sprintf("TemplateOverride %q of MachineDeploymentClass %q cannot be deleted because it is used by Cluster %q",
+							o.Name, mdTopology.Class, c.Name),
+					))
+				}
+			}
+		}
+	}
+	return allErrs
+}
+
 // validateMachineHealthCheckClass validates the MachineHealthCheckSpec fields defined in a MachineHealthCheckClass.
 func validateMachineHealthCheckClass(fldPath *field.Path, namepace string, m *clusterv1.MachineHealthCheckClass) field.ErrorList {
 	mhc := clusterv1.MachineHealthCheck{
@@ -485,11 +591,13 @@ func validateMachineHealthCheckClass(fldPath *field.Path, namepace string, m *cl
 			Namespace: namepace,
 		},
 		Spec: clusterv1.MachineHealthCheckSpec{
-			NodeStartupTimeout:  m.NodeStartupTimeout,
-			MaxUnhealthy:        m.MaxUnhealthy,
-			UnhealthyConditions: m.UnhealthyConditions,
-			UnhealthyRange:      m.UnhealthyRange,
-			RemediationTemplate: m.RemediationTemplate,
+			NodeStartupTimeout:            m.NodeStartupTimeout,
+			InfrastructureStartupTimeout:  m.InfrastructureStartupTimeout,
+			RemediationSuppressionWindows: m.RemediationSuppressionWindows,
+			MaxUnhealthy:                  m.MaxUnhealthy,
+			UnhealthyConditions:           m.UnhealthyConditions,
+			UnhealthyRange:                m.UnhealthyRange,
+			RemediationTemplate:           m.RemediationTemplate,
 		}}
 
 	return (&MachineHealthCheck{}).validateCommonFields(&mhc, fldPath)
@@ -23,6 +23,7 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	utilfeature "k8s.io/component-base/featuregate/testing"
@@ -1226,6 +1227,104 @@ func TestClusterClassValidation(t *testing.T) {
 				Build(),
 			expectErr: true,
 		},
+		{
+			name: "should not return error for valid MachineDeployment templateOverrides",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cpInfra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithTemplateOverrides(
+							clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+							clusterv1.TemplateOverrideClass{Name: "verbosity", Template: clusterv1.BootstrapTemplateOverrideTarget, Path: "/spec/template/spec/verbosity"},
+						).
+						Build()).
+				Build(),
+			expectErr: false,
+		},
+		{
+			name: "should return error for duplicated MachineDeployment templateOverrides names",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cpInfra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithTemplateOverrides(
+							clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+							clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/flavor"},
+						).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "should return error for invalid MachineDeployment templateOverrides template",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cpInfra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithTemplateOverrides(
+							clusterv1.TemplateOverrideClass{Name: "instanceType", Template: "ControlPlane", Path: "/spec/template/spec/instanceType"},
+						).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
+		{
+			name: "should return error for invalid MachineDeployment templateOverrides path",
+			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
+				WithInfrastructureClusterTemplate(
+					builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "infra1").Build()).
+				WithControlPlaneTemplate(
+					builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").
+						Build()).
+				WithControlPlaneInfrastructureMachineTemplate(
+					builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "cpInfra1").
+						Build()).
+				WithWorkerMachineDeploymentClasses(
+					*builder.MachineDeploymentClass("aa").
+						WithInfrastructureTemplate(
+							builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+						WithBootstrapTemplate(
+							builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+						WithTemplateOverrides(
+							clusterv1.TemplateOverrideClass{Name: "diskSize", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/disks/0/size"},
+						).
+						Build()).
+				Build(),
+			expectErr: true,
+		},
 		{
 			name: "should return error for invalid MachineDeployment namingStrategy.template",
 			in: builder.ClusterClass(metav1.NamespaceDefault, "class1").
@@ -1343,6 +1442,44 @@ func TestClusterClassValidationWithClusterAwareChecks(t *testing.T) {
 	// Enabling the feature flag temporarily for this test.
 	defer utilfeature.SetFeatureGateDuringTest(t, feature.Gates, feature.ClusterTopology, true)()
 
+	sharedClusterClass := func(exportToNamespaces string) *clusterv1.ClusterClass {
+		clusterClass := builder.ClusterClass("shared", "class1").
+			WithInfrastructureClusterTemplate(
+				builder.InfrastructureClusterTemplate("shared", "inf").Build()).
+			WithControlPlaneTemplate(
+				builder.ControlPlaneTemplate("shared", "cp1").Build()).
+			Build()
+		clusterClass.Annotations = map[string]string{clusterv1.ClusterClassExportToNamespacesAnnotation: exportToNamespaces}
+		return clusterClass
+	}
+	clusterClassWithTemplateOverrides := func(o ...clusterv1.TemplateOverrideClass) *clusterv1.ClusterClass {
+		return builder.ClusterClass(metav1.NamespaceDefault, "class1").
+			WithInfrastructureClusterTemplate(
+				builder.InfrastructureClusterTemplate(metav1.NamespaceDefault, "inf").Build()).
+			WithControlPlaneTemplate(
+				builder.ControlPlaneTemplate(metav1.NamespaceDefault, "cp1").Build()).
+			WithWorkerMachineDeploymentClasses(
+				*builder.MachineDeploymentClass("aa").
+					WithInfrastructureTemplate(
+						builder.InfrastructureMachineTemplate(metav1.NamespaceDefault, "infra1").Build()).
+					WithBootstrapTemplate(
+						builder.BootstrapTemplate(metav1.NamespaceDefault, "bootstrap1").Build()).
+					WithTemplateOverrides(o...).
+					Build()).
+			Build()
+	}
+	clusterUsingSharedClass := func(name string) *clusterv1.Cluster {
+		cluster := builder.Cluster(metav1.NamespaceDefault, name).
+			WithLabels(map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""}).
+			WithTopology(
+				builder.ClusterTopology().
+					WithClass("class1").
+					Build()).
+			Build()
+		cluster.Spec.Topology.ClassNamespace = "shared"
+		return cluster
+	}
+
 	tests := []struct {
 		name            string
 		oldClusterClass *clusterv1.ClusterClass
@@ -1791,6 +1928,76 @@ func TestClusterClassValidationWithClusterAwareChecks(t *testing.T) {
 				Build(),
 			expectErr: false,
 		},
+		{
+			name: "pass if a TemplateOverride not in use gets removed",
+			clusters: []client.Object{
+				builder.Cluster(metav1.NamespaceDefault, "cluster1").
+					WithLabels(map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""}).
+					WithTopology(
+						builder.ClusterTopology().
+							WithClass("class1").
+							WithMachineDeployment(
+								builder.MachineDeploymentTopology("workers1").
+									WithClass("aa").
+									WithTemplateOverrides(clusterv1.TemplateOverride{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}}).
+									Build(),
+							).
+							Build()).
+					Build(),
+			},
+			oldClusterClass: clusterClassWithTemplateOverrides(
+				clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+				clusterv1.TemplateOverrideClass{Name: "flavor", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/flavor"},
+			),
+			newClusterClass: clusterClassWithTemplateOverrides(
+				clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+			),
+			expectErr: false,
+		},
+		{
+			name: "error if a TemplateOverride in use gets removed",
+			clusters: []client.Object{
+				builder.Cluster(metav1.NamespaceDefault, "cluster1").
+					WithLabels(map[string]string{clusterv1.ClusterTopologyOwnedLabel: ""}).
+					WithTopology(
+						builder.ClusterTopology().
+							WithClass("class1").
+							WithMachineDeployment(
+								builder.MachineDeploymentTopology("workers1").
+									WithClass("aa").
+									WithTemplateOverrides(clusterv1.TemplateOverride{Name: "instanceType", Value: apiextensionsv1.JSON{Raw: []byte(`"large"`)}}).
+									Build(),
+							).
+							Build()).
+					Build(),
+			},
+			oldClusterClass: clusterClassWithTemplateOverrides(
+				clusterv1.TemplateOverrideClass{Name: "instanceType", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/instanceType"},
+				clusterv1.TemplateOverrideClass{Name: "flavor", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/flavor"},
+			),
+			newClusterClass: clusterClassWithTemplateOverrides(
+				clusterv1.TemplateOverrideClass{Name: "flavor", Template: clusterv1.InfrastructureTemplateOverrideTarget, Path: "/spec/template/spec/flavor"},
+			),
+			expectErr: true,
+		},
+		{
+			name: "pass if the ClusterClass is still exported to the namespaces of the Clusters using it",
+			clusters: []client.Object{
+				clusterUsingSharedClass("cluster1"),
+			},
+			oldClusterClass: sharedClusterClass("*"),
+			newClusterClass: sharedClusterClass(metav1.NamespaceDefault),
+			expectErr:       false,
+		},
+		{
+			name: "error if the ClusterClass is not exported anymore to the namespaces of the Clusters using it",
+			clusters: []client.Object{
+				clusterUsingSharedClass("cluster1"),
+			},
+			oldClusterClass: sharedClusterClass("*"),
+			newClusterClass: sharedClusterClass("other"),
+			expectErr:       true,
+		},
 	}
 
 	for _, tt := range tests {
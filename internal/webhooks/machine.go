@@ -19,18 +19,27 @@ package webhooks
 import (
 	"context"
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/sets"
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
+	"sigs.k8s.io/cluster-api/internal/contract"
+	utilcontract "sigs.k8s.io/cluster-api/util/contract"
 	"sigs.k8s.io/cluster-api/util/labels"
 	"sigs.k8s.io/cluster-api/util/version"
 )
@@ -49,7 +58,11 @@ func (webhook *Machine) SetupWebhookWithManager(mgr ctrl.Manager) error {
 // +kubebuilder:webhook:verbs=create;update,path=/mutate-cluster-x-k8s-io-v1beta1-machine,mutating=true,failurePolicy=fail,matchPolicy=Equivalent,groups=cluster.x-k8s.io,resources=machines,versions=v1beta1,name=default.machine.cluster.x-k8s.io,sideEffects=None,admissionReviewVersions=v1;v1beta1
 
 // Machine implements a validation and defaulting webhook for Machine.
-type Machine struct{}
+type Machine struct {
+	// Client is used to read the bootstrap config, the bootstrap data Secret and the InfraMachine CRD of a Machine
+	// to validate the bootstrap data format; the validation is skipped if Client is nil.
+	Client client.Reader
+}
 
 var _ webhook.CustomValidator = &Machine{}
 var _ webhook.CustomDefaulter = &Machine{}
@@ -87,17 +100,17 @@ func (webhook *Machine) Default(_ context.Context, obj runtime.Object) error {
 }
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (webhook *Machine) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (webhook *Machine) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	m, ok := obj.(*clusterv1.Machine)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", obj))
 	}
 
-	return nil, webhook.validate(nil, m)
+	return nil, webhook.validate(ctx, nil, m)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *Machine) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (webhook *Machine) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldM, ok := oldObj.(*clusterv1.Machine)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", oldObj))
@@ -108,7 +121,7 @@ func (webhook *Machine) ValidateUpdate(_ context.Context, oldObj, newObj runtime
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a Machine but got a %T", newObj))
 	}
 
-	return nil, webhook.validate(oldM, newM)
+	return nil, webhook.validate(ctx, oldM, newM)
 }
 
 // ValidateDelete implements webhook.CustomValidator so a webhook will be registered for the type.
@@ -116,7 +129,7 @@ func (webhook *Machine) ValidateDelete(_ context.Context, _ runtime.Object) (adm
 	return nil, nil
 }
 
-func (webhook *Machine) validate(oldM, newM *clusterv1.Machine) error {
+func (webhook *Machine) validate(ctx context.Context, oldM, newM *clusterv1.Machine) error {
 	var allErrs field.ErrorList
 	specPath := field.NewPath("spec")
 	if newM.Spec.Bootstrap.ConfigRef == nil && newM.Spec.Bootstrap.DataSecretName == nil {
@@ -167,8 +180,123 @@ func (webhook *Machine) validate(oldM, newM *clusterv1.Machine) error {
 		}
 	}
 
+	allErrs = append(allErrs, validateMachineTaints(newM.Spec.Taints, specPath.Child("taints"))...)
+
+	allErrs = append(allErrs, webhook.validateBootstrapDataFormat(ctx, oldM, newM, specPath.Child("bootstrap"))...)
+
 	if len(allErrs) == 0 {
 		return nil
 	}
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("Machine").GroupKind(), newM.Name, allErrs)
 }
+
+// validateBootstrapDataFormat validates that the format of the bootstrap data of a Machine is one of the formats
+// accepted by the infrastructure provider, as advertised with the BootstrapDataFormatsAnnotation on the InfraMachine CRD.
+// The format of the bootstrap data is read from the spec.format field of the bootstrap config, or from the format key
+// of the bootstrap data Secret if the Machine does not have a bootstrap config.
+func (webhook *Machine) validateBootstrapDataFormat(ctx context.Context, oldM, newM *clusterv1.Machine, fldPath *field.Path) field.ErrorList {
+	if webhook.Client == nil {
+		return nil
+	}
+
+	// Validate the format only on create or when the bootstrap config or the infrastructure references change.
+	// NOTE: Changes to dataSecretName are ignored if the Machine has a bootstrap config, given that in this case
+	// dataSecretName is set by the Machine controller and the format has already been validated on the bootstrap config.
+	if oldM != nil &&
+		reflect.DeepEqual(oldM.Spec.Bootstrap.ConfigRef, newM.Spec.Bootstrap.ConfigRef) &&
+		reflect.DeepEqual(oldM.Spec.InfrastructureRef, newM.Spec.InfrastructureRef) &&
+		(newM.Spec.Bootstrap.ConfigRef != nil || reflect.DeepEqual(oldM.Spec.Bootstrap.DataSecretName, newM.Spec.Bootstrap.DataSecretName)) {
+		return nil
+	}
+
+	// If the bootstrap config or the bootstrap data Secret do not exist yet, or they do not declare a format,
+	// there is not enough information to validate the format.
+	var format string
+	formatPath := fldPath.Child("configRef")
+	switch {
+	case newM.Spec.Bootstrap.ConfigRef != nil:
+		bootstrapConfig, err := external.Get(ctx, webhook.Client, newM.Spec.Bootstrap.ConfigRef, newM.Namespace)
+		if err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return field.ErrorList{field.InternalError(formatPath, errors.Wrap(err, "failed to get the bootstrap config to validate the bootstrap data format"))}
+		}
+		bootstrapFormat, err := contract.Bootstrap().Format().Get(bootstrapConfig)
+		if err != nil {
+			return nil
+		}
+		format = *bootstrapFormat
+	case newM.Spec.Bootstrap.DataSecretName != nil:
+		formatPath = fldPath.Child("dataSecretName")
+		secret := &corev1.Secret{}
+		if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: newM.Namespace, Name: *newM.Spec.Bootstrap.DataSecretName}, secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				return nil
+			}
+			return field.ErrorList{field.InternalError(formatPath, errors.Wrapf(err, "failed to get the bootstrap data Secret %s to validate the bootstrap data format", *newM.Spec.Bootstrap.DataSecretName))}
+		}
+		format = string(secret.Data["format"])
+	}
+	if format == "" {
+		return nil
+	}
+
+	// If the infrastructure provider does not advertise the accepted formats, any format is accepted.
+	infraMachineGVK := newM.Spec.InfrastructureRef.GroupVersionKind()
+	crdMetadata := &metav1.PartialObjectMetadata{}
+	crdMetadata.SetGroupVersionKind(apiextensionsv1.SchemeGroupVersion.WithKind("CustomResourceDefinition"))
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Name: utilcontract.CalculateCRDName(infraMachineGVK.Group, infraMachineGVK.Kind)}, crdMetadata); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(formatPath, errors.Wrapf(err, "failed to get the CustomResourceDefinition for %s to validate the bootstrap data format", infraMachineGVK.Kind))}
+	}
+	accepted := []string{}
+	for _, f := range strings.Split(crdMetadata.GetAnnotations()[clusterv1.BootstrapDataFormatsAnnotation], ",") {
+		if f = strings.TrimSpace(f); f != "" {
+			accepted = append(accepted, f)
+		}
+	}
+	if len(accepted) == 0 {
+		return nil
+	}
+	if !sets.New[string](accepted...).Has(format) {
+		return field.ErrorList{
+			field.Invalid(
+				formatPath,
+				format,
+				fmt.Sprintf("bootstrap data format %q is not accepted by %s, accepted formats are %s", format, infraMachineGVK.Kind, strings.Join(accepted, ", ")),
+			),
+		}
+	}
+	return nil
+}
+
+// validateMachineTaints validates the taints CAPI should manage on the Node corresponding to a Machine.
+func validateMachineTaints(taints []corev1.Taint, pathPrefix *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := sets.Set[string]{}
+	for i, taint := range taints {
+		if taint.Key == "" {
+			allErrs = append(allErrs, field.Required(pathPrefix.Index(i).Child("key"), "must be set"))
+		}
+		switch taint.Effect {
+		case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		default:
+			allErrs = append(allErrs, field.NotSupported(pathPrefix.Index(i).Child("effect"), taint.Effect,
+				[]string{string(corev1.TaintEffectNoSchedule), string(corev1.TaintEffectPreferNoSchedule), string(corev1.TaintEffectNoExecute)}))
+		}
+		if taint.MatchTaint(&clusterv1.NodeUninitializedTaint) {
+			allErrs = append(allErrs, field.Forbidden(pathPrefix.Index(i), fmt.Sprintf("taint %s is managed by Cluster API and cannot be set", clusterv1.NodeUninitializedTaint.Key)))
+		}
+		if id := fmt.Sprintf("%s:%s", taint.Key, taint.Effect); seen.Has(id) {
+			allErrs = append(allErrs, field.Duplicate(pathPrefix.Index(i), id))
+		} else {
+			seen.Insert(id)
+		}
+	}
+
+	return allErrs
+}
@@ -21,10 +21,16 @@ import (
 
 	. "github.com/onsi/gomega"
 	corev1 "k8s.io/api/core/v1"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 )
 
@@ -284,3 +290,247 @@ func TestMachineVersionValidation(t *testing.T) {
 		})
 	}
 }
+
+func TestMachineTaintsValidation(t *testing.T) {
+	tests := []struct {
+		name      string
+		taints    []corev1.Taint
+		expectErr bool
+	}{
+		{
+			name: "should succeed when given valid taints",
+			taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoExecute},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should return error when given a taint without key",
+			taints: []corev1.Taint{
+				{Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when given a taint with an invalid effect",
+			taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: "Invalid"},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when given duplicated taints",
+			taints: []corev1.Taint{
+				{Key: "dedicated", Value: "gpu", Effect: corev1.TaintEffectNoSchedule},
+				{Key: "dedicated", Value: "infra", Effect: corev1.TaintEffectNoSchedule},
+			},
+			expectErr: true,
+		},
+		{
+			name: "should return error when given the taint managed by Cluster API",
+			taints: []corev1.Taint{
+				clusterv1.NodeUninitializedTaint,
+			},
+			expectErr: true,
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			m := &clusterv1.Machine{
+				Spec: clusterv1.MachineSpec{
+					Bootstrap: clusterv1.Bootstrap{ConfigRef: nil, DataSecretName: pointer.String("test")},
+					Taints:    tt.taints,
+				},
+			}
+			webhook := &Machine{}
+
+			warnings, err := webhook.ValidateCreate(ctx, m)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
+func TestMachineBootstrapDataFormatValidation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clusterv1.AddToScheme(scheme)
+	_ = corev1.AddToScheme(scheme)
+	_ = apiextensionsv1.AddToScheme(scheme)
+
+	infraMachineCRD := func(acceptedFormats *string) *apiextensionsv1.CustomResourceDefinition {
+		crd := builder.GenericInfrastructureMachineCRD.DeepCopy()
+		if acceptedFormats != nil {
+			crd.SetAnnotations(map[string]string{clusterv1.BootstrapDataFormatsAnnotation: *acceptedFormats})
+		}
+		return crd
+	}
+	bootstrapConfig := func(format string) *unstructured.Unstructured {
+		obj := builder.BootstrapConfig(metav1.NamespaceDefault, "bootstrap-config").Build()
+		if format != "" {
+			_ = unstructured.SetNestedField(obj.Object, format, "spec", "format")
+		}
+		return obj
+	}
+	bootstrapSecret := func(format string) *corev1.Secret {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "bootstrap-data"},
+			Data:       map[string][]byte{"value": []byte("data")},
+		}
+		if format != "" {
+			secret.Data["format"] = []byte(format)
+		}
+		return secret
+	}
+	machineWithConfigRef := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "machine"},
+		Spec: clusterv1.MachineSpec{
+			Bootstrap: clusterv1.Bootstrap{
+				ConfigRef: &corev1.ObjectReference{
+					APIVersion: builder.BootstrapGroupVersion.String(),
+					Kind:       builder.GenericBootstrapConfigKind,
+					Namespace:  metav1.NamespaceDefault,
+					Name:       "bootstrap-config",
+				},
+			},
+			InfrastructureRef: corev1.ObjectReference{
+				APIVersion: builder.InfrastructureGroupVersion.String(),
+				Kind:       builder.GenericInfrastructureMachineKind,
+				Namespace:  metav1.NamespaceDefault,
+				Name:       "infra-machine",
+			},
+		},
+	}
+	machineWithDataSecretName := machineWithConfigRef.DeepCopy()
+	machineWithDataSecretName.Spec.Bootstrap.ConfigRef = nil
+	machineWithDataSecretName.Spec.Bootstrap.DataSecretName = pointer.String("bootstrap-data")
+
+	tests := []struct {
+		name      string
+		objects   []client.Object
+		oldM      *clusterv1.Machine
+		newM      *clusterv1.Machine
+		expectErr bool
+	}{
+		{
+			name:      "should succeed when the bootstrap config format is accepted by the infrastructure provider",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config, ignition")), bootstrapConfig("ignition")},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the bootstrap config format is not accepted by the infrastructure provider",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapConfig("ignition")},
+			newM:      machineWithConfigRef,
+			expectErr: true,
+		},
+		{
+			name:      "should succeed when the infrastructure provider advertises an empty list of accepted formats",
+			objects:   []client.Object{infraMachineCRD(pointer.String("")), bootstrapConfig("ignition")},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the infrastructure provider does not advertise the accepted formats",
+			objects:   []client.Object{infraMachineCRD(nil), bootstrapConfig("ignition")},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the bootstrap config does not declare a format",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapConfig("")},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the bootstrap config does not exist",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config"))},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the InfraMachine CRD does not exist",
+			objects:   []client.Object{bootstrapConfig("ignition")},
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the bootstrap data Secret format is accepted by the infrastructure provider",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config,ignition")), bootstrapSecret("ignition")},
+			newM:      machineWithDataSecretName,
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the bootstrap data Secret format is not accepted by the infrastructure provider",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapSecret("ignition")},
+			newM:      machineWithDataSecretName,
+			expectErr: true,
+		},
+		{
+			name:      "should succeed when the bootstrap data Secret does not declare a format",
+			objects:   []client.Object{infraMachineCRD(pointer.String("ignition")), bootstrapSecret("")},
+			newM:      machineWithDataSecretName,
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the bootstrap and infrastructure references are not changed",
+			objects:   []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapConfig("ignition")},
+			oldM:      machineWithConfigRef,
+			newM:      machineWithConfigRef,
+			expectErr: false,
+		},
+		{
+			name:    "should succeed when dataSecretName is set on a Machine with a bootstrap config",
+			objects: []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapConfig("ignition"), bootstrapSecret("ignition")},
+			oldM:    machineWithConfigRef,
+			newM: func() *clusterv1.Machine {
+				m := machineWithConfigRef.DeepCopy()
+				m.Spec.Bootstrap.DataSecretName = pointer.String("bootstrap-data")
+				return m
+			}(),
+			expectErr: false,
+		},
+		{
+			name:    "should return error when the infrastructure reference is changed to a provider not accepting the format",
+			objects: []client.Object{infraMachineCRD(pointer.String("cloud-config")), bootstrapConfig("ignition")},
+			oldM: func() *clusterv1.Machine {
+				m := machineWithConfigRef.DeepCopy()
+				m.Spec.InfrastructureRef.Name = "old-infra-machine"
+				return m
+			}(),
+			newM:      machineWithConfigRef,
+			expectErr: true,
+		},
+	}
+
+	for i := range tests {
+		tt := tests[i]
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &Machine{
+				Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.objects...).Build(),
+			}
+
+			var err error
+			if tt.oldM == nil {
+				_, err = webhook.ValidateCreate(ctx, tt.newM)
+			} else {
+				_, err = webhook.ValidateUpdate(ctx, tt.oldM, tt.newM)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -22,6 +22,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/blang/semver/v4"
 	"github.com/pkg/errors"
 	v1 "k8s.io/api/admission/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -33,11 +34,14 @@ import (
 	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/controllers/external"
 	"sigs.k8s.io/cluster-api/feature"
+	"sigs.k8s.io/cluster-api/internal/contract"
 	"sigs.k8s.io/cluster-api/util/version"
 )
 
@@ -59,6 +63,10 @@ func (webhook *MachineDeployment) SetupWebhookWithManager(mgr ctrl.Manager) erro
 // MachineDeployment implements a validation and defaulting webhook for MachineDeployment.
 type MachineDeployment struct {
 	Decoder *admission.Decoder
+
+	// Client is used to read the Cluster and the control plane of a MachineDeployment to validate the
+	// version skew between the kubelets and the API server; the validation is skipped if Client is nil.
+	Client client.Reader
 }
 
 var _ webhook.CustomDefaulter = &MachineDeployment{}
@@ -162,17 +170,17 @@ func (webhook *MachineDeployment) Default(ctx context.Context, obj runtime.Objec
 }
 
 // ValidateCreate implements webhook.CustomValidator so a webhook will be registered for the type.
-func (webhook *MachineDeployment) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+func (webhook *MachineDeployment) ValidateCreate(ctx context.Context, obj runtime.Object) (admission.Warnings, error) {
 	m, ok := obj.(*clusterv1.MachineDeployment)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", obj))
 	}
 
-	return nil, webhook.validate(nil, m)
+	return nil, webhook.validate(ctx, nil, m)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *MachineDeployment) ValidateUpdate(_ context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
+func (webhook *MachineDeployment) ValidateUpdate(ctx context.Context, oldObj, newObj runtime.Object) (admission.Warnings, error) {
 	oldMD, ok := oldObj.(*clusterv1.MachineDeployment)
 	if !ok {
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", oldObj))
@@ -183,7 +191,7 @@ func (webhook *MachineDeployment) ValidateUpdate(_ context.Context, oldObj, newO
 		return nil, apierrors.NewBadRequest(fmt.Sprintf("expected a MachineDeployment but got a %T", newObj))
 	}
 
-	return nil, webhook.validate(oldMD, newMD)
+	return nil, webhook.validate(ctx, oldMD, newMD)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
@@ -191,7 +199,7 @@ func (webhook *MachineDeployment) ValidateDelete(_ context.Context, _ runtime.Ob
 	return nil, nil
 }
 
-func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeployment) error {
+func (webhook *MachineDeployment) validate(ctx context.Context, oldMD, newMD *clusterv1.MachineDeployment) error {
 	var allErrs field.ErrorList
 	// The MachineDeployment name is used as a label value. This check ensures names which are not be valid label values are rejected.
 	if errs := validation.IsValidLabelValue(newMD.Name); len(errs) != 0 {
@@ -223,13 +231,16 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 		)
 	}
 
-	// MachineSet preflight checks that should be skipped could also be set as annotation on the MachineDeployment
+	// MachineSet preflight checks that should be skipped or enabled could also be set as annotation on the MachineDeployment
 	// since MachineDeployment annotations are synced to the MachineSet.
 	if feature.Gates.Enabled(feature.MachineSetPreflightChecks) {
 		if err := validateSkippedMachineSetPreflightChecks(newMD); err != nil {
 			allErrs = append(allErrs, err)
 		}
 	}
+	if err := validateEnabledMachineSetPreflightChecks(newMD); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if oldMD != nil && oldMD.Spec.ClusterName != newMD.Spec.ClusterName {
 		allErrs = append(
@@ -271,9 +282,15 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	if newMD.Spec.Template.Spec.Version != nil {
 		if !version.KubeSemver.MatchString(*newMD.Spec.Template.Spec.Version) {
 			allErrs = append(allErrs, field.Invalid(specPath.Child("template", "spec", "version"), *newMD.Spec.Template.Spec.Version, "must be a valid semantic version"))
+		} else if oldMD == nil || pointer.StringDeref(oldMD.Spec.Template.Spec.Version, "") != *newMD.Spec.Template.Spec.Version {
+			// The version skew is only validated when the version changes, so MachineDeployments already violating
+			// the version skew policy can still be updated.
+			allErrs = append(allErrs, webhook.validateVersionSkew(ctx, newMD, specPath.Child("template", "spec", "version"))...)
 		}
 	}
 
+	allErrs = append(allErrs, validateMachineTaints(newMD.Spec.Template.Spec.Taints, specPath.Child("template", "spec", "taints"))...)
+
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMD.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
 
@@ -284,6 +301,71 @@ func (webhook *MachineDeployment) validate(oldMD, newMD *clusterv1.MachineDeploy
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineDeployment").GroupKind(), newMD.Name, allErrs)
 }
 
+// validateVersionSkew validates that the version of the MachineDeployment is within the version skew supported by
+// the control plane of the Cluster, i.e. it is not newer than the control plane version and it is not more minor
+// versions older than the control plane version than allowed by the Kubernetes version skew policy.
+func (webhook *MachineDeployment) validateVersionSkew(ctx context.Context, md *clusterv1.MachineDeployment, fldPath *field.Path) field.ErrorList {
+	if webhook.Client == nil || skipVersionSkewValidation(md.Annotations) {
+		return nil
+	}
+
+	mdVersion, err := semver.ParseTolerant(*md.Spec.Template.Spec.Version)
+	if err != nil {
+		// NOTE: this should never happen as the version has already been validated.
+		return nil
+	}
+
+	// If the Cluster or its control plane do not exist yet, or the control plane does not have a version,
+	// there is not enough information to validate the version skew.
+	cluster := &clusterv1.Cluster{}
+	if err := webhook.Client.Get(ctx, client.ObjectKey{Namespace: md.Namespace, Name: md.Spec.ClusterName}, cluster); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(fldPath, errors.Wrapf(err, "failed to get Cluster %s to validate the version skew", md.Spec.ClusterName))}
+	}
+	if cluster.Spec.ControlPlaneRef == nil {
+		return nil
+	}
+	controlPlane, err := external.Get(ctx, webhook.Client, cluster.Spec.ControlPlaneRef, cluster.Namespace)
+	if err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return field.ErrorList{field.InternalError(fldPath, errors.Wrap(err, "failed to get the control plane to validate the version skew"))}
+	}
+	cpVersion, err := contract.ControlPlane().Version().Get(controlPlane)
+	if err != nil {
+		return nil
+	}
+	cpSemver, err := semver.ParseTolerant(*cpVersion)
+	if err != nil {
+		return nil
+	}
+
+	// NOTE: The MachineSet preflight checks enforce the same rules at runtime; rejecting the change here gives
+	// immediate feedback and prevents rollouts which would never complete.
+	if isKubeletVersionNewer(cpSemver, mdVersion) {
+		return field.ErrorList{
+			field.Forbidden(
+				fldPath,
+				fmt.Sprintf("version %q is higher than the control plane version %q; upgrade the control plane first or set the %q annotation to skip this check",
+					mdVersion, cpSemver, clusterv1.SkipVersionSkewValidationAnnotation),
+			),
+		}
+	}
+	if exceedsKubeletVersionSkew(cpSemver, mdVersion) {
+		return field.ErrorList{
+			field.Forbidden(
+				fldPath,
+				fmt.Sprintf("version %q is more than %d minor versions older than the control plane version %q; set the %q annotation to skip this check",
+					mdVersion, maxKubeletVersionSkew(cpSemver), cpSemver, clusterv1.SkipVersionSkewValidationAnnotation),
+			),
+		}
+	}
+	return nil
+}
+
 // calculateMachineDeploymentReplicas calculates the default value of the replicas field.
 // The value will be calculated based on the following logic:
 // * if replicas is already set on newMD, keep the current value
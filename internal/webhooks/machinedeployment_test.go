@@ -27,9 +27,12 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/intstr"
 	"k8s.io/utils/pointer"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/test/builder"
 	"sigs.k8s.io/cluster-api/internal/webhooks/util"
 )
 
@@ -498,6 +501,126 @@ func TestMachineDeploymentVersionValidation(t *testing.T) {
 	}
 }
 
+func TestMachineDeploymentVersionSkewValidation(t *testing.T) {
+	controlPlane := builder.ControlPlane(metav1.NamespaceDefault, "cp").
+		WithVersion("v1.27.3").
+		Build()
+	cluster := builder.Cluster(metav1.NamespaceDefault, "test-cluster").
+		WithControlPlane(controlPlane).
+		Build()
+
+	tests := []struct {
+		name        string
+		oldVersion  string
+		version     string
+		annotations map[string]string
+		objects     []client.Object
+		expectErr   bool
+	}{
+		{
+			name:      "should succeed when the version is within the version skew",
+			version:   "v1.25.0",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: false,
+		},
+		{
+			name:      "should return error when the version is higher than the control plane version",
+			version:   "v1.28.0",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the version is older than the version skew",
+			version:   "v1.24.0",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the major version is higher than the control plane version",
+			version:   "v2.0.0",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: true,
+		},
+		{
+			name:      "should return error when the major version is older than the control plane version",
+			version:   "v0.27.0",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: true,
+		},
+		{
+			name:      "should succeed when only the patch version is higher than the control plane version",
+			version:   "v1.27.5",
+			objects:   []client.Object{cluster, controlPlane},
+			expectErr: false,
+		},
+		{
+			name:        "should succeed when the version skew validation is skipped via annotation",
+			version:     "v1.24.0",
+			annotations: map[string]string{clusterv1.SkipVersionSkewValidationAnnotation: ""},
+			objects:     []client.Object{cluster, controlPlane},
+			expectErr:   false,
+		},
+		{
+			name:       "should succeed when the version does not change",
+			oldVersion: "v1.24.0",
+			version:    "v1.24.0",
+			objects:    []client.Object{cluster, controlPlane},
+			expectErr:  false,
+		},
+		{
+			name:      "should succeed when the control plane does not exist",
+			version:   "v1.24.0",
+			objects:   []client.Object{cluster},
+			expectErr: false,
+		},
+		{
+			name:      "should succeed when the Cluster does not exist",
+			version:   "v1.24.0",
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			md := &clusterv1.MachineDeployment{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "test-md",
+					Namespace:   metav1.NamespaceDefault,
+					Annotations: tt.annotations,
+				},
+				Spec: clusterv1.MachineDeploymentSpec{
+					ClusterName: "test-cluster",
+					Template: clusterv1.MachineTemplateSpec{
+						Spec: clusterv1.MachineSpec{
+							Version: pointer.String(tt.version),
+						},
+					},
+				},
+			}
+
+			webhook := MachineDeployment{
+				Client: fake.NewClientBuilder().WithScheme(fakeScheme).WithObjects(tt.objects...).Build(),
+			}
+
+			var err error
+			if tt.oldVersion != "" {
+				oldMD := md.DeepCopy()
+				oldMD.Spec.Template.Spec.Version = pointer.String(tt.oldVersion)
+				_, err = webhook.ValidateUpdate(ctx, oldMD, md)
+			} else {
+				_, err = webhook.ValidateCreate(ctx, md)
+			}
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestMachineDeploymentClusterNameImmutable(t *testing.T) {
 	tests := []struct {
 		name           string
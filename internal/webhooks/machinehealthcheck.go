@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/internal/util/cron"
 )
 
 var (
@@ -38,6 +39,9 @@ var (
 	minNodeStartupTimeout = metav1.Duration{Duration: 30 * time.Second}
 	// We allow users to disable the nodeStartupTimeout by setting the duration to 0.
 	disabledNodeStartupTimeout = clusterv1.ZeroDuration
+
+	minRemediationSuppressionWindowDuration = time.Minute
+	maxRemediationSuppressionWindowDuration = 7 * 24 * time.Hour
 )
 
 // SetMinNodeStartupTimeout allows users to optionally set a custom timeout
@@ -165,7 +169,7 @@ func (webhook *MachineHealthCheck) validate(oldMHC, newMHC *clusterv1.MachineHea
 	return apierrors.NewInvalid(clusterv1.GroupVersion.WithKind("MachineHealthCheck").GroupKind(), newMHC.Name, allErrs)
 }
 
-// ValidateCommonFields validates UnhealthyConditions NodeStartupTimeout, MaxUnhealthy, and RemediationTemplate of the MHC.
+// ValidateCommonFields validates UnhealthyConditions NodeStartupTimeout, InfrastructureStartupTimeout, RemediationSuppressionWindows, MaxUnhealthy, and RemediationTemplate of the MHC.
 // These are the fields in common with other types which define MachineHealthChecks such as MachineHealthCheckClass and MachineHealthCheckTopology.
 func (webhook *MachineHealthCheck) validateCommonFields(m *clusterv1.MachineHealthCheck, fldPath *field.Path) field.ErrorList {
 	var allErrs field.ErrorList
@@ -178,6 +182,28 @@ func (webhook *MachineHealthCheck) validateCommonFields(m *clusterv1.MachineHeal
 			field.Invalid(fldPath.Child("nodeStartupTimeout"), m.Spec.NodeStartupTimeout.String(), "must be at least 30s"),
 		)
 	}
+	if m.Spec.InfrastructureStartupTimeout != nil &&
+		m.Spec.InfrastructureStartupTimeout.Seconds() != disabledNodeStartupTimeout.Seconds() &&
+		m.Spec.InfrastructureStartupTimeout.Seconds() < minNodeStartupTimeout.Seconds() {
+		allErrs = append(
+			allErrs,
+			field.Invalid(fldPath.Child("infrastructureStartupTimeout"), m.Spec.InfrastructureStartupTimeout.String(), "must be at least 30s"),
+		)
+	}
+	for i, window := range m.Spec.RemediationSuppressionWindows {
+		if _, err := cron.Parse(window.Schedule); err != nil {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("remediationSuppressionWindows").Index(i).Child("schedule"), window.Schedule, err.Error()),
+			)
+		}
+		if window.Duration.Duration < minRemediationSuppressionWindowDuration || window.Duration.Duration > maxRemediationSuppressionWindowDuration {
+			allErrs = append(
+				allErrs,
+				field.Invalid(fldPath.Child("remediationSuppressionWindows").Index(i).Child("duration"), window.Duration.String(), "must be at least 1m and at most 168h"),
+			)
+		}
+	}
 	if m.Spec.MaxUnhealthy != nil {
 		if _, err := intstr.GetScaledValueFromIntOrPercent(m.Spec.MaxUnhealthy, 0, false); err != nil {
 			allErrs = append(
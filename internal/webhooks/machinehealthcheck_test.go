@@ -329,6 +329,164 @@ func TestMachineHealthCheckNodeStartupTimeout(t *testing.T) {
 	}
 }
 
+func TestMachineHealthCheckInfrastructureStartupTimeout(t *testing.T) {
+	zero := metav1.Duration{Duration: 0}
+	twentyNineSeconds := metav1.Duration{Duration: 29 * time.Second}
+	thirtySeconds := metav1.Duration{Duration: 30 * time.Second}
+	oneMinute := metav1.Duration{Duration: 1 * time.Minute}
+	minusOneMinute := metav1.Duration{Duration: -1 * time.Minute}
+
+	tests := []struct {
+		name      string
+		timeout   *metav1.Duration
+		expectErr bool
+	}{
+		{
+			name:      "when the infrastructureStartupTimeout is not given",
+			timeout:   nil,
+			expectErr: false,
+		},
+		{
+			name:      "when the infrastructureStartupTimeout is greater than 30s",
+			timeout:   &oneMinute,
+			expectErr: false,
+		},
+		{
+			name:      "when the infrastructureStartupTimeout is 30s",
+			timeout:   &thirtySeconds,
+			expectErr: false,
+		},
+		{
+			name:      "when the infrastructureStartupTimeout is 29s",
+			timeout:   &twentyNineSeconds,
+			expectErr: true,
+		},
+		{
+			name:      "when the infrastructureStartupTimeout is less than 0",
+			timeout:   &minusOneMinute,
+			expectErr: true,
+		},
+		{
+			name:      "when the infrastructureStartupTimeout is 0 (disabled)",
+			timeout:   &zero,
+			expectErr: false,
+		},
+	}
+
+	for _, tt := range tests {
+		g := NewWithT(t)
+
+		mhc := &clusterv1.MachineHealthCheck{
+			Spec: clusterv1.MachineHealthCheckSpec{
+				InfrastructureStartupTimeout: tt.timeout,
+				Selector: metav1.LabelSelector{
+					MatchLabels: map[string]string{
+						"test": "test",
+					},
+				},
+				UnhealthyConditions: []clusterv1.UnhealthyCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionFalse,
+					},
+				},
+			},
+		}
+		webhook := &MachineHealthCheck{}
+
+		if tt.expectErr {
+			warnings, err := webhook.ValidateCreate(ctx, mhc)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+			warnings, err = webhook.ValidateUpdate(ctx, mhc, mhc)
+			g.Expect(err).To(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		} else {
+			warnings, err := webhook.ValidateCreate(ctx, mhc)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+			warnings, err = webhook.ValidateUpdate(ctx, mhc, mhc)
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(warnings).To(BeEmpty())
+		}
+	}
+}
+
+func TestMachineHealthCheckRemediationSuppressionWindows(t *testing.T) {
+	tests := []struct {
+		name      string
+		windows   []clusterv1.RemediationSuppressionWindow
+		expectErr bool
+	}{
+		{
+			name:      "when no remediation suppression windows are given",
+			expectErr: false,
+		},
+		{
+			name: "when the remediation suppression windows are valid",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+				{Schedule: "@daily", Duration: metav1.Duration{Duration: time.Minute}},
+				{Schedule: "0 0 1 * *", Duration: metav1.Duration{Duration: 7 * 24 * time.Hour}},
+			},
+			expectErr: false,
+		},
+		{
+			name: "when the schedule is not valid",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * *", Duration: metav1.Duration{Duration: 4 * time.Hour}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "when the duration is less than 1m",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 59 * time.Second}},
+			},
+			expectErr: true,
+		},
+		{
+			name: "when the duration is greater than 7 days",
+			windows: []clusterv1.RemediationSuppressionWindow{
+				{Schedule: "0 2 * * sat", Duration: metav1.Duration{Duration: 7*24*time.Hour + time.Minute}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			mhc := &clusterv1.MachineHealthCheck{
+				Spec: clusterv1.MachineHealthCheckSpec{
+					RemediationSuppressionWindows: tt.windows,
+					Selector: metav1.LabelSelector{
+						MatchLabels: map[string]string{
+							"test": "test",
+						},
+					},
+					UnhealthyConditions: []clusterv1.UnhealthyCondition{
+						{
+							Type:   corev1.NodeReady,
+							Status: corev1.ConditionFalse,
+						},
+					},
+				},
+			}
+			webhook := &MachineHealthCheck{}
+
+			warnings, err := webhook.ValidateCreate(ctx, mhc)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+			g.Expect(warnings).To(BeEmpty())
+		})
+	}
+}
+
 func TestMachineHealthCheckMaxUnhealthy(t *testing.T) {
 	tests := []struct {
 		name      string
@@ -152,6 +152,9 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 			allErrs = append(allErrs, err)
 		}
 	}
+	if err := validateEnabledMachineSetPreflightChecks(newMS); err != nil {
+		allErrs = append(allErrs, err)
+	}
 
 	if oldMS != nil && oldMS.Spec.ClusterName != newMS.Spec.ClusterName {
 		allErrs = append(
@@ -176,6 +179,8 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 		}
 	}
 
+	allErrs = append(allErrs, validateMachineTaints(newMS.Spec.Template.Spec.Taints, specPath.Child("template", "spec", "taints"))...)
+
 	// Validate the metadata of the template.
 	allErrs = append(allErrs, newMS.Spec.Template.ObjectMeta.Validate(specPath.Child("template", "metadata"))...)
 
@@ -187,11 +192,19 @@ func (webhook *MachineSet) validate(oldMS, newMS *clusterv1.MachineSet) error {
 }
 
 func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
+	return validateMachineSetPreflightChecksAnnotation(o, clusterv1.MachineSetSkipPreflightChecksAnnotation)
+}
+
+func validateEnabledMachineSetPreflightChecks(o client.Object) *field.Error {
+	return validateMachineSetPreflightChecksAnnotation(o, clusterv1.MachineSetEnablePreflightChecksAnnotation)
+}
+
+func validateMachineSetPreflightChecksAnnotation(o client.Object, annotation string) *field.Error {
 	if o == nil {
 		return nil
 	}
-	skip := o.GetAnnotations()[clusterv1.MachineSetSkipPreflightChecksAnnotation]
-	if skip == "" {
+	value := o.GetAnnotations()[annotation]
+	if value == "" {
 		return nil
 	}
 
@@ -202,19 +215,19 @@ func validateSkippedMachineSetPreflightChecks(o client.Object) *field.Error {
 		clusterv1.MachineSetPreflightCheckControlPlaneIsStable,
 	)
 
-	skippedList := strings.Split(skip, ",")
+	list := strings.Split(value, ",")
 	invalid := []clusterv1.MachineSetPreflightCheck{}
-	for i := range skippedList {
-		skipped := clusterv1.MachineSetPreflightCheck(strings.TrimSpace(skippedList[i]))
-		if !supported.Has(skipped) {
-			invalid = append(invalid, skipped)
+	for i := range list {
+		check := clusterv1.MachineSetPreflightCheck(strings.TrimSpace(list[i]))
+		if !supported.Has(check) {
+			invalid = append(invalid, check)
 		}
 	}
 	if len(invalid) > 0 {
 		return field.Invalid(
-			field.NewPath("metadata", "annotations", clusterv1.MachineSetSkipPreflightChecksAnnotation),
+			field.NewPath("metadata", "annotations", annotation),
 			invalid,
-			fmt.Sprintf("skipped preflight check(s) must be among: %v", sets.List(supported)),
+			fmt.Sprintf("preflight check(s) must be among: %v", sets.List(supported)),
 		)
 	}
 	return nil
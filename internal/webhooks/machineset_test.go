@@ -314,6 +314,54 @@ func TestValidateSkippedMachineSetPreflightChecks(t *testing.T) {
 	}
 }
 
+func TestValidateEnabledMachineSetPreflightChecks(t *testing.T) {
+	tests := []struct {
+		name      string
+		ms        *clusterv1.MachineSet
+		expectErr bool
+	}{
+		{
+			name:      "should pass if the machine set enable preflight checks annotation is not set",
+			ms:        &clusterv1.MachineSet{},
+			expectErr: false,
+		},
+		{
+			name: "should pass if only valid preflight checks are enabled",
+			ms: &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.MachineSetEnablePreflightChecksAnnotation: string(clusterv1.MachineSetPreflightCheckKubeadmVersionSkew) + "," + string(clusterv1.MachineSetPreflightCheckControlPlaneIsStable),
+					},
+				},
+			},
+			expectErr: false,
+		},
+		{
+			name: "should fail if invalid preflight checks are enabled",
+			ms: &clusterv1.MachineSet{
+				ObjectMeta: metav1.ObjectMeta{
+					Annotations: map[string]string{
+						clusterv1.MachineSetEnablePreflightChecksAnnotation: "invalid-preflight-check-name",
+					},
+				},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			err := validateEnabledMachineSetPreflightChecks(tt.ms)
+			if tt.expectErr {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
+
 func TestMachineSetTemplateMetadataValidation(t *testing.T) {
 	tests := []struct {
 		name        string
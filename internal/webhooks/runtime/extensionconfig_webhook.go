@@ -221,6 +221,26 @@ func validateExtensionConfigSpec(e *runtimev1.ExtensionConfig) field.ErrorList {
 			}
 		}
 	}
+
+	// Validate ClientCertificate if defined
+	if e.Spec.ClientConfig.ClientCertificate != nil {
+		for _, msg := range validation.IsDNS1123Subdomain(e.Spec.ClientConfig.ClientCertificate.Name) {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("clientConfig", "clientCertificate", "name"),
+				e.Spec.ClientConfig.ClientCertificate.Name,
+				msg,
+			))
+		}
+
+		for _, msg := range validation.IsDNS1123Label(e.Spec.ClientConfig.ClientCertificate.Namespace) {
+			allErrs = append(allErrs, field.Invalid(
+				specPath.Child("clientConfig", "clientCertificate", "namespace"),
+				e.Spec.ClientConfig.ClientCertificate.Namespace,
+				msg,
+			))
+		}
+	}
+
 	if e.Spec.NamespaceSelector == nil {
 		allErrs = append(allErrs, field.Required(
 			specPath.Child("namespaceSelector"),
@@ -217,6 +217,18 @@ func TestExtensionConfigValidate(t *testing.T) {
 		},
 	}
 
+	extensionWithClientCertificate := extensionWithService.DeepCopy()
+	extensionWithClientCertificate.Spec.ClientConfig.ClientCertificate = &runtimev1.SecretReference{
+		Namespace: "bar",
+		Name:      "foo-client-cert",
+	}
+
+	extensionWithNoClientCertificateName := extensionWithClientCertificate.DeepCopy()
+	extensionWithNoClientCertificateName.Spec.ClientConfig.ClientCertificate.Name = ""
+
+	extensionWithBadClientCertificateNamespace := extensionWithClientCertificate.DeepCopy()
+	extensionWithBadClientCertificateNamespace.Spec.ClientConfig.ClientCertificate.Namespace = "INVALID"
+
 	tests := []struct {
 		name        string
 		in          *runtimev1.ExtensionConfig
@@ -280,6 +292,24 @@ func TestExtensionConfigValidate(t *testing.T) {
 			featureGate: true,
 			expectErr:   true,
 		},
+		{
+			name:        "creation should succeed if ClientCertificate is correctly defined",
+			in:          extensionWithClientCertificate,
+			featureGate: true,
+			expectErr:   false,
+		},
+		{
+			name:        "creation should fail if no ClientCertificate Name is defined",
+			in:          extensionWithNoClientCertificateName,
+			featureGate: true,
+			expectErr:   true,
+		},
+		{
+			name:        "creation should fail if ClientCertificate Namespace violates Kubernetes naming rules",
+			in:          extensionWithBadClientCertificateNamespace,
+			featureGate: true,
+			expectErr:   true,
+		},
 		{
 			name:        "update should fail if URL is invalid",
 			old:         extensionWithURL,
@@ -0,0 +1,62 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"github.com/blang/semver/v4"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// minVerKubeletVersionSkewThree is the minimum API server version for which kubelets are allowed
+// to be up to three minor versions older than the API server.
+var minVerKubeletVersionSkewThree = semver.MustParse("1.28.0")
+
+// maxKubeletVersionSkew returns the maximum number of minor versions a kubelet is allowed to be older than
+// the API server according to the Kubernetes version skew policy.
+func maxKubeletVersionSkew(apiServerVersion semver.Version) uint64 {
+	if apiServerVersion.LT(minVerKubeletVersionSkewThree) {
+		return 2
+	}
+	return 3
+}
+
+// isKubeletVersionNewer returns true if a kubelet with the given version is newer than the API server version;
+// only major and minor versions are compared, given that the version skew policy does not apply to patch versions.
+func isKubeletVersionNewer(apiServerVersion, kubeletVersion semver.Version) bool {
+	return majorMinor(kubeletVersion).GT(majorMinor(apiServerVersion))
+}
+
+// exceedsKubeletVersionSkew returns true if a kubelet with the given version is more minor versions older than
+// the API server version than allowed by the Kubernetes version skew policy.
+func exceedsKubeletVersionSkew(apiServerVersion, kubeletVersion semver.Version) bool {
+	if kubeletVersion.Major != apiServerVersion.Major {
+		return kubeletVersion.Major < apiServerVersion.Major
+	}
+	return kubeletVersion.Minor+maxKubeletVersionSkew(apiServerVersion) < apiServerVersion.Minor
+}
+
+// majorMinor returns the given version without patch, pre-release and build metadata.
+func majorMinor(v semver.Version) semver.Version {
+	return semver.Version{Major: v.Major, Minor: v.Minor}
+}
+
+// skipVersionSkewValidation returns true if the version skew validation has been disabled via annotation.
+func skipVersionSkewValidation(annotations map[string]string) bool {
+	_, ok := annotations[clusterv1.SkipVersionSkewValidationAnnotation]
+	return ok
+}
@@ -22,12 +22,14 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net"
 	"os"
 	goruntime "runtime"
 	"time"
 
 	"github.com/spf13/pflag"
 	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
 	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -67,8 +69,12 @@ import (
 	"sigs.k8s.io/cluster-api/feature"
 	runtimeclient "sigs.k8s.io/cluster-api/internal/runtime/client"
 	runtimeregistry "sigs.k8s.io/cluster-api/internal/runtime/registry"
+	adaptiveconcurrency "sigs.k8s.io/cluster-api/internal/util/concurrency"
+	"sigs.k8s.io/cluster-api/internal/util/ratelimit"
 	runtimewebhooks "sigs.k8s.io/cluster-api/internal/webhooks/runtime"
 	"sigs.k8s.io/cluster-api/util/flags"
+	"sigs.k8s.io/cluster-api/util/priority"
+	"sigs.k8s.io/cluster-api/util/sharding"
 	"sigs.k8s.io/cluster-api/version"
 	"sigs.k8s.io/cluster-api/webhooks"
 )
@@ -108,8 +114,22 @@ var (
 	machineDeploymentConcurrency   int
 	machinePoolConcurrency         int
 	clusterResourceSetConcurrency  int
+	clusterGroupConcurrency        int
 	machineHealthCheckConcurrency  int
 	nodeDrainClientTimeout         time.Duration
+	machineProvisioningThreshold   time.Duration
+	machineDeletingThreshold       time.Duration
+	machineCertsExpiryThreshold    time.Duration
+	machineDeleteBlockedThreshold  time.Duration
+	clusterDeletionPhaseTimeout    time.Duration
+	extensionConfigProbeInterval   time.Duration
+	reconcilePriority              priority.Options
+	shard                          sharding.Shard
+	adaptiveConcurrencyEnabled     bool
+	adaptiveConcurrency            adaptiveconcurrency.Options
+	reconcileRateLimitEnabled      bool
+	reconcileRateLimit             ratelimit.Options
+	workloadClustersSocket         string
 )
 
 func init() {
@@ -191,6 +211,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&clusterResourceSetConcurrency, "clusterresourceset-concurrency", 10,
 		"Number of cluster resource sets to process simultaneously")
 
+	fs.IntVar(&clusterGroupConcurrency, "clustergroup-concurrency", 10,
+		"Number of cluster groups to process simultaneously")
+
 	fs.IntVar(&machineHealthCheckConcurrency, "machinehealthcheck-concurrency", 10,
 		"Number of machine health checks to process simultaneously")
 
@@ -206,6 +229,63 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.DurationVar(&nodeDrainClientTimeout, "node-drain-client-timeout-duration", time.Second*10,
 		"The timeout of the client used for draining nodes. Defaults to 10s")
 
+	fs.DurationVar(&machineProvisioningThreshold, "machine-provisioning-stuck-threshold", 0,
+		"The time after which a Machine in the Pending, Provisioning or Provisioned phase is reported as stuck by the PhaseWithinThreshold condition. If zero, provisioning Machines are never reported as stuck")
+
+	fs.DurationVar(&machineDeletingThreshold, "machine-deleting-stuck-threshold", 0,
+		"The time after which a Machine in the Deleting phase is reported as stuck by the PhaseWithinThreshold condition. If zero, deleting Machines are never reported as stuck")
+
+	fs.DurationVar(&machineCertsExpiryThreshold, "machine-certificates-expiry-warning-threshold", 0,
+		"The time before the expiry of its certificates after which a Machine is reported by the CertificatesNotExpiring condition. If zero, only Machines with expired certificates are reported")
+
+	fs.DurationVar(&machineDeleteBlockedThreshold, "machine-deletion-blocked-threshold", 0,
+		"The time after which a deleting Machine is reported by the DeletionBlocked condition, naming the finalizers and the deletion hooks blocking the deletion. If zero, deleting Machines are never reported")
+
+	fs.DurationVar(&clusterDeletionPhaseTimeout, "cluster-deletion-phase-timeout", 0,
+		"The time after which a Cluster deletion phase, i.e. the deletion of the workers, of the control plane or of the infrastructure, is considered timed out and the next phase is started. If zero, every phase waits for the previous ones to complete")
+
+	fs.DurationVar(&extensionConfigProbeInterval, "extension-config-probe-interval", 5*time.Minute,
+		"The interval at which the runtime extensions are discovered again, to report unavailable extensions and handlers by the HandlersAvailable condition of the ExtensionConfigs. If zero, extensions are discovered only on changes of the ExtensionConfigs; only used if the RuntimeSDK feature flag is enabled")
+
+	fs.StringVar(&workloadClustersSocket, "workload-clusters-socket", "",
+		"Path of a unix socket used to connect to the workload clusters instead of their API server endpoints, e.g. when the workload clusters are served by the in-memory infrastructure provider running on the same host with the same flag. For testing purposes only")
+
+	fs.IntVar(&shard.Count, "shard-count", 1,
+		"Number of shards to split the objects reconciled by the controllers across multiple deployments of the manager; objects are assigned to shards as defined by --shard-by. Defaults to 1, i.e. no sharding")
+
+	fs.IntVar(&shard.ID, "shard-id", 0,
+		"The shard reconciled by this manager, from 0 to --shard-count minus 1. Each shard uses its own leader election ID")
+
+	fs.StringVar((*string)(&shard.Mode), "shard-by", string(sharding.NamespaceMode),
+		fmt.Sprintf("How objects are assigned to shards, one of %q or %q; with %q objects are assigned to shards by the %s label", sharding.NamespaceMode, sharding.ClusterMode, sharding.ClusterMode, clusterv1.ClusterNameLabel))
+
+	fs.BoolVar(&reconcilePriority.Enabled, "reconcile-priority", false,
+		"Enable the fast lane in the reconcile queue of the Machine and MachineSet controllers for objects being deleted or recently created; steady-state resyncs are enqueued with a random delay")
+
+	fs.DurationVar(&reconcilePriority.RecentlyCreatedPeriod, "reconcile-priority-recently-created-period", priority.DefaultRecentlyCreatedPeriod,
+		"The period after creation in which an object is considered recently created and reconciled in the fast lane. Used only if --reconcile-priority is set")
+
+	fs.DurationVar(&reconcilePriority.LowPriorityMaxDelay, "reconcile-priority-low-priority-max-delay", priority.DefaultLowPriorityMaxDelay,
+		"The maximum delay for enqueuing steady-state resyncs. Used only if --reconcile-priority is set")
+
+	fs.BoolVar(&adaptiveConcurrencyEnabled, "adaptive-concurrency", false,
+		"Adjust the number of concurrent reconciles of each controller between --adaptive-concurrency-min and the value of its concurrency flag, based on the number of pending reconciles and on the latency of the API server")
+
+	fs.IntVar(&adaptiveConcurrency.MinConcurrentReconciles, "adaptive-concurrency-min", 1,
+		"The minimum number of concurrent reconciles of each controller. Used only if --adaptive-concurrency is set")
+
+	fs.DurationVar(&adaptiveConcurrency.LatencyThreshold, "adaptive-concurrency-latency-threshold", 500*time.Millisecond,
+		"The average latency of the requests to the API server above which the number of concurrent reconciles is decreased. Used only if --adaptive-concurrency is set")
+
+	fs.BoolVar(&reconcileRateLimitEnabled, "reconcile-rate-limit", false,
+		"Limit the reconciles per second of the core controllers for the objects of each namespace and of each Cluster; limits can be set for a Namespace or a Cluster with the cluster.x-k8s.io/reconcile-rate-limit annotation")
+
+	fs.Float64Var(&reconcileRateLimit.NamespaceQPS, "reconcile-rate-limit-namespace-qps", 0,
+		"The default maximum number of reconciles per second of each core controller for the objects of a namespace. If zero, only namespaces with the annotation are limited. Used only if --reconcile-rate-limit is set")
+
+	fs.Float64Var(&reconcileRateLimit.ClusterQPS, "reconcile-rate-limit-cluster-qps", 0,
+		"The default maximum number of reconciles per second of each core controller for the objects of a Cluster. If zero, only Clusters with the annotation are limited. Used only if --reconcile-rate-limit is set")
+
 	fs.IntVar(&webhookPort, "webhook-port", 9443,
 		"Webhook Server port")
 
@@ -249,6 +329,30 @@ func main() {
 	restConfig.Burst = restConfigBurst
 	restConfig.UserAgent = remote.DefaultClusterAPIUserAgent(controllerName)
 
+	if err := shard.Validate(); err != nil {
+		setupLog.Error(err, "unable to start manager")
+		os.Exit(1)
+	}
+
+	if adaptiveConcurrencyEnabled {
+		if adaptiveConcurrency.MinConcurrentReconciles < 1 {
+			setupLog.Error(errors.New("adaptive concurrency min must be greater than zero"), "unable to start manager")
+			os.Exit(1)
+		}
+		latencyTracker := adaptiveconcurrency.NewLatencyTracker()
+		restConfig.Wrap(latencyTracker.WrapTransport)
+		adaptiveConcurrency.Latency = latencyTracker.Latency
+		adaptiveconcurrency.Configure(adaptiveConcurrency)
+	}
+
+	if reconcileRateLimitEnabled {
+		if reconcileRateLimit.NamespaceQPS < 0 || reconcileRateLimit.ClusterQPS < 0 {
+			setupLog.Error(errors.New("reconcile rate limits must not be negative"), "unable to start manager")
+			os.Exit(1)
+		}
+		ratelimit.Configure(reconcileRateLimit)
+	}
+
 	if nodeDrainClientTimeout <= 0 {
 		setupLog.Error(errors.New("node drain client timeout must be greater than zero"), "unable to start manager")
 		os.Exit(1)
@@ -289,7 +393,7 @@ func main() {
 	ctrlOptions := ctrl.Options{
 		Scheme:                     scheme,
 		LeaderElection:             enableLeaderElection,
-		LeaderElectionID:           "controller-leader-election-capi",
+		LeaderElectionID:           shard.LeaderElectionID("controller-leader-election-capi"),
 		LeaseDuration:              &leaderElectionLeaseDuration,
 		RenewDeadline:              &leaderElectionRenewDeadline,
 		RetryPeriod:                &leaderElectionRetryPeriod,
@@ -381,6 +485,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 	// Set up a ClusterCacheTracker and ClusterCacheReconciler to provide to controllers
 	// requiring a connection to a remote cluster
 	log := ctrl.Log.WithName("remote").WithName("ClusterCacheTracker")
+	var dialContext func(ctx context.Context, network, address string) (net.Conn, error)
+	if workloadClustersSocket != "" {
+		dialContext = remote.NewUnixSocketDialer(workloadClustersSocket)
+	}
 	tracker, err := remote.NewClusterCacheTracker(
 		mgr,
 		remote.ClusterCacheTrackerOptions{
@@ -388,6 +496,14 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			ControllerName:      controllerName,
 			Log:                 &log,
 			Indexes:             []remote.Index{remote.NodeProviderIDIndex},
+			// Note: VolumeAttachments are only read by the Machine controller when deleting Machines, so they are not
+			// cached to avoid watching all the VolumeAttachments of the workload clusters.
+			ClientUncachedObjects: []client.Object{
+				&corev1.ConfigMap{},
+				&corev1.Secret{},
+				&storagev1.VolumeAttachment{},
+			},
+			DialContext: dialContext,
 		},
 	)
 	if err != nil {
@@ -433,6 +549,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			RuntimeClient:             runtimeClient,
 			UnstructuredCachingClient: unstructuredCachingClient,
 			WatchFilterValue:          watchFilterValue,
+			Shard:                     shard,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterClassConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterClass")
 			os.Exit(1)
@@ -445,6 +562,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Tracker:                   tracker,
 			UnstructuredCachingClient: unstructuredCachingClient,
 			WatchFilterValue:          watchFilterValue,
+			Shard:                     shard,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterTopologyConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterTopology")
 			os.Exit(1)
@@ -454,6 +572,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Client:           mgr.GetClient(),
 			APIReader:        mgr.GetAPIReader(),
 			WatchFilterValue: watchFilterValue,
+			Shard:            shard,
 		}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "MachineDeploymentTopology")
 			os.Exit(1)
@@ -463,6 +582,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Client:           mgr.GetClient(),
 			APIReader:        mgr.GetAPIReader(),
 			WatchFilterValue: watchFilterValue,
+			Shard:            shard,
 		}).SetupWithManager(ctx, mgr, controller.Options{}); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "MachineSetTopology")
 			os.Exit(1)
@@ -475,6 +595,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			APIReader:        mgr.GetAPIReader(),
 			RuntimeClient:    runtimeClient,
 			WatchFilterValue: watchFilterValue,
+			ProbeInterval:    extensionConfigProbeInterval,
 		}).SetupWithManager(ctx, mgr, concurrency(extensionConfigConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ExtensionConfig")
 			os.Exit(1)
@@ -486,6 +607,8 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		UnstructuredCachingClient: unstructuredCachingClient,
 		APIReader:                 mgr.GetAPIReader(),
 		WatchFilterValue:          watchFilterValue,
+		Shard:                     shard,
+		DeletionPhaseTimeout:      clusterDeletionPhaseTimeout,
 	}).SetupWithManager(ctx, mgr, concurrency(clusterConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Cluster")
 		os.Exit(1)
@@ -496,7 +619,13 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		APIReader:                 mgr.GetAPIReader(),
 		Tracker:                   tracker,
 		WatchFilterValue:          watchFilterValue,
+		Shard:                     shard,
 		NodeDrainClientTimeout:    nodeDrainClientTimeout,
+		StuckInPhaseThresholds:    machineStuckInPhaseThresholds(),
+		ReconcilePriority:         reconcilePriority,
+
+		CertificatesExpiryWarningThreshold: machineCertsExpiryThreshold,
+		DeletionBlockedThreshold:           machineDeleteBlockedThreshold,
 	}).SetupWithManager(ctx, mgr, concurrency(machineConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Machine")
 		os.Exit(1)
@@ -506,7 +635,10 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		UnstructuredCachingClient: unstructuredCachingClient,
 		APIReader:                 mgr.GetAPIReader(),
 		Tracker:                   tracker,
+		RuntimeClient:             runtimeClient,
 		WatchFilterValue:          watchFilterValue,
+		Shard:                     shard,
+		ReconcilePriority:         reconcilePriority,
 	}).SetupWithManager(ctx, mgr, concurrency(machineSetConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineSet")
 		os.Exit(1)
@@ -516,6 +648,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		UnstructuredCachingClient: unstructuredCachingClient,
 		APIReader:                 mgr.GetAPIReader(),
 		WatchFilterValue:          watchFilterValue,
+		Shard:                     shard,
 	}).SetupWithManager(ctx, mgr, concurrency(machineDeploymentConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineDeployment")
 		os.Exit(1)
@@ -523,10 +656,12 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 
 	if feature.Gates.Enabled(feature.MachinePool) {
 		if err := (&expcontrollers.MachinePoolReconciler{
-			Client:           mgr.GetClient(),
-			APIReader:        mgr.GetAPIReader(),
-			Tracker:          tracker,
-			WatchFilterValue: watchFilterValue,
+			Client:                 mgr.GetClient(),
+			APIReader:              mgr.GetAPIReader(),
+			Tracker:                tracker,
+			WatchFilterValue:       watchFilterValue,
+			Shard:                  shard,
+			NodeDrainClientTimeout: nodeDrainClientTimeout,
 		}).SetupWithManager(ctx, mgr, concurrency(machinePoolConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "MachinePool")
 			os.Exit(1)
@@ -538,6 +673,7 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 			Client:           mgr.GetClient(),
 			Tracker:          tracker,
 			WatchFilterValue: watchFilterValue,
+			Shard:            shard,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterResourceSetConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterResourceSet")
 			os.Exit(1)
@@ -545,16 +681,29 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		if err := (&addonscontrollers.ClusterResourceSetBindingReconciler{
 			Client:           mgr.GetClient(),
 			WatchFilterValue: watchFilterValue,
+			Shard:            shard,
 		}).SetupWithManager(ctx, mgr, concurrency(clusterResourceSetConcurrency)); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ClusterResourceSetBinding")
 			os.Exit(1)
 		}
 	}
 
+	if feature.Gates.Enabled(feature.ClusterGroup) {
+		if err := (&expcontrollers.ClusterGroupReconciler{
+			Client:           mgr.GetClient(),
+			WatchFilterValue: watchFilterValue,
+			Shard:            shard,
+		}).SetupWithManager(ctx, mgr, concurrency(clusterGroupConcurrency)); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ClusterGroup")
+			os.Exit(1)
+		}
+	}
+
 	if err := (&controllers.MachineHealthCheckReconciler{
 		Client:           mgr.GetClient(),
 		Tracker:          tracker,
 		WatchFilterValue: watchFilterValue,
+		Shard:            shard,
 	}).SetupWithManager(ctx, mgr, concurrency(machineHealthCheckConcurrency)); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "MachineHealthCheck")
 		os.Exit(1)
@@ -571,12 +720,12 @@ func setupWebhooks(mgr ctrl.Manager) {
 
 	// NOTE: ClusterClass and managed topologies are behind ClusterTopology feature gate flag; the webhook
 	// is going to prevent usage of Cluster.Topology in case the feature flag is disabled.
-	if err := (&webhooks.Cluster{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.Cluster{Client: mgr.GetClient(), SubjectAccessReviewClient: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Cluster")
 		os.Exit(1)
 	}
 
-	if err := (&webhooks.Machine{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.Machine{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "Machine")
 		os.Exit(1)
 	}
@@ -586,7 +735,7 @@ func setupWebhooks(mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
-	if err := (&webhooks.MachineDeployment{}).SetupWebhookWithManager(mgr); err != nil {
+	if err := (&webhooks.MachineDeployment{Client: mgr.GetClient()}).SetupWebhookWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create webhook", "webhook", "MachineDeployment")
 		os.Exit(1)
 	}
@@ -639,3 +788,17 @@ func setupWebhooks(mgr ctrl.Manager) {
 func concurrency(c int) controller.Options {
 	return controller.Options{MaxConcurrentReconciles: c}
 }
+
+// machineStuckInPhaseThresholds returns the thresholds after which a Machine is reported as stuck in its current phase.
+func machineStuckInPhaseThresholds() map[clusterv1.MachinePhase]time.Duration {
+	thresholds := map[clusterv1.MachinePhase]time.Duration{}
+	if machineProvisioningThreshold > 0 {
+		thresholds[clusterv1.MachinePhasePending] = machineProvisioningThreshold
+		thresholds[clusterv1.MachinePhaseProvisioning] = machineProvisioningThreshold
+		thresholds[clusterv1.MachinePhaseProvisioned] = machineProvisioningThreshold
+	}
+	if machineDeletingThreshold > 0 {
+		thresholds[clusterv1.MachinePhaseDeleting] = machineDeletingThreshold
+	}
+	return thresholds
+}
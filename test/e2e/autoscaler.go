@@ -100,7 +100,7 @@ func AutoscalerSpec(ctx context.Context, inputGetter func() AutoscalerSpecInput)
 			flavor = *input.Flavor
 		}
 
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -62,6 +62,10 @@ type ClusterUpgradeConformanceSpecInput struct {
 
 	// Flavor to use when creating the cluster for testing, "upgrades" is used if not specified.
 	Flavor *string
+
+	// ConformanceFocusSets is an optional list of conformance subsets to run in parallel against the upgraded cluster,
+	// instead of the single run defined by the kubetest configuration.
+	ConformanceFocusSets []kubetest.FocusSet
 }
 
 // ClusterUpgradeConformanceSpec implements a spec that upgrades a cluster and runs the Kubernetes conformance suite.
@@ -125,7 +129,7 @@ func ClusterUpgradeConformanceSpec(ctx context.Context, inputGetter func() Clust
 	It("Should create and upgrade a workload cluster and eventually run kubetest", func() {
 		By("Creating a workload cluster")
 
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -244,6 +248,7 @@ func ClusterUpgradeConformanceSpec(ctx context.Context, inputGetter func() Clust
 					ArtifactsDirectory: input.ArtifactFolder,
 					ConfigFilePath:     kubetestConfigFilePath,
 					GinkgoNodes:        int(clusterResources.ExpectedWorkerNodes()),
+					FocusSets:          input.ConformanceFocusSets,
 				},
 			)
 			Expect(err).ToNot(HaveOccurred(), "Failed to run Kubernetes conformance")
@@ -157,7 +157,7 @@ func clusterUpgradeWithRuntimeSDKSpec(ctx context.Context, inputGetter func() cl
 			Namespace: namespace.Name,
 		}
 
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
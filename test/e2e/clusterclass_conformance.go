@@ -0,0 +1,201 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/cluster-api/test/framework"
+	"sigs.k8s.io/cluster-api/test/framework/clusterctl"
+	"sigs.k8s.io/cluster-api/util"
+)
+
+// ClusterClassConformanceSpecInput is the input for ClusterClassConformanceSpec.
+type ClusterClassConformanceSpecInput struct {
+	E2EConfig             *clusterctl.E2EConfig
+	ClusterctlConfigPath  string
+	BootstrapClusterProxy framework.ClusterProxy
+	ArtifactFolder        string
+	SkipCleanup           bool
+	ControlPlaneWaiters   clusterctl.ControlPlaneWaiters
+
+	// InfrastructureProviders specifies the infrastructure to use for clusterctl
+	// operations (Example: get cluster templates).
+	// Note: In most cases this need not be specified. It only needs to be specified when
+	// multiple infrastructure providers (ex: CAPD + in-memory) are installed on the cluster as clusterctl will not be
+	// able to identify the default.
+	InfrastructureProvider *string
+
+	// Flavor is the template flavor used to create the cluster for testing; the template must create a Cluster
+	// using the provider's ClusterClass, and the ClusterClass must define a MachineHealthCheck for the MachineDeployments
+	// matching the e2e.remediation.condition condition.
+	// If not specified, "topology" is used.
+	Flavor *string
+
+	// SkipUpgrade skips the upgrade of the Cluster topology.
+	// If false, the KUBERNETES_VERSION_UPGRADE_FROM, KUBERNETES_VERSION_UPGRADE_TO, ETCD_VERSION_UPGRADE_TO and
+	// COREDNS_VERSION_UPGRADE_TO variables are required.
+	SkipUpgrade bool
+
+	// SkipRemediation skips the MachineHealthCheck remediation of the MachineDeployment Machines.
+	SkipRemediation bool
+}
+
+// ClusterClassConformanceSpec implements a spec that exercises the Cluster API contract for a Cluster using a provider's ClusterClass,
+// that is creating a Cluster, scaling its MachineDeployment topology, upgrading it, remediating an unhealthy Machine and deleting it.
+// Providers can use this spec to validate their ClusterClass support.
+// NOTE: The upgrade only works with Clusters using a KubeadmControlPlane.
+func ClusterClassConformanceSpec(ctx context.Context, inputGetter func() ClusterClassConformanceSpecInput) {
+	var (
+		specName         = "clusterclass-conformance"
+		input            ClusterClassConformanceSpecInput
+		namespace        *corev1.Namespace
+		cancelWatches    context.CancelFunc
+		clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult
+	)
+
+	BeforeEach(func() {
+		Expect(ctx).NotTo(BeNil(), "ctx is required for %s spec", specName)
+		input = inputGetter()
+		Expect(input.E2EConfig).ToNot(BeNil(), "Invalid argument. input.E2EConfig can't be nil when calling %s spec", specName)
+		Expect(input.ClusterctlConfigPath).To(BeAnExistingFile(), "Invalid argument. input.ClusterctlConfigPath must be an existing file when calling %s spec", specName)
+		Expect(input.BootstrapClusterProxy).ToNot(BeNil(), "Invalid argument. input.BootstrapClusterProxy can't be nil when calling %s spec", specName)
+		Expect(os.MkdirAll(input.ArtifactFolder, 0750)).To(Succeed(), "Invalid argument. input.ArtifactFolder can't be created for %s spec", specName)
+
+		if input.SkipUpgrade {
+			Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersion))
+		} else {
+			Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersionUpgradeFrom))
+			Expect(input.E2EConfig.Variables).To(HaveKey(KubernetesVersionUpgradeTo))
+			Expect(input.E2EConfig.Variables).To(HaveKey(EtcdVersionUpgradeTo))
+			Expect(input.E2EConfig.Variables).To(HaveKey(CoreDNSVersionUpgradeTo))
+		}
+
+		// Setup a Namespace where to host objects for this spec and create a watcher for the namespace events.
+		namespace, cancelWatches = setupSpecNamespace(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder)
+		clusterResources = new(clusterctl.ApplyClusterTemplateAndWaitResult)
+	})
+
+	It("Should create, scale, upgrade, remediate and delete a Cluster using the ClusterClass", func() {
+		By("Creating a workload cluster")
+
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
+		if input.InfrastructureProvider != nil {
+			infrastructureProvider = *input.InfrastructureProvider
+		}
+
+		kubernetesVersion := input.E2EConfig.GetVariable(KubernetesVersion)
+		if !input.SkipUpgrade {
+			kubernetesVersion = input.E2EConfig.GetVariable(KubernetesVersionUpgradeFrom)
+		}
+
+		clusterctl.ApplyClusterTemplateAndWait(ctx, clusterctl.ApplyClusterTemplateAndWaitInput{
+			ClusterProxy: input.BootstrapClusterProxy,
+			ConfigCluster: clusterctl.ConfigClusterInput{
+				LogFolder:                filepath.Join(input.ArtifactFolder, "clusters", input.BootstrapClusterProxy.GetName()),
+				ClusterctlConfigPath:     input.ClusterctlConfigPath,
+				KubeconfigPath:           input.BootstrapClusterProxy.GetKubeconfigPath(),
+				InfrastructureProvider:   infrastructureProvider,
+				Flavor:                   pointer.StringDeref(input.Flavor, "topology"),
+				Namespace:                namespace.Name,
+				ClusterName:              fmt.Sprintf("%s-%s", specName, util.RandomString(6)),
+				KubernetesVersion:        kubernetesVersion,
+				ControlPlaneMachineCount: pointer.Int64(1),
+				WorkerMachineCount:       pointer.Int64(1),
+			},
+			ControlPlaneWaiters:          input.ControlPlaneWaiters,
+			WaitForClusterIntervals:      input.E2EConfig.GetIntervals(specName, "wait-cluster"),
+			WaitForControlPlaneIntervals: input.E2EConfig.GetIntervals(specName, "wait-control-plane"),
+			WaitForMachineDeployments:    input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
+			WaitForMachinePools:          input.E2EConfig.GetIntervals(specName, "wait-machine-pool-nodes"),
+		}, clusterResources)
+
+		By("Checking the Cluster uses a ClusterClass")
+		Expect(clusterResources.Cluster.Spec.Topology).ToNot(BeNil(), "Cluster %s must use a ClusterClass when calling %s spec", klog.KObj(clusterResources.Cluster), specName)
+		Expect(clusterResources.Cluster.Spec.Topology.Workers).ToNot(BeNil(), "Cluster %s must have MachineDeployment topologies when calling %s spec", klog.KObj(clusterResources.Cluster), specName)
+		Expect(clusterResources.Cluster.Spec.Topology.Workers.MachineDeployments).ToNot(BeEmpty(), "Cluster %s must have MachineDeployment topologies when calling %s spec", klog.KObj(clusterResources.Cluster), specName)
+		framework.GetClusterClassByName(ctx, framework.GetClusterClassByNameInput{
+			Getter:    input.BootstrapClusterProxy.GetClient(),
+			Name:      clusterResources.Cluster.Spec.Topology.Class,
+			Namespace: clusterResources.Cluster.Namespace,
+		})
+
+		By("Scaling the MachineDeployment topology out to 2")
+		scaleMachineDeploymentAndWait(ctx, input.BootstrapClusterProxy, clusterResources, 2, input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"))
+		By("Scaling the MachineDeployment topology down to 1")
+		scaleMachineDeploymentAndWait(ctx, input.BootstrapClusterProxy, clusterResources, 1, input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"))
+
+		if !input.SkipUpgrade {
+			By("Upgrading the Cluster topology")
+			framework.UpgradeClusterTopologyAndWaitForUpgrade(ctx, framework.UpgradeClusterTopologyAndWaitForUpgradeInput{
+				ClusterProxy:                   input.BootstrapClusterProxy,
+				Cluster:                        clusterResources.Cluster,
+				ControlPlane:                   clusterResources.ControlPlane,
+				EtcdImageTag:                   input.E2EConfig.GetVariable(EtcdVersionUpgradeTo),
+				DNSImageTag:                    input.E2EConfig.GetVariable(CoreDNSVersionUpgradeTo),
+				MachineDeployments:             clusterResources.MachineDeployments,
+				MachinePools:                   clusterResources.MachinePools,
+				KubernetesUpgradeVersion:       input.E2EConfig.GetVariable(KubernetesVersionUpgradeTo),
+				WaitForMachinesToBeUpgraded:    input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
+				WaitForMachinePoolToBeUpgraded: input.E2EConfig.GetIntervals(specName, "wait-machine-pool-upgrade"),
+				WaitForKubeProxyUpgrade:        input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
+				WaitForDNSUpgrade:              input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
+				WaitForEtcdUpgrade:             input.E2EConfig.GetIntervals(specName, "wait-machine-upgrade"),
+			})
+
+			By("Waiting until nodes are ready")
+			workloadProxy := input.BootstrapClusterProxy.GetWorkloadCluster(ctx, namespace.Name, clusterResources.Cluster.Name)
+			framework.WaitForNodesReady(ctx, framework.WaitForNodesReadyInput{
+				Lister:            workloadProxy.GetClient(),
+				KubernetesVersion: input.E2EConfig.GetVariable(KubernetesVersionUpgradeTo),
+				Count:             int(clusterResources.ExpectedTotalNodes()),
+				WaitForNodesReady: input.E2EConfig.GetIntervals(specName, "wait-nodes-ready"),
+			})
+		}
+
+		if !input.SkipRemediation {
+			By("Setting a machine unhealthy and wait for MachineDeployment remediation")
+			framework.DiscoverMachineHealthChecksAndWaitForRemediation(ctx, framework.DiscoverMachineHealthCheckAndWaitForRemediationInput{
+				ClusterProxy:              input.BootstrapClusterProxy,
+				Cluster:                   clusterResources.Cluster,
+				WaitForMachineRemediation: input.E2EConfig.GetIntervals(specName, "wait-machine-remediation"),
+			})
+		}
+
+		By("Deleting the workload cluster")
+		framework.DeleteClusterAndWait(ctx, framework.DeleteClusterAndWaitInput{
+			Client:  input.BootstrapClusterProxy.GetClient(),
+			Cluster: clusterResources.Cluster,
+		}, input.E2EConfig.GetIntervals(specName, "wait-delete-cluster")...)
+
+		By("PASSED!")
+	})
+
+	AfterEach(func() {
+		// Dumps all the resources in the spec namespace, then cleanups the cluster object and the spec namespace itself.
+		dumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, namespace, cancelWatches, clusterResources.Cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
+	})
+}
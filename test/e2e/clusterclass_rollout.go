@@ -114,7 +114,7 @@ func ClusterClassRolloutSpec(ctx context.Context, inputGetter func() ClusterClas
 
 	It("Should successfully rollout the managed topology upon changes to the ClusterClass", func() {
 		By("Creating a workload cluster")
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -126,6 +126,29 @@ type ClusterctlUpgradeSpecInput struct {
 	IPAMProviders             []string
 	RuntimeExtensionProviders []string
 	AddonProviders            []string
+	// Upgrades can be used to upgrade the management cluster through a sequence of version hops, e.g. from the
+	// oldest supported release to an intermediate release and then to the latest version; the spec checks that
+	// the workload cluster created with the oldest release is not rolled out after every hop.
+	// If not set, the management cluster is upgraded once, using the custom providers above or, if none is set,
+	// the latest version available for the current contract.
+	Upgrades []ClusterctlUpgradeSpecInputUpgrade
+}
+
+// ClusterctlUpgradeSpecInputUpgrade defines a version hop of ClusterctlUpgradeSpec.
+// Either Contract or at least one of the custom providers must be set.
+type ClusterctlUpgradeSpecInputUpgrade struct {
+	// Contract is the contract to upgrade to, using the latest version available for the contract, e.g. `v1beta1`.
+	Contract string
+	// Custom providers can be specified to upgrade to a specific version, e.g. `cluster-api:v1.5.0`.
+	CoreProvider              string
+	BootstrapProviders        []string
+	ControlPlaneProviders     []string
+	InfrastructureProviders   []string
+	IPAMProviders             []string
+	RuntimeExtensionProviders []string
+	AddonProviders            []string
+	// PostUpgrade is called after the hop, before checking that the workload cluster is not rolled out.
+	PostUpgrade func(managementClusterProxy framework.ClusterProxy, clusterNamespace, clusterName string)
 }
 
 // ClusterctlUpgradeSpec implements a test that verifies clusterctl upgrade of a management cluster.
@@ -137,6 +160,9 @@ type ClusterctlUpgradeSpecInput struct {
 // workload cluster (henceforth called secondary workload cluster) from the new management cluster using the default cluster template of the old release
 // then run clusterctl upgrade to the latest version of Cluster API and ensure correct operation by
 // scaling a MachineDeployment.
+// The upgrade can also go through multiple version hops, e.g. through intermediate releases and contract versions,
+// by setting ClusterctlUpgradeSpecInput.Upgrades; the spec checks that the secondary workload cluster is not rolled
+// out after every hop.
 //
 // To use this spec the variables INIT_WITH_BINARY and INIT_WITH_PROVIDERS_CONTRACT must be set or specified directly
 // in the spec input. See ClusterctlUpgradeSpecInput for further information.
@@ -224,7 +250,7 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 		By("Creating a workload cluster to be used as a new management cluster")
 		// NOTE: given that the bootstrap cluster could be shared by several tests, it is not practical to use it for testing clusterctl upgrades.
 		// So we are creating a workload cluster that will be used as a new management cluster where to install older version of providers
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -335,11 +361,6 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 
 		By("THE MANAGEMENT CLUSTER WITH THE OLDER VERSION OF PROVIDERS IS UP&RUNNING!")
 
-		machineCRD := &apiextensionsv1.CustomResourceDefinition{}
-		if err := managementClusterProxy.GetClient().Get(ctx, client.ObjectKey{Name: "machines.cluster.x-k8s.io"}, machineCRD); err != nil {
-			Expect(err).ToNot(HaveOccurred(), "failed to retrieve a machine CRD")
-		}
-
 		Byf("Creating a namespace for hosting the %s test workload cluster", specName)
 		testNamespace, testCancelWatches = framework.CreateNamespaceAndWatchEvents(ctx, framework.CreateNamespaceAndWatchEventsInput{
 			Creator:   managementClusterProxy.GetClient(),
@@ -394,18 +415,7 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 		}
 
 		// Build GroupVersionKind for Machine resources
-		machineListGVK := schema.GroupVersionKind{
-			Group: machineCRD.Spec.Group,
-			Kind:  machineCRD.Spec.Names.ListKind,
-		}
-
-		// Pick the storage version
-		for _, version := range machineCRD.Spec.Versions {
-			if version.Storage {
-				machineListGVK.Version = version.Name
-				break
-			}
-		}
+		machineListGVK := getMachineListGVK(ctx, managementClusterProxy)
 
 		By("Waiting for the machines to exist")
 		Eventually(func() (int64, error) {
@@ -446,21 +456,9 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 			client.MatchingLabels{clusterv1.ClusterNameLabel: workLoadClusterName},
 		)
 		Expect(err).ToNot(HaveOccurred())
-		// Check if the user want a custom upgrade
-		isCustomUpgrade := input.CoreProvider != "" ||
-			len(input.BootstrapProviders) > 0 ||
-			len(input.ControlPlaneProviders) > 0 ||
-			len(input.InfrastructureProviders) > 0 ||
-			len(input.IPAMProviders) > 0 ||
-			len(input.RuntimeExtensionProviders) > 0 ||
-			len(input.AddonProviders) > 0
-
-		if isCustomUpgrade {
-			By("Upgrading providers to custom versions")
-			clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
-				ClusterctlConfigPath:      input.ClusterctlConfigPath,
-				ClusterctlVariables:       input.UpgradeClusterctlVariables,
-				ClusterProxy:              managementClusterProxy,
+		upgrades := input.Upgrades
+		if len(upgrades) == 0 {
+			upgrades = []ClusterctlUpgradeSpecInputUpgrade{{
 				CoreProvider:              input.CoreProvider,
 				BootstrapProviders:        input.BootstrapProviders,
 				ControlPlaneProviders:     input.ControlPlaneProviders,
@@ -468,40 +466,78 @@ func ClusterctlUpgradeSpec(ctx context.Context, inputGetter func() ClusterctlUpg
 				IPAMProviders:             input.IPAMProviders,
 				RuntimeExtensionProviders: input.RuntimeExtensionProviders,
 				AddonProviders:            input.AddonProviders,
-				LogFolder:                 filepath.Join(input.ArtifactFolder, "clusters", cluster.Name),
-			}, input.E2EConfig.GetIntervals(specName, "wait-controllers")...)
-		} else {
-			By("Upgrading providers to the latest version available")
-			clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
-				ClusterctlConfigPath: input.ClusterctlConfigPath,
-				ClusterctlVariables:  input.UpgradeClusterctlVariables,
-				ClusterProxy:         managementClusterProxy,
-				Contract:             clusterv1.GroupVersion.Version,
-				LogFolder:            filepath.Join(input.ArtifactFolder, "clusters", cluster.Name),
-			}, input.E2EConfig.GetIntervals(specName, "wait-controllers")...)
+			}}
 		}
 
-		By("THE MANAGEMENT CLUSTER WAS SUCCESSFULLY UPGRADED!")
+		for i, upgrade := range upgrades {
+			// Check if the user want a custom upgrade
+			isCustomUpgrade := upgrade.CoreProvider != "" ||
+				len(upgrade.BootstrapProviders) > 0 ||
+				len(upgrade.ControlPlaneProviders) > 0 ||
+				len(upgrade.InfrastructureProviders) > 0 ||
+				len(upgrade.IPAMProviders) > 0 ||
+				len(upgrade.RuntimeExtensionProviders) > 0 ||
+				len(upgrade.AddonProviders) > 0
+
+			if isCustomUpgrade {
+				Byf("[%d] Upgrading providers to custom versions", i)
+				clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
+					ClusterctlConfigPath:      input.ClusterctlConfigPath,
+					ClusterctlVariables:       input.UpgradeClusterctlVariables,
+					ClusterProxy:              managementClusterProxy,
+					CoreProvider:              upgrade.CoreProvider,
+					BootstrapProviders:        upgrade.BootstrapProviders,
+					ControlPlaneProviders:     upgrade.ControlPlaneProviders,
+					InfrastructureProviders:   upgrade.InfrastructureProviders,
+					IPAMProviders:             upgrade.IPAMProviders,
+					RuntimeExtensionProviders: upgrade.RuntimeExtensionProviders,
+					AddonProviders:            upgrade.AddonProviders,
+					LogFolder:                 filepath.Join(input.ArtifactFolder, "clusters", cluster.Name),
+				}, input.E2EConfig.GetIntervals(specName, "wait-controllers")...)
+			} else {
+				contract := upgrade.Contract
+				if contract == "" {
+					contract = clusterv1.GroupVersion.Version
+				}
+				Byf("[%d] Upgrading providers to the latest version available for the %s contract", i, contract)
+				clusterctl.UpgradeManagementClusterAndWait(ctx, clusterctl.UpgradeManagementClusterAndWaitInput{
+					ClusterctlConfigPath: input.ClusterctlConfigPath,
+					ClusterctlVariables:  input.UpgradeClusterctlVariables,
+					ClusterProxy:         managementClusterProxy,
+					Contract:             contract,
+					LogFolder:            filepath.Join(input.ArtifactFolder, "clusters", cluster.Name),
+				}, input.E2EConfig.GetIntervals(specName, "wait-controllers")...)
+			}
 
-		if input.PostUpgrade != nil {
-			By("Running Post-upgrade steps against the management cluster")
-			input.PostUpgrade(managementClusterProxy, testNamespace.Name, managementClusterName)
-		}
+			Byf("[%d] THE MANAGEMENT CLUSTER WAS SUCCESSFULLY UPGRADED!", i)
 
-		// After the upgrade check that there were no unexpected rollouts.
-		log.Logf("Verify there are no unexpected rollouts")
-		Consistently(func() bool {
-			postUpgradeMachineList := &unstructured.UnstructuredList{}
-			postUpgradeMachineList.SetGroupVersionKind(clusterv1.GroupVersion.WithKind("MachineList"))
-			err = managementClusterProxy.GetClient().List(
-				ctx,
-				postUpgradeMachineList,
-				client.InNamespace(testNamespace.Name),
-				client.MatchingLabels{clusterv1.ClusterNameLabel: workLoadClusterName},
-			)
-			Expect(err).ToNot(HaveOccurred())
-			return validateMachineRollout(preUpgradeMachineList, postUpgradeMachineList)
-		}, "3m", "30s").Should(BeTrue(), "Machines should remain the same after the upgrade")
+			if upgrade.PostUpgrade != nil {
+				Byf("[%d] Running Post-upgrade steps against the management cluster", i)
+				upgrade.PostUpgrade(managementClusterProxy, testNamespace.Name, managementClusterName)
+			}
+			if input.PostUpgrade != nil && i == len(upgrades)-1 {
+				By("Running Post-upgrade steps against the management cluster")
+				input.PostUpgrade(managementClusterProxy, testNamespace.Name, managementClusterName)
+			}
+
+			// After each upgrade check that there were no unexpected rollouts.
+			// NOTE: the Machines are read using the storage version of the Machine CRD after the upgrade,
+			// given that intermediate hops could install versions of Cluster API not serving the current API version.
+			log.Logf("Verify there are no unexpected rollouts")
+			postUpgradeMachineListGVK := getMachineListGVK(ctx, managementClusterProxy)
+			Consistently(func() bool {
+				postUpgradeMachineList := &unstructured.UnstructuredList{}
+				postUpgradeMachineList.SetGroupVersionKind(postUpgradeMachineListGVK)
+				err = managementClusterProxy.GetClient().List(
+					ctx,
+					postUpgradeMachineList,
+					client.InNamespace(testNamespace.Name),
+					client.MatchingLabels{clusterv1.ClusterNameLabel: workLoadClusterName},
+				)
+				Expect(err).ToNot(HaveOccurred())
+				return validateMachineRollout(preUpgradeMachineList, postUpgradeMachineList)
+			}, "3m", "30s").Should(BeTrue(), "Machines should remain the same after the upgrade")
+		}
 
 		// After upgrading we are sure the version is the latest version of the API,
 		// so it is possible to use the standard helpers
@@ -736,6 +772,28 @@ func names(objs *unstructured.UnstructuredList) sets.Set[string] {
 	return ret
 }
 
+// getMachineListGVK returns the GroupVersionKind of the Machine list for the storage version of the Machine CRD.
+func getMachineListGVK(ctx context.Context, managementClusterProxy framework.ClusterProxy) schema.GroupVersionKind {
+	machineCRD := &apiextensionsv1.CustomResourceDefinition{}
+	if err := managementClusterProxy.GetClient().Get(ctx, client.ObjectKey{Name: "machines.cluster.x-k8s.io"}, machineCRD); err != nil {
+		Expect(err).ToNot(HaveOccurred(), "failed to retrieve a machine CRD")
+	}
+
+	machineListGVK := schema.GroupVersionKind{
+		Group: machineCRD.Spec.Group,
+		Kind:  machineCRD.Spec.Names.ListKind,
+	}
+
+	// Pick the storage version
+	for _, version := range machineCRD.Spec.Versions {
+		if version.Storage {
+			machineListGVK.Version = version.Name
+			break
+		}
+	}
+	return machineListGVK
+}
+
 // getValueOrFallback returns the input value unless it is empty, then it returns the fallback input.
 func getValueOrFallback(value []string, fallback []string) []string {
 	if value != nil {
@@ -144,3 +144,40 @@ var _ = Describe("When testing clusterctl upgrades using ClusterClass (v1.5=>cur
 		}
 	})
 })
+
+var _ = Describe("When testing clusterctl upgrades (v1.4=>v1.5=>current)", func() {
+	ClusterctlUpgradeSpec(ctx, func() ClusterctlUpgradeSpecInput {
+		return ClusterctlUpgradeSpecInput{
+			E2EConfig:              e2eConfig,
+			ClusterctlConfigPath:   clusterctlConfigPath,
+			BootstrapClusterProxy:  bootstrapClusterProxy,
+			ArtifactFolder:         artifactFolder,
+			SkipCleanup:            skipCleanup,
+			InfrastructureProvider: pointer.String("docker"),
+			InitWithBinary:         "https://github.com/kubernetes-sigs/cluster-api/releases/download/v1.4.5/clusterctl-{OS}-{ARCH}",
+			// We have to pin the providers because with `InitWithProvidersContract` the test would
+			// use the latest version for the contract (which is v1.5.X for v1beta1).
+			InitWithCoreProvider:            "cluster-api:v1.4.5",
+			InitWithBootstrapProviders:      []string{"kubeadm:v1.4.5"},
+			InitWithControlPlaneProviders:   []string{"kubeadm:v1.4.5"},
+			InitWithInfrastructureProviders: []string{"docker:v1.4.5"},
+			InitWithProvidersContract:       "v1beta1",
+			// NOTE: If this version is changed here the image and SHA must also be updated in all DockerMachineTemplates in `test/e2e/data/infrastructure-docker/v1.4/bases.
+			InitWithKubernetesVersion: "v1.27.3",
+			WorkloadKubernetesVersion: "v1.27.3",
+			MgmtFlavor:                "topology",
+			WorkloadFlavor:            "",
+			Upgrades: []ClusterctlUpgradeSpecInputUpgrade{
+				{
+					CoreProvider:            "cluster-api:v1.5.0",
+					BootstrapProviders:      []string{"kubeadm:v1.5.0"},
+					ControlPlaneProviders:   []string{"kubeadm:v1.5.0"},
+					InfrastructureProviders: []string{"docker:v1.5.0"},
+				},
+				{
+					Contract: "v1beta1",
+				},
+			},
+		}
+	})
+})
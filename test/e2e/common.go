@@ -30,6 +30,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/e2e/internal/log"
 	"sigs.k8s.io/cluster-api/test/framework"
 	"sigs.k8s.io/cluster-api/util"
 )
@@ -62,7 +63,31 @@ func setupSpecNamespace(ctx context.Context, specName string, clusterProxy frame
 		LogFolder: filepath.Join(artifactFolder, "clusters", clusterProxy.GetName()),
 	})
 
-	return namespace, cancelWatches
+	// Record the timeline of the spec in the namespace; the timeline is written to the artifacts,
+	// together with the steps of the spec, when the watches are cancelled.
+	timeline := framework.NewTimeline()
+	timelineCtx, cancelTimeline := context.WithCancel(ctx)
+	timelineDone := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(timelineDone)
+		framework.WatchTimeline(timelineCtx, framework.WatchTimelineInput{
+			Lister:    clusterProxy.GetClient(),
+			Namespace: namespace.Name,
+			Timeline:  timeline,
+		})
+	}()
+
+	return namespace, func() {
+		cancelTimeline()
+		<-timelineDone
+		cancelWatches()
+
+		timeline.MarkSpecEvents(CurrentSpecReport())
+		if err := timeline.WriteArtifacts(filepath.Join(artifactFolder, "clusters", clusterProxy.GetName(), "resources", namespace.Name)); err != nil {
+			log.Logf("Failed to write the timeline of the %q test spec: %v", specName, err)
+		}
+	}
 }
 
 // dumpAllResources dumps all the resources in the spec namespace and the workload cluster.
@@ -90,7 +90,7 @@ func K8SConformanceSpec(ctx context.Context, inputGetter func() K8SConformanceSp
 	It("Should create a workload cluster and run kubetest", func() {
 		By("Creating a workload cluster")
 
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
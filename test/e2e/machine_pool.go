@@ -84,7 +84,7 @@ func MachinePoolSpec(ctx context.Context, inputGetter func() MachinePoolInput) {
 	It("Should successfully create a cluster with machine pool machines", func() {
 		By("Creating a workload cluster")
 		workerMachineCount := int32(2)
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -83,7 +83,7 @@ func MachineDeploymentRemediationSpec(ctx context.Context, inputGetter func() Ma
 	It("Should replace unhealthy machines", func() {
 		By("Creating a workload cluster")
 
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -77,7 +77,7 @@ func MachineDeploymentRolloutSpec(ctx context.Context, inputGetter func() Machin
 
 	It("Should successfully upgrade Machines upon changes in relevant MachineDeployment fields", func() {
 		By("Creating a workload cluster")
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -77,7 +77,7 @@ func MachineDeploymentScaleSpec(ctx context.Context, inputGetter func() MachineD
 
 	It("Should successfully scale a MachineDeployment up and down upon changes to the MachineDeployment replica count", func() {
 		By("Creating a workload cluster")
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -104,21 +104,9 @@ func MachineDeploymentScaleSpec(ctx context.Context, inputGetter func() MachineD
 		Expect(clusterResources.MachineDeployments[0].Spec.Replicas).To(Equal(pointer.Int32(1)))
 
 		By("Scaling the MachineDeployment out to 3")
-		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
-			ClusterProxy:              input.BootstrapClusterProxy,
-			Cluster:                   clusterResources.Cluster,
-			MachineDeployment:         clusterResources.MachineDeployments[0],
-			Replicas:                  3,
-			WaitForMachineDeployments: input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
-		})
+		scaleMachineDeploymentAndWait(ctx, input.BootstrapClusterProxy, clusterResources, 3, input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"))
 		By("Scaling the MachineDeployment down to 1")
-		framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
-			ClusterProxy:              input.BootstrapClusterProxy,
-			Cluster:                   clusterResources.Cluster,
-			MachineDeployment:         clusterResources.MachineDeployments[0],
-			Replicas:                  1,
-			WaitForMachineDeployments: input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"),
-		})
+		scaleMachineDeploymentAndWait(ctx, input.BootstrapClusterProxy, clusterResources, 1, input.E2EConfig.GetIntervals(specName, "wait-worker-nodes"))
 		By("PASSED!")
 	})
 
@@ -127,3 +115,26 @@ func MachineDeploymentScaleSpec(ctx context.Context, inputGetter func() MachineD
 		dumpSpecResourcesAndCleanup(ctx, specName, input.BootstrapClusterProxy, input.ArtifactFolder, namespace, cancelWatches, clusterResources.Cluster, input.E2EConfig.GetIntervals, input.SkipCleanup)
 	})
 }
+
+// scaleMachineDeploymentAndWait scales the first MachineDeployment of a Cluster and waits for the scale operation to complete.
+// NOTE: When the Cluster is using ClusterClass the MachineDeployment is scaled via the Cluster topology, otherwise
+// the topology controller would revert the change.
+func scaleMachineDeploymentAndWait(ctx context.Context, clusterProxy framework.ClusterProxy, clusterResources *clusterctl.ApplyClusterTemplateAndWaitResult, replicas int32, intervals []interface{}) {
+	if clusterResources.Cluster.Spec.Topology != nil {
+		framework.ScaleAndWaitMachineDeploymentTopology(ctx, framework.ScaleAndWaitMachineDeploymentTopologyInput{
+			ClusterProxy:              clusterProxy,
+			Cluster:                   clusterResources.Cluster,
+			Replicas:                  replicas,
+			WaitForMachineDeployments: intervals,
+		})
+		return
+	}
+
+	framework.ScaleAndWaitMachineDeployment(ctx, framework.ScaleAndWaitMachineDeploymentInput{
+		ClusterProxy:              clusterProxy,
+		Cluster:                   clusterResources.Cluster,
+		MachineDeployment:         clusterResources.MachineDeployments[0],
+		Replicas:                  replicas,
+		WaitForMachineDeployments: intervals,
+	})
+}
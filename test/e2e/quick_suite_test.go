@@ -0,0 +1,73 @@
+//go:build e2e
+// +build e2e
+
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package e2e
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	"k8s.io/utils/pointer"
+)
+
+// The quick suite is a curated set of specs covering the core workflows of Cluster API (create, scale,
+// MachineHealthCheck remediation and delete); the specs run using the in-memory provider by default, so the
+// whole suite completes in few minutes and can be used as a PR-blocking signal.
+// NOTE: Upgrades are not part of the quick suite, because the ClusterClass upgrade specs require MachinePools,
+// which are not supported by the in-memory provider.
+// NOTE: The infrastructure provider can be changed by setting the INFRASTRUCTURE_PROVIDER variable.
+const quickSuiteDefaultInfrastructureProvider = "in-memory"
+
+var _ = Describe("When following the Cluster API quick-start [QuickSuite]", func() {
+	QuickStartSpec(ctx, func() QuickStartSpecInput {
+		return QuickStartSpecInput{
+			E2EConfig:              e2eConfig,
+			ClusterctlConfigPath:   clusterctlConfigPath,
+			BootstrapClusterProxy:  bootstrapClusterProxy,
+			ArtifactFolder:         artifactFolder,
+			SkipCleanup:            skipCleanup,
+			InfrastructureProvider: pointer.String(e2eConfig.GetInfrastructureProvider(quickSuiteDefaultInfrastructureProvider)),
+		}
+	})
+})
+
+var _ = Describe("When testing MachineDeployment scale out/in [QuickSuite]", func() {
+	MachineDeploymentScaleSpec(ctx, func() MachineDeploymentScaleSpecInput {
+		return MachineDeploymentScaleSpecInput{
+			E2EConfig:              e2eConfig,
+			ClusterctlConfigPath:   clusterctlConfigPath,
+			BootstrapClusterProxy:  bootstrapClusterProxy,
+			ArtifactFolder:         artifactFolder,
+			SkipCleanup:            skipCleanup,
+			InfrastructureProvider: pointer.String(e2eConfig.GetInfrastructureProvider(quickSuiteDefaultInfrastructureProvider)),
+		}
+	})
+})
+
+var _ = Describe("When testing MachineDeployment remediation [QuickSuite]", func() {
+	MachineDeploymentRemediationSpec(ctx, func() MachineDeploymentRemediationSpecInput {
+		return MachineDeploymentRemediationSpecInput{
+			E2EConfig:              e2eConfig,
+			ClusterctlConfigPath:   clusterctlConfigPath,
+			BootstrapClusterProxy:  bootstrapClusterProxy,
+			ArtifactFolder:         artifactFolder,
+			SkipCleanup:            skipCleanup,
+			InfrastructureProvider: pointer.String(e2eConfig.GetInfrastructureProvider(quickSuiteDefaultInfrastructureProvider)),
+			Flavor:                 pointer.String("md-remediation"),
+		}
+	})
+})
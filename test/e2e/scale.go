@@ -23,6 +23,7 @@ import (
 	"math"
 	"os"
 	"path/filepath"
+	"sort"
 	"strconv"
 	"sync"
 	"time"
@@ -53,6 +54,12 @@ const (
 	scaleControlPlaneMachineCount = "CAPI_SCALE_CONTROL_PLANE_MACHINE_COUNT"
 	scaleWorkerMachineCount       = "CAPI_SCALE_WORKER_MACHINE_COUNT"
 	scaleMachineDeploymentCount   = "CAPI_SCALE_MACHINE_DEPLOYMENT_COUNT"
+	scaleLoadProfile              = "CAPI_SCALE_LOAD_PROFILE"
+	scaleRampDuration             = "CAPI_SCALE_RAMP_DURATION"
+	scaleChurnPercentage          = "CAPI_SCALE_CHURN_PERCENTAGE"
+
+	// scaleResultsFileName is the name of the file in the artifact folder where the results of the scale test are written.
+	scaleResultsFileName = "scale-results.json"
 
 	// Note: Names must consist of lower case alphanumeric characters or '-'.
 	scaleClusterNamePlaceholder      = "scale-cluster-name-placeholder"
@@ -131,6 +138,42 @@ type scaleSpecInput struct {
 	// If set to true, the test will create the workload clusters and immediately continue without waiting
 	// for the clusters to be fully provisioned.
 	SkipWaitForCreation bool
+
+	// LoadProfile defines how the cluster operations are submitted, either "burst" or "ramp".
+	// With "burst" all the operations are submitted at once and are only limited by Concurrency.
+	// With "ramp" the operations are submitted at a constant rate over RampDuration.
+	// If not specified, "burst" will be used.
+	// Can be overridden by variable CAPI_SCALE_LOAD_PROFILE.
+	LoadProfile *framework.ScaleLoadProfile
+
+	// RampDuration is the time over which the cluster operations are submitted when using the "ramp" LoadProfile.
+	// If not specified, 1 minute will be used.
+	// Can be overridden by variable CAPI_SCALE_RAMP_DURATION.
+	RampDuration *time.Duration
+
+	// ChurnPercentage is the percentage of the workload clusters which are deleted and created again
+	// after all the workload clusters have been created.
+	// If not specified, 0 will be used.
+	// Can be overridden by variable CAPI_SCALE_CHURN_PERCENTAGE.
+	ChurnPercentage *int64
+}
+
+// scaleResults are the results of a scale test; they are written as JSON to the artifact folder
+// so they can be used to track regressions.
+type scaleResults struct {
+	LoadProfile              framework.ScaleLoadProfile `json:"loadProfile"`
+	ClusterCount             int64                      `json:"clusterCount"`
+	Concurrency              int64                      `json:"concurrency"`
+	ControlPlaneMachineCount int64                      `json:"controlPlaneMachineCount"`
+	MachineDeploymentCount   int64                      `json:"machineDeploymentCount"`
+	WorkerMachineCount       int64                      `json:"workerMachineCount"`
+	ChurnPercentage          int64                      `json:"churnPercentage"`
+
+	// Latencies are the latencies of the cluster operations by phase, e.g. "create", "churn-delete", "churn-create" and "delete".
+	Latencies map[string]framework.ScaleLatencyStats `json:"latencies"`
+
+	// ControllerResourceUsage is the resource usage of the controllers at the end of each phase.
+	ControllerResourceUsage map[string][]framework.ControllerResourceUsage `json:"controllerResourceUsage"`
 }
 
 // scaleSpec implements a scale test.
@@ -167,7 +210,7 @@ func scaleSpec(ctx context.Context, inputGetter func() scaleSpecInput) {
 	})
 
 	It("Should create and delete workload clusters", func() {
-		infrastructureProvider := clusterctl.DefaultInfrastructureProvider
+		infrastructureProvider := input.E2EConfig.GetInfrastructureProvider(clusterctl.DefaultInfrastructureProvider)
 		if input.InfrastructureProvider != nil {
 			infrastructureProvider = *input.InfrastructureProvider
 		}
@@ -237,6 +280,77 @@ func scaleSpec(ctx context.Context, inputGetter func() scaleSpecInput) {
 			Expect(err).NotTo(HaveOccurred(), "%q value should be integer", scaleConcurrency)
 		}
 
+		loadProfile := framework.ScaleLoadProfileBurst
+		if input.LoadProfile != nil {
+			loadProfile = *input.LoadProfile
+		}
+		// If variable is defined that will take precedence.
+		if input.E2EConfig.HasVariable(scaleLoadProfile) {
+			loadProfile = framework.ScaleLoadProfile(input.E2EConfig.GetVariable(scaleLoadProfile))
+		}
+		Expect(loadProfile).To(BeElementOf(framework.ScaleLoadProfileBurst, framework.ScaleLoadProfileRamp), "%q value should be either %q or %q", scaleLoadProfile, framework.ScaleLoadProfileBurst, framework.ScaleLoadProfileRamp)
+
+		rampDuration := 1 * time.Minute
+		if input.RampDuration != nil {
+			rampDuration = *input.RampDuration
+		}
+		// If variable is defined that will take precedence.
+		if input.E2EConfig.HasVariable(scaleRampDuration) {
+			rampDurationStr := input.E2EConfig.GetVariable(scaleRampDuration)
+			var err error
+			rampDuration, err = time.ParseDuration(rampDurationStr)
+			Expect(err).NotTo(HaveOccurred(), "%q value should be a duration", scaleRampDuration)
+		}
+
+		churnPercentage := int64(0)
+		if input.ChurnPercentage != nil {
+			churnPercentage = *input.ChurnPercentage
+		}
+		// If variable is defined that will take precedence.
+		if input.E2EConfig.HasVariable(scaleChurnPercentage) {
+			churnPercentageStr := input.E2EConfig.GetVariable(scaleChurnPercentage)
+			var err error
+			churnPercentage, err = strconv.ParseInt(churnPercentageStr, 10, 64)
+			Expect(err).NotTo(HaveOccurred(), "%q value should be integer", scaleChurnPercentage)
+		}
+		Expect(churnPercentage).To(BeNumerically(">=", 0), "%q value should be between 0 and 100", scaleChurnPercentage)
+		Expect(churnPercentage).To(BeNumerically("<=", 100), "%q value should be between 0 and 100", scaleChurnPercentage)
+
+		// With the ramp load profile, operations are submitted at a constant rate over the ramp duration.
+		var submitInterval time.Duration
+		if loadProfile == framework.ScaleLoadProfileRamp && clusterCount > 0 {
+			submitInterval = rampDuration / time.Duration(clusterCount)
+		}
+
+		results := &scaleResults{
+			LoadProfile:              loadProfile,
+			ClusterCount:             clusterCount,
+			Concurrency:              concurrency,
+			ControlPlaneMachineCount: *controlPlaneMachineCount,
+			MachineDeploymentCount:   *machineDeploymentCount,
+			WorkerMachineCount:       *workerMachineCount,
+			ChurnPercentage:          churnPercentage,
+			Latencies:                map[string]framework.ScaleLatencyStats{},
+			ControllerResourceUsage:  map[string][]framework.ControllerResourceUsage{},
+		}
+		controllerDeployments := framework.GetControllerDeployments(ctx, framework.GetControllerDeploymentsInput{
+			Lister: input.BootstrapClusterProxy.GetClient(),
+		})
+		recordResults := func(phase string, workResults []workResult) {
+			results.Latencies[phase] = framework.NewScaleLatencyStats(workLatencies(workResults))
+			results.ControllerResourceUsage[phase] = framework.GetControllerResourceUsage(ctx, framework.GetControllerResourceUsageInput{
+				Lister:      input.BootstrapClusterProxy.GetClient(),
+				ClientSet:   input.BootstrapClusterProxy.GetClientSet(),
+				Deployments: controllerDeployments,
+			})
+		}
+		// Write the results collected so far even if the test fails.
+		defer func() {
+			resultsPath := filepath.Join(input.ArtifactFolder, scaleResultsFileName)
+			log.Logf("Writing scale results to %s", resultsPath)
+			framework.WriteScaleResults(resultsPath, results)
+		}()
+
 		// TODO(ykakarap): Follow-up: Add support for legacy cluster templates.
 
 		By("Create the ClusterClass to be used by all workload clusters")
@@ -313,14 +427,21 @@ func scaleSpec(ctx context.Context, inputGetter func() scaleSpecInput) {
 			creator = getClusterCreateFn(input.BootstrapClusterProxy)
 		}
 
+		createClusterWorkerFunc := func(ctx context.Context, inputChan chan string, resultChan chan workResult, wg *sync.WaitGroup) {
+			createClusterWorker(ctx, input.BootstrapClusterProxy, inputChan, resultChan, wg, namespace.Name, input.DeployClusterInSeparateNamespaces, baseClusterClassYAML, baseClusterTemplateYAML, creator)
+		}
+		deleteClusterWorkerFunc := func(ctx context.Context, inputChan chan string, resultChan chan workResult, wg *sync.WaitGroup) {
+			deleteClusterAndWaitWorker(ctx, inputChan, resultChan, wg, input.BootstrapClusterProxy.GetClient(), namespace.Name, input.DeployClusterInSeparateNamespaces)
+		}
+
 		clusterCreateResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
-			ClusterNames: clusterNames,
-			Concurrency:  concurrency,
-			FailFast:     input.FailFast,
-			WorkerFunc: func(ctx context.Context, inputChan chan string, resultChan chan workResult, wg *sync.WaitGroup) {
-				createClusterWorker(ctx, input.BootstrapClusterProxy, inputChan, resultChan, wg, namespace.Name, input.DeployClusterInSeparateNamespaces, baseClusterClassYAML, baseClusterTemplateYAML, creator)
-			},
+			ClusterNames:   clusterNames,
+			Concurrency:    concurrency,
+			FailFast:       input.FailFast,
+			SubmitInterval: submitInterval,
+			WorkerFunc:     createClusterWorkerFunc,
 		})
+		recordResults("create", clusterCreateResults)
 		if err != nil {
 			// Call Fail to notify ginkgo that the suit has failed.
 			// Ginkgo will print the first observed error failure in this case.
@@ -340,20 +461,54 @@ func scaleSpec(ctx context.Context, inputGetter func() scaleSpecInput) {
 			clusterNamesToDelete = append(clusterNamesToDelete, result.clusterName)
 		}
 
+		if churnPercentage > 0 {
+			// Delete and create again a percentage of the workload clusters, rounded up.
+			churnCount := (int64(len(clusterNamesToDelete))*churnPercentage + 99) / 100
+			sort.Strings(clusterNamesToDelete)
+			clusterNamesToChurn := clusterNamesToDelete[:churnCount]
+
+			By(fmt.Sprintf("Churn %d%% of the workload clusters concurrently", churnPercentage))
+			churnDeleteResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
+				ClusterNames:   clusterNamesToChurn,
+				Concurrency:    concurrency,
+				FailFast:       input.FailFast,
+				SubmitInterval: submitInterval,
+				WorkerFunc:     deleteClusterWorkerFunc,
+			})
+			recordResults("churn-delete", churnDeleteResults)
+			if err != nil {
+				log.Logf("Failed to delete clusters during churn. Error: %s", err.Error())
+				Fail("")
+			}
+
+			churnCreateResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
+				ClusterNames:   clusterNamesToChurn,
+				Concurrency:    concurrency,
+				FailFast:       input.FailFast,
+				SubmitInterval: submitInterval,
+				WorkerFunc:     createClusterWorkerFunc,
+			})
+			recordResults("churn-create", churnCreateResults)
+			if err != nil {
+				log.Logf("Failed to create clusters during churn. Error: %s", err.Error())
+				Fail("")
+			}
+		}
+
 		if input.SkipCleanup {
 			return
 		}
 
 		By("Delete the workload clusters concurrently")
 		// Now delete all the workload clusters.
-		_, err = workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
-			ClusterNames: clusterNamesToDelete,
-			Concurrency:  concurrency,
-			FailFast:     input.FailFast,
-			WorkerFunc: func(ctx context.Context, inputChan chan string, resultChan chan workResult, wg *sync.WaitGroup) {
-				deleteClusterAndWaitWorker(ctx, inputChan, resultChan, wg, input.BootstrapClusterProxy.GetClient(), namespace.Name, input.DeployClusterInSeparateNamespaces)
-			},
+		clusterDeleteResults, err := workConcurrentlyAndWait(ctx, workConcurrentlyAndWaitInput{
+			ClusterNames:   clusterNamesToDelete,
+			Concurrency:    concurrency,
+			FailFast:       input.FailFast,
+			SubmitInterval: submitInterval,
+			WorkerFunc:     deleteClusterWorkerFunc,
 		})
+		recordResults("delete", clusterDeleteResults)
 		if err != nil {
 			// Call Fail to notify ginkgo that the suit has failed.
 			// Ginkgo will print the first observed error failure in this case.
@@ -408,6 +563,10 @@ type workConcurrentlyAndWaitInput struct {
 
 	FailFast bool
 
+	// SubmitInterval is the interval between submitting the names of two clusters to the workers.
+	// If zero, all the names are submitted at once.
+	SubmitInterval time.Duration
+
 	WorkerFunc func(ctx context.Context, inputChan chan string, errChan chan workResult, wg *sync.WaitGroup)
 }
 
@@ -434,8 +593,20 @@ func workConcurrentlyAndWait(ctx context.Context, input workConcurrentlyAndWaitI
 
 	// Adding the cluster names into the input channel.
 	go func() {
-		for _, name := range input.ClusterNames {
-			inputChan <- name
+		for i, name := range input.ClusterNames {
+			if i > 0 && input.SubmitInterval > 0 {
+				select {
+				case <-ctx.Done():
+				case <-time.After(input.SubmitInterval):
+				}
+			}
+			select {
+			case <-ctx.Done():
+				// If the context is cancelled, all the workers are shut down and no one is reading from the channel.
+			case inputChan <- name:
+				continue
+			}
+			break
 		}
 		// All the clusters are requested.
 		// Close the channel to shut down workers as they become unused.
@@ -535,11 +706,13 @@ func createClusterWorker(ctx context.Context, clusterProxy framework.ClusterProx
 
 				// This defer will catch ginkgo failures and record them.
 				// The recorded panics are then handled by the parent goroutine.
+				start := time.Now()
 				defer func() {
 					e := recover()
 					resultChan <- workResult{
 						clusterName: clusterName,
 						err:         e,
+						duration:    time.Since(start),
 					}
 				}()
 
@@ -602,11 +775,13 @@ func deleteClusterAndWaitWorker(ctx context.Context, inputChan <-chan string, re
 
 				// This defer will catch ginkgo failures and record them.
 				// The recorded panics are then handled by the parent goroutine.
+				start := time.Now()
 				defer func() {
 					e := recover()
 					resultChan <- workResult{
 						clusterName: clusterName,
 						err:         e,
+						duration:    time.Since(start),
 					}
 				}()
 
@@ -651,6 +826,19 @@ func deleteClusterAndWaitWorker(ctx context.Context, inputChan <-chan string, re
 type workResult struct {
 	clusterName string
 	err         any
+	// duration is the time it took to work on the cluster.
+	duration time.Duration
+}
+
+// workLatencies returns the durations of the successful work results.
+func workLatencies(results []workResult) []time.Duration {
+	latencies := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		if result.err == nil {
+			latencies = append(latencies, result.duration)
+		}
+	}
+	return latencies
 }
 
 func modifyMachineDeployments(baseClusterTemplateYAML []byte, count int) []byte {
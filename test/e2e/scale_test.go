@@ -20,8 +20,12 @@ limitations under the License.
 package e2e
 
 import (
+	"time"
+
 	. "github.com/onsi/ginkgo/v2"
 	"k8s.io/utils/pointer"
+
+	"sigs.k8s.io/cluster-api/test/framework"
 )
 
 var _ = Describe("When testing the machinery for scale testing using in-memory provider", func() {
@@ -61,3 +65,27 @@ var _ = Describe("When scale testing using in-memory provider  [Scale]", func()
 		}
 	})
 })
+
+var _ = Describe("When scale testing using in-memory provider with a ramp load profile and churn [Scale]", func() {
+	scaleSpec(ctx, func() scaleSpecInput {
+		loadProfile := framework.ScaleLoadProfileRamp
+		rampDuration := 2 * time.Minute
+		return scaleSpecInput{
+			E2EConfig:                e2eConfig,
+			ClusterctlConfigPath:     clusterctlConfigPath,
+			InfrastructureProvider:   pointer.String("in-memory"),
+			BootstrapClusterProxy:    bootstrapClusterProxy,
+			ArtifactFolder:           artifactFolder,
+			ClusterCount:             pointer.Int64(10),
+			Concurrency:              pointer.Int64(5),
+			Flavor:                   pointer.String(""),
+			ControlPlaneMachineCount: pointer.Int64(1),
+			MachineDeploymentCount:   pointer.Int64(1),
+			WorkerMachineCount:       pointer.Int64(3),
+			LoadProfile:              &loadProfile,
+			RampDuration:             &rampDuration,
+			ChurnPercentage:          pointer.Int64(20),
+			SkipCleanup:              skipCleanup,
+		}
+	})
+})
@@ -20,25 +20,18 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
-	"os"
-	"path"
-	"path/filepath"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
-	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
-	apierrors "k8s.io/apimachinery/pkg/api/errors"
-	apimeta "k8s.io/apimachinery/pkg/api/meta"
-	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/util/wait"
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/yaml"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework/collector"
 	. "sigs.k8s.io/cluster-api/test/framework/ginkgoextensions"
 )
 
@@ -55,58 +48,13 @@ func GetCAPIResources(ctx context.Context, input GetCAPIResourcesInput) []*unstr
 	Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for GetCAPIResources")
 	Expect(input.Namespace).NotTo(BeEmpty(), "input.Namespace is required for GetCAPIResources")
 
-	types := getClusterAPITypes(ctx, input.Lister)
-
-	objList := []*unstructured.Unstructured{}
-	for i := range types {
-		typeMeta := types[i]
-		typeList := new(unstructured.UnstructuredList)
-		typeList.SetAPIVersion(typeMeta.APIVersion)
-		typeList.SetKind(typeMeta.Kind)
-
-		if err := input.Lister.List(ctx, typeList, client.InNamespace(input.Namespace)); err != nil {
-			if apierrors.IsNotFound(err) {
-				continue
-			}
-			Fail(fmt.Sprintf("failed to list %q resources: %v", typeList.GroupVersionKind(), err))
-		}
-		for i := range typeList.Items {
-			obj := typeList.Items[i]
-			objList = append(objList, &obj)
-		}
+	objList, err := collector.GetCAPIResources(ctx, input.Lister, input.Namespace)
+	if err != nil {
+		Fail(err.Error())
 	}
-
 	return objList
 }
 
-// getClusterAPITypes returns the list of TypeMeta to be considered for the move discovery phase.
-// This list includes all the types belonging to CAPI providers.
-func getClusterAPITypes(ctx context.Context, lister Lister) []metav1.TypeMeta {
-	discoveredTypes := []metav1.TypeMeta{}
-
-	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
-	Eventually(func() error {
-		return lister.List(ctx, crdList, capiProviderOptions()...)
-	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "failed to list CRDs for CAPI providers")
-
-	for _, crd := range crdList.Items {
-		for _, version := range crd.Spec.Versions {
-			if !version.Storage {
-				continue
-			}
-
-			discoveredTypes = append(discoveredTypes, metav1.TypeMeta{
-				Kind: crd.Spec.Names.Kind,
-				APIVersion: metav1.GroupVersion{
-					Group:   crd.Spec.Group,
-					Version: version.Name,
-				}.String(),
-			})
-		}
-	}
-	return discoveredTypes
-}
-
 // DumpAllResourcesInput is the input for DumpAllResources.
 type DumpAllResourcesInput struct {
 	Lister    Lister
@@ -174,24 +122,7 @@ func DumpResourcesForCluster(ctx context.Context, input DumpResourcesForClusterI
 }
 
 func dumpObject(resource runtime.Object, logPath string) {
-	resourceYAML, err := yaml.Marshal(resource)
-	Expect(err).ToNot(HaveOccurred(), "Failed to marshal %s", resource.GetObjectKind().GroupVersionKind().String())
-
-	metaObj, err := apimeta.Accessor(resource)
-	Expect(err).ToNot(HaveOccurred(), "Failed to get accessor for %s", resource.GetObjectKind().GroupVersionKind().String())
-
-	kind := resource.GetObjectKind().GroupVersionKind().Kind
-	namespace := metaObj.GetNamespace()
-	name := metaObj.GetName()
-
-	resourceFilePath := filepath.Clean(path.Join(logPath, namespace, kind, name+".yaml"))
-	Expect(os.MkdirAll(filepath.Dir(resourceFilePath), 0750)).To(Succeed(), "Failed to create folder %s", filepath.Dir(resourceFilePath))
-
-	f, err := os.OpenFile(resourceFilePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
-	Expect(err).ToNot(HaveOccurred(), "Failed to open %s", resourceFilePath)
-	defer f.Close()
-
-	Expect(os.WriteFile(f.Name(), resourceYAML, 0600)).To(Succeed(), "Failed to write %s", resourceFilePath)
+	Expect(collector.WriteObject(collector.NewDirectorySink(logPath), "", resource)).To(Succeed())
 }
 
 // capiProviderOptions returns a set of ListOptions that allows to identify all the objects belonging to Cluster API providers.
@@ -0,0 +1,331 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	. "sigs.k8s.io/cluster-api/test/framework/ginkgoextensions"
+	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+)
+
+// NOTE: The network partition helpers are implemented using NetworkPolicies, so they require the CNI
+// of the management cluster to enforce NetworkPolicies. Depending on the CNI, connections established
+// before the NetworkPolicy is created might not be interrupted.
+
+const chaosNetworkPolicyPrefix = "chaos-partition"
+
+// KillControllerPodsInput is the input for KillControllerPods.
+type KillControllerPodsInput struct {
+	Lister     Lister
+	Deleter    Deleter
+	Deployment *appsv1.Deployment
+
+	// Count is the number of Pods to kill. If zero, all the Pods of the Deployment are killed.
+	Count int
+}
+
+// KillControllerPods force deletes the Pods of a controller Deployment and returns the names of the killed Pods.
+func KillControllerPods(ctx context.Context, input KillControllerPodsInput) []string {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for KillControllerPods")
+	Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for KillControllerPods")
+	Expect(input.Deleter).NotTo(BeNil(), "input.Deleter is required for KillControllerPods")
+	Expect(input.Deployment).NotTo(BeNil(), "input.Deployment is required for KillControllerPods")
+
+	// Ignore Pods which are already being deleted.
+	pods := []corev1.Pod{}
+	for _, pod := range listDeploymentPods(ctx, input.Lister, input.Deployment) {
+		if pod.DeletionTimestamp.IsZero() {
+			pods = append(pods, pod)
+		}
+	}
+	Expect(pods).NotTo(BeEmpty(), "Failed to find Pods for deployment %s", klog.KObj(input.Deployment))
+
+	count := input.Count
+	if count == 0 || count > len(pods) {
+		count = len(pods)
+	}
+
+	killed := []string{}
+	for i := range pods[:count] {
+		pod := &pods[i]
+		log.Logf("Killing Pod %s of deployment %s", klog.KObj(pod), klog.KObj(input.Deployment))
+		Eventually(func() error {
+			if err := input.Deleter.Delete(ctx, pod, client.GracePeriodSeconds(0)); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			return nil
+		}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to kill Pod %s", klog.KObj(pod))
+		killed = append(killed, pod.Name)
+	}
+	return killed
+}
+
+// WaitForControllerPodsRecoveredInput is the input for WaitForControllerPodsRecovered.
+type WaitForControllerPodsRecoveredInput struct {
+	Lister     Lister
+	Deployment *appsv1.Deployment
+
+	// KilledPods are the names of the Pods killed by KillControllerPods; they must not exist anymore
+	// for the Deployment to be considered recovered.
+	KilledPods []string
+
+	// FaultInjectedAt is the time at which the fault has been injected; the recovery time is measured from it.
+	// If zero, the recovery time is measured from the call to WaitForControllerPodsRecovered.
+	FaultInjectedAt time.Time
+
+	// MaxRecoveryTime, if set, is the maximum time the Deployment is allowed to take to recover.
+	MaxRecoveryTime time.Duration
+}
+
+// WaitForControllerPodsRecovered waits until the desired number of Pods of a controller Deployment are ready,
+// none of them being a killed Pod, and returns the recovery time.
+func WaitForControllerPodsRecovered(ctx context.Context, input WaitForControllerPodsRecoveredInput, intervals ...interface{}) time.Duration {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForControllerPodsRecovered")
+	Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for WaitForControllerPodsRecovered")
+	Expect(input.Deployment).NotTo(BeNil(), "input.Deployment is required for WaitForControllerPodsRecovered")
+
+	start := input.FaultInjectedAt
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	replicas := 1
+	if input.Deployment.Spec.Replicas != nil {
+		replicas = int(*input.Deployment.Spec.Replicas)
+	}
+	killed := sets.New[string](input.KilledPods...)
+
+	Byf("Waiting for the Pods of deployment %s to recover", klog.KObj(input.Deployment))
+	Eventually(func() error {
+		ready := 0
+		for _, pod := range listDeploymentPods(ctx, input.Lister, input.Deployment) {
+			if killed.Has(pod.Name) {
+				return errors.Errorf("killed Pod %s still exists", klog.KObj(&pod))
+			}
+			if pod.DeletionTimestamp.IsZero() && isPodReady(&pod) {
+				ready++
+			}
+		}
+		if ready < replicas {
+			return errors.Errorf("%d of %d Pods are ready", ready, replicas)
+		}
+		return nil
+	}, intervals...).Should(Succeed(), "Pods of deployment %s failed to recover", klog.KObj(input.Deployment))
+
+	return expectRecoveryTime(fmt.Sprintf("deployment %s", klog.KObj(input.Deployment)), start, input.MaxRecoveryTime)
+}
+
+// PartitionControllerPodsInput is the input for PartitionControllerPods.
+type PartitionControllerPodsInput struct {
+	Creator    Creator
+	Deployment *appsv1.Deployment
+}
+
+// PartitionControllerPods isolates the Pods of a controller Deployment from the network, by denying all their
+// ingress and egress traffic, and returns the NetworkPolicy implementing the partition.
+// The partition can be removed using HealNetworkPartition.
+func PartitionControllerPods(ctx context.Context, input PartitionControllerPodsInput) *networkingv1.NetworkPolicy {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for PartitionControllerPods")
+	Expect(input.Creator).NotTo(BeNil(), "input.Creator is required for PartitionControllerPods")
+	Expect(input.Deployment).NotTo(BeNil(), "input.Deployment is required for PartitionControllerPods")
+
+	networkPolicy := &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      fmt.Sprintf("%s-%s", chaosNetworkPolicyPrefix, input.Deployment.Name),
+			Namespace: input.Deployment.Namespace,
+		},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: *input.Deployment.Spec.Selector,
+			PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress, networkingv1.PolicyTypeEgress},
+		},
+	}
+
+	log.Logf("Partitioning the Pods of deployment %s from the network", klog.KObj(input.Deployment))
+	createNetworkPolicy(ctx, input.Creator, networkPolicy)
+	return networkPolicy
+}
+
+// PartitionWorkloadClusterAPIInput is the input for PartitionWorkloadClusterAPI.
+type PartitionWorkloadClusterAPIInput struct {
+	Creator Creator
+	Cluster *clusterv1.Cluster
+
+	// Deployments are the controller Deployments which should not be able to reach the workload cluster API server.
+	Deployments []*appsv1.Deployment
+}
+
+// PartitionWorkloadClusterAPI prevents the Pods of the given controller Deployments from reaching the control plane
+// endpoint of a workload cluster, and returns the NetworkPolicies implementing the partition.
+// The partition can be removed using HealNetworkPartition.
+func PartitionWorkloadClusterAPI(ctx context.Context, input PartitionWorkloadClusterAPIInput) []*networkingv1.NetworkPolicy {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for PartitionWorkloadClusterAPI")
+	Expect(input.Creator).NotTo(BeNil(), "input.Creator is required for PartitionWorkloadClusterAPI")
+	Expect(input.Cluster).NotTo(BeNil(), "input.Cluster is required for PartitionWorkloadClusterAPI")
+	Expect(input.Cluster.Spec.ControlPlaneEndpoint.Host).NotTo(BeEmpty(), "Cluster %s must have a control plane endpoint", klog.KObj(input.Cluster))
+
+	ips := []net.IP{net.ParseIP(input.Cluster.Spec.ControlPlaneEndpoint.Host)}
+	if ips[0] == nil {
+		var err error
+		ips, err = net.LookupIP(input.Cluster.Spec.ControlPlaneEndpoint.Host)
+		Expect(err).NotTo(HaveOccurred(), "Failed to resolve the control plane endpoint of Cluster %s", klog.KObj(input.Cluster))
+	}
+
+	// Allow all the egress traffic except the traffic to the control plane endpoint.
+	egress := []networkingv1.NetworkPolicyPeer{}
+	for _, ip := range ips {
+		ipBlock := &networkingv1.IPBlock{CIDR: "0.0.0.0/0", Except: []string{fmt.Sprintf("%s/32", ip)}}
+		if ip.To4() == nil {
+			ipBlock = &networkingv1.IPBlock{CIDR: "::/0", Except: []string{fmt.Sprintf("%s/128", ip)}}
+		}
+		egress = append(egress, networkingv1.NetworkPolicyPeer{IPBlock: ipBlock})
+	}
+
+	networkPolicies := []*networkingv1.NetworkPolicy{}
+	for _, deployment := range input.Deployments {
+		networkPolicy := &networkingv1.NetworkPolicy{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("%s-%s-%s", chaosNetworkPolicyPrefix, deployment.Name, input.Cluster.Name),
+				Namespace: deployment.Namespace,
+			},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: *deployment.Spec.Selector,
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress:      []networkingv1.NetworkPolicyEgressRule{{To: egress}},
+			},
+		}
+
+		log.Logf("Partitioning the Pods of deployment %s from the API server of Cluster %s", klog.KObj(deployment), klog.KObj(input.Cluster))
+		createNetworkPolicy(ctx, input.Creator, networkPolicy)
+		networkPolicies = append(networkPolicies, networkPolicy)
+	}
+	return networkPolicies
+}
+
+// HealNetworkPartitionInput is the input for HealNetworkPartition.
+type HealNetworkPartitionInput struct {
+	Deleter         Deleter
+	NetworkPolicies []*networkingv1.NetworkPolicy
+}
+
+// HealNetworkPartition removes a network partition created by PartitionControllerPods or PartitionWorkloadClusterAPI
+// and returns the time at which the partition has been removed.
+func HealNetworkPartition(ctx context.Context, input HealNetworkPartitionInput) time.Time {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for HealNetworkPartition")
+	Expect(input.Deleter).NotTo(BeNil(), "input.Deleter is required for HealNetworkPartition")
+
+	for _, networkPolicy := range input.NetworkPolicies {
+		log.Logf("Removing network partition %s", klog.KObj(networkPolicy))
+		Eventually(func() error {
+			if err := input.Deleter.Delete(ctx, networkPolicy); err != nil && !apierrors.IsNotFound(err) {
+				return err
+			}
+			return nil
+		}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to delete NetworkPolicy %s", klog.KObj(networkPolicy))
+	}
+	return time.Now()
+}
+
+// WaitForRecoveryInput is the input for WaitForRecovery.
+type WaitForRecoveryInput struct {
+	// Name describes what is recovering, e.g. "Cluster default/foo".
+	Name string
+
+	// Recovered returns nil when the system under test has recovered.
+	Recovered func(ctx context.Context) error
+
+	// FaultInjectedAt is the time at which the fault has been injected or removed; the recovery time is measured from it.
+	// If zero, the recovery time is measured from the call to WaitForRecovery.
+	FaultInjectedAt time.Time
+
+	// MaxRecoveryTime, if set, is the maximum time the system under test is allowed to take to recover.
+	MaxRecoveryTime time.Duration
+}
+
+// WaitForRecovery waits until the system under test has recovered after a fault, e.g. a network partition
+// being healed, and returns the recovery time.
+func WaitForRecovery(ctx context.Context, input WaitForRecoveryInput, intervals ...interface{}) time.Duration {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WaitForRecovery")
+	Expect(input.Recovered).NotTo(BeNil(), "input.Recovered is required for WaitForRecovery")
+
+	start := input.FaultInjectedAt
+	if start.IsZero() {
+		start = time.Now()
+	}
+
+	Byf("Waiting for %s to recover", input.Name)
+	Eventually(func() error {
+		return input.Recovered(ctx)
+	}, intervals...).Should(Succeed(), "%s failed to recover", input.Name)
+
+	return expectRecoveryTime(input.Name, start, input.MaxRecoveryTime)
+}
+
+// expectRecoveryTime returns the time elapsed since start and, if maxRecoveryTime is set, asserts it is not exceeded.
+func expectRecoveryTime(name string, start time.Time, maxRecoveryTime time.Duration) time.Duration {
+	recoveryTime := time.Since(start)
+	log.Logf("%s recovered in %s", name, recoveryTime)
+	if maxRecoveryTime > 0 {
+		Expect(recoveryTime).To(BeNumerically("<=", maxRecoveryTime), "%s took %s to recover, more than %s", name, recoveryTime, maxRecoveryTime)
+	}
+	return recoveryTime
+}
+
+func createNetworkPolicy(ctx context.Context, creator Creator, networkPolicy *networkingv1.NetworkPolicy) {
+	Eventually(func() error {
+		if err := creator.Create(ctx, networkPolicy); err != nil && !apierrors.IsAlreadyExists(err) {
+			return err
+		}
+		return nil
+	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to create NetworkPolicy %s", klog.KObj(networkPolicy))
+}
+
+func listDeploymentPods(ctx context.Context, lister Lister, deployment *appsv1.Deployment) []corev1.Pod {
+	selector, err := metav1.LabelSelectorAsMap(deployment.Spec.Selector)
+	Expect(err).NotTo(HaveOccurred(), "Failed to create Pods selector for deployment %s", klog.KObj(deployment))
+
+	pods := &corev1.PodList{}
+	Eventually(func() error {
+		return lister.List(ctx, pods, client.InNamespace(deployment.Namespace), client.MatchingLabels(selector))
+	}, retryableOperationTimeout, retryableOperationInterval).Should(Succeed(), "Failed to list Pods for deployment %s", klog.KObj(deployment))
+
+	return pods.Items
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady && c.Status == corev1.ConditionTrue {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,80 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+)
+
+func TestPartitionWorkloadClusterAPI(t *testing.T) {
+	// The framework helpers use the global Gomega instance.
+	RegisterTestingT(t)
+	g := NewWithT(t)
+	ctx := context.Background()
+
+	scheme := runtime.NewScheme()
+	framework.TryAddDefaultSchemes(scheme)
+	c := fake.NewClientBuilder().WithScheme(scheme).Build()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: metav1.NamespaceDefault, Name: "foo"},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{Host: "10.0.0.1", Port: 6443},
+		},
+	}
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"control-plane": "controller-manager"}}
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "capi-system", Name: "capi-controller-manager"},
+		Spec:       appsv1.DeploymentSpec{Selector: selector},
+	}
+
+	networkPolicies := framework.PartitionWorkloadClusterAPI(ctx, framework.PartitionWorkloadClusterAPIInput{
+		Creator:     c,
+		Cluster:     cluster,
+		Deployments: []*appsv1.Deployment{deployment},
+	})
+	g.Expect(networkPolicies).To(HaveLen(1))
+
+	networkPolicy := &networkingv1.NetworkPolicy{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(networkPolicies[0]), networkPolicy)).To(Succeed())
+	g.Expect(networkPolicy.Namespace).To(Equal(deployment.Namespace))
+	g.Expect(networkPolicy.Spec.PodSelector).To(Equal(*selector))
+	g.Expect(networkPolicy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+	g.Expect(networkPolicy.Spec.Egress).To(ConsistOf(networkingv1.NetworkPolicyEgressRule{
+		To: []networkingv1.NetworkPolicyPeer{{IPBlock: &networkingv1.IPBlock{CIDR: "0.0.0.0/0", Except: []string{"10.0.0.1/32"}}}},
+	}))
+
+	framework.HealNetworkPartition(ctx, framework.HealNetworkPartitionInput{
+		Deleter:         c,
+		NetworkPolicies: networkPolicies,
+	})
+	policies := &networkingv1.NetworkPolicyList{}
+	g.Expect(c.List(ctx, policies)).To(Succeed())
+	g.Expect(policies.Items).To(BeEmpty())
+}
@@ -95,14 +95,19 @@ func UpgradeClusterTopologyAndWaitForUpgrade(ctx context.Context, input UpgradeC
 	Expect(err).ToNot(HaveOccurred())
 
 	input.Cluster.Spec.Topology.Version = input.KubernetesUpgradeVersion
+	// NOTE: etcd and CoreDNS upgrades are only triggered when the corresponding variables are defined
+	// in the Cluster topology; e.g. this allows to run the upgrade using ClusterClasses without those variables.
+	etcdUpgrade, dnsUpgrade := false, false
 	for i, variable := range input.Cluster.Spec.Topology.Variables {
 		if variable.Name == "etcdImageTag" {
 			// NOTE: strconv.Quote is used to produce a valid JSON string.
 			input.Cluster.Spec.Topology.Variables[i].Value = apiextensionsv1.JSON{Raw: []byte(strconv.Quote(input.EtcdImageTag))}
+			etcdUpgrade = input.EtcdImageTag != ""
 		}
 		if variable.Name == "coreDNSImageTag" {
 			// NOTE: strconv.Quote is used to produce a valid JSON string.
 			input.Cluster.Spec.Topology.Variables[i].Value = apiextensionsv1.JSON{Raw: []byte(strconv.Quote(input.DNSImageTag))}
+			dnsUpgrade = input.DNSImageTag != ""
 		}
 	}
 	Eventually(func() error {
@@ -133,8 +138,8 @@ func UpgradeClusterTopologyAndWaitForUpgrade(ctx context.Context, input UpgradeC
 		KubernetesVersion: input.KubernetesUpgradeVersion,
 	}, input.WaitForKubeProxyUpgrade...)
 
-	// Wait for the CoreDNS upgrade if the DNSImageTag is set.
-	if input.DNSImageTag != "" {
+	// Wait for the CoreDNS upgrade if the DNSImageTag is set and the coreDNSImageTag variable is defined.
+	if dnsUpgrade {
 		log.Logf("Waiting for CoreDNS to have the upgraded image tag")
 		WaitForDNSUpgrade(ctx, WaitForDNSUpgradeInput{
 			Getter:     workloadClient,
@@ -142,8 +147,8 @@ func UpgradeClusterTopologyAndWaitForUpgrade(ctx context.Context, input UpgradeC
 		}, input.WaitForDNSUpgrade...)
 	}
 
-	// Wait for the etcd upgrade if the EtcdImageTag is set.
-	if input.EtcdImageTag != "" {
+	// Wait for the etcd upgrade if the EtcdImageTag is set and the etcdImageTag variable is defined.
+	if etcdUpgrade {
 		log.Logf("Waiting for etcd to have the upgraded image tag")
 		lblSelector, err := labels.Parse("component=etcd")
 		Expect(err).ToNot(HaveOccurred())
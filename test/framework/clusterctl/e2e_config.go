@@ -582,6 +582,22 @@ func (c *E2EConfig) GetVariable(varName string) string {
 	return value
 }
 
+// InfrastructureProviderVariable is the name of the variable that can be used to override the infrastructure
+// provider used by specs supporting it, thus allowing to run the same specs against different providers.
+const InfrastructureProviderVariable = "INFRASTRUCTURE_PROVIDER"
+
+// GetInfrastructureProvider returns the infrastructure provider defined by the INFRASTRUCTURE_PROVIDER variable
+// in the environment or in the e2e config file, or the given default provider if the variable is not set.
+func (c *E2EConfig) GetInfrastructureProvider(defaultProvider string) string {
+	if !c.HasVariable(InfrastructureProviderVariable) {
+		return defaultProvider
+	}
+	if provider := c.GetVariable(InfrastructureProviderVariable); provider != "" {
+		return provider
+	}
+	return defaultProvider
+}
+
 // GetInt64PtrVariable returns an Int64Ptr variable from the e2e config file.
 func (c *E2EConfig) GetInt64PtrVariable(varName string) *int64 {
 	wCountStr := c.GetVariable(varName)
@@ -0,0 +1,128 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"path"
+	"time"
+
+	"github.com/pkg/errors"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	apimeta "k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/yaml"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	retryableOperationInterval = 3 * time.Second
+	retryableOperationTimeout  = 3 * time.Minute
+)
+
+// Lister can list resources.
+type Lister interface {
+	List(ctx context.Context, list client.ObjectList, opts ...client.ListOption) error
+}
+
+// GetCAPIResources reads all the CAPI resources in a namespace.
+// This list includes all the types belonging to CAPI providers.
+func GetCAPIResources(ctx context.Context, lister Lister, namespace string) ([]*unstructured.Unstructured, error) {
+	types, err := getClusterAPITypes(ctx, lister)
+	if err != nil {
+		return nil, err
+	}
+
+	objList := []*unstructured.Unstructured{}
+	for i := range types {
+		typeMeta := types[i]
+		typeList := new(unstructured.UnstructuredList)
+		typeList.SetAPIVersion(typeMeta.APIVersion)
+		typeList.SetKind(typeMeta.Kind)
+
+		if err := lister.List(ctx, typeList, client.InNamespace(namespace)); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return nil, errors.Wrapf(err, "failed to list %q resources", typeList.GroupVersionKind())
+		}
+		for i := range typeList.Items {
+			obj := typeList.Items[i]
+			objList = append(objList, &obj)
+		}
+	}
+
+	return objList, nil
+}
+
+// getClusterAPITypes returns the list of TypeMeta to be considered for the move discovery phase.
+// This list includes all the types belonging to CAPI providers.
+func getClusterAPITypes(ctx context.Context, lister Lister) ([]metav1.TypeMeta, error) {
+	discoveredTypes := []metav1.TypeMeta{}
+
+	crdList := &apiextensionsv1.CustomResourceDefinitionList{}
+	var listErr error
+	_ = wait.PollUntilContextTimeout(ctx, retryableOperationInterval, retryableOperationTimeout, true, func(ctx context.Context) (bool, error) {
+		if listErr = lister.List(ctx, crdList, client.HasLabels{clusterv1.ProviderNameLabel}); listErr != nil {
+			return false, nil //nolint:nilerr
+		}
+		return true, nil
+	})
+	if listErr != nil {
+		return nil, errors.Wrap(listErr, "failed to list CRDs for CAPI providers")
+	}
+
+	for _, crd := range crdList.Items {
+		for _, version := range crd.Spec.Versions {
+			if !version.Storage {
+				continue
+			}
+
+			discoveredTypes = append(discoveredTypes, metav1.TypeMeta{
+				Kind: crd.Spec.Names.Kind,
+				APIVersion: metav1.GroupVersion{
+					Group:   crd.Spec.Group,
+					Version: version.Name,
+				}.String(),
+			})
+		}
+	}
+	return discoveredTypes, nil
+}
+
+// WriteObject writes the YAML of an object to the Sink, as <dir>/<namespace>/<kind>/<name>.yaml.
+func WriteObject(sink Sink, dir string, obj runtime.Object) error {
+	gvk := obj.GetObjectKind().GroupVersionKind()
+
+	objYAML, err := yaml.Marshal(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to marshal %s", gvk)
+	}
+
+	metaObj, err := apimeta.Accessor(obj)
+	if err != nil {
+		return errors.Wrapf(err, "failed to get accessor for %s", gvk)
+	}
+
+	return sink.WriteFile(path.Join(dir, metaObj.GetNamespace(), gvk.Kind, metaObj.GetName()+".yaml"), objYAML)
+}
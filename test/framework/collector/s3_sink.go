@@ -0,0 +1,270 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/fs"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+const (
+	defaultS3Region = "us-east-1"
+
+	s3Algorithm     = "AWS4-HMAC-SHA256"
+	s3Service       = "s3"
+	s3DateFormat    = "20060102"
+	s3TimeFormat    = "20060102T150405Z"
+	s3SignedHeaders = "host;x-amz-content-sha256;x-amz-date"
+)
+
+// S3SinkOptions are the options for NewS3Sink.
+type S3SinkOptions struct {
+	// Endpoint is the URL of the S3-compatible object store, e.g. https://s3.us-east-1.amazonaws.com.
+	// Objects are addressed using path-style URLs, i.e. <Endpoint>/<Bucket>/<Prefix>/<path>.
+	Endpoint string
+
+	// Region is the region used to sign requests. Defaults to us-east-1.
+	Region string
+
+	// Bucket is the bucket the objects are uploaded to.
+	Bucket string
+
+	// Prefix is prepended to the key of all the uploaded objects.
+	Prefix string
+
+	// AccessKeyID and SecretAccessKey are the credentials used to sign requests.
+	// If AccessKeyID is empty, requests are not signed.
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// StagingDir is the local directory where data is written before being uploaded.
+	// Defaults to a new temporary directory.
+	StagingDir string
+
+	// HTTPClient is the client used to upload objects. Defaults to http.DefaultClient.
+	HTTPClient *http.Client
+}
+
+// s3Sink is a Sink uploading data to an S3-compatible object store.
+// Data is written to a local staging directory, and the files which changed since the
+// last Flush are uploaded on Flush.
+type s3Sink struct {
+	*DirectorySink
+
+	opts       S3SinkOptions
+	stagingDir string
+
+	lock     sync.Mutex
+	uploaded map[string]uploadedFile
+}
+
+// uploadedFile identifies the version of a staged file which has been uploaded.
+type uploadedFile struct {
+	modTime time.Time
+	size    int64
+}
+
+// NewS3Sink returns a Sink uploading data to an S3-compatible object store.
+func NewS3Sink(opts S3SinkOptions) (Sink, error) {
+	if opts.Endpoint == "" {
+		return nil, errors.New("failed to create S3 sink: endpoint is required")
+	}
+	if opts.Bucket == "" {
+		return nil, errors.New("failed to create S3 sink: bucket is required")
+	}
+	if opts.AccessKeyID != "" && opts.SecretAccessKey == "" {
+		return nil, errors.New("failed to create S3 sink: secret access key is required when access key id is set")
+	}
+	if opts.Region == "" {
+		opts.Region = defaultS3Region
+	}
+	if opts.HTTPClient == nil {
+		opts.HTTPClient = http.DefaultClient
+	}
+	opts.Endpoint = strings.TrimSuffix(opts.Endpoint, "/")
+
+	stagingDir := opts.StagingDir
+	if stagingDir == "" {
+		var err error
+		stagingDir, err = os.MkdirTemp("", "collector-s3-")
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to create S3 sink staging directory")
+		}
+	}
+
+	return &s3Sink{
+		DirectorySink: NewDirectorySink(stagingDir),
+		opts:          opts,
+		stagingDir:    stagingDir,
+		uploaded:      map[string]uploadedFile{},
+	}, nil
+}
+
+// Flush uploads the staged files which changed since the last Flush.
+func (s *s3Sink) Flush(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+
+	var errs []string
+	err := filepath.WalkDir(s.stagingDir, func(filePath string, d fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if d.IsDir() {
+			return nil
+		}
+		info, err := d.Info()
+		if err != nil {
+			return err
+		}
+		rel, err := filepath.Rel(s.stagingDir, filePath)
+		if err != nil {
+			return err
+		}
+		key := filepath.ToSlash(rel)
+
+		current := uploadedFile{modTime: info.ModTime(), size: info.Size()}
+		if previous, ok := s.uploaded[key]; ok && previous == current {
+			return nil
+		}
+
+		data, err := os.ReadFile(filePath) //nolint:gosec // The file is in the staging directory of the sink.
+		if err != nil {
+			return err
+		}
+		if err := s.putObject(ctx, key, data); err != nil {
+			// Keep uploading the other files, the failed ones are retried on the next Flush.
+			errs = append(errs, err.Error())
+			return nil
+		}
+		s.uploaded[key] = current
+		return nil
+	})
+	if err != nil {
+		return errors.Wrapf(err, "failed to read S3 sink staging directory %s", s.stagingDir)
+	}
+	if len(errs) > 0 {
+		return errors.Errorf("failed to upload %d files: %s", len(errs), strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// putObject uploads an object to the bucket.
+func (s *s3Sink) putObject(ctx context.Context, p string, data []byte) error {
+	canonicalURI := s3EncodePath("/" + path.Join(s.opts.Bucket, s.opts.Prefix, p))
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.opts.Endpoint+canonicalURI, bytes.NewReader(data))
+	if err != nil {
+		return errors.Wrapf(err, "failed to create request to upload %s", p)
+	}
+	req.ContentLength = int64(len(data))
+	if s.opts.AccessKeyID != "" {
+		s.sign(req, canonicalURI, data, time.Now())
+	}
+
+	resp, err := s.opts.HTTPClient.Do(req)
+	if err != nil {
+		return errors.Wrapf(err, "failed to upload %s", p)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("failed to upload %s: got status %s: %s", p, resp.Status, string(body))
+	}
+	return nil
+}
+
+// sign signs the request using the AWS Signature Version 4.
+func (s *s3Sink) sign(req *http.Request, canonicalURI string, data []byte, now time.Time) {
+	now = now.UTC()
+	amzDate := now.Format(s3TimeFormat)
+	date := now.Format(s3DateFormat)
+	payloadHash := sha256Hex(data)
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", payloadHash)
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		canonicalURI,
+		"", // No query string.
+		"host:" + req.URL.Host,
+		"x-amz-content-sha256:" + payloadHash,
+		"x-amz-date:" + amzDate,
+		"",
+		s3SignedHeaders,
+		payloadHash,
+	}, "\n")
+
+	scope := strings.Join([]string{date, s.opts.Region, s3Service, "aws4_request"}, "/")
+	stringToSign := strings.Join([]string{
+		s3Algorithm,
+		amzDate,
+		scope,
+		sha256Hex([]byte(canonicalRequest)),
+	}, "\n")
+
+	signingKey := hmacSHA256([]byte("AWS4"+s.opts.SecretAccessKey), date)
+	signingKey = hmacSHA256(signingKey, s.opts.Region)
+	signingKey = hmacSHA256(signingKey, s3Service)
+	signingKey = hmacSHA256(signingKey, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(signingKey, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("%s Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s3Algorithm, s.opts.AccessKeyID, scope, s3SignedHeaders, signature))
+}
+
+// s3EncodePath URI-encodes every byte of the path except unreserved characters and '/', as required by S3.
+func s3EncodePath(p string) string {
+	var b strings.Builder
+	for i := 0; i < len(p); i++ {
+		c := p[i]
+		if (c >= 'A' && c <= 'Z') || (c >= 'a' && c <= 'z') || (c >= '0' && c <= '9') ||
+			c == '-' || c == '.' || c == '_' || c == '~' || c == '/' {
+			b.WriteByte(c)
+			continue
+		}
+		fmt.Fprintf(&b, "%%%02X", c)
+	}
+	return b.String()
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	h := hmac.New(sha256.New, key)
+	_, _ = h.Write([]byte(data))
+	return h.Sum(nil)
+}
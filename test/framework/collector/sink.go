@@ -0,0 +1,91 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+)
+
+// Sink is the destination of the collected logs and resources.
+// Paths are slash-separated and relative to the root of the sink.
+type Sink interface {
+	// WriteFile writes data to the file at path, replacing its content if the file already exists.
+	WriteFile(path string, data []byte) error
+
+	// OpenAppend opens the file at path for appending, creating it if it does not exist.
+	OpenAppend(path string) (io.WriteCloser, error)
+
+	// Flush makes the data written so far available at the destination of the sink,
+	// e.g. by uploading it to a remote object store.
+	Flush(ctx context.Context) error
+}
+
+// DirectorySink is a Sink writing to a local directory.
+type DirectorySink struct {
+	root string
+}
+
+var _ Sink = &DirectorySink{}
+
+// NewDirectorySink returns a Sink writing to the given local directory.
+func NewDirectorySink(root string) *DirectorySink {
+	return &DirectorySink{root: root}
+}
+
+// WriteFile writes data to the file at path, replacing its content if the file already exists.
+func (s *DirectorySink) WriteFile(p string, data []byte) error {
+	filePath, err := s.mkdirFor(p)
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filePath, data, 0600); err != nil {
+		return errors.Wrapf(err, "failed to write %s", filePath)
+	}
+	return nil
+}
+
+// OpenAppend opens the file at path for appending, creating it if it does not exist.
+func (s *DirectorySink) OpenAppend(p string) (io.WriteCloser, error) {
+	filePath, err := s.mkdirFor(p)
+	if err != nil {
+		return nil, err
+	}
+	f, err := os.OpenFile(filePath, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to open %s", filePath)
+	}
+	return f, nil
+}
+
+// Flush is a no-op, data is written to the directory as soon as it is written to the sink.
+func (s *DirectorySink) Flush(_ context.Context) error {
+	return nil
+}
+
+func (s *DirectorySink) mkdirFor(p string) (string, error) {
+	filePath := filepath.Join(s.root, filepath.FromSlash(path.Clean("/"+p)))
+	if err := os.MkdirAll(filepath.Dir(filePath), 0750); err != nil {
+		return "", errors.Wrapf(err, "failed to create folder %s", filepath.Dir(filePath))
+	}
+	return filePath, nil
+}
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/test/framework/collector"
+)
+
+func TestDirectorySink(t *testing.T) {
+	g := NewWithT(t)
+
+	root := t.TempDir()
+	sink := collector.NewDirectorySink(root)
+
+	g.Expect(sink.WriteFile("a/b/file.txt", []byte("foo"))).To(Succeed())
+	g.Expect(sink.WriteFile("a/b/file.txt", []byte("bar"))).To(Succeed())
+	g.Expect(os.ReadFile(filepath.Join(root, "a", "b", "file.txt"))).To(Equal([]byte("bar")))
+
+	for _, data := range []string{"foo", "bar"} {
+		w, err := sink.OpenAppend("logs/file.log")
+		g.Expect(err).ToNot(HaveOccurred())
+		_, err = w.Write([]byte(data))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(w.Close()).To(Succeed())
+	}
+	g.Expect(os.ReadFile(filepath.Join(root, "logs", "file.log"))).To(Equal([]byte("foobar")))
+
+	// Paths can't escape the root of the sink.
+	g.Expect(sink.WriteFile("../escaped.txt", []byte("foo"))).To(Succeed())
+	g.Expect(filepath.Join(root, "escaped.txt")).To(BeAnExistingFile())
+
+	g.Expect(sink.Flush(context.Background())).To(Succeed())
+}
+
+func TestS3Sink(t *testing.T) {
+	g := NewWithT(t)
+
+	var lock sync.Mutex
+	uploads := map[string]string{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		if err != nil || r.Method != http.MethodPut {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		sum := sha256.Sum256(body)
+		if r.Header.Get("X-Amz-Content-Sha256") != hex.EncodeToString(sum[:]) ||
+			!strings.HasPrefix(r.Header.Get("Authorization"), "AWS4-HMAC-SHA256 Credential=access-key/") ||
+			!strings.Contains(r.Header.Get("Authorization"), "/eu-west-1/s3/aws4_request, SignedHeaders=host;x-amz-content-sha256;x-amz-date, Signature=") {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		lock.Lock()
+		defer lock.Unlock()
+		uploads[r.URL.EscapedPath()] = string(body)
+	}))
+	defer server.Close()
+
+	sink, err := collector.NewS3Sink(collector.S3SinkOptions{
+		Endpoint:        server.URL,
+		Region:          "eu-west-1",
+		Bucket:          "bucket",
+		Prefix:          "run-1",
+		AccessKeyID:     "access-key",
+		SecretAccessKey: "secret-key",
+		StagingDir:      t.TempDir(),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(sink.WriteFile("resources/default/Cluster/foo+bar.yaml", []byte("cluster"))).To(Succeed())
+	w, err := sink.OpenAppend("logs/capi-system/capi-controller-manager/manager.log")
+	g.Expect(err).ToNot(HaveOccurred())
+	_, err = w.Write([]byte("line 1\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// Nothing is uploaded before Flush.
+	g.Expect(uploads).To(BeEmpty())
+
+	g.Expect(sink.Flush(context.Background())).To(Succeed())
+	g.Expect(uploads).To(Equal(map[string]string{
+		"/bucket/run-1/resources/default/Cluster/foo%2Bbar.yaml":             "cluster",
+		"/bucket/run-1/logs/capi-system/capi-controller-manager/manager.log": "line 1\n",
+	}))
+
+	// Only the files which changed are uploaded again.
+	uploads = map[string]string{}
+	_, err = w.Write([]byte("line 2\n"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(w.Close()).To(Succeed())
+	g.Expect(sink.Flush(context.Background())).To(Succeed())
+	g.Expect(uploads).To(Equal(map[string]string{
+		"/bucket/run-1/logs/capi-system/capi-controller-manager/manager.log": "line 1\nline 2\n",
+	}))
+}
+
+func TestS3SinkUploadFailure(t *testing.T) {
+	g := NewWithT(t)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sink, err := collector.NewS3Sink(collector.S3SinkOptions{
+		Endpoint:   server.URL,
+		Bucket:     "bucket",
+		StagingDir: t.TempDir(),
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(sink.WriteFile("file.txt", []byte("foo"))).To(Succeed())
+	g.Expect(sink.Flush(context.Background())).ToNot(Succeed())
+}
+
+func TestNewS3SinkValidation(t *testing.T) {
+	g := NewWithT(t)
+
+	_, err := collector.NewS3Sink(collector.S3SinkOptions{Bucket: "bucket"})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = collector.NewS3Sink(collector.S3SinkOptions{Endpoint: "http://localhost"})
+	g.Expect(err).To(HaveOccurred())
+
+	_, err = collector.NewS3Sink(collector.S3SinkOptions{Endpoint: "http://localhost", Bucket: "bucket", AccessKeyID: "access-key"})
+	g.Expect(err).To(HaveOccurred())
+}
@@ -0,0 +1,152 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collector
+
+import (
+	"context"
+	"path"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/kubernetes"
+	toolscache "sigs.k8s.io/controller-runtime/pkg/cache"
+
+	"sigs.k8s.io/cluster-api/test/framework/internal/log"
+)
+
+const (
+	defaultFlushInterval = time.Minute
+	finalFlushTimeout    = 5 * time.Minute
+
+	// snapshotTimestampFormat is the format of the folders containing the resource snapshots.
+	// It sorts lexicographically and does not contain characters that have to be escaped in object store keys.
+	snapshotTimestampFormat = "20060102T150405Z"
+)
+
+// StreamInput is the input for Stream.
+type StreamInput struct {
+	Cache     toolscache.Cache
+	ClientSet *kubernetes.Clientset
+	Lister    Lister
+
+	// Deployments are the controller Deployments whose logs are streamed to logs/<namespace>/<deployment> in the Sink.
+	Deployments []*appsv1.Deployment
+
+	// Namespaces are the namespaces whose CAPI resources are periodically written to resources/<timestamp> in the Sink.
+	Namespaces []string
+
+	// SnapshotInterval is the interval between two resource snapshots.
+	// If zero, no resource snapshots are taken.
+	SnapshotInterval time.Duration
+
+	// FlushInterval is the interval between two flushes of the Sink. Defaults to 1m.
+	FlushInterval time.Duration
+
+	Sink Sink
+}
+
+// Stream continuously collects controller logs and periodic snapshots of CAPI resources to the Sink, flushing it
+// periodically, so long-running tests can be monitored while they are still running.
+// Collection stops when ctx is done; the returned channel is closed after the final flush of the Sink.
+// Errors when collecting resources or flushing the Sink are logged and do not cause the test to fail.
+func Stream(ctx context.Context, input StreamInput) <-chan struct{} {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for Stream")
+	Expect(input.Sink).NotTo(BeNil(), "input.Sink is required for Stream")
+	if len(input.Deployments) > 0 {
+		Expect(input.Cache).NotTo(BeNil(), "input.Cache is required for Stream when streaming logs")
+		Expect(input.ClientSet).NotTo(BeNil(), "input.ClientSet is required for Stream when streaming logs")
+	}
+	if input.SnapshotInterval > 0 {
+		Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for Stream when taking resource snapshots")
+	}
+
+	flushInterval := input.FlushInterval
+	if flushInterval <= 0 {
+		flushInterval = defaultFlushInterval
+	}
+
+	for _, deployment := range input.Deployments {
+		WatchPodLogs(ctx, WatchPodLogsInput{
+			Cache:          input.Cache,
+			ClientSet:      input.ClientSet,
+			Namespace:      deployment.Namespace,
+			DeploymentName: deployment.Name,
+			LabelSelector:  deployment.Spec.Selector,
+			Sink:           input.Sink,
+			Path:           path.Join("logs", deployment.Namespace),
+		})
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer GinkgoRecover()
+		defer close(done)
+
+		flushTicker := time.NewTicker(flushInterval)
+		defer flushTicker.Stop()
+
+		// A nil channel blocks forever, which disables snapshots if no interval is set.
+		var snapshotC <-chan time.Time
+		if input.SnapshotInterval > 0 {
+			snapshotTicker := time.NewTicker(input.SnapshotInterval)
+			defer snapshotTicker.Stop()
+			snapshotC = snapshotTicker.C
+			snapshotResources(ctx, input)
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				// Use a new context for the final flush, ctx is already done.
+				flushCtx, cancel := context.WithTimeout(context.Background(), finalFlushTimeout)
+				defer cancel()
+				flushSink(flushCtx, input.Sink)
+				return
+			case <-snapshotC:
+				snapshotResources(ctx, input)
+			case <-flushTicker.C:
+				flushSink(ctx, input.Sink)
+			}
+		}
+	}()
+	return done
+}
+
+// snapshotResources writes the CAPI resources of the input namespaces to resources/<timestamp> in the Sink.
+func snapshotResources(ctx context.Context, input StreamInput) {
+	dir := path.Join("resources", time.Now().UTC().Format(snapshotTimestampFormat))
+	for _, namespace := range input.Namespaces {
+		resources, err := GetCAPIResources(ctx, input.Lister, namespace)
+		if err != nil {
+			log.Logf("Failed to get CAPI resources in namespace %s for snapshot: %v", namespace, err)
+			continue
+		}
+		for i := range resources {
+			if err := WriteObject(input.Sink, dir, resources[i]); err != nil {
+				log.Logf("Failed to write resource for snapshot: %v", err)
+			}
+		}
+	}
+}
+
+func flushSink(ctx context.Context, sink Sink) {
+	if err := sink.Flush(ctx); err != nil {
+		log.Logf("Failed to flush collected logs and resources: %v", err)
+	}
+}
@@ -26,10 +26,12 @@ import (
 	"runtime"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/onsi/ginkgo/v2"
 	"github.com/pkg/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/client-go/discovery"
 	"k8s.io/client-go/tools/clientcmd"
 	"sigs.k8s.io/yaml"
@@ -72,6 +74,24 @@ type RunInput struct {
 	// KubeTestRepoListPath is optional file for specifying custom image repositories
 	// https://github.com/kubernetes/kubernetes/blob/master/test/images/README.md#testing-the-new-image
 	KubeTestRepoListPath string
+	// FocusSets is an optional list of conformance subsets to run in parallel instead of the
+	// single run defined by the kubetest e2e config file. Each focus set runs in its own conformance
+	// container and stores its output in a dedicated subdirectory of the artifacts directory.
+	FocusSets []FocusSet
+}
+
+// FocusSet defines a subset of the conformance suite to be run by kubetest.
+type FocusSet struct {
+	// Name of the focus set; it is used to name the artifacts subdirectory and the JUnit reports
+	// of the run, so it must be unique.
+	Name string
+	// Focus is the ginkgo focus regex of the run; it overrides ginkgo.focus in the kubetest e2e config file.
+	Focus string
+	// Skip is an optional ginkgo skip regex of the run; if set, it overrides ginkgo.skip in the kubetest e2e config file.
+	Skip string
+	// GinkgoNodes is the number of Ginkgo nodes to use for this run, which allows to skew the available
+	// parallelism across focus sets. If not specified, RunInput.GinkgoNodes is used.
+	GinkgoNodes int
 }
 
 // Run executes kube-test given an artifact directory, and sets settings
@@ -102,7 +122,73 @@ func Run(ctx context.Context, input RunInput) error {
 		input.KubernetesVersion = discoveredVersion
 	}
 	input.ArtifactsDirectory = framework.ResolveArtifactsDirectory(input.ArtifactsDirectory)
-	reportDir := path.Join(input.ArtifactsDirectory, "kubetest")
+	if input.ConformanceImage == "" {
+		input.ConformanceImage = versionToConformanceImage(input.KubernetesVersion)
+	}
+
+	config, err := parseKubetestConfig(input.ConfigFilePath)
+	if err != nil {
+		return err
+	}
+
+	containerRuntime, err := container.NewDockerClient()
+	if err != nil {
+		return errors.Wrap(err, "Unable to run conformance tests")
+	}
+	ctx = container.RuntimeInto(ctx, containerRuntime)
+
+	if len(input.FocusSets) == 0 {
+		return run(ctx, input, runOptions{
+			reportDir:    path.Join(input.ArtifactsDirectory, "kubetest"),
+			reportPrefix: "kubetest.",
+			ginkgoNodes:  input.GinkgoNodes,
+			config:       config,
+		})
+	}
+
+	if err := validateFocusSets(input.FocusSets); err != nil {
+		return err
+	}
+
+	// Run all the focus sets in parallel, each one in its own conformance container.
+	var wg sync.WaitGroup
+	errs := make([]error, len(input.FocusSets))
+	for i := range input.FocusSets {
+		focusSet := input.FocusSets[i]
+		opts := runOptions{
+			reportDir:    path.Join(input.ArtifactsDirectory, "kubetest", focusSet.Name),
+			reportPrefix: fmt.Sprintf("kubetest.%s.", focusSet.Name),
+			ginkgoNodes:  focusSet.GinkgoNodes,
+			config:       config.withFocusSet(focusSet),
+		}
+		if opts.ginkgoNodes == 0 {
+			opts.ginkgoNodes = input.GinkgoNodes
+		}
+
+		wg.Add(1)
+		go func(i int) {
+			defer ginkgo.GinkgoRecover()
+			defer wg.Done()
+			if err := run(ctx, input, opts); err != nil {
+				errs[i] = errors.Wrapf(err, "failed to run conformance focus set %q", input.FocusSets[i].Name)
+			}
+		}(i)
+	}
+	wg.Wait()
+	return kerrors.NewAggregate(errs)
+}
+
+// runOptions are the options of a single kubetest run.
+type runOptions struct {
+	reportDir    string
+	reportPrefix string
+	ginkgoNodes  int
+	config       kubetestConfig
+}
+
+// run executes a single kubetest run in a conformance container.
+func run(ctx context.Context, input RunInput, opts runOptions) error {
+	reportDir := opts.reportDir
 	outputDir := path.Join(reportDir, "e2e-output")
 	kubetestConfigDir := path.Join(reportDir, "config")
 	if err := os.MkdirAll(outputDir, 0o750); err != nil {
@@ -112,15 +198,10 @@ func Run(ctx context.Context, input RunInput) error {
 		return err
 	}
 	ginkgoVars := map[string]string{
-		"nodes":             strconv.Itoa(input.GinkgoNodes),
+		"nodes":             strconv.Itoa(opts.ginkgoNodes),
 		"slowSpecThreshold": strconv.Itoa(input.GinkgoSlowSpecThreshold),
 	}
 
-	config, err := parseKubetestConfig(input.ConfigFilePath)
-	if err != nil {
-		return err
-	}
-
 	tmpKubeConfigPath, err := dockeriseKubeconfig(kubetestConfigDir, input.ClusterProxy.GetKubeconfigPath())
 	if err != nil {
 		return err
@@ -132,14 +213,11 @@ func Run(ctx context.Context, input RunInput) error {
 		"report-dir":           "/output",
 		"e2e-output-dir":       "/output/e2e-output",
 		"dump-logs-on-failure": "false",
-		"report-prefix":        "kubetest.",
+		"report-prefix":        opts.reportPrefix,
 		"num-nodes":            strconv.FormatInt(int64(input.NumberOfNodes), 10),
 	}
 	ginkgoArgs := buildArgs(ginkgoVars, "-")
 	e2eArgs := buildArgs(e2eVars, "--")
-	if input.ConformanceImage == "" {
-		input.ConformanceImage = versionToConformanceImage(input.KubernetesVersion)
-	}
 	volumeMounts := map[string]string{
 		tmpKubeConfigPath: "/tmp/kubeconfig",
 		reportDir:         "/output",
@@ -168,18 +246,17 @@ func Run(ctx context.Context, input RunInput) error {
 		"/usr/local/bin/e2e.test",
 		"--")
 	args = append(args, e2eArgs...)
-	args = append(args, config.toFlags()...)
+	args = append(args, opts.config.toFlags()...)
 
 	// Get our current working directory. Just for information, so we don't need
 	// to worry about errors at this point.
 	cwd, _ := os.Getwd()
 	ginkgoextensions.Byf("Running e2e test: dir=%s, command=%q", cwd, args)
 
-	containerRuntime, err := container.NewDockerClient()
+	containerRuntime, err := container.RuntimeFrom(ctx)
 	if err != nil {
 		return errors.Wrap(err, "Unable to run conformance tests")
 	}
-	ctx = container.RuntimeInto(ctx, containerRuntime)
 
 	err = containerRuntime.RunContainer(ctx, &container.RunContainerInput{
 		Image:           input.ConformanceImage,
@@ -199,12 +276,43 @@ func Run(ctx context.Context, input RunInput) error {
 	return framework.GatherJUnitReports(reportDir, input.ArtifactsDirectory)
 }
 
+// validateFocusSets checks that all the focus sets have a unique name and a focus.
+func validateFocusSets(focusSets []FocusSet) error {
+	names := map[string]bool{}
+	for _, focusSet := range focusSets {
+		if focusSet.Name == "" {
+			return errors.New("focus sets must have a name")
+		}
+		if names[focusSet.Name] {
+			return errors.Errorf("focus set names must be unique, %q is used more than once", focusSet.Name)
+		}
+		names[focusSet.Name] = true
+		if focusSet.Focus == "" {
+			return errors.Errorf("focus set %q must have a focus", focusSet.Name)
+		}
+	}
+	return nil
+}
+
 type kubetestConfig map[string]string
 
 func (c kubetestConfig) toFlags() []string {
 	return buildArgs(c, "-")
 }
 
+// withFocusSet returns a copy of the kubetest config with the focus and skip regexes of the given focus set.
+func (c kubetestConfig) withFocusSet(focusSet FocusSet) kubetestConfig {
+	conf := make(kubetestConfig, len(c))
+	for k, v := range c {
+		conf[k] = v
+	}
+	conf["ginkgo.focus"] = focusSet.Focus
+	if focusSet.Skip != "" {
+		conf["ginkgo.skip"] = focusSet.Skip
+	}
+	return conf
+}
+
 func parseKubetestConfig(kubetestConfigFile string) (kubetestConfig, error) {
 	conf := make(kubetestConfig)
 	data, err := os.ReadFile(kubetestConfigFile) //nolint:gosec
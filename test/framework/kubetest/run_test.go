@@ -0,0 +1,92 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package kubetest
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+)
+
+func TestValidateFocusSets(t *testing.T) {
+	tests := []struct {
+		name      string
+		focusSets []FocusSet
+		wantErr   bool
+	}{
+		{
+			name: "valid focus sets",
+			focusSets: []FocusSet{
+				{Name: "apps", Focus: `\[sig-apps\].*\[Conformance\]`},
+				{Name: "network", Focus: `\[sig-network\].*\[Conformance\]`, Skip: `\[Serial\]`, GinkgoNodes: 2},
+			},
+		},
+		{
+			name:      "focus set without a name",
+			focusSets: []FocusSet{{Focus: `\[Conformance\]`}},
+			wantErr:   true,
+		},
+		{
+			name:      "focus set without a focus",
+			focusSets: []FocusSet{{Name: "apps"}},
+			wantErr:   true,
+		},
+		{
+			name: "focus sets with duplicated names",
+			focusSets: []FocusSet{
+				{Name: "apps", Focus: `\[sig-apps\]`},
+				{Name: "apps", Focus: `\[sig-network\]`},
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			err := validateFocusSets(tt.focusSets)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+		})
+	}
+}
+
+func TestKubetestConfigWithFocusSet(t *testing.T) {
+	g := NewWithT(t)
+
+	config := kubetestConfig{
+		"ginkgo.focus": `\[Conformance\]`,
+		"ginkgo.skip":  `\[Serial\]`,
+		"ginkgo.v":     "true",
+	}
+
+	got := config.withFocusSet(FocusSet{Name: "apps", Focus: `\[sig-apps\]`})
+	g.Expect(got).To(Equal(kubetestConfig{
+		"ginkgo.focus": `\[sig-apps\]`,
+		"ginkgo.skip":  `\[Serial\]`,
+		"ginkgo.v":     "true",
+	}))
+
+	got = config.withFocusSet(FocusSet{Name: "apps", Focus: `\[sig-apps\]`, Skip: `\[Slow\]`})
+	g.Expect(got["ginkgo.skip"]).To(Equal(`\[Slow\]`))
+
+	// The original config is not modified.
+	g.Expect(config["ginkgo.focus"]).To(Equal(`\[Conformance\]`))
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ScaleLoadProfile defines how the operations of a scale test are submitted.
+type ScaleLoadProfile string
+
+const (
+	// ScaleLoadProfileBurst submits all the operations at once; the rate is only limited by the concurrency.
+	ScaleLoadProfileBurst ScaleLoadProfile = "burst"
+
+	// ScaleLoadProfileRamp submits the operations at a constant rate over a ramp duration.
+	ScaleLoadProfileRamp ScaleLoadProfile = "ramp"
+)
+
+const (
+	processCPUSecondsMetric          = "process_cpu_seconds_total"
+	processResidentMemoryBytesMetric = "process_resident_memory_bytes"
+)
+
+// ScaleLatencyStats summarizes the latencies of the operations of a scale test.
+// All the latencies are in seconds.
+type ScaleLatencyStats struct {
+	Count int     `json:"count"`
+	Min   float64 `json:"min"`
+	Max   float64 `json:"max"`
+	Mean  float64 `json:"mean"`
+	P50   float64 `json:"p50"`
+	P90   float64 `json:"p90"`
+	P99   float64 `json:"p99"`
+}
+
+// NewScaleLatencyStats computes the ScaleLatencyStats for the given latencies.
+func NewScaleLatencyStats(latencies []time.Duration) ScaleLatencyStats {
+	if len(latencies) == 0 {
+		return ScaleLatencyStats{}
+	}
+
+	sorted := make([]time.Duration, len(latencies))
+	copy(sorted, latencies)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, l := range sorted {
+		total += l
+	}
+
+	// percentile uses the nearest-rank method.
+	percentile := func(p int) float64 {
+		rank := (p*len(sorted) + 99) / 100
+		if rank < 1 {
+			rank = 1
+		}
+		return sorted[rank-1].Seconds()
+	}
+
+	return ScaleLatencyStats{
+		Count: len(sorted),
+		Min:   sorted[0].Seconds(),
+		Max:   sorted[len(sorted)-1].Seconds(),
+		Mean:  (total / time.Duration(len(sorted))).Seconds(),
+		P50:   percentile(50),
+		P90:   percentile(90),
+		P99:   percentile(99),
+	}
+}
+
+// ControllerResourceUsage is the resource usage of a controller Pod, as reported by its process metrics.
+type ControllerResourceUsage struct {
+	Deployment          string  `json:"deployment"`
+	Namespace           string  `json:"namespace"`
+	Pod                 string  `json:"pod"`
+	CPUSeconds          float64 `json:"cpuSeconds"`
+	ResidentMemoryBytes float64 `json:"residentMemoryBytes"`
+	Error               string  `json:"error,omitempty"`
+}
+
+// GetControllerResourceUsageInput is the input for GetControllerResourceUsage.
+type GetControllerResourceUsageInput struct {
+	Lister      Lister
+	ClientSet   *kubernetes.Clientset
+	Deployments []*appsv1.Deployment
+}
+
+// GetControllerResourceUsage returns the resource usage of the Pods of the given controller Deployments.
+// It expects to find port 8080 open on the controller, like WatchPodMetrics.
+// Failing to get the metrics of a Pod does not cause the test to fail; the error is reported in the result instead.
+func GetControllerResourceUsage(ctx context.Context, input GetControllerResourceUsageInput) []ControllerResourceUsage {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for GetControllerResourceUsage")
+	Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for GetControllerResourceUsage")
+	Expect(input.ClientSet).NotTo(BeNil(), "input.ClientSet is required for GetControllerResourceUsage")
+
+	usage := []ControllerResourceUsage{}
+	for _, deployment := range input.Deployments {
+		for _, pod := range listDeploymentPods(ctx, input.Lister, deployment) {
+			podUsage := ControllerResourceUsage{
+				Deployment: deployment.Name,
+				Namespace:  pod.Namespace,
+				Pod:        pod.Name,
+			}
+
+			data, err := input.ClientSet.CoreV1().RESTClient().Get().
+				Namespace(pod.Namespace).
+				Resource("pods").
+				Name(fmt.Sprintf("%s:8080", pod.Name)).
+				SubResource("proxy").
+				Suffix("metrics").
+				Do(ctx).
+				Raw()
+			if err == nil {
+				podUsage.CPUSeconds, podUsage.ResidentMemoryBytes, err = parseProcessMetrics(data)
+			}
+			if err != nil {
+				podUsage.Error = err.Error()
+			}
+			usage = append(usage, podUsage)
+		}
+	}
+	return usage
+}
+
+// parseProcessMetrics returns the CPU time and the resident memory reported by the process metrics
+// in the Prometheus text format.
+func parseProcessMetrics(data []byte) (cpuSeconds, residentMemoryBytes float64, err error) {
+	var foundCPU, foundMemory bool
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) < 2 || (fields[0] != processCPUSecondsMetric && fields[0] != processResidentMemoryBytesMetric) {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			return 0, 0, errors.Wrapf(err, "failed to parse %s metric", fields[0])
+		}
+		if fields[0] == processCPUSecondsMetric {
+			cpuSeconds, foundCPU = value, true
+		} else {
+			residentMemoryBytes, foundMemory = value, true
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, 0, errors.Wrap(err, "failed to read metrics")
+	}
+	if !foundCPU || !foundMemory {
+		return 0, 0, errors.Errorf("metrics %s and %s not found", processCPUSecondsMetric, processResidentMemoryBytesMetric)
+	}
+	return cpuSeconds, residentMemoryBytes, nil
+}
+
+// WriteScaleResults writes the results of a scale test as JSON to the given path.
+func WriteScaleResults(path string, results any) {
+	data, err := json.MarshalIndent(results, "", "  ")
+	Expect(err).NotTo(HaveOccurred(), "Failed to marshal scale results")
+	Expect(os.MkdirAll(filepath.Dir(path), 0750)).To(Succeed(), "Failed to create folder for scale results")
+	Expect(os.WriteFile(path, data, 0600)).To(Succeed(), "Failed to write scale results to %s", path)
+}
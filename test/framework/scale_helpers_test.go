@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+
+	"sigs.k8s.io/cluster-api/test/framework"
+)
+
+func TestNewScaleLatencyStats(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(framework.NewScaleLatencyStats(nil)).To(Equal(framework.ScaleLatencyStats{}))
+
+	latencies := []time.Duration{}
+	for i := 100; i >= 1; i-- {
+		latencies = append(latencies, time.Duration(i)*time.Second)
+	}
+	g.Expect(framework.NewScaleLatencyStats(latencies)).To(Equal(framework.ScaleLatencyStats{
+		Count: 100,
+		Min:   1,
+		Max:   100,
+		Mean:  50.5,
+		P50:   50,
+		P90:   90,
+		P99:   99,
+	}))
+}
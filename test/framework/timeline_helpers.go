@@ -0,0 +1,351 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework
+
+import (
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/onsi/ginkgo/v2/types"
+	. "github.com/onsi/gomega"
+	"github.com/pkg/errors"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	controlplanev1 "sigs.k8s.io/cluster-api/controlplane/kubeadm/api/v1beta1"
+)
+
+const (
+	// TimelineConditionSource is the source of the intervals reporting the state of a condition.
+	TimelineConditionSource = "Condition"
+	// TimelineRolloutSource is the source of the intervals reporting a rollout of a MachineDeployment or a KubeadmControlPlane.
+	TimelineRolloutSource = "Rollout"
+	// TimelineStepSource is the source of the intervals reporting the intervention points of a test, e.g. the steps of a spec.
+	TimelineStepSource = "Step"
+
+	defaultTimelinePollInterval = 5 * time.Second
+)
+
+// TimelineInterval is an interval of the timeline of a test; the intervals of the intervention points
+// of a test have the same start and end time.
+type TimelineInterval struct {
+	Source  string    `json:"source"`
+	Locator string    `json:"locator"`
+	Type    string    `json:"type,omitempty"`
+	Status  string    `json:"status,omitempty"`
+	Reason  string    `json:"reason,omitempty"`
+	Message string    `json:"message,omitempty"`
+	From    time.Time `json:"from"`
+	To      time.Time `json:"to"`
+}
+
+// Timeline records the intervals of a test, e.g. how long the conditions of the Cluster API objects
+// have been in each state, how long rollouts took and when the test intervened, so they can be
+// correlated with each other when analyzing flakes.
+type Timeline struct {
+	lock      sync.Mutex
+	intervals []TimelineInterval
+	// open tracks the intervals which are not ended yet, by source, locator and type.
+	open map[string]int
+	now  func() time.Time
+}
+
+// NewTimeline returns a new Timeline.
+func NewTimeline() *Timeline {
+	return &Timeline{
+		open: map[string]int{},
+		now:  time.Now,
+	}
+}
+
+// Mark records an intervention point of the test.
+func (t *Timeline) Mark(locator, message string) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	t.intervals = append(t.intervals, TimelineInterval{
+		Source:  TimelineStepSource,
+		Locator: locator,
+		Message: message,
+		From:    now,
+		To:      now,
+	})
+}
+
+// MarkSpecEvents records the steps of a spec, e.g. the ones from By, as intervention points.
+func (t *Timeline) MarkSpecEvents(report types.SpecReport) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	for _, e := range report.SpecEvents {
+		if e.SpecEventType != types.SpecEventByStart {
+			continue
+		}
+		t.intervals = append(t.intervals, TimelineInterval{
+			Source:  TimelineStepSource,
+			Locator: report.FullText(),
+			Message: e.Message,
+			From:    e.TimelineLocation.Time,
+			To:      e.TimelineLocation.Time,
+		})
+	}
+}
+
+// Observe records the current state of a source for a locator: if it differs from the state of the current
+// interval, the current interval is ended and a new one is started; if inProgress is false, the current interval
+// is ended without starting a new one.
+func (t *Timeline) Observe(interval TimelineInterval, inProgress bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	key := timelineKey(interval.Source, interval.Locator, interval.Type)
+	if i, ok := t.open[key]; ok {
+		current := t.intervals[i]
+		if inProgress && current.Status == interval.Status && current.Reason == interval.Reason && current.Message == interval.Message {
+			return
+		}
+		t.intervals[i].To = now
+		delete(t.open, key)
+	}
+	if !inProgress {
+		return
+	}
+
+	interval.From = now
+	t.intervals = append(t.intervals, interval)
+	t.open[key] = len(t.intervals) - 1
+}
+
+// Intervals returns the intervals recorded so far, sorted by start time; the intervals which are not
+// ended yet end now.
+func (t *Timeline) Intervals() []TimelineInterval {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	intervals := make([]TimelineInterval, len(t.intervals))
+	copy(intervals, t.intervals)
+	for _, i := range t.open {
+		intervals[i].To = now
+	}
+	sort.SliceStable(intervals, func(i, j int) bool {
+		return intervals[i].From.Before(intervals[j].From)
+	})
+	return intervals
+}
+
+// WriteArtifacts writes the timeline to the folder, both as a JSON list of intervals (timeline.json) and as a
+// JUnit report with a test case for each locator (junit.timeline.xml).
+func (t *Timeline) WriteArtifacts(folder string) error {
+	if err := os.MkdirAll(folder, 0750); err != nil {
+		return errors.Wrapf(err, "failed to create folder %s", folder)
+	}
+
+	intervals := t.Intervals()
+	timelineJSON, err := json.MarshalIndent(intervals, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the timeline")
+	}
+	if err := os.WriteFile(filepath.Join(folder, "timeline.json"), timelineJSON, 0600); err != nil {
+		return errors.Wrap(err, "failed to write the timeline")
+	}
+
+	junitXML, err := xml.MarshalIndent(timelineJUnitSuite(intervals), "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "failed to marshal the timeline JUnit report")
+	}
+	if err := os.WriteFile(filepath.Join(folder, "junit.timeline.xml"), append([]byte(xml.Header), junitXML...), 0600); err != nil {
+		return errors.Wrap(err, "failed to write the timeline JUnit report")
+	}
+	return nil
+}
+
+type junitTestSuite struct {
+	XMLName   xml.Name        `xml:"testsuite"`
+	Name      string          `xml:"name,attr"`
+	Tests     int             `xml:"tests,attr"`
+	TestCases []junitTestCase `xml:"testcase"`
+}
+
+type junitTestCase struct {
+	Name      string  `xml:"name,attr"`
+	Time      float64 `xml:"time,attr"`
+	SystemOut string  `xml:"system-out"`
+}
+
+// timelineJUnitSuite returns a JUnit test suite with a test case for each locator, reporting its intervals.
+func timelineJUnitSuite(intervals []TimelineInterval) junitTestSuite {
+	byLocator := map[string][]TimelineInterval{}
+	locators := []string{}
+	for _, interval := range intervals {
+		if _, ok := byLocator[interval.Locator]; !ok {
+			locators = append(locators, interval.Locator)
+		}
+		byLocator[interval.Locator] = append(byLocator[interval.Locator], interval)
+	}
+	sort.Strings(locators)
+
+	suite := junitTestSuite{Name: "timeline", Tests: len(locators)}
+	for _, locator := range locators {
+		var out strings.Builder
+		first, last := byLocator[locator][0].From, byLocator[locator][0].To
+		for _, interval := range byLocator[locator] {
+			if interval.To.After(last) {
+				last = interval.To
+			}
+			fmt.Fprintf(&out, "%s - %s %s %s %s %s %s\n",
+				interval.From.UTC().Format(time.RFC3339), interval.To.UTC().Format(time.RFC3339),
+				interval.Source, interval.Type, interval.Status, interval.Reason, interval.Message)
+		}
+		suite.TestCases = append(suite.TestCases, junitTestCase{
+			Name:      fmt.Sprintf("[timeline] %s", locator),
+			Time:      last.Sub(first).Seconds(),
+			SystemOut: out.String(),
+		})
+	}
+	return suite
+}
+
+// WatchTimelineInput is the input for WatchTimeline.
+type WatchTimelineInput struct {
+	Lister    Lister
+	Namespace string
+	Timeline  *Timeline
+
+	// PollInterval is the interval between two observations of the objects. Defaults to 5s.
+	PollInterval time.Duration
+}
+
+// WatchTimeline records the conditions of the Clusters, KubeadmControlPlanes, MachineDeployments and Machines in
+// a namespace, and the rollouts of the KubeadmControlPlanes and MachineDeployments, until ctx is done.
+// NOTE: objects are observed at every poll interval, so state changes shorter than the interval might not be recorded.
+func WatchTimeline(ctx context.Context, input WatchTimelineInput) {
+	Expect(ctx).NotTo(BeNil(), "ctx is required for WatchTimeline")
+	Expect(input.Lister).NotTo(BeNil(), "input.Lister is required for WatchTimeline")
+	Expect(input.Namespace).NotTo(BeEmpty(), "input.Namespace is required for WatchTimeline")
+	Expect(input.Timeline).NotTo(BeNil(), "input.Timeline is required for WatchTimeline")
+
+	pollInterval := input.PollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultTimelinePollInterval
+	}
+
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+	for {
+		observeTimeline(ctx, input.Lister, input.Namespace, input.Timeline)
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// observeTimeline records the current state of the objects in a namespace; objects of types that
+// can't be listed, e.g. because the corresponding provider is not installed, are ignored.
+func observeTimeline(ctx context.Context, lister Lister, namespace string, timeline *Timeline) {
+	observed := map[string]bool{}
+	observeConditions := func(kind string, obj client.Object, conditions clusterv1.Conditions) string {
+		locator := fmt.Sprintf("%s %s", kind, klog.KObj(obj))
+		for _, c := range conditions {
+			observed[timelineKey(TimelineConditionSource, locator, string(c.Type))] = true
+			timeline.Observe(TimelineInterval{
+				Source:  TimelineConditionSource,
+				Locator: locator,
+				Type:    string(c.Type),
+				Status:  string(c.Status),
+				Reason:  c.Reason,
+				Message: c.Message,
+			}, true)
+		}
+		return locator
+	}
+	observeRollout := func(locator string, replicas, updatedReplicas int32) {
+		observed[timelineKey(TimelineRolloutSource, locator, "")] = true
+		timeline.Observe(TimelineInterval{
+			Source:  TimelineRolloutSource,
+			Locator: locator,
+		}, updatedReplicas < replicas)
+	}
+
+	clusterList := &clusterv1.ClusterList{}
+	if err := lister.List(ctx, clusterList, client.InNamespace(namespace)); err == nil {
+		for i := range clusterList.Items {
+			c := &clusterList.Items[i]
+			observeConditions("Cluster", c, c.Status.Conditions)
+		}
+	}
+
+	kcpList := &controlplanev1.KubeadmControlPlaneList{}
+	if err := lister.List(ctx, kcpList, client.InNamespace(namespace)); err == nil {
+		for i := range kcpList.Items {
+			kcp := &kcpList.Items[i]
+			locator := observeConditions("KubeadmControlPlane", kcp, kcp.Status.Conditions)
+			observeRollout(locator, kcp.Status.Replicas, kcp.Status.UpdatedReplicas)
+		}
+	}
+
+	mdList := &clusterv1.MachineDeploymentList{}
+	if err := lister.List(ctx, mdList, client.InNamespace(namespace)); err == nil {
+		for i := range mdList.Items {
+			md := &mdList.Items[i]
+			locator := observeConditions("MachineDeployment", md, md.Status.Conditions)
+			observeRollout(locator, md.Status.Replicas, md.Status.UpdatedReplicas)
+		}
+	}
+
+	machineList := &clusterv1.MachineList{}
+	if err := lister.List(ctx, machineList, client.InNamespace(namespace)); err == nil {
+		for i := range machineList.Items {
+			m := &machineList.Items[i]
+			observeConditions("Machine", m, m.Status.Conditions)
+		}
+	}
+
+	timeline.endUnobserved(observed)
+}
+
+// endUnobserved ends the current intervals of conditions and rollouts which have not been observed, e.g.
+// because the corresponding condition has been removed or the object has been deleted.
+func (t *Timeline) endUnobserved(observed map[string]bool) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+
+	now := t.now()
+	for key, i := range t.open {
+		if !observed[key] {
+			t.intervals[i].To = now
+			delete(t.open, key)
+		}
+	}
+}
+
+func timelineKey(source, locator, typ string) string {
+	return strings.Join([]string{source, locator, typ}, "/")
+}
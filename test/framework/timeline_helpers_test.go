@@ -0,0 +1,112 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package framework_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	. "github.com/onsi/gomega/gstruct"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/test/framework"
+)
+
+func TestWatchTimeline(t *testing.T) {
+	// WatchTimeline uses the global Gomega instance to validate its input.
+	RegisterTestingT(t)
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"},
+		Status: clusterv1.ClusterStatus{Conditions: clusterv1.Conditions{
+			{Type: clusterv1.ReadyCondition, Status: corev1.ConditionFalse, Reason: "WaitingForControlPlane"},
+		}},
+	}
+	md := &clusterv1.MachineDeployment{
+		ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "md"},
+		Status:     clusterv1.MachineDeploymentStatus{Replicas: 3, UpdatedReplicas: 1},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cluster, md).WithStatusSubresource(cluster, md).Build()
+
+	// Observe the objects once for every call, given that the context is already done.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	timeline := framework.NewTimeline()
+	watch := func() {
+		framework.WatchTimeline(ctx, framework.WatchTimelineInput{
+			Lister:    c,
+			Namespace: "ns",
+			Timeline:  timeline,
+		})
+	}
+
+	watch()
+	timeline.Mark("test", "Upgrading the control plane")
+	watch()
+	g.Expect(timeline.Intervals()).To(HaveLen(3))
+
+	// A new interval is started when the state changes, and the rollout ends when all the replicas are updated.
+	cluster.Status.Conditions[0].Status = corev1.ConditionTrue
+	cluster.Status.Conditions[0].Reason = ""
+	g.Expect(c.Status().Update(context.Background(), cluster)).To(Succeed())
+	md.Status.UpdatedReplicas = 3
+	g.Expect(c.Status().Update(context.Background(), md)).To(Succeed())
+	watch()
+
+	intervals := timeline.Intervals()
+	g.Expect(intervals).To(HaveLen(4))
+	g.Expect(intervals[0]).To(MatchFields(IgnoreExtras, Fields{
+		"Source":  Equal(framework.TimelineConditionSource),
+		"Locator": Equal("Cluster ns/cluster"),
+		"Type":    Equal(string(clusterv1.ReadyCondition)),
+		"Status":  Equal(string(corev1.ConditionFalse)),
+		"Reason":  Equal("WaitingForControlPlane"),
+	}))
+	g.Expect(intervals[1]).To(MatchFields(IgnoreExtras, Fields{
+		"Source":  Equal(framework.TimelineRolloutSource),
+		"Locator": Equal("MachineDeployment ns/md"),
+	}))
+	g.Expect(intervals[2]).To(MatchFields(IgnoreExtras, Fields{
+		"Source":  Equal(framework.TimelineStepSource),
+		"Message": Equal("Upgrading the control plane"),
+	}))
+	g.Expect(intervals[3]).To(MatchFields(IgnoreExtras, Fields{
+		"Source": Equal(framework.TimelineConditionSource),
+		"Status": Equal(string(corev1.ConditionTrue)),
+	}))
+	g.Expect(intervals[3].From).ToNot(BeTemporally("<", intervals[0].To))
+
+	// The timeline is written as JSON and as a JUnit report.
+	folder := t.TempDir()
+	g.Expect(timeline.WriteArtifacts(folder)).To(Succeed())
+	g.Expect(filepath.Join(folder, "timeline.json")).To(BeAnExistingFile())
+	junit, err := os.ReadFile(filepath.Join(folder, "junit.timeline.xml"))
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(string(junit)).To(ContainSubstring(`<testcase name="[timeline] Cluster ns/cluster"`))
+	g.Expect(string(junit)).To(ContainSubstring(`<testcase name="[timeline] MachineDeployment ns/md"`))
+}
@@ -487,6 +487,25 @@ func (d *dockerRuntime) RunContainer(ctx context.Context, runConfig *RunContaine
 		return errors.Wrapf(err, "error creating container %q", runConfig.Name)
 	}
 
+	// Connect the container to the additional networks before starting it, so the
+	// networks are already available when the container boots.
+	for _, n := range runConfig.AdditionalNetworks {
+		endpointSettings := &network.EndpointSettings{}
+		if n.IPv4Address != "" || n.IPv6Address != "" {
+			endpointSettings.IPAMConfig = &network.EndpointIPAMConfig{
+				IPv4Address: n.IPv4Address,
+				IPv6Address: n.IPv6Address,
+			}
+		}
+		if err := d.dockerClient.NetworkConnect(ctx, n.Name, resp.ID, endpointSettings); err != nil {
+			err := errors.Wrapf(err, "error connecting container %q to network %q", runConfig.Name, n.Name)
+			if reterr := d.dockerClient.ContainerRemove(ctx, resp.ID, types.ContainerRemoveOptions{Force: true, RemoveVolumes: true}); reterr != nil {
+				return kerrors.NewAggregate([]error{err, errors.Wrapf(reterr, "error deleting container")})
+			}
+			return err
+		}
+	}
+
 	var containerOutput types.HijackedResponse
 	if output != nil {
 		// Read out any output from the container
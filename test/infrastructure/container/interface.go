@@ -54,6 +54,16 @@ type Mount struct {
 	ReadOnly bool
 }
 
+// Network contains the details of an additional network to connect the container to.
+type Network struct {
+	// Name is the name of the network to connect to.
+	Name string
+	// IPv4Address is the static IPv4 address to assign to the container on the network, if any.
+	IPv4Address string
+	// IPv6Address is the static IPv6 address to assign to the container on the network, if any.
+	IPv6Address string
+}
+
 // PortMapping contains port mapping information for the container.
 type PortMapping struct {
 	// ContainerPort is the port in the container to map to.
@@ -74,6 +84,8 @@ type RunContainerInput struct {
 	Name string
 	// Network is the name of the network to connect to.
 	Network string
+	// AdditionalNetworks is a list of networks to connect to in addition to Network.
+	AdditionalNetworks []Network
 	// User is the user name to run as.
 	User string
 	// Group is the user group to run as.
@@ -20,6 +20,7 @@ import (
 	apiconversion "k8s.io/apimachinery/pkg/conversion"
 	"sigs.k8s.io/controller-runtime/pkg/conversion"
 
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/api/v1beta1"
 	utilconversion "sigs.k8s.io/cluster-api/util/conversion"
 )
@@ -41,6 +42,9 @@ func (src *DockerCluster) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.LoadBalancer.CustomHAProxyConfigTemplateRef = restored.Spec.LoadBalancer.CustomHAProxyConfigTemplateRef
 	}
 
+	restoreFailureDomains(dst.Spec.FailureDomains, restored.Spec.FailureDomains)
+	restoreFailureDomains(dst.Status.FailureDomains, restored.Status.FailureDomains)
+
 	return nil
 }
 
@@ -89,6 +93,8 @@ func (src *DockerClusterTemplate) ConvertTo(dstRaw conversion.Hub) error {
 		dst.Spec.Template.Spec.LoadBalancer.CustomHAProxyConfigTemplateRef = restored.Spec.Template.Spec.LoadBalancer.CustomHAProxyConfigTemplateRef
 	}
 
+	restoreFailureDomains(dst.Spec.Template.Spec.FailureDomains, restored.Spec.Template.Spec.FailureDomains)
+
 	return nil
 }
 
@@ -122,13 +128,33 @@ func (dst *DockerClusterTemplateList) ConvertFrom(srcRaw conversion.Hub) error {
 func (src *DockerMachine) ConvertTo(dstRaw conversion.Hub) error {
 	dst := dstRaw.(*infrav1.DockerMachine)
 
-	return Convert_v1alpha4_DockerMachine_To_v1beta1_DockerMachine(src, dst, nil)
+	if err := Convert_v1alpha4_DockerMachine_To_v1beta1_DockerMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	// Manually restore data.
+	restored := &infrav1.DockerMachine{}
+	if ok, err := utilconversion.UnmarshalData(src, restored); err != nil || !ok {
+		return err
+	}
+
+	dst.Spec.Networks = restored.Spec.Networks
+
+	return nil
 }
 
 func (dst *DockerMachine) ConvertFrom(srcRaw conversion.Hub) error {
 	src := srcRaw.(*infrav1.DockerMachine)
 
-	return Convert_v1beta1_DockerMachine_To_v1alpha4_DockerMachine(src, dst, nil)
+	if err := Convert_v1beta1_DockerMachine_To_v1alpha4_DockerMachine(src, dst, nil); err != nil {
+		return err
+	}
+
+	if err := utilconversion.MarshalData(src, dst); err != nil {
+		return err
+	}
+
+	return nil
 }
 
 func (src *DockerMachineList) ConvertTo(dstRaw conversion.Hub) error {
@@ -157,6 +183,7 @@ func (src *DockerMachineTemplate) ConvertTo(dstRaw conversion.Hub) error {
 	}
 
 	dst.Spec.Template.ObjectMeta = restored.Spec.Template.ObjectMeta
+	dst.Spec.Template.Spec.Networks = restored.Spec.Template.Spec.Networks
 
 	return nil
 }
@@ -201,3 +228,20 @@ func Convert_v1beta1_DockerMachineTemplateResource_To_v1alpha4_DockerMachineTemp
 func Convert_v1beta1_DockerLoadBalancer_To_v1alpha4_DockerLoadBalancer(in *infrav1.DockerLoadBalancer, out *DockerLoadBalancer, s apiconversion.Scope) error {
 	return autoConvert_v1beta1_DockerLoadBalancer_To_v1alpha4_DockerLoadBalancer(in, out, s)
 }
+
+func Convert_v1beta1_DockerMachineSpec_To_v1alpha4_DockerMachineSpec(in *infrav1.DockerMachineSpec, out *DockerMachineSpec, s apiconversion.Scope) error {
+	// NOTE: custom conversion func is required because spec.networks has been added in v1beta1.
+	return autoConvert_v1beta1_DockerMachineSpec_To_v1alpha4_DockerMachineSpec(in, out, s)
+}
+
+// restoreFailureDomains restores the fields of the failure domains which do not exist in v1alpha4.
+func restoreFailureDomains(dst, restored clusterv1.FailureDomains) {
+	for id, restoredFailureDomain := range restored {
+		if failureDomain, ok := dst[id]; ok {
+			failureDomain.Region = restoredFailureDomain.Region
+			failureDomain.Zone = restoredFailureDomain.Zone
+			failureDomain.Weight = restoredFailureDomain.Weight
+			dst[id] = failureDomain
+		}
+	}
+}
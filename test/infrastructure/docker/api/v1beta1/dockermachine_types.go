@@ -49,6 +49,12 @@ type DockerMachineSpec struct {
 	// +optional
 	ExtraMounts []Mount `json:"extraMounts,omitempty"`
 
+	// Networks describes additional Docker networks the node container should be connected to,
+	// in addition to the default network used by CAPD.
+	// This can be used to simulate nodes with multiple NICs.
+	// +optional
+	Networks []Network `json:"networks,omitempty"`
+
 	// Bootstrapped is true when the kubeadm bootstrapping has been run
 	// against this machine
 	//
@@ -74,6 +80,22 @@ type Mount struct {
 	Readonly bool `json:"readOnly,omitempty"`
 }
 
+// Network specifies an additional Docker network to connect a container to.
+type Network struct {
+	// Name of the Docker network. The network must already exist.
+	Name string `json:"name"`
+
+	// IPv4Address is the static IPv4 address to assign to the container on this network.
+	// If empty, the address is assigned by Docker.
+	// +optional
+	IPv4Address string `json:"ipv4Address,omitempty"`
+
+	// IPv6Address is the static IPv6 address to assign to the container on this network.
+	// If empty, the address is assigned by Docker.
+	// +optional
+	IPv6Address string `json:"ipv6Address,omitempty"`
+}
+
 // DockerMachineStatus defines the observed state of DockerMachine.
 type DockerMachineStatus struct {
 	// Ready denotes that the machine (docker container) is ready
@@ -0,0 +1,139 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1beta1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+const (
+	// DefaultExhaustionThresholdPercent is the percentage of used addresses above which an InClusterIPPool
+	// is considered near exhaustion when spec.exhaustionThresholdPercent is not set.
+	DefaultExhaustionThresholdPercent int32 = 90
+)
+
+// Conditions and condition Reasons for the InClusterIPPool object.
+
+const (
+	// AddressesAvailableCondition documents the availability of free addresses in an InClusterIPPool.
+	AddressesAvailableCondition clusterv1.ConditionType = "AddressesAvailable"
+
+	// PoolNearExhaustionReason (Severity=Warning) documents an InClusterIPPool with a percentage of used
+	// addresses above the exhaustion threshold.
+	PoolNearExhaustionReason = "PoolNearExhaustion"
+
+	// PoolExhaustedReason (Severity=Error) documents an InClusterIPPool without free addresses;
+	// this reason is also used for IPAddressClaims that cannot be fulfilled.
+	PoolExhaustedReason = "PoolExhausted"
+
+	// InvalidPoolSpecReason (Severity=Error) documents an InClusterIPPool with addresses that cannot be parsed.
+	InvalidPoolSpecReason = "InvalidPoolSpec"
+)
+
+// InClusterIPPoolSpec defines the desired state of InClusterIPPool.
+type InClusterIPPoolSpec struct {
+	// Addresses is the list of addresses that can be allocated from the pool.
+	// Each entry can be a single IP address (e.g. 10.0.0.1), a range (e.g. 10.0.0.10-10.0.0.20)
+	// or a CIDR (e.g. 10.0.0.0/24); IPv4 and IPv6 addresses are supported.
+	// +kubebuilder:validation:MinItems=1
+	Addresses []string `json:"addresses"`
+
+	// Prefix is the network prefix to use for the allocated addresses.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=128
+	Prefix int `json:"prefix"`
+
+	// Gateway is the network gateway of the network the addresses are from.
+	// The gateway address is never allocated.
+	// +optional
+	Gateway string `json:"gateway,omitempty"`
+
+	// ExhaustionThresholdPercent is the percentage of used addresses above which the pool is considered
+	// near exhaustion; when the threshold is exceeded the AddressesAvailable condition is set to false
+	// and a warning event is emitted. Defaults to 90.
+	// +optional
+	// +kubebuilder:validation:Minimum=1
+	// +kubebuilder:validation:Maximum=100
+	ExhaustionThresholdPercent *int32 `json:"exhaustionThresholdPercent,omitempty"`
+}
+
+// InClusterIPPoolStatus defines the observed state of InClusterIPPool.
+type InClusterIPPoolStatus struct {
+	// Addresses reports the usage of the addresses in the pool.
+	// +optional
+	Addresses InClusterIPPoolAddressesStatus `json:"addresses,omitempty"`
+
+	// Conditions defines current service state of the InClusterIPPool.
+	// +optional
+	Conditions clusterv1.Conditions `json:"conditions,omitempty"`
+}
+
+// InClusterIPPoolAddressesStatus reports the usage of the addresses in an InClusterIPPool.
+type InClusterIPPoolAddressesStatus struct {
+	// Total is the number of addresses in the pool.
+	Total int `json:"total"`
+
+	// Used is the number of addresses allocated from the pool.
+	Used int `json:"used"`
+
+	// Free is the number of addresses in the pool still available for allocation.
+	Free int `json:"free"`
+}
+
+// +kubebuilder:resource:path=inclusterippools,scope=Namespaced,categories=cluster-api
+// +kubebuilder:storageversion
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:printcolumn:name="Total",type="integer",JSONPath=".status.addresses.total",description="Number of addresses in the pool"
+// +kubebuilder:printcolumn:name="Free",type="integer",JSONPath=".status.addresses.free",description="Number of free addresses in the pool"
+// +kubebuilder:printcolumn:name="Used",type="integer",JSONPath=".status.addresses.used",description="Number of used addresses in the pool"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp",description="Time duration since creation of InClusterIPPool"
+
+// InClusterIPPool is the Schema for the inclusterippools API.
+// It is a reference implementation of an IPAM pool fulfilling IPAddressClaims, intended for testing.
+type InClusterIPPool struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   InClusterIPPoolSpec   `json:"spec,omitempty"`
+	Status InClusterIPPoolStatus `json:"status,omitempty"`
+}
+
+// GetConditions returns the set of conditions for this object.
+func (p *InClusterIPPool) GetConditions() clusterv1.Conditions {
+	return p.Status.Conditions
+}
+
+// SetConditions sets the conditions on this object.
+func (p *InClusterIPPool) SetConditions(conditions clusterv1.Conditions) {
+	p.Status.Conditions = conditions
+}
+
+// +kubebuilder:object:root=true
+
+// InClusterIPPoolList contains a list of InClusterIPPool.
+type InClusterIPPoolList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []InClusterIPPool `json:"items"`
+}
+
+func init() {
+	objectTypes = append(objectTypes, &InClusterIPPool{}, &InClusterIPPoolList{})
+}
@@ -280,3 +280,125 @@ func (in *DockerMachinePoolTemplateSpec) DeepCopy() *DockerMachinePoolTemplateSp
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterIPPool) DeepCopyInto(out *InClusterIPPool) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterIPPool.
+func (in *InClusterIPPool) DeepCopy() *InClusterIPPool {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterIPPool)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InClusterIPPool) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterIPPoolAddressesStatus) DeepCopyInto(out *InClusterIPPoolAddressesStatus) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterIPPoolAddressesStatus.
+func (in *InClusterIPPoolAddressesStatus) DeepCopy() *InClusterIPPoolAddressesStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterIPPoolAddressesStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterIPPoolList) DeepCopyInto(out *InClusterIPPoolList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]InClusterIPPool, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterIPPoolList.
+func (in *InClusterIPPoolList) DeepCopy() *InClusterIPPoolList {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterIPPoolList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *InClusterIPPoolList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterIPPoolSpec) DeepCopyInto(out *InClusterIPPoolSpec) {
+	*out = *in
+	if in.Addresses != nil {
+		in, out := &in.Addresses, &out.Addresses
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ExhaustionThresholdPercent != nil {
+		in, out := &in.ExhaustionThresholdPercent, &out.ExhaustionThresholdPercent
+		*out = new(int32)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterIPPoolSpec.
+func (in *InClusterIPPoolSpec) DeepCopy() *InClusterIPPoolSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterIPPoolSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InClusterIPPoolStatus) DeepCopyInto(out *InClusterIPPoolStatus) {
+	*out = *in
+	out.Addresses = in.Addresses
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make(cluster_apiapiv1beta1.Conditions, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InClusterIPPoolStatus.
+func (in *InClusterIPPoolStatus) DeepCopy() *InClusterIPPoolStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(InClusterIPPoolStatus)
+	in.DeepCopyInto(out)
+	return out
+}
@@ -51,3 +51,19 @@ func (r *DockerMachinePoolReconciler) SetupWithManager(ctx context.Context, mgr
 		WatchFilterValue: r.WatchFilterValue,
 	}).SetupWithManager(ctx, mgr, options)
 }
+
+// InClusterIPPoolReconciler reconciles an InClusterIPPool object.
+type InClusterIPPoolReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+}
+
+// SetupWithManager will add watches for this controller.
+func (r *InClusterIPPoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	return (&dockermachinepoolcontrollers.InClusterIPPoolReconciler{
+		Client:           r.Client,
+		WatchFilterValue: r.WatchFilterValue,
+	}).SetupWithManager(ctx, mgr, options)
+}
@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -48,6 +49,7 @@ import (
 	infraexpv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/exp/api/v1beta1"
 	"sigs.k8s.io/cluster-api/test/infrastructure/docker/internal/docker"
 	"sigs.k8s.io/cluster-api/util"
+	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/conditions"
 	"sigs.k8s.io/cluster-api/util/patch"
 	"sigs.k8s.io/cluster-api/util/predicates"
@@ -269,6 +271,10 @@ func (r *DockerMachinePoolReconciler) reconcileNormal(ctx context.Context, clust
 		return ctrl.Result{}, nil
 	}
 
+	if err := r.reconcileBootstrapDataRotation(ctx, machinePool, dockerMachinePool); err != nil {
+		return ctrl.Result{}, err
+	}
+
 	if machinePool.Spec.Replicas == nil {
 		machinePool.Spec.Replicas = pointer.Int32(1)
 	}
@@ -325,6 +331,28 @@ func (r *DockerMachinePoolReconciler) reconcileNormal(ctx context.Context, clust
 	return ctrl.Result{RequeueAfter: requeueAfter}, nil
 }
 
+// reconcileBootstrapDataRotation acknowledges the upcoming rotation of the bootstrap data signaled by the bootstrap provider.
+// Docker containers are bootstrapped with the bootstrap data available at creation time, so the rotated bootstrap data
+// are picked up by the containers created after the rotation, while existing containers are not rolled out.
+func (r *DockerMachinePoolReconciler) reconcileBootstrapDataRotation(ctx context.Context, machinePool *expv1.MachinePool, dockerMachinePool *infraexpv1.DockerMachinePool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	secret := &corev1.Secret{}
+	key := client.ObjectKey{Namespace: machinePool.Namespace, Name: *machinePool.Spec.Template.Spec.Bootstrap.DataSecretName}
+	if err := r.Client.Get(ctx, key, secret); err != nil {
+		return errors.Wrapf(err, "failed to retrieve bootstrap data secret %s", klog.KRef(key.Namespace, key.Name))
+	}
+
+	rotation, ok := secret.GetAnnotations()[clusterv1.BootstrapDataRotationAnnotation]
+	if !ok || dockerMachinePool.GetAnnotations()[clusterv1.BootstrapDataRotationAcknowledgedAnnotation] == rotation {
+		return nil
+	}
+
+	log.Info("Acknowledging bootstrap data rotation", "Secret", klog.KObj(secret), "rotationTime", rotation)
+	annotations.AddAnnotations(dockerMachinePool, map[string]string{clusterv1.BootstrapDataRotationAcknowledgedAnnotation: rotation})
+	return nil
+}
+
 func getDockerMachines(ctx context.Context, c client.Client, cluster clusterv1.Cluster, machinePool expv1.MachinePool, dockerMachinePool infraexpv1.DockerMachinePool) (*infrav1.DockerMachineList, error) {
 	dockerMachineList := &infrav1.DockerMachineList{}
 	labels := map[string]string{
@@ -0,0 +1,106 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"net/netip"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/util/sets"
+)
+
+// maxPoolSize is the maximum number of addresses an InClusterIPPool can contain.
+// NOTE: InClusterIPPool is meant for tests, so we are intentionally keeping pools small
+// to avoid computing huge address sets in memory.
+const maxPoolSize = 1 << 16
+
+// poolAddresses returns the list of addresses defined by an InClusterIPPool, excluding the gateway;
+// addresses are returned in the same order they are defined in the pool.
+func poolAddresses(addresses []string, gateway string) ([]netip.Addr, error) {
+	exclude := sets.Set[netip.Addr]{}
+	if gateway != "" {
+		gw, err := netip.ParseAddr(gateway)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse gateway %q", gateway)
+		}
+		exclude.Insert(gw)
+	}
+
+	seen := sets.Set[netip.Addr]{}
+	ret := []netip.Addr{}
+	for _, a := range addresses {
+		start, end, err := parseAddressRange(a)
+		if err != nil {
+			return nil, err
+		}
+		for ip := start; ip.IsValid() && ip.Compare(end) <= 0; ip = ip.Next() {
+			if exclude.Has(ip) || seen.Has(ip) {
+				continue
+			}
+			if len(ret) >= maxPoolSize {
+				return nil, errors.Errorf("pool cannot contain more than %d addresses", maxPoolSize)
+			}
+			seen.Insert(ip)
+			ret = append(ret, ip)
+		}
+	}
+	return ret, nil
+}
+
+// parseAddressRange parses a single IP address, a range in the form <start>-<end> or a CIDR
+// and returns the first and the last address included.
+func parseAddressRange(s string) (netip.Addr, netip.Addr, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case strings.Contains(s, "/"):
+		prefix, err := netip.ParsePrefix(s)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, errors.Wrapf(err, "failed to parse CIDR %q", s)
+		}
+		prefix = prefix.Masked()
+		if prefix.Addr().BitLen()-prefix.Bits() > 16 {
+			return netip.Addr{}, netip.Addr{}, errors.Errorf("CIDR %q cannot contain more than %d addresses", s, maxPoolSize)
+		}
+		start := prefix.Addr()
+		end := start
+		for next := end.Next(); next.IsValid() && prefix.Contains(next); next = next.Next() {
+			end = next
+		}
+		return start, end, nil
+	case strings.Contains(s, "-"):
+		parts := strings.SplitN(s, "-", 2)
+		start, err := netip.ParseAddr(strings.TrimSpace(parts[0]))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, errors.Wrapf(err, "failed to parse range %q", s)
+		}
+		end, err := netip.ParseAddr(strings.TrimSpace(parts[1]))
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, errors.Wrapf(err, "failed to parse range %q", s)
+		}
+		if start.Is4() != end.Is4() || start.Compare(end) > 0 {
+			return netip.Addr{}, netip.Addr{}, errors.Errorf("invalid range %q", s)
+		}
+		return start, end, nil
+	default:
+		ip, err := netip.ParseAddr(s)
+		if err != nil {
+			return netip.Addr{}, netip.Addr{}, errors.Wrapf(err, "failed to parse address %q", s)
+		}
+		return ip, ip, nil
+	}
+}
@@ -0,0 +1,349 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"sort"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	kerrors "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	infraexpv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+	"sigs.k8s.io/cluster-api/util/predicates"
+)
+
+const inClusterIPPoolKind = "InClusterIPPool"
+
+// InClusterIPPoolReconciler reconciles an InClusterIPPool object, allocating IPAddresses
+// for the IPAddressClaims referencing the pool.
+type InClusterIPPoolReconciler struct {
+	Client client.Client
+
+	// WatchFilterValue is the label value used to filter events prior to reconciliation.
+	WatchFilterValue string
+
+	recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=inclusterippools,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=infrastructure.cluster.x-k8s.io,resources=inclusterippools/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims,verbs=get;list;watch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddressclaims/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=ipam.cluster.x-k8s.io,resources=ipaddresses,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list;watch;create;patch
+
+func (r *InClusterIPPoolReconciler) Reconcile(ctx context.Context, req ctrl.Request) (_ ctrl.Result, rerr error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	// Fetch the InClusterIPPool instance.
+	pool := &infraexpv1.InClusterIPPool{}
+	if err := r.Client.Get(ctx, req.NamespacedName, pool); err != nil {
+		if apierrors.IsNotFound(err) {
+			deletePoolMetrics(req.Namespace, req.Name)
+			return ctrl.Result{}, nil
+		}
+		return ctrl.Result{}, err
+	}
+
+	// Initialize the patch helper
+	patchHelper, err := patch.NewHelper(pool, r.Client)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Always attempt to Patch the InClusterIPPool object and status after each reconciliation.
+	defer func() {
+		if err := patchHelper.Patch(ctx, pool, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+			infraexpv1.AddressesAvailableCondition,
+		}}); err != nil {
+			log.Error(err, "failed to patch InClusterIPPool")
+			if rerr == nil {
+				rerr = err
+			}
+		}
+	}()
+
+	// Addresses allocated from a pool being deleted are garbage collected together with the corresponding claims.
+	if !pool.ObjectMeta.DeletionTimestamp.IsZero() {
+		return ctrl.Result{}, nil
+	}
+
+	return ctrl.Result{}, r.reconcileNormal(ctx, pool)
+}
+
+func (r *InClusterIPPoolReconciler) reconcileNormal(ctx context.Context, pool *infraexpv1.InClusterIPPool) error {
+	log := ctrl.LoggerFrom(ctx)
+
+	addresses, err := poolAddresses(pool.Spec.Addresses, pool.Spec.Gateway)
+	if err != nil {
+		if !conditions.IsFalse(pool, infraexpv1.AddressesAvailableCondition) ||
+			conditions.GetReason(pool, infraexpv1.AddressesAvailableCondition) != infraexpv1.InvalidPoolSpecReason {
+			r.recorder.Eventf(pool, corev1.EventTypeWarning, infraexpv1.InvalidPoolSpecReason, "Invalid addresses: %v", err)
+		}
+		conditions.MarkFalse(pool, infraexpv1.AddressesAvailableCondition, infraexpv1.InvalidPoolSpecReason, clusterv1.ConditionSeverityError, err.Error())
+		// No need to requeue, a change to the pool spec will trigger a new reconcile.
+		return nil
+	}
+
+	// Get the IPAddresses already allocated from this pool.
+	ipAddressList := &ipamv1.IPAddressList{}
+	if err := r.Client.List(ctx, ipAddressList, client.InNamespace(pool.Namespace)); err != nil {
+		return errors.Wrapf(err, "failed to list IPAddresses in namespace %s", pool.Namespace)
+	}
+	used := map[netip.Addr]string{}
+	allocated := map[string]*ipamv1.IPAddress{}
+	for i := range ipAddressList.Items {
+		ipAddress := &ipAddressList.Items[i]
+		if !isInClusterIPPoolRef(ipAddress.Spec.PoolRef, pool.Name) {
+			continue
+		}
+		addr, err := netip.ParseAddr(ipAddress.Spec.Address)
+		if err != nil {
+			log.Info("Ignoring IPAddress with an invalid address", "IPAddress", klog.KObj(ipAddress), "address", ipAddress.Spec.Address)
+			continue
+		}
+		used[addr] = ipAddress.Spec.ClaimRef.Name
+		allocated[ipAddress.Spec.ClaimRef.Name] = ipAddress
+	}
+
+	// Get the IPAddressClaims for this pool, and fulfill them in order of creation.
+	claimList := &ipamv1.IPAddressClaimList{}
+	if err := r.Client.List(ctx, claimList, client.InNamespace(pool.Namespace)); err != nil {
+		return errors.Wrapf(err, "failed to list IPAddressClaims in namespace %s", pool.Namespace)
+	}
+	claims := []*ipamv1.IPAddressClaim{}
+	for i := range claimList.Items {
+		claim := &claimList.Items[i]
+		if !isInClusterIPPoolRef(claim.Spec.PoolRef, pool.Name) || !claim.DeletionTimestamp.IsZero() {
+			continue
+		}
+		claims = append(claims, claim)
+	}
+	sort.Slice(claims, func(i, j int) bool {
+		if !claims[i].CreationTimestamp.Equal(&claims[j].CreationTimestamp) {
+			return claims[i].CreationTimestamp.Before(&claims[j].CreationTimestamp)
+		}
+		return claims[i].Name < claims[j].Name
+	})
+
+	next := 0
+	pending := 0
+	var errs []error
+	for _, claim := range claims {
+		ipAddress, ok := allocated[claim.Name]
+		if !ok {
+			for next < len(addresses) {
+				if _, ok := used[addresses[next]]; !ok {
+					break
+				}
+				next++
+			}
+			if next < len(addresses) {
+				ipAddress, err = r.createIPAddress(ctx, pool, claim, addresses[next])
+				if err != nil {
+					errs = append(errs, err)
+					continue
+				}
+				used[addresses[next]] = claim.Name
+			}
+		}
+		if ipAddress == nil {
+			pending++
+		}
+		if err := r.patchClaim(ctx, pool, claim, ipAddress); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	// Compute the pool usage; only addresses still included in the pool spec are considered.
+	total := len(addresses)
+	usedCount := 0
+	for _, addr := range addresses {
+		if _, ok := used[addr]; ok {
+			usedCount++
+		}
+	}
+	pool.Status.Addresses = infraexpv1.InClusterIPPoolAddressesStatus{
+		Total: total,
+		Used:  usedCount,
+		Free:  total - usedCount,
+	}
+	setPoolMetrics(pool.Namespace, pool.Name, total, usedCount, total-usedCount)
+
+	r.setAddressesAvailableCondition(pool, pending)
+
+	return kerrors.NewAggregate(errs)
+}
+
+// setAddressesAvailableCondition sets the AddressesAvailable condition according to the pool usage,
+// and emits an event every time the pool transitions to near exhaustion or exhausted.
+func (r *InClusterIPPoolReconciler) setAddressesAvailableCondition(pool *infraexpv1.InClusterIPPool, pending int) {
+	threshold := infraexpv1.DefaultExhaustionThresholdPercent
+	if pool.Spec.ExhaustionThresholdPercent != nil {
+		threshold = *pool.Spec.ExhaustionThresholdPercent
+	}
+
+	usage := pool.Status.Addresses
+	previousReason := ""
+	if conditions.IsFalse(pool, infraexpv1.AddressesAvailableCondition) {
+		previousReason = conditions.GetReason(pool, infraexpv1.AddressesAvailableCondition)
+	}
+
+	switch {
+	case usage.Free == 0:
+		message := fmt.Sprintf("%d of %d addresses in use", usage.Used, usage.Total)
+		if pending > 0 {
+			message = fmt.Sprintf("%s, %d IPAddressClaims pending", message, pending)
+		}
+		conditions.MarkFalse(pool, infraexpv1.AddressesAvailableCondition, infraexpv1.PoolExhaustedReason, clusterv1.ConditionSeverityError, message)
+		if previousReason != infraexpv1.PoolExhaustedReason {
+			r.recorder.Event(pool, corev1.EventTypeWarning, infraexpv1.PoolExhaustedReason, fmt.Sprintf("Pool is exhausted: %s", message))
+		}
+	case usage.Used*100 > int(threshold)*usage.Total:
+		message := fmt.Sprintf("%d of %d addresses in use, above the %d%% threshold", usage.Used, usage.Total, threshold)
+		conditions.MarkFalse(pool, infraexpv1.AddressesAvailableCondition, infraexpv1.PoolNearExhaustionReason, clusterv1.ConditionSeverityWarning, message)
+		if previousReason != infraexpv1.PoolNearExhaustionReason {
+			r.recorder.Event(pool, corev1.EventTypeWarning, infraexpv1.PoolNearExhaustionReason, fmt.Sprintf("Pool is near exhaustion: %s", message))
+		}
+	default:
+		conditions.MarkTrue(pool, infraexpv1.AddressesAvailableCondition)
+	}
+}
+
+func (r *InClusterIPPoolReconciler) createIPAddress(ctx context.Context, pool *infraexpv1.InClusterIPPool, claim *ipamv1.IPAddressClaim, addr netip.Addr) (*ipamv1.IPAddress, error) {
+	log := ctrl.LoggerFrom(ctx)
+
+	ipAddress := &ipamv1.IPAddress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      claim.Name,
+			Namespace: claim.Namespace,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(claim, ipamv1.GroupVersion.WithKind("IPAddressClaim")),
+				{
+					APIVersion: infraexpv1.GroupVersion.String(),
+					Kind:       inClusterIPPoolKind,
+					Name:       pool.Name,
+					UID:        pool.UID,
+				},
+			},
+		},
+		Spec: ipamv1.IPAddressSpec{
+			ClaimRef: corev1.LocalObjectReference{Name: claim.Name},
+			PoolRef:  claim.Spec.PoolRef,
+			Address:  addr.String(),
+			Prefix:   pool.Spec.Prefix,
+			Gateway:  pool.Spec.Gateway,
+		},
+	}
+	if err := r.Client.Create(ctx, ipAddress); err != nil {
+		return nil, errors.Wrapf(err, "failed to create IPAddress for IPAddressClaim %s", klog.KObj(claim))
+	}
+	log.Info("Allocated IPAddress", "IPAddressClaim", klog.KObj(claim), "address", ipAddress.Spec.Address)
+	return ipAddress, nil
+}
+
+// patchClaim reports the IPAddress allocated for a claim, if any, in the claim status.
+func (r *InClusterIPPoolReconciler) patchClaim(ctx context.Context, pool *infraexpv1.InClusterIPPool, claim *ipamv1.IPAddressClaim, ipAddress *ipamv1.IPAddress) error {
+	patchHelper, err := patch.NewHelper(claim, r.Client)
+	if err != nil {
+		return err
+	}
+
+	if ipAddress != nil {
+		claim.Status.AddressRef = corev1.LocalObjectReference{Name: ipAddress.Name}
+		conditions.MarkTrue(claim, clusterv1.ReadyCondition)
+	} else {
+		conditions.MarkFalse(claim, clusterv1.ReadyCondition, infraexpv1.PoolExhaustedReason, clusterv1.ConditionSeverityError,
+			"No free addresses in InClusterIPPool %s", pool.Name)
+	}
+
+	if err := patchHelper.Patch(ctx, claim, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{
+		clusterv1.ReadyCondition,
+	}}); err != nil {
+		return errors.Wrapf(err, "failed to patch IPAddressClaim %s", klog.KObj(claim))
+	}
+	return nil
+}
+
+// SetupWithManager will add watches for this controller.
+func (r *InClusterIPPoolReconciler) SetupWithManager(ctx context.Context, mgr ctrl.Manager, options controller.Options) error {
+	err := ctrl.NewControllerManagedBy(mgr).
+		For(&infraexpv1.InClusterIPPool{}).
+		WithOptions(options).
+		WithEventFilter(predicates.ResourceNotPausedAndHasFilterLabel(ctrl.LoggerFrom(ctx), r.WatchFilterValue)).
+		Watches(
+			&ipamv1.IPAddressClaim{},
+			handler.EnqueueRequestsFromMapFunc(ipAddressClaimToInClusterIPPool),
+		).
+		Watches(
+			&ipamv1.IPAddress{},
+			handler.EnqueueRequestsFromMapFunc(ipAddressToInClusterIPPool),
+		).
+		Complete(r)
+	if err != nil {
+		return errors.Wrap(err, "failed setting up with a controller manager")
+	}
+
+	r.recorder = mgr.GetEventRecorderFor("inclusterippool-controller")
+	return nil
+}
+
+func ipAddressClaimToInClusterIPPool(_ context.Context, o client.Object) []ctrl.Request {
+	claim, ok := o.(*ipamv1.IPAddressClaim)
+	if !ok {
+		panic(fmt.Sprintf("Expected an IPAddressClaim but got a %T", o))
+	}
+	return poolRefToInClusterIPPool(claim.Namespace, claim.Spec.PoolRef)
+}
+
+func ipAddressToInClusterIPPool(_ context.Context, o client.Object) []ctrl.Request {
+	ipAddress, ok := o.(*ipamv1.IPAddress)
+	if !ok {
+		panic(fmt.Sprintf("Expected an IPAddress but got a %T", o))
+	}
+	return poolRefToInClusterIPPool(ipAddress.Namespace, ipAddress.Spec.PoolRef)
+}
+
+func poolRefToInClusterIPPool(namespace string, poolRef corev1.TypedLocalObjectReference) []ctrl.Request {
+	if !isInClusterIPPoolRef(poolRef, poolRef.Name) {
+		return nil
+	}
+	return []ctrl.Request{{NamespacedName: types.NamespacedName{Namespace: namespace, Name: poolRef.Name}}}
+}
+
+// isInClusterIPPoolRef returns true if poolRef points to the InClusterIPPool with the given name.
+func isInClusterIPPoolRef(poolRef corev1.TypedLocalObjectReference, name string) bool {
+	return poolRef.APIGroup != nil && *poolRef.APIGroup == infraexpv1.GroupVersion.Group &&
+		poolRef.Kind == inClusterIPPoolKind && poolRef.Name == name
+}
@@ -0,0 +1,208 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"context"
+	"fmt"
+	"net/netip"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"k8s.io/utils/pointer"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	ipamv1 "sigs.k8s.io/cluster-api/exp/ipam/api/v1beta1"
+	infraexpv1 "sigs.k8s.io/cluster-api/test/infrastructure/docker/exp/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestPoolAddresses(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses []string
+		gateway   string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:      "single addresses",
+			addresses: []string{"10.0.0.1", "10.0.0.3"},
+			want:      []string{"10.0.0.1", "10.0.0.3"},
+		},
+		{
+			name:      "range excluding the gateway",
+			addresses: []string{"10.0.0.1-10.0.0.4"},
+			gateway:   "10.0.0.1",
+			want:      []string{"10.0.0.2", "10.0.0.3", "10.0.0.4"},
+		},
+		{
+			name:      "CIDR",
+			addresses: []string{"10.0.0.0/30"},
+			want:      []string{"10.0.0.0", "10.0.0.1", "10.0.0.2", "10.0.0.3"},
+		},
+		{
+			name:      "IPv6 range with duplicates",
+			addresses: []string{"fd00::1-fd00::2", "fd00::2"},
+			want:      []string{"fd00::1", "fd00::2"},
+		},
+		{
+			name:      "invalid address",
+			addresses: []string{"10.0.0.300"},
+			wantErr:   true,
+		},
+		{
+			name:      "invalid range",
+			addresses: []string{"10.0.0.4-10.0.0.1"},
+			wantErr:   true,
+		},
+		{
+			name:      "CIDR too big",
+			addresses: []string{"10.0.0.0/8"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := poolAddresses(tt.addresses, tt.gateway)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+
+			want := []netip.Addr{}
+			for _, a := range tt.want {
+				want = append(want, netip.MustParseAddr(a))
+			}
+			g.Expect(got).To(Equal(want))
+		})
+	}
+}
+
+func TestInClusterIPPoolReconciler(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(infraexpv1.AddToScheme(scheme)).To(Succeed())
+	g.Expect(ipamv1.AddToScheme(scheme)).To(Succeed())
+
+	pool := &infraexpv1.InClusterIPPool{
+		ObjectMeta: metav1.ObjectMeta{Name: "pool", Namespace: metav1.NamespaceDefault},
+		Spec: infraexpv1.InClusterIPPoolSpec{
+			Addresses:                  []string{"10.0.0.1-10.0.0.4"},
+			Prefix:                     24,
+			Gateway:                    "10.0.0.1",
+			ExhaustionThresholdPercent: pointer.Int32(50),
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(pool, newIPAddressClaim("claim-1", 0), newIPAddressClaim("claim-2", 1)).
+		WithStatusSubresource(&infraexpv1.InClusterIPPool{}, &ipamv1.IPAddressClaim{}).
+		Build()
+	recorder := record.NewFakeRecorder(10)
+	r := &InClusterIPPoolReconciler{
+		Client:   c,
+		recorder: recorder,
+	}
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(pool)}
+
+	// Two out of three addresses in use, above the 50% threshold.
+	_, err := r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(c.Get(context.Background(), req.NamespacedName, pool)).To(Succeed())
+	g.Expect(pool.Status.Addresses).To(Equal(infraexpv1.InClusterIPPoolAddressesStatus{Total: 3, Used: 2, Free: 1}))
+	g.Expect(conditions.IsFalse(pool, infraexpv1.AddressesAvailableCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(pool, infraexpv1.AddressesAvailableCondition)).To(Equal(infraexpv1.PoolNearExhaustionReason))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infraexpv1.PoolNearExhaustionReason)))
+	g.Expect(testutil.ToFloat64(poolAddressesFree.WithLabelValues(pool.Namespace, pool.Name))).To(Equal(float64(1)))
+
+	for i, address := range []string{"10.0.0.2", "10.0.0.3"} {
+		claim := &ipamv1.IPAddressClaim{}
+		g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: fmt.Sprintf("claim-%d", i+1)}, claim)).To(Succeed())
+		g.Expect(claim.Status.AddressRef.Name).To(Equal(claim.Name))
+		g.Expect(conditions.IsTrue(claim, clusterv1.ReadyCondition)).To(BeTrue())
+
+		ipAddress := &ipamv1.IPAddress{}
+		g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: claim.Name}, ipAddress)).To(Succeed())
+		g.Expect(ipAddress.Spec.Address).To(Equal(address))
+		g.Expect(ipAddress.Spec.Prefix).To(Equal(24))
+		g.Expect(ipAddress.Spec.Gateway).To(Equal("10.0.0.1"))
+		g.Expect(ipAddress.Spec.ClaimRef.Name).To(Equal(claim.Name))
+	}
+
+	// Reconciling again does not emit another event.
+	_, err = r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(recorder.Events).ToNot(Receive())
+
+	// Two more claims exhaust the pool, and the last one can't be fulfilled.
+	g.Expect(c.Create(context.Background(), newIPAddressClaim("claim-3", 2))).To(Succeed())
+	g.Expect(c.Create(context.Background(), newIPAddressClaim("claim-4", 3))).To(Succeed())
+
+	_, err = r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	g.Expect(c.Get(context.Background(), req.NamespacedName, pool)).To(Succeed())
+	g.Expect(pool.Status.Addresses).To(Equal(infraexpv1.InClusterIPPoolAddressesStatus{Total: 3, Used: 3, Free: 0}))
+	g.Expect(conditions.GetReason(pool, infraexpv1.AddressesAvailableCondition)).To(Equal(infraexpv1.PoolExhaustedReason))
+	g.Expect(conditions.GetMessage(pool, infraexpv1.AddressesAvailableCondition)).To(ContainSubstring("1 IPAddressClaims pending"))
+	g.Expect(recorder.Events).To(Receive(ContainSubstring(infraexpv1.PoolExhaustedReason)))
+	g.Expect(testutil.ToFloat64(poolAddressesUsed.WithLabelValues(pool.Namespace, pool.Name))).To(Equal(float64(3)))
+
+	claim := &ipamv1.IPAddressClaim{}
+	g.Expect(c.Get(context.Background(), client.ObjectKey{Namespace: pool.Namespace, Name: "claim-4"}, claim)).To(Succeed())
+	g.Expect(claim.Status.AddressRef.Name).To(BeEmpty())
+	g.Expect(conditions.IsFalse(claim, clusterv1.ReadyCondition)).To(BeTrue())
+	g.Expect(conditions.GetReason(claim, clusterv1.ReadyCondition)).To(Equal(infraexpv1.PoolExhaustedReason))
+
+	// Deleting the pool removes its metrics.
+	g.Expect(c.Delete(context.Background(), pool)).To(Succeed())
+	_, err = r.Reconcile(context.Background(), req)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(testutil.CollectAndCount(poolAddressesTotal)).To(Equal(0))
+}
+
+func newIPAddressClaim(name string, createdAfter time.Duration) *ipamv1.IPAddressClaim {
+	return &ipamv1.IPAddressClaim{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			Namespace:         metav1.NamespaceDefault,
+			CreationTimestamp: metav1.NewTime(time.Date(2023, 1, 1, 0, 0, 0, 0, time.UTC).Add(createdAfter * time.Minute)),
+		},
+		Spec: ipamv1.IPAddressClaimSpec{
+			PoolRef: corev1.TypedLocalObjectReference{
+				APIGroup: pointer.String(infraexpv1.GroupVersion.Group),
+				Kind:     inClusterIPPoolKind,
+				Name:     "pool",
+			},
+		},
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+)
+
+func init() {
+	// Register the metrics at the controller-runtime metrics registry.
+	ctrlmetrics.Registry.MustRegister(poolAddressesTotal)
+	ctrlmetrics.Registry.MustRegister(poolAddressesUsed)
+	ctrlmetrics.Registry.MustRegister(poolAddressesFree)
+}
+
+var (
+	// poolAddressesTotal reports the number of addresses in each InClusterIPPool.
+	poolAddressesTotal = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capd_inclusterippool_addresses_total",
+		Help: "Number of addresses in the InClusterIPPool",
+	}, []string{"namespace", "name"})
+
+	// poolAddressesUsed reports the number of addresses allocated from each InClusterIPPool.
+	poolAddressesUsed = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capd_inclusterippool_addresses_used",
+		Help: "Number of addresses allocated from the InClusterIPPool",
+	}, []string{"namespace", "name"})
+
+	// poolAddressesFree reports the number of addresses still available in each InClusterIPPool.
+	poolAddressesFree = prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "capd_inclusterippool_addresses_free",
+		Help: "Number of free addresses in the InClusterIPPool",
+	}, []string{"namespace", "name"})
+)
+
+func setPoolMetrics(namespace, name string, total, used, free int) {
+	poolAddressesTotal.WithLabelValues(namespace, name).Set(float64(total))
+	poolAddressesUsed.WithLabelValues(namespace, name).Set(float64(used))
+	poolAddressesFree.WithLabelValues(namespace, name).Set(float64(free))
+}
+
+func deletePoolMetrics(namespace, name string) {
+	poolAddressesTotal.DeleteLabelValues(namespace, name)
+	poolAddressesUsed.DeleteLabelValues(namespace, name)
+	poolAddressesFree.DeleteLabelValues(namespace, name)
+}
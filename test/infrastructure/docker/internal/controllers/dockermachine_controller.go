@@ -294,7 +294,7 @@ func (r *DockerMachineReconciler) reconcileNormal(ctx context.Context, cluster *
 	if !externalMachine.Exists() {
 		// NOTE: FailureDomains don't mean much in CAPD since it's all local, but we are setting a label on
 		// each container, so we can check placement.
-		if err := externalMachine.Create(ctx, dockerMachine.Spec.CustomImage, role, machine.Spec.Version, docker.FailureDomainLabel(machine.Spec.FailureDomain), dockerMachine.Spec.ExtraMounts); err != nil {
+		if err := externalMachine.Create(ctx, dockerMachine.Spec.CustomImage, role, machine.Spec.Version, docker.FailureDomainLabel(machine.Spec.FailureDomain), dockerMachine.Spec.ExtraMounts, dockerMachine.Spec.Networks); err != nil {
 			return ctrl.Result{}, errors.Wrap(err, "failed to create worker DockerMachine")
 		}
 	}
@@ -597,5 +597,19 @@ func setMachineAddress(ctx context.Context, dockerMachine *infrav1.DockerMachine
 			})
 	}
 
+	// Static addresses on additional networks are reported as internal addresses.
+	for _, network := range dockerMachine.Spec.Networks {
+		for _, addr := range []string{network.IPv4Address, network.IPv6Address} {
+			if addr == "" {
+				continue
+			}
+			dockerMachine.Status.Addresses = append(dockerMachine.Status.Addresses,
+				clusterv1.MachineAddress{
+					Type:    clusterv1.MachineInternalIP,
+					Address: addr,
+				})
+		}
+	}
+
 	return nil
 }
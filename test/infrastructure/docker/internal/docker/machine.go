@@ -55,8 +55,8 @@ var (
 )
 
 type nodeCreator interface {
-	CreateControlPlaneNode(ctx context.Context, name, clusterName, listenAddress string, port int32, mounts []v1alpha4.Mount, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (node *types.Node, err error)
-	CreateWorkerNode(ctx context.Context, name, clusterName string, mounts []v1alpha4.Mount, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (node *types.Node, err error)
+	CreateControlPlaneNode(ctx context.Context, name, clusterName, listenAddress string, port int32, mounts []v1alpha4.Mount, networks []container.Network, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (node *types.Node, err error)
+	CreateWorkerNode(ctx context.Context, name, clusterName string, mounts []v1alpha4.Mount, networks []container.Network, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (node *types.Node, err error)
 }
 
 // Machine implement a service for managing the docker containers hosting a kubernetes nodes.
@@ -201,7 +201,7 @@ func (m *Machine) ContainerImage() string {
 }
 
 // Create creates a docker container hosting a Kubernetes node.
-func (m *Machine) Create(ctx context.Context, image string, role string, version *string, labels map[string]string, mounts []infrav1.Mount) error {
+func (m *Machine) Create(ctx context.Context, image string, role string, version *string, labels map[string]string, mounts []infrav1.Mount, networks []infrav1.Network) error {
 	log := ctrl.LoggerFrom(ctx)
 
 	// Create if not exists.
@@ -232,6 +232,7 @@ func (m *Machine) Create(ctx context.Context, image string, role string, version
 				"127.0.0.1",
 				0,
 				kindMounts(mounts),
+				containerNetworks(networks),
 				nil,
 				labels,
 				m.ipFamily,
@@ -247,6 +248,7 @@ func (m *Machine) Create(ctx context.Context, image string, role string, version
 				m.ContainerName(),
 				m.cluster,
 				kindMounts(mounts),
+				containerNetworks(networks),
 				nil,
 				labels,
 				m.ipFamily,
@@ -291,6 +293,22 @@ func kindMounts(mounts []infrav1.Mount) []v1alpha4.Mount {
 	return ret
 }
 
+func containerNetworks(networks []infrav1.Network) []container.Network {
+	if len(networks) == 0 {
+		return nil
+	}
+
+	ret := make([]container.Network, 0, len(networks))
+	for _, n := range networks {
+		ret = append(ret, container.Network{
+			Name:        n.Name,
+			IPv4Address: n.IPv4Address,
+			IPv6Address: n.IPv6Address,
+		})
+	}
+	return ret
+}
+
 // PreloadLoadImages takes a list of container images and imports them into a machine.
 func (m *Machine) PreloadLoadImages(ctx context.Context, images []string) error {
 	// Save the image into a tar
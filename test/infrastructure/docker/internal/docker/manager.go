@@ -51,6 +51,7 @@ type nodeCreateOpts struct {
 	Role         string
 	EntryPoint   []string
 	Mounts       []v1alpha4.Mount
+	Networks     []container.Network
 	PortMappings []v1alpha4.PortMapping
 	Labels       map[string]string
 	IPFamily     clusterv1.ClusterIPFamily
@@ -60,7 +61,7 @@ type nodeCreateOpts struct {
 // CreateControlPlaneNode will create a new control plane container.
 // NOTE: If port is 0 picking a host port for the control plane is delegated to the container runtime and is not stable across container restarts.
 // This means that connection to a control plane node may take some time to recover if the underlying container is restarted.
-func (m *Manager) CreateControlPlaneNode(ctx context.Context, name, clusterName, listenAddress string, port int32, mounts []v1alpha4.Mount, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (*types.Node, error) {
+func (m *Manager) CreateControlPlaneNode(ctx context.Context, name, clusterName, listenAddress string, port int32, mounts []v1alpha4.Mount, networks []container.Network, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (*types.Node, error) {
 	// add api server port mapping
 	portMappingsWithAPIServer := append(portMappings, v1alpha4.PortMapping{
 		ListenAddress: listenAddress,
@@ -74,6 +75,7 @@ func (m *Manager) CreateControlPlaneNode(ctx context.Context, name, clusterName,
 		Role:         constants.ControlPlaneNodeRoleValue,
 		PortMappings: portMappingsWithAPIServer,
 		Mounts:       mounts,
+		Networks:     networks,
 		Labels:       labels,
 		IPFamily:     ipFamily,
 		KindMapping:  kindMapping,
@@ -87,13 +89,14 @@ func (m *Manager) CreateControlPlaneNode(ctx context.Context, name, clusterName,
 }
 
 // CreateWorkerNode will create a new worker container.
-func (m *Manager) CreateWorkerNode(ctx context.Context, name, clusterName string, mounts []v1alpha4.Mount, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (*types.Node, error) {
+func (m *Manager) CreateWorkerNode(ctx context.Context, name, clusterName string, mounts []v1alpha4.Mount, networks []container.Network, portMappings []v1alpha4.PortMapping, labels map[string]string, ipFamily clusterv1.ClusterIPFamily, kindMapping kind.Mapping) (*types.Node, error) {
 	createOpts := &nodeCreateOpts{
 		Name:         name,
 		ClusterName:  clusterName,
 		Role:         constants.WorkerNodeRoleValue,
 		PortMappings: portMappings,
 		Mounts:       mounts,
+		Networks:     networks,
 		Labels:       labels,
 		IPFamily:     ipFamily,
 		KindMapping:  kindMapping,
@@ -154,11 +157,12 @@ func createNode(ctx context.Context, opts *nodeCreateOpts) (*types.Node, error)
 		// filesystem, which is not only better for performance, but allows
 		// running kind in kind for "party tricks"
 		// (please don't depend on doing this though!)
-		Entrypoint:   opts.EntryPoint,
-		Volumes:      map[string]string{"/var": ""},
-		Mounts:       generateMountInfo(opts.Mounts),
-		PortMappings: generatePortMappings(opts.PortMappings),
-		Network:      DefaultNetwork,
+		Entrypoint:         opts.EntryPoint,
+		Volumes:            map[string]string{"/var": ""},
+		Mounts:             generateMountInfo(opts.Mounts),
+		PortMappings:       generatePortMappings(opts.PortMappings),
+		Network:            DefaultNetwork,
+		AdditionalNetworks: opts.Networks,
 		Tmpfs: map[string]string{
 			"/tmp": "", // various things depend on working /tmp
 			"/run": "", // systemd wants a writable /run
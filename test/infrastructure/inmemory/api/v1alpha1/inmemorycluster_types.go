@@ -36,6 +36,33 @@ type InMemoryClusterSpec struct {
 	// ControlPlaneEndpoint represents the endpoint used to communicate with the control plane.
 	// +optional
 	ControlPlaneEndpoint APIEndpoint `json:"controlPlaneEndpoint"`
+
+	// KubernetesVersion is the Kubernetes version emulated by the fake API server of the workload cluster;
+	// it is reported by the /version endpoint and it is used to adjust the discovery documents accordingly.
+	// If not set, the fake API server emulates the latest Kubernetes version supported by the in-memory provider.
+	// +optional
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// Behaviour of the InMemoryCluster; this will allow to make a simulation more alike to real use cases
+	// e.g. by delaying the point at which the control plane endpoint becomes reachable.
+	// +optional
+	Behaviour *InMemoryClusterBehaviour `json:"behaviour,omitempty"`
+}
+
+// InMemoryClusterBehaviour defines the behaviour of the InMemoryCluster.
+type InMemoryClusterBehaviour struct {
+	// ControlPlaneEndpoint defines the behaviour of the control plane endpoint of the InMemoryCluster.
+	// +optional
+	ControlPlaneEndpoint *InMemoryControlPlaneEndpointBehaviour `json:"controlPlaneEndpoint,omitempty"`
+}
+
+// InMemoryControlPlaneEndpointBehaviour defines the behaviour of the control plane endpoint of the InMemoryCluster.
+type InMemoryControlPlaneEndpointBehaviour struct {
+	// PropagationDelay is the duration, after the first API server is provisioned, during which the control plane
+	// endpoint refuses connections; this simulates the propagation delay of DNS records or load balancers in
+	// real infrastructures, which breaks clients using the kubeconfig of the workload cluster too early.
+	// +optional
+	PropagationDelay metav1.Duration `json:"propagationDelay,omitempty"`
 }
 
 // InMemoryClusterStatus defines the observed state of the InMemoryCluster.
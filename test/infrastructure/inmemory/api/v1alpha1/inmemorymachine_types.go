@@ -17,6 +17,7 @@ limitations under the License.
 package v1alpha1
 
 import (
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -26,6 +27,15 @@ const (
 	// MachineFinalizer allows ReconcileInMemoryMachine to clean up resources associated with InMemoryMachine before
 	// removing it from the API server.
 	MachineFinalizer = "inmemorymachine.infrastructure.cluster.x-k8s.io"
+
+	// PayloadAnnotation is the annotation used to store the payload defined in the ResourceConsumption behaviour
+	// of an InMemoryMachine.
+	PayloadAnnotation = "inmemorymachine.infrastructure.cluster.x-k8s.io/payload"
+
+	// MaxPayloadSize is the maximum size of the payloads defined in the ResourceConsumption behaviour of an InMemoryMachine;
+	// the API server limits the total size of the annotations of an object to 256Ki, so some room is left for the
+	// other annotations of the object.
+	MaxPayloadSize = 250 * 1024
 )
 
 const (
@@ -96,6 +106,10 @@ type InMemoryMachineBehaviour struct {
 
 	// Etcd defines the behaviour of the etcd member hosted on the InMemoryMachine.
 	Etcd *InMemoryEtcdBehaviour `json:"etcd,omitempty"`
+
+	// ResourceConsumption defines an additional payload allocated for the InMemoryMachine; this allows to characterize
+	// the memory consumption of the in-memory provider and of the informers against realistic object sizes.
+	ResourceConsumption *InMemoryResourceConsumptionBehaviour `json:"resourceConsumption,omitempty"`
 }
 
 // InMemoryVMBehaviour defines the behaviour of the VM implementing the InMemoryMachine.
@@ -126,6 +140,24 @@ type InMemoryEtcdBehaviour struct {
 	Provisioning CommonProvisioningSettings `json:"provisioning,omitempty"`
 }
 
+// InMemoryResourceConsumptionBehaviour defines the additional payload allocated for an InMemoryMachine.
+// The payload is stored in the inmemorymachine.infrastructure.cluster.x-k8s.io/payload annotation.
+type InMemoryResourceConsumptionBehaviour struct {
+	// InMemoryMachinePayload is the size of the payload to be added to the InMemoryMachine, simulating
+	// objects with many annotations or large statuses in the management cluster.
+	// NOTE: the API server limits the total size of the annotations of an object to 256Ki, so the payload can't
+	// be bigger than 250Ki.
+	// +optional
+	InMemoryMachinePayload *resource.Quantity `json:"inMemoryMachinePayload,omitempty"`
+
+	// CloudObjectsPayload is the size of the payload to be added to each of the objects simulating the InMemoryMachine
+	// in the in-memory backend, i.e. the CloudMachine, the Node and the static Pods; this simulates the memory used
+	// for each fake machine by the in-memory provider and by the informers watching the workload cluster.
+	// NOTE: the payload can't be bigger than 250Ki.
+	// +optional
+	CloudObjectsPayload *resource.Quantity `json:"cloudObjectsPayload,omitempty"`
+}
+
 // CommonProvisioningSettings holds parameters that applies to provisioning of most of the objects.
 type CommonProvisioningSettings struct {
 	// StartupDuration defines the duration of the object provisioning phase.
@@ -77,7 +77,7 @@ func (in *InMemoryCluster) DeepCopyInto(out *InMemoryCluster) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 	in.Status.DeepCopyInto(&out.Status)
 }
 
@@ -99,6 +99,26 @@ func (in *InMemoryCluster) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InMemoryClusterBehaviour) DeepCopyInto(out *InMemoryClusterBehaviour) {
+	*out = *in
+	if in.ControlPlaneEndpoint != nil {
+		in, out := &in.ControlPlaneEndpoint, &out.ControlPlaneEndpoint
+		*out = new(InMemoryControlPlaneEndpointBehaviour)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryClusterBehaviour.
+func (in *InMemoryClusterBehaviour) DeepCopy() *InMemoryClusterBehaviour {
+	if in == nil {
+		return nil
+	}
+	out := new(InMemoryClusterBehaviour)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InMemoryClusterList) DeepCopyInto(out *InMemoryClusterList) {
 	*out = *in
@@ -135,6 +155,11 @@ func (in *InMemoryClusterList) DeepCopyObject() runtime.Object {
 func (in *InMemoryClusterSpec) DeepCopyInto(out *InMemoryClusterSpec) {
 	*out = *in
 	out.ControlPlaneEndpoint = in.ControlPlaneEndpoint
+	if in.Behaviour != nil {
+		in, out := &in.Behaviour, &out.Behaviour
+		*out = new(InMemoryClusterBehaviour)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryClusterSpec.
@@ -231,7 +256,7 @@ func (in *InMemoryClusterTemplateList) DeepCopyObject() runtime.Object {
 func (in *InMemoryClusterTemplateResource) DeepCopyInto(out *InMemoryClusterTemplateResource) {
 	*out = *in
 	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
+	in.Spec.DeepCopyInto(&out.Spec)
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryClusterTemplateResource.
@@ -260,6 +285,22 @@ func (in *InMemoryClusterTemplateSpec) DeepCopy() *InMemoryClusterTemplateSpec {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InMemoryControlPlaneEndpointBehaviour) DeepCopyInto(out *InMemoryControlPlaneEndpointBehaviour) {
+	*out = *in
+	out.PropagationDelay = in.PropagationDelay
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryControlPlaneEndpointBehaviour.
+func (in *InMemoryControlPlaneEndpointBehaviour) DeepCopy() *InMemoryControlPlaneEndpointBehaviour {
+	if in == nil {
+		return nil
+	}
+	out := new(InMemoryControlPlaneEndpointBehaviour)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InMemoryEtcdBehaviour) DeepCopyInto(out *InMemoryEtcdBehaviour) {
 	*out = *in
@@ -326,6 +367,11 @@ func (in *InMemoryMachineBehaviour) DeepCopyInto(out *InMemoryMachineBehaviour)
 		*out = new(InMemoryEtcdBehaviour)
 		**out = **in
 	}
+	if in.ResourceConsumption != nil {
+		in, out := &in.ResourceConsumption, &out.ResourceConsumption
+		*out = new(InMemoryResourceConsumptionBehaviour)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryMachineBehaviour.
@@ -524,6 +570,31 @@ func (in *InMemoryNodeBehaviour) DeepCopy() *InMemoryNodeBehaviour {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *InMemoryResourceConsumptionBehaviour) DeepCopyInto(out *InMemoryResourceConsumptionBehaviour) {
+	*out = *in
+	if in.InMemoryMachinePayload != nil {
+		in, out := &in.InMemoryMachinePayload, &out.InMemoryMachinePayload
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	if in.CloudObjectsPayload != nil {
+		in, out := &in.CloudObjectsPayload, &out.CloudObjectsPayload
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new InMemoryResourceConsumptionBehaviour.
+func (in *InMemoryResourceConsumptionBehaviour) DeepCopy() *InMemoryResourceConsumptionBehaviour {
+	if in == nil {
+		return nil
+	}
+	out := new(InMemoryResourceConsumptionBehaviour)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *InMemoryVMBehaviour) DeepCopyInto(out *InMemoryVMBehaviour) {
 	*out = *in
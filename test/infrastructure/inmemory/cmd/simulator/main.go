@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// main is the main package for the in-memory simulator, serving fake workload clusters from a profile
+// without the Cluster API controllers.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/spf13/pflag"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/klog/v2"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/server"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/simulator"
+)
+
+var (
+	cloudScheme = runtime.NewScheme()
+	setupLog    = ctrl.Log.WithName("setup")
+
+	// flags.
+	profilePath   string
+	kubeconfigDir string
+	host          string
+	minPort       int
+	maxPort       int
+	debugPort     int
+)
+
+func init() {
+	_ = corev1.AddToScheme(cloudScheme)
+	_ = rbacv1.AddToScheme(cloudScheme)
+}
+
+// InitFlags initializes the flags.
+func InitFlags(fs *pflag.FlagSet) {
+	fs.StringVar(&profilePath, "profile", "",
+		"Path of the YAML file defining the fake workload clusters to simulate.")
+
+	fs.StringVar(&kubeconfigDir, "kubeconfig-dir", ".",
+		"Directory where the kubeconfig of each fake workload cluster is written, as <cluster name>.kubeconfig.")
+
+	fs.StringVar(&host, "host", "127.0.0.1",
+		"Host the fake API servers are listening on.")
+
+	fs.IntVar(&minPort, "min-port", server.DefaultMinPort,
+		"Minimum port of the fake API servers; each workload cluster is served on a dedicated port.")
+
+	fs.IntVar(&maxPort, "max-port", server.DefaultMaxPort,
+		"Maximum port of the fake API servers.")
+
+	fs.IntVar(&debugPort, "debug-port", server.DefaultDebugPort,
+		"Port of the debug endpoint of the fake API servers.")
+}
+
+func main() {
+	klog.InitFlags(nil)
+	InitFlags(pflag.CommandLine)
+	pflag.CommandLine.AddGoFlagSet(flag.CommandLine)
+	pflag.Parse()
+	ctrl.SetLogger(klog.Background())
+
+	if profilePath == "" {
+		setupLog.Error(nil, "--profile must be set")
+		os.Exit(1)
+	}
+
+	ctx := ctrl.SetupSignalHandler()
+	if err := run(ctx); err != nil {
+		setupLog.Error(err, "simulator failed")
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context) error {
+	profile, err := simulator.LoadProfile(profilePath)
+	if err != nil {
+		return err
+	}
+
+	cloudMgr := cloud.NewManager(cloudScheme)
+	if err := cloudMgr.Start(ctx); err != nil {
+		return errors.Wrap(err, "failed to start the cloud manager")
+	}
+
+	apiServerMux, err := server.NewWorkloadClustersMux(cloudMgr, host, server.CustomPorts{
+		MinPort:   minPort,
+		MaxPort:   maxPort,
+		DebugPort: debugPort,
+	})
+	if err != nil {
+		return errors.Wrap(err, "failed to create the workload clusters mux")
+	}
+	defer func() {
+		if err := apiServerMux.Shutdown(context.Background()); err != nil {
+			setupLog.Error(err, "failed to shut down the workload clusters mux")
+		}
+	}()
+
+	kubeconfigs, err := simulator.New(cloudMgr, apiServerMux).AddClusters(ctx, profile)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(kubeconfigDir, 0750); err != nil {
+		return errors.Wrap(err, "failed to create the kubeconfig directory")
+	}
+	names := make([]string, 0, len(kubeconfigs))
+	for name := range kubeconfigs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		path := filepath.Join(kubeconfigDir, fmt.Sprintf("%s.kubeconfig", name))
+		if err := os.WriteFile(path, kubeconfigs[name], 0600); err != nil {
+			return errors.Wrapf(err, "failed to write the kubeconfig of workload cluster %s", name)
+		}
+		setupLog.Info("Fake workload cluster is running", "cluster", name, "kubeconfig", path)
+	}
+
+	setupLog.Info("Simulating fake workload clusters, press Ctrl+C to stop", "count", len(names))
+	<-ctx.Done()
+	return nil
+}
@@ -20,6 +20,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
 	cbuilder "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/builder"
+	ccache "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/cache"
 	cclient "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/client"
 	cmanager "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/manager"
 )
@@ -40,4 +41,7 @@ var (
 
 	// NewControllerManagedBy returns a new controller builder that will be started by the provided Manager.
 	NewControllerManagedBy = cbuilder.ControllerManagedBy
+
+	// NewUsageCollector returns a prometheus.Collector reporting the simulated usage of the resource groups in a Cache.
+	NewUsageCollector = ccache.NewUsageCollector
 )
@@ -28,6 +28,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/watch"
 	"k8s.io/client-go/util/workqueue"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
@@ -42,6 +43,8 @@ type Cache interface {
 	AddResourceGroup(name string)
 	DeleteResourceGroup(name string)
 
+	Usage() []ResourceGroupUsage
+
 	Get(resourceGroup string, key client.ObjectKey, obj client.Object) error
 	List(resourceGroup string, list client.ObjectList, opts ...client.ListOption) error
 	Create(resourceGroup string, obj client.Object) error
@@ -51,6 +54,8 @@ type Cache interface {
 
 	GetInformer(ctx context.Context, obj client.Object) (Informer, error)
 	GetInformerForKind(ctx context.Context, gvk schema.GroupVersionKind) (Informer, error)
+
+	Watch(resourceGroup string, gvk schema.GroupVersionKind, opts WatchOptions) (watch.Interface, error)
 }
 
 // Informer forwards events to event handlers.
@@ -91,6 +96,20 @@ type resourceGroupTracker struct {
 	objects map[schema.GroupVersionKind]map[types.NamespacedName]client.Object
 	// ownedObjects tracks ownership. Key is the owner, values are the owned objects.
 	ownedObjects map[ownReference]map[ownReference]struct{}
+
+	// createdAt is the time the resource group has been created.
+	createdAt time.Time
+	// usage tracks objects created and deleted in the resource group, by GVK.
+	usage map[schema.GroupVersionKind]*kindUsageTracker
+
+	// resourceVersion is incremented on every change to the objects in the resource group, like the etcd revision
+	// in a Kubernetes API server.
+	resourceVersion uint64
+	// watchHistory retains the latest events for the resource group, allowing watches to start from a past resourceVersion.
+	watchHistory []historyEvent
+	// watchHistoryStart is the resourceVersion after which all the events are retained in watchHistory.
+	watchHistoryStart uint64
+	watchers          map[*watcher]struct{}
 }
 
 type ownReference struct {
@@ -162,13 +181,25 @@ func (c *cache) AddResourceGroup(name string) {
 	c.resourceGroups[name] = &resourceGroupTracker{
 		objects:      map[schema.GroupVersionKind]map[types.NamespacedName]client.Object{},
 		ownedObjects: map[ownReference]map[ownReference]struct{}{},
+		createdAt:    time.Now().UTC(),
+		usage:        map[schema.GroupVersionKind]*kindUsageTracker{},
+		watchers:     map[*watcher]struct{}{},
 	}
 }
 
 func (c *cache) DeleteResourceGroup(name string) {
 	c.lock.Lock()
-	defer c.lock.Unlock()
+	tracker, ok := c.resourceGroups[name]
 	delete(c.resourceGroups, name)
+	c.lock.Unlock()
+
+	// Note: The tracker is locked after releasing the cache lock, because the cache lock is acquired
+	// while holding the tracker lock when informing event handlers.
+	if ok {
+		tracker.lock.Lock()
+		defer tracker.lock.Unlock()
+		tracker.stopWatchers()
+	}
 }
 
 func (c *cache) resourceGroupTracker(resourceGroup string) *resourceGroupTracker {
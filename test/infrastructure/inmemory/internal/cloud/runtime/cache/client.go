@@ -18,6 +18,7 @@ package cache
 
 import (
 	"fmt"
+	"strconv"
 	"time"
 
 	jsonpatch "github.com/evanphx/json-patch/v5"
@@ -145,6 +146,11 @@ func (c *cache) List(resourceGroup string, list client.ObjectList, opts ...clien
 	if err := meta.SetList(list, items); err != nil {
 		return apierrors.NewInternalError(err)
 	}
+	listAccessor, err := meta.ListAccessor(list)
+	if err != nil {
+		return apierrors.NewInternalError(err)
+	}
+	listAccessor.SetResourceVersion(strconv.FormatUint(tracker.resourceVersion, 10))
 	return nil
 }
 
@@ -232,6 +238,7 @@ func (c *cache) store(resourceGroup string, obj client.Object, replaceExisting b
 	}
 	tracker.objects[objGVK][objKey] = obj.DeepCopyObject().(client.Object)
 	updateTrackerOwnerReferences(tracker, nil, obj, objRef)
+	tracker.trackCreate(objGVK)
 	c.afterCreate(resourceGroup, obj)
 	return nil
 }
@@ -429,7 +436,13 @@ func (c *cache) doTryDeleteLocked(resourceGroup string, tracker *resourceGroupTr
 		}
 
 		objects[objKey] = obj
-		c.afterUpdate(resourceGroup, oldObj, obj)
+		if len(obj.GetFinalizers()) > 0 {
+			c.afterUpdate(resourceGroup, oldObj, obj)
+		} else {
+			// The object is removed immediately, so from the watch point of view there is only the Deleted event
+			// recorded by afterDelete.
+			c.informDelete(resourceGroup, obj)
+		}
 	}
 
 	// If the object still has finalizers return early.
@@ -441,6 +454,7 @@ func (c *cache) doTryDeleteLocked(resourceGroup string, tracker *resourceGroupTr
 	// Note: we don't call informDelete here because we couldn't reconcile it
 	// because the object is already gone from the tracker.
 	delete(objects, objKey)
+	tracker.trackDelete(objGVK, obj)
 	c.afterDelete(resourceGroup, obj)
 	return true, nil
 }
@@ -104,7 +104,7 @@ func Test_cache_client(t *testing.T) {
 			r := c.resourceGroups["foo"].objects[cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind)][key]
 			g.Expect(r.GetObjectKind().GroupVersionKind()).To(BeComparableTo(cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind)), "gvk must be set")
 			g.Expect(r.GetName()).To(Equal("bar"), "name must be equal to object tracker key")
-			g.Expect(r.GetResourceVersion()).To(Equal("v1"), "resourceVersion must be set")
+			g.Expect(r.GetResourceVersion()).To(Equal("1"), "resourceVersion must be set")
 			g.Expect(r.GetCreationTimestamp()).ToNot(BeZero(), "creation timestamp must be set")
 			g.Expect(r.GetAnnotations()).To(HaveKey(lastSyncTimeAnnotation), "last sync annotation must exists")
 
@@ -271,7 +271,8 @@ func Test_cache_client(t *testing.T) {
 			// Check all the computed fields are as expected.
 			g.Expect(obj.GetObjectKind().GroupVersionKind()).To(BeComparableTo(cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind)), "gvk must be set")
 			g.Expect(obj.GetName()).To(Equal("bar"), "name must be equal to object tracker key")
-			g.Expect(obj.GetResourceVersion()).To(Equal("v1"), "resourceVersion must be set")
+			// Note: resourceVersion is incremented for each change in the resource group, and barz has been created before bar.
+			g.Expect(obj.GetResourceVersion()).To(Equal("2"), "resourceVersion must be set")
 			g.Expect(obj.GetCreationTimestamp()).ToNot(BeZero(), "creation timestamp must be set")
 			g.Expect(obj.GetAnnotations()).To(HaveKey(lastSyncTimeAnnotation), "last sync annotation must be set")
 		})
@@ -17,30 +17,31 @@ limitations under the License.
 package cache
 
 import (
-	"fmt"
 	"reflect"
-	"strconv"
-	"strings"
 	"time"
 
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 )
 
-func (c *cache) beforeCreate(_ string, obj client.Object) error {
+// Note: Hooks are called with the resource group tracker already locked.
+
+func (c *cache) beforeCreate(resourceGroup string, obj client.Object) error {
 	now := time.Now().UTC()
 	obj.SetCreationTimestamp(metav1.Time{Time: now})
 	// TODO: UID
 	obj.SetAnnotations(appendAnnotations(obj, lastSyncTimeAnnotation, now.Format(time.RFC3339)))
-	obj.SetResourceVersion(fmt.Sprintf("v%d", 1))
+	obj.SetResourceVersion(c.resourceGroupTracker(resourceGroup).nextResourceVersion())
 	return nil
 }
 
 func (c *cache) afterCreate(resourceGroup string, obj client.Object) {
+	c.resourceGroupTracker(resourceGroup).recordWatchEvent(watch.Added, obj)
 	c.informCreate(resourceGroup, obj)
 }
 
-func (c *cache) beforeUpdate(_ string, oldObj, newObj client.Object) error {
+func (c *cache) beforeUpdate(resourceGroup string, oldObj, newObj client.Object) error {
 	newObj.SetCreationTimestamp(oldObj.GetCreationTimestamp())
 	newObj.SetResourceVersion(oldObj.GetResourceVersion())
 	// TODO: UID
@@ -51,28 +52,34 @@ func (c *cache) beforeUpdate(_ string, oldObj, newObj client.Object) error {
 	if !reflect.DeepEqual(newObj, oldObj) {
 		now := time.Now().UTC()
 		newObj.SetAnnotations(appendAnnotations(newObj, lastSyncTimeAnnotation, now.Format(time.RFC3339)))
-
-		oldResourceVersion, _ := strconv.Atoi(strings.TrimPrefix(oldObj.GetResourceVersion(), "v"))
-		newObj.SetResourceVersion(fmt.Sprintf("v%d", oldResourceVersion+1))
+		newObj.SetResourceVersion(c.resourceGroupTracker(resourceGroup).nextResourceVersion())
 	}
 	return nil
 }
 
 func (c *cache) afterUpdate(resourceGroup string, oldObj, newObj client.Object) {
+	if reflect.DeepEqual(newObj, oldObj) {
+		return
+	}
+	// Note: From the watch point of view setting the deletionTimestamp is an update, the Deleted event is
+	// recorded when the object is actually removed.
+	c.resourceGroupTracker(resourceGroup).recordWatchEvent(watch.Modified, newObj)
 	if oldObj.GetDeletionTimestamp().IsZero() && !newObj.GetDeletionTimestamp().IsZero() {
 		c.informDelete(resourceGroup, newObj)
 		return
 	}
-	if !reflect.DeepEqual(newObj, oldObj) {
-		c.informUpdate(resourceGroup, oldObj, newObj)
-	}
+	c.informUpdate(resourceGroup, oldObj, newObj)
 }
 
 func (c *cache) beforeDelete(_ string, _ client.Object) error {
 	return nil
 }
 
-func (c *cache) afterDelete(_ string, _ client.Object) {
+func (c *cache) afterDelete(resourceGroup string, obj client.Object) {
+	tracker := c.resourceGroupTracker(resourceGroup)
+	obj = obj.DeepCopyObject().(client.Object)
+	obj.SetResourceVersion(tracker.nextResourceVersion())
+	tracker.recordWatchEvent(watch.Deleted, obj)
 }
 
 func appendAnnotations(obj client.Object, kayValuePair ...string) map[string]string {
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+var (
+	usageLabels = []string{"resource_group", "kind"}
+
+	objectsDesc = prometheus.NewDesc(
+		"capim_resourcegroup_objects",
+		"Number of objects existing in a resource group.",
+		usageLabels, nil,
+	)
+	objectsCreatedDesc = prometheus.NewDesc(
+		"capim_resourcegroup_objects_created_total",
+		"Number of objects created in a resource group.",
+		usageLabels, nil,
+	)
+	objectsDeletedDesc = prometheus.NewDesc(
+		"capim_resourcegroup_objects_deleted_total",
+		"Number of objects deleted from a resource group.",
+		usageLabels, nil,
+	)
+	objectHoursDesc = prometheus.NewDesc(
+		"capim_resourcegroup_object_hours_total",
+		"Sum of the lifetime in hours of the objects created in a resource group, e.g. machine-hours for CloudMachines.",
+		usageLabels, nil,
+	)
+)
+
+// NewUsageCollector returns a prometheus.Collector reporting the simulated usage of the resource groups in a Cache.
+func NewUsageCollector(c Cache) prometheus.Collector {
+	return &usageCollector{cache: c}
+}
+
+type usageCollector struct {
+	cache Cache
+}
+
+// Describe implements prometheus.Collector.
+func (u *usageCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- objectsDesc
+	ch <- objectsCreatedDesc
+	ch <- objectsDeletedDesc
+	ch <- objectHoursDesc
+}
+
+// Collect implements prometheus.Collector.
+func (u *usageCollector) Collect(ch chan<- prometheus.Metric) {
+	for _, resourceGroup := range u.cache.Usage() {
+		for _, kind := range resourceGroup.Kinds {
+			ch <- prometheus.MustNewConstMetric(objectsDesc, prometheus.GaugeValue, float64(kind.Objects), resourceGroup.ResourceGroup, kind.Kind)
+			ch <- prometheus.MustNewConstMetric(objectsCreatedDesc, prometheus.CounterValue, float64(kind.Created), resourceGroup.ResourceGroup, kind.Kind)
+			ch <- prometheus.MustNewConstMetric(objectsDeletedDesc, prometheus.CounterValue, float64(kind.Deleted), resourceGroup.ResourceGroup, kind.Kind)
+			ch <- prometheus.MustNewConstMetric(objectHoursDesc, prometheus.CounterValue, kind.ObjectHours, resourceGroup.ResourceGroup, kind.Kind)
+		}
+	}
+}
@@ -0,0 +1,123 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// ResourceGroupUsage reports the simulated usage of the objects in a resource group,
+// thus allowing to approximate the cost profile of a workload cluster.
+// e.g. the object hours for CloudMachines are the simulated machine-hours for the workload cluster.
+type ResourceGroupUsage struct {
+	ResourceGroup string      `json:"resourceGroup"`
+	CreatedAt     time.Time   `json:"createdAt"`
+	Kinds         []KindUsage `json:"kinds"`
+}
+
+// KindUsage reports the simulated usage of the objects of a kind in a resource group.
+type KindUsage struct {
+	// Kind is the group kind of the objects, e.g. CloudMachine.virtual.cluster.x-k8s.io.
+	Kind string `json:"kind"`
+
+	// Objects is the number of objects currently existing in the resource group.
+	Objects int `json:"objects"`
+
+	// Created is the number of objects created in the resource group.
+	Created int `json:"created"`
+
+	// Deleted is the number of objects deleted from the resource group.
+	Deleted int `json:"deleted"`
+
+	// ObjectHours is the sum of the lifetime, in hours, of all the objects created in the resource group,
+	// including both the objects currently existing and the deleted ones.
+	ObjectHours float64 `json:"objectHours"`
+}
+
+type kindUsageTracker struct {
+	created int
+	deleted int
+	// deletedLifetime is the sum of the lifetime of deleted objects.
+	deletedLifetime time.Duration
+}
+
+// trackCreate records the creation of an object.
+// Note: The tracker must be already locked when calling this method.
+func (t *resourceGroupTracker) trackCreate(gvk schema.GroupVersionKind) {
+	t.kindUsageTracker(gvk).created++
+}
+
+// trackDelete records the deletion of an object.
+// Note: The tracker must be already locked when calling this method.
+func (t *resourceGroupTracker) trackDelete(gvk schema.GroupVersionKind, obj client.Object) {
+	u := t.kindUsageTracker(gvk)
+	u.deleted++
+	u.deletedLifetime += time.Since(obj.GetCreationTimestamp().Time)
+}
+
+func (t *resourceGroupTracker) kindUsageTracker(gvk schema.GroupVersionKind) *kindUsageTracker {
+	u, ok := t.usage[gvk]
+	if !ok {
+		u = &kindUsageTracker{}
+		t.usage[gvk] = u
+	}
+	return u
+}
+
+// Usage returns the simulated usage of all the resource groups in the cache, sorted by name.
+func (c *cache) Usage() []ResourceGroupUsage {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	now := time.Now()
+	usage := make([]ResourceGroupUsage, 0, len(c.resourceGroups))
+	for name, tracker := range c.resourceGroups {
+		usage = append(usage, tracker.usageReport(name, now))
+	}
+	sort.Slice(usage, func(i, j int) bool { return usage[i].ResourceGroup < usage[j].ResourceGroup })
+	return usage
+}
+
+func (t *resourceGroupTracker) usageReport(resourceGroup string, now time.Time) ResourceGroupUsage {
+	t.lock.RLock()
+	defer t.lock.RUnlock()
+
+	report := ResourceGroupUsage{
+		ResourceGroup: resourceGroup,
+		CreatedAt:     t.createdAt,
+		Kinds:         []KindUsage{},
+	}
+	for gvk, u := range t.usage {
+		lifetime := u.deletedLifetime
+		for _, obj := range t.objects[gvk] {
+			lifetime += now.Sub(obj.GetCreationTimestamp().Time)
+		}
+		report.Kinds = append(report.Kinds, KindUsage{
+			Kind:        gvk.GroupKind().String(),
+			Objects:     len(t.objects[gvk]),
+			Created:     u.created,
+			Deleted:     u.deleted,
+			ObjectHours: lifetime.Hours(),
+		})
+	}
+	sort.Slice(report.Kinds, func(i, j int) bool { return report.Kinds[i].Kind < report.Kinds[j].Kind })
+	return report
+}
@@ -0,0 +1,68 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	cloudv1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/api/v1alpha1"
+)
+
+func Test_cache_usage(t *testing.T) {
+	g := NewWithT(t)
+
+	c := NewCache(scheme).(*cache)
+	c.AddResourceGroup("foo")
+	c.AddResourceGroup("bar")
+
+	for _, name := range []string{"baz", "qux"} {
+		err := c.Create("foo", &cloudv1.CloudMachine{ObjectMeta: metav1.ObjectMeta{Name: name}})
+		g.Expect(err).ToNot(HaveOccurred())
+	}
+
+	// Simulate objects existing for one hour.
+	tracker := c.resourceGroupTracker("foo")
+	for _, obj := range tracker.objects[cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind)] {
+		obj.SetCreationTimestamp(metav1.NewTime(time.Now().Add(-1 * time.Hour)))
+	}
+
+	obj := &cloudv1.CloudMachine{}
+	err := c.Get("foo", types.NamespacedName{Name: "baz"}, obj)
+	g.Expect(err).ToNot(HaveOccurred())
+	err = c.Delete("foo", obj)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	usage := c.Usage()
+	g.Expect(usage).To(HaveLen(2))
+	g.Expect(usage[0].ResourceGroup).To(Equal("bar"))
+	g.Expect(usage[0].Kinds).To(BeEmpty())
+
+	g.Expect(usage[1].ResourceGroup).To(Equal("foo"))
+	g.Expect(usage[1].Kinds).To(HaveLen(1))
+	kind := usage[1].Kinds[0]
+	g.Expect(kind.Kind).To(Equal(cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind).GroupKind().String()))
+	g.Expect(kind.Objects).To(Equal(1))
+	g.Expect(kind.Created).To(Equal(2))
+	g.Expect(kind.Deleted).To(Equal(1))
+	// Both the deleted and the existing object contribute to the object hours.
+	g.Expect(kind.ObjectHours).To(BeNumerically("~", 2, 0.01))
+}
@@ -0,0 +1,308 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// watchHistorySize is the number of events retained for each resource group; watches starting
+	// from a resourceVersion older than the retained events fail with a ResourceExpired error.
+	watchHistorySize = 1000
+
+	// maxWatcherQueueSize is the number of events a watcher can lag behind before being terminated,
+	// like the API server does with slow watchers.
+	maxWatcherQueueSize = 10000
+
+	// initialEventsEndAnnotation marks the bookmark sent after the initial events when SendInitialEvents is set.
+	initialEventsEndAnnotation = "k8s.io/initial-events-end"
+)
+
+// WatchOptions are the options for a Watch.
+type WatchOptions struct {
+	// ResourceVersion is the resourceVersion the watch starts from, with the same semantic of the Kubernetes API server:
+	// if empty or "0", the watch begins with synthetic Added events for all the existing objects, otherwise it begins
+	// with the events that happened after the given resourceVersion.
+	ResourceVersion string
+
+	// SendInitialEvents, if set, sends a bookmark annotated with k8s.io/initial-events-end after the synthetic Added events.
+	SendInitialEvents bool
+
+	// BookmarkInterval, if greater than zero, is the interval at which bookmarks are sent while the watch is idle.
+	BookmarkInterval time.Duration
+}
+
+// historyEvent is an event retained in the watch history of a resource group.
+type historyEvent struct {
+	gvk             schema.GroupVersionKind
+	resourceVersion uint64
+	event           watch.Event
+}
+
+// nextResourceVersion increments and returns the resourceVersion of the resource group.
+// Note: The tracker must be already locked when calling this method.
+func (t *resourceGroupTracker) nextResourceVersion() string {
+	t.resourceVersion++
+	return strconv.FormatUint(t.resourceVersion, 10)
+}
+
+// recordWatchEvent records an event in the watch history of the resource group and dispatches it to the watchers.
+// Note: The tracker must be already locked when calling this method.
+func (t *resourceGroupTracker) recordWatchEvent(eventType watch.EventType, obj client.Object) {
+	rv, _ := strconv.ParseUint(obj.GetResourceVersion(), 10, 64)
+	e := historyEvent{
+		gvk:             obj.GetObjectKind().GroupVersionKind(),
+		resourceVersion: rv,
+		event: watch.Event{
+			Type:   eventType,
+			Object: obj.DeepCopyObject(),
+		},
+	}
+
+	t.watchHistory = append(t.watchHistory, e)
+	if len(t.watchHistory) > watchHistorySize {
+		t.watchHistoryStart = t.watchHistory[0].resourceVersion
+		t.watchHistory = t.watchHistory[1:]
+	}
+
+	for w := range t.watchers {
+		if !w.push(e) {
+			delete(t.watchers, w)
+		}
+	}
+}
+
+// stopWatchers stops all the watchers of the resource group.
+// Note: The tracker must be already locked when calling this method.
+func (t *resourceGroupTracker) stopWatchers() {
+	for w := range t.watchers {
+		w.terminate()
+		delete(t.watchers, w)
+	}
+}
+
+// Watch returns a watch.Interface for the objects of the given kind in a resource group.
+func (c *cache) Watch(resourceGroup string, gvk schema.GroupVersionKind, opts WatchOptions) (watch.Interface, error) {
+	if resourceGroup == "" {
+		return nil, apierrors.NewBadRequest("resourceGroup must not be empty")
+	}
+
+	tracker := c.resourceGroupTracker(resourceGroup)
+	if tracker == nil {
+		return nil, apierrors.NewBadRequest(fmt.Sprintf("resourceGroup %s does not exist", resourceGroup))
+	}
+
+	tracker.lock.Lock()
+	defer tracker.lock.Unlock()
+
+	w := &watcher{
+		cache:            c,
+		tracker:          tracker,
+		gvk:              gvk,
+		bookmarkInterval: opts.BookmarkInterval,
+		progress:         tracker.resourceVersion,
+		result:           make(chan watch.Event),
+		notify:           make(chan struct{}, 1),
+		done:             make(chan struct{}),
+	}
+
+	switch opts.ResourceVersion {
+	case "", "0":
+		objs := make([]client.Object, 0, len(tracker.objects[gvk]))
+		for _, obj := range tracker.objects[gvk] {
+			objs = append(objs, obj)
+		}
+		sort.Slice(objs, func(i, j int) bool {
+			return resourceVersionOf(objs[i]) < resourceVersionOf(objs[j])
+		})
+		for _, obj := range objs {
+			w.queue = append(w.queue, watch.Event{Type: watch.Added, Object: obj.DeepCopyObject()})
+		}
+		if opts.SendInitialEvents {
+			bookmark := w.bookmark(tracker.resourceVersion)
+			bookmark.Object.(client.Object).SetAnnotations(map[string]string{initialEventsEndAnnotation: "true"})
+			w.queue = append(w.queue, bookmark)
+		}
+	default:
+		rv, err := strconv.ParseUint(opts.ResourceVersion, 10, 64)
+		if err != nil {
+			return nil, apierrors.NewBadRequest(fmt.Sprintf("invalid resourceVersion %q", opts.ResourceVersion))
+		}
+		if rv < tracker.watchHistoryStart {
+			return nil, apierrors.NewResourceExpired(fmt.Sprintf("too old resource version: %d (%d)", rv, tracker.watchHistoryStart))
+		}
+		for _, e := range tracker.watchHistory {
+			if e.resourceVersion > rv && e.gvk == gvk {
+				w.queue = append(w.queue, watch.Event{Type: e.event.Type, Object: e.event.Object.DeepCopyObject()})
+			}
+		}
+	}
+
+	tracker.watchers[w] = struct{}{}
+	go w.run()
+	return w, nil
+}
+
+func resourceVersionOf(obj client.Object) uint64 {
+	rv, _ := strconv.ParseUint(obj.GetResourceVersion(), 10, 64)
+	return rv
+}
+
+// watcher implements watch.Interface for a kind in a resource group.
+// Events are queued when recorded, so recording events never blocks on slow consumers.
+type watcher struct {
+	cache            *cache
+	tracker          *resourceGroupTracker
+	gvk              schema.GroupVersionKind
+	bookmarkInterval time.Duration
+
+	lock sync.Mutex
+	// queue holds the events not yet delivered to the result channel.
+	queue []watch.Event
+	// progress is the resourceVersion of the resource group when the last event has been queued.
+	progress   uint64
+	terminated bool
+
+	result   chan watch.Event
+	notify   chan struct{}
+	done     chan struct{}
+	stopOnce sync.Once
+}
+
+var _ watch.Interface = &watcher{}
+
+// ResultChan returns the channel delivering the watch events; the channel is closed when the watch stops.
+func (w *watcher) ResultChan() <-chan watch.Event {
+	return w.result
+}
+
+// Stop stops the watch.
+func (w *watcher) Stop() {
+	w.tracker.lock.Lock()
+	delete(w.tracker.watchers, w)
+	w.tracker.lock.Unlock()
+
+	w.stopOnce.Do(func() { close(w.done) })
+}
+
+// push queues an event, if it is for the kind of the watcher, and returns false if the watcher has been terminated
+// because too many events are queued.
+func (w *watcher) push(e historyEvent) bool {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.progress = e.resourceVersion
+	if e.gvk == w.gvk {
+		if len(w.queue) >= maxWatcherQueueSize {
+			w.terminated = true
+			w.queue = nil
+		} else {
+			w.queue = append(w.queue, watch.Event{Type: e.event.Type, Object: e.event.Object.DeepCopyObject()})
+		}
+	}
+	w.signal()
+	return !w.terminated
+}
+
+// terminate terminates the watcher, closing the result channel.
+func (w *watcher) terminate() {
+	w.lock.Lock()
+	defer w.lock.Unlock()
+
+	w.terminated = true
+	w.queue = nil
+	w.signal()
+}
+
+func (w *watcher) signal() {
+	select {
+	case w.notify <- struct{}{}:
+	default:
+	}
+}
+
+// run delivers the queued events to the result channel, sending bookmarks while idle if required.
+func (w *watcher) run() {
+	defer close(w.result)
+
+	var bookmarkC <-chan time.Time
+	if w.bookmarkInterval > 0 {
+		bookmarkTicker := time.NewTicker(w.bookmarkInterval)
+		defer bookmarkTicker.Stop()
+		bookmarkC = bookmarkTicker.C
+	}
+
+	for {
+		w.lock.Lock()
+		if w.terminated {
+			w.lock.Unlock()
+			return
+		}
+		if len(w.queue) > 0 {
+			e := w.queue[0]
+			w.queue = w.queue[1:]
+			w.lock.Unlock()
+
+			select {
+			case w.result <- e:
+			case <-w.done:
+				return
+			}
+			continue
+		}
+		w.lock.Unlock()
+
+		select {
+		case <-w.notify:
+		case <-bookmarkC:
+			// Bookmarks are only sent when all the events are delivered, so the bookmark resourceVersion
+			// is never ahead of the events received by the consumer.
+			w.lock.Lock()
+			if len(w.queue) == 0 && !w.terminated {
+				w.queue = append(w.queue, w.bookmark(w.progress))
+			}
+			w.lock.Unlock()
+		case <-w.done:
+			return
+		}
+	}
+}
+
+// bookmark returns a bookmark event for the given resourceVersion.
+func (w *watcher) bookmark(resourceVersion uint64) watch.Event {
+	var obj client.Object
+	if o, err := w.cache.scheme.New(w.gvk); err == nil {
+		obj, _ = o.(client.Object)
+	}
+	if obj == nil {
+		obj = &unstructured.Unstructured{}
+	}
+	obj.GetObjectKind().SetGroupVersionKind(w.gvk)
+	obj.SetResourceVersion(strconv.FormatUint(resourceVersion, 10))
+	return watch.Event{Type: watch.Bookmark, Object: obj}
+}
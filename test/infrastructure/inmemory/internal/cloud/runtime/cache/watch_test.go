@@ -0,0 +1,207 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cache
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cloudv1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/api/v1alpha1"
+)
+
+var machineGVK = cloudv1.GroupVersion.WithKind(cloudv1.CloudMachineKind)
+
+func Test_cache_resourceVersion(t *testing.T) {
+	g := NewWithT(t)
+
+	c := NewCache(scheme).(*cache)
+	c.AddResourceGroup("foo")
+
+	foo := createMachine(t, c, "foo", "foo")
+	bar := createMachine(t, c, "foo", "bar")
+	g.Expect(foo.GetResourceVersion()).To(Equal("1"))
+	g.Expect(bar.GetResourceVersion()).To(Equal("2"))
+
+	// An update without changes does not change the resourceVersion.
+	g.Expect(c.Update("foo", foo)).To(Succeed())
+	g.Expect(foo.GetResourceVersion()).To(Equal("1"))
+
+	foo.SetLabels(map[string]string{"foo": "bar"})
+	g.Expect(c.Update("foo", foo)).To(Succeed())
+	g.Expect(foo.GetResourceVersion()).To(Equal("3"))
+
+	list := &cloudv1.CloudMachineList{}
+	g.Expect(c.List("foo", list)).To(Succeed())
+	g.Expect(list.GetResourceVersion()).To(Equal("3"), "list resourceVersion must be the latest resourceVersion of the resource group")
+
+	// Resource groups have independent resourceVersions.
+	c.AddResourceGroup("bar")
+	g.Expect(createMachine(t, c, "bar", "foo").GetResourceVersion()).To(Equal("1"))
+}
+
+func Test_cache_Watch(t *testing.T) {
+	t.Run("starts with synthetic Added events and receives changes", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		createMachine(t, c, "foo", "foo")
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		defer w.Stop()
+
+		bar := createMachine(t, c, "foo", "bar")
+		bar.SetLabels(map[string]string{"foo": "bar"})
+		g.Expect(c.Update("foo", bar)).To(Succeed())
+		g.Expect(c.Delete("foo", bar)).To(Succeed())
+
+		// Deleting an object without finalizers only generates a Deleted event.
+		g.Expect(receiveEvents(g, w, 4)).To(Equal([]string{"ADDED/foo/1", "ADDED/bar/2", "MODIFIED/bar/3", "DELETED/bar/5"}))
+	})
+
+	t.Run("starts from a resourceVersion", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		foo := createMachine(t, c, "foo", "foo")
+		createMachine(t, c, "foo", "bar")
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{ResourceVersion: foo.GetResourceVersion()})
+		g.Expect(err).ToNot(HaveOccurred())
+		defer w.Stop()
+
+		g.Expect(receiveEvents(g, w, 1)).To(Equal([]string{"ADDED/bar/2"}))
+	})
+
+	t.Run("fails if the resourceVersion is too old", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		// Note: Creating two more objects than the history size drops the events for resourceVersion 1 and 2 from the history.
+		for i := 0; i < watchHistorySize+2; i++ {
+			createMachine(t, c, "foo", fmt.Sprintf("machine-%d", i))
+		}
+
+		_, err := c.Watch("foo", machineGVK, WatchOptions{ResourceVersion: "1"})
+		g.Expect(err).To(HaveOccurred())
+		g.Expect(apierrors.IsResourceExpired(err)).To(BeTrue())
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{ResourceVersion: "2"})
+		g.Expect(err).ToNot(HaveOccurred())
+		w.Stop()
+	})
+
+	t.Run("records deletion of objects with finalizers", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.garbageCollectorQueue = workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())
+		defer c.garbageCollectorQueue.ShutDown()
+		c.AddResourceGroup("foo")
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+		defer w.Stop()
+
+		foo := &cloudv1.CloudMachine{}
+		foo.SetName("foo")
+		foo.SetFinalizers([]string{"foo"})
+		g.Expect(c.Create("foo", foo)).To(Succeed())
+		g.Expect(c.Delete("foo", foo)).To(Succeed())
+
+		g.Expect(c.Get("foo", client.ObjectKeyFromObject(foo), foo)).To(Succeed())
+		foo.SetFinalizers(nil)
+		g.Expect(c.Update("foo", foo)).To(Succeed())
+		deleted, err := c.tryDelete("foo", machineGVK, client.ObjectKeyFromObject(foo))
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(deleted).To(BeTrue())
+
+		g.Expect(receiveEvents(g, w, 4)).To(Equal([]string{"ADDED/foo/1", "MODIFIED/foo/2", "MODIFIED/foo/3", "DELETED/foo/4"}))
+	})
+
+	t.Run("sends bookmarks", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		createMachine(t, c, "foo", "foo")
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{SendInitialEvents: true})
+		g.Expect(err).ToNot(HaveOccurred())
+		defer w.Stop()
+
+		g.Expect(receiveEvents(g, w, 1)).To(Equal([]string{"ADDED/foo/1"}))
+		var e watch.Event
+		g.Eventually(w.ResultChan(), 5*time.Second).Should(Receive(&e))
+		g.Expect(e.Type).To(Equal(watch.Bookmark))
+		g.Expect(e.Object.(client.Object).GetResourceVersion()).To(Equal("1"))
+		g.Expect(e.Object.(client.Object).GetAnnotations()).To(HaveKeyWithValue(initialEventsEndAnnotation, "true"))
+
+		// Bookmarks progress with the changes to objects of other kinds.
+		otherGVK := cloudv1.GroupVersion.WithKind("Other")
+		other, err := c.Watch("foo", otherGVK, WatchOptions{ResourceVersion: "1", BookmarkInterval: 100 * time.Millisecond})
+		g.Expect(err).ToNot(HaveOccurred())
+		defer other.Stop()
+
+		createMachine(t, c, "foo", "bar")
+		g.Eventually(func() string {
+			return receiveEvents(g, other, 1)[0]
+		}, 5*time.Second).Should(Equal("BOOKMARK//2"))
+	})
+
+	t.Run("stops when the resource group is deleted", func(t *testing.T) {
+		g := NewWithT(t)
+
+		c := NewCache(scheme).(*cache)
+		c.AddResourceGroup("foo")
+
+		w, err := c.Watch("foo", machineGVK, WatchOptions{})
+		g.Expect(err).ToNot(HaveOccurred())
+
+		c.DeleteResourceGroup("foo")
+		g.Eventually(w.ResultChan()).Should(BeClosed())
+	})
+}
+
+// receiveEvents receives n events from a watch, returning them as <type>/<name>/<resourceVersion>.
+func receiveEvents(g *WithT, w watch.Interface, n int) []string {
+	events := []string{}
+	for len(events) < n {
+		var e watch.Event
+		g.Eventually(w.ResultChan(), 5*time.Second).Should(Receive(&e))
+		o := e.Object.(client.Object)
+		if e.Type == watch.Bookmark {
+			g.Expect(o.GetObjectKind().GroupVersionKind()).ToNot(BeZero(), "bookmarks must have the kind of the watch")
+		}
+		events = append(events, fmt.Sprintf("%s/%s/%s", e.Type, o.GetName(), o.GetResourceVersion()))
+	}
+	return events
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controllers
+
+import (
+	"strings"
+
+	"github.com/blang/semver/v4"
+	"github.com/pkg/errors"
+)
+
+// etcdVersions maps Kubernetes minor versions to the etcd version kubeadm deployed by default at release time.
+// NOTE: The mapping intentionally uses a different etcd version for most of the Kubernetes minors, so
+// upgrading the Kubernetes version of a control plane results in an upgrade of the simulated etcd members too.
+var etcdVersions = map[uint64]string{
+	22: "3.5.0-0",
+	23: "3.5.1-0",
+	24: "3.5.3-0",
+	25: "3.5.4-0",
+	26: "3.5.6-0",
+	27: "3.5.7-0",
+	28: "3.5.9-0",
+	29: "3.5.10-0",
+}
+
+const (
+	minEtcdVersionsMinor = 22
+	maxEtcdVersionsMinor = 29
+)
+
+// etcdVersionForKubernetesVersion returns the etcd version for a Kubernetes version.
+// Kubernetes versions older or newer than the ones in the mapping use respectively the oldest or the newest etcd version.
+func etcdVersionForKubernetesVersion(version string) (string, error) {
+	v, err := semver.ParseTolerant(strings.TrimPrefix(version, "v"))
+	if err != nil {
+		return "", errors.Wrapf(err, "failed to parse Kubernetes version %q", version)
+	}
+
+	minor := v.Minor
+	if minor < minEtcdVersionsMinor {
+		minor = minEtcdVersionsMinor
+	}
+	if minor > maxEtcdVersionsMinor {
+		minor = maxEtcdVersionsMinor
+	}
+	return etcdVersions[minor], nil
+}
@@ -20,6 +20,7 @@ package controllers
 import (
 	"context"
 	"sync"
+	"time"
 
 	"github.com/pkg/errors"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -176,6 +177,20 @@ func (r *InMemoryClusterReconciler) reconcileNormal(_ context.Context, cluster *
 		return errors.Wrap(err, "failed to init the listener for the workload cluster")
 	}
 
+	// Set the Kubernetes version emulated by the API server of the workload cluster.
+	if err := r.APIServerMux.SetKubernetesVersion(resourceGroup, inMemoryCluster.Spec.KubernetesVersion); err != nil {
+		return errors.Wrap(err, "failed to set the Kubernetes version for the workload cluster")
+	}
+
+	// Set the propagation delay of the control plane endpoint of the workload cluster, if any.
+	var propagationDelay time.Duration
+	if inMemoryCluster.Spec.Behaviour != nil && inMemoryCluster.Spec.Behaviour.ControlPlaneEndpoint != nil {
+		propagationDelay = inMemoryCluster.Spec.Behaviour.ControlPlaneEndpoint.PropagationDelay.Duration
+	}
+	if err := r.APIServerMux.SetControlPlaneEndpointPropagationDelay(resourceGroup, propagationDelay); err != nil {
+		return errors.Wrap(err, "failed to set the control plane endpoint propagation delay for the workload cluster")
+	}
+
 	// Surface the control plane endpoint
 	if inMemoryCluster.Spec.ControlPlaneEndpoint.Host == "" {
 		inMemoryCluster.Spec.ControlPlaneEndpoint.Host = listener.Host()
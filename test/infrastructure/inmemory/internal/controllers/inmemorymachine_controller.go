@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/pkg/errors"
@@ -30,6 +31,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	rbacv1 "k8s.io/api/rbac/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	kerrors "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -210,6 +212,9 @@ func (r *InMemoryMachineReconciler) reconcileNormal(ctx context.Context, cluster
 		return ctrl.Result{}, nil
 	}
 
+	// Add the payload to the InMemoryMachine, if required by the ResourceConsumption behaviour.
+	setPayloadAnnotation(inMemoryMachine, inMemoryMachinePayloadSize(inMemoryMachine))
+
 	// Call the inner reconciliation methods.
 	phases := []func(ctx context.Context, cluster *clusterv1.Cluster, machine *clusterv1.Machine, inMemoryMachine *infrav1.InMemoryMachine) (ctrl.Result, error){
 		r.reconcileNormalCloudMachine,
@@ -259,6 +264,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalCloudMachine(ctx context.Cont
 			return ctrl.Result{}, err
 		}
 
+		setPayloadAnnotation(cloudMachine, cloudObjectsPayloadSize(inMemoryMachine))
 		if err := cloudClient.Create(ctx, cloudMachine); err != nil && !apierrors.IsAlreadyExists(err) {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to create CloudMachine")
 		}
@@ -363,6 +369,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalNode(ctx context.Context, clu
 
 		// NOTE: for the first control plane machine we might create the node before etcd and API server pod are running
 		// but this is not an issue, because it won't be visible to CAPI until the API server start serving requests.
+		setPayloadAnnotation(node, cloudObjectsPayloadSize(inMemoryMachine))
 		if err := cloudClient.Create(ctx, node); err != nil && !apierrors.IsAlreadyExists(err) {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to create Node")
 		}
@@ -372,6 +379,40 @@ func (r *InMemoryMachineReconciler) reconcileNormalNode(ctx context.Context, clu
 	return ctrl.Result{}, nil
 }
 
+// inMemoryMachinePayloadSize returns the size of the payload to be added to the InMemoryMachine.
+func inMemoryMachinePayloadSize(inMemoryMachine *infrav1.InMemoryMachine) *resource.Quantity {
+	if inMemoryMachine.Spec.Behaviour == nil || inMemoryMachine.Spec.Behaviour.ResourceConsumption == nil {
+		return nil
+	}
+	return inMemoryMachine.Spec.Behaviour.ResourceConsumption.InMemoryMachinePayload
+}
+
+// cloudObjectsPayloadSize returns the size of the payload to be added to the objects simulating the InMemoryMachine
+// in the in-memory backend.
+func cloudObjectsPayloadSize(inMemoryMachine *infrav1.InMemoryMachine) *resource.Quantity {
+	if inMemoryMachine.Spec.Behaviour == nil || inMemoryMachine.Spec.Behaviour.ResourceConsumption == nil {
+		return nil
+	}
+	return inMemoryMachine.Spec.Behaviour.ResourceConsumption.CloudObjectsPayload
+}
+
+// setPayloadAnnotation sets a payload annotation of the given size on the object.
+// NOTE: the payload is not updated if it already has the expected size, so it is allocated only once per object.
+// NOTE: the size is capped to MaxPayloadSize, given that bigger payloads are rejected by the API server.
+func setPayloadAnnotation(obj metav1.Object, size *resource.Quantity) {
+	if size == nil || size.Value() <= 0 {
+		return
+	}
+	payloadSize := size.Value()
+	if payloadSize > infrav1.MaxPayloadSize {
+		payloadSize = infrav1.MaxPayloadSize
+	}
+	if int64(len(obj.GetAnnotations()[infrav1.PayloadAnnotation])) == payloadSize {
+		return
+	}
+	annotations.AddAnnotations(obj, map[string]string{infrav1.PayloadAnnotation: strings.Repeat("x", int(payloadSize))})
+}
+
 func calculateProviderID(inMemoryMachine *infrav1.InMemoryMachine) string {
 	return fmt.Sprintf("in-memory://%s", inMemoryMachine.Name)
 }
@@ -416,6 +457,16 @@ func (r *InMemoryMachineReconciler) reconcileNormalETCD(ctx context.Context, clu
 	resourceGroup := klog.KObj(cluster).String()
 	cloudClient := r.CloudManager.GetResourceGroup(resourceGroup).GetClient()
 
+	// Compute the etcd version for the Kubernetes version of the machine, so the etcd members
+	// get upgraded together with the control plane machines.
+	if machine.Spec.Version == nil {
+		return ctrl.Result{}, errors.New("failed to compute etcd version: machine.spec.version is not set")
+	}
+	etcdVersion, err := etcdVersionForKubernetesVersion(*machine.Spec.Version)
+	if err != nil {
+		return ctrl.Result{}, errors.Wrap(err, "failed to compute etcd version")
+	}
+
 	// Create the etcd pod
 	// TODO: consider if to handle an additional setting adding a delay in between create pod and pod ready
 	etcdMember := fmt.Sprintf("etcd-%s", inMemoryMachine.Name)
@@ -430,6 +481,12 @@ func (r *InMemoryMachineReconciler) reconcileNormalETCD(ctx context.Context, clu
 		},
 		Spec: corev1.PodSpec{
 			NodeName: inMemoryMachine.Name,
+			Containers: []corev1.Container{
+				{
+					Name:  "etcd",
+					Image: fmt.Sprintf("registry.k8s.io/etcd:%s", etcdVersion),
+				},
+			},
 		},
 		Status: corev1.PodStatus{
 			Phase: corev1.PodRunning,
@@ -484,6 +541,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalETCD(ctx context.Context, clu
 
 		// NOTE: for the first control plane machine we might create the etcd pod before the API server pod is running
 		// but this is not an issue, because it won't be visible to CAPI until the API server start serving requests.
+		setPayloadAnnotation(etcdPod, cloudObjectsPayloadSize(inMemoryMachine))
 		if err := cloudClient.Create(ctx, etcdPod); err != nil && !apierrors.IsAlreadyExists(err) {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to create Pod")
 		}
@@ -651,6 +709,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalAPIServer(ctx context.Context
 			return ctrl.Result{}, errors.Wrapf(err, "failed to get apiServer Pod")
 		}
 
+		setPayloadAnnotation(apiServerPod, cloudObjectsPayloadSize(inMemoryMachine))
 		if err := cloudClient.Create(ctx, apiServerPod); err != nil && !apierrors.IsAlreadyExists(err) {
 			return ctrl.Result{}, errors.Wrapf(err, "failed to create apiServer Pod")
 		}
@@ -735,6 +794,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalScheduler(ctx context.Context
 			},
 		},
 	}
+	setPayloadAnnotation(schedulerPod, cloudObjectsPayloadSize(inMemoryMachine))
 	if err := cloudClient.Create(ctx, schedulerPod); err != nil && !apierrors.IsAlreadyExists(err) {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create scheduler Pod")
 	}
@@ -783,6 +843,7 @@ func (r *InMemoryMachineReconciler) reconcileNormalControllerManager(ctx context
 			},
 		},
 	}
+	setPayloadAnnotation(controllerManagerPod, cloudObjectsPayloadSize(inMemoryMachine))
 	if err := cloudClient.Create(ctx, controllerManagerPod); err != nil && !apierrors.IsAlreadyExists(err) {
 		return ctrl.Result{}, errors.Wrapf(err, "failed to create controller manager Pod")
 	}
@@ -31,9 +31,11 @@ import (
 	"github.com/pkg/errors"
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/klog/v2"
+	"k8s.io/utils/pointer"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -65,6 +67,9 @@ var (
 				clusterv1.MachineControlPlaneLabel: "",
 			},
 		},
+		Spec: clusterv1.MachineSpec{
+			Version: pointer.String("v1.28.0"),
+		},
 	}
 
 	workerMachine = &clusterv1.Machine{
@@ -146,6 +151,70 @@ func TestReconcileNormalCloudMachine(t *testing.T) {
 	})
 }
 
+func TestReconcileNormalCloudMachineWithPayload(t *testing.T) {
+	g := NewWithT(t)
+
+	inMemoryMachine := &infrav1.InMemoryMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: "bar",
+		},
+		Spec: infrav1.InMemoryMachineSpec{
+			Behaviour: &infrav1.InMemoryMachineBehaviour{
+				ResourceConsumption: &infrav1.InMemoryResourceConsumptionBehaviour{
+					CloudObjectsPayload: resource.NewQuantity(1024, resource.BinarySI),
+				},
+			},
+		},
+	}
+
+	r := InMemoryMachineReconciler{
+		CloudManager: cmanager.New(scheme),
+	}
+	r.CloudManager.AddResourceGroup(klog.KObj(cluster).String())
+	c := r.CloudManager.GetResourceGroup(klog.KObj(cluster).String()).GetClient()
+
+	_, err := r.reconcileNormalCloudMachine(ctx, cluster, cpMachine, inMemoryMachine)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	got := &cloudv1.CloudMachine{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: inMemoryMachine.Name,
+		},
+	}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(got), got)).To(Succeed())
+	g.Expect(got.Annotations[infrav1.PayloadAnnotation]).To(HaveLen(1024))
+}
+
+func TestSetPayloadAnnotation(t *testing.T) {
+	t.Run("no-op without a payload size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := &corev1.Node{}
+		setPayloadAnnotation(obj, nil)
+		g.Expect(obj.Annotations).To(BeNil())
+	})
+
+	t.Run("sets a payload of the given size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := &corev1.Node{}
+		setPayloadAnnotation(obj, resource.NewQuantity(2048, resource.BinarySI))
+		g.Expect(obj.Annotations[infrav1.PayloadAnnotation]).To(HaveLen(2048))
+
+		// Changing the payload size updates the payload.
+		setPayloadAnnotation(obj, resource.NewQuantity(10, resource.BinarySI))
+		g.Expect(obj.Annotations[infrav1.PayloadAnnotation]).To(HaveLen(10))
+	})
+
+	t.Run("caps the payload size", func(t *testing.T) {
+		g := NewWithT(t)
+
+		obj := &corev1.Node{}
+		setPayloadAnnotation(obj, resource.NewQuantity(1024*1024*1024, resource.BinarySI))
+		g.Expect(obj.Annotations[infrav1.PayloadAnnotation]).To(HaveLen(infrav1.MaxPayloadSize))
+	})
+}
+
 func TestReconcileNormalNode(t *testing.T) {
 	inMemoryMachineWithVMNotYetProvisioned := &infrav1.InMemoryMachine{
 		ObjectMeta: metav1.ObjectMeta{
@@ -364,6 +433,8 @@ func TestReconcileNormalEtcd(t *testing.T) {
 			g.Expect(got.Annotations).To(HaveKey(cloudv1.EtcdClusterIDAnnotationName))
 			g.Expect(got.Annotations).To(HaveKey(cloudv1.EtcdMemberIDAnnotationName))
 			g.Expect(got.Annotations).To(HaveKey(cloudv1.EtcdLeaderFromAnnotationName))
+			g.Expect(got.Spec.Containers).To(HaveLen(1))
+			g.Expect(got.Spec.Containers[0].Image).To(Equal("registry.k8s.io/etcd:3.5.9-0"))
 
 			g.Expect(conditions.IsTrue(inMemoryMachineWithNodeProvisioned1, infrav1.EtcdProvisionedCondition)).To(BeTrue())
 			g.Expect(conditions.Get(inMemoryMachineWithNodeProvisioned1, infrav1.EtcdProvisionedCondition).LastTransitionTime.Time).To(BeTemporally(">", conditions.Get(inMemoryMachineWithNodeProvisioned1, infrav1.NodeProvisionedCondition).LastTransitionTime.Time, inMemoryMachineWithNodeProvisioned1.Spec.Behaviour.Etcd.Provisioning.StartupDuration.Duration))
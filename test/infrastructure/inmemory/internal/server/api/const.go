@@ -32,7 +32,7 @@ var (
 		APIResources: []metav1.APIResource{
 			{
 				Name:         "nodes",
-				SingularName: "",
+				SingularName: "node",
 				Namespaced:   false,
 				Kind:         "Node",
 				Verbs: []string{
@@ -47,7 +47,7 @@ var (
 			},
 			{
 				Name:         "pods",
-				SingularName: "",
+				SingularName: "pod",
 				Namespaced:   true,
 				Kind:         "Pod",
 				Verbs: []string{
@@ -67,7 +67,7 @@ var (
 			},
 			{
 				Name:         "configmaps",
-				SingularName: "",
+				SingularName: "configmap",
 				Namespaced:   true,
 				Kind:         "ConfigMap",
 				Verbs: []string{
@@ -87,7 +87,7 @@ var (
 			},
 			{
 				Name:         "secrets",
-				SingularName: "",
+				SingularName: "secret",
 				Namespaced:   true,
 				Kind:         "Secret",
 				Verbs: []string{
@@ -145,7 +145,7 @@ var (
 		APIResources: []metav1.APIResource{
 			{
 				Name:         "clusterrolebindings",
-				SingularName: "",
+				SingularName: "clusterrolebinding",
 				Namespaced:   false,
 				Kind:         "ClusterRoleBinding",
 				Verbs: []string{
@@ -162,7 +162,7 @@ var (
 			},
 			{
 				Name:         "clusterroles",
-				SingularName: "",
+				SingularName: "clusterrole",
 				Namespaced:   false,
 				Kind:         "ClusterRole",
 				Verbs: []string{
@@ -179,7 +179,7 @@ var (
 			},
 			{
 				Name:         "rolebindings",
-				SingularName: "",
+				SingularName: "rolebinding",
 				Namespaced:   true,
 				Kind:         "RoleBinding",
 				Verbs: []string{
@@ -196,7 +196,7 @@ var (
 			},
 			{
 				Name:         "roles",
-				SingularName: "",
+				SingularName: "role",
 				Namespaced:   true,
 				Kind:         "Role",
 				Verbs: []string{
@@ -46,6 +46,7 @@ func NewDebugHandler(manager cmanager.Manager, log logr.Logger, infoProvider Deb
 
 	// Discovery endpoints
 	ws.Route(ws.GET("/listeners").To(debugServer.listenersList))
+	ws.Route(ws.GET("/usage").To(debugServer.usageReport))
 
 	debugServer.container.Add(ws)
 
@@ -71,3 +72,12 @@ func (h *debugHandler) listenersList(_ *restful.Request, resp *restful.Response)
 		return
 	}
 }
+
+func (h *debugHandler) usageReport(_ *restful.Request, resp *restful.Response) {
+	usage := h.manager.GetCache().Usage()
+
+	if err := resp.WriteEntity(usage); err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+}
@@ -0,0 +1,385 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/emicklei/go-restful/v3"
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/httpstream"
+	"k8s.io/apimachinery/pkg/util/httpstream/spdy"
+	"k8s.io/apimachinery/pkg/util/remotecommand"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	cloudv1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/api/v1alpha1"
+	cclient "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/client"
+)
+
+const (
+	// etcdClientPort is the port etcd members are serving client requests on.
+	etcdClientPort = "2379"
+
+	// execStreamCreationTimeout is the time to wait for the client to create all the streams of an exec request.
+	execStreamCreationTimeout = 30 * time.Second
+)
+
+// execOptions are the options of an exec request.
+type execOptions struct {
+	container string
+	command   []string
+	stdin     bool
+	stdout    bool
+	stderr    bool
+	tty       bool
+}
+
+// execStreams are the streams of an exec request.
+type execStreams struct {
+	errorStream  httpstream.Stream
+	stdinStream  httpstream.Stream
+	stdoutStream httpstream.Stream
+	stderrStream httpstream.Stream
+	resizeStream httpstream.Stream
+}
+
+// apiV1Exec handles exec requests to pods.
+// Given that in the in-memory provider there are no real containers, only a few commands are supported,
+// and the output of those commands is computed from the state of the resource group the pod belongs to;
+// currently it is possible to run etcdctl member list and etcdctl endpoint health in etcd pods.
+// NOTE: only the v4.channel.k8s.io streaming protocol, the default for recent clients, is supported.
+func (h *apiServerHandler) apiV1Exec(req *restful.Request, resp *restful.Response) {
+	ctx := req.Request.Context()
+
+	cloudClient, pod, err := h.getPod(ctx, req)
+	if err != nil {
+		if status, ok := err.(apierrors.APIStatus); ok || errors.As(err, &status) {
+			_ = resp.WriteHeaderAndEntity(int(status.Status().Code), status.Status())
+			return
+		}
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	opts, err := execOptionsFromRequest(req, pod)
+	if err != nil {
+		_ = resp.WriteHeaderAndEntity(http.StatusBadRequest, apierrors.NewBadRequest(err.Error()).Status())
+		return
+	}
+
+	// Perform a sub protocol negotiation, ensuring that client and server agree on how
+	// to handle communications over the exec connection.
+	if _, err := httpstream.Handshake(req.Request, resp.ResponseWriter, []string{remotecommand.StreamProtocolV4Name}); err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	// Upgrade the connection specifying what to do when a new http stream is received.
+	// After being received, the new stream will be published into the stream channel for handling.
+	streamChan := make(chan httpstream.Stream, 5)
+	upgrader := spdy.NewResponseUpgrader()
+	conn := upgrader.UpgradeResponse(resp.ResponseWriter, req.Request, execStreamReceived(streamChan))
+	if conn == nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, "failed to get upgraded connection")
+		return
+	}
+	defer func() {
+		_ = conn.Close()
+	}()
+	conn.SetIdleTimeout(10 * time.Minute)
+
+	streams, err := waitForExecStreams(streamChan, opts, time.After(execStreamCreationTimeout))
+	if err != nil {
+		h.log.Error(err, "Exec: error waiting for streams", "Pod", klog.KObj(pod))
+		if streams.errorStream != nil {
+			writeExecStatus(streams.errorStream, err, 0)
+			_ = streams.errorStream.Close()
+		}
+		return
+	}
+
+	h.log.V(4).Info("Exec: running command", "Pod", klog.KObj(pod), "container", opts.container, "command", opts.command)
+	stdout, stderr := io.Discard, io.Discard
+	if streams.stdoutStream != nil {
+		stdout = streams.stdoutStream
+	}
+	if streams.stderrStream != nil {
+		stderr = streams.stderrStream
+	} else if opts.tty {
+		// When using a tty, stderr is merged into stdout.
+		stderr = stdout
+	}
+	exitCode, err := h.doExec(ctx, cloudClient, pod, opts, stdout, stderr)
+
+	// Close the output streams before reporting the result of the command, so the client
+	// can complete reading the output.
+	for _, s := range []httpstream.Stream{streams.stdinStream, streams.stdoutStream, streams.stderrStream, streams.resizeStream} {
+		if s != nil {
+			_ = s.Close()
+		}
+	}
+	writeExecStatus(streams.errorStream, err, exitCode)
+	_ = streams.errorStream.Close()
+}
+
+// doExec runs a command in a pod.
+// NOTE: The func returns an error when the command cannot be run, while failures of the command itself are reported
+// using a non-zero exit code and by writing to stderr.
+func (h *apiServerHandler) doExec(ctx context.Context, cloudClient cclient.Client, pod *corev1.Pod, opts *execOptions, stdout, stderr io.Writer) (int, error) {
+	if isEtcdPod(pod) && opts.container == "etcd" && opts.command[0] == "etcdctl" {
+		return etcdctl(ctx, cloudClient, pod, opts.command[1:], stdout, stderr)
+	}
+	return 0, errors.Errorf("executable file %q not found in container %q of pod %s", opts.command[0], opts.container, klog.KObj(pod))
+}
+
+// etcdctlFlagsWithValue are the etcdctl flags which are expecting a value.
+var etcdctlFlagsWithValue = sets.New[string](
+	"--cacert", "--cert", "--key", "--endpoints", "--user", "--password",
+	"--dial-timeout", "--command-timeout", "--keepalive-time", "--keepalive-timeout", "-w", "--write-out",
+)
+
+// etcdctl mimics running etcdctl in an etcd pod.
+// Flags are ignored, given that the endpoint and the certificates to use are implicitly defined by the pod.
+func etcdctl(ctx context.Context, cloudClient cclient.Client, pod *corev1.Pod, args []string, stdout, stderr io.Writer) (int, error) {
+	subcommand := []string{}
+	for i := 0; i < len(args); i++ {
+		if strings.HasPrefix(args[i], "-") {
+			// Skip the value of the flag, if not set with --flag=value.
+			if etcdctlFlagsWithValue.Has(args[i]) {
+				i++
+			}
+			continue
+		}
+		subcommand = append(subcommand, args[i])
+	}
+
+	switch strings.Join(subcommand, " ") {
+	case "member list":
+		if _, ok := pod.Annotations[cloudv1.EtcdMemberRemoved]; ok {
+			fmt.Fprintln(stderr, "Error: etcdserver: the member has been permanently removed from the cluster")
+			return 1, nil
+		}
+
+		etcdPods := &corev1.PodList{}
+		if err := cloudClient.List(ctx, etcdPods,
+			client.InNamespace(metav1.NamespaceSystem),
+			client.MatchingLabels{
+				"component": "etcd",
+				"tier":      "control-plane"},
+		); err != nil {
+			return 0, errors.Wrap(err, "failed to list etcd members")
+		}
+		for _, p := range etcdPods.Items {
+			if _, ok := p.Annotations[cloudv1.EtcdMemberRemoved]; ok {
+				continue
+			}
+			memberID, err := strconv.ParseUint(p.Annotations[cloudv1.EtcdMemberIDAnnotationName], 10, 64)
+			if err != nil {
+				return 0, errors.Wrapf(err, "failed read member ID annotation from etcd member with name %s", p.Name)
+			}
+			name := strings.TrimPrefix(p.Name, "etcd-")
+			fmt.Fprintf(stdout, "%x, started, %s, https://%s:2380, https://%s:%s, false\n", memberID, name, name, name, etcdClientPort)
+		}
+		return 0, nil
+	case "endpoint health":
+		endpoint := fmt.Sprintf("127.0.0.1:%s", etcdClientPort)
+		if _, ok := pod.Annotations[cloudv1.EtcdMemberRemoved]; ok || !isPodReady(pod) {
+			fmt.Fprintf(stderr, "%s is unhealthy: failed to commit proposal: context deadline exceeded\n", endpoint)
+			fmt.Fprintln(stderr, "Error: unhealthy cluster")
+			return 1, nil
+		}
+		fmt.Fprintf(stdout, "%s is healthy: successfully committed proposal: took = 1ms\n", endpoint)
+		return 0, nil
+	default:
+		fmt.Fprintf(stderr, "Error: unknown command %q for \"etcdctl\"\n", strings.Join(subcommand, " "))
+		return 1, nil
+	}
+}
+
+// getPod returns the pod targeted by a request to a pod subresource, e.g. exec or portforward.
+func (h *apiServerHandler) getPod(ctx context.Context, req *restful.Request) (cclient.Client, *corev1.Pod, error) {
+	// Gets the resource group the request targets (the resolver is aware of the mapping host<->resourceGroup)
+	resourceGroup, err := h.resourceGroupResolver(req.Request.Host)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// Gets at client to the resource group.
+	cloudClient := h.manager.GetResourceGroup(resourceGroup).GetClient()
+
+	pod := &corev1.Pod{}
+	if err := cloudClient.Get(ctx, client.ObjectKey{Namespace: req.PathParameter("namespace"), Name: req.PathParameter("name")}, pod); err != nil {
+		return nil, nil, err
+	}
+	return cloudClient, pod, nil
+}
+
+// execOptionsFromRequest returns the options of an exec request.
+func execOptionsFromRequest(req *restful.Request, pod *corev1.Pod) (*execOptions, error) {
+	query := req.Request.URL.Query()
+	opts := &execOptions{
+		container: query.Get("container"),
+		command:   query["command"],
+	}
+
+	for name, value := range map[string]*bool{"stdin": &opts.stdin, "stdout": &opts.stdout, "stderr": &opts.stderr, "tty": &opts.tty} {
+		if v := query.Get(name); v != "" {
+			b, err := strconv.ParseBool(v)
+			if err != nil {
+				return nil, errors.Errorf("invalid value %q for %s", v, name)
+			}
+			*value = b
+		}
+	}
+
+	if len(opts.command) == 0 {
+		return nil, errors.New("you must specify at least one command for the container")
+	}
+	if !opts.stdin && !opts.stdout && !opts.stderr {
+		return nil, errors.New("you must specify at least one of stdin, stdout, stderr")
+	}
+
+	// Defaults the container if the pod has only one.
+	// NOTE: pods created by older versions of the in-memory provider do not have containers, and
+	// in this case the container is not validated.
+	if opts.container == "" && len(pod.Spec.Containers) == 1 {
+		opts.container = pod.Spec.Containers[0].Name
+	}
+	if len(pod.Spec.Containers) > 0 {
+		found := false
+		for _, c := range pod.Spec.Containers {
+			if c.Name == opts.container {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil, errors.Errorf("container %q is not valid for pod %s", opts.container, pod.Name)
+		}
+	}
+	return opts, nil
+}
+
+// execStreamReceived is the httpstream.NewStreamHandler for exec streams.
+// Each valid stream is sent to the streams channel.
+func execStreamReceived(streamsCh chan httpstream.Stream) func(httpstream.Stream, <-chan struct{}) error {
+	return func(stream httpstream.Stream, _ <-chan struct{}) error {
+		switch streamType := stream.Headers().Get(corev1.StreamType); streamType {
+		case corev1.StreamTypeError, corev1.StreamTypeStdin, corev1.StreamTypeStdout, corev1.StreamTypeStderr, corev1.StreamTypeResize:
+			streamsCh <- stream
+			return nil
+		case "":
+			return fmt.Errorf("%q header is required", corev1.StreamType)
+		default:
+			return fmt.Errorf("invalid stream type %q", streamType)
+		}
+	}
+}
+
+// waitForExecStreams waits for the client to create all the streams expected for the given exec options.
+func waitForExecStreams(streamsCh chan httpstream.Stream, opts *execOptions, timeout <-chan time.Time) (*execStreams, error) {
+	expected := 1
+	for _, b := range []bool{opts.stdin, opts.stdout, opts.stderr && !opts.tty, opts.tty} {
+		if b {
+			expected++
+		}
+	}
+
+	streams := &execStreams{}
+	for received := 0; received < expected; received++ {
+		select {
+		case stream := <-streamsCh:
+			switch stream.Headers().Get(corev1.StreamType) {
+			case corev1.StreamTypeError:
+				streams.errorStream = stream
+			case corev1.StreamTypeStdin:
+				streams.stdinStream = stream
+			case corev1.StreamTypeStdout:
+				streams.stdoutStream = stream
+			case corev1.StreamTypeStderr:
+				streams.stderrStream = stream
+			case corev1.StreamTypeResize:
+				streams.resizeStream = stream
+			}
+		case <-timeout:
+			return streams, errors.New("timed out waiting for client to create streams")
+		}
+	}
+	if streams.errorStream == nil {
+		return streams, errors.New("error stream not received")
+	}
+	return streams, nil
+}
+
+// writeExecStatus writes the result of an exec request to the error stream, using the v4.channel.k8s.io protocol format.
+func writeExecStatus(errorStream io.Writer, err error, exitCode int) {
+	status := metav1.Status{
+		TypeMeta: metav1.TypeMeta{
+			Kind:       "Status",
+			APIVersion: "v1",
+		},
+		Status: metav1.StatusSuccess,
+	}
+	switch {
+	case err != nil:
+		status.Status = metav1.StatusFailure
+		status.Message = err.Error()
+	case exitCode != 0:
+		status.Status = metav1.StatusFailure
+		status.Reason = remotecommand.NonZeroExitCodeReason
+		status.Message = fmt.Sprintf("command terminated with non-zero exit code: %d", exitCode)
+		status.Details = &metav1.StatusDetails{
+			Causes: []metav1.StatusCause{
+				{
+					Type:    remotecommand.ExitCodeCauseType,
+					Message: strconv.Itoa(exitCode),
+				},
+			},
+		}
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		return
+	}
+	_, _ = errorStream.Write(data)
+}
+
+func isEtcdPod(pod *corev1.Pod) bool {
+	return pod.Namespace == metav1.NamespaceSystem && pod.Labels["component"] == "etcd" && pod.Labels["tier"] == "control-plane"
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
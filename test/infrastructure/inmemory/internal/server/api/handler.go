@@ -27,6 +27,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/emicklei/go-restful/v3"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
@@ -56,13 +57,18 @@ import (
 // request targets.
 type ResourceGroupResolver func(host string) (string, error)
 
+// KubernetesVersionResolver defines a func that returns the Kubernetes version emulated by the
+// API server of a workloadCluster/resourceGroup.
+type KubernetesVersionResolver func(resourceGroup string) (string, error)
+
 // NewAPIServerHandler returns an http.Handler for a fake API server.
-func NewAPIServerHandler(manager cmanager.Manager, log logr.Logger, resolver ResourceGroupResolver) http.Handler {
+func NewAPIServerHandler(manager cmanager.Manager, log logr.Logger, resolver ResourceGroupResolver, versionResolver KubernetesVersionResolver) http.Handler {
 	apiServer := &apiServerHandler{
-		container:             restful.NewContainer(),
-		manager:               manager,
-		log:                   log,
-		resourceGroupResolver: resolver,
+		container:                 restful.NewContainer(),
+		manager:                   manager,
+		log:                       log,
+		resourceGroupResolver:     resolver,
+		kubernetesVersionResolver: versionResolver,
 		requestInfoResolver: server.NewRequestInfoResolver(&server.Config{
 			LegacyAPIGroupPrefixes: sets.NewString(server.DefaultLegacyAPIPrefix),
 		}),
@@ -77,6 +83,9 @@ func NewAPIServerHandler(manager cmanager.Manager, log logr.Logger, resolver Res
 	// Health check
 	ws.Route(ws.GET("/").To(apiServer.healthz))
 
+	// Version
+	ws.Route(ws.GET("/version").To(apiServer.version))
+
 	// Discovery endpoints
 	ws.Route(ws.GET("/api").To(apiServer.apiDiscovery))
 	ws.Route(ws.GET("/api/v1").To(apiServer.apiV1Discovery))
@@ -120,6 +129,8 @@ func NewAPIServerHandler(manager cmanager.Manager, log logr.Logger, resolver Res
 	// Port forward endpoints
 	ws.Route(ws.GET("/api/v1/namespaces/{namespace}/pods/{name}/portforward").To(apiServer.apiV1PortForward))
 	ws.Route(ws.POST("/api/v1/namespaces/{namespace}/pods/{name}/portforward").Consumes("*/*").To(apiServer.apiV1PortForward))
+	ws.Route(ws.GET("/api/v1/namespaces/{namespace}/pods/{name}/exec").To(apiServer.apiV1Exec))
+	ws.Route(ws.POST("/api/v1/namespaces/{namespace}/pods/{name}/exec").Consumes("*/*").To(apiServer.apiV1Exec))
 
 	apiServer.container.Add(ws)
 
@@ -127,11 +138,12 @@ func NewAPIServerHandler(manager cmanager.Manager, log logr.Logger, resolver Res
 }
 
 type apiServerHandler struct {
-	container             *restful.Container
-	manager               cmanager.Manager
-	log                   logr.Logger
-	resourceGroupResolver ResourceGroupResolver
-	requestInfoResolver   *request.RequestInfoFactory
+	container                 *restful.Container
+	manager                   cmanager.Manager
+	log                       logr.Logger
+	resourceGroupResolver     ResourceGroupResolver
+	kubernetesVersionResolver KubernetesVersionResolver
+	requestInfoResolver       *request.RequestInfoFactory
 }
 
 func (h *apiServerHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -191,6 +203,37 @@ func cleanDryRun(u *url.URL) string {
 	return strings.Join(sets.NewString(dryRun...).List(), ",")
 }
 
+func (h *apiServerHandler) version(req *restful.Request, resp *restful.Response) {
+	v, err := h.getKubernetesVersion(req)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := resp.WriteEntity(versionInfo(v)); err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+}
+
+// getKubernetesVersion returns the Kubernetes version emulated by the API server of the workload cluster
+// a request targets.
+func (h *apiServerHandler) getKubernetesVersion(req *restful.Request) (semver.Version, error) {
+	resourceGroup, err := h.resourceGroupResolver(req.Request.Host)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	version, err := h.kubernetesVersionResolver(resourceGroup)
+	if err != nil {
+		return semver.Version{}, err
+	}
+	v, err := semver.ParseTolerant(version)
+	if err != nil {
+		return semver.Version{}, errors.Wrapf(err, "failed to parse Kubernetes version %q", version)
+	}
+	return v, nil
+}
+
 func (h *apiServerHandler) apiDiscovery(_ *restful.Request, resp *restful.Response) {
 	if err := resp.WriteEntity(apiVersions); err != nil {
 		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
@@ -198,8 +241,14 @@ func (h *apiServerHandler) apiDiscovery(_ *restful.Request, resp *restful.Respon
 	}
 }
 
-func (h *apiServerHandler) apiV1Discovery(_ *restful.Request, resp *restful.Response) {
-	if err := resp.WriteEntity(corev1APIResourceList); err != nil {
+func (h *apiServerHandler) apiV1Discovery(req *restful.Request, resp *restful.Response) {
+	v, err := h.getKubernetesVersion(req)
+	if err != nil {
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if err := resp.WriteEntity(apiResourceListForVersion(corev1APIResourceList, v)); err != nil {
 		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -207,15 +256,21 @@ func (h *apiServerHandler) apiV1Discovery(_ *restful.Request, resp *restful.Resp
 
 func (h *apiServerHandler) apisDiscovery(req *restful.Request, resp *restful.Response) {
 	if req.PathParameter("group") != "" {
+		v, err := h.getKubernetesVersion(req)
+		if err != nil {
+			_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+			return
+		}
+
 		if req.PathParameter("group") == "rbac.authorization.k8s.io" && req.PathParameter("version") == "v1" {
-			if err := resp.WriteEntity(rbacv1APIResourceList); err != nil {
+			if err := resp.WriteEntity(apiResourceListForVersion(rbacv1APIResourceList, v)); err != nil {
 				_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 				return
 			}
 			return
 		}
 		if req.PathParameter("group") == "apps" && req.PathParameter("version") == "v1" {
-			if err := resp.WriteEntity(appsV1ResourceList); err != nil {
+			if err := resp.WriteEntity(apiResourceListForVersion(appsV1ResourceList, v)); err != nil {
 				_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 				return
 			}
@@ -351,6 +406,12 @@ func (h *apiServerHandler) apiV1Watch(req *restful.Request, resp *restful.Respon
 	// If the request is a Watch handle it using watchForResource.
 	err = h.watchForResource(req, resp, resourceGroup, *gvk)
 	if err != nil {
+		// Note: API errors, e.g. ResourceExpired when the requested resourceVersion is too old, are returned as a Status,
+		// so clients can react accordingly, e.g. by re-listing.
+		if status, ok := err.(apierrors.APIStatus); ok || errors.As(err, &status) {
+			_ = resp.WriteHeaderAndEntity(int(status.Status().Code), status.Status())
+			return
+		}
 		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -542,12 +603,23 @@ func (h *apiServerHandler) apiV1PortForward(req *restful.Request, resp *restful.
 	podName := req.PathParameter("name")
 	podNamespace := req.PathParameter("namespace")
 
+	// Gets the pod the request targets; this ensures port forward requests for pods that do not exist
+	// fail like in a real cluster.
+	_, pod, err := h.getPod(req.Request.Context(), req)
+	if err != nil {
+		if status, ok := err.(apierrors.APIStatus); ok || errors.As(err, &status) {
+			_ = resp.WriteHeaderAndEntity(int(status.Status().Code), status.Status())
+			return
+		}
+		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+		return
+	}
+
 	// Perform a sub protocol negotiation, ensuring that client and server agree on how
 	// to handle communications over the port forwarded connection.
 	request := req.Request
 	respWriter := resp.ResponseWriter
-	_, err := httpstream.Handshake(request, respWriter, []string{portforward.PortForwardProtocolV1Name})
-	if err != nil {
+	if _, err := httpstream.Handshake(request, respWriter, []string{portforward.PortForwardProtocolV1Name}); err != nil {
 		_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 		return
 	}
@@ -577,9 +649,16 @@ func (h *apiServerHandler) apiV1PortForward(req *restful.Request, resp *restful.
 		streamChan,
 		podName,
 		podNamespace,
-		func(ctx context.Context, podName, podNamespace, _ string, stream io.ReadWriteCloser) error {
+		func(ctx context.Context, podName, podNamespace, port string, stream io.ReadWriteCloser) error {
+			// etcd pods are only serving the etcd client port.
+			if isEtcdPod(pod) && port != etcdClientPort {
+				return errors.Errorf("port %s is not exposed by etcd pod %s/%s", port, podNamespace, podName)
+			}
+
 			// Given that in the in-memory provider there is no real infrastructure, and thus no real workload cluster,
 			// we are going to forward all the connection back to the same server (the CAPIM controller pod).
+			// NOTE: connections to etcd pods are then routed to the corresponding fake etcd member because the
+			// etcd client sets the server name to the name of the targeted etcd pod during the TLS handshake.
 			return h.doPortForward(ctx, req.Request.Host, stream)
 		},
 	)
@@ -0,0 +1,55 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package api
+
+import (
+	"fmt"
+
+	"github.com/blang/semver/v4"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+// minVerSingularNameInDiscovery is the min version of the API server populating the singularName
+// of the built-in resources in the discovery documents.
+var minVerSingularNameInDiscovery = semver.MustParse("1.27.0")
+
+// versionInfo returns the value returned by the /version call for an API server emulating the given Kubernetes version.
+func versionInfo(v semver.Version) *version.Info {
+	return &version.Info{
+		Major:      fmt.Sprintf("%d", v.Major),
+		Minor:      fmt.Sprintf("%d", v.Minor),
+		GitVersion: fmt.Sprintf("v%d.%d.%d", v.Major, v.Minor, v.Patch),
+		Compiler:   "gc",
+		Platform:   "linux/amd64",
+	}
+}
+
+// apiResourceListForVersion returns the value returned by a discovery call for an API server emulating the given Kubernetes version.
+func apiResourceListForVersion(apiResourceList *metav1.APIResourceList, v semver.Version) *metav1.APIResourceList {
+	// Using a version without pre-release and build metadata, so that e.g. v1.27.0-rc.0 is considered as v1.27.
+	v = semver.Version{Major: v.Major, Minor: v.Minor, Patch: v.Patch}
+	if v.GTE(minVerSingularNameInDiscovery) {
+		return apiResourceList
+	}
+
+	list := apiResourceList.DeepCopy()
+	for i := range list.APIResources {
+		list.APIResources[i].SingularName = ""
+	}
+	return list
+}
@@ -24,10 +24,14 @@ import (
 
 	"github.com/emicklei/go-restful/v3"
 	"github.com/pkg/errors"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/apimachinery/pkg/watch"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/cache"
 )
 
 // Event records a lifecycle event for a Kubernetes object.
@@ -36,116 +40,77 @@ type Event struct {
 	Object runtime.Object  `json:"object,omitempty"`
 }
 
-// WatchEventDispatcher dispatches events for a single resourceGroup.
-type WatchEventDispatcher struct {
-	resourceGroup string
-	events        chan *Event
-}
+// watchBookmarkInterval is the interval at which bookmarks are sent to watches allowing bookmarks.
+var watchBookmarkInterval = time.Minute
 
-// OnCreate dispatches Create events.
-func (m *WatchEventDispatcher) OnCreate(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
-		return
-	}
-	m.events <- &Event{
-		Type:   watch.Added,
-		Object: o,
+func (h *apiServerHandler) watchForResource(req *restful.Request, resp *restful.Response, resourceGroup string, gvk schema.GroupVersionKind) error {
+	ctx := req.Request.Context()
+	queryTimeout := req.QueryParameter("timeoutSeconds")
+
+	selector, err := labels.Parse(req.QueryParameter("labelSelector"))
+	if err != nil {
+		return apierrors.NewBadRequest(fmt.Sprintf("invalid labelSelector: %v", err))
 	}
-}
 
-// OnUpdate dispatches Update events.
-func (m *WatchEventDispatcher) OnUpdate(resourceGroup string, _, o client.Object) {
-	if resourceGroup != m.resourceGroup {
-		return
+	opts := cache.WatchOptions{
+		ResourceVersion:   req.QueryParameter("resourceVersion"),
+		SendInitialEvents: req.QueryParameter("sendInitialEvents") == "true",
 	}
-	m.events <- &Event{
-		Type:   watch.Modified,
-		Object: o,
+	if req.QueryParameter("allowWatchBookmarks") == "true" {
+		opts.BookmarkInterval = watchBookmarkInterval
 	}
-}
 
-// OnDelete dispatches Delete events.
-func (m *WatchEventDispatcher) OnDelete(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
-		return
-	}
-	m.events <- &Event{
-		Type:   watch.Deleted,
-		Object: o,
+	h.log.Info(fmt.Sprintf("Serving Watch for %v", req.Request.URL))
+	w, err := h.manager.GetCache().Watch(resourceGroup, gvk, opts)
+	if err != nil {
+		return err
 	}
+	defer w.Stop()
+
+	return runWatch(ctx, w, watchFilter{namespace: req.PathParameter("namespace"), selector: selector}, queryTimeout, resp)
 }
 
-// OnGeneric dispatches Generic events.
-func (m *WatchEventDispatcher) OnGeneric(resourceGroup string, o client.Object) {
-	if resourceGroup != m.resourceGroup {
-		return
-	}
-	m.events <- &Event{
-		Type:   "GENERIC",
-		Object: o,
-	}
+// watchFilter filters the events of a watch by namespace and labels.
+type watchFilter struct {
+	namespace string
+	selector  labels.Selector
 }
 
-func (h *apiServerHandler) watchForResource(req *restful.Request, resp *restful.Response, resourceGroup string, gvk schema.GroupVersionKind) (reterr error) {
-	ctx := req.Request.Context()
-	queryTimeout := req.QueryParameter("timeoutSeconds")
-	c := h.manager.GetCache()
-	i, err := c.GetInformerForKind(ctx, gvk)
-	if err != nil {
-		return err
+func (f watchFilter) matches(e watch.Event) bool {
+	// Bookmarks only carry the resourceVersion, so they are never filtered.
+	if e.Type == watch.Bookmark {
+		return true
 	}
-	h.log.Info(fmt.Sprintf("Serving Watch for %v", req.Request.URL))
-	// With an unbuffered event channel RemoveEventHandler could be blocked because it requires a lock on the informer.
-	// When Run stops reading from the channel the informer could be blocked with an unbuffered chanel and then RemoveEventHandler never goes through.
-	// 1000 is used to avoid deadlocks in clusters with a higher number of Machines/Nodes.
-	events := make(chan *Event, 1000)
-	watcher := &WatchEventDispatcher{
-		resourceGroup: resourceGroup,
-		events:        events,
+	o, ok := e.Object.(client.Object)
+	if !ok {
+		return false
 	}
-
-	if err := i.AddEventHandler(watcher); err != nil {
-		return err
+	if f.namespace != "" && o.GetNamespace() != f.namespace {
+		return false
 	}
-
-	// Defer cleanup which removes the event handler and ensures the channel is empty of events.
-	defer func() {
-		// Doing this to ensure the channel is empty.
-		// This reduces the probability of a deadlock when removing the event handler.
-	L:
-		for {
-			select {
-			case <-events:
-			default:
-				break L
-			}
-		}
-		reterr = i.RemoveEventHandler(watcher)
-		// Note: After we removed the handler, no new events will be written to the events channel.
-	}()
-
-	return watcher.Run(ctx, queryTimeout, resp)
+	return f.selector.Matches(labels.Set(o.GetLabels()))
 }
 
-// Run serves a series of encoded events via HTTP with Transfer-Encoding: chunked.
-func (m *WatchEventDispatcher) Run(ctx context.Context, timeout string, w http.ResponseWriter) error {
-	flusher, ok := w.(http.Flusher)
+// runWatch serves a series of encoded events via HTTP with Transfer-Encoding: chunked.
+func runWatch(ctx context.Context, w watch.Interface, filter watchFilter, timeout string, rw http.ResponseWriter) error {
+	flusher, ok := rw.(http.Flusher)
 	if !ok {
 		return errors.New("can't start Watch: can't get http.Flusher")
 	}
-	resp, ok := w.(*restful.Response)
+	resp, ok := rw.(*restful.Response)
 	if !ok {
 		return errors.New("can't start Watch: can't get restful.Response")
 	}
-	w.Header().Set("Transfer-Encoding", "chunked")
-	w.WriteHeader(http.StatusOK)
-	flusher.Flush()
 
 	timeoutTimer, seconds, err := setTimer(timeout)
 	if err != nil {
 		return errors.Wrapf(err, "can't start Watch: could not set timeout")
 	}
 
+	rw.Header().Set("Transfer-Encoding", "chunked")
+	rw.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
 	ctx, cancel := context.WithTimeout(ctx, seconds)
 	defer cancel()
 	defer timeoutTimer.Stop()
@@ -155,17 +120,18 @@ func (m *WatchEventDispatcher) Run(ctx context.Context, timeout string, w http.R
 			return nil
 		case <-timeoutTimer.C:
 			return nil
-		case event, ok := <-m.events:
+		case event, ok := <-w.ResultChan():
 			if !ok {
-				// End of results.
+				// End of results, e.g. the watcher has been terminated because it was too slow.
 				return nil
 			}
-			if err := resp.WriteEntity(event); err != nil {
-				_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
+			if !filter.matches(event) {
+				continue
 			}
-			if len(m.events) == 0 {
-				flusher.Flush()
+			if err := resp.WriteEntity(&Event{Type: event.Type, Object: event.Object}); err != nil {
+				_ = resp.WriteErrorString(http.StatusInternalServerError, err.Error())
 			}
+			flusher.Flush()
 		}
 	}
 }
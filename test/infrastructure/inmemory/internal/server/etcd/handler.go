@@ -334,12 +334,16 @@ func (b *baseServer) inspectEtcd(ctx context.Context, cloudClient cclient.Client
 			}
 		}
 
-		if pod.Name == etcdMember {
+		if pod.Name == fmt.Sprintf("%s%s", "etcd-", etcdMember) {
 			memberList.Header = &pb.ResponseHeader{
 				ClusterId: uint64(clusterID),
 				MemberId:  uint64(memberID),
 			}
 			statusResponse.Header = memberList.Header
+			statusResponse.Version = etcdVersion(&pod)
+			if !isPodReady(&pod) {
+				statusResponse.Errors = append(statusResponse.Errors, fmt.Sprintf("etcd member %s is not healthy", etcdMember))
+			}
 		}
 		memberList.Members = append(memberList.Members, &pb.Member{
 			ID:   uint64(memberID),
@@ -357,3 +361,32 @@ func (b *baseServer) inspectEtcd(ctx context.Context, cloudClient cclient.Client
 
 	return memberList, statusResponse, nil
 }
+
+// etcdVersion returns the version of the etcd member as reported by etcd, e.g. 3.5.9, by
+// reading the image tag of the etcd container in the etcd pod.
+// NOTE: etcd pods created by older versions of the in-memory provider do not have containers, and
+// in this case an empty version is returned.
+func etcdVersion(pod *corev1.Pod) string {
+	for _, c := range pod.Spec.Containers {
+		if c.Name != "etcd" {
+			continue
+		}
+		i := strings.LastIndex(c.Image, ":")
+		if i < 0 {
+			return ""
+		}
+		// Drop the image revision suffix, e.g. -0 in 3.5.9-0.
+		version, _, _ := strings.Cut(c.Image[i+1:], "-")
+		return version
+	}
+	return ""
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == corev1.PodReady {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
@@ -79,6 +79,12 @@ func Test_etcd_scalingflow(t *testing.T) {
 			},
 			Spec: corev1.PodSpec{
 				NodeName: fmt.Sprintf("etcd-%d", i),
+				Containers: []corev1.Container{
+					{
+						Name:  "etcd",
+						Image: "registry.k8s.io/etcd:3.5.9-0",
+					},
+				},
 			},
 			Status: corev1.PodStatus{
 				Phase: corev1.PodRunning,
@@ -115,6 +121,9 @@ func Test_etcd_scalingflow(t *testing.T) {
 		g.Expect(err).ToNot(HaveOccurred())
 
 		g.Expect(status.Leader).To(Equal(etcdMemberToBeLeader))
+		g.Expect(status.Header.MemberId).To(Equal(etcdMemberToBeLeader))
+		g.Expect(status.Version).To(Equal("3.5.9"))
+		g.Expect(status.Errors).To(BeEmpty())
 		g.Expect(members.GetMembers()).To(HaveLen(2))
 		g.Expect(members.GetMembers()).NotTo(ContainElement(fmt.Sprintf("etcd-%d", etcdMemberToRemove)))
 	})
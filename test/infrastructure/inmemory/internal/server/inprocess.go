@@ -0,0 +1,122 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"context"
+	"net"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// inProcessNetwork is the name of the network used by in-process connections.
+const inProcessNetwork = "inprocess"
+
+// inProcessAddr is the net.Addr of an in-process listener or connection.
+type inProcessAddr string
+
+// Network implements net.Addr.
+func (a inProcessAddr) Network() string { return inProcessNetwork }
+
+// String implements net.Addr.
+func (a inProcessAddr) String() string { return string(a) }
+
+// inProcessListener is a net.Listener serving connections created in the same process by DialContext,
+// thus not requiring to bind a host port.
+type inProcessListener struct {
+	addr      inProcessAddr
+	conns     chan net.Conn
+	closed    chan struct{}
+	closeOnce sync.Once
+}
+
+var _ net.Listener = &inProcessListener{}
+
+// newInProcessListener returns an inProcessListener for the given host port.
+func newInProcessListener(hostPort string) *inProcessListener {
+	return &inProcessListener{
+		addr:   inProcessAddr(hostPort),
+		conns:  make(chan net.Conn),
+		closed: make(chan struct{}),
+	}
+}
+
+// Accept implements net.Listener.
+func (l *inProcessListener) Accept() (net.Conn, error) {
+	select {
+	case conn := <-l.conns:
+		return conn, nil
+	case <-l.closed:
+		return nil, net.ErrClosed
+	}
+}
+
+// Close implements net.Listener.
+func (l *inProcessListener) Close() error {
+	l.closeOnce.Do(func() { close(l.closed) })
+	return nil
+}
+
+// Addr implements net.Listener.
+func (l *inProcessListener) Addr() net.Addr {
+	return l.addr
+}
+
+// DialContext returns the client side of a connection whose server side is handed over to Accept.
+func (l *inProcessListener) DialContext(ctx context.Context) (net.Conn, error) {
+	server, client := net.Pipe()
+	clientAddr := inProcessAddr("client-" + string(l.addr))
+
+	if err := l.serve(ctx, server); err != nil {
+		_ = client.Close()
+		return nil, err
+	}
+	return &inProcessConn{Conn: client, local: clientAddr, remote: l.addr}, nil
+}
+
+// serve hands over the server side of a connection to Accept, e.g. a connection accepted on a unix socket.
+// NOTE: The connection is closed if it can't be handed over.
+func (l *inProcessListener) serve(ctx context.Context, server net.Conn) error {
+	clientAddr := inProcessAddr("client-" + string(l.addr))
+
+	select {
+	case l.conns <- &inProcessConn{Conn: server, local: l.addr, remote: clientAddr}:
+		return nil
+	case <-l.closed:
+		_ = server.Close()
+		return errors.Errorf("failed to connect to %s: listener closed", l.addr)
+	case <-ctx.Done():
+		_ = server.Close()
+		return errors.Wrapf(ctx.Err(), "failed to connect to %s", l.addr)
+	}
+}
+
+// inProcessConn is a net.Conn reporting the addresses of an in-process connection.
+// NOTE: The local address of the server side of the connection is the host port of the
+// workload cluster, which is used to select the serving certificates during the TLS handshake.
+type inProcessConn struct {
+	net.Conn
+	local  net.Addr
+	remote net.Addr
+}
+
+// LocalAddr implements net.Conn.
+func (c *inProcessConn) LocalAddr() net.Addr { return c.local }
+
+// RemoteAddr implements net.Conn.
+func (c *inProcessConn) RemoteAddr() net.Addr { return c.remote }
@@ -17,11 +17,13 @@ limitations under the License.
 package server
 
 import (
+	"context"
 	"crypto/rsa"
 	"crypto/tls"
 	"crypto/x509"
 	"fmt"
 	"net"
+	"time"
 
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/sets"
@@ -39,6 +41,14 @@ type WorkloadClusterListener struct {
 	host string
 	port int
 
+	// kubernetesVersion is the Kubernetes version emulated by the API server of the workload cluster.
+	kubernetesVersion string
+
+	// propagationDelay is the duration, after the listener is started, during which connections are refused.
+	propagationDelay time.Duration
+	// readyAt is the time after which the listener accepts connections.
+	readyAt time.Time
+
 	scheme *runtime.Scheme
 
 	apiServers                  sets.Set[string]
@@ -53,6 +63,9 @@ type WorkloadClusterListener struct {
 	etcdServingCertificates map[string]*tls.Certificate
 
 	listener net.Listener
+
+	// dialContext is set when the workload cluster is served using the in-process transport.
+	dialContext func(ctx context.Context, network, address string) (net.Conn, error)
 }
 
 // Host returns the host of a WorkloadClusterListener.
@@ -75,8 +88,8 @@ func (s *WorkloadClusterListener) HostPort() string {
 	return net.JoinHostPort(s.host, fmt.Sprintf("%d", s.port))
 }
 
-// RESTConfig returns the rest config for a WorkloadClusterListener.
-func (s *WorkloadClusterListener) RESTConfig() (*rest.Config, error) {
+// KubeConfig returns a kubeconfig for connecting to a WorkloadClusterListener as an admin.
+func (s *WorkloadClusterListener) KubeConfig() ([]byte, error) {
 	kubeConfig := clientcmdapi.Config{
 		Clusters: map[string]*clientcmdapi.Cluster{
 			"in-memory": {
@@ -99,8 +112,12 @@ func (s *WorkloadClusterListener) RESTConfig() (*rest.Config, error) {
 		},
 		CurrentContext: "in-memory",
 	}
+	return clientcmd.Write(kubeConfig)
+}
 
-	b, err := clientcmd.Write(kubeConfig)
+// RESTConfig returns the rest config for a WorkloadClusterListener.
+func (s *WorkloadClusterListener) RESTConfig() (*rest.Config, error) {
+	b, err := s.KubeConfig()
 	if err != nil {
 		return nil, err
 	}
@@ -109,6 +126,7 @@ func (s *WorkloadClusterListener) RESTConfig() (*rest.Config, error) {
 	if err != nil {
 		return nil, err
 	}
+	restConfig.Dial = s.dialContext
 
 	return restConfig, nil
 }
@@ -24,10 +24,12 @@ import (
 	"fmt"
 	"net"
 	"net/http"
+	"os"
 	"strings"
 	"sync"
 	"time"
 
+	"github.com/blang/semver/v4"
 	"github.com/go-logr/logr"
 	"github.com/pkg/errors"
 	"golang.org/x/net/http2"
@@ -38,6 +40,7 @@ import (
 	"k8s.io/klog/v2"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	infrav1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/api/v1alpha1"
 	cmanager "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/manager"
 	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/server/api"
@@ -56,6 +59,10 @@ const (
 	DefaultMinPort = 20000
 	// DefaultMaxPort default max port of the workload clusters mux.
 	DefaultMaxPort = 24000
+
+	// DefaultKubernetesVersion is the Kubernetes version emulated by the API server of workload clusters
+	// not declaring a version.
+	DefaultKubernetesVersion = "v1.28.0"
 )
 
 // WorkloadClustersMuxOption define an option for the WorkloadClustersMux creation.
@@ -68,6 +75,9 @@ type WorkloadClustersMuxOptions struct {
 	MinPort   int
 	MaxPort   int
 	DebugPort int
+
+	InProcessTransport bool
+	UnixSocketPath     string
 }
 
 // ApplyOptions applies WorkloadClustersMuxOption to the current WorkloadClustersMuxOptions.
@@ -92,6 +102,30 @@ func (c CustomPorts) Apply(options *WorkloadClustersMuxOptions) {
 	options.DebugPort = c.DebugPort
 }
 
+// InProcessTransport configures the workload clusters mux to serve workload clusters over in-process
+// connections instead of binding a host port for each of them; this removes the limit on the number of
+// workload clusters imposed by the available host ports, but it requires clients to connect using
+// WorkloadClustersMux.DialContext.
+type InProcessTransport struct{}
+
+// Apply applies this configuration to the given WorkloadClustersMuxOptions.
+func (InProcessTransport) Apply(options *WorkloadClustersMuxOptions) {
+	options.InProcessTransport = true
+}
+
+// UnixSocketTransport configures the workload clusters mux to serve workload clusters through a unix socket,
+// for clients running in a different process on the same host, e.g. the Cluster API controllers; it implies
+// the InProcessTransport, and clients must connect using the dialer returned by remote.NewUnixSocketDialer.
+type UnixSocketTransport struct {
+	Path string
+}
+
+// Apply applies this configuration to the given WorkloadClustersMuxOptions.
+func (u UnixSocketTransport) Apply(options *WorkloadClustersMuxOptions) {
+	options.InProcessTransport = true
+	options.UnixSocketPath = u.Path
+}
+
 // WorkloadClustersMux implements a server that handles requests for multiple workload clusters.
 // Each workload clusters will get its own listener, serving on a dedicated port, eg.
 // wkl-cluster-1 >> :20000, wkl-cluster-2 >> :20001 etc.
@@ -103,6 +137,11 @@ type WorkloadClustersMux struct {
 	maxPort   int
 	portIndex int
 
+	// inProcess is true when workload clusters are served over in-process connections.
+	inProcess bool
+	// unixSocketListener accepts the connections to the workload clusters through a unix socket, if configured.
+	unixSocketListener net.Listener
+
 	manager cmanager.Manager // TODO: figure out if we can have a smaller interface (GetResourceGroup, GetSchema)
 
 	debugServer              http.Server
@@ -129,6 +168,7 @@ func NewWorkloadClustersMux(manager cmanager.Manager, host string, opts ...Workl
 		minPort:                   options.MinPort,
 		maxPort:                   options.MaxPort,
 		portIndex:                 options.MinPort,
+		inProcess:                 options.InProcessTransport,
 		manager:                   manager,
 		workloadClusterListeners:  map[string]*WorkloadClusterListener{},
 		workloadClusterNameByHost: map[string]string{},
@@ -159,9 +199,65 @@ func NewWorkloadClustersMux(manager cmanager.Manager, host string, opts ...Workl
 	}
 	go func() { _ = m.debugServer.Serve(l) }()
 
+	if options.UnixSocketPath != "" {
+		// Remove the socket left over by a previous run, if any.
+		if err := os.Remove(options.UnixSocketPath); err != nil && !os.IsNotExist(err) {
+			return nil, errors.Wrapf(err, "failed to remove unix socket %s", options.UnixSocketPath)
+		}
+		m.unixSocketListener, err = net.Listen("unix", options.UnixSocketPath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to create unix socket listener for workload cluster mux")
+		}
+		go m.serveUnixSocket()
+	}
+
 	return m, nil
 }
 
+// serveUnixSocket accepts the connections to the workload clusters through the unix socket, and it hands
+// them over to the in-process listeners of the target workload clusters.
+func (m *WorkloadClustersMux) serveUnixSocket() {
+	for {
+		conn, err := m.unixSocketListener.Accept()
+		if err != nil {
+			if !errors.Is(err, net.ErrClosed) {
+				m.log.Error(err, "Failed to accept connection on unix socket")
+			}
+			return
+		}
+
+		go func() {
+			if err := m.serveUnixSocketConn(conn); err != nil {
+				m.log.Error(err, "Failed to serve connection on unix socket")
+				_ = conn.Close()
+			}
+		}()
+	}
+}
+
+func (m *WorkloadClustersMux) serveUnixSocketConn(conn net.Conn) error {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	// Read the address of the target workload cluster written by the client.
+	if err := conn.SetReadDeadline(time.Now().Add(10 * time.Second)); err != nil {
+		return err
+	}
+	address, err := remote.ReadDialedAddress(conn)
+	if err != nil {
+		return err
+	}
+	if err := conn.SetReadDeadline(time.Time{}); err != nil {
+		return err
+	}
+
+	l, err := m.getInProcessListener(address)
+	if err != nil {
+		return err
+	}
+	return l.serve(ctx, conn)
+}
+
 // mixedHandler returns an handler that can serve either API server calls or etcd calls.
 func (m *WorkloadClustersMux) mixedHandler() http.Handler {
 	// Prepare a function that can identify which workloadCluster/resourceGroup a
@@ -178,8 +274,19 @@ func (m *WorkloadClustersMux) mixedHandler() http.Handler {
 		return wclName, nil
 	}
 
+	// Prepare a function that returns the Kubernetes version emulated by the API server of a workloadCluster.
+	kubernetesVersionResolver := func(wclName string) (string, error) {
+		m.lock.RLock()
+		defer m.lock.RUnlock()
+		wcl, ok := m.workloadClusterListeners[wclName]
+		if !ok {
+			return "", errors.Errorf("failed to get workloadClusterListener with name %s", wclName)
+		}
+		return wcl.kubernetesVersion, nil
+	}
+
 	// build the handlers for API server and etcd.
-	apiHandler := api.NewAPIServerHandler(m.manager, m.log, resourceGroupResolver)
+	apiHandler := api.NewAPIServerHandler(m.manager, m.log, resourceGroupResolver, kubernetesVersionResolver)
 	etcdHandler := etcd.NewEtcdServerHandler(m.manager, m.log, resourceGroupResolver)
 
 	// Creates the mixed handler combining the two above depending on
@@ -265,7 +372,10 @@ func (m *WorkloadClustersMux) HotRestart(clusters *infrav1.InMemoryClusterList)
 			return errors.Errorf("unable to restart the WorkloadClustersMux, cluster %s doesn't have the %s annotation", klog.KRef(c.Namespace, c.Name), infrav1.ResourceGroupAnnotationName)
 		}
 
-		m.initWorkloadClusterListenerWithPortLocked(resourceGroup, c.Spec.ControlPlaneEndpoint.Port)
+		wcl := m.initWorkloadClusterListenerWithPortLocked(resourceGroup, c.Spec.ControlPlaneEndpoint.Port)
+		if c.Spec.KubernetesVersion != "" {
+			wcl.kubernetesVersion = c.Spec.KubernetesVersion
+		}
 
 		if maxPort < c.Spec.ControlPlaneEndpoint.Port {
 			maxPort = c.Spec.ControlPlaneEndpoint.Port
@@ -303,10 +413,14 @@ func (m *WorkloadClustersMux) initWorkloadClusterListenerWithPortLocked(wclName
 		scheme:                  m.manager.GetScheme(),
 		host:                    m.host,
 		port:                    port,
+		kubernetesVersion:       DefaultKubernetesVersion,
 		apiServers:              sets.New[string](),
 		etcdMembers:             sets.New[string](),
 		etcdServingCertificates: map[string]*tls.Certificate{},
 	}
+	if m.inProcess {
+		wcl.dialContext = m.DialContext
+	}
 	m.workloadClusterListeners[wclName] = wcl
 	m.workloadClusterNameByHost[wcl.HostPort()] = wclName
 
@@ -314,6 +428,50 @@ func (m *WorkloadClustersMux) initWorkloadClusterListenerWithPortLocked(wclName
 	return wcl
 }
 
+// SetKubernetesVersion sets the Kubernetes version emulated by the API server of the WorkloadClusterListener.
+// If the version is empty, the DefaultKubernetesVersion is used.
+func (m *WorkloadClustersMux) SetKubernetesVersion(wclName, version string) error {
+	if version == "" {
+		version = DefaultKubernetesVersion
+	}
+	if _, err := semver.ParseTolerant(version); err != nil {
+		return errors.Wrapf(err, "failed to parse Kubernetes version %q", version)
+	}
+
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	wcl, ok := m.workloadClusterListeners[wclName]
+	if !ok {
+		return errors.Errorf("workloadClusterListener with name %s must be initialized before setting the Kubernetes version", wclName)
+	}
+	if wcl.kubernetesVersion != version {
+		wcl.kubernetesVersion = version
+		m.log.Info("Kubernetes version of the workloadClusterListener set", "listenerName", wclName, "version", version)
+	}
+	return nil
+}
+
+// SetControlPlaneEndpointPropagationDelay sets the duration, after the first API server is added, during which
+// the WorkloadClusterListener refuses connections; this simulates the propagation delay of the DNS record or of
+// the load balancer of the control plane endpoint.
+// NOTE: The delay applies every time the listener is started, e.g. also after all the API servers are removed and
+// a new one is added, or after a hot restart.
+func (m *WorkloadClustersMux) SetControlPlaneEndpointPropagationDelay(wclName string, delay time.Duration) error {
+	m.lock.Lock()
+	defer m.lock.Unlock()
+
+	wcl, ok := m.workloadClusterListeners[wclName]
+	if !ok {
+		return errors.Errorf("workloadClusterListener with name %s must be initialized before setting the control plane endpoint propagation delay", wclName)
+	}
+	if wcl.propagationDelay != delay {
+		wcl.propagationDelay = delay
+		m.log.Info("Control plane endpoint propagation delay of the workloadClusterListener set", "listenerName", wclName, "delay", delay)
+	}
+	return nil
+}
+
 // AddAPIServer mimics adding an API server instance behind the WorkloadClusterListener.
 // When the first API server instance is added the serving certificates and the admin certificate
 // for tests are generated, and the listener is started.
@@ -323,6 +481,7 @@ func (m *WorkloadClustersMux) AddAPIServer(wclName, podName string, caCert *x509
 	// doesn't work yet as GetCertificate (which is required for the tls handshake) also requires the lock.
 	var startServerErr error
 	var wcl *WorkloadClusterListener
+	var readyAt time.Time
 	err := func() error {
 		m.lock.Lock()
 		defer m.lock.Unlock()
@@ -374,17 +533,31 @@ func (m *WorkloadClustersMux) AddAPIServer(wclName, podName string, caCert *x509
 		// NOTE: There is only one listener for all API server instances; the same listener will act
 		// as a port forward target too.
 		if wcl.listener != nil {
+			readyAt = wcl.readyAt
 			return nil
 		}
 
-		l, err := net.Listen("tcp", wcl.HostPort())
-		if err != nil {
-			return errors.Wrapf(err, "failed to start WorkloadClusterListener %s, %s", wclName, wcl.HostPort())
+		// NOTE: When using the in-process transport, the listener does not bind a host port.
+		if m.inProcess {
+			wcl.listener = newInProcessListener(wcl.HostPort())
+		} else {
+			l, err := net.Listen("tcp", wcl.HostPort())
+			if err != nil {
+				return errors.Wrapf(err, "failed to start WorkloadClusterListener %s, %s", wclName, wcl.HostPort())
+			}
+			wcl.listener = l
+		}
+
+		wcl.readyAt = time.Now().Add(wcl.propagationDelay)
+		readyAt = wcl.readyAt
+		l := wcl.listener
+		if wcl.propagationDelay > 0 {
+			l = &propagationDelayListener{Listener: wcl.listener, readyAt: wcl.readyAt}
+			m.log.Info("WorkloadClusterListener will refuse connections until the control plane endpoint is propagated", "listenerName", wclName, "address", wcl.Address(), "readyAt", readyAt)
 		}
-		wcl.listener = l
 
 		go func() {
-			if startServerErr = m.muxServer.ServeTLS(wcl.listener, "", ""); startServerErr != nil && !errors.Is(startServerErr, http.ErrServerClosed) {
+			if startServerErr = m.muxServer.ServeTLS(l, "", ""); startServerErr != nil && !errors.Is(startServerErr, http.ErrServerClosed) {
 				m.log.Error(startServerErr, "Failed to start WorkloadClusterListener", "listenerName", wclName, "address", wcl.Address())
 			}
 		}()
@@ -394,13 +567,16 @@ func (m *WorkloadClustersMux) AddAPIServer(wclName, podName string, caCert *x509
 		return errors.Wrapf(err, "error starting server")
 	}
 
+	// If the control plane endpoint is not yet propagated, connections are refused, so it is not possible to
+	// check the server is working.
+	if time.Now().Before(readyAt) {
+		return nil
+	}
+
 	// Wait until the sever is working.
 	var pollErr error
 	err = wait.PollUntilContextTimeout(context.TODO(), 10*time.Millisecond, 1*time.Second, true, func(ctx context.Context) (done bool, err error) {
-		d := &net.Dialer{Timeout: 50 * time.Millisecond}
-		conn, err := tls.DialWithDialer(d, "tcp", wcl.HostPort(), &tls.Config{
-			InsecureSkipVerify: true, //nolint:gosec // config is used to connect to our own port.
-		})
+		conn, err := m.dialTLS(ctx, wcl.HostPort(), 50*time.Millisecond)
 		if err != nil {
 			pollErr = fmt.Errorf("server is not reachable: %w", err)
 			return false, nil
@@ -425,6 +601,63 @@ func (m *WorkloadClustersMux) AddAPIServer(wclName, podName string, caCert *x509
 	return nil
 }
 
+// dialTLS opens a TLS connection to a WorkloadClusterListener, using the in-process transport if configured.
+func (m *WorkloadClustersMux) dialTLS(ctx context.Context, hostPort string, timeout time.Duration) (net.Conn, error) {
+	tlsConfig := &tls.Config{
+		InsecureSkipVerify: true, //nolint:gosec // config is used to connect to our own port.
+	}
+
+	if !m.inProcess {
+		d := &net.Dialer{Timeout: timeout}
+		return tls.DialWithDialer(d, "tcp", hostPort, tlsConfig)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	rawConn, err := m.DialContext(ctx, "tcp", hostPort)
+	if err != nil {
+		return nil, err
+	}
+	conn := tls.Client(rawConn, tlsConfig)
+	if err := conn.HandshakeContext(ctx); err != nil {
+		_ = rawConn.Close()
+		return nil, err
+	}
+	return conn, nil
+}
+
+// DialContext connects to a WorkloadClusterListener served using the InProcessTransport.
+// It can be used as a dialer by the clients of the workload clusters running in the same process, e.g.
+// by setting it as rest.Config.Dial or as the dialer of the ClusterCacheTracker.
+func (m *WorkloadClustersMux) DialContext(ctx context.Context, _, address string) (net.Conn, error) {
+	l, err := m.getInProcessListener(address)
+	if err != nil {
+		return nil, err
+	}
+	return l.DialContext(ctx)
+}
+
+// getInProcessListener returns the in-process listener of the WorkloadClusterListener serving on address.
+func (m *WorkloadClustersMux) getInProcessListener(address string) (*inProcessListener, error) {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	wclName, ok := m.workloadClusterNameByHost[address]
+	if !ok {
+		return nil, errors.Errorf("failed to get workloadClusterListener for host %s", address)
+	}
+	wcl, ok := m.workloadClusterListeners[wclName]
+	if !ok {
+		return nil, errors.Errorf("failed to get workloadClusterListener with name %s", wclName)
+	}
+	l, ok := wcl.listener.(*inProcessListener)
+	if !ok {
+		return nil, errors.Errorf("workloadClusterListener with name %s is not served using the in-process transport or it is not started", wclName)
+	}
+	return l, nil
+}
+
 // DeleteAPIServer removes an API server instance from the WorkloadClusterListener.
 func (m *WorkloadClustersMux) DeleteAPIServer(wclName, podName string) error {
 	m.lock.Lock()
@@ -563,6 +796,12 @@ func (m *WorkloadClustersMux) Shutdown(ctx context.Context) error {
 		return errors.Wrap(err, "failed to shutdown the debug server")
 	}
 
+	if m.unixSocketListener != nil {
+		if err := m.unixSocketListener.Close(); err != nil {
+			return errors.Wrap(err, "failed to close the unix socket listener")
+		}
+	}
+
 	// NOTE: this closes all the listeners
 	if err := m.muxServer.Shutdown(ctx); err != nil {
 		return errors.Wrap(err, "failed to shutdown the mux server")
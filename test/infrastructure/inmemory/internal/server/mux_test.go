@@ -17,6 +17,7 @@ limitations under the License.
 package server
 
 import (
+	"bytes"
 	"context"
 	cryptorand "crypto/rand"
 	"crypto/rsa"
@@ -25,6 +26,8 @@ import (
 	"crypto/x509/pkix"
 	"fmt"
 	"math/big"
+	"net"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -37,10 +40,15 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/fields"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/remotecommand"
 	"k8s.io/klog/v2"
+	utilexec "k8s.io/utils/exec"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"sigs.k8s.io/cluster-api/controllers/remote"
 	cloudv1 "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/api/v1alpha1"
 	cmanager "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/manager"
 	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/server/proxy"
@@ -307,6 +315,19 @@ func TestAPI_PortForward(t *testing.T) {
 	err = manager.GetResourceGroup(wcl1).GetClient().Create(ctx, etcdPod)
 	g.Expect(err).ToNot(HaveOccurred())
 
+	apiServerPod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: metav1.NamespaceSystem,
+			Name:      "kube-apiserver-foo",
+			Labels: map[string]string{
+				"component": "kube-apiserver",
+				"tier":      "control-plane",
+			},
+		},
+	}
+	err = manager.GetResourceGroup(wcl1).GetClient().Create(ctx, apiServerPod)
+	g.Expect(err).ToNot(HaveOccurred())
+
 	// Test API server TLS handshake via port forward.
 
 	restConfig, err := listener.RESTConfig()
@@ -331,6 +352,11 @@ func TestAPI_PortForward(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 	defer conn.Close()
 
+	// Port forward to a pod that does not exist fails.
+
+	_, err = dialer1.DialContextWithAddr(ctx, "kube-apiserver-bar")
+	g.Expect(err).To(HaveOccurred())
+
 	// Test Etcd via port forward
 
 	caPool := x509.NewCertPool()
@@ -381,6 +407,253 @@ func TestAPI_PortForward(t *testing.T) {
 	g.Expect(err).ToNot(HaveOccurred())
 }
 
+func TestAPI_Exec(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	wcmux, c := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 500,
+		MaxPort:   DefaultMinPort + 599,
+		DebugPort: DefaultDebugPort + 5,
+	})
+	defer func() {
+		g.Expect(wcmux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	restConfig, err := wcmux.workloadClusterListeners["workload-cluster1"].RESTConfig()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	for _, name := range []string{"1", "2"} {
+		etcdPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      "etcd-" + name,
+				Labels: map[string]string{
+					"component": "etcd",
+					"tier":      "control-plane",
+				},
+				Annotations: map[string]string{
+					cloudv1.EtcdClusterIDAnnotationName: "1",
+					cloudv1.EtcdMemberIDAnnotationName:  "1" + name,
+				},
+			},
+			Spec: corev1.PodSpec{
+				Containers: []corev1.Container{{Name: "etcd", Image: "registry.k8s.io/etcd:3.5.9-0"}},
+			},
+		}
+		if name == "1" {
+			etcdPod.Status.Conditions = []corev1.PodCondition{{Type: corev1.PodReady, Status: corev1.ConditionTrue}}
+		}
+		g.Expect(c.Create(ctx, etcdPod)).To(Succeed())
+	}
+
+	exec := func(podName string, command ...string) (string, string, error) {
+		req := kubernetes.NewForConfigOrDie(restConfig).CoreV1().RESTClient().Post().
+			Resource("pods").
+			Namespace(metav1.NamespaceSystem).
+			Name(podName).
+			SubResource("exec").
+			VersionedParams(&corev1.PodExecOptions{
+				Container: "etcd",
+				Command:   command,
+				Stdout:    true,
+				Stderr:    true,
+			}, clientgoscheme.ParameterCodec)
+
+		executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+		g.Expect(err).ToNot(HaveOccurred())
+
+		stdout, stderr := &bytes.Buffer{}, &bytes.Buffer{}
+		err = executor.StreamWithContext(ctx, remotecommand.StreamOptions{Stdout: stdout, Stderr: stderr})
+		return stdout.String(), stderr.String(), err
+	}
+
+	stdout, _, err := exec("etcd-1", "etcdctl", "--cacert", "/etc/kubernetes/pki/etcd/ca.crt", "member", "list")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stdout).To(Equal("b, started, 1, https://1:2380, https://1:2379, false\nc, started, 2, https://2:2380, https://2:2379, false\n"))
+
+	stdout, _, err = exec("etcd-1", "etcdctl", "endpoint", "health")
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(stdout).To(ContainSubstring("127.0.0.1:2379 is healthy"))
+
+	_, stderr, err := exec("etcd-2", "etcdctl", "endpoint", "health")
+	var exitErr utilexec.ExitError
+	g.Expect(errors.As(err, &exitErr)).To(BeTrue())
+	g.Expect(exitErr.ExitStatus()).To(Equal(1))
+	g.Expect(stderr).To(ContainSubstring("127.0.0.1:2379 is unhealthy"))
+
+	_, _, err = exec("etcd-1", "ls")
+	g.Expect(err).To(HaveOccurred())
+	g.Expect(errors.As(err, &exitErr)).To(BeFalse())
+
+	_, _, err = exec("etcd-3", "etcdctl", "member", "list")
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAPI_Version(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	wcmux, _ := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 600,
+		MaxPort:   DefaultMinPort + 699,
+		DebugPort: DefaultDebugPort + 6,
+	})
+	defer func() {
+		g.Expect(wcmux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	restConfig, err := wcmux.workloadClusterListeners["workload-cluster1"].RESTConfig()
+	g.Expect(err).ToNot(HaveOccurred())
+	clientset := kubernetes.NewForConfigOrDie(restConfig)
+
+	// Default version.
+	v, err := clientset.Discovery().ServerVersion()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v.GitVersion).To(Equal(DefaultKubernetesVersion))
+
+	// Starting from v1.27 discovery documents include the singular name of the resources.
+	g.Expect(wcmux.SetKubernetesVersion("workload-cluster1", "v1.28.3")).To(Succeed())
+
+	v, err = clientset.Discovery().ServerVersion()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v.GitVersion).To(Equal("v1.28.3"))
+	g.Expect(v.Major).To(Equal("1"))
+	g.Expect(v.Minor).To(Equal("28"))
+
+	resources, err := clientset.Discovery().ServerResourcesForGroupVersion("v1")
+	g.Expect(err).ToNot(HaveOccurred())
+	for _, r := range resources.APIResources {
+		g.Expect(r.SingularName).ToNot(BeEmpty())
+	}
+
+	// Before v1.27 discovery documents do not include the singular name of the resources.
+	g.Expect(wcmux.SetKubernetesVersion("workload-cluster1", "v1.26.0")).To(Succeed())
+
+	v, err = clientset.Discovery().ServerVersion()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(v.GitVersion).To(Equal("v1.26.0"))
+
+	for _, gv := range []string{"v1", "apps/v1", "rbac.authorization.k8s.io/v1"} {
+		resources, err = clientset.Discovery().ServerResourcesForGroupVersion(gv)
+		g.Expect(err).ToNot(HaveOccurred())
+		for _, r := range resources.APIResources {
+			g.Expect(r.SingularName).To(BeEmpty())
+		}
+	}
+
+	// Invalid versions are rejected.
+	g.Expect(wcmux.SetKubernetesVersion("workload-cluster1", "foo")).ToNot(Succeed())
+}
+
+func TestAPI_InProcessTransport(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	wcmux, c := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 700,
+		MaxPort:   DefaultMinPort + 799,
+		DebugPort: DefaultDebugPort + 7,
+	}, InProcessTransport{})
+	defer func() {
+		g.Expect(wcmux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	wcl := wcmux.workloadClusterListeners["workload-cluster1"]
+
+	// The workload cluster does not bind a host port.
+	_, err := net.DialTimeout("tcp", wcl.HostPort(), 100*time.Millisecond)
+	g.Expect(err).To(HaveOccurred())
+
+	// Clients are served using the in-process transport.
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+	}
+	g.Expect(c.Create(ctx, node)).To(Succeed())
+
+	nodeList := &corev1.NodeList{}
+	g.Expect(c.List(ctx, nodeList)).To(Succeed())
+	g.Expect(nodeList.Items).To(HaveLen(1))
+
+	// Etcd is served using the in-process transport.
+	etcdClient, err := clientv3.New(clientv3.Config{
+		Endpoints:   []string{wcl.HostPort()},
+		DialTimeout: 1 * time.Second,
+		DialOptions: []grpc.DialOption{
+			grpc.WithBlock(),
+			grpc.WithContextDialer(func(ctx context.Context, addr string) (net.Conn, error) {
+				return wcmux.DialContext(ctx, "tcp", addr)
+			}),
+		},
+		TLS: &tls.Config{
+			InsecureSkipVerify: true, //nolint:gosec // config is used to connect to our own port.
+			ServerName:         "etcd-1",
+		},
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer etcdClient.Close()
+
+	// Connections to unknown addresses are rejected.
+	_, err = wcmux.DialContext(ctx, "tcp", "127.0.0.1:1")
+	g.Expect(err).To(HaveOccurred())
+
+	// Connections are rejected after the API server is removed.
+	g.Expect(wcmux.DeleteAPIServer("workload-cluster1", "kube-apiserver-1")).To(Succeed())
+	_, err = wcmux.DialContext(ctx, "tcp", wcl.HostPort())
+	g.Expect(err).To(HaveOccurred())
+}
+
+func TestAPI_UnixSocketTransport(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	socketPath := filepath.Join(t.TempDir(), "workload-clusters.sock")
+	wcmux, _ := setupWorkloadClusterListener(g, CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 800,
+		MaxPort:   DefaultMinPort + 899,
+		DebugPort: DefaultDebugPort + 8,
+	}, UnixSocketTransport{Path: socketPath})
+	defer func() {
+		g.Expect(wcmux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	wcl := wcmux.workloadClusterListeners["workload-cluster1"]
+
+	// The workload cluster does not bind a host port.
+	_, err := net.DialTimeout("tcp", wcl.HostPort(), 100*time.Millisecond)
+	g.Expect(err).To(HaveOccurred())
+
+	// Clients connecting through the unix socket are served, like the Cluster API controllers
+	// using the same dialer in the ClusterCacheTracker.
+	restConfig, err := wcl.RESTConfig()
+	g.Expect(err).ToNot(HaveOccurred())
+	restConfig.Dial = remote.NewUnixSocketDialer(socketPath)
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	g.Expect(err).ToNot(HaveOccurred())
+
+	node := &corev1.Node{
+		ObjectMeta: metav1.ObjectMeta{Name: "foo"},
+	}
+	g.Expect(c.Create(ctx, node)).To(Succeed())
+
+	nodeList := &corev1.NodeList{}
+	g.Expect(c.List(ctx, nodeList)).To(Succeed())
+	g.Expect(nodeList.Items).To(HaveLen(1))
+
+	// Connections to unknown addresses are rejected.
+	restConfig.Dial = func(ctx context.Context, network, _ string) (net.Conn, error) {
+		return remote.NewUnixSocketDialer(socketPath)(ctx, network, "127.0.0.1:1")
+	}
+	restConfig.Timeout = time.Second
+	c, err = client.New(restConfig, client.Options{Scheme: scheme, Mapper: c.RESTMapper()})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(c.List(ctx, nodeList)).ToNot(Succeed())
+}
+
 func TestAPI_corev1_Watch(t *testing.T) {
 	g := NewWithT(t)
 
@@ -479,11 +752,54 @@ func TestAPI_corev1_Watch(t *testing.T) {
 	g.Expect(receivedEvents).To(Equal(expectedEvents))
 }
 
-func setupWorkloadClusterListener(g Gomega, ports CustomPorts) (*WorkloadClustersMux, client.WithWatch) {
+func TestAPI_ControlPlaneEndpointPropagationDelay(t *testing.T) {
+	t.Parallel()
+	g := NewWithT(t)
+
+	manager := cmanager.New(scheme)
+	wcmux, err := NewWorkloadClustersMux(manager, "127.0.0.1", CustomPorts{
+		// NOTE: make sure to use ports different than other tests, so we can run tests in parallel
+		MinPort:   DefaultMinPort + 800,
+		MaxPort:   DefaultMinPort + 899,
+		DebugPort: DefaultDebugPort + 8,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		g.Expect(wcmux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	wcl1 := "workload-cluster1"
+	manager.AddResourceGroup(wcl1)
+	listener, err := wcmux.InitWorkloadClusterListener(wcl1)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(wcmux.SetControlPlaneEndpointPropagationDelay(wcl1, 2*time.Second)).To(Succeed())
+
+	caCert, caKey, err := newCertificateAuthority()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	// API servers are added without waiting for the control plane endpoint to be propagated.
+	g.Expect(wcmux.AddAPIServer(wcl1, "kube-apiserver-1", caCert, caKey)).To(Succeed())
+	g.Expect(wcmux.AddAPIServer(wcl1, "kube-apiserver-2", caCert, caKey)).To(Succeed())
+
+	// Connections are refused until the control plane endpoint is propagated.
+	_, err = wcmux.dialTLS(ctx, listener.HostPort(), time.Second)
+	g.Expect(err).To(HaveOccurred())
+
+	c, err := listener.GetClient()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Eventually(func() error {
+		return c.List(ctx, &corev1.NodeList{})
+	}, 5*time.Second, 100*time.Millisecond).Should(Succeed())
+
+	// Setting the propagation delay requires an initialized listener.
+	g.Expect(wcmux.SetControlPlaneEndpointPropagationDelay("workload-cluster2", time.Second)).ToNot(Succeed())
+}
+
+func setupWorkloadClusterListener(g Gomega, ports CustomPorts, opts ...WorkloadClustersMuxOption) (*WorkloadClustersMux, client.WithWatch) {
 	manager := cmanager.New(scheme)
 
 	host := "127.0.0.1"
-	wcmux, err := NewWorkloadClustersMux(manager, host, ports)
+	wcmux, err := NewWorkloadClustersMux(manager, host, append([]WorkloadClustersMuxOption{ports}, opts...)...)
 	g.Expect(err).ToNot(HaveOccurred())
 
 	// InfraCluster controller >> when "creating the load balancer"
@@ -0,0 +1,45 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package server
+
+import (
+	"net"
+	"time"
+)
+
+// propagationDelayListener is a net.Listener closing the connections accepted before readyAt, thus simulating
+// a control plane endpoint whose DNS record or load balancer is not yet propagated.
+type propagationDelayListener struct {
+	net.Listener
+	readyAt time.Time
+}
+
+var _ net.Listener = &propagationDelayListener{}
+
+// Accept implements net.Listener.
+func (l *propagationDelayListener) Accept() (net.Conn, error) {
+	for {
+		conn, err := l.Listener.Accept()
+		if err != nil {
+			return nil, err
+		}
+		if !time.Now().Before(l.readyAt) {
+			return conn, nil
+		}
+		_ = conn.Close()
+	}
+}
@@ -0,0 +1,220 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package simulator implements fake workload clusters served by the in-memory backend without the Cluster API
+// controllers, so tools connecting to workload clusters can be tested against many fake API servers.
+package simulator
+
+import (
+	"context"
+	"crypto/rsa"
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
+
+	cmanager "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/manager"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/server"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+// Profile defines the fake workload clusters to simulate.
+type Profile struct {
+	// Clusters are the groups of fake workload clusters to simulate.
+	Clusters []ClusterProfile `json:"clusters"`
+}
+
+// ClusterProfile defines a group of identical fake workload clusters.
+type ClusterProfile struct {
+	// NamePrefix is the prefix of the names of the workload clusters; the workload clusters are named
+	// `<namePrefix>-<index>`, with index starting from 0.
+	NamePrefix string `json:"namePrefix"`
+
+	// Count is the number of workload clusters. Defaults to 1.
+	Count int `json:"count,omitempty"`
+
+	// KubernetesVersion is the Kubernetes version emulated by the API servers. Defaults to server.DefaultKubernetesVersion.
+	KubernetesVersion string `json:"kubernetesVersion,omitempty"`
+
+	// ControlPlaneNodes is the number of control plane Nodes, each one running an API server Pod. Defaults to 1.
+	ControlPlaneNodes int `json:"controlPlaneNodes,omitempty"`
+
+	// WorkerNodes is the number of worker Nodes.
+	WorkerNodes int `json:"workerNodes,omitempty"`
+}
+
+// LoadProfile reads a Profile from a YAML file.
+func LoadProfile(path string) (*Profile, error) {
+	data, err := os.ReadFile(path) //nolint:gosec // The path is provided by the user running the simulator.
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to read profile %s", path)
+	}
+	profile := &Profile{}
+	if err := yaml.UnmarshalStrict(data, profile); err != nil {
+		return nil, errors.Wrapf(err, "failed to parse profile %s", path)
+	}
+	for i, c := range profile.Clusters {
+		if c.NamePrefix == "" {
+			return nil, errors.Errorf("invalid profile %s: clusters[%d].namePrefix must be set", path, i)
+		}
+		if c.Count < 0 || c.ControlPlaneNodes < 0 || c.WorkerNodes < 0 {
+			return nil, errors.Errorf("invalid profile %s: clusters[%d] counts must not be negative", path, i)
+		}
+	}
+	return profile, nil
+}
+
+// Simulator creates fake workload clusters in a cloud Manager, served by a WorkloadClustersMux.
+type Simulator struct {
+	manager cmanager.Manager
+	mux     *server.WorkloadClustersMux
+}
+
+// New returns a new Simulator.
+func New(manager cmanager.Manager, mux *server.WorkloadClustersMux) *Simulator {
+	return &Simulator{
+		manager: manager,
+		mux:     mux,
+	}
+}
+
+// AddClusters adds all the workload clusters of a Profile and returns their admin kubeconfig, by workload cluster name.
+func (s *Simulator) AddClusters(ctx context.Context, profile *Profile) (map[string][]byte, error) {
+	kubeconfigs := map[string][]byte{}
+	for _, c := range profile.Clusters {
+		count := c.Count
+		if count == 0 {
+			count = 1
+		}
+		for i := 0; i < count; i++ {
+			name := fmt.Sprintf("%s-%d", c.NamePrefix, i)
+			if _, ok := kubeconfigs[name]; ok {
+				return nil, errors.Errorf("failed to add workload cluster %s: duplicated name", name)
+			}
+			kubeconfig, err := s.AddCluster(ctx, name, c)
+			if err != nil {
+				return nil, err
+			}
+			kubeconfigs[name] = kubeconfig
+		}
+	}
+	return kubeconfigs, nil
+}
+
+// AddCluster adds a workload cluster and returns its admin kubeconfig; the workload cluster gets the Nodes
+// and the API server Pods defined in the ClusterProfile.
+// NOTE: etcd members are not simulated, so tools relying on etcd, e.g. KCP, can't be used with the workload cluster.
+func (s *Simulator) AddCluster(ctx context.Context, name string, profile ClusterProfile) ([]byte, error) {
+	s.manager.AddResourceGroup(name)
+
+	listener, err := s.mux.InitWorkloadClusterListener(name)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to init the listener for workload cluster %s", name)
+	}
+	if err := s.mux.SetKubernetesVersion(name, profile.KubernetesVersion); err != nil {
+		return nil, errors.Wrapf(err, "failed to set the Kubernetes version of workload cluster %s", name)
+	}
+
+	ca := &secret.Certificate{Purpose: secret.ClusterCA}
+	if err := ca.Generate(); err != nil {
+		return nil, errors.Wrapf(err, "failed to generate the certificate authority of workload cluster %s", name)
+	}
+	caCert, err := certs.DecodeCertPEM(ca.KeyPair.Cert)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the certificate authority of workload cluster %s", name)
+	}
+	caSigner, err := certs.DecodePrivateKeyPEM(ca.KeyPair.Key)
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to decode the certificate authority key of workload cluster %s", name)
+	}
+	caKey, ok := caSigner.(*rsa.PrivateKey)
+	if !ok {
+		return nil, errors.Errorf("failed to decode the certificate authority key of workload cluster %s: not an RSA key", name)
+	}
+
+	controlPlaneNodes := profile.ControlPlaneNodes
+	if controlPlaneNodes == 0 {
+		controlPlaneNodes = 1
+	}
+	cloudClient := s.manager.GetResourceGroup(name).GetClient()
+	for i := 0; i < controlPlaneNodes+profile.WorkerNodes; i++ {
+		node := &corev1.Node{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: fmt.Sprintf("%s-worker-%d", name, i-controlPlaneNodes),
+			},
+			Spec: corev1.NodeSpec{
+				ProviderID: fmt.Sprintf("in-memory://%s-%d", name, i),
+			},
+			Status: corev1.NodeStatus{
+				Conditions: []corev1.NodeCondition{
+					{
+						Type:   corev1.NodeReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+		if i < controlPlaneNodes {
+			node.Name = fmt.Sprintf("%s-control-plane-%d", name, i)
+			node.Labels = map[string]string{"node-role.kubernetes.io/control-plane": ""}
+		}
+		if err := cloudClient.Create(ctx, node); err != nil {
+			return nil, errors.Wrapf(err, "failed to create Node %s", node.Name)
+		}
+		if i >= controlPlaneNodes {
+			continue
+		}
+
+		apiServerPod := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Namespace: metav1.NamespaceSystem,
+				Name:      fmt.Sprintf("kube-apiserver-%s", node.Name),
+				Labels: map[string]string{
+					"component": "kube-apiserver",
+					"tier":      "control-plane",
+				},
+			},
+			Spec: corev1.PodSpec{
+				NodeName: node.Name,
+			},
+			Status: corev1.PodStatus{
+				Phase: corev1.PodRunning,
+				Conditions: []corev1.PodCondition{
+					{
+						Type:   corev1.PodReady,
+						Status: corev1.ConditionTrue,
+					},
+				},
+			},
+		}
+		if err := cloudClient.Create(ctx, apiServerPod); err != nil {
+			return nil, errors.Wrapf(err, "failed to create Pod %s", apiServerPod.Name)
+		}
+		if err := s.mux.AddAPIServer(name, apiServerPod.Name, caCert, caKey); err != nil {
+			return nil, errors.Wrapf(err, "failed to start the API server %s of workload cluster %s", apiServerPod.Name, name)
+		}
+	}
+
+	kubeconfig, err := listener.KubeConfig()
+	if err != nil {
+		return nil, errors.Wrapf(err, "failed to create the kubeconfig of workload cluster %s", name)
+	}
+	return kubeconfig, nil
+}
@@ -0,0 +1,134 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package simulator
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+
+	cmanager "sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/cloud/runtime/manager"
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/internal/server"
+)
+
+func TestLoadProfile(t *testing.T) {
+	tests := []struct {
+		name    string
+		profile string
+		want    *Profile
+		wantErr bool
+	}{
+		{
+			name: "valid profile",
+			profile: `clusters:
+- namePrefix: small
+  count: 10
+- namePrefix: large
+  kubernetesVersion: v1.27.3
+  controlPlaneNodes: 3
+  workerNodes: 100
+`,
+			want: &Profile{Clusters: []ClusterProfile{
+				{NamePrefix: "small", Count: 10},
+				{NamePrefix: "large", KubernetesVersion: "v1.27.3", ControlPlaneNodes: 3, WorkerNodes: 100},
+			}},
+		},
+		{
+			name:    "missing name prefix",
+			profile: "clusters:\n- count: 10\n",
+			wantErr: true,
+		},
+		{
+			name:    "unknown field",
+			profile: "clusters:\n- namePrefix: small\n  nodes: 10\n",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			path := filepath.Join(t.TempDir(), "profile.yaml")
+			g.Expect(os.WriteFile(path, []byte(tt.profile), 0600)).To(Succeed())
+
+			got, err := LoadProfile(path)
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestSimulator(t *testing.T) {
+	g := NewWithT(t)
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	scheme := runtime.NewScheme()
+	g.Expect(corev1.AddToScheme(scheme)).To(Succeed())
+	manager := cmanager.New(scheme)
+	g.Expect(manager.Start(ctx)).To(Succeed())
+
+	mux, err := server.NewWorkloadClustersMux(manager, "127.0.0.1", server.CustomPorts{
+		// NOTE: make sure to use ports different than the server tests, so the tests can run in parallel.
+		MinPort:   server.DefaultMinPort + 1000,
+		MaxPort:   server.DefaultMinPort + 1099,
+		DebugPort: server.DefaultDebugPort + 100,
+	})
+	g.Expect(err).ToNot(HaveOccurred())
+	defer func() {
+		g.Expect(mux.Shutdown(ctx)).To(Succeed())
+	}()
+
+	kubeconfigs, err := New(manager, mux).AddClusters(ctx, &Profile{Clusters: []ClusterProfile{
+		{NamePrefix: "small", Count: 2},
+		{NamePrefix: "large", KubernetesVersion: "v1.27.3", ControlPlaneNodes: 3, WorkerNodes: 5},
+	}})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(kubeconfigs).To(HaveLen(3))
+	g.Expect(kubeconfigs).To(HaveKey("small-0"))
+	g.Expect(kubeconfigs).To(HaveKey("small-1"))
+
+	// The fake workload clusters can be reached using their kubeconfig.
+	restConfig, err := clientcmd.RESTConfigFromKubeConfig(kubeconfigs["large-0"])
+	g.Expect(err).ToNot(HaveOccurred())
+	clientSet, err := kubernetes.NewForConfig(restConfig)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	version, err := clientSet.Discovery().ServerVersion()
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(version.GitVersion).To(Equal("v1.27.3"))
+
+	nodes, err := clientSet.CoreV1().Nodes().List(ctx, metav1.ListOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(nodes.Items).To(HaveLen(8))
+
+	pods, err := clientSet.CoreV1().Pods("kube-system").List(ctx, metav1.ListOptions{})
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(pods.Items).To(HaveLen(3))
+}
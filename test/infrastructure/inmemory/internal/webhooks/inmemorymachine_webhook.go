@@ -18,8 +18,12 @@ package webhooks
 
 import (
 	"context"
+	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -52,16 +56,54 @@ func (webhook *InMemoryMachine) Default(_ context.Context, _ runtime.Object) err
 var _ webhook.CustomValidator = &InMemoryMachine{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *InMemoryMachine) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (webhook *InMemoryMachine) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, webhook.validate(obj)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *InMemoryMachine) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (webhook *InMemoryMachine) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, webhook.validate(newObj)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (webhook *InMemoryMachine) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
+
+func (webhook *InMemoryMachine) validate(obj runtime.Object) error {
+	machine, ok := obj.(*v1alpha1.InMemoryMachine)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an InMemoryMachine but got a %T", obj))
+	}
+	if allErrs := validateInMemoryMachineBehaviour(machine.Spec.Behaviour, field.NewPath("spec", "behaviour")); len(allErrs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("InMemoryMachine").GroupKind(), machine.Name, allErrs)
+	}
+	return nil
+}
+
+// validateInMemoryMachineBehaviour validates the behaviour of an InMemoryMachine.
+func validateInMemoryMachineBehaviour(behaviour *v1alpha1.InMemoryMachineBehaviour, fldPath *field.Path) field.ErrorList {
+	if behaviour == nil || behaviour.ResourceConsumption == nil {
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	resourceConsumptionPath := fldPath.Child("resourceConsumption")
+	allErrs = append(allErrs, validatePayloadSize(behaviour.ResourceConsumption.InMemoryMachinePayload, resourceConsumptionPath.Child("inMemoryMachinePayload"))...)
+	allErrs = append(allErrs, validatePayloadSize(behaviour.ResourceConsumption.CloudObjectsPayload, resourceConsumptionPath.Child("cloudObjectsPayload"))...)
+	return allErrs
+}
+
+// validatePayloadSize validates that a payload fits in an annotation.
+func validatePayloadSize(size *resource.Quantity, fldPath *field.Path) field.ErrorList {
+	if size == nil {
+		return nil
+	}
+	if size.Sign() < 0 {
+		return field.ErrorList{field.Invalid(fldPath, size.String(), "must be greater than or equal to 0")}
+	}
+	if size.Cmp(*resource.NewQuantity(v1alpha1.MaxPayloadSize, resource.BinarySI)) > 0 {
+		return field.ErrorList{field.Invalid(fldPath, size.String(), fmt.Sprintf("must be less than or equal to %s", resource.NewQuantity(v1alpha1.MaxPayloadSize, resource.BinarySI)))}
+	}
+	return nil
+}
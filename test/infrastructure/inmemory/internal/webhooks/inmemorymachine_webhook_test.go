@@ -0,0 +1,103 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhooks
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/resource"
+
+	"sigs.k8s.io/cluster-api/test/infrastructure/inmemory/api/v1alpha1"
+)
+
+func TestInMemoryMachineValidatePayloadSize(t *testing.T) {
+	machine := func(inMemoryMachinePayload, cloudObjectsPayload string) *v1alpha1.InMemoryMachine {
+		m := &v1alpha1.InMemoryMachine{
+			Spec: v1alpha1.InMemoryMachineSpec{
+				Behaviour: &v1alpha1.InMemoryMachineBehaviour{
+					ResourceConsumption: &v1alpha1.InMemoryResourceConsumptionBehaviour{},
+				},
+			},
+		}
+		if inMemoryMachinePayload != "" {
+			q := resource.MustParse(inMemoryMachinePayload)
+			m.Spec.Behaviour.ResourceConsumption.InMemoryMachinePayload = &q
+		}
+		if cloudObjectsPayload != "" {
+			q := resource.MustParse(cloudObjectsPayload)
+			m.Spec.Behaviour.ResourceConsumption.CloudObjectsPayload = &q
+		}
+		return m
+	}
+
+	tests := []struct {
+		name      string
+		machine   *v1alpha1.InMemoryMachine
+		wantError bool
+	}{
+		{
+			name:    "no payload",
+			machine: machine("", ""),
+		},
+		{
+			name:    "payloads within the annotation size limit",
+			machine: machine("10Ki", "250Ki"),
+		},
+		{
+			name:      "InMemoryMachine payload bigger than the annotation size limit",
+			machine:   machine("1Mi", ""),
+			wantError: true,
+		},
+		{
+			name:      "cloud objects payload bigger than the annotation size limit",
+			machine:   machine("", "251Ki"),
+			wantError: true,
+		},
+		{
+			name:      "negative payload",
+			machine:   machine("-1", ""),
+			wantError: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			webhook := &InMemoryMachine{}
+			_, err := webhook.ValidateCreate(context.Background(), tt.machine)
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+
+			template := &InMemoryMachineTemplate{}
+			_, err = template.ValidateCreate(context.Background(), &v1alpha1.InMemoryMachineTemplate{
+				Spec: v1alpha1.InMemoryMachineTemplateSpec{
+					Template: v1alpha1.InMemoryMachineTemplateResource{Spec: tt.machine.Spec},
+				},
+			})
+			if tt.wantError {
+				g.Expect(err).To(HaveOccurred())
+			} else {
+				g.Expect(err).ToNot(HaveOccurred())
+			}
+		})
+	}
+}
@@ -18,8 +18,11 @@ package webhooks
 
 import (
 	"context"
+	"fmt"
 
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
@@ -52,16 +55,27 @@ func (webhook *InMemoryMachineTemplate) Default(_ context.Context, _ runtime.Obj
 var _ webhook.CustomValidator = &InMemoryMachineTemplate{}
 
 // ValidateCreate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *InMemoryMachineTemplate) ValidateCreate(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (webhook *InMemoryMachineTemplate) ValidateCreate(_ context.Context, obj runtime.Object) (admission.Warnings, error) {
+	return nil, webhook.validate(obj)
 }
 
 // ValidateUpdate implements webhook.Validator so a webhook will be registered for the type.
-func (webhook *InMemoryMachineTemplate) ValidateUpdate(_ context.Context, _, _ runtime.Object) (admission.Warnings, error) {
-	return nil, nil
+func (webhook *InMemoryMachineTemplate) ValidateUpdate(_ context.Context, _, newObj runtime.Object) (admission.Warnings, error) {
+	return nil, webhook.validate(newObj)
 }
 
 // ValidateDelete implements webhook.Validator so a webhook will be registered for the type.
 func (webhook *InMemoryMachineTemplate) ValidateDelete(_ context.Context, _ runtime.Object) (admission.Warnings, error) {
 	return nil, nil
 }
+
+func (webhook *InMemoryMachineTemplate) validate(obj runtime.Object) error {
+	template, ok := obj.(*v1alpha1.InMemoryMachineTemplate)
+	if !ok {
+		return apierrors.NewBadRequest(fmt.Sprintf("expected an InMemoryMachineTemplate but got a %T", obj))
+	}
+	if allErrs := validateInMemoryMachineBehaviour(template.Spec.Template.Spec.Behaviour, field.NewPath("spec", "template", "spec", "behaviour")); len(allErrs) > 0 {
+		return apierrors.NewInvalid(v1alpha1.GroupVersion.WithKind("InMemoryMachineTemplate").GroupKind(), template.Name, allErrs)
+	}
+	return nil
+}
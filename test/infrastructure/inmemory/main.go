@@ -41,6 +41,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/cache"
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/controller"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -81,8 +82,9 @@ var (
 	diagnosticsOptions          = flags.DiagnosticsOptions{}
 	logOptions                  = logs.NewOptions()
 	// CAPIM specific flags.
-	clusterConcurrency int
-	machineConcurrency int
+	clusterConcurrency     int
+	machineConcurrency     int
+	workloadClustersSocket string
 )
 
 func init() {
@@ -132,6 +134,9 @@ func InitFlags(fs *pflag.FlagSet) {
 	fs.IntVar(&machineConcurrency, "machine-concurrency", 10,
 		"Number of machines to process simultaneously")
 
+	fs.StringVar(&workloadClustersSocket, "workload-clusters-socket", "",
+		"Path of a unix socket used to serve the workload clusters instead of binding a host port for each of them. The Cluster API controllers must connect to the workload clusters using the same socket, e.g. with the --workload-clusters-socket flag of the Cluster API controller manager")
+
 	fs.DurationVar(&syncPeriod, "sync-period", 10*time.Minute,
 		"The minimum interval at which watched resources are reconciled (e.g. 15m)")
 
@@ -279,9 +284,16 @@ func setupReconcilers(ctx context.Context, mgr ctrl.Manager) {
 		os.Exit(1)
 	}
 
+	// Report the simulated usage of the cloud resources.
+	ctrlmetrics.Registry.MustRegister(cloud.NewUsageCollector(cloudMgr.GetCache()))
+
 	// Start an http server
 	podIP := os.Getenv("POD_IP")
-	apiServerMux, err := server.NewWorkloadClustersMux(cloudMgr, podIP)
+	var muxOptions []server.WorkloadClustersMuxOption
+	if workloadClustersSocket != "" {
+		muxOptions = append(muxOptions, server.UnixSocketTransport{Path: workloadClustersSocket})
+	}
+	apiServerMux, err := server.NewWorkloadClustersMux(cloudMgr, podIP, muxOptions...)
 	if err != nil {
 		setupLog.Error(err, "unable to create workload clusters mux")
 		os.Exit(1)
@@ -0,0 +1,206 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package addresses implements utility functions for the addresses reported by Machines,
+// including Machines with multiple addresses of the same type, e.g. dual-stack Machines.
+package addresses
+
+import (
+	"fmt"
+	"net"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/sets"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// knownTypes are the known address types.
+var knownTypes = sets.New(
+	clusterv1.MachineInternalIP,
+	clusterv1.MachineExternalIP,
+	clusterv1.MachineInternalDNS,
+	clusterv1.MachineExternalDNS,
+	clusterv1.MachineHostName,
+)
+
+// IsIPType returns true if the address type is an IP address type.
+func IsIPType(addressType clusterv1.MachineAddressType) bool {
+	return addressType == clusterv1.MachineInternalIP || addressType == clusterv1.MachineExternalIP
+}
+
+// IPFamily returns the IP family of an address, or an empty string if the address is not an IP address.
+func IPFamily(address clusterv1.MachineAddress) corev1.IPFamily {
+	if !IsIPType(address.Type) {
+		return ""
+	}
+	ip := net.ParseIP(address.Address)
+	switch {
+	case ip == nil:
+		return ""
+	case ip.To4() != nil:
+		return corev1.IPv4Protocol
+	default:
+		return corev1.IPv6Protocol
+	}
+}
+
+// Validate validates the addresses reported for a Machine:
+// - each address must be valid, see ValidateAddress,
+// - the same address must not be reported twice with the same type.
+func Validate(addresses clusterv1.MachineAddresses, fldPath *field.Path) field.ErrorList {
+	var allErrs field.ErrorList
+
+	seen := sets.Set[string]{}
+	for i, address := range addresses {
+		path := fldPath.Index(i)
+
+		if errs := ValidateAddress(address, path); len(errs) > 0 {
+			allErrs = append(allErrs, errs...)
+			continue
+		}
+
+		key := key(address)
+		if seen.Has(key) {
+			allErrs = append(allErrs, field.Duplicate(path, address))
+			continue
+		}
+		seen.Insert(key)
+	}
+
+	return allErrs
+}
+
+// ValidateAddress validates a single address reported for a Machine:
+// - the address must have a known type,
+// - IP addresses must be valid IPv4 or IPv6 addresses,
+// - DNS names and host names must be valid (case-insensitive) DNS subdomains.
+func ValidateAddress(address clusterv1.MachineAddress, fldPath *field.Path) field.ErrorList {
+	if !knownTypes.Has(address.Type) {
+		return field.ErrorList{field.NotSupported(fldPath.Child("type"), address.Type, supportedTypes())}
+	}
+
+	if IsIPType(address.Type) {
+		if net.ParseIP(address.Address) == nil {
+			return field.ErrorList{field.Invalid(fldPath.Child("address"), address.Address, "must be a valid IPv4 or IPv6 address")}
+		}
+		return nil
+	}
+
+	var allErrs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(strings.ToLower(address.Address)) {
+		allErrs = append(allErrs, field.Invalid(fldPath.Child("address"), address.Address, msg))
+	}
+	return allErrs
+}
+
+// Normalize returns a copy of the addresses without duplicates, i.e. addresses of the same type with the same
+// value, or with the same IP in a different form.
+// The order reported by the provider is preserved, given that providers usually report the preferred address
+// of each type first, and so are the addresses themselves, including the invalid ones.
+func Normalize(addresses clusterv1.MachineAddresses) clusterv1.MachineAddresses {
+	if addresses == nil {
+		return nil
+	}
+
+	normalized := make(clusterv1.MachineAddresses, 0, len(addresses))
+	seen := sets.Set[string]{}
+	for _, address := range addresses {
+		key := key(address)
+		if seen.Has(key) {
+			continue
+		}
+		seen.Insert(key)
+		normalized = append(normalized, address)
+	}
+	return normalized
+}
+
+// Preferred returns the preferred address of the given type and IP family, which is the first address
+// reported by the provider; if family is empty, the first address of the given type is returned.
+func Preferred(addresses clusterv1.MachineAddresses, addressType clusterv1.MachineAddressType, family corev1.IPFamily) (clusterv1.MachineAddress, bool) {
+	for _, address := range addresses {
+		if address.Type != addressType {
+			continue
+		}
+		if family != "" && IPFamily(address) != family {
+			continue
+		}
+		return address, true
+	}
+	return clusterv1.MachineAddress{}, false
+}
+
+// IPs returns the canonical form of the IP addresses, optionally filtered by type.
+func IPs(addresses clusterv1.MachineAddresses, addressTypes ...clusterv1.MachineAddressType) sets.Set[string] {
+	ips := sets.Set[string]{}
+	for _, address := range filter(addresses, addressTypes) {
+		if !IsIPType(address.Type) {
+			continue
+		}
+		if ip := net.ParseIP(address.Address); ip != nil {
+			ips.Insert(ip.String())
+		}
+	}
+	return ips
+}
+
+// DNSNames returns the DNS names and the host names, optionally filtered by type.
+func DNSNames(addresses clusterv1.MachineAddresses, addressTypes ...clusterv1.MachineAddressType) sets.Set[string] {
+	dnsNames := sets.Set[string]{}
+	for _, address := range filter(addresses, addressTypes) {
+		if IsIPType(address.Type) {
+			continue
+		}
+		dnsNames.Insert(address.Address)
+	}
+	return dnsNames
+}
+
+func filter(addresses clusterv1.MachineAddresses, addressTypes []clusterv1.MachineAddressType) clusterv1.MachineAddresses {
+	if len(addressTypes) == 0 {
+		return addresses
+	}
+	types := sets.New(addressTypes...)
+	filtered := clusterv1.MachineAddresses{}
+	for _, address := range addresses {
+		if types.Has(address.Type) {
+			filtered = append(filtered, address)
+		}
+	}
+	return filtered
+}
+
+func key(address clusterv1.MachineAddress) string {
+	value := address.Address
+	if IsIPType(address.Type) {
+		if ip := net.ParseIP(value); ip != nil {
+			value = ip.String()
+		}
+	}
+	return fmt.Sprintf("%s/%s", address.Type, value)
+}
+
+func supportedTypes() []string {
+	types := make([]string, 0, knownTypes.Len())
+	for _, t := range sets.List(knownTypes) {
+		types = append(types, string(t))
+	}
+	return types
+}
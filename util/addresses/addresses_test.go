@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package addresses
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name      string
+		addresses clusterv1.MachineAddresses
+		wantErrs  int
+	}{
+		{
+			name: "valid dual-stack addresses",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+				{Type: clusterv1.MachineInternalIP, Address: "fd00::1"},
+				{Type: clusterv1.MachineExternalIP, Address: "2001:db8::1"},
+				{Type: clusterv1.MachineInternalDNS, Address: "ip-10-0-0-1.ec2.internal"},
+				{Type: clusterv1.MachineHostName, Address: "Machine-1"},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "unknown type",
+			addresses: clusterv1.MachineAddresses{
+				{Type: "PrivateIP", Address: "10.0.0.1"},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "invalid IP",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalIP, Address: "10.0.0.256"},
+				{Type: clusterv1.MachineExternalIP, Address: "machine-1"},
+			},
+			wantErrs: 2,
+		},
+		{
+			name: "invalid DNS name",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalDNS, Address: "machine_1.internal"},
+			},
+			wantErrs: 1,
+		},
+		{
+			name: "duplicated addresses",
+			addresses: clusterv1.MachineAddresses{
+				{Type: clusterv1.MachineInternalIP, Address: "fd00::1"},
+				{Type: clusterv1.MachineInternalIP, Address: "fd00:0::1"},
+				// The same address with different types is allowed.
+				{Type: clusterv1.MachineExternalIP, Address: "fd00::1"},
+			},
+			wantErrs: 1,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			g.Expect(Validate(tt.addresses, field.NewPath("status", "addresses"))).To(HaveLen(tt.wantErrs))
+		})
+	}
+}
+
+func TestNormalize(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Normalize(nil)).To(BeNil())
+	g.Expect(Normalize(clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineHostName, Address: "machine-1"},
+		{Type: clusterv1.MachineExternalIP, Address: "2001:db8::1"},
+		{Type: clusterv1.MachineInternalDNS, Address: "machine-1.internal"},
+		{Type: clusterv1.MachineInternalIP, Address: "fd00:0::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "fd00::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "not-an-ip"},
+		{Type: clusterv1.MachineInternalIP, Address: "not-an-ip"},
+	})).To(Equal(clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineHostName, Address: "machine-1"},
+		{Type: clusterv1.MachineExternalIP, Address: "2001:db8::1"},
+		{Type: clusterv1.MachineInternalDNS, Address: "machine-1.internal"},
+		{Type: clusterv1.MachineInternalIP, Address: "fd00:0::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "not-an-ip"},
+	}))
+}
+
+func TestPreferred(t *testing.T) {
+	g := NewWithT(t)
+
+	addresses := clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineExternalIP, Address: "192.168.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "fd00::1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "10.0.0.2"},
+	}
+
+	address, ok := Preferred(addresses, clusterv1.MachineInternalIP, "")
+	g.Expect(ok).To(BeTrue())
+	g.Expect(address.Address).To(Equal("fd00::1"))
+
+	address, ok = Preferred(addresses, clusterv1.MachineInternalIP, corev1.IPv4Protocol)
+	g.Expect(ok).To(BeTrue())
+	g.Expect(address.Address).To(Equal("10.0.0.1"))
+
+	_, ok = Preferred(addresses, clusterv1.MachineExternalIP, corev1.IPv6Protocol)
+	g.Expect(ok).To(BeFalse())
+
+	_, ok = Preferred(addresses, clusterv1.MachineInternalDNS, "")
+	g.Expect(ok).To(BeFalse())
+}
+
+func TestIPsAndDNSNames(t *testing.T) {
+	g := NewWithT(t)
+
+	addresses := clusterv1.MachineAddresses{
+		{Type: clusterv1.MachineInternalIP, Address: "fd00:0::1"},
+		{Type: clusterv1.MachineExternalIP, Address: "192.168.0.1"},
+		{Type: clusterv1.MachineInternalIP, Address: "not-an-ip"},
+		{Type: clusterv1.MachineInternalDNS, Address: "machine-1.internal"},
+		{Type: clusterv1.MachineHostName, Address: "machine-1"},
+	}
+
+	g.Expect(IPs(addresses).UnsortedList()).To(ConsistOf("fd00::1", "192.168.0.1"))
+	g.Expect(IPs(addresses, clusterv1.MachineInternalIP).UnsortedList()).To(ConsistOf("fd00::1"))
+	g.Expect(DNSNames(addresses).UnsortedList()).To(ConsistOf("machine-1.internal", "machine-1"))
+	g.Expect(DNSNames(addresses, clusterv1.MachineHostName).UnsortedList()).To(ConsistOf("machine-1"))
+}
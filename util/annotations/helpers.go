@@ -63,6 +63,14 @@ func ReplicasManagedByExternalAutoscaler(o metav1.Object) bool {
 	return hasTruthyAnnotationValue(o, clusterv1.ReplicasManagedByAnnotation)
 }
 
+// HasAutoscalerMinMaxSize returns true if both the autoscaler min size and max size annotations are set,
+// i.e. if the replicas of the node group are managed by the autoscaler.
+func HasAutoscalerMinMaxSize(annotations map[string]string) bool {
+	_, hasMinSize := annotations[clusterv1.AutoscalerMinSizeAnnotation]
+	_, hasMaxSize := annotations[clusterv1.AutoscalerMaxSizeAnnotation]
+	return hasMinSize && hasMaxSize
+}
+
 // AddAnnotations sets the desired annotations on the object and returns true if the annotations have changed.
 func AddAnnotations(o metav1.Object, desired map[string]string) bool {
 	if len(desired) == 0 {
@@ -23,6 +23,8 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
 )
 
 func TestAddAnnotations(t *testing.T) {
@@ -277,3 +279,38 @@ func TestHasTruthyAnnotationValue(t *testing.T) {
 		})
 	}
 }
+
+func TestHasAutoscalerMinMaxSize(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expected    bool
+	}{
+		{
+			name:        "no annotations",
+			annotations: nil,
+			expected:    false,
+		},
+		{
+			name: "only min size",
+			annotations: map[string]string{
+				clusterv1.AutoscalerMinSizeAnnotation: "1",
+			},
+			expected: false,
+		},
+		{
+			name: "min size and max size",
+			annotations: map[string]string{
+				clusterv1.AutoscalerMinSizeAnnotation: "1",
+				clusterv1.AutoscalerMaxSizeAnnotation: "3",
+			},
+			expected: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			g.Expect(HasAutoscalerMinMaxSize(tt.annotations)).To(Equal(tt.expected))
+		})
+	}
+}
@@ -207,6 +207,34 @@ func (s Machines) Names() []string {
 	return names
 }
 
+// CountByFailureDomain returns the number of machines in each failure domain;
+// machines without failure domain are not counted.
+func (s Machines) CountByFailureDomain() map[string]int {
+	counts := map[string]int{}
+	for _, m := range s {
+		if m.Spec.FailureDomain == nil {
+			continue
+		}
+		counts[*m.Spec.FailureDomain]++
+	}
+	return counts
+}
+
+// SortedBy returns the machines sorted by the given compare funcs; a compare func is used only
+// when the previous ones consider two machines equal, and names are used as a final tie breaker.
+func (s Machines) SortedBy(compareFuncs ...CompareFunc) []*clusterv1.Machine {
+	res := s.UnsortedList()
+	sort.Slice(res, func(i, j int) bool {
+		for _, compare := range compareFuncs {
+			if c := compare(res[i], res[j]); c != 0 {
+				return c < 0
+			}
+		}
+		return res[i].Name < res[j].Name
+	})
+	return res
+}
+
 // SortedByVersion returns the machines sorted by version.
 func (s Machines) sortedByVersion() []*clusterv1.Machine {
 	res := make(machinesByVersion, 0, len(s))
@@ -52,6 +52,34 @@ func TestMachineCollection(t *testing.T) {
 			g.Expect(c3.Names()).To(ConsistOf("machine-1"))
 		})
 	})
+	t.Run("CountByFailureDomain", func(t *testing.T) {
+		t.Run("should return the number of machines in each failure domain", func(t *testing.T) {
+			g := NewWithT(t)
+			collection := collections.FromMachines(
+				machine("1", withFailureDomain("one")),
+				machine("2", withFailureDomain("one")),
+				machine("3", withFailureDomain("two")),
+				machine("4"),
+			)
+			g.Expect(collection.CountByFailureDomain()).To(Equal(map[string]int{"one": 2, "two": 1}))
+		})
+	})
+	t.Run("SortedBy", func(t *testing.T) {
+		t.Run("should sort by the given compare funcs, using names as tie breaker", func(t *testing.T) {
+			g := NewWithT(t)
+			collection := machines()
+			collection["machine-4"].Annotations = map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+			sortedMachines := collection.SortedBy(collections.ByDeletionPriority, collections.Reverse(collections.ByCreationTimestamp))
+			g.Expect(collections.FromMachines(sortedMachines...).Names()).To(HaveLen(5))
+			names := []string{}
+			for _, m := range sortedMachines {
+				names = append(names, m.Name)
+			}
+			g.Expect(names).To(Equal([]string{"machine-4", "machine-5", "machine-3", "machine-2", "machine-1"}))
+
+			g.Expect(collections.FromMachines(machine("b"), machine("a")).SortedBy()[0].Name).To(Equal("a"))
+		})
+	})
 	t.Run("Names", func(t *testing.T) {
 		t.Run("should return a slice of names of each machine in the collection", func(t *testing.T) {
 			g := NewWithT(t)
@@ -152,6 +180,12 @@ func withCreationTimestamp(timestamp metav1.Time) machineOpt {
 	}
 }
 
+func withFailureDomain(failureDomain string) machineOpt {
+	return func(m *clusterv1.Machine) {
+		m.Spec.FailureDomain = pointer.String(failureDomain)
+	}
+}
+
 func machine(name string, opts ...machineOpt) *clusterv1.Machine {
 	m := &clusterv1.Machine{
 		ObjectMeta: metav1.ObjectMeta{
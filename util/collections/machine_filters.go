@@ -20,6 +20,7 @@ import (
 	"time"
 
 	"github.com/blang/semver/v4"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/selection"
@@ -99,6 +100,18 @@ func InFailureDomains(failureDomains ...*string) Func {
 	}
 }
 
+// InFailureDomainsWithMachines returns a filter to find all machines in a failure domain
+// with at least minMachines machines among the given machines; machines without failure domain are not counted.
+func InFailureDomainsWithMachines(machines Machines, minMachines int) Func {
+	counts := machines.CountByFailureDomain()
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil || machine.Spec.FailureDomain == nil {
+			return false
+		}
+		return counts[*machine.Spec.FailureDomain] >= minMachines
+	}
+}
+
 // OwnedMachines returns a filter to find all machines owned by specified owner.
 // Usage: GetFilteredMachinesForCluster(ctx, client, cluster, OwnedMachines(controlPlane)).
 func OwnedMachines(owner client.Object) func(machine *clusterv1.Machine) bool {
@@ -168,6 +181,30 @@ func IsReady() Func {
 	}
 }
 
+// HasConditionStatus returns a filter to find all machines with the given condition set to the given status;
+// machines without the condition are considered as having the condition set to Unknown.
+func HasConditionStatus(conditionType clusterv1.ConditionType, status corev1.ConditionStatus) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil {
+			return false
+		}
+		if c := conditions.Get(machine, conditionType); c != nil {
+			return c.Status == status
+		}
+		return status == corev1.ConditionUnknown
+	}
+}
+
+// OlderThan returns a filter to find all machines created more than age before reconciliationTime.
+func OlderThan(reconciliationTime *metav1.Time, age time.Duration) Func {
+	return func(machine *clusterv1.Machine) bool {
+		if machine == nil || reconciliationTime == nil || machine.CreationTimestamp.IsZero() {
+			return false
+		}
+		return machine.CreationTimestamp.Add(age).Before(reconciliationTime.Time)
+	}
+}
+
 // ShouldRolloutAfter returns a filter to find all machines where
 // CreationTimestamp < rolloutAfter < reconciliationTIme.
 func ShouldRolloutAfter(reconciliationTime, rolloutAfter *metav1.Time) Func {
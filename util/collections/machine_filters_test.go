@@ -21,6 +21,7 @@ import (
 	"time"
 
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
@@ -269,6 +270,73 @@ func TestInFailureDomain(t *testing.T) {
 	})
 }
 
+func TestInFailureDomainsWithMachines(t *testing.T) {
+	machines := collections.FromMachines(
+		machine("1", withFailureDomain("one")),
+		machine("2", withFailureDomain("one")),
+		machine("3", withFailureDomain("two")),
+		machine("4"),
+	)
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InFailureDomainsWithMachines(machines, 1)(nil)).To(BeFalse())
+	})
+	t.Run("machine without failure domain returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InFailureDomainsWithMachines(machines, 0)(machines["4"])).To(BeFalse())
+	})
+	t.Run("machine in a failure domain with at least min machines returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InFailureDomainsWithMachines(machines, 2)(machines["1"])).To(BeTrue())
+	})
+	t.Run("machine in a failure domain with less than min machines returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.InFailureDomainsWithMachines(machines, 2)(machines["3"])).To(BeFalse())
+	})
+}
+
+func TestHasConditionStatus(t *testing.T) {
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionTrue)(nil)).To(BeFalse())
+	})
+	t.Run("machine with the condition set to the given status returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		conditions.MarkFalse(m, clusterv1.ReadyCondition, "reason", clusterv1.ConditionSeverityError, "")
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionFalse)(m)).To(BeTrue())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionTrue)(m)).To(BeFalse())
+	})
+	t.Run("machine without the condition is considered as Unknown", func(t *testing.T) {
+		g := NewWithT(t)
+		m := &clusterv1.Machine{}
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionUnknown)(m)).To(BeTrue())
+		g.Expect(collections.HasConditionStatus(clusterv1.ReadyCondition, corev1.ConditionFalse)(m)).To(BeFalse())
+	})
+}
+
+func TestOlderThan(t *testing.T) {
+	reconciliationTime := metav1.Now()
+	t.Run("nil machine returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.OlderThan(&reconciliationTime, time.Hour)(nil)).To(BeFalse())
+	})
+	t.Run("machine without creation timestamp returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(collections.OlderThan(&reconciliationTime, time.Hour)(&clusterv1.Machine{})).To(BeFalse())
+	})
+	t.Run("machine created before the given age returns true", func(t *testing.T) {
+		g := NewWithT(t)
+		m := machine("1", withCreationTimestamp(metav1.NewTime(reconciliationTime.Add(-2*time.Hour))))
+		g.Expect(collections.OlderThan(&reconciliationTime, time.Hour)(m)).To(BeTrue())
+	})
+	t.Run("machine created after the given age returns false", func(t *testing.T) {
+		g := NewWithT(t)
+		m := machine("1", withCreationTimestamp(metav1.NewTime(reconciliationTime.Add(-30*time.Minute))))
+		g.Expect(collections.OlderThan(&reconciliationTime, time.Hour)(m)).To(BeFalse())
+	})
+}
+
 func TestActiveMachinesInCluster(t *testing.T) {
 	t.Run("machine with deletion timestamp returns false", func(t *testing.T) {
 		g := NewWithT(t)
@@ -0,0 +1,115 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collections
+
+import (
+	corev1 "k8s.io/api/core/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+// CompareFunc is the function definition for a sorter; it returns a negative number if a
+// should sort before b, a positive number if a should sort after b, and zero if they are equal.
+// Usage: machines.SortedBy(collections.ByDeletionPriority, collections.ByCreationTimestamp).
+type CompareFunc func(a, b *clusterv1.Machine) int
+
+// Reverse returns a sorter that sorts in the opposite order of the given sorter.
+func Reverse(compare CompareFunc) CompareFunc {
+	return func(a, b *clusterv1.Machine) int {
+		return compare(b, a)
+	}
+}
+
+// ByCreationTimestamp is a sorter that sorts the oldest machines first.
+func ByCreationTimestamp(a, b *clusterv1.Machine) int {
+	switch {
+	case a.CreationTimestamp.Equal(&b.CreationTimestamp):
+		return 0
+	case a.CreationTimestamp.Before(&b.CreationTimestamp):
+		return -1
+	default:
+		return 1
+	}
+}
+
+// ByDeletionPriority is a sorter that sorts first the machines with a deletion timestamp,
+// then the machines with the DeleteMachineAnnotation, then all the other machines.
+func ByDeletionPriority(a, b *clusterv1.Machine) int {
+	return compareInt(deletionPriority(b), deletionPriority(a))
+}
+
+// ByConditionStatus returns a sorter that sorts first the machines with the given condition set to False,
+// then the machines with the condition set to Unknown or without the condition, then the machines with the
+// condition set to True; e.g. ByConditionStatus(clusterv1.ReadyCondition) sorts the machines not ready first.
+func ByConditionStatus(conditionType clusterv1.ConditionType) CompareFunc {
+	return func(a, b *clusterv1.Machine) int {
+		return compareInt(conditionStatusPriority(a, conditionType), conditionStatusPriority(b, conditionType))
+	}
+}
+
+// ByFailureDomainMachines returns a sorter that sorts first the machines in the failure domains with more
+// machines among the given machines, e.g. to spread machines across failure domains when scaling down;
+// machines without failure domain are sorted last.
+func ByFailureDomainMachines(machines Machines) CompareFunc {
+	counts := machines.CountByFailureDomain()
+	count := func(m *clusterv1.Machine) int {
+		if m.Spec.FailureDomain == nil {
+			return -1
+		}
+		return counts[*m.Spec.FailureDomain]
+	}
+	return func(a, b *clusterv1.Machine) int {
+		return compareInt(count(b), count(a))
+	}
+}
+
+func deletionPriority(m *clusterv1.Machine) int {
+	if !m.DeletionTimestamp.IsZero() {
+		return 2
+	}
+	if _, ok := m.Annotations[clusterv1.DeleteMachineAnnotation]; ok {
+		return 1
+	}
+	return 0
+}
+
+func conditionStatusPriority(m *clusterv1.Machine, conditionType clusterv1.ConditionType) int {
+	c := conditions.Get(m, conditionType)
+	if c == nil {
+		return 1
+	}
+	switch c.Status {
+	case corev1.ConditionFalse:
+		return 0
+	case corev1.ConditionTrue:
+		return 2
+	default:
+		return 1
+	}
+}
+
+func compareInt(a, b int) int {
+	switch {
+	case a < b:
+		return -1
+	case a > b:
+		return 1
+	default:
+		return 0
+	}
+}
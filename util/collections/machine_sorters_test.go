@@ -0,0 +1,85 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package collections_test
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/collections"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+func TestSorters(t *testing.T) {
+	sortedNames := func(machines collections.Machines, compareFuncs ...collections.CompareFunc) []string {
+		names := []string{}
+		for _, m := range machines.SortedBy(compareFuncs...) {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+
+	t.Run("ByCreationTimestamp sorts the oldest machines first", func(t *testing.T) {
+		g := NewWithT(t)
+		g.Expect(sortedNames(machines(), collections.ByCreationTimestamp)).To(Equal([]string{"machine-1", "machine-2", "machine-3", "machine-4", "machine-5"}))
+		g.Expect(sortedNames(machines(), collections.Reverse(collections.ByCreationTimestamp))).To(Equal([]string{"machine-5", "machine-4", "machine-3", "machine-2", "machine-1"}))
+	})
+
+	t.Run("ByDeletionPriority sorts first deleting machines, then machines with the delete annotation", func(t *testing.T) {
+		g := NewWithT(t)
+		deletionTimestamp := metav1.NewTime(time.Now())
+		machines := collections.FromMachines(
+			machine("a"),
+			machine("b", func(m *clusterv1.Machine) {
+				m.Annotations = map[string]string{clusterv1.DeleteMachineAnnotation: ""}
+			}),
+			machine("c", func(m *clusterv1.Machine) {
+				m.DeletionTimestamp = &deletionTimestamp
+			}),
+		)
+		g.Expect(sortedNames(machines, collections.ByDeletionPriority)).To(Equal([]string{"c", "b", "a"}))
+	})
+
+	t.Run("ByConditionStatus sorts first machines with the condition False, then Unknown, then True", func(t *testing.T) {
+		g := NewWithT(t)
+		machines := collections.FromMachines(
+			machine("a", func(m *clusterv1.Machine) {
+				conditions.MarkTrue(m, clusterv1.ReadyCondition)
+			}),
+			machine("b"),
+			machine("c", func(m *clusterv1.Machine) {
+				conditions.MarkFalse(m, clusterv1.ReadyCondition, "reason", clusterv1.ConditionSeverityError, "")
+			}),
+		)
+		g.Expect(sortedNames(machines, collections.ByConditionStatus(clusterv1.ReadyCondition))).To(Equal([]string{"c", "b", "a"}))
+	})
+
+	t.Run("ByFailureDomainMachines sorts first machines in the failure domains with more machines", func(t *testing.T) {
+		g := NewWithT(t)
+		machines := collections.FromMachines(
+			machine("a", withFailureDomain("one")),
+			machine("b", withFailureDomain("two")),
+			machine("c", withFailureDomain("two")),
+			machine("d"),
+		)
+		g.Expect(sortedNames(machines, collections.ByFailureDomainMachines(machines))).To(Equal([]string{"b", "c", "a", "d"}))
+	})
+}
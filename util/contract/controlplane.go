@@ -0,0 +1,46 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contract
+
+import (
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// IsControlPlaneInitialized returns true if the status.initialized field of a control plane object is true.
+// Leaving the field undefined is equivalent to setting the value to false.
+func IsControlPlaneInitialized(controlPlane *unstructured.Unstructured) (bool, error) {
+	initialized, found, err := unstructured.NestedBool(controlPlane.Object, "status", "initialized")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to determine if %v %q is initialized",
+			controlPlane.GroupVersionKind(), controlPlane.GetName())
+	}
+	return initialized && found, nil
+}
+
+// IsExternalManagedControlPlane returns true if the status.externalManagedControlPlane field of a control plane
+// object is true, i.e. the control plane is managed outside of Cluster API (e.g. AKS, EKS, GKE) and
+// there are no control plane Machines and Nodes.
+// Leaving the field undefined is equivalent to setting the value to false.
+func IsExternalManagedControlPlane(controlPlane *unstructured.Unstructured) (bool, error) {
+	managed, found, err := unstructured.NestedBool(controlPlane.Object, "status", "externalManagedControlPlane")
+	if err != nil {
+		return false, errors.Wrapf(err, "failed to determine if %v %q is externally managed",
+			controlPlane.GroupVersionKind(), controlPlane.GetName())
+	}
+	return managed && found, nil
+}
@@ -0,0 +1,124 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package contract
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestIsControlPlaneInitialized(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  map[string]interface{}
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "field not set",
+			status: map[string]interface{}{},
+			want:   false,
+		},
+		{
+			name:   "field set to false",
+			status: map[string]interface{}{"initialized": false},
+			want:   false,
+		},
+		{
+			name:   "field set to true",
+			status: map[string]interface{}{"initialized": true},
+			want:   true,
+		},
+		{
+			name:    "field with wrong type",
+			status:  map[string]interface{}{"initialized": "true"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := IsControlPlaneInitialized(controlPlaneWithStatus(tt.status))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func TestIsExternalManagedControlPlane(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  map[string]interface{}
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:   "field not set",
+			status: map[string]interface{}{},
+			want:   false,
+		},
+		{
+			name:   "field set to false",
+			status: map[string]interface{}{"externalManagedControlPlane": false},
+			want:   false,
+		},
+		{
+			name:   "field set to true",
+			status: map[string]interface{}{"externalManagedControlPlane": true},
+			want:   true,
+		},
+		{
+			name:    "field with wrong type",
+			status:  map[string]interface{}{"externalManagedControlPlane": "true"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			got, err := IsExternalManagedControlPlane(controlPlaneWithStatus(tt.status))
+			if tt.wantErr {
+				g.Expect(err).To(HaveOccurred())
+				return
+			}
+			g.Expect(err).ToNot(HaveOccurred())
+			g.Expect(got).To(Equal(tt.want))
+		})
+	}
+}
+
+func controlPlaneWithStatus(status map[string]interface{}) *unstructured.Unstructured {
+	return &unstructured.Unstructured{
+		Object: map[string]interface{}{
+			"apiVersion": "controlplane.cluster.x-k8s.io/v1beta1",
+			"kind":       "GenericControlPlane",
+			"metadata": map[string]interface{}{
+				"name":      "cp",
+				"namespace": "default",
+			},
+			"status": status,
+		},
+	}
+}
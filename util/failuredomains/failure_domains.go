@@ -28,8 +28,9 @@ import (
 )
 
 type failureDomainAggregation struct {
-	id    string
-	count int
+	id     string
+	count  int
+	weight int
 }
 type failureDomainAggregations []failureDomainAggregation
 
@@ -39,9 +40,10 @@ func (f failureDomainAggregations) Len() int {
 }
 
 // Less reports whether the element with
-// index i should sort before the element with index j.
+// index i should sort before the element with index j, i.e. if
+// the failure domain i has fewer machines relative to its weight.
 func (f failureDomainAggregations) Less(i, j int) bool {
-	return f[i].count < f[j].count
+	return f[i].count*f[j].weight < f[j].count*f[i].weight
 }
 
 // Swap swaps the elements with indexes i and j.
@@ -76,16 +78,29 @@ func orderDescending(failureDomains clusterv1.FailureDomains, machines collectio
 	return aggregations
 }
 
-// PickFewest returns the failure domain with the fewest number of machines.
+// PickFewest returns the failure domain with the fewest number of machines relative to its weight,
+// considering the machine to be added; ties are broken by failure domain id.
 func PickFewest(failureDomains clusterv1.FailureDomains, machines collections.Machines) *string {
 	aggregations := pick(failureDomains, machines)
 	if len(aggregations) == 0 {
 		return nil
 	}
-	sort.Sort(aggregations)
+	sort.Slice(aggregations, func(i, j int) bool {
+		li := (aggregations[i].count + 1) * aggregations[j].weight
+		lj := (aggregations[j].count + 1) * aggregations[i].weight
+		if li == lj {
+			return aggregations[i].id < aggregations[j].id
+		}
+		return li < lj
+	})
 	return pointer.String(aggregations[0].id)
 }
 
+// Weight returns the weight of a failure domain, defaulting to 1.
+func Weight(failureDomain clusterv1.FailureDomainSpec) int {
+	return int(pointer.Int32Deref(failureDomain.Weight, 1))
+}
+
 func pick(failureDomains clusterv1.FailureDomains, machines collections.Machines) failureDomainAggregations {
 	if len(failureDomains) == 0 {
 		return failureDomainAggregations{}
@@ -115,7 +130,7 @@ func pick(failureDomains clusterv1.FailureDomains, machines collections.Machines
 
 	// Gather up tuples of failure domains ids and counts
 	for fd, count := range counters {
-		aggregations = append(aggregations, failureDomainAggregation{id: fd, count: count})
+		aggregations = append(aggregations, failureDomainAggregation{id: fd, count: count, weight: Weight(failureDomains[fd])})
 	}
 
 	return aggregations
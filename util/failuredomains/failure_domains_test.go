@@ -20,6 +20,7 @@ import (
 	"testing"
 
 	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/utils/pointer"
 
 	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
@@ -36,6 +37,7 @@ func TestNewFailureDomainPicker(t *testing.T) {
 	}
 	machinea := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: a}}
 	machineb := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: b}}
+	machineb2 := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-b"}, Spec: clusterv1.MachineSpec{FailureDomain: b}}
 	machinenil := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: nil}}
 
 	testcases := []struct {
@@ -82,6 +84,15 @@ func TestNewFailureDomainPicker(t *testing.T) {
 			fds:      fds,
 			expected: []*string{a, b},
 		},
+		{
+			name: "machines are spread according to the failure domain weights",
+			fds: clusterv1.FailureDomains{
+				*a: clusterv1.FailureDomainSpec{Weight: pointer.Int32(2)},
+				*b: clusterv1.FailureDomainSpec{},
+			},
+			machines: collections.FromMachines(machinea.DeepCopy(), machineb2.DeepCopy()),
+			expected: []*string{a},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
@@ -107,6 +118,7 @@ func TestNewFailureDomainPickMost(t *testing.T) {
 	}
 	machinea := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: a}}
 	machineb := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: b}}
+	machineb2 := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Name: "machine-b"}, Spec: clusterv1.MachineSpec{FailureDomain: b}}
 	machinenil := &clusterv1.Machine{Spec: clusterv1.MachineSpec{FailureDomain: nil}}
 
 	testcases := []struct {
@@ -162,6 +174,15 @@ func TestNewFailureDomainPickMost(t *testing.T) {
 			name:     "nil failure domains with no machines",
 			expected: nil,
 		},
+		{
+			name: "failure domain with the most machines according to the failure domain weights",
+			fds: clusterv1.FailureDomains{
+				*a: clusterv1.FailureDomainSpec{ControlPlane: true, Weight: pointer.Int32(2)},
+				*b: clusterv1.FailureDomainSpec{ControlPlane: true},
+			},
+			machines: collections.FromMachines(machinea.DeepCopy(), machineb2.DeepCopy()),
+			expected: []*string{b},
+		},
 	}
 	for _, tc := range testcases {
 		t.Run(tc.name, func(t *testing.T) {
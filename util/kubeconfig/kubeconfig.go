@@ -96,21 +96,47 @@ func New(clusterName, endpoint string, caCert *x509.Certificate, caKey crypto.Si
 	}, nil
 }
 
+// Endpoint returns the endpoint to be used as server in the Kubeconfig secret for the given cluster,
+// i.e. the kubeconfig endpoint annotation of the cluster if set, the control plane endpoint otherwise.
+func Endpoint(cluster *clusterv1.Cluster) string {
+	if endpoint := cluster.GetAnnotations()[clusterv1.ClusterKubeconfigEndpointAnnotation]; endpoint != "" {
+		return endpoint
+	}
+	return cluster.Spec.ControlPlaneEndpoint.String()
+}
+
+// UserEndpoint returns the endpoint to be used as server in the user Kubeconfig for the given cluster,
+// or an empty string if the cluster has no user kubeconfig endpoint annotation.
+func UserEndpoint(cluster *clusterv1.Cluster) string {
+	return cluster.GetAnnotations()[clusterv1.ClusterUserKubeconfigEndpointAnnotation]
+}
+
 // CreateSecret creates the Kubeconfig secret for the given cluster.
+// If the cluster has a user kubeconfig endpoint, the secret also includes the user Kubeconfig.
 func CreateSecret(ctx context.Context, c client.Client, cluster *clusterv1.Cluster) error {
 	name := util.ObjectKey(cluster)
-	return CreateSecretWithOwner(ctx, c, name, cluster.Spec.ControlPlaneEndpoint.String(), metav1.OwnerReference{
-		APIVersion: clusterv1.GroupVersion.String(),
-		Kind:       "Cluster",
-		Name:       cluster.Name,
-		UID:        cluster.UID,
-	})
+	out, err := generateKubeconfig(ctx, secret.NewSecretStore(nil, c), name, fmt.Sprintf("https://%s", Endpoint(cluster)))
+	if err != nil {
+		return err
+	}
+
+	configSecret := GenerateSecret(cluster, out)
+	if err := SetUserKubeconfig(configSecret, UserEndpoint(cluster)); err != nil {
+		return err
+	}
+	return c.Create(ctx, configSecret)
 }
 
 // CreateSecretWithOwner creates the Kubeconfig secret for the given cluster name, namespace, endpoint, and owner reference.
 func CreateSecretWithOwner(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference) error {
+	return CreateSecretWithOwnerFromStore(ctx, c, secret.NewSecretStore(nil, c), clusterName, endpoint, owner)
+}
+
+// CreateSecretWithOwnerFromStore creates the Kubeconfig secret for the given cluster name, namespace, endpoint, and owner reference,
+// reading the cluster CA from the given certificates store.
+func CreateSecretWithOwnerFromStore(ctx context.Context, c client.Client, store secret.Store, clusterName client.ObjectKey, endpoint string, owner metav1.OwnerReference) error {
 	server := fmt.Sprintf("https://%s", endpoint)
-	out, err := generateKubeconfig(ctx, c, clusterName, server)
+	out, err := generateKubeconfig(ctx, store, clusterName, server)
 	if err != nil {
 		return err
 	}
@@ -176,8 +202,95 @@ func NeedsClientCertRotation(configSecret *corev1.Secret, threshold time.Duratio
 	return false, nil
 }
 
+// NeedsEndpointUpdate returns whether the server of the Kubeconfig secret does not match the given endpoint.
+func NeedsEndpointUpdate(configSecret *corev1.Secret, endpoint string) (bool, error) {
+	server, err := getServer(configSecret)
+	if err != nil {
+		return false, err
+	}
+	return server != fmt.Sprintf("https://%s", endpoint), nil
+}
+
+// NeedsUserEndpointUpdate returns whether the server of the user Kubeconfig in the Kubeconfig secret does not match
+// the given endpoint; an empty endpoint means that the secret must not include the user Kubeconfig.
+func NeedsUserEndpointUpdate(configSecret *corev1.Secret, endpoint string) (bool, error) {
+	if _, ok := configSecret.Data[secret.UserKubeconfigDataName]; !ok {
+		return endpoint != "", nil
+	}
+	if endpoint == "" {
+		return true, nil
+	}
+	server, err := getUserServer(configSecret)
+	if err != nil {
+		return false, err
+	}
+	return server != fmt.Sprintf("https://%s", endpoint), nil
+}
+
+// SetUserKubeconfig sets the user Kubeconfig in the given secret, using the credentials of the Kubeconfig
+// in the secret and the given endpoint; if the endpoint is empty, the user Kubeconfig is removed.
+// NOTE: The secret is not stored, it is up to the caller to create or update it.
+func SetUserKubeconfig(configSecret *corev1.Secret, endpoint string) error {
+	if endpoint == "" {
+		delete(configSecret.Data, secret.UserKubeconfigDataName)
+		return nil
+	}
+	return setUserKubeconfig(configSecret, fmt.Sprintf("https://%s", endpoint))
+}
+
 // RegenerateSecret creates and stores a new Kubeconfig in the given secret.
 func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1.Secret) error {
+	return RegenerateSecretFromStore(ctx, c, secret.NewSecretStore(nil, c), configSecret)
+}
+
+// RegenerateSecretFromStore creates and stores a new Kubeconfig in the given secret,
+// reading the cluster CA from the given certificates store.
+func RegenerateSecretFromStore(ctx context.Context, c client.Client, store secret.Store, configSecret *corev1.Secret) error {
+	server, err := getServer(configSecret)
+	if err != nil {
+		return err
+	}
+	return regenerateSecret(ctx, c, store, configSecret, server)
+}
+
+// RegenerateSecretWithEndpoint creates and stores a new Kubeconfig in the given secret, using the given endpoint.
+func RegenerateSecretWithEndpoint(ctx context.Context, c client.Client, configSecret *corev1.Secret, endpoint string) error {
+	return RegenerateSecretWithEndpointFromStore(ctx, c, secret.NewSecretStore(nil, c), configSecret, endpoint)
+}
+
+// RegenerateSecretWithEndpointFromStore creates and stores a new Kubeconfig in the given secret, using the given endpoint
+// and reading the cluster CA from the given certificates store.
+func RegenerateSecretWithEndpointFromStore(ctx context.Context, c client.Client, store secret.Store, configSecret *corev1.Secret, endpoint string) error {
+	return regenerateSecret(ctx, c, store, configSecret, fmt.Sprintf("https://%s", endpoint))
+}
+
+func regenerateSecret(ctx context.Context, c client.Client, store secret.Store, configSecret *corev1.Secret, server string) error {
+	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
+	if err != nil {
+		return errors.Wrap(err, "failed to parse secret name")
+	}
+	key := client.ObjectKey{Name: clusterName, Namespace: configSecret.Namespace}
+	out, err := generateKubeconfig(ctx, store, key, server)
+	if err != nil {
+		return err
+	}
+	configSecret.Data[secret.KubeconfigDataName] = out
+
+	// Regenerate the user Kubeconfig as well, so it uses the new credentials.
+	if _, ok := configSecret.Data[secret.UserKubeconfigDataName]; ok {
+		userServer, err := getUserServer(configSecret)
+		if err != nil {
+			return err
+		}
+		if err := setUserKubeconfig(configSecret, userServer); err != nil {
+			return err
+		}
+	}
+	return c.Update(ctx, configSecret)
+}
+
+// setUserKubeconfig sets the user Kubeconfig in the given secret as a copy of the Kubeconfig with the given server.
+func setUserKubeconfig(configSecret *corev1.Secret, server string) error {
 	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
 	if err != nil {
 		return errors.Wrap(err, "failed to parse secret name")
@@ -191,33 +304,72 @@ func RegenerateSecret(ctx context.Context, c client.Client, configSecret *corev1
 	if err != nil {
 		return errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
 	}
-	endpoint := config.Clusters[clusterName].Server
-	key := client.ObjectKey{Name: clusterName, Namespace: configSecret.Namespace}
-	out, err := generateKubeconfig(ctx, c, key, endpoint)
+	cluster, ok := config.Clusters[clusterName]
+	if !ok {
+		return errors.Errorf("kubeconfig Secret does not contain cluster %q", clusterName)
+	}
+	cluster.Server = server
+
+	out, err := clientcmd.Write(*config)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "failed to serialize config to yaml")
 	}
-	configSecret.Data[secret.KubeconfigDataName] = out
-	return c.Update(ctx, configSecret)
+	configSecret.Data[secret.UserKubeconfigDataName] = out
+	return nil
 }
 
-func generateKubeconfig(ctx context.Context, c client.Client, clusterName client.ObjectKey, endpoint string) ([]byte, error) {
-	clusterCA, err := secret.GetFromNamespacedName(ctx, c, clusterName, secret.ClusterCA)
+// getServer returns the server of the cluster in the Kubeconfig secret.
+func getServer(configSecret *corev1.Secret) (string, error) {
+	data, err := toKubeconfigBytes(configSecret)
 	if err != nil {
+		return "", err
+	}
+	return serverFromKubeconfig(configSecret, data)
+}
+
+// getUserServer returns the server of the cluster in the user Kubeconfig of the Kubeconfig secret.
+func getUserServer(configSecret *corev1.Secret) (string, error) {
+	data, ok := configSecret.Data[secret.UserKubeconfigDataName]
+	if !ok {
+		return "", errors.Errorf("missing key %q in secret data", secret.UserKubeconfigDataName)
+	}
+	return serverFromKubeconfig(configSecret, data)
+}
+
+func serverFromKubeconfig(configSecret *corev1.Secret, data []byte) (string, error) {
+	clusterName, _, err := secret.ParseSecretName(configSecret.Name)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to parse secret name")
+	}
+
+	config, err := clientcmd.Load(data)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to convert kubeconfig Secret into a clientcmdapi.Config")
+	}
+	cluster, ok := config.Clusters[clusterName]
+	if !ok {
+		return "", errors.Errorf("kubeconfig Secret does not contain cluster %q", clusterName)
+	}
+	return cluster.Server, nil
+}
+
+func generateKubeconfig(ctx context.Context, store secret.Store, clusterName client.ObjectKey, endpoint string) ([]byte, error) {
+	clusterCA := &secret.Certificate{Purpose: secret.ClusterCA}
+	if err := store.Get(ctx, clusterName, clusterCA); err != nil {
 		if apierrors.IsNotFound(err) {
 			return nil, ErrDependentCertificateNotFound
 		}
 		return nil, err
 	}
 
-	cert, err := certs.DecodeCertPEM(clusterCA.Data[secret.TLSCrtDataName])
+	cert, err := certs.DecodeCertPEM(clusterCA.KeyPair.Cert)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode CA Cert")
 	} else if cert == nil {
 		return nil, errors.New("certificate not found in config")
 	}
 
-	key, err := certs.DecodePrivateKeyPEM(clusterCA.Data[secret.TLSKeyDataName])
+	key, err := certs.DecodePrivateKeyPEM(clusterCA.KeyPair.Key)
 	if err != nil {
 		return nil, errors.Wrap(err, "failed to decode private key")
 	} else if key == nil {
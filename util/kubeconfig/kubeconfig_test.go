@@ -417,3 +417,150 @@ func TestRegenerateClientCerts(t *testing.T) {
 
 	g.Expect(newCert.NotAfter).To(BeTemporally(">", oldCert.NotAfter))
 }
+
+func TestNeedsEndpointUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(NeedsEndpointUpdate(validSecret, "test-cluster-api:6443")).To(BeFalse())
+	g.Expect(NeedsEndpointUpdate(validSecret, "new-cluster-api:6443")).To(BeTrue())
+}
+
+func TestRegenerateSecretWithEndpoint(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: certs.EncodeCertPEM(caCert),
+		},
+	}
+
+	configSecret := validSecret.DeepCopy()
+	c := fake.NewClientBuilder().WithObjects(configSecret, caSecret).Build()
+
+	g.Expect(RegenerateSecretWithEndpoint(ctx, c, configSecret, "new-cluster-api:6443")).To(Succeed())
+
+	newSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, util.ObjectKey(configSecret), newSecret)).To(Succeed())
+	newConfig, err := clientcmd.Load(newSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newConfig.Clusters["test1"].Server).To(Equal("https://new-cluster-api:6443"))
+	g.Expect(NeedsEndpointUpdate(newSecret, "new-cluster-api:6443")).To(BeFalse())
+}
+
+func TestCreateSecretWithKubeconfigEndpoints(t *testing.T) {
+	g := NewWithT(t)
+
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: certs.EncodeCertPEM(caCert),
+		},
+	}
+
+	c := fake.NewClientBuilder().WithObjects(caSecret).Build()
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1",
+			Namespace: "test",
+			Annotations: map[string]string{
+				clusterv1.ClusterKubeconfigEndpointAnnotation:     "10.0.0.1:6443",
+				clusterv1.ClusterUserKubeconfigEndpointAnnotation: "api.example.com:443",
+			},
+		},
+		Spec: clusterv1.ClusterSpec{
+			ControlPlaneEndpoint: clusterv1.APIEndpoint{
+				Host: "localhost",
+				Port: 8443,
+			},
+		},
+	}
+
+	g.Expect(CreateSecret(ctx, c, cluster)).To(Succeed())
+
+	s := &corev1.Secret{}
+	key := client.ObjectKey{Name: "test1-kubeconfig", Namespace: "test"}
+	g.Expect(c.Get(ctx, key, s)).To(Succeed())
+
+	config, err := clientcmd.Load(s.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(config.Clusters["test1"].Server).To(Equal("https://10.0.0.1:6443"))
+
+	userConfig, err := clientcmd.Load(s.Data[secret.UserKubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(userConfig.Clusters["test1"].Server).To(Equal("https://api.example.com:443"))
+	g.Expect(userConfig.AuthInfos).To(Equal(config.AuthInfos))
+}
+
+func TestNeedsUserEndpointUpdate(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(NeedsUserEndpointUpdate(validSecret, "")).To(BeFalse())
+	g.Expect(NeedsUserEndpointUpdate(validSecret, "api.example.com:443")).To(BeTrue())
+
+	configSecret := validSecret.DeepCopy()
+	g.Expect(SetUserKubeconfig(configSecret, "api.example.com:443")).To(Succeed())
+	g.Expect(NeedsUserEndpointUpdate(configSecret, "api.example.com:443")).To(BeFalse())
+	g.Expect(NeedsUserEndpointUpdate(configSecret, "new-api.example.com:443")).To(BeTrue())
+	g.Expect(NeedsUserEndpointUpdate(configSecret, "")).To(BeTrue())
+
+	g.Expect(SetUserKubeconfig(configSecret, "")).To(Succeed())
+	g.Expect(configSecret.Data).ToNot(HaveKey(secret.UserKubeconfigDataName))
+	g.Expect(NeedsUserEndpointUpdate(configSecret, "")).To(BeFalse())
+}
+
+func TestRegenerateSecretWithUserKubeconfig(t *testing.T) {
+	g := NewWithT(t)
+	caKey, err := certs.NewPrivateKey()
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caCert, err := getTestCACert(caKey)
+	g.Expect(err).ToNot(HaveOccurred())
+
+	caSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test1-ca",
+			Namespace: "test",
+		},
+		Data: map[string][]byte{
+			secret.TLSKeyDataName: certs.EncodePrivateKeyPEM(caKey),
+			secret.TLSCrtDataName: certs.EncodeCertPEM(caCert),
+		},
+	}
+
+	configSecret := validSecret.DeepCopy()
+	g.Expect(SetUserKubeconfig(configSecret, "api.example.com:443")).To(Succeed())
+	c := fake.NewClientBuilder().WithObjects(configSecret, caSecret).Build()
+
+	g.Expect(RegenerateSecret(ctx, c, configSecret)).To(Succeed())
+
+	newSecret := &corev1.Secret{}
+	g.Expect(c.Get(ctx, util.ObjectKey(configSecret), newSecret)).To(Succeed())
+	newConfig, err := clientcmd.Load(newSecret.Data[secret.KubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newConfig.Clusters["test1"].Server).To(Equal("https://test-cluster-api:6443"))
+	newUserConfig, err := clientcmd.Load(newSecret.Data[secret.UserKubeconfigDataName])
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(newUserConfig.Clusters["test1"].Server).To(Equal("https://api.example.com:443"))
+	g.Expect(newUserConfig.AuthInfos).To(Equal(newConfig.AuthInfos))
+}
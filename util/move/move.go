@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package move implements helpers for providers to register companion objects, which clusterctl move
+// must move together with other objects even if they are not linked by an OwnerReference.
+package move
+
+import (
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Annotation can be set on objects that providers wish to move together with other objects, e.g. Secrets
+// with cloud credentials or IPAM pools created by a provider for a Cluster, but that are not linked to them by
+// an OwnerReference.
+// The value is a comma separated list of references in the <Kind>.<group>/<name> format, e.g. Cluster.cluster.x-k8s.io/my-cluster,
+// or <Kind>/<name> for the core group; the referenced objects must be in the same namespace of the annotated object, or
+// cluster-scoped. The annotated object is moved together with the hierarchy of any of the referenced objects.
+// NOTE: Both the annotated object and the referenced objects must be of a type discovered by clusterctl move, i.e.
+// Secrets, ConfigMaps or the types defined by the provider CRDs.
+const Annotation = "clusterctl.cluster.x-k8s.io/move-with"
+
+// RegisterCompanion sets the move-with annotation on companion, so clusterctl move moves it together with owner,
+// e.g. a Secret with cloud credentials together with the Cluster it has been created for.
+// owner must be in the same namespace of companion, or cluster-scoped.
+// NOTE: companion is not patched; the caller is expected to persist the change.
+func RegisterCompanion(scheme *runtime.Scheme, companion, owner client.Object) error {
+	ref, err := reference(scheme, companion, owner)
+	if err != nil {
+		return err
+	}
+
+	refs := references(companion)
+	for _, r := range refs {
+		if r == ref {
+			return nil
+		}
+	}
+	setReferences(companion, append(refs, ref))
+	return nil
+}
+
+// UnregisterCompanion removes owner from the move-with annotation of companion.
+// NOTE: companion is not patched; the caller is expected to persist the change.
+func UnregisterCompanion(scheme *runtime.Scheme, companion, owner client.Object) error {
+	ref, err := reference(scheme, companion, owner)
+	if err != nil {
+		return err
+	}
+
+	refs := []string{}
+	for _, r := range references(companion) {
+		if r != ref {
+			refs = append(refs, r)
+		}
+	}
+	setReferences(companion, refs)
+	return nil
+}
+
+// IsCompanionOf returns true if companion is registered to be moved together with owner.
+func IsCompanionOf(scheme *runtime.Scheme, companion, owner client.Object) (bool, error) {
+	ref, err := reference(scheme, companion, owner)
+	if err != nil {
+		return false, err
+	}
+
+	for _, r := range references(companion) {
+		if r == ref {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// reference returns the reference to owner in the <Kind>.<group>/<name> format used by the move-with annotation.
+func reference(scheme *runtime.Scheme, companion, owner client.Object) (string, error) {
+	if owner.GetName() == "" {
+		return "", errors.New("failed to get reference: owner name must be set")
+	}
+	if owner.GetNamespace() != "" && owner.GetNamespace() != companion.GetNamespace() {
+		return "", errors.Errorf("failed to get reference: owner must be in namespace %q or cluster-scoped", companion.GetNamespace())
+	}
+	gvk, err := apiutil.GVKForObject(owner, scheme)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to get reference")
+	}
+	return gvk.GroupKind().String() + "/" + owner.GetName(), nil
+}
+
+func references(obj client.Object) []string {
+	value, ok := obj.GetAnnotations()[Annotation]
+	if !ok {
+		return []string{}
+	}
+
+	refs := []string{}
+	for _, r := range strings.Split(value, ",") {
+		if r = strings.TrimSpace(r); r != "" {
+			refs = append(refs, r)
+		}
+	}
+	return refs
+}
+
+func setReferences(obj client.Object, refs []string) {
+	annotations := obj.GetAnnotations()
+	if len(refs) == 0 {
+		delete(annotations, Annotation)
+		obj.SetAnnotations(annotations)
+		return
+	}
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	annotations[Annotation] = strings.Join(refs, ",")
+	obj.SetAnnotations(annotations)
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package move
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestRegisterCompanion(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+	_ = clusterv1.AddToScheme(scheme)
+
+	cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "cluster1"}}
+	configMap := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "pool1"}}
+
+	t.Run("registers and unregisters a companion", func(t *testing.T) {
+		g := NewWithT(t)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "credentials"}}
+		g.Expect(RegisterCompanion(scheme, secret, cluster)).To(Succeed())
+		g.Expect(RegisterCompanion(scheme, secret, configMap)).To(Succeed())
+		g.Expect(RegisterCompanion(scheme, secret, cluster)).To(Succeed())
+		g.Expect(secret.GetAnnotations()).To(HaveKeyWithValue(Annotation, "Cluster.cluster.x-k8s.io/cluster1,ConfigMap/pool1"))
+
+		ok, err := IsCompanionOf(scheme, secret, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeTrue())
+
+		g.Expect(UnregisterCompanion(scheme, secret, cluster)).To(Succeed())
+		g.Expect(secret.GetAnnotations()).To(HaveKeyWithValue(Annotation, "ConfigMap/pool1"))
+
+		ok, err = IsCompanionOf(scheme, secret, cluster)
+		g.Expect(err).ToNot(HaveOccurred())
+		g.Expect(ok).To(BeFalse())
+
+		g.Expect(UnregisterCompanion(scheme, secret, configMap)).To(Succeed())
+		g.Expect(secret.GetAnnotations()).ToNot(HaveKey(Annotation))
+	})
+
+	t.Run("fails if the owner is in another namespace", func(t *testing.T) {
+		g := NewWithT(t)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns2", Name: "credentials"}}
+		g.Expect(RegisterCompanion(scheme, secret, cluster)).ToNot(Succeed())
+	})
+
+	t.Run("fails if the owner type is not in the scheme", func(t *testing.T) {
+		g := NewWithT(t)
+
+		secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Namespace: "ns1", Name: "credentials"}}
+		g.Expect(RegisterCompanion(runtime.NewScheme(), secret, cluster)).ToNot(Succeed())
+	})
+}
@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package paused implements helpers to acknowledge that reconciliation of Cluster API objects is paused.
+package paused
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/annotations"
+	"sigs.k8s.io/cluster-api/util/conditions"
+	"sigs.k8s.io/cluster-api/util/patch"
+)
+
+// EnsurePausedCondition sets the Paused condition on obj if the Cluster has spec.paused set or if obj has
+// the paused annotation, and removes it otherwise.
+// If the condition changed, obj is patched right away, so the pause is acknowledged even if the caller
+// returns early without patching obj; callers are expected to stop reconciling when isPaused is true.
+func EnsurePausedCondition(ctx context.Context, c client.Client, cluster *clusterv1.Cluster, obj conditions.Setter) (isPaused bool, conditionChanged bool, err error) {
+	desired := pausedCondition(cluster, obj)
+	isPaused = desired != nil
+
+	current := conditions.Get(obj, clusterv1.PausedCondition)
+	switch {
+	case desired == nil && current == nil:
+		return false, false, nil
+	case desired != nil && current != nil &&
+		desired.Status == current.Status && desired.Reason == current.Reason && desired.Message == current.Message:
+		return true, false, nil
+	}
+
+	patchHelper, err := patch.NewHelper(obj, c)
+	if err != nil {
+		return isPaused, false, err
+	}
+
+	if desired != nil {
+		conditions.Set(obj, desired)
+	} else {
+		conditions.Delete(obj, clusterv1.PausedCondition)
+	}
+
+	if err := patchHelper.Patch(ctx, obj, patch.WithOwnedConditions{Conditions: []clusterv1.ConditionType{clusterv1.PausedCondition}}); err != nil {
+		return isPaused, false, errors.Wrapf(err, "failed to patch %s condition", clusterv1.PausedCondition)
+	}
+	return isPaused, true, nil
+}
+
+// pausedCondition returns the Paused condition obj should have, or nil if obj is not paused.
+func pausedCondition(cluster *clusterv1.Cluster, obj client.Object) *clusterv1.Condition {
+	switch {
+	case cluster != nil && cluster.Spec.Paused:
+		return &clusterv1.Condition{
+			Type:    clusterv1.PausedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1.ClusterPausedReason,
+			Message: "Cluster spec.paused is set to true",
+		}
+	case annotations.HasPaused(obj):
+		return &clusterv1.Condition{
+			Type:    clusterv1.PausedCondition,
+			Status:  corev1.ConditionTrue,
+			Reason:  clusterv1.PausedAnnotationReason,
+			Message: "Object has the cluster.x-k8s.io/paused annotation",
+		}
+	default:
+		return nil
+	}
+}
@@ -0,0 +1,149 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package paused
+
+import (
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/conditions"
+)
+
+var ctx = ctrl.SetupSignalHandler()
+
+func TestEnsurePausedCondition(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+	pausedCluster := cluster.DeepCopy()
+	pausedCluster.Spec.Paused = true
+
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: metav1.NamespaceDefault},
+	}
+	annotatedMachine := machine.DeepCopy()
+	annotatedMachine.Annotations = map[string]string{clusterv1.PausedAnnotation: ""}
+
+	tests := []struct {
+		name            string
+		cluster         *clusterv1.Cluster
+		obj             *clusterv1.Machine
+		wantPaused      bool
+		wantReason      string
+		wantCondChanged []bool
+	}{
+		{
+			name:            "not paused",
+			cluster:         cluster,
+			obj:             machine,
+			wantPaused:      false,
+			wantCondChanged: []bool{false, false},
+		},
+		{
+			name:            "paused by the Cluster",
+			cluster:         pausedCluster,
+			obj:             machine,
+			wantPaused:      true,
+			wantReason:      clusterv1.ClusterPausedReason,
+			wantCondChanged: []bool{true, false},
+		},
+		{
+			name:            "paused by the annotation",
+			cluster:         cluster,
+			obj:             annotatedMachine,
+			wantPaused:      true,
+			wantReason:      clusterv1.PausedAnnotationReason,
+			wantCondChanged: []bool{true, false},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			obj := tt.obj.DeepCopy()
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(obj).
+				WithStatusSubresource(&clusterv1.Machine{}).
+				Build()
+
+			// The second call must be a no-op once the condition has been acknowledged.
+			for _, wantChanged := range tt.wantCondChanged {
+				isPaused, conditionChanged, err := EnsurePausedCondition(ctx, c, tt.cluster, obj)
+				g.Expect(err).ToNot(HaveOccurred())
+				g.Expect(isPaused).To(Equal(tt.wantPaused))
+				g.Expect(conditionChanged).To(Equal(wantChanged))
+			}
+
+			got := &clusterv1.Machine{}
+			g.Expect(c.Get(ctx, client.ObjectKeyFromObject(obj), got)).To(Succeed())
+			if !tt.wantPaused {
+				g.Expect(conditions.Has(got, clusterv1.PausedCondition)).To(BeFalse())
+				return
+			}
+			g.Expect(conditions.IsTrue(got, clusterv1.PausedCondition)).To(BeTrue())
+			g.Expect(conditions.GetReason(got, clusterv1.PausedCondition)).To(Equal(tt.wantReason))
+		})
+	}
+}
+
+func TestEnsurePausedConditionRemovedOnUnpause(t *testing.T) {
+	g := NewWithT(t)
+
+	scheme := runtime.NewScheme()
+	g.Expect(clusterv1.AddToScheme(scheme)).To(Succeed())
+
+	cluster := &clusterv1.Cluster{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-cluster", Namespace: metav1.NamespaceDefault},
+	}
+	machine := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-machine", Namespace: metav1.NamespaceDefault},
+		Status: clusterv1.MachineStatus{
+			Conditions: clusterv1.Conditions{
+				{Type: clusterv1.PausedCondition, Status: corev1.ConditionTrue, Reason: clusterv1.ClusterPausedReason},
+			},
+		},
+	}
+	c := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(machine).
+		WithStatusSubresource(&clusterv1.Machine{}).
+		Build()
+
+	isPaused, conditionChanged, err := EnsurePausedCondition(ctx, c, cluster, machine)
+	g.Expect(err).ToNot(HaveOccurred())
+	g.Expect(isPaused).To(BeFalse())
+	g.Expect(conditionChanged).To(BeTrue())
+
+	got := &clusterv1.Machine{}
+	g.Expect(c.Get(ctx, client.ObjectKeyFromObject(machine), got)).To(Succeed())
+	g.Expect(conditions.Has(got, clusterv1.PausedCondition)).To(BeFalse())
+}
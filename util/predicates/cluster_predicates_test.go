@@ -97,3 +97,55 @@ func TestClusterControlplaneInitializedPredicate(t *testing.T) {
 		})
 	}
 }
+
+func TestClusterPausedTransitionsPredicate(t *testing.T) {
+	g := NewWithT(t)
+	predicate := predicates.ClusterPausedTransitions(logr.New(log.NullLogSink{}))
+
+	pausedCluster := clusterv1.Cluster{Spec: clusterv1.ClusterSpec{Paused: true}}
+	unpausedCluster := clusterv1.Cluster{Spec: clusterv1.ClusterSpec{Paused: false}}
+
+	testcases := []struct {
+		name       string
+		oldCluster clusterv1.Cluster
+		newCluster clusterv1.Cluster
+		expected   bool
+	}{
+		{
+			name:       "unpaused -> paused: should return true",
+			oldCluster: unpausedCluster,
+			newCluster: pausedCluster,
+			expected:   true,
+		},
+		{
+			name:       "paused -> unpaused: should return true",
+			oldCluster: pausedCluster,
+			newCluster: unpausedCluster,
+			expected:   true,
+		},
+		{
+			name:       "paused -> paused: should return false",
+			oldCluster: pausedCluster,
+			newCluster: pausedCluster,
+			expected:   false,
+		},
+		{
+			name:       "unpaused -> unpaused: should return false",
+			oldCluster: unpausedCluster,
+			newCluster: unpausedCluster,
+			expected:   false,
+		},
+	}
+
+	for i := range testcases {
+		tc := testcases[i]
+		t.Run(tc.name, func(t *testing.T) {
+			ev := event.UpdateEvent{
+				ObjectOld: &tc.oldCluster,
+				ObjectNew: &tc.newCluster,
+			}
+
+			g.Expect(predicate.Update(ev)).To(Equal(tc.expected))
+		})
+	}
+}
@@ -26,6 +26,7 @@ import (
 
 	"sigs.k8s.io/cluster-api/util/annotations"
 	"sigs.k8s.io/cluster-api/util/labels"
+	"sigs.k8s.io/cluster-api/util/sharding"
 )
 
 // All returns a predicate that returns true only if all given predicates return true.
@@ -211,6 +212,44 @@ func processIfLabelMatch(logger logr.Logger, obj client.Object, labelValue strin
 	return false
 }
 
+// ResourceIsInShard returns a predicate that returns true only if the resource belongs to the given shard.
+// Example use:
+//
+//	func (r *MyReconciler) SetupWithManager(mgr ctrl.Manager, options controller.Options) error {
+//		controller, err := ctrl.NewControllerManagedBy(mgr).
+//			For(&v1.MyType{}, builder.WithPredicates(predicates.ResourceIsInShard(r.Log, r.Shard))).
+//			WithOptions(options).
+//			Build(r)
+//		return err
+//	}
+func ResourceIsInShard(logger logr.Logger, shard sharding.Shard) predicate.Funcs {
+	return predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return processIfInShard(logger.WithValues("predicate", "ResourceIsInShard", "eventType", "update"), e.ObjectNew, shard)
+		},
+		CreateFunc: func(e event.CreateEvent) bool {
+			return processIfInShard(logger.WithValues("predicate", "ResourceIsInShard", "eventType", "create"), e.Object, shard)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return processIfInShard(logger.WithValues("predicate", "ResourceIsInShard", "eventType", "delete"), e.Object, shard)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return processIfInShard(logger.WithValues("predicate", "ResourceIsInShard", "eventType", "generic"), e.Object, shard)
+		},
+	}
+}
+
+func processIfInShard(logger logr.Logger, obj client.Object, shard sharding.Shard) bool {
+	kind := strings.ToLower(obj.GetObjectKind().GroupVersionKind().Kind)
+	log := logger.WithValues("namespace", obj.GetNamespace(), kind, obj.GetName())
+	if shard.Contains(obj) {
+		log.V(6).Info("Resource belongs to the shard, will attempt to map resource")
+		return true
+	}
+	log.V(4).Info("Resource does not belong to the shard, will not attempt to map resource")
+	return false
+}
+
 // ResourceIsNotExternallyManaged returns a predicate that returns true only if the resource does not contain
 // the externally managed annotation.
 // This implements a requirement for InfraCluster providers to be able to ignore externally managed
@@ -0,0 +1,154 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package priority implements a fast lane in the reconcile queue for objects being deleted or recently created.
+package priority
+
+import (
+	"context"
+	"time"
+
+	"github.com/go-logr/logr"
+	"k8s.io/apimachinery/pkg/util/rand"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+)
+
+const (
+	// DefaultRecentlyCreatedPeriod is the default period after creation in which an object is considered recently created.
+	DefaultRecentlyCreatedPeriod = 10 * time.Minute
+
+	// DefaultLowPriorityMaxDelay is the default maximum delay for enqueuing low priority events.
+	DefaultLowPriorityMaxDelay = 30 * time.Second
+)
+
+// Options configures the priority of the objects in the reconcile queue of a controller.
+//
+// When enabled, events for objects being deleted or recently created and events for actual changes
+// are enqueued immediately, while low priority events, i.e. the periodic resync of objects and the initial
+// list of objects created long time ago when the controller starts, are enqueued with a random delay.
+// This spreads the low priority events over time, so the objects in the fast lane do not have to wait
+// for thousands of steady-state objects to be reconciled first.
+type Options struct {
+	// Enabled enables the fast lane in the reconcile queue.
+	Enabled bool
+
+	// RecentlyCreatedPeriod is the period after creation in which an object is considered recently created.
+	// Defaults to DefaultRecentlyCreatedPeriod.
+	RecentlyCreatedPeriod time.Duration
+
+	// LowPriorityMaxDelay is the maximum delay for enqueuing low priority events.
+	// Defaults to DefaultLowPriorityMaxDelay.
+	LowPriorityMaxDelay time.Duration
+}
+
+// HighPriority returns a predicate to be used for the For watch of a controller; the predicate filters out
+// low priority events, which are then enqueued by the LowPriorityHandler.
+// NOTE: If the fast lane is not enabled, the predicate accepts all the events.
+func (o Options) HighPriority(logger logr.Logger) predicate.Funcs {
+	if !o.Enabled {
+		return predicate.Funcs{}
+	}
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return o.isHighPriorityCreate(logger, e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return o.isHighPriorityUpdate(logger, e.ObjectOld, e.ObjectNew)
+		},
+	}
+}
+
+// LowPriorityHandler returns an event handler enqueuing low priority events with a random delay.
+// It must be used for a watch on the same type of the For watch of a controller, together with HighPriority.
+// NOTE: If the fast lane is not enabled, the handler does not enqueue any event.
+func (o Options) LowPriorityHandler() handler.EventHandler {
+	if !o.Enabled {
+		return handler.Funcs{}
+	}
+	logger := logr.Discard()
+	return handler.Funcs{
+		CreateFunc: func(_ context.Context, e event.CreateEvent, q workqueue.RateLimitingInterface) {
+			if e.Object == nil || o.isHighPriorityCreate(logger, e.Object) {
+				return
+			}
+			q.AddAfter(requestFor(e.Object), o.lowPriorityDelay())
+		},
+		UpdateFunc: func(_ context.Context, e event.UpdateEvent, q workqueue.RateLimitingInterface) {
+			if e.ObjectNew == nil || o.isHighPriorityUpdate(logger, e.ObjectOld, e.ObjectNew) {
+				return
+			}
+			q.AddAfter(requestFor(e.ObjectNew), o.lowPriorityDelay())
+		},
+	}
+}
+
+// isHighPriorityCreate returns true if a create event must be enqueued immediately.
+// NOTE: Create events are generated for all the existing objects when the controller starts; only
+// the ones for objects being deleted or recently created are high priority.
+func (o Options) isHighPriorityCreate(logger logr.Logger, obj client.Object) bool {
+	if obj == nil {
+		return true
+	}
+	if o.isInFastLane(obj) {
+		return true
+	}
+	logger.V(6).Info("Create event is low priority", "namespace", obj.GetNamespace(), "name", obj.GetName())
+	return false
+}
+
+// isHighPriorityUpdate returns true if an update event must be enqueued immediately.
+// NOTE: Update events for an object which has not been changed are generated by the periodic resync;
+// only the ones for objects being deleted or recently created are high priority.
+func (o Options) isHighPriorityUpdate(logger logr.Logger, oldObj, newObj client.Object) bool {
+	if oldObj == nil || newObj == nil {
+		return true
+	}
+	if oldObj.GetResourceVersion() != newObj.GetResourceVersion() || o.isInFastLane(newObj) {
+		return true
+	}
+	logger.V(6).Info("Resync event is low priority", "namespace", newObj.GetNamespace(), "name", newObj.GetName())
+	return false
+}
+
+// isInFastLane returns true for objects being deleted or recently created.
+func (o Options) isInFastLane(obj client.Object) bool {
+	if !obj.GetDeletionTimestamp().IsZero() {
+		return true
+	}
+	recentlyCreatedPeriod := o.RecentlyCreatedPeriod
+	if recentlyCreatedPeriod <= 0 {
+		recentlyCreatedPeriod = DefaultRecentlyCreatedPeriod
+	}
+	return time.Since(obj.GetCreationTimestamp().Time) < recentlyCreatedPeriod
+}
+
+// lowPriorityDelay returns a random delay up to LowPriorityMaxDelay.
+func (o Options) lowPriorityDelay() time.Duration {
+	maxDelay := o.LowPriorityMaxDelay
+	if maxDelay <= 0 {
+		maxDelay = DefaultLowPriorityMaxDelay
+	}
+	return time.Duration(rand.Int63nRange(0, int64(maxDelay)))
+}
+
+func requestFor(obj client.Object) reconcile.Request {
+	return reconcile.Request{NamespacedName: client.ObjectKeyFromObject(obj)}
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package priority
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/util/workqueue"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestHighPriority(t *testing.T) {
+	old := machine("old", time.Hour, false, "1")
+	recent := machine("recent", time.Minute, false, "1")
+	deleting := machine("deleting", time.Hour, true, "1")
+
+	tests := []struct {
+		name           string
+		options        Options
+		create         *clusterv1.Machine
+		updateOld      *clusterv1.Machine
+		updateNew      *clusterv1.Machine
+		expectAccepted bool
+	}{
+		{
+			name:           "fast lane disabled accepts create events for old objects",
+			options:        Options{},
+			create:         old,
+			expectAccepted: true,
+		},
+		{
+			name:           "create event for an old object is low priority",
+			options:        Options{Enabled: true},
+			create:         old,
+			expectAccepted: false,
+		},
+		{
+			name:           "create event for a recently created object is high priority",
+			options:        Options{Enabled: true},
+			create:         recent,
+			expectAccepted: true,
+		},
+		{
+			name:           "create event for an old object is high priority with a longer recently created period",
+			options:        Options{Enabled: true, RecentlyCreatedPeriod: 2 * time.Hour},
+			create:         old,
+			expectAccepted: true,
+		},
+		{
+			name:           "create event for a deleting object is high priority",
+			options:        Options{Enabled: true},
+			create:         deleting,
+			expectAccepted: true,
+		},
+		{
+			name:           "resync of an old object is low priority",
+			options:        Options{Enabled: true},
+			updateOld:      old,
+			updateNew:      old,
+			expectAccepted: false,
+		},
+		{
+			name:           "update of an old object is high priority",
+			options:        Options{Enabled: true},
+			updateOld:      old,
+			updateNew:      machine("old", time.Hour, false, "2"),
+			expectAccepted: true,
+		},
+		{
+			name:           "resync of a deleting object is high priority",
+			options:        Options{Enabled: true},
+			updateOld:      deleting,
+			updateNew:      deleting,
+			expectAccepted: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+
+			p := tt.options.HighPriority(logr.Discard())
+			q := &fakeQueue{RateLimitingInterface: workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter())}
+			h := tt.options.LowPriorityHandler()
+
+			var accepted bool
+			if tt.create != nil {
+				accepted = p.Create(event.CreateEvent{Object: tt.create})
+				h.Create(context.Background(), event.CreateEvent{Object: tt.create}, q)
+			} else {
+				accepted = p.Update(event.UpdateEvent{ObjectOld: tt.updateOld, ObjectNew: tt.updateNew})
+				h.Update(context.Background(), event.UpdateEvent{ObjectOld: tt.updateOld, ObjectNew: tt.updateNew}, q)
+			}
+			g.Expect(accepted).To(Equal(tt.expectAccepted))
+
+			// Low priority events are enqueued with a delay by the low priority handler.
+			if !tt.options.Enabled || tt.expectAccepted {
+				g.Expect(q.added).To(BeEmpty())
+				return
+			}
+			g.Expect(q.added).To(HaveLen(1))
+			g.Expect(q.delays[0]).To(BeNumerically("<", DefaultLowPriorityMaxDelay))
+		})
+	}
+}
+
+type fakeQueue struct {
+	workqueue.RateLimitingInterface
+	added  []interface{}
+	delays []time.Duration
+}
+
+func (q *fakeQueue) AddAfter(item interface{}, duration time.Duration) {
+	q.added = append(q.added, item)
+	q.delays = append(q.delays, duration)
+}
+
+func machine(name string, age time.Duration, deleting bool, resourceVersion string) *clusterv1.Machine {
+	m := &clusterv1.Machine{
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace:         "default",
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(time.Now().Add(-age)),
+			ResourceVersion:   resourceVersion,
+		},
+	}
+	if deleting {
+		m.DeletionTimestamp = &metav1.Time{Time: time.Now()}
+	}
+	return m
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record
+
+import (
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
+)
+
+// DefaultDeduplicationWindow is the default interval in which identical events are emitted only once.
+const DefaultDeduplicationWindow = 5 * time.Minute
+
+// NewDeduplicatingRecorder returns an EventRecorder which emits an event only if an identical event, i.e. an event with
+// the same type, reason and message, has not been emitted for the same object in the given window.
+// NOTE: This prevents controllers from emitting the same event at every reconcile, so alerting pipelines
+// can key off the event reasons without being flooded.
+func NewDeduplicatingRecorder(recorder record.EventRecorder, window time.Duration) record.EventRecorder {
+	return &deduplicatingRecorder{
+		recorder: recorder,
+		window:   window,
+		emitted:  map[eventKey]time.Time{},
+		now:      time.Now,
+	}
+}
+
+type deduplicatingRecorder struct {
+	recorder record.EventRecorder
+	window   time.Duration
+
+	lock sync.Mutex
+	// emitted and previous hold the events emitted in the current and in the previous generation; generations are
+	// rotated once per window, so the memory used by the recorder does not grow indefinitely.
+	emitted  map[eventKey]time.Time
+	previous map[eventKey]time.Time
+	rotated  time.Time
+	now      func() time.Time
+}
+
+type eventKey struct {
+	uid       types.UID
+	namespace string
+	name      string
+	eventType string
+	reason    string
+	message   string
+}
+
+// Event emits an event if an identical event has not been emitted in the deduplication window.
+func (r *deduplicatingRecorder) Event(object runtime.Object, eventtype, reason, message string) {
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.Event(object, eventtype, reason, message)
+}
+
+// Eventf emits an event if an identical event has not been emitted in the deduplication window.
+func (r *deduplicatingRecorder) Eventf(object runtime.Object, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.Event(object, eventtype, reason, message)
+}
+
+// AnnotatedEventf emits an event if an identical event has not been emitted in the deduplication window.
+func (r *deduplicatingRecorder) AnnotatedEventf(object runtime.Object, annotations map[string]string, eventtype, reason, messageFmt string, args ...interface{}) {
+	message := fmt.Sprintf(messageFmt, args...)
+	if r.isDuplicate(object, eventtype, reason, message) {
+		return
+	}
+	r.recorder.AnnotatedEventf(object, annotations, eventtype, reason, "%s", message)
+}
+
+// isDuplicate returns true if an identical event has been emitted for the object in the deduplication window,
+// otherwise it records the event as emitted.
+func (r *deduplicatingRecorder) isDuplicate(object runtime.Object, eventtype, reason, message string) bool {
+	key := eventKey{
+		eventType: eventtype,
+		reason:    reason,
+		message:   message,
+	}
+	if accessor, err := meta.Accessor(object); err == nil {
+		key.uid = accessor.GetUID()
+		key.namespace = accessor.GetNamespace()
+		key.name = accessor.GetName()
+	}
+
+	r.lock.Lock()
+	defer r.lock.Unlock()
+
+	now := r.now()
+	// Rotate the generations if the window is elapsed, dropping the events emitted before the previous generation;
+	// those events are expired, given that each generation lasts at least one window.
+	// NOTE: This avoids sweeping all the emitted events while holding the lock.
+	if now.Sub(r.rotated) >= r.window {
+		r.previous = r.emitted
+		r.emitted = map[eventKey]time.Time{}
+		r.rotated = now
+	}
+
+	if emitted, ok := r.emitted[key]; ok && now.Sub(emitted) < r.window {
+		return true
+	}
+	if emitted, ok := r.previous[key]; ok && now.Sub(emitted) < r.window {
+		return true
+	}
+	r.emitted[key] = now
+	return false
+}
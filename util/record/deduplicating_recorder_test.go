@@ -0,0 +1,74 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package record
+
+import (
+	"testing"
+	"time"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+)
+
+func TestDeduplicatingRecorder(t *testing.T) {
+	g := NewWithT(t)
+
+	now := time.Date(2000, 1, 1, 1, 0, 0, 0, time.UTC)
+	fakeRecorder := record.NewFakeRecorder(10)
+	recorder := NewDeduplicatingRecorder(fakeRecorder, time.Minute).(*deduplicatingRecorder)
+	recorder.now = func() time.Time { return now }
+
+	obj1 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "obj1", UID: "1"}}
+	obj2 := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "obj2", UID: "2"}}
+
+	// The first event is emitted.
+	recorder.Eventf(obj1, corev1.EventTypeNormal, "Reason", "message %d", 1)
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Reason message 1")))
+
+	// An identical event in the window is dropped.
+	recorder.Event(obj1, corev1.EventTypeNormal, "Reason", "message 1")
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+
+	// Events with a different message, reason or type, or for a different object are emitted.
+	recorder.Event(obj1, corev1.EventTypeNormal, "Reason", "message 2")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Reason message 2")))
+	recorder.Event(obj1, corev1.EventTypeNormal, "OtherReason", "message 1")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal OtherReason message 1")))
+	recorder.Event(obj1, corev1.EventTypeWarning, "Reason", "message 1")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Warning Reason message 1")))
+	recorder.AnnotatedEventf(obj2, nil, corev1.EventTypeNormal, "Reason", "message %d", 1)
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Reason message 1")))
+
+	// An identical event is emitted again after the window.
+	now = now.Add(time.Minute)
+	recorder.Event(obj1, corev1.EventTypeNormal, "Reason", "message 1")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Reason message 1")))
+
+	// An identical event emitted in the previous generation, but still in the window, is dropped.
+	now = now.Add(30 * time.Second)
+	recorder.Event(obj2, corev1.EventTypeNormal, "Reason", "message 3")
+	g.Expect(fakeRecorder.Events).To(Receive(Equal("Normal Reason message 3")))
+	now = now.Add(30 * time.Second)
+	recorder.Event(obj2, corev1.EventTypeNormal, "Reason", "message 3")
+	g.Expect(fakeRecorder.Events).ToNot(Receive())
+
+	// Expired events are dropped from the recorder.
+	g.Expect(recorder.emitted).To(BeEmpty())
+	g.Expect(recorder.previous).To(HaveLen(2))
+}
@@ -198,14 +198,13 @@ func (c Certificates) Lookup(ctx context.Context, ctrlclient client.Client, clus
 // First we try to lookup the certificate secret via the secretCachingClient. If we get a NotFound error
 // we fall back to the regular uncached client.
 func (c Certificates) LookupCached(ctx context.Context, secretCachingClient, ctrlclient client.Client, clusterName client.ObjectKey) error {
-	// Look up each certificate as a secret and populate the certificate/key
+	return c.LookupFromStore(ctx, NewSecretStore(secretCachingClient, ctrlclient), clusterName)
+}
+
+// LookupFromStore looks up each certificate from the store and populates the certificate with the stored data.
+func (c Certificates) LookupFromStore(ctx context.Context, store Store, clusterName client.ObjectKey) error {
 	for _, certificate := range c {
-		key := client.ObjectKey{
-			Name:      Name(clusterName.Name, certificate.Purpose),
-			Namespace: clusterName.Namespace,
-		}
-		s, err := getCertificateSecret(ctx, secretCachingClient, ctrlclient, key)
-		if err != nil {
+		if err := store.Get(ctx, clusterName, certificate); err != nil {
 			if apierrors.IsNotFound(err) {
 				if certificate.External {
 					return errors.Wrap(err, "external certificate not found")
@@ -214,13 +213,6 @@ func (c Certificates) LookupCached(ctx context.Context, secretCachingClient, ctr
 			}
 			return err
 		}
-		// If a user has a badly formatted secret it will prevent the cluster from working.
-		kp, err := secretToKeyPair(s)
-		if err != nil {
-			return errors.Wrapf(err, "failed to read keypair from certificate %s", klog.KObj(s))
-		}
-		certificate.KeyPair = kp
-		certificate.Secret = s
 	}
 	return nil
 }
@@ -280,15 +272,18 @@ func (c Certificates) Generate() error {
 
 // SaveGenerated will save any certificates that have been generated as Kubernetes secrets.
 func (c Certificates) SaveGenerated(ctx context.Context, ctrlclient client.Client, clusterName client.ObjectKey, owner metav1.OwnerReference) error {
+	return c.SaveGeneratedToStore(ctx, NewSecretStore(nil, ctrlclient), clusterName, owner)
+}
+
+// SaveGeneratedToStore will save any certificates that have been generated to the store.
+func (c Certificates) SaveGeneratedToStore(ctx context.Context, store Store, clusterName client.ObjectKey, owner metav1.OwnerReference) error {
 	for _, certificate := range c {
 		if !certificate.Generated {
 			continue
 		}
-		s := certificate.AsSecret(clusterName, owner)
-		if err := ctrlclient.Create(ctx, s); err != nil {
-			return errors.WithStack(err)
+		if err := store.Create(ctx, clusterName, certificate, owner); err != nil {
+			return err
 		}
-		certificate.Secret = s
 	}
 	return nil
 }
@@ -302,8 +297,14 @@ func (c Certificates) LookupOrGenerate(ctx context.Context, ctrlclient client.Cl
 // During lookup we first try to lookup the certificate secret via the secretCachingClient. If we get a NotFound error
 // we fall back to the regular uncached client.
 func (c Certificates) LookupOrGenerateCached(ctx context.Context, secretCachingClient, ctrlclient client.Client, clusterName client.ObjectKey, owner metav1.OwnerReference) error {
+	return c.LookupOrGenerateFromStore(ctx, NewSecretStore(secretCachingClient, ctrlclient), clusterName, owner)
+}
+
+// LookupOrGenerateFromStore is a convenience function that wraps cluster bootstrap certificate behavior
+// using the given store.
+func (c Certificates) LookupOrGenerateFromStore(ctx context.Context, store Store, clusterName client.ObjectKey, owner metav1.OwnerReference) error {
 	// Find the certificates that exist
-	if err := c.LookupCached(ctx, secretCachingClient, ctrlclient, clusterName); err != nil {
+	if err := c.LookupFromStore(ctx, store, clusterName); err != nil {
 		return err
 	}
 
@@ -313,7 +314,7 @@ func (c Certificates) LookupOrGenerateCached(ctx context.Context, secretCachingC
 	}
 
 	// Save any certificates that have been generated
-	return c.SaveGenerated(ctx, ctrlclient, clusterName, owner)
+	return c.SaveGeneratedToStore(ctx, store, clusterName, owner)
 }
 
 // Certificate represents a single certificate CA.
@@ -323,7 +324,9 @@ type Certificate struct {
 	Purpose           Purpose
 	KeyPair           *certs.KeyPair
 	CertFile, KeyFile string
-	Secret            *corev1.Secret
+	// Secret is the Kubernetes Secret the certificate has been read from or stored in.
+	// It is nil if the certificate is stored in a Store not using Kubernetes Secrets.
+	Secret *corev1.Secret
 }
 
 // Hashes hashes all the certificates stored in a CA certificate.
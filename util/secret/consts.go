@@ -20,6 +20,10 @@ const (
 	// KubeconfigDataName is the key used to store a Kubeconfig in the secret's data field.
 	KubeconfigDataName = "value"
 
+	// UserKubeconfigDataName is the key used to store the user Kubeconfig in the kubeconfig secret's data field,
+	// when the Cluster has a user kubeconfig endpoint.
+	UserKubeconfigDataName = "user-value"
+
 	// TLSKeyDataName is the key used to store a TLS private key in the secret's data field.
 	TLSKeyDataName = "tls.key"
 
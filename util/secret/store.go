@@ -0,0 +1,87 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog/v2"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Store is a backend storing the certificates of a cluster.
+// The default implementation stores certificates in Kubernetes Secrets, but alternative implementations
+// can be used to source certificates from an external secret store, e.g. a KMS or Vault.
+type Store interface {
+	// Get populates the KeyPair of the certificate for the given cluster.
+	// If the certificate does not exist in the store, an error for which apierrors.IsNotFound returns true
+	// must be returned.
+	Get(ctx context.Context, clusterName client.ObjectKey, certificate *Certificate) error
+
+	// Create stores the KeyPair of a generated certificate for the given cluster.
+	// The owner is the object responsible for generating the certificate.
+	Create(ctx context.Context, clusterName client.ObjectKey, certificate *Certificate, owner metav1.OwnerReference) error
+}
+
+// secretStore is a Store using Kubernetes Secrets.
+type secretStore struct {
+	secretCachingClient client.Client
+	client              client.Client
+}
+
+// NewSecretStore returns a Store using Kubernetes Secrets to store certificates.
+// During Get we first try to get the certificate secret via the secretCachingClient, if not nil. If we get a
+// NotFound error we fall back to the regular uncached client.
+// NOTE: Certificates read or created by this store have the Secret field set.
+func NewSecretStore(secretCachingClient, ctrlclient client.Client) Store {
+	return &secretStore{
+		secretCachingClient: secretCachingClient,
+		client:              ctrlclient,
+	}
+}
+
+// Get populates the KeyPair and the Secret of the certificate from the corresponding Kubernetes Secret.
+func (s *secretStore) Get(ctx context.Context, clusterName client.ObjectKey, certificate *Certificate) error {
+	key := client.ObjectKey{
+		Name:      Name(clusterName.Name, certificate.Purpose),
+		Namespace: clusterName.Namespace,
+	}
+	secret, err := getCertificateSecret(ctx, s.secretCachingClient, s.client, key)
+	if err != nil {
+		return err
+	}
+	// If a user has a badly formatted secret it will prevent the cluster from working.
+	kp, err := secretToKeyPair(secret)
+	if err != nil {
+		return errors.Wrapf(err, "failed to read keypair from certificate %s", klog.KObj(secret))
+	}
+	certificate.KeyPair = kp
+	certificate.Secret = secret
+	return nil
+}
+
+// Create creates a Kubernetes Secret for the certificate and sets the Secret of the certificate.
+func (s *secretStore) Create(ctx context.Context, clusterName client.ObjectKey, certificate *Certificate, owner metav1.OwnerReference) error {
+	secret := certificate.AsSecret(clusterName, owner)
+	if err := s.client.Create(ctx, secret); err != nil {
+		return errors.WithStack(err)
+	}
+	certificate.Secret = secret
+	return nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package secret_test
+
+import (
+	"context"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	bootstrapv1 "sigs.k8s.io/cluster-api/bootstrap/kubeadm/api/v1beta1"
+	"sigs.k8s.io/cluster-api/util/certs"
+	"sigs.k8s.io/cluster-api/util/secret"
+)
+
+var (
+	ctx         = ctrl.SetupSignalHandler()
+	clusterName = client.ObjectKey{Namespace: metav1.NamespaceDefault, Name: "foo"}
+	owner       = metav1.OwnerReference{APIVersion: "controlplane.cluster.x-k8s.io/v1beta1", Kind: "KubeadmControlPlane", Name: "foo"}
+)
+
+// memoryStore is a secret.Store keeping certificates in memory.
+type memoryStore struct {
+	keyPairs map[string]*certs.KeyPair
+}
+
+func (s *memoryStore) Get(_ context.Context, clusterName client.ObjectKey, certificate *secret.Certificate) error {
+	kp, ok := s.keyPairs[secret.Name(clusterName.Name, certificate.Purpose)]
+	if !ok {
+		return apierrors.NewNotFound(schema.GroupResource{Resource: "certificates"}, string(certificate.Purpose))
+	}
+	certificate.KeyPair = kp
+	return nil
+}
+
+func (s *memoryStore) Create(_ context.Context, clusterName client.ObjectKey, certificate *secret.Certificate, _ metav1.OwnerReference) error {
+	s.keyPairs[secret.Name(clusterName.Name, certificate.Purpose)] = certificate.KeyPair
+	return nil
+}
+
+func TestLookupOrGenerateFromStore(t *testing.T) {
+	g := NewWithT(t)
+
+	store := &memoryStore{keyPairs: map[string]*certs.KeyPair{}}
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.LookupOrGenerateFromStore(ctx, store, clusterName, owner)).To(Succeed())
+	for _, c := range certificates {
+		g.Expect(c.Generated).To(BeTrue())
+		g.Expect(c.Secret).To(BeNil())
+		g.Expect(store.keyPairs).To(HaveKeyWithValue(secret.Name(clusterName.Name, c.Purpose), c.KeyPair))
+	}
+
+	// Certificates are read back from the store instead of being generated again.
+	lookedUp := secret.NewControlPlaneJoinCerts(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(lookedUp.LookupOrGenerateFromStore(ctx, store, clusterName, owner)).To(Succeed())
+	g.Expect(lookedUp.EnsureAllExist()).To(Succeed())
+	for _, c := range lookedUp {
+		g.Expect(c.Generated).To(BeFalse())
+		g.Expect(c.KeyPair).To(Equal(certificates.GetByPurpose(c.Purpose).KeyPair))
+	}
+}
+
+func TestLookupFromStoreExternalCertificateNotFound(t *testing.T) {
+	g := NewWithT(t)
+
+	store := &memoryStore{keyPairs: map[string]*certs.KeyPair{}}
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{
+		Etcd: bootstrapv1.Etcd{
+			External: &bootstrapv1.ExternalEtcd{},
+		},
+	})
+	g.Expect(certificates.LookupFromStore(ctx, store, clusterName)).ToNot(Succeed())
+}
+
+func TestSecretStore(t *testing.T) {
+	g := NewWithT(t)
+
+	c := fake.NewClientBuilder().Build()
+	store := secret.NewSecretStore(nil, c)
+
+	certificates := secret.NewCertificatesForInitialControlPlane(&bootstrapv1.ClusterConfiguration{})
+	g.Expect(certificates.LookupOrGenerateFromStore(ctx, store, clusterName, owner)).To(Succeed())
+	for _, certificate := range certificates {
+		g.Expect(certificate.Secret).ToNot(BeNil())
+
+		s := &corev1.Secret{}
+		g.Expect(c.Get(ctx, client.ObjectKey{Namespace: clusterName.Namespace, Name: secret.Name(clusterName.Name, certificate.Purpose)}, s)).To(Succeed())
+		g.Expect(s.OwnerReferences).To(ConsistOf(owner))
+		g.Expect(s.Data).To(HaveKeyWithValue(secret.TLSCrtDataName, certificate.KeyPair.Cert))
+	}
+
+	lookedUp := secret.NewCertificatesForWorker("")
+	g.Expect(lookedUp.LookupFromStore(ctx, store, clusterName)).To(Succeed())
+	g.Expect(lookedUp.GetByPurpose(secret.ClusterCA).KeyPair).To(Equal(certificates.GetByPurpose(secret.ClusterCA).KeyPair))
+	g.Expect(lookedUp.GetByPurpose(secret.ClusterCA).Secret).ToNot(BeNil())
+}
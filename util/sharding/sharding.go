@@ -0,0 +1,142 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package sharding implements helpers to split the objects reconciled by controllers across multiple managers.
+package sharding
+
+import (
+	"fmt"
+	"hash/fnv"
+
+	"github.com/pkg/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+// Mode defines how objects are assigned to shards.
+type Mode string
+
+const (
+	// NamespaceMode assigns objects to shards by namespace.
+	NamespaceMode Mode = "namespace"
+
+	// ClusterMode assigns objects to shards by the Cluster they belong to, using the Cluster name for
+	// Cluster objects and the cluster.x-k8s.io/cluster-name label for all the other objects.
+	ClusterMode Mode = "cluster"
+)
+
+// Shard identifies the subset of the objects reconciled by a manager, when objects are split across Count managers.
+//
+// By default objects are assigned to shards by namespace, so all the objects of a Cluster, as well as the ClusterClasses
+// and the ClusterResourceSets used by the Cluster, are always reconciled by the same manager.
+// When using ClusterMode, objects are assigned to shards by the Cluster they belong to, so Clusters in the same
+// namespace can be reconciled by different managers; namespaced objects not belonging to a Cluster, e.g. ClusterClasses,
+// are assigned to shards by namespace and name, so each of them is still reconciled by exactly one manager.
+// In both modes cluster-scoped objects are reconciled by all the managers.
+//
+// NOTE: The shard is passed explicitly to the reconcilers, which filter both the events and the reconcile requests
+// of their objects, because objects of other shards can be enqueued by watches on related objects.
+type Shard struct {
+	// Count is the number of shards; zero or one disable sharding.
+	Count int
+
+	// ID is the shard reconciled by this manager, from 0 to Count-1.
+	ID int
+
+	// Mode defines how objects are assigned to shards; defaults to NamespaceMode.
+	Mode Mode
+}
+
+// Validate returns an error if the shard is not valid.
+func (s Shard) Validate() error {
+	if s.Count < 0 {
+		return errors.Errorf("shard count must be greater than or equal to 0, got %d", s.Count)
+	}
+	count := s.Count
+	if count == 0 {
+		count = 1
+	}
+	if s.ID < 0 || s.ID >= count {
+		return errors.Errorf("shard id must be between 0 and %d, got %d", count-1, s.ID)
+	}
+	switch s.Mode {
+	case "", NamespaceMode, ClusterMode:
+	default:
+		return errors.Errorf("shard mode must be one of %q or %q, got %q", NamespaceMode, ClusterMode, s.Mode)
+	}
+	return nil
+}
+
+// IsEnabled returns true if objects are split across more than one shard.
+func (s Shard) IsEnabled() bool {
+	return s.Count > 1
+}
+
+// Contains returns true if the object belongs to the shard.
+func (s Shard) Contains(obj client.Object) bool {
+	if !s.IsEnabled() || obj.GetNamespace() == "" {
+		return true
+	}
+	if s.Mode != ClusterMode {
+		return s.For(obj.GetNamespace()) == s.ID
+	}
+
+	clusterName, ok := clusterNameFor(obj)
+	if !ok {
+		return s.hash(obj.GetNamespace()+"/"+obj.GetName()) == s.ID
+	}
+	return s.ForCluster(obj.GetNamespace(), clusterName) == s.ID
+}
+
+// For returns the shard of the objects in the given namespace.
+func (s Shard) For(namespace string) int {
+	return s.hash(namespace)
+}
+
+// ForCluster returns the shard of the objects belonging to the given Cluster, when using ClusterMode.
+func (s Shard) ForCluster(namespace, clusterName string) int {
+	return s.hash(namespace + "/" + clusterName)
+}
+
+func (s Shard) hash(key string) int {
+	if !s.IsEnabled() {
+		return 0
+	}
+	h := fnv.New32a()
+	_, _ = h.Write([]byte(key))
+	return int(h.Sum32() % uint32(s.Count))
+}
+
+// clusterNameFor returns the name of the Cluster an object belongs to, if any.
+func clusterNameFor(obj client.Object) (string, bool) {
+	if _, ok := obj.(*clusterv1.Cluster); ok {
+		return obj.GetName(), true
+	}
+	if gvk := obj.GetObjectKind().GroupVersionKind(); gvk.Group == clusterv1.GroupVersion.Group && gvk.Kind == "Cluster" {
+		return obj.GetName(), true
+	}
+	clusterName, ok := obj.GetLabels()[clusterv1.ClusterNameLabel]
+	return clusterName, ok && clusterName != ""
+}
+
+// LeaderElectionID returns the leader election ID for the shard, so each shard elects its own leader.
+func (s Shard) LeaderElectionID(id string) string {
+	if !s.IsEnabled() {
+		return id
+	}
+	return fmt.Sprintf("%s-shard-%d", id, s.ID)
+}
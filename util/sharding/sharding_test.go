@@ -0,0 +1,135 @@
+/*
+Copyright 2023 The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	clusterv1 "sigs.k8s.io/cluster-api/api/v1beta1"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name    string
+		shard   Shard
+		wantErr bool
+	}{
+		{name: "sharding not configured", shard: Shard{}},
+		{name: "single shard", shard: Shard{Count: 1}},
+		{name: "last shard", shard: Shard{Count: 3, ID: 2}},
+		{name: "negative count", shard: Shard{Count: -1}, wantErr: true},
+		{name: "negative id", shard: Shard{Count: 3, ID: -1}, wantErr: true},
+		{name: "id out of range", shard: Shard{Count: 3, ID: 3}, wantErr: true},
+		{name: "id without sharding", shard: Shard{ID: 1}, wantErr: true},
+		{name: "cluster mode", shard: Shard{Count: 3, ID: 1, Mode: ClusterMode}},
+		{name: "invalid mode", shard: Shard{Count: 3, ID: 1, Mode: "foo"}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := NewWithT(t)
+			if tt.wantErr {
+				g.Expect(tt.shard.Validate()).ToNot(Succeed())
+				return
+			}
+			g.Expect(tt.shard.Validate()).To(Succeed())
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	g := NewWithT(t)
+
+	shards := []Shard{{Count: 3, ID: 0}, {Count: 3, ID: 1}, {Count: 3, ID: 2}}
+	for i := 0; i < 20; i++ {
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: fmt.Sprintf("ns-%d", i), Name: "cluster"}}
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{Namespace: cluster.Namespace, Name: "machine"}}
+
+		// Each namespaced object belongs to exactly one shard, the same for all the objects in a namespace.
+		owners := 0
+		for _, s := range shards {
+			g.Expect(s.Contains(machine)).To(Equal(s.Contains(cluster)))
+			if s.Contains(cluster) {
+				owners++
+			}
+		}
+		g.Expect(owners).To(Equal(1))
+	}
+
+	// Cluster-scoped objects belong to all the shards.
+	for _, s := range shards {
+		g.Expect(s.Contains(&metav1.PartialObjectMetadata{ObjectMeta: metav1.ObjectMeta{Name: "cluster-scoped"}})).To(BeTrue())
+	}
+
+	// All the objects belong to the shard when sharding is disabled.
+	g.Expect(Shard{}.Contains(&clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: "cluster"}})).To(BeTrue())
+}
+
+func TestContainsClusterMode(t *testing.T) {
+	g := NewWithT(t)
+
+	shards := []Shard{{Count: 3, ID: 0, Mode: ClusterMode}, {Count: 3, ID: 1, Mode: ClusterMode}, {Count: 3, ID: 2, Mode: ClusterMode}}
+	clusterOwners := map[int]bool{}
+	for i := 0; i < 20; i++ {
+		cluster := &clusterv1.Cluster{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: fmt.Sprintf("cluster-%d", i)}}
+		machine := &clusterv1.Machine{ObjectMeta: metav1.ObjectMeta{
+			Namespace: cluster.Namespace,
+			Name:      "machine",
+			Labels:    map[string]string{clusterv1.ClusterNameLabel: cluster.Name},
+		}}
+
+		// Each object of a Cluster belongs to exactly one shard, the same for all the objects of the Cluster.
+		owners := 0
+		for _, s := range shards {
+			g.Expect(s.Contains(machine)).To(Equal(s.Contains(cluster)))
+			if s.Contains(cluster) {
+				owners++
+				clusterOwners[s.ID] = true
+			}
+		}
+		g.Expect(owners).To(Equal(1))
+	}
+	// Clusters in the same namespace are spread across shards.
+	g.Expect(len(clusterOwners)).To(BeNumerically(">", 1))
+
+	// Namespaced objects not belonging to a Cluster belong to exactly one shard, and are spread across shards.
+	classOwners := map[int]bool{}
+	for i := 0; i < 20; i++ {
+		clusterClass := &clusterv1.ClusterClass{ObjectMeta: metav1.ObjectMeta{Namespace: "ns", Name: fmt.Sprintf("class-%d", i)}}
+
+		owners := 0
+		for _, s := range shards {
+			if s.Contains(clusterClass) {
+				owners++
+				classOwners[s.ID] = true
+			}
+		}
+		g.Expect(owners).To(Equal(1))
+	}
+	g.Expect(len(classOwners)).To(BeNumerically(">", 1))
+}
+
+func TestLeaderElectionID(t *testing.T) {
+	g := NewWithT(t)
+
+	g.Expect(Shard{}.LeaderElectionID("capi")).To(Equal("capi"))
+	g.Expect(Shard{Count: 1}.LeaderElectionID("capi")).To(Equal("capi"))
+	g.Expect(Shard{Count: 3, ID: 1}.LeaderElectionID("capi")).To(Equal("capi-shard-1"))
+}
@@ -102,8 +102,8 @@ func Ordinalize(n int) string {
 // IsExternalManagedControlPlane returns a bool indicating whether the control plane referenced
 // in the passed Unstructured resource is an externally managed control plane such as AKS, EKS, GKE, etc.
 func IsExternalManagedControlPlane(controlPlane *unstructured.Unstructured) bool {
-	managed, found, err := unstructured.NestedBool(controlPlane.Object, "status", "externalManagedControlPlane")
-	if err != nil || !found {
+	managed, err := contract.IsExternalManagedControlPlane(controlPlane)
+	if err != nil {
 		return false
 	}
 	return managed
@@ -27,12 +27,17 @@ import (
 // Cluster implements a validating and defaulting webhook for Cluster.
 type Cluster struct {
 	Client client.Reader
+
+	// SubjectAccessReviewClient is used to check that the user creating or updating a Cluster is allowed to use
+	// a ClusterClass in another namespace. If nil, the check is skipped.
+	SubjectAccessReviewClient client.Writer
 }
 
 // SetupWebhookWithManager sets up Cluster webhooks.
 func (webhook *Cluster) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return (&webhooks.Cluster{
-		Client: webhook.Client,
+		Client:                    webhook.Client,
+		SubjectAccessReviewClient: webhook.SubjectAccessReviewClient,
 	}).SetupWebhookWithManager(mgr)
 }
 
@@ -49,22 +54,28 @@ func (webhook *ClusterClass) SetupWebhookWithManager(mgr ctrl.Manager) error {
 }
 
 // Machine implements a validating and defaulting webhook for Machine.
-type Machine struct{}
+type Machine struct {
+	Client client.Reader
+}
 
 // SetupWebhookWithManager sets up Machine webhooks.
 func (webhook *Machine) SetupWebhookWithManager(mgr ctrl.Manager) error {
-	return (&webhooks.Machine{}).SetupWebhookWithManager(mgr)
+	return (&webhooks.Machine{
+		Client: webhook.Client,
+	}).SetupWebhookWithManager(mgr)
 }
 
 // MachineDeployment implements a validating and defaulting webhook for MachineDeployment.
 type MachineDeployment struct {
 	Decoder *admission.Decoder
+	Client  client.Reader
 }
 
 // SetupWebhookWithManager sets up MachineDeployment webhooks.
 func (webhook *MachineDeployment) SetupWebhookWithManager(mgr ctrl.Manager) error {
 	return (&webhooks.MachineDeployment{
 		Decoder: webhook.Decoder,
+		Client:  webhook.Client,
 	}).SetupWebhookWithManager(mgr)
 }
 